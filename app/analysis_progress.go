@@ -2,123 +2,134 @@ package app
 
 import (
 	"maps"
-	"math"
 	"sync"
 	"time"
+
+	"github.com/ludo-technologies/pyscn/domain"
 )
 
-// analysisProgressTracker estimates overall progress for concurrently running
-// analysis tasks. Tasks all start together, so the projected wall time is the
-// completion time of the largest still-pending task. Each task completion is
-// both a hard checkpoint and a calibration sample: the observed ratio of
-// actual to estimated time rescales the projection for the remaining tasks.
-type analysisProgressTracker struct {
+// analysisProgress combines the progress reported by concurrently running
+// analysis tasks into one percentage. Each task contributes the fraction of
+// its items done (files analyzed, pairs compared), weighted by its expected
+// share of the total work. A task that reports nothing counts as not started
+// until it completes, so the percentage only moves when work is finished.
+type analysisProgress struct {
 	mu          sync.Mutex
 	start       time.Time
-	pending     map[string]float64 // task name -> calibrated estimated seconds
-	doneWall    float64            // sum of completion wall times of finished tasks
-	doneEst     float64            // sum of estimated seconds of finished tasks
+	weights     map[string]float64 // task name -> relative cost
+	totalWeight float64
+	fractions   map[string]float64 // task name -> fraction of items done
 	durations   map[string]float64 // task name -> completion wall time in seconds
-	lastPercent int
+	percent     int
+	onPercent   func(percent int)
 }
 
-// minTimingSignal is the minimum accumulated estimated-seconds of completed
-// tasks before observed timings override the prior estimates; ratios derived
-// from near-instant tasks are too noisy to extrapolate from.
-const minTimingSignal = 0.3
+// minTaskWeight keeps tasks estimated as near-instant visible in the total
+const minTaskWeight = 0.05
 
-func newAnalysisProgressTracker(estimatedSeconds map[string]float64) *analysisProgressTracker {
-	pending := make(map[string]float64, len(estimatedSeconds))
-	for name, est := range estimatedSeconds {
-		if est < 0.05 {
-			est = 0.05
-		}
-		pending[name] = est
-	}
-	return &analysisProgressTracker{
+// newAnalysisProgress tracks the tasks of weights. onPercent is called with
+// the new percentage, in [0, 99], whenever it increases; 100 is left to the
+// caller once all tasks have returned.
+func newAnalysisProgress(weights map[string]float64, onPercent func(percent int)) *analysisProgress {
+	p := &analysisProgress{
 		start:     time.Now(),
-		pending:   pending,
-		durations: make(map[string]float64, len(estimatedSeconds)),
+		weights:   make(map[string]float64, len(weights)),
+		fractions: make(map[string]float64, len(weights)),
+		durations: make(map[string]float64, len(weights)),
+		onPercent: onPercent,
 	}
+	for name, weight := range weights {
+		weight = max(weight, minTaskWeight)
+		p.weights[name] = weight
+		p.totalWeight += weight
+	}
+	return p
 }
 
-// TaskCompleted records that the named task finished, capturing its wall-clock
-// completion time as a calibration sample for the remaining tasks.
-func (t *analysisProgressTracker) TaskCompleted(name string) {
-	t.mu.Lock()
-	defer t.mu.Unlock()
+// reporter returns the ProgressReporter for a task's context. Reports are
+// attributed to the task whatever stage they name.
+func (p *analysisProgress) reporter(task string) domain.ProgressReporter {
+	return domain.ProgressReporterFunc(func(_ string, done, total int) {
+		if total <= 0 {
+			return
+		}
+		p.setFraction(task, min(float64(done)/float64(total), 1))
+	})
+}
 
-	est, ok := t.pending[name]
-	if !ok {
-		return
+// stageReporter returns the ProgressReporter for a task's context that also
+// forwards the task's progress to stages, under the task's stage name
+func (p *analysisProgress) stageReporter(task string, stages domain.StageReporter) domain.ProgressReporter {
+	reporter := p.reporter(task)
+	if stages == nil {
+		return reporter
 	}
-	delete(t.pending, name)
-
-	wall := time.Since(t.start).Seconds()
-	t.durations[name] = wall
-	t.doneWall += wall
-	t.doneEst += est
+	name := stageName(task)
+	return domain.ProgressReporterFunc(func(stage string, done, total int) {
+		stages.ReportProgress(name, done, total)
+		reporter.ReportProgress(stage, done, total)
+	})
 }
 
-// CompletedDurations returns the observed wall-clock completion time per task.
-func (t *analysisProgressTracker) CompletedDurations() map[string]float64 {
-	t.mu.Lock()
-	defer t.mu.Unlock()
+// StartTasks restarts the clock completion times are measured from. The
+// analysis tasks start together once the shared parsing is done.
+func (p *analysisProgress) StartTasks() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.start = time.Now()
+}
 
-	out := make(map[string]float64, len(t.durations))
-	maps.Copy(out, t.durations)
-	return out
+// TaskCompleted marks the named task as done and records its wall-clock
+// completion time.
+func (p *analysisProgress) TaskCompleted(name string) {
+	p.mu.Lock()
+	if _, ok := p.weights[name]; ok {
+		p.durations[name] = time.Since(p.start).Seconds()
+	}
+	p.mu.Unlock()
+	p.setFraction(name, 1)
 }
 
-// Percent returns the current progress in [0, 99]. The value is monotonic; it
-// never reaches 100 here because completion is signalled explicitly by the
-// caller once all tasks have actually finished.
-func (t *analysisProgressTracker) Percent() int {
-	t.mu.Lock()
-	defer t.mu.Unlock()
+// setFraction updates a task's fraction and publishes the overall percentage
+// if it increased. Fractions never go back.
+func (p *analysisProgress) setFraction(task string, fraction float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
-	if len(t.pending) == 0 {
-		t.lastPercent = 99
-		return 99
+	if _, ok := p.weights[task]; !ok || fraction <= p.fractions[task] {
+		return
 	}
+	p.fractions[task] = fraction
 
-	// Rescale remaining estimates by the observed actual/estimated ratio once
-	// enough completed work has accumulated to be a meaningful sample.
-	ratio := 1.0
-	if t.doneEst >= minTimingSignal {
-		ratio = t.doneWall / t.doneEst
-		if ratio < 0.1 {
-			ratio = 0.1
-		} else if ratio > 20 {
-			ratio = 20
-		}
+	done := 0.0
+	for name, weight := range p.weights {
+		done += weight * p.fractions[name]
 	}
-
-	maxPending := 0.0
-	for _, est := range t.pending {
-		if est > maxPending {
-			maxPending = est
-		}
+	percent := min(int(done/p.totalWeight*100), 99)
+	if percent <= p.percent {
+		return
 	}
-	projected := maxPending * ratio
-	if projected < 0.1 {
-		projected = 0.1
+	p.percent = percent
+	if p.onPercent != nil {
+		p.onPercent(percent)
 	}
+}
 
-	elapsed := time.Since(t.start).Seconds()
-	frac := elapsed / projected
-	var percent float64
-	if frac <= 1.0 {
-		percent = frac * 95.0
-	} else {
-		// Past the projection: approach 99% asymptotically instead of
-		// freezing, so an underestimate still shows visible movement.
-		percent = 95.0 + 4.0*(1.0-math.Exp(-(frac-1.0)/0.5))
-	}
+// Percent returns the overall progress in [0, 99]
+func (p *analysisProgress) Percent() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.percent
+}
 
-	p := min(max(int(percent), t.lastPercent), 99)
-	t.lastPercent = p
-	return p
+// CompletedDurations returns the observed wall-clock completion time per task.
+func (p *analysisProgress) CompletedDurations() map[string]float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	out := make(map[string]float64, len(p.durations))
+	maps.Copy(out, p.durations)
+	return out
 }
 
 // applyTimingFactors scales estimated task durations by per-task calibration
@@ -2,87 +2,88 @@ package app
 
 import (
 	"testing"
-	"time"
 )
 
-func TestProgressTrackerAdvancesWithElapsedTime(t *testing.T) {
-	tracker := newAnalysisProgressTracker(map[string]float64{
+func TestProgressFollowsReportedItems(t *testing.T) {
+	progress := newAnalysisProgress(map[string]float64{
 		"small": 1.0,
-		"large": 10.0,
-	})
-	// Simulate 5s elapsed: projection is the largest pending task (10s)
-	tracker.start = time.Now().Add(-5 * time.Second)
+		"large": 3.0,
+	}, nil)
 
-	p := tracker.Percent()
-	// 5/10 of the projection at 95% scale => ~47%
-	if p < 40 || p > 55 {
-		t.Errorf("expected progress around 47%%, got %d%%", p)
-	}
-}
+	// Half of the large task's files done => 3*0.5 of 4 weight units
+	progress.reporter("large").ReportProgress("complexity", 5, 10)
 
-func TestProgressTrackerRecalibratesOnTaskCompletion(t *testing.T) {
-	tracker := newAnalysisProgressTracker(map[string]float64{
-		"small": 1.0,
-		"large": 10.0,
-	})
-	// The small task (estimated 1s) actually took 3s => machine is 3x slower
-	tracker.start = time.Now().Add(-3 * time.Second)
-	tracker.TaskCompleted("small")
-
-	p := tracker.Percent()
-	// Projection rescales to 10 * 3 = 30s; at 3s elapsed => ~9.5%
-	if p > 15 {
-		t.Errorf("expected recalibrated progress below 15%%, got %d%%", p)
+	if p := progress.Percent(); p != 37 {
+		t.Errorf("expected 37%%, got %d%%", p)
 	}
 }
 
-func TestProgressTrackerApproachesCapOnOverrun(t *testing.T) {
-	tracker := newAnalysisProgressTracker(map[string]float64{"only": 1.0})
-	// Elapsed time far beyond the 1s estimate
-	tracker.start = time.Now().Add(-30 * time.Second)
+func TestProgressCountsCompletedTasksAsDone(t *testing.T) {
+	progress := newAnalysisProgress(map[string]float64{
+		"small": 1.0,
+		"large": 3.0,
+	}, nil)
+	progress.TaskCompleted("small")
 
-	p := tracker.Percent()
-	if p < 95 || p > 99 {
-		t.Errorf("expected progress in [95, 99] on overrun, got %d%%", p)
+	if p := progress.Percent(); p != 25 {
+		t.Errorf("expected 25%%, got %d%%", p)
 	}
 }
 
-func TestProgressTrackerIsMonotonic(t *testing.T) {
-	tracker := newAnalysisProgressTracker(map[string]float64{
-		"small": 1.0,
-		"large": 10.0,
+func TestProgressIsMonotonic(t *testing.T) {
+	var published []int
+	progress := newAnalysisProgress(map[string]float64{"only": 1.0}, func(percent int) {
+		published = append(published, percent)
 	})
-	tracker.start = time.Now().Add(-8 * time.Second)
-	before := tracker.Percent()
+	reporter := progress.reporter("only")
 
-	// Completing the small task triples the projection, which would lower the
-	// raw time fraction; the displayed value must not move backwards
-	tracker.TaskCompleted("small")
-	after := tracker.Percent()
+	reporter.ReportProgress("clones", 60, 100)
+	reporter.ReportProgress("clones", 40, 100) // late report from another worker
+	reporter.ReportProgress("clones", 60, 100)
 
-	if after < before {
-		t.Errorf("progress moved backwards: %d%% -> %d%%", before, after)
+	if len(published) != 1 || published[0] != 60 {
+		t.Errorf("expected a single 60%% update, got %v", published)
+	}
+	if p := progress.Percent(); p != 60 {
+		t.Errorf("expected progress to stay at 60%%, got %d%%", p)
 	}
 }
 
-func TestProgressTrackerReports99WhenAllTasksDone(t *testing.T) {
-	tracker := newAnalysisProgressTracker(map[string]float64{"only": 1.0})
-	tracker.TaskCompleted("only")
+func TestProgressReports99WhenAllTasksDone(t *testing.T) {
+	progress := newAnalysisProgress(map[string]float64{"only": 1.0}, nil)
+	progress.TaskCompleted("only")
 
-	if p := tracker.Percent(); p != 99 {
+	if p := progress.Percent(); p != 99 {
 		t.Errorf("expected 99%% when all tasks completed, got %d%%", p)
 	}
 }
 
-func TestProgressTrackerIgnoresUnknownTask(t *testing.T) {
-	tracker := newAnalysisProgressTracker(map[string]float64{"only": 1.0})
-	tracker.TaskCompleted("nonexistent")
+func TestProgressIgnoresUnknownTasksAndTotals(t *testing.T) {
+	progress := newAnalysisProgress(map[string]float64{"only": 1.0}, nil)
+	progress.TaskCompleted("nonexistent")
+	progress.reporter("nonexistent").ReportProgress("parse", 1, 1)
+	progress.reporter("only").ReportProgress("parse", 3, 0)
 
-	if d := tracker.CompletedDurations(); len(d) != 0 {
+	if p := progress.Percent(); p != 0 {
+		t.Errorf("expected 0%%, got %d%%", p)
+	}
+	if d := progress.CompletedDurations(); len(d) != 0 {
 		t.Errorf("expected no recorded durations, got %v", d)
 	}
 }
 
+func TestProgressKeepsNearInstantTasksVisible(t *testing.T) {
+	progress := newAnalysisProgress(map[string]float64{
+		"instant": 0,
+		"other":   0.95,
+	}, nil)
+	progress.TaskCompleted("instant")
+
+	if p := progress.Percent(); p != 5 {
+		t.Errorf("expected 5%%, got %d%%", p)
+	}
+}
+
 func TestApplyTimingFactors(t *testing.T) {
 	estimates := map[string]float64{"a": 2.0, "b": 4.0}
 	factors := map[string]float64{"a": 3.0}
@@ -0,0 +1,185 @@
+package app
+
+import (
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/service"
+)
+
+// taskTimingNames are the names analysis tasks are reported under in the
+// manifest timings, matching the AnalyzerVersions keys where there is one
+var taskTimingNames = map[string]string{
+	taskNameParse:         "parse",
+	taskNameComplexity:    "complexity",
+	taskNameDeadCode:      "dead_code",
+	taskNameClones:        "clones",
+	taskNameCBO:           "cbo",
+	taskNameLCOM:          "lcom",
+	taskNameSystem:        "system",
+	taskNameCommunities:   "communities",
+	taskNameDocumentation: "documentation",
+	taskNameTyping:        "typing",
+}
+
+// stageName returns the name a task is reported under in the manifest
+// timings and in progress stages
+func stageName(task string) string {
+	if name, ok := taskTimingNames[task]; ok {
+		return name
+	}
+	return task
+}
+
+// taskScopes are the analysis scopes whose files each task analyzes
+var taskScopes = map[string]string{
+	taskNameComplexity:    domain.AnalysisScopeComplexity,
+	taskNameDeadCode:      domain.AnalysisScopeDeadCode,
+	taskNameClones:        domain.AnalysisScopeClones,
+	taskNameCBO:           domain.AnalysisScopeCBO,
+	taskNameLCOM:          domain.AnalysisScopeLCOM,
+	taskNameSystem:        domain.AnalysisScopeDependencies,
+	taskNameCommunities:   domain.AnalysisScopeCommunities,
+	taskNameDocumentation: domain.AnalysisScopeDocumentation,
+	taskNameTyping:        domain.AnalysisScopeTyping,
+}
+
+// snapshotTasks are the tasks that work on the shared project snapshot
+// rather than parsing their files themselves
+var snapshotTasks = map[string]bool{
+	taskNameComplexity: true,
+	taskNameDeadCode:   true,
+	taskNameCBO:        true,
+	taskNameLCOM:       true,
+}
+
+// goroutineSampleInterval is how often the goroutine count is sampled
+// while phases run
+const goroutineSampleInterval = 10 * time.Millisecond
+
+// analysisTimings records what each phase of a run costs. Goroutines are
+// counted process-wide, so a phase's peak includes the phases running
+// alongside it.
+type analysisTimings struct {
+	mu     sync.Mutex
+	phases []*phaseTiming
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// phaseTiming is one phase being timed
+type phaseTiming struct {
+	timing  domain.AnalyzerTiming
+	start   time.Time
+	running bool
+}
+
+// newAnalysisTimings starts sampling the goroutine count until Stop
+func newAnalysisTimings() *analysisTimings {
+	t := &analysisTimings{
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	go func() {
+		defer close(t.done)
+		ticker := time.NewTicker(goroutineSampleInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-t.stop:
+				return
+			case <-ticker.C:
+				t.sample()
+			}
+		}
+	}()
+	return t
+}
+
+// Add registers a phase; phases are reported in the order they were added
+func (t *analysisTimings) Add(task string, files int, bytesParsed int64) *phaseTiming {
+	phase := &phaseTiming{timing: domain.AnalyzerTiming{
+		Name:           stageName(task),
+		FilesProcessed: files,
+		BytesParsed:    bytesParsed,
+	}}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.phases = append(t.phases, phase)
+	return phase
+}
+
+// Begin starts the clock of a phase
+func (t *analysisTimings) Begin(phase *phaseTiming) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	phase.start = time.Now()
+	phase.running = true
+	phase.timing.PeakGoroutines = max(phase.timing.PeakGoroutines, runtime.NumGoroutine())
+}
+
+// End stops the clock of a phase
+func (t *analysisTimings) End(phase *phaseTiming) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !phase.running {
+		return
+	}
+	phase.running = false
+	phase.timing.WallTimeMs = time.Since(phase.start).Milliseconds()
+	phase.timing.PeakGoroutines = max(phase.timing.PeakGoroutines, runtime.NumGoroutine())
+}
+
+// sample raises the goroutine peak of the running phases
+func (t *analysisTimings) sample() {
+	count := runtime.NumGoroutine()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, phase := range t.phases {
+		if phase.running {
+			phase.timing.PeakGoroutines = max(phase.timing.PeakGoroutines, count)
+		}
+	}
+}
+
+// Stop ends the sampling and returns the timings of the phases
+func (t *analysisTimings) Stop() []domain.AnalyzerTiming {
+	close(t.stop)
+	<-t.done
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	timings := make([]domain.AnalyzerTiming, 0, len(t.phases))
+	for _, phase := range t.phases {
+		timings = append(timings, phase.timing)
+	}
+	return timings
+}
+
+// addTask registers an analysis task with the files of its scope. Tasks
+// that parse their own files count the size of those files as parsed.
+func (t *analysisTimings) addTask(task string, files analysisFiles, snapshot *service.ProjectSnapshot) *phaseTiming {
+	paths := files.all
+	if scope, ok := taskScopes[task]; ok {
+		paths = files.forScope(scope)
+	}
+	var bytesParsed int64
+	if snapshot == nil || !snapshotTasks[task] {
+		bytesParsed = totalFileSize(paths)
+	}
+	return t.Add(task, len(paths), bytesParsed)
+}
+
+// totalFileSize returns the combined size of the files that can be stat'ed
+func totalFileSize(paths []string) int64 {
+	var total int64
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			total += info.Size()
+		}
+	}
+	return total
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ludo-technologies/pyscn/service"
+)
+
+func TestAnalysisTimingsRecordPhasesInOrder(t *testing.T) {
+	timings := newAnalysisTimings()
+	parse := timings.Add(taskNameParse, 3, 120)
+	clones := timings.Add(taskNameClones, 2, 80)
+	custom := timings.Add("custom", 1, 0)
+
+	timings.Begin(clones)
+	timings.Begin(parse)
+	time.Sleep(2 * time.Millisecond)
+	timings.End(parse)
+	timings.End(clones)
+	timings.Begin(custom)
+	timings.End(custom)
+
+	got := timings.Stop()
+	if len(got) != 3 {
+		t.Fatalf("expected 3 timings, got %d", len(got))
+	}
+	for i, name := range []string{"parse", "clones", "custom"} {
+		if got[i].Name != name {
+			t.Errorf("timing %d: expected %q, got %q", i, name, got[i].Name)
+		}
+		if got[i].PeakGoroutines < 1 {
+			t.Errorf("%s: expected a goroutine peak, got %d", name, got[i].PeakGoroutines)
+		}
+	}
+	if got[0].WallTimeMs < 2 {
+		t.Errorf("expected parse to take at least 2ms, got %dms", got[0].WallTimeMs)
+	}
+	if got[0].FilesProcessed != 3 || got[0].BytesParsed != 120 {
+		t.Errorf("expected 3 files and 120 bytes, got %+v", got[0])
+	}
+}
+
+func TestAnalysisTimingsPeakIncludesConcurrentGoroutines(t *testing.T) {
+	timings := newAnalysisTimings()
+	phase := timings.Add(taskNameComplexity, 1, 0)
+	timings.Begin(phase)
+
+	release := make(chan struct{})
+	for range 20 {
+		go func() { <-release }()
+	}
+	time.Sleep(5 * goroutineSampleInterval)
+	close(release)
+	timings.End(phase)
+
+	if peak := timings.Stop()[0].PeakGoroutines; peak < 20 {
+		t.Errorf("expected a peak of at least 20 goroutines, got %d", peak)
+	}
+}
+
+func TestAnalyzeUseCase_Execute_RecordsTimings(t *testing.T) {
+	builder := NewAnalyzeUseCaseBuilder()
+	builder.WithFileReader(service.NewFileReader())
+	builder.WithFormatter(service.NewAnalyzeFormatter())
+	builder.WithParallelExecutor(service.NewParallelExecutor())
+	builder.WithErrorCategorizer(service.NewErrorCategorizer())
+	builder.WithComplexityUseCase(NewComplexityUseCase(
+		service.NewComplexityService(),
+		service.NewFileReader(),
+		service.NewOutputFormatter(),
+		service.NewConfigurationLoader(),
+	))
+	useCase, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Failed to build use case: %v", err)
+	}
+
+	config := AnalyzeUseCaseConfig{
+		SkipDeadCode:  true,
+		SkipClones:    true,
+		SkipCBO:       true,
+		SkipLCOM:      true,
+		SkipSystem:    true,
+		MinComplexity: 1,
+	}
+	response, err := useCase.Execute(context.Background(), config, []string{"../testdata/python/complex"})
+	if err != nil {
+		t.Fatalf("Analysis failed: %v", err)
+	}
+
+	timings := response.Manifest.Timings
+	if len(timings) != 2 || timings[0].Name != "parse" || timings[1].Name != "complexity" {
+		t.Fatalf("expected parse and complexity timings, got %+v", timings)
+	}
+	files := response.Manifest.FileCount
+	if timings[0].FilesProcessed != files || timings[1].FilesProcessed != files {
+		t.Errorf("expected %d files in each phase, got %+v", files, timings)
+	}
+	if timings[0].BytesParsed <= 0 {
+		t.Errorf("expected parsing to count the bytes it parsed, got %d", timings[0].BytesParsed)
+	}
+	if timings[1].BytesParsed != 0 {
+		t.Errorf("expected complexity to reuse the shared parse, got %d bytes", timings[1].BytesParsed)
+	}
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"cmp"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// analysisWarnings collects the warnings of one analyze run. A warning
+// raised by several analyses, like a file none of them could parse, is kept
+// once.
+type analysisWarnings struct {
+	mu       sync.Mutex
+	seen     map[domain.AnalysisWarning]bool
+	warnings []domain.AnalysisWarning
+}
+
+func newAnalysisWarnings() *analysisWarnings {
+	return &analysisWarnings{seen: make(map[domain.AnalysisWarning]bool)}
+}
+
+// ReportWarning implements domain.WarningReporter
+func (w *analysisWarnings) ReportWarning(warning domain.AnalysisWarning) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.seen[warning] {
+		return
+	}
+	w.seen[warning] = true
+	w.warnings = append(w.warnings, warning)
+}
+
+// warn records a warning of a report step and logs it
+func (w *analysisWarnings) warn(step, format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	log.Printf("WARNING: %s", message)
+	w.ReportWarning(domain.AnalysisWarning{Analyzer: step, Message: message})
+}
+
+// Warnings returns the warnings ordered by analyzer, file and message, so
+// reports do not depend on which analysis finished first
+func (w *analysisWarnings) Warnings() []domain.AnalysisWarning {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.warnings) == 0 {
+		return nil
+	}
+	warnings := slices.Clone(w.warnings)
+	slices.SortFunc(warnings, func(a, b domain.AnalysisWarning) int {
+		return cmp.Or(
+			cmp.Compare(a.Analyzer, b.Analyzer),
+			cmp.Compare(a.File, b.File),
+			cmp.Compare(a.Message, b.Message),
+		)
+	})
+	return warnings
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/service"
+)
+
+func TestAnalysisWarningsDedupeAndSort(t *testing.T) {
+	warnings := newAnalysisWarnings()
+	if got := warnings.Warnings(); got != nil {
+		t.Fatalf("expected no warnings, got %+v", got)
+	}
+
+	skipped := domain.AnalysisWarning{Analyzer: "parse", File: "b.py", Message: "skipped: syntax error"}
+	warnings.ReportWarning(domain.AnalysisWarning{Analyzer: "clones", Message: "clone pairs reached the limit"})
+	warnings.ReportWarning(skipped)
+	warnings.ReportWarning(domain.AnalysisWarning{Analyzer: "parse", File: "a.py", Message: "skipped: syntax error"})
+	warnings.ReportWarning(skipped)
+	warnings.warn("blame", "git blame failed: %s", "not a repository")
+
+	want := []domain.AnalysisWarning{
+		{Analyzer: "blame", Message: "git blame failed: not a repository"},
+		{Analyzer: "clones", Message: "clone pairs reached the limit"},
+		{Analyzer: "parse", File: "a.py", Message: "skipped: syntax error"},
+		skipped,
+	}
+	if got := warnings.Warnings(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestAnalyzeUseCase_Execute_ReportsSkippedFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "good.py"), []byte("def f(x):\n    return x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken.py"), []byte("def oops(:\n    pass\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := NewAnalyzeUseCaseBuilder()
+	builder.WithFileReader(service.NewFileReader())
+	builder.WithFormatter(service.NewAnalyzeFormatter())
+	builder.WithParallelExecutor(service.NewParallelExecutor())
+	builder.WithErrorCategorizer(service.NewErrorCategorizer())
+	builder.WithComplexityUseCase(NewComplexityUseCase(
+		service.NewComplexityService(),
+		service.NewFileReader(),
+		service.NewOutputFormatter(),
+		service.NewConfigurationLoader(),
+	))
+	useCase, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Failed to build use case: %v", err)
+	}
+
+	config := AnalyzeUseCaseConfig{
+		SkipDeadCode:  true,
+		SkipClones:    true,
+		SkipCBO:       true,
+		SkipLCOM:      true,
+		SkipSystem:    true,
+		MinComplexity: 1,
+	}
+	response, err := useCase.Execute(context.Background(), config, []string{dir})
+	if err != nil {
+		t.Fatalf("Analysis failed: %v", err)
+	}
+
+	if len(response.Warnings) != 1 {
+		t.Fatalf("expected one warning, got %+v", response.Warnings)
+	}
+	warning := response.Warnings[0]
+	if warning.Analyzer != "parse" || filepath.Base(warning.File) != "broken.py" {
+		t.Errorf("expected broken.py to be reported by the parse step, got %+v", warning)
+	}
+}
@@ -0,0 +1,34 @@
+package app
+
+import (
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// Plan resolves the configuration, the files and the analyses of an analyze
+// run without analyzing anything, so that users can see why a file is or is
+// not analyzed. The settings of the analyses are left to the caller.
+func (uc *AnalyzeUseCase) Plan(useCaseCfg AnalyzeUseCaseConfig, paths []string) (*domain.AnalyzePlan, error) {
+	run, err := uc.prepareRun(useCaseCfg, paths, AnalyzeRequestOverrides{})
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &domain.AnalyzePlan{
+		ConfigPath:      run.executionCfg.ConfigPath,
+		Recursive:       run.executionCfg.Recursive,
+		IncludePatterns: run.executionCfg.IncludePatterns,
+		ExcludePatterns: run.executionCfg.ExcludePatterns,
+		Files:           run.files,
+		Vendored:        run.vendored,
+	}
+	for _, task := range uc.createAnalysisTasks(run.config, paths, run.fileSets, nil, run.executionCfg) {
+		section := taskScopes[task.Name]
+		plan.Analyses = append(plan.Analyses, domain.PlannedAnalysis{
+			Name:    taskTimingNames[task.Name],
+			Section: section,
+			Enabled: task.Enabled,
+			Files:   len(run.fileSets.forScope(section)),
+		})
+	}
+	return plan, nil
+}
@@ -0,0 +1,143 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/service"
+)
+
+// analysisFiles holds the files each analysis runs on. Analyses without
+// include/exclude patterns of their own share the files collected with the
+// [analysis] patterns.
+type analysisFiles struct {
+	all    []string
+	scoped map[string][]string
+}
+
+// forScope returns the files of the analysis configured by section
+func (f analysisFiles) forScope(section string) []string {
+	if files, ok := f.scoped[section]; ok {
+		return files
+	}
+	return f.all
+}
+
+// union returns every file needed by the given analyses, shared files first
+func (f analysisFiles) union(sections ...string) []string {
+	if len(f.scoped) == 0 {
+		return f.all
+	}
+
+	seen := make(map[string]bool)
+	var files []string
+	add := func(paths []string) {
+		for _, path := range paths {
+			if !seen[path] {
+				seen[path] = true
+				files = append(files, path)
+			}
+		}
+	}
+	for _, section := range sections {
+		add(f.forScope(section))
+	}
+	return files
+}
+
+// every returns the files of all analyses, shared files first
+func (f analysisFiles) every() []string {
+	if len(f.scoped) == 0 {
+		return f.all
+	}
+	sections := make([]string, 0, len(f.scoped)+1)
+	sections = append(sections, "")
+	for section := range f.scoped {
+		sections = append(sections, section)
+	}
+	sort.Strings(sections[1:])
+	return f.union(sections...)
+}
+
+// snapshotForScope narrows a shared snapshot to the files of one analysis
+func (f analysisFiles) snapshotForScope(snapshot *service.ProjectSnapshot, section string) *service.ProjectSnapshot {
+	files, ok := f.scoped[section]
+	if !ok || snapshot == nil {
+		return snapshot
+	}
+	return snapshot.Subset(files)
+}
+
+// scopeEnabled reports whether the analysis configured by section will run
+func scopeEnabled(config AnalyzeUseCaseConfig, section string) bool {
+	switch section {
+	case domain.AnalysisScopeComplexity:
+		return !config.SkipComplexity
+	case domain.AnalysisScopeDeadCode:
+		return !config.SkipDeadCode
+	case domain.AnalysisScopeClones:
+		return !config.SkipClones
+	case domain.AnalysisScopeCBO:
+		return !config.SkipCBO
+	case domain.AnalysisScopeLCOM:
+		return !config.SkipLCOM
+	case domain.AnalysisScopeDependencies:
+		return !config.SkipSystem
+	case domain.AnalysisScopeCommunities:
+		return !config.SkipCommunities
+	case domain.AnalysisScopeDocumentation:
+		return !config.SkipDocumentation
+	case domain.AnalysisScopeTyping:
+		return !config.SkipTyping
+	}
+	return false
+}
+
+// walkingFileReader returns the file reader, walking directories as
+// [analysis] max_depth and follow_symlinks configure when it supports that
+func (uc *AnalyzeUseCase) walkingFileReader(executionCfg domain.AnalyzeExecutionConfig) domain.FileReader {
+	if reader, ok := uc.fileReader.(domain.WalkConfigurableFileReader); ok {
+		return reader.WithWalkOptions(executionCfg.Walk)
+	}
+	return uc.fileReader
+}
+
+// collectAnalysisFiles collects the files of every enabled analysis whose
+// section sets include_patterns or exclude_patterns. Patterns a section does
+// not set fall back to the [analysis] ones. Vendored files are left out
+// unless vendored is nil.
+func (uc *AnalyzeUseCase) collectAnalysisFiles(config AnalyzeUseCaseConfig, paths []string, files []string, executionCfg domain.AnalyzeExecutionConfig, vendored *service.VendoredDetector) (analysisFiles, error) {
+	result := analysisFiles{all: files}
+	for section, scope := range executionCfg.AnalysisScopes {
+		if !scopeEnabled(config, section) {
+			continue
+		}
+
+		includePatterns := executionCfg.IncludePatterns
+		if scope.IncludePatterns != nil {
+			includePatterns = scope.IncludePatterns
+		}
+		excludePatterns := executionCfg.ExcludePatterns
+		if scope.ExcludePatterns != nil {
+			excludePatterns = scope.ExcludePatterns
+		}
+
+		scoped, err := uc.walkingFileReader(executionCfg).CollectPythonFiles(paths, executionCfg.Recursive, includePatterns, excludePatterns)
+		if err != nil {
+			return analysisFiles{}, fmt.Errorf("failed to collect Python files for [%s]: %w", section, err)
+		}
+		if vendored != nil {
+			scoped, _ = vendored.Split(scoped)
+		}
+		if len(scoped) == 0 {
+			return analysisFiles{}, fmt.Errorf("no Python files match the [%s] include/exclude patterns", section)
+		}
+
+		if result.scoped == nil {
+			result.scoped = make(map[string][]string)
+		}
+		result.scoped[section] = scoped
+	}
+	return result, nil
+}
@@ -1,38 +1,80 @@
 package app
 
-import "strings"
+import (
+	"slices"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
 
 // ApplyAnalyzeSelection applies explicit analyzer selection to the use-case
 // config. It is shared by CLI and MCP entrypoints so selection semantics stay
-// consistent across transports.
+// consistent across transports. Entries may name a whole analysis or a rule
+// from the registry ("deadcode.unreachable"); a rule runs its analysis
+// limited to what the rule covers, unless the whole analysis is selected too.
 func ApplyAnalyzeSelection(config AnalyzeUseCaseConfig, analyses []string) AnalyzeUseCaseConfig {
-	selected := normalizeAnalyzeSelection(analyses)
-	config.SelectAnalysesUsed = len(selected) > 0
+	selected, rules := normalizeAnalyzeSelection(analyses)
+	config.SelectAnalysesUsed = len(selected) > 0 || len(rules) > 0
 	if !config.SelectAnalysesUsed {
 		return config
 	}
 
-	config.SkipComplexity = !selected["complexity"]
-	config.SkipDeadCode = !selected["deadcode"]
-	config.SkipClones = !selected["clones"]
-	config.SkipCBO = !selected["cbo"]
-	config.SkipLCOM = !selected["lcom"]
-	config.SkipSystem = !selected["deps"]
-	config.SkipCommunities = !selected["communities"]
+	enabled := make(map[string]bool, len(selected)+len(rules))
+	for analysis := range selected {
+		enabled[analysis] = true
+	}
+	for _, rule := range rules {
+		enabled[rule.Analysis] = true
+		if selected[rule.Analysis] {
+			continue
+		}
+		for _, reason := range rule.DeadCodeReasons {
+			if !slices.Contains(config.DeadCodeReasons, reason) {
+				config.DeadCodeReasons = append(config.DeadCodeReasons, reason)
+			}
+		}
+		for _, cloneType := range rule.CloneTypes {
+			if !slices.Contains(config.CloneTypes, cloneType) {
+				config.CloneTypes = append(config.CloneTypes, cloneType)
+			}
+		}
+		if rule.DependencyCheck != "" && !slices.Contains(config.DependencyChecks, rule.DependencyCheck) {
+			config.DependencyChecks = append(config.DependencyChecks, rule.DependencyCheck)
+		}
+	}
+
+	config.SkipComplexity = !enabled[domain.AnalysisComplexity]
+	config.SkipDeadCode = !enabled[domain.AnalysisDeadCode]
+	config.SkipClones = !enabled[domain.AnalysisClones]
+	config.SkipCBO = !enabled[domain.AnalysisCBO]
+	config.SkipLCOM = !enabled[domain.AnalysisLCOM]
+	config.SkipSystem = !enabled[domain.AnalysisDeps]
+	config.SkipCommunities = !enabled[domain.AnalysisCommunities]
+	config.SkipDocumentation = !enabled[domain.AnalysisDocumentation]
+	config.SkipTyping = !enabled[domain.AnalysisTyping]
 	return config
 }
 
-func normalizeAnalyzeSelection(analyses []string) map[string]bool {
+// normalizeAnalyzeSelection splits the selection into whole analyses and
+// registered rules. Unknown entries are kept as analysis names; callers
+// validate them beforehand.
+func normalizeAnalyzeSelection(analyses []string) (map[string]bool, []domain.AnalysisRule) {
 	selected := make(map[string]bool, len(analyses))
+	var rules []domain.AnalysisRule
 	for _, analysis := range analyses {
-		switch strings.ToLower(analysis) {
+		name := strings.ToLower(strings.TrimSpace(analysis))
+		switch name {
 		case "dead_code":
-			selected["deadcode"] = true
+			selected[domain.AnalysisDeadCode] = true
 		case "clone":
-			selected["clones"] = true
+			selected[domain.AnalysisClones] = true
 		default:
-			selected[strings.ToLower(analysis)] = true
+			if rule, ok := domain.LookupAnalysisRule(name); ok {
+				rules = append(rules, rule)
+				continue
+			}
+			selected[name] = true
 		}
 	}
-	return selected
+	return selected, rules
 }
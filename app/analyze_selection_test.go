@@ -1,8 +1,10 @@
 package app
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/ludo-technologies/pyscn/domain"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -40,3 +42,41 @@ func TestApplyAnalyzeSelection_MCPAnalyzerAliases(t *testing.T) {
 	assert.True(t, config.SkipComplexity)
 	assert.True(t, config.SkipSystem)
 }
+
+func TestApplyAnalyzeSelection_RulesNarrowTheirAnalysis(t *testing.T) {
+	config := ApplyAnalyzeSelection(AnalyzeUseCaseConfig{}, []string{"deadcode.returns", "deadcode.unreachable_branch", "deps.cycles", "clones.type3"})
+
+	assert.True(t, config.SelectAnalysesUsed)
+	assert.False(t, config.SkipDeadCode)
+	assert.False(t, config.SkipSystem)
+	assert.False(t, config.SkipClones)
+	assert.True(t, config.SkipComplexity)
+	assert.True(t, config.SkipCBO)
+
+	assert.ElementsMatch(t, []string{"inconsistent_return", "missing_return", "unreachable_branch"}, config.DeadCodeReasons)
+	assert.Equal(t, []string{domain.DependencyCheckCycles}, config.DependencyChecks)
+	assert.Equal(t, []domain.CloneType{domain.Type3Clone}, config.CloneTypes)
+}
+
+func TestApplyAnalyzeSelection_WholeAnalysisOverridesItsRules(t *testing.T) {
+	config := ApplyAnalyzeSelection(AnalyzeUseCaseConfig{}, []string{"deadcode.match", "deadcode", "deps.architecture"})
+
+	assert.False(t, config.SkipDeadCode)
+	assert.Empty(t, config.DeadCodeReasons)
+	assert.Equal(t, []string{domain.DependencyCheckArchitecture}, config.DependencyChecks)
+}
+
+func TestAnalysisRules_EveryDeadCodeReasonIsSelectable(t *testing.T) {
+	for _, rule := range domain.AnalysisRules() {
+		found, ok := domain.LookupAnalysisRule(strings.ToUpper(rule.ID))
+		assert.True(t, ok, rule.ID)
+		assert.Equal(t, rule.ID, found.ID)
+	}
+
+	rule, ok := domain.LookupAnalysisRule("deadcode.missing_return")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"missing_return"}, rule.DeadCodeReasons)
+
+	_, ok = domain.LookupAnalysisRule("deadcode.unused")
+	assert.False(t, ok)
+}
@@ -0,0 +1,65 @@
+package app
+
+import "github.com/ludo-technologies/pyscn/domain"
+
+// dropFindingDetails strips the per-finding detail from an analysis result,
+// keeping the summary statistics the health score is computed from. Run as
+// each task finishes, it lets the detail be collected while slower analyses
+// are still running.
+func dropFindingDetails(result interface{}) interface{} {
+	switch r := result.(type) {
+	case *domain.ComplexityResponse:
+		if r != nil {
+			r.Functions = nil
+			r.RawMetrics = nil
+		}
+	case *domain.DeadCodeResponse:
+		if r != nil {
+			r.Files = nil
+		}
+	case *domain.CloneResponse:
+		if r != nil {
+			r.Clones = nil
+			r.ClonePairs = nil
+			r.CloneGroups = nil
+		}
+	case *domain.CBOResponse:
+		if r != nil {
+			r.Classes = nil
+		}
+	case *domain.LCOMResponse:
+		if r != nil {
+			r.Classes = nil
+		}
+	case *domain.SystemAnalysisResponse:
+		if r != nil && r.DependencyAnalysis != nil {
+			r.DependencyAnalysis.DependencyMatrix = nil
+			r.DependencyAnalysis.ModuleMetrics = nil
+			r.DependencyAnalysis.LongestChains = nil
+			r.DependencyAnalysis.ImportInventory = nil
+		}
+	case *domain.DocumentationResponse:
+		if r != nil {
+			r.Findings = nil
+		}
+	case *domain.TypeCoverageResponse:
+		if r != nil {
+			r.Files = nil
+			r.Findings = nil
+		}
+	}
+	return result
+}
+
+// keepSummaryOnly reduces a response to its summary, manifest and warnings
+// once the summary has been computed from the per-analysis results
+func keepSummaryOnly(response *domain.AnalyzeResponse) {
+	*response = domain.AnalyzeResponse{
+		Summary:     response.Summary,
+		GeneratedAt: response.GeneratedAt,
+		Duration:    response.Duration,
+		Version:     response.Version,
+		Manifest:    response.Manifest,
+		Warnings:    response.Warnings,
+	}
+}
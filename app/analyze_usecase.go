@@ -6,6 +6,7 @@ import (
 	"io"
 	"log"
 	"math"
+	"slices"
 	"sync"
 	"time"
 
@@ -23,16 +24,37 @@ type AnalyzeUseCaseConfig struct {
 	SkipSystem      bool
 	SkipCommunities bool
 
+	// SkipDocumentation disables docstring coverage. Documentation analysis is
+	// opt-in: unless analyses are selected explicitly, it runs only when the
+	// [documentation] section enables it.
+	SkipDocumentation bool
+
+	// SkipTyping disables type annotation coverage, which is opt-in in the
+	// same way through the [typing] section.
+	SkipTyping bool
+
 	// SelectAnalysesUsed is true when --select was provided on the CLI.
 	SelectAnalysesUsed bool
 	// SkipCommunitiesExplicit is true when --skip-communities was provided.
 	SkipCommunitiesExplicit bool
 
+	// Rules selected with --select narrow their analysis to these dead code
+	// reasons, clone types and dependency checks. Empty when the whole
+	// analysis runs.
+	DeadCodeReasons  []string
+	CloneTypes       []domain.CloneType
+	DependencyChecks []string
+
 	MinComplexity   int
 	MinSeverity     domain.DeadCodeSeverity
 	CloneSimilarity float64
 	MinCBO          int
 
+	// Source context of dead code findings (nil and 0 = use config file or
+	// default)
+	DeadCodeShowContext  *bool
+	DeadCodeContextLines int
+
 	// Complexity thresholds (0 = unset, use config file or default)
 	LowThreshold                 int
 	MediumThreshold              int
@@ -41,6 +63,61 @@ type AnalyzeUseCaseConfig struct {
 
 	// Clone detection options
 	EnableDFA bool // Enable Data Flow Analysis for enhanced Type-4 detection
+	CloneJobs int  // Workers comparing clone candidates (0 = all CPUs)
+
+	// Clone report options (zero values keep the [clones] settings).
+	// FullReport keeps every group member regardless of caps and collapsing.
+	// CloneShowContent includes the source of clones, shown as diffs
+	// between members.
+	CloneMaxGroupMembers  int
+	CloneCollapseSameFile bool
+	CloneReportGroupBy    string
+	CloneShowContent      bool
+	FullReport            bool
+
+	// EnableBlame enriches high-complexity and dead code findings with git blame metadata
+	EnableBlame bool
+
+	// HistoryFile keeps the per-function complexity of the last HistoryRuns
+	// runs; each run is recorded and its functions annotated with their
+	// trend (empty or 0 = not recorded)
+	HistoryFile string
+	HistoryRuns int
+
+	// IncludeVendored analyzes vendored code instead of listing it in the
+	// report's vendored appendix; [analysis] include_vendored also sets it
+	IncludeVendored bool
+
+	// AbsolutePaths reports absolute file paths instead of paths relative to
+	// the project root. Both use forward slashes.
+	AbsolutePaths bool
+
+	// GroupByOwner adds a CODEOWNERS-based ownership report. CodeownersFile
+	// selects the file explicitly; when empty it is discovered from the targets.
+	GroupByOwner   bool
+	CodeownersFile string
+
+	// EnableHotspots ranks files by complexity weighted by how many commits
+	// touched them in the last ChurnWindowDays days (0 = default window)
+	EnableHotspots  bool
+	ChurnWindowDays int
+
+	// Workspace analyzes each path as an independent project and reports a
+	// per-target breakdown alongside a combined overview
+	Workspace bool
+
+	// MaxParallelTasks caps how many analyses run at the same time
+	// (0 = all at once)
+	MaxParallelTasks int
+
+	// Serial runs the analyses one after another on the shared parse
+	// snapshot, for machines short on memory; [analysis] serial also sets it
+	Serial bool
+
+	// SummaryOnly keeps only aggregate statistics and the health score.
+	// Per-finding detail is dropped as each analysis finishes, and the
+	// roll-ups, ownership, hotspots and blame enrichments are skipped.
+	SummaryOnly bool
 
 	ConfigFile string
 	Verbose    bool
@@ -54,13 +131,15 @@ type AnalyzeRequestOverrides struct {
 
 // AnalyzeUseCase orchestrates comprehensive analysis
 type AnalyzeUseCase struct {
-	complexityUseCase *ComplexityUseCase
-	deadCodeUseCase   *DeadCodeUseCase
-	cloneUseCase      *CloneUseCase
-	cboUseCase        *CBOUseCase
-	lcomUseCase       *LCOMUseCase
-	systemUseCase     *SystemAnalysisUseCase
-	communityUseCase  *CommunityUseCase
+	complexityUseCase    *ComplexityUseCase
+	deadCodeUseCase      *DeadCodeUseCase
+	cloneUseCase         *CloneUseCase
+	cboUseCase           *CBOUseCase
+	lcomUseCase          *LCOMUseCase
+	systemUseCase        *SystemAnalysisUseCase
+	communityUseCase     *CommunityUseCase
+	documentationUseCase *DocumentationUseCase
+	typeCoverageUseCase  *TypeCoverageUseCase
 
 	fileReader       domain.FileReader
 	configLoader     domain.AnalyzeConfigurationLoader
@@ -68,17 +147,21 @@ type AnalyzeUseCase struct {
 	progressManager  domain.ProgressManager
 	parallelExecutor domain.ParallelExecutor
 	errorCategorizer domain.ErrorCategorizer
+	blameProvider    domain.BlameProvider
+	churnProvider    domain.ChurnProvider
 }
 
 // AnalyzeUseCaseBuilder builds an AnalyzeUseCase
 type AnalyzeUseCaseBuilder struct {
-	complexityUseCase *ComplexityUseCase
-	deadCodeUseCase   *DeadCodeUseCase
-	cloneUseCase      *CloneUseCase
-	cboUseCase        *CBOUseCase
-	lcomUseCase       *LCOMUseCase
-	systemUseCase     *SystemAnalysisUseCase
-	communityUseCase  *CommunityUseCase
+	complexityUseCase    *ComplexityUseCase
+	deadCodeUseCase      *DeadCodeUseCase
+	cloneUseCase         *CloneUseCase
+	cboUseCase           *CBOUseCase
+	lcomUseCase          *LCOMUseCase
+	systemUseCase        *SystemAnalysisUseCase
+	communityUseCase     *CommunityUseCase
+	documentationUseCase *DocumentationUseCase
+	typeCoverageUseCase  *TypeCoverageUseCase
 
 	fileReader       domain.FileReader
 	configLoader     domain.AnalyzeConfigurationLoader
@@ -86,6 +169,8 @@ type AnalyzeUseCaseBuilder struct {
 	progressManager  domain.ProgressManager
 	parallelExecutor domain.ParallelExecutor
 	errorCategorizer domain.ErrorCategorizer
+	blameProvider    domain.BlameProvider
+	churnProvider    domain.ChurnProvider
 }
 
 // NewAnalyzeUseCaseBuilder creates a new builder
@@ -135,6 +220,18 @@ func (b *AnalyzeUseCaseBuilder) WithCommunityUseCase(uc *CommunityUseCase) *Anal
 	return b
 }
 
+// WithDocumentationUseCase sets the docstring coverage use case
+func (b *AnalyzeUseCaseBuilder) WithDocumentationUseCase(uc *DocumentationUseCase) *AnalyzeUseCaseBuilder {
+	b.documentationUseCase = uc
+	return b
+}
+
+// WithTypeCoverageUseCase sets the type annotation coverage use case
+func (b *AnalyzeUseCaseBuilder) WithTypeCoverageUseCase(uc *TypeCoverageUseCase) *AnalyzeUseCaseBuilder {
+	b.typeCoverageUseCase = uc
+	return b
+}
+
 // WithFileReader sets the file reader
 func (b *AnalyzeUseCaseBuilder) WithFileReader(fr domain.FileReader) *AnalyzeUseCaseBuilder {
 	b.fileReader = fr
@@ -171,6 +268,18 @@ func (b *AnalyzeUseCaseBuilder) WithErrorCategorizer(ec domain.ErrorCategorizer)
 	return b
 }
 
+// WithBlameProvider sets the git blame provider used for finding enrichment
+func (b *AnalyzeUseCaseBuilder) WithBlameProvider(bp domain.BlameProvider) *AnalyzeUseCaseBuilder {
+	b.blameProvider = bp
+	return b
+}
+
+// WithChurnProvider sets the git churn provider used for hotspot ranking
+func (b *AnalyzeUseCaseBuilder) WithChurnProvider(cp domain.ChurnProvider) *AnalyzeUseCaseBuilder {
+	b.churnProvider = cp
+	return b
+}
+
 // Build creates the AnalyzeUseCase
 func (b *AnalyzeUseCaseBuilder) Build() (*AnalyzeUseCase, error) {
 	if b.fileReader == nil {
@@ -191,33 +300,49 @@ func (b *AnalyzeUseCaseBuilder) Build() (*AnalyzeUseCase, error) {
 	if b.errorCategorizer == nil {
 		b.errorCategorizer = service.NewErrorCategorizer()
 	}
+	if b.blameProvider == nil {
+		b.blameProvider = service.NewGitBlameProvider()
+	}
+	if b.churnProvider == nil {
+		b.churnProvider = service.NewGitChurnProvider()
+	}
 
 	return &AnalyzeUseCase{
-		complexityUseCase: b.complexityUseCase,
-		deadCodeUseCase:   b.deadCodeUseCase,
-		cloneUseCase:      b.cloneUseCase,
-		cboUseCase:        b.cboUseCase,
-		lcomUseCase:       b.lcomUseCase,
-		systemUseCase:     b.systemUseCase,
-		communityUseCase:  b.communityUseCase,
-		fileReader:        b.fileReader,
-		configLoader:      b.configLoader,
-		formatter:         b.formatter,
-		progressManager:   b.progressManager,
-		parallelExecutor:  b.parallelExecutor,
-		errorCategorizer:  b.errorCategorizer,
+		complexityUseCase:    b.complexityUseCase,
+		deadCodeUseCase:      b.deadCodeUseCase,
+		cloneUseCase:         b.cloneUseCase,
+		cboUseCase:           b.cboUseCase,
+		lcomUseCase:          b.lcomUseCase,
+		systemUseCase:        b.systemUseCase,
+		communityUseCase:     b.communityUseCase,
+		documentationUseCase: b.documentationUseCase,
+		typeCoverageUseCase:  b.typeCoverageUseCase,
+		fileReader:           b.fileReader,
+		configLoader:         b.configLoader,
+		formatter:            b.formatter,
+		progressManager:      b.progressManager,
+		parallelExecutor:     b.parallelExecutor,
+		errorCategorizer:     b.errorCategorizer,
+		blameProvider:        b.blameProvider,
+		churnProvider:        b.churnProvider,
 	}, nil
 }
 
 // Task names used both for display and as keys for progress estimation
 const (
-	taskNameComplexity  = "Complexity Analysis"
-	taskNameDeadCode    = "Dead Code Detection"
-	taskNameClones      = "Clone Detection"
-	taskNameCBO         = "Class Coupling (CBO)"
-	taskNameLCOM        = "Class Cohesion (LCOM)"
-	taskNameSystem      = "System Analysis"
-	taskNameCommunities = "Community Detection"
+	taskNameComplexity    = "Complexity Analysis"
+	taskNameDeadCode      = "Dead Code Detection"
+	taskNameClones        = "Clone Detection"
+	taskNameCBO           = "Class Coupling (CBO)"
+	taskNameLCOM          = "Class Cohesion (LCOM)"
+	taskNameSystem        = "System Analysis"
+	taskNameCommunities   = "Community Detection"
+	taskNameDocumentation = "Documentation Coverage"
+	taskNameTyping        = "Type Annotation Coverage"
+
+	// taskNameParse is the parsing of the project snapshot shared by the
+	// per-file analyses; it only takes part in progress tracking
+	taskNameParse = "Parsing"
 )
 
 // AnalysisTask represents a single analysis task
@@ -241,117 +366,235 @@ func (uc *AnalyzeUseCase) ExecuteWithOverrides(ctx context.Context, useCaseCfg A
 }
 
 func (uc *AnalyzeUseCase) execute(ctx context.Context, useCaseCfg AnalyzeUseCaseConfig, paths []string, overrides AnalyzeRequestOverrides) (*domain.AnalyzeResponse, error) {
+	if useCaseCfg.Workspace && len(paths) > 1 {
+		return uc.executeWorkspace(ctx, useCaseCfg, paths, overrides)
+	}
+
 	startTime := time.Now()
 
-	executionCfg, err := uc.loadExecutionConfig(useCaseCfg.ConfigFile, paths)
+	run, err := uc.prepareRun(useCaseCfg, paths, overrides)
 	if err != nil {
 		return nil, err
 	}
-	if overrides.Recursive != nil {
-		executionCfg.Recursive = *overrides.Recursive
-	}
-	useCaseCfg.ConfigFile = executionCfg.ConfigPath
+	useCaseCfg, executionCfg := run.config, run.executionCfg
+	files, vendored, fileSets := run.files, run.vendored, run.fileSets
 
-	if !executionCfg.ComplexityEnabled {
-		useCaseCfg.SkipComplexity = true
-	}
-	if !executionCfg.DeadCodeEnabled {
-		useCaseCfg.SkipDeadCode = true
-	}
-	if !executionCfg.SystemEnabled {
-		useCaseCfg.SkipSystem = true
-	}
+	// Estimate per-task durations from file count, then calibrate with actual
+	// timings recorded by previous runs on this project (if any). They weigh
+	// the tasks against each other in the overall progress.
+	estimatedSeconds := uc.estimateTaskSeconds(len(files), useCaseCfg, executionCfg)
 
-	if !useCaseCfg.SelectAnalysesUsed && executionCfg.CommunitiesEnabledExplicit {
-		useCaseCfg.SkipCommunities = !executionCfg.CommunitiesEnabled
+	// Track progress from the items each task reports as done, weighting
+	// tasks by their estimated share of the run. The percentage goes to the
+	// progress bar and to the caller's reporter, if the context carries one.
+	callerProgress := domain.ProgressReporterFrom(ctx)
+	stages, _ := callerProgress.(domain.StageReporter)
+	startStage := func(task string) {
+		if stages != nil {
+			stages.StageStarted(stageName(task))
+		}
 	}
-	if useCaseCfg.SkipCommunitiesExplicit {
-		useCaseCfg.SkipCommunities = true
+	finishStage := func(task string) {
+		if stages != nil {
+			stages.StageFinished(stageName(task))
+		}
 	}
-
-	// Validate and collect files using configured patterns
-	files, err := uc.fileReader.CollectPythonFiles(
-		paths,
-		executionCfg.Recursive,
-		executionCfg.IncludePatterns,
-		executionCfg.ExcludePatterns,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to collect Python files: %w", err)
+	if uc.progressManager != nil {
+		uc.progressManager.Initialize(100) // 100% based progress
+		uc.progressManager.Start()
 	}
+	progress := newAnalysisProgress(applyTimingFactors(estimatedSeconds, service.LoadAnalysisTimingFactors()), func(percent int) {
+		if uc.progressManager != nil {
+			uc.progressManager.Update(percent, 100)
+		}
+		if callerProgress != nil {
+			callerProgress.ReportProgress(domain.ProgressStageAnalyze, percent, 100)
+		}
+	})
 
-	if len(files) == 0 {
-		return nil, fmt.Errorf("no Python files found in the specified paths")
-	}
+	// Collect what the analyses skip or cut short for the report
+	warnings := newAnalysisWarnings()
+	ctx = domain.WithWarningReporter(ctx, warnings)
 
-	// Estimate per-task durations from file count, then calibrate with actual
-	// timings recorded by previous runs on this project (if any)
-	estimatedSeconds := uc.estimateTaskSeconds(len(files), useCaseCfg, executionCfg)
+	// Record the cost of each phase for the manifest
+	timings := newAnalysisTimings()
 
 	var snapshot *service.ProjectSnapshot
 	if uc.needsProjectSnapshot(useCaseCfg) {
-		snapshot = service.BuildProjectSnapshotWithOptions(ctx, files, service.ProjectSnapshotOptions{
+		parseFiles := uc.snapshotFiles(useCaseCfg, fileSets)
+		parsePhase := timings.Add(taskNameParse, len(parseFiles), 0)
+		timings.Begin(parsePhase)
+		startStage(taskNameParse)
+		parseCtx := domain.WithProgressReporter(ctx, progress.stageReporter(taskNameParse, stages))
+		snapshot = service.BuildProjectSnapshotWithOptions(parseCtx, parseFiles, service.ProjectSnapshotOptions{
 			IncludeRawMetrics: uc.complexityUseCase != nil && !useCaseCfg.SkipComplexity,
+			TerminatingCalls:  executionCfg.DeadCodeTerminatingCalls,
 		})
-	}
-
-	// Start unified progress tracking; task completions feed back into the
-	// estimate so the bar recalibrates to actual machine/codebase speed
-	var tracker *analysisProgressTracker
-	var progressDone chan struct{}
-	if uc.progressManager != nil {
-		tracker = newAnalysisProgressTracker(applyTimingFactors(estimatedSeconds, service.LoadAnalysisTimingFactors()))
-		uc.progressManager.Initialize(100) // 100% based progress
-		progressDone = uc.startProgressUpdater(tracker)
+		timings.End(parsePhase)
+		parsePhase.timing.BytesParsed = snapshot.BytesParsed
+		progress.TaskCompleted(taskNameParse)
+		finishStage(taskNameParse)
 	}
 
 	// Create analysis tasks
-	tasks := uc.createAnalysisTasks(useCaseCfg, paths, files, snapshot, executionCfg)
-
-	// Execute tasks in parallel
+	progress.StartTasks()
+	tasks := uc.createAnalysisTasks(useCaseCfg, paths, fileSets, snapshot, executionCfg)
+
+	// Execute tasks in parallel, at most MaxParallelTasks at a time, or one
+	// after another in serial mode so that only one analysis holds its
+	// intermediate state at a time
+	serial := useCaseCfg.Serial || executionCfg.Serial
+	var slots chan struct{}
+	if useCaseCfg.MaxParallelTasks > 0 {
+		slots = make(chan struct{}, useCaseCfg.MaxParallelTasks)
+	}
+	runTask := func(t *AnalysisTask, phase *phaseTiming) {
+		timings.Begin(phase)
+		startStage(t.Name)
+		result, err := t.Execute(domain.WithProgressReporter(ctx, progress.stageReporter(t.Name, stages)))
+		timings.End(phase)
+		if useCaseCfg.SummaryOnly {
+			result = dropFindingDetails(result)
+		}
+		t.Result = result
+		t.Error = err
+		progress.TaskCompleted(t.Name)
+		finishStage(t.Name)
+	}
 	var wg sync.WaitGroup
 	for _, task := range tasks {
 		if !task.Enabled {
 			continue
 		}
 
+		phase := timings.addTask(task.Name, fileSets, snapshot)
+		if serial {
+			runTask(task, phase)
+			continue
+		}
 		wg.Add(1)
 		go func(t *AnalysisTask) {
 			defer wg.Done()
-			result, err := t.Execute(ctx)
-			t.Result = result
-			t.Error = err
-			if tracker != nil {
-				tracker.TaskCompleted(t.Name)
+			if slots != nil {
+				slots <- struct{}{}
+				defer func() { <-slots }()
 			}
+			runTask(t, phase)
 		}(task)
 	}
 
 	// Wait for all tasks to complete
 	wg.Wait()
+	phaseTimings := timings.Stop()
+
+	// A cancelled run still reports what the tasks finished, flagged as
+	// cancelled, but skips the enrichments that shell out to git
+	if ctx.Err() != nil {
+		if uc.progressManager != nil {
+			uc.progressManager.Complete(false)
+		}
+		response := uc.buildResponse(tasks, startTime)
+		attachVendored(response, vendored)
+		response.Manifest = service.BuildAnalysisManifest(paths, executionCfg.ConfigPath, len(files), response.Summary)
+		response.Manifest.Thresholds = service.ReportThresholds(response)
+		response.Manifest.Timings = phaseTimings
+		response.Warnings = warnings.Warnings()
+		if useCaseCfg.SummaryOnly {
+			keepSummaryOnly(response)
+		} else {
+			response.Packages = buildPackageRollup(response, fileSets.every(), uc.countFileLines)
+			service.ShapeCloneReport(response.Clone, useCaseCfg.FullReport)
+		}
+		response.Cancelled = true
+		normalizeReportPaths(response, paths, useCaseCfg.AbsolutePaths || executionCfg.AbsolutePaths)
+		return response, domain.NewCancelledError("analysis cancelled; results are partial", ctx.Err())
+	}
 
-	// Stop progress updater and ensure progress bar reaches 100%
-	if progressDone != nil {
-		close(progressDone)
+	// All tasks have returned: the progress bar reaches 100%
+	if uc.progressManager != nil {
 		uc.progressManager.Update(100, 100)
 		uc.progressManager.Complete(true)
 	}
-
-	// Persist observed timings to improve the initial estimate of future runs
-	if tracker != nil {
-		service.UpdateAnalysisTimingFactors(estimatedSeconds, tracker.CompletedDurations())
+	if callerProgress != nil {
+		callerProgress.ReportProgress(domain.ProgressStageAnalyze, 100, 100)
 	}
 
+	// Persist observed timings to improve the task weights of future runs
+	service.UpdateAnalysisTimingFactors(estimatedSeconds, progress.CompletedDurations())
+
 	// Check for errors
 	var errors []error
 	for _, task := range tasks {
 		if task.Enabled && task.Error != nil {
 			errors = append(errors, fmt.Errorf("%s: %w", task.Name, task.Error))
+			warnings.ReportWarning(domain.AnalysisWarning{
+				Analyzer: taskTimingNames[task.Name],
+				Message:  "analysis failed: " + task.Error.Error(),
+			})
 		}
 	}
 
 	// Build response
 	response := uc.buildResponse(tasks, startTime)
+	attachVendored(response, vendored)
+	response.Manifest = service.BuildAnalysisManifest(paths, executionCfg.ConfigPath, len(files), response.Summary)
+	response.Manifest.Thresholds = service.ReportThresholds(response)
+	response.Manifest.Timings = phaseTimings
+
+	if useCaseCfg.SummaryOnly {
+		response.Warnings = warnings.Warnings()
+		keepSummaryOnly(response)
+		normalizeReportPaths(response, paths, useCaseCfg.AbsolutePaths || executionCfg.AbsolutePaths)
+		if len(errors) > 0 {
+			return response, fmt.Errorf("analysis completed with %d error(s): %w", len(errors), errors[0])
+		}
+		return response, nil
+	}
+
+	if useCaseCfg.EnableBlame {
+		response.Summary.BlameEnabled = true
+		if err := enrichWithBlame(response, uc.blameProvider); err != nil {
+			warnings.warn("blame", "Failed to collect git blame metadata: %v", err)
+		}
+	}
+
+	if useCaseCfg.HistoryFile != "" && useCaseCfg.HistoryRuns > 0 && response.Complexity != nil {
+		if err := recordComplexityHistory(response, useCaseCfg.HistoryFile, useCaseCfg.HistoryRuns, service.FindProjectRoot(paths)); err != nil {
+			warnings.warn("history", "Skipping complexity history: %v", err)
+		} else {
+			response.Summary.HistoryEnabled = true
+		}
+	}
+
+	response.Packages = buildPackageRollup(response, fileSets.every(), uc.countFileLines)
+	service.ShapeCloneReport(response.Clone, useCaseCfg.FullReport)
+
+	if useCaseCfg.GroupByOwner || useCaseCfg.CodeownersFile != "" {
+		codeowners, err := service.LoadCodeowners(useCaseCfg.CodeownersFile, paths)
+		if err != nil {
+			warnings.warn("ownership", "Skipping ownership report: %v", err)
+		} else {
+			response.Ownership = buildOwnershipReport(response, files, codeowners, codeowners.Path)
+		}
+	}
+
+	if useCaseCfg.EnableHotspots {
+		if response.Complexity == nil {
+			warnings.warn("hotspots", "Skipping hotspots: complexity analysis did not run")
+		} else if hotspots, err := buildHotspotReport(response, files, uc.churnProvider, useCaseCfg.ChurnWindowDays, time.Now()); err != nil {
+			warnings.warn("hotspots", "Skipping hotspots: %v", err)
+		} else if hotspots == nil {
+			warnings.warn("hotspots", "Skipping hotspots: the analyzed files are not in a git repository")
+		} else {
+			response.Hotspots = hotspots
+		}
+	}
+
+	response.Warnings = warnings.Warnings()
+
+	// Paths are normalized last: the enrichments above read the files and
+	// query git with the paths the analyzers reported
+	normalizeReportPaths(response, paths, useCaseCfg.AbsolutePaths || executionCfg.AbsolutePaths)
 
 	// Return aggregated error if any tasks failed
 	if len(errors) > 0 {
@@ -361,6 +604,105 @@ func (uc *AnalyzeUseCase) execute(ctx context.Context, useCaseCfg AnalyzeUseCase
 	return response, nil
 }
 
+// preparedRun is an analyze run resolved up to the analyses: its
+// configuration and the files each analysis would see
+type preparedRun struct {
+	config       AnalyzeUseCaseConfig
+	executionCfg domain.AnalyzeExecutionConfig
+	files        []string
+	vendored     []domain.VendoredCode
+	fileSets     analysisFiles
+}
+
+// prepareRun loads the configuration, decides which analyses run and
+// collects their files
+func (uc *AnalyzeUseCase) prepareRun(useCaseCfg AnalyzeUseCaseConfig, paths []string, overrides AnalyzeRequestOverrides) (*preparedRun, error) {
+	executionCfg, err := uc.loadExecutionConfig(useCaseCfg.ConfigFile, paths)
+	if err != nil {
+		return nil, err
+	}
+	if overrides.Recursive != nil {
+		executionCfg.Recursive = *overrides.Recursive
+	}
+	useCaseCfg.ConfigFile = executionCfg.ConfigPath
+
+	if !executionCfg.ComplexityEnabled {
+		useCaseCfg.SkipComplexity = true
+	}
+	if !executionCfg.DeadCodeEnabled {
+		useCaseCfg.SkipDeadCode = true
+	}
+	if !executionCfg.SystemEnabled {
+		useCaseCfg.SkipSystem = true
+	}
+
+	if !useCaseCfg.SelectAnalysesUsed && executionCfg.CommunitiesEnabledExplicit {
+		useCaseCfg.SkipCommunities = !executionCfg.CommunitiesEnabled
+	}
+	if useCaseCfg.SkipCommunitiesExplicit {
+		useCaseCfg.SkipCommunities = true
+	}
+	if !useCaseCfg.SelectAnalysesUsed {
+		useCaseCfg.SkipDocumentation = !executionCfg.DocumentationEnabled
+		useCaseCfg.SkipTyping = !executionCfg.TypingEnabled
+	}
+
+	// Validate and collect files using configured patterns
+	files, err := uc.walkingFileReader(executionCfg).CollectPythonFiles(
+		paths,
+		executionCfg.Recursive,
+		executionCfg.IncludePatterns,
+		executionCfg.ExcludePatterns,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect Python files: %w", err)
+	}
+
+	// Vendored code is left out of the analyses and the scores, and only
+	// listed in the report
+	var vendoredDetector *service.VendoredDetector
+	var vendored []domain.VendoredCode
+	if !useCaseCfg.IncludeVendored && !executionCfg.IncludeVendored {
+		vendoredDetector = service.NewVendoredDetector(paths)
+		files, vendored = vendoredDetector.Split(files)
+	}
+
+	if len(files) == 0 {
+		if len(vendored) > 0 {
+			return nil, fmt.Errorf("all Python files in the specified paths are vendored; use --include-vendored to analyze them")
+		}
+		return nil, fmt.Errorf("no Python files found in the specified paths")
+	}
+
+	// Analyses with their own include/exclude patterns get their own files
+	fileSets, err := uc.collectAnalysisFiles(useCaseCfg, paths, files, executionCfg, vendoredDetector)
+	if err != nil {
+		return nil, err
+	}
+
+	return &preparedRun{
+		config:       useCaseCfg,
+		executionCfg: executionCfg,
+		files:        files,
+		vendored:     vendored,
+		fileSets:     fileSets,
+	}, nil
+}
+
+// attachVendored lists the vendored code left out of the analyses
+func attachVendored(response *domain.AnalyzeResponse, vendored []domain.VendoredCode) {
+	response.Vendored = vendored
+	for _, code := range vendored {
+		response.Summary.VendoredFiles += code.Files
+	}
+}
+
+// normalizeReportPaths rewrites the report's file paths to forward slashes,
+// relative to the project root unless absolute is set
+func normalizeReportPaths(response *domain.AnalyzeResponse, paths []string, absolute bool) {
+	service.NewReportPathNormalizer(service.ReportRoot(paths), absolute).NormalizeResponse(response)
+}
+
 func (uc *AnalyzeUseCase) needsProjectSnapshot(config AnalyzeUseCaseConfig) bool {
 	return (uc.complexityUseCase != nil && !config.SkipComplexity) ||
 		(uc.deadCodeUseCase != nil && !config.SkipDeadCode) ||
@@ -368,8 +710,27 @@ func (uc *AnalyzeUseCase) needsProjectSnapshot(config AnalyzeUseCaseConfig) bool
 		(uc.lcomUseCase != nil && !config.SkipLCOM)
 }
 
+// snapshotFiles returns the files the snapshot-backed analyses that will run
+// need between them
+func (uc *AnalyzeUseCase) snapshotFiles(config AnalyzeUseCaseConfig, fileSets analysisFiles) []string {
+	var sections []string
+	if uc.complexityUseCase != nil && !config.SkipComplexity {
+		sections = append(sections, domain.AnalysisScopeComplexity)
+	}
+	if uc.deadCodeUseCase != nil && !config.SkipDeadCode {
+		sections = append(sections, domain.AnalysisScopeDeadCode)
+	}
+	if uc.cboUseCase != nil && !config.SkipCBO {
+		sections = append(sections, domain.AnalysisScopeCBO)
+	}
+	if uc.lcomUseCase != nil && !config.SkipLCOM {
+		sections = append(sections, domain.AnalysisScopeLCOM)
+	}
+	return fileSets.union(sections...)
+}
+
 // createAnalysisTasks creates the analysis tasks based on configuration
-func (uc *AnalyzeUseCase) createAnalysisTasks(config AnalyzeUseCaseConfig, sourcePaths []string, files []string, snapshot *service.ProjectSnapshot, executionCfg domain.AnalyzeExecutionConfig) []*AnalysisTask {
+func (uc *AnalyzeUseCase) createAnalysisTasks(config AnalyzeUseCaseConfig, sourcePaths []string, files analysisFiles, snapshot *service.ProjectSnapshot, executionCfg domain.AnalyzeExecutionConfig) []*AnalysisTask {
 	tasks := []*AnalysisTask{}
 
 	// Complexity analysis task
@@ -378,8 +739,8 @@ func (uc *AnalyzeUseCase) createAnalysisTasks(config AnalyzeUseCaseConfig, sourc
 			Name:    taskNameComplexity,
 			Enabled: !config.SkipComplexity,
 			Execute: func(ctx context.Context) (interface{}, error) {
-				request := uc.buildComplexityTaskRequest(config, files, executionCfg)
-				return uc.complexityUseCase.analyzeSnapshotRequest(ctx, snapshot, request)
+				request := uc.buildComplexityTaskRequest(config, files.forScope(domain.AnalysisScopeComplexity), executionCfg)
+				return uc.complexityUseCase.analyzeSnapshotRequest(ctx, files.snapshotForScope(snapshot, domain.AnalysisScopeComplexity), request)
 			},
 		})
 	}
@@ -391,7 +752,7 @@ func (uc *AnalyzeUseCase) createAnalysisTasks(config AnalyzeUseCaseConfig, sourc
 			Enabled: !config.SkipDeadCode,
 			Execute: func(ctx context.Context) (interface{}, error) {
 				request := domain.DeadCodeRequest{
-					Paths:           files,
+					Paths:           files.forScope(domain.AnalysisScopeDeadCode),
 					Recursive:       domain.BoolPtr(executionCfg.Recursive),
 					IncludePatterns: []string{},
 					ExcludePatterns: []string{},
@@ -402,15 +763,16 @@ func (uc *AnalyzeUseCase) createAnalysisTasks(config AnalyzeUseCaseConfig, sourc
 					ConfigPath:      config.ConfigFile,
 					// Detection options left as nil to allow config file values to take precedence
 					// If not set in config, defaults from DefaultDeadCodeRequest() will be used
-					ShowContext:               nil,
-					ContextLines:              0, // 0 = use config file or default value
+					ShowContext:               config.DeadCodeShowContext,
+					ContextLines:              config.DeadCodeContextLines,
 					DetectAfterReturn:         nil,
 					DetectAfterBreak:          nil,
 					DetectAfterContinue:       nil,
 					DetectAfterRaise:          nil,
 					DetectUnreachableBranches: nil,
+					Reasons:                   config.DeadCodeReasons,
 				}
-				return uc.deadCodeUseCase.analyzeSnapshotRequest(ctx, snapshot, request)
+				return uc.deadCodeUseCase.analyzeSnapshotRequest(ctx, files.snapshotForScope(snapshot, domain.AnalysisScopeDeadCode), request)
 			},
 		})
 	}
@@ -421,7 +783,7 @@ func (uc *AnalyzeUseCase) createAnalysisTasks(config AnalyzeUseCaseConfig, sourc
 			Name:    taskNameClones,
 			Enabled: !config.SkipClones,
 			Execute: func(ctx context.Context) (interface{}, error) {
-				request := uc.buildCloneTaskRequest(config, files, executionCfg)
+				request := uc.buildCloneTaskRequest(config, files.forScope(domain.AnalysisScopeClones), executionCfg)
 				return uc.cloneUseCase.ExecuteAndReturn(ctx, request)
 			},
 		})
@@ -434,7 +796,7 @@ func (uc *AnalyzeUseCase) createAnalysisTasks(config AnalyzeUseCaseConfig, sourc
 			Enabled: !config.SkipCBO,
 			Execute: func(ctx context.Context) (interface{}, error) {
 				request := domain.CBORequest{
-					Paths:           files,
+					Paths:           files.forScope(domain.AnalysisScopeCBO),
 					Recursive:       domain.BoolPtr(executionCfg.Recursive),
 					IncludePatterns: []string{},
 					ExcludePatterns: []string{},
@@ -451,7 +813,7 @@ func (uc *AnalyzeUseCase) createAnalysisTasks(config AnalyzeUseCaseConfig, sourc
 					IncludeImports:        nil,
 					GroupNamespaceImports: nil,
 				}
-				return uc.cboUseCase.analyzeSnapshotRequest(ctx, snapshot, request)
+				return uc.cboUseCase.analyzeSnapshotRequest(ctx, files.snapshotForScope(snapshot, domain.AnalysisScopeCBO), request)
 			},
 		})
 	}
@@ -463,7 +825,7 @@ func (uc *AnalyzeUseCase) createAnalysisTasks(config AnalyzeUseCaseConfig, sourc
 			Enabled: !config.SkipLCOM,
 			Execute: func(ctx context.Context) (interface{}, error) {
 				request := domain.LCOMRequest{
-					Paths:           files,
+					Paths:           files.forScope(domain.AnalysisScopeLCOM),
 					Recursive:       domain.BoolPtr(executionCfg.Recursive),
 					IncludePatterns: []string{},
 					ExcludePatterns: []string{},
@@ -474,7 +836,7 @@ func (uc *AnalyzeUseCase) createAnalysisTasks(config AnalyzeUseCaseConfig, sourc
 					SortBy:          domain.SortByCohesion,
 					ConfigPath:      config.ConfigFile,
 				}
-				return uc.lcomUseCase.analyzeSnapshotRequest(ctx, snapshot, request)
+				return uc.lcomUseCase.analyzeSnapshotRequest(ctx, files.snapshotForScope(snapshot, domain.AnalysisScopeLCOM), request)
 			},
 		})
 	}
@@ -486,7 +848,7 @@ func (uc *AnalyzeUseCase) createAnalysisTasks(config AnalyzeUseCaseConfig, sourc
 			Enabled: !config.SkipSystem,
 			Execute: func(ctx context.Context) (interface{}, error) {
 				request := domain.SystemAnalysisRequest{
-					Paths:                files,
+					Paths:                files.forScope(domain.AnalysisScopeDependencies),
 					Recursive:            domain.BoolPtr(executionCfg.Recursive),
 					IncludePatterns:      []string{},
 					ExcludePatterns:      []string{},
@@ -501,6 +863,12 @@ func (uc *AnalyzeUseCase) createAnalysisTasks(config AnalyzeUseCaseConfig, sourc
 					DetectCycles:         nil,
 					ValidateArchitecture: nil,
 				}
+				if len(config.DependencyChecks) > 0 {
+					architecture := slices.Contains(config.DependencyChecks, domain.DependencyCheckArchitecture)
+					request.DetectCycles = domain.BoolPtr(slices.Contains(config.DependencyChecks, domain.DependencyCheckCycles))
+					request.AnalyzeArchitecture = domain.BoolPtr(architecture)
+					request.ValidateArchitecture = domain.BoolPtr(architecture)
+				}
 				return uc.systemUseCase.AnalyzeAndReturn(ctx, request)
 			},
 		})
@@ -513,7 +881,7 @@ func (uc *AnalyzeUseCase) createAnalysisTasks(config AnalyzeUseCaseConfig, sourc
 			Enabled: !config.SkipCommunities,
 			Execute: func(ctx context.Context) (interface{}, error) {
 				request := domain.CommunityAnalysisRequest{
-					Paths:           files,
+					Paths:           files.forScope(domain.AnalysisScopeCommunities),
 					SourcePaths:     append([]string(nil), sourcePaths...),
 					Recursive:       domain.BoolPtr(executionCfg.Recursive),
 					IncludePatterns: []string{},
@@ -527,6 +895,42 @@ func (uc *AnalyzeUseCase) createAnalysisTasks(config AnalyzeUseCaseConfig, sourc
 		})
 	}
 
+	// Docstring coverage task
+	if uc.documentationUseCase != nil {
+		tasks = append(tasks, &AnalysisTask{
+			Name:    taskNameDocumentation,
+			Enabled: !config.SkipDocumentation,
+			Execute: func(ctx context.Context) (interface{}, error) {
+				request := domain.DocumentationRequest{
+					Paths:           files.forScope(domain.AnalysisScopeDocumentation),
+					Recursive:       domain.BoolPtr(executionCfg.Recursive),
+					IncludePatterns: []string{},
+					ExcludePatterns: []string{},
+					ConfigPath:      config.ConfigFile,
+				}
+				return uc.documentationUseCase.AnalyzeAndReturn(ctx, request)
+			},
+		})
+	}
+
+	// Type annotation coverage task
+	if uc.typeCoverageUseCase != nil {
+		tasks = append(tasks, &AnalysisTask{
+			Name:    taskNameTyping,
+			Enabled: !config.SkipTyping,
+			Execute: func(ctx context.Context) (interface{}, error) {
+				request := domain.TypeCoverageRequest{
+					Paths:           files.forScope(domain.AnalysisScopeTyping),
+					Recursive:       domain.BoolPtr(executionCfg.Recursive),
+					IncludePatterns: []string{},
+					ExcludePatterns: []string{},
+					ConfigPath:      config.ConfigFile,
+				}
+				return uc.typeCoverageUseCase.AnalyzeAndReturn(ctx, request)
+			},
+		})
+	}
+
 	return tasks
 }
 
@@ -572,6 +976,10 @@ func (uc *AnalyzeUseCase) buildComplexityTaskRequest(config AnalyzeUseCaseConfig
 		NestingDepthThreshold:        nestingThreshold,
 		Enabled:                      domain.BoolPtr(executionCfg.ComplexityEnabled),
 		ReportUnchanged:              domain.BoolPtr(executionCfg.ComplexityReportUnchanged),
+		BoilerplatePatterns:          executionCfg.ComplexityBoilerplate,
+		ComprehensionWeight:          executionCfg.ComplexityComprehensionWeight,
+		GeneratorWeight:              executionCfg.ComplexityGeneratorWeight,
+		BooleanOperatorWeight:        executionCfg.ComplexityBooleanOperatorWeight,
 		ConfigPath:                   config.ConfigFile,
 	}
 }
@@ -579,14 +987,25 @@ func (uc *AnalyzeUseCase) buildComplexityTaskRequest(config AnalyzeUseCaseConfig
 func (uc *AnalyzeUseCase) buildCloneTaskRequest(config AnalyzeUseCaseConfig, files []string, executionCfg domain.AnalyzeExecutionConfig) domain.CloneRequest {
 	// Sparse request: zero values mean "not set" and are filled from the
 	// config file (or defaults) during MergeConfig inside the use case.
-	return domain.CloneRequest{
+	request := domain.CloneRequest{
 		Paths:               files,
 		Recursive:           domain.BoolPtr(executionCfg.Recursive),
 		OutputFormat:        domain.OutputFormatJSON,
 		OutputWriter:        io.Discard,
 		SimilarityThreshold: config.CloneSimilarity,
 		ConfigPath:          config.ConfigFile,
+		MaxGroupMembers:     config.CloneMaxGroupMembers,
+		ReportGroupBy:       config.CloneReportGroupBy,
+		CloneTypes:          config.CloneTypes,
+		Jobs:                config.CloneJobs,
+	}
+	if config.CloneCollapseSameFile {
+		request.CollapseSameFile = domain.BoolPtr(true)
+	}
+	if config.CloneShowContent {
+		request.ShowContent = domain.BoolPtr(true)
 	}
+	return request
 }
 
 // buildResponse builds the analyze response from task results
@@ -641,6 +1060,16 @@ func (uc *AnalyzeUseCase) buildResponse(tasks []*AnalysisTask, startTime time.Ti
 			if result != nil {
 				response.Communities = result
 			}
+		case *domain.DocumentationResponse:
+			response.Summary.DocumentationEnabled = true
+			if result != nil {
+				response.Documentation = result
+			}
+		case *domain.TypeCoverageResponse:
+			response.Summary.TypingEnabled = true
+			if result != nil {
+				response.TypeCoverage = result
+			}
 		case nil:
 			uc.markSummaryForTask(&response.Summary, task.Name)
 		default:
@@ -648,6 +1077,9 @@ func (uc *AnalyzeUseCase) buildResponse(tasks []*AnalysisTask, startTime time.Ti
 		}
 	}
 
+	// Suggest where duplicated code should be extracted to
+	service.SuggestCloneExtractionTargets(response.Clone, response.System)
+
 	// Calculate summary statistics
 	uc.calculateSummary(&response.Summary, response)
 
@@ -674,6 +1106,10 @@ func (uc *AnalyzeUseCase) markSummaryForTask(summary *domain.AnalyzeSummary, tas
 		summary.DepsEnabled = true
 	case taskNameCommunities:
 		summary.CommunitiesEnabled = true
+	case taskNameDocumentation:
+		summary.DocumentationEnabled = true
+	case taskNameTyping:
+		summary.TypingEnabled = true
 	}
 }
 
@@ -769,6 +1205,22 @@ func (uc *AnalyzeUseCase) calculateSummary(summary *domain.AnalyzeSummary, respo
 		summary.CommunityLayerAlignment = c.LayerAlignmentScore
 	}
 
+	// Docstring coverage (feeds the optional documentation score)
+	if response.Documentation != nil {
+		d := response.Documentation.Summary
+		undocumented := d.TotalItems - d.DocumentedItems
+		summary.DocumentedItems = &d.DocumentedItems
+		summary.UndocumentedItems = &undocumented
+		summary.DocstringCoverage = &d.Coverage
+	}
+
+	// Type annotation coverage (feeds the optional typedness score)
+	if response.TypeCoverage != nil {
+		t := response.TypeCoverage.Summary
+		summary.TypeAnnotationCoverage = &t.Coverage
+		summary.UnannotatedPublicFunctions = &t.UnannotatedPublicFunctions
+	}
+
 	// Calculate health score with error handling
 	if err := summary.CalculateHealthScore(); err != nil {
 		// Log warning
@@ -791,12 +1243,16 @@ func (uc *AnalyzeUseCase) loadExecutionConfig(configPath string, paths []string)
 
 // estimateTaskSeconds estimates the duration of each enabled analysis task in
 // seconds, keyed by task name. The formulas capture how each analysis scales
-// with file count; absolute accuracy comes from calibration against actual
+// with file count; relative accuracy comes from calibration against actual
 // timings (see applyTimingFactors and UpdateAnalysisTimingFactors).
 func (uc *AnalyzeUseCase) estimateTaskSeconds(fileCount int, config AnalyzeUseCaseConfig, executionCfg domain.AnalyzeExecutionConfig) map[string]float64 {
 	n := float64(fileCount)
 	estimates := map[string]float64{}
 
+	if uc.needsProjectSnapshot(config) {
+		estimates[taskNameParse] = 0.01 * n // Parsing: ~0.01s per file
+	}
+
 	// Linear analyses (fast)
 	if uc.complexityUseCase != nil && !config.SkipComplexity {
 		estimates[taskNameComplexity] = 0.01 * n // Complexity: ~0.01s per file
@@ -816,6 +1272,12 @@ func (uc *AnalyzeUseCase) estimateTaskSeconds(fileCount int, config AnalyzeUseCa
 	if uc.communityUseCase != nil && !config.SkipCommunities {
 		estimates[taskNameCommunities] = 0.02 * n
 	}
+	if uc.documentationUseCase != nil && !config.SkipDocumentation {
+		estimates[taskNameDocumentation] = 0.005 * n
+	}
+	if uc.typeCoverageUseCase != nil && !config.SkipTyping {
+		estimates[taskNameTyping] = 0.005 * n
+	}
 
 	// Clone detection - account for LSH configuration
 	if uc.cloneUseCase != nil && !config.SkipClones {
@@ -843,29 +1305,3 @@ func (uc *AnalyzeUseCase) estimateTaskSeconds(fileCount int, config AnalyzeUseCa
 
 	return estimates
 }
-
-// startProgressUpdater starts a background goroutine that periodically renders
-// the tracker's current progress estimate
-func (uc *AnalyzeUseCase) startProgressUpdater(tracker *analysisProgressTracker) chan struct{} {
-	done := make(chan struct{})
-
-	// Start progress bar
-	uc.progressManager.Start()
-
-	go func() {
-		ticker := time.NewTicker(100 * time.Millisecond)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-ticker.C:
-				uc.progressManager.Update(tracker.Percent(), 100)
-
-			case <-done:
-				return
-			}
-		}
-	}()
-
-	return done
-}
@@ -212,7 +212,7 @@ func TestAnalyzeUseCase_CommunityTaskSkippedByDefault(t *testing.T) {
 		SkipLCOM:        true,
 		SkipSystem:      true,
 		SkipCommunities: true,
-	}, []string{"."}, []string{"."}, nil, domain.AnalyzeExecutionConfig{})
+	}, []string{"."}, analysisFiles{all: []string{"."}}, nil, domain.AnalyzeExecutionConfig{})
 
 	var communityTask *AnalysisTask
 	for _, task := range tasks {
@@ -248,7 +248,7 @@ func TestAnalyzeUseCase_CommunityTaskRequestUsesDiscardWriter(t *testing.T) {
 		SkipLCOM:        true,
 		SkipSystem:      true,
 		SkipCommunities: false,
-	}, []string{filepath.Join("..", "testdata", "python", "mvc_app")}, []string{filepath.Join("..", "testdata", "python", "mvc_app")}, nil, domain.AnalyzeExecutionConfig{Recursive: true})
+	}, []string{filepath.Join("..", "testdata", "python", "mvc_app")}, analysisFiles{all: []string{filepath.Join("..", "testdata", "python", "mvc_app")}}, nil, domain.AnalyzeExecutionConfig{Recursive: true})
 
 	var communityTask *AnalysisTask
 	for _, task := range tasks {
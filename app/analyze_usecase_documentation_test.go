@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const documentationSource = `"""Orders."""
+
+class Order:
+    """An order."""
+
+    def total(self):
+        return 0
+
+
+def place(order):
+    return order
+`
+
+func TestAnalyzeUseCase_DocumentationEnabledFromConfig(t *testing.T) {
+	dir, configPath := writeTestProject(t, "[documentation]\nenabled = true\n", map[string]string{"orders.py": documentationSource})
+	useCase := newTestAnalyzeUseCase(t)
+
+	response, err := useCase.Execute(context.Background(), AnalyzeUseCaseConfig{ConfigFile: configPath}, []string{dir})
+	require.NoError(t, err)
+
+	assert.True(t, response.Summary.DocumentationEnabled)
+	require.NotNil(t, response.Documentation)
+	assert.Equal(t, domain.DocumentationConventionPublic, response.Documentation.Convention)
+	require.NotNil(t, response.Summary.DocumentationScore)
+	assert.Equal(t, 2, *response.Summary.DocumentedItems)
+	assert.Equal(t, 2, *response.Summary.UndocumentedItems)
+	assert.Equal(t, 50, *response.Summary.DocumentationScore)
+	assert.Len(t, response.Documentation.Findings, 2)
+}
+
+func TestAnalyzeUseCase_DocumentationSkippedByDefault(t *testing.T) {
+	dir, configPath := writeTestProject(t, "[complexity]\nenabled = true\n", map[string]string{"orders.py": documentationSource})
+	useCase := newTestAnalyzeUseCase(t)
+
+	response, err := useCase.Execute(context.Background(), AnalyzeUseCaseConfig{ConfigFile: configPath}, []string{dir})
+	require.NoError(t, err)
+
+	assert.False(t, response.Summary.DocumentationEnabled)
+	assert.Nil(t, response.Documentation)
+	assert.Nil(t, response.Summary.DocumentationScore)
+	assert.Nil(t, response.Summary.DocstringCoverage)
+}
+
+func TestAnalyzeUseCase_DocumentationSelected(t *testing.T) {
+	dir, configPath := writeTestProject(t, "[documentation]\nconvention = \"all\"\n", map[string]string{"orders.py": documentationSource})
+	useCase := newTestAnalyzeUseCase(t)
+
+	config := ApplyAnalyzeSelection(AnalyzeUseCaseConfig{ConfigFile: configPath}, []string{"documentation"})
+	response, err := useCase.Execute(context.Background(), config, []string{dir})
+	require.NoError(t, err)
+
+	require.NotNil(t, response.Documentation)
+	assert.Equal(t, domain.DocumentationConventionAll, response.Documentation.Convention)
+	assert.False(t, response.Summary.ComplexityEnabled)
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/service"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestAnalyzeUseCase builds an analyze use case with the opt-in
+// documentation and typing analyses wired in
+func newTestAnalyzeUseCase(t *testing.T) *AnalyzeUseCase {
+	t.Helper()
+	documentationUC, err := NewDocumentationUseCaseBuilder().
+		WithService(service.NewDocumentationService()).
+		WithFileReader(service.NewFileReader()).
+		WithConfigLoader(service.NewDocumentationConfigurationLoader()).
+		Build()
+	require.NoError(t, err)
+
+	typeCoverageUC, err := NewTypeCoverageUseCaseBuilder().
+		WithService(service.NewTypeCoverageService()).
+		WithFileReader(service.NewFileReader()).
+		WithConfigLoader(service.NewTypeCoverageConfigurationLoader()).
+		Build()
+	require.NoError(t, err)
+
+	useCase, err := NewAnalyzeUseCaseBuilder().
+		WithFileReader(service.NewFileReader()).
+		WithFormatter(service.NewAnalyzeFormatter()).
+		WithConfigLoader(service.NewAnalyzeConfigurationLoader()).
+		WithDocumentationUseCase(documentationUC).
+		WithTypeCoverageUseCase(typeCoverageUC).
+		Build()
+	require.NoError(t, err)
+	return useCase
+}
+
+// newComplexityAnalyzeUseCaseBuilder returns an analyze use case builder
+// with the complexity analysis wired in, for tests to add further analyses to
+func newComplexityAnalyzeUseCaseBuilder() *AnalyzeUseCaseBuilder {
+	builder := NewAnalyzeUseCaseBuilder()
+	builder.WithFileReader(service.NewFileReader())
+	builder.WithFormatter(service.NewAnalyzeFormatter())
+	builder.WithParallelExecutor(service.NewParallelExecutor())
+	builder.WithErrorCategorizer(service.NewErrorCategorizer())
+	builder.WithComplexityUseCase(NewComplexityUseCase(
+		service.NewComplexityService(),
+		service.NewFileReader(),
+		service.NewOutputFormatter(),
+		service.NewConfigurationLoader(),
+	))
+	return builder
+}
+
+// newComplexityAnalyzeUseCase builds an analyze use case running only the
+// complexity analysis
+func newComplexityAnalyzeUseCase(t *testing.T) *AnalyzeUseCase {
+	t.Helper()
+	useCase, err := newComplexityAnalyzeUseCaseBuilder().Build()
+	require.NoError(t, err)
+	return useCase
+}
+
+// writeTestProject writes files, keyed by slash-separated relative path, and
+// a .pyscn.toml holding config into a temporary directory. It returns the
+// directory and the config path.
+func writeTestProject(t *testing.T, config string, files map[string]string) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	configPath := filepath.Join(dir, ".pyscn.toml")
+	require.NoError(t, os.WriteFile(configPath, []byte(config), 0644))
+	return dir, configPath
+}
@@ -0,0 +1,106 @@
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scopedProjectFiles holds one source file and one test file, which the
+// default exclusions skip
+var scopedProjectFiles = map[string]string{
+	"orders.py": `def place(order: str, quantity: int) -> str:
+    return order
+`,
+	"tests/test_orders.py": `def test_place(client):
+    assert client
+`,
+}
+
+func TestAnalyzeUseCase_SectionPatternsWidenOneAnalysis(t *testing.T) {
+	dir, configPath := writeTestProject(t, `[documentation]
+enabled = true
+exclude_patterns = []
+
+[typing]
+enabled = true
+`, scopedProjectFiles)
+	useCase := newTestAnalyzeUseCase(t)
+
+	response, err := useCase.Execute(context.Background(), AnalyzeUseCaseConfig{ConfigFile: configPath}, []string{dir})
+	require.NoError(t, err)
+
+	// Documentation drops the default test exclusions
+	require.NotNil(t, response.Documentation)
+	assert.Equal(t, 2, response.Documentation.Summary.FilesAnalyzed)
+
+	// Typing keeps the [analysis] patterns and skips the tests
+	require.NotNil(t, response.TypeCoverage)
+	require.Len(t, response.TypeCoverage.Files, 1)
+	assert.Equal(t, "orders.py", filepath.Base(response.TypeCoverage.Files[0].FilePath))
+	require.NotNil(t, response.Summary.TypednessScore)
+	assert.Equal(t, 100, *response.Summary.TypednessScore)
+}
+
+func TestAnalyzeUseCase_SectionPatternsNarrowOneAnalysis(t *testing.T) {
+	dir, configPath := writeTestProject(t, `[analysis]
+exclude_patterns = ["**/build/**"]
+
+[documentation]
+enabled = true
+
+[typing]
+enabled = true
+exclude_patterns = ["**/tests/**"]
+`, scopedProjectFiles)
+	useCase := newTestAnalyzeUseCase(t)
+
+	response, err := useCase.Execute(context.Background(), AnalyzeUseCaseConfig{ConfigFile: configPath}, []string{dir})
+	require.NoError(t, err)
+
+	require.NotNil(t, response.Documentation)
+	assert.Equal(t, 2, response.Documentation.Summary.FilesAnalyzed)
+	require.NotNil(t, response.TypeCoverage)
+	assert.Len(t, response.TypeCoverage.Files, 1)
+}
+
+func TestAnalyzeUseCase_SectionPatternsMatchingNothing(t *testing.T) {
+	dir, configPath := writeTestProject(t, `[typing]
+enabled = true
+include_patterns = ["src/**/*.py"]
+`, scopedProjectFiles)
+	useCase := newTestAnalyzeUseCase(t)
+
+	_, err := useCase.Execute(context.Background(), AnalyzeUseCaseConfig{ConfigFile: configPath}, []string{dir})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "[typing]")
+}
+
+func TestAnalyzeUseCase_PlanListsFilesAndAnalyses(t *testing.T) {
+	dir, configPath := writeTestProject(t, `[documentation]
+enabled = true
+exclude_patterns = []
+`, scopedProjectFiles)
+	useCase := newTestAnalyzeUseCase(t)
+
+	plan, err := useCase.Plan(AnalyzeUseCaseConfig{ConfigFile: configPath}, []string{dir})
+	require.NoError(t, err)
+
+	assert.Equal(t, configPath, plan.ConfigPath)
+	require.Len(t, plan.Files, 1)
+	assert.Equal(t, "orders.py", filepath.Base(plan.Files[0]))
+
+	analyses := make(map[string]domain.PlannedAnalysis)
+	for _, analysis := range plan.Analyses {
+		analyses[analysis.Name] = analysis
+	}
+	require.Contains(t, analyses, "documentation")
+	assert.True(t, analyses["documentation"].Enabled)
+	assert.Equal(t, 2, analyses["documentation"].Files)
+	require.Contains(t, analyses, "typing")
+	assert.False(t, analyses["typing"].Enabled)
+}
@@ -2,8 +2,10 @@ package app
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/ludo-technologies/pyscn/domain"
@@ -590,3 +592,174 @@ func TestBuildComplexityTaskRequest_UsesExecutionConfigBooleans(t *testing.T) {
 		t.Error("ShowDetails: expected explicit true from execution config")
 	}
 }
+
+func TestAnalyzeUseCase_Execute_CancelledReturnsPartialResponse(t *testing.T) {
+	config := AnalyzeUseCaseConfig{
+		SkipDeadCode:  true,
+		SkipClones:    true,
+		SkipCBO:       true,
+		SkipLCOM:      true,
+		SkipSystem:    true,
+		MinComplexity: 1,
+	}
+
+	useCase := newComplexityAnalyzeUseCase(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	response, err := useCase.Execute(ctx, config, []string{"../testdata/python/simple"})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected a cancellation error, got %v", err)
+	}
+	if response == nil {
+		t.Fatal("Expected a partial response")
+	}
+	if !response.Cancelled {
+		t.Error("Expected the response to be flagged as cancelled")
+	}
+	if !response.Summary.ComplexityEnabled {
+		t.Error("Expected the interrupted analysis to be listed in the summary")
+	}
+}
+
+func TestAnalyzeUseCase_Execute_SummaryOnly(t *testing.T) {
+	useCase := newComplexityAnalyzeUseCase(t)
+
+	config := AnalyzeUseCaseConfig{
+		SkipDeadCode:  true,
+		SkipClones:    true,
+		SkipCBO:       true,
+		SkipLCOM:      true,
+		SkipSystem:    true,
+		MinComplexity: 1,
+	}
+	paths := []string{"../testdata/python/complex"}
+
+	full, err := useCase.Execute(context.Background(), config, paths)
+	if err != nil {
+		t.Fatalf("Full analysis failed: %v", err)
+	}
+
+	config.SummaryOnly = true
+	summary, err := useCase.Execute(context.Background(), config, paths)
+	if err != nil {
+		t.Fatalf("Summary-only analysis failed: %v", err)
+	}
+
+	if summary.Complexity != nil || summary.Packages != nil || summary.Suggestions != nil {
+		t.Error("Expected no per-finding detail in summary-only mode")
+	}
+	if summary.Manifest == nil {
+		t.Error("Expected the manifest to be kept")
+	}
+	if summary.Summary.HealthScore != full.Summary.HealthScore {
+		t.Errorf("Expected health score %d, got %d", full.Summary.HealthScore, summary.Summary.HealthScore)
+	}
+	if summary.Summary.TotalFunctions != full.Summary.TotalFunctions || summary.Summary.HighComplexityCount != full.Summary.HighComplexityCount {
+		t.Errorf("Expected the same complexity statistics, got %+v want %+v", summary.Summary, full.Summary)
+	}
+}
+
+func TestAnalyzeUseCase_Execute_Serial(t *testing.T) {
+	builder := newComplexityAnalyzeUseCaseBuilder()
+	builder.WithDeadCodeUseCase(NewDeadCodeUseCase(
+		service.NewDeadCodeService(),
+		service.NewFileReader(),
+		service.NewDeadCodeFormatter(),
+		service.NewDeadCodeConfigurationLoader(),
+	))
+	useCase, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Failed to build use case: %v", err)
+	}
+
+	config := AnalyzeUseCaseConfig{
+		SkipClones:    true,
+		SkipCBO:       true,
+		SkipLCOM:      true,
+		SkipSystem:    true,
+		MinComplexity: 1,
+		MinSeverity:   domain.DeadCodeSeverityInfo,
+	}
+	paths := []string{"../testdata/python/complex"}
+
+	concurrent, err := useCase.Execute(context.Background(), config, paths)
+	if err != nil {
+		t.Fatalf("Concurrent analysis failed: %v", err)
+	}
+
+	config.Serial = true
+	serial, err := useCase.Execute(context.Background(), config, paths)
+	if err != nil {
+		t.Fatalf("Serial analysis failed: %v", err)
+	}
+
+	if serial.Complexity == nil || serial.DeadCode == nil {
+		t.Fatal("Expected complexity and dead code results in serial mode")
+	}
+	if serial.Summary.HealthScore != concurrent.Summary.HealthScore {
+		t.Errorf("Expected health score %d, got %d", concurrent.Summary.HealthScore, serial.Summary.HealthScore)
+	}
+	if serial.Summary.TotalFunctions != concurrent.Summary.TotalFunctions || serial.Summary.DeadCodeCount != concurrent.Summary.DeadCodeCount {
+		t.Errorf("Expected the same statistics, got %+v want %+v", serial.Summary, concurrent.Summary)
+	}
+}
+
+func TestAnalyzeUseCase_Execute_ExcludesVendoredCode(t *testing.T) {
+	dir, _ := writeTestProject(t, "", map[string]string{
+		"app/main.py":             "def run(x):\n    return x + 1\n",
+		"vendor/six.py":           "def shim(x):\n    if x:\n        return 1\n    return 2\n",
+		"app/_compat/__init__.py": "# vendored from backports 1.0\n",
+		"app/_compat/shim.py":     "def shim(x):\n    return x\n",
+	})
+
+	useCase := newComplexityAnalyzeUseCase(t)
+
+	config := AnalyzeUseCaseConfig{
+		SkipDeadCode:  true,
+		SkipClones:    true,
+		SkipCBO:       true,
+		SkipLCOM:      true,
+		SkipSystem:    true,
+		MinComplexity: 1,
+	}
+
+	response, err := useCase.Execute(context.Background(), config, []string{dir})
+	if err != nil {
+		t.Fatalf("Analysis failed: %v", err)
+	}
+	for _, fn := range response.Complexity.Functions {
+		if fn.Name == "shim" {
+			t.Errorf("Expected vendored function %s to be left out", fn.FilePath)
+		}
+	}
+	want := []domain.VendoredCode{
+		{Path: "app/_compat", Reason: domain.VendoredReasonMarker, Files: 2},
+		{Path: "vendor", Reason: domain.VendoredReasonLayout, Files: 1},
+	}
+	if !reflect.DeepEqual(response.Vendored, want) {
+		t.Errorf("Expected vendored code %+v, got %+v", want, response.Vendored)
+	}
+	if response.Summary.VendoredFiles != 3 {
+		t.Errorf("Expected 3 vendored files, got %d", response.Summary.VendoredFiles)
+	}
+
+	config.IncludeVendored = true
+	response, err = useCase.Execute(context.Background(), config, []string{dir})
+	if err != nil {
+		t.Fatalf("Analysis with vendored code failed: %v", err)
+	}
+	if response.Vendored != nil || response.Summary.VendoredFiles != 0 {
+		t.Errorf("Expected no vendored appendix, got %+v", response.Vendored)
+	}
+	shims := 0
+	for _, fn := range response.Complexity.Functions {
+		if fn.Name == "shim" {
+			shims++
+		}
+	}
+	if shims != 2 {
+		t.Errorf("Expected both vendored functions to be analyzed, got %d", shims)
+	}
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const typingSource = `class Order:
+    def total(self, discount: float) -> float:
+        return 0.0
+
+
+def place(order, quantity):
+    return order
+`
+
+func TestAnalyzeUseCase_TypingEnabledFromConfig(t *testing.T) {
+	dir, configPath := writeTestProject(t, "[typing]\nenabled = true\n", map[string]string{"orders.py": typingSource})
+	useCase := newTestAnalyzeUseCase(t)
+
+	response, err := useCase.Execute(context.Background(), AnalyzeUseCaseConfig{ConfigFile: configPath}, []string{dir})
+	require.NoError(t, err)
+
+	assert.True(t, response.Summary.TypingEnabled)
+	require.NotNil(t, response.TypeCoverage)
+	// total is fully annotated (2/2), place has nothing (0/3)
+	assert.Equal(t, 2, response.TypeCoverage.Summary.Functions)
+	assert.Equal(t, 1, response.TypeCoverage.Summary.FullyAnnotatedFunctions)
+	require.NotNil(t, response.Summary.TypednessScore)
+	assert.Equal(t, 40, *response.Summary.TypednessScore)
+	require.NotNil(t, response.Summary.UnannotatedPublicFunctions)
+	assert.Equal(t, 1, *response.Summary.UnannotatedPublicFunctions)
+	require.Len(t, response.TypeCoverage.Findings, 1)
+	assert.Equal(t, "place", response.TypeCoverage.Findings[0].Name)
+}
+
+func TestAnalyzeUseCase_TypingSkippedByDefault(t *testing.T) {
+	dir, configPath := writeTestProject(t, "[complexity]\nenabled = true\n", map[string]string{"orders.py": typingSource})
+	useCase := newTestAnalyzeUseCase(t)
+
+	response, err := useCase.Execute(context.Background(), AnalyzeUseCaseConfig{ConfigFile: configPath}, []string{dir})
+	require.NoError(t, err)
+
+	assert.False(t, response.Summary.TypingEnabled)
+	assert.Nil(t, response.TypeCoverage)
+	assert.Nil(t, response.Summary.TypednessScore)
+	assert.Nil(t, response.Summary.TypeAnnotationCoverage)
+}
+
+func TestAnalyzeUseCase_TypingSelected(t *testing.T) {
+	dir, configPath := writeTestProject(t, "", map[string]string{"orders.py": typingSource})
+	useCase := newTestAnalyzeUseCase(t)
+
+	config := ApplyAnalyzeSelection(AnalyzeUseCaseConfig{ConfigFile: configPath}, []string{"typing"})
+	response, err := useCase.Execute(context.Background(), config, []string{dir})
+	require.NoError(t, err)
+
+	require.NotNil(t, response.TypeCoverage)
+	assert.Len(t, response.TypeCoverage.Files, 1)
+	assert.False(t, response.Summary.ComplexityEnabled)
+}
@@ -0,0 +1,165 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/service"
+)
+
+// executeWorkspace analyzes each target as an independent project and
+// combines the per-target summaries into a workspace overview.
+func (uc *AnalyzeUseCase) executeWorkspace(ctx context.Context, useCaseCfg AnalyzeUseCaseConfig, paths []string, overrides AnalyzeRequestOverrides) (*domain.AnalyzeResponse, error) {
+	startTime := time.Now()
+	targetCfg := useCaseCfg
+	targetCfg.Workspace = false
+
+	workspace := &domain.WorkspaceReport{
+		Targets: make([]domain.WorkspaceTarget, 0, len(paths)),
+	}
+	var failed []error
+	for _, path := range paths {
+		if ctx.Err() != nil {
+			break
+		}
+
+		result, err := uc.execute(ctx, targetCfg, []string{path}, overrides)
+		target := domain.WorkspaceTarget{Path: path, Result: result}
+		if err != nil {
+			target.Error = err.Error()
+			failed = append(failed, fmt.Errorf("%s: %w", path, err))
+		}
+		workspace.Targets = append(workspace.Targets, target)
+	}
+
+	response := &domain.AnalyzeResponse{
+		Workspace:   workspace,
+		GeneratedAt: time.Now(),
+		Duration:    time.Since(startTime).Milliseconds(),
+	}
+	response.Summary = combineWorkspaceSummaries(workspace.Targets)
+	response.Manifest = workspaceManifest(paths, workspace.Targets, response.Summary)
+
+	if err := ctx.Err(); err != nil {
+		response.Cancelled = true
+		return response, domain.NewCancelledError("workspace analysis cancelled; results are partial", err)
+	}
+	if len(failed) > 0 {
+		return response, fmt.Errorf("workspace analysis completed with %d failed target(s): %w", len(failed), failed[0])
+	}
+	return response, nil
+}
+
+// workspaceManifest combines the target manifests. Every target has its
+// own configuration and git checkout, so those are left to the per-target
+// manifests.
+func workspaceManifest(paths []string, targets []domain.WorkspaceTarget, summary domain.AnalyzeSummary) *domain.AnalysisManifest {
+	manifest := service.BuildAnalysisManifest(nil, "", 0, summary)
+	manifest.Targets = append([]string(nil), paths...)
+	for _, target := range targets {
+		if target.Result != nil && target.Result.Manifest != nil {
+			manifest.FileCount += target.Result.Manifest.FileCount
+		}
+	}
+	return manifest
+}
+
+// combineWorkspaceSummaries merges per-target summaries into one overview.
+// Counts are summed and averages are weighted by the population they were
+// computed over, so large targets weigh more than small ones. Community
+// metrics are per-graph and are not combined.
+func combineWorkspaceSummaries(targets []domain.WorkspaceTarget) domain.AnalyzeSummary {
+	var combined domain.AnalyzeSummary
+	var complexityWeighted, cognitiveWeighted, nestingWeighted float64
+	var duplicationWeighted, couplingWeighted, lcomWeighted float64
+	var deviationWeighted, complianceWeighted float64
+	archModules := 0
+
+	for _, target := range targets {
+		if target.Result == nil {
+			continue
+		}
+		s := target.Result.Summary
+
+		combined.ComplexityEnabled = combined.ComplexityEnabled || s.ComplexityEnabled
+		combined.DeadCodeEnabled = combined.DeadCodeEnabled || s.DeadCodeEnabled
+		combined.CloneEnabled = combined.CloneEnabled || s.CloneEnabled
+		combined.CBOEnabled = combined.CBOEnabled || s.CBOEnabled
+		combined.LCOMEnabled = combined.LCOMEnabled || s.LCOMEnabled
+		combined.DepsEnabled = combined.DepsEnabled || s.DepsEnabled
+		combined.ArchEnabled = combined.ArchEnabled || s.ArchEnabled
+		combined.BlameEnabled = combined.BlameEnabled || s.BlameEnabled
+
+		combined.TotalFiles += s.TotalFiles
+		combined.AnalyzedFiles += s.AnalyzedFiles
+		combined.SkippedFiles += s.SkippedFiles
+		combined.VendoredFiles += s.VendoredFiles
+
+		combined.TotalFunctions += s.TotalFunctions
+		combined.FunctionsParsed += s.FunctionsParsed
+		combined.HighComplexityCount += s.HighComplexityCount
+		complexityWeighted += s.AverageComplexity * float64(s.TotalFunctions)
+		cognitiveWeighted += s.AverageCognitiveComplexity * float64(s.TotalFunctions)
+		nestingWeighted += s.AverageNestingDepth * float64(s.TotalFunctions)
+
+		combined.DeadCodeCount += s.DeadCodeCount
+		combined.CriticalDeadCode += s.CriticalDeadCode
+		combined.WarningDeadCode += s.WarningDeadCode
+		combined.InfoDeadCode += s.InfoDeadCode
+
+		combined.TotalClones += s.TotalClones
+		combined.ClonePairs += s.ClonePairs
+		combined.CloneGroups += s.CloneGroups
+		duplicationWeighted += s.CodeDuplication * float64(s.TotalFiles)
+
+		combined.CBOClasses += s.CBOClasses
+		combined.HighCouplingClasses += s.HighCouplingClasses
+		combined.MediumCouplingClasses += s.MediumCouplingClasses
+		couplingWeighted += s.AverageCoupling * float64(s.CBOClasses)
+
+		combined.LCOMClasses += s.LCOMClasses
+		combined.HighLCOMClasses += s.HighLCOMClasses
+		combined.MediumLCOMClasses += s.MediumLCOMClasses
+		lcomWeighted += s.AverageLCOM * float64(s.LCOMClasses)
+
+		combined.DepsTotalModules += s.DepsTotalModules
+		combined.DepsModulesInCycles += s.DepsModulesInCycles
+		combined.DepsMaxDepth = max(combined.DepsMaxDepth, s.DepsMaxDepth)
+		deviationWeighted += s.DepsMainSequenceDeviation * float64(s.DepsTotalModules)
+		if s.ArchEnabled {
+			complianceWeighted += s.ArchCompliance * float64(s.DepsTotalModules)
+			archModules += s.DepsTotalModules
+		}
+	}
+
+	if combined.TotalFunctions > 0 {
+		combined.AverageComplexity = complexityWeighted / float64(combined.TotalFunctions)
+		combined.AverageCognitiveComplexity = cognitiveWeighted / float64(combined.TotalFunctions)
+		combined.AverageNestingDepth = nestingWeighted / float64(combined.TotalFunctions)
+	}
+	if combined.TotalFiles > 0 {
+		combined.CodeDuplication = duplicationWeighted / float64(combined.TotalFiles)
+	}
+	if combined.CBOClasses > 0 {
+		combined.AverageCoupling = couplingWeighted / float64(combined.CBOClasses)
+	}
+	if combined.LCOMClasses > 0 {
+		combined.AverageLCOM = lcomWeighted / float64(combined.LCOMClasses)
+	}
+	if combined.DepsTotalModules > 0 {
+		combined.DepsMainSequenceDeviation = deviationWeighted / float64(combined.DepsTotalModules)
+	}
+	if archModules > 0 {
+		combined.ArchCompliance = complianceWeighted / float64(archModules)
+	}
+
+	if err := combined.CalculateHealthScore(); err != nil {
+		log.Printf("WARNING: Failed to calculate workspace health score: %v", err)
+		combined.HealthScore = combined.CalculateFallbackScore()
+		combined.Grade = domain.GetGradeFromScore(combined.HealthScore)
+	}
+	return combined
+}
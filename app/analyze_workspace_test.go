@@ -0,0 +1,89 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeUseCase_Execute_WorkspaceAnalyzesTargetsIndependently(t *testing.T) {
+	config := AnalyzeUseCaseConfig{
+		SkipDeadCode:  true,
+		SkipClones:    true,
+		SkipCBO:       true,
+		SkipLCOM:      true,
+		SkipSystem:    true,
+		MinComplexity: 1,
+		Workspace:     true,
+	}
+
+	builder := NewAnalyzeUseCaseBuilder()
+	builder.WithFileReader(service.NewFileReader())
+	builder.WithFormatter(service.NewAnalyzeFormatter())
+	builder.WithParallelExecutor(service.NewParallelExecutor())
+	builder.WithErrorCategorizer(service.NewErrorCategorizer())
+	builder.WithComplexityUseCase(NewComplexityUseCase(
+		service.NewComplexityService(),
+		service.NewFileReader(),
+		service.NewOutputFormatter(),
+		service.NewConfigurationLoader(),
+	))
+	useCase, err := builder.Build()
+	require.NoError(t, err)
+
+	targets := []string{"../testdata/python/simple", "../testdata/python/complex"}
+	response, err := useCase.Execute(context.Background(), config, targets)
+	require.NoError(t, err)
+	require.NotNil(t, response.Workspace)
+	require.Len(t, response.Workspace.Targets, 2)
+
+	totalFiles := 0
+	for i, target := range response.Workspace.Targets {
+		assert.Equal(t, targets[i], target.Path)
+		require.NotNil(t, target.Result)
+		assert.Nil(t, target.Result.Workspace)
+		totalFiles += target.Result.Summary.TotalFiles
+	}
+	assert.Equal(t, totalFiles, response.Summary.TotalFiles)
+	assert.True(t, response.Summary.ComplexityEnabled)
+	assert.NotEmpty(t, response.Summary.Grade)
+}
+
+func TestCombineWorkspaceSummaries_WeightsAverages(t *testing.T) {
+	targets := []domain.WorkspaceTarget{
+		{Path: "a", Result: &domain.AnalyzeResponse{Summary: domain.AnalyzeSummary{
+			ComplexityEnabled: true,
+			TotalFiles:        2,
+			TotalFunctions:    10,
+			AverageComplexity: 2,
+			DepsEnabled:       true,
+			DepsTotalModules:  2,
+			DepsMaxDepth:      3,
+		}}},
+		{Path: "b", Error: "boom"},
+		{Path: "c", Result: &domain.AnalyzeResponse{Summary: domain.AnalyzeSummary{
+			ComplexityEnabled:   true,
+			TotalFiles:          3,
+			TotalFunctions:      30,
+			AverageComplexity:   6,
+			HighComplexityCount: 4,
+			DepsEnabled:         true,
+			DepsTotalModules:    3,
+			DepsMaxDepth:        5,
+		}}},
+	}
+
+	combined := combineWorkspaceSummaries(targets)
+
+	assert.Equal(t, 5, combined.TotalFiles)
+	assert.Equal(t, 40, combined.TotalFunctions)
+	assert.Equal(t, 4, combined.HighComplexityCount)
+	assert.InDelta(t, 5.0, combined.AverageComplexity, 0.001)
+	assert.Equal(t, 5, combined.DepsMaxDepth)
+	assert.Equal(t, 5, combined.DepsTotalModules)
+	assert.NotEmpty(t, combined.Grade)
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// enrichWithBlame attaches git blame metadata to high-risk complexity findings
+// and to every dead code finding. It stops at the first provider error, since
+// an error here means git itself is unusable rather than a single file failing.
+func enrichWithBlame(response *domain.AnalyzeResponse, provider domain.BlameProvider) error {
+	if response == nil || provider == nil {
+		return nil
+	}
+
+	if response.Complexity != nil {
+		for i := range response.Complexity.Functions {
+			fn := &response.Complexity.Functions[i]
+			if fn.RiskLevel != domain.RiskLevelHigh {
+				continue
+			}
+			info, err := provider.Blame(fn.FilePath, fn.StartLine, fn.EndLine)
+			if err != nil {
+				return err
+			}
+			fn.Blame = info
+		}
+	}
+
+	if response.DeadCode != nil {
+		for i := range response.DeadCode.Files {
+			file := &response.DeadCode.Files[i]
+			for j := range file.Functions {
+				fn := &file.Functions[j]
+				for k := range fn.Findings {
+					finding := &fn.Findings[k]
+					info, err := provider.Blame(finding.Location.FilePath, finding.Location.StartLine, finding.Location.EndLine)
+					if err != nil {
+						return err
+					}
+					finding.Blame = info
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubBlameProvider struct {
+	calls []string
+	err   error
+}
+
+func (s *stubBlameProvider) Blame(filePath string, startLine, endLine int) (*domain.BlameInfo, error) {
+	s.calls = append(s.calls, filePath)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &domain.BlameInfo{LastAuthor: "alice", AgeDays: startLine}, nil
+}
+
+func blameTestResponse() *domain.AnalyzeResponse {
+	return &domain.AnalyzeResponse{
+		Complexity: &domain.ComplexityResponse{
+			Functions: []domain.FunctionComplexity{
+				{Name: "simple", FilePath: "a.py", StartLine: 1, EndLine: 3, RiskLevel: domain.RiskLevelLow},
+				{Name: "tangled", FilePath: "a.py", StartLine: 10, EndLine: 60, RiskLevel: domain.RiskLevelHigh},
+			},
+		},
+		DeadCode: &domain.DeadCodeResponse{
+			Files: []domain.FileDeadCode{{
+				FilePath: "b.py",
+				Functions: []domain.FunctionDeadCode{{
+					Name:     "f",
+					FilePath: "b.py",
+					Findings: []domain.DeadCodeFinding{{
+						Location: domain.DeadCodeLocation{FilePath: "b.py", StartLine: 7, EndLine: 8},
+					}},
+				}},
+			}},
+		},
+	}
+}
+
+func TestEnrichWithBlame_AnnotatesHighRiskAndDeadCode(t *testing.T) {
+	response := blameTestResponse()
+	provider := &stubBlameProvider{}
+
+	require.NoError(t, enrichWithBlame(response, provider))
+
+	assert.Equal(t, []string{"a.py", "b.py"}, provider.calls)
+	assert.Nil(t, response.Complexity.Functions[0].Blame)
+	require.NotNil(t, response.Complexity.Functions[1].Blame)
+	assert.Equal(t, 10, response.Complexity.Functions[1].Blame.AgeDays)
+
+	finding := response.DeadCode.Files[0].Functions[0].Findings[0]
+	require.NotNil(t, finding.Blame)
+	assert.Equal(t, "alice", finding.Blame.LastAuthor)
+}
+
+func TestEnrichWithBlame_StopsOnProviderError(t *testing.T) {
+	response := blameTestResponse()
+	provider := &stubBlameProvider{err: errors.New("git not found")}
+
+	err := enrichWithBlame(response, provider)
+
+	assert.Error(t, err)
+	assert.Len(t, provider.calls, 1)
+}
@@ -167,7 +167,7 @@ func createMockCloneResponse() *domain.CloneResponse {
 		},
 		ClonePairs: []*domain.ClonePair{
 			{
-				ID: 1,
+				ID: "1",
 				Clone1: &domain.Clone{
 					ID:   1,
 					Type: domain.Type2Clone,
@@ -198,7 +198,7 @@ func createMockCloneResponse() *domain.CloneResponse {
 		},
 		CloneGroups: []*domain.CloneGroup{
 			{
-				ID: 1,
+				ID: "1",
 				Clones: []*domain.Clone{
 					{
 						ID:   1,
@@ -0,0 +1,18 @@
+package app
+
+import (
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/service"
+)
+
+// recordComplexityHistory annotates the complexity findings with their trend
+// over the runs stored in path, then stores this run, keeping the last
+// maxRuns
+func recordComplexityHistory(response *domain.AnalyzeResponse, path string, maxRuns int, root string) error {
+	history, err := service.LoadComplexityHistory(path)
+	if err != nil {
+		return err
+	}
+	service.RecordComplexityHistory(history, response.Complexity.Functions, root, response.GeneratedAt, maxRuns)
+	return service.SaveComplexityHistory(path, history)
+}
@@ -290,6 +290,9 @@ func (uc *DeadCodeUseCase) validateRequest(req domain.DeadCodeRequest) error {
 	default:
 		return fmt.Errorf("unsupported minimum severity: %s", req.MinSeverity)
 	}
+	if err := domain.ValidateDeadCodeReasonSeverities(req.ReasonSeverities); err != nil {
+		return err
+	}
 
 	// Validate output format
 	switch req.OutputFormat {
@@ -152,8 +152,13 @@ func createMockDeadCodeResponse() *domain.DeadCodeResponse {
 								Reason:       "Code after return statement is unreachable",
 								Severity:     domain.DeadCodeSeverityWarning,
 								Description:  "Remove unreachable code after return statement",
-								Context:      []string{"def test_function():", "    return True", "    print('unreachable')  # Dead code"},
-								BlockID:      "block_1",
+								Context: &domain.DeadCodeContext{
+									StartLine: 13,
+									Before:    []string{"def test_function():", "    return True"},
+									Lines:     []string{"    print('unreachable')  # Dead code"},
+									After:     []string{},
+								},
+								BlockID: "block_1",
 							},
 							{
 								Location: domain.DeadCodeLocation{
@@ -168,8 +173,13 @@ func createMockDeadCodeResponse() *domain.DeadCodeResponse {
 								Reason:       "This branch is never reached",
 								Severity:     domain.DeadCodeSeverityInfo,
 								Description:  "Remove or fix condition that makes this branch unreachable",
-								Context:      []string{"if False:", "    print('never executed')  # Dead code"},
-								BlockID:      "block_2",
+								Context: &domain.DeadCodeContext{
+									StartLine: 17,
+									Before:    []string{"if False:"},
+									Lines:     []string{"    print('never executed')  # Dead code"},
+									After:     []string{},
+								},
+								BlockID: "block_2",
 							},
 						},
 					},
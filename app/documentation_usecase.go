@@ -0,0 +1,131 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// DocumentationUseCase orchestrates docstring coverage analysis
+type DocumentationUseCase struct {
+	service      domain.DocumentationService
+	fileReader   domain.FileReader
+	configLoader domain.DocumentationConfigurationLoader
+}
+
+// NewDocumentationUseCase creates a new documentation use case
+func NewDocumentationUseCase(
+	service domain.DocumentationService,
+	fileReader domain.FileReader,
+	configLoader domain.DocumentationConfigurationLoader,
+) *DocumentationUseCase {
+	return &DocumentationUseCase{
+		service:      service,
+		fileReader:   fileReader,
+		configLoader: configLoader,
+	}
+}
+
+// AnalyzeAndReturn measures docstring coverage and returns the response without formatting
+func (uc *DocumentationUseCase) AnalyzeAndReturn(ctx context.Context, req domain.DocumentationRequest) (*domain.DocumentationResponse, error) {
+	finalReq, err := uc.loadAndMergeConfig(req)
+	if err != nil {
+		return nil, domain.NewConfigError("failed to load configuration", err)
+	}
+
+	if err := finalReq.Validate(); err != nil {
+		return nil, err
+	}
+
+	files, err := ResolveFilePaths(
+		uc.fileReader,
+		finalReq.Paths,
+		domain.BoolValue(finalReq.Recursive, true),
+		finalReq.IncludePatterns,
+		finalReq.ExcludePatterns,
+		false,
+	)
+	if err != nil {
+		return nil, domain.NewFileNotFoundError("failed to collect files", err)
+	}
+	if len(files) == 0 {
+		return nil, domain.NewInvalidInputError("no Python files found in the specified paths", nil)
+	}
+	finalReq.Paths = files
+
+	response, err := uc.service.Analyze(ctx, finalReq)
+	if err != nil {
+		return nil, domain.NewAnalysisError("documentation analysis failed", err)
+	}
+
+	return response, nil
+}
+
+// loadAndMergeConfig loads configuration and merges it with the request
+func (uc *DocumentationUseCase) loadAndMergeConfig(req domain.DocumentationRequest) (domain.DocumentationRequest, error) {
+	if uc.configLoader == nil {
+		return req, nil
+	}
+
+	var configReq *domain.DocumentationRequest
+	var err error
+
+	if req.ConfigPath != "" {
+		configReq, err = uc.configLoader.LoadConfig(req.ConfigPath)
+		if err != nil {
+			return req, fmt.Errorf("failed to load config from %s: %w", req.ConfigPath, err)
+		}
+	} else {
+		configReq = uc.configLoader.LoadDefaultConfig()
+	}
+
+	if configReq != nil {
+		merged := uc.configLoader.MergeConfig(configReq, &req)
+		return *merged, nil
+	}
+
+	return req, nil
+}
+
+// DocumentationUseCaseBuilder provides a builder pattern for creating DocumentationUseCase
+type DocumentationUseCaseBuilder struct {
+	service      domain.DocumentationService
+	fileReader   domain.FileReader
+	configLoader domain.DocumentationConfigurationLoader
+}
+
+// NewDocumentationUseCaseBuilder creates a new builder
+func NewDocumentationUseCaseBuilder() *DocumentationUseCaseBuilder {
+	return &DocumentationUseCaseBuilder{}
+}
+
+// WithService sets the documentation service
+func (b *DocumentationUseCaseBuilder) WithService(service domain.DocumentationService) *DocumentationUseCaseBuilder {
+	b.service = service
+	return b
+}
+
+// WithFileReader sets the file reader
+func (b *DocumentationUseCaseBuilder) WithFileReader(fileReader domain.FileReader) *DocumentationUseCaseBuilder {
+	b.fileReader = fileReader
+	return b
+}
+
+// WithConfigLoader sets the configuration loader
+func (b *DocumentationUseCaseBuilder) WithConfigLoader(configLoader domain.DocumentationConfigurationLoader) *DocumentationUseCaseBuilder {
+	b.configLoader = configLoader
+	return b
+}
+
+// Build creates the DocumentationUseCase with the configured dependencies
+func (b *DocumentationUseCaseBuilder) Build() (*DocumentationUseCase, error) {
+	if b.service == nil {
+		return nil, fmt.Errorf("documentation service is required")
+	}
+	if b.fileReader == nil {
+		return nil, fmt.Errorf("file reader is required")
+	}
+
+	return NewDocumentationUseCase(b.service, b.fileReader, b.configLoader), nil
+}
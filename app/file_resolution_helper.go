@@ -1,11 +1,8 @@
 package app
 
 import (
-	"path/filepath"
-	"strings"
-
-	"github.com/bmatcuk/doublestar/v4"
 	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/service"
 )
 
 // ResolveFilePaths resolves file paths for analysis.
@@ -81,16 +78,10 @@ func ResolveFilePaths(
 }
 
 func matchesExcludePattern(path string, excludePatterns []string) bool {
-	normalized := strings.ReplaceAll(filepath.ToSlash(path), "\\", "/")
 	for _, pattern := range excludePatterns {
-		if matched, _ := doublestar.Match(pattern, normalized); matched {
+		if service.MatchPathPattern(pattern, path, true) {
 			return true
 		}
-		if !strings.ContainsAny(pattern, "/\\") {
-			if matched, _ := doublestar.Match(pattern, filepath.Base(normalized)); matched {
-				return true
-			}
-		}
 	}
 	return false
 }
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// buildHotspotReport ranks files by total complexity times the number of
+// commits touching them in the last windowDays days. Files without churn or
+// without complexity results are left out. It returns nil when the files are
+// not in a git work tree.
+func buildHotspotReport(response *domain.AnalyzeResponse, files []string, provider domain.ChurnProvider, windowDays int, now time.Time) (*domain.HotspotReport, error) {
+	if response == nil || response.Complexity == nil || provider == nil {
+		return nil, nil
+	}
+	if windowDays <= 0 {
+		windowDays = domain.DefaultChurnWindowDays
+	}
+	since := now.AddDate(0, 0, -windowDays).UTC().Truncate(time.Second)
+
+	counts, err := provider.CommitCounts(files, since)
+	if err != nil || counts == nil {
+		return nil, err
+	}
+	// Complexity results may spell paths differently from the file list
+	commits := make(map[string]int, len(counts))
+	for path, n := range counts {
+		commits[hotspotPathKey(path)] = n
+	}
+
+	byFile := make(map[string]*domain.FileHotspot)
+	var order []string
+	for _, fn := range response.Complexity.Functions {
+		n := commits[hotspotPathKey(fn.FilePath)]
+		if n == 0 {
+			continue
+		}
+		hotspot, ok := byFile[fn.FilePath]
+		if !ok {
+			hotspot = &domain.FileHotspot{FilePath: fn.FilePath, Commits: n}
+			byFile[fn.FilePath] = hotspot
+			order = append(order, fn.FilePath)
+		}
+		hotspot.Functions++
+		hotspot.TotalComplexity += fn.Metrics.Complexity
+		if fn.Metrics.Complexity > hotspot.MaxComplexity {
+			hotspot.MaxComplexity = fn.Metrics.Complexity
+			hotspot.MostComplexFunction = fn.Name
+		}
+	}
+
+	report := &domain.HotspotReport{
+		WindowDays: windowDays,
+		Since:      since,
+		Files:      make([]domain.FileHotspot, 0, len(order)),
+	}
+	topScore := 0
+	for _, path := range order {
+		hotspot := byFile[path]
+		hotspot.Score = hotspot.TotalComplexity * hotspot.Commits
+		if hotspot.Score == 0 {
+			continue
+		}
+		topScore = max(topScore, hotspot.Score)
+		report.Files = append(report.Files, *hotspot)
+	}
+	for i := range report.Files {
+		report.Files[i].RelativeScore = float64(report.Files[i].Score) / float64(topScore)
+	}
+
+	sort.SliceStable(report.Files, func(i, j int) bool {
+		if report.Files[i].Score != report.Files[j].Score {
+			return report.Files[i].Score > report.Files[j].Score
+		}
+		return report.Files[i].FilePath < report.Files[j].FilePath
+	})
+	return report, nil
+}
+
+func hotspotPathKey(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubChurnProvider struct {
+	counts map[string]int
+	since  time.Time
+	err    error
+}
+
+func (s *stubChurnProvider) CommitCounts(filePaths []string, since time.Time) (map[string]int, error) {
+	s.since = since
+	return s.counts, s.err
+}
+
+func hotspotTestResponse() *domain.AnalyzeResponse {
+	function := func(name, path string, complexity int) domain.FunctionComplexity {
+		return domain.FunctionComplexity{Name: name, FilePath: path, Metrics: domain.ComplexityMetrics{Complexity: complexity}}
+	}
+	return &domain.AnalyzeResponse{
+		Complexity: &domain.ComplexityResponse{
+			Functions: []domain.FunctionComplexity{
+				function("parse", "parser.py", 20),
+				function("helper", "parser.py", 4),
+				function("render", "view.py", 30),
+				function("stable", "legacy.py", 50),
+			},
+		},
+	}
+}
+
+func TestBuildHotspotReport_RanksComplexityTimesChurn(t *testing.T) {
+	provider := &stubChurnProvider{counts: map[string]int{"parser.py": 10, "view.py": 2}}
+	now := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	report, err := buildHotspotReport(hotspotTestResponse(), []string{"parser.py", "view.py", "legacy.py"}, provider, 30, now)
+	require.NoError(t, err)
+	require.NotNil(t, report)
+
+	assert.Equal(t, 30, report.WindowDays)
+	assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), provider.since)
+	require.Len(t, report.Files, 2, "files without churn are left out")
+
+	top := report.Files[0]
+	assert.Equal(t, "parser.py", top.FilePath)
+	assert.Equal(t, 24, top.TotalComplexity)
+	assert.Equal(t, 240, top.Score)
+	assert.Equal(t, "parse", top.MostComplexFunction)
+	assert.Equal(t, 2, top.Functions)
+	assert.Equal(t, 1.0, top.RelativeScore)
+
+	assert.Equal(t, "view.py", report.Files[1].FilePath)
+	assert.Equal(t, 60, report.Files[1].Score)
+	assert.InDelta(t, 0.25, report.Files[1].RelativeScore, 1e-9)
+}
+
+func TestBuildHotspotReport_DefaultWindow(t *testing.T) {
+	report, err := buildHotspotReport(hotspotTestResponse(), nil, &stubChurnProvider{counts: map[string]int{}}, 0, time.Now())
+	require.NoError(t, err)
+	require.NotNil(t, report)
+	assert.Equal(t, domain.DefaultChurnWindowDays, report.WindowDays)
+	assert.Empty(t, report.Files)
+}
+
+func TestBuildHotspotReport_NoRepositoryOrError(t *testing.T) {
+	report, err := buildHotspotReport(hotspotTestResponse(), nil, &stubChurnProvider{}, 30, time.Now())
+	assert.NoError(t, err)
+	assert.Nil(t, report)
+
+	report, err = buildHotspotReport(hotspotTestResponse(), nil, &stubChurnProvider{err: errors.New("git failed")}, 30, time.Now())
+	assert.Error(t, err)
+	assert.Nil(t, report)
+}
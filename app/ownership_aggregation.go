@@ -0,0 +1,270 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"sort"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// ownershipScore accumulates the file-level metrics needed to score a slice
+// of the codebase with the same formulas as the overall health score.
+type ownershipScore struct {
+	files            map[string]struct{}
+	functions        int
+	complexitySum    int
+	cognitiveSum     int
+	nestingSum       int
+	highComplexity   int
+	criticalDeadCode int
+	warningDeadCode  int
+	infoDeadCode     int
+}
+
+func newOwnershipScore() *ownershipScore {
+	return &ownershipScore{files: make(map[string]struct{})}
+}
+
+func (s *ownershipScore) deadCodeCount() int {
+	return s.criticalDeadCode + s.warningDeadCode + s.infoDeadCode
+}
+
+func (s *ownershipScore) averageComplexity() float64 {
+	if s.functions == 0 {
+		return 0
+	}
+	return float64(s.complexitySum) / float64(s.functions)
+}
+
+// summary converts the accumulated metrics into a scored AnalyzeSummary
+func (s *ownershipScore) summary() domain.AnalyzeSummary {
+	summary := domain.AnalyzeSummary{
+		TotalFiles:          len(s.files),
+		AnalyzedFiles:       len(s.files),
+		TotalFunctions:      s.functions,
+		FunctionsParsed:     s.functions,
+		AverageComplexity:   s.averageComplexity(),
+		HighComplexityCount: s.highComplexity,
+		DeadCodeCount:       s.deadCodeCount(),
+		CriticalDeadCode:    s.criticalDeadCode,
+		WarningDeadCode:     s.warningDeadCode,
+		InfoDeadCode:        s.infoDeadCode,
+	}
+	if s.functions > 0 {
+		summary.AverageCognitiveComplexity = float64(s.cognitiveSum) / float64(s.functions)
+		summary.AverageNestingDepth = float64(s.nestingSum) / float64(s.functions)
+	}
+	if err := summary.CalculateHealthScore(); err != nil {
+		log.Printf("WARNING: Failed to calculate ownership score: %v", err)
+		summary.HealthScore = summary.CalculateFallbackScore()
+		summary.Grade = domain.GetGradeFromScore(summary.HealthScore)
+	}
+	return summary
+}
+
+// ownerAccumulator collects everything attributed to a single owner
+type ownerAccumulator struct {
+	total          *ownershipScore
+	packages       map[string]*ownershipScore
+	cloneFragments int
+	highComplexity []domain.OwnedFinding
+	deadCode       []domain.OwnedFinding
+}
+
+// ownershipAggregator groups findings by owner, caching lookups per file
+type ownershipAggregator struct {
+	resolver   domain.OwnershipResolver
+	owners     map[string]*ownerAccumulator
+	fileOwners map[string][]string
+}
+
+func (a *ownershipAggregator) ownersOf(filePath string) []*ownerAccumulator {
+	names, ok := a.fileOwners[filePath]
+	if !ok {
+		names = a.resolver.Owners(filePath)
+		if len(names) == 0 {
+			names = []string{domain.UnownedOwner}
+		}
+		a.fileOwners[filePath] = names
+	}
+
+	result := make([]*ownerAccumulator, 0, len(names))
+	for _, name := range names {
+		acc, ok := a.owners[name]
+		if !ok {
+			acc = &ownerAccumulator{
+				total:    newOwnershipScore(),
+				packages: make(map[string]*ownershipScore),
+			}
+			a.owners[name] = acc
+		}
+		result = append(result, acc)
+	}
+	return result
+}
+
+// scores returns the owner total and the package score for filePath
+func (acc *ownerAccumulator) scores(filePath string) []*ownershipScore {
+	pkg := filepath.ToSlash(filepath.Dir(filePath))
+	pkgScore, ok := acc.packages[pkg]
+	if !ok {
+		pkgScore = newOwnershipScore()
+		acc.packages[pkg] = pkgScore
+	}
+	pkgScore.files[filePath] = struct{}{}
+	acc.total.files[filePath] = struct{}{}
+	return []*ownershipScore{acc.total, pkgScore}
+}
+
+// buildOwnershipReport slices complexity, dead code and clone findings by the
+// owners of the files they occur in. Every analyzed file is attributed, so
+// owners without findings still appear with a clean score.
+func buildOwnershipReport(response *domain.AnalyzeResponse, files []string, resolver domain.OwnershipResolver, source string) *domain.OwnershipReport {
+	if response == nil || resolver == nil {
+		return nil
+	}
+
+	agg := &ownershipAggregator{
+		resolver:   resolver,
+		owners:     make(map[string]*ownerAccumulator),
+		fileOwners: make(map[string][]string),
+	}
+
+	for _, file := range files {
+		for _, acc := range agg.ownersOf(file) {
+			acc.scores(file)
+		}
+	}
+
+	if response.Complexity != nil {
+		for _, fn := range response.Complexity.Functions {
+			for _, acc := range agg.ownersOf(fn.FilePath) {
+				for _, score := range acc.scores(fn.FilePath) {
+					score.functions++
+					score.complexitySum += fn.Metrics.Complexity
+					score.cognitiveSum += fn.Metrics.CognitiveComplexity
+					score.nestingSum += fn.Metrics.NestingDepth
+					if fn.RiskLevel == domain.RiskLevelHigh {
+						score.highComplexity++
+					}
+				}
+				if fn.RiskLevel == domain.RiskLevelHigh {
+					acc.highComplexity = append(acc.highComplexity, domain.OwnedFinding{
+						Name:      fn.Name,
+						FilePath:  fn.FilePath,
+						StartLine: fn.StartLine,
+						EndLine:   fn.EndLine,
+						Severity:  string(fn.RiskLevel),
+						Detail:    fmt.Sprintf("complexity %d", fn.Metrics.Complexity),
+					})
+				}
+			}
+		}
+	}
+
+	if response.DeadCode != nil {
+		for _, file := range response.DeadCode.Files {
+			for _, fn := range file.Functions {
+				for _, finding := range fn.Findings {
+					path := finding.Location.FilePath
+					for _, acc := range agg.ownersOf(path) {
+						for _, score := range acc.scores(path) {
+							switch finding.Severity {
+							case domain.DeadCodeSeverityCritical:
+								score.criticalDeadCode++
+							case domain.DeadCodeSeverityWarning:
+								score.warningDeadCode++
+							default:
+								score.infoDeadCode++
+							}
+						}
+						acc.deadCode = append(acc.deadCode, domain.OwnedFinding{
+							Name:      finding.FunctionName,
+							FilePath:  path,
+							StartLine: finding.Location.StartLine,
+							EndLine:   finding.Location.EndLine,
+							Severity:  string(finding.Severity),
+							Detail:    finding.Reason,
+						})
+					}
+				}
+			}
+		}
+	}
+
+	if response.Clone != nil {
+		for _, clone := range response.Clone.Clones {
+			if clone == nil || clone.Location == nil {
+				continue
+			}
+			for _, acc := range agg.ownersOf(clone.Location.FilePath) {
+				acc.scores(clone.Location.FilePath)
+				acc.cloneFragments++
+			}
+		}
+	}
+
+	report := &domain.OwnershipReport{
+		CodeownersFile: source,
+		Owners:         make([]domain.OwnerSummary, 0, len(agg.owners)),
+	}
+	for name, acc := range agg.owners {
+		report.Owners = append(report.Owners, acc.toSummary(name))
+	}
+	sort.Slice(report.Owners, func(i, j int) bool {
+		iUnowned := report.Owners[i].Owner == domain.UnownedOwner
+		jUnowned := report.Owners[j].Owner == domain.UnownedOwner
+		if iUnowned != jUnowned {
+			return jUnowned
+		}
+		return report.Owners[i].Owner < report.Owners[j].Owner
+	})
+	return report
+}
+
+// toSummary scores the accumulated metrics for the owner and its packages
+func (acc *ownerAccumulator) toSummary(name string) domain.OwnerSummary {
+	total := acc.total.summary()
+	owner := domain.OwnerSummary{
+		Owner:                   name,
+		Files:                   len(acc.total.files),
+		Functions:               acc.total.functions,
+		AverageComplexity:       total.AverageComplexity,
+		HighComplexityCount:     acc.total.highComplexity,
+		DeadCodeCount:           acc.total.deadCodeCount(),
+		CriticalDeadCode:        acc.total.criticalDeadCode,
+		CloneFragments:          acc.cloneFragments,
+		ComplexityScore:         total.ComplexityScore,
+		DeadCodeScore:           total.DeadCodeScore,
+		HealthScore:             total.HealthScore,
+		Grade:                   total.Grade,
+		Packages:                make([]domain.OwnerPackageScore, 0, len(acc.packages)),
+		HighComplexityFunctions: acc.highComplexity,
+		DeadCodeFindings:        acc.deadCode,
+	}
+
+	for pkg, score := range acc.packages {
+		summary := score.summary()
+		owner.Packages = append(owner.Packages, domain.OwnerPackageScore{
+			Package:             pkg,
+			Files:               len(score.files),
+			Functions:           score.functions,
+			AverageComplexity:   summary.AverageComplexity,
+			HighComplexityCount: score.highComplexity,
+			DeadCodeCount:       score.deadCodeCount(),
+			ComplexityScore:     summary.ComplexityScore,
+			DeadCodeScore:       summary.DeadCodeScore,
+			HealthScore:         summary.HealthScore,
+			Grade:               summary.Grade,
+		})
+	}
+	sort.Slice(owner.Packages, func(i, j int) bool {
+		if owner.Packages[i].HealthScore != owner.Packages[j].HealthScore {
+			return owner.Packages[i].HealthScore < owner.Packages[j].HealthScore
+		}
+		return owner.Packages[i].Package < owner.Packages[j].Package
+	})
+	return owner
+}
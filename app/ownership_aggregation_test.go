@@ -0,0 +1,75 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type prefixOwnershipResolver map[string][]string
+
+func (r prefixOwnershipResolver) Owners(filePath string) []string {
+	for prefix, owners := range r {
+		if strings.HasPrefix(filePath, prefix) {
+			return owners
+		}
+	}
+	return nil
+}
+
+func TestBuildOwnershipReport_GroupsFindingsByOwner(t *testing.T) {
+	response := &domain.AnalyzeResponse{
+		Complexity: &domain.ComplexityResponse{
+			Functions: []domain.FunctionComplexity{
+				{Name: "simple", FilePath: "payments/api.py", Metrics: domain.ComplexityMetrics{Complexity: 2}, RiskLevel: domain.RiskLevelLow},
+				{Name: "tangled", FilePath: "payments/core/ledger.py", Metrics: domain.ComplexityMetrics{Complexity: 30}, RiskLevel: domain.RiskLevelHigh},
+				{Name: "helper", FilePath: "scripts/tool.py", Metrics: domain.ComplexityMetrics{Complexity: 1}, RiskLevel: domain.RiskLevelLow},
+			},
+		},
+		DeadCode: &domain.DeadCodeResponse{
+			Files: []domain.FileDeadCode{{
+				FilePath: "payments/api.py",
+				Functions: []domain.FunctionDeadCode{{
+					Name: "simple",
+					Findings: []domain.DeadCodeFinding{{
+						FunctionName: "simple",
+						Location:     domain.DeadCodeLocation{FilePath: "payments/api.py", StartLine: 4, EndLine: 5},
+						Severity:     domain.DeadCodeSeverityCritical,
+						Reason:       "unreachable_after_return",
+					}},
+				}},
+			}},
+		},
+	}
+	files := []string{"payments/api.py", "payments/core/ledger.py", "scripts/tool.py", "shared/util.py"}
+	resolver := prefixOwnershipResolver{
+		"payments/": {"@org/payments"},
+		"shared/":   {"@org/payments", "@org/platform"},
+	}
+
+	report := buildOwnershipReport(response, files, resolver, "CODEOWNERS")
+	require.NotNil(t, report)
+	require.Len(t, report.Owners, 3)
+
+	payments := report.Owners[0]
+	assert.Equal(t, "@org/payments", payments.Owner)
+	assert.Equal(t, 3, payments.Files)
+	assert.Equal(t, 2, payments.Functions)
+	assert.Equal(t, 1, payments.HighComplexityCount)
+	assert.Equal(t, 1, payments.DeadCodeCount)
+	require.Len(t, payments.HighComplexityFunctions, 1)
+	assert.Equal(t, "tangled", payments.HighComplexityFunctions[0].Name)
+	require.Len(t, payments.Packages, 3)
+	assert.Equal(t, "payments/core", payments.Packages[0].Package, "worst package should sort first")
+	assert.Less(t, payments.HealthScore, 100)
+
+	assert.Equal(t, "@org/platform", report.Owners[1].Owner)
+	assert.Equal(t, 1, report.Owners[1].Files)
+	assert.Equal(t, 100, report.Owners[1].HealthScore)
+
+	assert.Equal(t, domain.UnownedOwner, report.Owners[2].Owner)
+	assert.Equal(t, 1, report.Owners[2].Functions)
+}
@@ -0,0 +1,372 @@
+package app
+
+import (
+	"bytes"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// packageAccumulator collects the metrics of every file below one directory
+type packageAccumulator struct {
+	path   string
+	depth  int
+	parent string
+
+	score *ownershipScore
+
+	maxComplexity   int
+	totalLines      int
+	duplicatedLines int
+
+	cboSum    int
+	maxCBO    int
+	cboByRisk map[domain.RiskLevel]int
+	classes   int
+
+	lcomSum     int
+	lcomClasses int
+	highLCOM    int
+
+	missingDocstrings int
+	typing            domain.TypeCoverageCounts
+}
+
+// packageRollup attributes file-level results to a directory and all of its
+// ancestors up to the common root of the analyzed files
+type packageRollup struct {
+	root     []string
+	packages map[string]*packageAccumulator
+	chains   map[string][]*packageAccumulator
+}
+
+// splitDir returns the directory of filePath as path elements; "." yields none
+func splitDir(filePath string) []string {
+	dir := filepath.ToSlash(filepath.Dir(filepath.Clean(filePath)))
+	switch dir {
+	case ".":
+		return nil
+	case "/":
+		return []string{""}
+	}
+	return strings.Split(dir, "/")
+}
+
+// joinDir turns path elements back into a directory path
+func joinDir(elements []string) string {
+	if len(elements) == 0 {
+		return "."
+	}
+	if len(elements) == 1 && elements[0] == "" {
+		return "/"
+	}
+	return strings.Join(elements, "/")
+}
+
+// commonDir returns the deepest directory that contains every file
+func commonDir(files []string) []string {
+	var root []string
+	for i, file := range files {
+		dir := splitDir(file)
+		if i == 0 {
+			root = dir
+			continue
+		}
+		n := 0
+		for n < len(root) && n < len(dir) && root[n] == dir[n] {
+			n++
+		}
+		root = root[:n]
+	}
+	return root
+}
+
+func newPackageRollup(files []string) *packageRollup {
+	return &packageRollup{
+		root:     commonDir(files),
+		packages: make(map[string]*packageAccumulator),
+		chains:   make(map[string][]*packageAccumulator),
+	}
+}
+
+// chain returns the accumulators of the file's directory and its ancestors,
+// root first. Files outside the root count towards the root only.
+func (r *packageRollup) chain(filePath string) []*packageAccumulator {
+	dir := splitDir(filePath)
+	key := joinDir(dir)
+	if chain, ok := r.chains[key]; ok {
+		return chain
+	}
+
+	depth := len(dir) - len(r.root)
+	if depth < 0 || joinDir(dir[:len(r.root)]) != joinDir(r.root) {
+		depth = 0
+	}
+	chain := make([]*packageAccumulator, 0, depth+1)
+	parent := ""
+	for i := 0; i <= depth; i++ {
+		path := joinDir(dir[:len(r.root)+i])
+		if i == 0 {
+			path = joinDir(r.root)
+		}
+		acc, ok := r.packages[path]
+		if !ok {
+			acc = &packageAccumulator{
+				path:      path,
+				depth:     i,
+				parent:    parent,
+				score:     newOwnershipScore(),
+				cboByRisk: make(map[domain.RiskLevel]int),
+			}
+			r.packages[path] = acc
+		}
+		chain = append(chain, acc)
+		parent = path
+	}
+	r.chains[key] = chain
+	return chain
+}
+
+// buildPackageRollup aggregates complexity, dead code, duplication, coupling,
+// cohesion, documentation and typing results by directory. lineCount is used
+// for files without raw metrics when clone results need a line total.
+func buildPackageRollup(response *domain.AnalyzeResponse, files []string, lineCount func(filePath string) int) []domain.PackageSummary {
+	if response == nil || len(files) == 0 {
+		return nil
+	}
+
+	rollup := newPackageRollup(files)
+	for _, file := range files {
+		for _, acc := range rollup.chain(file) {
+			acc.score.files[file] = struct{}{}
+		}
+	}
+
+	if response.Complexity != nil {
+		for _, fn := range response.Complexity.Functions {
+			for _, acc := range rollup.chain(fn.FilePath) {
+				acc.score.files[fn.FilePath] = struct{}{}
+				acc.score.functions++
+				acc.score.complexitySum += fn.Metrics.Complexity
+				acc.score.cognitiveSum += fn.Metrics.CognitiveComplexity
+				acc.score.nestingSum += fn.Metrics.NestingDepth
+				if fn.RiskLevel == domain.RiskLevelHigh {
+					acc.score.highComplexity++
+				}
+				if fn.Metrics.Complexity > acc.maxComplexity {
+					acc.maxComplexity = fn.Metrics.Complexity
+				}
+			}
+		}
+	}
+
+	if response.DeadCode != nil {
+		for _, file := range response.DeadCode.Files {
+			for _, fn := range file.Functions {
+				for _, finding := range fn.Findings {
+					for _, acc := range rollup.chain(finding.Location.FilePath) {
+						switch finding.Severity {
+						case domain.DeadCodeSeverityCritical:
+							acc.score.criticalDeadCode++
+						case domain.DeadCodeSeverityWarning:
+							acc.score.warningDeadCode++
+						default:
+							acc.score.infoDeadCode++
+						}
+					}
+				}
+			}
+		}
+	}
+
+	if response.Clone != nil {
+		rollup.addDuplication(response, files, lineCount)
+	}
+
+	if response.CBO != nil {
+		for _, class := range response.CBO.Classes {
+			for _, acc := range rollup.chain(class.FilePath) {
+				acc.classes++
+				acc.cboSum += class.Metrics.CouplingCount
+				if class.Metrics.CouplingCount > acc.maxCBO {
+					acc.maxCBO = class.Metrics.CouplingCount
+				}
+				acc.cboByRisk[class.RiskLevel]++
+			}
+		}
+	}
+
+	if response.LCOM != nil {
+		for _, class := range response.LCOM.Classes {
+			for _, acc := range rollup.chain(class.FilePath) {
+				acc.lcomClasses++
+				acc.lcomSum += class.Metrics.LCOM4
+				if class.RiskLevel == domain.RiskLevelHigh {
+					acc.highLCOM++
+				}
+			}
+		}
+	}
+
+	if response.Documentation != nil {
+		for _, finding := range response.Documentation.Findings {
+			for _, acc := range rollup.chain(finding.Location.FilePath) {
+				acc.missingDocstrings++
+			}
+		}
+	}
+
+	if response.TypeCoverage != nil {
+		for _, file := range response.TypeCoverage.Files {
+			for _, acc := range rollup.chain(file.FilePath) {
+				acc.typing.Merge(file.Counts)
+			}
+		}
+	}
+
+	summaries := make([]domain.PackageSummary, 0, len(rollup.packages))
+	for _, acc := range rollup.packages {
+		summaries = append(summaries, acc.toSummary(response))
+	}
+	sortPackageTree(summaries)
+	return summaries
+}
+
+// addDuplication counts the lines covered by clone fragments against the
+// total lines of every file
+func (r *packageRollup) addDuplication(response *domain.AnalyzeResponse, files []string, lineCount func(filePath string) int) {
+	totals := make(map[string]int, len(files))
+	if response.Complexity != nil {
+		for _, metrics := range response.Complexity.RawMetrics {
+			totals[metrics.FilePath] = metrics.TotalLines
+		}
+	}
+
+	duplicated := make(map[string]map[int]struct{})
+	for _, clone := range response.Clone.Clones {
+		if clone == nil || clone.Location == nil {
+			continue
+		}
+		lines, ok := duplicated[clone.Location.FilePath]
+		if !ok {
+			lines = make(map[int]struct{})
+			duplicated[clone.Location.FilePath] = lines
+		}
+		for line := clone.Location.StartLine; line <= clone.Location.EndLine; line++ {
+			lines[line] = struct{}{}
+		}
+	}
+
+	for _, file := range files {
+		total, ok := totals[file]
+		if !ok && lineCount != nil {
+			total = lineCount(file)
+		}
+		dup := len(duplicated[file])
+		if dup > total {
+			dup = total
+		}
+		for _, acc := range r.chain(file) {
+			acc.totalLines += total
+			acc.duplicatedLines += dup
+		}
+	}
+}
+
+// toSummary converts the accumulated metrics, scoring complexity and dead
+// code with the same formulas as the overall health score
+func (acc *packageAccumulator) toSummary(response *domain.AnalyzeResponse) domain.PackageSummary {
+	scored := acc.score.summary()
+	name := acc.path
+	if acc.depth > 0 {
+		name = acc.path[strings.LastIndex(acc.path, "/")+1:]
+	}
+
+	summary := domain.PackageSummary{
+		Path:                acc.path,
+		Name:                name,
+		Parent:              acc.parent,
+		Depth:               acc.depth,
+		Files:               len(acc.score.files),
+		Functions:           acc.score.functions,
+		AverageComplexity:   scored.AverageComplexity,
+		MaxComplexity:       acc.maxComplexity,
+		HighComplexityCount: acc.score.highComplexity,
+		DeadCodeCount:       acc.score.deadCodeCount(),
+		CriticalDeadCode:    acc.score.criticalDeadCode,
+		TotalLines:          acc.totalLines,
+		DuplicatedLines:     acc.duplicatedLines,
+		Classes:             acc.classes,
+		MaxCBO:              acc.maxCBO,
+		LowRiskClasses:      acc.cboByRisk[domain.RiskLevelLow],
+		MediumRiskClasses:   acc.cboByRisk[domain.RiskLevelMedium],
+		HighRiskClasses:     acc.cboByRisk[domain.RiskLevelHigh],
+		HighLCOMCount:       acc.highLCOM,
+		ComplexityScore:     scored.ComplexityScore,
+		DeadCodeScore:       scored.DeadCodeScore,
+		HealthScore:         scored.HealthScore,
+		Grade:               scored.Grade,
+	}
+	if acc.totalLines > 0 {
+		summary.DuplicationPercentage = float64(acc.duplicatedLines) / float64(acc.totalLines) * 100
+	}
+	if acc.classes > 0 {
+		summary.AverageCBO = float64(acc.cboSum) / float64(acc.classes)
+	}
+	if acc.lcomClasses > 0 {
+		summary.AverageLCOM = float64(acc.lcomSum) / float64(acc.lcomClasses)
+	}
+	if response.Documentation != nil {
+		missing := acc.missingDocstrings
+		summary.MissingDocstrings = &missing
+	}
+	if response.TypeCoverage != nil {
+		coverage := acc.typing.Percentage()
+		summary.TypeAnnotationCoverage = &coverage
+	}
+	return summary
+}
+
+// sortPackageTree orders packages depth-first so each directory is followed
+// by its subdirectories
+func sortPackageTree(packages []domain.PackageSummary) {
+	sort.Slice(packages, func(i, j int) bool {
+		return comparePackagePaths(packages[i].Path, packages[j].Path) < 0
+	})
+}
+
+// comparePackagePaths compares paths element by element, so "a/b" sorts
+// right after "a" rather than after "a-b"
+func comparePackagePaths(a, b string) int {
+	as := strings.Split(a, "/")
+	bs := strings.Split(b, "/")
+	for k := 0; k < len(as) && k < len(bs); k++ {
+		if c := strings.Compare(as[k], bs[k]); c != 0 {
+			return c
+		}
+	}
+	return len(as) - len(bs)
+}
+
+// countFileLines reads filePath and counts its lines; unreadable files count as empty
+func (uc *AnalyzeUseCase) countFileLines(filePath string) int {
+	content, err := uc.fileReader.ReadFile(filePath)
+	if err != nil {
+		return 0
+	}
+	return countLines(content)
+}
+
+// countLines returns the number of lines in content
+func countLines(content []byte) int {
+	if len(content) == 0 {
+		return 0
+	}
+	lines := bytes.Count(content, []byte("\n"))
+	if content[len(content)-1] != '\n' {
+		lines++
+	}
+	return lines
+}
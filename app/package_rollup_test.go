@@ -0,0 +1,124 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildPackageRollup_AggregatesSubtrees(t *testing.T) {
+	response := &domain.AnalyzeResponse{
+		Complexity: &domain.ComplexityResponse{
+			Functions: []domain.FunctionComplexity{
+				{Name: "simple", FilePath: "src/app/api.py", Metrics: domain.ComplexityMetrics{Complexity: 2}, RiskLevel: domain.RiskLevelLow},
+				{Name: "tangled", FilePath: "src/app/core/ledger.py", Metrics: domain.ComplexityMetrics{Complexity: 30}, RiskLevel: domain.RiskLevelHigh},
+				{Name: "helper", FilePath: "src/app-tools/tool.py", Metrics: domain.ComplexityMetrics{Complexity: 4}, RiskLevel: domain.RiskLevelLow},
+			},
+			RawMetrics: []domain.RawMetrics{
+				{FilePath: "src/app/api.py", TotalLines: 40},
+				{FilePath: "src/app/core/ledger.py", TotalLines: 60},
+			},
+		},
+		DeadCode: &domain.DeadCodeResponse{
+			Files: []domain.FileDeadCode{{
+				FilePath: "src/app/core/ledger.py",
+				Functions: []domain.FunctionDeadCode{{
+					Name: "tangled",
+					Findings: []domain.DeadCodeFinding{{
+						Location: domain.DeadCodeLocation{FilePath: "src/app/core/ledger.py", StartLine: 4, EndLine: 5},
+						Severity: domain.DeadCodeSeverityCritical,
+					}},
+				}},
+			}},
+		},
+		Clone: &domain.CloneResponse{
+			Clones: []*domain.Clone{
+				{Location: &domain.CloneLocation{FilePath: "src/app/api.py", StartLine: 1, EndLine: 10}},
+				{Location: &domain.CloneLocation{FilePath: "src/app/api.py", StartLine: 6, EndLine: 15}},
+				{Location: &domain.CloneLocation{FilePath: "src/app/core/ledger.py", StartLine: 1, EndLine: 15}},
+			},
+		},
+		CBO: &domain.CBOResponse{
+			Classes: []domain.ClassCoupling{
+				{Name: "Ledger", FilePath: "src/app/core/ledger.py", Metrics: domain.CBOMetrics{CouplingCount: 9}, RiskLevel: domain.RiskLevelHigh},
+				{Name: "Api", FilePath: "src/app/api.py", Metrics: domain.CBOMetrics{CouplingCount: 1}, RiskLevel: domain.RiskLevelLow},
+			},
+		},
+	}
+	files := []string{"src/app/api.py", "src/app/core/ledger.py", "src/app-tools/tool.py"}
+	lineCount := func(filePath string) int { return 20 }
+
+	packages := buildPackageRollup(response, files, lineCount)
+	require.Len(t, packages, 4)
+
+	paths := make([]string, len(packages))
+	for i, pkg := range packages {
+		paths[i] = pkg.Path
+	}
+	assert.Equal(t, []string{"src", "src/app", "src/app/core", "src/app-tools"}, paths, "subdirectories should follow their parent")
+
+	root := packages[0]
+	assert.Equal(t, 0, root.Depth)
+	assert.Empty(t, root.Parent)
+	assert.Equal(t, 3, root.Files)
+	assert.Equal(t, 3, root.Functions)
+	assert.Equal(t, 30, root.MaxComplexity)
+	assert.Equal(t, 1, root.DeadCodeCount)
+	assert.Equal(t, 120, root.TotalLines)
+	assert.Equal(t, 30, root.DuplicatedLines)
+	assert.InDelta(t, 25.0, root.DuplicationPercentage, 1e-9)
+	assert.Equal(t, 2, root.Classes)
+	assert.InDelta(t, 5.0, root.AverageCBO, 1e-9)
+	assert.Equal(t, 1, root.HighRiskClasses)
+	assert.Equal(t, 1, root.LowRiskClasses)
+	assert.Nil(t, root.MissingDocstrings)
+	assert.Nil(t, root.TypeAnnotationCoverage)
+
+	app := packages[1]
+	assert.Equal(t, "app", app.Name)
+	assert.Equal(t, "src", app.Parent)
+	assert.Equal(t, 1, app.Depth)
+	assert.Equal(t, 2, app.Files)
+	assert.InDelta(t, 16.0, app.AverageComplexity, 1e-9)
+
+	core := packages[2]
+	assert.Equal(t, 2, core.Depth)
+	assert.Equal(t, 1, core.HighComplexityCount)
+	assert.InDelta(t, 25.0, core.DuplicationPercentage, 1e-9)
+	assert.Less(t, core.HealthScore, 100)
+
+	tools := packages[3]
+	assert.Equal(t, 20, tools.TotalLines, "files without raw metrics should use the line counter")
+	assert.Greater(t, tools.HealthScore, core.HealthScore)
+}
+
+func TestBuildPackageRollup_OptionalAnalyses(t *testing.T) {
+	response := &domain.AnalyzeResponse{
+		Documentation: &domain.DocumentationResponse{
+			Findings: []domain.DocumentationFinding{
+				{Name: "run", Location: domain.SourceLocation{FilePath: "pkg/a.py"}},
+			},
+		},
+		TypeCoverage: &domain.TypeCoverageResponse{
+			Files: []domain.TypeCoverageFile{
+				{FilePath: "pkg/a.py", Counts: domain.TypeCoverageCounts{Parameters: 3, AnnotatedParameters: 1, Returns: 1, AnnotatedReturns: 1}},
+			},
+		},
+	}
+
+	packages := buildPackageRollup(response, []string{"pkg/a.py", "b.py"}, nil)
+	require.Len(t, packages, 2)
+	assert.Equal(t, ".", packages[0].Path)
+	assert.Equal(t, "pkg", packages[1].Path)
+
+	require.NotNil(t, packages[0].MissingDocstrings)
+	assert.Equal(t, 1, *packages[0].MissingDocstrings)
+	require.NotNil(t, packages[1].TypeAnnotationCoverage)
+	assert.InDelta(t, 50.0, *packages[1].TypeAnnotationCoverage, 1e-9)
+}
+
+func TestBuildPackageRollup_Empty(t *testing.T) {
+	assert.Nil(t, buildPackageRollup(&domain.AnalyzeResponse{}, nil, nil))
+}
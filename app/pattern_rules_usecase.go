@@ -0,0 +1,244 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	svc "github.com/ludo-technologies/pyscn/service"
+)
+
+// PatternRulesUseCase orchestrates the custom rules workflow
+type PatternRulesUseCase struct {
+	service      domain.PatternRulesService
+	fileReader   domain.FileReader
+	formatter    domain.PatternRulesOutputFormatter
+	configLoader domain.PatternRulesConfigurationLoader
+	output       domain.ReportWriter
+}
+
+// NewPatternRulesUseCase creates a new custom rules use case
+func NewPatternRulesUseCase(
+	service domain.PatternRulesService,
+	fileReader domain.FileReader,
+	formatter domain.PatternRulesOutputFormatter,
+	configLoader domain.PatternRulesConfigurationLoader,
+) *PatternRulesUseCase {
+	return &PatternRulesUseCase{
+		service:      service,
+		fileReader:   fileReader,
+		formatter:    formatter,
+		configLoader: configLoader,
+		output:       svc.NewFileOutputWriter(nil),
+	}
+}
+
+// prepareAnalysis handles common preparation steps for analysis
+func (uc *PatternRulesUseCase) prepareAnalysis(ctx context.Context, req domain.PatternRulesRequest) (domain.PatternRulesRequest, error) {
+	// Validate input
+	if err := uc.validateRequest(req); err != nil {
+		return req, domain.NewInvalidInputError("invalid request", err)
+	}
+
+	// Load configuration if specified
+	finalReq, err := uc.loadAndMergeConfig(req)
+	if err != nil {
+		return req, domain.NewConfigError("failed to load configuration", err)
+	}
+
+	// Resolve file paths
+	files, err := ResolveFilePaths(
+		uc.fileReader,
+		finalReq.Paths,
+		domain.BoolValue(finalReq.Recursive, true),
+		finalReq.IncludePatterns,
+		finalReq.ExcludePatterns,
+		false,
+	)
+	if err != nil {
+		return req, domain.NewFileNotFoundError("failed to collect files", err)
+	}
+
+	if len(files) == 0 {
+		return req, domain.NewInvalidInputError("no Python files found in the specified paths", nil)
+	}
+
+	finalReq.Paths = files
+	return finalReq, nil
+}
+
+// Execute performs the complete custom rules workflow
+func (uc *PatternRulesUseCase) Execute(ctx context.Context, req domain.PatternRulesRequest) error {
+	// Prepare for analysis
+	finalReq, err := uc.prepareAnalysis(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	// Perform analysis
+	response, err := uc.service.Analyze(ctx, finalReq)
+	if err != nil {
+		return domain.NewAnalysisError("custom rules analysis failed", err)
+	}
+
+	// Delegate output handling to ReportWriter
+	var out io.Writer
+	if finalReq.OutputPath == "" {
+		out = finalReq.OutputWriter
+	}
+	if err := uc.output.Write(out, finalReq.OutputPath, finalReq.OutputFormat, finalReq.NoOpen, func(w io.Writer) error {
+		return uc.formatter.Write(response, finalReq.OutputFormat, w)
+	}); err != nil {
+		return domain.NewOutputError("failed to write output", err)
+	}
+
+	return nil
+}
+
+// AnalyzeAndReturn runs the custom rules and returns the response without formatting
+func (uc *PatternRulesUseCase) AnalyzeAndReturn(ctx context.Context, req domain.PatternRulesRequest) (*domain.PatternRulesResponse, error) {
+	// Prepare for analysis
+	finalReq, err := uc.prepareAnalysis(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Perform analysis and return the response
+	response, err := uc.service.Analyze(ctx, finalReq)
+	if err != nil {
+		return nil, domain.NewAnalysisError("custom rules analysis failed", err)
+	}
+
+	return response, nil
+}
+
+// validateRequest validates the custom rules request
+func (uc *PatternRulesUseCase) validateRequest(req domain.PatternRulesRequest) error {
+	if len(req.Paths) == 0 {
+		return fmt.Errorf("no input paths specified")
+	}
+
+	if req.OutputWriter == nil && req.OutputPath == "" {
+		return fmt.Errorf("output writer or output path is required")
+	}
+
+	return nil
+}
+
+// loadAndMergeConfig loads configuration from file and merges with request
+func (uc *PatternRulesUseCase) loadAndMergeConfig(req domain.PatternRulesRequest) (domain.PatternRulesRequest, error) {
+	if uc.configLoader == nil {
+		return req, nil
+	}
+
+	var configReq *domain.PatternRulesRequest
+	var err error
+
+	if req.ConfigPath != "" {
+		configReq, err = uc.configLoader.LoadConfig(req.ConfigPath)
+		if err != nil {
+			return req, fmt.Errorf("failed to load config from %s: %w", req.ConfigPath, err)
+		}
+	} else {
+		configReq, err = uc.configLoader.LoadDefaultConfig()
+		if err != nil {
+			return req, err
+		}
+	}
+
+	if configReq != nil {
+		merged := uc.configLoader.MergeConfig(configReq, &req)
+		return *merged, nil
+	}
+
+	return req, nil
+}
+
+// PatternRulesUseCaseBuilder provides a builder pattern for creating PatternRulesUseCase
+type PatternRulesUseCaseBuilder struct {
+	service      domain.PatternRulesService
+	fileReader   domain.FileReader
+	formatter    domain.PatternRulesOutputFormatter
+	configLoader domain.PatternRulesConfigurationLoader
+	output       domain.ReportWriter
+}
+
+// NewPatternRulesUseCaseBuilder creates a new builder
+func NewPatternRulesUseCaseBuilder() *PatternRulesUseCaseBuilder {
+	return &PatternRulesUseCaseBuilder{}
+}
+
+// WithService sets the custom rules service
+func (b *PatternRulesUseCaseBuilder) WithService(service domain.PatternRulesService) *PatternRulesUseCaseBuilder {
+	b.service = service
+	return b
+}
+
+// WithFileReader sets the file reader
+func (b *PatternRulesUseCaseBuilder) WithFileReader(fileReader domain.FileReader) *PatternRulesUseCaseBuilder {
+	b.fileReader = fileReader
+	return b
+}
+
+// WithFormatter sets the output formatter
+func (b *PatternRulesUseCaseBuilder) WithFormatter(formatter domain.PatternRulesOutputFormatter) *PatternRulesUseCaseBuilder {
+	b.formatter = formatter
+	return b
+}
+
+// WithConfigLoader sets the configuration loader
+func (b *PatternRulesUseCaseBuilder) WithConfigLoader(configLoader domain.PatternRulesConfigurationLoader) *PatternRulesUseCaseBuilder {
+	b.configLoader = configLoader
+	return b
+}
+
+// WithOutputWriter sets the report writer
+func (b *PatternRulesUseCaseBuilder) WithOutputWriter(output domain.ReportWriter) *PatternRulesUseCaseBuilder {
+	b.output = output
+	return b
+}
+
+// Build creates the PatternRulesUseCase with the configured dependencies.
+// Optional dependencies (configLoader) are filled with no-op defaults if nil.
+func (b *PatternRulesUseCaseBuilder) Build() (*PatternRulesUseCase, error) {
+	if b.service == nil {
+		return nil, fmt.Errorf("custom rules service is required")
+	}
+	if b.fileReader == nil {
+		return nil, fmt.Errorf("file reader is required")
+	}
+	if b.formatter == nil {
+		return nil, fmt.Errorf("output formatter is required")
+	}
+
+	if b.configLoader == nil {
+		b.configLoader = &noOpPatternRulesConfigLoader{}
+	}
+
+	uc := NewPatternRulesUseCase(
+		b.service,
+		b.fileReader,
+		b.formatter,
+		b.configLoader,
+	)
+	if b.output != nil {
+		uc.output = b.output
+	}
+	return uc, nil
+}
+
+// noOpPatternRulesConfigLoader is a no-op implementation
+type noOpPatternRulesConfigLoader struct{}
+
+func (n *noOpPatternRulesConfigLoader) LoadConfig(path string) (*domain.PatternRulesRequest, error) {
+	return nil, nil
+}
+
+func (n *noOpPatternRulesConfigLoader) LoadDefaultConfig() (*domain.PatternRulesRequest, error) {
+	return nil, nil
+}
+
+func (n *noOpPatternRulesConfigLoader) MergeConfig(base *domain.PatternRulesRequest, override *domain.PatternRulesRequest) *domain.PatternRulesRequest {
+	return override
+}
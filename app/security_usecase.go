@@ -0,0 +1,241 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	svc "github.com/ludo-technologies/pyscn/service"
+)
+
+// SecurityUseCase orchestrates the security analysis workflow
+type SecurityUseCase struct {
+	service      domain.SecurityService
+	fileReader   domain.FileReader
+	formatter    domain.SecurityOutputFormatter
+	configLoader domain.SecurityConfigurationLoader
+	output       domain.ReportWriter
+}
+
+// NewSecurityUseCase creates a new security use case
+func NewSecurityUseCase(
+	service domain.SecurityService,
+	fileReader domain.FileReader,
+	formatter domain.SecurityOutputFormatter,
+	configLoader domain.SecurityConfigurationLoader,
+) *SecurityUseCase {
+	return &SecurityUseCase{
+		service:      service,
+		fileReader:   fileReader,
+		formatter:    formatter,
+		configLoader: configLoader,
+		output:       svc.NewFileOutputWriter(nil),
+	}
+}
+
+// prepareAnalysis handles common preparation steps for analysis
+func (uc *SecurityUseCase) prepareAnalysis(ctx context.Context, req domain.SecurityRequest) (domain.SecurityRequest, error) {
+	// Validate input
+	if err := uc.validateRequest(req); err != nil {
+		return req, domain.NewInvalidInputError("invalid request", err)
+	}
+
+	// Load configuration if specified
+	finalReq, err := uc.loadAndMergeConfig(req)
+	if err != nil {
+		return req, domain.NewConfigError("failed to load configuration", err)
+	}
+
+	// Resolve file paths
+	files, err := ResolveFilePaths(
+		uc.fileReader,
+		finalReq.Paths,
+		domain.BoolValue(finalReq.Recursive, true),
+		finalReq.IncludePatterns,
+		finalReq.ExcludePatterns,
+		false,
+	)
+	if err != nil {
+		return req, domain.NewFileNotFoundError("failed to collect files", err)
+	}
+
+	if len(files) == 0 {
+		return req, domain.NewInvalidInputError("no Python files found in the specified paths", nil)
+	}
+
+	finalReq.Paths = files
+	return finalReq, nil
+}
+
+// Execute performs the complete security analysis workflow
+func (uc *SecurityUseCase) Execute(ctx context.Context, req domain.SecurityRequest) error {
+	// Prepare for analysis
+	finalReq, err := uc.prepareAnalysis(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	// Perform analysis
+	response, err := uc.service.Analyze(ctx, finalReq)
+	if err != nil {
+		return domain.NewAnalysisError("security analysis failed", err)
+	}
+
+	// Delegate output handling to ReportWriter
+	var out io.Writer
+	if finalReq.OutputPath == "" {
+		out = finalReq.OutputWriter
+	}
+	if err := uc.output.Write(out, finalReq.OutputPath, finalReq.OutputFormat, finalReq.NoOpen, func(w io.Writer) error {
+		return uc.formatter.Write(response, finalReq.OutputFormat, w)
+	}); err != nil {
+		return domain.NewOutputError("failed to write output", err)
+	}
+
+	return nil
+}
+
+// AnalyzeAndReturn performs security analysis and returns the response without formatting
+func (uc *SecurityUseCase) AnalyzeAndReturn(ctx context.Context, req domain.SecurityRequest) (*domain.SecurityResponse, error) {
+	// Prepare for analysis
+	finalReq, err := uc.prepareAnalysis(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Perform analysis and return the response
+	response, err := uc.service.Analyze(ctx, finalReq)
+	if err != nil {
+		return nil, domain.NewAnalysisError("security analysis failed", err)
+	}
+
+	return response, nil
+}
+
+// validateRequest validates the security request
+func (uc *SecurityUseCase) validateRequest(req domain.SecurityRequest) error {
+	if len(req.Paths) == 0 {
+		return fmt.Errorf("no input paths specified")
+	}
+
+	if req.OutputWriter == nil && req.OutputPath == "" {
+		return fmt.Errorf("output writer or output path is required")
+	}
+
+	return nil
+}
+
+// loadAndMergeConfig loads configuration from file and merges with request
+func (uc *SecurityUseCase) loadAndMergeConfig(req domain.SecurityRequest) (domain.SecurityRequest, error) {
+	if uc.configLoader == nil {
+		return req, nil
+	}
+
+	var configReq *domain.SecurityRequest
+	var err error
+
+	if req.ConfigPath != "" {
+		configReq, err = uc.configLoader.LoadConfig(req.ConfigPath)
+		if err != nil {
+			return req, fmt.Errorf("failed to load config from %s: %w", req.ConfigPath, err)
+		}
+	} else {
+		configReq = uc.configLoader.LoadDefaultConfig()
+	}
+
+	if configReq != nil {
+		merged := uc.configLoader.MergeConfig(configReq, &req)
+		return *merged, nil
+	}
+
+	return req, nil
+}
+
+// SecurityUseCaseBuilder provides a builder pattern for creating SecurityUseCase
+type SecurityUseCaseBuilder struct {
+	service      domain.SecurityService
+	fileReader   domain.FileReader
+	formatter    domain.SecurityOutputFormatter
+	configLoader domain.SecurityConfigurationLoader
+	output       domain.ReportWriter
+}
+
+// NewSecurityUseCaseBuilder creates a new builder
+func NewSecurityUseCaseBuilder() *SecurityUseCaseBuilder {
+	return &SecurityUseCaseBuilder{}
+}
+
+// WithService sets the security service
+func (b *SecurityUseCaseBuilder) WithService(service domain.SecurityService) *SecurityUseCaseBuilder {
+	b.service = service
+	return b
+}
+
+// WithFileReader sets the file reader
+func (b *SecurityUseCaseBuilder) WithFileReader(fileReader domain.FileReader) *SecurityUseCaseBuilder {
+	b.fileReader = fileReader
+	return b
+}
+
+// WithFormatter sets the output formatter
+func (b *SecurityUseCaseBuilder) WithFormatter(formatter domain.SecurityOutputFormatter) *SecurityUseCaseBuilder {
+	b.formatter = formatter
+	return b
+}
+
+// WithConfigLoader sets the configuration loader
+func (b *SecurityUseCaseBuilder) WithConfigLoader(configLoader domain.SecurityConfigurationLoader) *SecurityUseCaseBuilder {
+	b.configLoader = configLoader
+	return b
+}
+
+// WithOutputWriter sets the report writer
+func (b *SecurityUseCaseBuilder) WithOutputWriter(output domain.ReportWriter) *SecurityUseCaseBuilder {
+	b.output = output
+	return b
+}
+
+// Build creates the SecurityUseCase with the configured dependencies.
+// Optional dependencies (configLoader) are filled with no-op defaults if nil.
+func (b *SecurityUseCaseBuilder) Build() (*SecurityUseCase, error) {
+	if b.service == nil {
+		return nil, fmt.Errorf("security service is required")
+	}
+	if b.fileReader == nil {
+		return nil, fmt.Errorf("file reader is required")
+	}
+	if b.formatter == nil {
+		return nil, fmt.Errorf("output formatter is required")
+	}
+
+	if b.configLoader == nil {
+		b.configLoader = &noOpSecurityConfigLoader{}
+	}
+
+	uc := NewSecurityUseCase(
+		b.service,
+		b.fileReader,
+		b.formatter,
+		b.configLoader,
+	)
+	if b.output != nil {
+		uc.output = b.output
+	}
+	return uc, nil
+}
+
+// noOpSecurityConfigLoader is a no-op implementation
+type noOpSecurityConfigLoader struct{}
+
+func (n *noOpSecurityConfigLoader) LoadConfig(path string) (*domain.SecurityRequest, error) {
+	return nil, nil
+}
+
+func (n *noOpSecurityConfigLoader) LoadDefaultConfig() *domain.SecurityRequest {
+	return nil
+}
+
+func (n *noOpSecurityConfigLoader) MergeConfig(base *domain.SecurityRequest, override *domain.SecurityRequest) *domain.SecurityRequest {
+	return override
+}
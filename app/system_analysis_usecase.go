@@ -388,8 +388,10 @@ func (n *noOpSystemAnalysisConfigLoader) MergeConfig(base *domain.SystemAnalysis
 		merged.ConfigPath = override.ConfigPath
 	}
 
-	// NoOpen is a caller-only execution flag, not a persisted configuration.
+	// NoOpen and DetectArchitecture are caller-only execution flags, not
+	// persisted configuration.
 	merged.NoOpen = override.NoOpen
+	merged.DetectArchitecture = override.DetectArchitecture
 
 	// Pointer booleans are sparse: nil keeps the base, including a base value
 	// of false, while a non-nil override can explicitly select either value.
@@ -399,9 +401,15 @@ func (n *noOpSystemAnalysisConfigLoader) MergeConfig(base *domain.SystemAnalysis
 	merged.IncludeThirdParty = mergeOptional(merged.IncludeThirdParty, override.IncludeThirdParty)
 	merged.FollowRelative = mergeOptional(merged.FollowRelative, override.FollowRelative)
 	merged.DetectCycles = mergeOptional(merged.DetectCycles, override.DetectCycles)
+	merged.IncludeTypeChecking = mergeOptional(merged.IncludeTypeChecking, override.IncludeTypeChecking)
+	merged.CollapseFacades = mergeOptional(merged.CollapseFacades, override.CollapseFacades)
+	if override.CycleIgnoreEdgeKinds != nil {
+		merged.CycleIgnoreEdgeKinds = override.CycleIgnoreEdgeKinds
+	}
 	merged.ValidateArchitecture = mergeOptional(merged.ValidateArchitecture, override.ValidateArchitecture)
 	merged.ValidateCohesion = mergeOptional(merged.ValidateCohesion, override.ValidateCohesion)
 	merged.ValidateResponsibility = mergeOptional(merged.ValidateResponsibility, override.ValidateResponsibility)
+	merged.ValidateGodModules = mergeOptional(merged.ValidateGodModules, override.ValidateGodModules)
 	merged.Recursive = mergeOptional(merged.Recursive, override.Recursive)
 
 	// (Numeric overrides removed - fields no longer exist)
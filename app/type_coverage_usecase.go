@@ -0,0 +1,131 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// TypeCoverageUseCase orchestrates type annotation coverage analysis
+type TypeCoverageUseCase struct {
+	service      domain.TypeCoverageService
+	fileReader   domain.FileReader
+	configLoader domain.TypeCoverageConfigurationLoader
+}
+
+// NewTypeCoverageUseCase creates a new type coverage use case
+func NewTypeCoverageUseCase(
+	service domain.TypeCoverageService,
+	fileReader domain.FileReader,
+	configLoader domain.TypeCoverageConfigurationLoader,
+) *TypeCoverageUseCase {
+	return &TypeCoverageUseCase{
+		service:      service,
+		fileReader:   fileReader,
+		configLoader: configLoader,
+	}
+}
+
+// AnalyzeAndReturn measures type annotation coverage and returns the response without formatting
+func (uc *TypeCoverageUseCase) AnalyzeAndReturn(ctx context.Context, req domain.TypeCoverageRequest) (*domain.TypeCoverageResponse, error) {
+	finalReq, err := uc.loadAndMergeConfig(req)
+	if err != nil {
+		return nil, domain.NewConfigError("failed to load configuration", err)
+	}
+
+	if err := finalReq.Validate(); err != nil {
+		return nil, err
+	}
+
+	files, err := ResolveFilePaths(
+		uc.fileReader,
+		finalReq.Paths,
+		domain.BoolValue(finalReq.Recursive, true),
+		finalReq.IncludePatterns,
+		finalReq.ExcludePatterns,
+		false,
+	)
+	if err != nil {
+		return nil, domain.NewFileNotFoundError("failed to collect files", err)
+	}
+	if len(files) == 0 {
+		return nil, domain.NewInvalidInputError("no Python files found in the specified paths", nil)
+	}
+	finalReq.Paths = files
+
+	response, err := uc.service.Analyze(ctx, finalReq)
+	if err != nil {
+		return nil, domain.NewAnalysisError("type coverage analysis failed", err)
+	}
+
+	return response, nil
+}
+
+// loadAndMergeConfig loads configuration and merges it with the request
+func (uc *TypeCoverageUseCase) loadAndMergeConfig(req domain.TypeCoverageRequest) (domain.TypeCoverageRequest, error) {
+	if uc.configLoader == nil {
+		return req, nil
+	}
+
+	var configReq *domain.TypeCoverageRequest
+	var err error
+
+	if req.ConfigPath != "" {
+		configReq, err = uc.configLoader.LoadConfig(req.ConfigPath)
+		if err != nil {
+			return req, fmt.Errorf("failed to load config from %s: %w", req.ConfigPath, err)
+		}
+	} else {
+		configReq = uc.configLoader.LoadDefaultConfig()
+	}
+
+	if configReq != nil {
+		merged := uc.configLoader.MergeConfig(configReq, &req)
+		return *merged, nil
+	}
+
+	return req, nil
+}
+
+// TypeCoverageUseCaseBuilder provides a builder pattern for creating TypeCoverageUseCase
+type TypeCoverageUseCaseBuilder struct {
+	service      domain.TypeCoverageService
+	fileReader   domain.FileReader
+	configLoader domain.TypeCoverageConfigurationLoader
+}
+
+// NewTypeCoverageUseCaseBuilder creates a new builder
+func NewTypeCoverageUseCaseBuilder() *TypeCoverageUseCaseBuilder {
+	return &TypeCoverageUseCaseBuilder{}
+}
+
+// WithService sets the type coverage service
+func (b *TypeCoverageUseCaseBuilder) WithService(service domain.TypeCoverageService) *TypeCoverageUseCaseBuilder {
+	b.service = service
+	return b
+}
+
+// WithFileReader sets the file reader
+func (b *TypeCoverageUseCaseBuilder) WithFileReader(fileReader domain.FileReader) *TypeCoverageUseCaseBuilder {
+	b.fileReader = fileReader
+	return b
+}
+
+// WithConfigLoader sets the configuration loader
+func (b *TypeCoverageUseCaseBuilder) WithConfigLoader(configLoader domain.TypeCoverageConfigurationLoader) *TypeCoverageUseCaseBuilder {
+	b.configLoader = configLoader
+	return b
+}
+
+// Build creates the TypeCoverageUseCase with the configured dependencies
+func (b *TypeCoverageUseCaseBuilder) Build() (*TypeCoverageUseCase, error) {
+	if b.service == nil {
+		return nil, fmt.Errorf("type coverage service is required")
+	}
+	if b.fileReader == nil {
+		return nil, fmt.Errorf("file reader is required")
+	}
+
+	return NewTypeCoverageUseCase(b.service, b.fileReader, b.configLoader), nil
+}
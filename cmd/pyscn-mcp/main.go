@@ -26,6 +26,7 @@ func main() {
 		serverVersion,
 		mcpserver.WithToolCapabilities(true),
 		mcpserver.WithLogging(),
+		mcpserver.WithToolHandlerMiddleware(mcp.ProgressMiddleware),
 	)
 
 	configPath := os.Getenv("PYSCN_CONFIG")
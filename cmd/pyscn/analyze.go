@@ -5,15 +5,22 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"slices"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/ludo-technologies/pyscn/app"
 	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/config"
+	"github.com/ludo-technologies/pyscn/internal/i18n"
+	"github.com/ludo-technologies/pyscn/internal/tui"
 	"github.com/ludo-technologies/pyscn/internal/version"
 	"github.com/ludo-technologies/pyscn/service"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"golang.org/x/term"
 )
 
@@ -26,10 +33,25 @@ type AnalyzeCommand struct {
 	yaml   bool
 	noOpen bool
 
+	// GitHub check run payload, see service.BuildGitHubCheck
+	githubCheck bool
+
+	// URL template or editor preset for file links in HTML reports
+	linkTemplate string
+
+	// Language of the text and HTML reports
+	lang string
+
+	// Browse findings in a terminal UI instead of writing a report
+	interactive bool
+
 	// Configuration
 	configFile string
 	verbose    bool
 
+	// filesFrom names a file list to analyze ("-" = stdin)
+	filesFrom string
+
 	// Analysis selection
 	skipComplexity  bool
 	skipDeadCode    bool
@@ -46,6 +68,9 @@ type AnalyzeCommand struct {
 	cloneSimilarity float64
 	minCBO          int
 
+	// Source lines around dead code findings; -1 leaves it to the config
+	deadCodeContext int
+
 	// Complexity thresholds (0 = unset, use config/default)
 	lowThreshold                 int
 	mediumThreshold              int
@@ -54,10 +79,53 @@ type AnalyzeCommand struct {
 
 	// Clone detection options
 	enableDFA bool // Enable Data Flow Analysis for enhanced Type-4 detection
+	jobs      int  // Workers comparing clone candidates (0 = all CPUs)
+
+	// Clone report options
+	cloneMaxMembers    int    // Members listed per clone group (0 = config/default)
+	cloneCollapseFiles bool   // Fold clone group members sharing a file
+	cloneGroupBy       string // Clone report layout: group, file or package
+	showContent        bool   // Include clone source, shown as diffs between members
+	full               bool   // Keep every clone group member in the report
+
+	// Summary-only mode: aggregate statistics without per-finding detail
+	summaryOnly bool
+
+	// Run the analyses one after another to lower peak memory
+	serial bool
+
+	// Per-function complexity history (0 = [output] history_runs)
+	historyRuns int
 
 	// System analysis options
 	detectCycles bool // Detect circular dependencies
 	validateArch bool // Validate architecture rules
+
+	// Git integration
+	blame          bool   // Enrich findings with git blame metadata
+	byOwner        bool   // Group findings by CODEOWNERS owner
+	codeownersFile string // Explicit CODEOWNERS file (implies byOwner)
+	hotspots       bool   // Rank files by complexity weighted by git churn
+	churnDays      int    // Git history window for hotspots, in days
+
+	// Workspace options
+	workspace bool // Analyze each path as an independent project
+
+	// Remote targets
+	keepClone bool // Keep the temporary clones of git URL targets
+
+	// Analyze vendored code instead of listing it separately
+	includeVendored bool
+
+	// Report absolute paths instead of paths relative to the project root
+	absolutePaths bool
+
+	// List the files, analyses and settings without analyzing
+	dryRun bool
+
+	// Progress display: auto (a bar on terminals), none, or json events
+	progress     string
+	progressFile string // Where json progress events go instead of stderr
 }
 
 // NewAnalyzeCommand creates a new analyze command
@@ -80,9 +148,12 @@ func NewAnalyzeCommand() *AnalyzeCommand {
 		minSeverity:     "",
 		cloneSimilarity: 0,
 		minCBO:          0,
+		deadCodeContext: -1,
 		enableDFA:       true,
 		detectCycles:    true,
 		validateArch:    true,
+		churnDays:       domain.DefaultChurnWindowDays,
+		progress:        progressAuto,
 	}
 }
 
@@ -111,6 +182,12 @@ Examples:
   # Analyze specific files with JSON output
   pyscn analyze --json src/myfile.py
 
+  # Write HTML and JSON reports from one run
+  pyscn analyze --html --json src/
+
+  # Write the payload of a GitHub check run with annotated findings
+  pyscn analyze --github-check --no-open .
+
   # Skip clone detection, focus on complexity, dead code, and dependencies
   pyscn analyze --skip-clones src/
 
@@ -118,8 +195,43 @@ Examples:
   pyscn analyze --min-complexity 10 --min-severity critical --min-cbo 5 src/
 
   # Skip dependency analysis
-  pyscn analyze --skip-cbo src/`,
-		Args: cobra.MinimumNArgs(1),
+  pyscn analyze --skip-cbo src/
+
+  # Run only unreachable code and import cycle checks
+  pyscn analyze --select deadcode.unreachable,deps.cycles src/
+
+  # Annotate findings with git blame to route them to owners
+  pyscn analyze --blame --json src/
+
+  # Group findings and scores by CODEOWNERS team
+  pyscn analyze --by-owner .
+
+  # Rank complex files by how often they changed in the last 90 days
+  pyscn analyze --hotspots --churn-days 90 .
+
+  # Score vendored packages along with the project code
+  pyscn analyze --include-vendored .
+
+  # Analyze independent services as a workspace with per-target sections
+  pyscn analyze --workspace svc-a/ svc-b/
+
+  # Analyze a remote repository at a tag (shallow clone, removed afterwards)
+  pyscn analyze https://github.com/org/repo@v1.2.0
+
+  # Audit a release exactly as shipped (wheel, zip or sdist)
+  pyscn analyze dist/mypkg-1.0.0-py3-none-any.whl
+
+  # Analyze the Python files changed on this branch
+  git diff --name-only main | pyscn analyze --files-from -
+
+  # See which files and settings would be used, without analyzing
+  pyscn analyze --dry-run src/`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if c.filesFrom != "" {
+				return nil
+			}
+			return cobra.MinimumNArgs(1)(cmd, args)
+		},
 		RunE: c.runAnalyze,
 	}
 
@@ -128,8 +240,20 @@ Examples:
 	cmd.Flags().BoolVar(&c.json, "json", false, "Generate JSON report file")
 	cmd.Flags().BoolVar(&c.csv, "csv", false, "Generate CSV report file")
 	cmd.Flags().BoolVar(&c.yaml, "yaml", false, "Generate YAML report file")
+	cmd.Flags().BoolVar(&c.githubCheck, "github-check", false, "Generate a GitHub check run payload (title, Markdown summary, up to 50 annotations) as JSON")
 	cmd.Flags().BoolVar(&c.noOpen, "no-open", false, "Don't auto-open HTML in browser")
+	cmd.Flags().BoolVar(&c.absolutePaths, "absolute-paths", false, "Report absolute file paths instead of paths relative to the project root")
+	cmd.Flags().StringVar(&c.lang, "lang", "", fmt.Sprintf("Language of text and HTML reports: %s (default from [output] lang, else en)", strings.Join(i18n.Languages(), ", ")))
+	cmd.Flags().StringVar(&c.linkTemplate, "link-template", "", "Link file references in HTML reports: vscode, cursor, pycharm, idea, or a URL template with {path}, {relpath}, {line}")
+	cmd.Flags().BoolVarP(&c.interactive, "interactive", "i", false, "Browse findings in an interactive terminal UI")
 	cmd.Flags().StringVarP(&c.configFile, "config", "c", "", "Configuration file path")
+	cmd.Flags().StringVar(&c.filesFrom, "files-from", "", "Analyze the files listed in this file, one per line or NUL-separated (- = stdin), without walking directories")
+	cmd.Flags().BoolVar(&c.keepClone, "keep-clone", false, "Keep the temporary clone of git URL targets after the analysis")
+	cmd.Flags().BoolVar(&c.dryRun, "dry-run", false, "Print the files, analyses and effective settings that would be used, without analyzing")
+	cmd.Flags().StringVar(&c.progress, "progress", progressAuto, "Progress display: auto (bar on terminals), none, or json (NDJSON events on stderr or --progress-file)")
+	cmd.Flags().StringVar(&c.progressFile, "progress-file", "", "Write --progress json events to this file or named pipe instead of stderr")
+	_ = cmd.RegisterFlagCompletionFunc("progress", completeValues(progressAuto, progressNone, progressJSON))
+	cmd.Flags().BoolVar(&c.includeVendored, "include-vendored", false, "Analyze and score vendored code (vendor/, _vendor/, third_party/, \"# vendored\" packages) instead of listing it separately")
 
 	// Analysis selection flags
 	cmd.Flags().BoolVar(&c.skipComplexity, "skip-complexity", false, "Skip complexity analysis")
@@ -139,13 +263,24 @@ Examples:
 	cmd.Flags().BoolVar(&c.skipLCOM, "skip-lcom", false, "Skip class cohesion (LCOM4) analysis")
 	cmd.Flags().BoolVar(&c.skipSystem, "skip-deps", false, "Skip module dependencies and architecture analysis")
 	cmd.Flags().BoolVar(&c.skipCommunities, "skip-communities", false, "Skip module community detection")
-	cmd.Flags().StringSliceVar(&c.selectAnalyses, "select", []string{}, "Only run specified analyses (complexity,deadcode,clones,cbo,lcom,deps,communities)")
+	cmd.Flags().StringSliceVar(&c.selectAnalyses, "select", []string{}, "Only run specified analyses (complexity,deadcode,clones,cbo,lcom,deps,communities,documentation,typing) or rules (e.g. deadcode.unreachable,clones.type1,deps.cycles)")
 
 	// Quick filter flags
 	cmd.Flags().IntVar(&c.minComplexity, "min-complexity", 0, "Minimum complexity to report (default: 1)")
 	cmd.Flags().StringVar(&c.minSeverity, "min-severity", "", "Minimum dead code severity: critical, warning, info (default: warning)")
 	cmd.Flags().Float64Var(&c.cloneSimilarity, "clone-threshold", 0, "Minimum similarity for clone detection, 0.0-1.0 (default: 0.65)")
 	cmd.Flags().IntVar(&c.minCBO, "min-cbo", 0, "Minimum CBO to report")
+	cmd.Flags().IntVar(&c.deadCodeContext, "dead-code-context", -1, "Attach N source lines before and after each dead code finding to JSON and YAML reports; 0 turns it off (default: [dead_code] show_context and context_lines)")
+	cmd.Flags().IntVarP(&c.jobs, "jobs", "j", 0, "Workers comparing clone candidates (default: all CPUs)")
+
+	// Clone report flags
+	cmd.Flags().IntVar(&c.cloneMaxMembers, "clone-max-members", 0, "Members listed per clone group in the report (default: all)")
+	cmd.Flags().BoolVar(&c.cloneCollapseFiles, "clone-collapse-files", false, "List one member per file in each clone group")
+	cmd.Flags().StringVar(&c.cloneGroupBy, "clone-group-by", "", "Clone report layout: group, file, package (default: group)")
+	cmd.Flags().BoolVar(&c.showContent, "show-content", false, "Show what differs between clone members as diffs of their source")
+	cmd.Flags().BoolVar(&c.full, "full", false, "Keep every clone group member in the report, ignoring member caps and collapsing")
+	cmd.Flags().BoolVar(&c.serial, "serial", false, "Run the analyses one after another instead of all at once, lowering peak memory (default: [analysis] serial)")
+	cmd.Flags().BoolVar(&c.summaryOnly, "summary", false, "Only compute aggregate statistics and the health score, without per-finding detail; prints the summary unless --json, --yaml or --csv is set")
 
 	// Complexity threshold flags (0 = unset, use config file or default)
 	cmd.Flags().IntVar(&c.lowThreshold, "low-threshold", 0, "Upper bound for low-risk complexity (default: 9)")
@@ -153,6 +288,19 @@ Examples:
 	cmd.Flags().IntVar(&c.cognitiveComplexityThreshold, "cognitive-complexity-threshold", 0, "High-risk threshold for cognitive complexity (default: 25)")
 	cmd.Flags().IntVar(&c.nestingDepthThreshold, "nesting-depth-threshold", 0, "High-risk threshold for maximum nesting depth (default: 7)")
 
+	// Workspace flags
+	cmd.Flags().BoolVar(&c.workspace, "workspace", false, "Analyze each path as an independent project and report per-target results with a combined overview")
+
+	// Git integration flags
+	cmd.Flags().BoolVar(&c.blame, "blame", false, "Annotate high-complexity and dead code findings with git blame (last author, date, age)")
+	cmd.Flags().BoolVar(&c.byOwner, "by-owner", false, "Group findings and per-package scores by CODEOWNERS owner")
+	cmd.Flags().StringVar(&c.codeownersFile, "codeowners", "", "CODEOWNERS file to use for --by-owner (default: discovered from the repository)")
+	cmd.Flags().BoolVar(&c.hotspots, "hotspots", false, "Rank files by complexity weighted by how often they changed in git history")
+	cmd.Flags().IntVar(&c.churnDays, "churn-days", domain.DefaultChurnWindowDays, "Git history window for --hotspots, in days")
+
+	// History flags
+	cmd.Flags().IntVar(&c.historyRuns, "history-runs", 0, "Record the complexity of each function over the last N runs and show its trend in HTML reports (default: [output] history_runs)")
+
 	return cmd
 }
 
@@ -175,8 +323,116 @@ func (c *AnalyzeCommand) runAnalyze(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid --min-severity value %q (expected: critical, warning, info)", c.minSeverity)
 	}
 
+	if c.deadCodeContext < -1 || c.deadCodeContext > domain.MaxDeadCodeContextLines {
+		return fmt.Errorf("invalid --dead-code-context value %d (expected: 0-%d)", c.deadCodeContext, domain.MaxDeadCodeContextLines)
+	}
+
+	if c.churnDays <= 0 {
+		return fmt.Errorf("invalid --churn-days value %d (must be positive)", c.churnDays)
+	}
+
+	if c.cloneMaxMembers < 0 {
+		return fmt.Errorf("invalid --clone-max-members value %d (must not be negative)", c.cloneMaxMembers)
+	}
+
+	if c.jobs < 0 {
+		return fmt.Errorf("invalid --jobs value %d (must not be negative)", c.jobs)
+	}
+
+	if c.historyRuns < 0 {
+		return fmt.Errorf("invalid --history-runs value %d (must not be negative)", c.historyRuns)
+	}
+
+	switch c.cloneGroupBy {
+	case "", domain.CloneReportGroupByGroup, domain.CloneReportGroupByFile, domain.CloneReportGroupByPackage:
+	default:
+		return fmt.Errorf("invalid --clone-group-by value %q (expected: group, file, package)", c.cloneGroupBy)
+	}
+
+	if c.lang != "" {
+		if _, err := i18n.ParseLanguage(c.lang); err != nil {
+			return fmt.Errorf("invalid --lang flag: %w", err)
+		}
+	}
+
+	if c.linkTemplate != "" {
+		if _, err := service.NewSourceLinker(c.linkTemplate, "."); err != nil {
+			return fmt.Errorf("invalid --link-template flag: %w", err)
+		}
+	}
+
+	if c.dryRun {
+		if err := c.validateDryRun(args); err != nil {
+			return err
+		}
+	}
+
+	switch c.progress {
+	case progressAuto, progressNone:
+		if c.progressFile != "" {
+			return fmt.Errorf("--progress-file requires --progress json")
+		}
+	case progressJSON:
+	default:
+		return fmt.Errorf("invalid --progress %q: must be %s, %s or %s", c.progress, progressAuto, progressNone, progressJSON)
+	}
+
+	if c.summaryOnly && (c.html || c.interactive) {
+		return fmt.Errorf("--summary cannot be combined with --html or --interactive")
+	}
+
+	if c.interactive {
+		if service.IsMachineMode() {
+			return fmt.Errorf("--interactive cannot be combined with --ci")
+		}
+		if c.html || c.json || c.csv || c.yaml || c.githubCheck {
+			return fmt.Errorf("--interactive cannot be combined with report format flags")
+		}
+		if c.filesFrom == filesFromStdin {
+			return fmt.Errorf("--interactive cannot be combined with --files-from -")
+		}
+		if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+			return tui.ErrNotTerminal
+		}
+	}
+
+	if c.filesFrom != "" {
+		files, err := readFilesFrom(c.filesFrom, cmd.InOrStdin(), cmd.ErrOrStderr())
+		if err != nil {
+			return err
+		}
+		if len(files) == 0 && len(args) == 0 {
+			return fmt.Errorf("no Python files in --files-from list %s", c.filesFrom)
+		}
+		args = append(args, files...)
+	}
+
+	targets := args
+	args, cleanupClones, err := c.cloneRemoteTargets(cmd, args)
+	if err != nil {
+		return err
+	}
+	defer cleanupClones()
+
 	// Create use case configuration
 	config := c.createUseCaseConfig()
+	if limits, err := resourceLimitsFromFlags(cmd); err == nil && limits.MaxMemoryBytes > 0 {
+		// Every analysis holds its own intermediate state, so run only as
+		// many at once as the memory limit has room for
+		config.MaxParallelTasks = limits.Workers()
+	}
+
+	if c.dryRun {
+		useCase, err := c.buildAnalyzeUseCase(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to build analyze use case: %w", err)
+		}
+		return c.runDryRun(cmd, useCase, config, args)
+	}
+
+	if err := c.configureHistory(&config, args); err != nil {
+		return err
+	}
 
 	// Build the analyze use case
 	useCase, err := c.buildAnalyzeUseCase(cmd)
@@ -184,17 +440,50 @@ func (c *AnalyzeCommand) runAnalyze(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to build analyze use case: %w", err)
 	}
 
-	// Execute analysis with timeout and cancellation support
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Minute)
+	// Execute analysis with timeout and cancellation support. Ctrl-C stops
+	// the analyses and reports what they finished; a second Ctrl-C exits
+	// immediately.
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	context.AfterFunc(ctx, stop)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
 	defer cancel()
+	if c.progress == progressJSON {
+		stream, closeStream, err := c.openProgressStream(cmd)
+		if err != nil {
+			return err
+		}
+		defer closeStream()
+		ctx = domain.WithProgressReporter(ctx, stream)
+	}
 	response, analysisErr := useCase.Execute(ctx, config, args)
+	if response != nil {
+		stampManifest(cmd, response, targets)
+	}
+
+	if c.interactive && response != nil {
+		if err := tui.Run(os.Stdin, os.Stdout, service.CollectFindings(response)); err != nil {
+			return err
+		}
+		return analysisErr
+	}
 
 	// Generate output even if there were partial failures
 	var outputErr error
 	if response != nil {
-		// Generate output
-		if err := c.generateOutput(cmd, response, args); err != nil {
-			outputErr = err
+		// Generate output; --summary without a format flag prints the
+		// summary only
+		if !c.summaryOnly || c.json || c.yaml || c.csv || c.githubCheck {
+			if err := c.generateOutput(cmd, response, args); err != nil {
+				outputErr = err
+			}
+		}
+
+		// Partial results would show up as a drop on dashboards
+		if !response.Cancelled {
+			if err := c.exportMetrics(cmd, response, args); err != nil && outputErr == nil {
+				outputErr = err
+			}
 		}
 
 		// Print summary
@@ -223,6 +512,22 @@ func (c *AnalyzeCommand) createUseCaseConfig() app.AnalyzeUseCaseConfig {
 		CloneSimilarity:         c.cloneSimilarity,
 		MinCBO:                  c.minCBO,
 		EnableDFA:               c.enableDFA,
+		CloneJobs:               c.jobs,
+		CloneMaxGroupMembers:    c.cloneMaxMembers,
+		CloneCollapseSameFile:   c.cloneCollapseFiles,
+		CloneReportGroupBy:      c.cloneGroupBy,
+		CloneShowContent:        c.showContent,
+		FullReport:              c.full,
+		SummaryOnly:             c.summaryOnly,
+		Serial:                  c.serial,
+		EnableBlame:             c.blame,
+		GroupByOwner:            c.byOwner,
+		CodeownersFile:          c.codeownersFile,
+		EnableHotspots:          c.hotspots,
+		ChurnWindowDays:         c.churnDays,
+		Workspace:               c.workspace,
+		IncludeVendored:         c.includeVendored,
+		AbsolutePaths:           c.absolutePaths || c.interactive, // the TUI opens files by their reported path
 		SkipCommunities:         false,
 		SkipCommunitiesExplicit: c.skipCommunities,
 
@@ -265,6 +570,11 @@ func (c *AnalyzeCommand) createUseCaseConfig() app.AnalyzeUseCaseConfig {
 		config.MinSeverity = domain.DeadCodeSeverityInfo
 	}
 
+	if c.deadCodeContext >= 0 {
+		config.DeadCodeShowContext = domain.BoolPtr(c.deadCodeContext > 0)
+		config.DeadCodeContextLines = c.deadCodeContext
+	}
+
 	return config
 }
 
@@ -407,29 +717,72 @@ func (c *AnalyzeCommand) buildIndividualUseCases(builder *app.AnalyzeUseCaseBuil
 	}
 	builder.WithCommunityUseCase(communityUseCase)
 
+	// Docstring coverage use case
+	documentationUseCase, err := app.NewDocumentationUseCaseBuilder().
+		WithService(service.NewDocumentationService()).
+		WithFileReader(service.NewFileReader()).
+		WithConfigLoader(service.NewDocumentationConfigurationLoader()).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to build documentation use case: %w", err)
+	}
+	builder.WithDocumentationUseCase(documentationUseCase)
+
+	// Type annotation coverage use case
+	typeCoverageUseCase, err := app.NewTypeCoverageUseCaseBuilder().
+		WithService(service.NewTypeCoverageService()).
+		WithFileReader(service.NewFileReader()).
+		WithConfigLoader(service.NewTypeCoverageConfigurationLoader()).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to build type coverage use case: %w", err)
+	}
+	builder.WithTypeCoverageUseCase(typeCoverageUseCase)
+
 	return nil
 }
 
-// generateOutput generates the output report
+// generateOutput writes one report per requested format. All reports are
+// rendered from the same response and share a timestamped file name.
 func (c *AnalyzeCommand) generateOutput(cmd *cobra.Command, response *domain.AnalyzeResponse, args []string) error {
-	// Determine output format
-	format, extension, err := c.determineOutputFormat()
-	if err != nil {
-		return err
-	}
+	formats := c.determineOutputFormats()
 
 	// Generate filename with timestamp
 	targetPath := getTargetPathFromArgs(args)
-	filename, err := generateOutputFilePath("analyze", extension, targetPath)
+	filename, err := generateOutputFilePath("analyze", reportExtension(formats[0]), targetPath)
 	if err != nil {
 		return fmt.Errorf("failed to generate output path: %w", err)
 	}
+	base := strings.TrimSuffix(filename, reportExtension(formats[0]))
 
 	// Add version to response
 	response.Version = version.Version
 
 	// Create formatter
 	formatter := service.NewAnalyzeFormatter()
+	lang, err := c.resolveLanguage(args)
+	if err != nil {
+		return err
+	}
+	formatter.SetLanguage(lang)
+
+	for _, format := range formats {
+		if err := c.writeReport(cmd, formatter, response, format, base+reportExtension(format), args); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeReport writes the report in one format to filename
+func (c *AnalyzeCommand) writeReport(cmd *cobra.Command, formatter *service.AnalyzeFormatter, response *domain.AnalyzeResponse, format, filename string, args []string) error {
+	if format == "html" {
+		linker, err := c.resolveSourceLinker(args)
+		if err != nil {
+			return err
+		}
+		formatter.SetSourceLinker(linker)
+	}
 
 	// Create output file
 	file, err := os.Create(filename)
@@ -440,7 +793,7 @@ func (c *AnalyzeCommand) generateOutput(cmd *cobra.Command, response *domain.Ana
 
 	// Write standalone community JSON when only communities were selected.
 	formatType := domain.OutputFormat(format)
-	if c.shouldWriteStandaloneCommunityJSON(response) {
+	if c.shouldWriteStandaloneCommunityJSON(response, formatType) {
 		communityFormatter := service.NewCommunityFormatter()
 		if err := communityFormatter.Write(response.Communities, formatType, file); err != nil {
 			return fmt.Errorf("failed to write community analysis report: %w", err)
@@ -493,9 +846,16 @@ func getScoreIcon(score int) string {
 // printSummary prints a summary of the analysis results
 func (c *AnalyzeCommand) printSummary(cmd *cobra.Command, response *domain.AnalyzeResponse) {
 	fmt.Fprintf(cmd.ErrOrStderr(), "\n📊 Analysis Summary:\n")
+	if response.Cancelled {
+		fmt.Fprintf(cmd.ErrOrStderr(), "⚠️  Analysis was cancelled: results are partial\n")
+	}
 	fmt.Fprintf(cmd.ErrOrStderr(), "Health Score: %d/100 (Grade: %s)\n", response.Summary.HealthScore, response.Summary.Grade)
 	fmt.Fprintf(cmd.ErrOrStderr(), "Total time: %dms\n\n", response.Duration)
 
+	if c.verbose && response.Manifest != nil && len(response.Manifest.Timings) > 0 {
+		c.printTimings(cmd, response.Manifest.Timings)
+	}
+
 	// Print detailed scores section
 	fmt.Fprintf(cmd.ErrOrStderr(), "📈 Detailed Scores:\n")
 
@@ -552,12 +912,53 @@ func (c *AnalyzeCommand) printSummary(cmd *cobra.Command, response *domain.Analy
 			response.Summary.ArchCompliance*100)
 	}
 
+	if response.Summary.DocumentationScore != nil {
+		icon := getScoreIcon(*response.Summary.DocumentationScore)
+		fmt.Fprintf(cmd.ErrOrStderr(), "  Documentation:  %3d/100 %s  (%d undocumented)\n",
+			*response.Summary.DocumentationScore, icon,
+			*response.Summary.UndocumentedItems)
+	}
+
+	if response.Summary.TypednessScore != nil {
+		icon := getScoreIcon(*response.Summary.TypednessScore)
+		fmt.Fprintf(cmd.ErrOrStderr(), "  Typedness:      %3d/100 %s  (%d unannotated public functions)\n",
+			*response.Summary.TypednessScore, icon,
+			*response.Summary.UnannotatedPublicFunctions)
+	}
+
+	if response.Workspace != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "\n🗂️  Workspace Targets:\n")
+		for _, target := range response.Workspace.Targets {
+			if target.Result == nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "  %-30s failed: %s\n", target.Path, target.Error)
+				continue
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "  %-30s %3d/100 (Grade: %s, %d files)\n",
+				target.Path, target.Result.Summary.HealthScore, target.Result.Summary.Grade, target.Result.Summary.TotalFiles)
+		}
+	}
+
 	fmt.Fprintf(cmd.ErrOrStderr(), "\n")
 
 	// Print README badge snippet
 	c.printBadge(cmd, response.Summary.Grade)
 }
 
+// printTimings prints what each phase of the run cost
+func (c *AnalyzeCommand) printTimings(cmd *cobra.Command, timings []domain.AnalyzerTiming) {
+	fmt.Fprintf(cmd.ErrOrStderr(), "⏱️  Timings:\n")
+	fmt.Fprintf(cmd.ErrOrStderr(), "  %-15s %9s %7s %12s %11s\n", "Phase", "Time", "Files", "Parsed", "Goroutines")
+	for _, timing := range timings {
+		parsed := "-"
+		if timing.BytesParsed > 0 {
+			parsed = fmt.Sprintf("%.1f KB", float64(timing.BytesParsed)/1024)
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "  %-15s %7dms %7d %12s %11d\n",
+			timing.Name, timing.WallTimeMs, timing.FilesProcessed, parsed, timing.PeakGoroutines)
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "\n")
+}
+
 const badgeLandingURL = "https://pyscn.ludo-tech.org"
 
 // printBadge prints a Markdown badge snippet for the user's README
@@ -592,49 +993,242 @@ func gradeBadgeColor(grade string) string {
 
 // Helper methods
 
-// determineOutputFormat determines the output format based on flags
-func (c *AnalyzeCommand) determineOutputFormat() (string, string, error) {
-	formatCount := 0
-	var format string
-	var extension string
-
+// determineOutputFormats returns the report formats requested by the format
+// flags, which may be combined
+func (c *AnalyzeCommand) determineOutputFormats() []string {
+	var formats []string
 	if c.html {
-		formatCount++
-		format = "html"
-		extension = "html"
+		formats = append(formats, "html")
 	}
 	if c.json {
-		formatCount++
-		format = "json"
-		extension = "json"
+		formats = append(formats, "json")
 	}
 	if c.csv {
-		formatCount++
-		format = "csv"
-		extension = "csv"
+		formats = append(formats, "csv")
 	}
 	if c.yaml {
-		formatCount++
-		format = "yaml"
-		extension = "yaml"
+		formats = append(formats, "yaml")
 	}
-
-	// Check for conflicting flags
-	if formatCount > 1 {
-		return "", "", fmt.Errorf("only one output format flag can be specified")
+	if c.githubCheck {
+		formats = append(formats, string(domain.OutputFormatGitHubCheck))
 	}
 
 	// Default to HTML if no format specified
-	if formatCount == 0 {
-		return "html", "html", nil
+	if len(formats) == 0 {
+		return []string{"html"}
+	}
+	return formats
+}
+
+// reportExtension returns the file extension of a report format: the format
+// name, except for the GitHub check payload, which is JSON
+func reportExtension(format string) string {
+	if format == string(domain.OutputFormatGitHubCheck) {
+		return "github-check.json"
+	}
+	return format
+}
+
+// cloneRemoteTargets replaces git URL arguments (url[@ref]) with shallow
+// clones, and wheel, zip and sdist arguments with their extracted sources,
+// in temporary directories. The returned cleanup removes them, keeping the
+// clones when --keep-clone is set.
+func (c *AnalyzeCommand) cloneRemoteTargets(cmd *cobra.Command, args []string) ([]string, func(), error) {
+	resolved := make([]string, len(args))
+	var tempDirs, cloneDirs []string
+	removeAll := func() {
+		for _, dir := range tempDirs {
+			_ = os.RemoveAll(dir)
+		}
+	}
+
+	for i, arg := range args {
+		resolved[i] = arg
+		if service.IsArchiveTarget(arg) {
+			tempDir, err := os.MkdirTemp("", "pyscn-archive-")
+			if err != nil {
+				removeAll()
+				return nil, nil, fmt.Errorf("failed to create extraction directory: %w", err)
+			}
+			tempDirs = append(tempDirs, tempDir)
+
+			dir := filepath.Join(tempDir, service.ArchiveTargetName(arg))
+			fmt.Fprintf(cmd.ErrOrStderr(), "Extracting %s...\n", arg)
+			if err := service.ExtractArchiveTarget(arg, dir); err != nil {
+				removeAll()
+				return nil, nil, err
+			}
+			resolved[i] = dir
+			continue
+		}
+
+		target, ok := service.ParseRemoteTarget(arg)
+		if !ok {
+			continue
+		}
+		if _, err := os.Stat(arg); err == nil {
+			continue // A local path that happens to look like an scp-style URL
+		}
+
+		tempDir, err := os.MkdirTemp("", "pyscn-clone-")
+		if err != nil {
+			removeAll()
+			return nil, nil, fmt.Errorf("failed to create clone directory: %w", err)
+		}
+		tempDirs = append(tempDirs, tempDir)
+
+		dir := filepath.Join(tempDir, target.Name())
+		fmt.Fprintf(cmd.ErrOrStderr(), "Cloning %s...\n", target)
+		if err := service.CloneRemoteTarget(cmd.Context(), target, dir); err != nil {
+			removeAll()
+			return nil, nil, err
+		}
+		resolved[i] = dir
+		cloneDirs = append(cloneDirs, dir)
 	}
 
-	return format, extension, nil
+	cleanup := func() {
+		if !c.keepClone {
+			removeAll()
+			return
+		}
+		for _, dir := range tempDirs {
+			if !slices.ContainsFunc(cloneDirs, func(clone string) bool { return filepath.Dir(clone) == dir }) {
+				_ = os.RemoveAll(dir)
+			}
+		}
+		for _, dir := range cloneDirs {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Clone kept at %s\n", dir)
+		}
+	}
+	return resolved, cleanup, nil
+}
+
+// stampManifest adds what only the CLI knows to the report manifests: the
+// pyscn version, the explicitly set flags and the targets as given, e.g.
+// git URLs rather than the temporary clones analyzed in their place
+func stampManifest(cmd *cobra.Command, response *domain.AnalyzeResponse, targets []string) {
+	flags := make(map[string]string)
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		flags[f.Name] = f.Value.String()
+	})
+	stamp := func(manifest *domain.AnalysisManifest) {
+		if manifest == nil {
+			return
+		}
+		manifest.PyscnVersion = version.Version
+		if len(flags) > 0 {
+			manifest.Flags = flags
+		}
+	}
+
+	stamp(response.Manifest)
+	if response.Manifest != nil {
+		response.Manifest.Targets = append([]string(nil), targets...)
+	}
+	if response.Workspace != nil {
+		for _, target := range response.Workspace.Targets {
+			if target.Result != nil {
+				stamp(target.Result.Manifest)
+			}
+		}
+	}
+}
+
+// resolveSourceLinker builds the HTML report's file linker from the
+// --link-template flag or the [output] link_template setting. It returns
+// nil when neither is set.
+func (c *AnalyzeCommand) resolveSourceLinker(paths []string) (*service.SourceLinker, error) {
+	linkTemplate := c.linkTemplate
+	if linkTemplate == "" {
+		cfg, err := config.LoadConfigWithTarget(c.configFile, getTargetPathFromArgs(paths))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load configuration: %w", err)
+		}
+		if cfg != nil {
+			linkTemplate = cfg.Output.LinkTemplate
+		}
+	}
+	if linkTemplate == "" {
+		return nil, nil
+	}
+
+	linker, err := service.NewSourceLinker(linkTemplate, service.ReportRoot(paths))
+	if err != nil {
+		return nil, fmt.Errorf("invalid link_template setting: %w", err)
+	}
+	return linker, nil
+}
+
+// resolveLanguage returns the report language from the --lang flag or the
+// [output] lang setting, English when neither is set
+func (c *AnalyzeCommand) resolveLanguage(paths []string) (string, error) {
+	lang := c.lang
+	if lang == "" {
+		cfg, err := config.LoadConfigWithTarget(c.configFile, getTargetPathFromArgs(paths))
+		if err != nil {
+			return "", fmt.Errorf("failed to load configuration: %w", err)
+		}
+		if cfg != nil {
+			lang = cfg.Output.Lang
+		}
+	}
+	code, err := i18n.ParseLanguage(lang)
+	if err != nil {
+		return "", fmt.Errorf("invalid lang setting: %w", err)
+	}
+	return code, nil
+}
+
+// exportMetrics writes the key metrics of the run to the [output]
+// metrics_file and pushes them to the [output] metrics_pushgateway, when set
+func (c *AnalyzeCommand) exportMetrics(cmd *cobra.Command, response *domain.AnalyzeResponse, paths []string) error {
+	cfg, err := config.LoadConfigWithTarget(c.configFile, getTargetPathFromArgs(paths))
+	if err != nil || cfg == nil {
+		return nil // Reported by the analysis already
+	}
+	if cfg.Output.MetricsFile != "" {
+		if err := service.WriteOpenMetricsFile(cfg.Output.MetricsFile, response); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "📈 Metrics written: %s\n", cfg.Output.MetricsFile)
+	}
+	if cfg.Output.MetricsPushgateway != "" {
+		if err := service.PushMetrics(cmd.Context(), cfg.Output.MetricsPushgateway, cfg.Output.MetricsJob, response); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "📈 Metrics pushed to %s\n", cfg.Output.MetricsPushgateway)
+	}
+	return nil
+}
+
+// configureHistory points the use case at the complexity history in the
+// report directory when --history-runs or [output] history_runs is set
+func (c *AnalyzeCommand) configureHistory(useCaseCfg *app.AnalyzeUseCaseConfig, paths []string) error {
+	runs := c.historyRuns
+	if runs == 0 {
+		cfg, err := config.LoadConfigWithTarget(c.configFile, getTargetPathFromArgs(paths))
+		if err != nil || cfg == nil {
+			return nil // Reported by the analysis
+		}
+		runs = cfg.Output.HistoryRuns
+	}
+	if runs <= 0 {
+		return nil
+	}
+	outputDir, err := resolveOutputDirectory(getTargetPathFromArgs(paths))
+	if err != nil {
+		return err
+	}
+	useCaseCfg.HistoryFile = filepath.Join(outputDir, domain.ComplexityHistoryFileName)
+	useCaseCfg.HistoryRuns = runs
+	return nil
 }
 
 // shouldUseProgressBars returns true when the session appears to be interactive
+// and --progress leaves the display to pyscn
 func (c *AnalyzeCommand) shouldUseProgressBars(cmd *cobra.Command) bool {
-	if !service.IsInteractiveEnvironment() {
+	if c.progress != progressAuto || !service.IsInteractiveEnvironment() {
 		return false
 	}
 
@@ -646,9 +1240,11 @@ func (c *AnalyzeCommand) shouldUseProgressBars(cmd *cobra.Command) bool {
 }
 
 // shouldWriteStandaloneCommunityJSON returns true when community analysis is the
-// only selected analyzer and JSON output is requested.
-func (c *AnalyzeCommand) shouldWriteStandaloneCommunityJSON(response *domain.AnalyzeResponse) bool {
-	return c.json &&
+// only selected analyzer and the report being written is the JSON one. The
+// YAML and CSV reports of such a run keep the unified layout, which embeds
+// the communities, even next to --json.
+func (c *AnalyzeCommand) shouldWriteStandaloneCommunityJSON(response *domain.AnalyzeResponse, format domain.OutputFormat) bool {
+	return format == domain.OutputFormatJSON &&
 		len(c.selectAnalyses) == 1 &&
 		c.containsAnalysis("communities") &&
 		response != nil &&
@@ -665,20 +1261,24 @@ func (c *AnalyzeCommand) containsAnalysis(analysis string) bool {
 	return false
 }
 
+// validateSelectedAnalyses accepts analysis names and rule IDs from the
+// rule registry
 func (c *AnalyzeCommand) validateSelectedAnalyses() error {
-	validAnalyses := map[string]bool{
-		"complexity":  true,
-		"deadcode":    true,
-		"clones":      true,
-		"cbo":         true,
-		"lcom":        true,
-		"deps":        true,
-		"communities": true,
-	}
 	for _, analysis := range c.selectAnalyses {
-		if !validAnalyses[strings.ToLower(analysis)] {
-			return fmt.Errorf("invalid analysis type: %s. Valid options: complexity, deadcode, clones, cbo, lcom, deps, communities", analysis)
+		if domain.IsSelectableAnalysis(analysis) {
+			continue
+		}
+		if _, ok := domain.LookupAnalysisRule(analysis); ok {
+			continue
+		}
+		var rules []string
+		for _, rule := range domain.AnalysisRules() {
+			if len(rule.DeadCodeReasons) != 1 {
+				rules = append(rules, rule.ID)
+			}
 		}
+		return fmt.Errorf("invalid analysis type: %s. Valid options: %s, or a rule: %s (single dead code reasons as deadcode.<reason>)",
+			analysis, strings.Join(domain.SelectableAnalyses, ", "), strings.Join(rules, ", "))
 	}
 	return nil
 }
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/app"
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/config"
+	"github.com/ludo-technologies/pyscn/service"
+	"github.com/spf13/cobra"
+)
+
+// planSettingKeys are the configuration keys, or key prefixes ending in a
+// dot, whose effective values are listed under each analysis of a dry run
+var planSettingKeys = map[string][]string{
+	domain.AnalysisScopeComplexity:    {"complexity.", "output.min_complexity"},
+	domain.AnalysisScopeDeadCode:      {"dead_code."},
+	domain.AnalysisScopeClones:        {"clones."},
+	domain.AnalysisScopeCBO:           {"cbo."},
+	domain.AnalysisScopeLCOM:          {"lcom."},
+	domain.AnalysisScopeDependencies:  {"dependencies.", "architecture."},
+	domain.AnalysisScopeCommunities:   {"communities."},
+	domain.AnalysisScopeDocumentation: {"documentation."},
+	domain.AnalysisScopeTyping:        {"typing."},
+}
+
+// validateDryRun rejects the flags that have no meaning without an analysis
+func (c *AnalyzeCommand) validateDryRun(args []string) error {
+	switch {
+	case c.html || c.csv || c.githubCheck:
+		return fmt.Errorf("--dry-run cannot be combined with --html, --csv or --github-check; use --json or --yaml for a machine-readable plan")
+	case c.interactive:
+		return fmt.Errorf("--dry-run cannot be combined with --interactive")
+	case c.workspace && len(args) > 1:
+		return fmt.Errorf("--dry-run cannot be combined with --workspace")
+	}
+	return nil
+}
+
+// runDryRun prints the files analyze would read, the analyses it would run
+// and their effective settings, without analyzing anything
+func (c *AnalyzeCommand) runDryRun(cmd *cobra.Command, useCase *app.AnalyzeUseCase, useCaseCfg app.AnalyzeUseCaseConfig, paths []string) error {
+	plan, err := useCase.Plan(useCaseCfg, paths)
+	if err != nil {
+		return err
+	}
+
+	inspection, err := config.InspectConfig(plan.ConfigPath, analyzeFlagOverrides(cmd))
+	if err != nil {
+		return fmt.Errorf("failed to read configuration from %s: %w", plan.ConfigPath, err)
+	}
+	for i := range plan.Analyses {
+		analysis := &plan.Analyses[i]
+		if analysis.Enabled {
+			analysis.Settings = planSettings(inspection.Settings, planSettingKeys[analysis.Section])
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	switch {
+	case c.json:
+		return service.WriteJSON(out, plan)
+	case c.yaml:
+		return service.WriteYAML(out, plan)
+	}
+	writeAnalyzePlan(out, plan)
+	return nil
+}
+
+// planSettings returns the settings matching keys, in inspection order
+func planSettings(settings []config.EffectiveSetting, keys []string) []domain.PlannedSetting {
+	var planned []domain.PlannedSetting
+	for _, setting := range settings {
+		for _, key := range keys {
+			if setting.Key == key || (strings.HasSuffix(key, ".") && strings.HasPrefix(setting.Key, key)) {
+				planned = append(planned, domain.PlannedSetting{
+					Key:    setting.Key,
+					Value:  setting.Value,
+					Source: string(setting.Source),
+				})
+				break
+			}
+		}
+	}
+	return planned
+}
+
+// writeAnalyzePlan prints a dry run plan as text
+func writeAnalyzePlan(w io.Writer, plan *domain.AnalyzePlan) {
+	fmt.Fprintln(w, "Dry run: nothing was analyzed")
+	fmt.Fprintln(w)
+	if plan.ConfigPath != "" {
+		fmt.Fprintf(w, "Configuration:    %s\n", plan.ConfigPath)
+	} else {
+		fmt.Fprintln(w, "Configuration:    none found; using built-in defaults")
+	}
+	fmt.Fprintf(w, "Recursive:        %t\n", plan.Recursive)
+	fmt.Fprintf(w, "Include patterns: %s\n", formatPatterns(plan.IncludePatterns))
+	fmt.Fprintf(w, "Exclude patterns: %s\n", formatPatterns(plan.ExcludePatterns))
+
+	fmt.Fprintf(w, "\nFiles (%d):\n", len(plan.Files))
+	for _, file := range plan.Files {
+		fmt.Fprintf(w, "  %s\n", file)
+	}
+	if len(plan.Vendored) > 0 {
+		fmt.Fprintln(w, "\nVendored, not analyzed (--include-vendored to analyze):")
+		for _, code := range plan.Vendored {
+			fmt.Fprintf(w, "  %s (%s, %d files)\n", code.Path, code.Reason, code.Files)
+		}
+	}
+
+	fmt.Fprintln(w, "\nAnalyses:")
+	for _, analysis := range plan.Analyses {
+		if !analysis.Enabled {
+			fmt.Fprintf(w, "  %s: skipped\n", analysis.Name)
+			continue
+		}
+		fmt.Fprintf(w, "  %s: %d files\n", analysis.Name, analysis.Files)
+		for _, setting := range analysis.Settings {
+			fmt.Fprintf(w, "    %s = %s  # %s\n", setting.Key, formatSettingValue(setting.Value), setting.Source)
+		}
+	}
+}
+
+func formatPatterns(patterns []string) string {
+	if len(patterns) == 0 {
+		return "(none)"
+	}
+	return strings.Join(patterns, ", ")
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ludo-technologies/pyscn/service"
+	"github.com/spf13/cobra"
+)
+
+// Values of analyze --progress
+const (
+	progressAuto = "auto"
+	progressNone = "none"
+	progressJSON = "json"
+)
+
+// openProgressStream opens the NDJSON progress stream of --progress json on
+// --progress-file, or on stderr. Opening a named pipe waits for its reader.
+func (c *AnalyzeCommand) openProgressStream(cmd *cobra.Command) (*service.ProgressStream, func(), error) {
+	if c.progressFile == "" {
+		return service.NewProgressStream(cmd.ErrOrStderr()), func() {}, nil
+	}
+	file, err := os.OpenFile(c.progressFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open progress file: %w", err)
+	}
+	return service.NewProgressStream(file), func() { _ = file.Close() }, nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ludo-technologies/pyscn/service"
+	"github.com/spf13/cobra"
+)
+
+// APIDiffCommand represents the api-diff command
+type APIDiffCommand struct {
+	format string
+}
+
+// NewAPIDiffCommand creates a new api-diff command
+func NewAPIDiffCommand() *APIDiffCommand {
+	return &APIDiffCommand{
+		format: "text",
+	}
+}
+
+// CreateCobraCommand creates the cobra command for public API diffs
+func (c *APIDiffCommand) CreateCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "api-diff <old-rev> <new-rev> [paths...]",
+		Short: "Report public API changes between two git revisions",
+		Long: `Compare the public API of two git revisions.
+
+Reports public functions, classes and methods that were added or removed,
+and changes to their signatures: parameters added, removed, moved or made
+keyword-only or positional-only, defaults added, removed or changed,
+annotations, async, and base classes. Each change is rated with the
+semantic version bump it calls for, and the largest one is the bump the
+release needs.
+
+Public means not starting with an underscore, or listed in __all__ when a
+module defines it. Modules under tests and modules or packages starting
+with an underscore are left out. Paths restrict the comparison, like git
+pathspecs. Findings of the analyzers play no part.
+
+Examples:
+  # Changes since the last release
+  pyscn api-diff v1.2.0 HEAD
+
+  # A changelog section for the release notes
+  pyscn api-diff v1.2.0 v1.3.0 --format markdown
+
+  # Machine-readable changes of one package
+  pyscn api-diff main feature --format json src/shop`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: c.runAPIDiff,
+	}
+
+	cmd.Flags().StringVar(&c.format, "format", "text", "Output format: text, json or markdown")
+	_ = cmd.RegisterFlagCompletionFunc("format", completeValues("text", "json", "markdown"))
+
+	return cmd
+}
+
+// runAPIDiff executes the api-diff command
+func (c *APIDiffCommand) runAPIDiff(cmd *cobra.Command, args []string) error {
+	if c.format != "text" && c.format != "json" && c.format != "markdown" {
+		return fmt.Errorf("invalid --format %q: must be text, json or markdown", c.format)
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	diff, err := service.DiffAPIBetweenRevisions(ctx, args[0], args[1], args[2:])
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	switch c.format {
+	case "json":
+		return service.WriteJSON(out, diff)
+	case "markdown":
+		service.WriteAPIDiffMarkdown(out, diff)
+	default:
+		service.WriteAPIDiffText(out, diff)
+	}
+	return nil
+}
+
+// NewAPIDiffCmd creates and returns the api-diff cobra command
+func NewAPIDiffCmd() *cobra.Command {
+	apiDiffCommand := NewAPIDiffCommand()
+	return apiDiffCommand.CreateCobraCommand()
+}
@@ -0,0 +1,401 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/app"
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/version"
+	"github.com/ludo-technologies/pyscn/service"
+	"github.com/spf13/cobra"
+)
+
+// defaultArchBaselineFile is the baseline file used when --file is not given
+const defaultArchBaselineFile = "pyscn-arch-baseline.json"
+
+// ArchCommand represents the arch command and its subcommands
+type ArchCommand struct {
+	configFile string
+	force      bool
+	dryRun     bool
+
+	// check-import, baseline and check options
+	checkConfigFile string
+	projectDir      string
+	jsonOutput      bool
+	baselineFile    string
+	noShrink        bool
+}
+
+// NewArchCommand creates a new arch command
+func NewArchCommand() *ArchCommand {
+	return &ArchCommand{
+		configFile:   ".pyscn.toml",
+		force:        false,
+		dryRun:       false,
+		projectDir:   ".",
+		baselineFile: defaultArchBaselineFile,
+	}
+}
+
+// CreateCobraCommand creates the cobra command for architecture setup
+func (c *ArchCommand) CreateCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "arch",
+		Short: "Set up, query and gate architecture layers",
+	}
+
+	initCmd := &cobra.Command{
+		Use:   "init [paths...]",
+		Short: "Write the auto-detected architecture layers and rules to the config",
+		Long: `Detect the architecture layers of a project and write them, with the
+rules between them, to the configuration file for you to edit.
+
+Without configured layers, pyscn detects layers from module names on every
+run and validates imports against built-in rules. 'pyscn arch init' writes
+those layers and rules down, so that the rules are visible and yours to
+change. Each layer is annotated with its module count and the mean
+confidence of the detection; modules detected with low confidence are
+listed for review.
+
+The layers are appended to the configuration file, which is created when it
+does not exist. When it already defines layers or rules, use --force to
+replace them; other settings are kept.
+
+Examples:
+  # Detect layers in the current directory and write them to .pyscn.toml
+  pyscn arch init
+
+  # Print the detected layers without writing anything
+  pyscn arch init --dry-run src/
+
+  # Replace the layers and rules of pyproject.toml
+  pyscn arch init --config pyproject.toml --force`,
+		SilenceUsage: true,
+		RunE:         c.runInit,
+	}
+	initCmd.Flags().StringVarP(&c.configFile, "config", "c", c.configFile, "Configuration file to write the layers to (.pyscn.toml or pyproject.toml)")
+	initCmd.Flags().BoolVarP(&c.force, "force", "f", false, "Replace layers and rules already defined in the configuration file")
+	initCmd.Flags().BoolVar(&c.dryRun, "dry-run", false, "Print the detected layers and rules instead of writing them")
+
+	checkCmd := &cobra.Command{
+		Use:   "check-import <from-module> <to-module>",
+		Short: "Check whether the architecture rules allow an import",
+		Long: `Evaluate the architecture rules for a dependency from one module to
+another, whether or not the import exists, and explain which rule allows or
+denies it.
+
+The global layers and rules are checked, then every rule set whose scope
+holds both modules. Module names are dotted import paths from the project
+root; layers are resolved the same way as in 'pyscn analyze', including
+style presets and auto-detection.
+
+Exits with 1 when any rule denies the import, so proposed exceptions can be
+validated in CI.
+
+Examples:
+  # May the domain import the API layer?
+  pyscn arch check-import app.models.user app.api.routes
+
+  # Print the verdicts as JSON for a project in another directory
+  pyscn arch check-import --project backend/ --json services.billing.core.invoice services.billing.api.routes`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE:         c.runCheckImport,
+	}
+	checkCmd.Flags().StringVarP(&c.checkConfigFile, "config", "c", "", "Configuration file path (default: discovered from the project directory)")
+	checkCmd.Flags().StringVar(&c.projectDir, "project", c.projectDir, "Project directory the layers are resolved for")
+	checkCmd.Flags().BoolVar(&c.jsonOutput, "json", false, "Print the verdicts as JSON")
+
+	baselineCmd := &cobra.Command{
+		Use:   "baseline [paths...]",
+		Short: "Grandfather the current architecture violations",
+		Long: `Record the current architecture violations in a baseline file, so that
+'pyscn arch check' fails only on violations introduced later.
+
+Every layer, rule set, god module, responsibility and cohesion violation
+is recorded, except the ones at info severity. Commit the resulting file;
+running the command again replaces it with the current violations.
+
+Examples:
+  # Grandfather the violations of the current directory
+  pyscn arch baseline
+
+  # Record them in another file
+  pyscn arch baseline --file ci/arch-baseline.json src/`,
+		SilenceUsage: true,
+		RunE:         c.runBaseline,
+	}
+	baselineCmd.Flags().StringVar(&c.baselineFile, "file", c.baselineFile, "Baseline file to write")
+	baselineCmd.Flags().StringVarP(&c.checkConfigFile, "config", "c", "", "Configuration file path (default: discovered from the first path)")
+
+	gateCmd := &cobra.Command{
+		Use:   "check [paths...]",
+		Short: "Fail on architecture violations that are not grandfathered",
+		Long: `Check the architecture rules, failing only on violations that are
+neither recorded in the baseline file nor covered by a rule budget.
+
+A layer rule may tolerate a number of new violations of dependencies from
+its layer with 'budget = N' in [[architecture.rules]] or in the rules of a
+rule set. When nothing new exceeds the budgets and grandfathered violations
+were fixed, they are removed from the baseline file, so it only ever
+shrinks. Without a baseline file, every violation is new.
+
+Examples:
+  # Gate architecture in CI
+  pyscn arch check
+
+  # Report as JSON without rewriting the baseline
+  pyscn arch check --json --no-shrink`,
+		SilenceUsage: true,
+		RunE:         c.runGate,
+	}
+	gateCmd.Flags().StringVar(&c.baselineFile, "file", c.baselineFile, "Baseline file to check against")
+	gateCmd.Flags().StringVarP(&c.checkConfigFile, "config", "c", "", "Configuration file path (default: discovered from the first path)")
+	gateCmd.Flags().BoolVar(&c.noShrink, "no-shrink", false, "Do not remove fixed violations from the baseline file")
+	gateCmd.Flags().BoolVar(&c.jsonOutput, "json", false, "Print the report as JSON")
+
+	cmd.AddCommand(initCmd)
+	cmd.AddCommand(checkCmd)
+	cmd.AddCommand(baselineCmd)
+	cmd.AddCommand(gateCmd)
+	return cmd
+}
+
+// runInit detects the layers of the analyzed paths and writes them to the
+// configuration file
+func (c *ArchCommand) runInit(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+
+	existing, err := os.ReadFile(c.configFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read %s: %w", c.configFile, err)
+	}
+	tablePrefix := ""
+	if filepath.Base(c.configFile) == "pyproject.toml" {
+		tablePrefix = "tool.pyscn."
+	}
+	if !c.dryRun && !c.force && service.HasArchitectureLayerTables(string(existing), tablePrefix) {
+		return fmt.Errorf("%s already defines architecture layers or rules; use --force to replace them or --dry-run to print the detected ones", c.configFile)
+	}
+
+	configPath := ""
+	if existing != nil {
+		configPath = c.configFile
+	}
+	rules, err := c.resolveRules(cmd, args, configPath, true)
+	if err != nil {
+		return fmt.Errorf("architecture detection failed: %w", err)
+	}
+	if rules == nil || rules.Detection == nil || len(rules.Layers) == 0 {
+		return fmt.Errorf("no layers detected: no module name matches a built-in layer package (e.g. api, services, models, repositories)")
+	}
+	block := service.FormatArchitectureLayersTOML(rules, tablePrefix)
+
+	out := cmd.OutOrStdout()
+	if c.dryRun {
+		_, err := io.WriteString(out, block)
+		return err
+	}
+
+	content := strings.TrimRight(service.StripArchitectureLayerTables(string(existing), tablePrefix), "\n")
+	if content != "" {
+		content += "\n\n"
+	}
+	content += block
+	if err := os.WriteFile(c.configFile, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", c.configFile, err)
+	}
+
+	fmt.Fprintf(out, "✅ Wrote %d layer(s) detected in %d module(s) to %s\n", len(rules.Layers), len(rules.Detection.Assignments), c.configFile)
+	counts := make(map[string]int)
+	for _, assignment := range rules.Detection.Assignments {
+		counts[assignment.Layer]++
+	}
+	for _, layer := range rules.Layers {
+		fmt.Fprintf(out, "  %-16s %d module(s)\n", layer.Name, counts[layer.Name])
+	}
+	fmt.Fprintf(out, "\nReview the layers and rules in %s, then run 'pyscn analyze' to validate against them.\n", c.configFile)
+	return nil
+}
+
+// runCheckImport explains how the architecture rules treat an import
+func (c *ArchCommand) runCheckImport(cmd *cobra.Command, args []string) error {
+	configPath := c.checkConfigFile
+	if configPath == "" {
+		configPath = c.projectDir
+	}
+	rules, err := c.resolveRules(cmd, []string{c.projectDir}, configPath, false)
+	if err != nil {
+		return fmt.Errorf("failed to resolve architecture rules: %w", err)
+	}
+	check := service.NewSystemAnalysisService().CheckImport(rules, args[0], args[1])
+
+	out := cmd.OutOrStdout()
+	if c.jsonOutput {
+		data, err := json.MarshalIndent(check, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+	} else {
+		writeImportCheck(out, check)
+	}
+
+	for _, verdict := range check.Verdicts {
+		if verdict.Decision == domain.ImportDecisionDenied {
+			return fmt.Errorf("import of %s from %s is denied", check.To, check.From)
+		}
+	}
+	return nil
+}
+
+// runBaseline records the current architecture violations
+func (c *ArchCommand) runBaseline(cmd *cobra.Command, args []string) error {
+	result, err := c.analyzeArchitecture(cmd, args)
+	if err != nil {
+		return fmt.Errorf("architecture analysis failed: %w", err)
+	}
+	baseline := &domain.ArchitectureBaseline{
+		Version:    version.Version,
+		Violations: service.CollectArchitectureBaselineEntries(result),
+	}
+	if err := service.SaveArchitectureBaseline(c.baselineFile, baseline); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Grandfathered %d violation(s) in %s\n", len(baseline.Violations), c.baselineFile)
+	return nil
+}
+
+// runGate fails on architecture violations missing from the baseline and
+// beyond the rule budgets, shrinking the baseline otherwise
+func (c *ArchCommand) runGate(cmd *cobra.Command, args []string) error {
+	recorded, err := service.LoadArchitectureBaseline(c.baselineFile)
+	if err != nil {
+		return err
+	}
+	result, err := c.analyzeArchitecture(cmd, args)
+	if err != nil {
+		return fmt.Errorf("architecture analysis failed: %w", err)
+	}
+	current := service.CollectArchitectureBaselineEntries(result)
+	report := service.CheckArchitectureBaseline(recorded, current, result.Rules)
+
+	out := cmd.OutOrStdout()
+	if c.jsonOutput {
+		if err := service.WriteJSON(out, report); err != nil {
+			return err
+		}
+	} else {
+		service.WriteArchitectureGateReportText(out, report)
+	}
+
+	if len(report.New) > 0 {
+		return fmt.Errorf("%d new architecture violation(s) not in %s", len(report.New), c.baselineFile)
+	}
+	if recorded != nil && len(report.Fixed) > 0 && !c.noShrink {
+		shrunk := service.ShrinkArchitectureBaseline(recorded, report.Fixed, version.Version)
+		if err := service.SaveArchitectureBaseline(c.baselineFile, shrunk); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Removed %d fixed violation(s) from %s\n", len(report.Fixed), c.baselineFile)
+	}
+	return nil
+}
+
+func writeImportCheck(out io.Writer, check *domain.ImportCheck) {
+	fmt.Fprintf(out, "%s -> %s\n", check.From, check.To)
+	if len(check.Verdicts) == 0 {
+		fmt.Fprintln(out, "\nNo architecture layers or rule sets apply to these modules.")
+		return
+	}
+	for _, verdict := range check.Verdicts {
+		title := "Global rules"
+		if verdict.RuleSet != "" {
+			title = fmt.Sprintf("Rule set '%s' (%s)", verdict.RuleSet, verdict.Scope)
+		}
+		fmt.Fprintf(out, "\n%s: %s\n", title, verdict.Decision)
+		fmt.Fprintf(out, "  Layers: %s -> %s\n", describeLayerMatch(verdict.FromLayer, verdict.FromPattern),
+			describeLayerMatch(verdict.ToLayer, verdict.ToPattern))
+		if verdict.Rule != "" {
+			fmt.Fprintf(out, "  Rule:   %s\n", verdict.Rule)
+		}
+		fmt.Fprintf(out, "  Reason: %s\n", verdict.Reason)
+	}
+}
+
+func describeLayerMatch(layer, pattern string) string {
+	if pattern == "" {
+		return layer
+	}
+	return fmt.Sprintf("%s (package '%s')", layer, pattern)
+}
+
+// analyzeArchitecture runs architecture analysis over paths, or the current
+// directory when none are given, with the configuration discovered from the
+// first path unless --config is set
+func (c *ArchCommand) analyzeArchitecture(cmd *cobra.Command, paths []string) (*domain.ArchitectureAnalysisResult, error) {
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	configPath := c.checkConfigFile
+	if configPath == "" {
+		configPath = paths[0]
+		if info, err := os.Stat(configPath); err == nil && !info.IsDir() {
+			configPath = filepath.Dir(configPath)
+		}
+	}
+	return c.runArchitectureAnalysis(cmd, paths, configPath, false)
+}
+
+// resolveRules runs architecture analysis and returns the rules the modules
+// were checked against. With detect, layers in the configuration are ignored
+// so that they do not hide the detected ones.
+func (c *ArchCommand) resolveRules(cmd *cobra.Command, paths []string, configPath string, detect bool) (*domain.ArchitectureRules, error) {
+	result, err := c.runArchitectureAnalysis(cmd, paths, configPath, detect)
+	if err != nil {
+		return nil, err
+	}
+	return result.Rules, nil
+}
+
+func (c *ArchCommand) runArchitectureAnalysis(cmd *cobra.Command, paths []string, configPath string, detect bool) (*domain.ArchitectureAnalysisResult, error) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	request := domain.SystemAnalysisRequest{
+		Paths:               paths,
+		ConfigPath:          configPath,
+		OutputFormat:        domain.OutputFormatText,
+		OutputWriter:        io.Discard,
+		AnalyzeDependencies: domain.BoolPtr(false),
+		AnalyzeArchitecture: domain.BoolPtr(true),
+		DetectArchitecture:  detect,
+	}
+
+	useCase := app.NewSystemAnalysisUseCase(
+		service.NewSystemAnalysisService(),
+		service.NewFileReader(),
+		service.NewSystemAnalysisFormatter(),
+		service.NewSystemAnalysisConfigurationLoader(),
+	)
+	return useCase.AnalyzeArchitectureOnly(ctx, request)
+}
+
+// NewArchCmd creates and returns the arch cobra command
+func NewArchCmd() *cobra.Command {
+	archCommand := NewArchCommand()
+	return archCommand.CreateCobraCommand()
+}
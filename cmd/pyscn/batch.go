@@ -0,0 +1,262 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/version"
+	"github.com/ludo-technologies/pyscn/service"
+	"github.com/spf13/cobra"
+)
+
+// BatchCommand represents the batch command
+type BatchCommand struct {
+	manifest  string
+	outputDir string
+	jobs      int
+	json      bool
+}
+
+// NewBatchCommand creates a new batch command
+func NewBatchCommand() *BatchCommand {
+	return &BatchCommand{
+		manifest:  "",
+		outputDir: "",
+		jobs:      0,
+		json:      false,
+	}
+}
+
+// CreateCobraCommand creates the cobra command for batch analysis
+func (c *BatchCommand) CreateCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "batch --manifest repos.yaml",
+		Short: "Analyze many repositories in parallel and rank them",
+		Long: `Analyze every target listed in a manifest, write one report per target and
+an index ranking the targets by health score.
+
+The manifest is a YAML file:
+
+  output_dir: reports     # optional, defaults to pyscn-batch
+  jobs: 4                 # optional, defaults to one per CPU
+  targets:
+    - name: api
+      path: ../api
+      config: ../api/.pyscn.toml   # optional
+    - path: https://github.com/org/worker@v2.1.0
+
+Relative paths are resolved against the manifest. Targets without a name are
+named after their path. Git URLs are cloned into a temporary directory.
+
+The output directory receives index.html and index.json with the scores of
+every target, and one HTML (or JSON with --json) report per target.
+
+Examples:
+  # Analyze the targets of repos.yaml
+  pyscn batch --manifest repos.yaml
+
+  # Two targets at a time, reports as JSON
+  pyscn batch --manifest repos.yaml --jobs 2 --json --output-dir out/`,
+		SilenceUsage: true,
+		RunE:         c.runBatch,
+	}
+
+	cmd.Flags().StringVar(&c.manifest, "manifest", "", "YAML manifest listing the targets to analyze")
+	cmd.Flags().StringVarP(&c.outputDir, "output-dir", "o", "", "Directory for the reports and the index (overrides the manifest)")
+	cmd.Flags().IntVarP(&c.jobs, "jobs", "j", 0, "Targets to analyze at once (0 = manifest setting or one per CPU)")
+	cmd.Flags().BoolVar(&c.json, "json", false, "Write per-target reports as JSON instead of HTML")
+	_ = cmd.MarkFlagRequired("manifest")
+
+	return cmd
+}
+
+// runBatch analyzes the targets of the manifest and writes the index
+func (c *BatchCommand) runBatch(cmd *cobra.Command, args []string) error {
+	if c.jobs < 0 {
+		return fmt.Errorf("--jobs must not be negative, got %d", c.jobs)
+	}
+	manifest, err := service.LoadBatchManifest(c.manifest)
+	if err != nil {
+		return err
+	}
+
+	outputDir := c.outputDir
+	if outputDir == "" {
+		outputDir = manifest.OutputDir
+	}
+	if outputDir == "" {
+		outputDir = domain.DefaultBatchOutputDir
+	}
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	jobs := c.jobs
+	if jobs == 0 {
+		jobs = manifest.Jobs
+	}
+	if jobs == 0 {
+		jobs = runtime.NumCPU()
+	}
+	jobs = min(jobs, len(manifest.Targets))
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	results := make([]service.BatchResult, len(manifest.Targets))
+	indices := make(chan int)
+	var mu sync.Mutex // Serializes progress lines
+	var wg sync.WaitGroup
+	for range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				results[i] = c.analyzeTarget(ctx, i, manifest.Targets[i], outputDir)
+				mu.Lock()
+				if results[i].Err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "✗ %s: %v\n", results[i].Target.Name, results[i].Err)
+				} else {
+					fmt.Fprintf(cmd.ErrOrStderr(), "✓ %s: %d/100 (Grade: %s)\n", results[i].Target.Name,
+						results[i].Response.Summary.HealthScore, results[i].Response.Summary.Grade)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range manifest.Targets {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	index := &domain.BatchIndex{
+		GeneratedAt: time.Now(),
+		Version:     version.Short(),
+		Targets:     service.BuildBatchIndex(results),
+	}
+	if err := writeBatchIndex(outputDir, index); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "📊 Batch index generated: %s\n", filepath.Join(outputDir, "index.html"))
+
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d targets failed", failed, len(results))
+	}
+	return nil
+}
+
+// analyzeTarget runs the full analysis on one target and writes its report
+func (c *BatchCommand) analyzeTarget(ctx context.Context, i int, target domain.BatchTarget, outputDir string) service.BatchResult {
+	result := service.BatchResult{Target: target}
+
+	path := target.Path
+	if service.IsArchiveTarget(path) {
+		tempDir, err := os.MkdirTemp("", "pyscn-archive-")
+		if err != nil {
+			result.Err = fmt.Errorf("failed to create extraction directory: %w", err)
+			return result
+		}
+		defer os.RemoveAll(tempDir)
+		path = filepath.Join(tempDir, service.ArchiveTargetName(path))
+		if err := service.ExtractArchiveTarget(target.Path, path); err != nil {
+			result.Err = err
+			return result
+		}
+	} else if remote, ok := service.ParseRemoteTarget(path); ok {
+		if _, err := os.Stat(path); err != nil {
+			tempDir, err := os.MkdirTemp("", "pyscn-clone-")
+			if err != nil {
+				result.Err = fmt.Errorf("failed to create clone directory: %w", err)
+				return result
+			}
+			defer os.RemoveAll(tempDir)
+			path = filepath.Join(tempDir, remote.Name())
+			if err := service.CloneRemoteTarget(ctx, remote, path); err != nil {
+				result.Err = err
+				return result
+			}
+		}
+	}
+
+	analyze := NewAnalyzeCommand()
+	analyze.configFile = target.Config
+	// Progress bars of concurrent targets would overwrite each other
+	quiet := &cobra.Command{}
+	quiet.SetErr(io.Discard)
+	useCase, err := analyze.buildAnalyzeUseCase(quiet)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to build analyze use case: %w", err)
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+	response, err := useCase.Execute(ctx, analyze.createUseCaseConfig(), []string{path})
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Response = response
+
+	format, extension := domain.OutputFormatHTML, "html"
+	if c.json {
+		format, extension = domain.OutputFormatJSON, "json"
+	}
+	report := service.BatchReportFileName(i, target.Name, extension)
+	file, err := os.Create(filepath.Join(outputDir, report))
+	if err != nil {
+		result.Err = fmt.Errorf("failed to create report: %w", err)
+		return result
+	}
+	defer file.Close()
+	if err := service.NewAnalyzeFormatter().Write(response, format, file); err != nil {
+		result.Err = fmt.Errorf("failed to write report: %w", err)
+		return result
+	}
+	result.Report = report
+	return result
+}
+
+// writeBatchIndex writes index.json and index.html into outputDir
+func writeBatchIndex(outputDir string, index *domain.BatchIndex) error {
+	jsonFile, err := os.Create(filepath.Join(outputDir, "index.json"))
+	if err != nil {
+		return fmt.Errorf("failed to create batch index: %w", err)
+	}
+	defer jsonFile.Close()
+	if err := service.WriteJSON(jsonFile, index); err != nil {
+		return fmt.Errorf("failed to write batch index: %w", err)
+	}
+
+	htmlFile, err := os.Create(filepath.Join(outputDir, "index.html"))
+	if err != nil {
+		return fmt.Errorf("failed to create batch index: %w", err)
+	}
+	defer htmlFile.Close()
+	if err := service.WriteBatchIndexHTML(htmlFile, index); err != nil {
+		return fmt.Errorf("failed to write batch index: %w", err)
+	}
+	return nil
+}
+
+// NewBatchCmd creates and returns the batch cobra command
+func NewBatchCmd() *cobra.Command {
+	batchCommand := NewBatchCommand()
+	return batchCommand.CreateCobraCommand()
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/version"
+	"github.com/ludo-technologies/pyscn/service"
+	"github.com/spf13/cobra"
+)
+
+// BenchCommand represents the benchmark suite command
+type BenchCommand struct {
+	corpusFile      string
+	baselineFile    string
+	cacheDir        string
+	repos           []string
+	updateBaseline  bool
+	timeTolerance   float64
+	memoryTolerance float64
+	json            bool
+	worker          bool
+}
+
+// NewBenchCommand creates a new bench command
+func NewBenchCommand() *BenchCommand {
+	tolerance := domain.DefaultBenchTolerance()
+	return &BenchCommand{
+		corpusFile:      filepath.Join("bench", "corpus.toml"),
+		baselineFile:    filepath.Join("bench", "baseline.json"),
+		cacheDir:        filepath.Join(".cache", "pyscn-bench"),
+		updateBaseline:  false,
+		timeTolerance:   tolerance.WallClock,
+		memoryTolerance: tolerance.PeakRSS,
+		json:            false,
+		worker:          false,
+	}
+}
+
+// CreateCobraCommand creates the cobra command for the benchmark suite
+func (c *BenchCommand) CreateCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Benchmark analyses over a pinned corpus (developer tool)",
+		Long: `Run all analyses over a pinned corpus of real repositories and compare
+wall-clock time, peak memory and finding counts against a stored baseline.
+
+The corpus (bench/corpus.toml) lists repositories by git URL and pinned tag,
+or by a local path. Remote repositories are shallow-cloned once into the
+cache directory and reused. Each repository is analyzed in a separate pyscn
+process so that its peak resident set size can be measured.
+
+Time and memory may drift within the tolerances before they are reported.
+Finding counts are deterministic for a pinned ref, so any change is reported:
+it means an analysis now produces different results.
+
+The command exits with a non-zero status when a regression is found.
+Use --update-baseline after an intended change to record new numbers.
+
+Examples:
+  # Compare the whole corpus against bench/baseline.json
+  pyscn bench
+
+  # Benchmark a single repository
+  pyscn bench --repo requests
+
+  # Record a new baseline
+  pyscn bench --update-baseline`,
+		Args: cobra.NoArgs,
+		RunE: c.runBench,
+	}
+
+	cmd.Flags().StringVar(&c.corpusFile, "corpus", c.corpusFile, "Corpus file listing the pinned repositories")
+	cmd.Flags().StringVar(&c.baselineFile, "baseline", c.baselineFile, "Baseline file to compare against")
+	cmd.Flags().StringVar(&c.cacheDir, "cache-dir", c.cacheDir, "Directory for cloned repositories")
+	cmd.Flags().StringSliceVar(&c.repos, "repo", nil, "Only benchmark the named repositories")
+	cmd.Flags().BoolVar(&c.updateBaseline, "update-baseline", false, "Write the measured results to the baseline file")
+	cmd.Flags().Float64Var(&c.timeTolerance, "time-tolerance", c.timeTolerance, "Allowed relative wall-clock slowdown (0.25 = 25%)")
+	cmd.Flags().Float64Var(&c.memoryTolerance, "memory-tolerance", c.memoryTolerance, "Allowed relative peak RSS growth (0.20 = 20%)")
+	cmd.Flags().BoolVar(&c.json, "json", false, "Output JSON to stdout")
+
+	// Internal: analyze one directory and print its finding counts
+	cmd.Flags().BoolVar(&c.worker, "worker", false, "Run a single analysis and print finding counts")
+	_ = cmd.Flags().MarkHidden("worker")
+
+	return cmd
+}
+
+// runBench executes the benchmark suite
+func (c *BenchCommand) runBench(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if c.worker {
+		return c.runWorker(ctx, cmd)
+	}
+
+	corpus, err := service.LoadBenchCorpus(c.corpusFile)
+	if err != nil {
+		return err
+	}
+	repos, err := c.selectRepos(corpus)
+	if err != nil {
+		return err
+	}
+	baseline, err := service.LoadBenchBaseline(c.baselineFile)
+	if err != nil {
+		return err
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate pyscn executable: %w", err)
+	}
+
+	corpusDir := filepath.Dir(c.corpusFile)
+	results := make([]domain.BenchResult, 0, len(repos))
+	for _, repo := range repos {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Benchmarking %s...\n", repo.Name)
+		result := domain.BenchResult{Repo: repo.Name, Ref: repo.Ref}
+
+		dir, err := service.PrepareBenchRepo(ctx, repo, corpusDir, c.cacheDir)
+		if err != nil {
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+		if err := measureBenchRun(ctx, executable, dir, &result); err != nil {
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+
+	tolerance := domain.DefaultBenchTolerance()
+	tolerance.WallClock = c.timeTolerance
+	tolerance.PeakRSS = c.memoryTolerance
+	report := service.CompareBench(baseline, results, tolerance)
+
+	out := cmd.OutOrStdout()
+	if c.json {
+		if err := service.WriteJSON(out, report); err != nil {
+			return err
+		}
+	} else {
+		service.WriteBenchReportText(out, report, baseline)
+	}
+
+	if c.updateBaseline {
+		updated := mergeBenchBaseline(baseline, results)
+		if err := service.SaveBenchBaseline(c.baselineFile, updated); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Baseline written to %s\n", c.baselineFile)
+		return nil
+	}
+
+	for _, result := range results {
+		if result.Error != "" {
+			return fmt.Errorf("benchmark failed for %s: %s", result.Repo, result.Error)
+		}
+	}
+	if len(report.Regressions) > 0 {
+		return fmt.Errorf("%d benchmark regression(s) against %s", len(report.Regressions), c.baselineFile)
+	}
+	return nil
+}
+
+// selectRepos filters the corpus by the --repo flag
+func (c *BenchCommand) selectRepos(corpus *domain.BenchCorpus) ([]domain.BenchRepo, error) {
+	if len(c.repos) == 0 {
+		return corpus.Repos, nil
+	}
+
+	byName := make(map[string]domain.BenchRepo, len(corpus.Repos))
+	for _, repo := range corpus.Repos {
+		byName[repo.Name] = repo
+	}
+	selected := make([]domain.BenchRepo, 0, len(c.repos))
+	for _, name := range c.repos {
+		repo, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown corpus repository %q", name)
+		}
+		selected = append(selected, repo)
+	}
+	return selected, nil
+}
+
+// runWorker analyzes a single directory with all analyses enabled and prints
+// the finding counts as JSON. It runs in a child process so that the parent
+// can measure its peak memory in isolation.
+func (c *BenchCommand) runWorker(ctx context.Context, cmd *cobra.Command) error {
+	dir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	analyze := NewAnalyzeCommand()
+	useCase, err := analyze.buildAnalyzeUseCase(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to build analyze use case: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+	defer cancel()
+	response, err := useCase.Execute(ctx, analyze.createUseCaseConfig(), []string{dir})
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(cmd.OutOrStdout()).Encode(service.BenchFindingCounts(&response.Summary))
+}
+
+// measureBenchRun runs the worker over dir and records its wall-clock time,
+// peak RSS and finding counts
+func measureBenchRun(ctx context.Context, executable, dir string, result *domain.BenchResult) error {
+	var stdout, stderr bytes.Buffer
+	child := exec.CommandContext(ctx, executable, "bench", "--worker")
+	child.Dir = dir
+	child.Stdout = &stdout
+	child.Stderr = &stderr
+
+	start := time.Now()
+	err := child.Run()
+	result.WallClockMs = time.Since(start).Milliseconds()
+	if err != nil {
+		return fmt.Errorf("analysis failed: %w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	result.PeakRSSKB = peakRSSKB(child.ProcessState)
+
+	if err := json.Unmarshal(stdout.Bytes(), &result.Findings); err != nil {
+		return fmt.Errorf("failed to read analysis results: %w", err)
+	}
+	return nil
+}
+
+// mergeBenchBaseline replaces the baseline entries of the measured
+// repositories and keeps the others
+func mergeBenchBaseline(baseline *domain.BenchBaseline, results []domain.BenchResult) *domain.BenchBaseline {
+	measured := make(map[string]bool, len(results))
+	updated := &domain.BenchBaseline{Version: version.Version}
+	for _, result := range results {
+		if result.Error != "" {
+			continue
+		}
+		measured[result.Repo] = true
+		updated.Results = append(updated.Results, result)
+	}
+	for _, result := range baseline.Results {
+		if !measured[result.Repo] {
+			updated.Results = append(updated.Results, result)
+		}
+	}
+	return updated
+}
+
+// NewBenchCmd creates and returns the bench cobra command
+func NewBenchCmd() *cobra.Command {
+	benchCommand := NewBenchCommand()
+	return benchCommand.CreateCobraCommand()
+}
@@ -0,0 +1,20 @@
+//go:build darwin
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// peakRSSKB returns the maximum resident set size of a finished process.
+// macOS reports ru_maxrss in bytes.
+func peakRSSKB(state *os.ProcessState) int64 {
+	if state == nil {
+		return 0
+	}
+	if usage, ok := state.SysUsage().(*syscall.Rusage); ok {
+		return int64(usage.Maxrss) / 1024
+	}
+	return 0
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package main
+
+import "os"
+
+// peakRSSKB is not available on this platform; reports show n/a and memory
+// is left out of the baseline comparison
+func peakRSSKB(state *os.ProcessState) int64 {
+	return 0
+}
@@ -0,0 +1,20 @@
+//go:build unix && !darwin
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// peakRSSKB returns the maximum resident set size of a finished process.
+// Linux and the BSDs report ru_maxrss in kilobytes.
+func peakRSSKB(state *os.ProcessState) int64 {
+	if state == nil {
+		return 0
+	}
+	if usage, ok := state.SysUsage().(*syscall.Rusage); ok {
+		return int64(usage.Maxrss)
+	}
+	return 0
+}
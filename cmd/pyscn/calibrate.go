@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/config"
+	"github.com/ludo-technologies/pyscn/service"
+	"github.com/spf13/cobra"
+)
+
+// CalibrateCommand represents the calibrate command
+type CalibrateCommand struct {
+	configFile string
+	percentile float64
+	json       bool
+}
+
+// NewCalibrateCommand creates a new calibrate command
+func NewCalibrateCommand() *CalibrateCommand {
+	return &CalibrateCommand{
+		configFile: "",
+		percentile: domain.DefaultCalibrationPercentile,
+		json:       false,
+	}
+}
+
+// CreateCobraCommand creates the cobra command for threshold calibration
+func (c *CalibrateCommand) CreateCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "calibrate [paths...]",
+		Short: "Suggest thresholds from the distribution of metrics in the codebase",
+		Long: `Analyze a codebase and suggest thresholds that fit it, as a configuration
+snippet to paste into .pyscn.toml.
+
+pyscn calibrate measures cyclomatic complexity per function, coupling (CBO)
+per class and similarity per clone pair. The low thresholds are set at the
+given percentile, so about (100 - percentile)% of functions and classes are
+reported above low risk, and the medium thresholds halfway between that
+percentile and the maximum. The clone similarity threshold keeps about
+(100 - percentile)% of the clone pairs found with the current settings.
+
+Classes without coupling are only measured when [cbo] show_zeros is set,
+and clone pairs below the current similarity threshold are never found, so
+calibration can raise but not lower that threshold.
+
+Examples:
+  # Suggest thresholds flagging the worst 10%
+  pyscn calibrate
+
+  # Flag only the worst 5% of src/
+  pyscn calibrate --percentile 95 src/
+
+  # Machine-readable distributions and suggestions
+  pyscn calibrate --json`,
+		SilenceUsage: true,
+		RunE:         c.runCalibrate,
+	}
+
+	cmd.Flags().StringVarP(&c.configFile, "config", "c", "", "Configuration file path")
+	cmd.Flags().Float64Var(&c.percentile, "percentile", c.percentile, "Percentile to place the low thresholds at (between 0 and 100)")
+	cmd.Flags().BoolVar(&c.json, "json", false, "Output JSON to stdout")
+
+	return cmd
+}
+
+// runCalibrate analyzes the paths and prints the suggested thresholds
+func (c *CalibrateCommand) runCalibrate(cmd *cobra.Command, args []string) error {
+	if c.percentile <= 0 || c.percentile >= 100 {
+		return fmt.Errorf("--percentile must be between 0 and 100, got %g", c.percentile)
+	}
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	cfg, err := config.LoadConfigWithTarget(c.configFile, getTargetPathFromArgs(args))
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	analyze := NewAnalyzeCommand()
+	analyze.configFile = c.configFile
+	analyze.selectAnalyses = []string{domain.AnalysisComplexity, domain.AnalysisCBO, domain.AnalysisClones}
+	useCase, err := analyze.buildAnalyzeUseCase(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to build analyze use case: %w", err)
+	}
+	response, err := useCase.Execute(ctx, analyze.createUseCaseConfig(), args)
+	if err != nil {
+		return err
+	}
+
+	report := service.CalibrateThresholds(response, c.percentile, currentThresholds(cfg))
+	out := cmd.OutOrStdout()
+	if c.json {
+		return service.WriteJSON(out, report)
+	}
+	service.WriteCalibrationText(out, report)
+	return nil
+}
+
+// currentThresholds returns the thresholds calibrate suggests values for, as
+// configured, keyed by "section.key"
+func currentThresholds(cfg *config.Config) map[string]float64 {
+	current := map[string]float64{
+		"complexity.low_threshold":    float64(cfg.Complexity.LowThreshold),
+		"complexity.medium_threshold": float64(cfg.Complexity.MediumThreshold),
+		"cbo.low_threshold":           float64(domain.DefaultCBOLowThreshold),
+		"cbo.medium_threshold":        float64(domain.DefaultCBOMediumThreshold),
+		"clones.similarity_threshold": domain.DefaultCloneSimilarityThreshold,
+	}
+	if cfg.Clones != nil {
+		current["cbo.low_threshold"] = float64(cfg.Clones.CboLowThreshold)
+		current["cbo.medium_threshold"] = float64(cfg.Clones.CboMediumThreshold)
+		current["clones.similarity_threshold"] = cfg.Clones.Thresholds.SimilarityThreshold
+	}
+	return current
+}
+
+// NewCalibrateCmd creates and returns the calibrate cobra command
+func NewCalibrateCmd() *cobra.Command {
+	calibrateCommand := NewCalibrateCommand()
+	return calibrateCommand.CreateCobraCommand()
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/config"
+	"github.com/ludo-technologies/pyscn/service"
+	"github.com/spf13/cobra"
+)
+
+// CallGraphCommand represents the callgraph command
+type CallGraphCommand struct {
+	configFile   string
+	format       string
+	roots        []string
+	noStringRefs bool
+}
+
+// NewCallGraphCommand creates a new callgraph command
+func NewCallGraphCommand() *CallGraphCommand {
+	return &CallGraphCommand{
+		format: "text",
+	}
+}
+
+// CreateCobraCommand creates the cobra command for the call graph
+func (c *CallGraphCommand) CreateCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "callgraph [files...]",
+		Short: "Export the call graph and find functions no entry point reaches",
+		Long: `Export the static call graph of the given files and packages, and compute
+what the entry points reach.
+
+Calls are resolved by name: functions of the same module and enclosing
+scopes, self. and cls. methods, and names imported from other analyzed
+files. A class that is used stands for all of its methods, since calls on
+instances are not resolved. Reaching a definition reaches the module-level
+code of its file. String literals naming a function or class, as in
+getattr(obj, "run"), Celery task names or Django "app.Model" references,
+count as soft references unless --no-string-refs is given.
+
+Entry points are given with --root, as a file (app/main.py), a function of
+a file (app/main.py::main), a module (app.main) or a function of a module
+(app.main:main). Without --root, the scripts of [project.scripts] and
+[tool.poetry.scripts] in pyproject.toml are used.
+
+The text format lists the functions no entry point reaches, candidates for
+removal, and the share of function lines that are reachable.
+
+Examples:
+  # Unreachable functions from the console scripts of pyproject.toml
+  pyscn callgraph src/
+
+  # From a script's module-level code
+  pyscn callgraph --root scripts/migrate.py src/ scripts/
+
+  # Graphviz rendering
+  pyscn callgraph --root app.cli:main --format dot src/ | dot -Tsvg > calls.svg`,
+		Args: cobra.ArbitraryArgs,
+		RunE: c.runCallGraph,
+	}
+
+	cmd.Flags().StringVarP(&c.configFile, "config", "c", "", "Configuration file path")
+	cmd.Flags().StringVar(&c.format, "format", "text", "Output format: text, dot or json")
+	_ = cmd.RegisterFlagCompletionFunc("format", completeValues("text", "dot", "json"))
+	cmd.Flags().StringArrayVar(&c.roots, "root", nil, "Entry point to compute reachability from (repeatable)")
+	cmd.Flags().BoolVar(&c.noStringRefs, "no-string-refs", false, "Do not treat string literals naming a function or class as references")
+
+	return cmd
+}
+
+// runCallGraph builds and writes the call graph
+func (c *CallGraphCommand) runCallGraph(cmd *cobra.Command, args []string) error {
+	if c.format != "text" && c.format != "dot" && c.format != "json" {
+		return fmt.Errorf("invalid --format %q: must be text, dot or json", c.format)
+	}
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+
+	cfg, err := config.LoadConfigWithTarget(c.configFile, getTargetPathFromArgs(args))
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	fileReader := service.NewFileReader().WithWalkOptions(domain.FileWalkOptions{
+		MaxDepth:       cfg.Analysis.MaxDepth,
+		FollowSymlinks: cfg.Analysis.FollowSymlinks,
+	})
+	files, err := fileReader.CollectPythonFiles(args, cfg.Analysis.Recursive, cfg.Analysis.IncludePatterns, cfg.Analysis.ExcludePatterns)
+	if err != nil {
+		return fmt.Errorf("failed to collect Python files: %w", err)
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	roots := c.roots
+	if len(roots) == 0 {
+		roots = service.ProjectEntryPoints(service.FindProjectRoot(args))
+	}
+	report, err := service.BuildCallGraph(ctx, files, roots, !c.noStringRefs)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	switch c.format {
+	case "json":
+		return service.WriteJSON(out, report)
+	case "dot":
+		return service.WriteCallGraphDOT(out, report)
+	default:
+		service.WriteCallGraphText(out, report)
+	}
+	return nil
+}
+
+// NewCallGraphCmd creates and returns the callgraph cobra command
+func NewCallGraphCmd() *cobra.Command {
+	callGraphCommand := NewCallGraphCommand()
+	return callGraphCommand.CreateCobraCommand()
+}
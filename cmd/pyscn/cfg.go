@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/analyzer"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+	"github.com/ludo-technologies/pyscn/service"
+	"github.com/spf13/cobra"
+)
+
+// CFGCommand represents the cfg command
+type CFGCommand struct {
+	function string
+	format   string
+}
+
+// NewCFGCommand creates a new cfg command
+func NewCFGCommand() *CFGCommand {
+	return &CFGCommand{
+		function: "",
+		format:   "dot",
+	}
+}
+
+// CreateCobraCommand creates the cobra command for printing control flow graphs
+func (c *CFGCommand) CreateCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cfg <file>",
+		Short: "Print the control flow graphs of a Python file",
+		Long: `Print the control flow graphs pyscn builds for a Python file, for
+debugging analyzer decisions, teaching and external tooling.
+
+Every function and method gets its own graph, and the module body is named
+<module>. Methods are named Class.method and nested functions outer.inner.
+Blocks list the first source line of each statement they hold. Blocks the
+dead code detector considers unreachable are marked: drawn dashed in DOT,
+"reachable": false in JSON.
+
+Examples:
+  # All graphs as one Graphviz digraph
+  pyscn cfg app.py | dot -Tsvg > app.svg
+
+  # One method, as JSON
+  pyscn cfg --function Parser.parse --format json app.py`,
+		Args: cobra.ExactArgs(1),
+		RunE: c.runCFG,
+	}
+
+	cmd.Flags().StringVar(&c.function, "function", "", "Print only this function, by full or unqualified name")
+	cmd.Flags().StringVar(&c.format, "format", "dot", "Output format: dot or json")
+	_ = cmd.RegisterFlagCompletionFunc("format", completeValues("dot", "json"))
+
+	return cmd
+}
+
+// runCFG executes the cfg command
+func (c *CFGCommand) runCFG(cmd *cobra.Command, args []string) error {
+	if c.format != "dot" && c.format != "json" {
+		return fmt.Errorf("invalid --format %q: must be dot or json", c.format)
+	}
+
+	path := args[0]
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	result, err := parser.New().Parse(ctx, source)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	builder := analyzer.NewCFGBuilder()
+	builder.SetTerminatingCalls(analyzer.NewTerminatingCalls(analyzer.DefaultTerminatingCalls,
+		analyzer.NoReturnFunctions([]*parser.Node{result.AST})))
+	cfgs, err := builder.BuildAll(result.AST)
+	if err != nil {
+		return fmt.Errorf("failed to build control flow graphs for %s: %w", path, err)
+	}
+
+	names, err := selectCFGNames(cfgs, c.function)
+	if err != nil {
+		return err
+	}
+	dumps := make([]*analyzer.CFGDump, 0, len(names))
+	for _, name := range names {
+		dumps = append(dumps, analyzer.DumpCFG(name, cfgs[name], source))
+	}
+
+	out := cmd.OutOrStdout()
+	if c.format == "json" {
+		return service.WriteJSON(out, struct {
+			File string              `json:"file"`
+			CFGs []*analyzer.CFGDump `json:"cfgs"`
+		}{File: path, CFGs: dumps})
+	}
+	return analyzer.WriteCFGDOT(out, dumps)
+}
+
+// selectCFGNames returns the names of the graphs to print: all of them with
+// the module first, or the one function matching name by its full name or
+// its last dotted part
+func selectCFGNames(cfgs map[string]*analyzer.CFG, name string) ([]string, error) {
+	var names []string
+	for cfgName := range cfgs {
+		if cfgName != domain.ModuleFunctionName {
+			names = append(names, cfgName)
+		}
+	}
+	sort.Strings(names)
+
+	if name == "" {
+		return append([]string{domain.ModuleFunctionName}, names...), nil
+	}
+	if _, ok := cfgs[name]; ok {
+		return []string{name}, nil
+	}
+	var matches []string
+	for _, cfgName := range names {
+		if cfgName[strings.LastIndex(cfgName, ".")+1:] == name {
+			matches = append(matches, cfgName)
+		}
+	}
+	switch len(matches) {
+	case 1:
+		return matches, nil
+	case 0:
+		return nil, fmt.Errorf("no function %q; available: %s", name, strings.Join(append([]string{domain.ModuleFunctionName}, names...), ", "))
+	default:
+		return nil, fmt.Errorf("function name %q is ambiguous: %s", name, strings.Join(matches, ", "))
+	}
+}
+
+// NewCFGCmd creates and returns the cfg cobra command
+func NewCFGCmd() *cobra.Command {
+	cfgCommand := NewCFGCommand()
+	return cfgCommand.CreateCobraCommand()
+}
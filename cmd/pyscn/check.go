@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 
 	"github.com/ludo-technologies/pyscn/app"
@@ -18,6 +19,13 @@ type CheckCommand struct {
 	// Configuration
 	configFile string
 	quiet      bool
+	format     string
+
+	// filesFrom names a file list to check ("-" = stdin)
+	filesFrom string
+
+	// Findings collected for lint output
+	diagnostics []checkDiagnostic
 
 	// Quick override flags
 	maxComplexity     int
@@ -25,6 +33,7 @@ type CheckCommand struct {
 	skipClones        bool
 	allowCircularDeps bool
 	maxCycles         int
+	jobs              int // Workers comparing clone candidates (0 = all CPUs)
 
 	// Select specific analyses to run
 	selectAnalyses []string
@@ -35,6 +44,7 @@ func NewCheckCommand() *CheckCommand {
 	return &CheckCommand{
 		configFile:        "",
 		quiet:             false,
+		format:            checkFormatText,
 		maxComplexity:     10,    // Fail if complexity > 10
 		allowDeadCode:     false, // Fail on any dead code
 		skipClones:        false,
@@ -57,6 +67,8 @@ This command performs a fast analysis with predefined thresholds:
 • Clones: Reports clones with similarity > 0.8 (warning only)
 • Circular Dependencies: Fails if any cycles are detected
 • DI Anti-patterns: Detects dependency injection anti-patterns
+• Security: Flags dangerous sinks (eval, shell=True, pickle, yaml.load, SQL formatting) reached by untrusted input
+• Custom: Runs the declarative AST rules of the rule files listed in [custom_rules]
 By default, complexity, dead code, and clones analyses are run. Use --select to choose specific analyses.
 
 Exit codes:
@@ -86,12 +98,24 @@ Examples:
   # Check for DI anti-patterns
   pyscn check --select di src/
 
+  # Check for security-sensitive patterns
+  pyscn check --select security src/
+
+  # Check the team's custom rules
+  pyscn check --select custom src/
+
 	# Check with higher complexity threshold
   pyscn check --max-complexity 15 src/
 
   # Allow dead code, only check complexity
   pyscn check --allow-dead-code src/
 
+  # Check only the Python files changed since main
+  git diff --name-only main | pyscn check --files-from -
+
+  # Emit path:line:col: RULE message lines on stdout for editors and reviewdog
+  pyscn check --format lint --select complexity,deadcode,deps src/
+
   # Allow circular dependencies (warning only)
   pyscn check --allow-circular-deps src/
   
@@ -107,6 +131,9 @@ Examples:
 	// Configuration flags
 	cmd.Flags().StringVarP(&c.configFile, "config", "c", "", "Configuration file path")
 	cmd.Flags().BoolVarP(&c.quiet, "quiet", "q", false, "Suppress output unless issues found")
+	cmd.Flags().StringVar(&c.filesFrom, "files-from", "", "Check the files listed in this file, one per line or NUL-separated (- = stdin), without walking directories")
+	cmd.Flags().StringVar(&c.format, "format", checkFormatText, "Output format: text (findings on stderr) or lint (path:line:col: RULE message on stdout)")
+	_ = cmd.RegisterFlagCompletionFunc("format", completeValues(checkFormatText, checkFormatLint))
 
 	// Override flags for quick adjustments
 	cmd.Flags().IntVar(&c.maxComplexity, "max-complexity", 10, "Maximum allowed complexity")
@@ -114,21 +141,17 @@ Examples:
 	cmd.Flags().BoolVar(&c.skipClones, "skip-clones", false, "Skip clone detection")
 	cmd.Flags().BoolVar(&c.allowCircularDeps, "allow-circular-deps", false, "Allow circular dependencies (warnings only)")
 	cmd.Flags().IntVar(&c.maxCycles, "max-cycles", 0, "Maximum allowed circular dependency cycles before failing")
+	cmd.Flags().IntVarP(&c.jobs, "jobs", "j", 0, "Workers comparing clone candidates (default: all CPUs)")
 
 	// Select specific analyses to run
 	cmd.Flags().StringSliceVarP(&c.selectAnalyses, "select", "s", []string{},
-		"Comma-separated list of analyses to run: complexity, deadcode, clones, deps, mockdata, di")
+		"Comma-separated list of analyses to run: complexity, deadcode, clones, deps, mockdata, di, security, custom")
 
 	return cmd
 }
 
 // runCheck executes the quick check analysis
 func (c *CheckCommand) runCheck(cmd *cobra.Command, args []string) error {
-	// Default to current directory if no args
-	if len(args) == 0 {
-		args = []string{"."}
-	}
-
 	// Resolve the current configuration discovery result once and load it
 	// explicitly. This preserves check's existing cwd-based discovery while
 	// ensuring a discovered but malformed config fails the quality gate instead
@@ -143,6 +166,47 @@ func (c *CheckCommand) runCheck(cmd *cobra.Command, args []string) error {
 		c.configFile = originalConfigFile
 	}()
 
+	switch c.format {
+	case checkFormatText, checkFormatLint:
+	default:
+		return fmt.Errorf("invalid --format value %q (expected: text, lint)", c.format)
+	}
+	if c.jobs < 0 {
+		return fmt.Errorf("invalid --jobs value %d (must not be negative)", c.jobs)
+	}
+
+	// Lint output carries only diagnostics, so status lines and the usage
+	// text cobra prints on errors are suppressed
+	if c.lintMode() {
+		cmd.SilenceUsage = true
+	}
+	originalQuiet := c.quiet
+	c.quiet = c.quiet || c.lintMode()
+	c.diagnostics = nil
+	defer func() {
+		c.quiet = originalQuiet
+	}()
+
+	if c.filesFrom != "" {
+		files, err := readFilesFrom(c.filesFrom, cmd.InOrStdin(), cmd.ErrOrStderr())
+		if err != nil {
+			return err
+		}
+		if len(files) == 0 && len(args) == 0 {
+			// Nothing in the list to check, e.g. a diff without Python changes
+			if !c.quiet {
+				fmt.Fprintf(cmd.ErrOrStderr(), "✅ No Python files to check\n")
+			}
+			return nil
+		}
+		args = append(args, files...)
+	}
+
+	// Default to current directory if no args
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+
 	// Validate selected analyses before creating config
 	if len(c.selectAnalyses) > 0 {
 		if err := c.validateSelectedAnalyses(); err != nil {
@@ -151,14 +215,14 @@ func (c *CheckCommand) runCheck(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create use case configuration
-	skipComplexity, skipDeadCode, skipClones, skipDeps, skipMockdata, skipDI := c.determineEnabledAnalyses()
+	skipComplexity, skipDeadCode, skipClones, skipDeps, skipMockdata, skipDI, skipSecurity, skipCustom := c.determineEnabledAnalyses()
 
 	// Count issues found
 	var issueCount int
 	var hasErrors bool
 
 	if !c.quiet {
-		fmt.Fprintf(cmd.ErrOrStderr(), "🔍 Running quality check (%s)...\n", strings.Join(c.getEnabledAnalyses(skipComplexity, skipDeadCode, skipClones, skipDeps, skipMockdata, skipDI), ", "))
+		fmt.Fprintf(cmd.ErrOrStderr(), "🔍 Running quality check (%s)...\n", strings.Join(c.getEnabledAnalyses(skipComplexity, skipDeadCode, skipClones, skipDeps, skipMockdata, skipDI, skipSecurity, skipCustom), ", "))
 	}
 
 	// Run complexity check if enabled
@@ -244,6 +308,32 @@ func (c *CheckCommand) runCheck(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Run security pattern check if enabled
+	if !skipSecurity {
+		securityIssues, err := c.checkSecurity(cmd, args)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "❌ Security check failed: %v\n", err)
+			hasErrors = true
+		} else {
+			issueCount += securityIssues
+		}
+	}
+
+	// Run custom rules if enabled
+	if !skipCustom {
+		customIssues, err := c.checkPatternRules(cmd, args)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "❌ Custom rules check failed: %v\n", err)
+			hasErrors = true
+		} else {
+			issueCount += customIssues
+		}
+	}
+
+	if c.lintMode() {
+		c.writeDiagnostics(cmd.OutOrStdout())
+	}
+
 	// Handle results
 	if hasErrors {
 		return fmt.Errorf("analysis failed with errors")
@@ -251,8 +341,10 @@ func (c *CheckCommand) runCheck(cmd *cobra.Command, args []string) error {
 
 	// Generic issue handling
 	if issueCount > 0 {
-		fmt.Fprintf(cmd.ErrOrStderr(),
-			"❌ Found %d quality issue(s)\n", issueCount)
+		if !c.lintMode() {
+			fmt.Fprintf(cmd.ErrOrStderr(),
+				"❌ Found %d quality issue(s)\n", issueCount)
+		}
 		return fmt.Errorf("found %d quality issue(s)", issueCount)
 	}
 
@@ -281,7 +373,7 @@ func resolveCheckConfig(configPath string) (string, error) {
 }
 
 // determineEnabledAnalyses determines which analyses should run based on flags
-func (c *CheckCommand) determineEnabledAnalyses() (skipComplexity bool, skipDeadCode bool, skipClones bool, skipDeps bool, skipMockdata bool, skipDI bool) {
+func (c *CheckCommand) determineEnabledAnalyses() (skipComplexity bool, skipDeadCode bool, skipClones bool, skipDeps bool, skipMockdata bool, skipDI bool, skipSecurity bool, skipCustom bool) {
 	if len(c.selectAnalyses) > 0 {
 		// If --select is used, only run selected analyses
 		skipComplexity = !c.containsAnalysis("complexity")
@@ -290,6 +382,8 @@ func (c *CheckCommand) determineEnabledAnalyses() (skipComplexity bool, skipDead
 		skipDeps = !c.containsAnalysis("deps") && !c.containsAnalysis("circular")
 		skipMockdata = !c.containsAnalysis("mockdata")
 		skipDI = !c.containsAnalysis("di")
+		skipSecurity = !c.containsAnalysis("security")
+		skipCustom = !c.containsAnalysis("custom")
 	} else {
 		// Otherwise use original behavior (backward compatible)
 		skipComplexity = false    // Always run complexity
@@ -298,6 +392,8 @@ func (c *CheckCommand) determineEnabledAnalyses() (skipComplexity bool, skipDead
 		skipDeps = true           // Skip deps by default (opt-in via --select)
 		skipMockdata = true       // Skip mockdata by default (opt-in via --select)
 		skipDI = true             // Skip DI by default (opt-in via --select)
+		skipSecurity = true       // Skip security by default (opt-in via --select)
+		skipCustom = true         // Skip custom rules by default (opt-in via --select)
 	}
 	return
 }
@@ -318,7 +414,7 @@ func (c *CheckCommand) containsAnalysis(analysis string) bool {
 }
 
 // getEnabledAnalyses returns a list of enabled analyses for display
-func (c *CheckCommand) getEnabledAnalyses(skipComplexity bool, skipDeadCode bool, skipClones bool, skipDeps bool, skipMockdata bool, skipDI bool) []string {
+func (c *CheckCommand) getEnabledAnalyses(skipComplexity bool, skipDeadCode bool, skipClones bool, skipDeps bool, skipMockdata bool, skipDI bool, skipSecurity bool, skipCustom bool) []string {
 	var enabled []string
 	if !skipComplexity {
 		enabled = append(enabled, "complexity")
@@ -338,23 +434,24 @@ func (c *CheckCommand) getEnabledAnalyses(skipComplexity bool, skipDeadCode bool
 	if !skipDI {
 		enabled = append(enabled, "di")
 	}
+	if !skipSecurity {
+		enabled = append(enabled, "security")
+	}
+	if !skipCustom {
+		enabled = append(enabled, "custom")
+	}
 	return enabled
 }
 
+// checkAnalyses are the analyses check --select accepts; circular is an
+// alias of deps
+var checkAnalyses = []string{"complexity", "deadcode", "clones", "deps", "circular", "mockdata", "di", "security", "custom"}
+
 // validateSelectedAnalyses validates the --select flag values
 func (c *CheckCommand) validateSelectedAnalyses() error {
-	validAnalyses := map[string]bool{
-		"complexity": true,
-		"deadcode":   true,
-		"clones":     true,
-		"deps":       true,
-		"circular":   true,
-		"mockdata":   true,
-		"di":         true,
-	}
 	for _, analysis := range c.selectAnalyses {
-		if !validAnalyses[strings.ToLower(analysis)] {
-			return fmt.Errorf("invalid analysis type: %s. Valid options: complexity, deadcode, clones, deps, mockdata, di", analysis)
+		if !slices.Contains(checkAnalyses, strings.ToLower(analysis)) {
+			return fmt.Errorf("invalid analysis type: %s. Valid options: complexity, deadcode, clones, deps, mockdata, di, security, custom", analysis)
 		}
 	}
 	if len(c.selectAnalyses) == 0 {
@@ -407,15 +504,31 @@ func (c *CheckCommand) checkComplexity(cmd *cobra.Command, args []string) (int,
 		maxComplexity = response.Request.MaxComplexity
 	}
 
-	// Count functions that exceed the maximum complexity threshold
+	// Count functions that exceed the maximum complexity threshold, or their
+	// own "# pyscn: max-complexity=N" budget when they carry one. Boilerplate
+	// excluded from risk rating is only held to its budget.
 	issueCount := 0
 	for _, function := range response.Functions {
-		if function.Metrics.Complexity > maxComplexity {
+		if function.ExcludedFromRisk && function.ComplexityBudget == 0 {
+			continue
+		}
+		limit := maxComplexity
+		if function.ComplexityBudget > 0 {
+			limit = function.ComplexityBudget
+		}
+		if function.Metrics.Complexity > limit {
 			issueCount++
-			if !c.quiet {
-				fmt.Fprintf(cmd.ErrOrStderr(), "%s:%d:%d: %s is too complex (%d > %d)\n",
-					function.FilePath, function.StartLine, function.StartColumn+1, function.Name, function.Metrics.Complexity, maxComplexity)
+			message := fmt.Sprintf("%s is too complex (%d > %d)", function.Name, function.Metrics.Complexity, limit)
+			if function.ComplexityBudget > 0 {
+				message = fmt.Sprintf("%s exceeds its complexity budget (%d > %d)", function.Name, function.Metrics.Complexity, limit)
 			}
+			c.report(cmd.ErrOrStderr(), checkDiagnostic{
+				Path:    function.FilePath,
+				Line:    function.StartLine,
+				Column:  function.StartColumn + 1,
+				Rule:    "complexity.cyclomatic",
+				Message: message,
+			}, fmt.Sprintf("%s:%d:%d: %s", function.FilePath, function.StartLine, function.StartColumn+1, message))
 		}
 	}
 
@@ -472,14 +585,18 @@ func (c *CheckCommand) checkDeadCode(cmd *cobra.Command, args []string) (int, er
 			for _, finding := range function.Findings {
 				if finding.Severity.IsAtLeast(minSeverity) {
 					issueCount++
-					if !c.quiet {
-						fmt.Fprintf(cmd.ErrOrStderr(), "%s:%d:%d: %s (%s)\n",
-							finding.Location.FilePath,
-							finding.Location.StartLine,
-							finding.Location.StartColumn+1,
-							finding.Reason,
-							finding.Severity)
-					}
+					c.report(cmd.ErrOrStderr(), checkDiagnostic{
+						Path:    finding.Location.FilePath,
+						Line:    finding.Location.StartLine,
+						Column:  finding.Location.StartColumn + 1,
+						Rule:    "deadcode." + finding.Reason,
+						Message: fmt.Sprintf("%s (%s)", finding.Description, finding.Severity),
+					}, fmt.Sprintf("%s:%d:%d: %s (%s)",
+						finding.Location.FilePath,
+						finding.Location.StartLine,
+						finding.Location.StartColumn+1,
+						finding.Reason,
+						finding.Severity))
 				}
 			}
 		}
@@ -500,6 +617,7 @@ func (c *CheckCommand) checkClones(cmd *cobra.Command, args []string) (int, erro
 		OutputWriter: io.Discard,
 		SortBy:       domain.SortBySimilarity,
 		ConfigPath:   c.configFile,
+		Jobs:         c.jobs,
 	}
 
 	// Create use case with services
@@ -530,16 +648,22 @@ func (c *CheckCommand) checkClones(cmd *cobra.Command, args []string) (int, erro
 	issueCount := 0
 	for _, pair := range response.ClonePairs {
 		issueCount++
-		if !c.quiet {
-			fmt.Fprintf(cmd.ErrOrStderr(), "%s:%d:%d: clone of %s:%d:%d (similarity: %.1f%%)\n",
-				pair.Clone1.Location.FilePath,
-				pair.Clone1.Location.StartLine,
-				pair.Clone1.Location.StartCol+1,
-				pair.Clone2.Location.FilePath,
-				pair.Clone2.Location.StartLine,
-				pair.Clone2.Location.StartCol+1,
-				pair.Similarity*100)
-		}
+		message := fmt.Sprintf("clone of %s:%d:%d (similarity: %.1f%%)",
+			pair.Clone2.Location.FilePath,
+			pair.Clone2.Location.StartLine,
+			pair.Clone2.Location.StartCol+1,
+			pair.Similarity*100)
+		c.report(cmd.ErrOrStderr(), checkDiagnostic{
+			Path:    pair.Clone1.Location.FilePath,
+			Line:    pair.Clone1.Location.StartLine,
+			Column:  pair.Clone1.Location.StartCol + 1,
+			Rule:    fmt.Sprintf("clones.type%d", int(pair.Type)),
+			Message: message,
+		}, fmt.Sprintf("%s:%d:%d: %s",
+			pair.Clone1.Location.FilePath,
+			pair.Clone1.Location.StartLine,
+			pair.Clone1.Location.StartCol+1,
+			message))
 	}
 
 	return issueCount, nil
@@ -594,11 +718,14 @@ func (c *CheckCommand) checkCircularDependencies(cmd *cobra.Command, args []stri
 		}
 
 		// Format: file:line:col: message
-		cyclePath := strings.Join(cycle.Modules, " -> ")
-		if !c.quiet {
-			fmt.Fprintf(cmd.ErrOrStderr(), "%s:1:1: circular dependency detected: %s\n",
-				filePath, cyclePath)
-		}
+		message := "circular dependency detected: " + strings.Join(cycle.Modules, " -> ")
+		c.report(cmd.ErrOrStderr(), checkDiagnostic{
+			Path:    filePath,
+			Line:    1,
+			Column:  1,
+			Rule:    "deps.cycles",
+			Message: message,
+		}, fmt.Sprintf("%s:1:1: %s", filePath, message))
 	}
 
 	return cycles.TotalCycles, nil
@@ -646,14 +773,18 @@ func (c *CheckCommand) checkMockdata(cmd *cobra.Command, args []string) (int, er
 			// Only count warning and error level findings
 			if finding.Severity.IsAtLeast(domain.MockDataSeverityWarning) {
 				issueCount++
-				if !c.quiet {
-					fmt.Fprintf(cmd.ErrOrStderr(), "%s:%d:%d: mock data detected: %s (%s)\n",
-						finding.Location.FilePath,
-						finding.Location.StartLine,
-						finding.Location.StartColumn,
-						finding.Description,
-						finding.Rationale)
-				}
+				message := fmt.Sprintf("mock data detected: %s (%s)", finding.Description, finding.Rationale)
+				c.report(cmd.ErrOrStderr(), checkDiagnostic{
+					Path:    finding.Location.FilePath,
+					Line:    finding.Location.StartLine,
+					Column:  finding.Location.StartColumn + 1,
+					Rule:    "mockdata." + string(finding.Type),
+					Message: message,
+				}, fmt.Sprintf("%s:%d:%d: %s",
+					finding.Location.FilePath,
+					finding.Location.StartLine,
+					finding.Location.StartColumn,
+					message))
 			}
 		}
 	}
@@ -710,14 +841,142 @@ func (c *CheckCommand) countDIAntipatternIssues(writer io.Writer, response *doma
 	for _, finding := range response.Findings {
 		if finding.Severity.IsAtLeast(domain.DIAntipatternSeverityWarning) {
 			issueCount++
-			if !c.quiet {
-				fmt.Fprintf(writer, "%s:%d:%d: %s: %s\n",
-					finding.Location.FilePath,
-					finding.Location.StartLine,
-					finding.Location.StartCol+1,
-					finding.Type,
-					finding.Description)
-			}
+			c.report(writer, checkDiagnostic{
+				Path:    finding.Location.FilePath,
+				Line:    finding.Location.StartLine,
+				Column:  finding.Location.StartCol + 1,
+				Rule:    "di." + string(finding.Type),
+				Message: finding.Description,
+			}, fmt.Sprintf("%s:%d:%d: %s: %s",
+				finding.Location.FilePath,
+				finding.Location.StartLine,
+				finding.Location.StartCol+1,
+				finding.Type,
+				finding.Description))
+		}
+	}
+
+	return issueCount, nil
+}
+
+// checkSecurity runs security pattern detection and returns issue count
+func (c *CheckCommand) checkSecurity(cmd *cobra.Command, args []string) (int, error) {
+	// Create request with check-specific settings
+	request := &domain.SecurityRequest{
+		Paths:        args,
+		OutputFormat: domain.OutputFormatText,
+		OutputWriter: io.Discard,
+		ConfigPath:   c.configFile,
+	}
+
+	// Validate request
+	if err := request.Validate(); err != nil {
+		return 0, fmt.Errorf("invalid security request: %w", err)
+	}
+
+	// Build use case with defaults
+	useCase, err := app.NewSecurityUseCaseBuilder().
+		WithService(service.NewSecurityService()).
+		WithFileReader(service.NewFileReader()).
+		WithFormatter(service.NewSecurityFormatter()).
+		WithConfigLoader(service.NewSecurityConfigurationLoader()).
+		Build()
+	if err != nil {
+		return 0, fmt.Errorf("failed to create security use case: %w", err)
+	}
+
+	// Run analysis
+	response, err := useCase.AnalyzeAndReturn(cmd.Context(), *request)
+	if err != nil {
+		return 0, err
+	}
+
+	return c.countSecurityIssues(cmd.ErrOrStderr(), response)
+}
+
+func (c *CheckCommand) countSecurityIssues(writer io.Writer, response *domain.SecurityResponse) (int, error) {
+	if len(response.Errors) > 0 {
+		return 0, fmt.Errorf("analysis errors: %s", strings.Join(response.Errors, "; "))
+	}
+
+	issueCount := 0
+	for _, finding := range response.Findings {
+		if finding.Severity.IsAtLeast(domain.SecuritySeverityWarning) {
+			issueCount++
+			c.report(writer, checkDiagnostic{
+				Path:    finding.Location.FilePath,
+				Line:    finding.Location.StartLine,
+				Column:  finding.Location.StartCol + 1,
+				Rule:    "security." + string(finding.Rule),
+				Message: finding.Description,
+			}, fmt.Sprintf("%s:%d:%d: %s: %s",
+				finding.Location.FilePath,
+				finding.Location.StartLine,
+				finding.Location.StartCol+1,
+				finding.Rule,
+				finding.Description))
+		}
+	}
+
+	return issueCount, nil
+}
+
+func (c *CheckCommand) checkPatternRules(cmd *cobra.Command, args []string) (int, error) {
+	request := &domain.PatternRulesRequest{
+		Paths:        args,
+		OutputFormat: domain.OutputFormatText,
+		OutputWriter: io.Discard,
+		ConfigPath:   c.configFile,
+	}
+
+	if err := request.Validate(); err != nil {
+		return 0, fmt.Errorf("invalid custom rules request: %w", err)
+	}
+
+	useCase, err := app.NewPatternRulesUseCaseBuilder().
+		WithService(service.NewPatternRulesService()).
+		WithFileReader(service.NewFileReader()).
+		WithFormatter(service.NewPatternRulesFormatter()).
+		WithConfigLoader(service.NewPatternRulesConfigurationLoader()).
+		Build()
+	if err != nil {
+		return 0, fmt.Errorf("failed to create custom rules use case: %w", err)
+	}
+
+	response, err := useCase.AnalyzeAndReturn(cmd.Context(), *request)
+	if err != nil {
+		return 0, err
+	}
+	if response.Summary.RulesRun == 0 && !c.quiet {
+		fmt.Fprintf(cmd.ErrOrStderr(), "⚠️  No custom rules configured (set files in [custom_rules])\n")
+	}
+
+	return c.countPatternRuleIssues(cmd.ErrOrStderr(), response)
+}
+
+// countPatternRuleIssues reports findings at warning or error severity under
+// their user-defined rule IDs
+func (c *CheckCommand) countPatternRuleIssues(writer io.Writer, response *domain.PatternRulesResponse) (int, error) {
+	if len(response.Errors) > 0 {
+		return 0, fmt.Errorf("analysis errors: %s", strings.Join(response.Errors, "; "))
+	}
+
+	issueCount := 0
+	for _, finding := range response.Findings {
+		if finding.Severity.IsAtLeast(domain.PatternRuleSeverityWarning) {
+			issueCount++
+			c.report(writer, checkDiagnostic{
+				Path:    finding.Location.FilePath,
+				Line:    finding.Location.StartLine,
+				Column:  finding.Location.StartCol + 1,
+				Rule:    finding.Rule,
+				Message: finding.Message,
+			}, fmt.Sprintf("%s:%d:%d: %s: %s",
+				finding.Location.FilePath,
+				finding.Location.StartLine,
+				finding.Location.StartCol+1,
+				finding.Rule,
+				finding.Message))
 		}
 	}
 
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Output formats of the check command
+const (
+	checkFormatText = "text"
+	checkFormatLint = "lint"
+)
+
+// checkDiagnostic is one finding of the check command. In lint format every
+// diagnostic is written as "path:line:col: RULE message" with a 1-based line
+// and column, a dotted rule ID and a single-line message.
+type checkDiagnostic struct {
+	Path    string
+	Line    int
+	Column  int
+	Rule    string
+	Message string
+}
+
+// String formats the diagnostic as a lint line
+func (d checkDiagnostic) String() string {
+	line, column := d.Line, d.Column
+	if line < 1 {
+		line = 1
+	}
+	if column < 1 {
+		column = 1
+	}
+	message := strings.Join(strings.Fields(d.Message), " ")
+	return fmt.Sprintf("%s:%d:%d: %s %s", d.Path, line, column, d.Rule, message)
+}
+
+// lintMode reports whether findings are collected for lint output
+func (c *CheckCommand) lintMode() bool {
+	return c.format == checkFormatLint
+}
+
+// report records a finding. In lint format the diagnostic is kept for
+// writeDiagnostics; otherwise text, the classic check line, is printed
+// unless quiet is set.
+func (c *CheckCommand) report(writer io.Writer, diagnostic checkDiagnostic, text string) {
+	if c.lintMode() {
+		c.diagnostics = append(c.diagnostics, diagnostic)
+		return
+	}
+	if !c.quiet {
+		fmt.Fprintln(writer, text)
+	}
+}
+
+// writeDiagnostics writes the collected diagnostics ordered by path,
+// position and rule. Paths inside the working directory are made relative
+// to it, so every analyzer reports a file the same way.
+func (c *CheckCommand) writeDiagnostics(writer io.Writer) {
+	workDir, _ := os.Getwd()
+	for i := range c.diagnostics {
+		c.diagnostics[i].Path = lintPath(c.diagnostics[i].Path, workDir)
+	}
+	sort.SliceStable(c.diagnostics, func(i, j int) bool {
+		a, b := c.diagnostics[i], c.diagnostics[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		return a.Rule < b.Rule
+	})
+	for _, diagnostic := range c.diagnostics {
+		fmt.Fprintln(writer, diagnostic.String())
+	}
+}
+
+// lintPath returns path relative to workDir with forward slashes, or
+// unchanged when it lies outside workDir
+func lintPath(path, workDir string) string {
+	if workDir == "" || !filepath.IsAbs(path) {
+		return filepath.ToSlash(filepath.Clean(path))
+	}
+	rel, err := filepath.Rel(workDir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/config"
+	"github.com/ludo-technologies/pyscn/service"
+	"github.com/spf13/cobra"
+)
+
+// ClassesCommand represents the classes command
+type ClassesCommand struct {
+	configFile string
+	format     string
+	cbo        bool
+}
+
+// NewClassesCommand creates a new classes command
+func NewClassesCommand() *ClassesCommand {
+	return &ClassesCommand{
+		format: "mermaid",
+	}
+}
+
+// CreateCobraCommand creates the cobra command for class diagrams
+func (c *ClassesCommand) CreateCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "classes [files...]",
+		Short: "Generate class diagrams in Mermaid or PlantUML",
+		Long: `Generate a class diagram of the classes in the given files and packages.
+
+Each class is listed with its public attributes, set in the class body or
+in __init__, and its number of methods. Classes are linked by inheritance,
+and by composition when an attribute is annotated with a class of the
+diagram, constructed from one, or set from an __init__ parameter annotated
+with one. Names are resolved through the imports of each module. Bases and
+attribute types outside the analyzed files are shown as written, without
+an edge.
+
+With --cbo, classes are also annotated with their CBO (coupling between
+objects) and colored by its risk level: green, yellow or red.
+
+Examples:
+  # Mermaid diagram of a package, for a Markdown document
+  pyscn classes src/shop/orders
+
+  # PlantUML diagram colored by coupling
+  pyscn classes --format plantuml --cbo src/shop > shop.puml
+
+  # Classes and relations as JSON
+  pyscn classes --format json src/`,
+		Args: cobra.ArbitraryArgs,
+		RunE: c.runClasses,
+	}
+
+	cmd.Flags().StringVarP(&c.configFile, "config", "c", "", "Configuration file path")
+	cmd.Flags().StringVar(&c.format, "format", "mermaid", "Output format: mermaid, plantuml or json")
+	_ = cmd.RegisterFlagCompletionFunc("format", completeValues("mermaid", "plantuml", "json"))
+	cmd.Flags().BoolVar(&c.cbo, "cbo", false, "Annotate classes with CBO and color them by risk level")
+
+	return cmd
+}
+
+// runClasses extracts and writes the class diagram
+func (c *ClassesCommand) runClasses(cmd *cobra.Command, args []string) error {
+	if c.format != "mermaid" && c.format != "plantuml" && c.format != "json" {
+		return fmt.Errorf("invalid --format %q: must be mermaid, plantuml or json", c.format)
+	}
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+
+	cfg, err := config.LoadConfigWithTarget(c.configFile, getTargetPathFromArgs(args))
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	fileReader := service.NewFileReader().WithWalkOptions(domain.FileWalkOptions{
+		MaxDepth:       cfg.Analysis.MaxDepth,
+		FollowSymlinks: cfg.Analysis.FollowSymlinks,
+	})
+	files, err := fileReader.CollectPythonFiles(args, cfg.Analysis.Recursive, cfg.Analysis.IncludePatterns, cfg.Analysis.ExcludePatterns)
+	if err != nil {
+		return fmt.Errorf("failed to collect Python files: %w", err)
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	diagram, err := service.ExtractClassDiagram(ctx, files)
+	if err != nil {
+		return err
+	}
+	if c.cbo {
+		if err := c.annotateCBO(ctx, diagram, files, getTargetPathFromArgs(args)); err != nil {
+			return err
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	switch c.format {
+	case "json":
+		return service.WriteJSON(out, diagram)
+	case "plantuml":
+		service.WriteClassDiagramPlantUML(out, diagram)
+	default:
+		service.WriteClassDiagramMermaid(out, diagram)
+	}
+	return nil
+}
+
+// annotateCBO measures the coupling of every class with the configured CBO
+// thresholds
+func (c *ClassesCommand) annotateCBO(ctx context.Context, diagram *domain.ClassDiagram, files []string, target string) error {
+	configPath, err := config.NewTomlConfigLoader().ResolveConfigPath(c.configFile, target)
+	if err != nil {
+		return fmt.Errorf("failed to resolve configuration: %w", err)
+	}
+	loader := service.NewCBOConfigurationLoader()
+	request := loader.LoadDefaultConfig()
+	if configPath != "" {
+		if request, err = loader.LoadConfig(configPath); err != nil {
+			return err
+		}
+	}
+	request.Paths = files
+	request.MinCBO = 0
+	request.MaxCBO = 0
+	request.ShowZeros = domain.BoolPtr(true)
+
+	response, err := service.NewCBOService().Analyze(ctx, *request)
+	if err != nil {
+		return fmt.Errorf("CBO analysis failed: %w", err)
+	}
+	service.AnnotateClassDiagramCBO(diagram, response)
+	return nil
+}
+
+// NewClassesCmd creates and returns the classes cobra command
+func NewClassesCmd() *cobra.Command {
+	classesCommand := NewClassesCommand()
+	return classesCommand.CreateCobraCommand()
+}
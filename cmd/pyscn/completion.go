@@ -0,0 +1,122 @@
+package main
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// registerCompletions adds the dynamic completions shared by several
+// commands: --select values, configuration files and file lists. Command
+// specific completions, such as rule IDs for explain, are registered by
+// the commands themselves. Shell scripts come from cobra's completion
+// command: pyscn completion bash|zsh|fish|powershell.
+func registerCompletions(cmd *cobra.Command) {
+	if flag := cmd.Flags().Lookup("select"); flag != nil {
+		values := analyzeSelectValues
+		if cmd.Name() == "check" {
+			values = checkSelectValues
+		}
+		_ = cmd.RegisterFlagCompletionFunc("select", completeList(values))
+	}
+	if flag := cmd.Flags().Lookup("config"); flag != nil {
+		_ = cmd.MarkFlagFilename("config", "toml")
+	}
+	if flag := cmd.Flags().Lookup("files-from"); flag != nil {
+		_ = cmd.MarkFlagFilename("files-from")
+	}
+	for _, sub := range cmd.Commands() {
+		registerCompletions(sub)
+	}
+}
+
+// analyzeSelectValues returns the analyses and rule IDs analyze --select
+// accepts, described for shells that show descriptions
+func analyzeSelectValues() []cobra.Completion {
+	values := make([]cobra.Completion, 0, len(domain.SelectableAnalyses))
+	for _, analysis := range domain.SelectableAnalyses {
+		values = append(values, cobra.CompletionWithDesc(analysis, "analysis"))
+	}
+	return append(values, ruleIDValues()...)
+}
+
+// checkSelectValues returns the analyses check --select accepts
+func checkSelectValues() []cobra.Completion {
+	return checkAnalyses
+}
+
+// simulateFixValues returns the analyses simulate --fix accepts
+func simulateFixValues() []cobra.Completion {
+	return domain.SimulatableAnalyses()
+}
+
+// ruleIDValues returns every rule ID with its description
+func ruleIDValues() []cobra.Completion {
+	rules := domain.AnalysisRules()
+	values := make([]cobra.Completion, 0, len(rules))
+	for _, rule := range rules {
+		values = append(values, cobra.CompletionWithDesc(rule.ID, rule.Description))
+	}
+	return values
+}
+
+// completeList completes one item of a comma-separated list, keeping the
+// items already typed and leaving out the ones already chosen
+func completeList(values func() []cobra.Completion) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		done, current := "", toComplete
+		if i := strings.LastIndex(toComplete, ","); i >= 0 {
+			done, current = toComplete[:i+1], toComplete[i+1:]
+		}
+		chosen := strings.Split(done, ",")
+
+		var completions []cobra.Completion
+		for _, value := range values() {
+			name, _, _ := strings.Cut(value, "\t")
+			if strings.HasPrefix(name, strings.ToLower(current)) && !slices.Contains(chosen, name) {
+				completions = append(completions, done+value)
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+}
+
+// completeRuleIDs completes the rule ID argument of explain
+func completeRuleIDs(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var completions []cobra.Completion
+	for _, value := range analyzeSelectValues() {
+		if strings.HasPrefix(value, strings.ToLower(toComplete)) {
+			completions = append(completions, value)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeConfigKeys completes configuration keys and their sections
+func completeConfigKeys(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	var completions []cobra.Completion
+	section := ""
+	for _, key := range config.ConfigKeys() {
+		if current, _, ok := strings.Cut(key, "."); ok && current != section {
+			section = current
+			if strings.HasPrefix(section, toComplete) && !slices.Contains(args, section) {
+				completions = append(completions, section)
+			}
+		}
+		if strings.HasPrefix(key, toComplete) && !slices.Contains(args, key) {
+			completions = append(completions, key)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeValues completes a flag with a fixed set of values
+func completeValues(values ...string) cobra.CompletionFunc {
+	return cobra.FixedCompletions(values, cobra.ShellCompDirectiveNoFileComp)
+}
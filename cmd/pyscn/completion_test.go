@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// complete runs the hidden completion command the shell scripts call and
+// returns the candidates without their descriptions
+func complete(t *testing.T, args ...string) []string {
+	t.Helper()
+	var out bytes.Buffer
+	root := newRootCmd()
+	root.SetOut(&out)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs(append([]string{"__complete"}, args...))
+	if err := root.Execute(); err != nil {
+		t.Fatalf("completion of %v failed: %v", args, err)
+	}
+
+	var candidates []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if strings.HasPrefix(line, ":") {
+			break // Directive
+		}
+		name, _, _ := strings.Cut(line, "\t")
+		candidates = append(candidates, name)
+	}
+	return candidates
+}
+
+func containsAll(candidates []string, want ...string) bool {
+	set := make(map[string]bool, len(candidates))
+	for _, candidate := range candidates {
+		set[candidate] = true
+	}
+	for _, w := range want {
+		if !set[w] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCompletionSelect(t *testing.T) {
+	candidates := complete(t, "analyze", "--select", "complexity,clones.")
+	if !containsAll(candidates, "complexity,clones.type1", "complexity,clones.type4") {
+		t.Errorf("expected clone rules after the typed items, got %v", candidates)
+	}
+
+	candidates = complete(t, "analyze", "--select", "deadcode,")
+	if containsAll(candidates, "deadcode,deadcode") || !containsAll(candidates, "deadcode,complexity") {
+		t.Errorf("expected the analyses not chosen yet, got %v", candidates)
+	}
+
+	candidates = complete(t, "check", "--select", "")
+	if !containsAll(candidates, "mockdata", "security") || containsAll(candidates, "lcom") {
+		t.Errorf("expected the check analyses, got %v", candidates)
+	}
+}
+
+func TestCompletionRuleIDsAndConfigKeys(t *testing.T) {
+	if candidates := complete(t, "explain", "deps."); !containsAll(candidates, "deps.cycles", "deps.architecture") {
+		t.Errorf("expected dependency rules, got %v", candidates)
+	}
+	if candidates := complete(t, "explain", "deps.cycles", ""); len(candidates) != 0 {
+		t.Errorf("expected no second argument, got %v", candidates)
+	}
+
+	candidates := complete(t, "config", "show", "--effective", "clones.min_")
+	if !containsAll(candidates, "clones.min_lines", "clones.min_nodes") {
+		t.Errorf("expected clone keys, got %v", candidates)
+	}
+	if candidates := complete(t, "config", "show", "cl"); !containsAll(candidates, "clones") {
+		t.Errorf("expected the clones section, got %v", candidates)
+	}
+
+	if candidates := complete(t, "check", "--format", ""); !containsAll(candidates, "text", "lint") {
+		t.Errorf("expected check formats, got %v", candidates)
+	}
+}
+
+func TestConfigShowFiltersKeys(t *testing.T) {
+	var out bytes.Buffer
+	cmd := NewConfigCommand().CreateCobraCommand()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"show", "--effective", "clones.min_lines", "output"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("config show failed: %v", err)
+	}
+	text := out.String()
+	if !strings.Contains(text, "min_lines = ") || !strings.Contains(text, "[output]") {
+		t.Errorf("expected the selected settings, got:\n%s", text)
+	}
+	if strings.Contains(text, "min_nodes") || strings.Contains(text, "[complexity]") {
+		t.Errorf("expected other settings to be left out, got:\n%s", text)
+	}
+
+	cmd = NewConfigCommand().CreateCobraCommand()
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"show", "--effective", "no_such_key"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "no setting matches") {
+		t.Errorf("expected an unknown key to fail, got %v", err)
+	}
+}
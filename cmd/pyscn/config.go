@@ -0,0 +1,317 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/internal/config"
+	"github.com/ludo-technologies/pyscn/service"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/spf13/cobra"
+)
+
+// ConfigCommand represents the config inspection commands
+type ConfigCommand struct {
+	configFile string
+	effective  bool
+	json       bool
+
+	// Analyze flags that override configuration values
+	minComplexity                int
+	minSeverity                  string
+	cloneSimilarity              float64
+	minCBO                       int
+	lowThreshold                 int
+	mediumThreshold              int
+	cognitiveComplexityThreshold int
+	nestingDepthThreshold        int
+}
+
+// NewConfigCommand creates a new config command
+func NewConfigCommand() *ConfigCommand {
+	return &ConfigCommand{
+		configFile: "",
+		effective:  false,
+		json:       false,
+	}
+}
+
+// CreateCobraCommand creates the cobra command with its validate and show subcommands
+func (c *ConfigCommand) CreateCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Validate and inspect pyscn configuration",
+		Long: `Validate and inspect the pyscn configuration.
+
+The configuration file is discovered the same way analyze does: .pyscn.toml
+first, then pyproject.toml with a [tool.pyscn] section, searching from the
+current directory upwards. Use --config to point at a specific file.`,
+		Args: cobra.NoArgs,
+	}
+
+	validateCmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check the configuration file for mistakes",
+		Long: `Check the configuration file for mistakes.
+
+Reports TOML syntax errors, unknown keys (with a suggestion for likely typos),
+values of the wrong type, out-of-range thresholds and options that conflict
+with each other or are silently ignored.
+
+The command exits with a non-zero status when an error is found. Warnings
+are reported but do not fail validation.
+
+Examples:
+  # Validate the discovered configuration
+  pyscn config validate
+
+  # Validate a specific file
+  pyscn config validate --config ci/pyscn.toml`,
+		Args: cobra.NoArgs,
+		RunE: c.runValidate,
+		// The issues are already reported; usage text would bury them
+		SilenceUsage: true,
+	}
+
+	showCmd := &cobra.Command{
+		Use:   "show [key...]",
+		Short: "Show the configuration",
+		Long: `Show the configuration.
+
+With --effective, prints every setting after merging the built-in defaults,
+the configuration file and any analyze flags given on the command line,
+together with where each value came from (default, file or flag). Keys
+such as clones.min_lines, or sections such as clones, limit the output to
+those settings.
+
+Examples:
+  # Show the merged configuration and the source of each value
+  pyscn config show --effective
+
+  # See what analyze would use with a flag override
+  pyscn config show --effective --min-complexity 10
+
+  # Show the clone detection settings only
+  pyscn config show --effective clones`,
+		RunE:              c.runShow,
+		ValidArgsFunction: completeConfigKeys,
+		SilenceUsage:      true,
+	}
+
+	for _, sub := range []*cobra.Command{validateCmd, showCmd} {
+		sub.Flags().StringVarP(&c.configFile, "config", "c", "", "Configuration file path")
+		sub.Flags().BoolVar(&c.json, "json", false, "Output JSON to stdout")
+		c.addOverrideFlags(sub)
+	}
+	showCmd.Flags().BoolVar(&c.effective, "effective", false, "Show the merged configuration with the source of each value")
+
+	cmd.AddCommand(validateCmd, showCmd)
+	return cmd
+}
+
+// addOverrideFlags registers the analyze flags that override configuration values
+func (c *ConfigCommand) addOverrideFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&c.minComplexity, "min-complexity", 0, "Override output.min_complexity as analyze would")
+	cmd.Flags().StringVar(&c.minSeverity, "min-severity", "", "Override dead_code.min_severity as analyze would")
+	cmd.Flags().Float64Var(&c.cloneSimilarity, "clone-threshold", 0, "Override clones.similarity_threshold as analyze would")
+	cmd.Flags().IntVar(&c.minCBO, "min-cbo", 0, "Override cbo.min_cbo as analyze would")
+	cmd.Flags().IntVar(&c.lowThreshold, "low-threshold", 0, "Override complexity.low_threshold as analyze would")
+	cmd.Flags().IntVar(&c.mediumThreshold, "medium-threshold", 0, "Override complexity.medium_threshold as analyze would")
+	cmd.Flags().IntVar(&c.cognitiveComplexityThreshold, "cognitive-complexity-threshold", 0, "Override complexity.cognitive_complexity_threshold as analyze would")
+	cmd.Flags().IntVar(&c.nestingDepthThreshold, "nesting-depth-threshold", 0, "Override complexity.nesting_depth_threshold as analyze would")
+}
+
+// analyzeFlagOverrides maps the analyze flags of cmd that override
+// configuration values, when explicitly set, to configuration keys
+func analyzeFlagOverrides(cmd *cobra.Command) map[string]interface{} {
+	overrides := make(map[string]interface{})
+	flags := cmd.Flags()
+	set := func(flag, key string, value func(string) (interface{}, error)) {
+		if !flags.Changed(flag) {
+			return
+		}
+		if v, err := value(flag); err == nil {
+			overrides[key] = v
+		}
+	}
+	intFlag := func(name string) (interface{}, error) { return flags.GetInt(name) }
+	stringFlag := func(name string) (interface{}, error) { return flags.GetString(name) }
+	floatFlag := func(name string) (interface{}, error) { return flags.GetFloat64(name) }
+
+	set("min-complexity", "output.min_complexity", intFlag)
+	set("min-severity", "dead_code.min_severity", stringFlag)
+	set("clone-threshold", "clones.similarity_threshold", floatFlag)
+	set("min-cbo", "cbo.min_cbo", intFlag)
+	set("low-threshold", "complexity.low_threshold", intFlag)
+	set("medium-threshold", "complexity.medium_threshold", intFlag)
+	set("cognitive-complexity-threshold", "complexity.cognitive_complexity_threshold", intFlag)
+	set("nesting-depth-threshold", "complexity.nesting_depth_threshold", intFlag)
+	return overrides
+}
+
+// inspect resolves the configuration file and inspects it
+func (c *ConfigCommand) inspect(cmd *cobra.Command) (*config.ConfigInspection, error) {
+	loader := config.NewTomlConfigLoader()
+	path, err := loader.ResolveConfigPath(c.configFile, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve configuration: %w", err)
+	}
+
+	inspection, err := config.InspectConfig(path, analyzeFlagOverrides(cmd))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration from %s: %w", path, err)
+	}
+	return inspection, nil
+}
+
+// runValidate reports the issues in the configuration file
+func (c *ConfigCommand) runValidate(cmd *cobra.Command, args []string) error {
+	inspection, err := c.inspect(cmd)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if c.json {
+		result := struct {
+			Path   string               `json:"path,omitempty"`
+			Valid  bool                 `json:"valid"`
+			Issues []config.ConfigIssue `json:"issues"`
+		}{inspection.Path, !inspection.HasErrors(), inspection.Issues}
+		if err := service.WriteJSON(out, result); err != nil {
+			return err
+		}
+	} else {
+		writeConfigIssues(out, inspection)
+	}
+
+	if inspection.HasErrors() {
+		return fmt.Errorf("configuration is invalid")
+	}
+	return nil
+}
+
+// runShow prints the configuration
+func (c *ConfigCommand) runShow(cmd *cobra.Command, args []string) error {
+	if !c.effective {
+		return fmt.Errorf("only --effective is supported; run 'pyscn config show --effective'")
+	}
+
+	inspection, err := c.inspect(cmd)
+	if err != nil {
+		return err
+	}
+	if inspection.HasErrors() {
+		writeConfigIssues(cmd.ErrOrStderr(), inspection)
+		return fmt.Errorf("configuration is invalid")
+	}
+
+	if len(args) > 0 {
+		inspection.Settings = filterSettings(inspection.Settings, args)
+		if len(inspection.Settings) == 0 {
+			return fmt.Errorf("no setting matches %s; see 'pyscn config show --effective' for every key", strings.Join(args, ", "))
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	if c.json {
+		return service.WriteJSON(out, inspection)
+	}
+	writeEffectiveSettings(out, inspection)
+	return nil
+}
+
+// filterSettings keeps the settings whose key is one of keys or lies in one
+// of them as a section
+func filterSettings(settings []config.EffectiveSetting, keys []string) []config.EffectiveSetting {
+	var filtered []config.EffectiveSetting
+	for _, setting := range settings {
+		for _, key := range keys {
+			if setting.Key == key || strings.HasPrefix(setting.Key, key+".") {
+				filtered = append(filtered, setting)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// writeConfigIssues prints validation issues, one per line, in a
+// file:line: severity: message layout
+func writeConfigIssues(w io.Writer, inspection *config.ConfigInspection) {
+	source := inspection.Path
+	if source == "" {
+		fmt.Fprintln(w, "No configuration file found; using built-in defaults")
+		source = "defaults"
+	}
+
+	errorCount := 0
+	for _, issue := range inspection.Issues {
+		location := source
+		if issue.Line > 0 {
+			location = fmt.Sprintf("%s:%d", source, issue.Line)
+		}
+		if issue.Severity == config.IssueError {
+			errorCount++
+		}
+		fmt.Fprintf(w, "%s: %s: %s\n", location, issue.Severity, issue.Message)
+	}
+
+	warningCount := len(inspection.Issues) - errorCount
+	if len(inspection.Issues) == 0 {
+		fmt.Fprintf(w, "✅ %s is valid\n", source)
+		return
+	}
+	fmt.Fprintf(w, "\n%d error(s), %d warning(s)\n", errorCount, warningCount)
+}
+
+// writeEffectiveSettings prints the merged configuration as TOML-like
+// key = value lines annotated with their source
+func writeEffectiveSettings(w io.Writer, inspection *config.ConfigInspection) {
+	if inspection.Path != "" {
+		fmt.Fprintf(w, "# Configuration file: %s\n", inspection.Path)
+	} else {
+		fmt.Fprintln(w, "# No configuration file found; using built-in defaults")
+	}
+
+	section := ""
+	for _, setting := range inspection.Settings {
+		name := setting.Key
+		if dot := strings.Index(setting.Key, "."); dot >= 0 {
+			if current := setting.Key[:dot]; current != section {
+				section = current
+				fmt.Fprintf(w, "\n[%s]\n", section)
+			}
+			name = setting.Key[dot+1:]
+		}
+		fmt.Fprintf(w, "%s = %s  # %s\n", name, formatSettingValue(setting.Value), setting.Source)
+	}
+
+	for _, issue := range inspection.Issues {
+		fmt.Fprintf(w, "\n# %s: %s", issue.Severity, issue.Message)
+	}
+	if len(inspection.Issues) > 0 {
+		fmt.Fprintln(w)
+	}
+}
+
+// formatSettingValue renders a value as an inline TOML value
+func formatSettingValue(value interface{}) string {
+	data, err := toml.Marshal(map[string]interface{}{"v": value})
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	rendered := strings.TrimSpace(string(data))
+	if strings.HasPrefix(rendered, "v = ") {
+		return strings.TrimPrefix(rendered, "v = ")
+	}
+	// Arrays of tables do not fit on one line
+	return fmt.Sprint(value)
+}
+
+// NewConfigCmd creates and returns the config cobra command
+func NewConfigCmd() *cobra.Command {
+	configCommand := NewConfigCommand()
+	return configCommand.CreateCobraCommand()
+}
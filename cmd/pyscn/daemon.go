@@ -0,0 +1,358 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ludo-technologies/pyscn/internal/daemon"
+	"github.com/ludo-technologies/pyscn/internal/version"
+	"github.com/ludo-technologies/pyscn/service"
+	"github.com/spf13/cobra"
+)
+
+// daemonCommands are the commands a running daemon serves; everything else
+// always runs in the invoking process
+var daemonCommands = map[string]bool{
+	"analyze": true,
+	"check":   true,
+}
+
+// DaemonCommand represents the daemon command
+type DaemonCommand struct {
+	socket string
+	json   bool
+}
+
+// NewDaemonCommand creates a new daemon command
+func NewDaemonCommand() *DaemonCommand {
+	return &DaemonCommand{}
+}
+
+// CreateCobraCommand creates the cobra command for the daemon
+func (d *DaemonCommand) CreateCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Serve analyses from a warm background process",
+		Long: `Run pyscn as a long-lived process that keeps parsed files and their
+control flow graphs in memory between analyses.
+
+While a daemon is running, 'pyscn analyze' and 'pyscn check' hand their work
+to it over a local socket and print its output, so repeated runs on the same
+tree only reparse files that changed. Invocations fall back to running
+locally when no daemon is listening, when the daemon was built from a
+different pyscn version, or with --no-daemon. Interactive runs (--interactive
+or an HTML report that would open in the browser) always run locally.
+
+The daemon runs in the foreground; stop it with Ctrl+C or 'pyscn daemon stop'.
+
+Examples:
+  # Start a daemon in the background
+  pyscn daemon &
+
+  # Show what the daemon has cached
+  pyscn daemon status
+
+  # Run one analysis without the daemon
+  pyscn analyze --no-daemon --json src/
+
+  # Stop the daemon
+  pyscn daemon stop`,
+		Args: cobra.NoArgs,
+		RunE: d.runServe,
+	}
+
+	cmd.PersistentFlags().StringVar(&d.socket, "socket", "", "Socket path (default: $PYSCN_DAEMON_SOCKET or a per-user runtime directory)")
+
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the state of the running daemon",
+		Args:  cobra.NoArgs,
+		RunE:  d.runStatus,
+	}
+	statusCmd.Flags().BoolVar(&d.json, "json", false, "Print the status as JSON")
+
+	stopCmd := &cobra.Command{
+		Use:   "stop",
+		Short: "Stop the running daemon",
+		Args:  cobra.NoArgs,
+		RunE:  d.runStop,
+	}
+
+	cmd.AddCommand(statusCmd, stopCmd)
+	return cmd
+}
+
+// socketPath returns the socket selected by --socket or the default
+func (d *DaemonCommand) socketPath() string {
+	if d.socket != "" {
+		return d.socket
+	}
+	return daemon.DefaultSocketPath()
+}
+
+// runServe serves requests until interrupted or stopped
+func (d *DaemonCommand) runServe(cmd *cobra.Command, args []string) error {
+	path := d.socketPath()
+	listener, err := daemon.Listen(path)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(path)
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	cache := service.NewProjectFileCache()
+	service.SetProjectFileCache(cache)
+	defer service.SetProjectFileCache(nil)
+
+	server := &daemonServer{
+		socket:    path,
+		startedAt: time.Now(),
+		cache:     cache,
+		stop:      cancel,
+	}
+
+	// The daemon has no terminal of its own: never open a browser or draw
+	// progress bars, whatever the daemon's stderr is attached to
+	service.SetMachineMode(true)
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "pyscn daemon %s listening on %s (pid %d)\n", version.Short(), path, os.Getpid())
+	if err := daemon.Serve(ctx, listener, server.handle); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "pyscn daemon stopped after %d request(s)\n", server.requests)
+	return nil
+}
+
+// runStatus prints the state of the running daemon
+func (d *DaemonCommand) runStatus(cmd *cobra.Command, args []string) error {
+	resp, err := d.call(daemon.MethodStatus)
+	if err != nil {
+		return err
+	}
+	status := resp.Status
+	if status == nil {
+		return fmt.Errorf("daemon returned no status")
+	}
+
+	if d.json {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(status)
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "pyscn daemon %s (pid %d)\n", status.Version, status.PID)
+	fmt.Fprintf(out, "Socket:    %s\n", status.Socket)
+	fmt.Fprintf(out, "Uptime:    %s\n", time.Since(status.StartedAt).Round(time.Second))
+	fmt.Fprintf(out, "Requests:  %d\n", status.Requests)
+	fmt.Fprintf(out, "Cache:     %d file(s), %d hit(s), %d miss(es)\n", status.CachedFiles, status.CacheHits, status.CacheMisses)
+	return nil
+}
+
+// runStop asks the running daemon to shut down
+func (d *DaemonCommand) runStop(cmd *cobra.Command, args []string) error {
+	if _, err := d.call(daemon.MethodShutdown); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "pyscn daemon stopped\n")
+	return nil
+}
+
+// call sends a request without arguments to the daemon
+func (d *DaemonCommand) call(method string) (*daemon.Response, error) {
+	path := d.socketPath()
+	resp, err := daemon.Call(path, daemon.Request{Method: method, Version: daemonBuildID()})
+	if err != nil {
+		return nil, fmt.Errorf("no pyscn daemon is running on %s", path)
+	}
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+	return resp, nil
+}
+
+// daemonServer handles the requests of a running daemon. daemon.Serve
+// calls handle for one request at a time.
+type daemonServer struct {
+	socket    string
+	startedAt time.Time
+	cache     *service.ProjectFileCache
+	stop      context.CancelFunc
+	requests  int
+}
+
+func (s *daemonServer) handle(ctx context.Context, req daemon.Request) daemon.Response {
+	switch req.Method {
+	case daemon.MethodStatus:
+		stats := s.cache.Stats()
+		return daemon.Response{Status: &daemon.Status{
+			Version:     daemonBuildID(),
+			PID:         os.Getpid(),
+			Socket:      s.socket,
+			StartedAt:   s.startedAt,
+			Requests:    s.requests,
+			CachedFiles: stats.Entries,
+			CacheHits:   stats.Hits,
+			CacheMisses: stats.Misses,
+		}}
+	case daemon.MethodShutdown:
+		s.stop()
+		return daemon.Response{}
+	case daemon.MethodRun:
+		if req.Version != daemonBuildID() {
+			return daemon.Response{Error: fmt.Sprintf("daemon runs pyscn %s, client is %s", daemonBuildID(), req.Version)}
+		}
+		s.requests++
+		return s.run(req)
+	default:
+		return daemon.Response{Error: fmt.Sprintf("unknown method %q", req.Method)}
+	}
+}
+
+// run executes a CLI invocation in the client's working directory and
+// captures its output. Process-wide settings that commands may change are
+// restored afterwards.
+func (s *daemonServer) run(req daemon.Request) daemon.Response {
+	prevDir, err := os.Getwd()
+	if err != nil {
+		return daemon.Response{Error: fmt.Sprintf("failed to get working directory: %v", err)}
+	}
+	if err := os.Chdir(req.Cwd); err != nil {
+		return daemon.Response{Error: fmt.Sprintf("failed to enter %s: %v", req.Cwd, err)}
+	}
+	defer os.Chdir(prevDir)
+
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(0))
+	defer debug.SetMemoryLimit(debug.SetMemoryLimit(-1))
+
+	var stdout, stderr bytes.Buffer
+	log.SetOutput(&stderr)
+	defer log.SetOutput(os.Stderr)
+
+	root := newRootCmd()
+	if cmd, _, err := root.Find(req.Args); err != nil || cmd.Parent() != root || !daemonCommands[cmd.Name()] {
+		return daemon.Response{Error: "command is not served by the daemon"}
+	}
+	root.SetArgs(req.Args)
+	root.SetIn(strings.NewReader(""))
+	root.SetOut(&stdout)
+	root.SetErr(&stderr)
+
+	exitCode := 0
+	if err := root.Execute(); err != nil {
+		exitCode = 1
+	}
+	return daemon.Response{ExitCode: exitCode, Stdout: stdout.String(), Stderr: stderr.String()}
+}
+
+// runInDaemon hands the invocation to a running daemon when it can serve
+// it, copies the daemon's output and returns its exit code. It returns
+// false when the command should run in this process instead.
+func runInDaemon(args []string, stdout, stderr io.Writer) (int, bool) {
+	if !canRunInDaemon(args) {
+		return 0, false
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return 0, false
+	}
+
+	resp, err := daemon.Call(daemon.DefaultSocketPath(), daemon.Request{
+		Method:  daemon.MethodRun,
+		Version: daemonBuildID(),
+		Cwd:     cwd,
+		Args:    args,
+	})
+	if err != nil || resp.Error != "" {
+		return 0, false
+	}
+
+	_, _ = io.WriteString(stdout, resp.Stdout)
+	_, _ = io.WriteString(stderr, resp.Stderr)
+	return resp.ExitCode, true
+}
+
+// canRunInDaemon reports whether args name a command the daemon serves and
+// nothing about the invocation needs this process's terminal
+func canRunInDaemon(args []string) bool {
+	root := newRootCmd()
+	cmd, rest, err := root.Find(args)
+	if err != nil || cmd.Parent() != root || !daemonCommands[cmd.Name()] {
+		return false
+	}
+	if err := cmd.ParseFlags(rest); err != nil {
+		return false
+	}
+
+	flags := cmd.Flags()
+	if noDaemon, _ := flags.GetBool("no-daemon"); noDaemon {
+		return false
+	}
+	if help, err := flags.GetBool("help"); err == nil && help {
+		return false
+	}
+	// The daemon cannot read this process's standard input
+	if filesFrom, err := flags.GetString("files-from"); err == nil && filesFrom == filesFromStdin {
+		return false
+	}
+
+	if cmd.Name() == "analyze" {
+		if interactive, _ := flags.GetBool("interactive"); interactive {
+			return false
+		}
+		// The daemon returns stderr when the run is over, too late for progress events
+		if progress, _ := flags.GetString("progress"); progress == progressJSON {
+			return false
+		}
+		// An HTML report is opened in the browser from the invoking terminal
+		ci, _ := flags.GetBool("ci")
+		noOpen, _ := flags.GetBool("no-open")
+		if !ci && !noOpen && analyzeWritesHTML(cmd) && service.IsInteractiveEnvironment() && !service.IsSSH() {
+			return false
+		}
+	}
+	return true
+}
+
+// analyzeWritesHTML reports whether analyze produces an HTML report: when
+// --html is set, which combines with the other format flags, or when no
+// format flag is set
+func analyzeWritesHTML(cmd *cobra.Command) bool {
+	if html, _ := cmd.Flags().GetBool("html"); html {
+		return true
+	}
+	for _, format := range []string{"json", "csv", "yaml", "github-check"} {
+		if set, _ := cmd.Flags().GetBool(format); set {
+			return false
+		}
+	}
+	return true
+}
+
+// daemonBuildID identifies the pyscn build; the CLI delegates only to a
+// daemon built from the same version and commit
+func daemonBuildID() string {
+	return version.Version + "+" + version.Commit
+}
+
+// NewDaemonCmd creates and returns the daemon cobra command
+func NewDaemonCmd() *cobra.Command {
+	daemonCommand := NewDaemonCommand()
+	return daemonCommand.CreateCobraCommand()
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/config"
+	"github.com/ludo-technologies/pyscn/service"
+	"github.com/spf13/cobra"
+)
+
+// DeadCodeCommand represents the deadcode command
+type DeadCodeCommand struct {
+	configFile string
+	fixDryRun  bool
+	fix        bool
+	output     string
+}
+
+// NewDeadCodeCommand creates a new deadcode command
+func NewDeadCodeCommand() *DeadCodeCommand {
+	return &DeadCodeCommand{}
+}
+
+// CreateCobraCommand creates the cobra command for dead code fixes
+func (c *DeadCodeCommand) CreateCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deadcode [files...]",
+		Short: "Remove dead code that is safe to delete",
+		Long: `Find dead code that can be removed mechanically and remove it.
+
+Two kinds of removals are made:
+  • statements after a return, raise, break or continue in the same block,
+    when dead code detection reports them as unreachable
+  • module-level imports, written on one line, whose names are not used
+    anywhere else in the file (__init__.py files are skipped)
+
+The fixer is conservative. Lines with a "# noqa" or "# pyscn: ignore"
+comment are never touched, imports with a trailing comment are kept, and a
+file is left unchanged if the result would not parse.
+
+Without flags, lists the removals. With --fix-dry-run, writes them as a
+unified diff that 'git apply' or 'patch -p1' accept. With --fix, edits the
+files in place.
+
+Examples:
+  # List what would be removed
+  pyscn deadcode src/
+
+  # Review the changes as a patch
+  pyscn deadcode --fix-dry-run src/ > deadcode.patch
+
+  # Apply them
+  pyscn deadcode --fix src/`,
+		Args: cobra.ArbitraryArgs,
+		RunE: c.runDeadCode,
+	}
+
+	cmd.Flags().StringVarP(&c.configFile, "config", "c", "", "Configuration file path")
+	cmd.Flags().BoolVar(&c.fixDryRun, "fix-dry-run", false, "Print the removals as a unified diff without changing files")
+	cmd.Flags().BoolVar(&c.fix, "fix", false, "Remove the dead code in place")
+	cmd.Flags().StringVarP(&c.output, "output", "o", "", "Write the --fix-dry-run patch to this file instead of stdout")
+	cmd.MarkFlagsMutuallyExclusive("fix-dry-run", "fix")
+
+	return cmd
+}
+
+// runDeadCode computes and lists, prints or applies the fixes
+func (c *DeadCodeCommand) runDeadCode(cmd *cobra.Command, args []string) error {
+	if c.output != "" && !c.fixDryRun {
+		return fmt.Errorf("--output requires --fix-dry-run")
+	}
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+
+	cfg, err := config.LoadConfigWithTarget(c.configFile, getTargetPathFromArgs(args))
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	fileReader := service.NewFileReader().WithWalkOptions(domain.FileWalkOptions{
+		MaxDepth:       cfg.Analysis.MaxDepth,
+		FollowSymlinks: cfg.Analysis.FollowSymlinks,
+	})
+	files, err := fileReader.CollectPythonFiles(args, cfg.Analysis.Recursive, cfg.Analysis.IncludePatterns, cfg.Analysis.ExcludePatterns)
+	if err != nil {
+		return fmt.Errorf("failed to collect Python files: %w", err)
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	fixer := service.NewDeadCodeFixer()
+	var fixes []*service.DeadCodeFix
+	removals := 0
+	for _, file := range files {
+		fix, err := fixer.FixFile(ctx, file)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %v\n", err)
+			continue
+		}
+		if len(fix.Removals) > 0 {
+			fixes = append(fixes, fix)
+			removals += len(fix.Removals)
+		}
+	}
+
+	switch {
+	case c.fixDryRun:
+		err = c.writePatch(cmd, fixes)
+	case c.fix:
+		err = c.applyFixes(cmd, fixes)
+	default:
+		for _, fix := range fixes {
+			for _, removal := range fix.Removals {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s:%d: %s\n", fix.FilePath, removal.StartLine, removal.Description)
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if removals == 0 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "No safe dead code removals found in %d file(s)\n", len(files))
+	} else if !c.fix {
+		fmt.Fprintf(cmd.ErrOrStderr(), "%d removal(s) in %d file(s)\n", removals, len(fixes))
+	}
+	return nil
+}
+
+// writePatch writes all fixes as one unified diff
+func (c *DeadCodeCommand) writePatch(cmd *cobra.Command, fixes []*service.DeadCodeFix) error {
+	var patch strings.Builder
+	for _, fix := range fixes {
+		patch.WriteString(fix.Diff(patchPath(fix.FilePath)))
+	}
+
+	if c.output == "" {
+		_, err := fmt.Fprint(cmd.OutOrStdout(), patch.String())
+		return err
+	}
+	if err := os.WriteFile(c.output, []byte(patch.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write patch: %w", err)
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Patch written to %s\n", c.output)
+	return nil
+}
+
+// applyFixes rewrites the fixed files in place
+func (c *DeadCodeCommand) applyFixes(cmd *cobra.Command, fixes []*service.DeadCodeFix) error {
+	for _, fix := range fixes {
+		info, err := os.Stat(fix.FilePath)
+		if err != nil {
+			return fmt.Errorf("failed to stat %s: %w", fix.FilePath, err)
+		}
+		if err := os.WriteFile(fix.FilePath, fix.Fixed(), info.Mode().Perm()); err != nil {
+			return fmt.Errorf("failed to write %s: %w", fix.FilePath, err)
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Fixed %s (%d removal(s))\n", fix.FilePath, len(fix.Removals))
+	}
+	return nil
+}
+
+// patchPath returns path relative to the working directory, so the patch
+// applies from there
+func patchPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, abs); err == nil && !strings.HasPrefix(rel, "..") {
+				return rel
+			}
+		}
+	}
+	return path
+}
+
+// NewDeadCodeCmd creates and returns the deadcode cobra command
+func NewDeadCodeCmd() *cobra.Command {
+	deadCodeCommand := NewDeadCodeCommand()
+	return deadCodeCommand.CreateCobraCommand()
+}
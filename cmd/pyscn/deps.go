@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ludo-technologies/pyscn/app"
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/service"
+	"github.com/spf13/cobra"
+)
+
+// DepsCommand represents the module dependency command
+type DepsCommand struct {
+	configFile string
+	order      bool
+	inventory  bool
+	json       bool
+}
+
+// NewDepsCommand creates a new deps command
+func NewDepsCommand() *DepsCommand {
+	return &DepsCommand{
+		configFile: "",
+		order:      false,
+		inventory:  false,
+		json:       false,
+	}
+}
+
+// CreateCobraCommand creates the cobra command for dependency analysis
+func (c *DepsCommand) CreateCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deps [files...]",
+		Short: "Analyze module dependencies",
+		Long: `Analyze module dependencies within the project.
+
+Without flags, prints the dependency summary: module counts, circular
+dependencies, coupling and the longest dependency chains.
+
+With --order, prints the modules in topological order, dependencies first.
+Modules are grouped into levels: a module only depends on modules in earlier
+levels, so each level can be processed once the previous ones are done.
+Modules in a circular dependency are listed together as one component.
+This is useful for planning incremental typing or refactoring campaigns and
+for build-order tooling.
+
+With --inventory, lists every package imported from outside the project,
+classified as standard library, third-party or unknown, with usage counts
+and import locations. When a pyproject.toml or requirements file is found
+in the project root, imports are cross-checked against it to flag
+undeclared imports and declared dependencies that are never imported.
+
+Examples:
+  # Show the dependency summary
+  pyscn deps src/
+
+  # List modules in dependency order
+  pyscn deps --order src/
+
+  # Dependency order as JSON for tooling
+  pyscn deps --order --json src/
+
+  # List external imports and check them against pyproject.toml
+  pyscn deps --inventory .`,
+		Args: cobra.ArbitraryArgs,
+		RunE: c.runDeps,
+	}
+
+	cmd.Flags().StringVarP(&c.configFile, "config", "c", "", "Configuration file path")
+	cmd.Flags().BoolVar(&c.order, "order", false, "List modules in topological (dependency) order")
+	cmd.Flags().BoolVar(&c.inventory, "inventory", false, "List external imports and check them against declared dependencies")
+	cmd.Flags().BoolVar(&c.json, "json", false, "Output JSON to stdout")
+
+	return cmd
+}
+
+// runDeps executes the dependency analysis
+func (c *DepsCommand) runDeps(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+
+	request := domain.SystemAnalysisRequest{
+		Paths:               args,
+		OutputFormat:        domain.OutputFormatText,
+		OutputWriter:        cmd.OutOrStdout(),
+		AnalyzeDependencies: domain.BoolPtr(true),
+		AnalyzeArchitecture: domain.BoolPtr(false),
+		IncludeStdLib:       domain.BoolPtr(false),
+		IncludeThirdParty:   domain.BoolPtr(false),
+		FollowRelative:      domain.BoolPtr(true),
+		ConfigPath:          c.configFile,
+	}
+
+	useCase := app.NewSystemAnalysisUseCase(
+		service.NewSystemAnalysisService(),
+		service.NewFileReader(),
+		service.NewSystemAnalysisFormatter(),
+		service.NewSystemAnalysisConfigurationLoader(),
+	)
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	startTime := time.Now()
+	result, err := useCase.AnalyzeDependenciesOnly(ctx, request)
+	if err != nil {
+		return fmt.Errorf("dependency analysis failed: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	if c.order {
+		if c.json {
+			return service.WriteJSON(out, result.DependencyOrder)
+		}
+		service.WriteDependencyOrderText(out, result.DependencyOrder)
+		return nil
+	}
+	if c.inventory {
+		if c.json {
+			return service.WriteJSON(out, result.ImportInventory)
+		}
+		service.WriteImportInventoryText(out, result.ImportInventory)
+		return nil
+	}
+
+	format := domain.OutputFormatText
+	if c.json {
+		format = domain.OutputFormatJSON
+	}
+	response := &domain.SystemAnalysisResponse{
+		DependencyAnalysis: result,
+		GeneratedAt:        time.Now(),
+		Duration:           time.Since(startTime).Milliseconds(),
+	}
+	return service.NewSystemAnalysisFormatter().Write(response, format, out)
+}
+
+// NewDepsCmd creates and returns the deps cobra command
+func NewDepsCmd() *cobra.Command {
+	depsCommand := NewDepsCommand()
+	return depsCommand.CreateCobraCommand()
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/service"
+	"github.com/spf13/cobra"
+)
+
+// DiffCommand represents the semantic diff command
+type DiffCommand struct {
+	since string
+	json  bool
+}
+
+// NewDiffCommand creates a new diff command
+func NewDiffCommand() *DiffCommand {
+	return &DiffCommand{
+		since: "",
+		json:  false,
+	}
+}
+
+// CreateCobraCommand creates the cobra command for semantic diffs
+func (c *DiffCommand) CreateCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <old> <new> | diff --since <rev> [paths...]",
+		Short: "Summarize changes to functions and classes",
+		Long: `Compare two versions of Python code by their definitions.
+
+Lists the functions, methods and classes that were added, removed, renamed
+or modified. Modified definitions name what changed: the signature
+(parameters, annotations, async, base classes), decorators, docstring or
+body. Formatting and comments are not changes, and a definition nested in
+another is reported on its own rather than as a body change of its parent.
+Import and other top-level statement changes are reported for the module.
+
+With two files, compares them. With --since, compares every Python file
+that differs between a git revision and the work tree with its version at
+that revision; paths restrict the comparison. Untracked files are not
+included.
+
+Examples:
+  # Compare two files
+  pyscn diff old/cart.py cart.py
+
+  # Summarize the changes of a branch for a pull request
+  pyscn diff --since main
+
+  # Machine-readable changes under src/
+  pyscn diff --since HEAD~1 --json src/`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if c.since == "" && len(args) != 2 {
+				return fmt.Errorf("expected two files to compare, or --since with a git revision")
+			}
+			return nil
+		},
+		RunE: c.runDiff,
+	}
+
+	cmd.Flags().StringVar(&c.since, "since", "", "Compare changed files with their version at this git revision")
+	cmd.Flags().BoolVar(&c.json, "json", false, "Output JSON to stdout")
+
+	return cmd
+}
+
+// runDiff executes the diff command
+func (c *DiffCommand) runDiff(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var diffs []*domain.ASTDiff
+	if c.since != "" {
+		var err error
+		diffs, err = service.DiffPythonFilesSinceRevision(ctx, c.since, args)
+		if err != nil {
+			return err
+		}
+	} else {
+		diff, err := service.DiffPythonFiles(ctx, args[0], args[1])
+		if err != nil {
+			return err
+		}
+		diffs = []*domain.ASTDiff{diff}
+	}
+
+	out := cmd.OutOrStdout()
+	if c.json {
+		return service.WriteJSON(out, diffs)
+	}
+	service.WriteASTDiffText(out, diffs)
+	return nil
+}
+
+// NewDiffCmd creates and returns the diff cobra command
+func NewDiffCmd() *cobra.Command {
+	diffCommand := NewDiffCommand()
+	return diffCommand.CreateCobraCommand()
+}
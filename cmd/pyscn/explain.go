@@ -0,0 +1,313 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/analyzer"
+	"github.com/spf13/cobra"
+)
+
+// ruleDocsURL is where the rule catalog of the documentation is published
+const ruleDocsURL = "https://ludo-technologies.github.io/pyscn/rules/"
+
+// ExplainCommand represents the explain command
+type ExplainCommand struct {
+	format string
+}
+
+// NewExplainCommand creates a new explain command
+func NewExplainCommand() *ExplainCommand {
+	return &ExplainCommand{
+		format: "text",
+	}
+}
+
+// CreateCobraCommand creates the cobra command for explaining rules
+func (c *ExplainCommand) CreateCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain [rule-id]",
+		Short: "Explain what a rule reports and how to fix it",
+		Long: fmt.Sprintf(`Explain a rule: what it reports, why it matters, code it reports next
+to the same code fixed, and the options changing what it reports.
+
+Rule IDs are the ones check --format lint prints and --select accepts,
+such as deadcode.unreachable_after_return or clones.type2. Names of the rule
+catalog in the documentation, such as unreachable-after-return, work too.
+An analysis name lists its rules, and no argument lists every rule.
+
+The text comes from the rule registry the analyzers use, so it follows
+their behavior. --format markdown without a rule writes a reference page
+of every rule.
+
+Rule IDs:
+%s
+Examples:
+  # List the rules
+  pyscn explain
+
+  # Explain one rule
+  pyscn explain deadcode.unreachable_after_return
+
+  # Write a Markdown reference of every rule
+  pyscn explain --format markdown > rules.md`, indentedRuleIDs(domain.AnalysisRules())),
+		Args:              cobra.MaximumNArgs(1),
+		RunE:              c.runExplain,
+		ValidArgsFunction: completeRuleIDs,
+	}
+
+	cmd.Flags().StringVar(&c.format, "format", "text", "Output format: text or markdown")
+	_ = cmd.RegisterFlagCompletionFunc("format", completeValues("text", "markdown"))
+
+	return cmd
+}
+
+// runExplain executes the explain command
+func (c *ExplainCommand) runExplain(cmd *cobra.Command, args []string) error {
+	if c.format != "text" && c.format != "markdown" {
+		return fmt.Errorf("invalid --format %q: must be text or markdown", c.format)
+	}
+	markdown := c.format == "markdown"
+	out := cmd.OutOrStdout()
+
+	if len(args) == 0 {
+		if markdown {
+			writeRuleReferenceMarkdown(out, domain.AnalysisRules())
+			return nil
+		}
+		writeRuleList(out, domain.AnalysisRules())
+		return nil
+	}
+
+	if domain.IsSelectableAnalysis(args[0]) {
+		rules := rulesOfAnalysis(strings.ToLower(strings.TrimSpace(args[0])))
+		if len(rules) == 0 {
+			return fmt.Errorf("analysis %s has no rules of its own; run pyscn explain to list the rules", args[0])
+		}
+		if markdown {
+			writeRuleReferenceMarkdown(out, rules)
+			return nil
+		}
+		writeRuleList(out, rules)
+		return nil
+	}
+
+	rule, ok := findExplainedRule(args[0])
+	if !ok {
+		return fmt.Errorf("unknown rule %q; valid rule IDs are %s", args[0], strings.Join(ruleIDs(domain.AnalysisRules()), ", "))
+	}
+	if markdown {
+		writeRuleMarkdown(out, rule, "#")
+		return nil
+	}
+	writeRuleText(out, rule)
+	return nil
+}
+
+// findExplainedRule finds a rule by its ID or its rule catalog page
+func findExplainedRule(name string) (domain.AnalysisRule, bool) {
+	if rule, ok := domain.LookupAnalysisRule(name); ok {
+		return rule, true
+	}
+	page := strings.ToLower(strings.TrimSpace(name))
+	for _, rule := range domain.AnalysisRules() {
+		if rule.DocPage != "" && rule.DocPage == page {
+			return rule, true
+		}
+	}
+	return domain.AnalysisRule{}, false
+}
+
+// ruleIDs returns the IDs of rules
+func ruleIDs(rules []domain.AnalysisRule) []string {
+	ids := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		ids = append(ids, rule.ID)
+	}
+	return ids
+}
+
+// indentedRuleIDs lists the IDs of rules for the help text, one per line
+func indentedRuleIDs(rules []domain.AnalysisRule) string {
+	var b strings.Builder
+	for _, id := range ruleIDs(rules) {
+		fmt.Fprintf(&b, "  %s\n", id)
+	}
+	return b.String()
+}
+
+// rulesOfAnalysis returns the rules of one analysis, sorted by ID
+func rulesOfAnalysis(analysis string) []domain.AnalysisRule {
+	var rules []domain.AnalysisRule
+	for _, rule := range domain.AnalysisRules() {
+		if rule.Analysis == analysis {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// subRules returns the single-reason rules of a dead code category
+func subRules(rule domain.AnalysisRule) []domain.AnalysisRule {
+	if len(rule.DeadCodeReasons) < 2 {
+		return nil
+	}
+	rules := make([]domain.AnalysisRule, 0, len(rule.DeadCodeReasons))
+	for _, reason := range rule.DeadCodeReasons {
+		if sub, ok := domain.LookupAnalysisRule(rule.Analysis + "." + reason); ok {
+			rules = append(rules, sub)
+		}
+	}
+	return rules
+}
+
+// ruleSeverity returns the default severity of a single-reason dead code
+// rule, or "" for other rules
+func ruleSeverity(rule domain.AnalysisRule) string {
+	if rule.Analysis != domain.AnalysisDeadCode || len(rule.DeadCodeReasons) != 1 {
+		return ""
+	}
+	return string(analyzer.DefaultSeverity(analyzer.DeadCodeReason(rule.DeadCodeReasons[0])))
+}
+
+// writeRuleList writes one line per rule: its ID and description
+func writeRuleList(out io.Writer, rules []domain.AnalysisRule) {
+	width := 0
+	for _, rule := range rules {
+		width = max(width, len(rule.ID))
+	}
+	for _, rule := range rules {
+		fmt.Fprintf(out, "%-*s  %s\n", width, rule.ID, rule.Description)
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Run pyscn explain <rule-id> for details.")
+}
+
+// writeRuleText writes the explanation of a rule for the terminal
+func writeRuleText(out io.Writer, rule domain.AnalysisRule) {
+	fmt.Fprintln(out, rule.ID)
+	fmt.Fprintln(out, rule.Description)
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "Analysis: %s\n", rule.Analysis)
+	if severity := ruleSeverity(rule); severity != "" {
+		fmt.Fprintf(out, "Default severity: %s\n", severity)
+	}
+	if rule.DocPage != "" {
+		fmt.Fprintf(out, "Documentation: %s%s/\n", ruleDocsURL, rule.DocPage)
+	}
+
+	if rule.Rationale != "" {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "WHY IT MATTERS")
+		fmt.Fprintf(out, "  %s\n", rule.Rationale)
+	}
+	if subs := subRules(rule); len(subs) > 0 {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "RULES")
+		width := 0
+		for _, sub := range subs {
+			width = max(width, len(sub.ID))
+		}
+		for _, sub := range subs {
+			fmt.Fprintf(out, "  %-*s  %s\n", width, sub.ID, sub.Description)
+		}
+	}
+	if rule.Violation != "" {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "REPORTED")
+		writeIndented(out, rule.Violation)
+	}
+	if rule.Compliant != "" {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "INSTEAD")
+		writeIndented(out, rule.Compliant)
+	}
+	if len(rule.ConfigKeys) > 0 {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "CONFIGURATION")
+		for _, key := range rule.ConfigKeys {
+			fmt.Fprintf(out, "  %s\n", key)
+		}
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "SELECT")
+	fmt.Fprintf(out, "  pyscn analyze --select %s\n", rule.ID)
+}
+
+// writeIndented writes code indented by four spaces
+func writeIndented(out io.Writer, code string) {
+	for _, line := range strings.Split(code, "\n") {
+		if line == "" {
+			fmt.Fprintln(out)
+			continue
+		}
+		fmt.Fprintf(out, "    %s\n", line)
+	}
+}
+
+// writeRuleReferenceMarkdown writes a Markdown page documenting rules
+func writeRuleReferenceMarkdown(out io.Writer, rules []domain.AnalysisRule) {
+	fmt.Fprintln(out, "# Rule reference")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Generated by `pyscn explain --format markdown`.")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "| Rule | Description |")
+	fmt.Fprintln(out, "| --- | --- |")
+	for _, rule := range rules {
+		fmt.Fprintf(out, "| [`%s`](#%s) | %s |\n", rule.ID, markdownAnchor(rule.ID), rule.Description)
+	}
+	for _, rule := range rules {
+		fmt.Fprintln(out)
+		writeRuleMarkdown(out, rule, "##")
+	}
+}
+
+// writeRuleMarkdown writes the explanation of a rule as Markdown, with its
+// title at the given heading level
+func writeRuleMarkdown(out io.Writer, rule domain.AnalysisRule, heading string) {
+	fmt.Fprintf(out, "%s `%s` { #%s }\n\n", heading, rule.ID, markdownAnchor(rule.ID))
+	fmt.Fprintf(out, "%s.\n\n", strings.TrimSuffix(rule.Description, "."))
+	fmt.Fprintf(out, "**Analysis**: `%s`  \n", rule.Analysis)
+	if severity := ruleSeverity(rule); severity != "" {
+		fmt.Fprintf(out, "**Default severity**: %s  \n", severity)
+	}
+	fmt.Fprintf(out, "**Select**: `pyscn analyze --select %s`\n", rule.ID)
+	if rule.DocPage != "" {
+		fmt.Fprintf(out, "\nSee [%s](%s%s/).\n", rule.DocPage, ruleDocsURL, rule.DocPage)
+	}
+
+	if rule.Rationale != "" {
+		fmt.Fprintf(out, "\n%s# Why it matters\n\n%s\n", heading, rule.Rationale)
+	}
+	if subs := subRules(rule); len(subs) > 0 {
+		fmt.Fprintf(out, "\n%s# Rules\n\n", heading)
+		for _, sub := range subs {
+			fmt.Fprintf(out, "- [`%s`](#%s): %s\n", sub.ID, markdownAnchor(sub.ID), sub.Description)
+		}
+	}
+	if rule.Violation != "" {
+		fmt.Fprintf(out, "\n%s# Reported\n\n```python\n%s\n```\n", heading, rule.Violation)
+	}
+	if rule.Compliant != "" {
+		fmt.Fprintf(out, "\n%s# Instead\n\n```python\n%s\n```\n", heading, rule.Compliant)
+	}
+	if len(rule.ConfigKeys) > 0 {
+		fmt.Fprintf(out, "\n%s# Configuration\n\n", heading)
+		for _, key := range rule.ConfigKeys {
+			fmt.Fprintf(out, "- `%s`\n", key)
+		}
+	}
+}
+
+// markdownAnchor returns the heading anchor of a rule ID
+func markdownAnchor(id string) string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(id)
+}
+
+// NewExplainCmd creates and returns the explain cobra command
+func NewExplainCmd() *cobra.Command {
+	explainCommand := NewExplainCommand()
+	return explainCommand.CreateCobraCommand()
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// filesFromStdin is the --files-from value that reads standard input
+const filesFromStdin = "-"
+
+// readFilesFrom reads the file list named by --files-from: one path per
+// line, or NUL-separated when the list contains NUL bytes (find -print0,
+// git diff -z). Blank entries and files that are not Python sources are
+// skipped, and files that no longer exist are skipped with a warning, so
+// lists from git diff can be piped in unfiltered. Directories are rejected:
+// the list replaces directory walking rather than feeding it.
+func readFilesFrom(source string, stdin io.Reader, warn io.Writer) ([]string, error) {
+	var content []byte
+	var err error
+	if source == filesFromStdin {
+		content, err = io.ReadAll(stdin)
+	} else {
+		content, err = os.ReadFile(source)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read --files-from list %s: %w", source, err)
+	}
+
+	separator := []byte("\n")
+	if bytes.IndexByte(content, 0) >= 0 {
+		separator = []byte{0}
+	}
+
+	seen := make(map[string]bool)
+	var files []string
+	for _, entry := range bytes.Split(content, separator) {
+		path := strings.TrimSpace(string(entry))
+		if path == "" || seen[path] {
+			continue
+		}
+		seen[path] = true
+
+		info, statErr := os.Stat(path)
+		switch {
+		case statErr == nil && info.IsDir():
+			return nil, fmt.Errorf("--files-from lists files, not directories: %s", path)
+		case !parser.IsSourceFile(path):
+			continue
+		case statErr != nil:
+			fmt.Fprintf(warn, "Warning: skipping %s from --files-from: %v\n", path, statErr)
+			continue
+		}
+		files = append(files, path)
+	}
+	return files, nil
+}
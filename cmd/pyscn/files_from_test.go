@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadFilesFrom(t *testing.T) {
+	tempDir := t.TempDir()
+	app := filepath.Join(tempDir, "app.py")
+	stub := filepath.Join(tempDir, "app.pyi")
+	for _, path := range []string{app, stub} {
+		if err := os.WriteFile(path, []byte("x = 1\n"), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+	missing := filepath.Join(tempDir, "deleted.py")
+
+	t.Run("lines from stdin", func(t *testing.T) {
+		var warn bytes.Buffer
+		input := strings.Join([]string{app, "", "README.md", missing, stub, app}, "\n")
+		files, err := readFilesFrom(filesFromStdin, strings.NewReader(input), &warn)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := []string{app, stub}; !reflect.DeepEqual(files, want) {
+			t.Errorf("expected %v, got %v", want, files)
+		}
+		if !strings.Contains(warn.String(), "deleted.py") {
+			t.Errorf("expected a warning for the missing file, got %q", warn.String())
+		}
+	})
+
+	t.Run("NUL-separated list file", func(t *testing.T) {
+		list := filepath.Join(tempDir, "files.txt")
+		if err := os.WriteFile(list, []byte(stub+"\x00"+app+"\x00"), 0o644); err != nil {
+			t.Fatalf("failed to write list: %v", err)
+		}
+		files, err := readFilesFrom(list, nil, &bytes.Buffer{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := []string{stub, app}; !reflect.DeepEqual(files, want) {
+			t.Errorf("expected %v, got %v", want, files)
+		}
+	})
+
+	t.Run("directories are rejected", func(t *testing.T) {
+		_, err := readFilesFrom(filesFromStdin, strings.NewReader(tempDir+"\n"), &bytes.Buffer{})
+		if err == nil || !strings.Contains(err.Error(), "not directories") {
+			t.Errorf("expected a directory error, got %v", err)
+		}
+	})
+}
+
+func TestCheckCommandFilesFrom(t *testing.T) {
+	tempDir := t.TempDir()
+	listed := filepath.Join(tempDir, "listed.py")
+	unlisted := filepath.Join(tempDir, "unlisted.py")
+	dead := "def f():\n    return 1\n    print('never')\n"
+	for _, path := range []string{listed, unlisted} {
+		if err := os.WriteFile(path, []byte(dead), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+
+	run := func(input string) (string, error) {
+		cobraCmd := NewCheckCommand().CreateCobraCommand()
+		var stdout bytes.Buffer
+		cobraCmd.SetIn(strings.NewReader(input))
+		cobraCmd.SetOut(&stdout)
+		cobraCmd.SetErr(&bytes.Buffer{})
+		cobraCmd.SetArgs([]string{"--format", "lint", "--select", "deadcode", "--files-from", "-"})
+		err := cobraCmd.Execute()
+		return stdout.String(), err
+	}
+
+	output, err := run(listed + "\n")
+	if err == nil {
+		t.Fatal("expected check to fail on the listed file")
+	}
+	if !strings.Contains(output, "listed.py:3:1: deadcode.") || strings.Contains(output, "unlisted.py") {
+		t.Errorf("expected findings for the listed file only, got:\n%s", output)
+	}
+
+	if output, err := run("docs/index.md\n"); err != nil || output != "" {
+		t.Errorf("expected a list without Python files to pass silently, got %v, %q", err, output)
+	}
+}
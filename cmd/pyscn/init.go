@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/ludo-technologies/pyscn/internal/config"
 	"github.com/spf13/cobra"
@@ -11,8 +12,11 @@ import (
 
 // InitCommand represents the init command
 type InitCommand struct {
-	force      bool
-	configPath string
+	force         bool
+	configPath    string
+	template      string
+	ci            string
+	listTemplates bool
 	// format removed - TOML only now
 }
 
@@ -21,6 +25,7 @@ func NewInitCommand() *InitCommand {
 	return &InitCommand{
 		force:      false,
 		configPath: ".pyscn.toml", // TOML only
+		template:   config.InitTemplateDefault,
 	}
 }
 
@@ -42,6 +47,13 @@ The generated configuration includes settings for:
 • File inclusion/exclusion patterns
 • Output formatting preferences
 
+Use --template to start from a preset tuned for a kind of project instead
+of the generic defaults. Presets only list the settings they change, so the
+file stays short. Use --list-templates to see them all.
+
+Use --ci to also print a CI configuration that runs 'pyscn check' with the
+new configuration.
+
 Examples:
   # Create .pyscn.toml in current directory (recommended)
   pyscn init
@@ -50,19 +62,46 @@ Examples:
   pyscn init --config myconfig.toml
 
   # Overwrite existing configuration file
-  pyscn init --force`,
+  pyscn init --force
+
+  # Start from the Django preset and print a GitHub Actions workflow
+  pyscn init --template django --ci github`,
 		RunE: i.runInit,
 	}
 
 	// Add flags
 	cmd.Flags().BoolVarP(&i.force, "force", "f", false, "Overwrite existing configuration file")
 	cmd.Flags().StringVarP(&i.configPath, "config", "c", ".pyscn.toml", "Configuration file path")
+	cmd.Flags().StringVarP(&i.template, "template", "t", config.InitTemplateDefault,
+		"Configuration preset: "+strings.Join(config.InitTemplateNames(), ", "))
+	cmd.Flags().StringVar(&i.ci, "ci", "", "Also print a CI snippet: github, gitlab, pre-commit")
+	cmd.Flags().BoolVar(&i.listTemplates, "list-templates", false, "List the available presets and exit")
 
 	return cmd
 }
 
 // runInit executes the init command
 func (i *InitCommand) runInit(cmd *cobra.Command, args []string) error {
+	if i.listTemplates {
+		for _, t := range config.InitTemplates() {
+			fmt.Fprintf(cmd.OutOrStdout(), "  %-10s %s\n", t.Name, t.Description)
+		}
+		return nil
+	}
+
+	// Render everything before touching the filesystem so that a typo in
+	// --template or --ci does not leave a half-initialized project
+	configData, err := config.GenerateInitTemplateTOML(i.template)
+	if err != nil {
+		return err
+	}
+	var snippet *config.CISnippet
+	if i.ci != "" {
+		if snippet, err = config.GenerateCISnippet(i.ci, i.configPath); err != nil {
+			return err
+		}
+	}
+
 	// Resolve the absolute path
 	configPath, err := filepath.Abs(i.configPath)
 	if err != nil {
@@ -80,12 +119,6 @@ func (i *InitCommand) runInit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create directory %s: %w", configDir, err)
 	}
 
-	// Generate default config from domain values
-	configData, err := config.GenerateDefaultConfigTOML()
-	if err != nil {
-		return fmt.Errorf("failed to generate default configuration: %w", err)
-	}
-
 	// Write the configuration file
 	if err := os.WriteFile(configPath, []byte(configData), 0644); err != nil {
 		return fmt.Errorf("failed to write configuration file: %w", err)
@@ -97,12 +130,20 @@ func (i *InitCommand) runInit(cmd *cobra.Command, args []string) error {
 		relPath = configPath // Fall back to absolute path if relative fails
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "✅ Configuration file created: %s\n", relPath)
+	if i.template != "" && i.template != config.InitTemplateDefault {
+		fmt.Fprintf(cmd.OutOrStdout(), "✅ Configuration file created from the %s template: %s\n", i.template, relPath)
+	} else {
+		fmt.Fprintf(cmd.OutOrStdout(), "✅ Configuration file created: %s\n", relPath)
+	}
 	fmt.Fprintf(cmd.OutOrStdout(), "\nTo customize pyscn for your project:\n")
 	fmt.Fprintf(cmd.OutOrStdout(), "  1. Edit %s\n", relPath)
 	fmt.Fprintf(cmd.OutOrStdout(), "  2. Uncomment and modify settings as needed\n")
 	fmt.Fprintf(cmd.OutOrStdout(), "  3. Run 'pyscn analyze .' to use your configuration\n")
 
+	if snippet != nil {
+		fmt.Fprintf(cmd.OutOrStdout(), "\nAdd this to %s to run pyscn in CI:\n\n%s", snippet.Path, snippet.Content)
+	}
+
 	return nil
 }
 
@@ -7,10 +7,13 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var rootCmd = &cobra.Command{
-	Use:   "pyscn",
-	Short: "An Intelligent Python Code Quality Analyzer",
-	Long: `pyscn is an intelligent Python code quality analyzer that uses 
+// newRootCmd builds the command tree. The daemon builds a fresh tree for
+// every request so that no flag values carry over between invocations.
+func newRootCmd() *cobra.Command {
+	rootCmd := &cobra.Command{
+		Use:   "pyscn",
+		Short: "An Intelligent Python Code Quality Analyzer",
+		Long: `pyscn is an intelligent Python code quality analyzer that uses 
 Control Flow Graph (CFG) and APTED (tree edit distance) algorithms 
 to provide deep code quality insights beyond traditional linters.
 
@@ -19,22 +22,48 @@ Features:
   • Cyclomatic complexity analysis  
   • Clone detection with APTED algorithm
   • High-performance analysis (>10,000 lines/second)`,
-	Version: version.Short(),
-}
+		Version:           version.Short(),
+		PersistentPreRunE: applyRuntimeFlags,
+	}
 
-func init() {
 	// Global flags
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
+	addRuntimeFlags(rootCmd)
 
 	// Add main subcommands
 	rootCmd.AddCommand(NewAnalyzeCmd())
 	rootCmd.AddCommand(NewCheckCmd())
+	rootCmd.AddCommand(NewDepsCmd())
+	rootCmd.AddCommand(NewDeadCodeCmd())
 	rootCmd.AddCommand(NewVersionCmd())
 	rootCmd.AddCommand(NewInitCmd())
+	rootCmd.AddCommand(NewConfigCmd())
+	rootCmd.AddCommand(NewRatchetCmd())
+	rootCmd.AddCommand(NewSimulateCmd())
+	rootCmd.AddCommand(NewCalibrateCmd())
+	rootCmd.AddCommand(NewBatchCmd())
+	rootCmd.AddCommand(NewArchCmd())
+	rootCmd.AddCommand(NewBenchCmd())
+	rootCmd.AddCommand(NewParseCmd())
+	rootCmd.AddCommand(NewCFGCmd())
+	rootCmd.AddCommand(NewExplainCmd())
+	rootCmd.AddCommand(NewDiffCmd())
+	rootCmd.AddCommand(NewAPIDiffCmd())
+	rootCmd.AddCommand(NewClassesCmd())
+	rootCmd.AddCommand(NewCallGraphCmd())
+	rootCmd.AddCommand(NewDaemonCmd())
+	rootCmd.AddCommand(NewServeCmd())
+
+	registerCompletions(rootCmd)
+
+	return rootCmd
 }
 
 func main() {
-	if err := rootCmd.Execute(); err != nil {
+	if exitCode, ok := runInDaemon(os.Args[1:], os.Stdout, os.Stderr); ok {
+		os.Exit(exitCode)
+	}
+	if err := newRootCmd().Execute(); err != nil {
 		os.Exit(1)
 	}
 }
@@ -2,7 +2,11 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -307,6 +311,31 @@ func TestAnalyzeCommandValidation(t *testing.T) {
 			args:        []string{"/nonexistent/file.py"},
 			expectError: true,
 		},
+		{
+			name:        "Summary with HTML report",
+			args:        []string{"--summary", "--html", "../../testdata/python/simple"},
+			expectError: true,
+		},
+		{
+			name:        "Negative history runs",
+			args:        []string{"--history-runs", "-1", "../../testdata/python/simple"},
+			expectError: true,
+		},
+		{
+			name:        "Dead code context above the maximum",
+			args:        []string{"--dead-code-context", "21", "../../testdata/python/simple"},
+			expectError: true,
+		},
+		{
+			name:        "Unknown progress mode",
+			args:        []string{"--progress", "bar", "../../testdata/python/simple"},
+			expectError: true,
+		},
+		{
+			name:        "Progress file without json progress",
+			args:        []string{"--progress-file", "progress.ndjson", "../../testdata/python/simple"},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -343,6 +372,26 @@ func TestAnalyzeCommandSelectValidation(t *testing.T) {
 	}
 }
 
+func TestAnalyzeCommandJobs(t *testing.T) {
+	analyzeCmd := NewAnalyzeCommand()
+	cobraCmd := analyzeCmd.CreateCobraCommand()
+	if err := cobraCmd.ParseFlags([]string{"-j", "3"}); err != nil {
+		t.Fatal(err)
+	}
+	if config := analyzeCmd.createUseCaseConfig(); config.CloneJobs != 3 {
+		t.Errorf("expected -j 3 to reach the clone analysis, got %d", config.CloneJobs)
+	}
+
+	var stderr bytes.Buffer
+	cobraCmd = NewAnalyzeCommand().CreateCobraCommand()
+	cobraCmd.SetOut(&stderr)
+	cobraCmd.SetErr(&stderr)
+	cobraCmd.SetArgs([]string{"--jobs", "-1", "."})
+	if err := cobraCmd.Execute(); err == nil || !strings.Contains(err.Error(), "invalid --jobs") {
+		t.Errorf("expected a negative --jobs to fail, got %v", err)
+	}
+}
+
 func TestAnalyzeCommandSelectCommunitiesEnablesAnalysis(t *testing.T) {
 	analyzeCmd := NewAnalyzeCommand()
 	analyzeCmd.selectAnalyses = []string{"communities"}
@@ -393,29 +442,53 @@ func TestAnalyzeCommandShouldWriteStandaloneCommunityJSON(t *testing.T) {
 	response := &domain.AnalyzeResponse{
 		Communities: &domain.CommunityAnalysisResult{TotalCommunities: 2},
 	}
-	if !analyzeCmd.shouldWriteStandaloneCommunityJSON(response) {
+	if !analyzeCmd.shouldWriteStandaloneCommunityJSON(response, domain.OutputFormatJSON) {
 		t.Fatal("expected standalone community JSON for --json --select communities")
 	}
 
 	analyzeCmd.selectAnalyses = []string{"deps", "communities"}
-	if analyzeCmd.shouldWriteStandaloneCommunityJSON(response) {
+	if analyzeCmd.shouldWriteStandaloneCommunityJSON(response, domain.OutputFormatJSON) {
 		t.Fatal("expected unified analyze JSON when multiple analyses are selected")
 	}
 
 	analyzeCmd.selectAnalyses = []string{"communities"}
-	analyzeCmd.json = false
-	if analyzeCmd.shouldWriteStandaloneCommunityJSON(response) {
-		t.Fatal("expected unified analyze output for non-JSON formats")
+	for _, format := range []domain.OutputFormat{domain.OutputFormatYAML, domain.OutputFormatCSV, domain.OutputFormatHTML} {
+		if analyzeCmd.shouldWriteStandaloneCommunityJSON(response, format) {
+			t.Fatalf("expected unified analyze output for %s", format)
+		}
 	}
 
-	if analyzeCmd.shouldWriteStandaloneCommunityJSON(nil) {
+	if analyzeCmd.shouldWriteStandaloneCommunityJSON(nil, domain.OutputFormatJSON) {
 		t.Fatal("expected false when response is nil")
 	}
-	if analyzeCmd.shouldWriteStandaloneCommunityJSON(&domain.AnalyzeResponse{}) {
+	if analyzeCmd.shouldWriteStandaloneCommunityJSON(&domain.AnalyzeResponse{}, domain.OutputFormatJSON) {
 		t.Fatal("expected false when community analysis is nil")
 	}
 }
 
+func TestAnalyzeWritesHTML(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{nil, true},
+		{[]string{"--json"}, false},
+		{[]string{"--csv", "--yaml"}, false},
+		{[]string{"--github-check"}, false},
+		{[]string{"--html"}, true},
+		{[]string{"--html", "--json"}, true},
+	}
+	for _, tt := range tests {
+		cobraCmd := NewAnalyzeCommand().CreateCobraCommand()
+		if err := cobraCmd.ParseFlags(tt.args); err != nil {
+			t.Fatalf("ParseFlags(%v): %v", tt.args, err)
+		}
+		if got := analyzeWritesHTML(cobraCmd); got != tt.want {
+			t.Errorf("analyzeWritesHTML(%v) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
 func TestAnalyzeCommandSkipCommunitiesOverridesSelect(t *testing.T) {
 	analyzeCmd := NewAnalyzeCommand()
 	analyzeCmd.selectAnalyses = []string{"communities"}
@@ -1043,6 +1116,73 @@ func TestCountDIAntipatternIssuesFailsOnAnalysisErrors(t *testing.T) {
 	}
 }
 
+func TestCheckCommandSelectSecurity(t *testing.T) {
+	tempDir := t.TempDir()
+	source := "import subprocess\n\ndef run(cmd):\n    subprocess.run(cmd, shell=True)\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "runner.py"), []byte(source), 0o644); err != nil {
+		t.Fatalf("failed to write source file: %v", err)
+	}
+
+	checkCmd := NewCheckCommand()
+	cobraCmd := checkCmd.CreateCobraCommand()
+	var stderr bytes.Buffer
+	cobraCmd.SetErr(&stderr)
+	cobraCmd.SetArgs([]string{"--select", "security", tempDir})
+
+	if err := cobraCmd.Execute(); err == nil {
+		t.Fatal("expected check to fail on a shell injection finding")
+	}
+	if !strings.Contains(stderr.String(), "runner.py:4:5: shell_injection:") {
+		t.Errorf("expected shell_injection finding in output, got:\n%s", stderr.String())
+	}
+}
+
+func TestCheckCommandLintFormat(t *testing.T) {
+	tempDir := t.TempDir()
+	source := "import subprocess\n\n\ndef run(cmd):\n    subprocess.run(cmd, shell=True)\n\n\ndef done():\n    return 1\n    print('never')\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "runner.py"), []byte(source), 0o644); err != nil {
+		t.Fatalf("failed to write source file: %v", err)
+	}
+
+	checkCmd := NewCheckCommand()
+	cobraCmd := checkCmd.CreateCobraCommand()
+	var stdout, stderr bytes.Buffer
+	cobraCmd.SetOut(&stdout)
+	cobraCmd.SetErr(&stderr)
+	cobraCmd.SetArgs([]string{"--format", "lint", "--select", "security,deadcode", tempDir})
+
+	if err := cobraCmd.Execute(); err == nil {
+		t.Fatal("expected check to fail on the findings")
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lint lines on stdout, got:\n%s", stdout.String())
+	}
+	if !strings.Contains(lines[0], "runner.py:5:5: security.shell_injection ") {
+		t.Errorf("unexpected security line: %s", lines[0])
+	}
+	if !strings.Contains(lines[1], "runner.py:10:1: deadcode.unreachable_after_return ") {
+		t.Errorf("unexpected dead code line: %s", lines[1])
+	}
+	if strings.Contains(stderr.String(), "Running quality check") {
+		t.Errorf("expected no status lines in lint mode, got:\n%s", stderr.String())
+	}
+}
+
+func TestCheckDiagnosticString(t *testing.T) {
+	diagnostic := checkDiagnostic{Path: "a.py", Line: 0, Column: 0, Rule: "deps.cycles", Message: "cycle:\n  a -> b"}
+	if got := diagnostic.String(); got != "a.py:1:1: deps.cycles cycle: a -> b" {
+		t.Errorf("unexpected lint line: %q", got)
+	}
+	if got := lintPath("/work/src/a.py", "/work"); got != "src/a.py" {
+		t.Errorf("expected path relative to the working directory, got %q", got)
+	}
+	if got := lintPath("/other/a.py", "/work"); got != "/other/a.py" {
+		t.Errorf("expected paths outside the working directory unchanged, got %q", got)
+	}
+}
+
 // TestAnalyzeCommandThresholdFlags verifies that complexity threshold flags
 // on the analyze command are mapped into AnalyzeUseCaseConfig. This is the CLI
 // counterpart to the MergeConfig fix for issue #553.
@@ -1105,3 +1245,950 @@ func TestAnalyzeCommandThresholdFlags(t *testing.T) {
 		}
 	})
 }
+
+// TestDepsCommandOrder tests the topological module listing of pyscn deps --order
+func TestDepsCommandOrder(t *testing.T) {
+	depsCmd := NewDepsCommand()
+	cobraCmd := depsCmd.CreateCobraCommand()
+
+	var stdout, stderr bytes.Buffer
+	cobraCmd.SetOut(&stdout)
+	cobraCmd.SetErr(&stderr)
+	cobraCmd.SetArgs([]string{"--order", filepath.Join("..", "..", "testdata", "python", "circular_deps_test")})
+
+	if err := cobraCmd.Execute(); err != nil {
+		t.Fatalf("deps --order failed: %v, output: %s", err, stderr.String())
+	}
+
+	output := stdout.String()
+	if !strings.Contains(output, "DEPENDENCY ORDER") || !strings.Contains(output, "Level 0:") {
+		t.Errorf("Expected dependency order listing, got: %s", output)
+	}
+	if !strings.Contains(output, "[cycle]") {
+		t.Errorf("Expected circular modules to be grouped as a cycle, got: %s", output)
+	}
+}
+
+func TestDeadCodeCommandFix(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "module.py")
+	source := "import os\nimport sys\n\n\ndef f():\n    return sys.argv\n    print(\"dead\")\n"
+	if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) string {
+		cobraCmd := NewDeadCodeCommand().CreateCobraCommand()
+		var stdout, stderr bytes.Buffer
+		cobraCmd.SetOut(&stdout)
+		cobraCmd.SetErr(&stderr)
+		cobraCmd.SetArgs(append(args, dir))
+		if err := cobraCmd.Execute(); err != nil {
+			t.Fatalf("deadcode %v failed: %v, output: %s", args, err, stderr.String())
+		}
+		return stdout.String()
+	}
+
+	patch := run("--fix-dry-run")
+	if !strings.Contains(patch, "-import os\n") || !strings.Contains(patch, "-    print(\"dead\")\n") {
+		t.Errorf("Expected the patch to remove the unused import and the unreachable call, got: %s", patch)
+	}
+	if data, _ := os.ReadFile(path); string(data) != source {
+		t.Errorf("--fix-dry-run must not change files, got: %s", data)
+	}
+
+	run("--fix")
+	want := "import sys\n\n\ndef f():\n    return sys.argv\n"
+	if data, _ := os.ReadFile(path); string(data) != want {
+		t.Errorf("Expected fixed file %q, got %q", want, data)
+	}
+}
+
+func TestRatchetCommand(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "module.py")
+	ratchetFile := filepath.Join(dir, "pyscn-ratchet.json")
+	run := func(args ...string) (string, error) {
+		cobraCmd := NewRatchetCommand().CreateCobraCommand()
+		var stdout, stderr bytes.Buffer
+		cobraCmd.SetOut(&stdout)
+		cobraCmd.SetErr(&stderr)
+		cobraCmd.SetArgs(append(args, "--file", ratchetFile, dir))
+		err := cobraCmd.Execute()
+		return stdout.String(), err
+	}
+
+	if _, err := run("check"); err == nil || !strings.Contains(err.Error(), "ratchet update") {
+		t.Fatalf("Expected check without a ratchet file to fail, got %v", err)
+	}
+
+	source := "def f():\n    return 1\n    print(\"dead\")\n\n\ndef g():\n    return 2\n    print(\"dead\")\n"
+	if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run("update"); err != nil {
+		t.Fatalf("ratchet update failed: %v", err)
+	}
+	if _, err := run("check"); err != nil {
+		t.Fatalf("Expected an unchanged tree to pass, got %v", err)
+	}
+
+	// New dead code in h: one more finding than recorded for the file
+	if err := os.WriteFile(path, append(mustReadFile(t, path), []byte("\n\ndef h():\n    return 3\n    print(\"dead\")\n")...), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	output, err := run("check")
+	if err == nil || !strings.Contains(output, "dead_code 3 > 2 (recorded)") {
+		t.Fatalf("Expected a dead code regression, got %v: %s", err, output)
+	}
+
+	// Removing the dead code in g tightens the ratchet
+	if err := os.WriteFile(path, []byte("def f():\n    return 1\n    print(\"dead\")\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if output, err := run("check"); err != nil || !strings.Contains(output, "dead_code 2 -> 1") {
+		t.Fatalf("Expected an improvement, got %v: %s", err, output)
+	}
+	if data := mustReadFile(t, ratchetFile); !strings.Contains(string(data), "\"value\": 1") {
+		t.Errorf("Expected the ratchet to record the lower value, got %s", data)
+	}
+}
+
+func TestSimulateCommand(t *testing.T) {
+	dir := t.TempDir()
+	source := "def f():\n    return 1\n    print(\"dead\")\n\n\ndef g():\n    return 2\n    print(\"dead\")\n"
+	if err := os.WriteFile(filepath.Join(dir, "module.py"), []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run := func(args ...string) (string, error) {
+		cobraCmd := NewSimulateCommand().CreateCobraCommand()
+		var stdout, stderr bytes.Buffer
+		cobraCmd.SetOut(&stdout)
+		cobraCmd.SetErr(&stderr)
+		cobraCmd.SetArgs(append(args, dir))
+		err := cobraCmd.Execute()
+		return stdout.String(), err
+	}
+
+	if _, err := run("--fix", "deadcode,security"); err == nil || !strings.Contains(err.Error(), "security") {
+		t.Fatalf("Expected an unknown --fix analysis to fail, got %v", err)
+	}
+
+	output, err := run("--fix", "deadcode", "--json")
+	if err != nil {
+		t.Fatalf("simulate failed: %v", err)
+	}
+	var simulation domain.ScoreSimulation
+	if err := json.Unmarshal([]byte(output), &simulation); err != nil {
+		t.Fatalf("Expected JSON output, got %v: %s", err, output)
+	}
+	if len(simulation.Fixes) != 1 || simulation.Fixes[0].Analysis != "deadcode" || simulation.Fixes[0].Gain <= 0 {
+		t.Fatalf("Expected a dead code gain, got %+v", simulation.Fixes)
+	}
+	if simulation.ProjectedScore != simulation.HealthScore+simulation.Gain {
+		t.Errorf("Projected score %d does not match %d %+d", simulation.ProjectedScore, simulation.HealthScore, simulation.Gain)
+	}
+
+	output, err = run()
+	if err != nil || !strings.Contains(output, "deadcode") || !strings.Contains(output, "Fixing all of them:") {
+		t.Fatalf("Expected a text projection, got %v: %s", err, output)
+	}
+}
+
+func TestCalibrateCommand(t *testing.T) {
+	dir := t.TempDir()
+	source := "def f(x):\n    if x:\n        return 1\n    return 2\n\n\ndef g():\n    return 3\n"
+	if err := os.WriteFile(filepath.Join(dir, "module.py"), []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run := func(args ...string) (string, error) {
+		cobraCmd := NewCalibrateCommand().CreateCobraCommand()
+		var stdout, stderr bytes.Buffer
+		cobraCmd.SetOut(&stdout)
+		cobraCmd.SetErr(&stderr)
+		cobraCmd.SetArgs(append(args, dir))
+		err := cobraCmd.Execute()
+		return stdout.String(), err
+	}
+
+	if _, err := run("--percentile", "100"); err == nil || !strings.Contains(err.Error(), "--percentile") {
+		t.Fatalf("Expected an invalid percentile to fail, got %v", err)
+	}
+
+	output, err := run("--json")
+	if err != nil {
+		t.Fatalf("calibrate failed: %v", err)
+	}
+	var report domain.CalibrationReport
+	if err := json.Unmarshal([]byte(output), &report); err != nil {
+		t.Fatalf("Expected JSON output, got %v: %s", err, output)
+	}
+	if len(report.Distributions) == 0 || report.Distributions[0].Metric != domain.CalibrationMetricComplexity {
+		t.Fatalf("Expected a complexity distribution, got %+v", report.Distributions)
+	}
+	if report.Distributions[0].Max != 2 {
+		t.Errorf("Expected the highest complexity to be 2, got %v", report.Distributions[0].Max)
+	}
+	if len(report.Suggestions) < 2 || report.Suggestions[0].Current != float64(domain.DefaultComplexityLowThreshold) {
+		t.Errorf("Expected suggestions with the default thresholds as current values, got %+v", report.Suggestions)
+	}
+
+	output, err = run()
+	if err != nil || !strings.Contains(output, "[complexity]\nlow_threshold = ") {
+		t.Errorf("Expected a config snippet, got %v: %s", err, output)
+	}
+}
+
+func TestBatchCommand(t *testing.T) {
+	dir := t.TempDir()
+	for name, source := range map[string]string{
+		"simple":  "def f():\n    return 1\n",
+		"complex": "def f(x):\n    if x > 1:\n        if x > 2:\n            return 2\n        return 1\n    return 0\n\n\ndef g(x):\n    if x > 1:\n        if x > 2:\n            return 2\n        return 1\n    return 0\n",
+	} {
+		if err := os.MkdirAll(filepath.Join(dir, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name, "module.py"), []byte(source), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	manifest := filepath.Join(dir, "repos.yaml")
+	content := "output_dir: out\ntargets:\n  - path: simple\n  - path: complex\n  - path: missing\n"
+	if err := os.WriteFile(manifest, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cobraCmd := NewBatchCommand().CreateCobraCommand()
+	var stdout, stderr bytes.Buffer
+	cobraCmd.SetOut(&stdout)
+	cobraCmd.SetErr(&stderr)
+	cobraCmd.SetArgs([]string{"--manifest", manifest, "--jobs", "2"})
+	err := cobraCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "1 of 3 targets failed") {
+		t.Fatalf("Expected the missing target to fail the batch, got %v\n%s", err, stderr.String())
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "out", "index.json"))
+	if err != nil {
+		t.Fatalf("Expected index.json: %v", err)
+	}
+	var index domain.BatchIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatalf("Expected a JSON index, got %v", err)
+	}
+	if len(index.Targets) != 3 || index.Targets[2].Name != "missing" || index.Targets[2].Error == "" {
+		t.Fatalf("Expected the failed target listed last, got %+v", index.Targets)
+	}
+	for _, entry := range index.Targets[:2] {
+		if entry.Rank == 0 || entry.HealthScore == 0 || entry.Report == "" {
+			t.Errorf("Expected a ranked, scored entry with a report, got %+v", entry)
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, "out", entry.Report)); err != nil {
+			t.Errorf("Expected report %s: %v", entry.Report, err)
+		}
+	}
+	if index.Targets[0].HealthScore < index.Targets[1].HealthScore {
+		t.Errorf("Expected targets ranked by health score, got %+v", index.Targets)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "out", "index.html")); err != nil {
+		t.Errorf("Expected index.html: %v", err)
+	}
+}
+
+func TestServeCommand(t *testing.T) {
+	run := func(stdin string, args ...string) (string, error) {
+		cobraCmd := NewServeCommand().CreateCobraCommand()
+		var stdout, stderr bytes.Buffer
+		cobraCmd.SetIn(strings.NewReader(stdin))
+		cobraCmd.SetOut(&stdout)
+		cobraCmd.SetErr(&stderr)
+		cobraCmd.SetArgs(args)
+		err := cobraCmd.Execute()
+		return stdout.String(), err
+	}
+
+	if _, err := run(""); err == nil || !strings.Contains(err.Error(), "--stdio") {
+		t.Fatalf("Expected serve without --stdio to fail, got %v", err)
+	}
+
+	output, err := run(`{"jsonrpc":"2.0","id":7,"method":"list_methods"}`+"\n", "--stdio")
+	if err != nil {
+		t.Fatalf("serve failed: %v", err)
+	}
+	var response struct {
+		ID     int `json:"id"`
+		Result []struct {
+			Name string `json:"name"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(output), &response); err != nil {
+		t.Fatalf("Expected one JSON-RPC response, got %v: %s", err, output)
+	}
+	if response.ID != 7 || len(response.Result) == 0 || response.Result[0].Name != "analyze_code" {
+		t.Errorf("Expected the method list, got %s", output)
+	}
+}
+
+func TestArchInitCommand(t *testing.T) {
+	dir := t.TempDir()
+	for _, pkg := range []string{"api", "services", "models"} {
+		if err := os.MkdirAll(filepath.Join(dir, "app", pkg), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "app", pkg, "__init__.py"), []byte(""), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	configPath := filepath.Join(dir, ".pyscn.toml")
+	if err := os.WriteFile(configPath, []byte("[complexity]\nmax_complexity = 12\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run := func(args ...string) (string, error) {
+		cobraCmd := NewArchCommand().CreateCobraCommand()
+		var stdout, stderr bytes.Buffer
+		cobraCmd.SetOut(&stdout)
+		cobraCmd.SetErr(&stderr)
+		cobraCmd.SetArgs(append(append([]string{"init"}, args...), "--config", configPath, dir))
+		err := cobraCmd.Execute()
+		return stdout.String(), err
+	}
+
+	output, err := run("--dry-run")
+	if err != nil || !strings.Contains(output, `name = "presentation"`) {
+		t.Fatalf("Expected the detected layers on stdout, got %v: %s", err, output)
+	}
+	if data := mustReadFile(t, configPath); strings.Contains(string(data), "architecture") {
+		t.Errorf("Expected --dry-run to leave the config alone, got %s", data)
+	}
+
+	if _, err := run(); err != nil {
+		t.Fatalf("arch init failed: %v", err)
+	}
+	data := string(mustReadFile(t, configPath))
+	if !strings.Contains(data, "max_complexity = 12") || strings.Count(data, "[[architecture.layers]]") != 3 {
+		t.Errorf("Expected three layers appended to the existing settings, got %s", data)
+	}
+
+	if _, err := run(); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("Expected existing layers to require --force, got %v", err)
+	}
+	if _, err := run("--force"); err != nil {
+		t.Fatalf("arch init --force failed: %v", err)
+	}
+	if data := string(mustReadFile(t, configPath)); strings.Count(data, "[[architecture.layers]]") != 3 {
+		t.Errorf("Expected --force to replace the layers, got %s", data)
+	}
+}
+
+func TestArchCheckImportCommand(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, ".pyscn.toml")
+	config := `[[architecture.layers]]
+name = "web"
+packages = ["api"]
+
+[[architecture.layers]]
+name = "model"
+packages = ["models"]
+
+[[architecture.rules]]
+from = "model"
+allow = ["model"]
+`
+	if err := os.WriteFile(configPath, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.py"), []byte("import os\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run := func(from, to string) (string, error) {
+		cobraCmd := NewArchCommand().CreateCobraCommand()
+		var stdout, stderr bytes.Buffer
+		cobraCmd.SetOut(&stdout)
+		cobraCmd.SetErr(&stderr)
+		cobraCmd.SetArgs([]string{"check-import", "--project", dir, from, to})
+		err := cobraCmd.Execute()
+		return stdout.String(), err
+	}
+
+	output, err := run("app.models.user", "app.api.routes")
+	if err == nil || !strings.Contains(output, "Global rules: denied") || !strings.Contains(output, "model -> {model}") {
+		t.Fatalf("Expected the import to be denied by the model rule, got %v: %s", err, output)
+	}
+	output, err = run("app.models.user", "app.models.order")
+	if err != nil || !strings.Contains(output, "Global rules: allowed") {
+		t.Fatalf("Expected the import to be allowed, got %v: %s", err, output)
+	}
+}
+
+func TestArchBaselineAndCheckCommands(t *testing.T) {
+	dir := t.TempDir()
+	config := `[[architecture.layers]]
+name = "web"
+packages = ["api"]
+
+[[architecture.layers]]
+name = "model"
+packages = ["models"]
+
+[[architecture.rules]]
+from = "model"
+allow = ["model"]
+`
+	files := map[string]string{
+		".pyscn.toml":            config,
+		"requirements.txt":       "",
+		"app/__init__.py":        "",
+		"app/api/__init__.py":    "",
+		"app/api/routes.py":      "def route():\n    return 1\n",
+		"app/models/__init__.py": "",
+		"app/models/user.py":     "from app.api.routes import route\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	baselinePath := filepath.Join(dir, "baseline.json")
+	run := func(args ...string) (string, error) {
+		cobraCmd := NewArchCommand().CreateCobraCommand()
+		var stdout, stderr bytes.Buffer
+		cobraCmd.SetOut(&stdout)
+		cobraCmd.SetErr(&stderr)
+		cobraCmd.SetArgs(append(args, "--file", baselinePath, dir))
+		err := cobraCmd.Execute()
+		return stdout.String(), err
+	}
+
+	if output, err := run("check"); err == nil || !strings.Contains(output, "NEW VIOLATIONS (1)") {
+		t.Fatalf("Expected the violation to fail without a baseline, got %v: %s", err, output)
+	}
+	if _, err := run("baseline"); err != nil {
+		t.Fatalf("baseline failed: %v", err)
+	}
+	if output, err := run("check"); err != nil || !strings.Contains(output, "1 grandfathered violation(s)") {
+		t.Fatalf("Expected the grandfathered violation to pass, got %v: %s", err, output)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "app/models/user.py"), []byte("def user():\n    return 1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if output, err := run("check"); err != nil || !strings.Contains(output, "FIXED (1)") {
+		t.Fatalf("Expected the violation to be fixed, got %v: %s", err, output)
+	}
+	if data := mustReadFile(t, baselinePath); strings.Contains(string(data), "app.models.user") {
+		t.Errorf("Expected the fixed violation to be removed from the baseline, got %s", data)
+	}
+}
+
+func mustReadFile(t *testing.T, path string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestCheckCustomRules(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		".pyscn.toml": "[custom_rules]\nfiles = [\"rules.yaml\"]\n",
+		"rules.yaml": `rules:
+  - id: team.no-module-print
+    message: Use logging instead of print at import time
+    match:
+      node: Call
+      callee: print
+      scope: module
+  - id: team.long-call
+    message: informational only
+    severity: info
+    match:
+      node: Call
+`,
+		"main.py": "print(\"loaded\")\n\ndef run():\n    print(\"ok\")\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	cobraCmd := NewCheckCommand().CreateCobraCommand()
+	var stdout, stderr bytes.Buffer
+	cobraCmd.SetOut(&stdout)
+	cobraCmd.SetErr(&stderr)
+	cobraCmd.SetArgs([]string{"--select", "custom", "--format", "lint", "--config", filepath.Join(tempDir, ".pyscn.toml"), tempDir})
+
+	if err := cobraCmd.Execute(); err == nil || !strings.Contains(err.Error(), "found 1 quality issue(s)") {
+		t.Fatalf("expected one custom rule issue, got %v, stderr: %s", err, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "main.py:1:1: team.no-module-print Use logging instead of print at import time") {
+		t.Errorf("expected the finding under its user-defined rule ID, got %q", stdout.String())
+	}
+}
+
+func TestParseCommand(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "app.py")
+	if err := os.WriteFile(file, []byte("def f(x):\n    return eval(x)\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run := func(args ...string) (string, error) {
+		cobraCmd := NewParseCommand().CreateCobraCommand()
+		var stdout, stderr bytes.Buffer
+		cobraCmd.SetOut(&stdout)
+		cobraCmd.SetErr(&stderr)
+		cobraCmd.SetArgs(append(args, file))
+		err := cobraCmd.Execute()
+		return stdout.String(), err
+	}
+
+	output, err := run()
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	for _, want := range []string{";; ast", `(FunctionDef name="f" [1:0-2:18]`, ";; tree-sitter", `function: (identifier text="eval" [2:11-2:15])`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected sexp output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	output, err = run("--dump", "json", "--tree", "ast")
+	if err != nil || !strings.Contains(output, `"ast": {`) || strings.Contains(output, `"tree_sitter"`) {
+		t.Fatalf("Expected a JSON dump of the AST only, got %v: %s", err, output)
+	}
+
+	output, err = run("--query", `(call function: (identifier) @fn)`)
+	if err != nil || output != file+":2:11: @fn \"eval\"\n" {
+		t.Fatalf("Expected one capture, got %v: %q", err, output)
+	}
+
+	if _, err := run("--tree", "cst"); err == nil {
+		t.Error("Expected an invalid --tree to fail")
+	}
+}
+
+func TestCFGCommand(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "app.py")
+	source := "class Parser:\n    def parse(self, text):\n        if not text:\n            return None\n        return text\n\ndef parse():\n    pass\n"
+	if err := os.WriteFile(file, []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run := func(args ...string) (string, error) {
+		cobraCmd := NewCFGCommand().CreateCobraCommand()
+		var stdout, stderr bytes.Buffer
+		cobraCmd.SetOut(&stdout)
+		cobraCmd.SetErr(&stderr)
+		cobraCmd.SetArgs(append(args, file))
+		err := cobraCmd.Execute()
+		return stdout.String(), err
+	}
+
+	output, err := run()
+	if err != nil {
+		t.Fatalf("cfg failed: %v", err)
+	}
+	for _, want := range []string{"digraph CFG {", `label="<module>";`, `label="Parser.parse";`, `if not text:\l`, `[label="return"]`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected DOT output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	output, err = run("--function", "Parser.parse", "--format", "json")
+	if err != nil || !strings.Contains(output, `"name": "Parser.parse"`) || strings.Contains(output, `"name": "parse"`) {
+		t.Fatalf("Expected the JSON graph of Parser.parse only, got %v: %s", err, output)
+	}
+
+	if _, err := run("--function", "parse"); err != nil {
+		t.Errorf("Expected the full name parse to win over Parser.parse, got %v", err)
+	}
+	if _, err := run("--function", "missing"); err == nil || !strings.Contains(err.Error(), "available") {
+		t.Errorf("Expected an unknown function to list the available ones, got %v", err)
+	}
+	if _, err := run("--format", "svg"); err == nil {
+		t.Error("Expected an invalid --format to fail")
+	}
+}
+
+func TestExplainCommand(t *testing.T) {
+	run := func(args ...string) (string, error) {
+		cobraCmd := NewExplainCommand().CreateCobraCommand()
+		var stdout, stderr bytes.Buffer
+		cobraCmd.SetOut(&stdout)
+		cobraCmd.SetErr(&stderr)
+		cobraCmd.SetArgs(args)
+		err := cobraCmd.Execute()
+		return stdout.String(), err
+	}
+
+	output, err := run("deadcode.unreachable_after_return")
+	if err != nil {
+		t.Fatalf("explain failed: %v", err)
+	}
+	for _, want := range []string{"Default severity: critical", "REPORTED", "    def total(items):", "INSTEAD", "[dead_code] detect_after_return", "rules/unreachable-after-return/"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected the explanation to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	if byPage, err := run("unreachable-after-return"); err != nil || byPage != output {
+		t.Errorf("Expected the catalog page name to explain the same rule, got %v:\n%s", err, byPage)
+	}
+
+	output, err = run("deadcode.unreachable")
+	if err != nil || !strings.Contains(output, "deadcode.unreachable_after_exit") {
+		t.Errorf("Expected a category to list its rules, got %v:\n%s", err, output)
+	}
+
+	output, err = run("clones")
+	if err != nil || !strings.Contains(output, "clones.type4") || strings.Contains(output, "deadcode.") {
+		t.Errorf("Expected an analysis to list only its rules, got %v:\n%s", err, output)
+	}
+
+	if _, err := run("nope"); err == nil || !strings.Contains(err.Error(), "unknown rule") ||
+		!strings.Contains(err.Error(), "deadcode.unreachable_after_return") {
+		t.Errorf("Expected an unknown rule to fail listing the valid IDs, got %v", err)
+	}
+	output, err = run("--help")
+	if err != nil || !strings.Contains(output, "Rule IDs:\n  clones.type1\n") || !strings.Contains(output, "  deps.cycles\n") {
+		t.Errorf("Expected the help to list the rule IDs, got %v:\n%s", err, output)
+	}
+	if _, err := run("--format", "html"); err == nil {
+		t.Error("Expected an invalid --format to fail")
+	}
+
+	// The rule reference of the documentation is generated by explain
+	output, err = run("--format", "markdown")
+	if err != nil {
+		t.Fatalf("explain --format markdown failed: %v", err)
+	}
+	reference, err := os.ReadFile("../../website/docs/rules/reference.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(reference) != output {
+		t.Error("website/docs/rules/reference.md is out of date; regenerate it with pyscn explain --format markdown")
+	}
+}
+
+func TestDiffCommand(t *testing.T) {
+	dir := t.TempDir()
+	oldFile := filepath.Join(dir, "old.py")
+	newFile := filepath.Join(dir, "new.py")
+	if err := os.WriteFile(oldFile, []byte("def f(a):\n    return a\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(newFile, []byte("def f(a):\n    return a + 1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run := func(args ...string) (string, error) {
+		cobraCmd := NewDiffCommand().CreateCobraCommand()
+		var stdout, stderr bytes.Buffer
+		cobraCmd.SetOut(&stdout)
+		cobraCmd.SetErr(&stderr)
+		cobraCmd.SetArgs(args)
+		err := cobraCmd.Execute()
+		return stdout.String(), err
+	}
+
+	output, err := run(oldFile, newFile)
+	if err != nil || !strings.Contains(output, "modified  function  f (body)") {
+		t.Fatalf("Expected a body change of f, got %v: %s", err, output)
+	}
+
+	output, err = run("--json", oldFile, newFile)
+	if err != nil || !strings.Contains(output, `"aspects": [`) || !strings.Contains(output, `"body"`) {
+		t.Fatalf("Expected JSON changes, got %v: %s", err, output)
+	}
+
+	if _, err := run(oldFile); err == nil {
+		t.Error("Expected a single file without --since to fail")
+	}
+}
+
+func TestAPIDiffCommand(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	writeFile := func(content string) {
+		if err := os.WriteFile(filepath.Join(dir, "cart.py"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git("init", "-q")
+	writeFile("def total(items, rate=1):\n    return sum(items) * rate\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "v1")
+	writeFile("def total(items):\n    return sum(items)\n")
+	git("commit", "-q", "-am", "v2")
+	t.Chdir(dir)
+
+	run := func(args ...string) (string, error) {
+		cobraCmd := NewAPIDiffCommand().CreateCobraCommand()
+		var stdout, stderr bytes.Buffer
+		cobraCmd.SetOut(&stdout)
+		cobraCmd.SetErr(&stderr)
+		cobraCmd.SetArgs(args)
+		err := cobraCmd.Execute()
+		return stdout.String(), err
+	}
+
+	output, err := run("HEAD~1", "HEAD")
+	if err != nil || !strings.Contains(output, "major  total: parameter rate removed") || !strings.Contains(output, "Version bump: major") {
+		t.Fatalf("Expected a breaking parameter removal, got %v: %s", err, output)
+	}
+
+	output, err = run("--format", "json", "HEAD~1", "HEAD")
+	if err != nil || !strings.Contains(output, `"kind": "parameter_removed"`) || !strings.Contains(output, `"bump": "major"`) {
+		t.Fatalf("Expected JSON changes, got %v: %s", err, output)
+	}
+
+	output, err = run("--format", "markdown", "HEAD~1", "HEAD")
+	if err != nil || !strings.Contains(output, "### Breaking changes") {
+		t.Fatalf("Expected a Markdown changelog, got %v: %s", err, output)
+	}
+
+	if _, err := run("--format", "xml", "HEAD~1", "HEAD"); err == nil {
+		t.Error("Expected an unknown format to fail")
+	}
+	if _, err := run("HEAD"); err == nil {
+		t.Error("Expected a single revision to fail")
+	}
+}
+
+func TestClassesCommand(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "shop"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"shop/models.py": "class Item:\n    name: str\n",
+		"shop/cart.py":   "from .models import Item\n\nclass Cart:\n    def __init__(self):\n        self.items: list[Item] = []\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(dir)
+
+	run := func(args ...string) (string, error) {
+		cobraCmd := NewClassesCommand().CreateCobraCommand()
+		var stdout, stderr bytes.Buffer
+		cobraCmd.SetOut(&stdout)
+		cobraCmd.SetErr(&stderr)
+		cobraCmd.SetArgs(args)
+		err := cobraCmd.Execute()
+		return stdout.String(), err
+	}
+
+	output, err := run("shop")
+	if err != nil || !strings.HasPrefix(output, "classDiagram\n") || !strings.Contains(output, "shop_cart_Cart *-- shop_models_Item : items") {
+		t.Fatalf("Expected a Mermaid composition, got %v: %s", err, output)
+	}
+
+	output, err = run("--format", "plantuml", "--cbo", "shop")
+	if err != nil || !strings.Contains(output, `class "Cart" as shop_cart_Cart #bbf7d0 {`) || !strings.Contains(output, "CBO 1") {
+		t.Fatalf("Expected a PlantUML class colored by CBO, got %v: %s", err, output)
+	}
+
+	output, err = run("--format", "json", "shop")
+	if err != nil || !strings.Contains(output, `"id": "shop.models.Item"`) || !strings.Contains(output, `"kind": "composition"`) {
+		t.Fatalf("Expected JSON classes and relations, got %v: %s", err, output)
+	}
+
+	if _, err := run("--format", "dot", "shop"); err == nil || !strings.Contains(err.Error(), "invalid --format") {
+		t.Fatalf("Expected an invalid format error, got %v", err)
+	}
+}
+
+func TestCallGraphCommand(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "app"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"pyproject.toml": "[project]\nname = \"app\"\n\n[project.scripts]\napp = \"app.cli:main\"\n",
+		"app/cli.py":     "def main():\n    helper()\n    return getattr(main, \"dynamic\")\n\ndef helper():\n    pass\n\ndef dynamic():\n    pass\n\ndef unused():\n    pass\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(dir)
+
+	run := func(args ...string) (string, error) {
+		cobraCmd := NewCallGraphCommand().CreateCobraCommand()
+		var stdout, stderr bytes.Buffer
+		cobraCmd.SetOut(&stdout)
+		cobraCmd.SetErr(&stderr)
+		cobraCmd.SetArgs(args)
+		err := cobraCmd.Execute()
+		return stdout.String(), err
+	}
+
+	output, err := run("app")
+	if err != nil || !strings.Contains(output, "app/cli.py::main") || !strings.Contains(output, "Unreachable functions (1):") || !strings.Contains(output, "app.cli.unused") {
+		t.Fatalf("Expected the console script as entry point, got %v: %s", err, output)
+	}
+
+	output, err = run("--no-string-refs", "app")
+	if err != nil || !strings.Contains(output, "Unreachable functions (2):") || !strings.Contains(output, "app.cli.dynamic") {
+		t.Fatalf("Expected the function named by a string to be unreachable without string references, got %v: %s", err, output)
+	}
+
+	output, err = run("--root", "app/cli.py::unused", "--format", "json", "app")
+	if err != nil || !strings.Contains(output, `"roots": [
+    "app/cli.py::unused"`) || !strings.Contains(output, `"reachable_functions": 1`) {
+		t.Fatalf("Expected JSON reachability from --root, got %v: %s", err, output)
+	}
+
+	output, err = run("--format", "dot", "app")
+	if err != nil || !strings.HasPrefix(output, "digraph CallGraph {") || !strings.Contains(output, "n1 -> n2;") {
+		t.Fatalf("Expected a DOT call graph, got %v: %s", err, output)
+	}
+
+	if _, err := run("--root", "app.missing:main", "app"); err == nil || !strings.Contains(err.Error(), "module app.missing is not among the analyzed files") {
+		t.Fatalf("Expected an unknown entry point error, got %v", err)
+	}
+	if _, err := run("--format", "svg", "app"); err == nil || !strings.Contains(err.Error(), "invalid --format") {
+		t.Fatalf("Expected an invalid format error, got %v", err)
+	}
+}
+
+func TestAnalyzeCommandDryRun(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "app.py"), []byte("def run():\n    return 1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cobraCmd := NewAnalyzeCommand().CreateCobraCommand()
+	cobraCmd.SetOut(&stdout)
+	cobraCmd.SetErr(&stderr)
+	cobraCmd.SetArgs([]string{"--dry-run", "--low-threshold", "4", "--skip-clones", dir})
+	if err := cobraCmd.Execute(); err != nil {
+		t.Fatalf("dry run failed: %v\n%s", err, stderr.String())
+	}
+
+	out := stdout.String()
+	for _, want := range []string{
+		"Dry run: nothing was analyzed",
+		"Files (1):",
+		filepath.Join(dir, "app.py"),
+		"complexity.low_threshold = 4  # flag",
+		"clones: skipped",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected dry run output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(stderr.String(), "Analysis Summary") {
+		t.Error("expected no analysis during a dry run")
+	}
+
+	cobraCmd = NewAnalyzeCommand().CreateCobraCommand()
+	cobraCmd.SetOut(&stdout)
+	cobraCmd.SetErr(&stderr)
+	cobraCmd.SetArgs([]string{"--dry-run", "--html", dir})
+	if err := cobraCmd.Execute(); err == nil || !strings.Contains(err.Error(), "--dry-run") {
+		t.Errorf("expected --dry-run --html to fail, got %v", err)
+	}
+}
+
+func TestAnalyzeCommandMultipleFormats(t *testing.T) {
+	dir := t.TempDir()
+	reports := filepath.Join(dir, "reports")
+	files := map[string]string{
+		"app.py":      "def run():\n    return 1\n",
+		".pyscn.toml": fmt.Sprintf("[output]\ndirectory = %q\n", reports),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stderr bytes.Buffer
+	cobraCmd := NewAnalyzeCommand().CreateCobraCommand()
+	cobraCmd.SetOut(io.Discard)
+	cobraCmd.SetErr(&stderr)
+	cobraCmd.SetArgs([]string{"--json", "--yaml", "--select", "complexity", dir})
+	if err := cobraCmd.Execute(); err != nil {
+		t.Fatalf("analyze failed: %v\n%s", err, stderr.String())
+	}
+
+	jsonReports, _ := filepath.Glob(filepath.Join(reports, "analyze_*.json"))
+	yamlReports, _ := filepath.Glob(filepath.Join(reports, "analyze_*.yaml"))
+	if len(jsonReports) != 1 || len(yamlReports) != 1 {
+		t.Fatalf("expected one JSON and one YAML report, got %v and %v", jsonReports, yamlReports)
+	}
+	if strings.TrimSuffix(jsonReports[0], ".json") != strings.TrimSuffix(yamlReports[0], ".yaml") {
+		t.Errorf("expected the reports to share a file name, got %s and %s", jsonReports[0], yamlReports[0])
+	}
+	for _, want := range []string{"JSON report generated", "YAML report generated"} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Errorf("expected %q in:\n%s", want, stderr.String())
+		}
+	}
+}
+
+func TestAnalyzeCommandCommunitiesJSONAndYAML(t *testing.T) {
+	dir := t.TempDir()
+	reports := filepath.Join(dir, "reports")
+	files := map[string]string{
+		"a.py":        "import b\n\ndef run():\n    return b.run()\n",
+		"b.py":        "def run():\n    return 1\n",
+		".pyscn.toml": fmt.Sprintf("[output]\ndirectory = %q\n", reports),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stderr bytes.Buffer
+	cobraCmd := NewAnalyzeCommand().CreateCobraCommand()
+	cobraCmd.SetOut(io.Discard)
+	cobraCmd.SetErr(&stderr)
+	cobraCmd.SetArgs([]string{"--json", "--yaml", "--select", "communities", dir})
+	if err := cobraCmd.Execute(); err != nil {
+		t.Fatalf("analyze failed: %v\n%s", err, stderr.String())
+	}
+
+	jsonReports, _ := filepath.Glob(filepath.Join(reports, "analyze_*.json"))
+	yamlReports, _ := filepath.Glob(filepath.Join(reports, "analyze_*.yaml"))
+	if len(jsonReports) != 1 || len(yamlReports) != 1 {
+		t.Fatalf("expected one JSON and one YAML report, got %v and %v", jsonReports, yamlReports)
+	}
+	jsonData, err := os.ReadFile(jsonReports[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(jsonData), "community_analysis") {
+		t.Errorf("expected the standalone community JSON, got:\n%s", jsonData)
+	}
+	yamlData, err := os.ReadFile(yamlReports[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(yamlData), "community_analysis:") {
+		t.Errorf("expected the unified YAML report to embed the communities, got:\n%s", yamlData)
+	}
+}
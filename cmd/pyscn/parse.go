@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/ludo-technologies/pyscn/internal/parser"
+	"github.com/ludo-technologies/pyscn/service"
+	"github.com/spf13/cobra"
+)
+
+// ParseCommand represents the parse command
+type ParseCommand struct {
+	dump  string
+	tree  string
+	query string
+}
+
+// NewParseCommand creates a new parse command
+func NewParseCommand() *ParseCommand {
+	return &ParseCommand{
+		dump:  "sexp",
+		tree:  "both",
+		query: "",
+	}
+}
+
+// CreateCobraCommand creates the cobra command for dumping parse trees
+func (c *ParseCommand) CreateCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "parse <file>",
+		Short: "Print the parse tree of a Python file",
+		Long: `Print the parse tree of a Python file, for writing custom rules and
+debugging analyses.
+
+Two trees are available: the internal AST that pyscn's analyses work on, and
+the raw tree-sitter tree it is built from. Every node is printed with its
+position as startLine:startCol-endLine:endCol (1-based lines, 0-based code
+point columns); JSON output also includes byte offsets.
+
+With --query, runs a tree-sitter query against the raw tree instead and
+prints each capture as path:line:col: @name text.
+
+Examples:
+  # Both trees as s-expressions
+  pyscn parse app.py
+
+  # Only the internal AST, as JSON
+  pyscn parse --dump json --tree ast app.py
+
+  # Find calls to eval
+  pyscn parse --query '(call function: (identifier) @fn (#eq? @fn "eval"))' app.py`,
+		Args: cobra.ExactArgs(1),
+		RunE: c.runParse,
+	}
+
+	cmd.Flags().StringVar(&c.dump, "dump", "sexp", "Output format: sexp or json")
+	cmd.Flags().StringVar(&c.tree, "tree", "both", "Tree to print: ast, raw or both")
+	cmd.Flags().StringVar(&c.query, "query", "", "Run a tree-sitter query and print its captures")
+	cmd.MarkFlagsMutuallyExclusive("dump", "query")
+
+	return cmd
+}
+
+// runParse executes the parse command
+func (c *ParseCommand) runParse(cmd *cobra.Command, args []string) error {
+	if c.dump != "sexp" && c.dump != "json" {
+		return fmt.Errorf("invalid --dump %q: must be sexp or json", c.dump)
+	}
+	if c.tree != "ast" && c.tree != "raw" && c.tree != "both" {
+		return fmt.Errorf("invalid --tree %q: must be ast, raw or both", c.tree)
+	}
+
+	path := args[0]
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	result, err := parser.New().Parse(ctx, source)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	out := cmd.OutOrStdout()
+	if c.query != "" {
+		return c.writeQuery(out, path, result)
+	}
+	if c.dump == "json" {
+		return c.writeJSON(out, path, result)
+	}
+	return c.writeSExpr(out, result)
+}
+
+// writeQuery prints the captures of every query match
+func (c *ParseCommand) writeQuery(out io.Writer, path string, result *parser.ParseResult) error {
+	matches, err := result.Query(c.query)
+	if err != nil {
+		return err
+	}
+	for _, match := range matches {
+		for _, capture := range match.Captures {
+			fmt.Fprintf(out, "%s:%d:%d: @%s %s\n", path,
+				capture.Location.StartLine, capture.Location.StartCol,
+				capture.Name, strconv.Quote(capture.Text))
+		}
+	}
+	return nil
+}
+
+// writeSExpr prints the selected trees, each headed by a comment when both
+// are printed
+func (c *ParseCommand) writeSExpr(out io.Writer, result *parser.ParseResult) error {
+	if c.tree != "raw" {
+		if c.tree == "both" {
+			fmt.Fprintln(out, ";; ast")
+		}
+		if err := parser.WriteSExpr(out, parser.DumpAST(result.AST)); err != nil {
+			return err
+		}
+	}
+	if c.tree != "ast" {
+		if c.tree == "both" {
+			fmt.Fprintln(out, ";; tree-sitter")
+		}
+		if err := parser.WriteSExpr(out, parser.DumpTree(result.RootNode, result.SourceCode)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeJSON prints the selected trees as one JSON object
+func (c *ParseCommand) writeJSON(out io.Writer, path string, result *parser.ParseResult) error {
+	dump := struct {
+		File       string           `json:"file"`
+		AST        *parser.DumpNode `json:"ast,omitempty"`
+		TreeSitter *parser.DumpNode `json:"tree_sitter,omitempty"`
+	}{File: path}
+	if c.tree != "raw" {
+		dump.AST = parser.DumpAST(result.AST)
+	}
+	if c.tree != "ast" {
+		dump.TreeSitter = parser.DumpTree(result.RootNode, result.SourceCode)
+	}
+	return service.WriteJSON(out, dump)
+}
+
+// NewParseCmd creates and returns the parse cobra command
+func NewParseCmd() *cobra.Command {
+	parseCommand := NewParseCommand()
+	return parseCommand.CreateCobraCommand()
+}
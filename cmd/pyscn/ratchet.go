@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/version"
+	"github.com/ludo-technologies/pyscn/service"
+	"github.com/spf13/cobra"
+)
+
+// defaultRatchetFile is the ratchet file used when --file is not given
+const defaultRatchetFile = "pyscn-ratchet.json"
+
+// RatchetCommand represents the ratchet command and its subcommands
+type RatchetCommand struct {
+	ratchetFile string
+	configFile  string
+	noTighten   bool
+	json        bool
+}
+
+// NewRatchetCommand creates a new ratchet command
+func NewRatchetCommand() *RatchetCommand {
+	return &RatchetCommand{
+		ratchetFile: defaultRatchetFile,
+		configFile:  "",
+		noTighten:   false,
+		json:        false,
+	}
+}
+
+// CreateCobraCommand creates the cobra command for the ratchet
+func (c *RatchetCommand) CreateCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ratchet",
+		Short: "Fail only when metrics get worse than a recorded ratchet",
+		Long: `Record the current worst-case metrics of a codebase and fail later runs
+only when a metric gets worse, so legacy code can be improved step by step.
+
+pyscn ratchet update records, per function, cyclomatic and cognitive
+complexity above the configured limits, and per file, the number of dead code
+findings and clone fragments. Commit the resulting file.
+
+pyscn ratchet check fails when a recorded metric grows, or when a function or
+file without a record exceeds the limit: max_complexity (default 10) and the
+cognitive complexity threshold for functions, zero dead code findings and
+clones for files. When everything passes and a metric went down, the file is
+rewritten with the lower values, so the ratchet tightens automatically.
+
+Examples:
+  # Record the current state
+  pyscn ratchet update
+
+  # Fail on regressions and tighten the ratchet (e.g. in CI)
+  pyscn ratchet check
+
+  # Check a part of the project without rewriting the file
+  pyscn ratchet check --no-tighten src/billing/`,
+	}
+
+	cmd.PersistentFlags().StringVar(&c.ratchetFile, "file", c.ratchetFile, "Ratchet file to record to and check against")
+	cmd.PersistentFlags().StringVarP(&c.configFile, "config", "c", "", "Configuration file path")
+
+	update := &cobra.Command{
+		Use:          "update [paths...]",
+		Short:        "Record the current metrics in the ratchet file",
+		SilenceUsage: true,
+		RunE:         c.runUpdate,
+	}
+
+	check := &cobra.Command{
+		Use:          "check [paths...]",
+		Short:        "Fail when a metric is worse than recorded, tightening the ratchet otherwise",
+		SilenceUsage: true,
+		RunE:         c.runCheck,
+	}
+	check.Flags().BoolVar(&c.noTighten, "no-tighten", false, "Do not rewrite the ratchet file when metrics improved")
+	check.Flags().BoolVar(&c.json, "json", false, "Output JSON to stdout")
+
+	cmd.AddCommand(update, check)
+	return cmd
+}
+
+// runUpdate records the metrics of the analyzed files, keeping the entries
+// of files outside the run
+func (c *RatchetCommand) runUpdate(cmd *cobra.Command, args []string) error {
+	recorded, err := service.LoadRatchet(c.ratchetFile)
+	if err != nil {
+		return err
+	}
+	response, err := c.analyze(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	root := c.root()
+	thresholds := service.RatchetThresholds(response)
+	current := service.CollectRatchetEntries(response, root, thresholds)
+	analyzed := service.RatchetAnalyzedFiles(response, root)
+	updated := service.MergeRatchet(recorded, current, analyzed, root, version.Version, thresholds)
+	if err := service.SaveRatchet(c.ratchetFile, updated); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Recorded %d entries in %s\n", len(updated.Entries), c.ratchetFile)
+	return nil
+}
+
+// runCheck compares the run with the ratchet file and tightens it when
+// nothing regressed
+func (c *RatchetCommand) runCheck(cmd *cobra.Command, args []string) error {
+	recorded, err := service.LoadRatchet(c.ratchetFile)
+	if err != nil {
+		return err
+	}
+	if recorded == nil {
+		return fmt.Errorf("ratchet file %s not found; run 'pyscn ratchet update' to create it", c.ratchetFile)
+	}
+	response, err := c.analyze(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	// Files written by hand or by older versions may lack a threshold
+	configured := service.RatchetThresholds(response)
+	if recorded.Thresholds == nil {
+		recorded.Thresholds = make(map[string]int, len(configured))
+	}
+	for _, metric := range domain.RatchetMetrics {
+		if _, ok := recorded.Thresholds[metric]; !ok {
+			recorded.Thresholds[metric] = configured[metric]
+		}
+	}
+
+	root := c.root()
+	current := service.CollectRatchetEntries(response, root, recorded.Thresholds)
+	analyzed := service.RatchetAnalyzedFiles(response, root)
+	report := service.CompareRatchet(recorded, current, analyzed)
+
+	out := cmd.OutOrStdout()
+	if c.json {
+		if err := service.WriteJSON(out, report); err != nil {
+			return err
+		}
+	} else {
+		service.WriteRatchetReportText(out, report)
+	}
+
+	if len(report.Regressions) > 0 {
+		return fmt.Errorf("%d ratchet regression(s) against %s", len(report.Regressions), c.ratchetFile)
+	}
+	if len(report.Improvements) > 0 && !c.noTighten {
+		tightened := service.MergeRatchet(recorded, current, analyzed, root, version.Version, recorded.Thresholds)
+		if err := service.SaveRatchet(c.ratchetFile, tightened); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Tightened %s (%d improvement(s))\n", c.ratchetFile, len(report.Improvements))
+	}
+	return nil
+}
+
+// analyze runs the complexity, dead code and clone analyses over paths, or
+// the current directory when none are given
+func (c *RatchetCommand) analyze(cmd *cobra.Command, paths []string) (*domain.AnalyzeResponse, error) {
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	analyze := NewAnalyzeCommand()
+	analyze.configFile = c.configFile
+	analyze.selectAnalyses = []string{domain.AnalysisComplexity, domain.AnalysisDeadCode, domain.AnalysisClones}
+	useCase, err := analyze.buildAnalyzeUseCase(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build analyze use case: %w", err)
+	}
+	return useCase.Execute(ctx, analyze.createUseCaseConfig(), paths)
+}
+
+// root returns the directory ratchet paths are relative to: the directory of
+// the ratchet file
+func (c *RatchetCommand) root() string {
+	root, err := filepath.Abs(filepath.Dir(c.ratchetFile))
+	if err != nil {
+		return filepath.Dir(c.ratchetFile)
+	}
+	return root
+}
+
+// NewRatchetCmd creates and returns the ratchet cobra command
+func NewRatchetCmd() *cobra.Command {
+	ratchetCommand := NewRatchetCommand()
+	return ratchetCommand.CreateCobraCommand()
+}
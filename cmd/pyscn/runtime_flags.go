@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ludo-technologies/pyscn/service"
+	"github.com/spf13/cobra"
+)
+
+// addRuntimeFlags registers the global flags for running in constrained,
+// non-interactive environments such as containers
+func addRuntimeFlags(cmd *cobra.Command) {
+	flags := cmd.PersistentFlags()
+	flags.Bool("ci", false, "Machine mode: never open a browser, show progress bars or detect a terminal")
+	flags.Int("max-cpu", 0, "Maximum number of CPUs to use (0 = all available)")
+	flags.String("max-memory", "", "Soft memory limit, e.g. 512M or 2GiB (sizes worker pools and tunes the garbage collector)")
+	flags.Bool("no-daemon", false, "Run in this process even when a pyscn daemon is running")
+}
+
+// applyRuntimeFlags applies the global runtime flags before a command runs
+func applyRuntimeFlags(cmd *cobra.Command, args []string) error {
+	// Read the root's flag set: a subcommand may define a local flag with
+	// the same name (e.g. init --ci <provider>). Machine mode is only ever
+	// switched on: the daemon enables it for every command it runs.
+	if ci, _ := cmd.Root().PersistentFlags().GetBool("ci"); ci {
+		service.SetMachineMode(true)
+	}
+
+	limits, err := resourceLimitsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	service.ApplyResourceLimits(limits)
+	return nil
+}
+
+// resourceLimitsFromFlags reads --max-cpu and --max-memory
+func resourceLimitsFromFlags(cmd *cobra.Command) (service.ResourceLimits, error) {
+	var limits service.ResourceLimits
+	flags := cmd.Root().PersistentFlags()
+
+	maxCPU, _ := flags.GetInt("max-cpu")
+	if maxCPU < 0 {
+		return limits, fmt.Errorf("invalid --max-cpu value %d (must be >= 0)", maxCPU)
+	}
+	limits.MaxCPU = maxCPU
+
+	if maxMemory, _ := flags.GetString("max-memory"); maxMemory != "" {
+		bytes, err := service.ParseByteSize(maxMemory)
+		if err != nil {
+			return limits, fmt.Errorf("invalid --max-memory value: %w", err)
+		}
+		limits.MaxMemoryBytes = bytes
+	}
+	return limits, nil
+}
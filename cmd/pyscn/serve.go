@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ludo-technologies/pyscn/internal/config"
+	"github.com/ludo-technologies/pyscn/mcp"
+	"github.com/spf13/cobra"
+)
+
+// ServeCommand represents the serve command
+type ServeCommand struct {
+	stdio      bool
+	configFile string
+}
+
+// NewServeCommand creates a new serve command
+func NewServeCommand() *ServeCommand {
+	return &ServeCommand{
+		stdio:      false,
+		configFile: "",
+	}
+}
+
+// CreateCobraCommand creates the cobra command for the JSON-RPC server
+func (c *ServeCommand) CreateCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve --stdio",
+		Short: "Serve analyses over JSON-RPC 2.0",
+		Long: `Run a long-lived JSON-RPC 2.0 server for editors and tools that do not
+speak MCP or LSP.
+
+Requests and responses are JSON objects, one per line, on stdin and stdout.
+The methods are the pyscn MCP tools: the method is the tool name, params are
+the tool arguments and the result is what the tool returns. list_methods
+returns every method with a JSON schema of its params. Requests are answered
+in order; the server exits when stdin is closed.
+
+Examples:
+  # Start the server
+  pyscn serve --stdio
+
+  # One request
+  echo '{"jsonrpc":"2.0","id":1,"method":"get_health_score","params":{"path":"src"}}' | pyscn serve --stdio`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         c.runServe,
+	}
+
+	cmd.Flags().BoolVar(&c.stdio, "stdio", false, "Serve on stdin and stdout")
+	cmd.Flags().StringVarP(&c.configFile, "config", "c", "", "Configuration file path")
+
+	return cmd
+}
+
+// runServe serves JSON-RPC requests until stdin is closed
+func (c *ServeCommand) runServe(cmd *cobra.Command, args []string) error {
+	if !c.stdio {
+		return errors.New("--stdio is required; it is the only transport")
+	}
+
+	cfg, err := config.LoadConfig(c.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	handlers := mcp.NewHandlerSet(mcp.NewDependencies(cfg, c.configFile))
+	server := mcp.NewJSONRPCServer(mcp.Tools(handlers))
+
+	return server.Serve(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout())
+}
+
+// NewServeCmd creates and returns the serve cobra command
+func NewServeCmd() *cobra.Command {
+	serveCommand := NewServeCommand()
+	return serveCommand.CreateCobraCommand()
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/service"
+	"github.com/spf13/cobra"
+)
+
+// SimulateCommand represents the simulate command
+type SimulateCommand struct {
+	fix        []string
+	configFile string
+	json       bool
+}
+
+// NewSimulateCommand creates a new simulate command
+func NewSimulateCommand() *SimulateCommand {
+	return &SimulateCommand{
+		fix:        []string{},
+		configFile: "",
+		json:       false,
+	}
+}
+
+// CreateCobraCommand creates the cobra command for the simulation
+func (c *SimulateCommand) CreateCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate [paths...]",
+		Short: "Project the health score gain of resolving categories of findings",
+		Long: `Run the analyses and recompute the health score as if the findings of some
+analyses were resolved, to put a number on the payoff of refactoring work.
+
+Each analysis given to --fix is simulated on its own, showing the points its
+findings deduct now and the score it would leave, largest gain first. The
+last line projects the score with all of them resolved. Without --fix, every
+analysis whose findings deduct points is simulated.
+
+The projection uses the same penalties as the health score of pyscn analyze:
+it assumes every finding of an analysis is fixed, without new findings of
+other kinds.
+
+Examples:
+  # Which analysis is worth fixing first?
+  pyscn simulate
+
+  # Projected score with dead code and duplication gone
+  pyscn simulate --fix deadcode,clones src/
+
+  # Machine-readable projection
+  pyscn simulate --fix complexity --json`,
+		SilenceUsage: true,
+		RunE:         c.runSimulate,
+	}
+
+	cmd.Flags().StringSliceVar(&c.fix, "fix", []string{}, "Analyses whose findings are assumed resolved: complexity, deadcode, clones, cbo, lcom, deps, communities, documentation, typing (default: every analysis that deducts points)")
+	cmd.Flags().StringVarP(&c.configFile, "config", "c", "", "Configuration file path")
+	cmd.Flags().BoolVar(&c.json, "json", false, "Output JSON to stdout")
+	_ = cmd.RegisterFlagCompletionFunc("fix", completeList(simulateFixValues))
+
+	return cmd
+}
+
+// runSimulate analyzes the paths, or the current directory, and projects the
+// score of the requested fixes
+func (c *SimulateCommand) runSimulate(cmd *cobra.Command, args []string) error {
+	if err := domain.ValidateSimulatedFixes(c.fix); err != nil {
+		return fmt.Errorf("invalid --fix flag: %w", err)
+	}
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	analyze := NewAnalyzeCommand()
+	analyze.configFile = c.configFile
+	analyze.summaryOnly = true
+	useCase, err := analyze.buildAnalyzeUseCase(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to build analyze use case: %w", err)
+	}
+	response, err := useCase.Execute(ctx, analyze.createUseCaseConfig(), args)
+	if err != nil {
+		return err
+	}
+
+	simulation, err := response.Summary.SimulateFixes(c.fix)
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return service.WriteJSON(cmd.OutOrStdout(), simulation)
+	}
+	service.WriteScoreSimulationText(cmd.OutOrStdout(), simulation)
+	return nil
+}
+
+// NewSimulateCmd creates and returns the simulate cobra command
+func NewSimulateCmd() *cobra.Command {
+	simulateCommand := NewSimulateCommand()
+	return simulateCommand.CreateCobraCommand()
+}
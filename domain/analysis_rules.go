@@ -0,0 +1,604 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// Analyses selectable with --select, by their CLI names
+const (
+	AnalysisComplexity    = "complexity"
+	AnalysisDeadCode      = "deadcode"
+	AnalysisClones        = "clones"
+	AnalysisCBO           = "cbo"
+	AnalysisLCOM          = "lcom"
+	AnalysisDeps          = "deps"
+	AnalysisCommunities   = "communities"
+	AnalysisDocumentation = "documentation"
+	AnalysisTyping        = "typing"
+)
+
+// SelectableAnalyses lists the analyses --select accepts, in run order
+var SelectableAnalyses = []string{
+	AnalysisComplexity,
+	AnalysisDeadCode,
+	AnalysisClones,
+	AnalysisCBO,
+	AnalysisLCOM,
+	AnalysisDeps,
+	AnalysisCommunities,
+	AnalysisDocumentation,
+	AnalysisTyping,
+}
+
+// Dependency checks selectable as deps.* rules
+const (
+	DependencyCheckCycles       = "cycles"
+	DependencyCheckArchitecture = "architecture"
+)
+
+// AnalysisRule is a part of an analysis that --select can run on its own,
+// written "<analysis>.<rule>". Selecting a rule runs its analysis and keeps
+// only the results the rule covers.
+type AnalysisRule struct {
+	ID          string
+	Analysis    string
+	Description string
+
+	// DeadCodeReasons are the finding reasons a deadcode rule keeps
+	DeadCodeReasons []string
+
+	// CloneTypes are the clone types a clones rule detects
+	CloneTypes []CloneType
+
+	// DependencyCheck is the check a deps rule runs (DependencyCheck*)
+	DependencyCheck string
+
+	// Rationale explains why findings of the rule are worth fixing
+	Rationale string
+
+	// Violation is Python code the rule reports, and Compliant the same
+	// code written so that it does not
+	Violation string
+	Compliant string
+
+	// ConfigKeys are the options changing what the rule reports, written
+	// "[section] key"
+	ConfigKeys []string
+
+	// DocPage is the name of the rule's page in the rule catalog of the
+	// documentation, if it has one
+	DocPage string
+}
+
+// deadCodeConfigKeys are the options applying to every dead code rule
+var deadCodeConfigKeys = []string{
+	"[dead_code] min_severity",
+	"[dead_code] severities",
+	"[dead_code] ignore_patterns",
+}
+
+// cloneConfigKeys are the options applying to every clone rule
+var cloneConfigKeys = []string{
+	"[clones] min_lines",
+	"[clones] min_nodes",
+	"[clones] min_tokens",
+	"[clones] similarity_threshold",
+	"[clones] enabled_clone_types",
+}
+
+// analysisRules is the rule registry. Every dead code reason belongs to one
+// category and is selectable on its own as "deadcode.<reason>".
+var analysisRules = []AnalysisRule{
+	{
+		ID:          "deadcode.unreachable",
+		Analysis:    AnalysisDeadCode,
+		Description: "Code after return, break, continue, raise, a call that never returns or an infinite loop, and branches that never run",
+		DeadCodeReasons: []string{
+			"unreachable_after_return",
+			"unreachable_after_break",
+			"unreachable_after_continue",
+			"unreachable_after_raise",
+			"unreachable_after_exit",
+			"unreachable_after_infinite_loop",
+			"unreachable_branch",
+		},
+		Rationale:  "Code that can never run is usually a bug: a statement meant to run before the jump, or a condition that does not test what it should. Otherwise it only misleads readers.",
+		ConfigKeys: deadCodeConfigKeys,
+	},
+	{
+		ID:              "deadcode.match",
+		Analysis:        AnalysisDeadCode,
+		Description:     "Match cases shadowed by an earlier pattern and matches over enums without a wildcard",
+		DeadCodeReasons: []string{"unreachable_match_case", "non_exhaustive_match"},
+		Rationale:       "A shadowed case never runs, and a match missing enum members silently does nothing for them.",
+		ConfigKeys:      deadCodeConfigKeys,
+	},
+	{
+		ID:              "deadcode.returns",
+		Analysis:        AnalysisDeadCode,
+		Description:     "Functions mixing value and bare returns, or falling through despite a non-None return type",
+		DeadCodeReasons: []string{"inconsistent_return", "missing_return"},
+		Rationale:       "Callers of a function returning values on some paths get None on the others, which fails far from the cause.",
+		ConfigKeys:      deadCodeConfigKeys,
+	},
+	{
+		ID:              "deadcode.loops",
+		Analysis:        AnalysisDeadCode,
+		Description:     "Loop else clauses that always run or whose loop always breaks, and while conditions the body never changes",
+		DeadCodeReasons: []string{"useless_loop_else", "loop_always_breaks", "loop_condition_unmodified"},
+		Rationale:       "These loops do not do what their shape says: the else clause is not a fallback, the loop is not a loop, or it never ends.",
+		ConfigKeys:      deadCodeConfigKeys,
+	},
+	{
+		ID:              "deadcode.attributes",
+		Analysis:        AnalysisDeadCode,
+		Description:     "Instance attributes assigned through self that no code of the project reads",
+		DeadCodeReasons: []string{"unused_attribute"},
+		Rationale:       "An attribute nothing reads is state kept for nothing: often left over from a refactoring, or a typo of the name the code does read.",
+		ConfigKeys:      deadCodeConfigKeys,
+	},
+	{
+		ID:          "clones.type1",
+		Analysis:    AnalysisClones,
+		Description: "Identical code apart from whitespace and comments",
+		CloneTypes:  []CloneType{Type1Clone},
+		Rationale:   "Copied code has to be fixed in every copy. A bug fixed in one copy stays in the others.",
+		Violation: `def load_users(path):
+    with open(path) as f:
+        rows = [line.split(",") for line in f]
+    return [row for row in rows if row[0]]
+
+def load_orders(path):
+    with open(path) as f:
+        rows = [line.split(",") for line in f]  # orders.csv
+    return [row for row in rows if row[0]]`,
+		Compliant: `def load_rows(path):
+    with open(path) as f:
+        rows = [line.split(",") for line in f]
+    return [row for row in rows if row[0]]
+
+load_users = load_rows
+load_orders = load_rows`,
+		ConfigKeys: append([]string{"[clones] type1_threshold"}, cloneConfigKeys...),
+		DocPage:    "duplicate-code-identical",
+	},
+	{
+		ID:          "clones.type2",
+		Analysis:    AnalysisClones,
+		Description: "Identical structure with renamed identifiers or literals",
+		CloneTypes:  []CloneType{Type2Clone},
+		Rationale:   "Copies that differ only in names or constants are one function waiting for a parameter.",
+		Violation: `def total_price(items):
+    result = 0
+    for item in items:
+        result += item.price * 1.2
+    return result
+
+def total_weight(parcels):
+    weight = 0
+    for parcel in parcels:
+        weight += parcel.weight * 1.0
+    return weight`,
+		Compliant: `def total(values, factor=1.0):
+    result = 0
+    for value in values:
+        result += value * factor
+    return result`,
+		ConfigKeys: append([]string{"[clones] type2_threshold", "[clones] ignore_identifiers", "[clones] ignore_literals"}, cloneConfigKeys...),
+		DocPage:    "duplicate-code-renamed",
+	},
+	{
+		ID:          "clones.type3",
+		Analysis:    AnalysisClones,
+		Description: "Similar structure with added, removed or changed statements",
+		CloneTypes:  []CloneType{Type3Clone},
+		Rationale:   "Copies that drifted apart hide which differences are intended. Extracting the shared part makes the differences explicit.",
+		Violation: `def save_user(user):
+    validate(user)
+    db.insert("users", user.to_dict())
+    log.info("saved user %s", user.id)
+
+def save_order(order):
+    validate(order)
+    order.total = order.compute_total()
+    db.insert("orders", order.to_dict())
+    log.info("saved order %s", order.id)`,
+		Compliant: `def save(table, record):
+    validate(record)
+    db.insert(table, record.to_dict())
+    log.info("saved %s %s", table, record.id)
+
+def save_order(order):
+    order.total = order.compute_total()
+    save("orders", order)`,
+		ConfigKeys: append([]string{"[clones] type3_threshold"}, cloneConfigKeys...),
+		DocPage:    "duplicate-code-modified",
+	},
+	{
+		ID:          "clones.type4",
+		Analysis:    AnalysisClones,
+		Description: "Equivalent behavior written differently",
+		CloneTypes:  []CloneType{Type4Clone},
+		Rationale:   "Two implementations of the same behavior have to be kept in step by hand, and readers cannot tell whether they differ on purpose.",
+		Violation: `def squares(values):
+    result = []
+    for value in values:
+        result.append(value * value)
+    return result
+
+def squared(values):
+    return [value ** 2 for value in values]`,
+		Compliant: `def squares(values):
+    return [value * value for value in values]`,
+		ConfigKeys: append([]string{"[clones] type4_threshold", "[clones] enable_dfa"}, cloneConfigKeys...),
+		DocPage:    "duplicate-code-semantic",
+	},
+	{
+		ID:              "deps.cycles",
+		Analysis:        AnalysisDeps,
+		Description:     "Circular imports between modules",
+		DependencyCheck: DependencyCheckCycles,
+		Rationale:       "Modules in a cycle cannot be understood, tested or reused apart, and the cycle can fail at import time depending on which module is imported first.",
+		Violation: `# app/orders.py
+from app.customers import Customer
+
+# app/customers.py
+from app.orders import Order`,
+		Compliant: `# app/orders.py
+from app.customers import Customer
+
+# app/customers.py
+from typing import TYPE_CHECKING
+
+if TYPE_CHECKING:
+    from app.orders import Order`,
+		ConfigKeys: []string{"[dependencies] detect_cycles", "[dependencies] cycle_reporting", "[dependencies] max_cycles_to_show"},
+		DocPage:    "circular-import",
+	},
+	{
+		ID:              "deps.architecture",
+		Analysis:        AnalysisDeps,
+		Description:     "Imports breaking the configured layer rules",
+		DependencyCheck: DependencyCheckArchitecture,
+		Rationale:       "An import against the layer rules couples a layer to details it should not know about, so changes ripple in the wrong direction.",
+		Violation: `# app/domain/order.py, with a rule that domain must not depend on infrastructure
+from app.infrastructure.db import session
+
+def save(order):
+    session.add(order)`,
+		Compliant: `# app/domain/order.py
+from typing import Protocol
+
+class OrderRepository(Protocol):
+    def add(self, order) -> None: ...
+
+def save(order, repository: OrderRepository):
+    repository.add(order)`,
+		ConfigKeys: []string{"[architecture] style", "[architecture] layers", "[architecture] rules", "[architecture] validate_layers", "[architecture] strict_mode"},
+		DocPage:    "layer-violation",
+	},
+}
+
+// deadCodeReasonDocs document the rules of single dead code reasons
+var deadCodeReasonDocs = map[string]AnalysisRule{
+	"unreachable_after_return": {
+		Description: "Statements after a return",
+		Violation: `def total(items):
+    return sum(items)
+    print("done")`,
+		Compliant: `def total(items):
+    print("done")
+    return sum(items)`,
+		ConfigKeys: []string{"[dead_code] detect_after_return"},
+		DocPage:    "unreachable-after-return",
+	},
+	"unreachable_after_break": {
+		Description: "Statements after a break in the same block",
+		Violation: `def first_ready(items):
+    for item in items:
+        if item.ready:
+            break
+            print(item)`,
+		Compliant: `def first_ready(items):
+    for item in items:
+        if item.ready:
+            print(item)
+            break`,
+		ConfigKeys: []string{"[dead_code] detect_after_break"},
+		DocPage:    "unreachable-after-break",
+	},
+	"unreachable_after_continue": {
+		Description: "Statements after a continue in the same block",
+		Violation: `def process(items):
+    for item in items:
+        if item.skip:
+            continue
+            print("skipped")
+        item.run()`,
+		Compliant: `def process(items):
+    for item in items:
+        if item.skip:
+            print("skipped")
+            continue
+        item.run()`,
+		ConfigKeys: []string{"[dead_code] detect_after_continue"},
+		DocPage:    "unreachable-after-continue",
+	},
+	"unreachable_after_raise": {
+		Description: "Statements after a raise",
+		Violation: `def check(value):
+    if value < 0:
+        raise ValueError("negative")
+        value = 0
+    return value`,
+		Compliant: `def check(value):
+    if value < 0:
+        raise ValueError("negative")
+    return value`,
+		ConfigKeys: []string{"[dead_code] detect_after_raise"},
+		DocPage:    "unreachable-after-raise",
+	},
+	"unreachable_after_exit": {
+		Description: "Statements after a call that never returns, such as sys.exit() or a function annotated NoReturn",
+		Violation: `import sys
+
+def main():
+    sys.exit(1)
+    print("exiting")`,
+		Compliant: `import sys
+
+def main():
+    print("exiting")
+    sys.exit(1)`,
+		ConfigKeys: []string{"[dead_code] terminating_calls"},
+	},
+	"unreachable_after_infinite_loop": {
+		Description: "Statements after a while True loop that never breaks",
+		Violation: `def serve(server):
+    while True:
+        server.handle()
+    server.close()`,
+		Compliant: `def serve(server):
+    while server.running:
+        server.handle()
+    server.close()`,
+		DocPage: "unreachable-after-infinite-loop",
+	},
+	"unreachable_branch": {
+		Description: "Branches that can never run, such as the else clause of a while True loop",
+		Violation: `def poll(queue):
+    while True:
+        item = queue.get()
+        if item is None:
+            return
+        item.run()
+    else:
+        print("queue closed")`,
+		Compliant: `def poll(queue):
+    while True:
+        item = queue.get()
+        if item is None:
+            print("queue closed")
+            return
+        item.run()`,
+		ConfigKeys: []string{"[dead_code] detect_unreachable_branches"},
+		DocPage:    "unreachable-branch",
+	},
+	"unreachable_match_case": {
+		Description: "Match cases that an earlier case always matches first",
+		Violation: `def handle(command):
+    match command:
+        case _:
+            return "unknown"
+        case "stop":
+            return "stopping"`,
+		Compliant: `def handle(command):
+    match command:
+        case "stop":
+            return "stopping"
+        case _:
+            return "unknown"`,
+	},
+	"non_exhaustive_match": {
+		Description: "Matches over the members of an enum that leave some members out and have no wildcard case",
+		Violation: `from enum import Enum
+
+class Color(Enum):
+    RED = 1
+    GREEN = 2
+    BLUE = 3
+
+def name(color):
+    match color:
+        case Color.RED:
+            return "red"
+        case Color.GREEN:
+            return "green"
+    return None`,
+		Compliant: `from enum import Enum
+
+class Color(Enum):
+    RED = 1
+    GREEN = 2
+    BLUE = 3
+
+def name(color):
+    match color:
+        case Color.RED:
+            return "red"
+        case _:
+            return "other"`,
+	},
+	"inconsistent_return": {
+		Description: "Functions returning a value on some paths and a bare return or nothing on others",
+		Violation: `def find(items, key):
+    for item in items:
+        if item.key == key:
+            return item
+    if not items:
+        return`,
+		Compliant: `def find(items, key):
+    for item in items:
+        if item.key == key:
+            return item
+    return None`,
+	},
+	"missing_return": {
+		Description: "Functions annotated to return a value that can reach their end without returning",
+		Violation: `def parse(value: str) -> int:
+    if value.isdigit():
+        return int(value)
+    print("unparsed")`,
+		Compliant: `def parse(value: str) -> int:
+    if value.isdigit():
+        return int(value)
+    raise ValueError(value)`,
+	},
+	"useless_loop_else": {
+		Description: "Loop else clauses of loops without a break, so the else always runs",
+		Violation: `def notify(users):
+    for user in users:
+        user.send()
+    else:
+        print("done")`,
+		Compliant: `def notify(users):
+    for user in users:
+        user.send()
+    print("done")`,
+	},
+	"loop_always_breaks": {
+		Description: "Loops with an else clause whose body breaks on every path, so they run at most once",
+		Violation: `def first(items):
+    for item in items:
+        print(item)
+        break
+    else:
+        print("empty")`,
+		Compliant: `def first(items):
+    if items:
+        print(items[0])
+    else:
+        print("empty")`,
+	},
+	"loop_condition_unmodified": {
+		Description: "While loops whose condition only reads names the loop body never changes",
+		Violation: `def wait(count):
+    while count > 0:
+        print("waiting")`,
+		Compliant: `def wait(count):
+    while count > 0:
+        print("waiting")
+        count -= 1`,
+	},
+	"unused_attribute": {
+		Description: "Instance attributes assigned through self that no code of the project reads",
+		Violation: `class Cart:
+    def __init__(self):
+        self._items = []
+        self._cache = {}
+
+    def add(self, item):
+        self._items.append(item)`,
+		Compliant: `class Cart:
+    def __init__(self):
+        self._items = []
+
+    def add(self, item):
+        self._items.append(item)`,
+	},
+}
+
+// AnalysisRules returns the registered rules, including one rule per dead
+// code reason, sorted by ID
+func AnalysisRules() []AnalysisRule {
+	rules := make([]AnalysisRule, 0, len(analysisRules)+16)
+	for _, rule := range analysisRules {
+		rules = append(rules, withReasonExamples(rule))
+		if len(rule.DeadCodeReasons) > 1 {
+			for _, reason := range rule.DeadCodeReasons {
+				rules = append(rules, deadCodeReasonRule(rule, reason))
+			}
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	return rules
+}
+
+// LookupAnalysisRule finds a rule by ID, case-insensitively
+func LookupAnalysisRule(id string) (AnalysisRule, bool) {
+	id = strings.ToLower(strings.TrimSpace(id))
+	for _, rule := range analysisRules {
+		if rule.ID == id {
+			return withReasonExamples(rule), true
+		}
+		for _, reason := range rule.DeadCodeReasons {
+			if id == rule.Analysis+"."+reason {
+				return deadCodeReasonRule(rule, reason), true
+			}
+		}
+	}
+	return AnalysisRule{}, false
+}
+
+// DeadCodeReasons lists every dead code reason, grouped by category
+func DeadCodeReasons() []string {
+	var reasons []string
+	for _, rule := range analysisRules {
+		reasons = append(reasons, rule.DeadCodeReasons...)
+	}
+	return reasons
+}
+
+// DeadCodeReasonCategory returns the ID of the rule a dead code reason
+// belongs to, or "" for an unknown reason
+func DeadCodeReasonCategory(reason string) string {
+	for _, rule := range analysisRules {
+		for _, candidate := range rule.DeadCodeReasons {
+			if candidate == reason {
+				return rule.ID
+			}
+		}
+	}
+	return ""
+}
+
+// IsSelectableAnalysis reports whether name is an analysis --select accepts
+func IsSelectableAnalysis(name string) bool {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, analysis := range SelectableAnalyses {
+		if analysis == name {
+			return true
+		}
+	}
+	return false
+}
+
+func deadCodeReasonRule(category AnalysisRule, reason string) AnalysisRule {
+	doc := deadCodeReasonDocs[reason]
+	description := doc.Description
+	if description == "" {
+		description = "Only " + strings.ReplaceAll(reason, "_", " ") + " findings from " + category.ID
+	}
+	return AnalysisRule{
+		ID:              category.Analysis + "." + reason,
+		Analysis:        category.Analysis,
+		Description:     description,
+		DeadCodeReasons: []string{reason},
+		Rationale:       category.Rationale,
+		Violation:       doc.Violation,
+		Compliant:       doc.Compliant,
+		ConfigKeys:      append(append([]string{}, doc.ConfigKeys...), category.ConfigKeys...),
+		DocPage:         doc.DocPage,
+	}
+}
+
+// withReasonExamples gives a dead code category without examples of its own
+// those of its first reason
+func withReasonExamples(rule AnalysisRule) AnalysisRule {
+	if rule.Violation == "" && len(rule.DeadCodeReasons) > 0 {
+		doc := deadCodeReasonDocs[rule.DeadCodeReasons[0]]
+		rule.Violation, rule.Compliant = doc.Violation, doc.Compliant
+	}
+	return rule
+}
@@ -22,6 +22,7 @@ type AnalyzeExecutionConfig struct {
 	IncludePatterns []string
 	ExcludePatterns []string
 	Recursive       bool
+	Walk            FileWalkOptions
 	ShowDetails     bool
 
 	ComplexityEnabled            bool
@@ -32,8 +33,17 @@ type AnalyzeExecutionConfig struct {
 	ComplexityMaxComplexity      int
 	CognitiveComplexityThreshold int
 	NestingDepthThreshold        int
+	// ComplexityBoilerplate tag the functions they match as boilerplate
+	ComplexityBoilerplate []BoilerplatePattern
+	// Weights of expression-level branching, nil when unset
+	ComplexityComprehensionWeight   *float64
+	ComplexityGeneratorWeight       *float64
+	ComplexityBooleanOperatorWeight *float64
 
 	DeadCodeEnabled bool
+	// DeadCodeTerminatingCalls are the configured calls that never return,
+	// in addition to the built-in ones
+	DeadCodeTerminatingCalls []string
 
 	CloneLSHEnabled       string
 	CloneLSHAutoThreshold int
@@ -44,6 +54,43 @@ type AnalyzeExecutionConfig struct {
 
 	CommunitiesEnabled         bool
 	CommunitiesEnabledExplicit bool
+
+	DocumentationEnabled bool
+	TypingEnabled        bool
+
+	// IncludeVendored analyzes vendored code like the rest of the project
+	IncludeVendored bool
+
+	// Serial runs the analyses one after another instead of all at once
+	Serial bool
+
+	// AbsolutePaths reports absolute file paths instead of paths relative
+	// to the project root
+	AbsolutePaths bool
+
+	// AnalysisScopes overrides IncludePatterns/ExcludePatterns for single
+	// analyses, keyed by their configuration section (AnalysisScope* keys)
+	AnalysisScopes map[string]AnalysisScope
+}
+
+// Configuration sections that can narrow or widen the files one analysis sees
+const (
+	AnalysisScopeComplexity    = "complexity"
+	AnalysisScopeDeadCode      = "dead_code"
+	AnalysisScopeClones        = "clones"
+	AnalysisScopeCBO           = "cbo"
+	AnalysisScopeLCOM          = "lcom"
+	AnalysisScopeDependencies  = "dependencies"
+	AnalysisScopeCommunities   = "communities"
+	AnalysisScopeDocumentation = "documentation"
+	AnalysisScopeTyping        = "typing"
+)
+
+// AnalysisScope holds the file patterns configured for a single analysis. A
+// nil slice keeps the corresponding [analysis] patterns.
+type AnalysisScope struct {
+	IncludePatterns []string
+	ExcludePatterns []string
 }
 
 // AnalyzeConfigurationLoader resolves and loads configuration for AnalyzeUseCase.
@@ -111,6 +158,17 @@ const (
 	CommunityRiskHighRatio   = 0.60 // >= high
 	CommunityRiskMediumRatio = 0.30 // >= medium, otherwise low
 
+	// Documentation scoring only applies when docstring coverage ran. The
+	// penalty grows linearly as coverage drops below the target and is
+	// bounded so enabling it cannot dominate the other categories.
+	MaxDocumentationPenalty   = 5
+	DocumentationCoverageGoal = 80.0 // coverage (%) at or above which there is no penalty
+
+	// Typedness scoring mirrors documentation scoring: it only applies when
+	// type annotation coverage ran and its penalty is bounded the same way.
+	MaxTypingPenalty   = 5
+	TypingCoverageGoal = 80.0 // annotation coverage (%) at or above which there is no penalty
+
 	// Score display scale - all categories normalized to this base
 	MaxScoreBase = coredomain.MaxScoreBase
 
@@ -140,18 +198,40 @@ const (
 // AnalyzeResponse represents the combined results of all analyses
 type AnalyzeResponse struct {
 	// Analysis results
-	Complexity  *ComplexityResponse      `json:"complexity,omitempty" yaml:"complexity,omitempty"`
-	DeadCode    *DeadCodeResponse        `json:"dead_code,omitempty" yaml:"dead_code,omitempty"`
-	Clone       *CloneResponse           `json:"clone,omitempty" yaml:"clone,omitempty"`
-	CBO         *CBOResponse             `json:"cbo,omitempty" yaml:"cbo,omitempty"`
-	LCOM        *LCOMResponse            `json:"lcom,omitempty" yaml:"lcom,omitempty"`
-	System      *SystemAnalysisResponse  `json:"system,omitempty" yaml:"system,omitempty"`
-	Communities *CommunityAnalysisResult `json:"community_analysis,omitempty" yaml:"community_analysis,omitempty"`
-	MockData    *MockDataResponse        `json:"mock_data,omitempty" yaml:"mock_data,omitempty"`
+	Complexity    *ComplexityResponse      `json:"complexity,omitempty" yaml:"complexity,omitempty"`
+	DeadCode      *DeadCodeResponse        `json:"dead_code,omitempty" yaml:"dead_code,omitempty"`
+	Clone         *CloneResponse           `json:"clone,omitempty" yaml:"clone,omitempty"`
+	CBO           *CBOResponse             `json:"cbo,omitempty" yaml:"cbo,omitempty"`
+	LCOM          *LCOMResponse            `json:"lcom,omitempty" yaml:"lcom,omitempty"`
+	System        *SystemAnalysisResponse  `json:"system,omitempty" yaml:"system,omitempty"`
+	Communities   *CommunityAnalysisResult `json:"community_analysis,omitempty" yaml:"community_analysis,omitempty"`
+	MockData      *MockDataResponse        `json:"mock_data,omitempty" yaml:"mock_data,omitempty"`
+	Documentation *DocumentationResponse   `json:"documentation,omitempty" yaml:"documentation,omitempty"`
+	TypeCoverage  *TypeCoverageResponse    `json:"type_coverage,omitempty" yaml:"type_coverage,omitempty"`
+
+	// Findings and scores grouped by CODEOWNERS owner
+	Ownership *OwnershipReport `json:"ownership,omitempty" yaml:"ownership,omitempty"`
+
+	// Files ranked by complexity weighted by git churn
+	Hotspots *HotspotReport `json:"hotspots,omitempty" yaml:"hotspots,omitempty"`
+
+	// Results rolled up by directory, parents before their subdirectories
+	Packages []PackageSummary `json:"packages,omitempty" yaml:"packages,omitempty"`
+
+	// Vendored code left out of the analyses and the scores
+	Vendored []VendoredCode `json:"vendored,omitempty" yaml:"vendored,omitempty"`
+
+	// Per-target results when several projects are analyzed as a workspace;
+	// Summary then holds the combined overview
+	Workspace *WorkspaceReport `json:"workspace,omitempty" yaml:"workspace,omitempty"`
 
 	// Actionable suggestions derived from analysis results
 	Suggestions []Suggestion `json:"suggestions,omitempty" yaml:"suggestions,omitempty"`
 
+	// Warnings are the non-fatal problems the analyses ran into, like
+	// skipped files and truncated results
+	Warnings []AnalysisWarning `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+
 	// Overall summary
 	Summary AnalyzeSummary `json:"summary" yaml:"summary"`
 
@@ -159,6 +239,13 @@ type AnalyzeResponse struct {
 	GeneratedAt time.Time `json:"generated_at" yaml:"generated_at"`
 	Duration    int64     `json:"duration_ms" yaml:"duration_ms"`
 	Version     string    `json:"version" yaml:"version"`
+
+	// Cancelled is set when the run was interrupted: only the analyses that
+	// finished, or got partway, before cancellation have results
+	Cancelled bool `json:"cancelled,omitempty" yaml:"cancelled,omitempty"`
+
+	// Manifest records the inputs and environment that produced the report
+	Manifest *AnalysisManifest `json:"manifest,omitempty" yaml:"manifest,omitempty"`
 }
 
 // AnalyzeSummary provides an overall summary of all analyses
@@ -168,6 +255,9 @@ type AnalyzeSummary struct {
 	AnalyzedFiles int `json:"analyzed_files" yaml:"analyzed_files"`
 	SkippedFiles  int `json:"skipped_files" yaml:"skipped_files"`
 
+	// VendoredFiles counts the vendored files left out of the analyses
+	VendoredFiles int `json:"vendored_files,omitempty" yaml:"vendored_files,omitempty"`
+
 	// Analysis status
 	ComplexityEnabled bool `json:"complexity_enabled" yaml:"complexity_enabled"`
 	DeadCodeEnabled   bool `json:"dead_code_enabled" yaml:"dead_code_enabled"`
@@ -175,6 +265,12 @@ type AnalyzeSummary struct {
 	CBOEnabled        bool `json:"cbo_enabled" yaml:"cbo_enabled"`
 	MockDataEnabled   bool `json:"mock_data_enabled" yaml:"mock_data_enabled"`
 
+	// BlameEnabled is true when findings carry git blame metadata
+	BlameEnabled bool `json:"blame_enabled,omitempty" yaml:"blame_enabled,omitempty"`
+
+	// HistoryEnabled is true when complexity findings carry their history
+	HistoryEnabled bool `json:"history_enabled,omitempty" yaml:"history_enabled,omitempty"`
+
 	// System-level (module dependencies & architecture) summary used for scoring
 	DepsEnabled               bool    `json:"deps_enabled" yaml:"deps_enabled"`
 	ArchEnabled               bool    `json:"arch_enabled" yaml:"arch_enabled"`
@@ -194,6 +290,18 @@ type AnalyzeSummary struct {
 	CommunityPackageAlignment *float64 `json:"community_package_alignment,omitempty" yaml:"community_package_alignment,omitempty"`
 	CommunityLayerAlignment   *float64 `json:"community_layer_alignment,omitempty" yaml:"community_layer_alignment,omitempty"`
 
+	// Docstring coverage (nil unless DocumentationEnabled, so a skipped
+	// analysis is not reported as zero coverage)
+	DocumentationEnabled bool     `json:"documentation_enabled" yaml:"documentation_enabled"`
+	DocumentedItems      *int     `json:"documented_items,omitempty" yaml:"documented_items,omitempty"`
+	UndocumentedItems    *int     `json:"undocumented_items,omitempty" yaml:"undocumented_items,omitempty"`
+	DocstringCoverage    *float64 `json:"docstring_coverage,omitempty" yaml:"docstring_coverage,omitempty"`
+
+	// Type annotation coverage (nil unless TypingEnabled)
+	TypingEnabled              bool     `json:"typing_enabled" yaml:"typing_enabled"`
+	TypeAnnotationCoverage     *float64 `json:"type_annotation_coverage,omitempty" yaml:"type_annotation_coverage,omitempty"`
+	UnannotatedPublicFunctions *int     `json:"unannotated_public_functions,omitempty" yaml:"unannotated_public_functions,omitempty"`
+
 	// Key metrics
 	// TotalFunctions is the post-filter count (functions included after min_complexity filtering).
 	TotalFunctions int `json:"total_functions" yaml:"total_functions"`
@@ -244,9 +352,20 @@ type AnalyzeSummary struct {
 	ArchitectureScore int `json:"architecture_score" yaml:"architecture_score"`
 	CommunityScore    int `json:"community_score" yaml:"community_score"`
 
+	// DocumentationScore is the docstring coverage rounded to a 0-100 score.
+	// It is nil when documentation analysis did not run.
+	DocumentationScore *int `json:"documentation_score,omitempty" yaml:"documentation_score,omitempty"`
+
+	// TypednessScore is the type annotation coverage rounded to a 0-100 score.
+	// It is nil when typing analysis did not run.
+	TypednessScore *int `json:"typedness_score,omitempty" yaml:"typedness_score,omitempty"`
+
 	// CommunityRiskScore is a system-level 0-100 risk signal (higher = worse).
 	// It is the inverse of CommunityScore and only meaningful when communities ran.
 	CommunityRiskScore int `json:"community_risk_score" yaml:"community_risk_score"`
+
+	// Explanations break the health score down into the penalty of each category
+	Explanations []ScoreExplanation `json:"explanations,omitempty" yaml:"explanations,omitempty"`
 }
 
 // Validate checks if the summary contains valid values
@@ -300,6 +419,20 @@ func (s *AnalyzeSummary) Validate() error {
 		}
 	}
 
+	// Documentation checks (when enabled)
+	if s.DocumentationEnabled {
+		if s.DocstringCoverage != nil && (*s.DocstringCoverage < 0 || *s.DocstringCoverage > 100) {
+			return fmt.Errorf("DocstringCoverage must be 0-100, got %f", *s.DocstringCoverage)
+		}
+	}
+
+	// Typing checks (when enabled)
+	if s.TypingEnabled {
+		if s.TypeAnnotationCoverage != nil && (*s.TypeAnnotationCoverage < 0 || *s.TypeAnnotationCoverage > 100) {
+			return fmt.Errorf("TypeAnnotationCoverage must be 0-100, got %f", *s.TypeAnnotationCoverage)
+		}
+	}
+
 	// LCOM checks
 	if s.LCOMClasses > 0 {
 		if s.HighLCOMClasses > s.LCOMClasses {
@@ -423,6 +556,25 @@ func (s *AnalyzeSummary) calculateArchitecturePenalty() int {
 	return coredomain.ArchitecturePenalty(s.ArchCompliance)
 }
 
+// calculateDocumentationPenalty calculates the penalty for missing docstrings
+// (max MaxDocumentationPenalty). Coverage at or above DocumentationCoverageGoal
+// is not penalised; below it the penalty grows linearly to the max at 0%.
+func (s *AnalyzeSummary) calculateDocumentationPenalty() int {
+	return coverageShortfallPenalty(*s.DocstringCoverage, DocumentationCoverageGoal, MaxDocumentationPenalty)
+}
+
+// calculateTypingPenalty calculates the penalty for missing type annotations
+// (max MaxTypingPenalty), on the same linear scale as documentation.
+func (s *AnalyzeSummary) calculateTypingPenalty() int {
+	return coverageShortfallPenalty(*s.TypeAnnotationCoverage, TypingCoverageGoal, MaxTypingPenalty)
+}
+
+// coverageShortfallPenalty scales maxPenalty by how far coverage falls below goal.
+func coverageShortfallPenalty(coverage, goal float64, maxPenalty int) int {
+	shortfall := clamp01((goal - coverage) / goal)
+	return int(math.Round(shortfall * float64(maxPenalty)))
+}
+
 // clamp01 bounds a value to the [0, 1] interval.
 func clamp01(v float64) float64 {
 	if v < 0 {
@@ -541,6 +693,9 @@ func (s *AnalyzeSummary) CalculateHealthScore() error {
 		s.ArchitectureScore = 0
 		s.CommunityScore = 0
 		s.CommunityRiskScore = 0
+		s.DocumentationScore = nil
+		s.TypednessScore = nil
+		s.Explanations = nil
 		return fmt.Errorf("invalid summary data: %w", err)
 	}
 
@@ -590,6 +745,23 @@ func (s *AnalyzeSummary) CalculateHealthScore() error {
 		s.CommunityRiskScore = 0
 	}
 
+	// Documentation: only penalises when docstring coverage ran, so existing
+	// grades are unaffected unless it is opted into.
+	documentationPenalty := 0
+	if s.DocumentationEnabled && s.DocstringCoverage != nil {
+		documentationScore := int(math.Round(*s.DocstringCoverage))
+		s.DocumentationScore = &documentationScore
+		documentationPenalty = s.calculateDocumentationPenalty()
+	}
+
+	// Typedness: opt-in like documentation.
+	typingPenalty := 0
+	if s.TypingEnabled && s.TypeAnnotationCoverage != nil {
+		typednessScore := int(math.Round(*s.TypeAnnotationCoverage))
+		s.TypednessScore = &typednessScore
+		typingPenalty = s.calculateTypingPenalty()
+	}
+
 	score := coredomain.HealthScoreFromPenalties(
 		complexityPenalty,
 		deadCodePenalty,
@@ -599,9 +771,24 @@ func (s *AnalyzeSummary) CalculateHealthScore() error {
 		dependencyPenalty,
 		architecturePenalty,
 		communityPenalty,
+		documentationPenalty,
+		typingPenalty,
 	)
 	s.HealthScore = score
 	s.Grade = coredomain.GradeFromScore(score)
+	s.Explanations = s.scoreExplanations(healthPenalties{
+		complexity:    complexityPenalty,
+		deadCode:      deadCodePenalty,
+		duplication:   duplicationPenalty,
+		coupling:      couplingPenalty,
+		cohesion:      cohesionPenalty,
+		dependency:    dependencyPenalty,
+		architecture:  architecturePenalty,
+		community:     communityPenalty,
+		documentation: documentationPenalty,
+		typing:        typingPenalty,
+		sizeFactor:    normalizationFactor,
+	})
 
 	return nil
 }
@@ -0,0 +1,48 @@
+package domain
+
+// AnalyzePlan is what an analyze run would do, resolved without analyzing
+// anything: the configuration, the files and the analyses that would run
+type AnalyzePlan struct {
+	// ConfigPath is the configuration file in effect, empty for the
+	// built-in defaults
+	ConfigPath string `json:"config_path,omitempty" yaml:"config_path,omitempty"`
+
+	Recursive       bool     `json:"recursive" yaml:"recursive"`
+	IncludePatterns []string `json:"include_patterns" yaml:"include_patterns"`
+	ExcludePatterns []string `json:"exclude_patterns" yaml:"exclude_patterns"`
+
+	// Files are the Python files left after the include and exclude
+	// patterns, .gitignore and vendored code are applied
+	Files []string `json:"files" yaml:"files"`
+
+	// Vendored is the vendored code left out of the analyses
+	Vendored []VendoredCode `json:"vendored,omitempty" yaml:"vendored,omitempty"`
+
+	Analyses []PlannedAnalysis `json:"analyses" yaml:"analyses"`
+}
+
+// PlannedAnalysis is one analysis of an AnalyzePlan
+type PlannedAnalysis struct {
+	// Name is the analysis name used in manifests, like "complexity"
+	Name string `json:"name" yaml:"name"`
+
+	// Section is the configuration section of the analysis
+	Section string `json:"section" yaml:"section"`
+
+	Enabled bool `json:"enabled" yaml:"enabled"`
+
+	// Files is the number of files the analysis would see. It differs
+	// from the plan's files when the section sets its own patterns.
+	Files int `json:"files" yaml:"files"`
+
+	// Settings are the effective settings of the analysis
+	Settings []PlannedSetting `json:"settings,omitempty" yaml:"settings,omitempty"`
+}
+
+// PlannedSetting is an effective configuration value and where it came
+// from: "default", "file" or "flag"
+type PlannedSetting struct {
+	Key    string      `json:"key" yaml:"key"`
+	Value  interface{} `json:"value" yaml:"value"`
+	Source string      `json:"source" yaml:"source"`
+}
@@ -1,6 +1,8 @@
 package domain_test
 
 import (
+	"encoding/json"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -617,6 +619,185 @@ func TestAnalyzeSummary_CommunityScoring(t *testing.T) {
 	}
 }
 
+func TestAnalyzeSummary_CoverageScoring(t *testing.T) {
+	// set fills in one opt-in coverage analysis, score reads its score back
+	analyses := map[string]struct {
+		set   func(s *domain.AnalyzeSummary, enabled bool, coverage float64)
+		score func(s domain.AnalyzeSummary) *int
+	}{
+		"documentation": {
+			set: func(s *domain.AnalyzeSummary, enabled bool, coverage float64) {
+				s.DocumentationEnabled = enabled
+				s.DocstringCoverage = &coverage
+			},
+			score: func(s domain.AnalyzeSummary) *int { return s.DocumentationScore },
+		},
+		"typing": {
+			set: func(s *domain.AnalyzeSummary, enabled bool, coverage float64) {
+				s.TypingEnabled = enabled
+				s.TypeAnnotationCoverage = &coverage
+			},
+			score: func(s domain.AnalyzeSummary) *int { return s.TypednessScore },
+		},
+	}
+
+	tests := []struct {
+		name            string
+		analysis        string
+		disabled        bool
+		coverage        float64
+		expectedScore   int
+		expectedPenalty int
+		wantErr         bool
+	}{
+		{name: "documentation disabled - no effect", analysis: "documentation", disabled: true, coverage: 10, expectedScore: 0, expectedPenalty: 0},
+		{name: "documentation at goal", analysis: "documentation", coverage: domain.DocumentationCoverageGoal, expectedScore: 80, expectedPenalty: 0},
+		{name: "documentation half of goal", analysis: "documentation", coverage: 40, expectedScore: 40, expectedPenalty: 3},
+		{name: "nothing documented", analysis: "documentation", coverage: 0, expectedScore: 0, expectedPenalty: domain.MaxDocumentationPenalty},
+		{name: "documentation above 100", analysis: "documentation", coverage: 120, wantErr: true},
+		{name: "typing disabled - no effect", analysis: "typing", disabled: true, coverage: 10, expectedScore: 0, expectedPenalty: 0},
+		{name: "fully annotated", analysis: "typing", coverage: 100, expectedScore: 100, expectedPenalty: 0},
+		{name: "typing quarter of goal", analysis: "typing", coverage: 20, expectedScore: 20, expectedPenalty: 4},
+		{name: "nothing annotated", analysis: "typing", coverage: 0, expectedScore: 0, expectedPenalty: domain.MaxTypingPenalty},
+		{name: "typing negative", analysis: "typing", coverage: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis := analyses[tt.analysis]
+			baseline := domain.AnalyzeSummary{AverageComplexity: 2.0}
+			s := baseline
+			analysis.set(&s, !tt.disabled, tt.coverage)
+
+			err := s.CalculateHealthScore()
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected invalid coverage to be rejected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CalculateHealthScore() error: %v", err)
+			}
+			if err := baseline.CalculateHealthScore(); err != nil {
+				t.Fatalf("baseline CalculateHealthScore() error: %v", err)
+			}
+
+			score := analysis.score(s)
+			switch {
+			case tt.disabled && score != nil:
+				t.Errorf("%s score = %d, want none when disabled", tt.analysis, *score)
+			case !tt.disabled && score == nil:
+				t.Errorf("%s score missing, want %d", tt.analysis, tt.expectedScore)
+			case !tt.disabled && *score != tt.expectedScore:
+				t.Errorf("%s score = %d, want %d", tt.analysis, *score, tt.expectedScore)
+			}
+			if penalty := baseline.HealthScore - s.HealthScore; penalty != tt.expectedPenalty {
+				t.Errorf("%s penalty = %d, want %d", tt.analysis, penalty, tt.expectedPenalty)
+			}
+		})
+	}
+}
+
+func TestAnalyzeSummary_CoverageFieldsOmittedWhenNotRun(t *testing.T) {
+	coverageKeys := []string{
+		"documented_items", "undocumented_items", "docstring_coverage", "documentation_score",
+		"type_annotation_coverage", "unannotated_public_functions", "typedness_score",
+	}
+	summaryKeys := func(s domain.AnalyzeSummary) map[string]interface{} {
+		if err := s.CalculateHealthScore(); err != nil {
+			t.Fatalf("CalculateHealthScore() error = %v", err)
+		}
+		data, err := json.Marshal(s)
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %v", err)
+		}
+		var keys map[string]interface{}
+		if err := json.Unmarshal(data, &keys); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v", err)
+		}
+		return keys
+	}
+
+	skipped := summaryKeys(domain.AnalyzeSummary{AverageComplexity: 2.0})
+	for _, key := range coverageKeys {
+		if _, ok := skipped[key]; ok {
+			t.Errorf("%s written although the analysis did not run", key)
+		}
+	}
+
+	zero, none := 0.0, 0
+	ran := summaryKeys(domain.AnalyzeSummary{
+		AverageComplexity:          2.0,
+		DocumentationEnabled:       true,
+		DocumentedItems:            &none,
+		UndocumentedItems:          &none,
+		DocstringCoverage:          &zero,
+		TypingEnabled:              true,
+		TypeAnnotationCoverage:     &zero,
+		UnannotatedPublicFunctions: &none,
+	})
+	for _, key := range coverageKeys {
+		if value, ok := ran[key]; !ok || value != 0.0 {
+			t.Errorf("%s = %v, want a real score of 0", key, value)
+		}
+	}
+}
+
+func TestAnalyzeSummary_ScoreExplanations(t *testing.T) {
+	summary := domain.AnalyzeSummary{
+		TotalFiles:            40,
+		AverageComplexity:     8,
+		CriticalDeadCode:      4,
+		WarningDeadCode:       6,
+		CodeDuplication:       9,
+		CBOClasses:            10,
+		HighCouplingClasses:   2,
+		DepsEnabled:           true,
+		DepsTotalModules:      20,
+		DepsModulesInCycles:   4,
+		DepsMaxDepth:          5,
+		DocumentationEnabled:  true,
+		DocstringCoverage:     floatPtr(40),
+		MediumCouplingClasses: 1,
+	}
+	if err := summary.CalculateHealthScore(); err != nil {
+		t.Fatalf("CalculateHealthScore() error = %v", err)
+	}
+
+	var categories []string
+	totalPenalty := 0
+	for _, explanation := range summary.Explanations {
+		categories = append(categories, explanation.Category)
+		totalPenalty += explanation.Penalty
+		if explanation.Penalty < 0 || explanation.Penalty > explanation.MaxPenalty {
+			t.Errorf("%s penalty %d outside 0..%d", explanation.Category, explanation.Penalty, explanation.MaxPenalty)
+		}
+		if len(explanation.Inputs) == 0 || explanation.Formula == "" {
+			t.Errorf("%s explanation lacks inputs or formula", explanation.Category)
+		}
+	}
+
+	want := []string{"complexity", "dead_code", "duplication", "coupling", "cohesion", "dependencies", "documentation"}
+	if !reflect.DeepEqual(categories, want) {
+		t.Errorf("categories = %v, want %v", categories, want)
+	}
+	if got := 100 - totalPenalty; got != summary.HealthScore {
+		t.Errorf("100 - penalties = %d, want health score %d", got, summary.HealthScore)
+	}
+	if summary.Explanations[2].Score != summary.DuplicationScore {
+		t.Errorf("duplication explanation score = %d, want %d", summary.Explanations[2].Score, summary.DuplicationScore)
+	}
+
+	invalid := domain.AnalyzeSummary{CodeDuplication: 150, Explanations: summary.Explanations}
+	if err := invalid.CalculateHealthScore(); err == nil {
+		t.Fatal("expected invalid summary to be rejected")
+	}
+	if invalid.Explanations != nil {
+		t.Error("invalid summary should not keep explanations")
+	}
+}
+
 func TestAnalyzeSummary_IsHealthy(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -0,0 +1,132 @@
+package domain
+
+// SemverBump is the smallest version bump a change calls for
+type SemverBump string
+
+const (
+	SemverNone  SemverBump = "none"
+	SemverPatch SemverBump = "patch"
+	SemverMinor SemverBump = "minor"
+	SemverMajor SemverBump = "major"
+)
+
+// semverRank orders bumps from none to major
+var semverRank = map[SemverBump]int{
+	SemverNone:  0,
+	SemverPatch: 1,
+	SemverMinor: 2,
+	SemverMajor: 3,
+}
+
+// MaxSemverBump returns the larger of two bumps
+func MaxSemverBump(a, b SemverBump) SemverBump {
+	if semverRank[b] > semverRank[a] {
+		return b
+	}
+	return a
+}
+
+// APIChangeKind is how the public API changed
+type APIChangeKind string
+
+const (
+	APISymbolAdded   APIChangeKind = "added"
+	APISymbolRemoved APIChangeKind = "removed"
+	// APIAsyncChanged is a function that became a coroutine or stopped
+	// being one
+	APIAsyncChanged APIChangeKind = "async_changed"
+	// APIBasesChanged is a class whose base classes changed
+	APIBasesChanged APIChangeKind = "bases_changed"
+
+	APIParameterAdded   APIChangeKind = "parameter_added"
+	APIParameterRemoved APIChangeKind = "parameter_removed"
+	// APIParameterMoved is a positional parameter at another position
+	APIParameterMoved APIChangeKind = "parameter_moved"
+	// APIParameterKindChanged is a parameter that can be passed in fewer or
+	// more ways, such as one that became keyword-only
+	APIParameterKindChanged APIChangeKind = "parameter_kind_changed"
+
+	APIDefaultAdded   APIChangeKind = "default_added"
+	APIDefaultRemoved APIChangeKind = "default_removed"
+	APIDefaultChanged APIChangeKind = "default_changed"
+
+	APIAnnotationChanged       APIChangeKind = "annotation_changed"
+	APIReturnAnnotationChanged APIChangeKind = "return_annotation_changed"
+)
+
+// APIChange is a change to the public API of a module
+type APIChange struct {
+	Kind   APIChangeKind  `json:"kind"`
+	Symbol DiffSymbolKind `json:"symbol"`
+
+	// Module is the dotted module name, such as "shop.cart"
+	Module string `json:"module"`
+
+	// Name is the dotted name within the module, such as "Cart.total"
+	Name string `json:"name"`
+
+	// Parameter is the parameter a parameter, default or annotation change
+	// applies to
+	Parameter string `json:"parameter,omitempty"`
+
+	// Old and New are the values that changed: defaults, annotations,
+	// parameter kinds or positions, base classes
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+
+	// Bump is the version bump the change calls for
+	Bump SemverBump `json:"bump"`
+
+	OldSignature string `json:"old_signature,omitempty"`
+	NewSignature string `json:"new_signature,omitempty"`
+
+	// Location is where the definition is in the new revision, or in the
+	// old one for removals
+	Location *SourceLocation `json:"location,omitempty"`
+}
+
+// QualifiedName returns the module and name of the changed definition
+func (c APIChange) QualifiedName() string {
+	if c.Module == "" {
+		return c.Name
+	}
+	return c.Module + "." + c.Name
+}
+
+// APIDiffSummary counts API changes by the bump they call for
+type APIDiffSummary struct {
+	Major int `json:"major"`
+	Minor int `json:"minor"`
+	Patch int `json:"patch"`
+
+	// Bump is the largest bump of all changes, the one the next release
+	// needs
+	Bump SemverBump `json:"bump"`
+}
+
+// APIDiff is the difference between the public APIs of two revisions
+type APIDiff struct {
+	OldRevision string `json:"old_revision"`
+	NewRevision string `json:"new_revision"`
+
+	// Changes are ordered by module, then by their order in the new
+	// revision, followed by removals in the order of the old one
+	Changes []APIChange    `json:"changes"`
+	Summary APIDiffSummary `json:"summary"`
+}
+
+// Summarize counts the changes of the diff by bump
+func (d *APIDiff) Summarize() {
+	d.Summary = APIDiffSummary{Bump: SemverNone}
+	for _, change := range d.Changes {
+		switch change.Bump {
+		case SemverMajor:
+			d.Summary.Major++
+		case SemverMinor:
+			d.Summary.Minor++
+		case SemverPatch:
+			d.Summary.Patch++
+		}
+		d.Summary.Bump = MaxSemverBump(d.Summary.Bump, change.Bump)
+	}
+}
@@ -0,0 +1,59 @@
+package domain
+
+// ArchitectureBaselineEntry is an architecture violation as recorded in the
+// baseline file of `pyscn arch baseline` and reported by `pyscn arch check`
+type ArchitectureBaselineEntry struct {
+	RuleSet     string            `json:"rule_set,omitempty"`
+	Type        ViolationType     `json:"type"`
+	Rule        string            `json:"rule"`
+	Module      string            `json:"module"`
+	Target      string            `json:"target,omitempty"`
+	Severity    ViolationSeverity `json:"severity"`
+	Description string            `json:"description,omitempty"`
+
+	// Layer is the layer of Module for layer violations; it is not recorded
+	Layer string `json:"-"`
+}
+
+// Key identifies the violation independently of its severity and wording
+func (e ArchitectureBaselineEntry) Key() string {
+	return e.RuleSet + "\x00" + string(e.Type) + "\x00" + e.Rule + "\x00" + e.Module + "\x00" + e.Target
+}
+
+// ArchitectureBaseline is the committed list of grandfathered architecture
+// violations. `pyscn arch check` fails only on violations missing from it
+// and drops the entries that no longer occur.
+type ArchitectureBaseline struct {
+	// Version is the pyscn version that last wrote the file
+	Version string `json:"version"`
+
+	Violations []ArchitectureBaselineEntry `json:"violations"`
+}
+
+// ArchitectureBudgetUsage is the number of new violations charged to the
+// budget of a layer rule
+type ArchitectureBudgetUsage struct {
+	RuleSet string `json:"rule_set,omitempty"`
+	Layer   string `json:"layer"`
+	Budget  int    `json:"budget"`
+	Used    int    `json:"used"`
+}
+
+// ArchitectureGateReport is the outcome of checking a run against the
+// architecture baseline
+type ArchitectureGateReport struct {
+	// New are violations neither grandfathered nor covered by a budget
+	New []ArchitectureBaselineEntry `json:"new"`
+
+	// WithinBudget are violations missing from the baseline that the budget
+	// of their layer rule tolerates
+	WithinBudget []ArchitectureBaselineEntry `json:"within_budget"`
+
+	// Grandfathered is the number of current violations found in the baseline
+	Grandfathered int `json:"grandfathered"`
+
+	// Fixed are baseline entries that no longer occur
+	Fixed []ArchitectureBaselineEntry `json:"fixed"`
+
+	Budgets []ArchitectureBudgetUsage `json:"budgets"`
+}
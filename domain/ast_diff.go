@@ -0,0 +1,101 @@
+package domain
+
+// SymbolChangeKind is how a definition changed between two versions of a file
+type SymbolChangeKind string
+
+const (
+	SymbolAdded    SymbolChangeKind = "added"
+	SymbolRemoved  SymbolChangeKind = "removed"
+	SymbolModified SymbolChangeKind = "modified"
+	// SymbolRenamed is a definition whose name changed while its signature
+	// and body stayed the same
+	SymbolRenamed SymbolChangeKind = "renamed"
+)
+
+// DiffSymbolKind is the kind of definition a change applies to
+type DiffSymbolKind string
+
+const (
+	DiffSymbolModule   DiffSymbolKind = "module"
+	DiffSymbolClass    DiffSymbolKind = "class"
+	DiffSymbolFunction DiffSymbolKind = "function"
+	DiffSymbolMethod   DiffSymbolKind = "method"
+)
+
+// ChangeAspect is a part of a definition that changed. Formatting and
+// comments are not changes.
+type ChangeAspect string
+
+const (
+	// ChangeAspectSignature covers parameters, annotations and async for
+	// functions, and base classes for classes
+	ChangeAspectSignature  ChangeAspect = "signature"
+	ChangeAspectDecorators ChangeAspect = "decorators"
+	ChangeAspectDocstring  ChangeAspect = "docstring"
+	// ChangeAspectBody covers the statements of a body other than the
+	// docstring and nested definitions, which are reported on their own
+	ChangeAspectBody ChangeAspect = "body"
+	// ChangeAspectImports is the import statements of a module
+	ChangeAspectImports ChangeAspect = "imports"
+)
+
+// SymbolChange is a definition that differs between two versions of a file
+type SymbolChange struct {
+	Kind   SymbolChangeKind `json:"kind"`
+	Symbol DiffSymbolKind   `json:"symbol"`
+
+	// Name is the dotted name within the module, such as "Cart.total", in
+	// the new version; in the old one for removed definitions
+	Name string `json:"name"`
+
+	// OldName is the name in the old version of a renamed definition, or of
+	// a definition nested in a renamed class
+	OldName string `json:"old_name,omitempty"`
+
+	// Aspects are the parts of a modified or renamed definition that changed
+	Aspects []ChangeAspect `json:"aspects,omitempty"`
+
+	OldSignature string `json:"old_signature,omitempty"`
+	NewSignature string `json:"new_signature,omitempty"`
+
+	OldLocation *SourceLocation `json:"old_location,omitempty"`
+	NewLocation *SourceLocation `json:"new_location,omitempty"`
+}
+
+// HasAspect reports whether an aspect of the definition changed
+func (c SymbolChange) HasAspect(aspect ChangeAspect) bool {
+	for _, a := range c.Aspects {
+		if a == aspect {
+			return true
+		}
+	}
+	return false
+}
+
+// BodyOnly reports whether only the body of the definition changed, so
+// that callers and subclasses are unaffected
+func (c SymbolChange) BodyOnly() bool {
+	return c.Kind == SymbolModified && len(c.Aspects) == 1 && c.Aspects[0] == ChangeAspectBody
+}
+
+// ASTDiffSummary counts the changes of a diff by kind
+type ASTDiffSummary struct {
+	Added    int `json:"added"`
+	Removed  int `json:"removed"`
+	Modified int `json:"modified"`
+	Renamed  int `json:"renamed"`
+	// SignatureChanges counts modified definitions whose signature changed
+	SignatureChanges int `json:"signature_changes"`
+}
+
+// ASTDiff is the semantic difference between two versions of a Python file.
+// A file that does not exist in one version diffs against an empty module.
+type ASTDiff struct {
+	OldPath string `json:"old_path"`
+	NewPath string `json:"new_path"`
+
+	// Changes are in the order of the new version, followed by removals in
+	// the order of the old one
+	Changes []SymbolChange `json:"changes"`
+	Summary ASTDiffSummary `json:"summary"`
+}
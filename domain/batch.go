@@ -0,0 +1,59 @@
+package domain
+
+import "time"
+
+// DefaultBatchOutputDir is where `pyscn batch` writes reports when neither
+// --output-dir nor the manifest names a directory
+const DefaultBatchOutputDir = "pyscn-batch"
+
+// BatchManifest lists the targets `pyscn batch` analyzes
+type BatchManifest struct {
+	Targets []BatchTarget `yaml:"targets"`
+
+	// Jobs is the number of targets analyzed at once; 0 means one per CPU
+	Jobs int `yaml:"jobs"`
+
+	// OutputDir receives the per-target reports and the index
+	OutputDir string `yaml:"output_dir"`
+}
+
+// BatchTarget is one repository or path of a batch manifest
+type BatchTarget struct {
+	// Name identifies the target in the index and names its report file
+	Name string `yaml:"name"`
+
+	// Path is a local directory or a git URL with an optional @ref
+	Path string `yaml:"path"`
+
+	// Config is the configuration file for the target; empty to discover it
+	Config string `yaml:"config"`
+}
+
+// BatchIndex ranks the targets of a batch run by health score
+type BatchIndex struct {
+	GeneratedAt time.Time         `json:"generated_at"`
+	Version     string            `json:"version"`
+	Targets     []BatchIndexEntry `json:"targets"`
+}
+
+// BatchIndexEntry holds the scores of one target. Failed targets have no
+// rank and are listed last.
+type BatchIndexEntry struct {
+	Rank   int    `json:"rank,omitempty"`
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Report string `json:"report,omitempty"` // Report file, relative to the index
+	Error  string `json:"error,omitempty"`
+
+	HealthScore       int    `json:"health_score"`
+	Grade             string `json:"grade"`
+	ComplexityScore   int    `json:"complexity_score"`
+	DeadCodeScore     int    `json:"dead_code_score"`
+	DuplicationScore  int    `json:"duplication_score"`
+	CouplingScore     int    `json:"coupling_score"`
+	CohesionScore     int    `json:"cohesion_score"`
+	DependencyScore   int    `json:"dependency_score"`
+	ArchitectureScore int    `json:"architecture_score"`
+	TotalFiles        int    `json:"total_files"`
+	DurationMs        int64  `json:"duration_ms"`
+}
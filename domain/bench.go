@@ -0,0 +1,112 @@
+package domain
+
+// BenchCorpus is the pinned set of repositories used by `pyscn bench`
+type BenchCorpus struct {
+	Repos []BenchRepo `toml:"repo" json:"repos"`
+}
+
+// BenchRepo is a single repository in the benchmark corpus. Remote entries
+// are cloned at a pinned ref; local entries point at a directory relative to
+// the corpus file.
+type BenchRepo struct {
+	// Name identifies the repository in reports and in the baseline
+	Name string `toml:"name" json:"name"`
+
+	// URL is the git remote to clone; empty for local entries
+	URL string `toml:"url" json:"url,omitempty"`
+
+	// Ref is the tag or branch to check out; required for remote entries
+	Ref string `toml:"ref" json:"ref,omitempty"`
+
+	// Path is a local directory, resolved relative to the corpus file
+	Path string `toml:"path" json:"path,omitempty"`
+
+	// Subdir restricts analysis to a directory inside the repository
+	Subdir string `toml:"subdir" json:"subdir,omitempty"`
+}
+
+// IsRemote reports whether the repository has to be cloned
+func (r BenchRepo) IsRemote() bool {
+	return r.URL != ""
+}
+
+// BenchResult is the measurement of one analysis run over a corpus repository
+type BenchResult struct {
+	Repo string `json:"repo"`
+
+	// Ref is the pinned ref the measurement was taken at
+	Ref string `json:"ref,omitempty"`
+
+	// WallClockMs is the elapsed time of the analysis process
+	WallClockMs int64 `json:"wall_clock_ms"`
+
+	// PeakRSSKB is the maximum resident set size of the analysis process,
+	// or 0 when the platform does not report it
+	PeakRSSKB int64 `json:"peak_rss_kb"`
+
+	// Findings maps a finding kind (for example "dead_code") to its count
+	Findings map[string]int `json:"findings"`
+
+	// Error describes why the repository could not be measured, if it failed
+	Error string `json:"error,omitempty"`
+}
+
+// BenchBaseline is the stored set of measurements that runs are compared to
+type BenchBaseline struct {
+	// Version is the pyscn version that produced the baseline
+	Version string        `json:"version"`
+	Results []BenchResult `json:"results"`
+}
+
+// Result returns the baseline measurement for a repository, or nil
+func (b *BenchBaseline) Result(repo string) *BenchResult {
+	if b == nil {
+		return nil
+	}
+	for i := range b.Results {
+		if b.Results[i].Repo == repo {
+			return &b.Results[i]
+		}
+	}
+	return nil
+}
+
+// BenchTolerance bounds how much a run may deviate from the baseline before
+// it is reported as a regression
+type BenchTolerance struct {
+	// WallClock is the allowed relative slowdown (0.25 allows 25%)
+	WallClock float64
+
+	// PeakRSS is the allowed relative memory growth
+	PeakRSS float64
+
+	// MinWallClockMs ignores time regressions below this absolute difference,
+	// so that sub-second repositories do not flap on scheduler noise
+	MinWallClockMs int64
+}
+
+// DefaultBenchTolerance returns the tolerance used when no flags are given
+func DefaultBenchTolerance() BenchTolerance {
+	return BenchTolerance{
+		WallClock:      0.25,
+		PeakRSS:        0.20,
+		MinWallClockMs: 200,
+	}
+}
+
+// BenchRegression is a metric that moved outside the allowed tolerance
+type BenchRegression struct {
+	Repo     string  `json:"repo"`
+	Metric   string  `json:"metric"`
+	Baseline float64 `json:"baseline"`
+	Current  float64 `json:"current"`
+}
+
+// BenchReport is the outcome of a benchmark run
+type BenchReport struct {
+	Results     []BenchResult     `json:"results"`
+	Regressions []BenchRegression `json:"regressions"`
+
+	// Missing lists repositories that have no baseline entry yet
+	Missing []string `json:"missing,omitempty"`
+}
@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// BlameInfo describes the git history of the lines covered by a finding.
+// It identifies the most recent change within the range so reports can route
+// findings to owners and tell long-standing debt apart from fresh regressions.
+type BlameInfo struct {
+	// LastAuthor is the author of the most recent commit touching the range
+	LastAuthor string `json:"last_author" yaml:"last_author"`
+
+	// LastAuthorEmail is the e-mail address recorded for LastAuthor
+	LastAuthorEmail string `json:"last_author_email,omitempty" yaml:"last_author_email,omitempty"`
+
+	// LastCommit is the hash of the most recent commit touching the range
+	LastCommit string `json:"last_commit" yaml:"last_commit"`
+
+	// LastModified is the author time of the most recent commit
+	LastModified time.Time `json:"last_modified" yaml:"last_modified"`
+
+	// OldestModified is the author time of the oldest line in the range
+	OldestModified time.Time `json:"oldest_modified" yaml:"oldest_modified"`
+
+	// AgeDays is the number of whole days since LastModified: the age of
+	// the most recent change to the range, not of its oldest line
+	AgeDays int `json:"age_days" yaml:"age_days"`
+
+	// Uncommitted is true when any line in the range has not been committed yet
+	Uncommitted bool `json:"uncommitted,omitempty" yaml:"uncommitted,omitempty"`
+}
+
+// BlameProvider resolves git blame metadata for a line range of a file.
+// Implementations return (nil, nil) when the file is not tracked by git.
+type BlameProvider interface {
+	Blame(filePath string, startLine, endLine int) (*BlameInfo, error)
+}
@@ -0,0 +1,46 @@
+package domain
+
+// DefaultCalibrationPercentile is the percentile `pyscn calibrate` places
+// the low thresholds at when --percentile is not given
+const DefaultCalibrationPercentile = 90.0
+
+// Metrics whose distribution `pyscn calibrate` measures
+const (
+	CalibrationMetricComplexity      = "complexity"
+	CalibrationMetricCBO             = "cbo"
+	CalibrationMetricCloneSimilarity = "clone_similarity"
+)
+
+// MetricDistribution summarizes the values of one metric over the analyzed
+// functions, classes or clone pairs
+type MetricDistribution struct {
+	Metric  string  `json:"metric"`
+	Samples int     `json:"samples"`
+	Min     float64 `json:"min"`
+	Median  float64 `json:"median"`
+	P75     float64 `json:"p75"`
+	P90     float64 `json:"p90"`
+	P95     float64 `json:"p95"`
+	P99     float64 `json:"p99"`
+	Max     float64 `json:"max"`
+}
+
+// ThresholdSuggestion is a value for one configuration key derived from the
+// distribution of a metric
+type ThresholdSuggestion struct {
+	// Section and Key name the setting in .pyscn.toml, e.g. [complexity] low_threshold
+	Section string `json:"section"`
+	Key     string `json:"key"`
+
+	Metric     string  `json:"metric"`
+	Percentile float64 `json:"percentile"` // Percentile of the metric the value was taken at
+	Current    float64 `json:"current"`    // Value in effect for the run
+	Suggested  float64 `json:"suggested"`
+}
+
+// CalibrationReport is the result of `pyscn calibrate`
+type CalibrationReport struct {
+	Percentile    float64               `json:"percentile"`
+	Distributions []MetricDistribution  `json:"distributions"`
+	Suggestions   []ThresholdSuggestion `json:"suggestions"`
+}
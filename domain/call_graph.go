@@ -0,0 +1,84 @@
+package domain
+
+// CallGraphNodeKind is what a node of the call graph stands for
+type CallGraphNodeKind string
+
+const (
+	// CallGraphNodeModule is the module-level code of a file
+	CallGraphNodeModule   CallGraphNodeKind = "module"
+	CallGraphNodeFunction CallGraphNodeKind = "function"
+	CallGraphNodeMethod   CallGraphNodeKind = "method"
+	CallGraphNodeClass    CallGraphNodeKind = "class"
+)
+
+// CallGraphEdgeKind is how one node of the call graph leads to another
+type CallGraphEdgeKind string
+
+const (
+	// CallGraphEdgeCall is a call of a function
+	CallGraphEdgeCall CallGraphEdgeKind = "call"
+	// CallGraphEdgeUse is a reference that is not a call: a class
+	// instantiated, a function passed as a callback, a definition decorated
+	CallGraphEdgeUse CallGraphEdgeKind = "use"
+	// CallGraphEdgeMethod leads from a class to one of its methods
+	CallGraphEdgeMethod CallGraphEdgeKind = "method"
+	// CallGraphEdgeBase leads from a class to one of its bases
+	CallGraphEdgeBase CallGraphEdgeKind = "base"
+	// CallGraphEdgeString is a string literal naming a function or class, a
+	// soft reference such as getattr(obj, "run") or a Celery task name
+	CallGraphEdgeString CallGraphEdgeKind = "string"
+)
+
+// CallGraphNode is a function, method, class or module-level code
+type CallGraphNode struct {
+	// ID is the file and the qualified name, such as
+	// "src/shop/cart.py::Cart.total"; module-level code is named "<module>"
+	ID     string            `json:"id"`
+	Kind   CallGraphNodeKind `json:"kind"`
+	Module string            `json:"module"`
+	Name   string            `json:"name"`
+
+	Location SourceLocation `json:"location"`
+
+	// Lines counts the lines of a function or method, without those of the
+	// functions nested in it
+	Lines int `json:"lines,omitempty"`
+
+	// Reachable is set when entry points are given
+	Reachable *bool `json:"reachable,omitempty"`
+}
+
+// CallGraphEdge is an edge of the call graph between two node IDs
+type CallGraphEdge struct {
+	From string            `json:"from"`
+	To   string            `json:"to"`
+	Kind CallGraphEdgeKind `json:"kind"`
+}
+
+// CallGraphReachability sums up what the entry points reach. Functions
+// count methods too.
+type CallGraphReachability struct {
+	Functions            int `json:"functions"`
+	ReachableFunctions   int `json:"reachable_functions"`
+	UnreachableFunctions int `json:"unreachable_functions"`
+
+	Lines            int     `json:"lines"`
+	ReachableLines   int     `json:"reachable_lines"`
+	ReachablePercent float64 `json:"reachable_percent"`
+
+	// Unreachable holds the IDs of the functions and methods no entry point
+	// reaches: candidates for removal, in node order
+	Unreachable []string `json:"unreachable"`
+}
+
+// CallGraphReport is the static call graph of a set of files and, with
+// entry points, what they reach. Nodes are ordered by file and position,
+// edges by their source node.
+type CallGraphReport struct {
+	// Roots holds the IDs of the entry points
+	Roots []string `json:"roots"`
+
+	Nodes        []CallGraphNode        `json:"nodes"`
+	Edges        []CallGraphEdge        `json:"edges"`
+	Reachability *CallGraphReachability `json:"reachability,omitempty"`
+}
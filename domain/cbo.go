@@ -27,6 +27,10 @@ type CBORequest struct {
 	LowThreshold    int // Default: 3 (industry standard)
 	MediumThreshold int // Default: 7 (industry standard)
 
+	// Maximum coupled classes per coupling kind, checked independently of
+	// the risk thresholds; 0 means no limit
+	KindThresholds CBOCouplingKinds
+
 	// Configuration
 	ConfigPath string
 
@@ -39,6 +43,7 @@ type CBORequest struct {
 	IncludeBuiltins       *bool // Include dependencies on built-in types
 	IncludeImports        *bool // Include imported modules in dependency count
 	GroupNamespaceImports *bool // Collapse alias.Member references to a single alias edge
+	IncludeTypeHints      *bool // Count classes referenced only in annotations, whatever the annotation style
 }
 
 // CBOMetrics represents detailed CBO metrics for a class
@@ -53,10 +58,59 @@ type CBOMetrics struct {
 	AttributeAccessDependencies int // Method calls and attribute access
 	ImportDependencies          int // Explicitly imported classes
 
+	// Breakdown by coupling kind; a class coupled through several kinds
+	// counts under each of them
+	Kinds CBOCouplingKinds
+
 	// Dependency details
 	DependentClasses []string // List of class names this class depends on
 }
 
+// Coupling kinds of the CBO breakdown, as named in KindViolations
+const (
+	CBOKindInheritance   = "inheritance"
+	CBOKindComposition   = "composition"
+	CBOKindInstantiation = "instantiation"
+	CBOKindMethodCalls   = "method_calls"
+	CBOKindTypeHints     = "type_hints"
+)
+
+// CBOKinds lists the coupling kinds in report order, from the hardest to
+// change to the most harmless
+var CBOKinds = []string{
+	CBOKindInheritance,
+	CBOKindComposition,
+	CBOKindInstantiation,
+	CBOKindMethodCalls,
+	CBOKindTypeHints,
+}
+
+// CBOCouplingKinds counts coupled classes per kind of coupling
+type CBOCouplingKinds struct {
+	Inheritance   int // Base classes
+	Composition   int // Attribute types: self.x = X(), x: X in the class body
+	Instantiation int // Constructor calls
+	MethodCalls   int // Method calls and attribute access on other classes
+	TypeHints     int // Parameter, return and variable annotations
+}
+
+// Get returns the count of a kind named in CBOKinds
+func (k CBOCouplingKinds) Get(kind string) int {
+	switch kind {
+	case CBOKindInheritance:
+		return k.Inheritance
+	case CBOKindComposition:
+		return k.Composition
+	case CBOKindInstantiation:
+		return k.Instantiation
+	case CBOKindMethodCalls:
+		return k.MethodCalls
+	case CBOKindTypeHints:
+		return k.TypeHints
+	}
+	return 0
+}
+
 // ClassCoupling represents CBO analysis result for a single class
 type ClassCoupling struct {
 	// Class identification
@@ -71,6 +125,9 @@ type ClassCoupling struct {
 	// Risk assessment
 	RiskLevel RiskLevel
 
+	// Coupling kinds whose count exceeds its threshold in KindThresholds
+	KindViolations []string
+
 	// Additional context
 	IsAbstract  bool
 	BaseClasses []string
@@ -90,6 +147,9 @@ type CBOSummary struct {
 	MediumRiskClasses int
 	HighRiskClasses   int
 
+	// Classes exceeding at least one per-kind threshold
+	KindViolationClasses int
+
 	// CBO distribution
 	CBODistribution map[string]int
 
@@ -177,6 +237,7 @@ func DefaultCBORequest() *CBORequest {
 		IncludeBuiltins:       BoolPtr(false),
 		IncludeImports:        BoolPtr(true),
 		GroupNamespaceImports: BoolPtr(true),
+		IncludeTypeHints:      BoolPtr(true),
 		IncludePatterns:       DefaultAnalysisIncludePatterns(),
 		ExcludePatterns:       []string{},
 	}
@@ -0,0 +1,65 @@
+package domain
+
+// ClassRelationKind is how two classes of a class diagram are related
+type ClassRelationKind string
+
+const (
+	// ClassRelationInheritance points from a class to one of its bases
+	ClassRelationInheritance ClassRelationKind = "inheritance"
+	// ClassRelationComposition points from a class to the class of one of
+	// its attributes
+	ClassRelationComposition ClassRelationKind = "composition"
+)
+
+// DiagramAttribute is an attribute of a class, with its annotation or the
+// class it is constructed from
+type DiagramAttribute struct {
+	Name string `json:"name"`
+	Type string `json:"type,omitempty"`
+}
+
+// DiagramClass is a class of a class diagram
+type DiagramClass struct {
+	// ID is the module and the dotted name of the class, such as
+	// "shop.cart.Cart"
+	ID string `json:"id"`
+
+	// Name is the dotted name within the module; nested classes follow
+	// their enclosing class, as in "Cart.Line"
+	Name      string `json:"name"`
+	Module    string `json:"module"`
+	FilePath  string `json:"file_path"`
+	StartLine int    `json:"start_line"`
+
+	// Bases are the base classes as written, including those outside the
+	// diagram
+	Bases []string `json:"bases,omitempty"`
+
+	// Attributes are the public attributes set in the class body or in
+	// __init__
+	Attributes []DiagramAttribute `json:"attributes,omitempty"`
+	Methods    int                `json:"methods"`
+	IsAbstract bool               `json:"is_abstract,omitempty"`
+
+	// CBO and RiskLevel are set when the diagram is annotated with coupling
+	CBO       *int      `json:"cbo,omitempty"`
+	RiskLevel RiskLevel `json:"risk_level,omitempty"`
+}
+
+// ClassRelation is an edge of a class diagram between two class IDs
+type ClassRelation struct {
+	From string            `json:"from"`
+	To   string            `json:"to"`
+	Kind ClassRelationKind `json:"kind"`
+
+	// Attribute is the attribute of From holding a To, for compositions
+	Attribute string `json:"attribute,omitempty"`
+}
+
+// ClassDiagram is the classes of a set of modules and the relations
+// between them. Classes are ordered by module and position, relations by
+// their source class.
+type ClassDiagram struct {
+	Classes   []DiagramClass  `json:"classes"`
+	Relations []ClassRelation `json:"relations"`
+}
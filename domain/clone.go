@@ -2,8 +2,12 @@ package domain
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -84,7 +88,7 @@ func (c *Clone) String() string {
 
 // ClonePair represents a pair of similar code clones
 type ClonePair struct {
-	ID         int       `json:"id" yaml:"id" csv:"id"`
+	ID         string    `json:"id" yaml:"id" csv:"id"` // Stable content-derived ID, see AssignStableCloneIDs
 	Clone1     *Clone    `json:"clone1" yaml:"clone1" csv:"clone1"`
 	Clone2     *Clone    `json:"clone2" yaml:"clone2" csv:"clone2"`
 	Similarity float64   `json:"similarity" yaml:"similarity" csv:"similarity"`
@@ -104,16 +108,121 @@ func (cp *ClonePair) String() string {
 
 // CloneGroup represents a group of related clones
 type CloneGroup struct {
-	ID         int       `json:"id" yaml:"id" csv:"id"`
+	ID         string    `json:"id" yaml:"id" csv:"id"` // Stable content-derived ID, see AssignStableCloneIDs
 	Clones     []*Clone  `json:"clones" yaml:"clones" csv:"clones"`
 	Type       CloneType `json:"type" yaml:"type" csv:"type"`
 	Similarity float64   `json:"similarity" yaml:"similarity" csv:"similarity"`
-	Size       int       `json:"size" yaml:"size" csv:"size"`
+	Size       int       `json:"size" yaml:"size" csv:"size"` // Members detected, including nested ones
+
+	// Members left after dropping the ones nested in another member of the
+	// same file, and the ID of the member that best stands for the group,
+	// listed first in Clones; see SelectRepresentative
+	UniqueFragmentCount int `json:"unique_fragment_count" yaml:"unique_fragment_count" csv:"unique_fragment_count"`
+	RepresentativeID    int `json:"representative_id" yaml:"representative_id" csv:"representative_id"`
+
+	// Where a helper extracted from the group should live; set for groups
+	// spanning several modules when dependency analysis ran
+	ExtractionTarget *CloneExtractionTarget `json:"extraction_target,omitempty" yaml:"extraction_target,omitempty" csv:"-"`
+
+	// Members left out of Clones by the report options: CollapsedMembers
+	// folded into another member of the same file, OmittedMembers beyond
+	// max_group_members. TotalMembers counts every member when either is set.
+	TotalMembers     int `json:"total_members,omitempty" yaml:"total_members,omitempty" csv:"-"`
+	CollapsedMembers int `json:"collapsed_members,omitempty" yaml:"collapsed_members,omitempty" csv:"-"`
+	OmittedMembers   int `json:"omitted_members,omitempty" yaml:"omitted_members,omitempty" csv:"-"`
+}
+
+// Clone report grouping modes
+const (
+	CloneReportGroupByGroup   = "group"
+	CloneReportGroupByFile    = "file"
+	CloneReportGroupByPackage = "package"
+)
+
+// CloneLocationGroups lists the clone groups with members in one file or
+// package, for reports grouped by location
+type CloneLocationGroups struct {
+	Location       string                `json:"location" yaml:"location"` // File path or package directory
+	Groups         []CloneLocationMember `json:"groups" yaml:"groups"`
+	Fragments      int                   `json:"fragments" yaml:"fragments"`
+	DuplicateLines int                   `json:"duplicate_lines" yaml:"duplicate_lines"`
+}
+
+// CloneLocationMember summarizes one group's members within a location
+type CloneLocationMember struct {
+	GroupID    string    `json:"group_id" yaml:"group_id"`
+	Type       CloneType `json:"type" yaml:"type"`
+	Similarity float64   `json:"similarity" yaml:"similarity"`
+	Fragments  int       `json:"fragments" yaml:"fragments"` // Members of the group in this location
+	GroupSize  int       `json:"group_size" yaml:"group_size"`
+	Lines      []string  `json:"lines" yaml:"lines"` // "start-end" of each member here, prefixed with "file:" by package
+}
+
+// CloneSimilarityMatrix is the share of duplicated lines between each pair
+// of files, or of packages when report_group_by is package. Similarity[i][j]
+// is the number of lines of location i in clones of code in location j plus
+// the converse, over the lines of both; on the diagonal it is the share of
+// lines duplicated within the location. Only locations with clones are
+// listed, most duplicated lines first.
+type CloneSimilarityMatrix struct {
+	GroupBy    string      `json:"group_by" yaml:"group_by"` // file or package
+	Locations  []string    `json:"locations" yaml:"locations"`
+	Lines      []int       `json:"lines" yaml:"lines"` // Source lines of each location
+	Similarity [][]float64 `json:"similarity" yaml:"similarity"`
+
+	// OmittedLocations counts the locations with clones left out beyond
+	// the matrix size limit
+	OmittedLocations int `json:"omitted_locations,omitempty" yaml:"omitted_locations,omitempty"`
+}
+
+// String clone kinds, by the language a string literal embeds
+const (
+	StringCloneKindSQL   = "sql"
+	StringCloneKindHTML  = "html"
+	StringCloneKindShell = "shell"
+	StringCloneKindText  = "text"
+)
+
+// StringCloneGroup is a set of near-duplicate string literals, such as
+// embedded SQL queries, which AST-based clone detection sees only as
+// constants. Similarity is the lowest token similarity between literals
+// linked in the group.
+type StringCloneGroup struct {
+	ID         int            `json:"id" yaml:"id"`
+	Kind       string         `json:"kind" yaml:"kind"` // sql, html, shell or text
+	Similarity float64        `json:"similarity" yaml:"similarity"`
+	Literals   []*StringClone `json:"literals" yaml:"literals"`
+}
+
+// StringClone is a string literal of a string clone group
+type StringClone struct {
+	Location *CloneLocation `json:"location" yaml:"location"`
+	Length   int            `json:"length" yaml:"length"`   // Characters of the literal text
+	Preview  string         `json:"preview" yaml:"preview"` // Start of the text, whitespace collapsed
+}
+
+// CloneExtractionTarget suggests the package for a shared helper replacing
+// the clones of a group: the lowest package containing all their modules
+// where a new module adds no layer violation or import cycle
+type CloneExtractionTarget struct {
+	Package   string   `json:"package" yaml:"package"` // Empty for the top level
+	Layer     string   `json:"layer,omitempty" yaml:"layer,omitempty"`
+	Modules   []string `json:"modules" yaml:"modules"` // Modules containing the clones
+	Rationale string   `json:"rationale" yaml:"rationale"`
+}
+
+// CloneConsolidation summarizes the clone groups that share an extraction
+// target package
+type CloneConsolidation struct {
+	Package        string   `json:"package" yaml:"package"`
+	GroupIDs       []string `json:"group_ids" yaml:"group_ids"`
+	Fragments      int      `json:"fragments" yaml:"fragments"`
+	DuplicateLines int      `json:"duplicate_lines" yaml:"duplicate_lines"` // Lines removed by keeping one copy per group
 }
 
 // String returns string representation of CloneGroup
 func (cg *CloneGroup) String() string {
-	return fmt.Sprintf("CloneGroup{ID: %d, Type: %s, Size: %d, Similarity: %.3f}",
+	return fmt.Sprintf("CloneGroup{ID: %s, Type: %s, Size: %d, Similarity: %.3f}",
 		cg.ID, cg.Type.String(), cg.Size, cg.Similarity)
 }
 
@@ -123,6 +232,162 @@ func (cg *CloneGroup) AddClone(clone *Clone) {
 	cg.Size = len(cg.Clones)
 }
 
+// SelectRepresentative drops the members whose lines lie strictly within
+// another member of the same file, then moves the member covering the most
+// lines (the most AST nodes on a tie, the earliest location after that) to
+// the front as the group's representative. Size keeps the detected count.
+func (cg *CloneGroup) SelectRepresentative() {
+	unique := make([]*Clone, 0, len(cg.Clones))
+	for _, clone := range cg.Clones {
+		if !cloneNestedInAny(clone, cg.Clones) {
+			unique = append(unique, clone)
+		}
+	}
+	cg.Clones = unique
+	cg.UniqueFragmentCount = len(unique)
+	cg.RepresentativeID = 0
+
+	best := -1
+	for i, clone := range unique {
+		if clone != nil && clone.Location != nil && (best < 0 || representsBetter(clone, unique[best])) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return
+	}
+	representative := unique[best]
+	copy(unique[1:best+1], unique[:best])
+	unique[0] = representative
+	cg.RepresentativeID = representative.ID
+}
+
+// cloneNestedInAny reports whether clone's lines lie strictly within those
+// of another member of the same file
+func cloneNestedInAny(clone *Clone, members []*Clone) bool {
+	if clone == nil || clone.Location == nil {
+		return false
+	}
+	loc := clone.Location
+	for _, other := range members {
+		if other == nil || other.Location == nil || other.Location.FilePath != loc.FilePath {
+			continue
+		}
+		outer := other.Location
+		if outer.StartLine <= loc.StartLine && loc.EndLine <= outer.EndLine &&
+			(outer.StartLine != loc.StartLine || outer.EndLine != loc.EndLine) {
+			return true
+		}
+	}
+	return false
+}
+
+func representsBetter(a, b *Clone) bool {
+	if a.Location.LineCount() != b.Location.LineCount() {
+		return a.Location.LineCount() > b.Location.LineCount()
+	}
+	if a.Size != b.Size {
+		return a.Size > b.Size
+	}
+	if a.Location.FilePath != b.Location.FilePath {
+		return a.Location.FilePath < b.Location.FilePath
+	}
+	return a.Location.StartLine < b.Location.StartLine
+}
+
+// Prefixes of stable clone pair and group IDs
+const (
+	ClonePairIDPrefix  = "cp-"
+	CloneGroupIDPrefix = "cg-"
+)
+
+// cloneFingerprint identifies a clone member by its file and normalized
+// content. Line numbers are left out so that edits elsewhere in the file do
+// not change the ID.
+func cloneFingerprint(clone *Clone) string {
+	if clone == nil || clone.Location == nil {
+		return ""
+	}
+	if clone.Hash != "" {
+		return clone.Location.FilePath + "\x00" + clone.Hash
+	}
+	return clone.Location.String()
+}
+
+// StableCloneID derives an ID from the fingerprints of the given members.
+// Member order does not matter, so the ID survives file reordering and
+// changes in detection order.
+func StableCloneID(prefix string, members []*Clone) string {
+	fingerprints := make([]string, 0, len(members))
+	for _, member := range members {
+		fingerprints = append(fingerprints, cloneFingerprint(member))
+	}
+	sort.Strings(fingerprints)
+
+	sum := sha256.Sum256([]byte(strings.Join(fingerprints, "\n")))
+	return prefix + hex.EncodeToString(sum[:6])
+}
+
+// AssignStableCloneIDs sets content-derived IDs on clone pairs and groups.
+// Members with identical content in the same file share a fingerprint; IDs
+// that collide for that reason are disambiguated with a numeric suffix in
+// source order.
+func AssignStableCloneIDs(pairs []*ClonePair, groups []*CloneGroup) {
+	pairIDs := make([]string, len(pairs))
+	pairOrder := make([]string, len(pairs))
+	for i, pair := range pairs {
+		members := []*Clone{pair.Clone1, pair.Clone2}
+		pairIDs[i] = StableCloneID(ClonePairIDPrefix, members)
+		pairOrder[i] = cloneLocationKey(members)
+	}
+	for i, id := range disambiguateCloneIDs(pairIDs, pairOrder) {
+		pairs[i].ID = id
+	}
+
+	groupIDs := make([]string, len(groups))
+	groupOrder := make([]string, len(groups))
+	for i, group := range groups {
+		groupIDs[i] = StableCloneID(CloneGroupIDPrefix, group.Clones)
+		groupOrder[i] = cloneLocationKey(group.Clones)
+	}
+	for i, id := range disambiguateCloneIDs(groupIDs, groupOrder) {
+		groups[i].ID = id
+	}
+}
+
+// cloneLocationKey orders members by position, for breaking ID collisions
+func cloneLocationKey(members []*Clone) string {
+	keys := make([]string, 0, len(members))
+	for _, member := range members {
+		if member != nil && member.Location != nil {
+			keys = append(keys, fmt.Sprintf("%s:%09d:%09d", member.Location.FilePath, member.Location.StartLine, member.Location.StartCol))
+		}
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, "|")
+}
+
+// disambiguateCloneIDs appends -2, -3, ... to repeated IDs, ordered by key
+func disambiguateCloneIDs(ids, keys []string) []string {
+	indexes := make(map[string][]int)
+	for i, id := range ids {
+		indexes[id] = append(indexes[id], i)
+	}
+
+	result := make([]string, len(ids))
+	copy(result, ids)
+	for id, idx := range indexes {
+		if len(idx) < 2 {
+			continue
+		}
+		sort.SliceStable(idx, func(a, b int) bool { return keys[idx[a]] < keys[idx[b]] })
+		for n, i := range idx[1:] {
+			result[i] = fmt.Sprintf("%s-%d", id, n+2)
+		}
+	}
+	return result
+}
+
 // CloneStatistics provides statistics about clone detection results
 type CloneStatistics struct {
 	TotalFragments    int            `json:"total_fragments" yaml:"total_fragments" csv:"total_fragments"` // All extracted fragments (functions, classes, etc.)
@@ -147,12 +412,19 @@ type CloneRequest struct {
 	// Analysis configuration
 	MinLines            int     `json:"min_lines"`
 	MinNodes            int     `json:"min_nodes"`
+	MinTokens           int     `json:"min_tokens"`
 	SimilarityThreshold float64 `json:"similarity_threshold"`
 	MaxEditDistance     float64 `json:"max_edit_distance"`
 	IgnoreLiterals      *bool   `json:"ignore_literals"`
 	IgnoreIdentifiers   *bool   `json:"ignore_identifiers"`
 	SkipDocstrings      *bool   `json:"skip_docstrings"`
 
+	// String clones: near-duplicate string literals of at least
+	// MinStringLength characters, such as embedded SQL (opt-in)
+	StringClones              *bool   `json:"string_clones"`
+	MinStringLength           int     `json:"min_string_length"`
+	StringSimilarityThreshold float64 `json:"string_similarity_threshold"`
+
 	// Type-specific thresholds
 	Type1Threshold float64 `json:"type1_threshold"`
 	Type2Threshold float64 `json:"type2_threshold"`
@@ -172,6 +444,13 @@ type CloneRequest struct {
 	SortBy       SortCriteria `json:"sort_by"`
 	GroupClones  *bool        `json:"group_clones"`
 
+	// Report shaping: cap on the members listed per group (0 = all), fold
+	// members sharing a file into one, and "group", "file" or "package"
+	// layout of the report
+	MaxGroupMembers  int    `json:"max_group_members"`
+	CollapseSameFile *bool  `json:"collapse_same_file"`
+	ReportGroupBy    string `json:"report_group_by"`
+
 	// Grouping options
 	GroupMode      string  `json:"group_mode"`      // connected, star, complete_linkage, k_core
 	GroupThreshold float64 `json:"group_threshold"` // Minimum similarity for group membership
@@ -187,6 +466,7 @@ type CloneRequest struct {
 
 	// Performance configuration
 	Timeout time.Duration `json:"timeout"` // Maximum time for clone analysis (0 = no timeout)
+	Jobs    int           `json:"jobs"`    // Workers comparing fragment pairs (0 = all CPUs)
 
 	// LSH acceleration (opt-in)
 	LSHEnabled             string  `json:"lsh_enabled"`        // "auto", "true", "false"
@@ -205,6 +485,19 @@ type CloneResponse struct {
 	CloneGroups []*CloneGroup    `json:"clone_groups" yaml:"clone_groups" csv:"clone_groups"`
 	Statistics  *CloneStatistics `json:"statistics" yaml:"statistics" csv:"statistics"`
 
+	// Clone groups grouped by suggested extraction target, largest first
+	Consolidation []*CloneConsolidation `json:"consolidation,omitempty" yaml:"consolidation,omitempty" csv:"-"`
+
+	// Clone groups by file or package when report_group_by asks for it,
+	// most duplicated lines first
+	Locations []*CloneLocationGroups `json:"locations,omitempty" yaml:"locations,omitempty" csv:"-"`
+
+	// Share of duplicated lines between files or packages
+	SimilarityMatrix *CloneSimilarityMatrix `json:"similarity_matrix,omitempty" yaml:"similarity_matrix,omitempty" csv:"-"`
+
+	// Groups of near-duplicate string literals, when string_clones is on
+	StringClones []*StringCloneGroup `json:"string_clones,omitempty" yaml:"string_clones,omitempty" csv:"-"`
+
 	// Metadata
 	Request  *CloneRequest `json:"request,omitempty" yaml:"request,omitempty" csv:"-"`
 	Duration int64         `json:"duration_ms" yaml:"duration_ms" csv:"duration_ms"`
@@ -275,6 +568,10 @@ func (req *CloneRequest) Validate() error {
 		return NewValidationError("min_nodes must be >= 1")
 	}
 
+	if req.MinTokens < 0 {
+		return NewValidationError("min_tokens must be >= 0")
+	}
+
 	if req.SimilarityThreshold < 0.0 || req.SimilarityThreshold > 1.0 {
 		return NewValidationError("similarity_threshold must be between 0.0 and 1.0")
 	}
@@ -283,6 +580,14 @@ func (req *CloneRequest) Validate() error {
 		return NewValidationError("max_edit_distance must be >= 0.0")
 	}
 
+	if req.MinStringLength < 0 {
+		return NewValidationError("min_string_length must be >= 0")
+	}
+
+	if req.StringSimilarityThreshold < 0.0 || req.StringSimilarityThreshold > 1.0 {
+		return NewValidationError("string_similarity_threshold must be between 0.0 and 1.0")
+	}
+
 	// Validate type-specific thresholds
 	if req.Type1Threshold < 0.0 || req.Type1Threshold > 1.0 {
 		return NewValidationError("type1_threshold must be between 0.0 and 1.0")
@@ -300,6 +605,16 @@ func (req *CloneRequest) Validate() error {
 		return NewValidationError("type4_threshold must be between 0.0 and 1.0")
 	}
 
+	if req.MaxGroupMembers < 0 {
+		return NewValidationError("max_group_members must be >= 0")
+	}
+
+	switch req.ReportGroupBy {
+	case "", CloneReportGroupByGroup, CloneReportGroupByFile, CloneReportGroupByPackage:
+	default:
+		return NewValidationError("report_group_by must be one of group, file, package")
+	}
+
 	// Validate threshold ordering (Type1 > Type2 > Type3 > Type4)
 	// This ordering is required by the classifyCloneType else-if chain.
 	if req.Type1Threshold <= req.Type2Threshold {
@@ -327,40 +642,57 @@ func (req *CloneRequest) ShouldShowContent() bool {
 	return BoolValue(req.ShowContent, false)
 }
 
+// ShouldDetectStringClones determines if string literals are compared for
+// string clones
+func (req *CloneRequest) ShouldDetectStringClones() bool {
+	return BoolValue(req.StringClones, false)
+}
+
 // ShouldGroupClones determines if clones should be grouped
 func (req *CloneRequest) ShouldGroupClones() bool {
 	return BoolValue(req.GroupClones, true)
 }
 
+// ShouldCollapseSameFile determines if group members sharing a file are folded
+func (req *CloneRequest) ShouldCollapseSameFile() bool {
+	return BoolValue(req.CollapseSameFile, false)
+}
+
 // DefaultCloneRequest returns a default clone request
 func DefaultCloneRequest() *CloneRequest {
 	return &CloneRequest{
-		Paths:               []string{"."},
-		Recursive:           BoolPtr(true),
-		IncludePatterns:     DefaultAnalysisIncludePatterns(),
-		ExcludePatterns:     DefaultAnalysisExcludePatterns(),
-		MinLines:            5,
-		MinNodes:            10,
-		SimilarityThreshold: DefaultCloneSimilarityThreshold,
-		MaxEditDistance:     50.0,
-		IgnoreLiterals:      BoolPtr(false),
-		IgnoreIdentifiers:   BoolPtr(false),
-		SkipDocstrings:      BoolPtr(true),
-		Type1Threshold:      DefaultType1CloneThreshold,
-		Type2Threshold:      DefaultType2CloneThreshold,
-		Type3Threshold:      DefaultType3CloneThreshold,
-		Type4Threshold:      DefaultType4CloneThreshold,
-		OutputFormat:        OutputFormatText,
-		ShowDetails:         BoolPtr(false),
-		ShowContent:         BoolPtr(false),
-		SortBy:              SortBySimilarity,
-		GroupClones:         BoolPtr(true),
-		GroupMode:           "connected",
-		GroupThreshold:      DefaultType4CloneThreshold,
-		KCoreK:              2,
-		MinSimilarity:       0.0,
-		MaxSimilarity:       1.0,
-		CloneTypes:          DefaultEnabledCloneTypes,
+		Paths:                     []string{"."},
+		Recursive:                 BoolPtr(true),
+		IncludePatterns:           DefaultAnalysisIncludePatterns(),
+		ExcludePatterns:           DefaultAnalysisExcludePatterns(),
+		MinLines:                  5,
+		MinNodes:                  10,
+		MinTokens:                 DefaultCloneMinTokens,
+		SimilarityThreshold:       DefaultCloneSimilarityThreshold,
+		MaxEditDistance:           50.0,
+		IgnoreLiterals:            BoolPtr(false),
+		IgnoreIdentifiers:         BoolPtr(false),
+		SkipDocstrings:            BoolPtr(true),
+		StringClones:              BoolPtr(false),
+		MinStringLength:           DefaultCloneMinStringLength,
+		StringSimilarityThreshold: DefaultCloneStringSimilarityThreshold,
+		Type1Threshold:            DefaultType1CloneThreshold,
+		Type2Threshold:            DefaultType2CloneThreshold,
+		Type3Threshold:            DefaultType3CloneThreshold,
+		Type4Threshold:            DefaultType4CloneThreshold,
+		OutputFormat:              OutputFormatText,
+		ShowDetails:               BoolPtr(false),
+		ShowContent:               BoolPtr(false),
+		SortBy:                    SortBySimilarity,
+		GroupClones:               BoolPtr(true),
+		CollapseSameFile:          BoolPtr(false),
+		ReportGroupBy:             CloneReportGroupByGroup,
+		GroupMode:                 "connected",
+		GroupThreshold:            DefaultType4CloneThreshold,
+		KCoreK:                    2,
+		MinSimilarity:             0.0,
+		MaxSimilarity:             1.0,
+		CloneTypes:                DefaultEnabledCloneTypes,
 		// LSH defaults (auto-enable based on fragment count)
 		LSHEnabled:             "auto",
 		LSHAutoThreshold:       500,
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -114,7 +115,7 @@ func TestClonePair_String(t *testing.T) {
 
 func TestCloneGroup_String(t *testing.T) {
 	group := &CloneGroup{
-		ID:         1,
+		ID:         "1",
 		Type:       Type3Clone,
 		Size:       3,
 		Similarity: 0.75,
@@ -126,7 +127,7 @@ func TestCloneGroup_String(t *testing.T) {
 }
 
 func TestCloneGroup_AddClone(t *testing.T) {
-	group := &CloneGroup{ID: 1}
+	group := &CloneGroup{ID: "1"}
 	assert.Equal(t, 0, group.Size, "Initial size should be 0")
 	assert.Empty(t, group.Clones, "Initial clones should be empty")
 
@@ -153,6 +154,91 @@ func TestCloneGroup_AddClone(t *testing.T) {
 	assert.Len(t, group.Clones, 2, "Clones slice should contain two clones")
 }
 
+func TestCloneGroup_SelectRepresentative(t *testing.T) {
+	clone := func(id int, file string, start, end, size int) *Clone {
+		return &Clone{ID: id, Size: size, Location: &CloneLocation{FilePath: file, StartLine: start, EndLine: end}}
+	}
+	group := &CloneGroup{}
+	group.AddClone(clone(1, "a.py", 10, 14, 30))
+	group.AddClone(clone(2, "b.py", 1, 20, 80))
+	group.AddClone(clone(3, "b.py", 5, 12, 40)) // Nested in clone 2
+	group.AddClone(clone(4, "c.py", 3, 22, 90))
+	group.AddClone(clone(5, "a.py", 10, 14, 30)) // Same lines as clone 1, kept
+
+	group.SelectRepresentative()
+
+	assert.Equal(t, 5, group.Size, "Size should keep the detected count")
+	assert.Equal(t, 4, group.UniqueFragmentCount)
+	assert.Equal(t, 4, group.RepresentativeID, "the member covering the most lines should represent the group")
+	ids := make([]int, len(group.Clones))
+	for i, clone := range group.Clones {
+		ids[i] = clone.ID
+	}
+	assert.Equal(t, []int{4, 1, 2, 5}, ids)
+}
+
+func TestCloneGroup_SelectRepresentativeBreaksTies(t *testing.T) {
+	group := &CloneGroup{}
+	group.AddClone(&Clone{ID: 1, Size: 20, Location: &CloneLocation{FilePath: "b.py", StartLine: 1, EndLine: 5}})
+	group.AddClone(&Clone{ID: 2, Size: 25, Location: &CloneLocation{FilePath: "c.py", StartLine: 1, EndLine: 5}})
+	group.AddClone(&Clone{ID: 3, Size: 25, Location: &CloneLocation{FilePath: "a.py", StartLine: 9, EndLine: 13}})
+
+	group.SelectRepresentative()
+
+	assert.Equal(t, 3, group.RepresentativeID, "more nodes, then the earliest file, should win")
+	assert.Equal(t, 3, group.UniqueFragmentCount)
+}
+
+func TestAssignStableCloneIDs(t *testing.T) {
+	clone := func(path string, line int, hash string) *Clone {
+		return &Clone{Hash: hash, Location: &CloneLocation{FilePath: path, StartLine: line, EndLine: line + 5}}
+	}
+	a := clone("a.py", 1, "h1")
+	b := clone("b.py", 10, "h2")
+	c := clone("c.py", 20, "h3")
+
+	pairs := []*ClonePair{{Clone1: a, Clone2: b}, {Clone1: b, Clone2: c}}
+	groups := []*CloneGroup{{Clones: []*Clone{a, b, c}}}
+	AssignStableCloneIDs(pairs, groups)
+
+	assert.True(t, strings.HasPrefix(pairs[0].ID, ClonePairIDPrefix))
+	assert.True(t, strings.HasPrefix(groups[0].ID, CloneGroupIDPrefix))
+	assert.NotEqual(t, pairs[0].ID, pairs[1].ID)
+
+	// Member and detection order do not change the IDs
+	reordered := []*ClonePair{{Clone1: c, Clone2: b}, {Clone1: b, Clone2: a}}
+	reorderedGroups := []*CloneGroup{{Clones: []*Clone{c, a, b}}}
+	AssignStableCloneIDs(reordered, reorderedGroups)
+	assert.Equal(t, pairs[0].ID, reordered[1].ID)
+	assert.Equal(t, pairs[1].ID, reordered[0].ID)
+	assert.Equal(t, groups[0].ID, reorderedGroups[0].ID)
+
+	// Moving a clone within its file keeps the ID
+	moved := []*ClonePair{{Clone1: clone("a.py", 40, "h1"), Clone2: b}}
+	AssignStableCloneIDs(moved, nil)
+	assert.Equal(t, pairs[0].ID, moved[0].ID)
+}
+
+func TestAssignStableCloneIDs_IdenticalMembers(t *testing.T) {
+	// Three identical functions in one file share a fingerprint, so their
+	// pairs collide and are numbered in source order
+	clone := func(line int) *Clone {
+		return &Clone{Hash: "same", Location: &CloneLocation{FilePath: "dup.py", StartLine: line, EndLine: line + 3}}
+	}
+	first, second, third := clone(1), clone(10), clone(20)
+	pairs := []*ClonePair{
+		{Clone1: second, Clone2: third},
+		{Clone1: first, Clone2: second},
+		{Clone1: first, Clone2: third},
+	}
+	AssignStableCloneIDs(pairs, nil)
+
+	base := pairs[1].ID
+	assert.False(t, strings.Contains(strings.TrimPrefix(base, ClonePairIDPrefix), "-"))
+	assert.Equal(t, base+"-2", pairs[2].ID)
+	assert.Equal(t, base+"-3", pairs[0].ID)
+}
+
 func TestCloneRequest_Validate(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -202,6 +288,28 @@ func TestCloneRequest_Validate(t *testing.T) {
 			expectErr: true,
 			errMsg:    "min_nodes must be >= 1",
 		},
+		{
+			name: "invalid min tokens",
+			request: &CloneRequest{
+				Paths:     []string{"/test"},
+				MinLines:  5,
+				MinNodes:  10,
+				MinTokens: -1,
+			},
+			expectErr: true,
+			errMsg:    "min_tokens must be >= 0",
+		},
+		{
+			name: "invalid min string length",
+			request: &CloneRequest{
+				Paths:           []string{"/test"},
+				MinLines:        5,
+				MinNodes:        10,
+				MinStringLength: -1,
+			},
+			expectErr: true,
+			errMsg:    "min_string_length must be >= 0",
+		},
 		{
 			name: "invalid similarity threshold - too low",
 			request: &CloneRequest{
@@ -448,7 +556,7 @@ func TestCloneDetectionWorkflow(t *testing.T) {
 	}
 
 	clonePair := &ClonePair{
-		ID:         1,
+		ID:         "1",
 		Clone1:     clone1,
 		Clone2:     clone2,
 		Similarity: 0.92,
@@ -458,7 +566,7 @@ func TestCloneDetectionWorkflow(t *testing.T) {
 	}
 
 	cloneGroup := &CloneGroup{
-		ID:         1,
+		ID:         "1",
 		Type:       Type1Clone,
 		Similarity: 0.92,
 		Size:       2,
@@ -2,7 +2,9 @@ package domain
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"strings"
 )
 
 // OutputFormat represents the supported output formats
@@ -15,6 +17,10 @@ const (
 	OutputFormatCSV  OutputFormat = "csv"
 	OutputFormatHTML OutputFormat = "html"
 	OutputFormatDOT  OutputFormat = "dot"
+
+	// OutputFormatGitHubCheck is the output of a GitHub check run, see
+	// GitHubCheckRun
+	OutputFormatGitHubCheck OutputFormat = "github-check"
 )
 
 // SortCriteria represents the criteria for sorting results
@@ -75,6 +81,16 @@ type ComplexityRequest struct {
 	Enabled         *bool
 	ReportUnchanged *bool
 
+	// BoilerplatePatterns tag the functions they match as boilerplate
+	BoilerplatePatterns []BoilerplatePattern
+
+	// Weights of comprehension clauses, generator expression clauses and
+	// boolean operator sequences in cyclomatic complexity: 0, 0.5 or 1.
+	// nil counts them as the control flow graph does.
+	ComprehensionWeight   *float64
+	GeneratorWeight       *float64
+	BooleanOperatorWeight *float64
+
 	// Configuration
 	ConfigPath string
 
@@ -84,6 +100,17 @@ type ComplexityRequest struct {
 	ExcludePatterns []string
 }
 
+// BoilerplatePattern is a structural pattern of boilerplate code, such as
+// argparse setup or generated serializers. A function is tagged with the
+// first pattern matching its definition or a node in its body outside
+// nested functions. Tagged functions are measured as usual; with
+// ExcludeFromRisk they are rated low risk and raise no threshold warnings.
+type BoilerplatePattern struct {
+	Name            string           `json:"name" yaml:"name"`
+	Match           PatternRuleMatch `json:"match" yaml:"match"`
+	ExcludeFromRisk bool             `json:"exclude_from_risk,omitempty" yaml:"exclude_from_risk,omitempty"`
+}
+
 // ComplexityMetrics represents detailed complexity metrics for a function
 type ComplexityMetrics struct {
 	// McCabe cyclomatic complexity
@@ -104,6 +131,53 @@ type ComplexityMetrics struct {
 	LoopStatements    int
 	ExceptionHandlers int
 	SwitchCases       int
+
+	// Expression counts: boolean operator sequences (a and b and c counts
+	// once) and list, set, dict and generator comprehensions
+	BooleanOperators int
+	Comprehensions   int
+
+	// Async metrics, set for coroutines only
+	IsAsync         bool
+	AwaitPoints     int     // Suspension points: await, async for, async with
+	AsyncFanOut     int     // Awaitables run concurrently via gather/wait/create_task
+	AsyncComplexity int     // AwaitPoints + AsyncFanOut
+	AwaitDensity    float64 // Suspension points per line of the coroutine body
+
+	// Recursion metrics, set for functions on a call cycle
+	IsRecursive        bool
+	RecursionKind      string // "direct" or "mutual"
+	RecursionDepth     int    // Length of the shortest call cycle through the function (1 = direct)
+	RecursiveCalls     int    // Call sites that re-enter the cycle
+	UnboundedRecursion bool   // Every path to exit passes through a recursive call
+}
+
+// Breakdown lists the constructs adding to the complexity, e.g.
+// "3 if/elif, 2 loops, 1 bool op"; "-" when there are none
+func (m ComplexityMetrics) Breakdown() string {
+	var parts []string
+	for _, count := range []struct {
+		n                int
+		singular, plural string
+	}{
+		{m.IfStatements, "if/elif", "if/elif"},
+		{m.LoopStatements, "loop", "loops"},
+		{m.ExceptionHandlers, "except", "excepts"},
+		{m.SwitchCases, "case", "cases"},
+		{m.BooleanOperators, "bool op", "bool ops"},
+		{m.Comprehensions, "comprehension", "comprehensions"},
+	} {
+		switch {
+		case count.n == 1:
+			parts = append(parts, "1 "+count.singular)
+		case count.n > 1:
+			parts = append(parts, fmt.Sprintf("%d %s", count.n, count.plural))
+		}
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, ", ")
 }
 
 // FunctionComplexity represents complexity analysis result for a single function
@@ -120,6 +194,26 @@ type FunctionComplexity struct {
 
 	// Risk assessment
 	RiskLevel RiskLevel
+
+	// ComplexityBudget is the max-complexity allowed by a "# pyscn:
+	// max-complexity=N" directive on the function or an enclosing class; 0
+	// when none applies. Within budget the function is rated low risk.
+	ComplexityBudget int  `json:"complexity_budget,omitempty" yaml:"complexity_budget,omitempty"`
+	OverBudget       bool `json:"over_budget,omitempty" yaml:"over_budget,omitempty"`
+
+	// Boilerplate is the name of the boilerplate pattern the function
+	// matches; ExcludedFromRisk is set when the pattern excludes it from
+	// risk rating
+	Boilerplate      string `json:"boilerplate,omitempty" yaml:"boilerplate,omitempty"`
+	ExcludedFromRisk bool   `json:"excluded_from_risk,omitempty" yaml:"excluded_from_risk,omitempty"`
+
+	// Git history of the function body (populated when blame enrichment is enabled)
+	Blame *BlameInfo `json:"blame,omitempty" yaml:"blame,omitempty"`
+
+	// History is the function's complexity in the recorded runs, oldest
+	// first and ending with this one (populated when history is recorded
+	// and the function appeared in an earlier run)
+	History []int `json:"history,omitempty" yaml:"history,omitempty"`
 }
 
 // RawMetrics represents file-level raw code metrics.
@@ -165,6 +259,14 @@ type ComplexitySummary struct {
 	MediumRiskFunctions int
 	HighRiskFunctions   int
 
+	// Functions carrying a complexity budget, and those exceeding it
+	BudgetedFunctions   int
+	OverBudgetFunctions int
+
+	// Functions tagged as boilerplate, and those excluded from risk rating
+	BoilerplateFunctions      int
+	ExcludedFromRiskFunctions int
+
 	// Complexity distribution
 	ComplexityDistribution map[string]int
 }
@@ -214,6 +316,26 @@ type FileReader interface {
 	FileExists(path string) (bool, error)
 }
 
+// FileWalkOptions controls how directories are walked for Python files
+type FileWalkOptions struct {
+	// MaxDepth is how many directory levels below a target are walked;
+	// 0 means no limit
+	MaxDepth int
+
+	// FollowSymlinks walks symlinked directories too. Every directory is
+	// walked at most once, so symlink cycles end the walk.
+	FollowSymlinks bool
+}
+
+// WalkConfigurableFileReader is a FileReader whose directory walk can be
+// configured
+type WalkConfigurableFileReader interface {
+	FileReader
+
+	// WithWalkOptions returns a reader that walks directories with options
+	WithWalkOptions(options FileWalkOptions) FileReader
+}
+
 // OutputFormatter defines the interface for formatting analysis results
 type OutputFormatter interface {
 	// Format formats the analysis response according to the specified format
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// ComplexityHistoryFileName is the file, in the report directory, that keeps
+// the per-function complexity of recent analyze runs
+const ComplexityHistoryFileName = "complexity-history.json"
+
+// ComplexityHistory records the cyclomatic complexity of every reported
+// function over the last analyze runs, oldest first, so reports can show
+// whether a function is getting worse.
+type ComplexityHistory struct {
+	Version int                    `json:"version"`
+	Runs    []ComplexityHistoryRun `json:"runs"`
+}
+
+// ComplexityHistoryRun is the complexity of each function in one run, keyed
+// by FunctionFingerprint
+type ComplexityHistoryRun struct {
+	RecordedAt time.Time      `json:"recorded_at"`
+	Functions  map[string]int `json:"functions"`
+}
+
+// FunctionFingerprint identifies a function across runs by its file, relative
+// to the project root, and its name. Line numbers are left out so that
+// edits above a function do not break its history.
+func FunctionFingerprint(relPath, name string) string {
+	return relPath + "::" + name
+}
@@ -114,6 +114,14 @@ func TestComplexityMetrics(t *testing.T) {
 	if metrics.Edges != 12 {
 		t.Errorf("Expected edges 12, got %d", metrics.Edges)
 	}
+
+	metrics.BooleanOperators = 3
+	if got, want := metrics.Breakdown(), "2 if/elif, 1 loop, 1 except, 3 bool ops"; got != want {
+		t.Errorf("Breakdown() = %q, want %q", got, want)
+	}
+	if got := (ComplexityMetrics{Complexity: 1}).Breakdown(); got != "-" {
+		t.Errorf("Breakdown() of straight-line code = %q, want \"-\"", got)
+	}
 }
 
 func TestFunctionComplexity(t *testing.T) {
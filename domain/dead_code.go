@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"fmt"
 	"io"
 )
 
@@ -56,6 +57,17 @@ type DeadCodeRequest struct {
 	DetectAfterContinue       *bool // nil = use default (true), non-nil = explicitly set
 	DetectAfterRaise          *bool // nil = use default (true), non-nil = explicitly set
 	DetectUnreachableBranches *bool // nil = use default (true), non-nil = explicitly set
+
+	// Reasons limits findings to these reasons; empty = all reasons
+	Reasons []string
+
+	// ReasonSeverities replaces the default severity of findings by reason;
+	// reasons left out keep theirs
+	ReasonSeverities map[string]DeadCodeSeverity
+
+	// TerminatingCalls are qualified names of calls that never return, in
+	// addition to the built-in ones; code after them is unreachable
+	TerminatingCalls []string
 }
 
 // DeadCodeLocation represents the location of dead code
@@ -81,11 +93,24 @@ type DeadCodeFinding struct {
 	Severity    DeadCodeSeverity `json:"severity"`
 	Description string           `json:"description"`
 
-	// Context information (surrounding code)
-	Context []string `json:"context,omitempty"`
+	// Context holds the source around the finding; set when ShowContext is
+	Context *DeadCodeContext `json:"context,omitempty" yaml:"context,omitempty"`
 
 	// Metadata
 	BlockID string `json:"block_id,omitempty"`
+
+	// Git history of the dead lines (populated when blame enrichment is enabled)
+	Blame *BlameInfo `json:"blame,omitempty" yaml:"blame,omitempty"`
+}
+
+// DeadCodeContext holds the source lines of a finding and the ContextLines
+// lines before and after it, so that tools can render the finding without
+// reading the file
+type DeadCodeContext struct {
+	StartLine int      `json:"start_line" yaml:"start_line"` // Line number of the first line of Before, or of Lines when Before is empty
+	Before    []string `json:"before" yaml:"before"`
+	Lines     []string `json:"lines" yaml:"lines"`
+	After     []string `json:"after" yaml:"after"`
 }
 
 // FunctionDeadCode represents dead code analysis result for a single function
@@ -146,6 +171,16 @@ type DeadCodeSummary struct {
 	OverallDeadRatio float64 `json:"overall_dead_ratio"`
 }
 
+// DeadCodeReasonInfo describes a dead code reason and the severity its
+// findings are reported with
+type DeadCodeReasonInfo struct {
+	Reason          string           `json:"reason"`
+	Category        string           `json:"category"`
+	Description     string           `json:"description"`
+	Severity        DeadCodeSeverity `json:"severity"`
+	DefaultSeverity DeadCodeSeverity `json:"default_severity"`
+}
+
 // DeadCodeResponse represents the complete dead code analysis result
 type DeadCodeResponse struct {
 	// Analysis results
@@ -161,6 +196,9 @@ type DeadCodeResponse struct {
 	Version     string           `json:"version"`
 	Config      interface{}      `json:"config"`            // Configuration used for analysis
 	Request     *DeadCodeRequest `json:"request,omitempty"` // Merged configuration request
+
+	// Reasons is the reason taxonomy with the severities used for this report
+	Reasons []DeadCodeReasonInfo `json:"reasons"`
 }
 
 // DeadCodeService defines the core business logic for dead code analysis
@@ -279,6 +317,10 @@ func (req *DeadCodeRequest) Validate() error {
 		return NewInvalidInputError("invalid minimum severity level", nil)
 	}
 
+	if err := ValidateDeadCodeReasonSeverities(req.ReasonSeverities); err != nil {
+		return NewInvalidInputError(err.Error(), nil)
+	}
+
 	// Validate sort criteria
 	validSortBy := map[DeadCodeSortCriteria]bool{
 		DeadCodeSortBySeverity: true,
@@ -294,6 +336,20 @@ func (req *DeadCodeRequest) Validate() error {
 	return nil
 }
 
+// ValidateDeadCodeReasonSeverities checks that a severity mapping only names
+// known reasons and severities
+func ValidateDeadCodeReasonSeverities(severities map[string]DeadCodeSeverity) error {
+	for reason, severity := range severities {
+		if DeadCodeReasonCategory(reason) == "" {
+			return fmt.Errorf("unknown dead code reason %q in severity mapping", reason)
+		}
+		if severity.Level() == 0 {
+			return fmt.Errorf("invalid severity %q for dead code reason %q, must be one of: critical, warning, info", severity, reason)
+		}
+	}
+	return nil
+}
+
 // Helper methods for severity comparison
 
 // SeverityLevel returns the numeric level for comparison
@@ -154,6 +154,22 @@ const (
 
 	// DefaultArchitectureMaxResponsibilities is the maximum inferred responsibilities per module.
 	DefaultArchitectureMaxResponsibilities = 3
+
+	// DefaultGodModuleMaxLines is the line count a god module exceeds.
+	DefaultGodModuleMaxLines = 500
+
+	// DefaultGodModuleMaxDefinitions is the number of classes plus functions a god module exceeds.
+	DefaultGodModuleMaxDefinitions = 30
+
+	// DefaultGodModuleMaxFanIn is the number of importing modules a god module exceeds.
+	DefaultGodModuleMaxFanIn = 5
+
+	// DefaultGodModuleMaxFanOut is the number of imported modules a god module exceeds.
+	DefaultGodModuleMaxFanOut = 10
+
+	// DefaultFacadeMinExports is the number of re-exported names that makes
+	// a package __init__ holding nothing but re-exports a facade.
+	DefaultFacadeMinExports = 10
 )
 
 // ============================================================================
@@ -196,6 +212,9 @@ const (
 	// DefaultDeadCodeContextLines is the number of context lines shown around dead code.
 	DefaultDeadCodeContextLines = 3
 
+	// MaxDeadCodeContextLines is the largest number of context lines allowed.
+	MaxDeadCodeContextLines = 20
+
 	// DefaultDeadCodeSortBy is the default sort order for dead code results.
 	// Options: "severity", "file", "line"
 	DefaultDeadCodeSortBy = "severity"
@@ -212,6 +231,15 @@ const (
 	// DefaultCloneMinNodes is the minimum number of AST nodes for a code fragment.
 	DefaultCloneMinNodes = 20
 
+	// DefaultCloneMinTokens is the minimum number of tokens (AST leaves) for a code fragment; 0 disables the check.
+	DefaultCloneMinTokens = 0
+
+	// DefaultCloneMinStringLength is the minimum length, in characters, of a string literal compared for string clones.
+	DefaultCloneMinStringLength = 80
+
+	// DefaultCloneStringSimilarityThreshold is the minimum token similarity of near-duplicate string literals.
+	DefaultCloneStringSimilarityThreshold = 0.8
+
 	// DefaultCloneMaxEditDistance is the maximum tree edit distance for clone comparison.
 	DefaultCloneMaxEditDistance = 50.0
 
@@ -337,6 +365,30 @@ func DefaultMockDataDomains() []string {
 	}
 }
 
+// ============================================================================
+// Security Pattern Detection Defaults
+// ============================================================================
+
+const (
+	// DefaultSecurityMinSeverity is the minimum severity level for security reports.
+	// "info" additionally reports sinks fed by dynamic input of unknown origin.
+	// Options: "info", "warning", "error"
+	DefaultSecurityMinSeverity = "warning"
+
+	// DefaultSecurityIgnoreTests determines whether test files are ignored by default.
+	DefaultSecurityIgnoreTests = true
+)
+
+// ============================================================================
+// Documentation Coverage Defaults
+// ============================================================================
+
+const (
+	// DefaultDocumentationConvention selects which definitions need a docstring.
+	// Options: "public" (public API only), "all" (every module, class and function)
+	DefaultDocumentationConvention = "public"
+)
+
 // DefaultAnalysisIncludePatterns returns the canonical runtime source-file
 // globs used by implementation analyses.
 func DefaultAnalysisIncludePatterns() []string {
@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+)
+
+// DocumentationConvention selects which definitions are expected to carry a docstring
+type DocumentationConvention string
+
+const (
+	// DocumentationConventionPublic only counts public modules, classes and
+	// functions: names without a leading underscore, excluding dunder methods
+	// and functions nested inside other functions
+	DocumentationConventionPublic DocumentationConvention = "public"
+	// DocumentationConventionAll counts every module, class and function
+	DocumentationConventionAll DocumentationConvention = "all"
+)
+
+// DocumentationItemKind identifies what kind of definition a docstring belongs to
+type DocumentationItemKind string
+
+const (
+	DocumentationItemModule   DocumentationItemKind = "module"
+	DocumentationItemClass    DocumentationItemKind = "class"
+	DocumentationItemFunction DocumentationItemKind = "function"
+	DocumentationItemMethod   DocumentationItemKind = "method"
+)
+
+// DocumentationFinding represents a definition that is missing a docstring
+type DocumentationFinding struct {
+	// Kind of the undocumented definition
+	Kind DocumentationItemKind `json:"kind" yaml:"kind"`
+
+	// Name is the qualified name, e.g. "Parser.parse"; the module name for modules
+	Name string `json:"name" yaml:"name"`
+
+	// Location of the definition
+	Location SourceLocation `json:"location" yaml:"location"`
+
+	// Human-readable description of the issue
+	Description string `json:"description" yaml:"description"`
+}
+
+// DocumentationKindStats holds coverage counts for one kind of definition
+type DocumentationKindStats struct {
+	Total      int `json:"total" yaml:"total"`
+	Documented int `json:"documented" yaml:"documented"`
+}
+
+// DocumentationFileResult holds the per-file outcome of documentation analysis
+type DocumentationFileResult struct {
+	// Findings for definitions in this file that lack a docstring
+	Findings []DocumentationFinding
+
+	// ByKind counts the definitions in this file that were considered
+	ByKind map[DocumentationItemKind]DocumentationKindStats
+}
+
+// DocumentationRequest represents a request for docstring coverage analysis
+type DocumentationRequest struct {
+	// Input files or directories to analyze
+	Paths []string
+
+	// Analysis options
+	Recursive       *bool
+	IncludePatterns []string
+	ExcludePatterns []string
+
+	// Configuration
+	ConfigPath string
+
+	// Convention selects which definitions are expected to be documented
+	Convention DocumentationConvention
+}
+
+// DocumentationSummary represents aggregate docstring coverage
+type DocumentationSummary struct {
+	// TotalItems is the number of definitions considered
+	TotalItems int `json:"total_items" yaml:"total_items"`
+
+	// DocumentedItems is the number of those definitions with a docstring
+	DocumentedItems int `json:"documented_items" yaml:"documented_items"`
+
+	// Coverage is the documented percentage (0-100); 100 when there is nothing to document
+	Coverage float64 `json:"coverage" yaml:"coverage"`
+
+	// ByKind breaks down coverage by kind of definition
+	ByKind map[DocumentationItemKind]DocumentationKindStats `json:"by_kind" yaml:"by_kind"`
+
+	// FilesAnalyzed is the number of files analyzed
+	FilesAnalyzed int `json:"files_analyzed" yaml:"files_analyzed"`
+}
+
+// DocumentationResponse represents the complete docstring coverage result
+type DocumentationResponse struct {
+	// Convention the coverage was measured against
+	Convention DocumentationConvention `json:"convention" yaml:"convention"`
+
+	// Findings lists the definitions missing a docstring
+	Findings []DocumentationFinding `json:"findings" yaml:"findings"`
+
+	// Summary contains aggregate statistics
+	Summary DocumentationSummary `json:"summary" yaml:"summary"`
+
+	// Errors contains errors encountered during analysis
+	Errors []string `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+// DocumentationService defines the interface for docstring coverage analysis
+type DocumentationService interface {
+	// Analyze measures docstring coverage for the files in the request
+	Analyze(ctx context.Context, req DocumentationRequest) (*DocumentationResponse, error)
+}
+
+// DocumentationConfigurationLoader defines the interface for loading documentation configuration
+type DocumentationConfigurationLoader interface {
+	// LoadConfig loads configuration from the specified path
+	LoadConfig(path string) (*DocumentationRequest, error)
+
+	// LoadDefaultConfig loads the default configuration
+	LoadDefaultConfig() *DocumentationRequest
+
+	// MergeConfig merges request values with configuration file values
+	MergeConfig(base *DocumentationRequest, override *DocumentationRequest) *DocumentationRequest
+}
+
+// DefaultDocumentationRequest returns a DocumentationRequest with default values
+func DefaultDocumentationRequest() *DocumentationRequest {
+	return &DocumentationRequest{
+		Recursive:       BoolPtr(true),
+		IncludePatterns: DefaultAnalysisIncludePatterns(),
+		ExcludePatterns: []string{},
+		Convention:      DocumentationConvention(DefaultDocumentationConvention),
+	}
+}
+
+// IsValid reports whether the convention is one of the supported values
+func (c DocumentationConvention) IsValid() bool {
+	return c == DocumentationConventionPublic || c == DocumentationConventionAll
+}
+
+// Validate validates the request parameters
+func (r *DocumentationRequest) Validate() error {
+	if len(r.Paths) == 0 {
+		return NewInvalidInputError("at least one path must be specified", nil)
+	}
+	if r.Convention != "" && !r.Convention.IsValid() {
+		return NewInvalidInputError(fmt.Sprintf("invalid documentation convention %q (expected public or all)", r.Convention), nil)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package domain
+
+// FindingCategory identifies the analysis that produced a finding
+type FindingCategory string
+
+const (
+	FindingCategoryComplexity   FindingCategory = "complexity"
+	FindingCategoryDeadCode     FindingCategory = "dead_code"
+	FindingCategoryClone        FindingCategory = "clone"
+	FindingCategoryCBO          FindingCategory = "cbo"
+	FindingCategoryLCOM         FindingCategory = "lcom"
+	FindingCategoryCycle        FindingCategory = "cycle"
+	FindingCategoryArchitecture FindingCategory = "architecture"
+	FindingCategoryMockData     FindingCategory = "mock_data"
+)
+
+// FindingSeverity is the severity of a finding on a scale shared by all
+// analyses, so that findings of different kinds can be sorted and filtered
+// together
+type FindingSeverity string
+
+const (
+	FindingSeverityHigh   FindingSeverity = "high"
+	FindingSeverityMedium FindingSeverity = "medium"
+	FindingSeverityLow    FindingSeverity = "low"
+)
+
+// Rank orders severities from most (3) to least (1) severe
+func (s FindingSeverity) Rank() int {
+	switch s {
+	case FindingSeverityHigh:
+		return 3
+	case FindingSeverityMedium:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// NormalizeFindingSeverity maps the severity or risk level of any analysis
+// onto the shared scale
+func NormalizeFindingSeverity(severity string) FindingSeverity {
+	switch severity {
+	case "critical", "error", "high":
+		return FindingSeverityHigh
+	case "warning", "medium":
+		return FindingSeverityMedium
+	default:
+		return FindingSeverityLow
+	}
+}
+
+// Finding is a single actionable result of an analysis, flattened from the
+// analysis-specific response types. FilePath is empty for project-level
+// findings such as import cycles.
+type Finding struct {
+	Category  FindingCategory `json:"category"`
+	Severity  FindingSeverity `json:"severity"`
+	FilePath  string          `json:"file_path,omitempty"`
+	StartLine int             `json:"start_line,omitempty"`
+	EndLine   int             `json:"end_line,omitempty"`
+	Name      string          `json:"name"`    // Function, class, module or clone pair the finding is about
+	Message   string          `json:"message"` // One-line summary
+	Detail    string          `json:"detail,omitempty"`
+}
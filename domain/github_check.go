@@ -0,0 +1,45 @@
+package domain
+
+// MaxGitHubCheckAnnotations is the number of annotations the GitHub Checks
+// API accepts in one request
+const MaxGitHubCheckAnnotations = 50
+
+// Conclusions and annotation levels of the GitHub Checks API
+const (
+	GitHubCheckConclusionSuccess = "success"
+	GitHubCheckConclusionFailure = "failure"
+	GitHubCheckConclusionNeutral = "neutral"
+
+	GitHubAnnotationFailure = "failure"
+	GitHubAnnotationWarning = "warning"
+	GitHubAnnotationNotice  = "notice"
+)
+
+// GitHubCheckRun is the body of a request creating or updating a GitHub
+// check run, without head_sha, which the CI step posting it adds. See
+// https://docs.github.com/en/rest/checks/runs
+type GitHubCheckRun struct {
+	Name       string            `json:"name"`
+	Status     string            `json:"status"`
+	Conclusion string            `json:"conclusion"`
+	Output     GitHubCheckOutput `json:"output"`
+}
+
+// GitHubCheckOutput is the output shown on the check run page
+type GitHubCheckOutput struct {
+	Title       string                  `json:"title"`
+	Summary     string                  `json:"summary"` // Markdown
+	Annotations []GitHubCheckAnnotation `json:"annotations"`
+}
+
+// GitHubCheckAnnotation marks a finding on a line of the pull request diff.
+// Path is relative to the repository root.
+type GitHubCheckAnnotation struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	AnnotationLevel string `json:"annotation_level"`
+	Title           string `json:"title,omitempty"`
+	Message         string `json:"message"`
+	RawDetails      string `json:"raw_details,omitempty"`
+}
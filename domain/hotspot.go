@@ -0,0 +1,53 @@
+package domain
+
+import "time"
+
+// DefaultChurnWindowDays is the git history window used for hotspots when
+// none is configured
+const DefaultChurnWindowDays = 180
+
+// ChurnProvider counts how often files changed in git history.
+// Implementations return (nil, nil) when the files are not in a git work tree.
+type ChurnProvider interface {
+	// CommitCounts returns the number of commits since the given time that
+	// touched each file, keyed by the file paths as passed in. Files without
+	// commits in the window are omitted.
+	CommitCounts(filePaths []string, since time.Time) (map[string]int, error)
+}
+
+// HotspotReport ranks files by complexity weighted by change frequency, so
+// refactoring effort goes to complex code that is also changed often.
+type HotspotReport struct {
+	// WindowDays is the length of the git history window
+	WindowDays int `json:"window_days" yaml:"window_days"`
+
+	// Since is the start of the history window
+	Since time.Time `json:"since" yaml:"since"`
+
+	// Files lists files with both complexity and churn, highest score first
+	Files []FileHotspot `json:"files" yaml:"files"`
+}
+
+// FileHotspot combines the complexity and churn of a single file.
+type FileHotspot struct {
+	FilePath string `json:"file_path" yaml:"file_path"`
+
+	// Commits is the number of commits in the window that touched the file
+	Commits int `json:"commits" yaml:"commits"`
+
+	// Functions is the number of analyzed functions in the file
+	Functions int `json:"functions" yaml:"functions"`
+
+	// TotalComplexity is the sum of cyclomatic complexity over the functions
+	TotalComplexity int `json:"total_complexity" yaml:"total_complexity"`
+
+	// MaxComplexity is the complexity of MostComplexFunction
+	MaxComplexity       int    `json:"max_complexity" yaml:"max_complexity"`
+	MostComplexFunction string `json:"most_complex_function" yaml:"most_complex_function"`
+
+	// Score is TotalComplexity × Commits
+	Score int `json:"score" yaml:"score"`
+
+	// RelativeScore is Score divided by the highest score in the report (0..1]
+	RelativeScore float64 `json:"relative_score" yaml:"relative_score"`
+}
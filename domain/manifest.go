@@ -0,0 +1,142 @@
+package domain
+
+// AnalyzerVersions are the result versions of the individual analyzers. A
+// version is bumped whenever an analyzer can report different results for
+// the same input, so two reports are only comparable when the versions of
+// the analyzers they ran match.
+var AnalyzerVersions = map[string]string{
+	"complexity":    "1",
+	"dead_code":     "1",
+	"clones":        "1",
+	"cbo":           "1",
+	"lcom":          "1",
+	"dependencies":  "1",
+	"architecture":  "1",
+	"communities":   "1",
+	"mock_data":     "1",
+	"documentation": "1",
+	"typing":        "1",
+}
+
+// AnalysisManifest records what produced a report: the tool, its inputs and
+// the environment, so results can be reproduced and audited
+type AnalysisManifest struct {
+	PyscnVersion string `json:"pyscn_version" yaml:"pyscn_version"`
+	GoVersion    string `json:"go_version" yaml:"go_version"`
+	Platform     string `json:"platform" yaml:"platform"` // GOOS/GOARCH
+
+	// Configuration file used, with the SHA-256 of its contents; both are
+	// empty when the defaults were used
+	ConfigFile string `json:"config_file,omitempty" yaml:"config_file,omitempty"`
+	ConfigHash string `json:"config_hash,omitempty" yaml:"config_hash,omitempty"`
+
+	// Flags holds the command-line flags that were set explicitly
+	Flags map[string]string `json:"flags,omitempty" yaml:"flags,omitempty"`
+
+	Targets   []string `json:"targets" yaml:"targets"`
+	FileCount int      `json:"file_count" yaml:"file_count"`
+
+	// Root is the project root, with forward slashes. Relative file paths
+	// in the report are relative to it.
+	Root string `json:"root,omitempty" yaml:"root,omitempty"`
+
+	// Git describes the analyzed tree; nil outside a git work tree
+	Git *GitProvenance `json:"git,omitempty" yaml:"git,omitempty"`
+
+	// Analyzers maps each analyzer that ran to its AnalyzerVersions entry
+	Analyzers map[string]string `json:"analyzers" yaml:"analyzers"`
+
+	// Thresholds are the effective risk thresholds, so consumers can
+	// re-derive risk levels and tell when two runs were configured apart
+	Thresholds *RiskThresholds `json:"thresholds,omitempty" yaml:"thresholds,omitempty"`
+
+	// Timings is what each phase of the run cost, the shared parsing first
+	// and then the analyzers in the order they were started
+	Timings []AnalyzerTiming `json:"timings,omitempty" yaml:"timings,omitempty"`
+}
+
+// AnalyzerTiming is the cost of one phase of a run: the shared parsing of
+// the project ("parse") or one analyzer
+type AnalyzerTiming struct {
+	Name           string `json:"name" yaml:"name"`
+	WallTimeMs     int64  `json:"wall_time_ms" yaml:"wall_time_ms"`
+	FilesProcessed int    `json:"files_processed" yaml:"files_processed"`
+	// BytesParsed is the source the phase parsed itself. Analyzers working
+	// on the shared parse report 0, and files served from the parse cache
+	// are not counted.
+	BytesParsed int64 `json:"bytes_parsed" yaml:"bytes_parsed"`
+	// PeakGoroutines is the most goroutines the process ran while the phase
+	// ran, including those of the phases running alongside it
+	PeakGoroutines int `json:"peak_goroutines" yaml:"peak_goroutines"`
+}
+
+// RiskThresholds holds the thresholds results were bucketed with. Sections
+// of analyzers that did not run are nil.
+type RiskThresholds struct {
+	Complexity *ComplexityThresholds `json:"complexity,omitempty" yaml:"complexity,omitempty"`
+	CBO        *LevelThresholds      `json:"cbo,omitempty" yaml:"cbo,omitempty"`
+	LCOM       *LevelThresholds      `json:"lcom,omitempty" yaml:"lcom,omitempty"`
+	Clones     *CloneThresholds      `json:"clones,omitempty" yaml:"clones,omitempty"`
+}
+
+// LevelThresholds bucket a metric: values up to Low are low risk, up to
+// Medium medium risk and above it high risk
+type LevelThresholds struct {
+	Low    int `json:"low" yaml:"low"`
+	Medium int `json:"medium" yaml:"medium"`
+}
+
+// ComplexityThresholds bucket cyclomatic complexity like LevelThresholds and
+// flag functions whose cognitive complexity or nesting depth exceeds its limit
+type ComplexityThresholds struct {
+	Low          int `json:"low" yaml:"low"`
+	Medium       int `json:"medium" yaml:"medium"`
+	Cognitive    int `json:"cognitive" yaml:"cognitive"`
+	NestingDepth int `json:"nesting_depth" yaml:"nesting_depth"`
+}
+
+// CloneThresholds are the minimum similarities of a clone overall and of
+// each clone type
+type CloneThresholds struct {
+	Similarity float64 `json:"similarity" yaml:"similarity"`
+	Type1      float64 `json:"type1" yaml:"type1"`
+	Type2      float64 `json:"type2" yaml:"type2"`
+	Type3      float64 `json:"type3" yaml:"type3"`
+	Type4      float64 `json:"type4" yaml:"type4"`
+}
+
+// GitProvenance identifies the commit an analyzed tree was checked out from
+type GitProvenance struct {
+	Commit string `json:"commit" yaml:"commit"`
+	Branch string `json:"branch,omitempty" yaml:"branch,omitempty"`
+	// Dirty is true when tracked files had uncommitted changes, i.e. the
+	// analyzed code differs from Commit
+	Dirty bool `json:"dirty" yaml:"dirty"`
+}
+
+// EnabledAnalyzers returns the AnalyzerVersions keys of the analyzers that
+// ran according to the summary
+func (s AnalyzeSummary) EnabledAnalyzers() []string {
+	var names []string
+	for _, analyzer := range []struct {
+		name    string
+		enabled bool
+	}{
+		{"complexity", s.ComplexityEnabled},
+		{"dead_code", s.DeadCodeEnabled},
+		{"clones", s.CloneEnabled},
+		{"cbo", s.CBOEnabled},
+		{"lcom", s.LCOMEnabled},
+		{"dependencies", s.DepsEnabled},
+		{"architecture", s.ArchEnabled},
+		{"communities", s.CommunitiesEnabled},
+		{"mock_data", s.MockDataEnabled},
+		{"documentation", s.DocumentationEnabled},
+		{"typing", s.TypingEnabled},
+	} {
+		if analyzer.enabled {
+			names = append(names, analyzer.name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,73 @@
+package domain
+
+// UnownedOwner is the owner name used for files that no CODEOWNERS rule covers
+const UnownedOwner = "(unowned)"
+
+// OwnershipResolver maps a source file to the owners responsible for it.
+// An empty result means the file has no owner.
+type OwnershipResolver interface {
+	Owners(filePath string) []string
+}
+
+// OwnershipReport slices analysis results by code owner so each team can see
+// the findings and scores for the code it is responsible for.
+type OwnershipReport struct {
+	// CodeownersFile is the CODEOWNERS file the report was built from
+	CodeownersFile string `json:"codeowners_file" yaml:"codeowners_file"`
+
+	// Owners lists one entry per owner, with unowned files last
+	Owners []OwnerSummary `json:"owners" yaml:"owners"`
+}
+
+// OwnerSummary aggregates findings and scores for a single owner.
+// Files with several owners count towards each of them.
+type OwnerSummary struct {
+	Owner string `json:"owner" yaml:"owner"`
+
+	Files               int     `json:"files" yaml:"files"`
+	Functions           int     `json:"functions" yaml:"functions"`
+	AverageComplexity   float64 `json:"average_complexity" yaml:"average_complexity"`
+	HighComplexityCount int     `json:"high_complexity_count" yaml:"high_complexity_count"`
+	DeadCodeCount       int     `json:"dead_code_count" yaml:"dead_code_count"`
+	CriticalDeadCode    int     `json:"critical_dead_code" yaml:"critical_dead_code"`
+	CloneFragments      int     `json:"clone_fragments" yaml:"clone_fragments"`
+
+	// Scores cover complexity and dead code, the analyses that map to single files
+	ComplexityScore int    `json:"complexity_score" yaml:"complexity_score"`
+	DeadCodeScore   int    `json:"dead_code_score" yaml:"dead_code_score"`
+	HealthScore     int    `json:"health_score" yaml:"health_score"`
+	Grade           string `json:"grade" yaml:"grade"`
+
+	// Packages breaks the owner's files down by directory, worst score first
+	Packages []OwnerPackageScore `json:"packages" yaml:"packages"`
+
+	// Findings owned by this owner
+	HighComplexityFunctions []OwnedFinding `json:"high_complexity_functions,omitempty" yaml:"high_complexity_functions,omitempty"`
+	DeadCodeFindings        []OwnedFinding `json:"dead_code_findings,omitempty" yaml:"dead_code_findings,omitempty"`
+}
+
+// OwnerPackageScore holds the scores for an owner's files within one package directory
+type OwnerPackageScore struct {
+	Package string `json:"package" yaml:"package"`
+
+	Files               int     `json:"files" yaml:"files"`
+	Functions           int     `json:"functions" yaml:"functions"`
+	AverageComplexity   float64 `json:"average_complexity" yaml:"average_complexity"`
+	HighComplexityCount int     `json:"high_complexity_count" yaml:"high_complexity_count"`
+	DeadCodeCount       int     `json:"dead_code_count" yaml:"dead_code_count"`
+
+	ComplexityScore int    `json:"complexity_score" yaml:"complexity_score"`
+	DeadCodeScore   int    `json:"dead_code_score" yaml:"dead_code_score"`
+	HealthScore     int    `json:"health_score" yaml:"health_score"`
+	Grade           string `json:"grade" yaml:"grade"`
+}
+
+// OwnedFinding is a compact reference to a finding in an owner's slice of the report
+type OwnedFinding struct {
+	Name      string `json:"name" yaml:"name"`
+	FilePath  string `json:"file_path" yaml:"file_path"`
+	StartLine int    `json:"start_line" yaml:"start_line"`
+	EndLine   int    `json:"end_line" yaml:"end_line"`
+	Severity  string `json:"severity" yaml:"severity"`
+	Detail    string `json:"detail" yaml:"detail"`
+}
@@ -0,0 +1,59 @@
+package domain
+
+// PackageSummary rolls analysis results up to one directory. Every metric
+// covers the files in the directory and all of its subdirectories, so the
+// root entry matches the project as a whole.
+type PackageSummary struct {
+	// Path is the directory, as it appears in the file paths of the report
+	Path string `json:"path" yaml:"path"`
+
+	// Name is the last path element, or the full path for the root
+	Name string `json:"name" yaml:"name"`
+
+	// Parent is the enclosing package; empty for the root
+	Parent string `json:"parent,omitempty" yaml:"parent,omitempty"`
+
+	// Depth is the nesting level below the root (0)
+	Depth int `json:"depth" yaml:"depth"`
+
+	Files int `json:"files" yaml:"files"`
+
+	// Complexity
+	Functions           int     `json:"functions" yaml:"functions"`
+	AverageComplexity   float64 `json:"average_complexity" yaml:"average_complexity"`
+	MaxComplexity       int     `json:"max_complexity" yaml:"max_complexity"`
+	HighComplexityCount int     `json:"high_complexity_count" yaml:"high_complexity_count"`
+
+	// Dead code
+	DeadCodeCount    int `json:"dead_code_count" yaml:"dead_code_count"`
+	CriticalDeadCode int `json:"critical_dead_code" yaml:"critical_dead_code"`
+
+	// Duplication: lines inside clone fragments over total lines
+	TotalLines            int     `json:"total_lines" yaml:"total_lines"`
+	DuplicatedLines       int     `json:"duplicated_lines" yaml:"duplicated_lines"`
+	DuplicationPercentage float64 `json:"duplication_percentage" yaml:"duplication_percentage"`
+
+	// Coupling (CBO) distribution by risk level
+	Classes           int     `json:"classes" yaml:"classes"`
+	AverageCBO        float64 `json:"average_cbo" yaml:"average_cbo"`
+	MaxCBO            int     `json:"max_cbo" yaml:"max_cbo"`
+	LowRiskClasses    int     `json:"low_risk_classes" yaml:"low_risk_classes"`
+	MediumRiskClasses int     `json:"medium_risk_classes" yaml:"medium_risk_classes"`
+	HighRiskClasses   int     `json:"high_risk_classes" yaml:"high_risk_classes"`
+
+	// Cohesion (LCOM4)
+	AverageLCOM   float64 `json:"average_lcom" yaml:"average_lcom"`
+	HighLCOMCount int     `json:"high_lcom_count" yaml:"high_lcom_count"`
+
+	// MissingDocstrings is set when documentation coverage ran
+	MissingDocstrings *int `json:"missing_docstrings,omitempty" yaml:"missing_docstrings,omitempty"`
+
+	// TypeAnnotationCoverage is set when type annotation coverage ran
+	TypeAnnotationCoverage *float64 `json:"type_annotation_coverage,omitempty" yaml:"type_annotation_coverage,omitempty"`
+
+	// Scores cover complexity and dead code, the analyses that map to single files
+	ComplexityScore int    `json:"complexity_score" yaml:"complexity_score"`
+	DeadCodeScore   int    `json:"dead_code_score" yaml:"dead_code_score"`
+	HealthScore     int    `json:"health_score" yaml:"health_score"`
+	Grade           string `json:"grade" yaml:"grade"`
+}
@@ -0,0 +1,199 @@
+package domain
+
+import (
+	"context"
+	"io"
+)
+
+// PatternRuleSeverity is the severity a custom rule reports its findings at
+type PatternRuleSeverity string
+
+const (
+	PatternRuleSeverityInfo    PatternRuleSeverity = "info"
+	PatternRuleSeverityWarning PatternRuleSeverity = "warning"
+	PatternRuleSeverityError   PatternRuleSeverity = "error"
+)
+
+// PatternRuleScope restricts a custom rule to nodes by the definition they
+// are in
+type PatternRuleScope string
+
+const (
+	// PatternRuleScopeAny matches nodes anywhere
+	PatternRuleScopeAny PatternRuleScope = "any"
+	// PatternRuleScopeModule matches nodes outside any function or class
+	PatternRuleScopeModule PatternRuleScope = "module"
+	// PatternRuleScopeClass matches nodes whose innermost definition is a class
+	PatternRuleScopeClass PatternRuleScope = "class"
+	// PatternRuleScopeFunction matches nodes whose innermost definition is a
+	// function or lambda
+	PatternRuleScopeFunction PatternRuleScope = "function"
+)
+
+// PatternRuleMatch describes the AST nodes a custom rule matches. A node
+// matches when it has one of the node types and every other set condition
+// holds.
+type PatternRuleMatch struct {
+	// Nodes are parser node types, e.g. "Call", "FunctionDef", "ExceptHandler"
+	Nodes []string `json:"node" yaml:"node"`
+
+	// Name is a glob the name of a definition, argument, variable or
+	// attribute must match
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	// Callee is a glob the dotted name of the called function must match,
+	// e.g. "print" or "subprocess.*"; only calls match it
+	Callee string `json:"callee,omitempty" yaml:"callee,omitempty"`
+
+	// Scope restricts the definition the node is in; empty means any
+	Scope PatternRuleScope `json:"scope,omitempty" yaml:"scope,omitempty"`
+
+	// Inside lists node types of which at least one must enclose the node
+	Inside []string `json:"inside,omitempty" yaml:"inside,omitempty"`
+
+	// NotInside lists node types none of which may enclose the node
+	NotInside []string `json:"not_inside,omitempty" yaml:"not_inside,omitempty"`
+
+	// Bare, when set, requires an except handler without an exception type
+	// or a raise without an exception (true), or the opposite (false)
+	Bare *bool `json:"bare,omitempty" yaml:"bare,omitempty"`
+
+	// MinDecorators is the number of decorators a definition must have at least
+	MinDecorators int `json:"min_decorators,omitempty" yaml:"min_decorators,omitempty"`
+
+	// MinArgs is the number of arguments a call, or parameters a function,
+	// must have at least
+	MinArgs int `json:"min_args,omitempty" yaml:"min_args,omitempty"`
+
+	// MinLines is the number of lines the node must span at least
+	MinLines int `json:"min_lines,omitempty" yaml:"min_lines,omitempty"`
+}
+
+// PatternRule is a team convention declared in a rule file: a pattern and
+// the finding reported for each node matching it
+type PatternRule struct {
+	// ID is the user-defined rule ID findings are reported under
+	ID       string              `json:"id" yaml:"id"`
+	Message  string              `json:"message" yaml:"message"`
+	Severity PatternRuleSeverity `json:"severity" yaml:"severity"`
+	Match    PatternRuleMatch    `json:"match" yaml:"match"`
+}
+
+// PatternRuleFinding is a node matching a custom rule
+type PatternRuleFinding struct {
+	Rule     string              `json:"rule" yaml:"rule"`
+	Severity PatternRuleSeverity `json:"severity" yaml:"severity"`
+	Message  string              `json:"message" yaml:"message"`
+
+	// Node is the type of the matched node
+	Node string `json:"node" yaml:"node"`
+
+	// FunctionName is the dotted path of the functions and classes around
+	// the node, e.g. "Service.run"; empty at module level
+	FunctionName string         `json:"function_name,omitempty" yaml:"function_name,omitempty"`
+	Location     SourceLocation `json:"location" yaml:"location"`
+}
+
+// PatternRulesRequest represents a request to run custom rules
+type PatternRulesRequest struct {
+	// Input files or directories to analyze
+	Paths []string
+
+	// Output configuration
+	OutputFormat OutputFormat
+	OutputWriter io.Writer
+	OutputPath   string
+	NoOpen       bool
+
+	// Analysis options
+	Recursive       *bool
+	IncludePatterns []string
+	ExcludePatterns []string
+
+	// Configuration
+	ConfigPath string
+
+	// Rules to run, loaded from the rule files of the configuration
+	Rules []PatternRule
+
+	// RuleFiles are the rule files the rules were loaded from
+	RuleFiles []string
+}
+
+// PatternRulesSummary represents aggregate statistics for custom rules
+type PatternRulesSummary struct {
+	TotalFindings int                         `json:"total_findings" yaml:"total_findings"`
+	ByRule        map[string]int              `json:"by_rule" yaml:"by_rule"`
+	BySeverity    map[PatternRuleSeverity]int `json:"by_severity" yaml:"by_severity"`
+	RulesRun      int                         `json:"rules_run" yaml:"rules_run"`
+	FilesAnalyzed int                         `json:"files_analyzed" yaml:"files_analyzed"`
+	AffectedFiles int                         `json:"affected_files" yaml:"affected_files"`
+}
+
+// PatternRulesResponse represents the result of running custom rules
+type PatternRulesResponse struct {
+	Findings []PatternRuleFinding `json:"findings" yaml:"findings"`
+	Summary  PatternRulesSummary  `json:"summary" yaml:"summary"`
+
+	// Errors contains files that could not be analyzed
+	Errors []string `json:"errors,omitempty" yaml:"errors,omitempty"`
+
+	// Metadata
+	GeneratedAt string `json:"generated_at" yaml:"generated_at"`
+	Version     string `json:"version" yaml:"version"`
+}
+
+// PatternRulesService defines the interface for running custom rules
+type PatternRulesService interface {
+	// Analyze runs the rules of the request over its files
+	Analyze(ctx context.Context, req PatternRulesRequest) (*PatternRulesResponse, error)
+}
+
+// PatternRulesConfigurationLoader defines the interface for loading custom
+// rules configuration
+type PatternRulesConfigurationLoader interface {
+	// LoadConfig loads configuration, and the rule files it lists, from the
+	// specified path
+	LoadConfig(path string) (*PatternRulesRequest, error)
+
+	// LoadDefaultConfig loads the configuration discovered from the current
+	// directory
+	LoadDefaultConfig() (*PatternRulesRequest, error)
+
+	// MergeConfig merges CLI flags with configuration file
+	MergeConfig(base *PatternRulesRequest, override *PatternRulesRequest) *PatternRulesRequest
+}
+
+// PatternRulesOutputFormatter defines the interface for formatting custom
+// rule findings
+type PatternRulesOutputFormatter interface {
+	// Write writes the formatted output to the writer
+	Write(response *PatternRulesResponse, format OutputFormat, writer io.Writer) error
+}
+
+// SeverityOrder returns numeric order for severity (higher = more severe)
+func (s PatternRuleSeverity) SeverityOrder() int {
+	switch s {
+	case PatternRuleSeverityError:
+		return 3
+	case PatternRuleSeverityWarning:
+		return 2
+	case PatternRuleSeverityInfo:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// IsAtLeast returns true if this severity is at least as severe as the given level
+func (s PatternRuleSeverity) IsAtLeast(other PatternRuleSeverity) bool {
+	return s.SeverityOrder() >= other.SeverityOrder()
+}
+
+// Validate validates the request parameters
+func (r *PatternRulesRequest) Validate() error {
+	if len(r.Paths) == 0 {
+		return NewInvalidInputError("at least one path must be specified", nil)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package domain
+
+import "context"
+
+// Progress stages reported by the analyses. Each counts its own items: files
+// for parsing and the per-file analyses, fragment pairs or fragments for
+// clone detection.
+const (
+	ProgressStageParse       = "parse"
+	ProgressStageComplexity  = "complexity"
+	ProgressStageDeadCode    = "dead_code"
+	ProgressStageClones      = "clones"
+	ProgressStageCBO         = "cbo"
+	ProgressStageLCOM        = "lcom"
+	ProgressStageSystem      = "system"
+	ProgressStageCommunities = "communities"
+	ProgressStageDocs        = "documentation"
+	ProgressStageTyping      = "typing"
+
+	// ProgressStageAnalyze is the overall progress of an analyze run, as a
+	// percentage: done out of a total of 100
+	ProgressStageAnalyze = "analyze"
+)
+
+// ProgressReporter receives progress as work is actually done, rather than
+// estimated from elapsed time. Implementations must be safe for concurrent
+// use: analyses run in parallel and report from their worker goroutines.
+type ProgressReporter interface {
+	// ReportProgress records that done of total items of stage are finished.
+	// total is 0 when not known yet.
+	ReportProgress(stage string, done, total int)
+}
+
+// ProgressReporterFunc adapts a function to ProgressReporter
+type ProgressReporterFunc func(stage string, done, total int)
+
+// ReportProgress calls f
+func (f ProgressReporterFunc) ReportProgress(stage string, done, total int) {
+	f(stage, done, total)
+}
+
+// StageReporter is implemented by progress reporters that also track when
+// the stages of an analyze run start and finish. The analyze use case
+// forwards the progress of each stage to such a reporter, under the stage
+// names above, besides the overall ProgressStageAnalyze percentage.
+type StageReporter interface {
+	ProgressReporter
+	StageStarted(stage string)
+	StageFinished(stage string)
+}
+
+type progressReporterKey struct{}
+
+// WithProgressReporter returns a context whose analyses report their progress
+// to reporter
+func WithProgressReporter(ctx context.Context, reporter ProgressReporter) context.Context {
+	return context.WithValue(ctx, progressReporterKey{}, reporter)
+}
+
+// ProgressReporterFrom returns the reporter attached to ctx, or nil
+func ProgressReporterFrom(ctx context.Context) ProgressReporter {
+	if ctx == nil {
+		return nil
+	}
+	reporter, _ := ctx.Value(progressReporterKey{}).(ProgressReporter)
+	return reporter
+}
+
+// ReportProgress reports progress to the reporter attached to ctx, if any
+func ReportProgress(ctx context.Context, stage string, done, total int) {
+	if reporter := ProgressReporterFrom(ctx); reporter != nil {
+		reporter.ReportProgress(stage, done, total)
+	}
+}
+
+// Events of the machine-readable progress stream of analyze --progress json
+const (
+	ProgressEventPhaseStart  = "phase_start"
+	ProgressEventProgress    = "progress"
+	ProgressEventPhaseFinish = "phase_finish"
+)
+
+// ProgressEvent is one line of the machine-readable progress stream. Phase
+// is a progress stage; ProgressStageAnalyze events carry the overall
+// percentage as done out of 100.
+type ProgressEvent struct {
+	Event     string `json:"event"`
+	Phase     string `json:"phase"`
+	Done      int    `json:"done,omitempty"`
+	Total     int    `json:"total,omitempty"`
+	ElapsedMs int64  `json:"elapsed_ms"`       // Since the run started
+	ETAMs     *int64 `json:"eta_ms,omitempty"` // Until the phase finishes, extrapolated from its pace so far
+}
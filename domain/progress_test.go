@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"context"
+	"testing"
+)
+
+func TestReportProgressWithoutReporter(t *testing.T) {
+	// Must not panic when no reporter is attached
+	ReportProgress(context.Background(), ProgressStageParse, 1, 2)
+}
+
+func TestReportProgressReachesAttachedReporter(t *testing.T) {
+	var gotStage string
+	var gotDone, gotTotal int
+	ctx := WithProgressReporter(context.Background(), ProgressReporterFunc(func(stage string, done, total int) {
+		gotStage, gotDone, gotTotal = stage, done, total
+	}))
+
+	ReportProgress(ctx, ProgressStageClones, 3, 10)
+
+	if gotStage != ProgressStageClones || gotDone != 3 || gotTotal != 10 {
+		t.Errorf("expected clones 3/10, got %s %d/%d", gotStage, gotDone, gotTotal)
+	}
+}
@@ -0,0 +1,86 @@
+package domain
+
+// Metrics recorded by `pyscn ratchet`. Complexity metrics are recorded per
+// function, finding counts per file.
+const (
+	RatchetMetricComplexity          = "complexity"
+	RatchetMetricCognitiveComplexity = "cognitive_complexity"
+	RatchetMetricDeadCode            = "dead_code"
+	RatchetMetricClones              = "clones"
+)
+
+// RatchetMetrics lists the recorded metrics in report order
+var RatchetMetrics = []string{
+	RatchetMetricComplexity,
+	RatchetMetricCognitiveComplexity,
+	RatchetMetricDeadCode,
+	RatchetMetricClones,
+}
+
+// DefaultRatchetMaxComplexity is the complexity allowed for functions without
+// a recorded value when [complexity] max_complexity is unset; it matches the
+// default of `pyscn check --max-complexity`
+const DefaultRatchetMaxComplexity = 10
+
+// RatchetEntry is the recorded worst value of one metric for a file, or for
+// a function when Function is set
+type RatchetEntry struct {
+	// Path is relative to the directory of the ratchet file, with forward slashes
+	Path     string `json:"path"`
+	Function string `json:"function,omitempty"`
+	Metric   string `json:"metric"`
+	Value    int    `json:"value"`
+
+	// Line locates the function in the current run; it is not recorded
+	Line int `json:"-"`
+}
+
+// Key identifies the file or function and metric of the entry
+func (e RatchetEntry) Key() string {
+	return e.Path + "\x00" + e.Function + "\x00" + e.Metric
+}
+
+// Ratchet is the committed record of the worst metric values per file and
+// function. Values only ever go down: runs fail when a metric exceeds its
+// recorded value and lower the record when code improves.
+type Ratchet struct {
+	// Version is the pyscn version that last wrote the file
+	Version string `json:"version"`
+
+	// Thresholds are the values allowed for files and functions without an
+	// entry, per metric. Only values above them are recorded.
+	Thresholds map[string]int `json:"thresholds"`
+
+	Entries []RatchetEntry `json:"entries"`
+}
+
+// Threshold returns the value allowed for a metric without an entry
+func (r *Ratchet) Threshold(metric string) int {
+	if r == nil {
+		return 0
+	}
+	return r.Thresholds[metric]
+}
+
+// RatchetChange is a metric that moved against the ratchet
+type RatchetChange struct {
+	Path     string `json:"path"`
+	Function string `json:"function,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Metric   string `json:"metric"`
+
+	// Allowed is the recorded value, or the threshold when Recorded is false
+	Allowed  int  `json:"allowed"`
+	Recorded bool `json:"recorded"`
+	Current  int  `json:"current"`
+}
+
+// RatchetReport is the outcome of comparing a run with the ratchet
+type RatchetReport struct {
+	// Regressions are metrics above their recorded value or threshold
+	Regressions []RatchetChange `json:"regressions"`
+
+	// Improvements are recorded metrics that went down or disappeared;
+	// Current is 0 for a file or function that no longer exceeds its threshold
+	Improvements []RatchetChange `json:"improvements"`
+}
@@ -0,0 +1,184 @@
+package domain
+
+import "fmt"
+
+// ScoreInput is one measured value a category penalty is computed from
+type ScoreInput struct {
+	Name  string  `json:"name" yaml:"name"`
+	Value float64 `json:"value" yaml:"value"`
+}
+
+// ScoreExplanation shows how one category contributes to the health score.
+// The health score is 100 minus the sum of Penalty over all explanations,
+// floored at MinimumScore.
+type ScoreExplanation struct {
+	Category   string       `json:"category" yaml:"category"`
+	Inputs     []ScoreInput `json:"inputs" yaml:"inputs"`
+	Formula    string       `json:"formula" yaml:"formula"`
+	MaxPenalty int          `json:"max_penalty" yaml:"max_penalty"` // Weight: the most points the category can deduct
+	Penalty    int          `json:"penalty" yaml:"penalty"`         // Points deducted from the health score
+	Score      int          `json:"score" yaml:"score"`             // Category score (0-100)
+}
+
+// healthPenalties holds the penalty of each category computed by
+// CalculateHealthScore
+type healthPenalties struct {
+	complexity, deadCode, duplication, coupling, cohesion int
+	dependency, architecture, community                   int
+	documentation, typing                                 int
+	sizeFactor                                            float64
+}
+
+// scoreExplanations describes every category that takes part in the health
+// score. Optional categories only appear when they were scored, since they
+// deduct nothing otherwise.
+func (s *AnalyzeSummary) scoreExplanations(p healthPenalties) []ScoreExplanation {
+	explanations := []ScoreExplanation{
+		{
+			Category: "complexity",
+			Inputs: []ScoreInput{
+				{Name: "average_complexity", Value: s.AverageComplexity},
+				{Name: "average_cognitive_complexity", Value: s.AverageCognitiveComplexity},
+				{Name: "average_nesting_depth", Value: s.AverageNestingDepth},
+			},
+			Formula: fmt.Sprintf("largest of three linear ramps from 0 to %d: average_complexity 2→15, average_cognitive_complexity 15→%d, average_nesting_depth 3→%d",
+				MaxScoreBase, DefaultCognitiveComplexityThreshold, DefaultNestingDepthThreshold),
+			MaxPenalty: MaxScoreBase,
+			Penalty:    p.complexity,
+			Score:      s.ComplexityScore,
+		},
+		{
+			Category: "dead_code",
+			Inputs: []ScoreInput{
+				{Name: "critical_dead_code", Value: float64(s.CriticalDeadCode)},
+				{Name: "warning_dead_code", Value: float64(s.WarningDeadCode)},
+				{Name: "info_dead_code", Value: float64(s.InfoDeadCode)},
+				{Name: "size_factor", Value: p.sizeFactor},
+			},
+			Formula: fmt.Sprintf("min(%d, (critical + 0.5×warning + 0.2×info) / size_factor), where size_factor = 1 + log10(total_files / 10) above 10 files",
+				MaxDeadCodePenalty),
+			MaxPenalty: MaxDeadCodePenalty,
+			Penalty:    p.deadCode,
+			Score:      s.DeadCodeScore,
+		},
+		{
+			Category: "duplication",
+			Inputs: []ScoreInput{
+				{Name: "code_duplication_percentage", Value: s.CodeDuplication},
+			},
+			Formula:    fmt.Sprintf("min(%d, duplication / %g%% × %d)", MaxScoreBase, DuplicationThresholdHigh, MaxScoreBase),
+			MaxPenalty: MaxScoreBase,
+			Penalty:    p.duplication,
+			Score:      s.DuplicationScore,
+		},
+		{
+			Category: "coupling",
+			Inputs: []ScoreInput{
+				{Name: "high_coupling_classes", Value: float64(s.HighCouplingClasses)},
+				{Name: "medium_coupling_classes", Value: float64(s.MediumCouplingClasses)},
+				{Name: "cbo_classes", Value: float64(s.CBOClasses)},
+			},
+			Formula: fmt.Sprintf("min(%d, (high + %g×medium) / classes / %g × %d)",
+				MaxScoreBase, CouplingMediumWeight, CouplingSaturationRatio, MaxScoreBase),
+			MaxPenalty: MaxScoreBase,
+			Penalty:    p.coupling,
+			Score:      s.CouplingScore,
+		},
+		{
+			Category: "cohesion",
+			Inputs: []ScoreInput{
+				{Name: "high_lcom_classes", Value: float64(s.HighLCOMClasses)},
+				{Name: "medium_lcom_classes", Value: float64(s.MediumLCOMClasses)},
+				{Name: "lcom_classes", Value: float64(s.LCOMClasses)},
+			},
+			Formula: fmt.Sprintf("min(%d, (high + %g×medium) / classes / %g × %d)",
+				MaxScoreBase, CohesionMediumWeight, CohesionSaturationRatio, MaxScoreBase),
+			MaxPenalty: MaxScoreBase,
+			Penalty:    p.cohesion,
+			Score:      s.CohesionScore,
+		},
+	}
+
+	if s.DepsEnabled {
+		explanations = append(explanations, ScoreExplanation{
+			Category: "dependencies",
+			Inputs: []ScoreInput{
+				{Name: "deps_total_modules", Value: float64(s.DepsTotalModules)},
+				{Name: "deps_modules_in_cycles", Value: float64(s.DepsModulesInCycles)},
+				{Name: "deps_max_depth", Value: float64(s.DepsMaxDepth)},
+				{Name: "deps_main_sequence_deviation", Value: s.DepsMainSequenceDeviation},
+			},
+			Formula: fmt.Sprintf("cycles: min(%d, max(log2(in_cycles + 1), %d × in_cycles / modules)) + depth: min(%d, max_depth − max(3, ceil(log2(modules + 1)) + 1)) + main sequence: %d × deviation",
+				MaxCyclesPenalty, MaxCyclesPenalty, MaxDepthPenalty, MaxMSDPenalty),
+			MaxPenalty: MaxDependencyPenalty,
+			Penalty:    p.dependency,
+			Score:      s.DependencyScore,
+		})
+	}
+
+	if s.ArchEnabled {
+		explanations = append(explanations, ScoreExplanation{
+			Category: "architecture",
+			Inputs: []ScoreInput{
+				{Name: "arch_compliance", Value: s.ArchCompliance},
+			},
+			Formula:    fmt.Sprintf("%d × (1 − compliance)", MaxArchitecturePenalty),
+			MaxPenalty: MaxArchitecturePenalty,
+			Penalty:    p.architecture,
+			Score:      s.ArchitectureScore,
+		})
+	}
+
+	if ratio, scored := s.communityRiskRatio(); scored {
+		inputs := []ScoreInput{
+			{Name: "community_count", Value: float64(s.CommunityCount)},
+			{Name: "community_modularity", Value: s.CommunityModularity},
+			{Name: "community_bridge_modules", Value: float64(s.CommunityBridgeModules)},
+			{Name: "community_internal_edges", Value: float64(s.CommunityInternalEdges)},
+			{Name: "community_cross_edges", Value: float64(s.CommunityCrossEdges)},
+		}
+		if s.CommunityPackageAlignment != nil {
+			inputs = append(inputs, ScoreInput{Name: "community_package_alignment", Value: *s.CommunityPackageAlignment})
+		}
+		if s.CommunityLayerAlignment != nil {
+			inputs = append(inputs, ScoreInput{Name: "community_layer_alignment", Value: *s.CommunityLayerAlignment})
+		}
+		inputs = append(inputs, ScoreInput{Name: "risk_ratio", Value: ratio})
+		explanations = append(explanations, ScoreExplanation{
+			Category:   "communities",
+			Inputs:     inputs,
+			Formula:    fmt.Sprintf("%d × risk_ratio, the weighted average of modularity, cross-edge, bridge and alignment risks", MaxCommunityPenalty),
+			MaxPenalty: MaxCommunityPenalty,
+			Penalty:    p.community,
+			Score:      s.CommunityScore,
+		})
+	}
+
+	if s.DocumentationScore != nil {
+		explanations = append(explanations, ScoreExplanation{
+			Category: "documentation",
+			Inputs: []ScoreInput{
+				{Name: "docstring_coverage", Value: *s.DocstringCoverage},
+			},
+			Formula:    fmt.Sprintf("%d × max(0, (%g − coverage) / %g)", MaxDocumentationPenalty, DocumentationCoverageGoal, DocumentationCoverageGoal),
+			MaxPenalty: MaxDocumentationPenalty,
+			Penalty:    p.documentation,
+			Score:      *s.DocumentationScore,
+		})
+	}
+
+	if s.TypednessScore != nil {
+		explanations = append(explanations, ScoreExplanation{
+			Category: "typedness",
+			Inputs: []ScoreInput{
+				{Name: "type_annotation_coverage", Value: *s.TypeAnnotationCoverage},
+			},
+			Formula:    fmt.Sprintf("%d × max(0, (%g − coverage) / %g)", MaxTypingPenalty, TypingCoverageGoal, TypingCoverageGoal),
+			MaxPenalty: MaxTypingPenalty,
+			Penalty:    p.typing,
+			Score:      *s.TypednessScore,
+		})
+	}
+
+	return explanations
+}
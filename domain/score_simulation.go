@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	coredomain "github.com/ludo-technologies/polyscan/core/domain"
+)
+
+// simulatedCategories maps each analysis whose findings can be simulated
+// away to the score categories its findings deduct from
+var simulatedCategories = map[string][]string{
+	AnalysisComplexity:    {"complexity"},
+	AnalysisDeadCode:      {"dead_code"},
+	AnalysisClones:        {"duplication"},
+	AnalysisCBO:           {"coupling"},
+	AnalysisLCOM:          {"cohesion"},
+	AnalysisDeps:          {"dependencies", "architecture"},
+	AnalysisCommunities:   {"communities"},
+	AnalysisDocumentation: {"documentation"},
+	AnalysisTyping:        {"typedness"},
+}
+
+// ScoreSimulation projects the health score of a run under the assumption
+// that the findings of some analyses are resolved
+type ScoreSimulation struct {
+	HealthScore int    `json:"health_score" yaml:"health_score"`
+	Grade       string `json:"grade" yaml:"grade"`
+
+	// Fixes holds the projected gain of resolving each analysis on its own
+	Fixes []SimulatedFix `json:"fixes" yaml:"fixes"`
+
+	// Projected score with the findings of all Fixes resolved
+	ProjectedScore int    `json:"projected_score" yaml:"projected_score"`
+	ProjectedGrade string `json:"projected_grade" yaml:"projected_grade"`
+	Gain           int    `json:"gain" yaml:"gain"`
+}
+
+// SimulatedFix is the projected effect of resolving the findings of one
+// analysis
+type SimulatedFix struct {
+	Analysis       string `json:"analysis" yaml:"analysis"`
+	Penalty        int    `json:"penalty" yaml:"penalty"` // Points its findings deduct now
+	ProjectedScore int    `json:"projected_score" yaml:"projected_score"`
+	Gain           int    `json:"gain" yaml:"gain"`
+}
+
+// SimulatableAnalyses returns the analyses SimulateFixes accepts, in run
+// order
+func SimulatableAnalyses() []string {
+	var analyses []string
+	for _, analysis := range SelectableAnalyses {
+		if _, ok := simulatedCategories[analysis]; ok {
+			analyses = append(analyses, analysis)
+		}
+	}
+	return analyses
+}
+
+// ValidateSimulatedFixes rejects analyses SimulateFixes does not know
+func ValidateSimulatedFixes(analyses []string) error {
+	for _, analysis := range analyses {
+		if _, ok := simulatedCategories[analysis]; !ok {
+			return fmt.Errorf("cannot simulate fixing %q (expected: %s)", analysis, strings.Join(SimulatableAnalyses(), ", "))
+		}
+	}
+	return nil
+}
+
+// SimulateFixes recomputes the health score from the penalties of the score
+// explanations, leaving out those of the given analyses, or of every
+// analysis that deducts points when none are given. Every fix is also
+// simulated on its own, largest gain first, so that the gains show which
+// work pays off most. The summary must have been scored by
+// CalculateHealthScore.
+func (s *AnalyzeSummary) SimulateFixes(analyses []string) (*ScoreSimulation, error) {
+	if len(s.Explanations) == 0 {
+		return nil, fmt.Errorf("the health score has no breakdown to simulate fixes on")
+	}
+	if len(analyses) == 0 {
+		for _, analysis := range SimulatableAnalyses() {
+			if s.penaltyOf(simulatedCategories[analysis]) > 0 {
+				analyses = append(analyses, analysis)
+			}
+		}
+	}
+	if err := ValidateSimulatedFixes(analyses); err != nil {
+		return nil, err
+	}
+
+	simulation := &ScoreSimulation{
+		HealthScore: s.HealthScore,
+		Grade:       s.Grade,
+		Fixes:       make([]SimulatedFix, 0, len(analyses)),
+	}
+	for _, analysis := range analyses {
+		score := s.scoreWithout([]string{analysis})
+		simulation.Fixes = append(simulation.Fixes, SimulatedFix{
+			Analysis:       analysis,
+			Penalty:        s.penaltyOf(simulatedCategories[analysis]),
+			ProjectedScore: score,
+			Gain:           score - s.HealthScore,
+		})
+	}
+	slices.SortStableFunc(simulation.Fixes, func(a, b SimulatedFix) int {
+		return b.Gain - a.Gain
+	})
+	simulation.ProjectedScore = s.scoreWithout(analyses)
+	simulation.ProjectedGrade = coredomain.GradeFromScore(simulation.ProjectedScore)
+	simulation.Gain = simulation.ProjectedScore - s.HealthScore
+	return simulation, nil
+}
+
+// scoreWithout returns the health score without the penalties of analyses
+func (s *AnalyzeSummary) scoreWithout(analyses []string) int {
+	var resolved []string
+	for _, analysis := range analyses {
+		resolved = append(resolved, simulatedCategories[analysis]...)
+	}
+	var penalties []int
+	for _, explanation := range s.Explanations {
+		if !slices.Contains(resolved, explanation.Category) {
+			penalties = append(penalties, explanation.Penalty)
+		}
+	}
+	return coredomain.HealthScoreFromPenalties(penalties...)
+}
+
+// penaltyOf returns the points the given score categories deduct
+func (s *AnalyzeSummary) penaltyOf(categories []string) int {
+	penalty := 0
+	for _, explanation := range s.Explanations {
+		if slices.Contains(categories, explanation.Category) {
+			penalty += explanation.Penalty
+		}
+	}
+	return penalty
+}
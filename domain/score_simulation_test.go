@@ -0,0 +1,79 @@
+package domain_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// simulationSummary scores 79: dead code deducts 10, duplication 6, and deps
+// 5 through its dependency and architecture penalties
+func simulationSummary() domain.AnalyzeSummary {
+	return domain.AnalyzeSummary{
+		HealthScore: 79,
+		Grade:       "C",
+		Explanations: []domain.ScoreExplanation{
+			{Category: "complexity", Penalty: 0},
+			{Category: "dead_code", Penalty: 10},
+			{Category: "duplication", Penalty: 6},
+			{Category: "dependencies", Penalty: 3},
+			{Category: "architecture", Penalty: 2},
+		},
+	}
+}
+
+func TestAnalyzeSummary_SimulateFixes(t *testing.T) {
+	summary := simulationSummary()
+
+	simulation, err := summary.SimulateFixes([]string{domain.AnalysisClones, domain.AnalysisDeadCode, domain.AnalysisComplexity})
+	if err != nil {
+		t.Fatalf("SimulateFixes() error = %v", err)
+	}
+	want := []domain.SimulatedFix{
+		{Analysis: domain.AnalysisDeadCode, Penalty: 10, ProjectedScore: 89, Gain: 10},
+		{Analysis: domain.AnalysisClones, Penalty: 6, ProjectedScore: 85, Gain: 6},
+		{Analysis: domain.AnalysisComplexity, Penalty: 0, ProjectedScore: 79, Gain: 0},
+	}
+	if !reflect.DeepEqual(simulation.Fixes, want) {
+		t.Errorf("fixes = %+v, want %+v", simulation.Fixes, want)
+	}
+	if simulation.ProjectedScore != 95 || simulation.ProjectedGrade != "A" || simulation.Gain != 16 {
+		t.Errorf("projected %d (%s) %+d, want 95 (A) +16", simulation.ProjectedScore, simulation.ProjectedGrade, simulation.Gain)
+	}
+	if simulation.HealthScore != 79 || simulation.Grade != "C" {
+		t.Errorf("current score %d (%s), want 79 (C)", simulation.HealthScore, simulation.Grade)
+	}
+}
+
+func TestAnalyzeSummary_SimulateFixesDefaultsToPenalizedAnalyses(t *testing.T) {
+	summary := simulationSummary()
+
+	simulation, err := summary.SimulateFixes(nil)
+	if err != nil {
+		t.Fatalf("SimulateFixes() error = %v", err)
+	}
+	var analyses []string
+	for _, fix := range simulation.Fixes {
+		analyses = append(analyses, fix.Analysis)
+	}
+	// deps covers both the dependency and architecture penalties
+	if want := []string{domain.AnalysisDeadCode, domain.AnalysisClones, domain.AnalysisDeps}; !reflect.DeepEqual(analyses, want) {
+		t.Errorf("analyses = %v, want %v", analyses, want)
+	}
+	if simulation.Fixes[2].Penalty != 5 || simulation.ProjectedScore != 100 {
+		t.Errorf("unexpected simulation %+v", simulation)
+	}
+}
+
+func TestAnalyzeSummary_SimulateFixesErrors(t *testing.T) {
+	summary := simulationSummary()
+	if _, err := summary.SimulateFixes([]string{"security"}); err == nil {
+		t.Error("expected an unknown analysis to be rejected")
+	}
+
+	unscored := domain.AnalyzeSummary{}
+	if _, err := unscored.SimulateFixes(nil); err == nil {
+		t.Error("expected a summary without score breakdown to be rejected")
+	}
+}
@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"context"
+	"io"
+)
+
+// SecurityRule identifies the kind of dangerous sink a finding is about
+type SecurityRule string
+
+const (
+	// SecurityRuleCodeExecution flags eval, exec and compile on dynamic input
+	SecurityRuleCodeExecution SecurityRule = "code_execution"
+	// SecurityRuleShellInjection flags commands run through a shell
+	SecurityRuleShellInjection SecurityRule = "shell_injection"
+	// SecurityRuleUnsafeDeserialization flags pickle-style deserialization
+	SecurityRuleUnsafeDeserialization SecurityRule = "unsafe_deserialization"
+	// SecurityRuleUnsafeYAMLLoad flags yaml.load without a safe Loader
+	SecurityRuleUnsafeYAMLLoad SecurityRule = "unsafe_yaml_load"
+	// SecurityRuleSQLInjection flags SQL queries built by string formatting
+	SecurityRuleSQLInjection SecurityRule = "sql_injection"
+)
+
+// SecuritySeverity represents how likely a finding is to be exploitable
+type SecuritySeverity string
+
+const (
+	// SecuritySeverityInfo marks dynamic input of unknown origin
+	SecuritySeverityInfo SecuritySeverity = "info"
+	// SecuritySeverityWarning marks input a caller controls, or an API that
+	// is unsafe whatever its input
+	SecuritySeverityWarning SecuritySeverity = "warning"
+	// SecuritySeverityError marks input that comes from an obvious untrusted
+	// source in the same function
+	SecuritySeverityError SecuritySeverity = "error"
+)
+
+// SecurityFinding represents a dangerous sink and the input that reaches it
+type SecurityFinding struct {
+	// Rule that matched the sink
+	Rule SecurityRule `json:"rule" yaml:"rule"`
+
+	// Severity of the finding
+	Severity SecuritySeverity `json:"severity" yaml:"severity"`
+
+	// FunctionName is the function containing the sink
+	FunctionName string `json:"function_name" yaml:"function_name"`
+
+	// Location of the sink call
+	Location SourceLocation `json:"location" yaml:"location"`
+
+	// Sink is the dangerous call, e.g. "subprocess.run(shell=True)"
+	Sink string `json:"sink" yaml:"sink"`
+
+	// Source describes where the input reaching the sink comes from, e.g.
+	// "input() on line 3" or "parameter 'cmd'". Empty when unknown.
+	Source string `json:"source,omitempty" yaml:"source,omitempty"`
+
+	// Human-readable description of the issue
+	Description string `json:"description" yaml:"description"`
+
+	// Suggestion for fixing the issue
+	Suggestion string `json:"suggestion" yaml:"suggestion"`
+}
+
+// SecurityRequest represents a request for security pattern analysis
+type SecurityRequest struct {
+	// Input files or directories to analyze
+	Paths []string
+
+	// Output configuration
+	OutputFormat OutputFormat
+	OutputWriter io.Writer
+	OutputPath   string
+	NoOpen       bool
+
+	// Analysis options
+	Recursive       *bool
+	IncludePatterns []string
+	ExcludePatterns []string
+
+	// Configuration
+	ConfigPath string
+
+	// MinSeverity filters findings by minimum severity level
+	MinSeverity SecuritySeverity
+
+	// IgnoreTests skips test files
+	IgnoreTests *bool
+
+	// SortBy specifies the sort order
+	SortBy SortCriteria
+}
+
+// SecuritySummary represents aggregate statistics for security analysis
+type SecuritySummary struct {
+	// TotalFindings is the total number of findings
+	TotalFindings int `json:"total_findings" yaml:"total_findings"`
+
+	// ByRule breaks down findings by rule
+	ByRule map[SecurityRule]int `json:"by_rule" yaml:"by_rule"`
+
+	// BySeverity breaks down findings by severity
+	BySeverity map[SecuritySeverity]int `json:"by_severity" yaml:"by_severity"`
+
+	// FilesAnalyzed is the number of files analyzed
+	FilesAnalyzed int `json:"files_analyzed" yaml:"files_analyzed"`
+
+	// AffectedFiles is the number of files with at least one finding
+	AffectedFiles int `json:"affected_files" yaml:"affected_files"`
+}
+
+// SecurityResponse represents the complete security analysis result
+type SecurityResponse struct {
+	// Findings contains all detected issues
+	Findings []SecurityFinding `json:"findings" yaml:"findings"`
+
+	// Summary contains aggregate statistics
+	Summary SecuritySummary `json:"summary" yaml:"summary"`
+
+	// Warnings contains non-fatal issues encountered during analysis
+	Warnings []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+
+	// Errors contains errors encountered during analysis
+	Errors []string `json:"errors,omitempty" yaml:"errors,omitempty"`
+
+	// Metadata
+	GeneratedAt string      `json:"generated_at" yaml:"generated_at"`
+	Version     string      `json:"version" yaml:"version"`
+	Config      interface{} `json:"config,omitempty" yaml:"config,omitempty"`
+}
+
+// SecurityService defines the interface for security pattern analysis
+type SecurityService interface {
+	// Analyze performs security analysis on the given request
+	Analyze(ctx context.Context, req SecurityRequest) (*SecurityResponse, error)
+
+	// AnalyzeFile analyzes a single Python file
+	AnalyzeFile(ctx context.Context, filePath string, req SecurityRequest) (*SecurityResponse, error)
+}
+
+// SecurityConfigurationLoader defines the interface for loading security configuration
+type SecurityConfigurationLoader interface {
+	// LoadConfig loads configuration from the specified path
+	LoadConfig(path string) (*SecurityRequest, error)
+
+	// LoadDefaultConfig loads the default configuration
+	LoadDefaultConfig() *SecurityRequest
+
+	// MergeConfig merges CLI flags with configuration file
+	MergeConfig(base *SecurityRequest, override *SecurityRequest) *SecurityRequest
+}
+
+// SecurityOutputFormatter defines the interface for formatting security analysis results
+type SecurityOutputFormatter interface {
+	// Format formats the analysis response according to the specified format
+	Format(response *SecurityResponse, format OutputFormat) (string, error)
+
+	// Write writes the formatted output to the writer
+	Write(response *SecurityResponse, format OutputFormat, writer io.Writer) error
+}
+
+// DefaultSecurityRequest returns a SecurityRequest with default values
+func DefaultSecurityRequest() *SecurityRequest {
+	return &SecurityRequest{
+		OutputFormat:    OutputFormatJSON,
+		Recursive:       BoolPtr(true),
+		IncludePatterns: DefaultAnalysisIncludePatterns(),
+		ExcludePatterns: []string{},
+		MinSeverity:     SecuritySeverity(DefaultSecurityMinSeverity),
+		IgnoreTests:     BoolPtr(DefaultSecurityIgnoreTests),
+		SortBy:          SortBySeverity,
+	}
+}
+
+// SeverityOrder returns numeric order for severity (higher = more severe)
+func (s SecuritySeverity) SeverityOrder() int {
+	switch s {
+	case SecuritySeverityError:
+		return 3
+	case SecuritySeverityWarning:
+		return 2
+	case SecuritySeverityInfo:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// IsAtLeast returns true if this severity is at least as severe as the given level
+func (s SecuritySeverity) IsAtLeast(other SecuritySeverity) bool {
+	return s.SeverityOrder() >= other.SeverityOrder()
+}
+
+// Validate validates the request parameters
+func (r *SecurityRequest) Validate() error {
+	if len(r.Paths) == 0 {
+		return NewInvalidInputError("at least one path must be specified", nil)
+	}
+	return nil
+}
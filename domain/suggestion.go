@@ -177,6 +177,18 @@ func generateDeadCodeSuggestions(resp *DeadCodeResponse) []Suggestion {
 
 				title := fmt.Sprintf("Remove dead code after %s in '%s'",
 					humanizeReason(finding.Reason), finding.FunctionName)
+				switch finding.Reason {
+				case "inconsistent_return", "missing_return":
+					title = fmt.Sprintf("Make return paths consistent in '%s'", finding.FunctionName)
+				case "unreachable_match_case":
+					title = fmt.Sprintf("Remove shadowed match case in '%s'", finding.FunctionName)
+				case "non_exhaustive_match":
+					title = fmt.Sprintf("Add a catch-all case to the match in '%s'", finding.FunctionName)
+				case "useless_loop_else", "loop_always_breaks", "loop_condition_unmodified":
+					title = fmt.Sprintf("Fix the loop at line %d in '%s'", finding.Location.StartLine, finding.FunctionName)
+				case "unused_attribute":
+					title = fmt.Sprintf("Remove the unused attribute assigned in '%s'", finding.FunctionName)
+				}
 				desc := finding.Description
 				if desc == "" {
 					desc = fmt.Sprintf("Dead code detected at lines %d-%d in function '%s'.",
@@ -187,13 +199,46 @@ func generateDeadCodeSuggestions(resp *DeadCodeResponse) []Suggestion {
 				}
 
 				var steps []string
-				if finding.Reason == "unreachable_branch" {
+				switch finding.Reason {
+				case "unreachable_branch":
 					steps = []string{
 						"Review the condition guarding this branch — it may always be true/false",
 						"Simplify the conditional or remove the unreachable branch",
 						"Run tests to confirm no regressions",
 					}
-				} else {
+				case "inconsistent_return", "missing_return":
+					steps = []string{
+						fmt.Sprintf("Return a value or an explicit `return None` at line %d", finding.Location.StartLine),
+						"Widen the return annotation to Optional if None is a valid result",
+						"Run tests to confirm no regressions",
+					}
+				case "non_exhaustive_match":
+					steps = []string{
+						"Add `case _:` that handles or rejects unexpected values",
+						"Run tests to confirm no regressions",
+					}
+				case "useless_loop_else":
+					steps = []string{
+						"Move the else clause's code after the loop, or add the missing break",
+						"Run tests to confirm no regressions",
+					}
+				case "loop_always_breaks":
+					steps = []string{
+						"Replace the loop with a check of its first item, or fix the break that ends every iteration",
+						"Run tests to confirm no regressions",
+					}
+				case "loop_condition_unmodified":
+					steps = []string{
+						"Update the condition's variables in the loop body, or use `while True` with an explicit break",
+						"Run tests to confirm no regressions",
+					}
+				case "unused_attribute":
+					steps = []string{
+						fmt.Sprintf("Check the attribute at line %d is not read by code outside the project or by reflection", finding.Location.StartLine),
+						"Remove the assignment, or read the attribute where it was meant to be used",
+						"Run tests to confirm no regressions",
+					}
+				default:
 					steps = []string{
 						fmt.Sprintf("Delete lines %d-%d in %s",
 							finding.Location.StartLine, finding.Location.EndLine, finding.Location.FilePath),
@@ -244,6 +289,13 @@ func generateCloneSuggestions(resp *CloneResponse) []Suggestion {
 		title := fmt.Sprintf("Extract duplicated code (%s, %d fragments)", typeStr, memberCount)
 		desc := cloneDescription(group)
 		steps := cloneSteps(group.Type, memberCount)
+		if target := group.ExtractionTarget; target != nil {
+			location := "a new top-level module"
+			if target.Package != "" {
+				location = fmt.Sprintf("a new module in package '%s'", target.Package)
+			}
+			steps = append([]string{fmt.Sprintf("Place the shared code in %s", location)}, steps...)
+		}
 
 		s := Suggestion{
 			Category:    SuggestionCategoryClone,
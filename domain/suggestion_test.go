@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -272,13 +273,13 @@ func TestGenerateSuggestions_CloneGroups(t *testing.T) {
 		Clone: &CloneResponse{
 			CloneGroups: []*CloneGroup{
 				{
-					ID:         1,
+					ID:         "1",
 					Type:       Type1Clone,
 					Similarity: 1.0,
 					Clones:     make([]*Clone, 5), // 5 members -> critical
 				},
 				{
-					ID:         2,
+					ID:         "2",
 					Type:       Type4Clone,
 					Similarity: 0.75,
 					Clones:     make([]*Clone, 2), // 2 members -> warning
@@ -675,14 +676,14 @@ func TestGenerateSuggestions_ClonePrioritySurvivesLimit(t *testing.T) {
 	groups := make([]*CloneGroup, 12)
 	for i := 0; i < 11; i++ {
 		groups[i] = &CloneGroup{
-			ID:         i + 1,
+			ID:         strconv.Itoa(i + 1),
 			Type:       Type3Clone,
 			Similarity: 0.80,
 			Clones:     make([]*Clone, 2), // warning
 		}
 	}
 	groups[11] = &CloneGroup{
-		ID:         12,
+		ID:         "12",
 		Type:       Type1Clone,
 		Similarity: 1.0,
 		Clones:     make([]*Clone, 5), // critical+easy
@@ -766,7 +767,7 @@ func TestGenerateSuggestions_CloneType2Steps(t *testing.T) {
 		Clone: &CloneResponse{
 			CloneGroups: []*CloneGroup{
 				{
-					ID:         1,
+					ID:         "1",
 					Type:       Type2Clone,
 					Similarity: 0.95,
 					Clones:     make([]*Clone, 3),
@@ -792,7 +793,7 @@ func TestGenerateSuggestions_CloneType3Steps(t *testing.T) {
 		Clone: &CloneResponse{
 			CloneGroups: []*CloneGroup{
 				{
-					ID:         1,
+					ID:         "1",
 					Type:       Type3Clone,
 					Similarity: 0.80,
 					Clones:     make([]*Clone, 2),
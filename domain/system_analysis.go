@@ -32,7 +32,9 @@ type SystemAnalysisRequest struct {
 	IncludeStdLib                   *bool             // Include standard library dependencies
 	IncludeThirdParty               *bool             // Include third-party dependencies
 	FollowRelative                  *bool             // Follow relative imports
+	IncludeTypeChecking             *bool             // Record TYPE_CHECKING imports as tagged dependency edges
 	DetectCycles                    *bool             // Detect circular dependencies
+	CycleIgnoreEdgeKinds            []string          // Edge kinds excluded from cycle detection (nil = lazy, type_checking)
 	ValidateArchitecture            *bool             // Validate architecture rules
 	ValidateCohesion                *bool             // Validate package cohesion
 	ValidateResponsibility          *bool             // Validate single responsibility boundaries
@@ -40,10 +42,21 @@ type SystemAnalysisRequest struct {
 	MaxResponsibilities             int               // Maximum inferred responsibilities per module
 	CohesionViolationSeverity       ViolationSeverity // Severity for package cohesion violations
 	ResponsibilityViolationSeverity ViolationSeverity // Severity for SRP violations
+	ValidateGodModules              *bool             // Detect modules that are too large and too coupled
+	GodModuleMaxLines               int               // Lines a god module exceeds
+	GodModuleMaxDefinitions         int               // Classes plus functions a god module exceeds
+	GodModuleMaxFanIn               int               // Importing modules a god module exceeds
+	GodModuleMaxFanOut              int               // Imported modules a god module exceeds
+	FacadeMinExports                int               // Re-exported names a package init needs to count as a facade
+	CollapseFacades                 *bool             // Route imports through facades to the modules defining the names
 
 	// Architecture rules (loaded from config or specified directly)
 	ArchitectureRules *ArchitectureRules
 
+	// DetectArchitecture ignores configured layers and rules and auto-detects
+	// them, as `pyscn arch init` does
+	DetectArchitecture bool
+
 	// Integration with other analyses
 	ComplexityData map[string]int     // Module -> average complexity
 	ClonesData     map[string]float64 // Module -> duplication ratio
@@ -121,6 +134,107 @@ type DependencyAnalysisResult struct {
 	// Dependency chains
 	LongestChains []DependencyPath // Longest dependency chains
 	MaxDepth      int              // Maximum dependency depth
+
+	// Topological ordering
+	DependencyOrder *DependencyOrder // Modules ordered so dependencies come first
+
+	// External packages imported by the project
+	ImportInventory *ImportInventory // Stdlib and third-party imports with declared dependency checks
+
+	// Dynamic imports
+	DynamicDependencies int                  // Edges created from dynamic imports with a literal module name
+	UnanalyzableImports []UnanalyzableImport // Dynamic imports whose module name is computed at runtime
+
+	// Re-export facades
+	Facades []FacadeModule // Package inits that only re-export names of other modules, largest fan-out first
+}
+
+// FacadeModule is a package __init__ that does nothing but re-export names
+// defined in other project modules. Its edges reflect the public API rather
+// than real coupling.
+type FacadeModule struct {
+	Module    string   // Package module name
+	Exports   int      // Number of re-exported names
+	FanOut    int      // Number of modules the names are re-exported from
+	Sources   []string // Modules the names are re-exported from
+	Collapsed bool     // Imports through the facade were routed to the defining modules
+}
+
+// UnanalyzableImport is an informational finding for a dynamic import call,
+// such as importlib.import_module(name), whose target is not a string literal.
+// No dependency edge is created for it.
+type UnanalyzableImport struct {
+	Module   string // Module containing the call
+	FilePath string // File containing the call
+	Line     int    // Line number of the call
+	Function string // Called function, e.g. "importlib.import_module"
+}
+
+// DependencyOrder lists modules in topological order, dependencies first.
+// Modules that form a cycle cannot be ordered among themselves and are
+// grouped into a single strongly connected component.
+type DependencyOrder struct {
+	Components []OrderedComponent // Components in dependency order
+	Levels     int                // Number of distinct levels
+	IsAcyclic  bool               // True when no component contains a cycle
+}
+
+// OrderedComponent is a module, or a group of mutually dependent modules,
+// in the dependency order
+type OrderedComponent struct {
+	Modules []string // Modules in the component
+	Level   int      // 0 = no dependencies; otherwise 1 + highest level among dependencies
+	Cyclic  bool     // True when the modules form a circular dependency
+}
+
+// ExternalImportCategory classifies an imported top-level package
+type ExternalImportCategory string
+
+const (
+	ExternalImportStdlib     ExternalImportCategory = "stdlib"
+	ExternalImportThirdParty ExternalImportCategory = "third_party"
+	ExternalImportUnknown    ExternalImportCategory = "unknown"
+)
+
+// ImportInventory lists every package imported from outside the project and,
+// when a dependency manifest is found, cross-checks imports against it
+type ImportInventory struct {
+	Packages []ExternalPackage // Imported top-level packages, sorted by category then name
+
+	StdlibCount     int // Number of standard library packages
+	ThirdPartyCount int // Number of third-party packages
+	UnknownCount    int // Number of packages that could not be classified
+
+	ManifestFiles  []string             // pyproject.toml / requirements files that were read
+	Declared       []DeclaredDependency // Dependencies declared in the manifests
+	Undeclared     []string             // Imported non-stdlib packages missing from the manifests
+	UnusedDeclared []DeclaredDependency // Declared dependencies that are never imported
+}
+
+// ExternalPackage is a top-level package imported by the project
+type ExternalPackage struct {
+	Name         string                 // Top-level import name (e.g. "yaml")
+	Category     ExternalImportCategory // stdlib, third_party or unknown
+	Distribution string                 // Declared distribution providing the package, if known
+	UsageCount   int                    // Number of import statements
+	Modules      []string               // Fully qualified modules imported (e.g. "yaml.constructor")
+	Locations    []ImportLocation       // Where the package is imported
+}
+
+// ImportLocation is a single import statement of an external package
+type ImportLocation struct {
+	FilePath         string // File containing the import
+	Line             int    // Line number of the import
+	Module           string // Importing project module
+	TypeCheckingOnly bool   // Import only happens under TYPE_CHECKING
+}
+
+// DeclaredDependency is a dependency listed in a manifest file
+type DeclaredDependency struct {
+	Name        string   // Distribution name as declared
+	ImportNames []string // Top-level import names the distribution provides
+	Source      string   // Manifest file declaring the dependency
+	Optional    bool     // Declared as an optional, extra or development dependency
 }
 
 // ModuleDependencyMetrics contains dependency metrics for a single module
@@ -173,14 +287,18 @@ type CircularDependency struct {
 	Severity     CycleSeverity    // Severity level
 	Size         int              // Number of modules
 	Description  string           // Human-readable description
+	ModuleSizes  map[string]int   // Lines of code of each module in the cycle
+	HeaviestEdge *DependencyPath  // Direct dependency importing the most names
 }
 
 // DependencyPath represents a path of dependencies
 type DependencyPath struct {
-	From   string   // Starting module
-	To     string   // Ending module
-	Path   []string // Complete path
-	Length int      // Path length
+	From    string   // Starting module
+	To      string   // Ending module
+	Path    []string // Complete path
+	Length  int      // Path length
+	Weight  int      // Names imported over the direct edge From -> To, when known
+	Symbols []string // The imported names, sorted
 }
 
 // CycleSeverity represents severity of circular dependencies
@@ -225,6 +343,9 @@ type ArchitectureAnalysisResult struct {
 	LayerAnalysis          *LayerAnalysis          // Layer violation analysis
 	CohesionAnalysis       *CohesionAnalysis       // Package cohesion analysis
 	ResponsibilityAnalysis *ResponsibilityAnalysis // SRP violation analysis
+	GodModuleAnalysis      *GodModuleAnalysis      // God module detection
+	LayerDetection         *LayerDetection         // Auto-detected layers; nil when layers were configured
+	RuleSets               []RuleSetResult         // Compliance of each configured rule set
 
 	// Detailed violations
 	Violations        []ArchitectureViolation   // All architecture violations
@@ -233,6 +354,10 @@ type ArchitectureAnalysisResult struct {
 	// Architecture recommendations
 	Recommendations    []ArchitectureRecommendation // Specific recommendations
 	RefactoringTargets []string                     // Modules needing refactoring
+
+	// Rules the modules were checked against, after presets and
+	// auto-detection; nil when neither layers nor rule sets were defined
+	Rules *ArchitectureRules `json:"-" yaml:"-"`
 }
 
 // LayerAnalysis contains layer architecture validation results
@@ -254,6 +379,7 @@ type LayerViolation struct {
 	Severity    ViolationSeverity // Severity of violation
 	Description string            // Description of violation
 	Suggestion  string            // Suggested fix
+	EdgeKind    string            // Kind of import edge (import, type_checking, lazy, conditional)
 }
 
 // CohesionAnalysis contains package cohesion analysis
@@ -278,6 +404,83 @@ type SRPViolation struct {
 	Suggestion       string            // Refactoring suggestion
 }
 
+// LayerDetection describes layers pyscn detected from module names because
+// none were configured
+type LayerDetection struct {
+	Assignments []LayerAssignment // Module -> layer, sorted by module
+}
+
+// LayerAssignment is the layer detected for one module
+type LayerAssignment struct {
+	Module     string   // Module name
+	Layer      string   // Detected layer
+	Package    string   // Module path through the segment that matched the layer
+	Confidence float64  // 0-1: how clearly the name and the dependencies point to the layer
+	Candidates []string // Other layers the module name matched, best first
+}
+
+// RuleSetResult is the compliance of the modules a rule set applies to
+type RuleSetResult struct {
+	Name               string
+	Scopes             []string // Directories the rule set was applied to
+	ModulesAnalyzed    int
+	ComplianceScore    float64 // 1 - WeightedViolations/TotalRules, clamped to [0,1]
+	TotalViolations    int
+	WeightedViolations int
+	TotalRules         int // Dependencies checked within the scopes
+}
+
+// ImportDecision is how architecture rules treat a dependency
+type ImportDecision string
+
+const (
+	ImportDecisionAllowed   ImportDecision = "allowed"   // A rule allows it
+	ImportDecisionWarning   ImportDecision = "warning"   // Permitted but reported as a warning
+	ImportDecisionDenied    ImportDecision = "denied"    // Reported as an error
+	ImportDecisionUnchecked ImportDecision = "unchecked" // No rule applies
+)
+
+// ImportCheck explains how the architecture rules treat a dependency that
+// may not exist yet
+type ImportCheck struct {
+	From     string
+	To       string
+	Verdicts []ImportVerdict // The global rules first, then each rule set whose scope holds both modules
+}
+
+// ImportVerdict is the outcome of one set of rules for an ImportCheck
+type ImportVerdict struct {
+	RuleSet     string // Rule set name; empty for the global rules
+	Scope       string // Directory the rule set was applied to
+	FromLayer   string
+	FromPattern string // Layer package that matched the importing module
+	ToLayer     string
+	ToPattern   string // Layer package that matched the imported module
+	Decision    ImportDecision
+	Rule        string // Rule that decided, e.g. "domain !> presentation"
+	Reason      string
+}
+
+// GodModuleAnalysis contains god module detection results
+type GodModuleAnalysis struct {
+	GodModules []GodModule // Modules exceeding every god module threshold
+}
+
+// GodModule is a module that is large, defines many classes and functions
+// and is heavily coupled in both directions at once
+type GodModule struct {
+	Module     string            // Module name
+	FilePath   string            // Path of the module file
+	Lines      int               // Lines in the module
+	Classes    int               // Classes defined
+	Functions  int               // Functions and methods defined
+	FanIn      int               // Modules importing this module
+	FanOut     int               // Modules imported by this module
+	Severity   ViolationSeverity // Severity level
+	Clusters   [][]string        // Top-level classes and functions referencing each other, largest group first
+	Suggestion string            // Decomposition suggestion
+}
+
 // ArchitectureViolation represents an architecture rule violation
 type ArchitectureViolation struct {
 	Type        ViolationType     // Type of violation
@@ -288,6 +491,9 @@ type ArchitectureViolation struct {
 	Description string            // Human-readable description
 	Suggestion  string            // Suggested remediation
 	Location    *SourceLocation   // Location in code (if available)
+	EdgeKind    string            // Kind of import edge for dependency violations (import, type_checking, lazy, conditional)
+	RuleSet     string            // Rule set that reported the violation; empty for the global rules
+	Layer       string            // Layer of Module for layer violations
 }
 
 // ViolationType represents the type of architecture violation
@@ -299,6 +505,7 @@ const (
 	ViolationTypeCoupling       ViolationType = "coupling"       // Excessive coupling
 	ViolationTypeResponsibility ViolationType = "responsibility" // SRP violation
 	ViolationTypeCohesion       ViolationType = "cohesion"       // Low cohesion
+	ViolationTypeGodModule      ViolationType = "god-module"     // Oversized, heavily coupled module
 )
 
 // ViolationSeverity represents the severity of a violation
@@ -433,6 +640,18 @@ type ArchitectureRules struct {
 	StrictMode        bool     `json:"strict_mode" yaml:"strict_mode"`
 	AllowedPatterns   []string `json:"allowed_patterns" yaml:"allowed_patterns"`
 	ForbiddenPatterns []string `json:"forbidden_patterns" yaml:"forbidden_patterns"`
+
+	// Edge kinds (type_checking, lazy, conditional, import) that layer rules
+	// skip entirely, and those whose violations are downgraded to info
+	IgnoreEdgeKinds    []string `json:"ignore_edge_kinds" yaml:"ignore_edge_kinds"`
+	DowngradeEdgeKinds []string `json:"downgrade_edge_kinds" yaml:"downgrade_edge_kinds"`
+
+	// RuleSets apply their own layers and rules to sub-paths, independently
+	// of the layers above
+	RuleSets []ArchitectureRuleSet `json:"rule_sets" yaml:"rule_sets"`
+
+	// Detection is set when the layers were auto-detected
+	Detection *LayerDetection `json:"-" yaml:"-"`
 }
 
 // Layer defines an architectural layer
@@ -442,6 +661,17 @@ type Layer struct {
 	Description string   `json:"description" yaml:"description"`
 }
 
+// ArchitectureRuleSet is a named set of layers and rules. It is applied to
+// the modules under each directory matching one of Paths on their own; only
+// dependencies within one directory are checked against it.
+type ArchitectureRuleSet struct {
+	Name       string      `json:"name" yaml:"name"`
+	Paths      []string    `json:"paths" yaml:"paths"` // Directory globs relative to the project root, e.g. "services/*"
+	Layers     []Layer     `json:"layers" yaml:"layers"`
+	Rules      []LayerRule `json:"rules" yaml:"rules"`
+	StrictMode bool        `json:"strict_mode" yaml:"strict_mode"`
+}
+
 // LayerRule defines a dependency rule between layers
 type LayerRule struct {
 	From  string   `json:"from" yaml:"from"`
@@ -451,6 +681,9 @@ type LayerRule struct {
 	// on one of these emits a warning instead of an error. Used e.g. by the MVC
 	// preset for view -> model direct access.
 	Warn []string `json:"warn" yaml:"warn"`
+	// Budget is the number of violations of dependencies from the layer that
+	// `pyscn arch check` tolerates on top of the grandfathered ones
+	Budget int `json:"budget,omitempty" yaml:"budget,omitempty"`
 }
 
 // PackageRule defines rules for packages
@@ -523,6 +756,13 @@ func DefaultSystemAnalysisRequest() *SystemAnalysisRequest {
 		MaxResponsibilities:             DefaultArchitectureMaxResponsibilities,
 		CohesionViolationSeverity:       ViolationSeverityWarning,
 		ResponsibilityViolationSeverity: ViolationSeverityWarning,
+		ValidateGodModules:              BoolPtr(true),
+		GodModuleMaxLines:               DefaultGodModuleMaxLines,
+		GodModuleMaxDefinitions:         DefaultGodModuleMaxDefinitions,
+		GodModuleMaxFanIn:               DefaultGodModuleMaxFanIn,
+		GodModuleMaxFanOut:              DefaultGodModuleMaxFanOut,
+		FacadeMinExports:                DefaultFacadeMinExports,
+		CollapseFacades:                 BoolPtr(false),
 		IncludePatterns:                 DefaultPythonModuleIncludePatterns(),
 		ExcludePatterns:                 DefaultAnalysisExcludePatterns(),
 		ComplexityData:                  make(map[string]int),
@@ -538,6 +778,11 @@ type SourceLocation struct {
 	EndLine   int    `json:"end_line" yaml:"end_line"`
 	StartCol  int    `json:"start_col" yaml:"start_col"`
 	EndCol    int    `json:"end_col" yaml:"end_col"`
+
+	// StartByte and EndByte are the byte offsets of the range in the file;
+	// the columns count code points
+	StartByte int `json:"start_byte" yaml:"start_byte"`
+	EndByte   int `json:"end_byte" yaml:"end_byte"`
 }
 
 // String returns string representation of SourceLocation
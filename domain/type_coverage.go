@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"context"
+)
+
+// TypeCoverageFunction holds the annotation counts of one function. The
+// implicit self/cls parameter of methods is not counted, and neither is the
+// return type of __init__.
+type TypeCoverageFunction struct {
+	// Name is the qualified name, e.g. "Parser.parse"
+	Name string `json:"name" yaml:"name"`
+
+	// Location of the definition
+	Location SourceLocation `json:"location" yaml:"location"`
+
+	// Public is true for functions that are part of the public API
+	Public bool `json:"public" yaml:"public"`
+
+	// Parameter annotation counts
+	Parameters          int `json:"parameters" yaml:"parameters"`
+	AnnotatedParameters int `json:"annotated_parameters" yaml:"annotated_parameters"`
+
+	// ReturnCounted is false when the return type is not expected (__init__)
+	ReturnCounted   bool `json:"return_counted" yaml:"return_counted"`
+	ReturnAnnotated bool `json:"return_annotated" yaml:"return_annotated"`
+
+	// Coverage is the annotated percentage (0-100) of parameters and return type
+	Coverage float64 `json:"coverage" yaml:"coverage"`
+}
+
+// TypeCoverageCounts aggregates annotation counts over a set of functions
+type TypeCoverageCounts struct {
+	Functions               int `json:"functions" yaml:"functions"`
+	FullyAnnotatedFunctions int `json:"fully_annotated_functions" yaml:"fully_annotated_functions"`
+	Parameters              int `json:"parameters" yaml:"parameters"`
+	AnnotatedParameters     int `json:"annotated_parameters" yaml:"annotated_parameters"`
+	Returns                 int `json:"returns" yaml:"returns"`
+	AnnotatedReturns        int `json:"annotated_returns" yaml:"annotated_returns"`
+}
+
+// TypeCoverageFile holds per-file annotation coverage
+type TypeCoverageFile struct {
+	FilePath string `json:"file_path" yaml:"file_path"`
+
+	// Functions lists every function in the file in source order
+	Functions []TypeCoverageFunction `json:"functions" yaml:"functions"`
+
+	// Counts aggregates the functions in this file
+	Counts TypeCoverageCounts `json:"counts" yaml:"counts"`
+
+	// Coverage is the annotated percentage (0-100); 100 when there is nothing to annotate
+	Coverage float64 `json:"coverage" yaml:"coverage"`
+}
+
+// TypeCoverageFinding represents a public function without any annotation
+type TypeCoverageFinding struct {
+	// Name is the qualified name of the function
+	Name string `json:"name" yaml:"name"`
+
+	// Location of the definition
+	Location SourceLocation `json:"location" yaml:"location"`
+
+	// Human-readable description of the issue
+	Description string `json:"description" yaml:"description"`
+}
+
+// TypeCoverageRequest represents a request for type annotation coverage analysis
+type TypeCoverageRequest struct {
+	// Input files or directories to analyze
+	Paths []string
+
+	// Analysis options
+	Recursive       *bool
+	IncludePatterns []string
+	ExcludePatterns []string
+
+	// Configuration
+	ConfigPath string
+}
+
+// TypeCoverageSummary represents project-wide annotation coverage
+type TypeCoverageSummary struct {
+	TypeCoverageCounts `yaml:",inline"`
+
+	// Coverage is the annotated percentage (0-100) of all parameters and
+	// return types; 100 when there is nothing to annotate
+	Coverage float64 `json:"coverage" yaml:"coverage"`
+
+	// ParameterCoverage and ReturnCoverage break Coverage down (0-100)
+	ParameterCoverage float64 `json:"parameter_coverage" yaml:"parameter_coverage"`
+	ReturnCoverage    float64 `json:"return_coverage" yaml:"return_coverage"`
+
+	// UnannotatedPublicFunctions counts public functions without any annotation
+	UnannotatedPublicFunctions int `json:"unannotated_public_functions" yaml:"unannotated_public_functions"`
+
+	// FilesAnalyzed is the number of files analyzed
+	FilesAnalyzed int `json:"files_analyzed" yaml:"files_analyzed"`
+}
+
+// TypeCoverageResponse represents the complete type annotation coverage result
+type TypeCoverageResponse struct {
+	// Files holds per-file and per-function coverage, ordered by file path
+	Files []TypeCoverageFile `json:"files" yaml:"files"`
+
+	// Findings lists the fully unannotated public functions
+	Findings []TypeCoverageFinding `json:"findings" yaml:"findings"`
+
+	// Summary contains project-wide statistics
+	Summary TypeCoverageSummary `json:"summary" yaml:"summary"`
+
+	// Errors contains errors encountered during analysis
+	Errors []string `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+// TypeCoverageService defines the interface for type annotation coverage analysis
+type TypeCoverageService interface {
+	// Analyze measures annotation coverage for the files in the request
+	Analyze(ctx context.Context, req TypeCoverageRequest) (*TypeCoverageResponse, error)
+}
+
+// TypeCoverageConfigurationLoader defines the interface for loading type coverage configuration
+type TypeCoverageConfigurationLoader interface {
+	// LoadConfig loads configuration from the specified path
+	LoadConfig(path string) (*TypeCoverageRequest, error)
+
+	// LoadDefaultConfig loads the default configuration
+	LoadDefaultConfig() *TypeCoverageRequest
+
+	// MergeConfig merges request values with configuration file values
+	MergeConfig(base *TypeCoverageRequest, override *TypeCoverageRequest) *TypeCoverageRequest
+}
+
+// DefaultTypeCoverageRequest returns a TypeCoverageRequest with default values
+func DefaultTypeCoverageRequest() *TypeCoverageRequest {
+	return &TypeCoverageRequest{
+		Recursive:       BoolPtr(true),
+		IncludePatterns: DefaultAnalysisIncludePatterns(),
+		ExcludePatterns: []string{},
+	}
+}
+
+// Add accumulates the counts of one function
+func (c *TypeCoverageCounts) Add(fn TypeCoverageFunction) {
+	c.Functions++
+	c.Parameters += fn.Parameters
+	c.AnnotatedParameters += fn.AnnotatedParameters
+	if fn.ReturnCounted {
+		c.Returns++
+		if fn.ReturnAnnotated {
+			c.AnnotatedReturns++
+		}
+	}
+	if fn.AnnotatedParameters == fn.Parameters && (!fn.ReturnCounted || fn.ReturnAnnotated) {
+		c.FullyAnnotatedFunctions++
+	}
+}
+
+// Merge accumulates another set of counts
+func (c *TypeCoverageCounts) Merge(other TypeCoverageCounts) {
+	c.Functions += other.Functions
+	c.FullyAnnotatedFunctions += other.FullyAnnotatedFunctions
+	c.Parameters += other.Parameters
+	c.AnnotatedParameters += other.AnnotatedParameters
+	c.Returns += other.Returns
+	c.AnnotatedReturns += other.AnnotatedReturns
+}
+
+// Percentage returns the annotated percentage of parameters and return types;
+// 100 when there is nothing to annotate
+func (c TypeCoverageCounts) Percentage() float64 {
+	return coveragePercentage(c.AnnotatedParameters+c.AnnotatedReturns, c.Parameters+c.Returns)
+}
+
+// Validate validates the request parameters
+func (r *TypeCoverageRequest) Validate() error {
+	if len(r.Paths) == 0 {
+		return NewInvalidInputError("at least one path must be specified", nil)
+	}
+	return nil
+}
+
+// coveragePercentage returns part as a percentage of total, or 100 when total is 0
+func coveragePercentage(part, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+	return float64(part) / float64(total) * 100
+}
@@ -0,0 +1,28 @@
+package domain
+
+// Directory names of common vendoring layouts. Python files under a directory
+// with one of these names are vendored.
+var VendoredDirectoryNames = []string{"vendor", "_vendor", "third_party"}
+
+// Why a file counts as vendored
+const (
+	// VendoredReasonLayout marks files under a vendoring directory
+	VendoredReasonLayout = "layout"
+
+	// VendoredReasonMarker marks modules, or packages through their
+	// __init__.py, whose leading comments include a "# vendored" line
+	VendoredReasonMarker = "marker"
+)
+
+// VendoredCode is a vendored directory or module left out of the analyses
+// and the scores
+type VendoredCode struct {
+	// Path is the vendored directory, or the module for a marked module
+	Path string `json:"path" yaml:"path"`
+
+	// Reason is VendoredReasonLayout or VendoredReasonMarker
+	Reason string `json:"reason" yaml:"reason"`
+
+	// Files is the number of Python files found under Path
+	Files int `json:"files" yaml:"files"`
+}
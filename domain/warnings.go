@@ -0,0 +1,55 @@
+package domain
+
+import "context"
+
+// AnalysisWarning is a non-fatal problem an analysis ran into that makes its
+// results less complete: a file it skipped, a limit that cut it short
+type AnalysisWarning struct {
+	// Analyzer is the ProgressStage* name of the analysis, or the report
+	// step, that raised the warning
+	Analyzer string `json:"analyzer" yaml:"analyzer"`
+	File     string `json:"file,omitempty" yaml:"file,omitempty"`
+	Message  string `json:"message" yaml:"message"`
+}
+
+// WarningReporter collects the warnings of analyses. Implementations must be
+// safe for concurrent use: analyses run in parallel.
+type WarningReporter interface {
+	ReportWarning(warning AnalysisWarning)
+}
+
+// WarningReporterFunc adapts a function to WarningReporter
+type WarningReporterFunc func(warning AnalysisWarning)
+
+// ReportWarning calls f
+func (f WarningReporterFunc) ReportWarning(warning AnalysisWarning) {
+	f(warning)
+}
+
+type warningReporterKey struct{}
+
+// WithWarningReporter returns a context whose analyses report their
+// warnings to reporter
+func WithWarningReporter(ctx context.Context, reporter WarningReporter) context.Context {
+	return context.WithValue(ctx, warningReporterKey{}, reporter)
+}
+
+// WarningReporterFrom returns the reporter attached to ctx, or nil
+func WarningReporterFrom(ctx context.Context) WarningReporter {
+	if ctx == nil {
+		return nil
+	}
+	reporter, _ := ctx.Value(warningReporterKey{}).(WarningReporter)
+	return reporter
+}
+
+// ReportWarning reports a warning to the reporter attached to ctx. It
+// returns false when there is none, so callers can fall back to printing it.
+func ReportWarning(ctx context.Context, warning AnalysisWarning) bool {
+	reporter := WarningReporterFrom(ctx)
+	if reporter == nil {
+		return false
+	}
+	reporter.ReportWarning(warning)
+	return true
+}
@@ -0,0 +1,20 @@
+package domain
+
+// WorkspaceReport holds the results of analyzing several independent projects
+// in one invocation. Each target is analyzed on its own, with its own project
+// root and configuration, so targets never share a dependency graph.
+type WorkspaceReport struct {
+	Targets []WorkspaceTarget `json:"targets" yaml:"targets"`
+}
+
+// WorkspaceTarget is the analysis result for a single workspace target
+type WorkspaceTarget struct {
+	// Path is the target as given on the command line
+	Path string `json:"path" yaml:"path"`
+
+	// Result is the full analysis of the target; nil when it could not be analyzed
+	Result *AnalyzeResponse `json:"result,omitempty" yaml:"result,omitempty"`
+
+	// Error describes why the target failed, if it did
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
@@ -177,7 +177,7 @@ func TestCloneOutputFormatterIntegration(t *testing.T) {
 	}
 
 	clonePair := &domain.ClonePair{
-		ID:         1,
+		ID:         "1",
 		Clone1:     clone1,
 		Clone2:     clone2,
 		Similarity: domain.DefaultType1CloneThreshold,
@@ -0,0 +1,599 @@
+package analyzer
+
+import (
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// Kinds of parameters, by how callers can pass them
+const (
+	apiPositionalOnly      = "positional-only"
+	apiPositionalOrKeyword = "positional-or-keyword"
+	apiVarPositional       = "var-positional"
+	apiKeywordOnly         = "keyword-only"
+	apiVarKeyword          = "var-keyword"
+)
+
+// apiParameter is a parameter of a public function
+type apiParameter struct {
+	name       string
+	kind       string
+	annotation string
+	// defaultValue is the source of the default, normalized like the
+	// signature; hasDefault tells an empty one from none
+	defaultValue string
+	hasDefault   bool
+	// position is the index among the parameters that can be passed
+	// positionally, or -1
+	position int
+}
+
+// positional reports whether callers can pass the parameter positionally
+func (p *apiParameter) positional() bool {
+	return p.kind == apiPositionalOnly || p.kind == apiPositionalOrKeyword
+}
+
+// apiSymbol is a public class or function of a module
+type apiSymbol struct {
+	name     string
+	kind     domain.DiffSymbolKind
+	node     *parser.Node
+	children []*apiSymbol
+
+	signature string
+	async     bool
+	params    []*apiParameter
+	returns   string
+	bases     []string
+}
+
+// APISurface is the public API of one version of a module: its public
+// functions and classes, and the public methods and nested classes of
+// those classes
+type APISurface struct {
+	Path   string
+	Module string
+
+	version *diffVersion
+	symbols []*apiSymbol
+}
+
+// APIModuleName returns the dotted module name of a file, without a
+// leading src directory: "src/shop/cart.py" is "shop.cart" and
+// "shop/__init__.py" is "shop"
+func APIModuleName(filePath string) string {
+	p := strings.TrimSuffix(path.Clean(strings.ReplaceAll(filePath, "\\", "/")), ".py")
+	p = strings.TrimPrefix(p, "./")
+	p = strings.TrimPrefix(p, "src/")
+	p = strings.TrimSuffix(p, "/__init__")
+	return strings.ReplaceAll(p, "/", ".")
+}
+
+// IsPublicAPIModule reports whether a file is part of the public API: a
+// Python module outside tests whose name and packages do not start with an
+// underscore, other than __init__.py
+func IsPublicAPIModule(filePath string) bool {
+	p := path.Clean(strings.ReplaceAll(filePath, "\\", "/"))
+	if !strings.HasSuffix(p, ".py") {
+		return false
+	}
+	parts := strings.Split(strings.TrimSuffix(p, ".py"), "/")
+	for i, part := range parts {
+		if part == "." || part == ".." {
+			continue
+		}
+		if part == "tests" || part == "test" {
+			return false
+		}
+		last := i == len(parts)-1
+		if last && (part == "conftest" || strings.HasPrefix(part, "test_") || strings.HasSuffix(part, "_test")) {
+			return false
+		}
+		if strings.HasPrefix(part, "_") && !(last && part == "__init__") {
+			return false
+		}
+	}
+	return true
+}
+
+// ExtractAPISurface returns the public API of a module. A nil parse result
+// stands for a module that does not exist, with an empty API. When the
+// module lists its names in __all__, only those are public at module level.
+func ExtractAPISurface(filePath string, result *parser.ParseResult) *APISurface {
+	surface := &APISurface{
+		Path:    filePath,
+		Module:  APIModuleName(filePath),
+		version: &diffVersion{path: filePath, symbols: make(map[*parser.Node]bool)},
+	}
+	if result == nil || result.AST == nil {
+		return surface
+	}
+	surface.version.source = result.SourceCode
+
+	exported, hasAll := moduleAllNames(result.AST.Body)
+	public := func(name string) bool {
+		if hasAll {
+			return exported[name]
+		}
+		return !strings.HasPrefix(name, "_")
+	}
+	surface.symbols = surface.collect(result.AST.Body, "", domain.DiffSymbolModule, public)
+	return surface
+}
+
+// moduleAllNames returns the names a module lists in __all__
+func moduleAllNames(body []*parser.Node) (map[string]bool, bool) {
+	for _, stmt := range body {
+		if stmt == nil || stmt.Type != parser.NodeAssign {
+			continue
+		}
+		for _, target := range stmt.Targets {
+			if target.Type != parser.NodeName || target.Name != "__all__" {
+				continue
+			}
+			value, ok := stmt.Value.(*parser.Node)
+			if !ok || value == nil || (value.Type != parser.NodeList && value.Type != parser.NodeTuple) {
+				return nil, false
+			}
+			names := make(map[string]bool)
+			for _, child := range value.Children {
+				if name, ok := child.Value.(string); ok && child.Type == parser.NodeConstant {
+					names[name] = true
+				}
+			}
+			return names, true
+		}
+	}
+	return nil, false
+}
+
+// collect returns the public definitions of a scope, looking through
+// compound statements such as if TYPE_CHECKING blocks. A name defined more
+// than once is represented by its implementation: the last definition that
+// is not an overload or a property setter or deleter.
+func (s *APISurface) collect(body []*parser.Node, prefix string, parent domain.DiffSymbolKind, public func(string) bool) []*apiSymbol {
+	var symbols []*apiSymbol
+	byName := make(map[string]int)
+
+	var visit func(body []*parser.Node)
+	visit = func(body []*parser.Node) {
+		for _, stmt := range body {
+			if stmt == nil {
+				continue
+			}
+			switch stmt.Type {
+			case parser.NodeClassDef, parser.NodeFunctionDef, parser.NodeAsyncFunctionDef:
+				if !public(stmt.Name) {
+					continue
+				}
+				symbol := s.symbol(stmt, prefix, parent)
+				if i, ok := byName[stmt.Name]; ok {
+					if !isOverload(symbols[i].node) && (isOverload(stmt) || isAccessorRedefinition(stmt)) {
+						continue
+					}
+					symbols[i] = symbol
+					continue
+				}
+				byName[stmt.Name] = len(symbols)
+				symbols = append(symbols, symbol)
+
+			case parser.NodeIf, parser.NodeTry, parser.NodeWith, parser.NodeAsyncWith:
+				visit(stmt.Body)
+				visit(stmt.Orelse)
+				for _, handler := range stmt.Handlers {
+					visit(handler.Body)
+				}
+				visit(stmt.Finalbody)
+			}
+		}
+	}
+	visit(body)
+	return symbols
+}
+
+// symbol describes a public definition
+func (s *APISurface) symbol(node *parser.Node, prefix string, parent domain.DiffSymbolKind) *apiSymbol {
+	symbol := &apiSymbol{
+		name:      prefix + node.Name,
+		node:      node,
+		signature: s.version.signature(node),
+	}
+	header := symbol.signature
+	open := strings.Index(header, "(")
+	var inner, after string
+	if open >= 0 {
+		if end := matchingParen(header, open); end > open {
+			inner, after = header[open+1:end], header[end+1:]
+		}
+	}
+
+	if node.Type == parser.NodeClassDef {
+		symbol.kind = domain.DiffSymbolClass
+		for _, base := range splitTopLevel(inner, ',') {
+			if base = strings.TrimSpace(base); base != "" {
+				symbol.bases = append(symbol.bases, base)
+			}
+		}
+		classPublic := func(name string) bool { return !strings.HasPrefix(name, "_") || isDunderName(name) }
+		symbol.children = s.collect(node.Body, symbol.name+".", domain.DiffSymbolClass, classPublic)
+		return symbol
+	}
+
+	symbol.kind = domain.DiffSymbolFunction
+	if parent == domain.DiffSymbolClass {
+		symbol.kind = domain.DiffSymbolMethod
+	}
+	symbol.async = node.Type == parser.NodeAsyncFunctionDef
+	symbol.params = parseAPIParameters(inner)
+	if symbol.kind == domain.DiffSymbolMethod && !hasDecorator(node, "staticmethod") && len(symbol.params) > 0 && symbol.params[0].positional() {
+		// self or cls
+		symbol.params = symbol.params[1:]
+	}
+	position := 0
+	for _, param := range symbol.params {
+		param.position = -1
+		if param.positional() {
+			param.position = position
+			position++
+		}
+	}
+	if returns, ok := strings.CutPrefix(strings.TrimSpace(after), "->"); ok {
+		symbol.returns = strings.TrimSpace(returns)
+	}
+	return symbol
+}
+
+// isAccessorRedefinition reports whether a function is the setter or
+// deleter of a property defined before it
+func isAccessorRedefinition(node *parser.Node) bool {
+	for _, decorator := range node.Decorator {
+		name := decoratorQualifiedName(decorator)
+		if hasDecoratorSuffix(name, ".setter") || hasDecoratorSuffix(name, ".deleter") {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDecorator reports whether a definition has a decorator of this name
+func hasDecorator(node *parser.Node, name string) bool {
+	for _, decorator := range node.Decorator {
+		qualified := decoratorQualifiedName(decorator)
+		if qualified == name || hasDecoratorSuffix(qualified, "."+name) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseAPIParameters parses the parameter list of a normalized signature
+func parseAPIParameters(list string) []*apiParameter {
+	var params []*apiParameter
+	kind := apiPositionalOrKeyword
+	for _, part := range splitTopLevel(list, ',') {
+		part = strings.TrimSpace(part)
+		switch part {
+		case "":
+			continue
+		case "/":
+			for _, param := range params {
+				param.kind = apiPositionalOnly
+			}
+			continue
+		case "*":
+			kind = apiKeywordOnly
+			continue
+		}
+
+		param := &apiParameter{kind: kind}
+		left, defaultValue, hasDefault := cutTopLevel(part, '=')
+		name, annotation, _ := cutTopLevel(left, ':')
+		param.name = strings.TrimSpace(name)
+		param.annotation = strings.TrimSpace(annotation)
+		param.defaultValue, param.hasDefault = strings.TrimSpace(defaultValue), hasDefault
+		switch {
+		case strings.HasPrefix(param.name, "**"):
+			param.kind = apiVarKeyword
+		case strings.HasPrefix(param.name, "*"):
+			param.kind = apiVarPositional
+			kind = apiKeywordOnly
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// matchingParen returns the index of the parenthesis closing the one at
+// open, or -1
+func matchingParen(text string, open int) int {
+	depth := 0
+	var quote byte
+	for i := open; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitTopLevel splits text at separators outside brackets and strings
+func splitTopLevel(text string, sep byte) []string {
+	var parts []string
+	for {
+		before, after, found := cutTopLevel(text, sep)
+		parts = append(parts, before)
+		if !found {
+			return parts
+		}
+		text = after
+	}
+}
+
+// cutTopLevel cuts text around the first separator outside brackets and
+// strings. An = that is part of a comparison operator is not a separator.
+func cutTopLevel(text string, sep byte) (before, after string, found bool) {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+		case c == sep && depth == 0:
+			if sep == '=' && (i+1 < len(text) && text[i+1] == '=' || i > 0 && strings.IndexByte("=!<>", text[i-1]) >= 0) {
+				continue
+			}
+			return text[:i], text[i+1:], true
+		}
+	}
+	return text, "", false
+}
+
+// DiffAPISurfaces compares two versions of a module's public API. Changes
+// follow the order of the new version, followed by removals in the order
+// of the old one. The members of an added or removed class are not
+// reported on their own.
+func DiffAPISurfaces(oldSurface, newSurface *APISurface) []domain.APIChange {
+	d := &apiDiffer{oldSurface: oldSurface, newSurface: newSurface, changes: []domain.APIChange{}}
+	d.diffScope(oldSurface.symbols, newSurface.symbols)
+	return d.changes
+}
+
+// apiDiffer collects the API changes of a module
+type apiDiffer struct {
+	oldSurface, newSurface *APISurface
+	changes                []domain.APIChange
+}
+
+// diffScope compares the public definitions of one scope
+func (d *apiDiffer) diffScope(oldSymbols, newSymbols []*apiSymbol) {
+	oldByName := make(map[string]*apiSymbol, len(oldSymbols))
+	for _, symbol := range oldSymbols {
+		oldByName[symbol.name] = symbol
+	}
+	matched := make(map[*apiSymbol]bool)
+
+	for _, newSymbol := range newSymbols {
+		oldSymbol := oldByName[newSymbol.name]
+		if oldSymbol == nil || (oldSymbol.kind == domain.DiffSymbolClass) != (newSymbol.kind == domain.DiffSymbolClass) {
+			d.add(domain.APIChange{Kind: domain.APISymbolAdded, Bump: domain.SemverMinor, NewSignature: newSymbol.signature}, newSymbol, d.newSurface)
+			continue
+		}
+		matched[oldSymbol] = true
+		if newSymbol.kind == domain.DiffSymbolClass {
+			d.diffClass(oldSymbol, newSymbol)
+			d.diffScope(oldSymbol.children, newSymbol.children)
+			continue
+		}
+		d.diffFunction(oldSymbol, newSymbol)
+	}
+
+	for _, oldSymbol := range oldSymbols {
+		if !matched[oldSymbol] {
+			d.add(domain.APIChange{Kind: domain.APISymbolRemoved, Bump: domain.SemverMajor, OldSignature: oldSymbol.signature}, oldSymbol, d.oldSurface)
+		}
+	}
+}
+
+// add records a change to a definition of a surface
+func (d *apiDiffer) add(change domain.APIChange, symbol *apiSymbol, surface *APISurface) {
+	change.Symbol = symbol.kind
+	change.Module = surface.Module
+	change.Name = symbol.name
+	change.Location = surface.version.location(symbol.node)
+	d.changes = append(d.changes, change)
+}
+
+// modified records a change to a definition present in both versions
+func (d *apiDiffer) modified(change domain.APIChange, oldSymbol, newSymbol *apiSymbol) {
+	change.Module = d.newSurface.Module
+	change.OldSignature = oldSymbol.signature
+	change.NewSignature = newSymbol.signature
+	d.add(change, newSymbol, d.newSurface)
+}
+
+// diffClass compares the base classes of a class. Removing a base breaks
+// isinstance checks and inherited members; adding one does not.
+func (d *apiDiffer) diffClass(oldSymbol, newSymbol *apiSymbol) {
+	if strings.Join(oldSymbol.bases, ", ") == strings.Join(newSymbol.bases, ", ") {
+		return
+	}
+	bump := domain.SemverMinor
+	for _, base := range oldSymbol.bases {
+		if !slices.Contains(newSymbol.bases, base) {
+			bump = domain.SemverMajor
+		}
+	}
+	d.modified(domain.APIChange{
+		Kind: domain.APIBasesChanged,
+		Old:  strings.Join(oldSymbol.bases, ", "),
+		New:  strings.Join(newSymbol.bases, ", "),
+		Bump: bump,
+	}, oldSymbol, newSymbol)
+}
+
+// diffFunction compares the signatures of a function. A change that can
+// break a call is major, a way to call it that did not work before is
+// minor, and annotation changes are patch changes.
+func (d *apiDiffer) diffFunction(oldSymbol, newSymbol *apiSymbol) {
+	if oldSymbol.async != newSymbol.async {
+		d.modified(domain.APIChange{
+			Kind: domain.APIAsyncChanged,
+			Old:  asyncLabel(oldSymbol.async),
+			New:  asyncLabel(newSymbol.async),
+			Bump: domain.SemverMajor,
+		}, oldSymbol, newSymbol)
+	}
+
+	pairs, added := matchAPIParameters(oldSymbol.params, newSymbol.params)
+	for _, pair := range pairs {
+		oldParam, newParam := pair[0], pair[1]
+		if newParam == nil {
+			d.modified(domain.APIChange{Kind: domain.APIParameterRemoved, Parameter: oldParam.name, Bump: domain.SemverMajor}, oldSymbol, newSymbol)
+			continue
+		}
+		d.diffParameter(oldSymbol, newSymbol, oldParam, newParam)
+	}
+	for _, param := range added {
+		bump := domain.SemverMinor
+		if !param.hasDefault && param.kind != apiVarPositional && param.kind != apiVarKeyword {
+			bump = domain.SemverMajor
+		}
+		d.modified(domain.APIChange{Kind: domain.APIParameterAdded, Parameter: param.name, New: param.kind, Bump: bump}, oldSymbol, newSymbol)
+	}
+
+	if oldSymbol.returns != newSymbol.returns {
+		d.modified(domain.APIChange{
+			Kind: domain.APIReturnAnnotationChanged,
+			Old:  oldSymbol.returns,
+			New:  newSymbol.returns,
+			Bump: domain.SemverPatch,
+		}, oldSymbol, newSymbol)
+	}
+}
+
+// diffParameter compares a parameter present in both versions
+func (d *apiDiffer) diffParameter(oldSymbol, newSymbol *apiSymbol, oldParam, newParam *apiParameter) {
+	name := newParam.name
+	if oldParam.kind != newParam.kind {
+		// Keyword-only and positional-only parameters becoming regular
+		// ones can still be passed as before
+		bump := domain.SemverMajor
+		if newParam.kind == apiPositionalOrKeyword && (oldParam.kind == apiKeywordOnly || oldParam.kind == apiPositionalOnly) {
+			bump = domain.SemverMinor
+		}
+		d.modified(domain.APIChange{Kind: domain.APIParameterKindChanged, Parameter: name, Old: oldParam.kind, New: newParam.kind, Bump: bump}, oldSymbol, newSymbol)
+	} else if oldParam.positional() && oldParam.position != newParam.position {
+		d.modified(domain.APIChange{
+			Kind:      domain.APIParameterMoved,
+			Parameter: name,
+			Old:       strconv.Itoa(oldParam.position + 1),
+			New:       strconv.Itoa(newParam.position + 1),
+			Bump:      domain.SemverMajor,
+		}, oldSymbol, newSymbol)
+	}
+
+	switch {
+	case oldParam.hasDefault && !newParam.hasDefault:
+		d.modified(domain.APIChange{Kind: domain.APIDefaultRemoved, Parameter: name, Old: oldParam.defaultValue, Bump: domain.SemverMajor}, oldSymbol, newSymbol)
+	case !oldParam.hasDefault && newParam.hasDefault:
+		d.modified(domain.APIChange{Kind: domain.APIDefaultAdded, Parameter: name, New: newParam.defaultValue, Bump: domain.SemverMinor}, oldSymbol, newSymbol)
+	case oldParam.defaultValue != newParam.defaultValue:
+		// Calls still work, but may behave differently
+		d.modified(domain.APIChange{Kind: domain.APIDefaultChanged, Parameter: name, Old: oldParam.defaultValue, New: newParam.defaultValue, Bump: domain.SemverMinor}, oldSymbol, newSymbol)
+	}
+
+	if oldParam.annotation != newParam.annotation {
+		d.modified(domain.APIChange{Kind: domain.APIAnnotationChanged, Parameter: name, Old: oldParam.annotation, New: newParam.annotation, Bump: domain.SemverPatch}, oldSymbol, newSymbol)
+	}
+}
+
+// matchAPIParameters pairs the parameters of two versions of a function.
+// Parameters match by name; *args and **kwargs match whatever their name,
+// and positional-only parameters, whose names callers cannot use, by
+// position. Unmatched old parameters pair with nil.
+func matchAPIParameters(oldParams, newParams []*apiParameter) (pairs [][2]*apiParameter, added []*apiParameter) {
+	key := func(p *apiParameter) string {
+		switch p.kind {
+		case apiVarPositional:
+			return "*"
+		case apiVarKeyword:
+			return "**"
+		case apiPositionalOnly:
+			return "/" + strconv.Itoa(p.position)
+		}
+		return p.name
+	}
+	newByKey := make(map[string]*apiParameter, len(newParams))
+	for _, param := range newParams {
+		newByKey[key(param)] = param
+	}
+	for _, param := range newParams {
+		if _, ok := newByKey[param.name]; !ok {
+			newByKey[param.name] = param
+		}
+	}
+
+	matched := make(map[*apiParameter]bool)
+	for _, oldParam := range oldParams {
+		newParam := newByKey[key(oldParam)]
+		if newParam == nil || matched[newParam] {
+			newParam = nil
+		}
+		if newParam == nil {
+			// A parameter that became or stopped being positional-only
+			// keeps its name
+			if candidate := newByKey[oldParam.name]; candidate != nil && !matched[candidate] {
+				newParam = candidate
+			}
+		}
+		if newParam != nil {
+			matched[newParam] = true
+		}
+		pairs = append(pairs, [2]*apiParameter{oldParam, newParam})
+	}
+	for _, param := range newParams {
+		if !matched[param] {
+			added = append(added, param)
+		}
+	}
+	return pairs, added
+}
+
+// asyncLabel names whether a function is a coroutine
+func asyncLabel(async bool) string {
+	if async {
+		return "async"
+	}
+	return "sync"
+}
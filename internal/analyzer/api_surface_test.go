@@ -0,0 +1,197 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// diffAPISources renders the API changes between two sources as
+// "kind name(parameter) old->new bump" lines
+func diffAPISources(t *testing.T, oldSource, newSource string) string {
+	t.Helper()
+	parse := func(source string) *parser.ParseResult {
+		result, err := parser.New().Parse(context.Background(), []byte(source))
+		if err != nil {
+			t.Fatalf("failed to parse: %v", err)
+		}
+		return result
+	}
+	changes := DiffAPISurfaces(ExtractAPISurface("shop/cart.py", parse(oldSource)), ExtractAPISurface("shop/cart.py", parse(newSource)))
+	var lines []string
+	for _, change := range changes {
+		if change.Module != "shop.cart" {
+			t.Errorf("change %+v has module %q, want shop.cart", change, change.Module)
+		}
+		line := fmt.Sprintf("%s %s", change.Kind, change.Name)
+		if change.Parameter != "" {
+			line += "(" + change.Parameter + ")"
+		}
+		if change.Old != "" || change.New != "" {
+			line += fmt.Sprintf(" %s->%s", change.Old, change.New)
+		}
+		lines = append(lines, line+" "+string(change.Bump))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestDiffAPISurfaces(t *testing.T) {
+	tests := []struct {
+		name      string
+		oldSource string
+		newSource string
+		want      string
+	}{
+		{
+			name:      "body and formatting only",
+			oldSource: "def total(items, rate=1):\n    return sum(items)\n",
+			newSource: "def total(\n    items,  # the items\n    rate=1,\n):\n    return sum(items) * rate\n",
+			want:      "",
+		},
+		{
+			name:      "added and removed definitions",
+			oldSource: "def old():\n    pass\n\nclass Cart:\n    def add(self):\n        pass\n",
+			newSource: "def new():\n    pass\n\nclass Order:\n    def add(self):\n        pass\n",
+			want:      "added new minor\nadded Order minor\nremoved old major\nremoved Cart major",
+		},
+		{
+			name:      "private names are not API",
+			oldSource: "def _helper():\n    pass\n\nclass Cart:\n    def _check(self):\n        pass\n",
+			newSource: "class Cart:\n    def __len__(self):\n        return 0\n",
+			want:      "added Cart.__len__ minor",
+		},
+		{
+			name:      "__all__ restricts module names",
+			oldSource: "__all__ = ['total']\n\ndef total():\n    pass\n\ndef helper():\n    pass\n",
+			newSource: "__all__ = ['total']\n\ndef total():\n    pass\n\ndef helper(x):\n    pass\n",
+			want:      "",
+		},
+		{
+			name:      "parameters added",
+			oldSource: "def total(items):\n    pass\n",
+			newSource: "def total(items, rate, discount=0, *args, strict=False, **kwargs):\n    pass\n",
+			want: "parameter_added total(rate) ->positional-or-keyword major\n" +
+				"parameter_added total(discount) ->positional-or-keyword minor\n" +
+				"parameter_added total(*args) ->var-positional minor\n" +
+				"parameter_added total(strict) ->keyword-only minor\n" +
+				"parameter_added total(**kwargs) ->var-keyword minor",
+		},
+		{
+			name:      "parameters removed and moved",
+			oldSource: "def total(items, rate, discount):\n    pass\n",
+			newSource: "def total(rate, items):\n    pass\n",
+			want: "parameter_moved total(items) 1->2 major\n" +
+				"parameter_moved total(rate) 2->1 major\n" +
+				"parameter_removed total(discount) major",
+		},
+		{
+			name:      "parameter kinds",
+			oldSource: "def total(items, rate, *, strict=False):\n    pass\n",
+			newSource: "def total(items, /, *, rate, strict=False):\n    pass\n",
+			want: "parameter_kind_changed total(items) positional-or-keyword->positional-only major\n" +
+				"parameter_kind_changed total(rate) positional-or-keyword->keyword-only major",
+		},
+		{
+			name:      "keyword-only parameter becoming regular",
+			oldSource: "def total(items, *, rate=1):\n    pass\n",
+			newSource: "def total(items, rate=1):\n    pass\n",
+			want:      "parameter_kind_changed total(rate) keyword-only->positional-or-keyword minor",
+		},
+		{
+			name:      "renamed positional-only parameter",
+			oldSource: "def total(items, /):\n    pass\n",
+			newSource: "def total(values, /):\n    pass\n",
+			want:      "",
+		},
+		{
+			name:      "defaults",
+			oldSource: "def total(items, rate=1, strict=False, limit=None):\n    pass\n",
+			newSource: "def total(items=(), rate=2, strict=False, limit=None):\n    pass\n",
+			want: "default_added total(items) ->() minor\n" +
+				"default_changed total(rate) 1->2 minor",
+		},
+		{
+			name:      "default removed",
+			oldSource: "def total(items, rate=1):\n    pass\n",
+			newSource: "def total(items, rate):\n    pass\n",
+			want:      "default_removed total(rate) 1-> major",
+		},
+		{
+			name:      "annotations",
+			oldSource: "def total(items: list[int], rate: float = 1.0) -> int:\n    pass\n",
+			newSource: "def total(items: list[float], rate: float = 1.0) -> float:\n    pass\n",
+			want: "annotation_changed total(items) list[int]->list[float] patch\n" +
+				"return_annotation_changed total int->float patch",
+		},
+		{
+			name:      "methods ignore self and compare async",
+			oldSource: "class Cart:\n    def total(self, items):\n        pass\n\n    @staticmethod\n    def build(items):\n        pass\n",
+			newSource: "class Cart:\n    async def total(this, items):\n        pass\n\n    @staticmethod\n    def build():\n        pass\n",
+			want: "async_changed Cart.total sync->async major\n" +
+				"parameter_removed Cart.build(items) major",
+		},
+		{
+			name:      "base classes",
+			oldSource: "class Cart(Base, Sized):\n    pass\n\nclass Order(Base):\n    pass\n",
+			newSource: "class Cart(Base):\n    pass\n\nclass Order(Base, Sized):\n    pass\n",
+			want: "bases_changed Cart Base, Sized->Base major\n" +
+				"bases_changed Order Base->Base, Sized minor",
+		},
+		{
+			name:      "overloads and property setters",
+			oldSource: "class Cart:\n    @property\n    def size(self):\n        return 0\n\n    @size.setter\n    def size(self, value):\n        pass\n\n@overload\ndef get(key: int) -> int: ...\n@overload\ndef get(key: str) -> str: ...\ndef get(key):\n    pass\n",
+			newSource: "class Cart:\n    @property\n    def size(self):\n        return 0\n\n@overload\ndef get(key: int) -> int: ...\ndef get(key):\n    pass\n",
+			want:      "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffAPISources(t, tt.oldSource, tt.newSource); got != tt.want {
+				t.Errorf("changes:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffAPISurfacesMissingModule(t *testing.T) {
+	result, err := parser.New().Parse(context.Background(), []byte("def total():\n    pass\n"))
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	changes := DiffAPISurfaces(ExtractAPISurface("src/shop/cart.py", nil), ExtractAPISurface("src/shop/cart.py", result))
+	if len(changes) != 1 || changes[0].Kind != domain.APISymbolAdded || changes[0].QualifiedName() != "shop.cart.total" {
+		t.Fatalf("changes = %+v, want shop.cart.total added", changes)
+	}
+	if changes[0].NewSignature != "def total()" || changes[0].Location == nil || changes[0].Location.StartLine != 1 {
+		t.Errorf("change = %+v", changes[0])
+	}
+}
+
+func TestIsPublicAPIModule(t *testing.T) {
+	tests := map[string]bool{
+		"shop/cart.py":         true,
+		"shop/__init__.py":     true,
+		"src/shop/cart.py":     true,
+		"shop/_internal.py":    false,
+		"shop/_vendor/cart.py": false,
+		"shop/__main__.py":     false,
+		"tests/test_cart.py":   false,
+		"shop/test_cart.py":    false,
+		"shop/cart_test.py":    false,
+		"conftest.py":          false,
+		"shop/README.md":       false,
+	}
+	for path, want := range tests {
+		if got := IsPublicAPIModule(path); got != want {
+			t.Errorf("IsPublicAPIModule(%q) = %v, want %v", path, got, want)
+		}
+	}
+	if got := APIModuleName("src/shop/__init__.py"); got != "shop" {
+		t.Errorf("APIModuleName = %q, want shop", got)
+	}
+}
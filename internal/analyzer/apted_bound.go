@@ -0,0 +1,177 @@
+package analyzer
+
+import (
+	"math"
+	"sort"
+
+	coreapted "github.com/ludo-technologies/polyscan/core/apted"
+)
+
+// aptedExactTreeSize is the largest tree APTED compares exactly. Larger trees
+// take an approximate path whose distance is not bounded by the label
+// histogram, so they are never pruned.
+const aptedExactTreeSize = 500
+
+// maxCachedRenameCosts caps the rename cost cache, which grows with pairs of
+// identifiers over a large project
+const maxCachedRenameCosts = 1 << 16
+
+// aptedBoundEpsilon keeps pruning conservative against rounding in the sums
+// of edit costs
+const aptedBoundEpsilon = 1e-9
+
+// labelCount is the number of nodes of a tree carrying a label, with one of
+// them as the representative passed to the cost model
+type labelCount struct {
+	label string
+	node  *coreapted.TreeNode
+	count int
+}
+
+// labelHistogram counts the node labels of a tree, sorted by label
+type labelHistogram struct {
+	labels []labelCount
+	size   int
+}
+
+// newLabelHistogram counts the labels of a tree, or returns nil for trees
+// too large to be compared exactly
+func newLabelHistogram(root *coreapted.TreeNode) *labelHistogram {
+	if root == nil {
+		return nil
+	}
+	index := make(map[string]int)
+	histogram := &labelHistogram{}
+	stack := []*coreapted.TreeNode{root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		histogram.size++
+		if histogram.size > aptedExactTreeSize {
+			return nil
+		}
+		if i, ok := index[node.Label]; ok {
+			histogram.labels[i].count++
+		} else {
+			index[node.Label] = len(histogram.labels)
+			histogram.labels = append(histogram.labels, labelCount{label: node.Label, node: node, count: 1})
+		}
+		stack = append(stack, node.Children...)
+	}
+	sort.Slice(histogram.labels, func(i, j int) bool {
+		return histogram.labels[i].label < histogram.labels[j].label
+	})
+	return histogram
+}
+
+// aptedDistanceBounder computes lower bounds of the APTED distance between
+// two trees from their label histograms. Edit costs are cached by label, so a
+// bounder must not be shared between goroutines.
+type aptedDistanceBounder struct {
+	costModel   coreapted.CostModel
+	deleteCosts map[string]float64
+	insertCosts map[string]float64
+	renameCosts map[[2]string]float64
+}
+
+func newAPTEDDistanceBounder(costModel coreapted.CostModel) *aptedDistanceBounder {
+	return &aptedDistanceBounder{
+		costModel:   costModel,
+		deleteCosts: make(map[string]float64),
+		insertCosts: make(map[string]float64),
+		renameCosts: make(map[[2]string]float64),
+	}
+}
+
+// exceedsDistance reports whether the APTED distance between the trees of
+// two histograms is certainly greater than maxDistance.
+//
+// Any edit script maps some nodes of one tree to nodes of the other and
+// deletes or inserts the rest. Nodes of a label beyond the count in the
+// other tree cannot all map to nodes of the same label, so each of them
+// costs at least its cheapest delete or rename to another label of the other
+// tree. Summing these minimums over the extra nodes of either tree counts
+// every edit at most once, which makes each sum a lower bound of the
+// distance. The sums stop as soon as they pass maxDistance.
+func (b *aptedDistanceBounder) exceedsDistance(h1, h2 *labelHistogram, maxDistance float64) bool {
+	limit := maxDistance + aptedBoundEpsilon
+	return b.extraNodesCost(h1, h2, limit, true) > limit ||
+		b.extraNodesCost(h2, h1, limit, false) > limit
+}
+
+// extraNodesCost sums the minimum cost of the nodes of from beyond the counts
+// of to, stopping once the sum passes limit. Deleting from the first tree
+// mirrors inserting into the second one, so source tells which cost applies.
+func (b *aptedDistanceBounder) extraNodesCost(from, to *labelHistogram, limit float64, source bool) float64 {
+	total := 0.0
+	j := 0
+	for _, entry := range from.labels {
+		for j < len(to.labels) && to.labels[j].label < entry.label {
+			j++
+		}
+		extra := entry.count
+		if j < len(to.labels) && to.labels[j].label == entry.label {
+			extra -= to.labels[j].count
+		}
+		if extra <= 0 {
+			continue
+		}
+
+		total += float64(extra) * b.minNodeCost(entry, to, source)
+		if total > limit {
+			return total
+		}
+	}
+	return total
+}
+
+// minNodeCost is the cheapest way to not map a node to a node of its own
+// label: delete (or insert) it, or rename it to another label of the other tree
+func (b *aptedDistanceBounder) minNodeCost(entry labelCount, other *labelHistogram, source bool) float64 {
+	cost := b.removeCost(entry, source)
+	for _, candidate := range other.labels {
+		if cost <= 0 {
+			return 0
+		}
+		if candidate.label == entry.label {
+			continue
+		}
+		if source {
+			cost = math.Min(cost, b.renameCost(entry, candidate))
+		} else {
+			cost = math.Min(cost, b.renameCost(candidate, entry))
+		}
+	}
+	return cost
+}
+
+func (b *aptedDistanceBounder) removeCost(entry labelCount, source bool) float64 {
+	costs := b.insertCosts
+	if source {
+		costs = b.deleteCosts
+	}
+	if cost, ok := costs[entry.label]; ok {
+		return cost
+	}
+	var cost float64
+	if source {
+		cost = b.costModel.Delete(entry.node)
+	} else {
+		cost = b.costModel.Insert(entry.node)
+	}
+	costs[entry.label] = cost
+	return cost
+}
+
+func (b *aptedDistanceBounder) renameCost(from, to labelCount) float64 {
+	key := [2]string{from.label, to.label}
+	if cost, ok := b.renameCosts[key]; ok {
+		return cost
+	}
+	cost := b.costModel.Rename(from.node, to.node)
+	if len(b.renameCosts) >= maxCachedRenameCosts {
+		clear(b.renameCosts)
+	}
+	b.renameCosts[key] = cost
+	return cost
+}
@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"math/rand"
 	"testing"
 
 	coreapted "github.com/ludo-technologies/polyscan/core/apted"
@@ -75,3 +76,61 @@ func TestIsTopLevelDefinition(t *testing.T) {
 	assert.False(t, costModel.isTopLevelDefinition("Constant"))
 	assert.False(t, costModel.isTopLevelDefinition("If"))
 }
+
+func TestNewLabelHistogram(t *testing.T) {
+	root := coreapted.NewTreeNode(1, "FunctionDef(f)")
+	root.AddChild(coreapted.NewTreeNode(2, "Name(x)"))
+	root.AddChild(coreapted.NewTreeNode(3, "Return"))
+	root.Children[1].AddChild(coreapted.NewTreeNode(4, "Name(x)"))
+
+	histogram := newLabelHistogram(root)
+	assert.Equal(t, 4, histogram.size)
+	labels := make(map[string]int)
+	for _, entry := range histogram.labels {
+		labels[entry.label] = entry.count
+	}
+	assert.Equal(t, map[string]int{"FunctionDef(f)": 1, "Name(x)": 2, "Return": 1}, labels)
+
+	large := coreapted.NewTreeNode(0, "Module")
+	for i := 1; i <= aptedExactTreeSize; i++ {
+		large.AddChild(coreapted.NewTreeNode(i, "Pass"))
+	}
+	assert.Nil(t, newLabelHistogram(large), "trees compared approximately have no histogram")
+}
+
+func TestAPTEDDistanceBounderIsLowerBound(t *testing.T) {
+	labels := []string{"FunctionDef(f)", "FunctionDef(g)", "If", "For", "Return", "Name(x)", "Name(y)", "Constant(1)", "BinOp(+)", "Call(print)"}
+	random := rand.New(rand.NewSource(7))
+	randomTree := func() *coreapted.TreeNode {
+		nodes := []*coreapted.TreeNode{coreapted.NewTreeNode(0, labels[random.Intn(2)])}
+		for i := 1; i < 3+random.Intn(25); i++ {
+			node := coreapted.NewTreeNode(i, labels[random.Intn(len(labels))])
+			nodes[random.Intn(len(nodes))].AddChild(node)
+			nodes = append(nodes, node)
+		}
+		PrepareTreeForAPTED(nodes[0])
+		return nodes[0]
+	}
+
+	trees := make([]*coreapted.TreeNode, 30)
+	for i := range trees {
+		trees[i] = randomTree()
+	}
+
+	for _, costModel := range []coreapted.CostModel{NewPythonCostModel(), NewPythonCostModelWithConfig(true, true), coreapted.NewDefaultCostModel()} {
+		analyzer := newAPTEDAnalyzer(costModel)
+		bounder := newAPTEDDistanceBounder(costModel)
+		pruned := 0
+		for i := range trees {
+			for j := range trees {
+				distance := analyzer.ComputeDistance(trees[i], trees[j])
+				h1, h2 := newLabelHistogram(trees[i]), newLabelHistogram(trees[j])
+				assert.False(t, bounder.exceedsDistance(h1, h2, distance), "bound above distance %.3f for trees %d and %d", distance, i, j)
+				if bounder.exceedsDistance(h1, h2, distance/2) {
+					pruned++
+				}
+			}
+		}
+		assert.Positive(t, pruned, "the bound should rule out some pairs")
+	}
+}
@@ -0,0 +1,407 @@
+package analyzer
+
+import (
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// diffSymbol is a class or function definition of one version of a module
+type diffSymbol struct {
+	node *parser.Node
+	kind domain.DiffSymbolKind
+	// key identifies the definition among its siblings: its name, with an
+	// occurrence suffix for redefinitions such as property setters
+	key      string
+	children []*diffSymbol
+
+	signature  string
+	decorators uint64
+	docstring  string
+	body       uint64
+}
+
+// diffVersion is one side of a diff
+type diffVersion struct {
+	path   string
+	source []byte
+	// symbols are all definitions that are reported on their own, so that
+	// the body of the definition containing them skips them
+	symbols map[*parser.Node]bool
+}
+
+// DiffAST compares two versions of a module by their definitions. A nil
+// parse result stands for a file that does not exist in that version.
+//
+// Definitions are matched by name within their parent. A definition missing
+// from one side that has the same signature and body as one missing from the
+// other is reported as renamed. Classes and functions nested in matched
+// definitions are compared in turn.
+func DiffAST(oldPath string, oldResult *parser.ParseResult, newPath string, newResult *parser.ParseResult) *domain.ASTDiff {
+	oldVersion := &diffVersion{path: oldPath, symbols: make(map[*parser.Node]bool)}
+	newVersion := &diffVersion{path: newPath, symbols: make(map[*parser.Node]bool)}
+
+	var oldBody, newBody []*parser.Node
+	if oldResult != nil && oldResult.AST != nil {
+		oldVersion.source = oldResult.SourceCode
+		oldBody = oldResult.AST.Body
+	}
+	if newResult != nil && newResult.AST != nil {
+		newVersion.source = newResult.SourceCode
+		newBody = newResult.AST.Body
+	}
+	oldSymbols := oldVersion.collect(oldBody, domain.DiffSymbolModule)
+	newSymbols := newVersion.collect(newBody, domain.DiffSymbolModule)
+
+	diff := &domain.ASTDiff{OldPath: oldPath, NewPath: newPath, Changes: []domain.SymbolChange{}}
+
+	// Module level statements other than definitions
+	if oldResult != nil && newResult != nil {
+		var aspects []domain.ChangeAspect
+		if oldVersion.docstring(oldBody) != newVersion.docstring(newBody) {
+			aspects = append(aspects, domain.ChangeAspectDocstring)
+		}
+		oldImports, oldStatements := oldVersion.moduleHashes(oldBody)
+		newImports, newStatements := newVersion.moduleHashes(newBody)
+		if oldImports != newImports {
+			aspects = append(aspects, domain.ChangeAspectImports)
+		}
+		if oldStatements != newStatements {
+			aspects = append(aspects, domain.ChangeAspectBody)
+		}
+		if len(aspects) > 0 {
+			diff.Changes = append(diff.Changes, domain.SymbolChange{
+				Kind:    domain.SymbolModified,
+				Symbol:  domain.DiffSymbolModule,
+				Name:    docstringModuleName(newPath),
+				Aspects: aspects,
+			})
+		}
+	}
+
+	diffSymbols(diff, oldVersion, newVersion, oldSymbols, newSymbols, "", "")
+
+	for _, change := range diff.Changes {
+		switch change.Kind {
+		case domain.SymbolAdded:
+			diff.Summary.Added++
+		case domain.SymbolRemoved:
+			diff.Summary.Removed++
+		case domain.SymbolModified:
+			diff.Summary.Modified++
+			if change.HasAspect(domain.ChangeAspectSignature) {
+				diff.Summary.SignatureChanges++
+			}
+		case domain.SymbolRenamed:
+			diff.Summary.Renamed++
+		}
+	}
+	return diff
+}
+
+// collect returns the definitions directly in body, looking through
+// compound statements such as if TYPE_CHECKING blocks, with their nested
+// definitions
+func (v *diffVersion) collect(body []*parser.Node, parent domain.DiffSymbolKind) []*diffSymbol {
+	var symbols []*diffSymbol
+	occurrences := make(map[string]int)
+
+	var visit func(body []*parser.Node)
+	visit = func(body []*parser.Node) {
+		for _, stmt := range body {
+			if stmt == nil {
+				continue
+			}
+			switch stmt.Type {
+			case parser.NodeClassDef, parser.NodeFunctionDef, parser.NodeAsyncFunctionDef:
+				kind := domain.DiffSymbolClass
+				if stmt.Type != parser.NodeClassDef {
+					kind = domain.DiffSymbolFunction
+					if parent == domain.DiffSymbolClass {
+						kind = domain.DiffSymbolMethod
+					}
+				}
+				occurrences[stmt.Name]++
+				key := stmt.Name
+				if n := occurrences[stmt.Name]; n > 1 {
+					key = fmt.Sprintf("%s#%d", stmt.Name, n)
+				}
+				v.symbols[stmt] = true
+				symbols = append(symbols, &diffSymbol{
+					node:     stmt,
+					kind:     kind,
+					key:      key,
+					children: v.collect(stmt.Body, kind),
+				})
+
+			case parser.NodeIf, parser.NodeTry, parser.NodeWith, parser.NodeAsyncWith,
+				parser.NodeFor, parser.NodeAsyncFor, parser.NodeWhile:
+				visit(stmt.Body)
+				visit(stmt.Orelse)
+				for _, handler := range stmt.Handlers {
+					visit(handler.Body)
+				}
+				visit(stmt.Finalbody)
+			}
+		}
+	}
+	visit(body)
+
+	// Bodies are hashed once all nested definitions are known
+	for _, symbol := range symbols {
+		symbol.signature = v.signature(symbol.node)
+		symbol.decorators = v.hashNodes(symbol.node.Decorator)
+		symbol.docstring = v.docstring(symbol.node.Body)
+		symbol.body = v.hashNodes(v.withoutDocstring(symbol.node.Body))
+	}
+	return symbols
+}
+
+// diffSymbols compares the definitions of one scope and appends the changes
+func diffSymbols(diff *domain.ASTDiff, oldVersion, newVersion *diffVersion, oldSymbols, newSymbols []*diffSymbol, oldPrefix, newPrefix string) {
+	oldByKey := make(map[string]*diffSymbol, len(oldSymbols))
+	for _, symbol := range oldSymbols {
+		oldByKey[symbol.key] = symbol
+	}
+	matched := make(map[*diffSymbol]*diffSymbol)
+	for _, newSymbol := range newSymbols {
+		if oldSymbol, ok := oldByKey[newSymbol.key]; ok {
+			matched[newSymbol] = oldSymbol
+			matched[oldSymbol] = newSymbol
+		}
+	}
+
+	// A rename pairs the only unmatched definitions with the same shape
+	shapes := func(symbols []*diffSymbol) map[string][]*diffSymbol {
+		byShape := make(map[string][]*diffSymbol)
+		for _, symbol := range symbols {
+			if matched[symbol] == nil {
+				shape := symbol.shape()
+				byShape[shape] = append(byShape[shape], symbol)
+			}
+		}
+		return byShape
+	}
+	oldShapes := shapes(oldSymbols)
+	renamed := make(map[*diffSymbol]bool)
+	for shape, candidates := range shapes(newSymbols) {
+		if len(candidates) == 1 && len(oldShapes[shape]) == 1 {
+			newSymbol, oldSymbol := candidates[0], oldShapes[shape][0]
+			matched[newSymbol] = oldSymbol
+			matched[oldSymbol] = newSymbol
+			renamed[newSymbol] = true
+		}
+	}
+
+	for _, newSymbol := range newSymbols {
+		newName := newPrefix + newSymbol.node.Name
+		oldSymbol := matched[newSymbol]
+		if oldSymbol == nil {
+			diff.Changes = append(diff.Changes, domain.SymbolChange{
+				Kind:         domain.SymbolAdded,
+				Symbol:       newSymbol.kind,
+				Name:         newName,
+				NewSignature: newSymbol.signature,
+				NewLocation:  newVersion.location(newSymbol.node),
+			})
+			continue
+		}
+
+		oldName := oldPrefix + oldSymbol.node.Name
+		change := domain.SymbolChange{
+			Kind:         domain.SymbolModified,
+			Symbol:       newSymbol.kind,
+			Name:         newName,
+			Aspects:      changedAspects(oldSymbol, newSymbol),
+			OldSignature: oldSymbol.signature,
+			NewSignature: newSymbol.signature,
+			OldLocation:  oldVersion.location(oldSymbol.node),
+			NewLocation:  newVersion.location(newSymbol.node),
+		}
+		if renamed[newSymbol] {
+			change.Kind = domain.SymbolRenamed
+		}
+		if oldName != newName {
+			change.OldName = oldName
+		}
+		if change.Kind == domain.SymbolRenamed || len(change.Aspects) > 0 {
+			diff.Changes = append(diff.Changes, change)
+		}
+		diffSymbols(diff, oldVersion, newVersion, oldSymbol.children, newSymbol.children, oldName+".", newName+".")
+	}
+
+	for _, oldSymbol := range oldSymbols {
+		if matched[oldSymbol] == nil {
+			diff.Changes = append(diff.Changes, domain.SymbolChange{
+				Kind:         domain.SymbolRemoved,
+				Symbol:       oldSymbol.kind,
+				Name:         oldPrefix + oldSymbol.node.Name,
+				OldSignature: oldSymbol.signature,
+				OldLocation:  oldVersion.location(oldSymbol.node),
+			})
+		}
+	}
+}
+
+// changedAspects lists the parts of a definition that differ
+func changedAspects(oldSymbol, newSymbol *diffSymbol) []domain.ChangeAspect {
+	var aspects []domain.ChangeAspect
+	if signatureAfterName(oldSymbol) != signatureAfterName(newSymbol) {
+		aspects = append(aspects, domain.ChangeAspectSignature)
+	}
+	if oldSymbol.decorators != newSymbol.decorators {
+		aspects = append(aspects, domain.ChangeAspectDecorators)
+	}
+	if oldSymbol.docstring != newSymbol.docstring {
+		aspects = append(aspects, domain.ChangeAspectDocstring)
+	}
+	if oldSymbol.body != newSymbol.body {
+		aspects = append(aspects, domain.ChangeAspectBody)
+	}
+	return aspects
+}
+
+// shape identifies a definition by everything but its name
+func (s *diffSymbol) shape() string {
+	return fmt.Sprintf("%s\x00%s\x00%x\x00%s\x00%x", s.node.Type, signatureAfterName(s), s.decorators, s.docstring, s.body)
+}
+
+// signatureAfterName returns the signature without the keyword and name
+func signatureAfterName(s *diffSymbol) string {
+	prefix, rest, found := strings.Cut(s.signature, " "+s.node.Name)
+	if !found {
+		return s.signature
+	}
+	// Keep async, which is part of the signature
+	return strings.TrimSuffix(prefix, "def") + rest
+}
+
+// signature returns the header of a definition, such as
+// `def total(self, items: list[Item]) -> int`, normalized so that comments,
+// line breaks and trailing commas do not count as changes
+func (v *diffVersion) signature(node *parser.Node) string {
+	start, end := node.Location.StartByte, node.Location.EndByte
+	if len(node.Body) > 0 && node.Body[0] != nil {
+		end = node.Body[0].Location.StartByte
+	}
+	if start < 0 || end > len(v.source) || start >= end {
+		return node.Name
+	}
+	header := stripPythonComments(string(v.source[start:end]))
+	header = strings.Join(strings.Fields(header), " ")
+	header = strings.TrimSuffix(header, ":")
+	for _, replacement := range [][2]string{{"( ", "("}, {"[ ", "["}, {" )", ")"}, {" ]", "]"}, {",)", ")"}, {",]", "]"}} {
+		header = strings.ReplaceAll(header, replacement[0], replacement[1])
+	}
+	return strings.TrimSpace(header)
+}
+
+// stripPythonComments removes # comments outside string literals
+func stripPythonComments(text string) string {
+	var b strings.Builder
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && i+1 < len(text) {
+				b.WriteByte(c)
+				i++
+				c = text[i]
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			for i < len(text) && text[i] != '\n' {
+				i++
+			}
+			if i < len(text) {
+				b.WriteByte('\n')
+			}
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// docstring returns the docstring of a body, or "" when it has none
+func (v *diffVersion) docstring(body []*parser.Node) string {
+	if !hasDocstring(body) {
+		return ""
+	}
+	first := body[0]
+	if first.Type == parser.NodeExpr {
+		first = first.Children[0]
+	}
+	return first.Value.(string)
+}
+
+// withoutDocstring returns a body without its docstring
+func (v *diffVersion) withoutDocstring(body []*parser.Node) []*parser.Node {
+	if hasDocstring(body) {
+		return body[1:]
+	}
+	return body
+}
+
+// moduleHashes hashes the import statements and the other statements of a
+// module separately
+func (v *diffVersion) moduleHashes(body []*parser.Node) (imports, statements uint64) {
+	var importNodes, statementNodes []*parser.Node
+	for _, stmt := range v.withoutDocstring(body) {
+		if stmt != nil && (stmt.Type == parser.NodeImport || stmt.Type == parser.NodeImportFrom) {
+			importNodes = append(importNodes, stmt)
+		} else {
+			statementNodes = append(statementNodes, stmt)
+		}
+	}
+	return v.hashNodes(importNodes), v.hashNodes(statementNodes)
+}
+
+// hashNodes hashes the structure of nodes, ignoring positions and skipping
+// definitions reported on their own
+func (v *diffVersion) hashNodes(nodes []*parser.Node) uint64 {
+	h := fnv.New64a()
+	for _, node := range nodes {
+		v.hashNode(h, node)
+	}
+	return h.Sum64()
+}
+
+func (v *diffVersion) hashNode(h hash.Hash64, node *parser.Node) {
+	if node == nil || v.symbols[node] {
+		return
+	}
+	// Redundant parentheses are formatting
+	if node.Type == "parenthesized_expression" {
+		for _, child := range node.Children {
+			if child.Type != "(" && child.Type != ")" {
+				v.hashNode(h, child)
+			}
+		}
+		return
+	}
+	fmt.Fprintf(h, "(%s %q %q %q %q %d", node.Type, node.Name, node.Op, node.Module, node.Names, node.Level)
+	if node.Value != nil {
+		if _, ok := node.Value.(*parser.Node); !ok {
+			fmt.Fprintf(h, " %T:%v", node.Value, node.Value)
+		}
+	}
+	for _, child := range node.GetChildren() {
+		v.hashNode(h, child)
+	}
+	h.Write([]byte(")"))
+}
+
+// location returns where a definition is in this version
+func (v *diffVersion) location(node *parser.Node) *domain.SourceLocation {
+	loc := sourceLocation(v.path, node.Location)
+	return &loc
+}
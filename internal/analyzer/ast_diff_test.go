@@ -0,0 +1,181 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+func diffSources(t *testing.T, oldSource, newSource string) *domain.ASTDiff {
+	t.Helper()
+	parse := func(source string) *parser.ParseResult {
+		result, err := parser.New().Parse(context.Background(), []byte(source))
+		if err != nil {
+			t.Fatalf("failed to parse: %v", err)
+		}
+		return result
+	}
+	return DiffAST("old.py", parse(oldSource), "new.py", parse(newSource))
+}
+
+// describeChanges renders changes as "kind symbol name [aspects]" lines
+func describeChanges(diff *domain.ASTDiff) string {
+	var lines []string
+	for _, change := range diff.Changes {
+		name := change.Name
+		if change.OldName != "" {
+			name = change.OldName + "->" + change.Name
+		}
+		line := fmt.Sprintf("%s %s %s", change.Kind, change.Symbol, name)
+		if len(change.Aspects) > 0 {
+			line += fmt.Sprintf(" %v", change.Aspects)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestDiffAST(t *testing.T) {
+	tests := []struct {
+		name      string
+		oldSource string
+		newSource string
+		want      string
+	}{
+		{
+			name:      "formatting and comments are not changes",
+			oldSource: "def f(a, b):\n    return a + b\n",
+			newSource: "def f(\n    a,\n    b,  # second\n):\n    # sum\n    return (a + b)\n",
+			want:      "",
+		},
+		{
+			name:      "body only",
+			oldSource: "def f(a):\n    return a\n",
+			newSource: "def f(a):\n    return a * 2\n",
+			want:      "modified function f [body]",
+		},
+		{
+			name:      "signature",
+			oldSource: "def f(a):\n    return a\n",
+			newSource: "async def f(a, b: int = 0) -> int:\n    return a\n",
+			want:      "modified function f [signature]",
+		},
+		{
+			name:      "decorators and docstring",
+			oldSource: "def f():\n    \"\"\"Old.\"\"\"\n",
+			newSource: "@cache\ndef f():\n    \"\"\"New.\"\"\"\n",
+			want:      "modified function f [decorators docstring]",
+		},
+		{
+			name:      "added and removed",
+			oldSource: "def gone():\n    return 1\n",
+			newSource: "class New:\n    def method(self):\n        return 2\n",
+			want:      "added class New\nremoved function gone",
+		},
+		{
+			name:      "renamed",
+			oldSource: "def old_name(x):\n    return x + 1\n",
+			newSource: "def new_name(x):\n    return x + 1\n",
+			want:      "renamed function old_name->new_name",
+		},
+		{
+			name:      "ambiguous renames stay added and removed",
+			oldSource: "def a():\n    pass\n\ndef b():\n    pass\n",
+			newSource: "def c():\n    pass\n\ndef d():\n    pass\n",
+			want:      "added function c\nadded function d\nremoved function a\nremoved function b",
+		},
+		{
+			name:      "nested definitions are reported on their own",
+			oldSource: "class C(Base):\n    x = 1\n\n    def m(self):\n        def inner():\n            return 1\n        return inner\n",
+			newSource: "class C(Base, Mixin):\n    x = 1\n\n    def m(self):\n        def inner():\n            return 2\n        return inner\n",
+			want:      "modified class C [signature]\nmodified function C.m.inner [body]",
+		},
+		{
+			name:      "methods of a renamed class",
+			oldSource: "class Old:\n    def m(self):\n        return 1\n\n    def n(self):\n        return 2\n",
+			newSource: "class New:\n    def m(self):\n        return 1\n\n    def n(self):\n        return 3\n",
+			want:      "renamed class Old->New\nmodified method Old.n->New.n [body]",
+		},
+		{
+			name:      "redefinitions are matched in order",
+			oldSource: "class C:\n    @property\n    def v(self):\n        return 1\n\n    @v.setter\n    def v(self, value):\n        pass\n",
+			newSource: "class C:\n    @property\n    def v(self):\n        return 1\n\n    @v.setter\n    def v(self, value):\n        self._v = value\n",
+			want:      "modified method C.v [body]",
+		},
+		{
+			name:      "module statements",
+			oldSource: "import os\nX = 1\n\ndef f():\n    pass\n",
+			newSource: "import os\nimport sys\nX = 2\n\ndef f():\n    pass\n",
+			want:      "modified module new [imports body]",
+		},
+		{
+			name:      "definitions in compound statements",
+			oldSource: "if TYPE_CHECKING:\n    def f():\n        pass\n",
+			newSource: "if TYPE_CHECKING:\n    def f():\n        return 1\n",
+			want:      "modified function f [body]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := diffSources(t, tt.oldSource, tt.newSource)
+			if got := describeChanges(diff); got != tt.want {
+				t.Errorf("changes:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffASTRenamedClassMethods(t *testing.T) {
+	diff := diffSources(t,
+		"class Old:\n    def m(self):\n        return 1\n",
+		"class New:\n    def m(self):\n        return 1\n")
+	if got := describeChanges(diff); got != "renamed class Old->New" {
+		t.Errorf("changes = %q", got)
+	}
+}
+
+func TestDiffASTSignaturesAndSummary(t *testing.T) {
+	diff := diffSources(t,
+		"def f(a):  # note\n    return a\n\ndef g():\n    pass\n",
+		"def f(a, *, b=[1, 2]) -> list:\n    return a\n")
+
+	if len(diff.Changes) != 2 {
+		t.Fatalf("expected 2 changes, got %d: %s", len(diff.Changes), describeChanges(diff))
+	}
+	change := diff.Changes[0]
+	if change.OldSignature != "def f(a)" || change.NewSignature != "def f(a, *, b=[1, 2]) -> list" {
+		t.Errorf("signatures = %q, %q", change.OldSignature, change.NewSignature)
+	}
+	if change.BodyOnly() || !change.HasAspect(domain.ChangeAspectSignature) {
+		t.Errorf("expected a signature change, got %v", change.Aspects)
+	}
+	if change.OldLocation == nil || change.NewLocation == nil || change.NewLocation.FilePath != "new.py" {
+		t.Errorf("unexpected locations %+v %+v", change.OldLocation, change.NewLocation)
+	}
+
+	want := domain.ASTDiffSummary{Removed: 1, Modified: 1, SignatureChanges: 1}
+	if diff.Summary != want {
+		t.Errorf("summary = %+v, want %+v", diff.Summary, want)
+	}
+}
+
+func TestDiffASTMissingVersion(t *testing.T) {
+	result, err := parser.New().Parse(context.Background(), []byte("import os\n\ndef f():\n    pass\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff := DiffAST("new.py", nil, "new.py", result)
+	if got := describeChanges(diff); got != "added function f" {
+		t.Errorf("changes of an added file = %q", got)
+	}
+	diff = DiffAST("old.py", result, "old.py", nil)
+	if got := describeChanges(diff); got != "removed function f" {
+		t.Errorf("changes of a removed file = %q", got)
+	}
+}
@@ -0,0 +1,144 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// AsyncComplexityResult holds concurrency metrics for a coroutine
+type AsyncComplexityResult struct {
+	// True for `async def` functions; all other fields are zero otherwise
+	IsCoroutine bool
+
+	// Suspension points
+	AwaitPoints   int // await expressions
+	AsyncLoops    int // async for statements (one suspension per iteration)
+	AsyncContexts int // async with statements (suspends on enter and exit)
+
+	// Concurrent fan-out: awaitables passed to asyncio.gather/asyncio.wait and
+	// tasks scheduled with create_task/ensure_future
+	FanOut int
+
+	// AsyncComplexity is the number of suspension points plus the fan-out
+	AsyncComplexity int
+
+	// AwaitDensity is suspension points per line of the coroutine body
+	AwaitDensity float64
+}
+
+// SuspensionPoints returns the number of places the coroutine can yield
+// control to the event loop
+func (r *AsyncComplexityResult) SuspensionPoints() int {
+	return r.AwaitPoints + r.AsyncLoops + r.AsyncContexts
+}
+
+// CalculateAsyncComplexity computes suspension points and concurrent fan-out
+// for a function node. Nested functions, classes and lambdas are separate
+// scopes and are not counted.
+func CalculateAsyncComplexity(funcNode *parser.Node) *AsyncComplexityResult {
+	result := &AsyncComplexityResult{}
+	if funcNode == nil || funcNode.Type != parser.NodeAsyncFunctionDef {
+		return result
+	}
+	result.IsCoroutine = true
+
+	for _, stmt := range funcNode.Body {
+		walkAsyncScope(stmt, func(node *parser.Node) {
+			switch node.Type {
+			case parser.NodeAwait:
+				result.AwaitPoints++
+			case parser.NodeAsyncFor:
+				result.AsyncLoops++
+			case parser.NodeAsyncWith:
+				result.AsyncContexts++
+			case parser.NodeCall:
+				result.FanOut += asyncFanOut(node)
+			}
+		})
+	}
+
+	result.AsyncComplexity = result.SuspensionPoints() + result.FanOut
+
+	bodyLines := funcNode.Location.EndLine - funcNode.Location.StartLine
+	if bodyLines < 1 {
+		bodyLines = 1
+	}
+	result.AwaitDensity = float64(result.SuspensionPoints()) / float64(bodyLines)
+
+	return result
+}
+
+// walkAsyncScope visits node and its descendants without entering nested
+// function, class or lambda scopes
+func walkAsyncScope(node *parser.Node, visit func(*parser.Node)) {
+	node.Walk(func(n *parser.Node) bool {
+		switch n.Type {
+		case parser.NodeFunctionDef, parser.NodeAsyncFunctionDef, parser.NodeClassDef, parser.NodeLambda:
+			return false
+		}
+		visit(n)
+		return true
+	})
+}
+
+// asyncFanOut returns the number of awaitables a call runs concurrently
+func asyncFanOut(call *parser.Node) int {
+	function := asyncCallName(call)
+	switch {
+	case function == "gather" || strings.HasSuffix(function, ".gather"):
+		return len(call.Args)
+	case function == "asyncio.wait" || function == "asyncio.as_completed":
+		return 1
+	case strings.HasSuffix(function, "create_task") || strings.HasSuffix(function, "ensure_future"):
+		return 1
+	}
+	return 0
+}
+
+// gatherTasks returns the awaitables of an asyncio.gather call contained in
+// expr, or nil when expr does not await a gather with more than one argument
+func gatherTasks(expr *parser.Node) []*parser.Node {
+	var tasks []*parser.Node
+	walkAsyncScope(expr, func(node *parser.Node) {
+		if tasks != nil || node.Type != parser.NodeAwait {
+			return
+		}
+		call, ok := node.Value.(*parser.Node)
+		if !ok || call.Type != parser.NodeCall {
+			return
+		}
+		if function := asyncCallName(call); (function == "gather" || strings.HasSuffix(function, ".gather")) && len(call.Args) > 1 {
+			tasks = call.Args
+		}
+	})
+	return tasks
+}
+
+// containsAwait reports whether node awaits in the current scope
+func containsAwait(node *parser.Node) bool {
+	found := false
+	walkAsyncScope(node, func(n *parser.Node) {
+		if n.Type == parser.NodeAwait {
+			found = true
+		}
+	})
+	return found
+}
+
+func asyncCallName(call *parser.Node) string {
+	value, ok := call.Value.(*parser.Node)
+	if !ok {
+		return ""
+	}
+	switch value.Type {
+	case parser.NodeName:
+		return value.Name
+	case parser.NodeAttribute:
+		if object, ok := value.Value.(*parser.Node); ok && object.Type == parser.NodeName {
+			return object.Name + "." + value.Name
+		}
+		return value.Name
+	}
+	return ""
+}
@@ -0,0 +1,95 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCalculateAsyncComplexity(t *testing.T) {
+	source := `
+async def sync_all(client, ids):
+    async with client.session() as session:
+        users, orders = await asyncio.gather(fetch_users(session), fetch_orders(session), fetch_prices(session))
+        async for event in session.events():
+            task = asyncio.create_task(handle(event))
+            await task
+
+        def callback():
+            return None
+
+        async def nested():
+            await other()
+    return users
+`
+	ast := parseSource(t, source)
+	result := CalculateAsyncComplexity(ast.Body[0])
+
+	if !result.IsCoroutine {
+		t.Fatal("expected async def to be a coroutine")
+	}
+	if result.AwaitPoints != 2 {
+		t.Errorf("AwaitPoints = %d, want 2", result.AwaitPoints)
+	}
+	if result.AsyncLoops != 1 || result.AsyncContexts != 1 {
+		t.Errorf("AsyncLoops = %d, AsyncContexts = %d, want 1 and 1", result.AsyncLoops, result.AsyncContexts)
+	}
+	if result.FanOut != 4 {
+		t.Errorf("FanOut = %d, want 4 (3 gathered + 1 task)", result.FanOut)
+	}
+	if result.AsyncComplexity != 8 {
+		t.Errorf("AsyncComplexity = %d, want 8", result.AsyncComplexity)
+	}
+	if result.AwaitDensity <= 0 || result.AwaitDensity > 1 {
+		t.Errorf("AwaitDensity = %f, want a ratio in (0, 1]", result.AwaitDensity)
+	}
+
+	syncFunc := parseSource(t, "def f():\n    return 1\n").Body[0]
+	if CalculateAsyncComplexity(syncFunc).IsCoroutine {
+		t.Error("def should not be a coroutine")
+	}
+}
+
+func TestCFGBuilderModelsAwaitPoints(t *testing.T) {
+	source := `
+async def handler(request):
+    body = await request.json()
+    a, b = await asyncio.gather(load_a(body), load_b(body))
+    async for chunk in stream():
+        pass
+    async with lock:
+        pass
+    return a + b
+`
+	ast := parseSource(t, source)
+	cfg, err := NewCFGBuilder().Build(ast.Body[0])
+	if err != nil {
+		t.Fatalf("Failed to build CFG: %v", err)
+	}
+
+	counts := make(map[string]int)
+	for _, block := range cfg.Blocks {
+		for _, label := range []string{LabelAwaitResume, LabelGatherTask, LabelAsyncLoopHeader, LabelAsyncWithSetup, LabelAsyncWithTeardown} {
+			if strings.HasPrefix(block.Label, label+"_") {
+				counts[label]++
+			}
+		}
+	}
+
+	want := map[string]int{
+		LabelAwaitResume:       2,
+		LabelGatherTask:        2,
+		LabelAsyncLoopHeader:   1,
+		LabelAsyncWithSetup:    1,
+		LabelAsyncWithTeardown: 1,
+	}
+	for label, n := range want {
+		if counts[label] != n {
+			t.Errorf("%s blocks = %d, want %d", label, counts[label], n)
+		}
+	}
+
+	// Fan-out does not add decision points
+	if got := CalculateComplexity(cfg).Complexity; got != 2 {
+		t.Errorf("Complexity = %d, want 2", got)
+	}
+}
@@ -0,0 +1,108 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// BoilerplateMatcher tags functions matching boilerplate patterns
+type BoilerplateMatcher struct {
+	patterns []domain.BoilerplatePattern
+	compiled []*NodePattern
+}
+
+// NewBoilerplateMatcher validates and compiles boilerplate patterns. It
+// fails on the first pattern without a name or with an invalid match.
+func NewBoilerplateMatcher(patterns []domain.BoilerplatePattern) (*BoilerplateMatcher, error) {
+	matcher := &BoilerplateMatcher{}
+	for i, pattern := range patterns {
+		if strings.TrimSpace(pattern.Name) == "" {
+			return nil, fmt.Errorf("boilerplate pattern %d: name is required", i+1)
+		}
+		compiled, err := CompileNodePattern(pattern.Match)
+		if err != nil {
+			return nil, fmt.Errorf("boilerplate pattern %q: %w", pattern.Name, err)
+		}
+		matcher.patterns = append(matcher.patterns, pattern)
+		matcher.compiled = append(matcher.compiled, compiled)
+	}
+	return matcher, nil
+}
+
+// Functions returns the pattern each function of the AST matches, keyed by
+// the function's CFG name. A function matches a pattern when its definition
+// or a node of its body outside nested functions does; the module body is
+// named domain.ModuleFunctionName. The earliest pattern wins.
+func (m *BoilerplateMatcher) Functions(ast *parser.Node) map[string]domain.BoilerplatePattern {
+	if m == nil || len(m.patterns) == 0 || ast == nil {
+		return nil
+	}
+
+	matched := make(map[string]int)
+	WalkWithAncestors(ast, func(node *parser.Node, ancestors []*parser.Node) {
+		for i, pattern := range m.compiled {
+			if !pattern.Matches(node, ancestors) {
+				continue
+			}
+			name := boilerplateFunctionName(node, ancestors)
+			if previous, ok := matched[name]; !ok || i < previous {
+				matched[name] = i
+			}
+			return
+		}
+	})
+
+	functions := make(map[string]domain.BoilerplatePattern, len(matched))
+	for name, i := range matched {
+		functions[name] = m.patterns[i]
+	}
+	return functions
+}
+
+// boilerplateFunctionName returns the CFG name of the function a node
+// belongs to: the node itself when it is a function definition, else the
+// innermost function around it. Class bodies belong to the function or
+// module around the class, and decorators to the scope around the
+// definition they decorate, as in the CFG builder.
+func boilerplateFunctionName(node *parser.Node, ancestors []*parser.Node) string {
+	owner := -1
+	if node.Type != parser.NodeFunctionDef && node.Type != parser.NodeAsyncFunctionDef {
+		skip := false
+		for i := len(ancestors) - 1; i >= 0 && owner < 0; i-- {
+			switch ancestors[i].Type {
+			case parser.NodeDecorator:
+				skip = true
+			case parser.NodeFunctionDef, parser.NodeAsyncFunctionDef:
+				if skip {
+					skip = false
+					continue
+				}
+				owner = i
+			case parser.NodeClassDef:
+				skip = false
+			}
+		}
+		if owner < 0 {
+			return domain.ModuleFunctionName
+		}
+	}
+
+	scope := ancestors
+	if owner >= 0 {
+		scope = ancestors[:owner+1]
+	}
+	var names []string
+	for _, ancestor := range scope {
+		switch ancestor.Type {
+		case parser.NodeFunctionDef, parser.NodeAsyncFunctionDef, parser.NodeClassDef:
+			names = append(names, ancestor.Name)
+		}
+	}
+	if owner < 0 {
+		names = append(names, node.Name)
+	}
+	return strings.Join(names, ".")
+}
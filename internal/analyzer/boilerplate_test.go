@@ -0,0 +1,87 @@
+package analyzer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+func TestBoilerplateMatcherFunctions(t *testing.T) {
+	code := `
+import argparse
+
+def build_parser():
+    parser = argparse.ArgumentParser()
+    parser.add_argument("--verbose")
+    return parser
+
+def run(args):
+    def helper():
+        parser.add_argument("--x")
+    return helper
+
+class Message:
+    def to_dict(self):
+        return {}
+
+    @register(lambda: parser.add_argument("--y"))
+    def load(self):
+        pass
+
+parser.add_argument("--top")
+`
+	result, err := parser.New().Parse(context.Background(), []byte(code))
+	if err != nil {
+		t.Fatalf("failed to parse code: %v", err)
+	}
+	matcher, err := NewBoilerplateMatcher([]domain.BoilerplatePattern{
+		{Name: "argparse", Match: domain.PatternRuleMatch{Nodes: []string{"Call"}, Callee: "*.add_argument"}, ExcludeFromRisk: true},
+		{Name: "serializer", Match: domain.PatternRuleMatch{Nodes: []string{"FunctionDef"}, Name: "to_*"}},
+		{Name: "any def", Match: domain.PatternRuleMatch{Nodes: []string{"FunctionDef"}, Name: "build_*"}},
+	})
+	if err != nil {
+		t.Fatalf("NewBoilerplateMatcher failed: %v", err)
+	}
+
+	got := make(map[string]string)
+	for name, pattern := range matcher.Functions(result.AST) {
+		got[name] = pattern.Name
+	}
+	want := map[string]string{
+		"build_parser":    "argparse",
+		"run.helper":      "argparse",
+		"Message.to_dict": "serializer",
+		"<module>":        "argparse",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Functions() = %v, want %v", got, want)
+	}
+	for name, pattern := range want {
+		if got[name] != pattern {
+			t.Errorf("function %s tagged %q, want %q", name, got[name], pattern)
+		}
+	}
+}
+
+func TestNewBoilerplateMatcherRejectsInvalidPatterns(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern domain.BoilerplatePattern
+		wantErr string
+	}{
+		{"no name", domain.BoilerplatePattern{Match: domain.PatternRuleMatch{Nodes: []string{"Call"}}}, "name is required"},
+		{"no node", domain.BoilerplatePattern{Name: "p"}, `boilerplate pattern "p": match.node`},
+		{"unknown node", domain.BoilerplatePattern{Name: "p", Match: domain.PatternRuleMatch{Nodes: []string{"Print"}}}, `unknown node type "Print"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewBoilerplateMatcher([]domain.BoilerplatePattern{tt.pattern})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,693 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// CallGraphFunction is a function or method in the project call graph. The
+// module-level code of each file is a function too, named
+// domain.ModuleFunctionName.
+type CallGraphFunction struct {
+	ID       string // filePath + "::" + Name
+	FilePath string
+	Name     string // Qualified name within the file, e.g. "Parser.parse"
+	CFG      *CFG
+
+	// Location is the definition, or only the file for module-level code
+	Location domain.SourceLocation
+
+	// Calls holds the IDs of resolved callees
+	Calls map[string]bool
+
+	// Uses holds the IDs of functions and classes the function refers to
+	// without calling them: classes it instantiates, functions it passes as
+	// callbacks, and nested functions and classes it decorates
+	Uses map[string]bool
+
+	// StringRefs holds the IDs of functions and classes a string literal of
+	// the function names, as getattr(obj, "run"), a Celery task name
+	// "tasks.send_email" or a Django model reference "shop.Order" do. They
+	// are soft references, matched by the last dotted part of the string
+	// against definition names, so they may name an unrelated definition.
+	StringRefs map[string]bool
+
+	// callSites holds the call expressions in the function's own scope
+	callSites []*parser.Node
+
+	// references holds the names and attributes in the function's own scope,
+	// including lambdas, and decorated holds its decorated nested
+	// definitions
+	references []*parser.Node
+	decorated  []*parser.Node
+	strings    []string
+}
+
+// CallGraphClass is a class in the project call graph. Calls on instances
+// are not resolved, so a used class stands for all of its methods.
+type CallGraphClass struct {
+	ID       string // filePath + "::" + Name
+	FilePath string
+	Name     string // Qualified name within the file, e.g. "Parser.State"
+	Location domain.SourceLocation
+
+	// Methods holds the IDs of the functions defined directly in the class
+	Methods []string
+
+	// Bases holds the IDs of resolved base classes
+	Bases map[string]bool
+
+	scope     string
+	baseNodes []*parser.Node
+}
+
+// RecursionInfo describes how a function takes part in recursion
+type RecursionInfo struct {
+	// Kind is "direct" when the function calls itself and "mutual" when it is
+	// part of a larger call cycle
+	Kind string
+
+	// CycleLength is the length of the shortest call cycle through the
+	// function: 1 for direct recursion
+	CycleLength int
+
+	// Cycle lists the functions of the strongly connected component, sorted
+	Cycle []string
+
+	// RecursiveCalls counts call sites that re-enter the cycle
+	RecursiveCalls int
+
+	// Unbounded is true when every path from entry to exit passes through a
+	// recursive call, i.e. there is no obvious base case
+	Unbounded bool
+}
+
+// Recursion kinds
+const (
+	RecursionDirect = "direct"
+	RecursionMutual = "mutual"
+)
+
+// CallGraph is a static call graph over the functions of a project. Calls are
+// resolved by name: local and enclosing functions, module-level functions,
+// `self.`/`cls.` methods of the enclosing class, and names imported with
+// `from module import name` from other analyzed files.
+type CallGraph struct {
+	Functions map[string]*CallGraphFunction
+	Classes   map[string]*CallGraphClass
+
+	files map[string]*callGraphFile
+}
+
+type callGraphFile struct {
+	path    string
+	modPath string            // Slash-separated module path without extension, for import matching
+	imports map[string]string // Local name -> "module:name" (module may be relative)
+	targets map[string]string // Local name -> resolved ID, "" when unresolved
+}
+
+// NewCallGraph creates an empty call graph
+func NewCallGraph() *CallGraph {
+	return &CallGraph{
+		Functions: make(map[string]*CallGraphFunction),
+		Classes:   make(map[string]*CallGraphClass),
+		files:     make(map[string]*callGraphFile),
+	}
+}
+
+// CallGraphID returns the graph ID of a function in a file
+func CallGraphID(filePath, name string) string {
+	return filePath + "::" + name
+}
+
+// AddFile adds the functions of a parsed file. cfgs is the result of
+// CFGBuilder.BuildAll for the same AST.
+func (g *CallGraph) AddFile(filePath string, ast *parser.Node, cfgs map[string]*CFG) {
+	file := &callGraphFile{
+		path:    filePath,
+		modPath: strings.TrimSuffix(strings.TrimSuffix(filepath.ToSlash(filePath), ".py"), "/__init__"),
+		imports: make(map[string]string),
+		targets: make(map[string]string),
+	}
+	g.files[filePath] = file
+	if ast != nil {
+		collectFromImports(ast, file.imports)
+	}
+
+	for name, cfg := range cfgs {
+		if name == domain.ModuleFunctionName {
+			continue
+		}
+		funcNode := complexitySourceNode(cfg)
+		if funcNode == nil || (funcNode.Type != parser.NodeFunctionDef && funcNode.Type != parser.NodeAsyncFunctionDef) {
+			continue
+		}
+		fn := newCallGraphFunction(filePath, name, funcNode.Body, cfg)
+		fn.Location = sourceLocation(filePath, funcNode.Location)
+		g.Functions[fn.ID] = fn
+	}
+	if ast == nil {
+		return
+	}
+
+	module := newCallGraphFunction(filePath, domain.ModuleFunctionName, ast.Body, cfgs[domain.ModuleFunctionName])
+	g.Functions[module.ID] = module
+	g.addClasses(filePath, "", ast.Body)
+	for name := range cfgs {
+		idx := strings.LastIndex(name, ".")
+		if idx == -1 {
+			continue
+		}
+		if class, ok := g.Classes[CallGraphID(filePath, name[:idx])]; ok {
+			class.Methods = append(class.Methods, CallGraphID(filePath, name))
+		}
+	}
+}
+
+// newCallGraphFunction collects the call sites and references of a function
+// body
+func newCallGraphFunction(filePath, name string, body []*parser.Node, cfg *CFG) *CallGraphFunction {
+	fn := &CallGraphFunction{
+		ID:         CallGraphID(filePath, name),
+		FilePath:   filePath,
+		Name:       name,
+		CFG:        cfg,
+		Location:   domain.SourceLocation{FilePath: filePath},
+		Calls:      make(map[string]bool),
+		Uses:       make(map[string]bool),
+		StringRefs: make(map[string]bool),
+	}
+	for _, stmt := range body {
+		walkAsyncScope(stmt, func(n *parser.Node) {
+			if n.Type == parser.NodeCall {
+				fn.callSites = append(fn.callSites, n)
+			}
+		})
+	}
+
+	addString := func(n *parser.Node) {
+		if s, ok := n.Value.(string); ok && isDottedIdentifier(s) {
+			fn.strings = append(fn.strings, s)
+		}
+	}
+
+	// String literals of class bodies, such as Django field declarations,
+	// are evaluated in this scope too
+	visitStrings := func(n *parser.Node) bool {
+		switch n.Type {
+		case parser.NodeFunctionDef, parser.NodeAsyncFunctionDef:
+			return false
+		case parser.NodeConstant:
+			addString(n)
+		}
+		return true
+	}
+
+	// Decorators and bases of nested definitions are evaluated in this
+	// scope, and decorating a definition registers it somewhere
+	var visit func(n *parser.Node) bool
+	visit = func(n *parser.Node) bool {
+		switch n.Type {
+		case parser.NodeFunctionDef, parser.NodeAsyncFunctionDef, parser.NodeClassDef:
+			for _, child := range append(append([]*parser.Node{}, n.Decorator...), n.Bases...) {
+				child.Walk(visit)
+			}
+			if len(n.Decorator) > 0 {
+				fn.decorated = append(fn.decorated, n)
+			}
+			if n.Type == parser.NodeClassDef {
+				for _, stmt := range n.Body {
+					stmt.Walk(visitStrings)
+				}
+			}
+			return false
+		case parser.NodeName, parser.NodeAttribute:
+			fn.references = append(fn.references, n)
+		case parser.NodeConstant:
+			addString(n)
+		}
+		return true
+	}
+	for _, stmt := range body {
+		stmt.Walk(visit)
+	}
+	return fn
+}
+
+// scope returns the qualified name names in the function are looked up
+// from: empty at module level
+func (fn *CallGraphFunction) scope() string {
+	if fn.Name == domain.ModuleFunctionName {
+		return ""
+	}
+	return fn.Name
+}
+
+// addClasses adds the classes defined in stmts, nested ones included
+func (g *CallGraph) addClasses(filePath, scope string, stmts []*parser.Node) {
+	for _, stmt := range stmts {
+		stmt.Walk(func(n *parser.Node) bool {
+			switch n.Type {
+			case parser.NodeFunctionDef, parser.NodeAsyncFunctionDef:
+				g.addClasses(filePath, qualifiedScopeName(scope, n.Name), n.Body)
+				return false
+			case parser.NodeClassDef:
+				name := qualifiedScopeName(scope, n.Name)
+				class := &CallGraphClass{
+					ID:        CallGraphID(filePath, name),
+					FilePath:  filePath,
+					Name:      name,
+					Location:  sourceLocation(filePath, n.Location),
+					Bases:     make(map[string]bool),
+					scope:     scope,
+					baseNodes: n.Bases,
+				}
+				g.Classes[class.ID] = class
+				g.addClasses(filePath, name, n.Body)
+				return false
+			}
+			return true
+		})
+	}
+}
+
+// qualifiedScopeName joins a scope and a name defined in it
+func qualifiedScopeName(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+// Resolve links call sites, references and string literals to the
+// functions and classes added so far. Call it after all files have been
+// added.
+func (g *CallGraph) Resolve() {
+	named := g.nodesByName()
+	for _, fn := range g.Functions {
+		for _, call := range fn.callSites {
+			if callee := g.resolveCall(fn, call); callee != "" {
+				fn.Calls[callee] = true
+			}
+		}
+		for _, reference := range fn.references {
+			if id := g.resolveReference(fn, reference); id != "" && !fn.Calls[id] {
+				fn.Uses[id] = true
+			}
+		}
+		for _, def := range fn.decorated {
+			id := CallGraphID(fn.FilePath, qualifiedScopeName(fn.scope(), def.Name))
+			if g.hasNode(id) {
+				fn.Uses[id] = true
+			}
+		}
+		for _, s := range fn.strings {
+			for _, id := range named[s[strings.LastIndex(s, ".")+1:]] {
+				if id != fn.ID && !fn.Calls[id] && !fn.Uses[id] {
+					fn.StringRefs[id] = true
+				}
+			}
+		}
+	}
+	for _, class := range g.Classes {
+		for _, base := range class.baseNodes {
+			if base.Type != parser.NodeName {
+				continue
+			}
+			if id := g.resolveName(class.FilePath, class.scope, base.Name); id != "" {
+				class.Bases[id] = true
+			}
+		}
+	}
+}
+
+// nodesByName returns the IDs of the functions and classes by the last
+// part of their qualified name. Module-level code has no name to refer to.
+func (g *CallGraph) nodesByName() map[string][]string {
+	named := make(map[string][]string)
+	for id, fn := range g.Functions {
+		if fn.Name != domain.ModuleFunctionName {
+			name := fn.Name[strings.LastIndex(fn.Name, ".")+1:]
+			named[name] = append(named[name], id)
+		}
+	}
+	for id, class := range g.Classes {
+		name := class.Name[strings.LastIndex(class.Name, ".")+1:]
+		named[name] = append(named[name], id)
+	}
+	return named
+}
+
+// isDottedIdentifier reports whether s is an identifier or identifiers
+// joined by dots, as in "shop.Order"
+func isDottedIdentifier(s string) bool {
+	for _, part := range strings.Split(s, ".") {
+		if !isIdentifier(part) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasNode reports whether id is a function or a class of the graph
+func (g *CallGraph) hasNode(id string) bool {
+	if _, ok := g.Functions[id]; ok {
+		return true
+	}
+	_, ok := g.Classes[id]
+	return ok
+}
+
+// resolveCall returns the ID of the function a call invokes, or an empty
+// string when it cannot be resolved
+func (g *CallGraph) resolveCall(caller *CallGraphFunction, call *parser.Node) string {
+	callee, ok := call.Value.(*parser.Node)
+	if !ok {
+		return ""
+	}
+	id := g.resolveReference(caller, callee)
+	if _, ok := g.Functions[id]; !ok {
+		return ""
+	}
+	return id
+}
+
+// resolveReference returns the ID of the function or class a name or a
+// `self.`/`cls.` attribute refers to, or an empty string when it cannot be
+// resolved
+func (g *CallGraph) resolveReference(caller *CallGraphFunction, node *parser.Node) string {
+	switch node.Type {
+	case parser.NodeName:
+		return g.resolveName(caller.FilePath, caller.scope(), node.Name)
+
+	case parser.NodeAttribute:
+		object, ok := node.Value.(*parser.Node)
+		if !ok || object.Type != parser.NodeName || (object.Name != "self" && object.Name != "cls") {
+			return ""
+		}
+		idx := strings.LastIndex(caller.scope(), ".")
+		if idx == -1 {
+			return ""
+		}
+		id := CallGraphID(caller.FilePath, caller.Name[:idx]+"."+node.Name)
+		if _, ok := g.Functions[id]; ok {
+			return id
+		}
+	}
+	return ""
+}
+
+// resolveName looks a name up in scope, its enclosing scopes and the
+// module, innermost first, and then in the names the file imports
+func (g *CallGraph) resolveName(filePath, scope, name string) string {
+	for {
+		if id := CallGraphID(filePath, qualifiedScopeName(scope, name)); g.hasNode(id) {
+			return id
+		}
+		if scope == "" {
+			break
+		}
+		if idx := strings.LastIndex(scope, "."); idx != -1 {
+			scope = scope[:idx]
+		} else {
+			scope = ""
+		}
+	}
+	return g.resolveImport(filePath, name)
+}
+
+// resolveImport resolves a name bound by `from module import name`
+func (g *CallGraph) resolveImport(filePath, name string) string {
+	file := g.files[filePath]
+	if file == nil {
+		return ""
+	}
+	target, ok := file.imports[name]
+	if !ok {
+		return ""
+	}
+	if id, ok := file.targets[name]; ok {
+		return id
+	}
+	id := g.findImported(filePath, target)
+	file.targets[name] = id
+	return id
+}
+
+// findImported returns the ID of the function or class a "module:name"
+// import of filePath binds
+func (g *CallGraph) findImported(filePath, target string) string {
+	module, imported, _ := strings.Cut(target, ":")
+
+	modPath := strings.ReplaceAll(strings.TrimLeft(module, "."), ".", "/")
+	if level := len(module) - len(strings.TrimLeft(module, ".")); level > 0 {
+		dir := filepath.ToSlash(filepath.Dir(filePath))
+		for i := 1; i < level; i++ {
+			dir = filepath.ToSlash(filepath.Dir(dir))
+		}
+		modPath = strings.TrimSuffix(dir+"/"+modPath, "/")
+	}
+
+	for path, other := range g.files {
+		if other.modPath == modPath || strings.HasSuffix(other.modPath, "/"+modPath) {
+			if g.hasNode(CallGraphID(path, imported)) {
+				return CallGraphID(path, imported)
+			}
+		}
+	}
+	return ""
+}
+
+// collectFromImports records `from module import name [as alias]` bindings
+func collectFromImports(ast *parser.Node, imports map[string]string) {
+	ast.Walk(func(n *parser.Node) bool {
+		if n.Type != parser.NodeImportFrom {
+			return true
+		}
+		module := n.Module
+		if n.Level > 0 {
+			module = strings.Repeat(".", n.Level) + module
+		}
+		for _, name := range n.Names {
+			if name != "*" {
+				imports[name] = module + ":" + name
+			}
+		}
+		// Aliased names are bound under the alias only
+		for _, child := range n.Children {
+			if child.Type != parser.NodeAlias {
+				continue
+			}
+			if asName, ok := child.Value.(string); ok && asName != "" {
+				delete(imports, child.Name)
+				imports[asName] = module + ":" + child.Name
+			}
+		}
+		return false
+	})
+}
+
+// Recursion finds recursive functions using strongly connected components
+// of the call graph. Functions that are not recursive are omitted.
+func (g *CallGraph) Recursion() map[string]*RecursionInfo {
+	result := make(map[string]*RecursionInfo)
+
+	for _, component := range g.stronglyConnectedComponents() {
+		if len(component) == 1 && !g.Functions[component[0]].Calls[component[0]] {
+			continue
+		}
+		members := make(map[string]bool, len(component))
+		names := make([]string, 0, len(component))
+		for _, id := range component {
+			members[id] = true
+			names = append(names, g.Functions[id].Name)
+		}
+		sort.Strings(names)
+
+		for _, id := range component {
+			fn := g.Functions[id]
+			info := &RecursionInfo{
+				Kind:        RecursionMutual,
+				CycleLength: g.shortestCycle(id, members),
+				Cycle:       names,
+			}
+			if fn.Calls[id] {
+				info.Kind = RecursionDirect
+			}
+
+			cfgStmts := make(map[*parser.Node]bool)
+			for _, block := range fn.CFG.Blocks {
+				for _, value := range block.Statements {
+					if stmt := mustPythonNode(value); stmt != nil {
+						cfgStmts[stmt] = true
+					}
+				}
+			}
+
+			recursiveStmts := make(map[*parser.Node]bool)
+			for _, call := range fn.callSites {
+				if callee := g.resolveCall(fn, call); members[callee] {
+					info.RecursiveCalls++
+					stmt := enclosingStatement(call, cfgStmts)
+					if stmt != nil && !isConditionallyEvaluated(call, stmt) {
+						recursiveStmts[stmt] = true
+					}
+				}
+			}
+			info.Unbounded = !hasPathAvoiding(fn.CFG, recursiveStmts)
+			result[id] = info
+		}
+	}
+
+	return result
+}
+
+// stronglyConnectedComponents returns the SCCs of the graph (Tarjan)
+func (g *CallGraph) stronglyConnectedComponents() [][]string {
+	ids := make([]string, 0, len(g.Functions))
+	for id := range g.Functions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	index := 0
+	indices := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var components [][]string
+
+	var connect func(v string)
+	connect = func(v string) {
+		indices[v] = index
+		lowlink[v] = index
+		index++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		callees := make([]string, 0, len(g.Functions[v].Calls))
+		for w := range g.Functions[v].Calls {
+			callees = append(callees, w)
+		}
+		sort.Strings(callees)
+		for _, w := range callees {
+			if _, visited := indices[w]; !visited {
+				connect(w)
+				lowlink[v] = min(lowlink[v], lowlink[w])
+			} else if onStack[w] {
+				lowlink[v] = min(lowlink[v], indices[w])
+			}
+		}
+
+		if lowlink[v] == indices[v] {
+			var component []string
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				component = append(component, w)
+				if w == v {
+					break
+				}
+			}
+			components = append(components, component)
+		}
+	}
+
+	for _, id := range ids {
+		if _, visited := indices[id]; !visited {
+			connect(id)
+		}
+	}
+	return components
+}
+
+// shortestCycle returns the length of the shortest call cycle from id back to
+// itself within the component
+func (g *CallGraph) shortestCycle(id string, members map[string]bool) int {
+	distance := map[string]int{id: 0}
+	queue := []string{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for callee := range g.Functions[current].Calls {
+			if !members[callee] {
+				continue
+			}
+			if callee == id {
+				return distance[current] + 1
+			}
+			if _, seen := distance[callee]; !seen {
+				distance[callee] = distance[current] + 1
+				queue = append(queue, callee)
+			}
+		}
+	}
+	return 0
+}
+
+// enclosingStatement returns the innermost CFG statement containing node
+func enclosingStatement(node *parser.Node, stmts map[*parser.Node]bool) *parser.Node {
+	for ; node != nil; node = node.Parent {
+		if stmts[node] {
+			return node
+		}
+	}
+	return nil
+}
+
+// isConditionallyEvaluated reports whether a call inside stmt only runs under
+// a condition of its own, as in `return f(n - 1) if n else 1`
+func isConditionallyEvaluated(call, stmt *parser.Node) bool {
+	for node := call.Parent; node != nil && node != stmt; node = node.Parent {
+		switch node.Type {
+		case parser.NodeIfExp, parser.NodeBoolOp, parser.NodeLambda,
+			parser.NodeListComp, parser.NodeSetComp, parser.NodeDictComp, parser.NodeGeneratorExp:
+			return true
+		}
+	}
+	return false
+}
+
+// hasPathAvoiding reports whether the exit of a CFG can be reached from its
+// entry without executing a block that contains one of the given statements
+func hasPathAvoiding(cfg *CFG, stmts map[*parser.Node]bool) bool {
+	if cfg == nil {
+		return true
+	}
+	visited := make(map[*BasicBlock]bool)
+	stack := []*BasicBlock{cfg.Entry}
+	for len(stack) > 0 {
+		block := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if block == nil || visited[block] {
+			continue
+		}
+		visited[block] = true
+		if block == cfg.Exit {
+			return true
+		}
+		if blockExecutesAny(block, stmts) {
+			continue
+		}
+		for _, edge := range block.Successors {
+			if edge != nil {
+				stack = append(stack, edge.To)
+			}
+		}
+	}
+	return false
+}
+
+func blockExecutesAny(block *BasicBlock, stmts map[*parser.Node]bool) bool {
+	for _, value := range block.Statements {
+		if stmt := mustPythonNode(value); stmt != nil && stmts[stmt] {
+			return true
+		}
+	}
+	return false
+}
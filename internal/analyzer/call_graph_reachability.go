@@ -0,0 +1,156 @@
+package analyzer
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// Reachable returns the IDs of the functions and classes reachable from the
+// roots through calls and uses, and through string references when
+// stringRefs is set. Reaching a class reaches its methods and bases, and
+// reaching anything defined in a file reaches the module-level code of the
+// file, which ran when the file was imported.
+func (g *CallGraph) Reachable(roots []string, stringRefs bool) map[string]bool {
+	reached := make(map[string]bool)
+	queue := make([]string, 0, len(roots))
+	visit := func(id string) {
+		if !reached[id] && g.hasNode(id) {
+			reached[id] = true
+			queue = append(queue, id)
+		}
+	}
+	visitAll := func(ids map[string]bool) {
+		for id := range ids {
+			visit(id)
+		}
+	}
+
+	for _, root := range roots {
+		visit(root)
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if fn, ok := g.Functions[id]; ok {
+			visitAll(fn.Calls)
+			visitAll(fn.Uses)
+			if stringRefs {
+				visitAll(fn.StringRefs)
+			}
+			visit(CallGraphID(fn.FilePath, domain.ModuleFunctionName))
+			continue
+		}
+		class := g.Classes[id]
+		for _, method := range class.Methods {
+			visit(method)
+		}
+		visitAll(class.Bases)
+		visit(CallGraphID(class.FilePath, domain.ModuleFunctionName))
+	}
+	return reached
+}
+
+// EntryPoint returns the ID of the function, class or module-level code an
+// entry point names. It accepts a file ("app/main.py"), a function or class
+// of a file by qualified name ("app/main.py::Cli.run"), a module
+// ("app.main"), and a function or class of a module, as in console scripts
+// ("app.main:run") or dotted ("app.main.run").
+func (g *CallGraph) EntryPoint(spec string) (string, error) {
+	if path, name, ok := strings.Cut(spec, "::"); ok {
+		file, err := g.findFile(path)
+		if err != nil {
+			return "", err
+		}
+		return g.lookupEntryPoint(file, name)
+	}
+	if strings.HasSuffix(spec, ".py") || strings.ContainsAny(spec, `/\`) {
+		file, err := g.findFile(spec)
+		if err != nil {
+			return "", err
+		}
+		return CallGraphID(file, domain.ModuleFunctionName), nil
+	}
+
+	// Console scripts may carry extras, as in "app.main:run [cli]"
+	spec = strings.TrimSpace(strings.SplitN(spec, "[", 2)[0])
+	if module, name, ok := strings.Cut(spec, ":"); ok {
+		file, err := g.findModule(strings.TrimSpace(module))
+		if err != nil {
+			return "", err
+		}
+		return g.lookupEntryPoint(file, strings.TrimSpace(name))
+	}
+
+	// The longest module prefix of a dotted name wins
+	parts := strings.Split(spec, ".")
+	for i := len(parts); i > 0; i-- {
+		if len(g.moduleFiles(strings.Join(parts[:i], "."))) == 0 {
+			continue
+		}
+		file, err := g.findModule(strings.Join(parts[:i], "."))
+		if err != nil {
+			return "", err
+		}
+		if i == len(parts) {
+			return CallGraphID(file, domain.ModuleFunctionName), nil
+		}
+		return g.lookupEntryPoint(file, strings.Join(parts[i:], "."))
+	}
+	return "", fmt.Errorf("module %s is not among the analyzed files", spec)
+}
+
+// lookupEntryPoint returns the ID of a function or class of a file
+func (g *CallGraph) lookupEntryPoint(file, name string) (string, error) {
+	if id := CallGraphID(file, name); g.hasNode(id) {
+		return id, nil
+	}
+	return "", fmt.Errorf("no function or class %s in %s", name, file)
+}
+
+// findFile returns the analyzed file at path
+func (g *CallGraph) findFile(path string) (string, error) {
+	want := filepath.Clean(path)
+	wantAbs, _ := filepath.Abs(want)
+	for file := range g.files {
+		if filepath.Clean(file) == want {
+			return file, nil
+		}
+		if abs, err := filepath.Abs(file); err == nil && abs == wantAbs {
+			return file, nil
+		}
+	}
+	return "", fmt.Errorf("file %s is not among the analyzed files", path)
+}
+
+// findModule returns the analyzed file of a dotted module name, matched
+// against the end of file paths so that source directories such as src/
+// do not matter
+func (g *CallGraph) findModule(module string) (string, error) {
+	matches := g.moduleFiles(module)
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		return "", fmt.Errorf("module %s is not among the analyzed files", module)
+	default:
+		return "", fmt.Errorf("module %s is ambiguous: %s", module, strings.Join(matches, ", "))
+	}
+}
+
+// moduleFiles returns the analyzed files whose path ends with a dotted
+// module name, sorted
+func (g *CallGraph) moduleFiles(module string) []string {
+	modPath := strings.ReplaceAll(module, ".", "/")
+	var matches []string
+	for path, file := range g.files {
+		if file.modPath == modPath || strings.HasSuffix(file.modPath, "/"+modPath) {
+			matches = append(matches, path)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
@@ -0,0 +1,266 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+)
+
+func buildCallGraph(t *testing.T, files map[string]string) *CallGraph {
+	t.Helper()
+	graph := NewCallGraph()
+	for path, source := range files {
+		ast := parseSource(t, source)
+		cfgs, err := NewCFGBuilder().BuildAll(ast)
+		if err != nil {
+			t.Fatalf("Failed to build CFGs for %s: %v", path, err)
+		}
+		graph.AddFile(path, ast, cfgs)
+	}
+	graph.Resolve()
+	return graph
+}
+
+func TestCallGraphRecursion(t *testing.T) {
+	graph := buildCallGraph(t, map[string]string{
+		"pkg/tree.py": `
+from .walker import visit_children as visit_all
+
+def factorial(n):
+    if n <= 1:
+        return 1
+    return n * factorial(n - 1)
+
+def forever(n):
+    return forever(n + 1)
+
+def ternary(n):
+    return 1 if n == 0 else ternary(n - 1)
+
+def is_even(n):
+    if n == 0:
+        return True
+    return is_odd(n - 1)
+
+def is_odd(n):
+    return is_even(n - 1)
+
+def visit(node):
+    for child in node.children:
+        visit_all(child)
+
+def helper():
+    return factorial(3)
+
+class Node:
+    def depth(self):
+        return 1 + self.depth()
+
+    def outer(self):
+        def inner(x):
+            return inner(x)
+        return inner(1)
+`,
+		"pkg/walker.py": `
+from pkg.tree import visit
+
+def visit_children(node):
+    visit(node)
+`,
+	})
+
+	recursion := graph.Recursion()
+
+	tests := []struct {
+		id          string
+		kind        string
+		cycleLength int
+		unbounded   bool
+	}{
+		{CallGraphID("pkg/tree.py", "factorial"), RecursionDirect, 1, false},
+		{CallGraphID("pkg/tree.py", "forever"), RecursionDirect, 1, true},
+		{CallGraphID("pkg/tree.py", "ternary"), RecursionDirect, 1, false},
+		{CallGraphID("pkg/tree.py", "is_even"), RecursionMutual, 2, false},
+		{CallGraphID("pkg/tree.py", "is_odd"), RecursionMutual, 2, true},
+		{CallGraphID("pkg/tree.py", "visit"), RecursionMutual, 2, false},
+		{CallGraphID("pkg/walker.py", "visit_children"), RecursionMutual, 2, true},
+		{CallGraphID("pkg/tree.py", "Node.depth"), RecursionDirect, 1, true},
+		{CallGraphID("pkg/tree.py", "Node.outer.inner"), RecursionDirect, 1, true},
+	}
+	for _, tt := range tests {
+		info := recursion[tt.id]
+		if info == nil {
+			t.Errorf("%s: expected recursion", tt.id)
+			continue
+		}
+		if info.Kind != tt.kind || info.CycleLength != tt.cycleLength || info.Unbounded != tt.unbounded {
+			t.Errorf("%s: got kind=%s cycle=%d unbounded=%v, want %s %d %v",
+				tt.id, info.Kind, info.CycleLength, info.Unbounded, tt.kind, tt.cycleLength, tt.unbounded)
+		}
+	}
+
+	for _, name := range []string{"helper", "Node.outer"} {
+		if info := recursion[CallGraphID("pkg/tree.py", name)]; info != nil {
+			t.Errorf("%s should not be recursive, got %+v", name, info)
+		}
+	}
+
+	if cycle := recursion[CallGraphID("pkg/tree.py", "is_even")].Cycle; len(cycle) != 2 || cycle[0] != "is_even" || cycle[1] != "is_odd" {
+		t.Errorf("unexpected cycle %v", cycle)
+	}
+}
+
+func TestCallGraphReachability(t *testing.T) {
+	graph := buildCallGraph(t, map[string]string{
+		"src/app/cli.py": `
+from .core import Engine, helper
+
+def main():
+    engine = Engine()
+    return helper(engine, on_done=cleanup)
+
+def cleanup():
+    pass
+
+def unused():
+    def inner():
+        pass
+    return inner
+`,
+		"src/app/core.py": `
+class Base:
+    def shared(self):
+        pass
+
+class Engine(Base):
+    def run(self):
+        return sorted([], key=lambda v: score(v))
+
+def score(v):
+    return v
+
+def helper(engine, on_done):
+    return engine.run()
+
+def orphan():
+    return score(1)
+
+def register(fn):
+    return fn
+
+@register
+def plugin():
+    pass
+`,
+	})
+
+	root, err := graph.EntryPoint("app.cli:main")
+	if err != nil || root != CallGraphID("src/app/cli.py", "main") {
+		t.Fatalf("EntryPoint = %q, %v", root, err)
+	}
+	reached := graph.Reachable([]string{root}, true)
+
+	for _, name := range []string{"main", "cleanup", "<module>"} {
+		if !reached[CallGraphID("src/app/cli.py", name)] {
+			t.Errorf("cli %s should be reachable", name)
+		}
+	}
+	for _, name := range []string{"Engine", "Engine.run", "Base", "Base.shared", "score", "helper", "register", "plugin", "<module>"} {
+		if !reached[CallGraphID("src/app/core.py", name)] {
+			t.Errorf("core %s should be reachable", name)
+		}
+	}
+	for _, id := range []string{CallGraphID("src/app/cli.py", "unused"), CallGraphID("src/app/cli.py", "unused.inner"), CallGraphID("src/app/core.py", "orphan")} {
+		if reached[id] {
+			t.Errorf("%s should not be reachable", id)
+		}
+	}
+}
+
+func TestCallGraphStringReferences(t *testing.T) {
+	graph := buildCallGraph(t, map[string]string{
+		"shop/models.py": `
+class Customer:
+    pass
+
+class Order:
+    customer = ForeignKey("shop.Customer")
+
+class Unused:
+    pass
+`,
+		"shop/tasks.py": `
+def send_receipt(order):
+    pass
+
+def handle(event):
+    return getattr(event, "on_paid")
+
+def on_paid():
+    pass
+
+def run():
+    enqueue("shop.tasks.send_receipt", "not a name")
+    return handle
+`,
+	})
+
+	run := CallGraphID("shop/tasks.py", "run")
+	if refs := graph.Functions[run].StringRefs; !refs[CallGraphID("shop/tasks.py", "send_receipt")] || len(refs) != 1 {
+		t.Errorf("run string references = %v, want send_receipt only", refs)
+	}
+	module := CallGraphID("shop/models.py", "<module>")
+	if refs := graph.Functions[module].StringRefs; !refs[CallGraphID("shop/models.py", "Customer")] {
+		t.Errorf("class body strings should be module string references, got %v", refs)
+	}
+
+	reached := graph.Reachable([]string{run}, true)
+	for _, id := range []string{CallGraphID("shop/tasks.py", "send_receipt"), CallGraphID("shop/tasks.py", "on_paid")} {
+		if !reached[id] {
+			t.Errorf("%s should be reachable through a string reference", id)
+		}
+	}
+	if reached[CallGraphID("shop/models.py", "Unused")] {
+		t.Error("Unused should not be reachable")
+	}
+
+	reached = graph.Reachable([]string{run}, false)
+	if reached[CallGraphID("shop/tasks.py", "send_receipt")] || reached[CallGraphID("shop/tasks.py", "on_paid")] {
+		t.Error("string references should not be followed when disabled")
+	}
+}
+
+func TestCallGraphEntryPoint(t *testing.T) {
+	graph := buildCallGraph(t, map[string]string{
+		"src/app/cli.py":      "def main():\n    pass\n\nclass Cli:\n    def run(self):\n        pass\n",
+		"src/app/__init__.py": "",
+		"other/app/cli.py":    "",
+	})
+
+	tests := []struct {
+		spec string
+		want string
+		err  string
+	}{
+		{"src/app/cli.py", CallGraphID("src/app/cli.py", "<module>"), ""},
+		{"./src/app/cli.py::Cli.run", CallGraphID("src/app/cli.py", "Cli.run"), ""},
+		{"src.app.cli:main [extra]", CallGraphID("src/app/cli.py", "main"), ""},
+		{"src.app.cli.Cli", CallGraphID("src/app/cli.py", "Cli"), ""},
+		{"app", CallGraphID("src/app/__init__.py", "<module>"), ""},
+		{"app.cli:main", "", "ambiguous"},
+		{"src/app/cli.py::missing", "", "no function or class missing"},
+		{"missing.py", "", "not among the analyzed files"},
+		{"missing.mod", "", "not among the analyzed files"},
+	}
+	for _, tt := range tests {
+		got, err := graph.EntryPoint(tt.spec)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("EntryPoint(%q) error = %v, want %q", tt.spec, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("EntryPoint(%q) = %q, %v, want %q", tt.spec, got, err, tt.want)
+		}
+	}
+}
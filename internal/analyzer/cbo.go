@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"sort"
@@ -29,6 +30,15 @@ type CBOResult struct {
 	AttributeAccessDependencies int
 	ImportDependencies          int
 
+	// Coupling by kind. A class counts once under every kind it is coupled
+	// through, imported or not, so the kinds can add up to more than
+	// CouplingCount.
+	InheritanceCoupling   int
+	CompositionCoupling   int
+	InstantiationCoupling int
+	MethodCallCoupling    int
+	TypeHintCoupling      int
+
 	// Detailed dependency list
 	DependentClasses []string
 
@@ -48,6 +58,7 @@ type CBOOptions struct {
 	IncludeImports        bool
 	PublicClassesOnly     bool
 	GroupNamespaceImports bool
+	IncludeTypeHints      bool // Count classes referenced only in annotations
 	ExcludePatterns       []string
 	LowThreshold          int // Default: 3 (industry standard)
 	MediumThreshold       int // Default: 7 (industry standard)
@@ -61,6 +72,7 @@ func DefaultCBOOptions() *CBOOptions {
 		IncludeImports:        true,
 		PublicClassesOnly:     false,
 		GroupNamespaceImports: true,
+		IncludeTypeHints:      true,
 		ExcludePatterns:       []string{"test_*", "*_test", "__*__"},
 		LowThreshold:          domain.DefaultCBOLowThreshold,    // Industry standard: CBO <= 3 is low risk
 		MediumThreshold:       domain.DefaultCBOMediumThreshold, // Industry standard: 3 < CBO <= 7 is medium risk
@@ -77,12 +89,9 @@ type CBOAnalyzer struct {
 	namespaceAliases map[string]string         // module.name -> alias mapping (only for alias imports)
 	regexCache       map[string]*regexp.Regexp // pattern -> compiled regex cache
 
-	// futureAnnotations is true when the file being analyzed has
-	// `from __future__ import annotations` (PEP 563) at module scope. Under
-	// PEP 563 all annotations are evaluated as strings at runtime, so a class
-	// referenced only inside a type annotation is never actually imported or
-	// resolved and imposes zero coupling cost. File-local, reset per AST. See #628.
-	futureAnnotations bool
+	// annotationParser parses string annotations ("Foo", "list[Foo]");
+	// created on first use
+	annotationParser *parser.Parser
 }
 
 type cboDependencyKind int
@@ -93,6 +102,7 @@ const (
 	dependencyKindInstantiation
 	dependencyKindAttributeAccess
 	dependencyKindImport
+	dependencyKindComposition
 )
 
 type cboImportMaps struct {
@@ -107,6 +117,10 @@ type cboDependencies struct {
 	instantiations    map[string]bool
 	attributeAccesses map[string]bool
 	imports           map[string]bool
+
+	// byKind records every kind a dependency is used through; the maps above
+	// file imported dependencies under imports only
+	byKind map[cboDependencyKind]map[string]bool
 }
 
 func newCBODependencies() *cboDependencies {
@@ -117,6 +131,7 @@ func newCBODependencies() *cboDependencies {
 		instantiations:    make(map[string]bool),
 		attributeAccesses: make(map[string]bool),
 		imports:           make(map[string]bool),
+		byKind:            make(map[cboDependencyKind]map[string]bool),
 	}
 }
 
@@ -160,7 +175,6 @@ func (a *CBOAnalyzer) AnalyzeClasses(ast *parser.Node, filePath string) ([]*CBOR
 	// cannot change dependency identity in the next file.
 	a.importedNames = imports.importedNames
 	a.namespaceAliases = imports.namespaceAliases
-	a.futureAnnotations = a.hasFutureAnnotations(ast)
 
 	var results []*CBOResult
 
@@ -228,6 +242,11 @@ func (a *CBOAnalyzer) analyzeClass(classNode *parser.Node, filePath string, allC
 	result.InstantiationDependencies = len(dependencies.instantiations)
 	result.AttributeAccessDependencies = len(dependencies.attributeAccesses)
 	result.ImportDependencies = len(dependencies.imports)
+	result.InheritanceCoupling = len(dependencies.byKind[dependencyKindInheritance])
+	result.CompositionCoupling = len(dependencies.byKind[dependencyKindComposition])
+	result.InstantiationCoupling = len(dependencies.byKind[dependencyKindInstantiation])
+	result.MethodCallCoupling = len(dependencies.byKind[dependencyKindAttributeAccess])
+	result.TypeHintCoupling = len(dependencies.byKind[dependencyKindTypeHint])
 	result.RiskLevel = a.assessRiskLevel(result.CouplingCount)
 
 	// 6. Check if class is abstract
@@ -254,6 +273,9 @@ func (a *CBOAnalyzer) removeSameFileTopLevelPeers(dependencies *cboDependencies,
 		delete(dependencies.instantiations, depName)
 		delete(dependencies.attributeAccesses, depName)
 		delete(dependencies.imports, depName)
+		for _, names := range dependencies.byKind {
+			delete(names, depName)
+		}
 	}
 }
 
@@ -282,9 +304,20 @@ func (a *CBOAnalyzer) analyzeTypeHints(classNode *parser.Node, dependencies *cbo
 			// Variable with type annotation: x: SomeType = value. A class-body
 			// or method-body annotation resolves at its own lexical scope, so
 			// no signature scope is forced here.
+			// An annotated attribute also composes the annotated types.
+			hinted := dependencies
+			if a.isCompositionTarget(node, classNode) {
+				hinted = newCBODependencies()
+			}
 			for _, child := range node.Children {
 				if child != nil && a.isTypeAnnotation(child) {
-					a.extractTypeAnnotationDependencies(child, dependencies, result, resolver, nil)
+					a.extractTypeAnnotationDependencies(child, hinted, result, resolver, nil)
+				}
+			}
+			if hinted != dependencies {
+				for name := range hinted.all {
+					a.addDependency(dependencies, name, dependencyKindTypeHint)
+					a.addDependency(dependencies, name, dependencyKindComposition)
 				}
 			}
 		case parser.NodeFunctionDef, parser.NodeAsyncFunctionDef:
@@ -305,7 +338,8 @@ func (a *CBOAnalyzer) isTypeAnnotation(node *parser.Node) bool {
 		node.Type == parser.NodeTypeNode ||
 		node.Type == parser.NodeGenericType ||
 		node.Type == parser.NodeTypeParameter ||
-		node.Type == parser.NodeBinOp // Union type: X | Y (Python 3.10+)
+		node.Type == parser.NodeBinOp || // Union type: X | Y (Python 3.10+)
+		isStringConstant(node) // Forward reference: "User"
 }
 
 // extractTypeAnnotationDependencies extracts class dependencies from type
@@ -323,7 +357,11 @@ func (a *CBOAnalyzer) extractTypeAnnotationDependencies(node *parser.Node, depen
 	case parser.NodeSubscript:
 		// Generic type: List[User], Dict[str, User]
 		// For generics, we want to extract the type parameters, not the container
-		for _, typeArg := range a.typeArgumentNodes(node) {
+		typeArgs := a.typeArgumentNodes(node)
+		if container, ok := node.Value.(*parser.Node); ok && container != nil {
+			typeArgs = a.typeArgumentsOf(a.extractClassName(container), typeArgs)
+		}
+		for _, typeArg := range typeArgs {
 			a.extractTypeAnnotationDependencies(typeArg, dependencies, result, resolver, sigScope)
 		}
 	case parser.NodeAttribute:
@@ -349,9 +387,24 @@ func (a *CBOAnalyzer) extractTypeAnnotationDependencies(node *parser.Node, depen
 	case parser.NodeGenericType:
 		// Tree-sitter generic_type node (e.g., List[User])
 		// Look for type_parameter children to get the actual types we depend on
+		container := ""
 		for _, child := range node.Children {
-			if child != nil && child.Type == parser.NodeTypeParameter {
-				a.extractTypeAnnotationDependencies(child, dependencies, result, resolver, sigScope)
+			if child == nil {
+				continue
+			}
+			switch child.Type {
+			case parser.NodeName, parser.NodeAttribute:
+				container = a.extractClassName(child)
+			case parser.NodeTypeParameter:
+				var typeArgs []*parser.Node
+				for _, arg := range child.Children {
+					if arg != nil && a.isTypeAnnotation(arg) {
+						typeArgs = append(typeArgs, arg)
+					}
+				}
+				for _, typeArg := range a.typeArgumentsOf(container, typeArgs) {
+					a.extractTypeAnnotationDependencies(typeArg, dependencies, result, resolver, sigScope)
+				}
 			}
 		}
 	case parser.NodeTypeParameter:
@@ -361,6 +414,16 @@ func (a *CBOAnalyzer) extractTypeAnnotationDependencies(node *parser.Node, depen
 				a.extractTypeAnnotationDependencies(child, dependencies, result, resolver, sigScope)
 			}
 		}
+	case parser.NodeConstant:
+		// String annotation: "User", "list[User]", "User | None". Parse it
+		// and resolve the names in the scope the string appears in.
+		if expr := a.parseStringAnnotation(node); expr != nil {
+			scope := sigScope
+			if scope == nil {
+				scope = resolver.referenceScope(node)
+			}
+			a.extractTypeAnnotationDependencies(expr, dependencies, result, resolver, scope)
+		}
 	case parser.NodeBinOp:
 		// Union type using | operator (Python 3.10+): Context | None, str | int
 		if node.Op == "|" {
@@ -375,6 +438,49 @@ func (a *CBOAnalyzer) extractTypeAnnotationDependencies(node *parser.Node, depen
 	}
 }
 
+// typeArgumentsOf returns the arguments of a generic annotation that name
+// types: none for Literal, whose arguments are values, and only the first
+// for Annotated, whose other arguments are metadata
+func (a *CBOAnalyzer) typeArgumentsOf(container string, typeArgs []*parser.Node) []*parser.Node {
+	switch dependencyLeafName(a.resolveImportedName(container)) {
+	case "Literal":
+		return nil
+	case "Annotated":
+		if len(typeArgs) == 1 && (typeArgs[0].Type == parser.NodeTuple || typeArgs[0].Type == parser.NodeList) {
+			typeArgs = typeArgs[0].Children
+		}
+		if len(typeArgs) > 1 {
+			return typeArgs[:1]
+		}
+	}
+	return typeArgs
+}
+
+// parseStringAnnotation parses the expression of a string annotation, or
+// returns nil when the string is not a valid expression
+func (a *CBOAnalyzer) parseStringAnnotation(node *parser.Node) *parser.Node {
+	text, ok := node.Value.(string)
+	if !ok {
+		return nil
+	}
+	text = strings.TrimSpace(strings.Trim(text, `"'`))
+	if text == "" {
+		return nil
+	}
+	if a.annotationParser == nil {
+		a.annotationParser = parser.New()
+	}
+	parsed, err := a.annotationParser.Parse(context.Background(), []byte(text))
+	if err != nil || parsed.AST == nil || len(parsed.AST.Body) != 1 {
+		return nil
+	}
+	expr := parsed.AST.Body[0]
+	if !a.isTypeAnnotation(expr) || isStringConstant(expr) {
+		return nil
+	}
+	return expr
+}
+
 // annotationExcluded reports whether a type-annotation reference resolves to a
 // nested class and so must not be counted as coupling. When sigScope is set
 // (a method signature annotation) the name is resolved in that fixed scope;
@@ -426,15 +532,19 @@ func (a *CBOAnalyzer) analyzeInstantiationAndAccess(classNode *parser.Node, depe
 
 	a.walkNode(classNode, func(node *parser.Node) bool {
 		switch node.Type {
-		case parser.NodeAssign:
+		case parser.NodeAssign, parser.NodeAnnAssign:
 			// Assignment that might contain class instantiation: self.logger = Logger()
-			// Use structural AST analysis instead of string parsing
+			// Use structural AST analysis instead of string parsing. Storing
+			// the instance in an attribute is composition as well.
 			if node.Value != nil {
 				if valueNode, ok := node.Value.(*parser.Node); ok {
 					if valueNode.Type == parser.NodeCall {
 						className := a.extractClassNameFromCallNode(valueNode)
 						if dep := a.callDependencyName(className, allClasses, false); dep != "" && include(node, dep) {
-							a.addDependency(dependencies, dep, dependencyKindInstantiation)
+							a.addDependency(dependencies, dep, a.callDependencyKind(className, dep))
+							if a.isCompositionTarget(node, classNode) {
+								a.addDependency(dependencies, dep, dependencyKindComposition)
+							}
 						}
 						// Note: function calls are NOT added to dependencies
 					}
@@ -445,7 +555,7 @@ func (a *CBOAnalyzer) analyzeInstantiationAndAccess(classNode *parser.Node, depe
 			// Use structural AST analysis instead of string parsing
 			className := a.extractClassNameFromCallNode(node)
 			if dep := a.callDependencyName(className, allClasses, false); dep != "" && include(node, dep) {
-				a.addDependency(dependencies, dep, dependencyKindInstantiation)
+				a.addDependency(dependencies, dep, a.callDependencyKind(className, dep))
 			}
 			// Note: function calls are NOT added to dependencies
 		case parser.NodeAttribute:
@@ -480,6 +590,39 @@ func (a *CBOAnalyzer) analyzeInstantiationAndAccess(classNode *parser.Node, depe
 	})
 }
 
+// callDependencyKind tells a constructor call from a method called on the
+// class it depends on: Registry.lookup() calls a method of Registry, while
+// Registry() and models.Registry() instantiate it
+func (a *CBOAnalyzer) callDependencyKind(callee, dep string) cboDependencyKind {
+	if !strings.HasPrefix(callee, dep+".") {
+		return dependencyKindInstantiation
+	}
+	if a.looksLikeClassReference(dependencyLeafName(callee)) {
+		return dependencyKindInstantiation
+	}
+	return dependencyKindAttributeAccess
+}
+
+// isCompositionTarget reports whether an assignment stores its value on the
+// class: a statement of the class body or an assignment to self.x or cls.x
+func (a *CBOAnalyzer) isCompositionTarget(assign, classNode *parser.Node) bool {
+	for _, stmt := range classNode.Body {
+		if stmt == assign {
+			return true
+		}
+	}
+	for _, target := range assign.Targets {
+		if target == nil || target.Type != parser.NodeAttribute {
+			continue
+		}
+		if object, ok := target.Value.(*parser.Node); ok && object != nil && object.Type == parser.NodeName &&
+			(object.Name == "self" || object.Name == "cls") {
+			return true
+		}
+	}
+	return false
+}
+
 // Helper methods
 
 // collectClasses collects all class definitions from AST
@@ -649,6 +792,11 @@ func (r *nestedClassResolver) signatureScope(methodNode *parser.Node) *parser.No
 	return enclosingScope(methodNode, r.classNode, r.parents)
 }
 
+// referenceScope returns the function or class body a reference appears in
+func (r *nestedClassResolver) referenceScope(ref *parser.Node) *parser.Node {
+	return enclosingScope(ref, r.classNode, r.parents)
+}
+
 // collectImports collects import statements and their aliases.
 // It returns both the alias -> module.name map and a reverse map of
 // module.name -> alias for namespace aliases created by "import ... as ...".
@@ -715,60 +863,6 @@ func (a *CBOAnalyzer) collectImports(ast *parser.Node) cboImportMaps {
 	return imports
 }
 
-// hasFutureAnnotations reports whether the module contains
-// `from __future__ import annotations` (PEP 563). When present, every
-// annotation in the file is evaluated as a string at runtime rather than
-// resolved eagerly, so annotation-only references carry no import cost.
-//
-// Tree-sitter's Python grammar parses `from __future__ import ...` as its
-// own "future_import_statement" node rather than the general
-// import_from_statement, and pyscn's AST builder has no dedicated case for
-// it, so it falls through to a generic node whose Type is the raw grammar
-// name and whose children are the raw parse-tree tokens. We detect it by
-// that raw type and look for an "annotations" name among its children
-// rather than relying on node.Module/node.Names, which only ImportFrom
-// nodes populate.
-func (a *CBOAnalyzer) hasFutureAnnotations(ast *parser.Node) bool {
-	found := false
-	a.walkNode(ast, func(node *parser.Node) bool {
-		if found {
-			return false
-		}
-		if node.Type == parser.NodeImportFrom && node.Module == "__future__" {
-			for _, name := range node.Names {
-				if name == "annotations" {
-					found = true
-					return false
-				}
-			}
-		}
-		if string(node.Type) == "future_import_statement" && importsAnnotationsName(node) {
-			found = true
-			return false
-		}
-		return true
-	})
-	return found
-}
-
-// importsAnnotationsName reports whether an "annotations" Name token appears
-// anywhere among node's descendants. Used to inspect the raw children of a
-// future_import_statement node (see hasFutureAnnotations).
-func importsAnnotationsName(node *parser.Node) bool {
-	if node == nil {
-		return false
-	}
-	if node.Type == parser.NodeName && node.Name == "annotations" {
-		return true
-	}
-	for _, child := range node.Children {
-		if importsAnnotationsName(child) {
-			return true
-		}
-	}
-	return false
-}
-
 func importBindingName(module string) string {
 	if strings.Contains(module, ".") {
 		return strings.SplitN(module, ".", 2)[0]
@@ -1050,17 +1144,20 @@ func (a *CBOAnalyzer) addDependency(dependencies *cboDependencies, className str
 		return
 	}
 
-	// Under PEP 563 (`from __future__ import annotations`), annotations are
-	// stored as strings and never evaluated at runtime. A reference that
-	// appears only inside a type annotation therefore has zero import cost
-	// and should not be counted as coupling. See #628.
-	if kind == dependencyKindTypeHint && a.futureAnnotations {
+	// Annotations count the same whether they are evaluated eagerly, quoted,
+	// or deferred by `from __future__ import annotations`; excluding them is
+	// a matter of IncludeTypeHints, not of annotation style (see #628).
+	if kind == dependencyKindTypeHint && !a.options.IncludeTypeHints {
 		return
 	}
 
 	className = a.canonicalDependencyName(className)
 
 	dependencies.all[className] = true
+	if dependencies.byKind[kind] == nil {
+		dependencies.byKind[kind] = make(map[string]bool)
+	}
+	dependencies.byKind[kind][className] = true
 
 	if a.isImportedDependency(className) {
 		dependencies.imports[className] = true
@@ -1679,11 +1679,10 @@ class Outer:
 	assert.Equal(t, 0, outer.CouplingCount)
 }
 
-func TestCBOAnalyzer_FutureAnnotationsExcludeAnnotationOnlyDependencies(t *testing.T) {
-	// Regression test for #628: with `from __future__ import annotations`
-	// (PEP 563), annotations are stored as strings and never evaluated at
-	// runtime, so a class-level annotation referencing an imported type has
-	// zero import cost and must not inflate CBO.
+func TestCBOAnalyzer_ExcludeTypeHintsDropsAnnotationOnlyDependencies(t *testing.T) {
+	// #628: a class-level annotation referencing an imported type has no
+	// runtime cost under PEP 563. With IncludeTypeHints off, annotation-only
+	// references must not inflate CBO, whatever the annotation style.
 	pythonCode := `
 from __future__ import annotations
 import ast
@@ -1692,14 +1691,15 @@ class TOKENS:
     ASSERT: type[ast.Assert]
     ATTRIBUTE: type[ast.Attribute]
     CALL: type[ast.Call]
-    RETURN: type[ast.Return]
+    RETURN: "type[ast.Return]"
 `
 
 	ast, err := parseCode(pythonCode)
 	require.NoError(t, err)
 
-	analyzer := NewCBOAnalyzer(DefaultCBOOptions())
-	results, err := analyzer.AnalyzeClasses(ast, "test.py")
+	options := DefaultCBOOptions()
+	options.IncludeTypeHints = false
+	results, err := NewCBOAnalyzer(options).AnalyzeClasses(ast, "test.py")
 	require.NoError(t, err)
 
 	resultMap := make(map[string]*CBOResult)
@@ -1709,17 +1709,16 @@ class TOKENS:
 
 	tokens := resultMap["TOKENS"]
 	require.NotNil(t, tokens)
-	assert.Equal(t, 0, tokens.CouplingCount, "annotation-only references under PEP 563 must not count as coupling")
+	assert.Equal(t, 0, tokens.CouplingCount, "annotation-only references must not count without type hints")
 	assert.Equal(t, 0, tokens.ImportDependencies)
 	assert.Equal(t, 0, tokens.TypeHintDependencies)
 	assert.Empty(t, tokens.DependentClasses)
 }
 
-func TestCBOAnalyzer_FutureAnnotationsStillCountRuntimeUsage(t *testing.T) {
-	// Even with `from __future__ import annotations`, a name that is actually
-	// used at runtime (instantiated, called, or accessed) still gets
-	// evaluated eagerly and must keep counting as coupling. Only references
-	// that appear solely inside a type annotation are exempt.
+func TestCBOAnalyzer_ExcludeTypeHintsStillCountsRuntimeUsage(t *testing.T) {
+	// With IncludeTypeHints off, a name that is actually used at runtime
+	// (instantiated, called, or accessed) must keep counting as coupling.
+	// Only references that appear solely inside a type annotation are exempt.
 	pythonCode := `
 from __future__ import annotations
 import ast
@@ -1734,8 +1733,9 @@ class Visitor:
 	astTree, err := parseCode(pythonCode)
 	require.NoError(t, err)
 
-	analyzer := NewCBOAnalyzer(DefaultCBOOptions())
-	results, err := analyzer.AnalyzeClasses(astTree, "test.py")
+	options := DefaultCBOOptions()
+	options.IncludeTypeHints = false
+	results, err := NewCBOAnalyzer(options).AnalyzeClasses(astTree, "test.py")
 	require.NoError(t, err)
 
 	resultMap := make(map[string]*CBOResult)
@@ -1745,15 +1745,71 @@ class Visitor:
 
 	visitor := resultMap["Visitor"]
 	require.NotNil(t, visitor)
-	assert.Contains(t, visitor.DependentClasses, "ast.Call", "runtime instantiation must still count even under PEP 563")
-	assert.NotContains(t, visitor.DependentClasses, "ast.AST", "annotation-only reference must not count under PEP 563")
+	assert.Contains(t, visitor.DependentClasses, "ast.Call", "runtime instantiation must still count without type hints")
+	assert.NotContains(t, visitor.DependentClasses, "ast.AST", "annotation-only reference must not count without type hints")
 	assert.Equal(t, 1, visitor.CouplingCount)
 }
 
+func TestCBOAnalyzer_AnnotationStylesCountAlike(t *testing.T) {
+	// The same dependencies written through typing constructs, builtin
+	// generics, unions, string annotations, or under PEP 563 must yield the
+	// same coupling (see #628).
+	styles := map[string]string{
+		"typing": `
+from typing import List, Optional, Union
+from models import Order, User
+
+class Service:
+    def run(self, user: Optional[User], orders: List[Order]) -> Union[User, Order]: ...
+`,
+		"builtin generics and unions": `
+from models import Order, User
+
+class Service:
+    def run(self, user: User | None, orders: list[Order]) -> User | Order: ...
+`,
+		"strings": `
+from typing import Optional
+from models import Order, User
+
+class Service:
+    def run(self, user: "Optional[User]", orders: list["Order"]) -> "User | Order": ...
+`,
+		"future annotations": `
+from __future__ import annotations
+from models import Order, User
+
+class Service:
+    def run(self, user: User | None, orders: list[Order]) -> User | Order: ...
+`,
+		"literal and annotated": `
+from typing import Annotated, Literal
+from models import Order, User, Meta
+
+class Service:
+    kind: Literal["Meta"]
+
+    def run(self, user: Annotated[User, Meta], orders: list[Order]) -> Annotated[User | Order, "doc"]: ...
+`,
+	}
+
+	for name, code := range styles {
+		t.Run(name, func(t *testing.T) {
+			ast, err := parseCode(code)
+			require.NoError(t, err)
+
+			results, err := NewCBOAnalyzer(DefaultCBOOptions()).AnalyzeClasses(ast, "service.py")
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+
+			assert.Equal(t, []string{"Order", "User"}, results[0].DependentClasses)
+			assert.Equal(t, 2, results[0].TypeHintCoupling)
+		})
+	}
+}
+
 func TestCBOAnalyzer_WithoutFutureAnnotationsTypeHintsStillCount(t *testing.T) {
-	// Without `from __future__ import annotations`, annotations are evaluated
-	// eagerly, so the pre-#628 behavior (annotation references count as
-	// coupling) must be unchanged.
+	// By default annotation references count as coupling.
 	pythonCode := `
 import ast
 
@@ -1886,3 +1942,56 @@ func BenchmarkLargeClassHierarchy(b *testing.B) {
 		}
 	}
 }
+
+func TestCBOAnalyzer_CouplingKinds(t *testing.T) {
+	pythonCode := `
+from models import Base, Engine, Logger, Wheel, Driver, Route
+
+class Car(Base):
+    wheel: Wheel
+
+    def __init__(self):
+        self.engine = Engine()
+        self.logger = Logger()
+
+    def drive(self, driver: Driver) -> Route:
+        Logger.flush()
+        return Route()
+`
+
+	ast, err := parseCode(pythonCode)
+	require.NoError(t, err)
+
+	results, err := NewCBOAnalyzer(DefaultCBOOptions()).AnalyzeClasses(ast, "car.py")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	car := results[0]
+	assert.Equal(t, 6, car.CouplingCount)
+	assert.Equal(t, 1, car.InheritanceCoupling)   // Base
+	assert.Equal(t, 3, car.CompositionCoupling)   // Wheel, Engine, Logger
+	assert.Equal(t, 3, car.InstantiationCoupling) // Engine, Logger, Route
+	assert.Equal(t, 1, car.MethodCallCoupling)    // Logger
+	assert.Equal(t, 3, car.TypeHintCoupling)      // Wheel, Driver, Route
+}
+
+func TestCBOAnalyzer_CouplingKindsLocalInstanceIsNotComposition(t *testing.T) {
+	pythonCode := `
+from models import Engine
+
+class Car:
+    def start(self):
+        engine = Engine()
+        engine.run()
+`
+
+	ast, err := parseCode(pythonCode)
+	require.NoError(t, err)
+
+	results, err := NewCBOAnalyzer(DefaultCBOOptions()).AnalyzeClasses(ast, "car.py")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	assert.Equal(t, 1, results[0].InstantiationCoupling)
+	assert.Equal(t, 0, results[0].CompositionCoupling)
+}
@@ -3,6 +3,7 @@ package analyzer
 import (
 	"fmt"
 	"log"
+	"maps"
 	"strconv"
 	"strings"
 
@@ -18,6 +19,10 @@ const (
 	LabelEntry        = "ENTRY"
 	LabelExit         = "EXIT"
 
+	// LabelUnreachableAfterExit labels the code after a terminating call,
+	// e.g. sys.exit()
+	LabelUnreachableAfterExit = "unreachable_after_exit"
+
 	// Conditional labels
 	LabelIfThen  = "if_then"
 	LabelIfMerge = "if_merge"
@@ -41,6 +46,13 @@ const (
 	LabelMatchEval    = "match_eval"
 	LabelMatchCase    = "match_case"
 	LabelMatchMerge   = "match_merge"
+
+	// Async labels: suspension points and asyncio.gather fan-out
+	LabelAwaitResume       = "await_resume"
+	LabelGatherTask        = "gather_task"
+	LabelAsyncLoopHeader   = "async_loop_header"
+	LabelAsyncWithSetup    = "async_with_setup"
+	LabelAsyncWithTeardown = "async_with_teardown"
 )
 
 // loopContext tracks the context of a loop for break/continue handling
@@ -85,6 +97,19 @@ type CFGBuilder struct {
 
 	// exceptionStack tracks nested try blocks for exception handling
 	exceptionStack []*exceptionContext
+
+	// terminatingCalls are the calls after which control never continues
+	terminatingCalls *TerminatingCalls
+
+	// importAliases maps the names bound by the imports of the module and
+	// the enclosing functions to what they import, to resolve terminating
+	// calls
+	importAliases map[string]string
+
+	// boundNames holds the names the module and the enclosing functions
+	// bind other than by an import, which shadow terminating builtins and
+	// imports
+	boundNames map[string]bool
 }
 
 // NewCFGBuilder creates a new CFG builder
@@ -96,9 +121,17 @@ func NewCFGBuilder() *CFGBuilder {
 		logger:         nil, // Can be set via SetLogger if needed
 		loopStack:      []*loopContext{},
 		exceptionStack: []*exceptionContext{},
+
+		terminatingCalls: NewTerminatingCalls(DefaultTerminatingCalls, nil),
 	}
 }
 
+// SetTerminatingCalls replaces the calls that end a block, by default
+// DefaultTerminatingCalls
+func (b *CFGBuilder) SetTerminatingCalls(calls *TerminatingCalls) {
+	b.terminatingCalls = calls
+}
+
 // SetLogger sets an optional logger for error reporting
 func (b *CFGBuilder) SetLogger(logger *log.Logger) {
 	b.logger = logger
@@ -135,6 +168,25 @@ func (b *CFGBuilder) Build(node *parser.Node) (*CFG, error) {
 	b.cfg = NewCFG(cfgName)
 	b.currentBlock = b.cfg.Entry
 
+	switch node.Type {
+	case parser.NodeModule:
+		b.importAliases, b.boundNames = scopeBindings(node)
+	case parser.NodeFunctionDef, parser.NodeAsyncFunctionDef:
+		// Names the function binds hide those of the enclosing scopes
+		imports, bound := scopeBindings(node)
+		aliases := maps.Clone(b.importAliases)
+		if aliases == nil {
+			aliases = make(map[string]string)
+		}
+		maps.Copy(aliases, imports)
+		for name := range b.boundNames {
+			if _, imported := imports[name]; !imported {
+				bound[name] = true
+			}
+		}
+		b.importAliases, b.boundNames = aliases, bound
+	}
+
 	// Store the source root opaquely for complexity and DFA enrichment.
 	b.cfg.FunctionNode = node
 
@@ -247,6 +299,10 @@ func (b *CFGBuilder) buildNestedFunction(node *parser.Node) error {
 	// Copy logger if set
 	nestedBuilder.logger = b.logger
 
+	nestedBuilder.terminatingCalls = b.terminatingCalls
+	nestedBuilder.importAliases = b.importAliases
+	nestedBuilder.boundNames = b.boundNames
+
 	// Build CFG for the nested function
 	funcCFG, err := nestedBuilder.Build(node)
 	if err != nil {
@@ -366,6 +422,7 @@ func (b *CFGBuilder) processStatement(stmt *parser.Node) {
 		b.currentBlock.AddStatement(stmt)
 
 	case parser.NodeAssign, parser.NodeAugAssign, parser.NodeAnnAssign:
+		defer b.processAwaitPoints(stmt)
 		// Check if the assignment value is a comprehension
 		if stmt.Value != nil {
 			if valNode, ok := stmt.Value.(*parser.Node); ok {
@@ -383,6 +440,7 @@ func (b *CFGBuilder) processStatement(stmt *parser.Node) {
 		b.currentBlock.AddStatement(stmt)
 
 	case parser.NodeExpr:
+		defer b.processAwaitPoints(stmt)
 		// Check if the expression is a comprehension
 		if stmt.Value != nil {
 			if valNode, ok := stmt.Value.(*parser.Node); ok {
@@ -404,6 +462,9 @@ func (b *CFGBuilder) processStatement(stmt *parser.Node) {
 		}
 		// Regular expression statement
 		b.currentBlock.AddStatement(stmt)
+		if b.terminatingCalls.terminates(stmt, b.importAliases, b.boundNames) {
+			b.processTerminatingCall()
+		}
 
 	case parser.NodeImport, parser.NodeImportFrom:
 		// Import statements
@@ -463,8 +524,9 @@ func (b *CFGBuilder) processStatement(stmt *parser.Node) {
 		b.processMatchStatement(stmt)
 
 	case parser.NodeAwait:
-		// Handle await expressions - treat as expression in current block
+		// Handle await expressions - the block ends at the suspension point
 		b.currentBlock.AddStatement(stmt)
+		b.processAwaitPoints(stmt)
 
 	case parser.NodeYield, parser.NodeYieldFrom:
 		// Handle yield expressions - treat as expression (potential suspend point)
@@ -477,6 +539,9 @@ func (b *CFGBuilder) processStatement(stmt *parser.Node) {
 	default:
 		// For other statements, just add them to current block
 		b.currentBlock.AddStatement(stmt)
+		if b.terminatingCalls.terminates(stmt, b.importAliases, b.boundNames) {
+			b.processTerminatingCall()
+		}
 	}
 }
 
@@ -675,8 +740,13 @@ func (b *CFGBuilder) processIfStatementElif(stmt *parser.Node, finalMerge *Basic
 
 // processForStatement handles for and async for loops
 func (b *CFGBuilder) processForStatement(stmt *parser.Node) {
-	// Create loop header block (iterator evaluation and condition check)
-	headerBlock := b.createBlock(LabelLoopHeader)
+	// Create loop header block (iterator evaluation and condition check).
+	// For async for, the header awaits __anext__ on every iteration.
+	headerLabel := LabelLoopHeader
+	if stmt.Type == parser.NodeAsyncFor {
+		headerLabel = LabelAsyncLoopHeader
+	}
+	headerBlock := b.createBlock(headerLabel)
 	b.cfg.ConnectBlocks(b.currentBlock, headerBlock, EdgeNormal)
 
 	// Add the for statement (iterator setup) to header
@@ -728,7 +798,7 @@ func (b *CFGBuilder) processForStatement(stmt *parser.Node) {
 	// Process else clause if present
 	if elseBlock != nil {
 		b.currentBlock = elseBlock
-		for _, elseStmt := range stmt.Orelse {
+		for _, elseStmt := range loopElseStatements(stmt) {
 			b.processStatement(elseStmt)
 		}
 		// Connect else to exit
@@ -741,6 +811,20 @@ func (b *CFGBuilder) processForStatement(stmt *parser.Node) {
 	b.currentBlock = exitBlock
 }
 
+// loopElseStatements returns the statements of a loop's else clause. The
+// parser keeps the else_clause wrapper for loops, unlike for try statements.
+func loopElseStatements(stmt *parser.Node) []*parser.Node {
+	var stmts []*parser.Node
+	for _, elseStmt := range stmt.Orelse {
+		if elseStmt.Type == parser.NodeElseClause {
+			stmts = append(stmts, elseStmt.Body...)
+		} else {
+			stmts = append(stmts, elseStmt)
+		}
+	}
+	return stmts
+}
+
 // processWhileStatement handles while loops
 func (b *CFGBuilder) processWhileStatement(stmt *parser.Node) {
 	// Create loop header block (condition evaluation)
@@ -796,7 +880,7 @@ func (b *CFGBuilder) processWhileStatement(stmt *parser.Node) {
 	// Process else clause if present
 	if elseBlock != nil {
 		b.currentBlock = elseBlock
-		for _, elseStmt := range stmt.Orelse {
+		for _, elseStmt := range loopElseStatements(stmt) {
 			b.processStatement(elseStmt)
 		}
 		// Connect else to exit
@@ -1116,7 +1200,25 @@ func (b *CFGBuilder) processTryStatement(stmt *parser.Node) {
 func (b *CFGBuilder) processRaiseStatement(stmt *parser.Node) {
 	// Add raise statement to current block
 	b.currentBlock.AddStatement(stmt)
+	b.connectRaise()
+
+	// Create unreachable block for any code after raise
+	unreachableBlock := b.createBlock(LabelUnreachable)
+	b.currentBlock = unreachableBlock
+}
 
+// processTerminatingCall ends the current block after a call that never
+// returns. Such calls exit by raising (SystemExit, AssertionError), so
+// control leaves like a raise would; code after them is unreachable.
+func (b *CFGBuilder) processTerminatingCall() {
+	b.connectRaise()
+	b.currentBlock = b.createBlock(LabelUnreachableAfterExit)
+}
+
+// connectRaise connects the current block to where a raised exception
+// goes: the next enclosing finally block, the handlers of the enclosing try
+// or the exit
+func (b *CFGBuilder) connectRaise() {
 	// Find the next finally block that needs to execute before this raise propagates.
 	// Walk the exception stack from innermost to outermost, skipping any finally
 	// blocks we're currently processing (to avoid self-loops), until we find the first
@@ -1151,10 +1253,6 @@ func (b *CFGBuilder) processRaiseStatement(stmt *parser.Node) {
 		// No exception context - connect to exit (unhandled exception)
 		b.cfg.ConnectBlocks(b.currentBlock, b.cfg.Exit, EdgeException)
 	}
-
-	// Create unreachable block for any code after raise
-	unreachableBlock := b.createBlock(LabelUnreachable)
-	b.currentBlock = unreachableBlock
 }
 
 // pushExceptionContext pushes an exception context onto the stack
@@ -1171,8 +1269,14 @@ func (b *CFGBuilder) popExceptionContext() {
 
 // processWithStatement handles with and async with statements
 func (b *CFGBuilder) processWithStatement(stmt *parser.Node) {
+	// Async context managers suspend in both __aenter__ and __aexit__
+	setupLabel, teardownLabel := LabelWithSetup, LabelWithTeardown
+	if stmt.Type == parser.NodeAsyncWith {
+		setupLabel, teardownLabel = LabelAsyncWithSetup, LabelAsyncWithTeardown
+	}
+
 	// Create setup block (context manager entry)
-	setupBlock := b.createBlock(LabelWithSetup)
+	setupBlock := b.createBlock(setupLabel)
 	b.cfg.ConnectBlocks(b.currentBlock, setupBlock, EdgeNormal)
 
 	// Add the with statement (context manager setup) to setup block
@@ -1182,7 +1286,7 @@ func (b *CFGBuilder) processWithStatement(stmt *parser.Node) {
 	bodyBlock := b.createBlock(LabelWithBody)
 
 	// Create teardown block (context manager exit - always executed)
-	teardownBlock := b.createBlock(LabelWithTeardown)
+	teardownBlock := b.createBlock(teardownLabel)
 
 	// Create exit block
 	exitBlock := b.createBlock("with_exit")
@@ -1214,6 +1318,28 @@ func (b *CFGBuilder) processWithStatement(stmt *parser.Node) {
 	b.currentBlock = exitBlock
 }
 
+// processAwaitPoints ends the current block after a statement that awaits, so
+// every suspension point is a block boundary. Awaiting asyncio.gather with
+// several awaitables fans out to one block per awaitable that join in the
+// resume block.
+func (b *CFGBuilder) processAwaitPoints(stmt *parser.Node) {
+	if !containsAwait(stmt) || b.hasSuccessor(b.currentBlock, b.cfg.Exit) {
+		return
+	}
+
+	resumeBlock := b.createBlock(LabelAwaitResume)
+	if tasks := gatherTasks(stmt); len(tasks) > 0 {
+		for range tasks {
+			taskBlock := b.createBlock(LabelGatherTask)
+			b.cfg.ConnectBlocks(b.currentBlock, taskBlock, EdgeNormal)
+			b.cfg.ConnectBlocks(taskBlock, resumeBlock, EdgeNormal)
+		}
+	} else {
+		b.cfg.ConnectBlocks(b.currentBlock, resumeBlock, EdgeNormal)
+	}
+	b.currentBlock = resumeBlock
+}
+
 // processMatchStatement handles match statements (Python 3.10+)
 func (b *CFGBuilder) processMatchStatement(stmt *parser.Node) {
 	// Create match evaluation block
@@ -1228,12 +1354,22 @@ func (b *CFGBuilder) processMatchStatement(stmt *parser.Node) {
 
 	// Process match cases
 	if len(stmt.Body) > 0 {
+		caseInfos := AnalyzeMatchCases(stmt)
+		shadowed := make(map[*parser.Node]bool, len(caseInfos))
+		for _, info := range caseInfos {
+			shadowed[info.Case] = info.Shadowed
+		}
+
 		for i, caseNode := range stmt.Body {
 			// Create case block
 			caseBlock := b.createBlock(LabelMatchCase + "_" + strconv.Itoa(i+1))
 
-			// Connect match evaluation to this case (conditional edge)
-			b.cfg.ConnectBlocks(matchBlock, caseBlock, EdgeCondTrue)
+			// Connect match evaluation to this case (conditional edge).
+			// Cases shadowed by an earlier pattern can never be selected, so
+			// their blocks are left without predecessors.
+			if !shadowed[caseNode] {
+				b.cfg.ConnectBlocks(matchBlock, caseBlock, EdgeCondTrue)
+			}
 
 			// Process case body
 			b.currentBlock = caseBlock
@@ -1442,7 +1578,7 @@ func (b *CFGBuilder) convertElifClauseToIf(elifNode *parser.Node) *parser.Node {
 }
 
 // blockTerminates checks if a block ends with a terminating statement
-// (return, raise, break, or continue)
+// (return, raise, break, continue or a terminating call)
 // Only checks the last statement to avoid false positives from nested control flow
 func (b *CFGBuilder) blockTerminates(block *BasicBlock) bool {
 	if block == nil || len(block.Statements) == 0 {
@@ -1454,7 +1590,8 @@ func (b *CFGBuilder) blockTerminates(block *BasicBlock) bool {
 	return lastStmt.Type == parser.NodeReturn ||
 		lastStmt.Type == parser.NodeRaise ||
 		lastStmt.Type == parser.NodeBreak ||
-		lastStmt.Type == parser.NodeContinue
+		lastStmt.Type == parser.NodeContinue ||
+		b.terminatingCalls.terminates(lastStmt, b.importAliases, b.boundNames)
 }
 
 // allBranchesTerminate checks if all branches of a conditional terminate
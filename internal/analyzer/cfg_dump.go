@@ -0,0 +1,176 @@
+package analyzer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// CFGDump is a serializable view of the control flow graph of one function,
+// class body or module. The CFG cannot be marshalled directly because its
+// blocks link to each other and hold AST nodes.
+type CFGDump struct {
+	Name   string          `json:"name"`
+	Entry  string          `json:"entry"`
+	Exit   string          `json:"exit"`
+	Blocks []*CFGDumpBlock `json:"blocks"`
+	Edges  []*CFGDumpEdge  `json:"edges"`
+}
+
+// CFGDumpBlock is a basic block of a dumped CFG. Statements are the first
+// source line of each statement in the block, and the line range covers only
+// the headers of compound statements.
+type CFGDumpBlock struct {
+	ID         string   `json:"id"`
+	Label      string   `json:"label"`
+	StartLine  int      `json:"start_line,omitempty"`
+	EndLine    int      `json:"end_line,omitempty"`
+	Statements []string `json:"statements,omitempty"`
+	// Reachable follows the dead code detector: fallthrough edges after
+	// return, raise, break and continue and the false edge of a `while
+	// True` header are never taken
+	Reachable bool `json:"reachable"`
+}
+
+// CFGDumpEdge is an edge of a dumped CFG
+type CFGDumpEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Type string `json:"type"`
+}
+
+// DumpCFG converts a CFG into a dump. Blocks are listed in creation order
+// and edges in the order of their source block's successors. source is the
+// file the CFG was built from, used to print statements.
+func DumpCFG(name string, cfg *CFG, source []byte) *CFGDump {
+	dump := &CFGDump{Name: name}
+	if cfg == nil {
+		return dump
+	}
+	if cfg.Entry != nil {
+		dump.Entry = cfg.Entry.ID
+	}
+	if cfg.Exit != nil {
+		dump.Exit = cfg.Exit.ID
+	}
+
+	reachable := AnalyzeLoops(cfg).Reachable
+	for _, block := range sortedCFGBlocks(cfg) {
+		dumped := &CFGDumpBlock{
+			ID:        block.ID,
+			Label:     block.Label,
+			Reachable: reachable[block.ID],
+		}
+		for _, value := range block.Statements {
+			stmt, ok := pythonNode(value)
+			if !ok {
+				continue
+			}
+			if dumped.StartLine == 0 || stmt.Location.StartLine < dumped.StartLine {
+				dumped.StartLine = stmt.Location.StartLine
+			}
+			// A compound statement's body lives in other blocks
+			endLine := stmt.Location.EndLine
+			if len(stmt.Body) > 0 {
+				endLine = stmt.Location.StartLine
+			}
+			if endLine > dumped.EndLine {
+				dumped.EndLine = endLine
+			}
+			dumped.Statements = append(dumped.Statements, statementHead(stmt, source))
+		}
+		dump.Blocks = append(dump.Blocks, dumped)
+
+		for _, edge := range block.Successors {
+			if edge == nil || edge.To == nil {
+				continue
+			}
+			dump.Edges = append(dump.Edges, &CFGDumpEdge{From: block.ID, To: edge.To.ID, Type: edge.Type.String()})
+		}
+	}
+	return dump
+}
+
+// sortedCFGBlocks returns the blocks of a CFG in creation order
+func sortedCFGBlocks(cfg *CFG) []*BasicBlock {
+	blocks := make([]*BasicBlock, 0, len(cfg.Blocks))
+	for _, block := range cfg.Blocks {
+		if block != nil {
+			blocks = append(blocks, block)
+		}
+	}
+	sort.Slice(blocks, func(i, j int) bool {
+		a, errA := strconv.Atoi(strings.TrimPrefix(blocks[i].ID, "bb"))
+		b, errB := strconv.Atoi(strings.TrimPrefix(blocks[j].ID, "bb"))
+		if errA != nil || errB != nil {
+			return blocks[i].ID < blocks[j].ID
+		}
+		return a < b
+	})
+	return blocks
+}
+
+// statementHead returns the first source line of a statement, so compound
+// statements show only their header
+func statementHead(stmt *parser.Node, source []byte) string {
+	start, end := stmt.Location.StartByte, stmt.Location.EndByte
+	if start < 0 || end > len(source) || start >= end {
+		return string(stmt.Type)
+	}
+	text := string(source[start:end])
+	if line, _, found := strings.Cut(text, "\n"); found {
+		text = line
+	}
+	return strings.TrimSpace(text)
+}
+
+// WriteCFGDOT writes CFG dumps as one Graphviz digraph, each CFG in its own
+// cluster. Unreachable blocks are drawn dashed and grey, and edges are
+// labeled with their type unless it is normal flow.
+func WriteCFGDOT(out io.Writer, dumps []*CFGDump) error {
+	w := bufio.NewWriter(out)
+	fmt.Fprintln(w, "digraph CFG {")
+	fmt.Fprintln(w, "  node [shape=box, fontname=\"monospace\"];")
+	for i, dump := range dumps {
+		prefix := fmt.Sprintf("f%d_", i)
+		fmt.Fprintf(w, "\n  subgraph cluster_%d {\n", i)
+		fmt.Fprintf(w, "    label=%s;\n", strconv.Quote(dump.Name))
+		for _, block := range dump.Blocks {
+			lines := []string{block.ID + " " + block.Label}
+			lines = append(lines, block.Statements...)
+			label := ""
+			for _, line := range lines {
+				label += dotEscape(line) + `\l`
+			}
+			attrs := ""
+			if !block.Reachable {
+				attrs = ", style=dashed, color=grey, fontcolor=grey"
+			}
+			fmt.Fprintf(w, "    %s%s [label=\"%s\"%s];\n", prefix, block.ID, label, attrs)
+		}
+		for _, edge := range dump.Edges {
+			attrs := ""
+			switch edge.Type {
+			case "normal":
+			case "exception":
+				attrs = fmt.Sprintf(" [label=%q, style=dashed]", edge.Type)
+			default:
+				attrs = fmt.Sprintf(" [label=%q]", edge.Type)
+			}
+			fmt.Fprintf(w, "    %s%s -> %s%s%s;\n", prefix, edge.From, prefix, edge.To, attrs)
+		}
+		fmt.Fprintln(w, "  }")
+	}
+	fmt.Fprintln(w, "}")
+	return w.Flush()
+}
+
+// dotEscape escapes text for a double-quoted DOT label
+func dotEscape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(text)
+}
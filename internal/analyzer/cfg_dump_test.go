@@ -0,0 +1,53 @@
+package analyzer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDumpCFG(t *testing.T) {
+	source := `def f(x):
+    while x > 0:
+        x -= 1
+    return x
+    print("dead")
+`
+	cfgs, err := NewCFGBuilder().BuildAll(parseSource(t, source))
+	require.NoError(t, err)
+
+	dump := DumpCFG("f", cfgs["f"], []byte(source))
+	assert.Equal(t, "f", dump.Name)
+	assert.Equal(t, cfgs["f"].Entry.ID, dump.Entry)
+	assert.Equal(t, cfgs["f"].Exit.ID, dump.Exit)
+
+	blocks := make(map[string]*CFGDumpBlock)
+	for _, block := range dump.Blocks {
+		for _, stmt := range block.Statements {
+			blocks[stmt] = block
+		}
+	}
+	require.Contains(t, blocks, "while x > 0:")
+	header := blocks["while x > 0:"]
+	assert.Equal(t, 2, header.StartLine)
+	assert.Equal(t, 2, header.EndLine, "the loop body lives in other blocks")
+	assert.True(t, header.Reachable)
+	require.Contains(t, blocks, `print("dead")`)
+	assert.False(t, blocks[`print("dead")`].Reachable)
+
+	edgeTypes := make(map[string]bool)
+	for _, edge := range dump.Edges {
+		if edge.From == header.ID {
+			edgeTypes[edge.Type] = true
+		}
+	}
+	assert.Equal(t, map[string]bool{"true": true, "false": true}, edgeTypes)
+
+	var out bytes.Buffer
+	require.NoError(t, WriteCFGDOT(&out, []*CFGDump{dump}))
+	assert.Contains(t, out.String(), `label="f";`)
+	assert.Contains(t, out.String(), `print(\"dead\")\l", style=dashed`)
+	assert.Contains(t, out.String(), `[label="loop"]`)
+}
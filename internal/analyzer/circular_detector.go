@@ -2,6 +2,7 @@ package analyzer
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -11,14 +12,20 @@ import (
 // CircularDependencyDetector enriches cycles detected by polyscan core.
 type CircularDependencyDetector struct {
 	graph      *DependencyGraph
-	components [][]string // Strongly connected components
+	ignored    EdgeKindSet // Edge kinds that cannot take part in a cycle
+	components [][]string  // Strongly connected components
 }
 
+// loadTimeDependencyGraph is the dependency graph without the edges whose
+// kind is in ignored. With the default ignored kinds it keeps exactly the
+// imports that run while a module is being loaded.
 type loadTimeDependencyGraph struct {
 	*DependencyGraph
+	ignored EdgeKindSet
 }
 
-// Successors excludes lazy imports because they cannot form load-time cycles.
+// Successors excludes ignored edges, such as lazy imports, which cannot form
+// load-time cycles.
 func (g loadTimeDependencyGraph) Successors(moduleName string) []string {
 	node := g.Nodes[moduleName]
 	if node == nil {
@@ -27,7 +34,7 @@ func (g loadTimeDependencyGraph) Successors(moduleName string) []string {
 
 	dependencies := make([]string, 0, len(node.Dependencies))
 	for dependency := range node.Dependencies {
-		if !node.LazyDependencies[dependency] {
+		if !g.ignored.MatchesDependency(node, dependency) {
 			dependencies = append(dependencies, dependency)
 		}
 	}
@@ -35,7 +42,7 @@ func (g loadTimeDependencyGraph) Successors(moduleName string) []string {
 	return dependencies
 }
 
-// Predecessors excludes modules whose edge to moduleName is a lazy import.
+// Predecessors excludes modules whose edge to moduleName is ignored.
 func (g loadTimeDependencyGraph) Predecessors(moduleName string) []string {
 	node := g.Nodes[moduleName]
 	if node == nil {
@@ -45,7 +52,7 @@ func (g loadTimeDependencyGraph) Predecessors(moduleName string) []string {
 	dependents := make([]string, 0, len(node.Dependents))
 	for dependent := range node.Dependents {
 		dependentNode := g.Nodes[dependent]
-		if dependentNode != nil && !dependentNode.LazyDependencies[moduleName] {
+		if dependentNode != nil && !g.ignored.MatchesDependency(dependentNode, moduleName) {
 			dependents = append(dependents, dependent)
 		}
 	}
@@ -60,6 +67,9 @@ type CircularDependency struct {
 	Severity     CycleSeverity     // Severity level of this cycle
 	Size         int               // Number of modules in the cycle
 	Description  string            // Human-readable description
+
+	ModuleSizes  map[string]int   // Lines of code of each module in the cycle
+	HeaviestEdge *DependencyChain // Direct dependency importing the most names
 }
 
 // DependencyChain represents a chain of dependencies
@@ -68,6 +78,9 @@ type DependencyChain struct {
 	To     string   // Ending module
 	Path   []string // Complete dependency path
 	Length int      // Length of the chain
+
+	Weight  int      // Names imported over the direct edge From -> To
+	Symbols []string // The imported names, sorted
 }
 
 // CycleSeverity represents the severity level of a circular dependency
@@ -102,13 +115,21 @@ type CircularDependencyResult struct {
 // NewCircularDependencyDetector creates a new circular dependency detector
 func NewCircularDependencyDetector(graph *DependencyGraph) *CircularDependencyDetector {
 	return &CircularDependencyDetector{
-		graph: graph,
+		graph:   graph,
+		ignored: DefaultCycleIgnoredEdgeKinds(),
 	}
 }
 
+// WithIgnoredEdgeKinds sets the edge kinds excluded from cycle detection.
+// Lazy and TYPE_CHECKING imports are ignored by default.
+func (cdd *CircularDependencyDetector) WithIgnoredEdgeKinds(kinds EdgeKindSet) *CircularDependencyDetector {
+	cdd.ignored = kinds
+	return cdd
+}
+
 // DetectCircularDependencies detects all circular dependencies in the graph
 func (cdd *CircularDependencyDetector) DetectCircularDependencies() *CircularDependencyResult {
-	coreResult := coregraph.NewCycleDetector().DetectCycles(loadTimeDependencyGraph{cdd.graph})
+	coreResult := coregraph.NewCycleDetector().DetectCycles(loadTimeDependencyGraph{cdd.graph, cdd.ignored})
 	cdd.components = coreResult.Cycles
 	for _, component := range cdd.components {
 		sort.Strings(component)
@@ -150,6 +171,7 @@ func (cdd *CircularDependencyDetector) processComponents() []*CircularDependency
 
 		// Find all dependency chains within the component
 		circularDep.Dependencies = cdd.findDependencyChains(component)
+		annotateCycle(cdd.graph, circularDep)
 
 		// Assess severity
 		circularDep.Severity = cdd.assessCycleSeverity(circularDep)
@@ -185,8 +207,8 @@ func (cdd *CircularDependencyDetector) findDependencyChains(modules []string) []
 	for _, from := range modules {
 		if node := cdd.graph.Nodes[from]; node != nil {
 			for to := range node.Dependencies {
-				if node.LazyDependencies[to] {
-					continue // lazy edges are not load-time dependencies (#460)
+				if cdd.ignored.MatchesDependency(node, to) {
+					continue // e.g. lazy edges are not load-time dependencies (#460)
 				}
 				if moduleSet[to] {
 					// Find the shortest path from 'from' to 'to' within the component
@@ -198,6 +220,7 @@ func (cdd *CircularDependencyDetector) findDependencyChains(modules []string) []
 							Path:   path,
 							Length: len(path) - 1, // Number of edges
 						}
+						setChainWeight(cdd.graph, &chain)
 						chains = append(chains, chain)
 					}
 				}
@@ -208,6 +231,39 @@ func (cdd *CircularDependencyDetector) findDependencyChains(modules []string) []
 	return chains
 }
 
+// setChainWeight copies the weight and imported names of the direct edge
+// behind a chain
+func setChainWeight(graph *DependencyGraph, chain *DependencyChain) {
+	chain.Weight = 1
+	if edge := graph.findEdge(chain.From, chain.To); edge != nil {
+		chain.Weight = edge.Weight()
+		chain.Symbols = slices.Clone(edge.Symbols)
+	}
+}
+
+// annotateCycle records the size of each module in a cycle and its heaviest
+// edge, the one whose removal means moving the most imported names. Ties go
+// to the first edge by (From, To) so the choice is stable across runs.
+func annotateCycle(graph *DependencyGraph, cycle *CircularDependency) {
+	cycle.ModuleSizes = make(map[string]int, len(cycle.Modules))
+	for _, module := range cycle.Modules {
+		if node := graph.Nodes[module]; node != nil {
+			cycle.ModuleSizes[module] = node.LineCount
+		}
+	}
+
+	cycle.HeaviestEdge = nil
+	for i := range cycle.Dependencies {
+		chain := &cycle.Dependencies[i]
+		heaviest := cycle.HeaviestEdge
+		if heaviest == nil || chain.Weight > heaviest.Weight ||
+			(chain.Weight == heaviest.Weight &&
+				(chain.From < heaviest.From || (chain.From == heaviest.From && chain.To < heaviest.To))) {
+			cycle.HeaviestEdge = chain
+		}
+	}
+}
+
 // findPathInComponent finds a path between two modules within a component
 func (cdd *CircularDependencyDetector) findPathInComponent(from, to string, moduleSet map[string]bool) []string {
 	if from == to {
@@ -226,8 +282,8 @@ func (cdd *CircularDependencyDetector) findPathInComponent(from, to string, modu
 
 		if node := cdd.graph.Nodes[current]; node != nil {
 			for dependency := range node.Dependencies {
-				if node.LazyDependencies[dependency] {
-					continue // lazy edges are not load-time dependencies (#460)
+				if cdd.ignored.MatchesDependency(node, dependency) {
+					continue // e.g. lazy edges are not load-time dependencies (#460)
 				}
 				if !moduleSet[dependency] {
 					continue // Skip modules outside the component
@@ -395,6 +451,7 @@ func HasCircularDependencies(graph *DependencyGraph) bool {
 // FindSimpleCycles finds all simple cycles (2-module cycles) in the graph
 func FindSimpleCycles(graph *DependencyGraph) []*CircularDependency {
 	var simpleCycles []*CircularDependency
+	ignored := DefaultCycleIgnoredEdgeKinds()
 
 	// Check each pair of modules for mutual dependencies
 	modules := graph.GetModuleNames()
@@ -412,10 +469,10 @@ func FindSimpleCycles(graph *DependencyGraph) []*CircularDependency {
 			}
 
 			// Check if A depends on B and B depends on A at load time.
-			// Lazy (function-body) imports are excluded: they cannot form a
-			// load-time cycle. See issue #460.
-			aToB := nodeA.Dependencies[moduleB] && !nodeA.LazyDependencies[moduleB]
-			bToA := nodeB.Dependencies[moduleA] && !nodeB.LazyDependencies[moduleA]
+			// Lazy (function-body) and TYPE_CHECKING imports are excluded:
+			// they cannot form a load-time cycle. See issue #460.
+			aToB := nodeA.Dependencies[moduleB] && !ignored.MatchesDependency(nodeA, moduleB)
+			bToA := nodeB.Dependencies[moduleA] && !ignored.MatchesDependency(nodeB, moduleA)
 			if aToB && bToA {
 				cycle := &CircularDependency{
 					Modules:     []string{moduleA, moduleB},
@@ -427,6 +484,10 @@ func FindSimpleCycles(graph *DependencyGraph) []*CircularDependency {
 						{From: moduleB, To: moduleA, Path: []string{moduleB, moduleA}, Length: 1},
 					},
 				}
+				for k := range cycle.Dependencies {
+					setChainWeight(graph, &cycle.Dependencies[k])
+				}
+				annotateCycle(graph, cycle)
 				simpleCycles = append(simpleCycles, cycle)
 			}
 		}
@@ -0,0 +1,389 @@
+package analyzer
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// diagramClass is a class of a class diagram with the names it refers to,
+// resolved once every module is added
+type diagramClass struct {
+	domain.DiagramClass
+
+	// imports maps the names bound by the imports of the module to what
+	// they import, such as "Item" to "shop.models.Item"
+	imports map[string]string
+
+	bases []string
+	// parts maps attributes, private ones included, to the names in their
+	// annotation or constructor
+	parts []diagramPart
+}
+
+type diagramPart struct {
+	attribute string
+	names     []string
+}
+
+// ClassDiagramExtractor collects the classes of modules and the
+// inheritance and composition between them
+type ClassDiagramExtractor struct {
+	classes []*diagramClass
+}
+
+// NewClassDiagramExtractor creates an extractor without classes
+func NewClassDiagramExtractor() *ClassDiagramExtractor {
+	return &ClassDiagramExtractor{}
+}
+
+// AddModule adds the classes of a parsed module, nested classes included
+func (e *ClassDiagramExtractor) AddModule(filePath string, result *parser.ParseResult) {
+	if result == nil || result.AST == nil {
+		return
+	}
+	module := APIModuleName(filePath)
+	isPackage := path.Base(strings.ReplaceAll(filePath, "\\", "/")) == "__init__.py"
+	imports := diagramImports(module, isPackage, result.AST.Body)
+	version := &diffVersion{path: filePath, source: result.SourceCode}
+
+	var visit func(body []*parser.Node, prefix string)
+	visit = func(body []*parser.Node, prefix string) {
+		for _, stmt := range body {
+			if stmt == nil {
+				continue
+			}
+			switch stmt.Type {
+			case parser.NodeClassDef:
+				class := e.class(version, module, prefix+stmt.Name, stmt, imports)
+				e.classes = append(e.classes, class)
+				visit(stmt.Body, prefix+stmt.Name+".")
+			case parser.NodeIf, parser.NodeTry, parser.NodeWith, parser.NodeAsyncWith:
+				visit(stmt.Body, prefix)
+				visit(stmt.Orelse, prefix)
+				for _, handler := range stmt.Handlers {
+					visit(handler.Body, prefix)
+				}
+				visit(stmt.Finalbody, prefix)
+			}
+		}
+	}
+	visit(result.AST.Body, "")
+}
+
+// class describes a class definition
+func (e *ClassDiagramExtractor) class(version *diffVersion, module, name string, node *parser.Node, imports map[string]string) *diagramClass {
+	class := &diagramClass{
+		DiagramClass: domain.DiagramClass{
+			ID:        module + "." + name,
+			Name:      name,
+			Module:    module,
+			FilePath:  version.path,
+			StartLine: node.Location.StartLine,
+		},
+		imports: imports,
+	}
+
+	header := version.signature(node)
+	if open := strings.Index(header, "("); open >= 0 {
+		if end := matchingParen(header, open); end > open {
+			for _, base := range splitTopLevel(header[open+1:end], ',') {
+				base = strings.TrimSpace(base)
+				if keyword, value, ok := strings.Cut(base, "="); ok {
+					// metaclass=... and other keywords
+					if strings.TrimSpace(keyword) == "metaclass" && isABCName(strings.TrimSpace(value), "ABCMeta") {
+						class.IsAbstract = true
+					}
+					continue
+				}
+				if isABCName(base, "ABC") {
+					class.IsAbstract = true
+				}
+				class.Bases = append(class.Bases, base)
+				// Base[T] inherits from Base, not from T
+				generic, _, _ := strings.Cut(base, "[")
+				class.bases = append(class.bases, strings.TrimSpace(generic))
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	addAttribute := func(name, typ string) {
+		if name == "" || seen[name] || isDunderName(name) {
+			return
+		}
+		seen[name] = true
+		if names := typeNames(typ); len(names) > 0 {
+			class.parts = append(class.parts, diagramPart{attribute: name, names: names})
+		}
+		if !strings.HasPrefix(name, "_") {
+			class.Attributes = append(class.Attributes, domain.DiagramAttribute{Name: name, Type: typ})
+		}
+	}
+
+	for _, stmt := range node.Body {
+		if stmt == nil {
+			continue
+		}
+		switch stmt.Type {
+		case parser.NodeFunctionDef, parser.NodeAsyncFunctionDef:
+			class.Methods++
+			for _, decorator := range stmt.Decorator {
+				if isABCName(decoratorQualifiedName(decorator), "abstractmethod") {
+					class.IsAbstract = true
+				}
+			}
+		case parser.NodeAnnAssign, parser.NodeAssign:
+			if len(stmt.Targets) == 1 && stmt.Targets[0] != nil && stmt.Targets[0].Type == parser.NodeName {
+				addAttribute(stmt.Targets[0].Name, attributeType(version, stmt, nil))
+			}
+		}
+	}
+	for _, stmt := range node.Body {
+		if stmt != nil && stmt.Type == parser.NodeFunctionDef && stmt.Name == "__init__" {
+			e.initAttributes(version, stmt, addAttribute)
+		}
+	}
+	return class
+}
+
+// isABCName reports whether name refers to a name of the abc module, either
+// imported from it or through the module
+func isABCName(name, abcName string) bool {
+	return name == abcName || name == "abc."+abcName
+}
+
+// initAttributes adds the attributes __init__ sets on self, typed by their
+// annotation, the class they are constructed from, or the annotation of the
+// parameter they are set from
+func (e *ClassDiagramExtractor) initAttributes(version *diffVersion, init *parser.Node, add func(name, typ string)) {
+	params := make(map[string]string)
+	header := version.signature(init)
+	if open := strings.Index(header, "("); open >= 0 {
+		if end := matchingParen(header, open); end > open {
+			for _, param := range parseAPIParameters(header[open+1 : end]) {
+				params[param.name] = param.annotation
+			}
+		}
+	}
+
+	var visit func(body []*parser.Node)
+	visit = func(body []*parser.Node) {
+		for _, stmt := range body {
+			if stmt == nil {
+				continue
+			}
+			switch stmt.Type {
+			case parser.NodeAnnAssign, parser.NodeAssign:
+				if len(stmt.Targets) != 1 {
+					continue
+				}
+				target := stmt.Targets[0]
+				if target == nil || target.Type != parser.NodeAttribute {
+					continue
+				}
+				if object := nodeValue(target); object == nil || object.Type != parser.NodeName || object.Name != "self" {
+					continue
+				}
+				add(target.Name, attributeType(version, stmt, params))
+			case parser.NodeIf, parser.NodeTry, parser.NodeWith:
+				visit(stmt.Body)
+				visit(stmt.Orelse)
+				for _, handler := range stmt.Handlers {
+					visit(handler.Body)
+				}
+				visit(stmt.Finalbody)
+			}
+		}
+	}
+	visit(init.Body)
+}
+
+// attributeType returns the annotation of an assignment, else the class
+// its value is constructed from or the annotation of the parameter it is
+// set from
+func attributeType(version *diffVersion, assign *parser.Node, params map[string]string) string {
+	if assign.Type == parser.NodeAnnAssign && len(assign.Children) > 0 {
+		if annotation := version.text(assign.Children[0]); annotation != "" {
+			return annotation
+		}
+	}
+	value := nodeValue(assign)
+	if value == nil {
+		return ""
+	}
+	switch value.Type {
+	case parser.NodeCall:
+		callee := dottedName(nodeValue(value))
+		if leaf := callee[strings.LastIndex(callee, ".")+1:]; leaf != "" && unicode.IsUpper([]rune(leaf)[0]) {
+			return callee
+		}
+	case parser.NodeName:
+		return params[value.Name]
+	}
+	return ""
+}
+
+// text returns the source of a node, with runs of whitespace collapsed
+func (v *diffVersion) text(node *parser.Node) string {
+	start, end := node.Location.StartByte, node.Location.EndByte
+	if start < 0 || end > len(v.source) || start >= end {
+		return ""
+	}
+	return strings.Join(strings.Fields(string(v.source[start:end])), " ")
+}
+
+// typeNames returns the dotted names in an annotation, such as "Optional"
+// and "Item" in "Optional[Item]". String annotations count as their
+// content.
+func typeNames(text string) []string {
+	var names []string
+	for _, field := range strings.FieldsFunc(text, func(r rune) bool {
+		return r != '.' && r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		field = strings.Trim(field, ".")
+		if field != "" && !unicode.IsDigit([]rune(field)[0]) {
+			names = append(names, field)
+		}
+	}
+	return names
+}
+
+// diagramImports maps the names bound by the module-level imports of a
+// module to the absolute names they import
+func diagramImports(module string, isPackage bool, body []*parser.Node) map[string]string {
+	imports := make(map[string]string)
+	var visit func(body []*parser.Node)
+	visit = func(body []*parser.Node) {
+		for _, stmt := range body {
+			if stmt == nil {
+				continue
+			}
+			switch stmt.Type {
+			case parser.NodeImport:
+				for _, name := range stmt.Names {
+					imports[importBindingName(name)] = importBindingName(name)
+				}
+				for _, child := range stmt.Children {
+					if alias, ok := child.Value.(string); ok && child.Type == parser.NodeAlias {
+						imports[alias] = child.Name
+					}
+				}
+			case parser.NodeImportFrom:
+				from := stmt.Module
+				if stmt.Level > 0 {
+					from = relativeImportBase(module, isPackage, stmt.Level, stmt.Module)
+				}
+				for _, name := range stmt.Names {
+					imports[name] = from + "." + name
+				}
+				for _, child := range stmt.Children {
+					if alias, ok := child.Value.(string); ok && child.Type == parser.NodeAlias {
+						imports[alias] = from + "." + child.Name
+					}
+				}
+			case parser.NodeIf, parser.NodeTry:
+				visit(stmt.Body)
+				visit(stmt.Orelse)
+				for _, handler := range stmt.Handlers {
+					visit(handler.Body)
+				}
+			}
+		}
+	}
+	visit(body)
+	return imports
+}
+
+// relativeImportBase returns the absolute module a relative import with
+// level leading dots refers to
+func relativeImportBase(module string, isPackage bool, level int, name string) string {
+	parts := strings.Split(module, ".")
+	if !isPackage {
+		level++
+	}
+	keep := max(len(parts)-level+1, 0)
+	base := strings.Join(parts[:keep], ".")
+	switch {
+	case name == "":
+		return base
+	case base == "":
+		return name
+	}
+	return base + "." + name
+}
+
+// Diagram returns the classes added so far and the relations between
+// them. A name refers to a class of the diagram through the imports of
+// its module, as a class of the same module, by its full ID, or else by
+// its last part when only one class of the diagram has that name.
+func (e *ClassDiagramExtractor) Diagram() *domain.ClassDiagram {
+	classes := make([]*diagramClass, len(e.classes))
+	copy(classes, e.classes)
+	sort.SliceStable(classes, func(i, j int) bool {
+		if classes[i].Module != classes[j].Module {
+			return classes[i].Module < classes[j].Module
+		}
+		return classes[i].StartLine < classes[j].StartLine
+	})
+
+	byID := make(map[string]*diagramClass, len(classes))
+	byLeaf := make(map[string][]*diagramClass)
+	for _, class := range classes {
+		byID[class.ID] = class
+		leaf := class.Name[strings.LastIndex(class.Name, ".")+1:]
+		byLeaf[leaf] = append(byLeaf[leaf], class)
+	}
+	resolve := func(from *diagramClass, name string) *diagramClass {
+		head, rest, _ := strings.Cut(name, ".")
+		if imported, ok := from.imports[head]; ok {
+			if target, ok := byID[strings.TrimSuffix(imported+"."+rest, ".")]; ok {
+				return target
+			}
+		}
+		if target, ok := byID[from.Module+"."+name]; ok {
+			return target
+		}
+		if target, ok := byID[name]; ok {
+			return target
+		}
+		if candidates := byLeaf[name[strings.LastIndex(name, ".")+1:]]; len(candidates) == 1 {
+			return candidates[0]
+		}
+		return nil
+	}
+
+	diagram := &domain.ClassDiagram{
+		Classes:   make([]domain.DiagramClass, 0, len(classes)),
+		Relations: []domain.ClassRelation{},
+	}
+	for _, class := range classes {
+		diagram.Classes = append(diagram.Classes, class.DiagramClass)
+
+		seen := make(map[domain.ClassRelation]bool)
+		add := func(relation domain.ClassRelation) {
+			if relation.From == relation.To || seen[relation] {
+				return
+			}
+			seen[relation] = true
+			diagram.Relations = append(diagram.Relations, relation)
+		}
+		for _, base := range class.bases {
+			if target := resolve(class, base); target != nil {
+				add(domain.ClassRelation{From: class.ID, To: target.ID, Kind: domain.ClassRelationInheritance})
+			}
+		}
+		for _, part := range class.parts {
+			for _, name := range part.names {
+				if target := resolve(class, name); target != nil {
+					add(domain.ClassRelation{From: class.ID, To: target.ID, Kind: domain.ClassRelationComposition, Attribute: part.attribute})
+				}
+			}
+		}
+	}
+	return diagram
+}
@@ -0,0 +1,189 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// extractDiagram builds the class diagram of path/source pairs
+func extractDiagram(t *testing.T, files ...string) *domain.ClassDiagram {
+	t.Helper()
+	extractor := NewClassDiagramExtractor()
+	for i := 0; i < len(files); i += 2 {
+		result, err := parser.New().Parse(context.Background(), []byte(files[i+1]))
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", files[i], err)
+		}
+		extractor.AddModule(files[i], result)
+	}
+	return extractor.Diagram()
+}
+
+// relationLines renders relations as "from kind to (attribute)" lines
+func relationLines(diagram *domain.ClassDiagram) string {
+	var lines []string
+	for _, relation := range diagram.Relations {
+		line := fmt.Sprintf("%s %s %s", relation.From, relation.Kind, relation.To)
+		if relation.Attribute != "" {
+			line += " (" + relation.Attribute + ")"
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestClassDiagram_ClassesAndAttributes(t *testing.T) {
+	diagram := extractDiagram(t, "src/shop/cart.py", `
+from abc import ABC, abstractmethod
+
+class Base(ABC):
+    @abstractmethod
+    def total(self) -> int: ...
+
+class Cart(Base, metaclass=Meta):
+    currency: str = "EUR"
+    limit = 10
+    __slots__ = ("items",)
+
+    def __init__(self, owner: "User", items=None):
+        self.owner = owner
+        self.items: list[Item] = items or []
+        self._cache = Cache()
+        if items:
+            self.count = len(items)
+
+    def total(self) -> int:
+        self.later = 1
+        return 0
+
+    class Line:
+        pass
+`)
+
+	if len(diagram.Classes) != 3 {
+		t.Fatalf("got %d classes, want 3: %+v", len(diagram.Classes), diagram.Classes)
+	}
+	base, cart, line := diagram.Classes[0], diagram.Classes[1], diagram.Classes[2]
+	if base.ID != "shop.cart.Base" || !base.IsAbstract || base.Methods != 1 {
+		t.Errorf("unexpected base class %+v", base)
+	}
+	if line.ID != "shop.cart.Cart.Line" || line.Name != "Cart.Line" || line.Module != "shop.cart" {
+		t.Errorf("unexpected nested class %+v", line)
+	}
+
+	if cart.FilePath != "src/shop/cart.py" || cart.StartLine != 8 || cart.IsAbstract || cart.Methods != 2 {
+		t.Errorf("unexpected class %+v", cart)
+	}
+	if want := []string{"Base"}; !reflect.DeepEqual(cart.Bases, want) {
+		t.Errorf("bases = %v, want %v", cart.Bases, want)
+	}
+	want := []domain.DiagramAttribute{
+		{Name: "currency", Type: "str"},
+		{Name: "limit"},
+		{Name: "owner", Type: `"User"`},
+		{Name: "items", Type: "list[Item]"},
+		{Name: "count"},
+	}
+	if !reflect.DeepEqual(cart.Attributes, want) {
+		t.Errorf("attributes = %+v, want %+v", cart.Attributes, want)
+	}
+	if got := relationLines(diagram); got != "shop.cart.Cart inheritance shop.cart.Base" {
+		t.Errorf("relations:\n%s", got)
+	}
+}
+
+func TestClassDiagram_ResolvesRelationsAcrossModules(t *testing.T) {
+	diagram := extractDiagram(t,
+		"shop/models.py", `
+class Item:
+    pass
+
+class User:
+    pass
+`,
+		"shop/cache.py", `
+class Cache:
+    pass
+`,
+		"shop/orders/order.py", `
+from ..models import Item as Product
+import shop.cache
+from typing import Generic, Optional, TypeVar
+
+T = TypeVar("T")
+
+class Repo(Generic[T]):
+    pass
+
+class Order(Repo[Product]):
+    lines: list[Product]
+    buyer: Optional["User"]
+
+    def __init__(self, cache: shop.cache.Cache):
+        self._cache = cache
+        self.other = Product()
+        self.extra = Product()
+`,
+		"legacy/models.py", `
+class Item:
+    pass
+`)
+
+	want := strings.Join([]string{
+		"shop.orders.order.Order inheritance shop.orders.order.Repo",
+		"shop.orders.order.Order composition shop.models.Item (lines)",
+		"shop.orders.order.Order composition shop.models.User (buyer)",
+		"shop.orders.order.Order composition shop.cache.Cache (_cache)",
+		"shop.orders.order.Order composition shop.models.Item (other)",
+		"shop.orders.order.Order composition shop.models.Item (extra)",
+	}, "\n")
+	if got := relationLines(diagram); got != want {
+		t.Errorf("relations:\n%s\nwant:\n%s", got, want)
+	}
+
+	var ids []string
+	for _, class := range diagram.Classes {
+		ids = append(ids, class.ID)
+	}
+	wantIDs := []string{"legacy.models.Item", "shop.cache.Cache", "shop.models.Item", "shop.models.User", "shop.orders.order.Repo", "shop.orders.order.Order"}
+	if !reflect.DeepEqual(ids, wantIDs) {
+		t.Errorf("classes = %v, want %v", ids, wantIDs)
+	}
+}
+
+func TestClassDiagram_AmbiguousNamesStayUnresolved(t *testing.T) {
+	diagram := extractDiagram(t,
+		"a/models.py", "class Item:\n    pass\n",
+		"b/models.py", "class Item:\n    pass\n",
+		"c/cart.py", "class Cart:\n    item: Item\n")
+	if len(diagram.Relations) != 0 {
+		t.Errorf("expected no relations, got:\n%s", relationLines(diagram))
+	}
+}
+
+func TestRelativeImportBase(t *testing.T) {
+	tests := []struct {
+		module    string
+		isPackage bool
+		level     int
+		name      string
+		want      string
+	}{
+		{"shop.cart", false, 1, "models", "shop.models"},
+		{"shop.cart", false, 1, "", "shop"},
+		{"shop", true, 1, "models", "shop.models"},
+		{"shop.orders.order", false, 2, "models", "shop.models"},
+		{"cart", false, 1, "models", "models"},
+	}
+	for _, tt := range tests {
+		if got := relativeImportBase(tt.module, tt.isPackage, tt.level, tt.name); got != tt.want {
+			t.Errorf("relativeImportBase(%q, %v, %d, %q) = %q, want %q", tt.module, tt.isPackage, tt.level, tt.name, got, tt.want)
+		}
+	}
+}
@@ -609,6 +609,7 @@ def helper():
 func TestExtractFragmentsWithSource(t *testing.T) {
 	t.Run("ExtractWithContent", func(t *testing.T) {
 		config := DefaultCloneDetectorConfig()
+		config.EnableMultiDimensionalAnalysis = true
 		config.EnableTextualAnalysis = true
 		config.MinLines = 1
 		config.MinNodes = 1
@@ -641,7 +642,7 @@ def goodbye():
 		}
 	})
 
-	t.Run("ExtractWithContentWhenTextualAnalysisDisabled", func(t *testing.T) {
+	t.Run("DropContentWhenTextualAnalysisDisabled", func(t *testing.T) {
 		config := DefaultCloneDetectorConfig()
 		config.EnableTextualAnalysis = false
 		config.MinLines = 1
@@ -663,7 +664,8 @@ def goodbye():
 		require.NotEmpty(t, fragments)
 
 		for _, f := range fragments {
-			assert.NotEmpty(t, f.Content, "Source-aware extraction should populate fragment content")
+			assert.Empty(t, f.Content, "Fragment content should be dropped after hashing")
+			assert.NotEmpty(t, f.Hash, "Fragment hash should be computed from the source")
 		}
 	})
 }
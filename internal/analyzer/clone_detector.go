@@ -71,6 +71,7 @@ type CodeFragment struct {
 	Content    string   // Original source code content
 	Hash       string   // FNV-64a hex hash of Type-1 normalized content; "" when no source content
 	Size       int      // Number of AST nodes
+	TokenCount int      // Number of AST leaves: names, literals and other terminal nodes
 	LineCount  int      // Number of source lines
 	Complexity int      // Cyclomatic complexity (if applicable)
 	Features   []string // Detector-populated clone feature cache for this fragment's tree
@@ -80,6 +81,9 @@ type CodeFragment struct {
 	// core caches the core/clone projection of this fragment, populated by
 	// prepareFragments so per-pair comparisons don't re-convert the tree.
 	core *coreclone.CodeFragment
+	// labels counts the node labels of TreeNode for bounding APTED distances;
+	// nil when the tree is too large to bound
+	labels *labelHistogram
 }
 
 // ItemID returns the fragment's unique ID for core/clone grouping.
@@ -144,12 +148,13 @@ func toCoreFragment(fragment *CodeFragment, id int) *coreclone.CodeFragment {
 // NewCodeFragment creates a new code fragment
 func NewCodeFragment(location *CodeLocation, astNode *parser.Node, content string) *CodeFragment {
 	return &CodeFragment{
-		Location:  location,
-		ASTNode:   astNode,
-		Content:   content,
-		Hash:      fragmentHashNormalizer.HashFragmentContent(content),
-		Size:      calculateASTSize(astNode),
-		LineCount: location.EndLine - location.StartLine + 1,
+		Location:   location,
+		ASTNode:    astNode,
+		Content:    content,
+		Hash:       fragmentHashNormalizer.HashFragmentContent(content),
+		Size:       calculateASTSize(astNode),
+		TokenCount: calculateASTTokens(astNode),
+		LineCount:  location.EndLine - location.StartLine + 1,
 	}
 }
 
@@ -167,6 +172,25 @@ func calculateASTSize(node *parser.Node) int {
 	return size
 }
 
+// calculateASTTokens counts the leaves of an AST, which stand for its
+// names, literals and keywords without statement and expression wrappers
+func calculateASTTokens(node *parser.Node) int {
+	if node == nil {
+		return 0
+	}
+
+	children := parser.OrderedChildren(node, nil)
+	if len(children) == 0 {
+		return 1
+	}
+	tokens := 0
+	for _, child := range children {
+		tokens += calculateASTTokens(child)
+	}
+
+	return tokens
+}
+
 // ClonePair represents a pair of similar code fragments
 type ClonePair struct {
 	Fragment1  *CodeFragment
@@ -229,6 +253,22 @@ type CloneDetectionResult struct {
 	Pairs      []*ClonePair
 	Groups     []*CloneGroup
 	Statistics *CloneDetectionStatistics
+
+	// Truncation records the limits that cut detection short
+	Truncation CloneTruncation
+}
+
+// CloneTruncation records where clone detection hit a limit, so clones may
+// be missing from the result
+type CloneTruncation struct {
+	// PairLimit is MaxClonePairs when more pairs were found than it keeps;
+	// the lowest-similarity pairs were dropped
+	PairLimit int
+
+	// CandidateLimitedQueries counts the LSH lookups that returned only
+	// CandidateLimit candidates and may have missed some
+	CandidateLimitedQueries int
+	CandidateLimit          int
 }
 
 // CloneDetectorConfig holds configuration for clone detection
@@ -239,6 +279,9 @@ type CloneDetectorConfig struct {
 	// Minimum number of AST nodes for a code fragment
 	MinNodes int
 
+	// Minimum number of tokens (AST leaves) for a code fragment; 0 disables the check
+	MinTokens int
+
 	// Similarity thresholds for different clone types
 	Type1Threshold float64 // Usually > domain.DefaultType1CloneThreshold
 	Type2Threshold float64 // Usually > domain.DefaultType2CloneThreshold
@@ -368,6 +411,7 @@ type CloneDetector struct {
 	cloneDetectorConfig CloneDetectorConfig
 
 	analyzer         *coreapted.APTEDAnalyzer
+	bounder          *aptedDistanceBounder // Skips APTED for pairs that cannot reach a threshold
 	converter        *TreeConverter
 	classifier       *CloneClassifier // Multi-dimensional classifier (optional)
 	textualAnalyzer  *coreclone.TextualSimilarityAnalyzer
@@ -376,6 +420,7 @@ type CloneDetector struct {
 	fragments        []*CodeFragment
 	clonePairs       []*ClonePair
 	cloneGroups      []*CloneGroup
+	truncation       CloneTruncation
 }
 
 // buildCloneCostModel creates the APTED cost model for the given configuration.
@@ -429,17 +474,22 @@ func NewCloneDetector(config *CloneDetectorConfig) *CloneDetector {
 	}
 
 	textualAnalyzer := coreclone.NewTextualSimilarityAnalyzer(removePythonComments)
+	// Type-1 is decided on content hashes in classifyClonePair, so the pair
+	// classifier gets no textual analyzer and caps the remaining pairs below
+	// the Type-1 threshold
 	pairClassifier := coreclone.NewPairClassifier(coreclone.ClassifierConfig{
 		Type1Threshold: config.Type1Threshold, Type2Threshold: config.Type2Threshold,
 		Type3Threshold: config.Type3Threshold, Type4Threshold: config.Type4Threshold,
 		EnableType1: true, EnableType2: true, EnableType3: true, EnableType4: true,
 		JaccardPreFilterThreshold: 0.10,
-	}, textualAnalyzer, coreclone.NewSyntacticSimilarityAnalyzerWithExtractor(
+	}, nil, coreclone.NewSyntacticSimilarityAnalyzerWithExtractor(
 		newPythonCloneFeatureExtractor().WithOptions(3, 4, true, false)))
 
+	costModel := buildCloneCostModel(config)
 	return &CloneDetector{
 		cloneDetectorConfig: *config,
-		analyzer:            newAPTEDAnalyzer(buildCloneCostModel(config)),
+		analyzer:            newAPTEDAnalyzer(costModel),
+		bounder:             newAPTEDDistanceBounder(costModel),
 		converter:           NewTreeConverterWithConfig(config.SkipDocstrings),
 		classifier:          buildCloneClassifier(config),
 		textualAnalyzer:     textualAnalyzer,
@@ -452,13 +502,16 @@ func NewCloneDetector(config *CloneDetectorConfig) *CloneDetector {
 }
 
 // newWorkerDetector returns a shallow copy of cd with private instances of the
-// stateful analyzers (the APTED analyzer's scratch buffers and the classifier's
-// internal analyzers), so that fragment comparisons can run concurrently across
-// goroutines. Immutable state (config, fragments, the core textual/syntactic
-// analyzers, pair classifier, and feature extractor) is shared.
+// stateful analyzers (the APTED analyzer's scratch buffers, the bounder's cost
+// caches and the classifier's internal analyzers), so that fragment
+// comparisons can run concurrently across goroutines. Immutable state (config,
+// fragments, the core textual/syntactic analyzers, pair classifier, and
+// feature extractor) is shared.
 func (cd *CloneDetector) newWorkerDetector() *CloneDetector {
 	w := *cd
-	w.analyzer = newAPTEDAnalyzer(buildCloneCostModel(&cd.cloneDetectorConfig))
+	costModel := buildCloneCostModel(&cd.cloneDetectorConfig)
+	w.analyzer = newAPTEDAnalyzer(costModel)
+	w.bounder = newAPTEDDistanceBounder(costModel)
 	w.classifier = buildCloneClassifier(&cd.cloneDetectorConfig)
 	return &w
 }
@@ -535,7 +588,10 @@ func (cd *CloneDetector) ExtractFragments(astNodes []*parser.Node, filePath stri
 }
 
 // ExtractFragmentsWithSource extracts code fragments from AST nodes with source content.
-// Source content is needed for Type-1 clone classification and optional report output.
+// The content of each fragment is hashed for Type-1 classification and then
+// dropped unless textual multi-dimensional analysis compares it, so the source
+// is not retained for the whole run; use a FragmentSourceReader to read it
+// again for reports.
 func (cd *CloneDetector) ExtractFragmentsWithSource(astNodes []*parser.Node, filePath string, sourceCode []byte) []*CodeFragment {
 	var fragments []*CodeFragment
 	lines := splitLines(sourceCode)
@@ -563,13 +619,15 @@ func (cd *CloneDetector) extractFragmentsRecursiveWithSource(node *parser.Node,
 			EndCol:    node.Location.EndCol,
 		}
 
-		// Extract content from source code if textual analysis is enabled
 		content := ""
 		if len(lines) > 0 {
-			content = cd.extractSourceContent(lines, &node.Location)
+			content = extractSourceContent(lines, node.Location.StartLine, node.Location.EndLine)
 		}
 
 		fragment := NewCodeFragment(location, node, content)
+		if !cd.retainsFragmentContent() {
+			fragment.Content = ""
+		}
 
 		// Filter fragments based on configuration
 		if cd.shouldIncludeFragment(fragment) {
@@ -583,21 +641,25 @@ func (cd *CloneDetector) extractFragmentsRecursiveWithSource(node *parser.Node,
 	}
 }
 
-// extractSourceContent extracts source code content for a given location
-func (cd *CloneDetector) extractSourceContent(lines [][]byte, loc *parser.Location) string {
-	if loc == nil || len(lines) == 0 {
-		return ""
-	}
+// retainsFragmentContent reports whether fragments must keep their source
+// text after hashing, which only the textual analyzer of the
+// multi-dimensional classifier compares
+func (cd *CloneDetector) retainsFragmentContent() bool {
+	return cd.classifier != nil &&
+		cd.cloneDetectorConfig.EnableMultiDimensionalAnalysis &&
+		cd.cloneDetectorConfig.EnableTextualAnalysis
+}
 
-	if loc.StartLine < 1 || loc.EndLine > len(lines) {
+// extractSourceContent extracts the source lines startLine to endLine (1-indexed)
+func extractSourceContent(lines [][]byte, startLine, endLine int) string {
+	if len(lines) == 0 || startLine < 1 || endLine > len(lines) {
 		return ""
 	}
 
-	// Extract lines from StartLine to EndLine (1-indexed)
 	var result []byte
-	for i := loc.StartLine - 1; i < loc.EndLine && i < len(lines); i++ {
+	for i := startLine - 1; i < endLine; i++ {
 		result = append(result, lines[i]...)
-		if i < loc.EndLine-1 {
+		if i < endLine-1 {
 			result = append(result, '\n')
 		}
 	}
@@ -687,9 +749,17 @@ func (cd *CloneDetector) shouldIncludeFragment(fragment *CodeFragment) bool {
 		return false
 	}
 
+	if fragment.TokenCount < cd.cloneDetectorConfig.MinTokens {
+		return false
+	}
+
 	return true
 }
 
+// cancelCheckInterval is how many fragment pairs are compared between two
+// cancellation checks in the pair loops
+const cancelCheckInterval = 64
+
 // isCancelled checks if the context is cancelled
 func isCancelled(ctx context.Context) bool {
 	select {
@@ -710,6 +780,7 @@ func (cd *CloneDetector) DetectClonesWithContext(ctx context.Context, fragments
 	cd.fragments = fragments
 	cd.clonePairs = []*ClonePair{}
 	cd.cloneGroups = []*CloneGroup{}
+	cd.truncation = CloneTruncation{}
 
 	// Check for cancellation before starting
 	if isCancelled(ctx) {
@@ -717,7 +788,7 @@ func (cd *CloneDetector) DetectClonesWithContext(ctx context.Context, fragments
 	}
 
 	// Convert AST fragments to tree nodes
-	cd.prepareFragments()
+	cd.prepareFragments(ctx)
 
 	// Check for cancellation after preparation
 	if isCancelled(ctx) {
@@ -760,13 +831,14 @@ func (cd *CloneDetector) DetectClonesWithLSH(ctx context.Context, fragments []*C
 	cd.fragments = fragments
 	cd.clonePairs = []*ClonePair{}
 	cd.cloneGroups = []*CloneGroup{}
+	cd.truncation = CloneTruncation{}
 
 	if isCancelled(ctx) {
 		return cd.buildCloneDetectionResult()
 	}
 
 	// Prepare TreeNodes for APTED and feature extraction
-	cd.prepareFragments()
+	cd.prepareFragments(ctx)
 
 	if isCancelled(ctx) {
 		return cd.buildCloneDetectionResult()
@@ -833,7 +905,7 @@ func (cd *CloneDetector) DetectClonesWithLSH(ctx context.Context, fragments []*C
 				if isCancelled(ctx) {
 					continue
 				}
-				pair := wd.compareFragments(cd.fragments[c.a], cd.fragments[c.b])
+				pair := wd.compareFragmentsAbove(cd.fragments[c.a], cd.fragments[c.b], wd.minimumSignificantSimilarity())
 				if pair != nil && wd.isSignificantClone(pair) {
 					verified[w] = append(verified[w], pair)
 				}
@@ -841,11 +913,16 @@ func (cd *CloneDetector) DetectClonesWithLSH(ctx context.Context, fragments []*C
 		}()
 	}
 
-	for _, r := range records {
+	for ri, r := range records {
 		if isCancelled(ctx) {
 			break
 		}
-		cands := lsh.FindCandidates(r.sig)
+		domain.ReportProgress(ctx, domain.ProgressStageClones, ri, len(records))
+		cands, limited := lsh.findCandidates(r.sig)
+		if limited {
+			cd.truncation.CandidateLimitedQueries++
+			cd.truncation.CandidateLimit = lsh.maxCandidates
+		}
 		for _, j := range cands {
 			i := r.idx
 			if j == i || j < 0 || i < 0 {
@@ -882,6 +959,7 @@ func (cd *CloneDetector) DetectClonesWithLSH(ctx context.Context, fragments []*C
 	}
 	close(candCh)
 	wg.Wait()
+	domain.ReportProgress(ctx, domain.ProgressStageClones, len(records), len(records))
 
 	for _, pairs := range verified {
 		cd.clonePairs = append(cd.clonePairs, pairs...)
@@ -950,14 +1028,18 @@ func (cd *CloneDetector) buildCloneDetectionResult() *CloneDetectionResult {
 		Pairs:      cd.clonePairs,
 		Groups:     cd.cloneGroups,
 		Statistics: stats,
+		Truncation: cd.truncation,
 	}
 }
 
 // prepareFragments converts AST fragments to tree nodes, populates clone
 // features, and caches each fragment's core/clone projection so per-pair
-// comparisons don't re-convert trees.
-func (cd *CloneDetector) prepareFragments() {
+// comparisons don't re-convert trees. Stops early when ctx is cancelled.
+func (cd *CloneDetector) prepareFragments(ctx context.Context) {
 	for i, fragment := range cd.fragments {
+		if isCancelled(ctx) {
+			return
+		}
 		if fragment == nil {
 			continue
 		}
@@ -973,6 +1055,7 @@ func (cd *CloneDetector) prepareFragments() {
 		features, _ := cd.featureExtractor.ExtractFeatures(fragment.core.ASTNode)
 		fragment.Features = features
 		fragment.core.Features = features
+		fragment.labels = newLabelHistogram(fragment.TreeNode)
 	}
 }
 
@@ -996,13 +1079,13 @@ func (cd *CloneDetector) detectClonePairsWithContext(ctx context.Context) {
 	cd.limitAndSortClonePairs(maxPairs)
 }
 
-// clonePairMinHeap is a min-heap on Similarity: the root is the worst retained
-// pair, so a better candidate can replace it in O(log n). Used to keep the
-// best maxPairs candidates per worker with bounded memory.
+// clonePairMinHeap is a min-heap in clonePairBefore order: the root is the
+// worst retained pair, so a better candidate can replace it in O(log n). Used
+// to keep the best maxPairs candidates per worker with bounded memory.
 type clonePairMinHeap []*ClonePair
 
 func (h clonePairMinHeap) Len() int           { return len(h) }
-func (h clonePairMinHeap) Less(i, j int) bool { return h[i].Similarity < h[j].Similarity }
+func (h clonePairMinHeap) Less(i, j int) bool { return clonePairBefore(h[j], h[i]) }
 func (h clonePairMinHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
 func (h *clonePairMinHeap) Push(x any)        { *h = append(*h, x.(*ClonePair)) }
 func (h *clonePairMinHeap) Pop() any {
@@ -1013,40 +1096,66 @@ func (h *clonePairMinHeap) Pop() any {
 	return x
 }
 
-// detectClonePairsParallel enumerates all unordered fragment pairs row by row,
-// fanning rows out across workers. Each worker keeps a bounded min-heap of its
-// best pairs, so memory stays O(workers * maxPairs) regardless of input size.
-// The union of per-worker top-maxPairs always contains the global top-maxPairs.
+// pairChunksPerWorker is how many chunks of rows each worker gets on average.
+// Several chunks per worker let idle workers take over the remaining work when
+// some chunks turn out slower than others.
+const pairChunksPerWorker = 16
+
+// detectClonePairsParallel enumerates all unordered fragment pairs row by row.
+// Rows are cut into chunks of about the same number of pairs, which workers
+// take from a shared counter until none are left. Each worker keeps a bounded
+// min-heap of its best pairs, so memory stays O(workers * maxPairs) regardless
+// of input size. The union of per-worker top-maxPairs always contains the
+// global top-maxPairs, and since pairs are totally ordered the merged result
+// does not depend on how chunks were scheduled.
 func (cd *CloneDetector) detectClonePairsParallel(ctx context.Context, maxPairs int) {
 	n := len(cd.fragments)
 	workers := cd.effectiveWorkers(n - 1)
 	heaps := make([]clonePairMinHeap, workers)
+	chunks := pairRowChunks(n, workers*pairChunksPerWorker)
+	totalPairs := n * (n - 1) / 2
+	var compared atomic.Int64
 
-	cd.runParallelIndexed(ctx, workers, n, func(wd *CloneDetector, worker, i int) {
+	cd.runParallelIndexed(ctx, workers, len(chunks)-1, func(wd *CloneDetector, worker, chunk int) {
 		h := &heaps[worker]
-		for j := i + 1; j < n; j++ {
-			// Once the heap is full, the worst retained similarity becomes a
-			// pruning floor for this worker.
-			floor := 0.0
-			if h.Len() >= maxPairs {
-				floor = (*h)[0].Similarity
-			}
-			pair := wd.tryCreateClonePair(i, j, floor)
-			if pair == nil {
-				continue
+		for i := chunks[chunk]; i < chunks[chunk+1]; i++ {
+			if isCancelled(ctx) {
+				return
 			}
-			if h.Len() < maxPairs {
-				heap.Push(h, pair)
-			} else if pair.Similarity > (*h)[0].Similarity {
-				(*h)[0] = pair
-				heap.Fix(h, 0)
+			for j := i + 1; j < n; j++ {
+				// A row can hold thousands of comparisons; check between
+				// batches so cancellation takes effect promptly
+				if (j-i)%cancelCheckInterval == 0 && isCancelled(ctx) {
+					return
+				}
+				// Once the heap is full, the worst retained similarity becomes
+				// a pruning floor for this worker.
+				floor := 0.0
+				if h.Len() >= maxPairs {
+					floor = (*h)[0].Similarity
+				}
+				pair := wd.tryCreateClonePair(i, j, floor)
+				if pair == nil {
+					continue
+				}
+				if h.Len() < maxPairs {
+					heap.Push(h, pair)
+				} else if clonePairBefore(pair, (*h)[0]) {
+					(*h)[0] = pair
+					heap.Fix(h, 0)
+				}
 			}
+			domain.ReportProgress(ctx, domain.ProgressStageClones, int(compared.Add(int64(n-1-i))), totalPairs)
 		}
 	})
 
 	total := 0
 	for w := range heaps {
 		total += heaps[w].Len()
+		// A full heap pruned the pairs below its floor
+		if heaps[w].Len() >= maxPairs {
+			cd.truncation.PairLimit = maxPairs
+		}
 	}
 	merged := make([]*ClonePair, 0, total)
 	for w := range heaps {
@@ -1055,15 +1164,92 @@ func (cd *CloneDetector) detectClonePairsParallel(ctx context.Context, maxPairs
 	cd.clonePairs = merged
 }
 
+// pairRowChunks splits the rows of the n x n upper-triangular pair matrix into
+// at most chunks ranges holding about the same number of pairs. Row i pairs
+// with the n-1-i fragments after it, so early chunks have fewer rows. Returns
+// the chunk boundaries: chunk c covers rows [bounds[c], bounds[c+1]).
+func pairRowChunks(n, chunks int) []int {
+	bounds := []int{0}
+	if n <= 1 {
+		return append(bounds, n)
+	}
+	if chunks < 1 {
+		chunks = 1
+	}
+	totalPairs := n * (n - 1) / 2
+	target := (totalPairs + chunks - 1) / chunks
+
+	// The last row has no pairs and stays with the chunk before it
+	pairs := 0
+	for i := 0; i < n; i++ {
+		pairs += n - 1 - i
+		if pairs >= target && i+2 < n {
+			bounds = append(bounds, i+1)
+			pairs = 0
+		}
+	}
+	return append(bounds, n)
+}
+
+// clonePairBefore reports whether pair a ranks before pair b: by descending
+// similarity, then by the locations of their fragments, so that ties are
+// broken the same way on every run
+func clonePairBefore(a, b *ClonePair) bool {
+	if a.Similarity != b.Similarity {
+		return a.Similarity > b.Similarity
+	}
+	if fragmentBefore(a.Fragment1, b.Fragment1) {
+		return true
+	}
+	if fragmentBefore(b.Fragment1, a.Fragment1) {
+		return false
+	}
+	return fragmentBefore(a.Fragment2, b.Fragment2)
+}
+
+// fragmentBefore orders fragments by file and position, then by their index
+// in the analyzed fragment set
+func fragmentBefore(a, b *CodeFragment) bool {
+	if a == nil || b == nil {
+		return a == nil && b != nil
+	}
+	if a.Location != nil && b.Location != nil {
+		la, lb := a.Location, b.Location
+		if la.FilePath != lb.FilePath {
+			return la.FilePath < lb.FilePath
+		}
+		if la.StartLine != lb.StartLine {
+			return la.StartLine < lb.StartLine
+		}
+		if la.StartCol != lb.StartCol {
+			return la.StartCol < lb.StartCol
+		}
+		if la.EndLine != lb.EndLine {
+			return la.EndLine < lb.EndLine
+		}
+		if la.EndCol != lb.EndCol {
+			return la.EndCol < lb.EndCol
+		}
+	}
+	return a.id < b.id
+}
+
 // compareFragments compares two fragments and returns a clone pair if similar.
 // Uses a Jaccard pre-filter on pre-computed features to minimize expensive APTED calls.
 func (cd *CloneDetector) compareFragments(fragment1, fragment2 *CodeFragment) *ClonePair {
+	return cd.compareFragmentsAbove(fragment1, fragment2, 0)
+}
+
+// compareFragmentsAbove compares two fragments like compareFragments, for a
+// caller that discards pairs below minSimilarity: APTED is skipped when the
+// label histograms show the pair cannot reach it.
+func (cd *CloneDetector) compareFragmentsAbove(fragment1, fragment2 *CodeFragment, minSimilarity float64) *ClonePair {
 	if fragment1.TreeNode == nil || fragment2.TreeNode == nil {
 		return nil
 	}
 
 	if cd.usesSemanticClassifier() {
-		return cd.compareFragmentsWithClassifier(fragment1, fragment2)
+		return cd.compareFragmentsWithClassifier(fragment1, fragment2, minSimilarity)
 	}
 
 	// Early filtering check
@@ -1080,11 +1266,11 @@ func (cd *CloneDetector) compareFragments(fragment1, fragment2 *CodeFragment) *C
 
 	// Use multi-dimensional classifier if enabled
 	if cd.classifier != nil && cd.cloneDetectorConfig.EnableMultiDimensionalAnalysis {
-		return cd.compareFragmentsWithClassifier(fragment1, fragment2)
+		return cd.compareFragmentsWithClassifier(fragment1, fragment2, minSimilarity)
 	}
 
 	// Fallback to single-metric classification (backward compatible)
-	return cd.compareFragmentsSingleMetric(fragment1, fragment2)
+	return cd.compareFragmentsSingleMetric(fragment1, fragment2, minSimilarity)
 }
 
 func (cd *CloneDetector) usesSemanticClassifier() bool {
@@ -1095,17 +1281,24 @@ func (cd *CloneDetector) usesSemanticClassifier() bool {
 
 // compareFragmentsWithClassifier uses the classifier as a gate and APTED for
 // final similarity/distance scoring and clone-type classification.
-func (cd *CloneDetector) compareFragmentsWithClassifier(fragment1, fragment2 *CodeFragment) *ClonePair {
+func (cd *CloneDetector) compareFragmentsWithClassifier(fragment1, fragment2 *CodeFragment, minSimilarity float64) *ClonePair {
 	result := cd.classifier.ClassifyClone(fragment1, fragment2)
 	if result == nil {
 		return nil
 	}
 
+	// Semantic Type-4 pairs keep the classifier's similarity, so only the
+	// others are decided by APTED
+	semantic := result.CloneType == Type4Clone && result.Analyzer == "semantic"
+	if !semantic && cd.cannotReachSimilarity(fragment1, fragment2, minSimilarity) {
+		return nil
+	}
+
 	// Always run APTED with the detector's cost model (boilerplate-aware) so that
 	// Distance is populated and clone type is derived from a consistent metric.
 	distance, similarity := cd.analyzer.ComputeDistanceAndSimilarity(fragment1.TreeNode, fragment2.TreeNode)
 
-	if result.CloneType == Type4Clone && result.Analyzer == "semantic" {
+	if semantic {
 		return &ClonePair{
 			Fragment1:  fragment1,
 			Fragment2:  fragment2,
@@ -1133,10 +1326,47 @@ func (cd *CloneDetector) compareFragmentsWithClassifier(fragment1, fragment2 *Co
 
 // compareFragmentsSingleMetric uses APTED for clone type classification.
 // Jaccard pre-filtering is handled at the compareFragments level.
-func (cd *CloneDetector) compareFragmentsSingleMetric(fragment1, fragment2 *CodeFragment) *ClonePair {
+func (cd *CloneDetector) compareFragmentsSingleMetric(fragment1, fragment2 *CodeFragment, minSimilarity float64) *ClonePair {
+	if cd.cannotReachSimilarity(fragment1, fragment2, minSimilarity) {
+		return nil
+	}
 	return cd.compareWithAPTED(fragment1, fragment2)
 }
 
+// cannotReachSimilarity reports whether the APTED similarity of two fragments
+// is certainly below both minSimilarity and the lowest similarity any clone
+// type accepts, judging from a lower bound of their distance. Similarity is
+// 1 - distance / max(size1, size2), so the pair needs a distance of at most
+// (1 - minimum) * max(size1, size2).
+func (cd *CloneDetector) cannotReachSimilarity(fragment1, fragment2 *CodeFragment, minSimilarity float64) bool {
+	if cd.bounder == nil || fragment1.labels == nil || fragment2.labels == nil {
+		return false
+	}
+	minimum := math.Max(minSimilarity, cd.minimumClassifiedSimilarity())
+	if minimum <= 0 {
+		return false
+	}
+	maxSize := float64(max(fragment1.labels.size, fragment2.labels.size))
+	return cd.bounder.exceedsDistance(fragment1.labels, fragment2.labels, (1-minimum)*maxSize)
+}
+
+// minimumClassifiedSimilarity is the lowest APTED similarity that receives a
+// clone type
+func (cd *CloneDetector) minimumClassifiedSimilarity() float64 {
+	config := &cd.cloneDetectorConfig
+	return math.Min(math.Min(config.Type1Threshold, config.Type2Threshold),
+		math.Min(config.Type3Threshold, config.Type4Threshold))
+}
+
+// minimumSignificantSimilarity is the lowest similarity isSignificantClone
+// accepts
+func (cd *CloneDetector) minimumSignificantSimilarity() float64 {
+	if cd.cloneDetectorConfig.SimilarityThreshold > 0 {
+		return cd.cloneDetectorConfig.SimilarityThreshold
+	}
+	return cd.cloneDetectorConfig.Type4Threshold
+}
+
 // compareWithAPTED uses the APTED algorithm for precise similarity measurement.
 func (cd *CloneDetector) compareWithAPTED(fragment1, fragment2 *CodeFragment) *ClonePair {
 	distance, similarity := cd.analyzer.ComputeDistanceAndSimilarity(fragment1.TreeNode, fragment2.TreeNode)
@@ -1162,7 +1392,16 @@ func (cd *CloneDetector) compareWithAPTED(fragment1, fragment2 *CodeFragment) *C
 // gating Type-1 on exact textual match and Type-2 on syntactic (normalized
 // AST) similarity. Returns the (possibly capped) similarity actually used for
 // classification.
+//
+// The textual match compares the hashes of the normalized content, which
+// fragments keep after their source text is dropped.
 func (cd *CloneDetector) classifyClonePair(fragment1, fragment2 *CodeFragment, similarity float64) (CloneType, float64) {
+	if similarity >= cd.cloneDetectorConfig.Type1Threshold {
+		hash1, hash2 := fragmentContentHash(fragment1), fragmentContentHash(fragment2)
+		if hash1 != "" && hash1 == hash2 {
+			return Type1Clone, similarity
+		}
+	}
 	coreType, capped := cd.pairClassifier.ClassifyPair(fragment1.coreFragment(), fragment2.coreFragment(), similarity)
 	return CloneType(coreType), capped
 }
@@ -1170,12 +1409,7 @@ func (cd *CloneDetector) classifyClonePair(fragment1, fragment2 *CodeFragment, s
 // isSignificantClone determines if a clone pair is significant enough to report
 func (cd *CloneDetector) isSignificantClone(pair *ClonePair) bool {
 	// Check minimum similarity threshold
-	// Use SimilarityThreshold if set, otherwise fall back to Type4Threshold
-	minThreshold := cd.cloneDetectorConfig.SimilarityThreshold
-	if minThreshold <= 0 {
-		minThreshold = cd.cloneDetectorConfig.Type4Threshold
-	}
-	if pair.Similarity < minThreshold {
+	if pair.Similarity < cd.minimumSignificantSimilarity() {
 		return false
 	}
 
@@ -1323,7 +1557,7 @@ func (cd *CloneDetector) tryCreateClonePair(i, j int, minSimilarity float64) *Cl
 	}
 
 	// Full similarity computation (compareFragments already calls shouldCompareFragments)
-	pair := cd.compareFragments(fragment1, fragment2)
+	pair := cd.compareFragmentsAbove(fragment1, fragment2, math.Max(minSimilarity, cd.minimumSignificantSimilarity()))
 	if pair != nil && cd.isSignificantClone(pair) && pair.Similarity >= minSimilarity {
 		return pair
 	}
@@ -1332,14 +1566,16 @@ func (cd *CloneDetector) tryCreateClonePair(i, j int, minSimilarity float64) *Cl
 
 // limitAndSortClonePairs ensures final results are sorted and limited
 func (cd *CloneDetector) limitAndSortClonePairs(maxPairs int) {
-	// Sort clone pairs by similarity (descending)
+	// Sort clone pairs by similarity (descending), in the same order however
+	// workers found them
 	sort.Slice(cd.clonePairs, func(i, j int) bool {
-		return cd.clonePairs[i].Similarity > cd.clonePairs[j].Similarity
+		return clonePairBefore(cd.clonePairs[i], cd.clonePairs[j])
 	})
 
 	// Limit the number of pairs to prevent memory issues
 	if len(cd.clonePairs) > maxPairs {
 		cd.clonePairs = cd.clonePairs[:maxPairs]
+		cd.truncation.PairLimit = maxPairs
 	}
 }
 
@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -188,6 +189,42 @@ func TestCalculateASTSize(t *testing.T) {
 	assert.Equal(t, 2, size, "Duplicate child references should not inflate AST size")
 }
 
+func TestCalculateASTTokens(t *testing.T) {
+	assert.Equal(t, 0, calculateASTTokens(nil))
+	assert.Equal(t, 1, calculateASTTokens(&parser.Node{Type: parser.NodeName, Name: "variable"}))
+
+	// value = items[index]: only the three names are tokens
+	assign := &parser.Node{
+		Type: parser.NodeAssign,
+		Targets: []*parser.Node{
+			{Type: parser.NodeName, Name: "value"},
+		},
+		Value: &parser.Node{
+			Type:  parser.NodeSubscript,
+			Value: &parser.Node{Type: parser.NodeName, Name: "items"},
+			Children: []*parser.Node{
+				{Type: parser.NodeName, Name: "index"},
+			},
+		},
+	}
+	assert.Equal(t, 3, calculateASTTokens(assign))
+	assert.Equal(t, 5, calculateASTSize(assign))
+
+	// A dense one-liner has more tokens per line than a block of trivial
+	// statements
+	dense := parseFirstFragmentWithContent(t, "dense.py", `def dense(rows):
+    return {k: [v * 2 for v in vs if v > 0] for k, vs in rows.items() if vs}
+`)
+	trivial := parseFirstFragmentWithContent(t, "trivial.py", `def trivial():
+    pass
+    pass
+    pass
+    pass
+`)
+	assert.Greater(t, dense.TokenCount, trivial.TokenCount)
+	assert.Less(t, dense.LineCount, trivial.LineCount)
+}
+
 func TestCompareWithAPTEDExpressionOnlyDifferenceReportsDistance(t *testing.T) {
 	left := `if cond:
     value = items[i]
@@ -391,6 +428,12 @@ func TestCloneDetector_ShouldIncludeFragment(t *testing.T) {
 			assert.Equal(t, tt.expected, result)
 		})
 	}
+
+	t.Run("min tokens", func(t *testing.T) {
+		detector := NewCloneDetector(&CloneDetectorConfig{MinLines: 5, MinNodes: 10, MinTokens: 12})
+		assert.False(t, detector.shouldIncludeFragment(&CodeFragment{Size: 15, LineCount: 8, TokenCount: 11}))
+		assert.True(t, detector.shouldIncludeFragment(&CodeFragment{Size: 15, LineCount: 8, TokenCount: 12}))
+	})
 }
 
 func TestCloneDetector_ClassifyClonePair(t *testing.T) {
@@ -461,6 +504,57 @@ func TestCloneDetector_Type1RequiresTextualMatch(t *testing.T) {
 	assert.GreaterOrEqual(t, textMismatch.Similarity, config.Type2Threshold)
 }
 
+func TestCloneDetector_Type1WithDroppedContent(t *testing.T) {
+	config := DefaultCloneDetectorConfig()
+	config.MinLines = 1
+	config.MinNodes = 1
+	detector := NewCloneDetector(config)
+
+	source := "def first(a):\n    return a + 1\n\n\ndef second(a):\n    return a + 1 # same\n"
+	result, err := parser.New().Parse(t.Context(), []byte(source))
+	require.NoError(t, err)
+
+	fragments := detector.ExtractFragmentsWithSource([]*parser.Node{result.AST}, "test.py", []byte(source))
+	require.Len(t, fragments, 2)
+	for _, fragment := range fragments {
+		require.Empty(t, fragment.Content)
+	}
+
+	cloneType, _ := detector.classifyClonePair(fragments[0], fragments[1], 1.0)
+	assert.Equal(t, Type2Clone, cloneType, "definitions with different names are not textual matches")
+
+	body1, body2 := *fragments[0], *fragments[1]
+	body1.Hash = fragmentHashNormalizer.HashFragmentContent("return a + 1")
+	body2.Hash = fragmentHashNormalizer.HashFragmentContent("return  a + 1  # same")
+	cloneType, _ = detector.classifyClonePair(&body1, &body2, 1.0)
+	assert.Equal(t, Type1Clone, cloneType)
+}
+
+func TestFragmentSourceReader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "module.py")
+	source := "import os\n\ndef f():\n    return os.sep\n"
+	require.NoError(t, os.WriteFile(path, []byte(source), 0o644))
+
+	config := DefaultCloneDetectorConfig()
+	config.MinLines = 1
+	config.MinNodes = 1
+	result, err := parser.New().Parse(t.Context(), []byte(source))
+	require.NoError(t, err)
+	fragments := NewCloneDetector(config).ExtractFragmentsWithSource([]*parser.Node{result.AST}, path, []byte(source))
+	require.NotEmpty(t, fragments)
+	fragment := fragments[0]
+	require.Empty(t, fragment.Content)
+
+	reader := NewFragmentSourceReader()
+	assert.Equal(t, "def f():\n    return os.sep", reader.FragmentContent(fragment))
+	assert.Equal(t, "import os", reader.Content(path, 1, 1, ""))
+	assert.Empty(t, reader.Content(path, 3, 10, ""), "lines past the end of the file")
+
+	require.NoError(t, os.WriteFile(path, []byte("import os\n\ndef f():\n    return os.linesep\n"), 0o644))
+	assert.Empty(t, NewFragmentSourceReader().FragmentContent(fragment), "a changed file yields no content")
+	assert.Empty(t, reader.Content(filepath.Join(t.TempDir(), "missing.py"), 1, 1, ""))
+}
+
 func TestCloneDetector_IsSignificantClone(t *testing.T) {
 	config := DefaultCloneDetectorConfig()
 	detector := NewCloneDetector(config)
@@ -1209,3 +1303,103 @@ func groupContainsFragment(g *CloneGroup, f *CodeFragment) bool {
 	}
 	return false
 }
+
+func TestPairRowChunks(t *testing.T) {
+	tests := []struct {
+		n, chunks int
+		want      []int
+	}{
+		{n: 0, chunks: 4, want: []int{0, 0}},
+		{n: 1, chunks: 4, want: []int{0, 1}},
+		{n: 5, chunks: 1, want: []int{0, 5}},
+		// 10 pairs: rows hold 4, 3, 2, 1 and 0 pairs
+		{n: 5, chunks: 2, want: []int{0, 2, 5}},
+		{n: 5, chunks: 10, want: []int{0, 1, 2, 3, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("n=%d chunks=%d", tt.n, tt.chunks), func(t *testing.T) {
+			assert.Equal(t, tt.want, pairRowChunks(tt.n, tt.chunks))
+		})
+	}
+
+	// Every row belongs to exactly one chunk
+	bounds := pairRowChunks(1000, 64)
+	assert.LessOrEqual(t, len(bounds)-1, 64)
+	assert.Equal(t, 0, bounds[0])
+	assert.Equal(t, 1000, bounds[len(bounds)-1])
+	for c := 1; c < len(bounds); c++ {
+		assert.Less(t, bounds[c-1], bounds[c])
+	}
+}
+
+func TestCloneDetector_ParallelPairsAreDeterministic(t *testing.T) {
+	describe := func(workers int) []string {
+		config := cloneBenchmarkConfig(false)
+		config.MaxGoroutines = workers
+		// Fewer pairs than ties at similarity 1.0, so that the kept pairs
+		// depend on how ties are broken
+		config.MaxClonePairs = 10
+
+		result := NewCloneDetector(config).DetectClones(buildCloneBenchmarkFragments(4, 4, 8))
+		pairs := make([]string, len(result.Pairs))
+		for i, pair := range result.Pairs {
+			pairs[i] = fmt.Sprintf("%s %s %.6f", pair.Fragment1.Location, pair.Fragment2.Location, pair.Similarity)
+		}
+		return pairs
+	}
+
+	want := describe(1)
+	require.Len(t, want, 10)
+	for _, workers := range []int{2, 4, 8} {
+		for run := 0; run < 3; run++ {
+			assert.Equal(t, want, describe(workers), "pairs with %d workers", workers)
+		}
+	}
+}
+
+func TestCloneDetector_RecordsPairLimitTruncation(t *testing.T) {
+	config := cloneBenchmarkConfig(false)
+	config.MaxClonePairs = 10
+	result := NewCloneDetector(config).DetectClones(buildCloneBenchmarkFragments(4, 4, 8))
+	assert.Len(t, result.Pairs, 10)
+	assert.Equal(t, 10, result.Truncation.PairLimit)
+
+	config.MaxClonePairs = 100000
+	result = NewCloneDetector(config).DetectClones(buildCloneBenchmarkFragments(4, 4, 8))
+	assert.Zero(t, result.Truncation.PairLimit)
+}
+
+func TestCloneDetector_PruningKeepsClonePairs(t *testing.T) {
+	for _, multiDimensional := range []bool{false, true} {
+		config := cloneBenchmarkConfig(false)
+		config.Type4Threshold = 0.6
+		config.EnableMultiDimensionalAnalysis = multiDimensional
+		detector := NewCloneDetector(config)
+		detector.fragments = buildCloneBenchmarkFragments(4, 2, 12)
+		detector.prepareFragments(context.Background())
+
+		unpruned := *detector
+		unpruned.bounder = nil
+
+		pruned := 0
+		fragments := detector.fragments
+		for i := range fragments {
+			for j := i + 1; j < len(fragments); j++ {
+				minSimilarity := detector.minimumSignificantSimilarity()
+				want := unpruned.compareFragmentsAbove(fragments[i], fragments[j], minSimilarity)
+				got := detector.compareFragmentsAbove(fragments[i], fragments[j], minSimilarity)
+				if detector.cannotReachSimilarity(fragments[i], fragments[j], minSimilarity) {
+					pruned++
+				}
+				if want == nil || !detector.isSignificantClone(want) {
+					continue
+				}
+				require.NotNil(t, got, "pair %d-%d was pruned", i, j)
+				assert.Equal(t, want.Similarity, got.Similarity)
+				assert.Equal(t, want.CloneType, got.CloneType)
+			}
+		}
+		assert.Positive(t, pruned)
+	}
+}
@@ -2,6 +2,7 @@ package analyzer
 
 import (
 	"fmt"
+	"strings"
 
 	corecfg "github.com/ludo-technologies/polyscan/core/cfg"
 	"github.com/ludo-technologies/pyscn/internal/config"
@@ -33,6 +34,15 @@ type ComplexityResult struct {
 	LoopStatements    int
 	ExceptionHandlers int
 	SwitchCases       int
+	BooleanOperators  int // Sequences of like operators: a and b and c counts once
+	Comprehensions    int
+
+	// Async metrics, set for coroutines only
+	IsAsync         bool
+	AwaitPoints     int     // Suspension points: await, async for, async with
+	AsyncFanOut     int     // Awaitables run concurrently via gather/wait/create_task
+	AsyncComplexity int     // AwaitPoints + AsyncFanOut
+	AwaitDensity    float64 // Suspension points per line of the coroutine body
 
 	// Risk assessment based on complexity thresholds
 	RiskLevel string // "low", "medium", "high"
@@ -57,10 +67,16 @@ func (cr *ComplexityResult) GetDetailedMetrics() map[string]int {
 		"nodes":                cr.Nodes,
 		"edges":                cr.Edges,
 		"cognitive_complexity": cr.CognitiveComplexity,
+		"nesting_depth":        cr.NestingDepth,
 		"if_statements":        cr.IfStatements,
 		"loop_statements":      cr.LoopStatements,
 		"exception_handlers":   cr.ExceptionHandlers,
 		"switch_cases":         cr.SwitchCases,
+		"boolean_operators":    cr.BooleanOperators,
+		"comprehensions":       cr.Comprehensions,
+		"await_points":         cr.AwaitPoints,
+		"async_fan_out":        cr.AsyncFanOut,
+		"async_complexity":     cr.AsyncComplexity,
 	}
 }
 
@@ -93,6 +109,9 @@ func CalculateComplexityWithConfig(cfg *CFG, complexityConfig *config.Complexity
 	astMetrics, hasASTMetrics := calculateASTComplexityMetrics(complexitySourceNode(cfg))
 	reportedMetrics := resolveCoreComplexityMetrics(coreResult, conditionalDecisions, astMetrics, hasASTMetrics)
 	decisionPoints := countCoreDecisionPoints(conditionalDecisions, reportedMetrics, hasASTMetrics)
+	if hasASTMetrics {
+		decisionPoints += weightedExpressionDecisions(reachableBlocks, astMetrics, complexityConfig)
+	}
 	complexity := decisionPoints + 1
 
 	// Ensure minimum complexity of 1 for any function
@@ -106,8 +125,10 @@ func CalculateComplexityWithConfig(cfg *CFG, complexityConfig *config.Complexity
 	startLine := 0
 	startCol := 0
 	endLine := 0
+	asyncMetrics := &AsyncComplexityResult{}
 	if sourceNode := complexitySourceNode(cfg); sourceNode != nil {
 		nestingDepth = CalculateMaxNestingDepth(sourceNode).MaxDepth
+		asyncMetrics = CalculateAsyncComplexity(sourceNode)
 
 		startLine = sourceNode.Location.StartLine
 		startCol = sourceNode.Location.StartCol
@@ -129,6 +150,13 @@ func CalculateComplexityWithConfig(cfg *CFG, complexityConfig *config.Complexity
 		LoopStatements:      reportedMetrics.LoopStatements,
 		ExceptionHandlers:   reportedMetrics.ExceptionHandlers,
 		SwitchCases:         reportedMetrics.SwitchCases,
+		BooleanOperators:    reportedMetrics.BooleanOperators,
+		Comprehensions:      reportedMetrics.Comprehensions,
+		IsAsync:             asyncMetrics.IsCoroutine,
+		AwaitPoints:         asyncMetrics.SuspensionPoints(),
+		AsyncFanOut:         asyncMetrics.FanOut,
+		AsyncComplexity:     asyncMetrics.AsyncComplexity,
+		AwaitDensity:        asyncMetrics.AwaitDensity,
 		RiskLevel:           complexityConfig.AssessRiskLevel(complexity, reportedMetrics.CognitiveComplexity, nestingDepth),
 	}
 
@@ -213,6 +241,83 @@ type astComplexityMetrics struct {
 	ExceptionHandlers   int
 	MatchStatements     int
 	SwitchCases         int
+	BooleanOperators    int
+	Comprehensions      int
+
+	// Clauses (each for and each if filter) of the list, set and dict
+	// comprehensions and of the generator expressions
+	ComprehensionClauses int
+	GeneratorClauses     int
+}
+
+// weightedExpressionDecisions applies the configured comprehension,
+// generator and boolean operator weights. It returns the change to the
+// decision points counted from the control flow graph, which models only
+// the comprehensions standing as a statement, assignment or return value.
+// A weighted kind is counted wherever it appears instead, and the weighted
+// total is rounded down.
+func weightedExpressionDecisions(blocks map[string]*BasicBlock, metrics astComplexityMetrics, complexityConfig *config.ComplexityConfig) int {
+	if complexityConfig == nil {
+		return 0
+	}
+	comprehensionWeight := complexityConfig.ComprehensionWeight
+	generatorWeight := complexityConfig.GeneratorWeight
+	booleanOperatorWeight := complexityConfig.BooleanOperatorWeight
+	if comprehensionWeight == nil && generatorWeight == nil && booleanOperatorWeight == nil {
+		return 0
+	}
+
+	graphComprehensions, graphGenerators := countGraphComprehensionClauses(blocks)
+	adjustment := 0
+	weighted := 0.0
+	if comprehensionWeight != nil {
+		adjustment -= graphComprehensions
+		weighted += *comprehensionWeight * float64(metrics.ComprehensionClauses)
+	}
+	if generatorWeight != nil {
+		adjustment -= graphGenerators
+		weighted += *generatorWeight * float64(metrics.GeneratorClauses)
+	}
+	if booleanOperatorWeight != nil {
+		weighted += *booleanOperatorWeight * float64(metrics.BooleanOperators)
+	}
+	return adjustment + int(weighted)
+}
+
+// countGraphComprehensionClauses returns the decision points the control
+// flow graph holds for reachable comprehensions and generator expressions
+func countGraphComprehensionClauses(blocks map[string]*BasicBlock) (comprehensions, generators int) {
+	for _, block := range blocks {
+		if !strings.HasPrefix(block.Label, "comp_init_") || len(block.Statements) == 0 {
+			continue
+		}
+		node, ok := pythonNode(block.Statements[0])
+		if !ok {
+			continue
+		}
+		if node.Type == parser.NodeGeneratorExp {
+			generators += comprehensionClauses(node)
+		} else {
+			comprehensions += comprehensionClauses(node)
+		}
+	}
+	return comprehensions, generators
+}
+
+// comprehensionClauses counts the for clauses of a comprehension and the
+// ones with an if filter, one decision point each
+func comprehensionClauses(node *parser.Node) int {
+	clauses := 0
+	for _, child := range node.Children {
+		if child == nil || child.Type != parser.NodeComprehension {
+			continue
+		}
+		clauses++
+		if child.Test != nil {
+			clauses++
+		}
+	}
+	return clauses
 }
 
 func complexitySourceNode(cfg *CFG) *parser.Node {
@@ -255,6 +360,15 @@ func collectASTStatementMetrics(node *parser.Node, metrics *astComplexityMetrics
 		metrics.ExceptionHandlers++
 	case parser.NodeMatchCase:
 		metrics.SwitchCases++
+	case parser.NodeListComp, parser.NodeSetComp, parser.NodeDictComp:
+		metrics.Comprehensions++
+		metrics.ComprehensionClauses += comprehensionClauses(node)
+	case parser.NodeGeneratorExp:
+		metrics.Comprehensions++
+		metrics.GeneratorClauses += comprehensionClauses(node)
+	case parser.NodeBoolOp:
+		collectBoolOpMetrics(node, "", metrics)
+		return
 	}
 
 	for _, child := range node.GetChildren() {
@@ -262,6 +376,22 @@ func collectASTStatementMetrics(node *parser.Node, metrics *astComplexityMetrics
 	}
 }
 
+// collectBoolOpMetrics counts boolean operator sequences the way cognitive
+// complexity does: nested operands with the parent's operator continue its
+// sequence, a different operator starts a new one
+func collectBoolOpMetrics(node *parser.Node, parentOp string, metrics *astComplexityMetrics) {
+	if node.Op != parentOp {
+		metrics.BooleanOperators++
+	}
+	for _, child := range node.Children {
+		if child != nil && child.Type == parser.NodeBoolOp {
+			collectBoolOpMetrics(child, node.Op, metrics)
+		} else {
+			collectASTStatementMetrics(child, metrics)
+		}
+	}
+}
+
 // CalculateFileComplexity calculates complexity for all functions in a collection of CFGs
 func CalculateFileComplexity(cfgs []*CFG) []*ComplexityResult {
 	defaultConfig := config.DefaultConfig()
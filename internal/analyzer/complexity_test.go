@@ -391,6 +391,32 @@ func TestCalculateComplexity_ExceptionHandlersUseClauses(t *testing.T) {
 	}
 }
 
+func TestCalculateComplexity_CountsBooleanOperatorsAndComprehensions(t *testing.T) {
+	source := `def select(items, a, b, c):
+    if a and b and c:
+        return [x for x in items if x]
+    if a or b and c:
+        return {x: x for x in items}
+    return sum(x for x in items if x > 0)
+`
+
+	res := calculateFunctionComplexityForSource(t, source, "select")
+
+	// "a and b and c" is one sequence; "a or b and c" mixes operators: two
+	if res.BooleanOperators != 3 {
+		t.Fatalf("BooleanOperators = %d, want 3", res.BooleanOperators)
+	}
+	if res.Comprehensions != 3 {
+		t.Fatalf("Comprehensions = %d, want 3", res.Comprehensions)
+	}
+	if res.IfStatements != 2 {
+		t.Fatalf("IfStatements = %d, want 2", res.IfStatements)
+	}
+	if res.NestingDepth != 1 {
+		t.Fatalf("NestingDepth = %d, want 1", res.NestingDepth)
+	}
+}
+
 func TestCalculateComplexity_ComputesCognitiveComplexityInAnalyzer(t *testing.T) {
 	source := `def branch(value):
     if value:
@@ -451,8 +477,54 @@ func TestCalculateComplexity_MatchCasesReplaceSingleCFGDecision(t *testing.T) {
 	}
 }
 
+func TestCalculateComplexity_ExpressionWeights(t *testing.T) {
+	source := `def select(items, a, b, c):
+    if a and b or c:
+        return [x for x in items if x]
+    return sum(x for x in items if x > 0)
+`
+	weight := func(w float64) *float64 { return &w }
+
+	tests := []struct {
+		name       string
+		configure  func(*config.ComplexityConfig)
+		complexity int
+	}{
+		// The if, plus the returned comprehension's for and if clauses
+		{name: "unset counts the control flow graph", configure: func(*config.ComplexityConfig) {}, complexity: 4},
+		{name: "comprehensions ignored", configure: func(c *config.ComplexityConfig) { c.ComprehensionWeight = weight(0) }, complexity: 2},
+		{name: "generator arguments counted", configure: func(c *config.ComplexityConfig) { c.GeneratorWeight = weight(1) }, complexity: 6},
+		{name: "boolean operator sequences counted", configure: func(c *config.ComplexityConfig) { c.BooleanOperatorWeight = weight(1) }, complexity: 6},
+		{
+			name: "half weights rounded down",
+			configure: func(c *config.ComplexityConfig) {
+				c.ComprehensionWeight = weight(0.5)
+				c.GeneratorWeight = weight(0.5)
+				c.BooleanOperatorWeight = weight(0.5)
+			},
+			complexity: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			complexityConfig := config.DefaultConfig().Complexity
+			tt.configure(&complexityConfig)
+			res := calculateFunctionComplexityForSourceWithConfig(t, source, "select", &complexityConfig)
+			if res.Complexity != tt.complexity {
+				t.Errorf("Complexity = %d, want %d", res.Complexity, tt.complexity)
+			}
+		})
+	}
+}
+
 func calculateFunctionComplexityForSource(t *testing.T, source, functionName string) *ComplexityResult {
 	t.Helper()
+	return calculateFunctionComplexityForSourceWithConfig(t, source, functionName, &config.DefaultConfig().Complexity)
+}
+
+func calculateFunctionComplexityForSourceWithConfig(t *testing.T, source, functionName string, complexityConfig *config.ComplexityConfig) *ComplexityResult {
+	t.Helper()
 
 	p := parser.New()
 	result, err := p.Parse(context.Background(), []byte(source))
@@ -470,7 +542,7 @@ func calculateFunctionComplexityForSource(t *testing.T, source, functionName str
 		t.Fatalf("Expected CFG for %q", functionName)
 	}
 
-	return CalculateComplexity(cfg)
+	return CalculateComplexityWithConfig(cfg, complexityConfig)
 }
 
 func TestAssessRiskLevel(t *testing.T) {
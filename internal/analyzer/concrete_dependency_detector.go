@@ -65,18 +65,12 @@ func (d *ConcreteDependencyDetector) detectConcreteTypeHints(ast *parser.Node, f
 			typeName := d.extractTypeHintName(arg)
 			if typeName != "" && d.isConcreteType(typeName) {
 				finding := domain.DIAntipatternFinding{
-					Type:       domain.DIAntipatternConcreteDependency,
-					Subtype:    string(domain.ConcreteDepTypeHint),
-					Severity:   domain.DIAntipatternSeverityInfo,
-					ClassName:  class.Name,
-					MethodName: "__init__",
-					Location: domain.SourceLocation{
-						FilePath:  filePath,
-						StartLine: arg.Location.StartLine,
-						EndLine:   arg.Location.EndLine,
-						StartCol:  arg.Location.StartCol,
-						EndCol:    arg.Location.EndCol,
-					},
+					Type:        domain.DIAntipatternConcreteDependency,
+					Subtype:     string(domain.ConcreteDepTypeHint),
+					Severity:    domain.DIAntipatternSeverityInfo,
+					ClassName:   class.Name,
+					MethodName:  "__init__",
+					Location:    sourceLocation(filePath, arg.Location),
 					Description: fmt.Sprintf("Parameter '%s' has concrete type hint '%s'", arg.Name, typeName),
 					Suggestion:  "Consider using an abstract type (Protocol, ABC, or interface) instead of a concrete class",
 					Details: map[string]interface{}{
@@ -109,18 +103,12 @@ func (d *ConcreteDependencyDetector) detectDirectInstantiation(ast *parser.Node,
 
 		for _, inst := range instantiations {
 			finding := domain.DIAntipatternFinding{
-				Type:       domain.DIAntipatternConcreteDependency,
-				Subtype:    string(domain.ConcreteDepInstantiation),
-				Severity:   domain.DIAntipatternSeverityWarning,
-				ClassName:  class.Name,
-				MethodName: "__init__",
-				Location: domain.SourceLocation{
-					FilePath:  filePath,
-					StartLine: inst.location.StartLine,
-					EndLine:   inst.location.EndLine,
-					StartCol:  inst.location.StartCol,
-					EndCol:    inst.location.EndCol,
-				},
+				Type:        domain.DIAntipatternConcreteDependency,
+				Subtype:     string(domain.ConcreteDepInstantiation),
+				Severity:    domain.DIAntipatternSeverityWarning,
+				ClassName:   class.Name,
+				MethodName:  "__init__",
+				Location:    sourceLocation(filePath, inst.location),
 				Description: fmt.Sprintf("Directly instantiates concrete class '%s' in constructor", inst.className),
 				Suggestion:  "Inject the dependency as a parameter instead of creating it in the constructor",
 				Details: map[string]interface{}{
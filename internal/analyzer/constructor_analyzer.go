@@ -51,17 +51,11 @@ func (a *ConstructorAnalyzer) analyzeClass(classNode *parser.Node, filePath stri
 	paramCount := a.countParameters(initMethod)
 	if paramCount > a.threshold {
 		finding := domain.DIAntipatternFinding{
-			Type:       domain.DIAntipatternConstructorOverInjection,
-			Severity:   domain.DIAntipatternSeverityWarning,
-			ClassName:  classNode.Name,
-			MethodName: "__init__",
-			Location: domain.SourceLocation{
-				FilePath:  filePath,
-				StartLine: initMethod.Location.StartLine,
-				EndLine:   initMethod.Location.EndLine,
-				StartCol:  initMethod.Location.StartCol,
-				EndCol:    initMethod.Location.EndCol,
-			},
+			Type:        domain.DIAntipatternConstructorOverInjection,
+			Severity:    domain.DIAntipatternSeverityWarning,
+			ClassName:   classNode.Name,
+			MethodName:  "__init__",
+			Location:    sourceLocation(filePath, initMethod.Location),
 			Description: fmt.Sprintf("Constructor has %d parameters (threshold: %d)", paramCount, a.threshold),
 			Suggestion:  "Consider using a builder pattern, parameter object, or splitting responsibilities",
 			Details: map[string]interface{}{
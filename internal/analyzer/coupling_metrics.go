@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"sort"
@@ -63,6 +64,12 @@ func NewCouplingMetricsCalculator(graph *DependencyGraph, options *CouplingMetri
 
 // CalculateMetrics calculates all metrics for the dependency graph
 func (calc *CouplingMetricsCalculator) CalculateMetrics() error {
+	return calc.CalculateMetricsWithContext(context.Background())
+}
+
+// CalculateMetricsWithContext is CalculateMetrics with cancellation checked
+// between modules and between the system-level metrics
+func (calc *CouplingMetricsCalculator) CalculateMetricsWithContext(ctx context.Context) error {
 	config := coregraph.CouplingConfig{}
 	if calc.includeAbstractness {
 		config.AbstractnessFunc = func(moduleName string) (float64, error) {
@@ -80,6 +87,9 @@ func (calc *CouplingMetricsCalculator) CalculateMetrics() error {
 	}
 
 	for moduleName, coupling := range coreMetrics {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		node := calc.graph.Nodes[moduleName]
 		if node == nil {
 			return fmt.Errorf("calculate module metrics: module %q not found", moduleName)
@@ -103,9 +113,7 @@ func (calc *CouplingMetricsCalculator) CalculateMetrics() error {
 	}
 
 	// Calculate system-level metrics
-	calc.calculateSystemMetrics()
-
-	return nil
+	return calc.calculateSystemMetrics(ctx)
 }
 
 // calculateAbstractness calculates the abstractness of a module
@@ -118,7 +126,7 @@ func (calc *CouplingMetricsCalculator) calculateAbstractness(node *ModuleNode) f
 }
 
 // calculateSystemMetrics calculates system-wide metrics
-func (calc *CouplingMetricsCalculator) calculateSystemMetrics() {
+func (calc *CouplingMetricsCalculator) calculateSystemMetrics(ctx context.Context) error {
 	systemMetrics := calc.graph.SystemMetrics
 
 	// Basic counts
@@ -127,7 +135,7 @@ func (calc *CouplingMetricsCalculator) calculateSystemMetrics() {
 	systemMetrics.PackageCount = len(calc.graph.GetPackages())
 
 	if systemMetrics.TotalModules == 0 {
-		return
+		return nil
 	}
 
 	// Aggregate metrics
@@ -157,12 +165,18 @@ func (calc *CouplingMetricsCalculator) calculateSystemMetrics() {
 
 	// Count cyclic dependencies
 	systemMetrics.CyclicDependencies = len(calc.graph.GetModulesInCycles())
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
 	// Calculate system complexity
 	systemMetrics.SystemComplexity = calc.calculateSystemComplexity()
 
 	// Calculate max dependency depth
 	systemMetrics.MaxDependencyDepth = calc.calculateMaxDependencyDepth()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
 	// Identify refactoring priorities
 	systemMetrics.RefactoringPriority = calc.identifyRefactoringPriorities()
@@ -171,6 +185,7 @@ func (calc *CouplingMetricsCalculator) calculateSystemMetrics() {
 	systemMetrics.ZoneOfPain = calc.modulesMatching(isZoneOfPain)
 	systemMetrics.ZoneOfUselessness = calc.modulesMatching(isZoneOfUselessness)
 	systemMetrics.MainSequence = calc.modulesMatching(isOnMainSequence)
+	return nil
 }
 
 func isStableModule(metrics *ModuleMetrics) bool {
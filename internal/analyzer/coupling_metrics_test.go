@@ -0,0 +1,42 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCouplingMetricsCalculator_CalculateMetrics(t *testing.T) {
+	graph := NewDependencyGraph("/proj")
+	graph.AddModule("app.core", "/proj/app/core.py")
+	graph.AddModule("app.api", "/proj/app/api.py")
+	graph.AddDependency("app.api", "app.core", DependencyEdgeImport, nil)
+
+	calc := NewCouplingMetricsCalculator(graph, DefaultCouplingMetricsOptions())
+	if err := calc.CalculateMetrics(); err != nil {
+		t.Fatalf("CalculateMetrics failed: %v", err)
+	}
+
+	core := graph.ModuleMetrics["app.core"]
+	if core == nil || core.AfferentCoupling != 1 || core.EfferentCoupling != 0 {
+		t.Errorf("expected app.core to have Ca=1, Ce=0, got %+v", core)
+	}
+	if graph.SystemMetrics.TotalModules != 2 {
+		t.Errorf("expected 2 modules in system metrics, got %d", graph.SystemMetrics.TotalModules)
+	}
+}
+
+func TestCouplingMetricsCalculator_StopsWhenCancelled(t *testing.T) {
+	graph := NewDependencyGraph("/proj")
+	graph.AddModule("app.core", "/proj/app/core.py")
+	graph.AddModule("app.api", "/proj/app/api.py")
+	graph.AddDependency("app.api", "app.core", DependencyEdgeImport, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calc := NewCouplingMetricsCalculator(graph, DefaultCouplingMetricsOptions())
+	if err := calc.CalculateMetricsWithContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -39,13 +40,69 @@ const (
 	// ReasonUnreachableAfterRaise indicates code after a raise statement
 	ReasonUnreachableAfterRaise DeadCodeReason = "unreachable_after_raise"
 
+	// ReasonUnreachableAfterExit indicates code after a call that never
+	// returns, e.g. sys.exit()
+	ReasonUnreachableAfterExit DeadCodeReason = "unreachable_after_exit"
+
 	// ReasonUnreachableBranch indicates an unreachable branch condition
 	ReasonUnreachableBranch DeadCodeReason = "unreachable_branch"
 
 	// ReasonUnreachableAfterInfiniteLoop indicates code after an infinite loop
 	ReasonUnreachableAfterInfiniteLoop DeadCodeReason = "unreachable_after_infinite_loop"
+
+	// ReasonUnreachableMatchCase indicates a case clause shadowed by an earlier pattern
+	ReasonUnreachableMatchCase DeadCodeReason = "unreachable_match_case"
+
+	// ReasonNonExhaustiveMatch indicates a match over enum values without a wildcard case
+	ReasonNonExhaustiveMatch DeadCodeReason = "non_exhaustive_match"
+
+	// ReasonInconsistentReturn indicates a function mixing value returns with bare returns or an implicit None
+	ReasonInconsistentReturn DeadCodeReason = "inconsistent_return"
+
+	// ReasonMissingReturn indicates a fall-through path in a function whose return type excludes None
+	ReasonMissingReturn DeadCodeReason = "missing_return"
+
+	// ReasonUselessLoopElse indicates a loop else clause that always runs because the loop never breaks
+	ReasonUselessLoopElse DeadCodeReason = "useless_loop_else"
+
+	// ReasonLoopAlwaysBreaks indicates a loop with an else clause whose body breaks on every path
+	ReasonLoopAlwaysBreaks DeadCodeReason = "loop_always_breaks"
+
+	// ReasonLoopConditionUnmodified indicates a while condition whose names the loop body never modifies
+	ReasonLoopConditionUnmodified DeadCodeReason = "loop_condition_unmodified"
+
+	// ReasonUnusedAttribute indicates an instance attribute that is assigned but never read
+	ReasonUnusedAttribute DeadCodeReason = "unused_attribute"
 )
 
+// reasonSeverities are the severities the detector reports each reason with
+var reasonSeverities = map[DeadCodeReason]SeverityLevel{
+	ReasonUnreachableAfterReturn:       SeverityLevelCritical,
+	ReasonUnreachableAfterBreak:        SeverityLevelCritical,
+	ReasonUnreachableAfterContinue:     SeverityLevelCritical,
+	ReasonUnreachableAfterRaise:        SeverityLevelCritical,
+	ReasonUnreachableAfterExit:         SeverityLevelCritical,
+	ReasonUnreachableAfterInfiniteLoop: SeverityLevelCritical,
+	ReasonUnreachableBranch:            SeverityLevelWarning,
+	ReasonUnreachableMatchCase:         SeverityLevelCritical,
+	ReasonNonExhaustiveMatch:           SeverityLevelInfo,
+	ReasonInconsistentReturn:           SeverityLevelWarning,
+	ReasonMissingReturn:                SeverityLevelWarning,
+	ReasonUselessLoopElse:              SeverityLevelWarning,
+	ReasonLoopAlwaysBreaks:             SeverityLevelWarning,
+	ReasonLoopConditionUnmodified:      SeverityLevelWarning,
+	ReasonUnusedAttribute:              SeverityLevelWarning,
+}
+
+// DefaultSeverity returns the severity findings of a reason are reported
+// with before any configured mapping applies
+func DefaultSeverity(reason DeadCodeReason) SeverityLevel {
+	if severity, ok := reasonSeverities[reason]; ok {
+		return severity
+	}
+	return SeverityLevelWarning
+}
+
 // DeadCodeFinding represents a single dead code detection result
 type DeadCodeFinding struct {
 	// Function information
@@ -62,9 +119,6 @@ type DeadCodeFinding struct {
 	Reason      DeadCodeReason `json:"reason"`
 	Severity    SeverityLevel  `json:"severity"`
 	Description string         `json:"description"`
-
-	// Context information
-	Context []string `json:"context,omitempty"`
 }
 
 // DeadCodeResult contains the results of dead code analysis for a single CFG
@@ -146,6 +200,39 @@ func (dcd *DeadCodeDetector) Detect() *DeadCodeResult {
 		}
 	}
 
+	// Code after loops that never end, which the core analysis reaches
+	// through the false edge of their header
+	loops := AnalyzeLoops(dcd.cfg)
+	for _, block := range dcd.cfg.Blocks {
+		if block == nil || reportedBlocks[block.ID] || !reachResult.Reachable[block.ID] || loops.Reachable[block.ID] {
+			continue
+		}
+		if finding := dcd.analyzeLoopDeadBlock(block, loops); finding != nil {
+			result.Findings = append(result.Findings, finding)
+			reportedBlocks[block.ID] = true
+			result.DeadBlocks++
+		}
+	}
+
+	// Loop else clauses that always or almost never run, and conditions
+	// that never change
+	for _, loop := range loops.Loops {
+		result.Findings = append(result.Findings, dcd.analyzeLoop(loop)...)
+	}
+
+	// Informational findings for matches over enum values without a fallback
+	for _, block := range dcd.cfg.Blocks {
+		if block == nil || !reachResult.Reachable[block.ID] {
+			continue
+		}
+		if finding := dcd.analyzeMatchExhaustiveness(block); finding != nil {
+			result.Findings = append(result.Findings, finding)
+		}
+	}
+
+	// Return-path consistency findings
+	result.Findings = append(result.Findings, dcd.analyzeReturnPaths()...)
+
 	// Merge overlapping/contiguous findings that share a reason. A compound
 	// statement (e.g. `if`) spans its body, so the body's own block produces a
 	// finding whose line range is nested inside the `if` finding's range. Left
@@ -222,6 +309,14 @@ func (dcd *DeadCodeDetector) analyzeCoreDeadBlock(block *BasicBlock, coreReason
 	case "after_throw":
 		reason, severity = ReasonUnreachableAfterRaise, SeverityLevelCritical
 	}
+	if strings.HasPrefix(block.Label, LabelUnreachableAfterExit) {
+		reason, severity = ReasonUnreachableAfterExit, SeverityLevelCritical
+	}
+
+	// Case blocks are only disconnected when an earlier pattern shadows them
+	if first, ok := block.Statements[0].(*parser.Node); ok && first.Type == parser.NodeMatchCase {
+		reason, severity = ReasonUnreachableMatchCase, SeverityLevelCritical
+	}
 
 	// Create a finding for this dead block
 	finding := &DeadCodeFinding{
@@ -234,7 +329,6 @@ func (dcd *DeadCodeDetector) analyzeCoreDeadBlock(block *BasicBlock, coreReason
 		Reason:       reason,
 		Severity:     severity,
 		Description:  dcd.generateDescription(reason, block),
-		Context:      dcd.getBlockContext(block),
 	}
 
 	findings = append(findings, finding)
@@ -242,6 +336,157 @@ func (dcd *DeadCodeDetector) analyzeCoreDeadBlock(block *BasicBlock, coreReason
 	return findings
 }
 
+// analyzeLoopDeadBlock reports a block that is unreachable only because a
+// `while True` loop never ends: code after the loop, or its else clause
+func (dcd *DeadCodeDetector) analyzeLoopDeadBlock(block *BasicBlock, loops *LoopAnalysis) *DeadCodeFinding {
+	if len(block.Statements) == 0 || isOnlyNoOpStatements(block) {
+		return nil
+	}
+
+	line := dcd.getBlockStartLine(block)
+	reason := ReasonUnreachableAfterInfiniteLoop
+	description := DescribeReason(reason)
+	if loop := loops.AlwaysTrueLoopElse(line); loop != nil {
+		reason = ReasonUnreachableBranch
+		description = fmt.Sprintf("Else clause of the loop at line %d never runs: its condition is always true", loop.Loop.Location.StartLine)
+	} else if loop := loops.InfiniteLoopBefore(line); loop != nil {
+		description = fmt.Sprintf("Code appears after the infinite loop at line %d, which has no reachable break, and will never be executed", loop.Loop.Location.StartLine)
+	}
+
+	return &DeadCodeFinding{
+		FunctionName: dcd.getFunctionName(),
+		FilePath:     dcd.getFilePath(),
+		StartLine:    line,
+		EndLine:      dcd.getBlockEndLine(block),
+		BlockID:      block.ID,
+		Code:         dcd.getBlockCode(block),
+		Reason:       reason,
+		Severity:     reasonSeverities[reason],
+		Description:  description,
+	}
+}
+
+// analyzeLoop reports a loop whose else clause always runs, a loop with an
+// else clause that breaks on every path, and a while loop whose condition
+// never changes
+func (dcd *DeadCodeDetector) analyzeLoop(loop *LoopResult) []*DeadCodeFinding {
+	var findings []*DeadCodeFinding
+	keyword := "for"
+	if loop.Loop.Type == parser.NodeWhile {
+		keyword = "while"
+	}
+	newFinding := func(line int, code string, reason DeadCodeReason, description string) *DeadCodeFinding {
+		return &DeadCodeFinding{
+			FunctionName: dcd.getFunctionName(),
+			FilePath:     dcd.getFilePath(),
+			StartLine:    line,
+			EndLine:      line,
+			BlockID:      loop.Header.ID,
+			Code:         code,
+			Reason:       reason,
+			Severity:     SeverityLevelWarning,
+			Description:  description,
+		}
+	}
+
+	// The else clause of a `while True` loop is reported as unreachable
+	if loop.HasElse() && !loop.AlwaysTrue {
+		switch {
+		case !loop.HasBreak:
+			findings = append(findings, newFinding(loop.ElseLine(), "else", ReasonUselessLoopElse,
+				fmt.Sprintf("The loop at line %d never breaks, so its else clause always runs; move the code after the loop", loop.Loop.Location.StartLine)))
+		case !loop.Iterates:
+			findings = append(findings, newFinding(loop.Loop.Location.StartLine, keyword, ReasonLoopAlwaysBreaks,
+				"Loop body breaks on every path, so it runs at most once and its else clause only runs when it does not iterate"))
+		}
+	}
+
+	if len(loop.UnmodifiedCondition) > 0 {
+		findings = append(findings, newFinding(loop.Loop.Location.StartLine, keyword, ReasonLoopConditionUnmodified,
+			fmt.Sprintf("Loop body never modifies %s, so the condition does not change between iterations", strings.Join(loop.UnmodifiedCondition, ", "))))
+	}
+
+	return findings
+}
+
+// analyzeMatchExhaustiveness reports a match statement whose unguarded cases
+// are all values of one enum-like class and that has no wildcard or capture
+// case. Values added to the enum later would silently match nothing.
+func (dcd *DeadCodeDetector) analyzeMatchExhaustiveness(block *BasicBlock) *DeadCodeFinding {
+	if len(block.Statements) == 0 {
+		return nil
+	}
+	match, ok := block.Statements[0].(*parser.Node)
+	if !ok || match.Type != parser.NodeMatch {
+		return nil
+	}
+
+	infos := AnalyzeMatchCases(match)
+	if len(infos) == 0 || HasIrrefutableCase(infos) {
+		return nil
+	}
+	class := enumMatchClass(infos)
+	if class == "" {
+		return nil
+	}
+
+	return &DeadCodeFinding{
+		FunctionName: dcd.getFunctionName(),
+		FilePath:     dcd.getFilePath(),
+		StartLine:    match.Location.StartLine,
+		EndLine:      match.Location.StartLine,
+		BlockID:      block.ID,
+		Code:         "match",
+		Reason:       ReasonNonExhaustiveMatch,
+		Severity:     SeverityLevelInfo,
+		Description:  "Match over " + class + " values has no wildcard case; unmatched values fall through silently",
+	}
+}
+
+// analyzeReturnPaths reports functions that mix value returns with bare
+// returns or implicit None, and functions whose annotated return type
+// excludes None but that can fall off the end of the body
+func (dcd *DeadCodeDetector) analyzeReturnPaths() []*DeadCodeFinding {
+	paths := AnalyzeReturnPaths(dcd.cfg)
+	if paths == nil {
+		return nil
+	}
+
+	var findings []*DeadCodeFinding
+	newFinding := func(line int, code string, reason DeadCodeReason, description string) *DeadCodeFinding {
+		return &DeadCodeFinding{
+			FunctionName: dcd.getFunctionName(),
+			FilePath:     dcd.getFilePath(),
+			StartLine:    line,
+			EndLine:      line,
+			BlockID:      dcd.cfg.Exit.ID,
+			Code:         code,
+			Reason:       reason,
+			Severity:     SeverityLevelWarning,
+			Description:  description,
+		}
+	}
+
+	if paths.MissingReturn() {
+		findings = append(findings, newFinding(paths.FallThroughLine, "", ReasonMissingReturn,
+			"Function is annotated to return "+paths.ReturnAnnotation+" but this path falls off the end and returns None"))
+	}
+
+	if paths.IsInconsistent() {
+		for _, ret := range paths.BareReturns {
+			findings = append(findings, newFinding(ret.Location.StartLine, "return", ReasonInconsistentReturn,
+				"Bare return in a function that also returns values; use an explicit `return None`"))
+		}
+		// A missing-return finding already covers the fall-through path
+		if paths.FallsThrough && !paths.MissingReturn() {
+			findings = append(findings, newFinding(paths.FallThroughLine, "", ReasonInconsistentReturn,
+				"This path falls off the end and implicitly returns None, but other paths return values"))
+		}
+	}
+
+	return findings
+}
+
 // determineDeadCodeReason analyzes the block to determine why it's dead
 func (dcd *DeadCodeDetector) determineDeadCodeReason(block *BasicBlock) (DeadCodeReason, SeverityLevel) {
 	// Check direct predecessors for control flow patterns
@@ -494,15 +739,18 @@ func (dcd *DeadCodeDetector) getNodeDescription(node *parser.Node) string {
 	}
 }
 
-// getBlockContext provides context around the dead code
-func (dcd *DeadCodeDetector) getBlockContext(block *BasicBlock) []string {
-	// For now, return empty context
-	// This can be enhanced to show surrounding code
-	return []string{}
-}
-
 // generateDescription creates a human-readable description of the dead code
 func (dcd *DeadCodeDetector) generateDescription(reason DeadCodeReason, block *BasicBlock) string {
+	if reason == ReasonUnreachableMatchCase {
+		if first, ok := block.Statements[0].(*parser.Node); ok && isIrrefutablePattern(first.Test) {
+			return "Catch-all case follows an earlier catch-all case and will never be selected"
+		}
+	}
+	return DescribeReason(reason)
+}
+
+// DescribeReason returns a human-readable description of a dead code reason
+func DescribeReason(reason DeadCodeReason) string {
 	switch reason {
 	case ReasonUnreachableAfterReturn:
 		return "Code appears after a return statement and will never be executed"
@@ -512,10 +760,28 @@ func (dcd *DeadCodeDetector) generateDescription(reason DeadCodeReason, block *B
 		return "Code appears after a continue statement and will never be executed"
 	case ReasonUnreachableAfterRaise:
 		return "Code appears after a raise statement and will never be executed"
+	case ReasonUnreachableAfterExit:
+		return "Code appears after a call that never returns, such as sys.exit(), and will never be executed"
 	case ReasonUnreachableBranch:
 		return "Code in this branch is unreachable under normal execution flow"
 	case ReasonUnreachableAfterInfiniteLoop:
 		return "Code appears after an infinite loop and will never be executed"
+	case ReasonUnreachableMatchCase:
+		return "Case pattern is already matched by an earlier case and will never be selected"
+	case ReasonNonExhaustiveMatch:
+		return "Match statement has no wildcard case"
+	case ReasonInconsistentReturn:
+		return "Function mixes value returns with bare returns or an implicit None"
+	case ReasonMissingReturn:
+		return "Function can fall off the end although its return type excludes None"
+	case ReasonUselessLoopElse:
+		return "Loop never breaks, so its else clause always runs"
+	case ReasonLoopAlwaysBreaks:
+		return "Loop body breaks on every path, so the loop runs at most once"
+	case ReasonLoopConditionUnmodified:
+		return "Loop body never modifies the names in the loop condition"
+	case ReasonUnusedAttribute:
+		return "Instance attribute is assigned but never read"
 	default:
 		return "Code is unreachable and will never be executed"
 	}
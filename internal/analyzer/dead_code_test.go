@@ -825,3 +825,96 @@ func TestIsOnlyNoOpStatements(t *testing.T) {
 	assert.False(t, isOnlyNoOpStatements(&BasicBlock{Statements: []any{semi, ret}}),
 		"block mixing separators and a real statement")
 }
+
+func TestDeadCodeMatchStatements(t *testing.T) {
+	code := `
+def shadowed(command):
+    match command:
+        case "start":
+            return 1
+        case "stop" | "start":
+            return 2
+        case "stop":
+            return 3
+        case other:
+            return 4
+        case _:
+            return 5
+
+
+def non_exhaustive(color):
+    match color:
+        case Color.RED:
+            return "red"
+        case Color.GREEN | Color.BLUE:
+            return "cool"
+    return None
+
+
+def exhaustive(color):
+    match color:
+        case Color.RED:
+            return "red"
+        case _:
+            return "other"
+`
+
+	p := parser.New()
+	parseResult, err := p.Parse(context.Background(), []byte(code))
+	require.NoError(t, err)
+
+	cfgs, err := NewCFGBuilder().BuildAll(parseResult.AST)
+	require.NoError(t, err)
+
+	result := DetectInFunction(cfgs["shadowed"])
+	var caseLines []int
+	for _, finding := range result.Findings {
+		if finding.Reason == ReasonUnreachableMatchCase {
+			assert.Equal(t, SeverityLevelCritical, finding.Severity)
+			caseLines = append(caseLines, finding.StartLine)
+		}
+	}
+	// `case "stop" | "start"` is still reachable through "stop"
+	assert.Equal(t, []int{8, 12}, caseLines)
+
+	result = DetectInFunction(cfgs["non_exhaustive"])
+	require.Len(t, result.Findings, 1)
+	assert.Equal(t, ReasonNonExhaustiveMatch, result.Findings[0].Reason)
+	assert.Equal(t, SeverityLevelInfo, result.Findings[0].Severity)
+	assert.Equal(t, 17, result.Findings[0].StartLine)
+	assert.Contains(t, result.Findings[0].Description, "Color")
+	assert.Equal(t, 0, result.DeadBlocks)
+
+	result = DetectInFunction(cfgs["exhaustive"])
+	assert.Empty(t, result.Findings)
+}
+
+func TestDeadCodeReturnPathFindings(t *testing.T) {
+	code := `
+def parse(value) -> int:
+    if value.isdigit():
+        return int(value)
+    if not value:
+        return
+    print("unparsed")
+`
+
+	p := parser.New()
+	parseResult, err := p.Parse(context.Background(), []byte(code))
+	require.NoError(t, err)
+
+	cfgs, err := NewCFGBuilder().BuildAll(parseResult.AST)
+	require.NoError(t, err)
+
+	result := DetectInFunction(cfgs["parse"])
+	reasons := make(map[DeadCodeReason][]int)
+	for _, finding := range result.Findings {
+		assert.Equal(t, SeverityLevelWarning, finding.Severity)
+		reasons[finding.Reason] = append(reasons[finding.Reason], finding.StartLine)
+	}
+
+	assert.Equal(t, []int{7}, reasons[ReasonMissingReturn])
+	// The fall-through is already reported as a missing return
+	assert.Equal(t, []int{6}, reasons[ReasonInconsistentReturn])
+	assert.Equal(t, 0, result.DeadBlocks)
+}
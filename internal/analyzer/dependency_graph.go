@@ -2,7 +2,9 @@ package analyzer
 
 import (
 	"fmt"
+	"maps"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
@@ -34,6 +36,14 @@ type ModuleNode struct {
 	// entry is removed. See issue #460.
 	LazyDependencies map[string]bool
 
+	// TypeCheckingDependencies, ConditionalDependencies and DynamicDependencies
+	// follow the same rule for imports inside `if TYPE_CHECKING:` blocks,
+	// try/except import fallbacks and importlib-style dynamic imports: a target
+	// stays in the set only while every import of it has that kind.
+	TypeCheckingDependencies map[string]bool
+	ConditionalDependencies  map[string]bool
+	DynamicDependencies      map[string]bool
+
 	// Metrics
 	InDegree  int // Number of incoming dependencies
 	OutDegree int // Number of outgoing dependencies
@@ -53,6 +63,146 @@ type DependencyEdge struct {
 	EdgeType   DependencyEdgeType // Type of dependency
 	ImportInfo *ImportInfo        // Details about the import
 	IsLazy     bool               // True if every import forming this edge is lazy (function/method-body)
+
+	IsTypeChecking bool // True if every import forming this edge is inside a TYPE_CHECKING block
+	IsConditional  bool // True if every import forming this edge is a try/except import fallback
+	IsDynamic      bool // True if every import forming this edge is a dynamic import with a literal name
+
+	Symbols []string // Distinct names imported over this edge by every import forming it, sorted
+}
+
+// Weight is the number of names imported over the edge, at least 1: a plain
+// `import pkg.mod` counts as one name.
+func (e *DependencyEdge) Weight() int {
+	return max(1, len(e.Symbols))
+}
+
+// addSymbols records names imported over the edge, keeping Symbols sorted
+// and free of duplicates
+func (e *DependencyEdge) addSymbols(names []string) {
+	for _, name := range names {
+		if i, found := slices.BinarySearch(e.Symbols, name); !found {
+			e.Symbols = slices.Insert(e.Symbols, i, name)
+		}
+	}
+}
+
+// DependencyEdgeKind classifies how an import edge is executed
+type DependencyEdgeKind string
+
+const (
+	EdgeKindImport       DependencyEdgeKind = "import"        // Unconditional module-level import
+	EdgeKindTypeChecking DependencyEdgeKind = "type_checking" // Import inside `if TYPE_CHECKING:`
+	EdgeKindLazy         DependencyEdgeKind = "lazy"          // Import inside a function or method body
+	EdgeKindConditional  DependencyEdgeKind = "conditional"   // Import inside a try/except fallback
+	EdgeKindDynamic      DependencyEdgeKind = "dynamic"       // importlib.import_module, __import__ or entry-point string
+)
+
+// ParseDependencyEdgeKind converts a configuration value to an edge kind
+func ParseDependencyEdgeKind(value string) (DependencyEdgeKind, error) {
+	switch kind := DependencyEdgeKind(strings.ToLower(strings.TrimSpace(value))); kind {
+	case EdgeKindImport, EdgeKindTypeChecking, EdgeKindLazy, EdgeKindConditional, EdgeKindDynamic:
+		return kind, nil
+	}
+	return "", fmt.Errorf("unknown dependency edge kind %q (expected import, type_checking, lazy, conditional or dynamic)", value)
+}
+
+// Kind returns the edge kind used for reporting. An edge that is both lazy and
+// conditional reports the kind that is furthest from a load-time import.
+func (e *DependencyEdge) Kind() DependencyEdgeKind {
+	switch {
+	case e.IsTypeChecking:
+		return EdgeKindTypeChecking
+	case e.IsLazy:
+		return EdgeKindLazy
+	case e.IsConditional:
+		return EdgeKindConditional
+	case e.IsDynamic:
+		return EdgeKindDynamic
+	}
+	return EdgeKindImport
+}
+
+// EdgeKindSet is a set of dependency edge kinds, used to select the edges an
+// analysis ignores
+type EdgeKindSet map[DependencyEdgeKind]bool
+
+// NewEdgeKindSet creates a set containing kinds
+func NewEdgeKindSet(kinds ...DependencyEdgeKind) EdgeKindSet {
+	set := make(EdgeKindSet, len(kinds))
+	for _, kind := range kinds {
+		set[kind] = true
+	}
+	return set
+}
+
+// ParseEdgeKindSet converts configuration values to an edge kind set
+func ParseEdgeKindSet(values []string) (EdgeKindSet, error) {
+	set := make(EdgeKindSet, len(values))
+	for _, value := range values {
+		kind, err := ParseDependencyEdgeKind(value)
+		if err != nil {
+			return nil, err
+		}
+		set[kind] = true
+	}
+	return set, nil
+}
+
+// DefaultCycleIgnoredEdgeKinds returns the edge kinds that cannot form a
+// load-time import cycle
+func DefaultCycleIgnoredEdgeKinds() EdgeKindSet {
+	return NewEdgeKindSet(EdgeKindLazy, EdgeKindTypeChecking)
+}
+
+// MatchesEdge reports whether edge has any kind in the set
+func (s EdgeKindSet) MatchesEdge(edge *DependencyEdge) bool {
+	if edge == nil || len(s) == 0 {
+		return false
+	}
+	return (s[EdgeKindTypeChecking] && edge.IsTypeChecking) ||
+		(s[EdgeKindLazy] && edge.IsLazy) ||
+		(s[EdgeKindConditional] && edge.IsConditional) ||
+		(s[EdgeKindDynamic] && edge.IsDynamic) ||
+		(s[EdgeKindImport] && edge.Kind() == EdgeKindImport)
+}
+
+// MatchesDependency reports whether the dependency of node on target has any
+// kind in the set
+func (s EdgeKindSet) MatchesDependency(node *ModuleNode, target string) bool {
+	if node == nil || len(s) == 0 {
+		return false
+	}
+	lazy := node.LazyDependencies[target]
+	typeChecking := node.TypeCheckingDependencies[target]
+	conditional := node.ConditionalDependencies[target]
+	dynamic := node.DynamicDependencies[target]
+	return (s[EdgeKindTypeChecking] && typeChecking) ||
+		(s[EdgeKindLazy] && lazy) ||
+		(s[EdgeKindConditional] && conditional) ||
+		(s[EdgeKindDynamic] && dynamic) ||
+		(s[EdgeKindImport] && !lazy && !typeChecking && !conditional && !dynamic)
+}
+
+// ExternalImport records an absolute import that does not resolve to a module
+// of the analyzed project, such as a standard library or third-party package
+type ExternalImport struct {
+	Module         string // Imported module name as written
+	TopLevel       string // Top-level package name
+	FromModule     string // Importing project module
+	FilePath       string // File containing the import
+	Line           int    // Line number where import occurs
+	IsTypeChecking bool   // True if import is inside a TYPE_CHECKING block
+	IsLazy         bool   // True if import is inside a function/method body
+}
+
+// DynamicImport records a dynamic import call such as
+// importlib.import_module(name) whose target cannot be determined statically
+type DynamicImport struct {
+	FromModule string // Module containing the call
+	FilePath   string // File containing the call
+	Line       int    // Line number of the call
+	Function   string // Called function as written, e.g. "importlib.import_module"
 }
 
 // DependencyEdgeType represents the type of dependency relationship
@@ -75,6 +225,8 @@ type ImportInfo struct {
 	Line           int      // Line number where import occurs
 	IsTypeChecking bool     // True if import is inside a TYPE_CHECKING block
 	IsLazy         bool     // True if import is inside a function/method body (not executed at module load)
+	IsConditional  bool     // True if import is inside a try/except import fallback
+	IsDynamic      bool     // True if import is an importlib.import_module, __import__ or entry-point call
 }
 
 // DependencyGraph represents the complete module dependency graph
@@ -94,6 +246,17 @@ type DependencyGraph struct {
 	CyclicGroups  [][]string                // Strongly connected components (cycles)
 	ModuleMetrics map[string]*ModuleMetrics // Module-level metrics
 	SystemMetrics *SystemMetrics            // System-wide metrics
+
+	// Imports of modules outside the project, in file order
+	ExternalImports []*ExternalImport
+
+	// Dynamic import calls whose module name is not a string literal
+	UnresolvedDynamicImports []*DynamicImport
+
+	// Package inits that only re-export names of other modules, by module name
+	Facades map[string]*FacadeModule
+
+	edgeIndex map[[2]string]*DependencyEdge // Edges by (from, to)
 }
 
 // ModuleMetrics contains metrics for a single module
@@ -158,6 +321,7 @@ func NewDependencyGraph(projectRoot string) *DependencyGraph {
 		ModuleMetrics: make(map[string]*ModuleMetrics),
 		ProjectRoot:   projectRoot,
 		SystemMetrics: &SystemMetrics{},
+		edgeIndex:     make(map[[2]string]*DependencyEdge),
 	}
 }
 
@@ -188,6 +352,10 @@ func (g *DependencyGraph) AddModule(moduleName, filePath string) *ModuleNode {
 		Imports:          make([]string, 0),
 		ImportedBy:       make([]string, 0),
 		PublicNames:      make([]string, 0),
+
+		TypeCheckingDependencies: make(map[string]bool),
+		ConditionalDependencies:  make(map[string]bool),
+		DynamicDependencies:      make(map[string]bool),
 	}
 
 	g.Nodes[moduleName] = node
@@ -197,6 +365,17 @@ func (g *DependencyGraph) AddModule(moduleName, filePath string) *ModuleNode {
 
 // AddDependency adds a dependency edge between two modules
 func (g *DependencyGraph) AddDependency(from, to string, edgeType DependencyEdgeType, importInfo *ImportInfo) {
+	var symbols []string
+	if importInfo != nil {
+		symbols = importInfo.ImportedNames
+	}
+	g.addDependency(from, to, edgeType, importInfo, symbols)
+}
+
+// addDependency adds a dependency whose import brings symbols from the
+// target module. An import of several names can depend on more than one
+// module, each of them providing some of the names.
+func (g *DependencyGraph) addDependency(from, to string, edgeType DependencyEdgeType, importInfo *ImportInfo, symbols []string) {
 	// Ensure both nodes exist
 	fromNode := g.Nodes[from]
 	toNode := g.Nodes[to]
@@ -211,30 +390,64 @@ func (g *DependencyGraph) AddDependency(from, to string, edgeType DependencyEdge
 	}
 
 	isLazy := importInfo != nil && importInfo.IsLazy
+	isTypeChecking := importInfo != nil && importInfo.IsTypeChecking
+	isConditional := importInfo != nil && importInfo.IsConditional
+	isDynamic := importInfo != nil && importInfo.IsDynamic
 
 	// Check if dependency already exists
 	if fromNode.Dependencies[to] {
+		edge := g.findEdge(from, to)
+		if edge != nil {
+			edge.addSymbols(symbols)
+		}
+
 		// A pair is only treated as lazy when EVERY import forming it is lazy.
 		// If a module-level (non-lazy) import to the same target arrives later,
-		// promote the existing edge to a real load-time dependency.
+		// promote the existing edge to a real load-time dependency. The same
+		// applies to TYPE_CHECKING and conditional imports.
 		if !isLazy && fromNode.LazyDependencies[to] {
 			delete(fromNode.LazyDependencies, to)
-			if edge := g.findEdge(from, to); edge != nil {
+			if edge != nil {
 				edge.IsLazy = false
 			}
 		}
+		if !isTypeChecking && fromNode.TypeCheckingDependencies[to] {
+			delete(fromNode.TypeCheckingDependencies, to)
+			if edge != nil {
+				edge.IsTypeChecking = false
+			}
+		}
+		if !isConditional && fromNode.ConditionalDependencies[to] {
+			delete(fromNode.ConditionalDependencies, to)
+			if edge != nil {
+				edge.IsConditional = false
+			}
+		}
+		if !isDynamic && fromNode.DynamicDependencies[to] {
+			delete(fromNode.DynamicDependencies, to)
+			if edge != nil {
+				edge.IsDynamic = false
+			}
+		}
 		return
 	}
 
 	// Add to graph structure
 	edge := &DependencyEdge{
-		From:       from,
-		To:         to,
-		EdgeType:   edgeType,
-		ImportInfo: importInfo,
-		IsLazy:     isLazy,
+		From:           from,
+		To:             to,
+		EdgeType:       edgeType,
+		ImportInfo:     importInfo,
+		IsLazy:         isLazy,
+		IsTypeChecking: isTypeChecking,
+		IsConditional:  isConditional,
+		IsDynamic:      isDynamic,
 	}
+	edge.addSymbols(symbols)
 	g.Edges = append(g.Edges, edge)
+	if g.edgeIndex != nil {
+		g.edgeIndex[[2]string{from, to}] = edge
+	}
 	g.TotalEdges++
 
 	// Update node relationships
@@ -242,6 +455,24 @@ func (g *DependencyGraph) AddDependency(from, to string, edgeType DependencyEdge
 	if isLazy {
 		fromNode.LazyDependencies[to] = true
 	}
+	if isTypeChecking {
+		if fromNode.TypeCheckingDependencies == nil {
+			fromNode.TypeCheckingDependencies = make(map[string]bool)
+		}
+		fromNode.TypeCheckingDependencies[to] = true
+	}
+	if isConditional {
+		if fromNode.ConditionalDependencies == nil {
+			fromNode.ConditionalDependencies = make(map[string]bool)
+		}
+		fromNode.ConditionalDependencies[to] = true
+	}
+	if isDynamic {
+		if fromNode.DynamicDependencies == nil {
+			fromNode.DynamicDependencies = make(map[string]bool)
+		}
+		fromNode.DynamicDependencies[to] = true
+	}
 	fromNode.Imports = append(fromNode.Imports, to)
 	fromNode.OutDegree++
 
@@ -252,6 +483,9 @@ func (g *DependencyGraph) AddDependency(from, to string, edgeType DependencyEdge
 
 // findEdge returns the dependency edge for the given (from, to) pair, or nil.
 func (g *DependencyGraph) findEdge(from, to string) *DependencyEdge {
+	if g.edgeIndex != nil {
+		return g.edgeIndex[[2]string{from, to}]
+	}
 	for _, edge := range g.Edges {
 		if edge.From == from && edge.To == to {
 			return edge
@@ -511,6 +745,10 @@ func (g *DependencyGraph) Clone() *DependencyGraph {
 			FunctionCount:    node.FunctionCount,
 			ClassCount:       node.ClassCount,
 			PublicNames:      make([]string, len(node.PublicNames)),
+
+			TypeCheckingDependencies: make(map[string]bool, len(node.TypeCheckingDependencies)),
+			ConditionalDependencies:  make(map[string]bool, len(node.ConditionalDependencies)),
+			DynamicDependencies:      make(map[string]bool, len(node.DynamicDependencies)),
 		}
 
 		// Copy maps and slices
@@ -523,6 +761,15 @@ func (g *DependencyGraph) Clone() *DependencyGraph {
 		for dep := range node.LazyDependencies {
 			newNode.LazyDependencies[dep] = true
 		}
+		for dep := range node.TypeCheckingDependencies {
+			newNode.TypeCheckingDependencies[dep] = true
+		}
+		for dep := range node.ConditionalDependencies {
+			newNode.ConditionalDependencies[dep] = true
+		}
+		for dep := range node.DynamicDependencies {
+			newNode.DynamicDependencies[dep] = true
+		}
 		copy(newNode.Imports, node.Imports)
 		copy(newNode.ImportedBy, node.ImportedBy)
 		copy(newNode.PublicNames, node.PublicNames)
@@ -543,8 +790,36 @@ func (g *DependencyGraph) Clone() *DependencyGraph {
 			EdgeType:   edge.EdgeType,
 			ImportInfo: newImportInfo,
 			IsLazy:     edge.IsLazy,
+
+			IsTypeChecking: edge.IsTypeChecking,
+			IsConditional:  edge.IsConditional,
+			IsDynamic:      edge.IsDynamic,
+
+			Symbols: slices.Clone(edge.Symbols),
 		}
 		clone.Edges = append(clone.Edges, newEdge)
+		if clone.edgeIndex != nil {
+			clone.edgeIndex[[2]string{newEdge.From, newEdge.To}] = newEdge
+		}
+	}
+
+	// Copy external imports
+	for _, ext := range g.ExternalImports {
+		newExt := *ext
+		clone.ExternalImports = append(clone.ExternalImports, &newExt)
+	}
+	for _, dyn := range g.UnresolvedDynamicImports {
+		newDyn := *dyn
+		clone.UnresolvedDynamicImports = append(clone.UnresolvedDynamicImports, &newDyn)
+	}
+
+	if g.Facades != nil {
+		clone.Facades = make(map[string]*FacadeModule, len(g.Facades))
+		for name, facade := range g.Facades {
+			newFacade := *facade
+			newFacade.Exports = maps.Clone(facade.Exports)
+			clone.Facades[name] = &newFacade
+		}
 	}
 
 	clone.TotalModules = g.TotalModules
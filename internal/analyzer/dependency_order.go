@@ -0,0 +1,115 @@
+package analyzer
+
+import (
+	"sort"
+
+	coregraph "github.com/ludo-technologies/polyscan/core/graph"
+)
+
+// OrderedComponent is a group of modules that must be handled together when
+// walking the dependency graph. It holds a single module unless the modules
+// form a load-time cycle, in which case they cannot be ordered among
+// themselves.
+type OrderedComponent struct {
+	Modules []string // Modules in the component, sorted by name
+	Level   int      // 0 when the component has no dependencies, otherwise 1 + the highest dependency level
+	Cyclic  bool     // True when the modules form a circular dependency
+}
+
+// DependencyOrderResult lists modules in dependency order: every component
+// appears after all components it depends on.
+type DependencyOrderResult struct {
+	Components []OrderedComponent
+	Levels     int  // Number of distinct levels
+	IsAcyclic  bool // True when every component holds a single module
+}
+
+// ComputeDependencyOrder collapses strongly connected components and returns
+// them in topological order, dependencies first. Lazy (function-level) and
+// TYPE_CHECKING imports are ignored, matching the default circular dependency
+// detection. Components on the same level do not depend on each other and
+// are sorted by name.
+func ComputeDependencyOrder(graph *DependencyGraph) *DependencyOrderResult {
+	result := &DependencyOrderResult{IsAcyclic: true}
+	if graph == nil || len(graph.Nodes) == 0 {
+		return result
+	}
+
+	loadTime := loadTimeDependencyGraph{graph, DefaultCycleIgnoredEdgeKinds()}
+
+	// Assign every module to a component: cycles from Tarjan's algorithm,
+	// singletons for everything else
+	componentOf := make(map[string]int, len(graph.Nodes))
+	var components []OrderedComponent
+	for _, cycle := range coregraph.NewCycleDetector().DetectCycles(loadTime).Cycles {
+		modules := append([]string(nil), cycle...)
+		sort.Strings(modules)
+		for _, module := range modules {
+			componentOf[module] = len(components)
+		}
+		components = append(components, OrderedComponent{Modules: modules, Cyclic: true})
+		result.IsAcyclic = false
+	}
+	for _, module := range graph.GetModuleNames() {
+		if _, ok := componentOf[module]; ok {
+			continue
+		}
+		componentOf[module] = len(components)
+		components = append(components, OrderedComponent{Modules: []string{module}})
+	}
+
+	// Build the condensed graph: component -> components it depends on
+	dependencies := make([]map[int]bool, len(components))
+	dependents := make([][]int, len(components))
+	for module, from := range componentOf {
+		for _, dep := range loadTime.Successors(module) {
+			to, ok := componentOf[dep]
+			if !ok || to == from {
+				continue
+			}
+			if dependencies[from] == nil {
+				dependencies[from] = make(map[int]bool)
+			}
+			if !dependencies[from][to] {
+				dependencies[from][to] = true
+				dependents[to] = append(dependents[to], from)
+			}
+		}
+	}
+
+	// Kahn's algorithm from the components without dependencies upwards
+	remaining := make([]int, len(components))
+	var ready []int
+	for i := range components {
+		remaining[i] = len(dependencies[i])
+		if remaining[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+	for len(ready) > 0 {
+		current := ready[0]
+		ready = ready[1:]
+		for _, dependent := range dependents[current] {
+			if level := components[current].Level + 1; level > components[dependent].Level {
+				components[dependent].Level = level
+			}
+			remaining[dependent]--
+			if remaining[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	sort.Slice(components, func(i, j int) bool {
+		if components[i].Level != components[j].Level {
+			return components[i].Level < components[j].Level
+		}
+		return components[i].Modules[0] < components[j].Modules[0]
+	})
+
+	result.Components = components
+	if len(components) > 0 {
+		result.Levels = components[len(components)-1].Level + 1
+	}
+	return result
+}
@@ -0,0 +1,75 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newOrderTestGraph builds a graph from "from -> to" pairs, adding modules as needed
+func newOrderTestGraph(edges [][2]string, extraModules ...string) *DependencyGraph {
+	graph := NewDependencyGraph("/project")
+	for _, module := range extraModules {
+		graph.AddModule(module, "")
+	}
+	for _, edge := range edges {
+		for _, module := range edge {
+			if graph.GetModule(module) == nil {
+				graph.AddModule(module, "")
+			}
+		}
+		graph.AddDependency(edge[0], edge[1], DependencyEdgeImport, nil)
+	}
+	return graph
+}
+
+func TestComputeDependencyOrder_AcyclicGraph(t *testing.T) {
+	graph := newOrderTestGraph([][2]string{
+		{"app.main", "app.services"},
+		{"app.main", "app.utils"},
+		{"app.services", "app.models"},
+		{"app.models", "app.utils"},
+	})
+
+	result := ComputeDependencyOrder(graph)
+
+	require.True(t, result.IsAcyclic)
+	assert.Equal(t, 4, result.Levels)
+	var order []string
+	for _, component := range result.Components {
+		require.Len(t, component.Modules, 1)
+		order = append(order, component.Modules[0])
+	}
+	assert.Equal(t, []string{"app.utils", "app.models", "app.services", "app.main"}, order)
+}
+
+func TestComputeDependencyOrder_CollapsesCycles(t *testing.T) {
+	graph := newOrderTestGraph([][2]string{
+		{"pkg.a", "pkg.b"},
+		{"pkg.b", "pkg.a"},
+		{"pkg.b", "pkg.base"},
+		{"pkg.cli", "pkg.a"},
+	}, "pkg.standalone")
+
+	result := ComputeDependencyOrder(graph)
+
+	require.False(t, result.IsAcyclic)
+	require.Len(t, result.Components, 4)
+	assert.Equal(t, []string{"pkg.base"}, result.Components[0].Modules)
+	assert.Equal(t, []string{"pkg.standalone"}, result.Components[1].Modules)
+	assert.Equal(t, 0, result.Components[1].Level)
+	assert.Equal(t, []string{"pkg.a", "pkg.b"}, result.Components[2].Modules)
+	assert.True(t, result.Components[2].Cyclic)
+	assert.Equal(t, 1, result.Components[2].Level)
+	assert.Equal(t, []string{"pkg.cli"}, result.Components[3].Modules)
+	assert.Equal(t, 2, result.Components[3].Level)
+}
+
+func TestComputeDependencyOrder_EmptyGraph(t *testing.T) {
+	result := ComputeDependencyOrder(NewDependencyGraph("/project"))
+
+	assert.True(t, result.IsAcyclic)
+	assert.Empty(t, result.Components)
+	assert.Equal(t, 0, result.Levels)
+}
@@ -0,0 +1,198 @@
+package analyzer
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// DocstringAnalyzer measures docstring coverage of modules, classes and
+// functions. A definition is documented when the first statement of its body
+// is a string constant.
+type DocstringAnalyzer struct {
+	convention domain.DocumentationConvention
+}
+
+// NewDocstringAnalyzer creates a new docstring analyzer. An empty convention
+// falls back to the default.
+func NewDocstringAnalyzer(convention domain.DocumentationConvention) *DocstringAnalyzer {
+	if convention == "" {
+		convention = domain.DocumentationConvention(domain.DefaultDocumentationConvention)
+	}
+	return &DocstringAnalyzer{convention: convention}
+}
+
+// docstringScope describes the body definitions are being collected from
+type docstringScope struct {
+	kind   domain.DocumentationItemKind
+	prefix string
+	public bool
+}
+
+// Analyze collects coverage counts and missing-docstring findings for one file
+func (a *DocstringAnalyzer) Analyze(ast *parser.Node, filePath string) *domain.DocumentationFileResult {
+	result := &domain.DocumentationFileResult{
+		ByKind: make(map[domain.DocumentationItemKind]domain.DocumentationKindStats),
+	}
+	if ast == nil {
+		return result
+	}
+
+	moduleName := docstringModuleName(filePath)
+	modulePublic := isPublicDocstringName(moduleName)
+
+	// Empty modules (typically bare __init__.py files) have nothing to document
+	if len(ast.Body) > 0 {
+		a.record(result, domain.DocumentationItemModule, moduleName, modulePublic, hasDocstring(ast.Body), filePath, parser.Location{StartLine: 1, EndLine: ast.Location.EndLine})
+	}
+
+	a.visitBody(result, ast.Body, docstringScope{kind: domain.DocumentationItemModule, public: modulePublic}, filePath)
+	return result
+}
+
+// visitBody records every class and function defined directly in body,
+// looking through compound statements such as if TYPE_CHECKING blocks
+func (a *DocstringAnalyzer) visitBody(result *domain.DocumentationFileResult, body []*parser.Node, scope docstringScope, filePath string) {
+	for _, stmt := range body {
+		if stmt == nil {
+			continue
+		}
+
+		switch stmt.Type {
+		case parser.NodeClassDef:
+			name := scope.prefix + stmt.Name
+			public := scope.public && scope.kind != domain.DocumentationItemFunction && isPublicDocstringName(stmt.Name)
+			a.record(result, domain.DocumentationItemClass, name, public, hasDocstring(stmt.Body), filePath, stmt.Location)
+			a.visitBody(result, stmt.Body, docstringScope{kind: domain.DocumentationItemClass, prefix: name + ".", public: public}, filePath)
+
+		case parser.NodeFunctionDef, parser.NodeAsyncFunctionDef:
+			name := scope.prefix + stmt.Name
+			// Overload stubs share the docstring of the implementation
+			if !isOverload(stmt) {
+				kind := domain.DocumentationItemFunction
+				if scope.kind == domain.DocumentationItemClass {
+					kind = domain.DocumentationItemMethod
+				}
+				public := scope.public && scope.kind != domain.DocumentationItemFunction &&
+					isPublicDocstringName(stmt.Name) && !isDunderName(stmt.Name)
+				a.record(result, kind, name, public, hasDocstring(stmt.Body), filePath, stmt.Location)
+			}
+			a.visitBody(result, stmt.Body, docstringScope{kind: domain.DocumentationItemFunction, prefix: name + ".", public: false}, filePath)
+
+		case parser.NodeIf, parser.NodeTry, parser.NodeWith, parser.NodeAsyncWith,
+			parser.NodeFor, parser.NodeAsyncFor, parser.NodeWhile:
+			a.visitBody(result, stmt.Body, scope, filePath)
+			a.visitBody(result, stmt.Orelse, scope, filePath)
+			for _, handler := range stmt.Handlers {
+				a.visitBody(result, handler.Body, scope, filePath)
+			}
+			a.visitBody(result, stmt.Finalbody, scope, filePath)
+		}
+	}
+}
+
+// record counts a definition if the convention covers it and emits a finding
+// when it is undocumented
+func (a *DocstringAnalyzer) record(result *domain.DocumentationFileResult, kind domain.DocumentationItemKind, name string, public, documented bool, filePath string, loc parser.Location) {
+	if a.convention == domain.DocumentationConventionPublic && !public {
+		return
+	}
+
+	stats := result.ByKind[kind]
+	stats.Total++
+	if documented {
+		stats.Documented++
+		result.ByKind[kind] = stats
+		return
+	}
+	result.ByKind[kind] = stats
+
+	result.Findings = append(result.Findings, domain.DocumentationFinding{
+		Kind:        kind,
+		Name:        name,
+		Location:    sourceLocation(filePath, loc),
+		Description: fmt.Sprintf("%s '%s' has no docstring", strings.ToUpper(string(kind[:1]))+string(kind[1:]), name),
+	})
+}
+
+// hasDocstring reports whether a body starts with a string constant
+func hasDocstring(body []*parser.Node) bool {
+	if len(body) == 0 {
+		return false
+	}
+	first := body[0]
+	// The parser unwraps expression statements, but Expr(Constant) is
+	// accepted too for hand-built trees
+	if first.Type == parser.NodeExpr && len(first.Children) == 1 {
+		first = first.Children[0]
+	}
+	return isStringConstant(first)
+}
+
+// docstringModuleName returns the module name of a file; packages are named
+// after their directory
+func docstringModuleName(filePath string) string {
+	name := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+	if name == "__init__" {
+		if dir := filepath.Base(filepath.Dir(filePath)); dir != "." && dir != string(filepath.Separator) {
+			return dir
+		}
+	}
+	return name
+}
+
+// isPublicDocstringName reports whether a name is part of the public API
+func isPublicDocstringName(name string) bool {
+	return !strings.HasPrefix(name, "_")
+}
+
+// isDunderName reports whether a name is a special method such as __repr__
+func isDunderName(name string) bool {
+	return len(name) > 4 && strings.HasPrefix(name, "__") && strings.HasSuffix(name, "__")
+}
+
+// isOverload reports whether a function is decorated with typing.overload
+func isOverload(node *parser.Node) bool {
+	for _, decorator := range node.Decorator {
+		name := decoratorQualifiedName(decorator)
+		if name == "overload" || hasDecoratorSuffix(name, ".overload") {
+			return true
+		}
+	}
+	return false
+}
+
+// SortDocumentationFindings orders findings by file and position
+func SortDocumentationFindings(findings []domain.DocumentationFinding) []domain.DocumentationFinding {
+	sorted := make([]domain.DocumentationFinding, len(findings))
+	copy(sorted, findings)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Location.FilePath != sorted[j].Location.FilePath {
+			return sorted[i].Location.FilePath < sorted[j].Location.FilePath
+		}
+		return sorted[i].Location.StartLine < sorted[j].Location.StartLine
+	})
+	return sorted
+}
+
+// GenerateDocumentationSummary aggregates per-kind counts into a coverage summary
+func GenerateDocumentationSummary(byKind map[domain.DocumentationItemKind]domain.DocumentationKindStats, filesAnalyzed int) domain.DocumentationSummary {
+	summary := domain.DocumentationSummary{
+		ByKind:        make(map[domain.DocumentationItemKind]domain.DocumentationKindStats, len(byKind)),
+		FilesAnalyzed: filesAnalyzed,
+		Coverage:      100,
+	}
+	for kind, stats := range byKind {
+		summary.ByKind[kind] = stats
+		summary.TotalItems += stats.Total
+		summary.DocumentedItems += stats.Documented
+	}
+	if summary.TotalItems > 0 {
+		summary.Coverage = float64(summary.DocumentedItems) / float64(summary.TotalItems) * 100
+	}
+	return summary
+}
@@ -0,0 +1,165 @@
+package analyzer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+const docstringSample = `"""Module docstring."""
+import typing
+
+class Public:
+    """Documented class."""
+
+    def __init__(self):
+        pass
+
+    def __repr__(self):
+        return "Public()"
+
+    def method(self):
+        """Documented method."""
+
+    def undocumented(self):
+        def helper():
+            pass
+        return helper
+
+    def _private(self):
+        pass
+
+class _Hidden:
+    def visible_name(self):
+        pass
+
+if typing.TYPE_CHECKING:
+    def guarded():
+        pass
+
+@typing.overload
+def parse(value: int) -> int: ...
+
+def parse(value):
+    """Parse a value."""
+    return value
+`
+
+func analyzeDocstrings(t *testing.T, code, filePath string, convention domain.DocumentationConvention) *domain.DocumentationFileResult {
+	t.Helper()
+	result, err := parser.New().Parse(context.Background(), []byte(code))
+	if err != nil {
+		t.Fatalf("failed to parse code: %v", err)
+	}
+	return NewDocstringAnalyzer(convention).Analyze(result.AST, filePath)
+}
+
+func findingNames(findings []domain.DocumentationFinding) []string {
+	names := make([]string, len(findings))
+	for i, f := range findings {
+		names[i] = f.Name
+	}
+	return names
+}
+
+func TestDocstringAnalyzer_PublicConvention(t *testing.T) {
+	result := analyzeDocstrings(t, docstringSample, "pkg/module.py", domain.DocumentationConventionPublic)
+
+	// Dunder methods, private names and nested helpers are not public API
+	want := []string{"Public.undocumented", "guarded"}
+	got := findingNames(result.Findings)
+	if len(got) != len(want) {
+		t.Fatalf("findings = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("finding %d = %s, want %s", i, got[i], want[i])
+		}
+	}
+
+	expected := map[domain.DocumentationItemKind]domain.DocumentationKindStats{
+		domain.DocumentationItemModule:   {Total: 1, Documented: 1},
+		domain.DocumentationItemClass:    {Total: 1, Documented: 1},
+		domain.DocumentationItemMethod:   {Total: 2, Documented: 1},
+		domain.DocumentationItemFunction: {Total: 2, Documented: 1},
+	}
+	for kind, stats := range expected {
+		if result.ByKind[kind] != stats {
+			t.Errorf("%s stats = %+v, want %+v", kind, result.ByKind[kind], stats)
+		}
+	}
+}
+
+func TestDocstringAnalyzer_AllConvention(t *testing.T) {
+	result := analyzeDocstrings(t, docstringSample, "pkg/module.py", domain.DocumentationConventionAll)
+
+	// Private, dunder and nested definitions are counted too; overload stubs never are
+	got := findingNames(result.Findings)
+	want := []string{
+		"Public.__init__", "Public.__repr__", "Public.undocumented", "Public.undocumented.helper",
+		"Public._private", "_Hidden", "_Hidden.visible_name", "guarded",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("findings = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("finding %d = %s, want %s", i, got[i], want[i])
+		}
+	}
+	if result.Findings[3].Kind != domain.DocumentationItemFunction {
+		t.Errorf("nested helper kind = %s, want function", result.Findings[3].Kind)
+	}
+}
+
+func TestDocstringAnalyzer_Modules(t *testing.T) {
+	tests := []struct {
+		name       string
+		code       string
+		filePath   string
+		convention domain.DocumentationConvention
+		wantTotal  int
+		wantName   string
+	}{
+		{name: "undocumented module", code: "x = 1\n", filePath: "src/util.py", convention: domain.DocumentationConventionPublic, wantTotal: 1, wantName: "util"},
+		{name: "package named after directory", code: "x = 1\n", filePath: "src/pkg/__init__.py", convention: domain.DocumentationConventionPublic, wantTotal: 1, wantName: "pkg"},
+		{name: "empty init is skipped", code: "", filePath: "src/pkg/__init__.py", convention: domain.DocumentationConventionAll},
+		{name: "private module is skipped", code: "def f():\n    pass\n", filePath: "src/_impl.py", convention: domain.DocumentationConventionPublic},
+		{name: "private module counted under all", code: "x = 1\n", filePath: "src/_impl.py", convention: domain.DocumentationConventionAll, wantTotal: 1, wantName: "_impl"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := analyzeDocstrings(t, tt.code, tt.filePath, tt.convention)
+			module := result.ByKind[domain.DocumentationItemModule]
+			if module.Total != tt.wantTotal {
+				t.Fatalf("module total = %d, want %d", module.Total, tt.wantTotal)
+			}
+			if tt.wantTotal > 0 && (len(result.Findings) == 0 || result.Findings[0].Name != tt.wantName) {
+				t.Errorf("findings = %v, want module %s first", findingNames(result.Findings), tt.wantName)
+			}
+			if tt.wantTotal == 0 && len(result.Findings) != 0 {
+				t.Errorf("expected no findings, got %v", findingNames(result.Findings))
+			}
+		})
+	}
+}
+
+func TestGenerateDocumentationSummary(t *testing.T) {
+	summary := GenerateDocumentationSummary(map[domain.DocumentationItemKind]domain.DocumentationKindStats{
+		domain.DocumentationItemClass:    {Total: 2, Documented: 1},
+		domain.DocumentationItemFunction: {Total: 2, Documented: 2},
+	}, 3)
+	if summary.TotalItems != 4 || summary.DocumentedItems != 3 || summary.FilesAnalyzed != 3 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+	if summary.Coverage != 75 {
+		t.Errorf("coverage = %f, want 75", summary.Coverage)
+	}
+
+	if empty := GenerateDocumentationSummary(nil, 1); empty.Coverage != 100 {
+		t.Errorf("coverage with nothing to document = %f, want 100", empty.Coverage)
+	}
+}
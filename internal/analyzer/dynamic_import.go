@@ -0,0 +1,197 @@
+package analyzer
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// dynamicImportForm describes how a dynamic import function names its target
+type dynamicImportForm int
+
+const (
+	dynamicModuleName dynamicImportForm = iota // "pkg.mod", optionally relative to a package argument
+	dynamicObjectPath                          // "pkg.mod:attr" or "pkg.mod.attr"
+	dynamicEntryPoint                          // "name = pkg.mod:attr [extra]"
+)
+
+// dynamicImportFunction describes a function that imports a module named by
+// one of its arguments
+type dynamicImportFunction struct {
+	form     dynamicImportForm
+	argIndex int    // Positional index of the target argument
+	keyword  string // Keyword name of the target argument
+}
+
+var (
+	dottedNamePattern   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+	relativeNamePattern = regexp.MustCompile(`^\.+([A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*)?$`)
+)
+
+// lookupDynamicImportFunction matches the called function name against the
+// known dynamic import APIs. Methods reached through an alias, such as
+// il.import_module, are matched on their final attribute.
+func lookupDynamicImportFunction(function string) (dynamicImportFunction, bool) {
+	last := function
+	if idx := strings.LastIndex(function, "."); idx != -1 {
+		last = function[idx+1:]
+	}
+
+	switch {
+	case function == "__import__" || function == "builtins.__import__":
+		return dynamicImportFunction{form: dynamicModuleName, keyword: "name"}, true
+	case last == "import_module":
+		return dynamicImportFunction{form: dynamicModuleName, keyword: "name"}, true
+	case last == "import_string":
+		// Django and Werkzeug helpers
+		return dynamicImportFunction{form: dynamicObjectPath, keyword: "dotted_path"}, true
+	case function == "pkgutil.resolve_name":
+		return dynamicImportFunction{form: dynamicObjectPath, keyword: "name"}, true
+	case last == "EntryPoint":
+		return dynamicImportFunction{form: dynamicObjectPath, argIndex: 1, keyword: "value"}, true
+	case strings.HasSuffix(function, "EntryPoint.parse"):
+		return dynamicImportFunction{form: dynamicEntryPoint, keyword: "src"}, true
+	}
+	return dynamicImportFunction{}, false
+}
+
+// dynamicImportFromCall converts a dynamic import call to an ImportInfo when
+// its target is a string literal. The second result is the called function
+// name and is empty when call is not a dynamic import. A recognized call with
+// a nil ImportInfo cannot be resolved statically.
+func (ma *ModuleAnalyzer) dynamicImportFromCall(call *parser.Node) (*ImportInfo, string) {
+	function := ma.nodeQualifiedName(call)
+	spec, ok := lookupDynamicImportFunction(function)
+	if !ok {
+		return nil, ""
+	}
+	arg := callArgument(call, spec.argIndex, spec.keyword)
+	if arg == nil {
+		// EntryPoint(...) and friends are also used without a target, for
+		// example with keyword unpacking; nothing to report in that case
+		if len(call.Args) == 0 && len(call.Keywords) == 0 {
+			return nil, ""
+		}
+		return nil, function
+	}
+	target, ok := stringLiteral(arg)
+	if !ok {
+		return nil, function
+	}
+
+	imp := &ImportInfo{
+		Line:           call.Location.StartLine,
+		IsTypeChecking: ma.isInTypeCheckingBlock(call),
+		IsLazy:         ma.isInFunctionScope(call),
+		IsConditional:  ma.isInImportFallback(call),
+		IsDynamic:      true,
+	}
+
+	switch spec.form {
+	case dynamicEntryPoint:
+		_, value, found := strings.Cut(target, "=")
+		if !found {
+			return nil, function
+		}
+		if idx := strings.Index(value, "["); idx != -1 {
+			value = value[:idx]
+		}
+		target = strings.TrimSpace(value)
+		fallthrough
+	case dynamicObjectPath:
+		module, attr := splitObjectPath(target)
+		if !dottedNamePattern.MatchString(module) {
+			return nil, function
+		}
+		imp.Statement = module
+		if attr != "" {
+			imp.ImportedNames = []string{attr}
+		}
+		return imp, function
+	}
+
+	if relativeNamePattern.MatchString(target) {
+		return ma.relativeDynamicImport(call, imp, target), function
+	}
+	if !dottedNamePattern.MatchString(target) {
+		return nil, function
+	}
+	imp.Statement = "import " + target
+	imp.ImportedNames = []string{target}
+	return imp, function
+}
+
+// relativeDynamicImport resolves import_module(".name", package). Relative
+// names are only analyzable when package is a string literal or __package__.
+func (ma *ModuleAnalyzer) relativeDynamicImport(call *parser.Node, imp *ImportInfo, target string) *ImportInfo {
+	name := strings.TrimLeft(target, ".")
+	level := len(target) - len(name)
+
+	pkgArg := callArgument(call, 1, "package")
+	if pkgArg == nil {
+		return nil
+	}
+	if pkgArg.Type == parser.NodeName && pkgArg.Name == "__package__" {
+		imp.Statement = name
+		imp.IsRelative = true
+		imp.Level = level
+		return imp
+	}
+
+	pkg, ok := stringLiteral(pkgArg)
+	if !ok || !dottedNamePattern.MatchString(pkg) {
+		return nil
+	}
+	parts := strings.Split(pkg, ".")
+	if level-1 >= len(parts) {
+		return nil
+	}
+	module := strings.Join(parts[:len(parts)-(level-1)], ".")
+	if name != "" {
+		module += "." + name
+	}
+	imp.Statement = "import " + module
+	imp.ImportedNames = []string{module}
+	return imp
+}
+
+// splitObjectPath splits "pkg.mod:attr" or "pkg.mod.attr" into the module and
+// the first attribute name
+func splitObjectPath(path string) (string, string) {
+	if module, attr, found := strings.Cut(path, ":"); found {
+		attr, _, _ = strings.Cut(strings.TrimSpace(attr), ".")
+		return strings.TrimSpace(module), attr
+	}
+	idx := strings.LastIndex(path, ".")
+	if idx == -1 {
+		return path, ""
+	}
+	return path[:idx], path[idx+1:]
+}
+
+// callArgument returns the positional argument at index or the keyword
+// argument with the given name
+func callArgument(call *parser.Node, index int, keyword string) *parser.Node {
+	for _, kw := range call.Keywords {
+		if kw.Name == keyword {
+			value, _ := kw.Value.(*parser.Node)
+			return value
+		}
+	}
+	if index < len(call.Args) {
+		if arg := call.Args[index]; arg.Type != parser.NodeStarred {
+			return arg
+		}
+	}
+	return nil
+}
+
+// stringLiteral returns the value of a plain string constant
+func stringLiteral(node *parser.Node) (string, bool) {
+	if node == nil || node.Type != parser.NodeConstant {
+		return "", false
+	}
+	value, ok := node.Value.(string)
+	return value, ok
+}
@@ -0,0 +1,194 @@
+package analyzer
+
+import (
+	"context"
+	"os"
+	"sort"
+
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// FacadeModule is a package __init__ that does nothing but re-export names
+// defined in other modules of the project. Facades gather many edges for
+// little code of their own, so they look like the most coupled modules of
+// the graph unless collapsed.
+type FacadeModule struct {
+	Module    string            // Package module name
+	Exports   map[string]string // Re-exported name -> project module defining it
+	Collapsed bool              // Imports through the facade were routed to the defining modules
+}
+
+// FanOut is the number of distinct modules the facade re-exports from
+func (f *FacadeModule) FanOut() int {
+	return len(f.Sources())
+}
+
+// Sources returns the modules the facade re-exports from, sorted
+func (f *FacadeModule) Sources() []string {
+	sources := make(map[string]bool, len(f.Exports))
+	for _, source := range f.Exports {
+		sources[source] = true
+	}
+	return sortedModuleNames(sources)
+}
+
+// detectFacades records the package inits of graph that only re-export at
+// least facadeMinExports names of other project modules
+func (ma *ModuleAnalyzer) detectFacades(graph *DependencyGraph) {
+	ma.facades = make(map[string]*FacadeModule)
+	for _, moduleName := range graph.GetModuleNames() {
+		node := graph.GetModule(moduleName)
+		if node == nil || !node.IsPackage {
+			continue
+		}
+		content, err := os.ReadFile(node.FilePath)
+		if err != nil {
+			continue
+		}
+		result, err := parser.New().Parse(context.Background(), content)
+		if err != nil || result.AST == nil || !onlyReExports(result.AST.Body) {
+			continue
+		}
+
+		exports := ma.facadeExports(graph, result.AST, node)
+		if len(exports) < ma.facadeMinExports {
+			continue
+		}
+		ma.facades[moduleName] = &FacadeModule{
+			Module:    moduleName,
+			Exports:   exports,
+			Collapsed: ma.collapseFacades,
+		}
+	}
+	graph.Facades = ma.facades
+}
+
+// facadeExports maps the names a package init imports from other project
+// modules, under their exported alias, to the module defining them
+func (ma *ModuleAnalyzer) facadeExports(graph *DependencyGraph, ast *parser.Node, node *ModuleNode) map[string]string {
+	exports := make(map[string]string)
+	ast.Walk(func(n *parser.Node) bool {
+		if n.Type != parser.NodeImportFrom {
+			return true
+		}
+		imp := ma.importsFromNode(n)[0]
+		if imp.IsTypeChecking {
+			return false
+		}
+		targetModule := ma.resolveImport(imp, node.FilePath)
+		if targetModule == "" {
+			return false
+		}
+		for _, name := range n.Names {
+			if name == "*" {
+				continue
+			}
+			source := targetModule
+			if concrete := importedNameModule(targetModule, name); graph.GetModule(concrete) != nil {
+				source = concrete
+			}
+			if source == node.Name || graph.GetModule(source) == nil {
+				continue
+			}
+			exports[importedAlias(n, name)] = source
+		}
+		return false
+	})
+
+	if declared, hasAll := ma.reExportResolver.extractAllDeclaration(ast); hasAll && len(declared) > 0 {
+		public := make(map[string]string, len(declared))
+		for _, name := range declared {
+			if source, ok := exports[name]; ok {
+				public[name] = source
+			}
+		}
+		exports = public
+	}
+	return exports
+}
+
+// importedAlias returns the name a from-import binds name to
+func importedAlias(node *parser.Node, name string) string {
+	for _, child := range node.Children {
+		if child.Type == parser.NodeAlias && child.Name == name {
+			if alias, ok := child.Value.(string); ok && alias != "" {
+				return alias
+			}
+			break
+		}
+	}
+	return name
+}
+
+// onlyReExports reports whether a module body holds nothing but imports, a
+// docstring and assignments to dunder names such as __all__ and
+// __version__. Import fallbacks in try or if blocks are allowed too.
+func onlyReExports(body []*parser.Node) bool {
+	for i, stmt := range body {
+		if stmt == nil {
+			continue
+		}
+		switch stmt.Type {
+		case parser.NodeImport, parser.NodeImportFrom, parser.NodePass:
+		case parser.NodeAssign, parser.NodeAugAssign, parser.NodeAnnAssign:
+			for _, target := range stmt.Targets {
+				if target == nil || target.Type != parser.NodeName || !isDunderName(target.Name) {
+					return false
+				}
+			}
+		case parser.NodeTry, parser.NodeIf:
+			if !onlyReExports(stmt.Body) || !onlyReExports(stmt.Orelse) || !onlyReExports(stmt.Finalbody) {
+				return false
+			}
+			for _, handler := range stmt.Handlers {
+				if handler != nil && !onlyReExports(handler.Body) {
+					return false
+				}
+			}
+		case parser.NodeElifClause, parser.NodeElseClause, parser.NodeExceptHandler:
+			if !onlyReExports(stmt.Body) || !onlyReExports(stmt.Orelse) {
+				return false
+			}
+		default:
+			if i == 0 && hasDocstring(body) {
+				continue
+			}
+			return false
+		}
+	}
+	return true
+}
+
+// facadeTarget returns the module defining a name imported from a collapsed
+// facade, or "" when target is not one or does not re-export the name
+func (ma *ModuleAnalyzer) facadeTarget(targetModule, importedName string) string {
+	if !ma.collapseFacades {
+		return ""
+	}
+	facade := ma.facades[targetModule]
+	if facade == nil {
+		return ""
+	}
+	return facade.Exports[importedName]
+}
+
+// isCollapsedFacade reports whether the edges of moduleName are dropped
+// because it is a collapsed facade
+func (ma *ModuleAnalyzer) isCollapsedFacade(moduleName string) bool {
+	return ma.collapseFacades && ma.facades[moduleName] != nil
+}
+
+// SortedFacades returns the facades of the graph, largest fan-out first
+func (g *DependencyGraph) SortedFacades() []*FacadeModule {
+	facades := make([]*FacadeModule, 0, len(g.Facades))
+	for _, facade := range g.Facades {
+		facades = append(facades, facade)
+	}
+	sort.Slice(facades, func(i, j int) bool {
+		if fi, fj := facades[i].FanOut(), facades[j].FanOut(); fi != fj {
+			return fi > fj
+		}
+		return facades[i].Module < facades[j].Module
+	})
+	return facades
+}
@@ -0,0 +1,83 @@
+package analyzer
+
+import (
+	"os"
+)
+
+// fragmentSourceCacheSize is the number of files whose lines a
+// FragmentSourceReader keeps. Reports list the fragments of a file together,
+// so a few files are enough to read each of them once.
+const fragmentSourceCacheSize = 16
+
+// FragmentSourceReader reads the source text of clone fragments again from
+// their files after it was dropped during extraction
+type FragmentSourceReader struct {
+	files map[string][][]byte
+	order []string // Cached paths, least recently read first
+}
+
+// NewFragmentSourceReader creates a reader with an empty file cache
+func NewFragmentSourceReader() *FragmentSourceReader {
+	return &FragmentSourceReader{
+		files: make(map[string][][]byte),
+	}
+}
+
+// Content returns the source lines startLine to endLine (1-indexed) of a
+// file. When hash is not empty, the text must still hash to it; a file that
+// changed or can no longer be read since the analysis yields "".
+func (r *FragmentSourceReader) Content(filePath string, startLine, endLine int, hash string) string {
+	lines, ok := r.lines(filePath)
+	if !ok {
+		return ""
+	}
+	content := extractSourceContent(lines, startLine, endLine)
+	if hash != "" && fragmentHashNormalizer.HashFragmentContent(content) != hash {
+		return ""
+	}
+	return content
+}
+
+// FragmentContent returns the source text of a fragment, reading it from
+// its file when the fragment no longer holds it
+func (r *FragmentSourceReader) FragmentContent(fragment *CodeFragment) string {
+	if fragment == nil || fragment.Location == nil {
+		return ""
+	}
+	if fragment.Content != "" {
+		return fragment.Content
+	}
+	return r.Content(fragment.Location.FilePath, fragment.Location.StartLine, fragment.Location.EndLine, fragment.Hash)
+}
+
+// lines returns the lines of a file, reading it when it is not cached
+func (r *FragmentSourceReader) lines(filePath string) ([][]byte, bool) {
+	if lines, ok := r.files[filePath]; ok {
+		r.touch(filePath)
+		return lines, true
+	}
+
+	source, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, false
+	}
+	lines := splitLines(source)
+
+	if len(r.order) >= fragmentSourceCacheSize {
+		delete(r.files, r.order[0])
+		r.order = r.order[1:]
+	}
+	r.files[filePath] = lines
+	r.order = append(r.order, filePath)
+	return lines, true
+}
+
+// touch marks a cached file as the most recently read
+func (r *FragmentSourceReader) touch(filePath string) {
+	for i, path := range r.order {
+		if path == filePath {
+			r.order = append(append(r.order[:i:i], r.order[i+1:]...), filePath)
+			return
+		}
+	}
+}
@@ -107,18 +107,12 @@ func (d *HiddenDependencyDetector) detectGlobalStatements(ast *parser.Node, file
 			methodName := d.findContainingMethodName(globalNode, class)
 
 			finding := domain.DIAntipatternFinding{
-				Type:       domain.DIAntipatternHiddenDependency,
-				Subtype:    string(domain.HiddenDepGlobal),
-				Severity:   domain.DIAntipatternSeverityError,
-				ClassName:  class.Name,
-				MethodName: methodName,
-				Location: domain.SourceLocation{
-					FilePath:  filePath,
-					StartLine: globalNode.Location.StartLine,
-					EndLine:   globalNode.Location.EndLine,
-					StartCol:  globalNode.Location.StartCol,
-					EndCol:    globalNode.Location.EndCol,
-				},
+				Type:        domain.DIAntipatternHiddenDependency,
+				Subtype:     string(domain.HiddenDepGlobal),
+				Severity:    domain.DIAntipatternSeverityError,
+				ClassName:   class.Name,
+				MethodName:  methodName,
+				Location:    sourceLocation(filePath, globalNode.Location),
 				Description: fmt.Sprintf("Uses global statement to access '%s'", namesStr),
 				Suggestion:  "Inject the dependency as a constructor parameter instead of using global state",
 				Details: map[string]interface{}{
@@ -142,17 +136,11 @@ func (d *HiddenDependencyDetector) detectSingletonPattern(ast *parser.Node, file
 		// Check for _instance class variable
 		if d.hasSingletonPattern(class) {
 			finding := domain.DIAntipatternFinding{
-				Type:      domain.DIAntipatternHiddenDependency,
-				Subtype:   string(domain.HiddenDepSingleton),
-				Severity:  domain.DIAntipatternSeverityWarning,
-				ClassName: class.Name,
-				Location: domain.SourceLocation{
-					FilePath:  filePath,
-					StartLine: class.Location.StartLine,
-					EndLine:   class.Location.EndLine,
-					StartCol:  class.Location.StartCol,
-					EndCol:    class.Location.EndCol,
-				},
+				Type:        domain.DIAntipatternHiddenDependency,
+				Subtype:     string(domain.HiddenDepSingleton),
+				Severity:    domain.DIAntipatternSeverityWarning,
+				ClassName:   class.Name,
+				Location:    sourceLocation(filePath, class.Location),
 				Description: "Class implements singleton pattern using '_instance' class variable",
 				Suggestion:  "Consider using dependency injection to provide a single instance instead of singleton pattern",
 				Details: map[string]interface{}{
@@ -186,18 +174,12 @@ func (d *HiddenDependencyDetector) detectModuleVariableAccess(ast *parser.Node,
 
 			for varName, location := range accessedVars {
 				finding := domain.DIAntipatternFinding{
-					Type:       domain.DIAntipatternHiddenDependency,
-					Subtype:    string(domain.HiddenDepModuleVariable),
-					Severity:   domain.DIAntipatternSeverityWarning,
-					ClassName:  class.Name,
-					MethodName: method.Name,
-					Location: domain.SourceLocation{
-						FilePath:  filePath,
-						StartLine: location.StartLine,
-						EndLine:   location.EndLine,
-						StartCol:  location.StartCol,
-						EndCol:    location.EndCol,
-					},
+					Type:        domain.DIAntipatternHiddenDependency,
+					Subtype:     string(domain.HiddenDepModuleVariable),
+					Severity:    domain.DIAntipatternSeverityWarning,
+					ClassName:   class.Name,
+					MethodName:  method.Name,
+					Location:    sourceLocation(filePath, location),
 					Description: fmt.Sprintf("Directly accesses module-level variable '%s'", varName),
 					Suggestion:  "Inject the dependency as a constructor parameter instead of accessing module-level state",
 					Details: map[string]interface{}{
@@ -0,0 +1,389 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// LoopResult describes how control leaves one for or while loop
+type LoopResult struct {
+	Loop   *parser.Node // The for or while statement
+	Header *BasicBlock
+
+	// AlwaysTrue is true for a while loop whose condition is a truthy
+	// literal; its condition never ends the loop and its else clause never runs
+	AlwaysTrue bool
+
+	// HasBreak is true when a break of this loop is reachable
+	HasBreak bool
+
+	// Iterates is true when a reachable path in the body gets back to the
+	// loop header, through the end of the body or a continue
+	Iterates bool
+
+	// UnmodifiedCondition lists the local names of a while condition the
+	// body never modifies, when the condition depends on nothing else
+	UnmodifiedCondition []string
+}
+
+// Infinite reports whether the loop never ends: its condition is always true
+// and its body never breaks
+func (r *LoopResult) Infinite() bool {
+	return r.AlwaysTrue && !r.HasBreak
+}
+
+// HasElse reports whether the loop has an else clause
+func (r *LoopResult) HasElse() bool {
+	return len(r.Loop.Orelse) > 0
+}
+
+// ElseLine returns the line of the loop's else clause
+func (r *LoopResult) ElseLine() int {
+	if !r.HasElse() {
+		return 0
+	}
+	return r.Loop.Orelse[0].Location.StartLine
+}
+
+// LoopAnalysis holds the loops of a function CFG in source order
+type LoopAnalysis struct {
+	Loops []*LoopResult
+
+	// Reachable holds the IDs of the blocks reachable from the entry when
+	// the false edge of a `while True` header is never taken
+	Reachable map[string]bool
+}
+
+// InfiniteLoopBefore returns the last infinite loop ending before line, or
+// nil when there is none
+func (a *LoopAnalysis) InfiniteLoopBefore(line int) *LoopResult {
+	var found *LoopResult
+	for _, loop := range a.Loops {
+		if loop.Infinite() && loop.Loop.Location.EndLine < line {
+			if found == nil || loop.Loop.Location.EndLine > found.Loop.Location.EndLine {
+				found = loop
+			}
+		}
+	}
+	return found
+}
+
+// AlwaysTrueLoopElse returns the always-true while loop whose else clause
+// spans line, or nil when there is none
+func (a *LoopAnalysis) AlwaysTrueLoopElse(line int) *LoopResult {
+	for _, loop := range a.Loops {
+		if loop.AlwaysTrue && loop.HasElse() && line >= loop.ElseLine() && line <= loop.Loop.Location.EndLine {
+			return loop
+		}
+	}
+	return nil
+}
+
+// AnalyzeLoops finds the loops of a function CFG and how control leaves
+// them. Reachability follows the same rules as the dead code detector, and
+// additionally never takes the false edge of a `while True` header, so the
+// code after a loop that never ends is unreachable.
+func AnalyzeLoops(cfg *CFG) *LoopAnalysis {
+	analysis := &LoopAnalysis{Reachable: make(map[string]bool)}
+	if cfg == nil || cfg.Entry == nil {
+		return analysis
+	}
+
+	stack := []*BasicBlock{cfg.Entry}
+	for len(stack) > 0 {
+		block := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if block == nil || analysis.Reachable[block.ID] {
+			continue
+		}
+		analysis.Reachable[block.ID] = true
+
+		terminates := blockHasTerminator(block)
+		for _, edge := range block.Successors {
+			if edge == nil || edge.To == nil {
+				continue
+			}
+			if terminates && edge.Type == EdgeNormal {
+				continue
+			}
+			if edge.Type == EdgeCondFalse && alwaysTakesTrueBranch(block) {
+				continue
+			}
+			stack = append(stack, edge.To)
+		}
+	}
+
+	// Map each statement to its block to tell whether a break is reachable
+	blockOf := make(map[*parser.Node]*BasicBlock)
+	for _, block := range cfg.Blocks {
+		for _, value := range block.Statements {
+			if stmt, ok := pythonNode(value); ok {
+				blockOf[stmt] = block
+			}
+		}
+	}
+
+	locals := functionLocals(complexitySourceNode(cfg))
+	for stmt, header := range blockOf {
+		if !isLoopHeader(stmt, header) || !analysis.Reachable[header.ID] {
+			continue
+		}
+		loop := &LoopResult{
+			Loop:       stmt,
+			Header:     header,
+			AlwaysTrue: stmt.Type == parser.NodeWhile && isTruthyConstant(stmt.Test),
+		}
+		for _, brk := range loopBreaks(stmt) {
+			if block := blockOf[brk]; block != nil && analysis.Reachable[block.ID] {
+				loop.HasBreak = true
+				break
+			}
+		}
+		for _, edge := range header.Predecessors {
+			if edge == nil || edge.From == nil || !analysis.Reachable[edge.From.ID] {
+				continue
+			}
+			if edge.Type == EdgeLoop || edge.Type == EdgeContinue {
+				loop.Iterates = true
+				break
+			}
+		}
+		if stmt.Type == parser.NodeWhile && !loop.AlwaysTrue {
+			loop.UnmodifiedCondition = unmodifiedConditionNames(stmt, locals)
+		}
+		analysis.Loops = append(analysis.Loops, loop)
+	}
+
+	sort.Slice(analysis.Loops, func(i, j int) bool {
+		return analysis.Loops[i].Loop.Location.StartLine < analysis.Loops[j].Loop.Location.StartLine
+	})
+	return analysis
+}
+
+// isLoopHeader reports whether block is the header of the loop stmt
+func isLoopHeader(stmt *parser.Node, block *BasicBlock) bool {
+	switch stmt.Type {
+	case parser.NodeFor, parser.NodeAsyncFor, parser.NodeWhile:
+		return strings.HasPrefix(block.Label, LabelLoopHeader) || strings.HasPrefix(block.Label, LabelAsyncLoopHeader)
+	}
+	return false
+}
+
+// loopBreaks returns the break statements of a loop's body that leave this
+// loop rather than a nested one. A break in the else clause of a nested
+// loop leaves this one; a break in this loop's own else clause does not.
+func loopBreaks(loop *parser.Node) []*parser.Node {
+	var breaks []*parser.Node
+	var visit func(stmts []*parser.Node)
+	visit = func(stmts []*parser.Node) {
+		for _, stmt := range stmts {
+			stmt.Walk(func(n *parser.Node) bool {
+				switch n.Type {
+				case parser.NodeBreak:
+					breaks = append(breaks, n)
+				case parser.NodeFor, parser.NodeAsyncFor, parser.NodeWhile:
+					visit(loopElseStatements(n))
+					return false
+				case parser.NodeFunctionDef, parser.NodeAsyncFunctionDef, parser.NodeClassDef, parser.NodeLambda:
+					return false
+				}
+				return true
+			})
+		}
+	}
+	visit(loop.Body)
+	return breaks
+}
+
+// pureConditionCalls are builtins whose result depends only on their
+// arguments, so a condition calling them changes only when they do
+var pureConditionCalls = map[string]bool{
+	"len": true, "abs": true, "min": true, "max": true, "bool": true,
+	"int": true, "float": true, "str": true, "round": true,
+}
+
+// readOnlyCalls are builtins that never change their arguments, so passing
+// a name to them does not modify it
+var readOnlyCalls = map[string]bool{
+	"print": true, "repr": true, "format": true, "isinstance": true, "hash": true,
+	"id": true, "type": true, "sorted": true,
+}
+
+// unmodifiedConditionNames returns the names of a while condition when the
+// condition reads only local names, literals and pure builtins, and the
+// loop body never rebinds or mutates any of them. Such a condition never
+// changes between iterations: the loop runs zero times or until it breaks.
+func unmodifiedConditionNames(loop *parser.Node, locals map[string]bool) []string {
+	names := make(map[string]bool)
+	pure := true
+	loop.Test.Walk(func(n *parser.Node) bool {
+		switch n.Type {
+		case parser.NodeName:
+			names[n.Name] = true
+		case parser.NodeCall:
+			callee, _ := n.Value.(*parser.Node)
+			if callee == nil || callee.Type != parser.NodeName || !pureConditionCalls[callee.Name] || len(n.Keywords) > 0 {
+				pure = false
+				return false
+			}
+			for _, arg := range n.Args {
+				if arg.Type != parser.NodeName && arg.Type != parser.NodeConstant {
+					pure = false
+				}
+				arg.Walk(func(a *parser.Node) bool {
+					if a.Type == parser.NodeName {
+						names[a.Name] = true
+					}
+					return true
+				})
+			}
+			return false
+		case parser.NodeAttribute, parser.NodeSubscript, parser.NodeNamedExpr, parser.NodeAwait,
+			parser.NodeLambda, parser.NodeListComp, parser.NodeSetComp, parser.NodeDictComp, parser.NodeGeneratorExp,
+			parser.NodeYield, parser.NodeYieldFrom:
+			pure = false
+			return false
+		}
+		return true
+	})
+	if !pure || len(names) == 0 {
+		return nil
+	}
+
+	modified := make(map[string]bool)
+	for _, stmt := range loop.Body {
+		collectModifiedNames(stmt, modified)
+	}
+
+	var unmodified []string
+	for name := range names {
+		if !locals[name] || modified[name] {
+			return nil
+		}
+		unmodified = append(unmodified, name)
+	}
+	sort.Strings(unmodified)
+	return unmodified
+}
+
+// functionLocals returns the names bound in a function: its parameters and
+// the names its body assigns, minus those declared global or nonlocal
+func functionLocals(funcNode *parser.Node) map[string]bool {
+	locals := make(map[string]bool)
+	if funcNode == nil || (funcNode.Type != parser.NodeFunctionDef && funcNode.Type != parser.NodeAsyncFunctionDef) {
+		return locals
+	}
+	for _, arg := range funcNode.Args {
+		if arg != nil && arg.Type == parser.NodeArg {
+			locals[strings.TrimLeft(arg.Name, "*")] = true
+		}
+	}
+
+	declared := make(map[string]bool)
+	for _, stmt := range funcNode.Body {
+		walkAsyncScope(stmt, func(n *parser.Node) {
+			switch n.Type {
+			case parser.NodeGlobal, parser.NodeNonlocal:
+				for _, name := range n.Names {
+					declared[name] = true
+				}
+			default:
+				collectBoundNames(n, locals)
+			}
+		})
+	}
+	for name := range declared {
+		delete(locals, name)
+	}
+	return locals
+}
+
+// collectBoundNames adds the names a single node binds
+func collectBoundNames(n *parser.Node, names map[string]bool) {
+	switch n.Type {
+	case parser.NodeAssign, parser.NodeAugAssign, parser.NodeAnnAssign, parser.NodeFor, parser.NodeAsyncFor, parser.NodeDelete:
+		for _, target := range n.Targets {
+			addTargetNames(target, names)
+		}
+	case parser.NodeWithItem:
+		addTargetNames(n.Target, names)
+		if n.Name != "" {
+			names[n.Name] = true
+		}
+	case parser.NodeNamedExpr:
+		if len(n.Children) > 0 {
+			addTargetNames(n.Children[0], names)
+		}
+	case parser.NodeExceptHandler:
+		if n.Name != "" {
+			names[n.Name] = true
+		}
+	case parser.NodeFunctionDef, parser.NodeAsyncFunctionDef, parser.NodeClassDef:
+		names[n.Name] = true
+	case parser.NodeImport, parser.NodeImportFrom:
+		for _, name := range n.Names {
+			root, _, _ := strings.Cut(name, ".")
+			names[root] = true
+		}
+		for _, child := range n.Children {
+			if asName, ok := child.Value.(string); ok && child.Type == parser.NodeAlias && asName != "" {
+				names[asName] = true
+			}
+		}
+	}
+}
+
+// collectModifiedNames adds the names a statement may change: the names it
+// binds, the objects whose attributes or items it assigns, the receivers of
+// its method calls and the names it passes to calls other than read-only
+// builtins
+func collectModifiedNames(stmt *parser.Node, modified map[string]bool) {
+	stmt.Walk(func(n *parser.Node) bool {
+		collectBoundNames(n, modified)
+		switch n.Type {
+		case parser.NodeGlobal, parser.NodeNonlocal:
+			for _, name := range n.Names {
+				modified[name] = true
+			}
+		case parser.NodeCall:
+			callee, _ := n.Value.(*parser.Node)
+			if callee != nil && callee.Type == parser.NodeAttribute {
+				addTargetNames(callee, modified)
+			}
+			if callee != nil && callee.Type == parser.NodeName && (readOnlyCalls[callee.Name] || pureConditionCalls[callee.Name]) {
+				return true
+			}
+			for _, arg := range append(append([]*parser.Node{}, n.Args...), n.Keywords...) {
+				arg.Walk(func(a *parser.Node) bool {
+					if a.Type == parser.NodeName {
+						modified[a.Name] = true
+					}
+					return true
+				})
+			}
+		}
+		return true
+	})
+}
+
+// addTargetNames adds the names an assignment target binds, or the object
+// an attribute or subscript target changes
+func addTargetNames(target *parser.Node, names map[string]bool) {
+	if target == nil {
+		return
+	}
+	switch target.Type {
+	case parser.NodeName:
+		names[target.Name] = true
+	case parser.NodeAttribute, parser.NodeSubscript:
+		if base, ok := target.Value.(*parser.Node); ok {
+			addTargetNames(base, names)
+		}
+	default:
+		for _, child := range target.Children {
+			addTargetNames(child, names)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loopFindings(t *testing.T, code, function string) map[DeadCodeReason][]int {
+	t.Helper()
+	cfgs, err := NewCFGBuilder().BuildAll(parseSource(t, code))
+	require.NoError(t, err)
+
+	reasons := make(map[DeadCodeReason][]int)
+	for _, finding := range DetectInFunction(cfgs[function]).Findings {
+		reasons[finding.Reason] = append(reasons[finding.Reason], finding.StartLine)
+	}
+	return reasons
+}
+
+func TestDeadCodeAfterInfiniteLoop(t *testing.T) {
+	code := `
+def serve(queue):
+    while True:
+        handle(queue.get())
+    close(queue)
+`
+	cfgs, err := NewCFGBuilder().BuildAll(parseSource(t, code))
+	require.NoError(t, err)
+
+	result := DetectInFunction(cfgs["serve"])
+	require.Len(t, result.Findings, 1)
+	finding := result.Findings[0]
+	assert.Equal(t, ReasonUnreachableAfterInfiniteLoop, finding.Reason)
+	assert.Equal(t, SeverityLevelCritical, finding.Severity)
+	assert.Equal(t, 5, finding.StartLine)
+	assert.Contains(t, finding.Description, "line 3")
+	assert.Equal(t, 1, result.DeadBlocks)
+}
+
+func TestInfiniteLoopWithBreak(t *testing.T) {
+	reasons := loopFindings(t, `
+def poll(queue):
+    while 1:
+        item = queue.get()
+        if item is None:
+            break
+    else:
+        log("never")
+    close(queue)
+`, "poll")
+
+	assert.Empty(t, reasons[ReasonUnreachableAfterInfiniteLoop])
+	assert.Equal(t, []int{8}, reasons[ReasonUnreachableBranch])
+}
+
+func TestLoopElseFindings(t *testing.T) {
+	tests := []struct {
+		name   string
+		code   string
+		reason DeadCodeReason
+		lines  []int
+	}{
+		{
+			name: "ElseWithoutBreak",
+			code: `
+def f(items):
+    for item in items:
+        handle(item)
+    else:
+        log("done")
+`,
+			reason: ReasonUselessLoopElse,
+			lines:  []int{5},
+		},
+		{
+			name: "ElseWithBreak",
+			code: `
+def f(items):
+    for item in items:
+        if item:
+            break
+    else:
+        return None
+    return item
+`,
+		},
+		{
+			name: "BreakOnlyInNestedLoop",
+			code: `
+def f(rows):
+    for row in rows:
+        for cell in row:
+            break
+    else:
+        log("done")
+`,
+			reason: ReasonUselessLoopElse,
+			lines:  []int{6},
+		},
+		{
+			name: "BodyAlwaysBreaks",
+			code: `
+def f(items):
+    for item in items:
+        handle(item)
+        break
+    else:
+        log("empty")
+`,
+			reason: ReasonLoopAlwaysBreaks,
+			lines:  []int{3},
+		},
+		{
+			name: "BreakOrContinue",
+			code: `
+def f(items):
+    for item in items:
+        if not item:
+            continue
+        break
+    else:
+        log("none")
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reasons := loopFindings(t, tt.code, "f")
+			if tt.reason == "" {
+				assert.Empty(t, reasons[ReasonUselessLoopElse])
+				assert.Empty(t, reasons[ReasonLoopAlwaysBreaks])
+				return
+			}
+			assert.Equal(t, tt.lines, reasons[tt.reason])
+		})
+	}
+}
+
+func TestLoopConditionUnmodified(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		reported bool
+	}{
+		{
+			name: "NeverIncremented",
+			code: `
+def f(n):
+    i = 0
+    while i < n:
+        print(i)
+`,
+			reported: true,
+		},
+		{
+			name: "PureBuiltin",
+			code: `
+def f(items):
+    i = 0
+    total = 0
+    while i < len(items):
+        total += items[i]
+    return total
+`,
+			reported: true,
+		},
+		{
+			name: "Incremented",
+			code: `
+def f(n):
+    i = 0
+    while i < n:
+        i += 1
+`,
+		},
+		{
+			name: "MutatedByMethod",
+			code: `
+def f(stack):
+    while stack:
+        stack.pop()
+`,
+		},
+		{
+			name: "PassedToCall",
+			code: `
+def f(queue):
+    while queue:
+        drain(queue)
+`,
+		},
+		{
+			name: "GlobalName",
+			code: `
+def f():
+    while running:
+        tick()
+`,
+		},
+		{
+			name: "AttributeCondition",
+			code: `
+def f(self):
+    while self.running:
+        tick()
+`,
+		},
+		{
+			name: "CallCondition",
+			code: `
+def f(event):
+    while not event.is_set():
+        tick()
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reasons := loopFindings(t, tt.code, "f")
+			if tt.reported {
+				assert.Len(t, reasons[ReasonLoopConditionUnmodified], 1)
+			} else {
+				assert.Empty(t, reasons[ReasonLoopConditionUnmodified])
+			}
+		})
+	}
+}
@@ -34,6 +34,13 @@ func (idx *lshCandidateIndex) AddFragment(id int, signature *corelsh.MinHashSign
 }
 
 func (idx *lshCandidateIndex) FindCandidates(signature *corelsh.MinHashSignature) []int {
+	ids, _ := idx.findCandidates(signature)
+	return ids
+}
+
+// findCandidates returns the candidates of a signature and whether the
+// candidate limit cut them short
+func (idx *lshCandidateIndex) findCandidates(signature *corelsh.MinHashSignature) ([]int, bool) {
 	candidates := idx.index.FindCandidatesLimit(signature, idx.maxCandidates)
 	ids := make([]int, 0, len(candidates))
 	for _, candidate := range candidates {
@@ -43,5 +50,5 @@ func (idx *lshCandidateIndex) FindCandidates(signature *corelsh.MinHashSignature
 		}
 	}
 	sort.Ints(ids)
-	return ids
+	return ids, len(candidates) >= idx.maxCandidates
 }
@@ -46,7 +46,7 @@ func prepareTestFragment(t *testing.T, cfg *CloneDetectorConfig, fragment *CodeF
 	t.Helper()
 	detector := NewCloneDetector(cfg)
 	detector.fragments = []*CodeFragment{fragment}
-	detector.prepareFragments()
+	detector.prepareFragments(context.Background())
 }
 
 func TestCloneDetector_DetectClonesWithLSH_Simple(t *testing.T) {
@@ -0,0 +1,246 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// Tree-sitter node types used for match statement patterns
+const (
+	patternCase     = "case_pattern"
+	patternUnion    = "union_pattern"
+	patternAs       = "as_pattern"
+	patternTuple    = "tuple_pattern"
+	patternDotted   = "dotted_name"
+	patternWildcard = "_"
+)
+
+// MatchCaseInfo describes how a single case clause relates to the clauses
+// before it
+type MatchCaseInfo struct {
+	Case        *parser.Node
+	Guarded     bool // Has an `if` guard
+	Irrefutable bool // Pattern matches any subject
+	Shadowed    bool // An earlier unguarded clause already matches everything this clause matches
+}
+
+// AnalyzeMatchCases classifies each case clause of a match statement.
+// A clause is shadowed when it follows an unguarded irrefutable clause
+// (a wildcard or bare capture), or when every alternative of its pattern
+// is a literal or value pattern already handled by an earlier unguarded
+// clause.
+func AnalyzeMatchCases(match *parser.Node) []MatchCaseInfo {
+	if match == nil || match.Type != parser.NodeMatch {
+		return nil
+	}
+
+	infos := make([]MatchCaseInfo, 0, len(match.Body))
+	seen := make(map[string]bool)
+	catchAll := false
+
+	for _, caseNode := range match.Body {
+		if caseNode == nil || caseNode.Type != parser.NodeMatchCase {
+			continue
+		}
+		info := MatchCaseInfo{
+			Case:        caseNode,
+			Guarded:     caseNode.Value != nil,
+			Irrefutable: isIrrefutablePattern(caseNode.Test),
+		}
+
+		alternatives := patternAlternatives(caseNode.Test)
+		signatures := make([]string, 0, len(alternatives))
+		for _, alt := range alternatives {
+			if sig := patternSignature(alt); sig != "" {
+				signatures = append(signatures, sig)
+			}
+		}
+
+		if catchAll {
+			info.Shadowed = true
+		} else if len(signatures) > 0 && len(signatures) == len(alternatives) {
+			info.Shadowed = true
+			for _, sig := range signatures {
+				if !seen[sig] {
+					info.Shadowed = false
+					break
+				}
+			}
+		}
+
+		if !info.Guarded {
+			if info.Irrefutable {
+				catchAll = true
+			}
+			for _, sig := range signatures {
+				seen[sig] = true
+			}
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos
+}
+
+// HasIrrefutableCase reports whether a match statement has an unguarded
+// clause that matches any subject
+func HasIrrefutableCase(infos []MatchCaseInfo) bool {
+	for _, info := range infos {
+		if info.Irrefutable && !info.Guarded {
+			return true
+		}
+	}
+	return false
+}
+
+// enumMatchClass returns the shared class name when every unguarded clause of
+// a match statement is a value pattern of the same enum-like class
+// (`case Color.RED | Color.GREEN:`), and an empty string otherwise
+func enumMatchClass(infos []MatchCaseInfo) string {
+	class := ""
+	for _, info := range infos {
+		if info.Guarded {
+			continue
+		}
+		for _, alt := range patternAlternatives(info.Case.Test) {
+			parts := dottedNameParts(alt)
+			if len(parts) < 2 {
+				return ""
+			}
+			prefix := strings.Join(parts[:len(parts)-1], ".")
+			if class == "" {
+				class = prefix
+			} else if class != prefix {
+				return ""
+			}
+		}
+	}
+	return class
+}
+
+// isIrrefutablePattern reports whether a pattern matches any subject
+func isIrrefutablePattern(pattern *parser.Node) bool {
+	pattern = unwrapPattern(pattern)
+	if pattern == nil {
+		return false
+	}
+
+	switch string(pattern.Type) {
+	case patternWildcard:
+		return true
+	case patternDotted:
+		// A bare name is a capture pattern; dotted names are value patterns
+		return len(dottedNameParts(pattern)) == 1
+	case patternAs:
+		for _, child := range pattern.Children {
+			if string(child.Type) == patternCase {
+				return isIrrefutablePattern(child)
+			}
+		}
+	case patternUnion:
+		for _, alt := range patternAlternatives(pattern) {
+			if isIrrefutablePattern(alt) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// patternAlternatives splits an or-pattern into its alternatives
+func patternAlternatives(pattern *parser.Node) []*parser.Node {
+	pattern = unwrapPattern(pattern)
+	if pattern == nil {
+		return nil
+	}
+	if string(pattern.Type) != patternUnion {
+		return []*parser.Node{pattern}
+	}
+
+	var alternatives []*parser.Node
+	for _, child := range pattern.Children {
+		if child.Type == "|" {
+			continue
+		}
+		alternatives = append(alternatives, patternAlternatives(child)...)
+	}
+	return alternatives
+}
+
+// unwrapPattern strips case_pattern wrappers and parenthesized groups
+func unwrapPattern(pattern *parser.Node) *parser.Node {
+	for pattern != nil {
+		switch string(pattern.Type) {
+		case patternCase:
+			if len(pattern.Children) != 1 {
+				return pattern
+			}
+			pattern = pattern.Children[0]
+		case patternTuple:
+			inner := groupedPattern(pattern)
+			if inner == nil {
+				return pattern
+			}
+			pattern = inner
+		default:
+			return pattern
+		}
+	}
+	return nil
+}
+
+// groupedPattern returns the inner pattern of `(p)`, or nil for a tuple
+// pattern such as `(p,)` or `(a, b)`
+func groupedPattern(tuple *parser.Node) *parser.Node {
+	var inner *parser.Node
+	for _, child := range tuple.Children {
+		switch child.Type {
+		case "(", ")":
+		case ",":
+			return nil
+		default:
+			if inner != nil {
+				return nil
+			}
+			inner = child
+		}
+	}
+	return inner
+}
+
+// patternSignature returns a comparable key for literal and value patterns,
+// or an empty string for patterns that cannot be compared structurally
+func patternSignature(pattern *parser.Node) string {
+	if pattern == nil {
+		return ""
+	}
+	switch {
+	case pattern.Type == parser.NodeConstant:
+		if pattern.Value == nil {
+			return "literal:None"
+		}
+		return fmt.Sprintf("literal:%T:%v", pattern.Value, pattern.Value)
+	case string(pattern.Type) == patternDotted:
+		if parts := dottedNameParts(pattern); len(parts) > 1 {
+			return "value:" + strings.Join(parts, ".")
+		}
+	}
+	return ""
+}
+
+// dottedNameParts returns the names of a dotted_name pattern node
+func dottedNameParts(node *parser.Node) []string {
+	if node == nil || string(node.Type) != patternDotted {
+		return nil
+	}
+	var parts []string
+	for _, child := range node.Children {
+		if child.Type == parser.NodeName {
+			parts = append(parts, child.Name)
+		}
+	}
+	return parts
+}
@@ -0,0 +1,74 @@
+package analyzer
+
+import (
+	"testing"
+)
+
+func TestAnalyzeMatchCases(t *testing.T) {
+	source := `
+match value:
+    case 1:
+        pass
+    case (x) if x > 2:
+        pass
+    case 1 | 2:
+        pass
+    case None:
+        pass
+    case [a, b] as pair:
+        pass
+    case (_):
+        pass
+    case Point(x=0):
+        pass
+    case _:
+        pass
+`
+	ast := parseSource(t, source)
+	infos := AnalyzeMatchCases(ast.Body[0])
+	if len(infos) != 8 {
+		t.Fatalf("expected 8 cases, got %d", len(infos))
+	}
+
+	want := []struct {
+		guarded, irrefutable, shadowed bool
+	}{
+		{false, false, false}, // 1
+		{true, true, false},   // (x) if x > 2: guarded capture does not shadow
+		{false, false, false}, // 1 | 2: 2 is new
+		{false, false, false}, // None
+		{false, false, false}, // [a, b] as pair
+		{false, true, false},  // (_)
+		{false, false, true},  // Point(x=0): after a catch-all
+		{false, true, true},   // _: duplicate catch-all
+	}
+	for i, w := range want {
+		got := infos[i]
+		if got.Guarded != w.guarded || got.Irrefutable != w.irrefutable || got.Shadowed != w.shadowed {
+			t.Errorf("case %d: got guarded=%v irrefutable=%v shadowed=%v, want %v %v %v",
+				i+1, got.Guarded, got.Irrefutable, got.Shadowed, w.guarded, w.irrefutable, w.shadowed)
+		}
+	}
+	if !HasIrrefutableCase(infos) {
+		t.Error("expected an unguarded catch-all case")
+	}
+}
+
+func TestEnumMatchClass(t *testing.T) {
+	tests := []struct {
+		source string
+		class  string
+	}{
+		{"match c:\n    case Color.RED:\n        pass\n    case Color.BLUE | Color.GREEN:\n        pass\n", "Color"},
+		{"match c:\n    case enums.Color.RED:\n        pass\n", "enums.Color"},
+		{"match c:\n    case Color.RED:\n        pass\n    case Shape.SQUARE:\n        pass\n", ""},
+		{"match c:\n    case Color.RED:\n        pass\n    case 1:\n        pass\n", ""},
+		{"match c:\n    case Color.RED:\n        pass\n    case x if x:\n        pass\n", "Color"},
+	}
+	for _, tt := range tests {
+		infos := AnalyzeMatchCases(parseSource(t, tt.source).Body[0])
+		if got := enumMatchClass(infos); got != tt.class {
+			t.Errorf("enumMatchClass(%q) = %q, want %q", tt.source, got, tt.class)
+		}
+	}
+}
@@ -27,10 +27,19 @@ type ModuleAnalyzer struct {
 	// Re-export resolution
 	reExportResolver *ReExportResolver // Resolves re-exports in __init__.py files
 
+	// Top-level package names of the modules in the graph being analyzed
+	projectPackages map[string]bool
+
+	// Re-export facades of the graph being analyzed, by module name
+	facades          map[string]*FacadeModule
+	facadeMinExports int
+	collapseFacades  bool
+
 	// Analysis options
 	includeStdLib     bool
 	includeThirdParty bool
 	followRelative    bool
+	includeTypeCheck  bool
 }
 
 var pythonModuleExtensions = [...]string{".py", ".pyi"}
@@ -45,6 +54,18 @@ type ModuleAnalysisOptions struct {
 	IncludeStdLib     *bool    // Include standard library dependencies
 	IncludeThirdParty *bool    // Include third-party dependencies
 	FollowRelative    *bool    // Follow relative imports
+
+	// IncludeTypeChecking records imports inside `if TYPE_CHECKING:` blocks as
+	// edges tagged IsTypeChecking instead of dropping them
+	IncludeTypeChecking *bool
+
+	// FacadeMinExports is the number of re-exported names that makes a
+	// package __init__ holding nothing but re-exports a facade (0 = default)
+	FacadeMinExports int
+
+	// CollapseFacades routes imports through facades to the modules defining
+	// the imported names and drops the facades' own re-export edges
+	CollapseFacades *bool
 }
 
 // DefaultModuleAnalysisOptions returns default analysis options
@@ -55,6 +76,10 @@ func DefaultModuleAnalysisOptions() *ModuleAnalysisOptions {
 		IncludeStdLib:     domain.BoolPtr(false),
 		IncludeThirdParty: domain.BoolPtr(true),
 		FollowRelative:    domain.BoolPtr(true),
+
+		IncludeTypeChecking: domain.BoolPtr(false),
+		FacadeMinExports:    domain.DefaultFacadeMinExports,
+		CollapseFacades:     domain.BoolPtr(false),
 	}
 }
 
@@ -88,6 +113,12 @@ func NewModuleAnalyzer(options *ModuleAnalysisOptions) (*ModuleAnalyzer, error)
 		includeStdLib:     domain.BoolValue(options.IncludeStdLib, domain.BoolValue(defaults.IncludeStdLib, false)),
 		includeThirdParty: domain.BoolValue(options.IncludeThirdParty, domain.BoolValue(defaults.IncludeThirdParty, true)),
 		followRelative:    domain.BoolValue(options.FollowRelative, domain.BoolValue(defaults.FollowRelative, true)),
+		includeTypeCheck:  domain.BoolValue(options.IncludeTypeChecking, false),
+		facadeMinExports:  options.FacadeMinExports,
+		collapseFacades:   domain.BoolValue(options.CollapseFacades, false),
+	}
+	if analyzer.facadeMinExports <= 0 {
+		analyzer.facadeMinExports = defaults.FacadeMinExports
 	}
 	analyzer.pythonPath = append([]string(nil), analyzer.moduleRoots...)
 	analyzer.reExportResolver = NewReExportResolverWithRoots(absRoot, analyzer.moduleRoots)
@@ -180,6 +211,8 @@ func (ma *ModuleAnalyzer) AnalyzeProject() (*DependencyGraph, error) {
 			graph.AddModule(moduleName, filePath)
 		}
 	}
+	ma.projectPackages = projectTopLevelPackages(graph)
+	ma.detectFacades(graph)
 
 	// Second pass: Analyze dependencies for each module
 	for _, filePath := range files {
@@ -194,6 +227,12 @@ func (ma *ModuleAnalyzer) AnalyzeProject() (*DependencyGraph, error) {
 
 // AnalyzeFiles analyzes specific Python files and builds a dependency graph
 func (ma *ModuleAnalyzer) AnalyzeFiles(filePaths []string) (*DependencyGraph, error) {
+	return ma.AnalyzeFilesWithContext(context.Background(), filePaths)
+}
+
+// AnalyzeFilesWithContext is AnalyzeFiles with cancellation checked before
+// each file. A cancelled run returns the context error and no graph.
+func (ma *ModuleAnalyzer) AnalyzeFilesWithContext(ctx context.Context, filePaths []string) (*DependencyGraph, error) {
 	graph := NewDependencyGraph(ma.projectRoot)
 
 	// Filter and validate files
@@ -219,9 +258,14 @@ func (ma *ModuleAnalyzer) AnalyzeFiles(filePaths []string) (*DependencyGraph, er
 			graph.AddModule(moduleName, filePath)
 		}
 	}
+	ma.projectPackages = projectTopLevelPackages(graph)
+	ma.detectFacades(graph)
 
 	// Analyze dependencies
 	for _, filePath := range validFiles {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		if err := ma.analyzeModuleDependencies(graph, filePath); err != nil {
 			continue
 		}
@@ -264,11 +308,27 @@ func (ma *ModuleAnalyzer) analyzeModuleDependencies(graph *DependencyGraph, file
 	module.PublicNames = facts.publicNames
 	module.LineCount = countSourceLines(content)
 
+	for _, dyn := range facts.unresolvedDynamic {
+		dyn.FromModule = moduleName
+		dyn.FilePath = filePath
+		graph.UnresolvedDynamicImports = append(graph.UnresolvedDynamicImports, dyn)
+	}
+
 	// Process each import
 	for _, imp := range facts.imports {
-		// Skip TYPE_CHECKING imports entirely: they never execute at runtime,
-		// so they are not real dependencies for any analysis.
-		if imp.IsTypeChecking {
+		ma.recordExternalImport(graph, moduleName, filePath, imp)
+
+		// A collapsed facade only forwards names; its importers depend on
+		// the defining modules directly
+		if ma.isCollapsedFacade(moduleName) {
+			continue
+		}
+
+		// TYPE_CHECKING imports never execute at runtime, so unless requested
+		// they are not dependencies for any analysis. When included, their
+		// edges are tagged so cycle detection and architecture rules can
+		// ignore them.
+		if imp.IsTypeChecking && !ma.includeTypeCheck {
 			continue
 		}
 
@@ -284,12 +344,13 @@ func (ma *ModuleAnalyzer) analyzeModuleDependencies(graph *DependencyGraph, file
 		}
 
 		edgeType := ma.dependencyEdgeType(imp)
-		for _, resolvedModule := range ma.importDependencyTargets(graph, imp, targetModule) {
+		targets := ma.importDependencyTargets(graph, imp, targetModule)
+		for _, resolvedModule := range sortedModuleNames(targets) {
 			if ma.shouldSkipPackageInitDependency(filePath, moduleName, resolvedModule) {
 				continue
 			}
 			if ma.shouldIncludeDependency(resolvedModule) {
-				graph.AddDependency(moduleName, resolvedModule, edgeType, imp)
+				graph.addDependency(moduleName, resolvedModule, edgeType, imp, targets[resolvedModule])
 			}
 		}
 	}
@@ -297,6 +358,45 @@ func (ma *ModuleAnalyzer) analyzeModuleDependencies(graph *DependencyGraph, file
 	return nil
 }
 
+// recordExternalImport adds imp to the graph's external imports when it is an
+// absolute import that does not resolve to a module of the project
+func (ma *ModuleAnalyzer) recordExternalImport(graph *DependencyGraph, moduleName, filePath string, imp *ImportInfo) {
+	if imp.IsRelative {
+		return
+	}
+	importName := ma.moduleNameFromImport(imp)
+	if importName == "" {
+		return
+	}
+	if resolved := ma.resolveAbsoluteImportWithProject(imp, filePath); resolved != "" && graph.GetModule(resolved) != nil {
+		return
+	}
+	topLevel, _, _ := strings.Cut(importName, ".")
+	if ma.projectPackages[topLevel] {
+		return
+	}
+
+	graph.ExternalImports = append(graph.ExternalImports, &ExternalImport{
+		Module:         importName,
+		TopLevel:       topLevel,
+		FromModule:     moduleName,
+		FilePath:       filePath,
+		Line:           imp.Line,
+		IsTypeChecking: imp.IsTypeChecking,
+		IsLazy:         imp.IsLazy,
+	})
+}
+
+// projectTopLevelPackages returns the first component of every module name in graph
+func projectTopLevelPackages(graph *DependencyGraph) map[string]bool {
+	packages := make(map[string]bool, len(graph.Nodes))
+	for name := range graph.Nodes {
+		topLevel, _, _ := strings.Cut(name, ".")
+		packages[topLevel] = true
+	}
+	return packages
+}
+
 func (ma *ModuleAnalyzer) dependencyEdgeType(imp *ImportInfo) DependencyEdgeType {
 	if imp.IsRelative {
 		return DependencyEdgeRelative
@@ -307,34 +407,41 @@ func (ma *ModuleAnalyzer) dependencyEdgeType(imp *ImportInfo) DependencyEdgeType
 	return DependencyEdgeImport
 }
 
-func (ma *ModuleAnalyzer) importDependencyTargets(graph *DependencyGraph, imp *ImportInfo, targetModule string) []string {
+// importDependencyTargets maps each module an import depends on to the
+// names it imports from that module
+func (ma *ModuleAnalyzer) importDependencyTargets(graph *DependencyGraph, imp *ImportInfo, targetModule string) map[string][]string {
 	if len(imp.ImportedNames) == 0 {
-		return []string{targetModule}
+		return map[string][]string{targetModule: nil}
 	}
 
-	targets := make(map[string]bool, len(imp.ImportedNames))
+	targets := make(map[string][]string, len(imp.ImportedNames))
 	for _, importedName := range imp.ImportedNames {
 		if importedName == "*" {
-			targets[targetModule] = true
+			targets[targetModule] = append(targets[targetModule], importedName)
+			continue
+		}
+
+		if source := ma.facadeTarget(targetModule, importedName); source != "" {
+			targets[source] = append(targets[source], importedName)
 			continue
 		}
 
 		if !imp.IsRelative {
 			if resolvedModule, found := ma.reExportResolver.ResolveReExport(targetModule, importedName); found {
-				targets[resolvedModule] = true
+				targets[resolvedModule] = append(targets[resolvedModule], importedName)
 				continue
 			}
 		}
 
 		if concreteModule := importedNameModule(targetModule, importedName); graph.GetModule(concreteModule) != nil {
-			targets[concreteModule] = true
+			targets[concreteModule] = append(targets[concreteModule], importedName)
 			continue
 		}
 
-		targets[targetModule] = true
+		targets[targetModule] = append(targets[targetModule], importedName)
 	}
 
-	return sortedModuleNames(targets)
+	return targets
 }
 
 func importedNameModule(moduleName, importedName string) string {
@@ -344,7 +451,7 @@ func importedNameModule(moduleName, importedName string) string {
 	return moduleName + "." + importedName
 }
 
-func sortedModuleNames(moduleSet map[string]bool) []string {
+func sortedModuleNames[V any](moduleSet map[string]V) []string {
 	modules := make([]string, 0, len(moduleSet))
 	for moduleName := range moduleSet {
 		if moduleName != "" {
@@ -361,6 +468,7 @@ func (ma *ModuleAnalyzer) shouldSkipPackageInitDependency(filePath, moduleName,
 
 type moduleFacts struct {
 	imports            []*ImportInfo
+	unresolvedDynamic  []*DynamicImport
 	functionCount      int
 	classCount         int
 	abstractClassCount int
@@ -377,6 +485,15 @@ func (ma *ModuleAnalyzer) collectModuleFacts(ast *parser.Node) moduleFacts {
 		switch node.Type {
 		case parser.NodeImport, parser.NodeImportFrom:
 			facts.imports = append(facts.imports, ma.importsFromNode(node)...)
+		case parser.NodeCall:
+			if imp, function := ma.dynamicImportFromCall(node); imp != nil {
+				facts.imports = append(facts.imports, imp)
+			} else if function != "" {
+				facts.unresolvedDynamic = append(facts.unresolvedDynamic, &DynamicImport{
+					Line:     node.Location.StartLine,
+					Function: function,
+				})
+			}
 		case parser.NodeFunctionDef, parser.NodeAsyncFunctionDef:
 			facts.functionCount++
 			if isPublicName(node.Name) {
@@ -414,6 +531,7 @@ func countSourceLines(content []byte) int {
 func (ma *ModuleAnalyzer) importsFromNode(node *parser.Node) []*ImportInfo {
 	isTypeChecking := ma.isInTypeCheckingBlock(node)
 	isLazy := ma.isInFunctionScope(node)
+	isConditional := ma.isInImportFallback(node)
 
 	switch node.Type {
 	case parser.NodeImport:
@@ -430,6 +548,7 @@ func (ma *ModuleAnalyzer) importsFromNode(node *parser.Node) []*ImportInfo {
 					Line:           node.Location.StartLine,
 					IsTypeChecking: isTypeChecking,
 					IsLazy:         isLazy,
+					IsConditional:  isConditional,
 				}
 				if alias, ok := child.Value.(string); ok {
 					imp.Alias = alias
@@ -448,6 +567,7 @@ func (ma *ModuleAnalyzer) importsFromNode(node *parser.Node) []*ImportInfo {
 				Line:           node.Location.StartLine,
 				IsTypeChecking: isTypeChecking,
 				IsLazy:         isLazy,
+				IsConditional:  isConditional,
 			})
 		}
 		return imports
@@ -482,6 +602,7 @@ func (ma *ModuleAnalyzer) importsFromNode(node *parser.Node) []*ImportInfo {
 			Line:           node.Location.StartLine,
 			IsTypeChecking: isTypeChecking,
 			IsLazy:         isLazy,
+			IsConditional:  isConditional,
 		}
 
 		if imp.IsRelative {
@@ -1086,6 +1207,28 @@ func (ma *ModuleAnalyzer) isInFunctionScope(node *parser.Node) bool {
 	return false
 }
 
+// isInImportFallback reports whether the node is inside a try/except import
+// fallback: the body of a try statement with an except clause, or the except
+// clause itself. Whether such an import runs depends on what is installed.
+func (ma *ModuleAnalyzer) isInImportFallback(node *parser.Node) bool {
+	child := node
+	for current := node.Parent; current != nil; current = current.Parent {
+		switch current.Type {
+		case parser.NodeTry:
+			if len(current.Handlers) > 0 && containsDirectNode(current.Body, child) {
+				return true
+			}
+		case parser.NodeExceptHandler:
+			return true
+		case parser.NodeFunctionDef, parser.NodeAsyncFunctionDef, parser.NodeClassDef:
+			// A try around a function definition does not guard its body
+			return false
+		}
+		child = current
+	}
+	return false
+}
+
 func containsDirectNode(nodes []*parser.Node, target *parser.Node) bool {
 	for _, node := range nodes {
 		if node == target {
@@ -0,0 +1,82 @@
+package analyzer
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEdgeSymbolsAccumulateAcrossImports(t *testing.T) {
+	root := writeEdgeKindProject(t, `from .a import A, helper
+from .a import A, CONST
+
+class B:
+    pass
+`)
+
+	graph := analyzeEdgeKindProject(t, root, false)
+	edge := graph.findEdge("foo.b", "foo.a")
+	if edge == nil {
+		t.Fatal("expected an edge foo.b -> foo.a")
+	}
+	if want := []string{"A", "CONST", "helper"}; !slices.Equal(edge.Symbols, want) {
+		t.Errorf("symbols = %v, want %v", edge.Symbols, want)
+	}
+	if edge.Weight() != 3 {
+		t.Errorf("weight = %d, want 3", edge.Weight())
+	}
+
+	clone := graph.Clone()
+	if cloned := clone.findEdge("foo.b", "foo.a"); cloned == nil || !slices.Equal(cloned.Symbols, edge.Symbols) {
+		t.Error("Clone should keep edge symbols")
+	}
+}
+
+func TestCycleAnnotatedWithSizesAndHeaviestEdge(t *testing.T) {
+	root := writeEdgeKindProject(t, `from .a import A, helper, CONST
+
+class B:
+    pass
+`)
+
+	graph := analyzeEdgeKindProject(t, root, false)
+	result := NewCircularDependencyDetector(graph).DetectCircularDependencies()
+	if len(result.CircularDependencies) != 1 {
+		t.Fatalf("expected 1 cycle, got %d", len(result.CircularDependencies))
+	}
+	cycle := result.CircularDependencies[0]
+
+	for _, module := range []string{"foo.a", "foo.b"} {
+		if cycle.ModuleSizes[module] != graph.Nodes[module].LineCount {
+			t.Errorf("size of %s = %d, want %d", module, cycle.ModuleSizes[module], graph.Nodes[module].LineCount)
+		}
+	}
+
+	for _, chain := range cycle.Dependencies {
+		want := map[string]int{"foo.a": 1, "foo.b": 3}[chain.From]
+		if chain.Weight != want {
+			t.Errorf("weight of %s -> %s = %d, want %d", chain.From, chain.To, chain.Weight, want)
+		}
+	}
+
+	heaviest := cycle.HeaviestEdge
+	if heaviest == nil || heaviest.From != "foo.b" || heaviest.To != "foo.a" {
+		t.Fatalf("heaviest edge = %+v, want foo.b -> foo.a", heaviest)
+	}
+	if want := []string{"A", "CONST", "helper"}; !slices.Equal(heaviest.Symbols, want) {
+		t.Errorf("heaviest edge symbols = %v, want %v", heaviest.Symbols, want)
+	}
+}
+
+func TestHeaviestEdgeTieBreaksByModuleName(t *testing.T) {
+	root := writeEdgeKindProject(t, "from .a import A\n\nclass B:\n    pass\n")
+
+	graph := analyzeEdgeKindProject(t, root, false)
+	cycles := FindSimpleCycles(graph)
+	if len(cycles) != 1 {
+		t.Fatalf("expected 1 cycle, got %d", len(cycles))
+	}
+	heaviest := cycles[0].HeaviestEdge
+	if heaviest == nil || heaviest.From != "foo.a" || heaviest.Weight != 1 {
+		t.Errorf("heaviest edge = %+v, want foo.a -> foo.b with weight 1", heaviest)
+	}
+}
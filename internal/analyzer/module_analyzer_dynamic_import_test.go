@@ -0,0 +1,126 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+func TestDynamicImportEdges(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"app/__init__.py":            "",
+		"app/plugins/__init__.py":    "",
+		"app/plugins/csv_export.py":  "class Exporter:\n    pass\n",
+		"app/plugins/json_export.py": "class Exporter:\n    pass\n",
+		"app/plugins/xml_export.py":  "class Exporter:\n    pass\n",
+		"app/plugins/yaml_export.py": "class Exporter:\n    pass\n",
+		"app/settings.py":            "DEBUG = False\n",
+		"app/registry.py": `import importlib
+from importlib import import_module
+from importlib.metadata import EntryPoint
+
+settings = importlib.import_module("app.settings")
+
+def load(name):
+    csv = import_module(".plugins.csv_export", __package__)
+    json = __import__("app.plugins.json_export")
+    xml = importlib.import_module(".xml_export", package="app.plugins")
+    ep = EntryPoint(name="yaml", value="app.plugins.yaml_export:Exporter", group="app.exporters")
+    return importlib.import_module(f"app.plugins.{name}")
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	analyzer, err := NewModuleAnalyzer(&ModuleAnalysisOptions{
+		ProjectRoot:       tmpDir,
+		IncludeStdLib:     domain.BoolPtr(false),
+		IncludeThirdParty: domain.BoolPtr(false),
+		FollowRelative:    domain.BoolPtr(true),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create module analyzer: %v", err)
+	}
+	graph, err := analyzer.AnalyzeProject()
+	if err != nil {
+		t.Fatalf("Failed to analyze project: %v", err)
+	}
+
+	tests := []struct {
+		target string
+		kind   DependencyEdgeKind
+	}{
+		{"app.settings", EdgeKindDynamic},
+		{"app.plugins.csv_export", EdgeKindLazy},
+		{"app.plugins.json_export", EdgeKindLazy},
+		{"app.plugins.xml_export", EdgeKindLazy},
+		{"app.plugins.yaml_export", EdgeKindLazy},
+	}
+	for _, tt := range tests {
+		edge := graph.findEdge("app.registry", tt.target)
+		if edge == nil {
+			t.Errorf("expected dynamic edge app.registry -> %s", tt.target)
+			continue
+		}
+		if !edge.IsDynamic {
+			t.Errorf("edge to %s should be flagged dynamic", tt.target)
+		}
+		if edge.Kind() != tt.kind {
+			t.Errorf("edge to %s kind = %q, want %q", tt.target, edge.Kind(), tt.kind)
+		}
+	}
+	if !graph.Nodes["app.registry"].DynamicDependencies["app.settings"] {
+		t.Error("app.settings should be recorded as a dynamic dependency")
+	}
+
+	if len(graph.UnresolvedDynamicImports) != 1 {
+		t.Fatalf("expected 1 unresolved dynamic import, got %d", len(graph.UnresolvedDynamicImports))
+	}
+	unresolved := graph.UnresolvedDynamicImports[0]
+	if unresolved.Function != "importlib.import_module" || unresolved.Line != 12 || unresolved.FromModule != "app.registry" {
+		t.Errorf("unexpected unresolved dynamic import: %+v", unresolved)
+	}
+}
+
+func TestDynamicImportPromotedByStaticImport(t *testing.T) {
+	root := writeEdgeKindProject(t, `import importlib
+from . import a
+
+mod = importlib.import_module("foo.a")
+`)
+	graph := analyzeEdgeKindProject(t, root, false)
+	edge := graph.findEdge("foo.b", "foo.a")
+	if edge == nil {
+		t.Fatal("expected foo.b -> foo.a edge")
+	}
+	if edge.IsDynamic || graph.Nodes["foo.b"].DynamicDependencies["foo.a"] {
+		t.Error("a static import of the same module should clear the dynamic flag")
+	}
+}
+
+func TestSplitObjectPath(t *testing.T) {
+	tests := []struct {
+		path, module, attr string
+	}{
+		{"pkg.mod:Class", "pkg.mod", "Class"},
+		{"pkg.mod:Class.method", "pkg.mod", "Class"},
+		{"pkg.mod.Class", "pkg.mod", "Class"},
+		{"pkg", "pkg", ""},
+	}
+	for _, tt := range tests {
+		module, attr := splitObjectPath(tt.path)
+		if module != tt.module || attr != tt.attr {
+			t.Errorf("splitObjectPath(%q) = (%q, %q), want (%q, %q)", tt.path, module, attr, tt.module, tt.attr)
+		}
+	}
+}
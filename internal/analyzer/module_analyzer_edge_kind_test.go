@@ -0,0 +1,163 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// writeEdgeKindProject creates package foo where a imports b at module level
+// and b imports a through the import kind under test.
+func writeEdgeKindProject(t *testing.T, moduleB string) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	pkgDir := filepath.Join(tmpDir, "foo")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatalf("Failed to create package dir: %v", err)
+	}
+	files := map[string]string{
+		"__init__.py": "",
+		"a.py":        "from .b import B\n\nclass A:\n    pass\n",
+		"b.py":        moduleB,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(pkgDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return tmpDir
+}
+
+func analyzeEdgeKindProject(t *testing.T, root string, includeTypeChecking bool) *DependencyGraph {
+	t.Helper()
+	analyzer, err := NewModuleAnalyzer(&ModuleAnalysisOptions{
+		ProjectRoot:         root,
+		IncludeStdLib:       domain.BoolPtr(false),
+		IncludeThirdParty:   domain.BoolPtr(true),
+		FollowRelative:      domain.BoolPtr(true),
+		IncludeTypeChecking: domain.BoolPtr(includeTypeChecking),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create module analyzer: %v", err)
+	}
+	graph, err := analyzer.AnalyzeProject()
+	if err != nil {
+		t.Fatalf("Failed to analyze project: %v", err)
+	}
+	return graph
+}
+
+func TestTypeCheckingEdgesIncludedWhenRequested(t *testing.T) {
+	root := writeEdgeKindProject(t, `from typing import TYPE_CHECKING
+
+if TYPE_CHECKING:
+    from .a import A
+
+class B:
+    pass
+`)
+
+	graph := analyzeEdgeKindProject(t, root, false)
+	if graph.Nodes["foo.b"].Dependencies["foo.a"] {
+		t.Fatal("TYPE_CHECKING import should be dropped by default")
+	}
+
+	graph = analyzeEdgeKindProject(t, root, true)
+	edge := graph.findEdge("foo.b", "foo.a")
+	if edge == nil {
+		t.Fatal("TYPE_CHECKING import should create an edge when requested")
+	}
+	if edge.Kind() != EdgeKindTypeChecking {
+		t.Errorf("edge kind = %q, want %q", edge.Kind(), EdgeKindTypeChecking)
+	}
+	if !graph.Nodes["foo.b"].TypeCheckingDependencies["foo.a"] {
+		t.Error("foo.b -> foo.a should be recorded as a TYPE_CHECKING dependency")
+	}
+
+	if NewCircularDependencyDetector(graph).DetectCircularDependencies().HasCircularDependencies {
+		t.Error("TYPE_CHECKING edges should not form cycles by default")
+	}
+	result := NewCircularDependencyDetector(graph).WithIgnoredEdgeKinds(NewEdgeKindSet()).DetectCircularDependencies()
+	if !result.HasCircularDependencies {
+		t.Error("expected a cycle when no edge kinds are ignored")
+	}
+}
+
+func TestConditionalImportEdges(t *testing.T) {
+	root := writeEdgeKindProject(t, `try:
+    from .a import A
+except ImportError:
+    A = None
+
+class B:
+    pass
+`)
+
+	graph := analyzeEdgeKindProject(t, root, false)
+	edge := graph.findEdge("foo.b", "foo.a")
+	if edge == nil {
+		t.Fatal("expected foo.b -> foo.a edge")
+	}
+	if edge.Kind() != EdgeKindConditional || !edge.IsConditional {
+		t.Errorf("edge kind = %q, want %q", edge.Kind(), EdgeKindConditional)
+	}
+
+	// Conditional imports still run at load time, so they form cycles unless ignored
+	if !NewCircularDependencyDetector(graph).DetectCircularDependencies().HasCircularDependencies {
+		t.Error("conditional import should form a cycle by default")
+	}
+	ignored := NewEdgeKindSet(EdgeKindConditional)
+	if NewCircularDependencyDetector(graph).WithIgnoredEdgeKinds(ignored).DetectCircularDependencies().HasCircularDependencies {
+		t.Error("conditional import should not form a cycle when ignored")
+	}
+}
+
+func TestImportFallbackDetection(t *testing.T) {
+	root := writeEdgeKindProject(t, `try:
+    import ujson as json
+except ImportError:
+    from .a import A
+
+try:
+    pass
+finally:
+    from . import a
+
+class B:
+    pass
+`)
+
+	graph := analyzeEdgeKindProject(t, root, false)
+	edge := graph.findEdge("foo.b", "foo.a")
+	if edge == nil {
+		t.Fatal("expected foo.b -> foo.a edge")
+	}
+	if edge.IsConditional {
+		t.Error("an import in a finally block runs unconditionally and should promote the edge")
+	}
+}
+
+func TestEdgeKindSetMatches(t *testing.T) {
+	edge := &DependencyEdge{From: "a", To: "b", IsLazy: true, IsConditional: true}
+	if edge.Kind() != EdgeKindLazy {
+		t.Errorf("Kind() = %q, want lazy", edge.Kind())
+	}
+	if !NewEdgeKindSet(EdgeKindConditional).MatchesEdge(edge) {
+		t.Error("conditional set should match a lazy conditional edge")
+	}
+	if NewEdgeKindSet(EdgeKindImport).MatchesEdge(edge) {
+		t.Error("import set should not match a lazy edge")
+	}
+	if !NewEdgeKindSet(EdgeKindImport).MatchesEdge(&DependencyEdge{From: "a", To: "b"}) {
+		t.Error("import set should match a plain edge")
+	}
+
+	if _, err := ParseEdgeKindSet([]string{"lazy", "Type_Checking"}); err != nil {
+		t.Errorf("ParseEdgeKindSet() error = %v", err)
+	}
+	if _, err := ParseEdgeKindSet([]string{"optional"}); err == nil {
+		t.Error("expected error for unknown edge kind")
+	}
+}
@@ -0,0 +1,107 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+func TestModuleAnalyzerRecordsExternalImports(t *testing.T) {
+	tmpDir := t.TempDir()
+	pkgDir := filepath.Join(tmpDir, "app")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatalf("Failed to create package dir: %v", err)
+	}
+
+	files := map[string]string{
+		"__init__.py": "",
+		"util.py":     "VALUE = 1\n",
+		"core.py": `import os
+import requests
+from yaml import safe_load
+from typing import TYPE_CHECKING
+from app import util
+from . import util as relative_util
+
+if TYPE_CHECKING:
+    import boto3
+
+def load():
+    import json.decoder
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(pkgDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	analyzer, err := NewModuleAnalyzer(&ModuleAnalysisOptions{
+		ProjectRoot:       tmpDir,
+		IncludePatterns:   []string{"**/*.py"},
+		ExcludePatterns:   []string{},
+		IncludeStdLib:     domain.BoolPtr(false),
+		IncludeThirdParty: domain.BoolPtr(false),
+		FollowRelative:    domain.BoolPtr(true),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create module analyzer: %v", err)
+	}
+
+	graph, err := analyzer.AnalyzeFiles([]string{
+		filepath.Join(pkgDir, "__init__.py"),
+		filepath.Join(pkgDir, "util.py"),
+		filepath.Join(pkgDir, "core.py"),
+	})
+	if err != nil {
+		t.Fatalf("AnalyzeFiles() error = %v", err)
+	}
+
+	byModule := make(map[string]*ExternalImport)
+	for _, ext := range graph.ExternalImports {
+		byModule[ext.Module] = ext
+	}
+
+	for _, want := range []string{"os", "requests", "yaml", "typing", "boto3", "json.decoder"} {
+		if byModule[want] == nil {
+			t.Errorf("expected external import %q, got %v", want, byModule)
+		}
+	}
+	if len(byModule) != 6 {
+		t.Errorf("expected 6 external imports, got %d: %v", len(byModule), byModule)
+	}
+	if byModule["app"] != nil {
+		t.Error("project package should not be recorded as external")
+	}
+
+	if ext := byModule["boto3"]; ext != nil && !ext.IsTypeChecking {
+		t.Error("boto3 import should be marked TYPE_CHECKING")
+	}
+	if ext := byModule["json.decoder"]; ext != nil {
+		if ext.TopLevel != "json" || !ext.IsLazy || ext.Line != 12 {
+			t.Errorf("unexpected json.decoder import: %+v", ext)
+		}
+	}
+	if ext := byModule["requests"]; ext != nil && ext.FromModule != "app.core" {
+		t.Errorf("FromModule = %q, want app.core", ext.FromModule)
+	}
+}
+
+func TestIsStandardLibraryModule(t *testing.T) {
+	tests := map[string]bool{
+		"os":           true,
+		"os.path":      true,
+		"__future__":   true,
+		"tomllib":      true,
+		"requests":     false,
+		"yaml.loader":  false,
+		"collectionsx": false,
+	}
+	for name, want := range tests {
+		if got := IsStandardLibraryModule(name); got != want {
+			t.Errorf("IsStandardLibraryModule(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
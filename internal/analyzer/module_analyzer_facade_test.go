@@ -0,0 +1,130 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// writeFacadeProject creates a project whose api package re-exports five
+// names of three modules, one of which imports back through the facade
+func writeFacadeProject(t *testing.T, apiInit string) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"api/__init__.py":  apiInit,
+		"api/helpers.py":   "def slugify(text):\n    return text\n",
+		"core/__init__.py": "",
+		"core/models.py":   "class User:\n    pass\n\nclass Group:\n    pass\n",
+		"core/services.py": "from api import User\n\ndef create_user():\n    return User()\n\ndef delete_user(user):\n    pass\n",
+		"app.py":           "import api\nfrom api import create_user, slugify\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+const facadeInit = `"""Public API."""
+from core.models import User, Group
+from core.services import create_user, delete_user
+from .helpers import slugify
+
+__all__ = ["User", "Group", "create_user", "delete_user", "slugify"]
+__version__ = "1.0"
+`
+
+func analyzeFacadeProject(t *testing.T, dir string, collapse bool, minExports int) *DependencyGraph {
+	t.Helper()
+	analyzer, err := NewModuleAnalyzer(&ModuleAnalysisOptions{
+		ProjectRoot:      dir,
+		FacadeMinExports: minExports,
+		CollapseFacades:  domain.BoolPtr(collapse),
+	})
+	if err != nil {
+		t.Fatalf("failed to create analyzer: %v", err)
+	}
+	graph, err := analyzer.AnalyzeProject()
+	if err != nil {
+		t.Fatalf("AnalyzeProject failed: %v", err)
+	}
+	return graph
+}
+
+func TestModuleAnalyzer_DetectsFacades(t *testing.T) {
+	graph := analyzeFacadeProject(t, writeFacadeProject(t, facadeInit), false, 5)
+
+	facade := graph.Facades["api"]
+	if facade == nil {
+		t.Fatalf("expected api to be a facade, got %v", graph.Facades)
+	}
+	if len(facade.Exports) != 5 {
+		t.Errorf("expected 5 exports, got %v", facade.Exports)
+	}
+	if facade.FanOut() != 3 {
+		t.Errorf("expected fan-out 3, got %v", facade.Sources())
+	}
+	if facade.Exports["slugify"] != "api.helpers" {
+		t.Errorf("expected slugify from api.helpers, got %q", facade.Exports["slugify"])
+	}
+	if facade.Collapsed {
+		t.Error("expected the facade not to be collapsed")
+	}
+
+	// Without collapsing, the facade closes a cycle with core.services
+	if !NewCircularDependencyDetector(graph).DetectCircularDependencies().HasCircularDependencies {
+		t.Error("expected a cycle through the facade")
+	}
+}
+
+func TestModuleAnalyzer_CollapsesFacades(t *testing.T) {
+	graph := analyzeFacadeProject(t, writeFacadeProject(t, facadeInit), true, 5)
+
+	if deps := graph.GetDependencies("api"); len(deps) != 0 {
+		t.Errorf("expected the collapsed facade to have no dependencies, got %v", deps)
+	}
+	if result := NewCircularDependencyDetector(graph).DetectCircularDependencies(); result.HasCircularDependencies {
+		t.Errorf("expected no cycle once the facade is collapsed, got %v", result.CircularDependencies)
+	}
+
+	services := graph.GetModule("core.services")
+	if !services.Dependencies["core.models"] || services.Dependencies["api"] {
+		t.Errorf("expected core.services to depend on core.models only, got %v", services.Dependencies)
+	}
+
+	// Named imports reach the defining modules; a plain import keeps the facade
+	app := graph.GetModule("app")
+	for _, want := range []string{"api", "api.helpers", "core.services"} {
+		if !app.Dependencies[want] {
+			t.Errorf("expected app to depend on %s, got %v", want, app.Dependencies)
+		}
+	}
+}
+
+func TestModuleAnalyzer_FacadeRequirements(t *testing.T) {
+	tests := []struct {
+		name       string
+		apiInit    string
+		minExports int
+	}{
+		{name: "too few exports", apiInit: facadeInit, minExports: 6},
+		{name: "defines code", apiInit: facadeInit + "\ndef version():\n    return __version__\n", minExports: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := analyzeFacadeProject(t, writeFacadeProject(t, tt.apiInit), true, tt.minExports)
+			if len(graph.Facades) != 0 {
+				t.Errorf("expected no facade, got %v", graph.Facades)
+			}
+		})
+	}
+}
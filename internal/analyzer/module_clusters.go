@@ -0,0 +1,92 @@
+package analyzer
+
+import (
+	"sort"
+
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// ModuleDefinitionClusters groups the top-level classes and functions of a
+// module by the references between them. Two definitions share a cluster
+// when one uses the name of the other, directly or through further
+// definitions, so each cluster could move to its own module without new
+// imports between the parts. Clusters are ordered by size, largest first,
+// then by position; names keep their source order.
+func ModuleDefinitionClusters(module *parser.Node) [][]string {
+	if module == nil {
+		return nil
+	}
+
+	var definitions []*parser.Node
+	index := make(map[string]int)
+	for _, stmt := range module.Body {
+		switch stmt.Type {
+		case parser.NodeFunctionDef, parser.NodeAsyncFunctionDef, parser.NodeClassDef:
+			if stmt.Name == "" {
+				continue
+			}
+			// A redefinition replaces the earlier one at runtime; both
+			// belong to the same name
+			if _, seen := index[stmt.Name]; !seen {
+				index[stmt.Name] = len(definitions)
+				definitions = append(definitions, stmt)
+			}
+		}
+	}
+	if len(definitions) == 0 {
+		return nil
+	}
+
+	parent := make([]int, len(definitions))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for _, stmt := range module.Body {
+		i, ok := index[stmt.Name]
+		if !ok || (stmt.Type != parser.NodeFunctionDef && stmt.Type != parser.NodeAsyncFunctionDef && stmt.Type != parser.NodeClassDef) {
+			continue
+		}
+		stmt.Walk(func(node *parser.Node) bool {
+			if node.Type != parser.NodeName {
+				return true
+			}
+			if j, ok := index[node.Name]; ok && j != i {
+				if a, b := find(i), find(j); a != b {
+					parent[b] = a
+				}
+			}
+			return true
+		})
+	}
+
+	groups := make(map[int][]int)
+	var roots []int
+	for i := range definitions {
+		root := find(i)
+		if _, ok := groups[root]; !ok {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], i)
+	}
+	sort.SliceStable(roots, func(a, b int) bool {
+		return len(groups[roots[a]]) > len(groups[roots[b]])
+	})
+
+	clusters := make([][]string, 0, len(roots))
+	for _, root := range roots {
+		names := make([]string, 0, len(groups[root]))
+		for _, i := range groups[root] {
+			names = append(names, definitions[i].Name)
+		}
+		clusters = append(clusters, names)
+	}
+	return clusters
+}
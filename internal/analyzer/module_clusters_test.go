@@ -0,0 +1,55 @@
+package analyzer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModuleDefinitionClusters(t *testing.T) {
+	source := `import logging
+
+LIMIT = 10
+
+def helper():
+    return LIMIT
+
+@register
+class Report:
+    def render(self):
+        return format_row(self)
+
+def format_row(report):
+    return str(report)
+
+class Exporter(Report):
+    pass
+
+async def fetch():
+    return helper()
+
+def standalone():
+    pass
+`
+	result, err := parser.New().Parse(context.Background(), []byte(source))
+	require.NoError(t, err)
+
+	clusters := ModuleDefinitionClusters(result.AST)
+
+	assert.Equal(t, [][]string{
+		{"Report", "format_row", "Exporter"},
+		{"helper", "fetch"},
+		{"standalone"},
+	}, clusters)
+}
+
+func TestModuleDefinitionClustersEmptyModule(t *testing.T) {
+	result, err := parser.New().Parse(context.Background(), []byte("X = 1\n"))
+	require.NoError(t, err)
+
+	assert.Nil(t, ModuleDefinitionClusters(result.AST))
+	assert.Nil(t, ModuleDefinitionClusters(nil))
+}
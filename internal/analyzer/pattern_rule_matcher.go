@@ -0,0 +1,289 @@
+package analyzer
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// patternRuleNodeTypes are the node types a custom rule may match or refer to
+var patternRuleNodeTypes = map[parser.NodeType]bool{
+	parser.NodeModule: true,
+
+	parser.NodeFunctionDef: true, parser.NodeAsyncFunctionDef: true, parser.NodeClassDef: true,
+	parser.NodeReturn: true, parser.NodeDelete: true, parser.NodeAssign: true, parser.NodeAugAssign: true,
+	parser.NodeAnnAssign: true, parser.NodeFor: true, parser.NodeAsyncFor: true, parser.NodeWhile: true,
+	parser.NodeIf: true, parser.NodeWith: true, parser.NodeAsyncWith: true, parser.NodeMatch: true,
+	parser.NodeRaise: true, parser.NodeTry: true, parser.NodeAssert: true, parser.NodeImport: true,
+	parser.NodeImportFrom: true, parser.NodeGlobal: true, parser.NodeNonlocal: true, parser.NodePass: true,
+	parser.NodeBreak: true, parser.NodeContinue: true,
+
+	parser.NodeBoolOp: true, parser.NodeNamedExpr: true, parser.NodeBinOp: true, parser.NodeUnaryOp: true,
+	parser.NodeLambda: true, parser.NodeIfExp: true, parser.NodeDict: true, parser.NodeSet: true,
+	parser.NodeListComp: true, parser.NodeSetComp: true, parser.NodeDictComp: true,
+	parser.NodeGeneratorExp: true, parser.NodeAwait: true, parser.NodeYield: true, parser.NodeYieldFrom: true,
+	parser.NodeCompare: true, parser.NodeCall: true, parser.NodeJoinedStr: true, parser.NodeConstant: true,
+	parser.NodeAttribute: true, parser.NodeSubscript: true, parser.NodeStarred: true, parser.NodeName: true,
+	parser.NodeList: true, parser.NodeTuple: true,
+
+	parser.NodeExceptHandler: true, parser.NodeArg: true, parser.NodeDecorator: true, parser.NodeMatchCase: true,
+}
+
+// NodePattern is a compiled structural pattern matching AST nodes. Custom
+// rules and complexity boilerplate patterns share it.
+type NodePattern struct {
+	match     domain.PatternRuleMatch
+	nodes     map[parser.NodeType]bool
+	inside    map[parser.NodeType]bool
+	notInside map[parser.NodeType]bool
+}
+
+// compiledPatternRule is a custom rule with its pattern compiled
+type compiledPatternRule struct {
+	rule    domain.PatternRule
+	pattern *NodePattern
+}
+
+// PatternRuleMatcher runs declarative custom rules over the AST of a file.
+// Each node is matched against every rule on its own; a rule reports each
+// matching node once.
+type PatternRuleMatcher struct {
+	rules []compiledPatternRule
+}
+
+// NewPatternRuleMatcher validates and compiles custom rules. It fails on the
+// first rule with an unknown node type, scope or severity, or a malformed glob.
+func NewPatternRuleMatcher(rules []domain.PatternRule) (*PatternRuleMatcher, error) {
+	matcher := &PatternRuleMatcher{rules: make([]compiledPatternRule, 0, len(rules))}
+	for _, rule := range rules {
+		compiled, err := compilePatternRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("custom rule %q: %w", rule.ID, err)
+		}
+		matcher.rules = append(matcher.rules, compiled)
+	}
+	return matcher, nil
+}
+
+func compilePatternRule(rule domain.PatternRule) (compiledPatternRule, error) {
+	compiled := compiledPatternRule{rule: rule}
+	var err error
+	if compiled.pattern, err = CompileNodePattern(rule.Match); err != nil {
+		return compiled, err
+	}
+	switch rule.Severity {
+	case domain.PatternRuleSeverityInfo, domain.PatternRuleSeverityWarning, domain.PatternRuleSeverityError:
+	default:
+		return compiled, fmt.Errorf("invalid severity %q, must be one of: info, warning, error", rule.Severity)
+	}
+	return compiled, nil
+}
+
+// CompileNodePattern validates and compiles a pattern. It fails on an
+// unknown node type or scope, a malformed glob or a negative minimum.
+func CompileNodePattern(match domain.PatternRuleMatch) (*NodePattern, error) {
+	pattern := &NodePattern{match: match}
+
+	if len(match.Nodes) == 0 {
+		return nil, fmt.Errorf("match.node must name at least one node type")
+	}
+	var err error
+	if pattern.nodes, err = patternRuleNodeSet("match.node", match.Nodes); err != nil {
+		return nil, err
+	}
+	if pattern.inside, err = patternRuleNodeSet("match.inside", match.Inside); err != nil {
+		return nil, err
+	}
+	if pattern.notInside, err = patternRuleNodeSet("match.not_inside", match.NotInside); err != nil {
+		return nil, err
+	}
+
+	for _, glob := range []struct{ key, value string }{
+		{"match.name", match.Name},
+		{"match.callee", match.Callee},
+	} {
+		if _, err := path.Match(glob.value, ""); err != nil {
+			return nil, fmt.Errorf("invalid %s glob %q: %w", glob.key, glob.value, err)
+		}
+	}
+
+	switch match.Scope {
+	case "", domain.PatternRuleScopeAny, domain.PatternRuleScopeModule, domain.PatternRuleScopeClass, domain.PatternRuleScopeFunction:
+	default:
+		return nil, fmt.Errorf("invalid match.scope %q, must be one of: any, module, class, function", match.Scope)
+	}
+	if match.MinDecorators < 0 || match.MinArgs < 0 || match.MinLines < 0 {
+		return nil, fmt.Errorf("match.min_decorators, match.min_args and match.min_lines must be >= 0")
+	}
+	return pattern, nil
+}
+
+func patternRuleNodeSet(key string, names []string) (map[parser.NodeType]bool, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	set := make(map[parser.NodeType]bool, len(names))
+	for _, name := range names {
+		nodeType := parser.NodeType(name)
+		if !patternRuleNodeTypes[nodeType] {
+			return nil, fmt.Errorf("unknown node type %q in %s", name, key)
+		}
+		set[nodeType] = true
+	}
+	return set, nil
+}
+
+// Analyze returns the findings of every rule in the AST of a file, in
+// source order
+func (m *PatternRuleMatcher) Analyze(ast *parser.Node, filePath string) []domain.PatternRuleFinding {
+	if ast == nil || len(m.rules) == 0 {
+		return nil
+	}
+
+	var findings []domain.PatternRuleFinding
+	WalkWithAncestors(ast, func(node *parser.Node, ancestors []*parser.Node) {
+		for i := range m.rules {
+			if m.rules[i].pattern.Matches(node, ancestors) {
+				findings = append(findings, m.rules[i].finding(node, ancestors, filePath))
+			}
+		}
+	})
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i].Location, findings[j].Location
+		if a.StartLine != b.StartLine {
+			return a.StartLine < b.StartLine
+		}
+		return a.StartCol < b.StartCol
+	})
+	return findings
+}
+
+// WalkWithAncestors visits every node of the AST in source order, with the
+// nodes enclosing it from the root down
+func WalkWithAncestors(ast *parser.Node, visit func(node *parser.Node, ancestors []*parser.Node)) {
+	if ast == nil {
+		return
+	}
+	var ancestors []*parser.Node
+	var walk func(node *parser.Node)
+	walk = func(node *parser.Node) {
+		visit(node, ancestors)
+		ancestors = append(ancestors, node)
+		for _, child := range node.GetChildren() {
+			if child != nil {
+				walk(child)
+			}
+		}
+		ancestors = ancestors[:len(ancestors)-1]
+	}
+	walk(ast)
+}
+
+// Matches reports whether the node, enclosed by ancestors from the root
+// down, matches the pattern
+func (p *NodePattern) Matches(node *parser.Node, ancestors []*parser.Node) bool {
+	pattern := p.match
+	if !p.nodes[node.Type] {
+		return false
+	}
+	if pattern.Name != "" {
+		if matched, _ := path.Match(pattern.Name, node.Name); !matched {
+			return false
+		}
+	}
+	if pattern.Callee != "" {
+		if node.Type != parser.NodeCall {
+			return false
+		}
+		if matched, _ := path.Match(pattern.Callee, dottedName(nodeValue(node))); !matched {
+			return false
+		}
+	}
+	if pattern.Bare != nil {
+		bare := (node.Type == parser.NodeExceptHandler || node.Type == parser.NodeRaise) && nodeValue(node) == nil
+		if bare != *pattern.Bare {
+			return false
+		}
+	}
+	if pattern.MinDecorators > 0 && len(node.Decorator) < pattern.MinDecorators {
+		return false
+	}
+	if pattern.MinArgs > 0 && len(node.Args)+len(node.Keywords) < pattern.MinArgs {
+		return false
+	}
+	if pattern.MinLines > 0 && node.Location.EndLine-node.Location.StartLine+1 < pattern.MinLines {
+		return false
+	}
+
+	if pattern.Scope != "" && pattern.Scope != domain.PatternRuleScopeAny {
+		definition := enclosingDefinition(ancestors)
+		scope := domain.PatternRuleScopeModule
+		switch {
+		case definition == nil:
+		case definition.Type == parser.NodeClassDef:
+			scope = domain.PatternRuleScopeClass
+		default:
+			scope = domain.PatternRuleScopeFunction
+		}
+		if scope != pattern.Scope {
+			return false
+		}
+	}
+	if p.inside != nil || p.notInside != nil {
+		inside := false
+		for _, ancestor := range ancestors {
+			if p.notInside[ancestor.Type] {
+				return false
+			}
+			inside = inside || p.inside[ancestor.Type]
+		}
+		if p.inside != nil && !inside {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *compiledPatternRule) finding(node *parser.Node, ancestors []*parser.Node, filePath string) domain.PatternRuleFinding {
+	finding := domain.PatternRuleFinding{
+		Rule:     r.rule.ID,
+		Severity: r.rule.Severity,
+		Message:  r.rule.Message,
+		Node:     string(node.Type),
+		Location: sourceLocation(filePath, node.Location),
+	}
+	var names []string
+	for _, ancestor := range ancestors {
+		switch ancestor.Type {
+		case parser.NodeFunctionDef, parser.NodeAsyncFunctionDef, parser.NodeClassDef:
+			names = append(names, ancestor.Name)
+		}
+	}
+	finding.FunctionName = strings.Join(names, ".")
+	return finding
+}
+
+// enclosingDefinition returns the innermost function, lambda or class whose
+// body holds the node. Decorators are evaluated outside the definition they
+// decorate, so a definition reached through one of its decorators is skipped.
+func enclosingDefinition(ancestors []*parser.Node) *parser.Node {
+	skip := false
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		switch ancestors[i].Type {
+		case parser.NodeDecorator:
+			skip = true
+		case parser.NodeFunctionDef, parser.NodeAsyncFunctionDef, parser.NodeClassDef, parser.NodeLambda:
+			if skip {
+				skip = false
+				continue
+			}
+			return ancestors[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package analyzer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+func matchPatternRules(t *testing.T, code string, rules ...domain.PatternRule) []domain.PatternRuleFinding {
+	t.Helper()
+	result, err := parser.New().Parse(context.Background(), []byte(code))
+	if err != nil {
+		t.Fatalf("failed to parse code: %v", err)
+	}
+	matcher, err := NewPatternRuleMatcher(rules)
+	if err != nil {
+		t.Fatalf("NewPatternRuleMatcher failed: %v", err)
+	}
+	return matcher.Analyze(result.AST, "test.py")
+}
+
+func TestPatternRuleMatcher(t *testing.T) {
+	bare := true
+	tests := []struct {
+		name      string
+		code      string
+		match     domain.PatternRuleMatch
+		wantLines []int
+	}{
+		{
+			name: "print at module level",
+			code: `
+print("start")
+
+def run():
+    print("inside")
+
+class Job:
+    print("body")
+`,
+			match:     domain.PatternRuleMatch{Nodes: []string{"Call"}, Callee: "print", Scope: domain.PatternRuleScopeModule},
+			wantLines: []int{2},
+		},
+		{
+			name: "bare except",
+			code: `
+try:
+    run()
+except:
+    pass
+try:
+    run()
+except ValueError:
+    pass
+`,
+			match:     domain.PatternRuleMatch{Nodes: []string{"ExceptHandler"}, Bare: &bare},
+			wantLines: []int{4},
+		},
+		{
+			name: "function with more than two decorators",
+			code: `
+@a
+@b
+@c
+def many():
+    pass
+
+@a
+@b
+def few():
+    pass
+`,
+			match:     domain.PatternRuleMatch{Nodes: []string{"FunctionDef", "AsyncFunctionDef"}, MinDecorators: 3},
+			wantLines: []int{5},
+		},
+		{
+			name: "callee glob inside a loop",
+			code: `
+import subprocess
+for cmd in cmds:
+    subprocess.run(cmd)
+subprocess.call(cmd)
+`,
+			match:     domain.PatternRuleMatch{Nodes: []string{"Call"}, Callee: "subprocess.*", Inside: []string{"For", "While"}},
+			wantLines: []int{4},
+		},
+		{
+			name: "decorator call is outside the function it decorates",
+			code: `
+@register("job")
+def job():
+    register("inner")
+`,
+			match:     domain.PatternRuleMatch{Nodes: []string{"Call"}, Callee: "register", Scope: domain.PatternRuleScopeModule},
+			wantLines: []int{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := domain.PatternRule{ID: "team.rule", Message: "message", Severity: domain.PatternRuleSeverityWarning, Match: tt.match}
+			findings := matchPatternRules(t, tt.code, rule)
+			if len(findings) != len(tt.wantLines) {
+				t.Fatalf("expected %d findings, got %+v", len(tt.wantLines), findings)
+			}
+			for i, finding := range findings {
+				if finding.Location.StartLine != tt.wantLines[i] {
+					t.Errorf("finding %d: expected line %d, got %d", i, tt.wantLines[i], finding.Location.StartLine)
+				}
+				if finding.Rule != "team.rule" || finding.Message != "message" {
+					t.Errorf("finding %d: expected the rule ID and message, got %+v", i, finding)
+				}
+			}
+		})
+	}
+}
+
+func TestPatternRuleMatcherFunctionName(t *testing.T) {
+	code := `
+class Service:
+    def run(self):
+        print("x")
+`
+	rule := domain.PatternRule{ID: "no-print", Message: "m", Severity: domain.PatternRuleSeverityInfo,
+		Match: domain.PatternRuleMatch{Nodes: []string{"Call"}, Callee: "print"}}
+	findings := matchPatternRules(t, code, rule)
+	if len(findings) != 1 || findings[0].FunctionName != "Service.run" {
+		t.Fatalf("expected one finding in Service.run, got %+v", findings)
+	}
+}
+
+func TestNewPatternRuleMatcherRejectsInvalidRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    domain.PatternRule
+		wantErr string
+	}{
+		{"no node", domain.PatternRule{ID: "r", Severity: "warning"}, "match.node"},
+		{"unknown node", domain.PatternRule{ID: "r", Severity: "warning", Match: domain.PatternRuleMatch{Nodes: []string{"Print"}}}, `unknown node type "Print"`},
+		{"bad glob", domain.PatternRule{ID: "r", Severity: "warning", Match: domain.PatternRuleMatch{Nodes: []string{"Call"}, Callee: "["}}, "invalid match.callee glob"},
+		{"bad scope", domain.PatternRule{ID: "r", Severity: "warning", Match: domain.PatternRuleMatch{Nodes: []string{"Call"}, Scope: "file"}}, "invalid match.scope"},
+		{"bad severity", domain.PatternRule{ID: "r", Severity: "fatal", Match: domain.PatternRuleMatch{Nodes: []string{"Call"}}}, "invalid severity"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPatternRuleMatcher([]domain.PatternRule{tt.rule})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) || !strings.Contains(err.Error(), `custom rule "r"`) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
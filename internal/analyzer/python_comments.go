@@ -99,3 +99,15 @@ func removeLineComment(line string) string {
 // comments, collapse whitespace) for fragment fingerprinting, independent of
 // any per-detector similarity configuration.
 var fragmentHashNormalizer = coreclone.NewTextualSimilarityAnalyzer(removePythonComments)
+
+// fragmentContentHash returns the Type-1 hash of a fragment: that of its
+// content while it holds it, otherwise the hash computed at extraction
+func fragmentContentHash(fragment *CodeFragment) string {
+	if fragment == nil {
+		return ""
+	}
+	if fragment.Content != "" {
+		return fragmentHashNormalizer.HashFragmentContent(fragment.Content)
+	}
+	return fragment.Hash
+}
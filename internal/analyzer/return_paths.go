@@ -0,0 +1,271 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// ReturnPathResult describes how a function leaves through its exit paths
+type ReturnPathResult struct {
+	// Return statements reachable in the function scope
+	ValueReturns []*parser.Node // return <expr>, excluding `return None`
+	NoneReturns  []*parser.Node // explicit `return None`
+	BareReturns  []*parser.Node // `return` without a value
+
+	// FallsThrough is true when a reachable path reaches the end of the
+	// function body and returns None implicitly
+	FallsThrough    bool
+	FallThroughLine int // Last line executed on the first fall-through path
+
+	// ReturnAnnotation is the declared return type, or empty when missing
+	ReturnAnnotation string
+
+	// IsGenerator is true when the function contains yield; bare returns
+	// only stop iteration in generators
+	IsGenerator bool
+
+	// IsStub is true when the body holds only a docstring, pass or `...`,
+	// as in protocols, abstract methods and overloads
+	IsStub bool
+}
+
+// IsInconsistent reports whether the function mixes value returns with bare
+// returns or an implicit None at the end of the body
+func (r *ReturnPathResult) IsInconsistent() bool {
+	if r.IsGenerator || r.IsStub || len(r.ValueReturns) == 0 {
+		return false
+	}
+	return len(r.BareReturns) > 0 || r.FallsThrough
+}
+
+// MissingReturn reports whether the function declares a return type that
+// does not admit None but has a path that falls off the end of the body
+func (r *ReturnPathResult) MissingReturn() bool {
+	if r.IsGenerator || r.IsStub || !r.FallsThrough || r.ReturnAnnotation == "" {
+		return false
+	}
+	return !annotationAllowsNone(r.ReturnAnnotation)
+}
+
+// exitMode records why control is moving towards the function exit
+type exitMode int
+
+const (
+	exitFallThrough exitMode = iota // Ordinary statement-to-statement flow
+	exitReturning                   // A return statement is unwinding through finally/teardown blocks
+	exitRaising                     // An exception is propagating
+)
+
+// AnalyzeReturnPaths classifies the return statements of a function CFG and
+// checks whether any path falls through to the exit. Paths are followed
+// together with the reason control is moving, so that a return or an
+// exception unwinding through a finally or with-teardown block is not
+// mistaken for a fall-through. It returns nil for CFGs that are not built
+// from a function definition.
+func AnalyzeReturnPaths(cfg *CFG) *ReturnPathResult {
+	funcNode := complexitySourceNode(cfg)
+	if funcNode == nil || (funcNode.Type != parser.NodeFunctionDef && funcNode.Type != parser.NodeAsyncFunctionDef) {
+		return nil
+	}
+
+	result := &ReturnPathResult{IsStub: isStubBody(funcNode.Body)}
+	if annotation, ok := funcNode.Value.(string); ok {
+		result.ReturnAnnotation = strings.TrimSpace(annotation)
+	}
+	for _, stmt := range funcNode.Body {
+		walkAsyncScope(stmt, func(n *parser.Node) {
+			if n.Type == parser.NodeYield || n.Type == parser.NodeYieldFrom {
+				result.IsGenerator = true
+			}
+		})
+	}
+
+	type pathState struct {
+		block *BasicBlock
+		mode  exitMode
+	}
+	visited := make(map[pathState]bool)
+	seenBlocks := make(map[*BasicBlock]bool)
+	stack := []pathState{{cfg.Entry, exitFallThrough}}
+
+	for len(stack) > 0 {
+		state := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if state.block == nil || visited[state] {
+			continue
+		}
+		visited[state] = true
+
+		block := state.block
+		if !seenBlocks[block] {
+			seenBlocks[block] = true
+			for _, value := range block.Statements {
+				if stmt := mustPythonNode(value); stmt != nil && stmt.Type == parser.NodeReturn {
+					result.addReturn(stmt)
+				}
+			}
+		}
+
+		terminates := blockHasTerminator(block)
+		for _, edge := range block.Successors {
+			if edge == nil || edge.To == nil {
+				continue
+			}
+			if terminates && edge.Type == EdgeNormal {
+				continue
+			}
+			if edge.Type == EdgeCondFalse && alwaysTakesTrueBranch(block) {
+				continue
+			}
+
+			mode := state.mode
+			switch edge.Type {
+			case EdgeReturn:
+				mode = exitReturning
+			case EdgeException:
+				mode = exitRaising
+				if strings.HasPrefix(edge.To.Label, LabelExceptBlock) {
+					mode = exitFallThrough
+				}
+			case EdgeBreak, EdgeContinue:
+				mode = exitFallThrough
+			}
+
+			if edge.To == cfg.Exit {
+				if mode == exitFallThrough && !result.FallsThrough {
+					result.FallsThrough = true
+					result.FallThroughLine = blockLastLine(block, funcNode)
+				}
+				continue
+			}
+			stack = append(stack, pathState{edge.To, mode})
+		}
+	}
+
+	return result
+}
+
+// blockHasTerminator reports whether a block ends in return, raise, break or
+// continue, after which its fallthrough edge is never taken
+func blockHasTerminator(block *BasicBlock) bool {
+	classifier := pythonCFGClassifier{}
+	for _, stmt := range block.Statements {
+		if classifier.IsReturn(stmt) || classifier.IsThrow(stmt) || classifier.IsBreak(stmt) || classifier.IsContinue(stmt) {
+			return true
+		}
+	}
+	return false
+}
+
+// alwaysTakesTrueBranch reports whether the false edge of a block can never
+// be taken: the header of a `while True` loop, or the evaluation block of a
+// match statement with a catch-all case
+func alwaysTakesTrueBranch(block *BasicBlock) bool {
+	if len(block.Statements) == 0 {
+		return false
+	}
+	stmt := mustPythonNode(block.Statements[len(block.Statements)-1])
+	if stmt == nil {
+		return false
+	}
+	switch stmt.Type {
+	case parser.NodeWhile:
+		return strings.HasPrefix(block.Label, LabelLoopHeader) && isTruthyConstant(stmt.Test)
+	case parser.NodeMatch:
+		return strings.HasPrefix(block.Label, LabelMatchEval) && HasIrrefutableCase(AnalyzeMatchCases(stmt))
+	}
+	return false
+}
+
+// isTruthyConstant reports whether an expression is a literal that is always true
+func isTruthyConstant(node *parser.Node) bool {
+	if node == nil || node.Type != parser.NodeConstant {
+		return false
+	}
+	switch value := node.Value.(type) {
+	case bool:
+		return value
+	case int64:
+		return value != 0
+	case int:
+		return value != 0
+	case float64:
+		return value != 0
+	case string:
+		return value != ""
+	}
+	return false
+}
+
+func (r *ReturnPathResult) addReturn(stmt *parser.Node) {
+	value, _ := stmt.Value.(*parser.Node)
+	switch {
+	case value == nil:
+		r.BareReturns = append(r.BareReturns, stmt)
+	case value.Type == parser.NodeConstant && value.Value == nil:
+		r.NoneReturns = append(r.NoneReturns, stmt)
+	default:
+		r.ValueReturns = append(r.ValueReturns, stmt)
+	}
+}
+
+// isStubBody reports whether a function body only holds placeholders
+func isStubBody(body []*parser.Node) bool {
+	for _, stmt := range body {
+		if stmt == nil {
+			continue
+		}
+		if stmt.Type == parser.NodeExpr {
+			if value, ok := stmt.Value.(*parser.Node); ok {
+				stmt = value
+			}
+		}
+		switch stmt.Type {
+		case parser.NodePass, parser.NodeConstant, "ellipsis":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// blockLastLine returns the last source line of a block, falling back to the
+// end of the function for empty blocks such as merge points
+func blockLastLine(block *BasicBlock, funcNode *parser.Node) int {
+	for i := len(block.Statements) - 1; i >= 0; i-- {
+		if stmt := mustPythonNode(block.Statements[i]); stmt != nil && stmt.Location.EndLine > 0 {
+			return stmt.Location.EndLine
+		}
+	}
+	return funcNode.Location.EndLine
+}
+
+// annotationAllowsNone reports whether a return annotation admits None.
+// Annotations that accept any value, and generator types whose return value
+// is rarely meaningful, are treated as admitting None.
+func annotationAllowsNone(annotation string) bool {
+	annotation = strings.Trim(strings.TrimSpace(annotation), `"'`)
+	switch annotation {
+	case "None", "Any", "object", "NoReturn", "Never",
+		"typing.Any", "typing.NoReturn", "typing.Never":
+		return true
+	}
+	for _, prefix := range []string{"Optional[", "typing.Optional[", "Generator[", "Iterator[", "Iterable[",
+		"AsyncGenerator[", "AsyncIterator[", "AsyncIterable[", "typing.Generator[", "typing.Iterator[",
+		"typing.Iterable[", "collections.abc."} {
+		if strings.HasPrefix(annotation, prefix) {
+			return true
+		}
+	}
+	if strings.HasPrefix(annotation, "Union[") || strings.HasPrefix(annotation, "typing.Union[") || strings.Contains(annotation, "|") {
+		for _, part := range strings.FieldsFunc(annotation, func(r rune) bool {
+			return r == '[' || r == ']' || r == ',' || r == '|' || r == ' '
+		}) {
+			if part == "None" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package analyzer
+
+import (
+	"testing"
+)
+
+func TestAnalyzeReturnPaths(t *testing.T) {
+	source := `
+def mixed(x):
+    if x:
+        return x
+    return
+
+def implicit(x):
+    if x:
+        return x
+
+def explicit_none(x):
+    if x:
+        return x
+    return None
+
+def missing(x) -> int:
+    if x > 0:
+        return 1
+    elif x < 0:
+        return -1
+
+def optional(x) -> Optional[int]:
+    if x:
+        return 1
+
+def union(x) -> int | None:
+    if x:
+        return 1
+
+def infinite(x) -> int:
+    while True:
+        if x:
+            return 1
+
+def finally_return(x) -> int:
+    try:
+        return int(x)
+    except ValueError:
+        return 0
+    finally:
+        print("done")
+
+def with_return(path) -> str:
+    with open(path) as f:
+        return f.read()
+
+def loop_else(items) -> int:
+    for item in items:
+        return item
+    else:
+        return 0
+
+def exhaustive_match(x) -> str:
+    match x:
+        case 1:
+            return "one"
+        case _:
+            return "other"
+
+def reraise(x) -> int:
+    try:
+        return int(x)
+    except ValueError:
+        raise
+
+def generator(items):
+    for item in items:
+        if item:
+            return
+        yield item
+
+def stub(x) -> int:
+    """Protocol method."""
+    ...
+
+def no_returns(x):
+    print(x)
+`
+	tests := []struct {
+		name         string
+		inconsistent bool
+		missing      bool
+	}{
+		{"mixed", true, false},
+		{"implicit", true, false},
+		{"explicit_none", false, false},
+		{"missing", true, true},
+		{"optional", true, false},
+		{"union", true, false},
+		{"infinite", false, false},
+		{"finally_return", false, false},
+		{"with_return", false, false},
+		{"loop_else", false, false},
+		{"exhaustive_match", false, false},
+		{"reraise", false, false},
+		{"generator", false, false},
+		{"stub", false, false},
+		{"no_returns", false, false},
+	}
+
+	ast := parseSource(t, source)
+	functions := make(map[string]*CFG)
+	for _, node := range ast.Body {
+		cfg, err := NewCFGBuilder().Build(node)
+		if err != nil {
+			t.Fatalf("Failed to build CFG for %s: %v", node.Name, err)
+		}
+		functions[node.Name] = cfg
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := AnalyzeReturnPaths(functions[tt.name])
+			if result == nil {
+				t.Fatal("expected a result for a function CFG")
+			}
+			if got := result.IsInconsistent(); got != tt.inconsistent {
+				t.Errorf("IsInconsistent() = %v, want %v (falls through: %v)", got, tt.inconsistent, result.FallsThrough)
+			}
+			if got := result.MissingReturn(); got != tt.missing {
+				t.Errorf("MissingReturn() = %v, want %v", got, tt.missing)
+			}
+		})
+	}
+
+	if line := AnalyzeReturnPaths(functions["missing"]).FallThroughLine; line != 20 {
+		t.Errorf("FallThroughLine = %d, want 20", line)
+	}
+}
+
+func TestAnnotationAllowsNone(t *testing.T) {
+	tests := []struct {
+		annotation string
+		want       bool
+	}{
+		{"int", false},
+		{"list[str]", false},
+		{"None", true},
+		{"Optional[int]", true},
+		{"typing.Optional[int]", true},
+		{"int | None", true},
+		{"Union[int, None]", true},
+		{"Union[int, str]", false},
+		{"'Node'", false},
+		{"Any", true},
+		{"Iterator[int]", true},
+	}
+	for _, tt := range tests {
+		if got := annotationAllowsNone(tt.annotation); got != tt.want {
+			t.Errorf("annotationAllowsNone(%q) = %v, want %v", tt.annotation, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,797 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// SecurityOptions configures security pattern detection
+type SecurityOptions struct {
+	MinSeverity domain.SecuritySeverity
+}
+
+// DefaultSecurityOptions returns default options
+func DefaultSecurityOptions() *SecurityOptions {
+	return &SecurityOptions{
+		MinSeverity: domain.SecuritySeverityWarning,
+	}
+}
+
+// SecurityDetector flags dangerous sinks and tracks, within each function,
+// whether obvious untrusted sources or parameters reach them. Taint is
+// propagated over the function's CFG, so reassigning a variable to a constant
+// clears it and a value tainted on either branch of an if stays tainted after
+// the join. Calls into other functions are not followed.
+type SecurityDetector struct {
+	minSeverity domain.SecuritySeverity
+}
+
+// NewSecurityDetector creates a new security pattern detector
+func NewSecurityDetector(options *SecurityOptions) *SecurityDetector {
+	if options == nil {
+		options = DefaultSecurityOptions()
+	}
+	return &SecurityDetector{minSeverity: options.MinSeverity}
+}
+
+// Analyze runs security pattern detection on the given AST
+func (d *SecurityDetector) Analyze(ast *parser.Node, filePath string) ([]domain.SecurityFinding, error) {
+	if ast == nil {
+		return nil, nil
+	}
+
+	cfgs, err := NewCFGBuilder().BuildAll(ast)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build CFGs: %w", err)
+	}
+
+	names := make([]string, 0, len(cfgs))
+	for name := range cfgs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var findings []domain.SecurityFinding
+	for _, name := range names {
+		for _, finding := range newTaintAnalysis(cfgs[name], name, filePath).run() {
+			if finding.Severity.IsAtLeast(d.minSeverity) {
+				findings = append(findings, finding)
+			}
+		}
+	}
+	return findings, nil
+}
+
+// taintLevel orders what is known about where a value comes from
+type taintLevel int
+
+const (
+	// taintClean values are constants or come from sanitizers
+	taintClean taintLevel = iota
+	// taintUnknown values are dynamic but of unknown origin (globals, attributes of self)
+	taintUnknown
+	// taintParam values come from a function parameter
+	taintParam
+	// taintSource values come from an obvious untrusted source
+	taintSource
+)
+
+type taint struct {
+	level  taintLevel
+	origin string
+}
+
+func (t taint) join(other taint) taint {
+	if other.level > t.level {
+		return other
+	}
+	return t
+}
+
+func (t taint) severity() domain.SecuritySeverity {
+	switch t.level {
+	case taintSource:
+		return domain.SecuritySeverityError
+	case taintParam:
+		return domain.SecuritySeverityWarning
+	default:
+		return domain.SecuritySeverityInfo
+	}
+}
+
+func (t taint) describe() string {
+	switch t.level {
+	case taintSource:
+		return "untrusted input from " + t.origin
+	case taintParam:
+		return "caller-controlled input from " + t.origin
+	default:
+		return "dynamic input"
+	}
+}
+
+// taintState is the dataflow fact at a program point
+type taintState struct {
+	vars map[string]taint
+	// queries holds names bound to strings built by formatting, with the
+	// taint of the interpolated values
+	queries map[string]taint
+}
+
+func newTaintState() *taintState {
+	return &taintState{vars: make(map[string]taint), queries: make(map[string]taint)}
+}
+
+func (s *taintState) clone() *taintState {
+	c := newTaintState()
+	for k, v := range s.vars {
+		c.vars[k] = v
+	}
+	for k, v := range s.queries {
+		c.queries[k] = v
+	}
+	return c
+}
+
+func (s *taintState) merge(other *taintState) {
+	for k, v := range other.vars {
+		if cur, ok := s.vars[k]; ok {
+			s.vars[k] = cur.join(v)
+		} else {
+			s.vars[k] = v
+		}
+	}
+	for k, v := range other.queries {
+		s.queries[k] = s.queries[k].join(v)
+	}
+}
+
+func (s *taintState) equal(other *taintState) bool {
+	if len(s.vars) != len(other.vars) || len(s.queries) != len(other.queries) {
+		return false
+	}
+	for k, v := range s.vars {
+		if o, ok := other.vars[k]; !ok || o != v {
+			return false
+		}
+	}
+	for k, v := range s.queries {
+		if o, ok := other.queries[k]; !ok || o != v {
+			return false
+		}
+	}
+	return true
+}
+
+// maxTaintIterations bounds the fixpoint loop; the lattice is small, so this
+// is only a guard against pathological graphs
+const maxTaintIterations = 50
+
+type taintAnalysis struct {
+	cfg      *CFG
+	funcName string
+	filePath string
+	blocks   []*BasicBlock
+	defs     *pythonRefExtractor
+}
+
+func newTaintAnalysis(cfg *CFG, funcName, filePath string) *taintAnalysis {
+	blocks := make([]*BasicBlock, 0, len(cfg.Blocks))
+	for _, block := range cfg.Blocks {
+		blocks = append(blocks, block)
+	}
+	sort.Slice(blocks, func(i, j int) bool {
+		return blockOrder(blocks[i].ID) < blockOrder(blocks[j].ID)
+	})
+	return &taintAnalysis{cfg: cfg, funcName: funcName, filePath: filePath, blocks: blocks, defs: &pythonRefExtractor{}}
+}
+
+// blockOrder recovers creation order from block IDs like "bb12"
+func blockOrder(id string) int {
+	n, err := strconv.Atoi(strings.TrimPrefix(id, "bb"))
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+func (a *taintAnalysis) run() []domain.SecurityFinding {
+	in := a.solve()
+
+	var findings []domain.SecurityFinding
+	seen := make(map[*parser.Node]bool)
+	for _, block := range a.blocks {
+		state, ok := in[block]
+		if !ok {
+			continue
+		}
+		state = state.clone()
+		for _, stmt := range block.Statements {
+			node, ok := pythonNode(stmt)
+			if !ok {
+				continue
+			}
+			for _, expr := range evaluatedExpressions(node) {
+				walkSinkCalls(expr, func(call *parser.Node) {
+					if seen[call] {
+						return
+					}
+					seen[call] = true
+					if finding, ok := a.checkCall(call, state); ok {
+						findings = append(findings, finding)
+					}
+				})
+			}
+			a.transfer(node, state)
+		}
+	}
+	return findings
+}
+
+// solve computes the taint state at the start of every reachable block
+func (a *taintAnalysis) solve() map[*BasicBlock]*taintState {
+	in := make(map[*BasicBlock]*taintState)
+	out := make(map[*BasicBlock]*taintState)
+
+	for i := 0; i < maxTaintIterations; i++ {
+		changed := false
+		for _, block := range a.blocks {
+			var state *taintState
+			if block == a.cfg.Entry {
+				state = a.entryState()
+			}
+			for _, edge := range block.Predecessors {
+				predOut, ok := out[edge.From]
+				if !ok {
+					continue
+				}
+				if state == nil {
+					state = predOut.clone()
+				} else {
+					state.merge(predOut)
+				}
+			}
+			if state == nil {
+				continue
+			}
+			in[block] = state
+
+			next := state.clone()
+			for _, stmt := range block.Statements {
+				if node, ok := pythonNode(stmt); ok {
+					a.transfer(node, next)
+				}
+			}
+			if prev, ok := out[block]; !ok || !prev.equal(next) {
+				out[block] = next
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	return in
+}
+
+// entryState marks the function's parameters as caller-controlled
+func (a *taintAnalysis) entryState() *taintState {
+	state := newTaintState()
+	fn, ok := pythonNode(a.cfg.FunctionNode)
+	if !ok || (fn.Type != parser.NodeFunctionDef && fn.Type != parser.NodeAsyncFunctionDef) {
+		return state
+	}
+	for i, arg := range fn.Args {
+		if arg == nil || arg.Type != parser.NodeArg || arg.Name == "" {
+			continue
+		}
+		name := strings.TrimLeft(arg.Name, "*")
+		if i == 0 && (name == "self" || name == "cls") {
+			continue
+		}
+		state.vars[name] = taint{level: taintParam, origin: fmt.Sprintf("parameter '%s'", name)}
+	}
+	return state
+}
+
+// transfer updates state with the bindings made by stmt
+func (a *taintAnalysis) transfer(stmt *parser.Node, state *taintState) {
+	defs := a.defs.extractDefinitions(stmt, nil, 0)
+	if len(defs) == 0 {
+		return
+	}
+
+	var value *parser.Node
+	var bound taint
+	switch stmt.Type {
+	case parser.NodeAssign, parser.NodeAnnAssign, parser.NodeAugAssign, parser.NodeNamedExpr:
+		value = nodeValue(stmt)
+		bound = taintOf(value, state)
+	case parser.NodeFor, parser.NodeAsyncFor:
+		bound = taintOf(stmt.Iter, state)
+	case parser.NodeWith, parser.NodeAsyncWith:
+		for _, item := range withItems(stmt) {
+			bound = bound.join(taintOf(nodeValue(item), state))
+		}
+	}
+
+	for _, def := range defs {
+		name := def.Name
+		if stmt.Type == parser.NodeAugAssign {
+			state.vars[name] = state.vars[name].join(bound)
+			if q, ok := state.queries[name]; ok {
+				state.queries[name] = q.join(bound)
+			} else if qt, ok := formattedString(value, state); ok {
+				state.queries[name] = qt
+			}
+			continue
+		}
+
+		state.vars[name] = bound
+		if qt, ok := formattedString(value, state); ok {
+			state.queries[name] = qt
+		} else {
+			delete(state.queries, name)
+		}
+	}
+}
+
+// evaluatedExpressions returns the parts of a CFG statement evaluated at its
+// position. Compound statements are stored whole in their header block, but
+// only their header expression runs there; the body has its own blocks.
+func evaluatedExpressions(stmt *parser.Node) []*parser.Node {
+	switch stmt.Type {
+	case parser.NodeIf, parser.NodeWhile:
+		return []*parser.Node{stmt.Test}
+	case parser.NodeFor, parser.NodeAsyncFor:
+		return []*parser.Node{stmt.Iter}
+	case parser.NodeWith, parser.NodeAsyncWith:
+		var exprs []*parser.Node
+		for _, item := range withItems(stmt) {
+			exprs = append(exprs, nodeValue(item))
+		}
+		return exprs
+	case parser.NodeFunctionDef, parser.NodeAsyncFunctionDef, parser.NodeClassDef,
+		parser.NodeTry, parser.NodeExceptHandler, parser.NodeMatch, parser.NodeMatchCase:
+		return nil
+	default:
+		return []*parser.Node{stmt}
+	}
+}
+
+func withItems(stmt *parser.Node) []*parser.Node {
+	var items []*parser.Node
+	for _, child := range stmt.Children {
+		if child != nil && child.Type == parser.NodeWithItem {
+			items = append(items, child)
+		}
+	}
+	return items
+}
+
+// walkSinkCalls visits every call in expr that is evaluated with expr,
+// skipping lambda bodies and nested definitions
+func walkSinkCalls(expr *parser.Node, visit func(*parser.Node)) {
+	if expr == nil {
+		return
+	}
+	switch expr.Type {
+	case parser.NodeLambda, parser.NodeFunctionDef, parser.NodeAsyncFunctionDef, parser.NodeClassDef:
+		return
+	case parser.NodeCall:
+		visit(expr)
+	}
+	for _, child := range expr.GetChildren() {
+		walkSinkCalls(child, visit)
+	}
+}
+
+// taintOf computes the taint of evaluating expr in state
+func taintOf(expr *parser.Node, state *taintState) taint {
+	if expr == nil {
+		return taint{}
+	}
+
+	switch expr.Type {
+	case parser.NodeConstant, parser.NodeLambda:
+		return taint{}
+	case parser.NodeName:
+		if t, ok := state.vars[expr.Name]; ok {
+			return t
+		}
+		return taint{level: taintUnknown}
+	case parser.NodeAttribute:
+		if source, ok := untrustedSource(dottedName(expr), false); ok {
+			return taint{level: taintSource, origin: fmt.Sprintf("%s on line %d", source, expr.Location.StartLine)}
+		}
+		return taintOf(nodeValue(expr), state)
+	case parser.NodeCall:
+		callee := nodeValue(expr)
+		name := dottedName(callee)
+		if source, ok := untrustedSource(name, true); ok {
+			return taint{level: taintSource, origin: fmt.Sprintf("%s on line %d", source, expr.Location.StartLine)}
+		}
+		if sanitizers[name] {
+			return taint{}
+		}
+		var t taint
+		if callee != nil && callee.Type == parser.NodeAttribute {
+			t = taintOf(nodeValue(callee), state)
+		}
+		for _, arg := range expr.Args {
+			t = t.join(taintOf(arg, state))
+		}
+		for _, kw := range expr.Keywords {
+			t = t.join(taintOf(nodeValue(kw), state))
+		}
+		return t
+	}
+
+	var t taint
+	for _, child := range expr.GetChildren() {
+		t = t.join(taintOf(child, state))
+	}
+	return t
+}
+
+// sanitizers return values that are safe to interpolate whatever their input
+var sanitizers = map[string]bool{
+	"shlex.quote": true, "pipes.quote": true,
+	"int": true, "float": true, "bool": true, "len": true,
+}
+
+// requestSourceAttributes are attributes of web framework request objects
+// carrying client-supplied data (Flask, Django, Starlette)
+var requestSourceAttributes = map[string]bool{
+	"args": true, "form": true, "values": true, "json": true, "data": true,
+	"files": true, "cookies": true, "headers": true, "get_json": true,
+	"get_data": true, "GET": true, "POST": true, "COOKIES": true, "META": true,
+	"FILES": true, "body": true, "query_params": true, "path_params": true,
+}
+
+// untrustedSource reports whether a dotted name reads an obvious untrusted
+// source, returning a short label for it
+func untrustedSource(name string, called bool) (string, bool) {
+	if name == "" {
+		return "", false
+	}
+	if called {
+		switch name {
+		case "input", "raw_input", "os.getenv":
+			return name + "()", true
+		}
+	}
+	for _, prefix := range []string{"sys.argv", "sys.stdin", "os.environ"} {
+		if name == prefix || strings.HasPrefix(name, prefix+".") {
+			return prefix, true
+		}
+	}
+	parts := strings.Split(name, ".")
+	for i := 0; i+1 < len(parts); i++ {
+		if parts[i] == "request" && requestSourceAttributes[parts[i+1]] {
+			return "request." + parts[i+1], true
+		}
+	}
+	return "", false
+}
+
+// dottedName renders a Name/Attribute chain like "os.path.join", or "" for
+// anything else
+func dottedName(node *parser.Node) string {
+	if node == nil {
+		return ""
+	}
+	switch node.Type {
+	case parser.NodeName:
+		return node.Name
+	case parser.NodeAttribute:
+		if base := dottedName(nodeValue(node)); base != "" {
+			return base + "." + node.Name
+		}
+	}
+	return ""
+}
+
+// formattedString reports whether expr builds a string by interpolation and
+// returns the taint of the interpolated values
+func formattedString(expr *parser.Node, state *taintState) (taint, bool) {
+	if expr == nil {
+		return taint{}, false
+	}
+	switch expr.Type {
+	case parser.NodeJoinedStr:
+		var t taint
+		formatted := false
+		for _, child := range expr.Children {
+			if child != nil && child.Type == parser.NodeFormattedValue {
+				formatted = true
+				t = t.join(taintOf(child, state))
+			}
+		}
+		return t, formatted
+	case parser.NodeBinOp:
+		switch expr.Op {
+		case "%":
+			if isStringConstant(expr.Left) {
+				return taintOf(expr.Right, state), true
+			}
+			if t, ok := formattedString(expr.Left, state); ok {
+				return t.join(taintOf(expr.Right, state)), true
+			}
+		case "+":
+			lt, lok := formattedString(expr.Left, state)
+			rt, rok := formattedString(expr.Right, state)
+			if lok || rok || isStringConstant(expr.Left) || isStringConstant(expr.Right) {
+				if !lok {
+					lt = taintOf(expr.Left, state)
+				}
+				if !rok {
+					rt = taintOf(expr.Right, state)
+				}
+				return lt.join(rt), true
+			}
+		}
+	case parser.NodeCall:
+		callee := nodeValue(expr)
+		if callee != nil && callee.Type == parser.NodeAttribute && callee.Name == "format" && isStringConstant(nodeValue(callee)) {
+			var t taint
+			for _, arg := range expr.Args {
+				t = t.join(taintOf(arg, state))
+			}
+			for _, kw := range expr.Keywords {
+				t = t.join(taintOf(nodeValue(kw), state))
+			}
+			return t, true
+		}
+	case parser.NodeName:
+		t, ok := state.queries[expr.Name]
+		return t, ok
+	}
+	return taint{}, false
+}
+
+var (
+	codeExecutionSinks = map[string]bool{
+		"eval": true, "exec": true, "compile": true,
+		"builtins.eval": true, "builtins.exec": true, "builtins.compile": true,
+	}
+	// shellSinks always run their command through a shell
+	shellSinks = map[string]bool{
+		"os.system": true, "os.popen": true,
+		"subprocess.getoutput": true, "subprocess.getstatusoutput": true,
+		"commands.getoutput": true, "commands.getstatusoutput": true,
+	}
+	// subprocessSinks use a shell only when called with shell=True
+	subprocessSinks = map[string]bool{
+		"subprocess.run": true, "subprocess.call": true, "subprocess.check_call": true,
+		"subprocess.check_output": true, "subprocess.Popen": true,
+	}
+	deserializationModules = map[string]bool{
+		"pickle": true, "cPickle": true, "_pickle": true, "dill": true, "marshal": true,
+	}
+	unsafeYAMLLoaders = map[string]bool{
+		"Loader": true, "UnsafeLoader": true, "CLoader": true, "CUnsafeLoader": true,
+	}
+	sqlExecuteMethods = map[string]bool{
+		"execute": true, "executemany": true, "executescript": true, "raw": true,
+		"read_sql": true, "read_sql_query": true,
+	}
+)
+
+// checkCall matches call against the known sinks
+func (a *taintAnalysis) checkCall(call *parser.Node, state *taintState) (domain.SecurityFinding, bool) {
+	callee := nodeValue(call)
+	name := dottedName(callee)
+	if callee == nil {
+		return domain.SecurityFinding{}, false
+	}
+
+	switch {
+	case codeExecutionSinks[name]:
+		t := taintOf(sinkArgument(call, 0, "source"), state)
+		if t.level == taintClean {
+			break
+		}
+		return a.finding(call, domain.SecurityRuleCodeExecution, t.severity(), name+"()", t,
+			fmt.Sprintf("%s() executes %s", name, t.describe()),
+			"Avoid evaluating dynamic code; use ast.literal_eval for literals or an explicit dispatch table"), true
+
+	case shellSinks[name], subprocessSinks[name] && hasTrueKeyword(call, "shell"):
+		sink := name + "()"
+		if subprocessSinks[name] {
+			sink = name + "(shell=True)"
+		}
+		t := taintOf(sinkArgument(call, 0, "args", "cmd", "command"), state)
+		if t.level == taintClean {
+			break
+		}
+		return a.finding(call, domain.SecurityRuleShellInjection, t.severity(), sink, t,
+			fmt.Sprintf("%s runs a shell command built from %s", sink, t.describe()),
+			"Pass the command as an argument list without shell=True, or quote input with shlex.quote"), true
+
+	case isDeserializationSink(name):
+		t := taintOf(sinkArgument(call, 0), state)
+		description := fmt.Sprintf("%s() can execute arbitrary code while deserializing", name)
+		if t.level >= taintParam {
+			description = fmt.Sprintf("%s() deserializes %s and can execute arbitrary code", name, t.describe())
+		}
+		return a.finding(call, domain.SecurityRuleUnsafeDeserialization, atLeastWarning(t), name+"()", t,
+			description, "Only deserialize trusted data, or use a data-only format such as JSON"), true
+
+	case isUnsafeYAMLLoad(call, name):
+		t := taintOf(sinkArgument(call, 0, "stream"), state)
+		description := fmt.Sprintf("%s() without a safe Loader can construct arbitrary Python objects", name)
+		if t.level >= taintParam {
+			description = fmt.Sprintf("%s() without a safe Loader parses %s and can construct arbitrary Python objects", name, t.describe())
+		}
+		return a.finding(call, domain.SecurityRuleUnsafeYAMLLoad, atLeastWarning(t), name+"()", t,
+			description, "Use yaml.safe_load or pass Loader=yaml.SafeLoader"), true
+
+	case callee.Type == parser.NodeAttribute && sqlExecuteMethods[callee.Name]:
+		t, ok := formattedString(sinkArgument(call, 0, "sql", "query"), state)
+		if !ok || t.level == taintClean {
+			break
+		}
+		sink := "." + callee.Name + "()"
+		return a.finding(call, domain.SecurityRuleSQLInjection, t.severity(), sink, t,
+			fmt.Sprintf("SQL query passed to %s is formatted with %s", sink, t.describe()),
+			"Use a parameterized query and pass values as query parameters"), true
+	}
+	return domain.SecurityFinding{}, false
+}
+
+func (a *taintAnalysis) finding(call *parser.Node, rule domain.SecurityRule, severity domain.SecuritySeverity, sink string, t taint, description, suggestion string) domain.SecurityFinding {
+	finding := domain.SecurityFinding{
+		Rule:         rule,
+		Severity:     severity,
+		FunctionName: a.funcName,
+		Location:     sourceLocation(a.filePath, call.Location),
+		Sink:         sink,
+		Description:  description,
+		Suggestion:   suggestion,
+	}
+	if t.level >= taintParam {
+		finding.Source = t.origin
+	}
+	return finding
+}
+
+// atLeastWarning is used for APIs that are unsafe whatever their input
+func atLeastWarning(t taint) domain.SecuritySeverity {
+	if t.level == taintSource {
+		return domain.SecuritySeverityError
+	}
+	return domain.SecuritySeverityWarning
+}
+
+// callArgument returns the positional argument at index, or the first of the
+// given keywords passed instead
+func sinkArgument(call *parser.Node, index int, keywords ...string) *parser.Node {
+	positional := 0
+	for _, arg := range call.Args {
+		if arg == nil || arg.Type == parser.NodeStarred {
+			continue
+		}
+		if positional == index {
+			return arg
+		}
+		positional++
+	}
+	for _, kw := range call.Keywords {
+		if kw == nil {
+			continue
+		}
+		for _, name := range keywords {
+			if kw.Name == name {
+				return nodeValue(kw)
+			}
+		}
+	}
+	return nil
+}
+
+func hasTrueKeyword(call *parser.Node, name string) bool {
+	for _, kw := range call.Keywords {
+		if kw == nil || kw.Name != name {
+			continue
+		}
+		value := nodeValue(kw)
+		if value == nil || value.Type != parser.NodeConstant {
+			return false
+		}
+		b, ok := value.Value.(bool)
+		return ok && b
+	}
+	return false
+}
+
+func isDeserializationSink(name string) bool {
+	module, fn, ok := strings.Cut(name, ".")
+	if !ok || !deserializationModules[module] {
+		return false
+	}
+	return fn == "load" || fn == "loads" || fn == "Unpickler"
+}
+
+func isUnsafeYAMLLoad(call *parser.Node, name string) bool {
+	switch name {
+	case "yaml.unsafe_load", "yaml.unsafe_load_all":
+		return true
+	case "yaml.load", "yaml.load_all":
+	default:
+		return false
+	}
+
+	loader := sinkArgument(call, 1, "Loader")
+	if loader == nil {
+		return true
+	}
+	loaderName := dottedName(loader)
+	if i := strings.LastIndex(loaderName, "."); i >= 0 {
+		loaderName = loaderName[i+1:]
+	}
+	return unsafeYAMLLoaders[loaderName]
+}
+
+// SortSecurityFindings sorts findings by the specified criteria
+func SortSecurityFindings(findings []domain.SecurityFinding, sortBy domain.SortCriteria) []domain.SecurityFinding {
+	sorted := make([]domain.SecurityFinding, len(findings))
+	copy(sorted, findings)
+
+	byLocation := func(i, j int) bool {
+		if sorted[i].Location.FilePath != sorted[j].Location.FilePath {
+			return sorted[i].Location.FilePath < sorted[j].Location.FilePath
+		}
+		if sorted[i].Location.StartLine != sorted[j].Location.StartLine {
+			return sorted[i].Location.StartLine < sorted[j].Location.StartLine
+		}
+		return sorted[i].Location.StartCol < sorted[j].Location.StartCol
+	}
+
+	switch sortBy {
+	case domain.SortByName:
+		sort.SliceStable(sorted, func(i, j int) bool {
+			if sorted[i].FunctionName != sorted[j].FunctionName {
+				return sorted[i].FunctionName < sorted[j].FunctionName
+			}
+			return byLocation(i, j)
+		})
+	case domain.SortByLocation:
+		sort.SliceStable(sorted, byLocation)
+	default:
+		// Default to severity (also handles SortBySeverity)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			if sorted[i].Severity.SeverityOrder() != sorted[j].Severity.SeverityOrder() {
+				return sorted[i].Severity.SeverityOrder() > sorted[j].Severity.SeverityOrder()
+			}
+			return byLocation(i, j)
+		})
+	}
+
+	return sorted
+}
+
+// GenerateSecuritySummary generates summary statistics from findings
+func GenerateSecuritySummary(findings []domain.SecurityFinding, filesAnalyzed int) domain.SecuritySummary {
+	summary := domain.SecuritySummary{
+		TotalFindings: len(findings),
+		ByRule:        make(map[domain.SecurityRule]int),
+		BySeverity:    make(map[domain.SecuritySeverity]int),
+		FilesAnalyzed: filesAnalyzed,
+	}
+
+	affectedFiles := make(map[string]bool)
+	for _, finding := range findings {
+		summary.ByRule[finding.Rule]++
+		summary.BySeverity[finding.Severity]++
+		affectedFiles[finding.Location.FilePath] = true
+	}
+	summary.AffectedFiles = len(affectedFiles)
+
+	return summary
+}
@@ -0,0 +1,317 @@
+package analyzer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+func analyzeSecurity(t *testing.T, code string) []domain.SecurityFinding {
+	t.Helper()
+	result, err := parser.New().Parse(context.Background(), []byte(code))
+	if err != nil {
+		t.Fatalf("failed to parse code: %v", err)
+	}
+	detector := NewSecurityDetector(&SecurityOptions{MinSeverity: domain.SecuritySeverityInfo})
+	findings, err := detector.Analyze(result.AST, "test.py")
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	return findings
+}
+
+func TestSecurityDetector(t *testing.T) {
+	tests := []struct {
+		name         string
+		code         string
+		wantRule     domain.SecurityRule
+		wantSeverity domain.SecuritySeverity
+		wantSource   string
+		wantLine     int
+	}{
+		{
+			name: "eval of input",
+			code: `
+def run():
+    expr = input()
+    return eval(expr)
+`,
+			wantRule:     domain.SecurityRuleCodeExecution,
+			wantSeverity: domain.SecuritySeverityError,
+			wantSource:   "input() on line 3",
+			wantLine:     4,
+		},
+		{
+			name: "exec of parameter",
+			code: `
+def run(code):
+    exec(code)
+`,
+			wantRule:     domain.SecurityRuleCodeExecution,
+			wantSeverity: domain.SecuritySeverityWarning,
+			wantSource:   "parameter 'code'",
+			wantLine:     3,
+		},
+		{
+			name: "eval of unknown global",
+			code: `
+def run():
+    eval(EXPRESSION)
+`,
+			wantRule:     domain.SecurityRuleCodeExecution,
+			wantSeverity: domain.SecuritySeverityInfo,
+			wantLine:     3,
+		},
+		{
+			name: "subprocess with shell and argv",
+			code: `
+import subprocess, sys
+def run():
+    target = sys.argv[1]
+    subprocess.run("ls " + target, shell=True)
+`,
+			wantRule:     domain.SecurityRuleShellInjection,
+			wantSeverity: domain.SecuritySeverityError,
+			wantSource:   "sys.argv on line 4",
+			wantLine:     5,
+		},
+		{
+			name: "os.system with request data",
+			code: `
+import os
+def handler(request):
+    host = request.args.get("host")
+    os.system(f"ping {host}")
+`,
+			wantRule:     domain.SecurityRuleShellInjection,
+			wantSeverity: domain.SecuritySeverityError,
+			wantSource:   "request.args on line 4",
+			wantLine:     5,
+		},
+		{
+			name: "pickle loads of constant is still a warning",
+			code: `
+import pickle
+def load():
+    return pickle.loads(BLOB)
+`,
+			wantRule:     domain.SecurityRuleUnsafeDeserialization,
+			wantSeverity: domain.SecuritySeverityWarning,
+			wantLine:     4,
+		},
+		{
+			name: "yaml load without loader",
+			code: `
+import yaml
+def load(stream):
+    return yaml.load(stream)
+`,
+			wantRule:     domain.SecurityRuleUnsafeYAMLLoad,
+			wantSeverity: domain.SecuritySeverityWarning,
+			wantSource:   "parameter 'stream'",
+			wantLine:     4,
+		},
+		{
+			name: "yaml load with unsafe loader",
+			code: `
+import yaml
+def load(stream):
+    return yaml.load(stream, Loader=yaml.UnsafeLoader)
+`,
+			wantRule:     domain.SecurityRuleUnsafeYAMLLoad,
+			wantSeverity: domain.SecuritySeverityWarning,
+			wantSource:   "parameter 'stream'",
+			wantLine:     4,
+		},
+		{
+			name: "sql built with percent formatting",
+			code: `
+def find(cursor, name):
+    cursor.execute("SELECT * FROM users WHERE name = '%s'" % name)
+`,
+			wantRule:     domain.SecurityRuleSQLInjection,
+			wantSeverity: domain.SecuritySeverityWarning,
+			wantSource:   "parameter 'name'",
+			wantLine:     3,
+		},
+		{
+			name: "sql built into a variable first",
+			code: `
+import os
+def find(cursor):
+    query = "SELECT * FROM t WHERE id = {}".format(os.environ["ID"])
+    query += " LIMIT 1"
+    cursor.execute(query)
+`,
+			wantRule:     domain.SecurityRuleSQLInjection,
+			wantSeverity: domain.SecuritySeverityError,
+			wantSource:   "os.environ on line 4",
+			wantLine:     6,
+		},
+		{
+			name: "taint joins across branches",
+			code: `
+def run(flag):
+    if flag:
+        code = input()
+    else:
+        code = "1 + 1"
+    eval(code)
+`,
+			wantRule:     domain.SecurityRuleCodeExecution,
+			wantSeverity: domain.SecuritySeverityError,
+			wantSource:   "input() on line 4",
+			wantLine:     7,
+		},
+		{
+			name: "taint flows around loops",
+			code: `
+def run(items):
+    cmd = "true"
+    for item in items:
+        eval(cmd)
+        cmd = input()
+`,
+			wantRule:     domain.SecurityRuleCodeExecution,
+			wantSeverity: domain.SecuritySeverityError,
+			wantSource:   "input() on line 6",
+			wantLine:     5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := analyzeSecurity(t, tt.code)
+			if len(findings) != 1 {
+				t.Fatalf("got %d findings, want 1: %+v", len(findings), findings)
+			}
+			f := findings[0]
+			if f.Rule != tt.wantRule {
+				t.Errorf("rule = %s, want %s", f.Rule, tt.wantRule)
+			}
+			if f.Severity != tt.wantSeverity {
+				t.Errorf("severity = %s, want %s", f.Severity, tt.wantSeverity)
+			}
+			if f.Source != tt.wantSource {
+				t.Errorf("source = %q, want %q", f.Source, tt.wantSource)
+			}
+			if f.Location.StartLine != tt.wantLine {
+				t.Errorf("line = %d, want %d", f.Location.StartLine, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestSecurityDetector_NoFindings(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+	}{
+		{
+			name: "constant eval",
+			code: `
+def run():
+    return eval("1 + 1")
+`,
+		},
+		{
+			name: "reassignment to a constant clears taint",
+			code: `
+def run():
+    expr = input()
+    expr = "2 * 3"
+    return eval(expr)
+`,
+		},
+		{
+			name: "subprocess without shell",
+			code: `
+import subprocess
+def run(path):
+    subprocess.run(["ls", path])
+`,
+		},
+		{
+			name: "quoted shell argument",
+			code: `
+import os, shlex
+def run(path):
+    os.system("ls " + shlex.quote(path))
+`,
+		},
+		{
+			name: "yaml load with safe loader",
+			code: `
+import yaml
+def load(stream):
+    return yaml.load(stream, Loader=yaml.SafeLoader)
+`,
+		},
+		{
+			name: "parameterized sql",
+			code: `
+def find(cursor, name):
+    cursor.execute("SELECT * FROM users WHERE name = ?", (name,))
+`,
+		},
+		{
+			name: "sql formatted from constants only",
+			code: `
+def count(cursor):
+    table = "users"
+    cursor.execute(f"SELECT COUNT(*) FROM {table}")
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if findings := analyzeSecurity(t, tt.code); len(findings) != 0 {
+				t.Errorf("got %d findings, want 0: %+v", len(findings), findings)
+			}
+		})
+	}
+}
+
+func TestSecurityDetector_MinSeverity(t *testing.T) {
+	code := `
+def run(code):
+    eval(code)
+    eval(GLOBAL)
+`
+	result, err := parser.New().Parse(context.Background(), []byte(code))
+	if err != nil {
+		t.Fatalf("failed to parse code: %v", err)
+	}
+
+	findings, err := NewSecurityDetector(nil).Analyze(result.AST, "test.py")
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if len(findings) != 1 || findings[0].Severity != domain.SecuritySeverityWarning {
+		t.Errorf("default options should keep only the warning, got %+v", findings)
+	}
+}
+
+func TestGenerateSecuritySummary(t *testing.T) {
+	findings := []domain.SecurityFinding{
+		{Rule: domain.SecurityRuleCodeExecution, Severity: domain.SecuritySeverityError, Location: domain.SourceLocation{FilePath: "a.py"}},
+		{Rule: domain.SecurityRuleSQLInjection, Severity: domain.SecuritySeverityWarning, Location: domain.SourceLocation{FilePath: "a.py"}},
+		{Rule: domain.SecurityRuleSQLInjection, Severity: domain.SecuritySeverityWarning, Location: domain.SourceLocation{FilePath: "b.py"}},
+	}
+
+	summary := GenerateSecuritySummary(findings, 3)
+	if summary.TotalFindings != 3 || summary.AffectedFiles != 2 || summary.FilesAnalyzed != 3 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+	if summary.ByRule[domain.SecurityRuleSQLInjection] != 2 {
+		t.Errorf("sql_injection count = %d, want 2", summary.ByRule[domain.SecurityRuleSQLInjection])
+	}
+
+	sorted := SortSecurityFindings(findings, domain.SortBySeverity)
+	if sorted[0].Severity != domain.SecuritySeverityError {
+		t.Errorf("severity sort should put errors first, got %s", sorted[0].Severity)
+	}
+}
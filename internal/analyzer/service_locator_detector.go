@@ -70,17 +70,11 @@ func (d *ServiceLocatorDetector) analyzeMethod(methodNode *parser.Node, classNam
 // createFinding creates a DIAntipatternFinding for service locator pattern
 func (d *ServiceLocatorDetector) createFinding(className, methodName, filePath string, node *parser.Node, locatorInfo *serviceLocatorInfo) domain.DIAntipatternFinding {
 	return domain.DIAntipatternFinding{
-		Type:       domain.DIAntipatternServiceLocator,
-		Severity:   domain.DIAntipatternSeverityWarning,
-		ClassName:  className,
-		MethodName: methodName,
-		Location: domain.SourceLocation{
-			FilePath:  filePath,
-			StartLine: node.Location.StartLine,
-			EndLine:   node.Location.EndLine,
-			StartCol:  node.Location.StartCol,
-			EndCol:    node.Location.EndCol,
-		},
+		Type:        domain.DIAntipatternServiceLocator,
+		Severity:    domain.DIAntipatternSeverityWarning,
+		ClassName:   className,
+		MethodName:  methodName,
+		Location:    sourceLocation(filePath, node.Location),
 		Description: fmt.Sprintf("Uses service locator pattern via '%s'", locatorInfo.methodName),
 		Suggestion:  "Inject the dependency as a constructor parameter instead of using service locator",
 		Details: map[string]interface{}{
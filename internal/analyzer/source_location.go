@@ -0,0 +1,20 @@
+package analyzer
+
+import (
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// sourceLocation converts the location of a parsed node into the location
+// reported in findings
+func sourceLocation(filePath string, loc parser.Location) domain.SourceLocation {
+	return domain.SourceLocation{
+		FilePath:  filePath,
+		StartLine: loc.StartLine,
+		EndLine:   loc.EndLine,
+		StartCol:  loc.StartCol,
+		EndCol:    loc.EndCol,
+		StartByte: loc.StartByte,
+		EndByte:   loc.EndByte,
+	}
+}
@@ -0,0 +1,417 @@
+package analyzer
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// stringShingleSize is the number of tokens in each shingle compared
+// between string literals
+const stringShingleSize = 3
+
+// stringPreviewLength caps the characters of a literal preview
+const stringPreviewLength = 80
+
+// sqlClauses are words of which an SQL statement has at least one after
+// its keyword, telling queries from prose starting with "Update"
+var sqlClauses = map[string]bool{
+	"from":   true,
+	"into":   true,
+	"set":    true,
+	"table":  true,
+	"index":  true,
+	"view":   true,
+	"where":  true,
+	"values": true,
+}
+
+// sqlKeywords start the statements of embedded SQL
+var sqlKeywords = map[string]bool{
+	"select": true,
+	"insert": true,
+	"update": true,
+	"delete": true,
+	"with":   true,
+	"create": true,
+	"alter":  true,
+	"drop":   true,
+	"merge":  true,
+	"upsert": true,
+}
+
+// shellCommands start common embedded shell commands
+var shellCommands = map[string]bool{
+	"bash":      true,
+	"sh":        true,
+	"git":       true,
+	"docker":    true,
+	"kubectl":   true,
+	"curl":      true,
+	"wget":      true,
+	"rm":        true,
+	"cp":        true,
+	"mv":        true,
+	"mkdir":     true,
+	"echo":      true,
+	"cd":        true,
+	"python":    true,
+	"python3":   true,
+	"pip":       true,
+	"make":      true,
+	"ssh":       true,
+	"scp":       true,
+	"rsync":     true,
+	"tar":       true,
+	"find":      true,
+	"grep":      true,
+	"sed":       true,
+	"awk":       true,
+	"chmod":     true,
+	"chown":     true,
+	"sudo":      true,
+	"npm":       true,
+	"systemctl": true,
+}
+
+// StringLiteral is a large string literal of a file, with its text
+// whitespace collapsed and f-string replacement fields as "{}"
+type StringLiteral struct {
+	FilePath  string
+	StartLine int
+	EndLine   int
+	StartCol  int
+	EndCol    int
+	Kind      string // One of the domain string clone kinds
+	Text      string
+
+	shingles map[string]bool
+}
+
+// StringCloneGroup is a set of near-duplicate string literals of one kind
+type StringCloneGroup struct {
+	Kind       string
+	Similarity float64 // Lowest similarity between linked literals
+	Literals   []*StringLiteral
+}
+
+// StringCloneDetector finds near-duplicate string literals, such as
+// embedded SQL, HTML templates and shell commands, which AST-based clone
+// detection sees only as constants. Literals are compared by the Jaccard
+// similarity of their token shingles, with numbers and query parameters
+// normalized, so that queries differing in a column or a bound value
+// still match.
+type StringCloneDetector struct {
+	minLength int
+	threshold float64
+	literals  []*StringLiteral
+}
+
+// NewStringCloneDetector creates a detector of literals of at least
+// minLength characters and similarity threshold
+func NewStringCloneDetector(minLength int, threshold float64) *StringCloneDetector {
+	return &StringCloneDetector{minLength: minLength, threshold: threshold}
+}
+
+// AddFile collects the large string literals of a parsed file. Strings
+// standing as statements, such as docstrings, are skipped.
+func (d *StringCloneDetector) AddFile(filePath string, ast *parser.Node) {
+	if ast == nil {
+		return
+	}
+	statements := make(map[*parser.Node]bool)
+	ast.Walk(func(n *parser.Node) bool {
+		for _, stmt := range n.Body {
+			statements[stmt] = true
+		}
+		if statements[n] && n.Type == parser.NodeConstant {
+			return false
+		}
+		text, ok := stringLiteralText(n)
+		if !ok {
+			return true
+		}
+		if utf8.RuneCountInString(text) >= d.minLength {
+			literal := &StringLiteral{
+				FilePath:  filePath,
+				StartLine: n.Location.StartLine,
+				EndLine:   n.Location.EndLine,
+				StartCol:  n.Location.StartCol,
+				EndCol:    n.Location.EndCol,
+				Text:      text,
+			}
+			tokens := stringTokens(text)
+			literal.Kind = stringKind(text, tokens)
+			literal.shingles = stringShingles(tokens)
+			d.literals = append(d.literals, literal)
+		}
+		// The parts of an f-string are not literals of their own
+		return false
+	})
+}
+
+// stringLiteralText returns the text of a string constant or f-string,
+// whitespace collapsed and without quotes
+func stringLiteralText(n *parser.Node) (string, bool) {
+	var raw string
+	switch n.Type {
+	case parser.NodeConstant:
+		value, ok := n.Value.(string)
+		if !ok {
+			return "", false
+		}
+		raw = value
+	case parser.NodeJoinedStr:
+		var b strings.Builder
+		for _, child := range n.Children {
+			switch child.Type {
+			case parser.NodeConstant:
+				if value, ok := child.Value.(string); ok {
+					b.WriteString(value)
+				}
+			case parser.NodeFormattedValue:
+				b.WriteString("{}")
+			}
+		}
+		raw = b.String()
+	default:
+		return "", false
+	}
+
+	// Constants keep the prefix and inner quotes of their source, as in
+	// r"..." and implicitly concatenated strings
+	if i := strings.IndexAny(raw, `"'`); i >= 0 && strings.Trim(raw[:i], "rRbBuUfF") == "" {
+		raw = raw[i:]
+	}
+	var b strings.Builder
+	space := false
+	for _, r := range raw {
+		switch {
+		case r == '"' || r == '\'':
+			continue
+		case unicode.IsSpace(r):
+			space = b.Len() > 0
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String(), true
+}
+
+// stringTokens splits literal text into lowercase words and symbols, with
+// numbers as "0" and placeholders and query parameters as "?"
+func stringTokens(text string) []string {
+	text = strings.ToLower(text)
+	var tokens []string
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == ' ':
+			i++
+		case isWordByte(c):
+			j := i
+			for j < len(text) && isWordByte(text[j]) {
+				j++
+			}
+			word := text[i:j]
+			if strings.Trim(word, "0123456789") == "" {
+				word = "0"
+			}
+			tokens = append(tokens, word)
+			i = j
+		case c == '{':
+			if end := strings.IndexByte(text[i:], '}'); end > 0 {
+				tokens = append(tokens, "?")
+				i += end + 1
+			} else {
+				tokens = append(tokens, "{")
+				i++
+			}
+		case c == '%' && i+1 < len(text) && strings.IndexByte("sdrf(", text[i+1]) >= 0:
+			j := i + 2
+			if text[i+1] == '(' {
+				if end := strings.IndexByte(text[i:], ')'); end > 0 {
+					j = i + end + 2
+				}
+			}
+			tokens = append(tokens, "?")
+			i = min(j, len(text))
+		case (c == ':' || c == '$') && i+1 < len(text) && isWordByte(text[i+1]) && (i == 0 || text[i-1] != ':'):
+			j := i + 1
+			for j < len(text) && isWordByte(text[j]) {
+				j++
+			}
+			tokens = append(tokens, "?")
+			i = j
+		default:
+			_, size := utf8.DecodeRuneInString(text[i:])
+			tokens = append(tokens, text[i:i+size])
+			i += size
+		}
+	}
+	return tokens
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c >= 0x80
+}
+
+// stringKind guesses the language a literal embeds from its first word
+// and markup
+func stringKind(text string, tokens []string) string {
+	first, clause := "", false
+	for _, token := range tokens {
+		if first == "" && isWordByte(token[0]) {
+			first = token
+		} else if sqlClauses[token] {
+			clause = true
+		}
+	}
+	switch {
+	case sqlKeywords[first] && clause:
+		return domain.StringCloneKindSQL
+	case strings.HasPrefix(text, "<") || strings.Contains(text, "</") || strings.Contains(text, "/>"):
+		return domain.StringCloneKindHTML
+	case shellCommands[first]:
+		return domain.StringCloneKindShell
+	}
+	return domain.StringCloneKindText
+}
+
+// stringShingles returns the sets of consecutive tokens of a literal
+func stringShingles(tokens []string) map[string]bool {
+	shingles := make(map[string]bool)
+	if len(tokens) < stringShingleSize {
+		shingles[strings.Join(tokens, "\x00")] = true
+		return shingles
+	}
+	for i := 0; i+stringShingleSize <= len(tokens); i++ {
+		shingles[strings.Join(tokens[i:i+stringShingleSize], "\x00")] = true
+	}
+	return shingles
+}
+
+// stringSimilarity is the Jaccard similarity of the shingles of two literals
+func stringSimilarity(a, b *StringLiteral) float64 {
+	shared := 0
+	for shingle := range a.shingles {
+		if b.shingles[shingle] {
+			shared++
+		}
+	}
+	union := len(a.shingles) + len(b.shingles) - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
+
+// DetectGroups links the literals of a kind at least as similar as the
+// threshold and returns the groups of linked literals, largest first
+func (d *StringCloneDetector) DetectGroups(ctx context.Context) []*StringCloneGroup {
+	// Sorted by shingle count, a literal is compared only with those whose
+	// count allows the threshold: the Jaccard similarity is at most the
+	// ratio of the counts
+	literals := append([]*StringLiteral(nil), d.literals...)
+	sort.SliceStable(literals, func(i, j int) bool {
+		return len(literals[i].shingles) < len(literals[j].shingles)
+	})
+
+	parent := make([]int, len(literals))
+	lowest := make([]float64, len(literals))
+	for i := range parent {
+		parent[i] = i
+		lowest[i] = 1
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for i, a := range literals {
+		if ctx.Err() != nil {
+			return nil
+		}
+		for j := i + 1; j < len(literals); j++ {
+			b := literals[j]
+			if float64(len(a.shingles)) < d.threshold*float64(len(b.shingles)) {
+				break
+			}
+			if a.Kind != b.Kind {
+				continue
+			}
+			similarity := stringSimilarity(a, b)
+			if similarity < d.threshold {
+				continue
+			}
+			ra, rb := find(i), find(j)
+			low := math.Min(similarity, math.Min(lowest[ra], lowest[rb]))
+			parent[rb] = ra
+			lowest[ra] = low
+		}
+	}
+
+	byRoot := make(map[int]*StringCloneGroup)
+	var groups []*StringCloneGroup
+	for i, literal := range literals {
+		root := find(i)
+		group, ok := byRoot[root]
+		if !ok {
+			group = &StringCloneGroup{Kind: literal.Kind, Similarity: lowest[root]}
+			byRoot[root] = group
+			groups = append(groups, group)
+		}
+		group.Literals = append(group.Literals, literal)
+	}
+
+	kept := groups[:0]
+	for _, group := range groups {
+		if len(group.Literals) < 2 {
+			continue
+		}
+		sort.Slice(group.Literals, func(i, j int) bool {
+			a, b := group.Literals[i], group.Literals[j]
+			if a.FilePath != b.FilePath {
+				return a.FilePath < b.FilePath
+			}
+			return a.StartLine < b.StartLine
+		})
+		kept = append(kept, group)
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		a, b := kept[i], kept[j]
+		if len(a.Literals) != len(b.Literals) {
+			return len(a.Literals) > len(b.Literals)
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Literals[0].FilePath != b.Literals[0].FilePath {
+			return a.Literals[0].FilePath < b.Literals[0].FilePath
+		}
+		return a.Literals[0].StartLine < b.Literals[0].StartLine
+	})
+	return kept
+}
+
+// Preview returns the start of the text of a literal
+func (l *StringLiteral) Preview() string {
+	if utf8.RuneCountInString(l.Text) <= stringPreviewLength {
+		return l.Text
+	}
+	runes := []rune(l.Text)
+	return string(runes[:stringPreviewLength-3]) + "..."
+}
@@ -0,0 +1,105 @@
+package analyzer
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+func TestStringCloneDetector(t *testing.T) {
+	detector := NewStringCloneDetector(40, 0.8)
+	detector.AddFile("app/users.py", parseSource(t, `
+"""Module docstring long enough to be compared with the queries below, if it were."""
+
+def active_users(db, since):
+    """SELECT id, name, email FROM users WHERE active = 1 AND created_at > %s ORDER BY name"""
+    return db.execute(
+        "SELECT id, name, email FROM users "
+        "WHERE active = 1 AND created_at > %s "
+        "ORDER BY name",
+        (since,),
+    )
+
+def page(title):
+    return f"<html><head><title>{title}</title></head><body><h1>{title}</h1></body></html>"
+`))
+	detector.AddFile("app/reports.py", parseSource(t, `
+def recent_users(db, day):
+    return db.execute("""
+        SELECT id, name, email
+        FROM users
+        WHERE active = 0 AND created_at > :day
+        ORDER BY name
+    """, {"day": day})
+
+def other_page(heading):
+    return f"<html><head><title>{heading}</title></head><body><h1>{heading}</h1></body></html>"
+
+def deploy():
+    return "Update failed because the server did not answer, please retry the deployment later"
+`))
+
+	groups := detector.DetectGroups(context.Background())
+	if len(groups) != 2 {
+		t.Fatalf("DetectGroups() returned %d groups, want 2: %+v", len(groups), groups)
+	}
+
+	kinds := map[string][]string{}
+	for _, group := range groups {
+		if group.Similarity < 0.8 || group.Similarity > 1 {
+			t.Errorf("group %s has similarity %v", group.Kind, group.Similarity)
+		}
+		for _, literal := range group.Literals {
+			kinds[group.Kind] = append(kinds[group.Kind], literal.FilePath)
+		}
+	}
+	want := map[string][]string{
+		domain.StringCloneKindHTML: {"app/reports.py", "app/users.py"},
+		domain.StringCloneKindSQL:  {"app/reports.py", "app/users.py"},
+	}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Errorf("groups = %v, want %v", kinds, want)
+	}
+
+	for _, group := range groups {
+		if group.Kind != domain.StringCloneKindSQL {
+			continue
+		}
+		query := group.Literals[1]
+		if query.StartLine != 7 || query.EndLine != 9 {
+			t.Errorf("query spans lines %d-%d, want 7-9", query.StartLine, query.EndLine)
+		}
+		if query.Text != "SELECT id, name, email FROM users WHERE active = 1 AND created_at > %s ORDER BY name" {
+			t.Errorf("query text = %q", query.Text)
+		}
+	}
+}
+
+func TestStringTokens(t *testing.T) {
+	got := stringTokens("SELECT a FROM t WHERE id = %(id)s AND n > :n AND x = $1 LIMIT 10 {}")
+	want := []string{"select", "a", "from", "t", "where", "id", "=", "?", "and", "n", ">", "?", "and", "x", "=", "?", "limit", "0", "?"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("stringTokens() = %q, want %q", got, want)
+	}
+}
+
+func TestStringKind(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"SELECT id FROM users", domain.StringCloneKindSQL},
+		{"insert into users (id) values (1)", domain.StringCloneKindSQL},
+		{"Update failed, please retry", domain.StringCloneKindText},
+		{"<div class=\"card\">{}</div>", domain.StringCloneKindHTML},
+		{"git clone --depth 1 {} && make build", domain.StringCloneKindShell},
+		{"The quick brown fox", domain.StringCloneKindText},
+	}
+	for _, tt := range tests {
+		if got := stringKind(tt.text, stringTokens(tt.text)); got != tt.want {
+			t.Errorf("stringKind(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
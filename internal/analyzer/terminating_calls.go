@@ -0,0 +1,256 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// DefaultTerminatingCalls are the calls that never return to their caller:
+// they end the process or raise unconditionally
+var DefaultTerminatingCalls = []string{
+	"exit",
+	"quit",
+	"sys.exit",
+	"os._exit",
+	"os.abort",
+	"typing.assert_never",
+	"typing_extensions.assert_never",
+}
+
+// noReturnAnnotations are the return annotations of functions that never
+// return
+var noReturnAnnotations = map[string]bool{
+	"NoReturn":                   true,
+	"Never":                      true,
+	"typing.NoReturn":            true,
+	"typing.Never":               true,
+	"typing_extensions.NoReturn": true,
+	"typing_extensions.Never":    true,
+}
+
+// TerminatingCalls is the set of calls the CFG builder ends a block at.
+// Qualified names are matched after resolving the import aliases of the
+// module, so `from sys import exit as die` makes `die()` terminating.
+// Functions are project functions that never return, matched by name.
+type TerminatingCalls struct {
+	qualified map[string]bool
+	functions map[string]bool
+}
+
+// NewTerminatingCalls returns the set of the given qualified call names and
+// project function names
+func NewTerminatingCalls(qualified, functions []string) *TerminatingCalls {
+	calls := &TerminatingCalls{
+		qualified: make(map[string]bool, len(qualified)),
+		functions: make(map[string]bool, len(functions)),
+	}
+	for _, name := range qualified {
+		if name = strings.TrimSpace(name); name != "" {
+			calls.qualified[name] = true
+		}
+	}
+	for _, name := range functions {
+		calls.functions[name] = true
+	}
+	return calls
+}
+
+// Key identifies the set: two sets with the same key terminate the same calls
+func (t *TerminatingCalls) Key() string {
+	if t == nil {
+		return ""
+	}
+	qualified := make([]string, 0, len(t.qualified))
+	for name := range t.qualified {
+		qualified = append(qualified, name)
+	}
+	functions := make([]string, 0, len(t.functions))
+	for name := range t.functions {
+		functions = append(functions, name)
+	}
+	sort.Strings(qualified)
+	sort.Strings(functions)
+	return strings.Join(qualified, ",") + ";" + strings.Join(functions, ",")
+}
+
+// terminates reports whether stmt is a call statement of a terminating
+// function. aliases maps the names bound by the module's imports to what
+// they import, and bound holds the names the module and the enclosing
+// functions bind otherwise, which no longer refer to a builtin or an import.
+func (t *TerminatingCalls) terminates(stmt *parser.Node, aliases map[string]string, bound map[string]bool) bool {
+	if t == nil || stmt == nil {
+		return false
+	}
+	call := stmt
+	if stmt.Type == parser.NodeExpr {
+		call, _ = stmt.Value.(*parser.Node)
+	}
+	if call == nil || call.Type != parser.NodeCall {
+		return false
+	}
+	callee, _ := call.Value.(*parser.Node)
+	name := dottedName(callee)
+	if name == "" {
+		return false
+	}
+	parts := strings.Split(name, ".")
+	if bound[parts[0]] {
+		// A module-level `def exit(code)` or an `exit` parameter is not the
+		// builtin; only project functions may still match below
+		return t.functions[parts[len(parts)-1]] && len(parts) == 1
+	}
+
+	resolved := parts
+	if imported, ok := aliases[parts[0]]; ok {
+		resolved = append(strings.Split(imported, "."), parts[1:]...)
+	}
+	if t.qualified[strings.Join(resolved, ".")] {
+		return true
+	}
+
+	// Project functions: bare and imported names, methods on self or cls
+	// and functions of imported modules
+	if !t.functions[parts[len(parts)-1]] {
+		return false
+	}
+	switch {
+	case len(parts) == 1:
+		return true
+	case len(parts) == 2 && (parts[0] == "self" || parts[0] == "cls"):
+		return true
+	default:
+		_, imported := aliases[parts[0]]
+		return imported
+	}
+}
+
+// addImportAliases maps the names an import statement binds to the
+// qualified names it imports
+func addImportAliases(stmt *parser.Node, aliases map[string]string) {
+	switch stmt.Type {
+	case parser.NodeImport:
+		for _, name := range stmt.Names {
+			// `import os.path` binds os
+			root, _, _ := strings.Cut(name, ".")
+			aliases[root] = root
+		}
+		for _, child := range stmt.Children {
+			if asName, ok := child.Value.(string); ok && child.Type == parser.NodeAlias && asName != "" {
+				root, _, _ := strings.Cut(child.Name, ".")
+				delete(aliases, root)
+				aliases[asName] = child.Name
+			}
+		}
+	case parser.NodeImportFrom:
+		// `from . import x` qualifies x by the dots alone
+		prefix := strings.Repeat(".", stmt.Level) + stmt.Module
+		if stmt.Module != "" {
+			prefix += "."
+		}
+		for _, name := range stmt.Names {
+			if name != "*" {
+				aliases[name] = prefix + name
+			}
+		}
+		for _, child := range stmt.Children {
+			if asName, ok := child.Value.(string); ok && child.Type == parser.NodeAlias && asName != "" {
+				delete(aliases, child.Name)
+				aliases[asName] = prefix + child.Name
+			}
+		}
+	}
+}
+
+// scopeBindings returns the names a module or function binds. imports maps
+// the names its import statements bind to the qualified names they import;
+// bound holds the names bound otherwise: parameters, definitions, and
+// assignment and loop targets. Nested functions and classes bind their own
+// name only.
+func scopeBindings(scope *parser.Node) (imports map[string]string, bound map[string]bool) {
+	imports = make(map[string]string)
+	bound = make(map[string]bool)
+	if scope == nil {
+		return imports, bound
+	}
+	for _, arg := range scope.Args {
+		if arg != nil && arg.Name != "" {
+			bound[arg.Name] = true
+		}
+	}
+	visit := func(n *parser.Node) bool {
+		switch n.Type {
+		case parser.NodeFunctionDef, parser.NodeAsyncFunctionDef, parser.NodeClassDef:
+			bound[n.Name] = true
+			return false
+		case parser.NodeLambda:
+			return false
+		case parser.NodeAssign, parser.NodeAnnAssign, parser.NodeAugAssign, parser.NodeFor, parser.NodeAsyncFor, parser.NodeNamedExpr:
+			for _, target := range n.Targets {
+				addBoundNames(target, bound)
+			}
+		case parser.NodeImport, parser.NodeImportFrom:
+			addImportAliases(n, imports)
+			return false
+		}
+		return true
+	}
+	for _, stmt := range scope.Body {
+		if stmt != nil {
+			stmt.Walk(visit)
+		}
+	}
+	return imports, bound
+}
+
+// addBoundNames adds the names an assignment target binds, unpacking
+// tuples, lists and starred targets
+func addBoundNames(target *parser.Node, bound map[string]bool) {
+	if target == nil {
+		return
+	}
+	switch target.Type {
+	case parser.NodeName:
+		bound[target.Name] = true
+	case parser.NodeAttribute, parser.NodeSubscript:
+	default:
+		for _, child := range target.Children {
+			addBoundNames(child, bound)
+		}
+	}
+}
+
+// NoReturnFunctions returns the names of the functions and methods of the
+// modules annotated to return NoReturn or Never. A name also defined by a
+// function that may return is left out, since its calls are ambiguous.
+func NoReturnFunctions(modules []*parser.Node) []string {
+	noReturn := make(map[string]bool)
+	returns := make(map[string]bool)
+	for _, module := range modules {
+		if module == nil {
+			continue
+		}
+		module.Walk(func(n *parser.Node) bool {
+			if n.Type != parser.NodeFunctionDef && n.Type != parser.NodeAsyncFunctionDef {
+				return true
+			}
+			annotation, _ := n.Value.(string)
+			if noReturnAnnotations[strings.TrimSpace(annotation)] {
+				noReturn[n.Name] = true
+			} else {
+				returns[n.Name] = true
+			}
+			return true
+		})
+	}
+
+	var names []string
+	for name := range noReturn {
+		if !returns[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,288 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadCodeAfterTerminatingCalls(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		function string
+		dead     []int
+	}{
+		{
+			name: "SysExit",
+			code: `
+import sys
+
+def main():
+    sys.exit(1)
+    print("done")
+`,
+			function: "main",
+			dead:     []int{6},
+		},
+		{
+			name: "ImportedAlias",
+			code: `
+from sys import exit as die
+
+def main():
+    die(1)
+    print("done")
+`,
+			function: "main",
+			dead:     []int{6},
+		},
+		{
+			name: "ModuleAlias",
+			code: `
+import os as o
+
+def main():
+    o._exit(1)
+    print("done")
+`,
+			function: "main",
+			dead:     []int{6},
+		},
+		{
+			name: "AssertNever",
+			code: `
+from typing import assert_never
+
+def handle(value):
+    if isinstance(value, int):
+        return value
+    assert_never(value)
+    print("done")
+`,
+			function: "handle",
+			dead:     []int{8},
+		},
+		{
+			name: "NoReturnFunction",
+			code: `
+from typing import NoReturn
+
+def fail(message) -> NoReturn:
+    raise RuntimeError(message)
+
+def main():
+    fail("boom")
+    print("done")
+`,
+			function: "main",
+			dead:     []int{9},
+		},
+		{
+			name: "CaughtSystemExit",
+			code: `
+import sys
+
+def main():
+    try:
+        sys.exit(1)
+    except SystemExit:
+        pass
+    print("done")
+`,
+			function: "main",
+		},
+		{
+			name: "ShadowedName",
+			code: `
+def exit_app():
+    pass
+
+def main():
+    exit_app()
+    print("done")
+`,
+			function: "main",
+		},
+		{
+			name: "ModuleDefinitionShadowsExit",
+			code: `
+def exit(code):
+    print(code)
+
+def f1(x):
+    exit(1)
+    return x
+`,
+			function: "f1",
+		},
+		{
+			name: "AssignmentShadowsQuit",
+			code: `
+quit = lambda: None
+
+def main():
+    quit()
+    print("done")
+`,
+			function: "main",
+		},
+		{
+			name: "ParameterShadowsExit",
+			code: `
+def main(exit):
+    exit(1)
+    print("done")
+`,
+			function: "main",
+		},
+		{
+			name: "EnclosingScopeShadowsExit",
+			code: `
+def outer():
+    def exit():
+        pass
+
+    def inner():
+        exit()
+        print("done")
+    return inner
+`,
+			function: "outer.inner",
+		},
+		{
+			name: "ModuleImportShadowsSys",
+			code: `
+import sys
+
+def main():
+    sys = object()
+    sys.exit(1)
+    print("done")
+`,
+			function: "main",
+		},
+		{
+			name: "LocalImport",
+			code: `
+def exit(code):
+    print(code)
+
+def main():
+    from sys import exit
+    exit(1)
+    print("done")
+`,
+			function: "main",
+			dead:     []int{8},
+		},
+		{
+			name: "LocalModuleImport",
+			code: `
+def main():
+    import sys
+    sys.exit(1)
+    print("done")
+`,
+			function: "main",
+			dead:     []int{5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ast := parseSource(t, tt.code)
+			builder := NewCFGBuilder()
+			builder.SetTerminatingCalls(NewTerminatingCalls(DefaultTerminatingCalls, NoReturnFunctions([]*parser.Node{ast})))
+			cfgs, err := builder.BuildAll(ast)
+			require.NoError(t, err)
+
+			result := DetectInFunction(cfgs[tt.function])
+			var dead []int
+			for _, finding := range result.Findings {
+				if finding.Reason == ReasonUnreachableAfterExit {
+					assert.Equal(t, SeverityLevelCritical, finding.Severity)
+					dead = append(dead, finding.StartLine)
+				}
+			}
+			assert.Equal(t, tt.dead, dead)
+		})
+	}
+}
+
+func TestTerminatingCallEndsReturnPaths(t *testing.T) {
+	code := `
+import sys
+
+def parse(value) -> int:
+    if value.isdigit():
+        return int(value)
+    sys.exit("not a number")
+`
+	ast := parseSource(t, code)
+	cfgs, err := NewCFGBuilder().BuildAll(ast)
+	require.NoError(t, err)
+
+	result := DetectInFunction(cfgs["parse"])
+	for _, finding := range result.Findings {
+		assert.NotEqual(t, ReasonMissingReturn, finding.Reason)
+	}
+}
+
+func TestTerminatingCallsConfigured(t *testing.T) {
+	code := `
+import myapp.log
+
+def main():
+    myapp.log.fatal("boom")
+    print("done")
+`
+	ast := parseSource(t, code)
+
+	cfgs, err := NewCFGBuilder().BuildAll(ast)
+	require.NoError(t, err)
+	assert.Empty(t, DetectInFunction(cfgs["main"]).Findings)
+
+	builder := NewCFGBuilder()
+	builder.SetTerminatingCalls(NewTerminatingCalls([]string{"myapp.log.fatal"}, nil))
+	cfgs, err = builder.BuildAll(ast)
+	require.NoError(t, err)
+	findings := DetectInFunction(cfgs["main"]).Findings
+	require.Len(t, findings, 1)
+	assert.Equal(t, ReasonUnreachableAfterExit, findings[0].Reason)
+}
+
+func TestNoReturnFunctions(t *testing.T) {
+	first := parseSource(t, `
+from typing import NoReturn, Never
+import typing
+
+def fail(message) -> NoReturn:
+    raise RuntimeError(message)
+
+def abort() -> typing.Never:
+    raise SystemExit(1)
+
+class Runner:
+    def stop(self) -> Never:
+        raise SystemExit(0)
+`)
+	second := parseSource(t, `
+def abort():
+    return None
+`)
+
+	assert.Equal(t, []string{"fail", "stop"}, NoReturnFunctions([]*parser.Node{first, second}))
+	assert.Equal(t, []string{"abort", "fail", "stop"}, NoReturnFunctions([]*parser.Node{first}))
+}
+
+func TestTerminatingCallsKey(t *testing.T) {
+	a := NewTerminatingCalls([]string{"sys.exit", "os._exit"}, []string{"fail"})
+	b := NewTerminatingCalls([]string{"os._exit", " sys.exit "}, []string{"fail"})
+	c := NewTerminatingCalls([]string{"sys.exit"}, []string{"fail"})
+
+	assert.Equal(t, a.Key(), b.Key())
+	assert.NotEqual(t, a.Key(), c.Key())
+}
@@ -0,0 +1,178 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// TypeCoverageAnalyzer measures how many function parameters and return
+// types carry a type annotation
+type TypeCoverageAnalyzer struct{}
+
+// NewTypeCoverageAnalyzer creates a new type annotation coverage analyzer
+func NewTypeCoverageAnalyzer() *TypeCoverageAnalyzer {
+	return &TypeCoverageAnalyzer{}
+}
+
+// typeCoverageScope describes the body functions are being collected from
+type typeCoverageScope struct {
+	inClass    bool
+	inFunction bool
+	prefix     string
+	public     bool
+}
+
+// Analyze collects per-function annotation counts and unannotated public
+// API findings for one file
+func (a *TypeCoverageAnalyzer) Analyze(ast *parser.Node, filePath string) (domain.TypeCoverageFile, []domain.TypeCoverageFinding) {
+	file := domain.TypeCoverageFile{FilePath: filePath}
+	var findings []domain.TypeCoverageFinding
+	if ast != nil {
+		a.visitBody(&file, &findings, ast.Body, typeCoverageScope{public: true}, filePath)
+	}
+	file.Coverage = file.Counts.Percentage()
+	return file, findings
+}
+
+// visitBody records every function defined in body, including methods and
+// nested functions, looking through compound statements
+func (a *TypeCoverageAnalyzer) visitBody(file *domain.TypeCoverageFile, findings *[]domain.TypeCoverageFinding, body []*parser.Node, scope typeCoverageScope, filePath string) {
+	for _, stmt := range body {
+		if stmt == nil {
+			continue
+		}
+
+		switch stmt.Type {
+		case parser.NodeClassDef:
+			name := scope.prefix + stmt.Name
+			public := scope.public && !scope.inFunction && isPublicDocstringName(stmt.Name)
+			a.visitBody(file, findings, stmt.Body, typeCoverageScope{inClass: true, prefix: name + ".", public: public}, filePath)
+
+		case parser.NodeFunctionDef, parser.NodeAsyncFunctionDef:
+			name := scope.prefix + stmt.Name
+			// Overload stubs are annotated by definition; only the
+			// implementation is counted
+			if !isOverload(stmt) {
+				fn := a.measureFunction(stmt, name, scope, filePath)
+				file.Functions = append(file.Functions, fn)
+				file.Counts.Add(fn)
+				if fn.Public && fn.AnnotatedParameters == 0 && !fn.ReturnAnnotated && (fn.Parameters > 0 || fn.ReturnCounted) {
+					*findings = append(*findings, domain.TypeCoverageFinding{
+						Name:        name,
+						Location:    fn.Location,
+						Description: fmt.Sprintf("Public function '%s' has no type annotations", name),
+					})
+				}
+			}
+			a.visitBody(file, findings, stmt.Body, typeCoverageScope{inFunction: true, prefix: name + "."}, filePath)
+
+		case parser.NodeIf, parser.NodeTry, parser.NodeWith, parser.NodeAsyncWith,
+			parser.NodeFor, parser.NodeAsyncFor, parser.NodeWhile:
+			a.visitBody(file, findings, stmt.Body, scope, filePath)
+			a.visitBody(file, findings, stmt.Orelse, scope, filePath)
+			for _, handler := range stmt.Handlers {
+				a.visitBody(file, findings, handler.Body, scope, filePath)
+			}
+			a.visitBody(file, findings, stmt.Finalbody, scope, filePath)
+		}
+	}
+}
+
+// measureFunction counts the annotated parameters and return type of a function
+func (a *TypeCoverageAnalyzer) measureFunction(node *parser.Node, name string, scope typeCoverageScope, filePath string) domain.TypeCoverageFunction {
+	fn := domain.TypeCoverageFunction{
+		Name:     name,
+		Location: sourceLocation(filePath, node.Location),
+		// Dunder methods such as __init__ are public API even though they
+		// start with an underscore
+		Public:          scope.public && !scope.inFunction && (isPublicDocstringName(node.Name) || isDunderName(node.Name)),
+		ReturnCounted:   !(scope.inClass && node.Name == "__init__"),
+		ReturnAnnotated: node.Right != nil,
+	}
+
+	args := node.Args
+	// self and cls are implied by the class and never annotated
+	if scope.inClass && len(args) > 0 && !isStaticMethod(node) {
+		args = args[1:]
+	}
+	for _, arg := range args {
+		if arg == nil {
+			continue
+		}
+		fn.Parameters++
+		if arg.Right != nil {
+			fn.AnnotatedParameters++
+		}
+	}
+
+	annotated := fn.AnnotatedParameters
+	total := fn.Parameters
+	if fn.ReturnCounted {
+		total++
+		if fn.ReturnAnnotated {
+			annotated++
+		}
+	}
+	fn.Coverage = 100
+	if total > 0 {
+		fn.Coverage = float64(annotated) / float64(total) * 100
+	}
+	return fn
+}
+
+// isStaticMethod reports whether a method is decorated with @staticmethod
+func isStaticMethod(node *parser.Node) bool {
+	for _, decorator := range node.Decorator {
+		if decoratorQualifiedName(decorator) == "staticmethod" {
+			return true
+		}
+	}
+	return false
+}
+
+// SortTypeCoverageFiles orders files by path
+func SortTypeCoverageFiles(files []domain.TypeCoverageFile) []domain.TypeCoverageFile {
+	sorted := make([]domain.TypeCoverageFile, len(files))
+	copy(sorted, files)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].FilePath < sorted[j].FilePath
+	})
+	return sorted
+}
+
+// SortTypeCoverageFindings orders findings by file and position
+func SortTypeCoverageFindings(findings []domain.TypeCoverageFinding) []domain.TypeCoverageFinding {
+	sorted := make([]domain.TypeCoverageFinding, len(findings))
+	copy(sorted, findings)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Location.FilePath != sorted[j].Location.FilePath {
+			return sorted[i].Location.FilePath < sorted[j].Location.FilePath
+		}
+		return sorted[i].Location.StartLine < sorted[j].Location.StartLine
+	})
+	return sorted
+}
+
+// GenerateTypeCoverageSummary aggregates per-file counts into a project summary
+func GenerateTypeCoverageSummary(files []domain.TypeCoverageFile, unannotatedPublic int) domain.TypeCoverageSummary {
+	summary := domain.TypeCoverageSummary{
+		UnannotatedPublicFunctions: unannotatedPublic,
+		FilesAnalyzed:              len(files),
+		ParameterCoverage:          100,
+		ReturnCoverage:             100,
+	}
+	for _, file := range files {
+		summary.Merge(file.Counts)
+	}
+	summary.Coverage = summary.Percentage()
+	if summary.Parameters > 0 {
+		summary.ParameterCoverage = float64(summary.AnnotatedParameters) / float64(summary.Parameters) * 100
+	}
+	if summary.Returns > 0 {
+		summary.ReturnCoverage = float64(summary.AnnotatedReturns) / float64(summary.Returns) * 100
+	}
+	return summary
+}
@@ -0,0 +1,156 @@
+package analyzer
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+const typeCoverageSample = `import typing
+
+def typed(a: int, b: str = "x") -> bool:
+    return True
+
+def partial(a: int, b, *args, **kwargs):
+    def inner(x):
+        return x
+    return inner
+
+def untyped(a, b):
+    pass
+
+def _private(a):
+    pass
+
+class Service:
+    def __init__(self, name: str):
+        self.name = name
+
+    def run(self, n):
+        pass
+
+    @staticmethod
+    def build(config) -> "Service":
+        pass
+
+    @classmethod
+    def create(cls):
+        pass
+
+@typing.overload
+def parse(value: int) -> int: ...
+
+def parse(value):
+    return value
+`
+
+func analyzeTypeCoverage(t *testing.T, code string) (domain.TypeCoverageFile, []domain.TypeCoverageFinding) {
+	t.Helper()
+	result, err := parser.New().Parse(context.Background(), []byte(code))
+	if err != nil {
+		t.Fatalf("failed to parse code: %v", err)
+	}
+	return NewTypeCoverageAnalyzer().Analyze(result.AST, "pkg/mod.py")
+}
+
+func TestTypeCoverageAnalyzer_Functions(t *testing.T) {
+	file, _ := analyzeTypeCoverage(t, typeCoverageSample)
+
+	expected := map[string]struct {
+		params, annotated int
+		returnCounted     bool
+		returnAnnotated   bool
+		public            bool
+	}{
+		"typed":            {2, 2, true, true, true},
+		"partial":          {4, 1, true, false, true},
+		"partial.inner":    {1, 0, true, false, false},
+		"untyped":          {2, 0, true, false, true},
+		"_private":         {1, 0, true, false, false},
+		"Service.__init__": {1, 1, false, false, true},
+		"Service.run":      {1, 0, true, false, true},
+		"Service.build":    {1, 0, true, true, true},
+		"Service.create":   {0, 0, true, false, true},
+		"parse":            {1, 0, true, false, true},
+	}
+
+	if len(file.Functions) != len(expected) {
+		t.Fatalf("expected %d functions, got %d: %+v", len(expected), len(file.Functions), file.Functions)
+	}
+	for _, fn := range file.Functions {
+		want, ok := expected[fn.Name]
+		if !ok {
+			t.Errorf("unexpected function %s", fn.Name)
+			continue
+		}
+		if fn.Parameters != want.params || fn.AnnotatedParameters != want.annotated {
+			t.Errorf("%s: expected %d/%d parameters annotated, got %d/%d", fn.Name, want.annotated, want.params, fn.AnnotatedParameters, fn.Parameters)
+		}
+		if fn.ReturnCounted != want.returnCounted || fn.ReturnAnnotated != want.returnAnnotated {
+			t.Errorf("%s: expected return counted=%v annotated=%v, got %v/%v", fn.Name, want.returnCounted, want.returnAnnotated, fn.ReturnCounted, fn.ReturnAnnotated)
+		}
+		if fn.Public != want.public {
+			t.Errorf("%s: expected public=%v, got %v", fn.Name, want.public, fn.Public)
+		}
+	}
+
+	// typed and Service.__init__ are fully annotated
+	if file.Counts.FullyAnnotatedFunctions != 2 {
+		t.Errorf("expected 2 fully annotated functions, got %d", file.Counts.FullyAnnotatedFunctions)
+	}
+	// 4 of 14 parameters and 2 of 9 returns are annotated
+	if file.Counts.Parameters != 14 || file.Counts.AnnotatedParameters != 4 {
+		t.Errorf("expected 4/14 parameters annotated, got %d/%d", file.Counts.AnnotatedParameters, file.Counts.Parameters)
+	}
+	if file.Counts.Returns != 9 || file.Counts.AnnotatedReturns != 2 {
+		t.Errorf("expected 2/9 returns annotated, got %d/%d", file.Counts.AnnotatedReturns, file.Counts.Returns)
+	}
+}
+
+func TestTypeCoverageAnalyzer_Findings(t *testing.T) {
+	_, findings := analyzeTypeCoverage(t, typeCoverageSample)
+
+	got := make([]string, len(findings))
+	for i, finding := range findings {
+		got[i] = finding.Name
+	}
+	want := []string{"untyped", "Service.run", "Service.create", "parse"}
+	if len(got) != len(want) {
+		t.Fatalf("expected findings %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected findings %v, got %v", want, got)
+			break
+		}
+	}
+}
+
+func TestGenerateTypeCoverageSummary(t *testing.T) {
+	files := []domain.TypeCoverageFile{
+		{FilePath: "a.py", Counts: domain.TypeCoverageCounts{Functions: 2, FullyAnnotatedFunctions: 1, Parameters: 3, AnnotatedParameters: 3, Returns: 2, AnnotatedReturns: 1}},
+		{FilePath: "b.py", Counts: domain.TypeCoverageCounts{Functions: 1, Parameters: 1, Returns: 1}},
+	}
+
+	summary := GenerateTypeCoverageSummary(files, 1)
+	if summary.Functions != 3 || summary.FullyAnnotatedFunctions != 1 {
+		t.Errorf("unexpected function counts: %+v", summary)
+	}
+	if math.Abs(summary.Coverage-4.0/7.0*100) > 1e-9 {
+		t.Errorf("expected coverage %.2f, got %.2f", 4.0/7.0*100, summary.Coverage)
+	}
+	if summary.ParameterCoverage != 75 || math.Abs(summary.ReturnCoverage-100.0/3.0) > 1e-9 {
+		t.Errorf("unexpected breakdown: params %.2f returns %.2f", summary.ParameterCoverage, summary.ReturnCoverage)
+	}
+	if summary.FilesAnalyzed != 2 || summary.UnannotatedPublicFunctions != 1 {
+		t.Errorf("unexpected file/finding counts: %+v", summary)
+	}
+
+	empty := GenerateTypeCoverageSummary(nil, 0)
+	if empty.Coverage != 100 {
+		t.Errorf("expected 100%% coverage with nothing to annotate, got %.2f", empty.Coverage)
+	}
+}
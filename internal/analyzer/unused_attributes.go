@@ -0,0 +1,346 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// attributeReflectionMethods are special methods through which a class may
+// read its attributes by name
+var attributeReflectionMethods = map[string]bool{
+	"__getattr__":      true,
+	"__getattribute__": true,
+	"__setattr__":      true,
+	"__getstate__":     true,
+	"__reduce__":       true,
+	"__reduce_ex__":    true,
+}
+
+// attributeReflectionCalls are calls reading the attributes of an object by
+// name or all at once
+var attributeReflectionCalls = map[string]bool{
+	"vars":    true,
+	"getattr": true,
+	"asdict":  true,
+	"astuple": true,
+}
+
+// attributeNeutralBases are bases known not to read the attributes of
+// their subclasses
+var attributeNeutralBases = map[string]bool{
+	"object":        true,
+	"ABC":           true,
+	"Generic":       true,
+	"Protocol":      true,
+	"Exception":     true,
+	"BaseException": true,
+}
+
+// AttributeUsageIndex records, across a project, the instance attributes
+// methods assign through self and every attribute name the code reads.
+// Reads are matched by name only, whatever object they are read from, so
+// an attribute is reported only when no code reads any attribute of that
+// name. String literals spelling an identifier count as reads, for
+// getattr() and field lists.
+type AttributeUsageIndex struct {
+	reads   map[string]bool
+	classes []*attributeClass
+
+	// classNames are the names of the classes defined in the project
+	classNames map[string]bool
+}
+
+// attributeClass is a class and the attributes its methods assign
+type attributeClass struct {
+	filePath string
+	name     string
+	bases    []string
+	methods  map[string]bool
+
+	// reflective is true when the class may read its attributes without
+	// naming them: it is decorated, as dataclasses are, or uses vars(),
+	// __dict__, getattr() or a special method such as __getstate__
+	reflective bool
+
+	writes []*attributeWrite
+}
+
+// attributeWrite is the first assignment of an attribute in a class
+type attributeWrite struct {
+	attribute string
+	method    string
+	startLine int
+	endLine   int
+	count     int
+}
+
+// NewAttributeUsageIndex creates an empty index
+func NewAttributeUsageIndex() *AttributeUsageIndex {
+	return &AttributeUsageIndex{
+		reads:      make(map[string]bool),
+		classNames: make(map[string]bool),
+	}
+}
+
+// AddFile indexes the attribute reads and the classes of a parsed file
+func (x *AttributeUsageIndex) AddFile(filePath string, ast *parser.Node) {
+	if ast == nil {
+		return
+	}
+	stores := attributeStores(ast)
+	ast.Walk(func(n *parser.Node) bool {
+		switch n.Type {
+		case parser.NodeAttribute:
+			if !stores[n] {
+				x.reads[n.Name] = true
+			}
+		case parser.NodeConstant:
+			if s, ok := n.Value.(string); ok && isIdentifier(s) {
+				x.reads[s] = true
+			}
+		}
+		return true
+	})
+	x.addClasses(filePath, "", ast.Body)
+}
+
+// attributeStores returns the attribute nodes that are assigned, augmented
+// or deleted rather than read. An augmented assignment reads the attribute
+// only to store it again.
+func attributeStores(ast *parser.Node) map[*parser.Node]bool {
+	stores := make(map[*parser.Node]bool)
+	ast.Walk(func(n *parser.Node) bool {
+		switch n.Type {
+		case parser.NodeAssign, parser.NodeAnnAssign, parser.NodeAugAssign, parser.NodeFor, parser.NodeAsyncFor, parser.NodeDelete:
+			for _, target := range n.Targets {
+				for _, attribute := range targetAttributes(target) {
+					stores[attribute] = true
+				}
+			}
+		}
+		return true
+	})
+	return stores
+}
+
+// targetAttributes returns the attributes an assignment target stores,
+// unpacking tuples, lists, starred and pattern targets
+func targetAttributes(target *parser.Node) []*parser.Node {
+	if target == nil {
+		return nil
+	}
+	switch target.Type {
+	case parser.NodeAttribute:
+		return []*parser.Node{target}
+	case parser.NodeName, parser.NodeSubscript:
+		return nil
+	}
+	var attributes []*parser.Node
+	for _, child := range target.Children {
+		attributes = append(attributes, targetAttributes(child)...)
+	}
+	return attributes
+}
+
+// addClasses indexes the classes defined in stmts, nested ones included,
+// with the qualified names the control flow graphs use
+func (x *AttributeUsageIndex) addClasses(filePath, scope string, stmts []*parser.Node) {
+	for _, stmt := range stmts {
+		stmt.Walk(func(n *parser.Node) bool {
+			switch n.Type {
+			case parser.NodeFunctionDef, parser.NodeAsyncFunctionDef:
+				x.addClasses(filePath, qualifiedScopeName(scope, n.Name), n.Body)
+				return false
+			case parser.NodeClassDef:
+				x.addClass(filePath, qualifiedScopeName(scope, n.Name), n)
+				return false
+			}
+			return true
+		})
+	}
+}
+
+// addClass indexes the attributes the methods of a class assign through
+// their first parameter
+func (x *AttributeUsageIndex) addClass(filePath, name string, classNode *parser.Node) {
+	class := &attributeClass{
+		filePath:   filePath,
+		name:       name,
+		methods:    make(map[string]bool),
+		reflective: len(classNode.Decorator) > 0,
+	}
+	x.classNames[classNode.Name] = true
+	for _, base := range classNode.Bases {
+		if base.Type == parser.NodeName || base.Type == parser.NodeAttribute {
+			full := dottedName(base)
+			class.bases = append(class.bases, full[strings.LastIndex(full, ".")+1:])
+		}
+	}
+
+	writes := make(map[string]*attributeWrite)
+	for _, stmt := range classNode.Body {
+		switch stmt.Type {
+		case parser.NodeFunctionDef, parser.NodeAsyncFunctionDef:
+		case parser.NodeClassDef:
+			x.addClass(filePath, name+"."+stmt.Name, stmt)
+			continue
+		default:
+			continue
+		}
+		class.methods[stmt.Name] = true
+		if attributeReflectionMethods[stmt.Name] {
+			class.reflective = true
+		}
+		self := instanceParameter(stmt)
+		if self == "" {
+			continue
+		}
+		method := name + "." + stmt.Name
+		stores := make(map[*parser.Node]*parser.Node)
+		for _, child := range stmt.Body {
+			child.Walk(func(n *parser.Node) bool {
+				switch n.Type {
+				case parser.NodeClassDef:
+					return false
+				case parser.NodeAssign, parser.NodeAnnAssign, parser.NodeAugAssign:
+					for _, target := range n.Targets {
+						for _, attribute := range targetAttributes(target) {
+							stores[attribute] = n
+						}
+					}
+				case parser.NodeAttribute:
+					if object := nodeValue(n); object != nil && object.Type == parser.NodeName && object.Name == self {
+						if n.Name == "__dict__" {
+							class.reflective = true
+						}
+						if stmt, ok := stores[n]; ok {
+							addAttributeWrite(class, writes, n.Name, method, stmt)
+						}
+					}
+				case parser.NodeCall:
+					if isAttributeReflectionCall(n) {
+						class.reflective = true
+					}
+				}
+				return true
+			})
+		}
+	}
+	x.classes = append(x.classes, class)
+}
+
+// addAttributeWrite records an assignment of an attribute, keeping the
+// first one
+func addAttributeWrite(class *attributeClass, writes map[string]*attributeWrite, attribute, method string, stmt *parser.Node) {
+	if write, ok := writes[attribute]; ok {
+		write.count++
+		return
+	}
+	write := &attributeWrite{
+		attribute: attribute,
+		method:    method,
+		startLine: stmt.Location.StartLine,
+		endLine:   stmt.Location.EndLine,
+		count:     1,
+	}
+	writes[attribute] = write
+	class.writes = append(class.writes, write)
+}
+
+// instanceParameter returns the name of the first parameter of a method
+// bound to instances, or "" for static and class methods
+func instanceParameter(method *parser.Node) string {
+	for _, decorator := range method.Decorator {
+		switch decoratorQualifiedName(decorator) {
+		case "staticmethod", "classmethod":
+			return ""
+		}
+	}
+	if len(method.Args) == 0 || method.Args[0].Type != parser.NodeArg {
+		return ""
+	}
+	return method.Args[0].Name
+}
+
+// isAttributeReflectionCall reports whether a call reads attributes of an
+// object by a computed name or all at once
+func isAttributeReflectionCall(call *parser.Node) bool {
+	name := dottedName(nodeValue(call))
+	name = name[strings.LastIndex(name, ".")+1:]
+	if !attributeReflectionCalls[name] {
+		return false
+	}
+	if name != "getattr" {
+		return true
+	}
+	// getattr(obj, "name") is an ordinary read
+	return len(call.Args) < 2 || call.Args[1].Type != parser.NodeConstant
+}
+
+// isIdentifier reports whether s is a Python identifier
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if r != '_' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// UnusedAttributes returns a finding for each attribute assigned through
+// self that no code of the project reads. Private attributes of classes
+// without reflection and whose bases are all defined in the project are
+// reported as warnings; the others as info, since code outside the
+// project, a base class or reflection may read them.
+func (x *AttributeUsageIndex) UnusedAttributes() []*DeadCodeFinding {
+	var findings []*DeadCodeFinding
+	for _, class := range x.classes {
+		externalBase := ""
+		for _, base := range class.bases {
+			if !attributeNeutralBases[base] && !x.classNames[base] && externalBase == "" {
+				externalBase = base
+			}
+		}
+		for _, write := range class.writes {
+			// Assigning a property runs its setter
+			if x.reads[write.attribute] || isDunderName(write.attribute) || class.methods[write.attribute] {
+				continue
+			}
+
+			severity := SeverityLevelWarning
+			description := fmt.Sprintf("Attribute '%s' of %s is assigned but never read in the project", write.attribute, class.name)
+			if write.count > 1 {
+				description = fmt.Sprintf("Attribute '%s' of %s is assigned %d times but never read in the project", write.attribute, class.name, write.count)
+			}
+			switch {
+			case class.reflective:
+				severity = SeverityLevelInfo
+				description += "; the class may read it by reflection"
+			case externalBase != "":
+				severity = SeverityLevelInfo
+				description += fmt.Sprintf("; base class %s may read it", externalBase)
+			case !strings.HasPrefix(write.attribute, "_"):
+				severity = SeverityLevelInfo
+				description += "; code outside the project may read it"
+			}
+
+			findings = append(findings, &DeadCodeFinding{
+				FunctionName: write.method,
+				FilePath:     class.filePath,
+				StartLine:    write.startLine,
+				EndLine:      write.endLine,
+				Code:         "self." + write.attribute + " = ...",
+				Reason:       ReasonUnusedAttribute,
+				Severity:     severity,
+				Description:  description,
+			})
+		}
+	}
+	return findings
+}
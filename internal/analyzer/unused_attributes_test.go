@@ -0,0 +1,137 @@
+package analyzer
+
+import (
+	"testing"
+)
+
+func unusedAttributes(t *testing.T, files map[string]string) map[string]*DeadCodeFinding {
+	t.Helper()
+	index := NewAttributeUsageIndex()
+	for path, source := range files {
+		index.AddFile(path, parseSource(t, source))
+	}
+	findings := make(map[string]*DeadCodeFinding)
+	for _, finding := range index.UnusedAttributes() {
+		findings[finding.Code] = finding
+	}
+	return findings
+}
+
+func TestUnusedAttributes(t *testing.T) {
+	findings := unusedAttributes(t, map[string]string{
+		"shop/cart.py": `
+import threading
+
+class Cart:
+    def __init__(self):
+        self._items = []
+        self._cache = {}
+        self.label = "cart"
+        self._count = 0
+        self._a, (self._b, *self._rest) = 1, (2, 3)
+
+    def add(self, item):
+        self._items.append(item)
+        self._cache = {}
+        self._count += 1
+
+    @property
+    def size(self):
+        return 0
+
+    @size.setter
+    def size(self, value):
+        self.size = value
+
+    @staticmethod
+    def make(other):
+        other._static = 1
+
+    def __repr__(self):
+        self.__seen = self.__module__ = True
+        return "Cart"
+
+class Worker(threading.Thread):
+    def __init__(self):
+        self._stopped = False
+
+class Dump:
+    def __init__(self):
+        self._x = 1
+
+    def dump(self):
+        return vars(self)
+
+class Outer:
+    class Inner:
+        def __init__(self):
+            self._inner = 1
+`,
+		"shop/report.py": `
+from .cart import Cart
+
+def report(cart: Cart):
+    print(cart._a, cart._b)
+    return getattr(cart, "_rest")
+`,
+	})
+
+	tests := []struct {
+		code     string
+		function string
+		line     int
+		severity SeverityLevel
+	}{
+		{"self._cache = ...", "Cart.__init__", 7, SeverityLevelWarning},
+		{"self.label = ...", "Cart.__init__", 8, SeverityLevelInfo},
+		{"self._count = ...", "Cart.__init__", 9, SeverityLevelWarning},
+		{"self.__seen = ...", "Cart.__repr__", 30, SeverityLevelWarning},
+		{"self._stopped = ...", "Worker.__init__", 35, SeverityLevelInfo},
+		{"self._x = ...", "Dump.__init__", 39, SeverityLevelInfo},
+		{"self._inner = ...", "Outer.Inner.__init__", 47, SeverityLevelWarning},
+	}
+	for _, tt := range tests {
+		finding, ok := findings[tt.code]
+		if !ok {
+			t.Errorf("%s is not reported", tt.code)
+			continue
+		}
+		delete(findings, tt.code)
+		if finding.Reason != ReasonUnusedAttribute {
+			t.Errorf("%s: reason = %s, want %s", tt.code, finding.Reason, ReasonUnusedAttribute)
+		}
+		if finding.FunctionName != tt.function || finding.StartLine != tt.line || finding.FilePath != "shop/cart.py" {
+			t.Errorf("%s: reported in %s:%s line %d, want %s line %d", tt.code, finding.FilePath, finding.FunctionName, finding.StartLine, tt.function, tt.line)
+		}
+		if finding.Severity != tt.severity {
+			t.Errorf("%s: severity = %s, want %s (%s)", tt.code, finding.Severity, tt.severity, finding.Description)
+		}
+	}
+	// _items is read, _a, _b and _rest are read from another file, size is
+	// a property, _static is not assigned through self and __module__ is
+	// a dunder name
+	for code := range findings {
+		t.Errorf("unexpected finding %s", code)
+	}
+}
+
+func TestUnusedAttributesDescription(t *testing.T) {
+	findings := unusedAttributes(t, map[string]string{
+		"cart.py": `
+class Cart:
+    def __init__(self):
+        self._cache = {}
+
+    def clear(self):
+        self._cache = {}
+`,
+	})
+	finding := findings["self._cache = ..."]
+	if finding == nil {
+		t.Fatal("self._cache is not reported")
+	}
+	want := "Attribute '_cache' of Cart is assigned 2 times but never read in the project"
+	if finding.Description != want {
+		t.Errorf("description = %q, want %q", finding.Description, want)
+	}
+}
@@ -22,6 +22,7 @@ show_zeros = true
 include_builtins = true
 include_imports = false
 group_namespace_imports = false
+include_type_hints = false
 `
 	configPath := filepath.Join(tempDir, ".pyscn.toml")
 	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
@@ -71,6 +72,9 @@ group_namespace_imports = false
 	if domain.BoolValue(config.CboGroupNamespaceImports, true) {
 		t.Errorf("Expected group_namespace_imports false, got %v", config.CboGroupNamespaceImports)
 	}
+	if domain.BoolValue(config.CboIncludeTypeHints, true) {
+		t.Errorf("Expected include_type_hints false, got %v", config.CboIncludeTypeHints)
+	}
 }
 
 func TestLoadCBOFromPyscnTomlPartial(t *testing.T) {
@@ -137,6 +141,8 @@ func TestMergeCboSection(t *testing.T) {
 		IncludeBuiltins:       boolPtr(true),
 		IncludeImports:        boolPtr(false),
 		GroupNamespaceImports: boolPtr(false),
+		MaxInheritance:        intPtr(2),
+		MaxTypeHints:          intPtr(8),
 	}
 
 	// Merge cbo settings
@@ -167,6 +173,12 @@ func TestMergeCboSection(t *testing.T) {
 	if domain.BoolValue(config.CboGroupNamespaceImports, true) {
 		t.Errorf("Expected group_namespace_imports false, got %v", config.CboGroupNamespaceImports)
 	}
+	if config.CboMaxInheritance != 2 || config.CboMaxTypeHints != 8 {
+		t.Errorf("Expected max_inheritance 2 and max_type_hints 8, got %d and %d", config.CboMaxInheritance, config.CboMaxTypeHints)
+	}
+	if config.CboMaxComposition != 0 {
+		t.Errorf("Expected max_composition to stay unlimited, got %d", config.CboMaxComposition)
+	}
 }
 
 func TestMergeCboSectionNilValues(t *testing.T) {
@@ -60,6 +60,7 @@ func (c *PyscnConfig) ToCloneRequest(outputWriter io.Writer) *domain.CloneReques
 		// Analysis configuration
 		MinLines:            c.Analysis.MinLines,
 		MinNodes:            c.Analysis.MinNodes,
+		MinTokens:           c.Analysis.MinTokens,
 		SimilarityThreshold: c.Thresholds.SimilarityThreshold,
 		MaxEditDistance:     c.Analysis.MaxEditDistance,
 		IgnoreLiterals:      domain.BoolPtr(domain.BoolValue(c.Analysis.IgnoreLiterals, false)),
@@ -75,6 +76,11 @@ func (c *PyscnConfig) ToCloneRequest(outputWriter io.Writer) *domain.CloneReques
 		// Advanced analysis
 		EnableDFA: domain.BoolValue(c.Analysis.EnableDFA, true), // Default: enabled
 
+		// String clones
+		StringClones:              domain.BoolPtr(domain.BoolValue(c.Analysis.StringClones, false)),
+		MinStringLength:           c.Analysis.MinStringLength,
+		StringSimilarityThreshold: c.Analysis.StringSimilarityThreshold,
+
 		// Output configuration
 		OutputFormat: outputFormat,
 		OutputWriter: outputWriter,
@@ -83,6 +89,11 @@ func (c *PyscnConfig) ToCloneRequest(outputWriter io.Writer) *domain.CloneReques
 		SortBy:       sortBy,
 		GroupClones:  domain.BoolPtr(domain.BoolValue(c.Output.GroupClones, true)),
 
+		// Report shaping
+		MaxGroupMembers:  c.Output.MaxGroupMembers,
+		CollapseSameFile: domain.BoolPtr(domain.BoolValue(c.Output.CollapseSameFile, false)),
+		ReportGroupBy:    c.Output.ReportGroupBy,
+
 		// Filtering
 		MinSimilarity: c.Filtering.MinSimilarity,
 		MaxSimilarity: c.Filtering.MaxSimilarity,
@@ -103,11 +114,15 @@ func FromCloneRequest(request *domain.CloneRequest) *PyscnConfig {
 	// Analysis configuration
 	config.Analysis.MinLines = request.MinLines
 	config.Analysis.MinNodes = request.MinNodes
+	config.Analysis.MinTokens = request.MinTokens
 	config.Analysis.MaxEditDistance = request.MaxEditDistance
 	config.Analysis.IgnoreLiterals = request.IgnoreLiterals
 	config.Analysis.IgnoreIdentifiers = request.IgnoreIdentifiers
 	config.Analysis.SkipDocstrings = request.SkipDocstrings
 	config.Analysis.EnableDFA = domain.BoolPtr(request.EnableDFA)
+	config.Analysis.StringClones = request.StringClones
+	config.Analysis.MinStringLength = request.MinStringLength
+	config.Analysis.StringSimilarityThreshold = request.StringSimilarityThreshold
 
 	// Thresholds
 	config.Thresholds.Type1Threshold = request.Type1Threshold
@@ -131,6 +146,9 @@ func FromCloneRequest(request *domain.CloneRequest) *PyscnConfig {
 	config.Output.ShowDetails = request.ShowDetails
 	config.Output.ShowContent = request.ShowContent
 	config.Output.GroupClones = request.GroupClones
+	config.Output.MaxGroupMembers = request.MaxGroupMembers
+	config.Output.CollapseSameFile = request.CollapseSameFile
+	config.Output.ReportGroupBy = request.ReportGroupBy
 	config.Output.Writer = request.OutputWriter
 
 	switch request.SortBy {
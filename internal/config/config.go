@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 
 	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/i18n"
 	"github.com/pelletier/go-toml/v2"
 )
 
@@ -97,6 +98,18 @@ type ComplexityConfig struct {
 	// MaxComplexity is the maximum allowed complexity before failing analysis
 	// 0 means no limit
 	MaxComplexity int `mapstructure:"max_complexity" yaml:"max_complexity"`
+
+	// Boilerplate are patterns tagging the functions they match as
+	// boilerplate, optionally excluding them from risk rating
+	Boilerplate []domain.BoilerplatePattern `mapstructure:"boilerplate" yaml:"boilerplate"`
+
+	// ComprehensionWeight, GeneratorWeight and BooleanOperatorWeight set
+	// what each comprehension clause, generator expression clause and
+	// boolean operator sequence adds to cyclomatic complexity: 0, 0.5 or 1.
+	// nil counts them as the control flow graph does.
+	ComprehensionWeight   *float64 `mapstructure:"comprehension_weight" yaml:"comprehension_weight"`
+	GeneratorWeight       *float64 `mapstructure:"generator_weight" yaml:"generator_weight"`
+	BooleanOperatorWeight *float64 `mapstructure:"boolean_operator_weight" yaml:"boolean_operator_weight"`
 }
 
 // OutputConfig holds configuration for output formatting
@@ -115,6 +128,34 @@ type OutputConfig struct {
 
 	// Directory specifies the output directory for reports (empty = tool default, e.g., ".pyscn/reports" under current working directory)
 	Directory string `mapstructure:"directory" yaml:"directory"`
+
+	// LinkTemplate turns file:line references in HTML reports into links:
+	// a preset name (vscode, cursor, pycharm, idea) or a URL with {path},
+	// {relpath}, {line}, {endline} and {commit} placeholders (empty = plain text)
+	LinkTemplate string `mapstructure:"link_template" yaml:"link_template"`
+
+	// Lang is the language of text and HTML analyze reports: en or ja
+	// (empty = en)
+	Lang string `mapstructure:"lang" yaml:"lang"`
+
+	// MetricsFile receives the key metrics of each analyze run in
+	// OpenMetrics text format (empty = not written)
+	MetricsFile string `mapstructure:"metrics_file" yaml:"metrics_file"`
+
+	// MetricsPushgateway is the URL of a Prometheus Pushgateway the metrics
+	// are pushed to after each analyze run (empty = not pushed)
+	MetricsPushgateway string `mapstructure:"metrics_pushgateway" yaml:"metrics_pushgateway"`
+
+	// MetricsJob is the job label of pushed metrics (empty = "pyscn")
+	MetricsJob string `mapstructure:"metrics_job" yaml:"metrics_job"`
+
+	// HistoryRuns is how many analyze runs the per-function complexity
+	// history keeps for the trend column of HTML reports (0 = not recorded)
+	HistoryRuns int `mapstructure:"history_runs" yaml:"history_runs"`
+
+	// AbsolutePaths reports absolute file paths instead of paths relative to
+	// the project root; both use forward slashes
+	AbsolutePaths bool `mapstructure:"absolute_paths" yaml:"absolute_paths"`
 }
 
 // DeadCodeConfig holds configuration for dead code detection
@@ -143,6 +184,15 @@ type DeadCodeConfig struct {
 
 	// IgnorePatterns specifies patterns for code to ignore (e.g., comments, debug code)
 	IgnorePatterns []string `mapstructure:"ignore_patterns" yaml:"ignore_patterns"`
+
+	// Severities maps reasons to the severity their findings are reported
+	// with, replacing the defaults of the reasons it names
+	Severities map[string]string `mapstructure:"severities" yaml:"severities"`
+
+	// TerminatingCalls are qualified names of calls that never return, e.g.
+	// "myapp.errors.fatal", in addition to sys.exit() and the other built-in
+	// ones. Code after them is unreachable.
+	TerminatingCalls []string `mapstructure:"terminating_calls" yaml:"terminating_calls"`
 }
 
 // AnalysisConfig holds general analysis configuration
@@ -156,8 +206,21 @@ type AnalysisConfig struct {
 	// Recursive controls whether to analyze directories recursively
 	Recursive bool `mapstructure:"recursive" yaml:"recursive"`
 
-	// FollowSymlinks controls whether to follow symbolic links
+	// FollowSymlinks controls whether to follow symbolic links to
+	// directories. Each directory is walked once, so symlink cycles end.
 	FollowSymlinks bool `mapstructure:"follow_symlinks" yaml:"follow_symlinks"`
+
+	// MaxDepth is how many directory levels below a target are walked;
+	// 0 means no limit
+	MaxDepth int `mapstructure:"max_depth" yaml:"max_depth"`
+
+	// IncludeVendored analyzes vendored code (vendor/, _vendor/, third_party/
+	// and packages marked "# vendored") instead of listing it separately
+	IncludeVendored bool `mapstructure:"include_vendored" yaml:"include_vendored"`
+
+	// Serial runs the analyses of analyze one after another instead of all
+	// at once, trading speed for peak memory
+	Serial bool `mapstructure:"serial" yaml:"serial"`
 }
 
 // DefaultConfig returns the default configuration
@@ -184,6 +247,7 @@ func DefaultConfig() *Config {
 			DetectAfterRaise:          true,
 			DetectUnreachableBranches: true,
 			IgnorePatterns:            []string{},
+			TerminatingCalls:          []string{},
 		},
 		// Use unified pyscn configuration
 		Clones: DefaultPyscnConfig(),
@@ -340,6 +404,12 @@ func PyscnConfigToConfig(pyscn *PyscnConfig) *Config {
 	if pyscn.ComplexityMaxComplexity > 0 {
 		cfg.Complexity.MaxComplexity = pyscn.ComplexityMaxComplexity
 	}
+	if len(pyscn.ComplexityBoilerplate) > 0 {
+		cfg.Complexity.Boilerplate = pyscn.ComplexityBoilerplate
+	}
+	cfg.Complexity.ComprehensionWeight = pyscn.ComplexityComprehensionWeight
+	cfg.Complexity.GeneratorWeight = pyscn.ComplexityGeneratorWeight
+	cfg.Complexity.BooleanOperatorWeight = pyscn.ComplexityBooleanOperatorWeight
 	cfg.Output.MinComplexity = pyscn.EffectiveOutputMinComplexity()
 
 	// DeadCode settings
@@ -376,6 +446,12 @@ func PyscnConfigToConfig(pyscn *PyscnConfig) *Config {
 	if len(pyscn.DeadCodeIgnorePatterns) > 0 {
 		cfg.DeadCode.IgnorePatterns = pyscn.DeadCodeIgnorePatterns
 	}
+	if len(pyscn.DeadCodeSeverities) > 0 {
+		cfg.DeadCode.Severities = pyscn.DeadCodeSeverities
+	}
+	if len(pyscn.DeadCodeTerminatingCalls) > 0 {
+		cfg.DeadCode.TerminatingCalls = pyscn.DeadCodeTerminatingCalls
+	}
 
 	// Output settings
 	if pyscn.OutputFormat != "" {
@@ -390,6 +466,27 @@ func PyscnConfigToConfig(pyscn *PyscnConfig) *Config {
 	if pyscn.OutputDirectory != "" {
 		cfg.Output.Directory = pyscn.OutputDirectory
 	}
+	if pyscn.OutputLinkTemplate != "" {
+		cfg.Output.LinkTemplate = pyscn.OutputLinkTemplate
+	}
+	if pyscn.OutputLang != "" {
+		cfg.Output.Lang = pyscn.OutputLang
+	}
+	if pyscn.OutputMetricsFile != "" {
+		cfg.Output.MetricsFile = pyscn.OutputMetricsFile
+	}
+	if pyscn.OutputMetricsPushgateway != "" {
+		cfg.Output.MetricsPushgateway = pyscn.OutputMetricsPushgateway
+	}
+	if pyscn.OutputMetricsJob != "" {
+		cfg.Output.MetricsJob = pyscn.OutputMetricsJob
+	}
+	if pyscn.OutputHistoryRuns > 0 {
+		cfg.Output.HistoryRuns = pyscn.OutputHistoryRuns
+	}
+	if pyscn.OutputAbsolutePaths != nil {
+		cfg.Output.AbsolutePaths = *pyscn.OutputAbsolutePaths
+	}
 
 	// Analysis settings
 	if pyscn.HasExplicitAnalysisIncludePatterns() {
@@ -404,6 +501,13 @@ func PyscnConfigToConfig(pyscn *PyscnConfig) *Config {
 	if pyscn.AnalysisFollowSymlinks != nil {
 		cfg.Analysis.FollowSymlinks = *pyscn.AnalysisFollowSymlinks
 	}
+	cfg.Analysis.MaxDepth = pyscn.AnalysisMaxDepth
+	if pyscn.AnalysisIncludeVendored != nil {
+		cfg.Analysis.IncludeVendored = *pyscn.AnalysisIncludeVendored
+	}
+	if pyscn.AnalysisSerial != nil {
+		cfg.Analysis.Serial = *pyscn.AnalysisSerial
+	}
 
 	// Clone settings - assign PyscnConfig directly as Clones
 	cfg.Clones = pyscn
@@ -618,6 +722,16 @@ func (c *Config) Validate() error {
 			c.Complexity.NestingDepthThreshold)
 	}
 
+	for name, weight := range map[string]*float64{
+		"comprehension_weight":    c.Complexity.ComprehensionWeight,
+		"generator_weight":        c.Complexity.GeneratorWeight,
+		"boolean_operator_weight": c.Complexity.BooleanOperatorWeight,
+	} {
+		if weight != nil && *weight != 0 && *weight != 0.5 && *weight != 1 {
+			return fmt.Errorf("complexity.%s must be 0, 0.5 or 1, got %g", name, *weight)
+		}
+	}
+
 	// Validate output format
 	validFormats := map[string]bool{
 		"text": true,
@@ -631,6 +745,10 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid output.format '%s', must be one of: text, json, yaml, csv, html", c.Output.Format)
 	}
 
+	if _, err := i18n.ParseLanguage(c.Output.Lang); err != nil {
+		return fmt.Errorf("invalid output.lang: %w", err)
+	}
+
 	// Validate sort options
 	validSortBy := map[string]bool{
 		"name":       true,
@@ -650,6 +768,9 @@ func (c *Config) Validate() error {
 	if len(c.Analysis.IncludePatterns) == 0 {
 		return fmt.Errorf("analysis.include_patterns cannot be empty")
 	}
+	if c.Analysis.MaxDepth < 0 {
+		return fmt.Errorf("analysis.max_depth must be >= 0, got %d", c.Analysis.MaxDepth)
+	}
 
 	// Validate dead code configuration
 	if err := c.validateDeadCodeConfig(); err != nil {
@@ -731,6 +852,10 @@ func ConfigToPyscnTomlConfig(cfg *Config) *PyscnTomlConfig {
 			CognitiveComplexityThreshold: &cfg.Complexity.CognitiveComplexityThreshold,
 			NestingDepthThreshold:        &cfg.Complexity.NestingDepthThreshold,
 			MaxComplexity:                &cfg.Complexity.MaxComplexity,
+			Boilerplate:                  boilerplatePatternsToToml(cfg.Complexity.Boilerplate),
+			ComprehensionWeight:          cfg.Complexity.ComprehensionWeight,
+			GeneratorWeight:              cfg.Complexity.GeneratorWeight,
+			BooleanOperatorWeight:        cfg.Complexity.BooleanOperatorWeight,
 		},
 		DeadCode: DeadCodeTomlConfig{
 			Enabled:                   &cfg.DeadCode.Enabled,
@@ -744,6 +869,8 @@ func ConfigToPyscnTomlConfig(cfg *Config) *PyscnTomlConfig {
 			DetectAfterRaise:          &cfg.DeadCode.DetectAfterRaise,
 			DetectUnreachableBranches: &cfg.DeadCode.DetectUnreachableBranches,
 			IgnorePatterns:            cfg.DeadCode.IgnorePatterns,
+			Severities:                cfg.DeadCode.Severities,
+			TerminatingCalls:          cfg.DeadCode.TerminatingCalls,
 		},
 		Output: OutputTomlConfig{
 			Format:        cfg.Output.Format,
@@ -751,12 +878,24 @@ func ConfigToPyscnTomlConfig(cfg *Config) *PyscnTomlConfig {
 			SortBy:        cfg.Output.SortBy,
 			MinComplexity: &cfg.Output.MinComplexity,
 			Directory:     cfg.Output.Directory,
+			LinkTemplate:  cfg.Output.LinkTemplate,
+			Lang:          cfg.Output.Lang,
+
+			MetricsFile:        cfg.Output.MetricsFile,
+			MetricsPushgateway: cfg.Output.MetricsPushgateway,
+			MetricsJob:         cfg.Output.MetricsJob,
+
+			HistoryRuns:   &cfg.Output.HistoryRuns,
+			AbsolutePaths: &cfg.Output.AbsolutePaths,
 		},
 		Analysis: AnalysisTomlConfig{
 			IncludePatterns: cfg.Analysis.IncludePatterns,
 			ExcludePatterns: cfg.Analysis.ExcludePatterns,
 			Recursive:       &cfg.Analysis.Recursive,
 			FollowSymlinks:  &cfg.Analysis.FollowSymlinks,
+			MaxDepth:        &cfg.Analysis.MaxDepth,
+			IncludeVendored: &cfg.Analysis.IncludeVendored,
+			Serial:          &cfg.Analysis.Serial,
 		},
 	}
 }
@@ -774,13 +913,22 @@ func (c *Config) validateDeadCodeConfig() error {
 		return fmt.Errorf("invalid dead_code.min_severity '%s', must be one of: critical, warning, info", c.DeadCode.MinSeverity)
 	}
 
+	for reason, severity := range c.DeadCode.Severities {
+		if domain.DeadCodeReasonCategory(reason) == "" {
+			return fmt.Errorf("unknown reason '%s' in dead_code.severities", reason)
+		}
+		if !validSeverities[severity] {
+			return fmt.Errorf("invalid dead_code.severities.%s '%s', must be one of: critical, warning, info", reason, severity)
+		}
+	}
+
 	// Validate context lines
 	if c.DeadCode.ContextLines < 0 {
 		return fmt.Errorf("dead_code.context_lines must be >= 0, got %d", c.DeadCode.ContextLines)
 	}
 
-	if c.DeadCode.ContextLines > 20 {
-		return fmt.Errorf("dead_code.context_lines cannot exceed 20, got %d", c.DeadCode.ContextLines)
+	if c.DeadCode.ContextLines > domain.MaxDeadCodeContextLines {
+		return fmt.Errorf("dead_code.context_lines cannot exceed %d, got %d", domain.MaxDeadCodeContextLines, c.DeadCode.ContextLines)
 	}
 
 	// Validate sort criteria
@@ -948,4 +1096,15 @@ type LayerRule struct {
 	Deny        []string `mapstructure:"deny" yaml:"deny"`
 	Warn        []string `mapstructure:"warn" yaml:"warn"`
 	Description string   `mapstructure:"description" yaml:"description"`
+	Budget      int      `mapstructure:"budget" yaml:"budget"`
+}
+
+// ArchitectureRuleSet defines layers and rules applied to each directory
+// matching one of its paths on their own
+type ArchitectureRuleSet struct {
+	Name       string            `mapstructure:"name" yaml:"name"`
+	Paths      []string          `mapstructure:"paths" yaml:"paths"`
+	StrictMode bool              `mapstructure:"strict_mode" yaml:"strict_mode"`
+	Layers     []LayerDefinition `mapstructure:"layers" yaml:"layers"`
+	Rules      []LayerRule       `mapstructure:"rules" yaml:"rules"`
 }
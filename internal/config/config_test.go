@@ -106,6 +106,15 @@ func TestConfigValidation(t *testing.T) {
 			expectError:   true,
 			errorContains: fmt.Sprintf("max_complexity (15) must be > medium_threshold (%d)", DefaultMediumComplexityThreshold),
 		},
+		{
+			name: "InvalidExpressionWeight",
+			modifyConfig: func(c *Config) {
+				weight := 2.0
+				c.Complexity.BooleanOperatorWeight = &weight
+			},
+			expectError:   true,
+			errorContains: "complexity.boolean_operator_weight must be 0, 0.5 or 1, got 2",
+		},
 		{
 			name: "InvalidOutputFormat",
 			modifyConfig: func(c *Config) {
@@ -122,6 +131,14 @@ func TestConfigValidation(t *testing.T) {
 			expectError:   true,
 			errorContains: "invalid output.sort_by 'invalid'",
 		},
+		{
+			name: "InvalidOutputLang",
+			modifyConfig: func(c *Config) {
+				c.Output.Lang = "fr"
+			},
+			expectError:   true,
+			errorContains: "invalid output.lang",
+		},
 		{
 			name: "InvalidMinComplexity",
 			modifyConfig: func(c *Config) {
@@ -138,6 +155,30 @@ func TestConfigValidation(t *testing.T) {
 			expectError:   true,
 			errorContains: "include_patterns cannot be empty",
 		},
+		{
+			name: "NegativeMaxDepth",
+			modifyConfig: func(c *Config) {
+				c.Analysis.MaxDepth = -1
+			},
+			expectError:   true,
+			errorContains: "max_depth must be >= 0",
+		},
+		{
+			name: "UnknownDeadCodeSeverityReason",
+			modifyConfig: func(c *Config) {
+				c.DeadCode.Severities = map[string]string{"after_return": "info"}
+			},
+			expectError:   true,
+			errorContains: "unknown reason 'after_return' in dead_code.severities",
+		},
+		{
+			name: "InvalidDeadCodeSeverity",
+			modifyConfig: func(c *Config) {
+				c.DeadCode.Severities = map[string]string{"unreachable_branch": "error"}
+			},
+			expectError:   true,
+			errorContains: "invalid dead_code.severities.unreachable_branch 'error'",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -374,18 +415,23 @@ func TestLoadConfig(t *testing.T) {
 	low_threshold = 5
 	medium_threshold = 15
 	max_complexity = 50
+	generator_weight = 0.5
 
 [output]
 format = "json"
 show_details = true
 sort_by = "complexity"
 min_complexity = 2
+absolute_paths = true
 
 [analysis]
 include_patterns = ["**/*.py", "**/*.pyx"]
 exclude_patterns = ["**/*test*.py"]
 recursive = true
-follow_symlinks = false
+follow_symlinks = true
+max_depth = 4
+include_vendored = true
+serial = true
 `
 
 		err := os.WriteFile(configPath, []byte(tomlContent), 0644)
@@ -411,6 +457,12 @@ follow_symlinks = false
 		if config.Complexity.ReportUnchanged {
 			t.Error("Expected report_unchanged to be false")
 		}
+		if config.Complexity.GeneratorWeight == nil || *config.Complexity.GeneratorWeight != 0.5 {
+			t.Errorf("Expected generator weight 0.5, got %v", config.Complexity.GeneratorWeight)
+		}
+		if config.Complexity.ComprehensionWeight != nil {
+			t.Errorf("Expected comprehension weight to stay unset, got %v", *config.Complexity.ComprehensionWeight)
+		}
 		if config.Complexity.MaxComplexity != 50 {
 			t.Errorf("Expected max complexity 50, got %d", config.Complexity.MaxComplexity)
 		}
@@ -423,9 +475,24 @@ follow_symlinks = false
 		if config.Output.SortBy != "complexity" {
 			t.Errorf("Expected sort_by complexity, got %s", config.Output.SortBy)
 		}
+		if !config.Output.AbsolutePaths {
+			t.Error("Expected absolute_paths to be true")
+		}
 		if len(config.Analysis.IncludePatterns) != 2 {
 			t.Errorf("Expected 2 include patterns, got %d", len(config.Analysis.IncludePatterns))
 		}
+		if !config.Analysis.IncludeVendored {
+			t.Error("Expected include_vendored to be true")
+		}
+		if !config.Analysis.Serial {
+			t.Error("Expected serial to be true")
+		}
+		if !config.Analysis.FollowSymlinks {
+			t.Error("Expected follow_symlinks to be true")
+		}
+		if config.Analysis.MaxDepth != 4 {
+			t.Errorf("Expected max_depth 4, got %d", config.Analysis.MaxDepth)
+		}
 	})
 
 	t.Run("LoadInvalidTOMLConfig", func(t *testing.T) {
@@ -641,6 +708,29 @@ include_patterns = ["from-pyproject"]
 	}
 }
 
+func TestLoadConfigWithTarget_OutputMetrics(t *testing.T) {
+	tempDir := t.TempDir()
+	content := `[output]
+metrics_file = "metrics/pyscn.prom"
+metrics_pushgateway = "http://localhost:9091"
+metrics_job = "backend"
+`
+	configPath := filepath.Join(tempDir, ".pyscn.toml")
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write .pyscn.toml: %v", err)
+	}
+
+	cfg, err := LoadConfigWithTarget(configPath, "")
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.Output.MetricsFile != "metrics/pyscn.prom" ||
+		cfg.Output.MetricsPushgateway != "http://localhost:9091" ||
+		cfg.Output.MetricsJob != "backend" {
+		t.Fatalf("Expected the metrics settings from [output], got %+v", cfg.Output)
+	}
+}
+
 // Helper function to check if a string contains a substring
 func containsString(str, substr string) bool {
 	return len(str) >= len(substr) &&
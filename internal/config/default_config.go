@@ -32,15 +32,18 @@ type DefaultConfigValues struct {
 	DeadCodeSortBy       string
 
 	// Clone thresholds
-	Type1Threshold       float64
-	Type2Threshold       float64
-	Type3Threshold       float64
-	Type4Threshold       float64
-	SimilarityThreshold  float64
-	GroupingThreshold    float64
-	CloneMinLines        int
-	CloneMinNodes        int
-	CloneMaxEditDistance float64
+	Type1Threshold                 float64
+	Type2Threshold                 float64
+	Type3Threshold                 float64
+	Type4Threshold                 float64
+	SimilarityThreshold            float64
+	GroupingThreshold              float64
+	CloneMinLines                  int
+	CloneMinNodes                  int
+	CloneMinTokens                 int
+	CloneMinStringLength           int
+	CloneStringSimilarityThreshold float64
+	CloneMaxEditDistance           float64
 
 	// LSH
 	LSHAutoThreshold       int
@@ -78,15 +81,18 @@ func newDefaultConfigValues() DefaultConfigValues {
 		DeadCodeSortBy:       domain.DefaultDeadCodeSortBy,
 
 		// Clone thresholds
-		Type1Threshold:       domain.DefaultType1CloneThreshold,
-		Type2Threshold:       domain.DefaultType2CloneThreshold,
-		Type3Threshold:       domain.DefaultType3CloneThreshold,
-		Type4Threshold:       domain.DefaultType4CloneThreshold,
-		SimilarityThreshold:  domain.DefaultCloneSimilarityThreshold,
-		GroupingThreshold:    domain.DefaultCloneGroupingThreshold,
-		CloneMinLines:        domain.DefaultCloneMinLines,
-		CloneMinNodes:        domain.DefaultCloneMinNodes,
-		CloneMaxEditDistance: domain.DefaultCloneMaxEditDistance,
+		Type1Threshold:                 domain.DefaultType1CloneThreshold,
+		Type2Threshold:                 domain.DefaultType2CloneThreshold,
+		Type3Threshold:                 domain.DefaultType3CloneThreshold,
+		Type4Threshold:                 domain.DefaultType4CloneThreshold,
+		SimilarityThreshold:            domain.DefaultCloneSimilarityThreshold,
+		GroupingThreshold:              domain.DefaultCloneGroupingThreshold,
+		CloneMinLines:                  domain.DefaultCloneMinLines,
+		CloneMinNodes:                  domain.DefaultCloneMinNodes,
+		CloneMinTokens:                 domain.DefaultCloneMinTokens,
+		CloneMinStringLength:           domain.DefaultCloneMinStringLength,
+		CloneStringSimilarityThreshold: domain.DefaultCloneStringSimilarityThreshold,
+		CloneMaxEditDistance:           domain.DefaultCloneMaxEditDistance,
 
 		// LSH
 		LSHAutoThreshold:       domain.DefaultLSHAutoThreshold,
@@ -158,10 +164,11 @@ func LoadDefaultConfigFromTOML() (*Config, error) {
 		cfg.Architecture.Rules = make([]LayerRule, len(tomlCfg.Architecture.Rules))
 		for i, rule := range tomlCfg.Architecture.Rules {
 			cfg.Architecture.Rules[i] = LayerRule{
-				From:  rule.From,
-				Allow: rule.Allow,
-				Deny:  rule.Deny,
-				Warn:  rule.Warn,
+				From:   rule.From,
+				Allow:  rule.Allow,
+				Deny:   rule.Deny,
+				Warn:   rule.Warn,
+				Budget: rule.Budget,
 			}
 		}
 	}
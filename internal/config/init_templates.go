@@ -0,0 +1,141 @@
+package config
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+//go:embed init_templates/*.tmpl
+var initTemplateFS embed.FS
+
+// InitTemplateDefault is the fully commented configuration written by
+// `pyscn init` when no template is selected
+const InitTemplateDefault = "default"
+
+// InitTemplate describes a configuration preset offered by `pyscn init`
+type InitTemplate struct {
+	Name        string
+	Description string
+}
+
+// InitTemplates lists the available presets in display order
+func InitTemplates() []InitTemplate {
+	return []InitTemplate{
+		{Name: InitTemplateDefault, Description: "Every option with its default value and an explanation"},
+		{Name: "strict", Description: "Tight thresholds that block regressions early in CI"},
+		{Name: "lenient", Description: "Relaxed thresholds for adopting pyscn on a legacy codebase"},
+		{Name: "django", Description: "Django project: excludes migrations and settings, validates MVT layers"},
+		{Name: "fastapi", Description: "FastAPI service: validates router, service and repository layers"},
+		{Name: "library", Description: "Reusable package: excludes docs and examples, forbids import cycles"},
+	}
+}
+
+// InitTemplateNames returns the names of the available presets
+func InitTemplateNames() []string {
+	templates := InitTemplates()
+	names := make([]string, len(templates))
+	for i, t := range templates {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// GenerateInitTemplateTOML renders the named preset. Presets only list the
+// settings they tune; everything else falls back to the defaults.
+func GenerateInitTemplateTOML(name string) (string, error) {
+	if name == "" || name == InitTemplateDefault {
+		return GenerateDefaultConfigTOML()
+	}
+
+	content, err := initTemplateFS.ReadFile("init_templates/" + name + ".toml.tmpl")
+	if err != nil {
+		return "", fmt.Errorf("unknown template %q (available: %s)", name, strings.Join(InitTemplateNames(), ", "))
+	}
+
+	tmpl, err := template.New(name).Parse(string(content))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s config template: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, newDefaultConfigValues()); err != nil {
+		return "", fmt.Errorf("failed to render %s config template: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// CI providers supported by GenerateCISnippet
+const (
+	CIProviderGitHub    = "github"
+	CIProviderGitLab    = "gitlab"
+	CIProviderPreCommit = "pre-commit"
+)
+
+// CISnippet is a ready-to-paste CI configuration running `pyscn check`
+type CISnippet struct {
+	Provider string
+	Path     string // Conventional location of the file in the repository
+	Content  string
+}
+
+// GenerateCISnippet returns a CI configuration for the provider that runs
+// the quality gate with the given configuration file
+func GenerateCISnippet(provider, configPath string) (*CISnippet, error) {
+	checkCmd := "pyscn check"
+	if configPath != "" && configPath != ".pyscn.toml" {
+		checkCmd += " --config " + configPath
+	}
+
+	switch provider {
+	case CIProviderGitHub:
+		return &CISnippet{
+			Provider: provider,
+			Path:     ".github/workflows/pyscn.yml",
+			Content: `name: pyscn
+on: [pull_request, push]
+
+jobs:
+  pyscn:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: astral-sh/setup-uv@v3
+      - run: uvx ` + checkCmd + ` .
+`,
+		}, nil
+	case CIProviderGitLab:
+		return &CISnippet{
+			Provider: provider,
+			Path:     ".gitlab-ci.yml",
+			Content: `pyscn:
+  stage: test
+  image: python:3.12-slim
+  script:
+    - pip install pyscn
+    - ` + checkCmd + ` .
+`,
+		}, nil
+	case CIProviderPreCommit:
+		return &CISnippet{
+			Provider: provider,
+			Path:     ".pre-commit-config.yaml",
+			Content: `repos:
+  - repo: local
+    hooks:
+      - id: pyscn
+        name: pyscn check
+        entry: ` + checkCmd + `
+        language: python
+        additional_dependencies: [pyscn]
+        pass_filenames: false
+        files: '\.py$'
+`,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown CI provider %q (available: %s, %s, %s)",
+			provider, CIProviderGitHub, CIProviderGitLab, CIProviderPreCommit)
+	}
+}
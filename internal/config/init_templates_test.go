@@ -0,0 +1,93 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateInitTemplateTOML_AllTemplatesValidate(t *testing.T) {
+	for _, name := range InitTemplateNames() {
+		t.Run(name, func(t *testing.T) {
+			content, err := GenerateInitTemplateTOML(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Contains(content, "{{") {
+				t.Fatalf("template %s left unrendered placeholders", name)
+			}
+
+			path := filepath.Join(t.TempDir(), ".pyscn.toml")
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			inspection, err := InspectConfig(path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(inspection.Issues) != 0 {
+				t.Errorf("template %s should validate cleanly, got %+v", name, inspection.Issues)
+			}
+		})
+	}
+}
+
+func TestGenerateInitTemplateTOML_PresetValues(t *testing.T) {
+	content, err := GenerateInitTemplateTOML("django")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), ".pyscn.toml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := NewTomlConfigLoader().LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ArchitectureStyle != ArchitectureStyleMVC {
+		t.Errorf("expected mvc architecture style, got %q", cfg.ArchitectureStyle)
+	}
+	found := false
+	for _, pattern := range cfg.AnalysisExcludePatterns {
+		if pattern == "**/migrations/**" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected migrations to be excluded, got %v", cfg.AnalysisExcludePatterns)
+	}
+}
+
+func TestGenerateInitTemplateTOML_Unknown(t *testing.T) {
+	_, err := GenerateInitTemplateTOML("flask")
+	if err == nil || !strings.Contains(err.Error(), "available: default, strict") {
+		t.Fatalf("expected unknown template error listing the presets, got %v", err)
+	}
+}
+
+func TestGenerateCISnippet(t *testing.T) {
+	for _, provider := range []string{CIProviderGitHub, CIProviderGitLab, CIProviderPreCommit} {
+		snippet, err := GenerateCISnippet(provider, ".pyscn.toml")
+		if err != nil {
+			t.Fatalf("%s: %v", provider, err)
+		}
+		if !strings.Contains(snippet.Content, "pyscn check") || strings.Contains(snippet.Content, "--config") {
+			t.Errorf("%s: expected a plain pyscn check invocation, got:\n%s", provider, snippet.Content)
+		}
+	}
+
+	snippet, err := GenerateCISnippet(CIProviderGitHub, "tools/pyscn.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(snippet.Content, "pyscn check --config tools/pyscn.toml .") {
+		t.Errorf("expected a custom config path to be passed, got:\n%s", snippet.Content)
+	}
+
+	if _, err := GenerateCISnippet("jenkins", ""); err == nil {
+		t.Error("expected an error for an unknown provider")
+	}
+}
@@ -0,0 +1,841 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// ConfigSource tells where an effective setting came from
+type ConfigSource string
+
+const (
+	ConfigSourceDefault ConfigSource = "default"
+	ConfigSourceFile    ConfigSource = "file"
+	ConfigSourceFlag    ConfigSource = "flag"
+)
+
+// IssueSeverity classifies a configuration problem
+type IssueSeverity string
+
+const (
+	IssueError   IssueSeverity = "error"   // The configuration is rejected or does not do what it says
+	IssueWarning IssueSeverity = "warning" // The configuration works but part of it is ignored or overridden
+)
+
+// ConfigIssue is a problem found while inspecting a configuration file
+type ConfigIssue struct {
+	Severity IssueSeverity `json:"severity"`
+	Key      string        `json:"key,omitempty"`  // Dotted key relative to the pyscn section
+	Line     int           `json:"line,omitempty"` // Source line, when the TOML parser reports one
+	Message  string        `json:"message"`
+}
+
+// EffectiveSetting is a single merged configuration value and its origin
+type EffectiveSetting struct {
+	Key    string       `json:"key"`
+	Value  interface{}  `json:"value"`
+	Source ConfigSource `json:"source"`
+}
+
+// ConfigInspection is the result of validating a configuration file and
+// resolving the effective configuration
+type ConfigInspection struct {
+	Path     string             `json:"path,omitempty"`
+	Issues   []ConfigIssue      `json:"issues"`
+	Settings []EffectiveSetting `json:"settings,omitempty"`
+}
+
+// HasErrors reports whether any issue is an error
+func (i *ConfigInspection) HasErrors() bool {
+	for _, issue := range i.Issues {
+		if issue.Severity == IssueError {
+			return true
+		}
+	}
+	return false
+}
+
+// unsupportedKeys are keys that appear in older generated configs but have
+// never been read. They are reported with a hint instead of as typos.
+var unsupportedKeys = map[string]string{
+	"cbo.enabled": "CBO analysis cannot be disabled from the config file; use --skip-cbo or --select",
+}
+
+// InspectConfig validates a configuration file and resolves the effective
+// configuration. Keys in overrides (dotted, relative to the pyscn section)
+// take precedence over the file, the way command line flags do. An empty
+// path inspects the built-in defaults. Only I/O failures are returned as
+// errors; problems with the file itself are reported as issues.
+func InspectConfig(path string, overrides map[string]interface{}) (*ConfigInspection, error) {
+	inspection := &ConfigInspection{Path: path, Issues: []ConfigIssue{}}
+
+	section := map[string]interface{}{}
+	isPyproject := path != "" && filepath.Base(path) == "pyproject.toml"
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var raw map[string]interface{}
+		if err := toml.Unmarshal(data, &raw); err != nil {
+			inspection.addDecodeError(err)
+			return inspection, nil
+		}
+
+		schema := reflect.TypeOf(PyscnTomlConfig{})
+		var typed interface{} = &PyscnTomlConfig{}
+		section = raw
+		if isPyproject {
+			schema = reflect.TypeOf(PyprojectPyscnSection{})
+			typed = &PyprojectToml{}
+			section = nestedTable(raw, "tool", "pyscn")
+		}
+
+		inspection.Issues = append(inspection.Issues, unknownKeyIssues(section, schema, "")...)
+		if err := toml.Unmarshal(data, typed); err != nil {
+			inspection.addDecodeError(err)
+			return inspection, nil
+		}
+	}
+
+	layered := applyConfigOverrides(section, overrides)
+	if isPyproject {
+		// pyproject.toml has no [tool.pyscn.mock_data] section; it was
+		// already reported as an unknown key
+		delete(layered, "mock_data")
+	}
+	cfg, err := pyscnConfigFromTable(layered)
+	if err != nil {
+		inspection.addDecodeError(err)
+		return inspection, nil
+	}
+
+	inspection.Issues = append(inspection.Issues, checkEffectiveConfig(cfg, section)...)
+	inspection.Settings = effectiveSettings(cfg, section, overrides)
+	return inspection, nil
+}
+
+func (i *ConfigInspection) addDecodeError(err error) {
+	issue := ConfigIssue{Severity: IssueError, Message: err.Error()}
+
+	var decodeErr *toml.DecodeError
+	if errors.As(err, &decodeErr) {
+		issue.Line, _ = decodeErr.Position()
+		issue.Key = strings.Join(decodeErr.Key(), ".")
+	}
+	i.Issues = append(i.Issues, issue)
+}
+
+// nestedTable returns the table at the given path, or an empty table
+func nestedTable(table map[string]interface{}, path ...string) map[string]interface{} {
+	current := table
+	for _, key := range path {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			return map[string]interface{}{}
+		}
+		current = next
+	}
+	return current
+}
+
+// unknownKeyIssues reports keys in a table that have no matching field in
+// the schema, suggesting the closest known key for likely typos
+func unknownKeyIssues(table map[string]interface{}, schema reflect.Type, prefix string) []ConfigIssue {
+	fields := tomlFields(schema)
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var issues []ConfigIssue
+	for _, key := range sortedTableKeys(table) {
+		fullKey := prefix + key
+		field, ok := fields[key]
+		if !ok {
+			if hint, known := unsupportedKeys[fullKey]; known {
+				issues = append(issues, ConfigIssue{
+					Severity: IssueWarning,
+					Key:      fullKey,
+					Message:  fmt.Sprintf("%s is not supported and has no effect: %s", fullKey, hint),
+				})
+				continue
+			}
+			message := fmt.Sprintf("unknown key %s", fullKey)
+			if suggestion := closestName(key, names); suggestion != "" {
+				message += fmt.Sprintf(" (did you mean %s%s?)", prefix, suggestion)
+			}
+			issues = append(issues, ConfigIssue{Severity: IssueError, Key: fullKey, Message: message})
+			continue
+		}
+
+		fieldType := field
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		switch value := table[key].(type) {
+		case map[string]interface{}:
+			if fieldType.Kind() == reflect.Struct {
+				issues = append(issues, unknownKeyIssues(value, fieldType, fullKey+".")...)
+			}
+		case []interface{}:
+			if fieldType.Kind() == reflect.Slice && fieldType.Elem().Kind() == reflect.Struct {
+				for i, item := range value {
+					if itemTable, ok := item.(map[string]interface{}); ok {
+						issues = append(issues, unknownKeyIssues(itemTable, fieldType.Elem(), fmt.Sprintf("%s[%d].", fullKey, i))...)
+					}
+				}
+			}
+		}
+	}
+	return issues
+}
+
+// ConfigKeys lists every dotted key the configuration file accepts, sorted.
+// Sections are listed by their keys; arrays of tables, such as
+// architecture.layers, are listed as one key.
+func ConfigKeys() []string {
+	var keys []string
+	var walk func(schema reflect.Type, prefix string)
+	walk = func(schema reflect.Type, prefix string) {
+		for name, field := range tomlFields(schema) {
+			for field.Kind() == reflect.Ptr {
+				field = field.Elem()
+			}
+			if field.Kind() == reflect.Struct {
+				walk(field, prefix+name+".")
+				continue
+			}
+			keys = append(keys, prefix+name)
+		}
+	}
+	walk(reflect.TypeOf(PyscnTomlConfig{}), "")
+	sort.Strings(keys)
+	return keys
+}
+
+// tomlFields maps the TOML key of each exported field to its type
+func tomlFields(schema reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < schema.NumField(); i++ {
+		field := schema.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("toml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+func sortedTableKeys(table map[string]interface{}) []string {
+	keys := make([]string, 0, len(table))
+	for key := range table {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// closestName returns the candidate within a small edit distance of name,
+// or an empty string when nothing is close enough to be a typo
+func closestName(name string, candidates []string) string {
+	best, bestDistance := "", len(name)/3+2
+	for _, candidate := range candidates {
+		if d := editDistance(name, candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// applyConfigOverrides returns a deep copy of table with the dotted override
+// keys set
+func applyConfigOverrides(table map[string]interface{}, overrides map[string]interface{}) map[string]interface{} {
+	result := copyTable(table)
+	for key, value := range overrides {
+		parts := strings.Split(key, ".")
+		current := result
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := current[part].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				current[part] = next
+			}
+			current = next
+		}
+		current[parts[len(parts)-1]] = value
+	}
+	return result
+}
+
+func copyTable(table map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(table))
+	for key, value := range table {
+		if nested, ok := value.(map[string]interface{}); ok {
+			value = copyTable(nested)
+		}
+		result[key] = value
+	}
+	return result
+}
+
+// pyscnConfigFromTable merges a pyscn section table into the defaults the
+// same way the file loaders do
+func pyscnConfigFromTable(table map[string]interface{}) (*PyscnConfig, error) {
+	data, err := toml.Marshal(table)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed PyscnTomlConfig
+	if err := toml.Unmarshal(data, &parsed); err != nil {
+		return nil, err
+	}
+	markTomlFieldPresence(data, &parsed.Analysis, "analysis", "include_patterns")
+
+	cfg := DefaultPyscnConfig()
+	(&TomlConfigLoader{}).mergePyscnTomlConfigs(cfg, &parsed)
+	return cfg, nil
+}
+
+// effectiveSettings flattens the merged configuration and attributes each
+// value to a flag, the file or the defaults
+func effectiveSettings(cfg *PyscnConfig, fileTable map[string]interface{}, overrides map[string]interface{}) []EffectiveSetting {
+	data, err := toml.Marshal(PyscnConfigToTomlConfig(cfg))
+	if err != nil {
+		return nil
+	}
+	var table map[string]interface{}
+	if err := toml.Unmarshal(data, &table); err != nil {
+		return nil
+	}
+
+	values := make(map[string]interface{})
+	flattenTable(table, "", values)
+	fromFile := make(map[string]interface{})
+	flattenTable(fileTable, "", fromFile)
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	settings := make([]EffectiveSetting, 0, len(keys))
+	for _, key := range keys {
+		source := ConfigSourceDefault
+		if _, ok := overrides[key]; ok {
+			source = ConfigSourceFlag
+		} else if _, ok := fromFile[key]; ok {
+			source = ConfigSourceFile
+		}
+		settings = append(settings, EffectiveSetting{Key: key, Value: values[key], Source: source})
+	}
+	return settings
+}
+
+// flattenTable collects the leaf values of a table under dotted keys.
+// Arrays of tables, such as architecture layers, are kept as one value.
+func flattenTable(table map[string]interface{}, prefix string, out map[string]interface{}) {
+	for key, value := range table {
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattenTable(nested, prefix+key+".", out)
+			continue
+		}
+		out[prefix+key] = value
+	}
+}
+
+// PyscnConfigToTomlConfig converts a merged configuration back to the TOML
+// file layout, so that it can be displayed or written as a config file
+func PyscnConfigToTomlConfig(c *PyscnConfig) *PyscnTomlConfig {
+	layers := make([]LayerDefinitionToml, len(c.ArchitectureLayers))
+	for i, layer := range c.ArchitectureLayers {
+		layers[i] = LayerDefinitionToml{
+			Name:        layer.Name,
+			Description: layer.Description,
+			Packages:    layer.Packages,
+			IsAbstract:  layer.IsAbstract,
+		}
+	}
+	rules := make([]LayerRuleToml, len(c.ArchitectureRules))
+	for i, rule := range c.ArchitectureRules {
+		rules[i] = LayerRuleToml(rule)
+	}
+	ruleSets := make([]ArchitectureRuleSetToml, len(c.ArchitectureRuleSets))
+	for i, rs := range c.ArchitectureRuleSets {
+		strictMode := rs.StrictMode
+		ruleSets[i] = ArchitectureRuleSetToml{
+			Name:       rs.Name,
+			Paths:      rs.Paths,
+			StrictMode: &strictMode,
+			Layers:     make([]LayerDefinitionToml, len(rs.Layers)),
+			Rules:      make([]LayerRuleToml, len(rs.Rules)),
+		}
+		for j, layer := range rs.Layers {
+			ruleSets[i].Layers[j] = LayerDefinitionToml{
+				Name:        layer.Name,
+				Description: layer.Description,
+				Packages:    layer.Packages,
+				IsAbstract:  layer.IsAbstract,
+			}
+		}
+		for j, rule := range rs.Rules {
+			ruleSets[i].Rules[j] = LayerRuleToml(rule)
+		}
+	}
+	outputMinComplexity := c.OutputMinComplexity
+
+	return &PyscnTomlConfig{
+		Complexity: ComplexityTomlConfig{
+			Enabled:                      c.ComplexityEnabled,
+			ReportUnchanged:              c.ComplexityReportUnchanged,
+			LowThreshold:                 &c.ComplexityLowThreshold,
+			MediumThreshold:              &c.ComplexityMediumThreshold,
+			CognitiveComplexityThreshold: &c.CognitiveComplexityThreshold,
+			NestingDepthThreshold:        &c.NestingDepthThreshold,
+			MaxComplexity:                &c.ComplexityMaxComplexity,
+			MinComplexity:                &c.ComplexityMinComplexity,
+			Boilerplate:                  boilerplatePatternsToToml(c.ComplexityBoilerplate),
+			ComprehensionWeight:          c.ComplexityComprehensionWeight,
+			GeneratorWeight:              c.ComplexityGeneratorWeight,
+			BooleanOperatorWeight:        c.ComplexityBooleanOperatorWeight,
+			IncludePatterns:              c.AnalyzerScopes[domain.AnalysisScopeComplexity].IncludePatterns,
+			ExcludePatterns:              c.AnalyzerScopes[domain.AnalysisScopeComplexity].ExcludePatterns,
+		},
+		DeadCode: DeadCodeTomlConfig{
+			Enabled:                   c.DeadCodeEnabled,
+			MinSeverity:               c.DeadCodeMinSeverity,
+			ShowContext:               c.DeadCodeShowContext,
+			ContextLines:              &c.DeadCodeContextLines,
+			SortBy:                    c.DeadCodeSortBy,
+			DetectAfterReturn:         c.DeadCodeDetectAfterReturn,
+			DetectAfterBreak:          c.DeadCodeDetectAfterBreak,
+			DetectAfterContinue:       c.DeadCodeDetectAfterContinue,
+			DetectAfterRaise:          c.DeadCodeDetectAfterRaise,
+			DetectUnreachableBranches: c.DeadCodeDetectUnreachableBranches,
+			IgnorePatterns:            c.DeadCodeIgnorePatterns,
+			Severities:                c.DeadCodeSeverities,
+			TerminatingCalls:          c.DeadCodeTerminatingCalls,
+			IncludePatterns:           c.AnalyzerScopes[domain.AnalysisScopeDeadCode].IncludePatterns,
+			ExcludePatterns:           c.AnalyzerScopes[domain.AnalysisScopeDeadCode].ExcludePatterns,
+		},
+		Output: OutputTomlConfig{
+			Format:        c.OutputFormat,
+			ShowDetails:   c.OutputShowDetails,
+			SortBy:        c.OutputSortBy,
+			MinComplexity: &outputMinComplexity,
+			Directory:     c.OutputDirectory,
+			LinkTemplate:  c.OutputLinkTemplate,
+			Lang:          c.OutputLang,
+
+			MetricsFile:        c.OutputMetricsFile,
+			MetricsPushgateway: c.OutputMetricsPushgateway,
+			MetricsJob:         c.OutputMetricsJob,
+
+			HistoryRuns:   &c.OutputHistoryRuns,
+			AbsolutePaths: c.OutputAbsolutePaths,
+		},
+		Analysis: AnalysisTomlConfig{
+			IncludePatterns: c.AnalysisIncludePatterns,
+			ExcludePatterns: c.AnalysisExcludePatterns,
+			Recursive:       c.AnalysisRecursive,
+			FollowSymlinks:  c.AnalysisFollowSymlinks,
+			MaxDepth:        &c.AnalysisMaxDepth,
+			IncludeVendored: c.AnalysisIncludeVendored,
+			Serial:          c.AnalysisSerial,
+		},
+		Cbo: CboTomlConfig{
+			LowThreshold:          &c.CboLowThreshold,
+			MediumThreshold:       &c.CboMediumThreshold,
+			MinCbo:                &c.CboMinCbo,
+			MaxCbo:                &c.CboMaxCbo,
+			ShowZeros:             c.CboShowZeros,
+			IncludeBuiltins:       c.CboIncludeBuiltins,
+			IncludeImports:        c.CboIncludeImports,
+			GroupNamespaceImports: c.CboGroupNamespaceImports,
+			IncludeTypeHints:      c.CboIncludeTypeHints,
+			MaxInheritance:        &c.CboMaxInheritance,
+			MaxComposition:        &c.CboMaxComposition,
+			MaxInstantiation:      &c.CboMaxInstantiation,
+			MaxMethodCalls:        &c.CboMaxMethodCalls,
+			MaxTypeHints:          &c.CboMaxTypeHints,
+			IncludePatterns:       c.AnalyzerScopes[domain.AnalysisScopeCBO].IncludePatterns,
+			ExcludePatterns:       c.AnalyzerScopes[domain.AnalysisScopeCBO].ExcludePatterns,
+		},
+		Lcom: LcomTomlConfig{
+			LowThreshold:    &c.LcomLowThreshold,
+			MediumThreshold: &c.LcomMediumThreshold,
+			IncludePatterns: c.AnalyzerScopes[domain.AnalysisScopeLCOM].IncludePatterns,
+			ExcludePatterns: c.AnalyzerScopes[domain.AnalysisScopeLCOM].ExcludePatterns,
+		},
+		Architecture: ArchitectureTomlConfig{
+			Enabled:                         c.ArchitectureEnabled,
+			ValidateLayers:                  c.ArchitectureValidateLayers,
+			ValidateCohesion:                c.ArchitectureValidateCohesion,
+			ValidateResponsibility:          c.ArchitectureValidateResponsibility,
+			MinCohesion:                     &c.ArchitectureMinCohesion,
+			MaxCoupling:                     &c.ArchitectureMaxCoupling,
+			MaxResponsibilities:             &c.ArchitectureMaxResponsibilities,
+			LayerViolationSeverity:          c.ArchitectureLayerViolationSeverity,
+			CohesionViolationSeverity:       c.ArchitectureCohesionViolationSeverity,
+			ResponsibilityViolationSeverity: c.ArchitectureResponsibilityViolationSeverity,
+			ValidateGodModules:              c.ArchitectureValidateGodModules,
+			GodModuleMaxLines:               &c.ArchitectureGodModuleMaxLines,
+			GodModuleMaxDefinitions:         &c.ArchitectureGodModuleMaxDefinitions,
+			GodModuleMaxFanIn:               &c.ArchitectureGodModuleMaxFanIn,
+			GodModuleMaxFanOut:              &c.ArchitectureGodModuleMaxFanOut,
+			ShowAllViolations:               c.ArchitectureShowAllViolations,
+			GroupByType:                     c.ArchitectureGroupByType,
+			IncludeSuggestions:              c.ArchitectureIncludeSuggestions,
+			MaxViolationsToShow:             &c.ArchitectureMaxViolationsToShow,
+			CustomPatterns:                  c.ArchitectureCustomPatterns,
+			AllowedPatterns:                 c.ArchitectureAllowedPatterns,
+			ForbiddenPatterns:               c.ArchitectureForbiddenPatterns,
+			StrictMode:                      c.ArchitectureStrictMode,
+			FailOnViolations:                c.ArchitectureFailOnViolations,
+			NeutralPrefixes:                 c.ArchitectureNeutralPrefixes,
+			IgnoreEdges:                     c.ArchitectureIgnoreEdges,
+			DowngradeEdges:                  c.ArchitectureDowngradeEdges,
+			Style:                           c.ArchitectureStyle,
+			Layers:                          layers,
+			Rules:                           rules,
+			RuleSets:                        ruleSets,
+		},
+		SystemAnalysis: SystemAnalysisTomlConfig{
+			Enabled:               c.SystemAnalysisEnabled,
+			EnableDependencies:    c.SystemAnalysisEnableDependencies,
+			EnableArchitecture:    c.SystemAnalysisEnableArchitecture,
+			UseComplexityData:     c.SystemAnalysisUseComplexityData,
+			UseClonesData:         c.SystemAnalysisUseClonesData,
+			UseDeadCodeData:       c.SystemAnalysisUseDeadCodeData,
+			GenerateUnifiedReport: c.SystemAnalysisGenerateUnifiedReport,
+		},
+		Dependencies: DependenciesTomlConfig{
+			Enabled:             c.DependenciesEnabled,
+			IncludeStdLib:       c.DependenciesIncludeStdLib,
+			IncludeThirdParty:   c.DependenciesIncludeThirdParty,
+			FollowRelative:      c.DependenciesFollowRelative,
+			DetectCycles:        c.DependenciesDetectCycles,
+			CalculateMetrics:    c.DependenciesCalculateMetrics,
+			FindLongChains:      c.DependenciesFindLongChains,
+			MinCoupling:         &c.DependenciesMinCoupling,
+			MaxCoupling:         &c.DependenciesMaxCoupling,
+			MinInstability:      &c.DependenciesMinInstability,
+			MaxDistance:         &c.DependenciesMaxDistance,
+			SortBy:              c.DependenciesSortBy,
+			ShowMatrix:          c.DependenciesShowMatrix,
+			ShowMetrics:         c.DependenciesShowMetrics,
+			ShowChains:          c.DependenciesShowChains,
+			GenerateDotGraph:    c.DependenciesGenerateDotGraph,
+			CycleReporting:      c.DependenciesCycleReporting,
+			MaxCyclesToShow:     &c.DependenciesMaxCyclesToShow,
+			ShowCyclePaths:      c.DependenciesShowCyclePaths,
+			IncludeTypeChecking: c.DependenciesIncludeTypeChecking,
+			CycleIgnoreEdges:    c.DependenciesCycleIgnoreEdges,
+			FacadeMinExports:    &c.DependenciesFacadeMinExports,
+			CollapseFacades:     c.DependenciesCollapseFacades,
+			IncludePatterns:     c.AnalyzerScopes[domain.AnalysisScopeDependencies].IncludePatterns,
+			ExcludePatterns:     c.AnalyzerScopes[domain.AnalysisScopeDependencies].ExcludePatterns,
+		},
+		Communities: CommunitiesTomlConfig{
+			Enabled:             c.CommunitiesEnabled,
+			Algorithm:           c.CommunitiesAlgorithm,
+			Scope:               c.CommunitiesScope,
+			MinCommunitySize:    &c.CommunitiesMinCommunitySize,
+			IncludeLazyEdges:    c.CommunitiesIncludeLazyEdges,
+			ReportBridgeModules: c.CommunitiesReportBridgeModules,
+			Resolution:          &c.CommunitiesResolution,
+			IncludePatterns:     c.AnalyzerScopes[domain.AnalysisScopeCommunities].IncludePatterns,
+			ExcludePatterns:     c.AnalyzerScopes[domain.AnalysisScopeCommunities].ExcludePatterns,
+		},
+		Clones: ClonesConfig{
+			MinLines:                  c.Analysis.MinLines,
+			MinNodes:                  c.Analysis.MinNodes,
+			MinTokens:                 c.Analysis.MinTokens,
+			MaxEditDistance:           c.Analysis.MaxEditDistance,
+			IgnoreLiterals:            c.Analysis.IgnoreLiterals,
+			IgnoreIdentifiers:         c.Analysis.IgnoreIdentifiers,
+			SkipDocstrings:            c.Analysis.SkipDocstrings,
+			CostModelType:             c.Analysis.CostModelType,
+			Type1Threshold:            c.Thresholds.Type1Threshold,
+			Type2Threshold:            c.Thresholds.Type2Threshold,
+			Type3Threshold:            c.Thresholds.Type3Threshold,
+			Type4Threshold:            c.Thresholds.Type4Threshold,
+			SimilarityThreshold:       c.Thresholds.SimilarityThreshold,
+			EnableDFA:                 c.Analysis.EnableDFA,
+			StringClones:              c.Analysis.StringClones,
+			MinStringLength:           c.Analysis.MinStringLength,
+			StringSimilarityThreshold: c.Analysis.StringSimilarityThreshold,
+			MinSimilarity:             c.Filtering.MinSimilarity,
+			MaxSimilarity:             c.Filtering.MaxSimilarity,
+			EnabledCloneTypes:         c.Filtering.EnabledCloneTypes,
+			MaxResults:                c.Filtering.MaxResults,
+			GroupingMode:              c.Grouping.Mode,
+			GroupingThreshold:         c.Grouping.Threshold,
+			KCoreK:                    c.Grouping.KCoreK,
+			LSHEnabled:                c.LSH.Enabled,
+			LSHAutoThreshold:          c.LSH.AutoThreshold,
+			LSHSimilarityThreshold:    c.LSH.SimilarityThreshold,
+			LSHBands:                  c.LSH.Bands,
+			LSHRows:                   c.LSH.Rows,
+			LSHHashes:                 c.LSH.Hashes,
+			MaxMemoryMB:               c.Performance.MaxMemoryMB,
+			BatchSize:                 c.Performance.BatchSize,
+			EnableBatching:            c.Performance.EnableBatching,
+			MaxGoroutines:             c.Performance.MaxGoroutines,
+			TimeoutSeconds:            c.Performance.TimeoutSeconds,
+			Paths:                     c.Input.Paths,
+			Recursive:                 c.Input.Recursive,
+			IncludePatterns:           c.Input.IncludePatterns,
+			ExcludePatterns:           c.Input.ExcludePatterns,
+			Format:                    c.Output.Format,
+			ShowDetails:               c.Output.ShowDetails,
+			ShowContent:               c.Output.ShowContent,
+			SortBy:                    c.Output.SortBy,
+			GroupClones:               c.Output.GroupClones,
+			MaxGroupMembers:           c.Output.MaxGroupMembers,
+			CollapseSameFile:          c.Output.CollapseSameFile,
+			ReportGroupBy:             c.Output.ReportGroupBy,
+		},
+		MockData: MockDataTomlConfig{
+			Enabled:        c.MockDataEnabled,
+			MinSeverity:    c.MockDataMinSeverity,
+			SortBy:         c.MockDataSortBy,
+			IgnoreTests:    c.MockDataIgnoreTests,
+			Keywords:       c.MockDataKeywords,
+			Domains:        c.MockDataDomains,
+			IgnorePatterns: c.MockDataIgnorePatterns,
+		},
+		DI: DITomlConfig{
+			Enabled:                   c.DIEnabled,
+			MinSeverity:               c.DIMinSeverity,
+			ConstructorParamThreshold: &c.DIConstructorParamThreshold,
+		},
+		Security: SecurityTomlConfig{
+			MinSeverity: c.SecurityMinSeverity,
+			IgnoreTests: c.SecurityIgnoreTests,
+		},
+		Documentation: DocumentationTomlConfig{
+			Enabled:         c.DocumentationEnabled,
+			Convention:      c.DocumentationConvention,
+			IncludePatterns: c.AnalyzerScopes[domain.AnalysisScopeDocumentation].IncludePatterns,
+			ExcludePatterns: c.AnalyzerScopes[domain.AnalysisScopeDocumentation].ExcludePatterns,
+		},
+		Typing: TypingTomlConfig{
+			Enabled:         c.TypingEnabled,
+			IncludePatterns: c.AnalyzerScopes[domain.AnalysisScopeTyping].IncludePatterns,
+			ExcludePatterns: c.AnalyzerScopes[domain.AnalysisScopeTyping].ExcludePatterns,
+		},
+		CustomRules: CustomRulesTomlConfig{
+			Files: c.CustomRulesFiles,
+		},
+	}
+}
+
+// positiveOnlyCloneKeys are [clones] keys whose values are only applied
+// when positive; zero or negative values silently keep the default
+var positiveOnlyCloneKeys = []string{
+	"min_lines", "min_nodes", "max_edit_distance",
+	"type1_threshold", "type2_threshold", "type3_threshold", "type4_threshold", "similarity_threshold",
+	"max_similarity", "max_results", "grouping_threshold", "k_core_k",
+	"lsh_auto_threshold", "lsh_similarity_threshold", "lsh_bands", "lsh_rows", "lsh_hashes",
+	"max_memory_mb", "batch_size", "max_goroutines", "timeout_seconds",
+}
+
+var validatorKeyPattern = regexp.MustCompile(`[a-z_]+\.[a-z0-9_]+`)
+
+// checkEffectiveConfig reports out-of-range values and conflicting options
+// in the merged configuration. fileTable is the pyscn section as written,
+// used to tell explicit settings from defaults.
+func checkEffectiveConfig(cfg *PyscnConfig, fileTable map[string]interface{}) []ConfigIssue {
+	var issues []ConfigIssue
+	addError := func(key, format string, args ...interface{}) {
+		issues = append(issues, ConfigIssue{Severity: IssueError, Key: key, Message: fmt.Sprintf(format, args...)})
+	}
+	addWarning := func(key, format string, args ...interface{}) {
+		issues = append(issues, ConfigIssue{Severity: IssueWarning, Key: key, Message: fmt.Sprintf(format, args...)})
+	}
+
+	// The analyzer-facing validators stop at the first problem; each one
+	// contributes at most one error
+	if err := PyscnConfigToConfig(cfg).Validate(); err != nil {
+		addError(validatorKeyPattern.FindString(err.Error()), "%s", err.Error())
+	}
+	if err := cfg.Validate(); err != nil {
+		addError("clones", "clones: %s", err.Error())
+	}
+
+	if cfg.CboLowThreshold < 0 || cfg.CboMediumThreshold <= cfg.CboLowThreshold {
+		addError("cbo.medium_threshold", "cbo.medium_threshold (%d) must be > cbo.low_threshold (%d) and thresholds must be >= 0",
+			cfg.CboMediumThreshold, cfg.CboLowThreshold)
+	}
+	if cfg.CboMaxCbo > 0 && cfg.CboMaxCbo < cfg.CboMinCbo {
+		addError("cbo.max_cbo", "cbo.max_cbo (%d) must be >= cbo.min_cbo (%d) or 0 for no limit", cfg.CboMaxCbo, cfg.CboMinCbo)
+	}
+	for _, kind := range []struct {
+		key   string
+		limit int
+	}{
+		{"cbo.max_inheritance", cfg.CboMaxInheritance},
+		{"cbo.max_composition", cfg.CboMaxComposition},
+		{"cbo.max_instantiation", cfg.CboMaxInstantiation},
+		{"cbo.max_method_calls", cfg.CboMaxMethodCalls},
+		{"cbo.max_type_hints", cfg.CboMaxTypeHints},
+	} {
+		if kind.limit < 0 {
+			addError(kind.key, "%s (%d) must be >= 0, 0 for no limit", kind.key, kind.limit)
+		}
+	}
+	if cfg.LcomLowThreshold < 0 || cfg.LcomMediumThreshold <= cfg.LcomLowThreshold {
+		addError("lcom.medium_threshold", "lcom.medium_threshold (%d) must be > lcom.low_threshold (%d) and thresholds must be >= 0",
+			cfg.LcomMediumThreshold, cfg.LcomLowThreshold)
+	}
+	if cfg.DependenciesMaxCoupling > 0 && cfg.DependenciesMaxCoupling < cfg.DependenciesMinCoupling {
+		addError("dependencies.max_coupling", "dependencies.max_coupling (%d) must be >= dependencies.min_coupling (%d)",
+			cfg.DependenciesMaxCoupling, cfg.DependenciesMinCoupling)
+	}
+	if cfg.ArchitectureMinCohesion < 0 || cfg.ArchitectureMinCohesion > 1 {
+		addError("architecture.min_cohesion", "architecture.min_cohesion must be between 0.0 and 1.0, got %g", cfg.ArchitectureMinCohesion)
+	}
+	for _, limit := range []struct {
+		key   string
+		value int
+	}{
+		{"architecture.god_module_max_lines", cfg.ArchitectureGodModuleMaxLines},
+		{"architecture.god_module_max_definitions", cfg.ArchitectureGodModuleMaxDefinitions},
+		{"architecture.god_module_max_fan_in", cfg.ArchitectureGodModuleMaxFanIn},
+		{"architecture.god_module_max_fan_out", cfg.ArchitectureGodModuleMaxFanOut},
+	} {
+		if limit.value < 0 {
+			addError(limit.key, "%s (%d) must be >= 0, 0 for the default", limit.key, limit.value)
+		}
+	}
+
+	checkBudgets := func(key string, rules []LayerRule) {
+		for i, rule := range rules {
+			if rule.Budget < 0 {
+				ruleKey := fmt.Sprintf("%s[%d].budget", key, i)
+				addError(ruleKey, "%s (%d) must be >= 0", ruleKey, rule.Budget)
+			}
+		}
+	}
+	checkBudgets("architecture.rules", cfg.ArchitectureRules)
+
+	ruleSetNames := make(map[string]bool, len(cfg.ArchitectureRuleSets))
+	for i, rs := range cfg.ArchitectureRuleSets {
+		key := fmt.Sprintf("architecture.rule_sets[%d]", i)
+		switch {
+		case rs.Name == "":
+			addError(key+".name", "%s.name must not be empty", key)
+		case ruleSetNames[rs.Name]:
+			addError(key+".name", "architecture rule set name %q is used more than once", rs.Name)
+		}
+		ruleSetNames[rs.Name] = true
+		if len(rs.Paths) == 0 {
+			addError(key+".paths", "%s.paths must list at least one directory", key)
+		}
+		if len(rs.Layers) == 0 {
+			addError(key+".layers", "%s.layers must define at least one layer", key)
+		}
+		checkBudgets(key+".rules", rs.Rules)
+	}
+
+	switch cfg.Grouping.Mode {
+	case "connected", "star", "complete_linkage", "k_core", "centroid":
+	default:
+		addError("clones.grouping_mode", "invalid clones.grouping_mode '%s', must be one of: connected, star, complete_linkage, k_core, centroid", cfg.Grouping.Mode)
+	}
+	switch strings.ToLower(cfg.LSH.Enabled) {
+	case "true", "false", "auto":
+	default:
+		addError("clones.lsh_enabled", "invalid clones.lsh_enabled '%s', must be one of: true, false, auto", cfg.LSH.Enabled)
+	}
+	if cfg.LSH.Bands*cfg.LSH.Rows > cfg.LSH.Hashes {
+		addError("clones.lsh_hashes", "clones.lsh_bands × clones.lsh_rows (%d) exceeds clones.lsh_hashes (%d)",
+			cfg.LSH.Bands*cfg.LSH.Rows, cfg.LSH.Hashes)
+	}
+
+	clones := nestedTable(fileTable, "clones")
+	for _, key := range positiveOnlyCloneKeys {
+		if value, ok := clones[key]; ok && !isPositiveNumber(value) {
+			addWarning("clones."+key, "clones.%s = %v is ignored; only values > 0 are applied, so the default is used", key, value)
+		}
+	}
+
+	complexity := nestedTable(fileTable, "complexity")
+	output := nestedTable(fileTable, "output")
+	if _, ok := complexity["min_complexity"]; ok {
+		if _, ok := output["min_complexity"]; ok && cfg.ComplexityMinComplexity != cfg.OutputMinComplexity {
+			addWarning("complexity.min_complexity", "output.min_complexity (%d) overrides complexity.min_complexity (%d)",
+				cfg.OutputMinComplexity, cfg.ComplexityMinComplexity)
+		}
+	}
+
+	architecture := nestedTable(fileTable, "architecture")
+	if cfg.ArchitectureEnabled != nil && !*cfg.ArchitectureEnabled {
+		if _, hasLayers := architecture["layers"]; hasLayers {
+			addWarning("architecture.layers", "architecture.layers are defined but architecture.enabled = false, so they are not validated")
+		}
+	}
+
+	excluded := make(map[string]bool, len(cfg.AnalysisExcludePatterns))
+	for _, pattern := range cfg.AnalysisExcludePatterns {
+		excluded[pattern] = true
+	}
+	for _, pattern := range cfg.AnalysisIncludePatterns {
+		if excluded[pattern] {
+			addWarning("analysis.exclude_patterns", "pattern %q is both included and excluded; exclusion wins", pattern)
+		}
+	}
+
+	return issues
+}
+
+func isPositiveNumber(value interface{}) bool {
+	switch v := value.(type) {
+	case int64:
+		return v > 0
+	case float64:
+		return v > 0
+	case int:
+		return v > 0
+	}
+	return true
+}
@@ -0,0 +1,270 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func writeInspectFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func findIssue(inspection *ConfigInspection, key string) *ConfigIssue {
+	for i := range inspection.Issues {
+		if inspection.Issues[i].Key == key {
+			return &inspection.Issues[i]
+		}
+	}
+	return nil
+}
+
+func findSetting(inspection *ConfigInspection, key string) *EffectiveSetting {
+	for i := range inspection.Settings {
+		if inspection.Settings[i].Key == key {
+			return &inspection.Settings[i]
+		}
+	}
+	return nil
+}
+
+func TestInspectConfig_DefaultTemplateIsClean(t *testing.T) {
+	content, err := GenerateDefaultConfigTOML()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inspection, err := InspectConfig(writeInspectFile(t, ".pyscn.toml", content), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inspection.Issues) != 0 {
+		t.Errorf("generated config should validate cleanly, got %+v", inspection.Issues)
+	}
+}
+
+func TestInspectConfig_Issues(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		key      string
+		severity IssueSeverity
+		contains string
+	}{
+		{
+			name:     "unknown key with suggestion",
+			content:  "[complexity]\nlow_treshold = 5\n",
+			key:      "complexity.low_treshold",
+			severity: IssueError,
+			contains: "did you mean complexity.low_threshold",
+		},
+		{
+			name:     "unknown section",
+			content:  "[complexityy]\nenabled = true\n",
+			key:      "complexityy",
+			severity: IssueError,
+			contains: "did you mean complexity",
+		},
+		{
+			name:     "unknown key in array of tables",
+			content:  "[[architecture.layers]]\nname = \"api\"\npackage = [\"api\"]\n",
+			key:      "architecture.layers[0].package",
+			severity: IssueError,
+			contains: "did you mean architecture.layers[0].packages",
+		},
+		{
+			name:     "wrong type",
+			content:  "[complexity]\nmedium_threshold = \"high\"\n",
+			key:      "complexity.medium_threshold",
+			severity: IssueError,
+			contains: "cannot decode",
+		},
+		{
+			name:     "out of range",
+			content:  "[complexity]\nlow_threshold = 30\nmedium_threshold = 20\n",
+			key:      "complexity.medium_threshold",
+			severity: IssueError,
+			contains: "must be > low_threshold",
+		},
+		{
+			name:     "conflicting cbo thresholds",
+			content:  "[cbo]\nlow_threshold = 10\nmedium_threshold = 5\n",
+			key:      "cbo.medium_threshold",
+			severity: IssueError,
+			contains: "must be >",
+		},
+		{
+			name:     "invalid grouping mode",
+			content:  "[clones]\ngrouping_mode = \"clustered\"\n",
+			key:      "clones.grouping_mode",
+			severity: IssueError,
+			contains: "must be one of",
+		},
+		{
+			name:     "ignored zero value",
+			content:  "[clones]\nmin_lines = 0\n",
+			key:      "clones.min_lines",
+			severity: IssueWarning,
+			contains: "is ignored",
+		},
+		{
+			name:     "min_complexity overridden by output",
+			content:  "[complexity]\nmin_complexity = 3\n[output]\nmin_complexity = 5\n",
+			key:      "complexity.min_complexity",
+			severity: IssueWarning,
+			contains: "overrides",
+		},
+		{
+			name:     "layers with architecture disabled",
+			content:  "[architecture]\nenabled = false\n[[architecture.layers]]\nname = \"api\"\npackages = [\"api\"]\n",
+			key:      "architecture.layers",
+			severity: IssueWarning,
+			contains: "architecture.enabled = false",
+		},
+		{
+			name:     "rule set without paths",
+			content:  "[[architecture.rule_sets]]\nname = \"services\"\n[[architecture.rule_sets.layers]]\nname = \"api\"\npackages = [\"api\"]\n",
+			key:      "architecture.rule_sets[0].paths",
+			severity: IssueError,
+			contains: "at least one directory",
+		},
+		{
+			name:     "negative rule budget",
+			content:  "[[architecture.rules]]\nfrom = \"domain\"\nallow = [\"domain\"]\nbudget = -1\n",
+			key:      "architecture.rules[0].budget",
+			severity: IssueError,
+			contains: "must be >= 0",
+		},
+		{
+			name:     "unsupported legacy key",
+			content:  "[cbo]\nenabled = false\n",
+			key:      "cbo.enabled",
+			severity: IssueWarning,
+			contains: "has no effect",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inspection, err := InspectConfig(writeInspectFile(t, ".pyscn.toml", tt.content), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			issue := findIssue(inspection, tt.key)
+			if issue == nil {
+				t.Fatalf("expected issue for %s, got %+v", tt.key, inspection.Issues)
+			}
+			if issue.Severity != tt.severity {
+				t.Errorf("expected severity %s, got %s", tt.severity, issue.Severity)
+			}
+			if !strings.Contains(issue.Message, tt.contains) {
+				t.Errorf("expected message containing %q, got %q", tt.contains, issue.Message)
+			}
+		})
+	}
+}
+
+func TestInspectConfig_SyntaxErrorReportsLine(t *testing.T) {
+	inspection, err := InspectConfig(writeInspectFile(t, ".pyscn.toml", "[output]\nformat = \"json\"\n[complexity\n"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !inspection.HasErrors() || inspection.Issues[0].Line != 3 {
+		t.Fatalf("expected syntax error on line 3, got %+v", inspection.Issues)
+	}
+	if len(inspection.Settings) != 0 {
+		t.Error("no effective settings should be reported for an unparsable file")
+	}
+}
+
+func TestInspectConfig_Provenance(t *testing.T) {
+	path := writeInspectFile(t, ".pyscn.toml", "[complexity]\nlow_threshold = 5\n[output]\nmin_complexity = 3\n")
+
+	inspection, err := InspectConfig(path, map[string]interface{}{"output.min_complexity": 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inspection.HasErrors() {
+		t.Fatalf("unexpected issues: %+v", inspection.Issues)
+	}
+
+	expected := []struct {
+		key    string
+		value  interface{}
+		source ConfigSource
+	}{
+		{"complexity.low_threshold", int64(5), ConfigSourceFile},
+		{"output.min_complexity", int64(8), ConfigSourceFlag},
+		{"complexity.medium_threshold", int64(DefaultMediumComplexityThreshold), ConfigSourceDefault},
+	}
+	for _, e := range expected {
+		setting := findSetting(inspection, e.key)
+		if setting == nil {
+			t.Fatalf("missing setting %s", e.key)
+		}
+		if setting.Value != e.value || setting.Source != e.source {
+			t.Errorf("%s: expected %v from %s, got %v from %s", e.key, e.value, e.source, setting.Value, setting.Source)
+		}
+	}
+}
+
+func TestInspectConfig_Pyproject(t *testing.T) {
+	path := writeInspectFile(t, "pyproject.toml", `
+[project]
+name = "demo"
+
+[tool.pyscn.complexity]
+low_threshold = 4
+
+[tool.pyscn.mock_data]
+enabled = true
+`)
+
+	inspection, err := InspectConfig(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issue := findIssue(inspection, "mock_data"); issue == nil || issue.Severity != IssueError {
+		t.Errorf("mock_data is not read from pyproject.toml and should be reported, got %+v", inspection.Issues)
+	}
+	if setting := findSetting(inspection, "complexity.low_threshold"); setting == nil || setting.Source != ConfigSourceFile {
+		t.Errorf("expected complexity.low_threshold from file, got %+v", setting)
+	}
+}
+
+func TestInspectConfig_NoFile(t *testing.T) {
+	inspection, err := InspectConfig("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inspection.Issues) != 0 {
+		t.Errorf("defaults should be valid, got %+v", inspection.Issues)
+	}
+	for _, setting := range inspection.Settings {
+		if setting.Source != ConfigSourceDefault {
+			t.Errorf("%s: expected default source, got %s", setting.Key, setting.Source)
+		}
+	}
+}
+
+func TestConfigKeys(t *testing.T) {
+	keys := ConfigKeys()
+	for _, want := range []string{"clones.min_lines", "output.directory", "dependencies.collapse_facades", "architecture.layers"} {
+		if !slices.Contains(keys, want) {
+			t.Errorf("expected %s among the configuration keys", want)
+		}
+	}
+	if slices.Contains(keys, "architecture.layers.name") {
+		t.Error("expected arrays of tables to be listed as one key")
+	}
+	if !slices.IsSorted(keys) {
+		t.Error("expected the keys to be sorted")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/ludo-technologies/pyscn/domain"
 	"github.com/pelletier/go-toml/v2"
 )
 
@@ -31,6 +32,10 @@ type PyprojectPyscnSection struct {
 	Communities    CommunitiesTomlConfig    `toml:"communities"`
 	Clones         ClonesConfig             `toml:"clones"`
 	DI             DITomlConfig             `toml:"di"`
+	Security       SecurityTomlConfig       `toml:"security"`
+	Documentation  DocumentationTomlConfig  `toml:"documentation"`
+	Typing         TypingTomlConfig         `toml:"typing"`
+	CustomRules    CustomRulesTomlConfig    `toml:"custom_rules"`
 }
 
 // LoadPyprojectConfig loads pyscn configuration from pyproject.toml
@@ -82,6 +87,10 @@ func loadPyprojectConfigData(data []byte) (*PyscnConfig, error) {
 	mergeCommunitiesSection(config, &pyproject.Tool.Pyscn.Communities)
 	mergeClonesSection(config, &pyproject.Tool.Pyscn.Clones)
 	mergeDISection(config, &pyproject.Tool.Pyscn.DI)
+	mergeSecuritySection(config, &pyproject.Tool.Pyscn.Security)
+	mergeDocumentationSection(config, &pyproject.Tool.Pyscn.Documentation)
+	mergeTypingSection(config, &pyproject.Tool.Pyscn.Typing)
+	mergeCustomRulesSection(config, &pyproject.Tool.Pyscn.CustomRules)
 
 	return config, nil
 }
@@ -113,6 +122,19 @@ func mergeComplexitySection(defaults *PyscnConfig, complexity *ComplexityTomlCon
 	if complexity.MinComplexity != nil {
 		defaults.ComplexityMinComplexity = *complexity.MinComplexity
 	}
+	if len(complexity.Boilerplate) > 0 {
+		defaults.ComplexityBoilerplate = boilerplatePatternsFromToml(complexity.Boilerplate)
+	}
+	if complexity.ComprehensionWeight != nil {
+		defaults.ComplexityComprehensionWeight = complexity.ComprehensionWeight
+	}
+	if complexity.GeneratorWeight != nil {
+		defaults.ComplexityGeneratorWeight = complexity.GeneratorWeight
+	}
+	if complexity.BooleanOperatorWeight != nil {
+		defaults.ComplexityBooleanOperatorWeight = complexity.BooleanOperatorWeight
+	}
+	defaults.setAnalyzerScope(domain.AnalysisScopeComplexity, complexity.IncludePatterns, complexity.ExcludePatterns)
 }
 
 // mergeClonesSection merges settings from the [clones] section
@@ -125,6 +147,9 @@ func mergeClonesSection(defaults *PyscnConfig, clones *ClonesConfig) {
 	if clones.MinNodes > 0 {
 		defaults.Analysis.MinNodes = clones.MinNodes
 	}
+	if clones.MinTokens != 0 {
+		defaults.Analysis.MinTokens = clones.MinTokens
+	}
 	if clones.MaxEditDistance > 0 {
 		defaults.Analysis.MaxEditDistance = clones.MaxEditDistance
 	}
@@ -143,6 +168,15 @@ func mergeClonesSection(defaults *PyscnConfig, clones *ClonesConfig) {
 	if clones.EnableDFA != nil {
 		defaults.Analysis.EnableDFA = clones.EnableDFA
 	}
+	if clones.StringClones != nil {
+		defaults.Analysis.StringClones = clones.StringClones
+	}
+	if clones.MinStringLength > 0 {
+		defaults.Analysis.MinStringLength = clones.MinStringLength
+	}
+	if clones.StringSimilarityThreshold > 0 {
+		defaults.Analysis.StringSimilarityThreshold = clones.StringSimilarityThreshold
+	}
 
 	// Thresholds
 	if clones.Type1Threshold > 0 {
@@ -233,7 +267,7 @@ func mergeClonesSection(defaults *PyscnConfig, clones *ClonesConfig) {
 	if len(clones.IncludePatterns) > 0 {
 		defaults.Input.IncludePatterns = clones.IncludePatterns
 	}
-	if len(clones.ExcludePatterns) > 0 {
+	if clones.ExcludePatterns != nil {
 		defaults.Input.ExcludePatterns = clones.ExcludePatterns
 	}
 
@@ -250,9 +284,19 @@ func mergeClonesSection(defaults *PyscnConfig, clones *ClonesConfig) {
 	if clones.GroupClones != nil {
 		defaults.Output.GroupClones = clones.GroupClones
 	}
+	if clones.MaxGroupMembers > 0 {
+		defaults.Output.MaxGroupMembers = clones.MaxGroupMembers
+	}
+	if clones.CollapseSameFile != nil {
+		defaults.Output.CollapseSameFile = clones.CollapseSameFile
+	}
+	if clones.ReportGroupBy != "" {
+		defaults.Output.ReportGroupBy = clones.ReportGroupBy
+	}
 	if clones.Format != "" {
 		defaults.Output.Format = clones.Format
 	}
+	defaults.setAnalyzerScope(domain.AnalysisScopeClones, clones.IncludePatterns, clones.ExcludePatterns)
 }
 
 // mergeDeadCodeSection merges settings from the [dead_code] section
@@ -290,6 +334,13 @@ func mergeDeadCodeSection(defaults *PyscnConfig, deadCode *DeadCodeTomlConfig) {
 	if len(deadCode.IgnorePatterns) > 0 {
 		defaults.DeadCodeIgnorePatterns = deadCode.IgnorePatterns
 	}
+	if len(deadCode.Severities) > 0 {
+		defaults.DeadCodeSeverities = deadCode.Severities
+	}
+	if len(deadCode.TerminatingCalls) > 0 {
+		defaults.DeadCodeTerminatingCalls = deadCode.TerminatingCalls
+	}
+	defaults.setAnalyzerScope(domain.AnalysisScopeDeadCode, deadCode.IncludePatterns, deadCode.ExcludePatterns)
 }
 
 // mergeOutputSection merges settings from the [output] section
@@ -309,6 +360,27 @@ func mergeOutputSection(defaults *PyscnConfig, output *OutputTomlConfig) {
 	if output.Directory != "" {
 		defaults.OutputDirectory = output.Directory
 	}
+	if output.LinkTemplate != "" {
+		defaults.OutputLinkTemplate = output.LinkTemplate
+	}
+	if output.Lang != "" {
+		defaults.OutputLang = output.Lang
+	}
+	if output.MetricsFile != "" {
+		defaults.OutputMetricsFile = output.MetricsFile
+	}
+	if output.MetricsPushgateway != "" {
+		defaults.OutputMetricsPushgateway = output.MetricsPushgateway
+	}
+	if output.MetricsJob != "" {
+		defaults.OutputMetricsJob = output.MetricsJob
+	}
+	if output.HistoryRuns != nil {
+		defaults.OutputHistoryRuns = *output.HistoryRuns
+	}
+	if output.AbsolutePaths != nil {
+		defaults.OutputAbsolutePaths = output.AbsolutePaths
+	}
 }
 
 // mergeAnalysisSection merges settings from the [analysis] section
@@ -326,6 +398,15 @@ func mergeAnalysisSection(defaults *PyscnConfig, analysis *AnalysisTomlConfig) {
 	if analysis.FollowSymlinks != nil {
 		defaults.AnalysisFollowSymlinks = analysis.FollowSymlinks
 	}
+	if analysis.MaxDepth != nil {
+		defaults.AnalysisMaxDepth = *analysis.MaxDepth
+	}
+	if analysis.IncludeVendored != nil {
+		defaults.AnalysisIncludeVendored = analysis.IncludeVendored
+	}
+	if analysis.Serial != nil {
+		defaults.AnalysisSerial = analysis.Serial
+	}
 }
 
 // mergeCboSection merges settings from the [cbo] section
@@ -354,6 +435,25 @@ func mergeCboSection(defaults *PyscnConfig, cbo *CboTomlConfig) {
 	if cbo.GroupNamespaceImports != nil {
 		defaults.CboGroupNamespaceImports = cbo.GroupNamespaceImports
 	}
+	if cbo.IncludeTypeHints != nil {
+		defaults.CboIncludeTypeHints = cbo.IncludeTypeHints
+	}
+	if cbo.MaxInheritance != nil {
+		defaults.CboMaxInheritance = *cbo.MaxInheritance
+	}
+	if cbo.MaxComposition != nil {
+		defaults.CboMaxComposition = *cbo.MaxComposition
+	}
+	if cbo.MaxInstantiation != nil {
+		defaults.CboMaxInstantiation = *cbo.MaxInstantiation
+	}
+	if cbo.MaxMethodCalls != nil {
+		defaults.CboMaxMethodCalls = *cbo.MaxMethodCalls
+	}
+	if cbo.MaxTypeHints != nil {
+		defaults.CboMaxTypeHints = *cbo.MaxTypeHints
+	}
+	defaults.setAnalyzerScope(domain.AnalysisScopeCBO, cbo.IncludePatterns, cbo.ExcludePatterns)
 }
 
 // mergeLcomSection merges settings from the [lcom] section
@@ -364,6 +464,7 @@ func mergeLcomSection(defaults *PyscnConfig, lcom *LcomTomlConfig) {
 	if lcom.MediumThreshold != nil {
 		defaults.LcomMediumThreshold = *lcom.MediumThreshold
 	}
+	defaults.setAnalyzerScope(domain.AnalysisScopeLCOM, lcom.IncludePatterns, lcom.ExcludePatterns)
 }
 
 // mergeArchitectureSection merges settings from the [architecture] section
@@ -398,6 +499,21 @@ func mergeArchitectureSection(defaults *PyscnConfig, arch *ArchitectureTomlConfi
 	if arch.ResponsibilityViolationSeverity != "" {
 		defaults.ArchitectureResponsibilityViolationSeverity = arch.ResponsibilityViolationSeverity
 	}
+	if arch.ValidateGodModules != nil {
+		defaults.ArchitectureValidateGodModules = arch.ValidateGodModules
+	}
+	if arch.GodModuleMaxLines != nil {
+		defaults.ArchitectureGodModuleMaxLines = *arch.GodModuleMaxLines
+	}
+	if arch.GodModuleMaxDefinitions != nil {
+		defaults.ArchitectureGodModuleMaxDefinitions = *arch.GodModuleMaxDefinitions
+	}
+	if arch.GodModuleMaxFanIn != nil {
+		defaults.ArchitectureGodModuleMaxFanIn = *arch.GodModuleMaxFanIn
+	}
+	if arch.GodModuleMaxFanOut != nil {
+		defaults.ArchitectureGodModuleMaxFanOut = *arch.GodModuleMaxFanOut
+	}
 	if arch.ShowAllViolations != nil {
 		defaults.ArchitectureShowAllViolations = arch.ShowAllViolations
 	}
@@ -428,6 +544,12 @@ func mergeArchitectureSection(defaults *PyscnConfig, arch *ArchitectureTomlConfi
 	if len(arch.NeutralPrefixes) > 0 {
 		defaults.ArchitectureNeutralPrefixes = arch.NeutralPrefixes
 	}
+	if len(arch.IgnoreEdges) > 0 {
+		defaults.ArchitectureIgnoreEdges = arch.IgnoreEdges
+	}
+	if len(arch.DowngradeEdges) > 0 {
+		defaults.ArchitectureDowngradeEdges = arch.DowngradeEdges
+	}
 	if arch.Style != "" {
 		defaults.ArchitectureStyle = arch.Style
 	}
@@ -452,6 +574,32 @@ func mergeArchitectureSection(defaults *PyscnConfig, arch *ArchitectureTomlConfi
 		}
 		defaults.ArchitectureRules = rules
 	}
+	if len(arch.RuleSets) > 0 {
+		ruleSets := make([]ArchitectureRuleSet, len(arch.RuleSets))
+		for i, rs := range arch.RuleSets {
+			ruleSets[i] = ArchitectureRuleSet{
+				Name:   rs.Name,
+				Paths:  rs.Paths,
+				Layers: make([]LayerDefinition, len(rs.Layers)),
+				Rules:  make([]LayerRule, len(rs.Rules)),
+			}
+			if rs.StrictMode != nil {
+				ruleSets[i].StrictMode = *rs.StrictMode
+			}
+			for j, l := range rs.Layers {
+				ruleSets[i].Layers[j] = LayerDefinition{
+					Name:        l.Name,
+					Description: l.Description,
+					Packages:    l.Packages,
+					IsAbstract:  l.IsAbstract,
+				}
+			}
+			for j, r := range rs.Rules {
+				ruleSets[i].Rules[j] = LayerRule(r)
+			}
+		}
+		defaults.ArchitectureRuleSets = ruleSets
+	}
 }
 
 // mergeSystemAnalysisSection merges settings from the [system_analysis] section
@@ -502,6 +650,7 @@ func mergeCommunitiesSection(defaults *PyscnConfig, communities *CommunitiesToml
 	if communities.Resolution != nil {
 		defaults.CommunitiesResolution = *communities.Resolution
 	}
+	defaults.setAnalyzerScope(domain.AnalysisScopeCommunities, communities.IncludePatterns, communities.ExcludePatterns)
 }
 
 // mergeDependenciesSection merges settings from the [dependencies] section
@@ -557,12 +706,26 @@ func mergeDependenciesSection(defaults *PyscnConfig, dep *DependenciesTomlConfig
 	if dep.CycleReporting != "" {
 		defaults.DependenciesCycleReporting = dep.CycleReporting
 	}
+	if dep.IncludeTypeChecking != nil {
+		defaults.DependenciesIncludeTypeChecking = dep.IncludeTypeChecking
+	}
+	if dep.CycleIgnoreEdges != nil {
+		// An explicit empty list disables the default ignored edge kinds
+		defaults.DependenciesCycleIgnoreEdges = dep.CycleIgnoreEdges
+	}
+	if dep.FacadeMinExports != nil {
+		defaults.DependenciesFacadeMinExports = *dep.FacadeMinExports
+	}
+	if dep.CollapseFacades != nil {
+		defaults.DependenciesCollapseFacades = dep.CollapseFacades
+	}
 	if dep.MaxCyclesToShow != nil {
 		defaults.DependenciesMaxCyclesToShow = *dep.MaxCyclesToShow
 	}
 	if dep.ShowCyclePaths != nil {
 		defaults.DependenciesShowCyclePaths = dep.ShowCyclePaths
 	}
+	defaults.setAnalyzerScope(domain.AnalysisScopeDependencies, dep.IncludePatterns, dep.ExcludePatterns)
 }
 
 // mergeMockDataSection merges settings from the [mock_data] section
@@ -603,6 +766,42 @@ func mergeDISection(defaults *PyscnConfig, di *DITomlConfig) {
 	}
 }
 
+// mergeSecuritySection merges settings from the [security] section.
+func mergeSecuritySection(defaults *PyscnConfig, security *SecurityTomlConfig) {
+	if security.MinSeverity != "" {
+		defaults.SecurityMinSeverity = security.MinSeverity
+	}
+	if security.IgnoreTests != nil {
+		defaults.SecurityIgnoreTests = security.IgnoreTests
+	}
+}
+
+// mergeDocumentationSection merges settings from the [documentation] section.
+func mergeDocumentationSection(defaults *PyscnConfig, documentation *DocumentationTomlConfig) {
+	if documentation.Enabled != nil {
+		defaults.DocumentationEnabled = documentation.Enabled
+	}
+	if documentation.Convention != "" {
+		defaults.DocumentationConvention = documentation.Convention
+	}
+	defaults.setAnalyzerScope(domain.AnalysisScopeDocumentation, documentation.IncludePatterns, documentation.ExcludePatterns)
+}
+
+// mergeTypingSection merges settings from the [typing] section.
+func mergeTypingSection(defaults *PyscnConfig, typing *TypingTomlConfig) {
+	if typing.Enabled != nil {
+		defaults.TypingEnabled = typing.Enabled
+	}
+	defaults.setAnalyzerScope(domain.AnalysisScopeTyping, typing.IncludePatterns, typing.ExcludePatterns)
+}
+
+// mergeCustomRulesSection merges settings from the [custom_rules] section.
+func mergeCustomRulesSection(defaults *PyscnConfig, customRules *CustomRulesTomlConfig) {
+	if len(customRules.Files) > 0 {
+		defaults.CustomRulesFiles = customRules.Files
+	}
+}
+
 // findPyprojectToml walks up the directory tree to find pyproject.toml
 func findPyprojectToml(startDir string) (string, error) {
 	dir, err := normalizeSearchDir(startDir)
@@ -63,6 +63,9 @@ medium_threshold = 5
 [tool.pyscn.clones]
 min_lines = 10
 min_nodes = 20
+min_tokens = 15
+string_clones = true
+min_string_length = 120
 `
 	configPath := filepath.Join(tempDir, "pyproject.toml")
 	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
@@ -90,6 +93,15 @@ min_nodes = 20
 	if config.Analysis.MinNodes != 20 {
 		t.Errorf("Expected min_nodes 20, got %d", config.Analysis.MinNodes)
 	}
+	if config.Analysis.MinTokens != 15 {
+		t.Errorf("Expected min_tokens 15, got %d", config.Analysis.MinTokens)
+	}
+	if config.Analysis.StringClones == nil || !*config.Analysis.StringClones {
+		t.Error("Expected string_clones to be enabled")
+	}
+	if config.Analysis.MinStringLength != 120 {
+		t.Errorf("Expected min_string_length 120, got %d", config.Analysis.MinStringLength)
+	}
 }
 
 func TestLoadArchitectureLayersAndRulesFromPyprojectToml(t *testing.T) {
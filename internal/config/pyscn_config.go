@@ -44,18 +44,30 @@ type PyscnConfig struct {
 	ComplexityMaxComplexity      int   `mapstructure:"complexity_max_complexity" yaml:"complexity_max_complexity" json:"complexity_max_complexity"`
 	ComplexityMinComplexity      int   `mapstructure:"complexity_min_complexity" yaml:"complexity_min_complexity" json:"complexity_min_complexity"`
 
+	// ComplexityBoilerplate tag the functions they match as boilerplate
+	ComplexityBoilerplate []domain.BoilerplatePattern `mapstructure:"complexity_boilerplate" yaml:"complexity_boilerplate" json:"complexity_boilerplate"`
+
+	// Weights of comprehension clauses, generator expression clauses and
+	// boolean operator sequences in cyclomatic complexity (nil = as the
+	// control flow graph counts them)
+	ComplexityComprehensionWeight   *float64 `mapstructure:"complexity_comprehension_weight" yaml:"complexity_comprehension_weight" json:"complexity_comprehension_weight"`
+	ComplexityGeneratorWeight       *float64 `mapstructure:"complexity_generator_weight" yaml:"complexity_generator_weight" json:"complexity_generator_weight"`
+	ComplexityBooleanOperatorWeight *float64 `mapstructure:"complexity_boolean_operator_weight" yaml:"complexity_boolean_operator_weight" json:"complexity_boolean_operator_weight"`
+
 	// DeadCode Configuration (from [dead_code] section in TOML)
-	DeadCodeEnabled                   *bool    `mapstructure:"dead_code_enabled" yaml:"dead_code_enabled" json:"dead_code_enabled"`
-	DeadCodeMinSeverity               string   `mapstructure:"dead_code_min_severity" yaml:"dead_code_min_severity" json:"dead_code_min_severity"`
-	DeadCodeShowContext               *bool    `mapstructure:"dead_code_show_context" yaml:"dead_code_show_context" json:"dead_code_show_context"`
-	DeadCodeContextLines              int      `mapstructure:"dead_code_context_lines" yaml:"dead_code_context_lines" json:"dead_code_context_lines"`
-	DeadCodeSortBy                    string   `mapstructure:"dead_code_sort_by" yaml:"dead_code_sort_by" json:"dead_code_sort_by"`
-	DeadCodeDetectAfterReturn         *bool    `mapstructure:"dead_code_detect_after_return" yaml:"dead_code_detect_after_return" json:"dead_code_detect_after_return"`
-	DeadCodeDetectAfterBreak          *bool    `mapstructure:"dead_code_detect_after_break" yaml:"dead_code_detect_after_break" json:"dead_code_detect_after_break"`
-	DeadCodeDetectAfterContinue       *bool    `mapstructure:"dead_code_detect_after_continue" yaml:"dead_code_detect_after_continue" json:"dead_code_detect_after_continue"`
-	DeadCodeDetectAfterRaise          *bool    `mapstructure:"dead_code_detect_after_raise" yaml:"dead_code_detect_after_raise" json:"dead_code_detect_after_raise"`
-	DeadCodeDetectUnreachableBranches *bool    `mapstructure:"dead_code_detect_unreachable_branches" yaml:"dead_code_detect_unreachable_branches" json:"dead_code_detect_unreachable_branches"`
-	DeadCodeIgnorePatterns            []string `mapstructure:"dead_code_ignore_patterns" yaml:"dead_code_ignore_patterns" json:"dead_code_ignore_patterns"`
+	DeadCodeEnabled                   *bool             `mapstructure:"dead_code_enabled" yaml:"dead_code_enabled" json:"dead_code_enabled"`
+	DeadCodeMinSeverity               string            `mapstructure:"dead_code_min_severity" yaml:"dead_code_min_severity" json:"dead_code_min_severity"`
+	DeadCodeShowContext               *bool             `mapstructure:"dead_code_show_context" yaml:"dead_code_show_context" json:"dead_code_show_context"`
+	DeadCodeContextLines              int               `mapstructure:"dead_code_context_lines" yaml:"dead_code_context_lines" json:"dead_code_context_lines"`
+	DeadCodeSortBy                    string            `mapstructure:"dead_code_sort_by" yaml:"dead_code_sort_by" json:"dead_code_sort_by"`
+	DeadCodeDetectAfterReturn         *bool             `mapstructure:"dead_code_detect_after_return" yaml:"dead_code_detect_after_return" json:"dead_code_detect_after_return"`
+	DeadCodeDetectAfterBreak          *bool             `mapstructure:"dead_code_detect_after_break" yaml:"dead_code_detect_after_break" json:"dead_code_detect_after_break"`
+	DeadCodeDetectAfterContinue       *bool             `mapstructure:"dead_code_detect_after_continue" yaml:"dead_code_detect_after_continue" json:"dead_code_detect_after_continue"`
+	DeadCodeDetectAfterRaise          *bool             `mapstructure:"dead_code_detect_after_raise" yaml:"dead_code_detect_after_raise" json:"dead_code_detect_after_raise"`
+	DeadCodeDetectUnreachableBranches *bool             `mapstructure:"dead_code_detect_unreachable_branches" yaml:"dead_code_detect_unreachable_branches" json:"dead_code_detect_unreachable_branches"`
+	DeadCodeIgnorePatterns            []string          `mapstructure:"dead_code_ignore_patterns" yaml:"dead_code_ignore_patterns" json:"dead_code_ignore_patterns"`
+	DeadCodeSeverities                map[string]string `mapstructure:"dead_code_severities" yaml:"dead_code_severities" json:"dead_code_severities"`
+	DeadCodeTerminatingCalls          []string          `mapstructure:"dead_code_terminating_calls" yaml:"dead_code_terminating_calls" json:"dead_code_terminating_calls"`
 
 	// Output Configuration (from [output] section in TOML - general output settings)
 	OutputFormat        string `mapstructure:"output_format" yaml:"output_format" json:"output_format"`
@@ -63,12 +75,24 @@ type PyscnConfig struct {
 	OutputSortBy        string `mapstructure:"output_sort_by" yaml:"output_sort_by" json:"output_sort_by"`
 	OutputMinComplexity int    `mapstructure:"output_min_complexity" yaml:"output_min_complexity" json:"output_min_complexity"`
 	OutputDirectory     string `mapstructure:"output_directory" yaml:"output_directory" json:"output_directory"`
+	OutputLinkTemplate  string `mapstructure:"output_link_template" yaml:"output_link_template" json:"output_link_template"`
+	OutputLang          string `mapstructure:"output_lang" yaml:"output_lang" json:"output_lang"`
+
+	OutputMetricsFile        string `mapstructure:"output_metrics_file" yaml:"output_metrics_file" json:"output_metrics_file"`
+	OutputMetricsPushgateway string `mapstructure:"output_metrics_pushgateway" yaml:"output_metrics_pushgateway" json:"output_metrics_pushgateway"`
+	OutputMetricsJob         string `mapstructure:"output_metrics_job" yaml:"output_metrics_job" json:"output_metrics_job"`
+
+	OutputHistoryRuns   int   `mapstructure:"output_history_runs" yaml:"output_history_runs" json:"output_history_runs"`
+	OutputAbsolutePaths *bool `mapstructure:"output_absolute_paths" yaml:"output_absolute_paths" json:"output_absolute_paths"`
 
 	// Analysis Configuration (from [analysis] section in TOML - general analysis settings)
 	AnalysisIncludePatterns []string `mapstructure:"analysis_include_patterns" yaml:"analysis_include_patterns" json:"analysis_include_patterns"`
 	AnalysisExcludePatterns []string `mapstructure:"analysis_exclude_patterns" yaml:"analysis_exclude_patterns" json:"analysis_exclude_patterns"`
 	AnalysisRecursive       *bool    `mapstructure:"analysis_recursive" yaml:"analysis_recursive" json:"analysis_recursive"`
 	AnalysisFollowSymlinks  *bool    `mapstructure:"analysis_follow_symlinks" yaml:"analysis_follow_symlinks" json:"analysis_follow_symlinks"`
+	AnalysisMaxDepth        int      `mapstructure:"analysis_max_depth" yaml:"analysis_max_depth" json:"analysis_max_depth"`
+	AnalysisIncludeVendored *bool    `mapstructure:"analysis_include_vendored" yaml:"analysis_include_vendored" json:"analysis_include_vendored"`
+	AnalysisSerial          *bool    `mapstructure:"analysis_serial" yaml:"analysis_serial" json:"analysis_serial"`
 	analysisIncludeExplicit bool     `mapstructure:"-" yaml:"-" json:"-"`
 
 	// CBO Configuration (from [cbo] section in TOML)
@@ -80,35 +104,49 @@ type PyscnConfig struct {
 	CboIncludeBuiltins       *bool `mapstructure:"cbo_include_builtins" yaml:"cbo_include_builtins" json:"cbo_include_builtins"`
 	CboIncludeImports        *bool `mapstructure:"cbo_include_imports" yaml:"cbo_include_imports" json:"cbo_include_imports"`
 	CboGroupNamespaceImports *bool `mapstructure:"cbo_group_namespace_imports" yaml:"cbo_group_namespace_imports" json:"cbo_group_namespace_imports"`
+	CboIncludeTypeHints      *bool `mapstructure:"cbo_include_type_hints" yaml:"cbo_include_type_hints" json:"cbo_include_type_hints"`
+	CboMaxInheritance        int   `mapstructure:"cbo_max_inheritance" yaml:"cbo_max_inheritance" json:"cbo_max_inheritance"`
+	CboMaxComposition        int   `mapstructure:"cbo_max_composition" yaml:"cbo_max_composition" json:"cbo_max_composition"`
+	CboMaxInstantiation      int   `mapstructure:"cbo_max_instantiation" yaml:"cbo_max_instantiation" json:"cbo_max_instantiation"`
+	CboMaxMethodCalls        int   `mapstructure:"cbo_max_method_calls" yaml:"cbo_max_method_calls" json:"cbo_max_method_calls"`
+	CboMaxTypeHints          int   `mapstructure:"cbo_max_type_hints" yaml:"cbo_max_type_hints" json:"cbo_max_type_hints"`
 
 	// LCOM Configuration (from [lcom] section in TOML)
 	LcomLowThreshold    int `mapstructure:"lcom_low_threshold" yaml:"lcom_low_threshold" json:"lcom_low_threshold"`
 	LcomMediumThreshold int `mapstructure:"lcom_medium_threshold" yaml:"lcom_medium_threshold" json:"lcom_medium_threshold"`
 
 	// Architecture Configuration (from [architecture] section in TOML)
-	ArchitectureEnabled                         *bool             `mapstructure:"architecture_enabled" yaml:"architecture_enabled" json:"architecture_enabled"`
-	ArchitectureValidateLayers                  *bool             `mapstructure:"architecture_validate_layers" yaml:"architecture_validate_layers" json:"architecture_validate_layers"`
-	ArchitectureValidateCohesion                *bool             `mapstructure:"architecture_validate_cohesion" yaml:"architecture_validate_cohesion" json:"architecture_validate_cohesion"`
-	ArchitectureValidateResponsibility          *bool             `mapstructure:"architecture_validate_responsibility" yaml:"architecture_validate_responsibility" json:"architecture_validate_responsibility"`
-	ArchitectureMinCohesion                     float64           `mapstructure:"architecture_min_cohesion" yaml:"architecture_min_cohesion" json:"architecture_min_cohesion"`
-	ArchitectureMaxCoupling                     int               `mapstructure:"architecture_max_coupling" yaml:"architecture_max_coupling" json:"architecture_max_coupling"`
-	ArchitectureMaxResponsibilities             int               `mapstructure:"architecture_max_responsibilities" yaml:"architecture_max_responsibilities" json:"architecture_max_responsibilities"`
-	ArchitectureLayerViolationSeverity          string            `mapstructure:"architecture_layer_violation_severity" yaml:"architecture_layer_violation_severity" json:"architecture_layer_violation_severity"`
-	ArchitectureCohesionViolationSeverity       string            `mapstructure:"architecture_cohesion_violation_severity" yaml:"architecture_cohesion_violation_severity" json:"architecture_cohesion_violation_severity"`
-	ArchitectureResponsibilityViolationSeverity string            `mapstructure:"architecture_responsibility_violation_severity" yaml:"architecture_responsibility_violation_severity" json:"architecture_responsibility_violation_severity"`
-	ArchitectureShowAllViolations               *bool             `mapstructure:"architecture_show_all_violations" yaml:"architecture_show_all_violations" json:"architecture_show_all_violations"`
-	ArchitectureGroupByType                     *bool             `mapstructure:"architecture_group_by_type" yaml:"architecture_group_by_type" json:"architecture_group_by_type"`
-	ArchitectureIncludeSuggestions              *bool             `mapstructure:"architecture_include_suggestions" yaml:"architecture_include_suggestions" json:"architecture_include_suggestions"`
-	ArchitectureMaxViolationsToShow             int               `mapstructure:"architecture_max_violations_to_show" yaml:"architecture_max_violations_to_show" json:"architecture_max_violations_to_show"`
-	ArchitectureCustomPatterns                  []string          `mapstructure:"architecture_custom_patterns" yaml:"architecture_custom_patterns" json:"architecture_custom_patterns"`
-	ArchitectureAllowedPatterns                 []string          `mapstructure:"architecture_allowed_patterns" yaml:"architecture_allowed_patterns" json:"architecture_allowed_patterns"`
-	ArchitectureForbiddenPatterns               []string          `mapstructure:"architecture_forbidden_patterns" yaml:"architecture_forbidden_patterns" json:"architecture_forbidden_patterns"`
-	ArchitectureStrictMode                      *bool             `mapstructure:"architecture_strict_mode" yaml:"architecture_strict_mode" json:"architecture_strict_mode"`
-	ArchitectureFailOnViolations                *bool             `mapstructure:"architecture_fail_on_violations" yaml:"architecture_fail_on_violations" json:"architecture_fail_on_violations"`
-	ArchitectureNeutralPrefixes                 []string          `mapstructure:"architecture_neutral_prefixes" yaml:"architecture_neutral_prefixes" json:"architecture_neutral_prefixes"`
-	ArchitectureStyle                           string            `mapstructure:"architecture_style" yaml:"architecture_style" json:"architecture_style"`
-	ArchitectureLayers                          []LayerDefinition `mapstructure:"architecture_layers" yaml:"architecture_layers" json:"architecture_layers"`
-	ArchitectureRules                           []LayerRule       `mapstructure:"architecture_rules" yaml:"architecture_rules" json:"architecture_rules"`
+	ArchitectureEnabled                         *bool                 `mapstructure:"architecture_enabled" yaml:"architecture_enabled" json:"architecture_enabled"`
+	ArchitectureValidateLayers                  *bool                 `mapstructure:"architecture_validate_layers" yaml:"architecture_validate_layers" json:"architecture_validate_layers"`
+	ArchitectureValidateCohesion                *bool                 `mapstructure:"architecture_validate_cohesion" yaml:"architecture_validate_cohesion" json:"architecture_validate_cohesion"`
+	ArchitectureValidateResponsibility          *bool                 `mapstructure:"architecture_validate_responsibility" yaml:"architecture_validate_responsibility" json:"architecture_validate_responsibility"`
+	ArchitectureMinCohesion                     float64               `mapstructure:"architecture_min_cohesion" yaml:"architecture_min_cohesion" json:"architecture_min_cohesion"`
+	ArchitectureMaxCoupling                     int                   `mapstructure:"architecture_max_coupling" yaml:"architecture_max_coupling" json:"architecture_max_coupling"`
+	ArchitectureMaxResponsibilities             int                   `mapstructure:"architecture_max_responsibilities" yaml:"architecture_max_responsibilities" json:"architecture_max_responsibilities"`
+	ArchitectureLayerViolationSeverity          string                `mapstructure:"architecture_layer_violation_severity" yaml:"architecture_layer_violation_severity" json:"architecture_layer_violation_severity"`
+	ArchitectureCohesionViolationSeverity       string                `mapstructure:"architecture_cohesion_violation_severity" yaml:"architecture_cohesion_violation_severity" json:"architecture_cohesion_violation_severity"`
+	ArchitectureResponsibilityViolationSeverity string                `mapstructure:"architecture_responsibility_violation_severity" yaml:"architecture_responsibility_violation_severity" json:"architecture_responsibility_violation_severity"`
+	ArchitectureValidateGodModules              *bool                 `mapstructure:"architecture_validate_god_modules" yaml:"architecture_validate_god_modules" json:"architecture_validate_god_modules"`
+	ArchitectureGodModuleMaxLines               int                   `mapstructure:"architecture_god_module_max_lines" yaml:"architecture_god_module_max_lines" json:"architecture_god_module_max_lines"`
+	ArchitectureGodModuleMaxDefinitions         int                   `mapstructure:"architecture_god_module_max_definitions" yaml:"architecture_god_module_max_definitions" json:"architecture_god_module_max_definitions"`
+	ArchitectureGodModuleMaxFanIn               int                   `mapstructure:"architecture_god_module_max_fan_in" yaml:"architecture_god_module_max_fan_in" json:"architecture_god_module_max_fan_in"`
+	ArchitectureGodModuleMaxFanOut              int                   `mapstructure:"architecture_god_module_max_fan_out" yaml:"architecture_god_module_max_fan_out" json:"architecture_god_module_max_fan_out"`
+	ArchitectureShowAllViolations               *bool                 `mapstructure:"architecture_show_all_violations" yaml:"architecture_show_all_violations" json:"architecture_show_all_violations"`
+	ArchitectureGroupByType                     *bool                 `mapstructure:"architecture_group_by_type" yaml:"architecture_group_by_type" json:"architecture_group_by_type"`
+	ArchitectureIncludeSuggestions              *bool                 `mapstructure:"architecture_include_suggestions" yaml:"architecture_include_suggestions" json:"architecture_include_suggestions"`
+	ArchitectureMaxViolationsToShow             int                   `mapstructure:"architecture_max_violations_to_show" yaml:"architecture_max_violations_to_show" json:"architecture_max_violations_to_show"`
+	ArchitectureCustomPatterns                  []string              `mapstructure:"architecture_custom_patterns" yaml:"architecture_custom_patterns" json:"architecture_custom_patterns"`
+	ArchitectureAllowedPatterns                 []string              `mapstructure:"architecture_allowed_patterns" yaml:"architecture_allowed_patterns" json:"architecture_allowed_patterns"`
+	ArchitectureForbiddenPatterns               []string              `mapstructure:"architecture_forbidden_patterns" yaml:"architecture_forbidden_patterns" json:"architecture_forbidden_patterns"`
+	ArchitectureStrictMode                      *bool                 `mapstructure:"architecture_strict_mode" yaml:"architecture_strict_mode" json:"architecture_strict_mode"`
+	ArchitectureFailOnViolations                *bool                 `mapstructure:"architecture_fail_on_violations" yaml:"architecture_fail_on_violations" json:"architecture_fail_on_violations"`
+	ArchitectureNeutralPrefixes                 []string              `mapstructure:"architecture_neutral_prefixes" yaml:"architecture_neutral_prefixes" json:"architecture_neutral_prefixes"`
+	ArchitectureIgnoreEdges                     []string              `mapstructure:"architecture_ignore_edges" yaml:"architecture_ignore_edges" json:"architecture_ignore_edges"`
+	ArchitectureDowngradeEdges                  []string              `mapstructure:"architecture_downgrade_edges" yaml:"architecture_downgrade_edges" json:"architecture_downgrade_edges"`
+	ArchitectureStyle                           string                `mapstructure:"architecture_style" yaml:"architecture_style" json:"architecture_style"`
+	ArchitectureLayers                          []LayerDefinition     `mapstructure:"architecture_layers" yaml:"architecture_layers" json:"architecture_layers"`
+	ArchitectureRules                           []LayerRule           `mapstructure:"architecture_rules" yaml:"architecture_rules" json:"architecture_rules"`
+	ArchitectureRuleSets                        []ArchitectureRuleSet `mapstructure:"architecture_rule_sets" yaml:"architecture_rule_sets" json:"architecture_rule_sets"`
 
 	// SystemAnalysis Configuration (from [system_analysis] section in TOML)
 	SystemAnalysisEnabled               *bool `mapstructure:"system_analysis_enabled" yaml:"system_analysis_enabled" json:"system_analysis_enabled"`
@@ -149,6 +187,14 @@ type PyscnConfig struct {
 	DependenciesMaxCyclesToShow   int     `mapstructure:"dependencies_max_cycles_to_show" yaml:"dependencies_max_cycles_to_show" json:"dependencies_max_cycles_to_show"`
 	DependenciesShowCyclePaths    *bool   `mapstructure:"dependencies_show_cycle_paths" yaml:"dependencies_show_cycle_paths" json:"dependencies_show_cycle_paths"`
 
+	// Edge kind handling; a nil CycleIgnoreEdges keeps the default (lazy, type_checking)
+	DependenciesIncludeTypeChecking *bool    `mapstructure:"dependencies_include_type_checking" yaml:"dependencies_include_type_checking" json:"dependencies_include_type_checking"`
+	DependenciesCycleIgnoreEdges    []string `mapstructure:"dependencies_cycle_ignore_edges" yaml:"dependencies_cycle_ignore_edges" json:"dependencies_cycle_ignore_edges"`
+
+	// Re-export facades
+	DependenciesFacadeMinExports int   `mapstructure:"dependencies_facade_min_exports" yaml:"dependencies_facade_min_exports" json:"dependencies_facade_min_exports"`
+	DependenciesCollapseFacades  *bool `mapstructure:"dependencies_collapse_facades" yaml:"dependencies_collapse_facades" json:"dependencies_collapse_facades"`
+
 	// MockData Configuration (from [mock_data] section in TOML)
 	MockDataEnabled        *bool    `mapstructure:"mock_data_enabled" yaml:"mock_data_enabled" json:"mock_data_enabled"`
 	MockDataMinSeverity    string   `mapstructure:"mock_data_min_severity" yaml:"mock_data_min_severity" json:"mock_data_min_severity"`
@@ -163,6 +209,25 @@ type PyscnConfig struct {
 	DIMinSeverity               string `mapstructure:"di_min_severity" yaml:"di_min_severity" json:"di_min_severity"`
 	DIConstructorParamThreshold int    `mapstructure:"di_constructor_param_threshold" yaml:"di_constructor_param_threshold" json:"di_constructor_param_threshold"`
 
+	// Security Configuration (from [security] section in TOML)
+	SecurityMinSeverity string `mapstructure:"security_min_severity" yaml:"security_min_severity" json:"security_min_severity"`
+	SecurityIgnoreTests *bool  `mapstructure:"security_ignore_tests" yaml:"security_ignore_tests" json:"security_ignore_tests"`
+
+	// Documentation Configuration (from [documentation] section in TOML)
+	DocumentationEnabled    *bool  `mapstructure:"documentation_enabled" yaml:"documentation_enabled" json:"documentation_enabled"`
+	DocumentationConvention string `mapstructure:"documentation_convention" yaml:"documentation_convention" json:"documentation_convention"`
+
+	// Typing Configuration (from [typing] section in TOML)
+	TypingEnabled *bool `mapstructure:"typing_enabled" yaml:"typing_enabled" json:"typing_enabled"`
+
+	// Custom Rules Configuration (from [custom_rules] section in TOML)
+	CustomRulesFiles []string `mapstructure:"custom_rules_files" yaml:"custom_rules_files" json:"custom_rules_files"`
+
+	// AnalyzerScopes holds include_patterns/exclude_patterns set in an
+	// analyzer's own section, keyed by section name. Only sections that set
+	// at least one of them are present.
+	AnalyzerScopes map[string]domain.AnalysisScope `mapstructure:"analyzer_scopes" yaml:"analyzer_scopes" json:"analyzer_scopes"`
+
 	// Track whether [output].min_complexity was explicitly set so it can
 	// override [complexity].min_complexity even when both resolve to defaults.
 	outputMinComplexityExplicit bool `mapstructure:"-" yaml:"-" json:"-"`
@@ -172,12 +237,48 @@ func (c *PyscnConfig) HasExplicitAnalysisIncludePatterns() bool {
 	return c != nil && c.analysisIncludeExplicit
 }
 
+// setAnalyzerScope records the file patterns of one analyzer section
+func (c *PyscnConfig) setAnalyzerScope(section string, includePatterns, excludePatterns []string) {
+	if includePatterns == nil && excludePatterns == nil {
+		return
+	}
+	if c.AnalyzerScopes == nil {
+		c.AnalyzerScopes = make(map[string]domain.AnalysisScope)
+	}
+	c.AnalyzerScopes[section] = domain.AnalysisScope{
+		IncludePatterns: includePatterns,
+		ExcludePatterns: excludePatterns,
+	}
+}
+
+// AnalyzerIncludePatterns returns the include patterns of an analyzer
+// section, falling back to [analysis] when the section sets none
+func (c *PyscnConfig) AnalyzerIncludePatterns(section string) []string {
+	if scope, ok := c.AnalyzerScopes[section]; ok && scope.IncludePatterns != nil {
+		return scope.IncludePatterns
+	}
+	return c.AnalysisIncludePatterns
+}
+
+// AnalyzerExcludePatterns returns the exclude patterns of an analyzer
+// section, falling back to [analysis] when the section sets none
+func (c *PyscnConfig) AnalyzerExcludePatterns(section string) []string {
+	if scope, ok := c.AnalyzerScopes[section]; ok && scope.ExcludePatterns != nil {
+		return scope.ExcludePatterns
+	}
+	return c.AnalysisExcludePatterns
+}
+
 // CloneAnalysisConfig holds core analysis parameters
 type CloneAnalysisConfig struct {
 	// Minimum requirements for clone candidates
 	MinLines int `mapstructure:"min_lines" yaml:"min_lines" json:"min_lines"`
 	MinNodes int `mapstructure:"min_nodes" yaml:"min_nodes" json:"min_nodes"`
 
+	// MinTokens counts AST leaves: names, literals and keywords. It filters
+	// out long but trivial fragments that pass min_lines; 0 disables it.
+	MinTokens int `mapstructure:"min_tokens" yaml:"min_tokens" json:"min_tokens"`
+
 	// Edit distance configuration
 	MaxEditDistance float64 `mapstructure:"max_edit_distance" yaml:"max_edit_distance" json:"max_edit_distance"`
 
@@ -191,6 +292,12 @@ type CloneAnalysisConfig struct {
 
 	// Advanced analysis
 	EnableDFA *bool `mapstructure:"enable_dfa" yaml:"enable_dfa" json:"enable_dfa"` // Data Flow Analysis for Type-4
+
+	// String clones compare string literals of at least MinStringLength
+	// characters, such as embedded SQL, apart from code clones
+	StringClones              *bool   `mapstructure:"string_clones" yaml:"string_clones" json:"string_clones"`
+	MinStringLength           int     `mapstructure:"min_string_length" yaml:"min_string_length" json:"min_string_length"`
+	StringSimilarityThreshold float64 `mapstructure:"string_similarity_threshold" yaml:"string_similarity_threshold" json:"string_similarity_threshold"`
 }
 
 // ThresholdConfig holds similarity thresholds for different clone types
@@ -239,6 +346,12 @@ type CloneOutputConfig struct {
 	SortBy      string `mapstructure:"sort_by" yaml:"sort_by" json:"sort_by"`
 	GroupClones *bool  `mapstructure:"group_clones" yaml:"group_clones" json:"group_clones"`
 
+	// Report shaping: members listed per group (0 = all), folding of members
+	// sharing a file, and the report layout (group, file or package)
+	MaxGroupMembers  int    `mapstructure:"max_group_members" yaml:"max_group_members" json:"max_group_members"`
+	CollapseSameFile *bool  `mapstructure:"collapse_same_file" yaml:"collapse_same_file" json:"collapse_same_file"`
+	ReportGroupBy    string `mapstructure:"report_group_by" yaml:"report_group_by" json:"report_group_by"`
+
 	// Output destination (not serialized)
 	Writer io.Writer `json:"-" yaml:"-" mapstructure:"-"`
 }
@@ -292,14 +405,18 @@ func DefaultPyscnConfig() *PyscnConfig {
 	return &PyscnConfig{
 		// Clone detection configuration
 		Analysis: CloneAnalysisConfig{
-			MinLines:          domain.DefaultCloneMinLines,
-			MinNodes:          domain.DefaultCloneMinNodes,
-			MaxEditDistance:   domain.DefaultCloneMaxEditDistance,
-			IgnoreLiterals:    domain.BoolPtr(false),
-			IgnoreIdentifiers: domain.BoolPtr(false),
-			SkipDocstrings:    domain.BoolPtr(true),
-			CostModelType:     "python",
-			EnableDFA:         domain.BoolPtr(true), // Enable Data Flow Analysis by default for multi-dimensional classification
+			MinLines:                  domain.DefaultCloneMinLines,
+			MinNodes:                  domain.DefaultCloneMinNodes,
+			MinTokens:                 domain.DefaultCloneMinTokens,
+			MaxEditDistance:           domain.DefaultCloneMaxEditDistance,
+			IgnoreLiterals:            domain.BoolPtr(false),
+			IgnoreIdentifiers:         domain.BoolPtr(false),
+			SkipDocstrings:            domain.BoolPtr(true),
+			CostModelType:             "python",
+			EnableDFA:                 domain.BoolPtr(true), // Enable Data Flow Analysis by default for multi-dimensional classification
+			StringClones:              domain.BoolPtr(false),
+			MinStringLength:           domain.DefaultCloneMinStringLength,
+			StringSimilarityThreshold: domain.DefaultCloneStringSimilarityThreshold,
 		},
 		Thresholds: ThresholdConfig{
 			Type1Threshold:      domain.DefaultType1CloneThreshold,
@@ -321,11 +438,13 @@ func DefaultPyscnConfig() *PyscnConfig {
 			ExcludePatterns: domain.DefaultAnalysisExcludePatterns(),
 		},
 		Output: CloneOutputConfig{
-			Format:      "text",
-			ShowDetails: domain.BoolPtr(false),
-			ShowContent: domain.BoolPtr(false),
-			SortBy:      "similarity",
-			GroupClones: domain.BoolPtr(true),
+			Format:           "text",
+			ShowDetails:      domain.BoolPtr(false),
+			ShowContent:      domain.BoolPtr(false),
+			SortBy:           "similarity",
+			GroupClones:      domain.BoolPtr(true),
+			CollapseSameFile: domain.BoolPtr(false),
+			ReportGroupBy:    domain.CloneReportGroupByGroup,
 		},
 		Performance: PerformanceConfig{
 			MaxMemoryMB:    domain.DefaultMaxMemoryMB,
@@ -370,6 +489,7 @@ func DefaultPyscnConfig() *PyscnConfig {
 		DeadCodeDetectAfterRaise:          domain.BoolPtr(true),
 		DeadCodeDetectUnreachableBranches: domain.BoolPtr(true),
 		DeadCodeIgnorePatterns:            []string{},
+		DeadCodeTerminatingCalls:          []string{},
 
 		// Output defaults (from [output] section - general output settings)
 		OutputFormat:        "text",
@@ -377,12 +497,15 @@ func DefaultPyscnConfig() *PyscnConfig {
 		OutputSortBy:        "complexity",
 		OutputMinComplexity: DefaultMinComplexityFilter,
 		OutputDirectory:     "", // empty = tool default (.pyscn/reports)
+		OutputAbsolutePaths: domain.BoolPtr(false),
 
 		// Analysis defaults (from [analysis] section - general analysis settings)
 		AnalysisIncludePatterns: domain.DefaultAnalysisIncludePatterns(),
 		AnalysisExcludePatterns: domain.DefaultAnalysisExcludePatterns(),
 		AnalysisRecursive:       domain.BoolPtr(true),
 		AnalysisFollowSymlinks:  domain.BoolPtr(false),
+		AnalysisIncludeVendored: domain.BoolPtr(false),
+		AnalysisSerial:          domain.BoolPtr(false),
 
 		// CBO defaults (from [cbo] section)
 		CboLowThreshold:          domain.DefaultCBOLowThreshold,
@@ -393,6 +516,7 @@ func DefaultPyscnConfig() *PyscnConfig {
 		CboIncludeBuiltins:       domain.BoolPtr(false),
 		CboIncludeImports:        domain.BoolPtr(true),
 		CboGroupNamespaceImports: domain.BoolPtr(true),
+		CboIncludeTypeHints:      domain.BoolPtr(true),
 
 		// LCOM defaults (from [lcom] section)
 		LcomLowThreshold:    domain.DefaultLCOMLowThreshold,
@@ -409,6 +533,11 @@ func DefaultPyscnConfig() *PyscnConfig {
 		ArchitectureLayerViolationSeverity:          "error",
 		ArchitectureCohesionViolationSeverity:       "warning",
 		ArchitectureResponsibilityViolationSeverity: "warning",
+		ArchitectureValidateGodModules:              domain.BoolPtr(true),
+		ArchitectureGodModuleMaxLines:               domain.DefaultGodModuleMaxLines,
+		ArchitectureGodModuleMaxDefinitions:         domain.DefaultGodModuleMaxDefinitions,
+		ArchitectureGodModuleMaxFanIn:               domain.DefaultGodModuleMaxFanIn,
+		ArchitectureGodModuleMaxFanOut:              domain.DefaultGodModuleMaxFanOut,
 		ArchitectureShowAllViolations:               domain.BoolPtr(false),
 		ArchitectureGroupByType:                     domain.BoolPtr(true),
 		ArchitectureIncludeSuggestions:              domain.BoolPtr(true),
@@ -459,6 +588,8 @@ func DefaultPyscnConfig() *PyscnConfig {
 		DependenciesCycleReporting:    "summary", // all, critical, summary
 		DependenciesMaxCyclesToShow:   10,
 		DependenciesShowCyclePaths:    domain.BoolPtr(false),
+		DependenciesFacadeMinExports:  domain.DefaultFacadeMinExports,
+		DependenciesCollapseFacades:   domain.BoolPtr(false),
 
 		// MockData defaults (from [mock_data] section)
 		MockDataEnabled:        domain.BoolPtr(false), // Disabled by default - opt-in
@@ -473,6 +604,17 @@ func DefaultPyscnConfig() *PyscnConfig {
 		DIEnabled:                   domain.BoolPtr(false), // Disabled by default - opt-in
 		DIMinSeverity:               string(domain.DIAntipatternSeverityWarning),
 		DIConstructorParamThreshold: domain.DefaultDIConstructorParamThreshold,
+
+		// Security defaults (from [security] section)
+		SecurityMinSeverity: domain.DefaultSecurityMinSeverity,
+		SecurityIgnoreTests: domain.BoolPtr(domain.DefaultSecurityIgnoreTests),
+
+		// Documentation defaults (from [documentation] section)
+		DocumentationEnabled:    domain.BoolPtr(false), // Disabled by default - opt-in
+		DocumentationConvention: domain.DefaultDocumentationConvention,
+
+		// Typing defaults (from [typing] section)
+		TypingEnabled: domain.BoolPtr(false), // Disabled by default - opt-in
 	}
 }
 
@@ -557,9 +699,18 @@ func (a *CloneAnalysisConfig) Validate() error {
 	if a.MinNodes < 1 {
 		return fmt.Errorf("min_nodes must be >= 1, got %d", a.MinNodes)
 	}
+	if a.MinTokens < 0 {
+		return fmt.Errorf("min_tokens must be >= 0, got %d", a.MinTokens)
+	}
 	if a.MaxEditDistance < 0 {
 		return fmt.Errorf("max_edit_distance must be >= 0, got %f", a.MaxEditDistance)
 	}
+	if a.MinStringLength < 0 {
+		return fmt.Errorf("min_string_length must be >= 0, got %d", a.MinStringLength)
+	}
+	if a.StringSimilarityThreshold < 0 || a.StringSimilarityThreshold > 1 {
+		return fmt.Errorf("string_similarity_threshold must be between 0.0 and 1.0, got %f", a.StringSimilarityThreshold)
+	}
 
 	validCostModels := []string{"default", "python", "weighted"}
 	valid := false
@@ -673,6 +824,16 @@ func (o *CloneOutputConfig) Validate() error {
 		return fmt.Errorf("sort_by must be one of %v, got %s", validSortBy, o.SortBy)
 	}
 
+	if o.MaxGroupMembers < 0 {
+		return fmt.Errorf("max_group_members must be >= 0, got %d", o.MaxGroupMembers)
+	}
+
+	switch o.ReportGroupBy {
+	case "", domain.CloneReportGroupByGroup, domain.CloneReportGroupByFile, domain.CloneReportGroupByPackage:
+	default:
+		return fmt.Errorf("report_group_by must be one of [group file package], got %s", o.ReportGroupBy)
+	}
+
 	return nil
 }
 
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/ludo-technologies/pyscn/domain"
 	"github.com/pelletier/go-toml/v2"
 )
 
@@ -24,6 +25,10 @@ type PyscnTomlConfig struct {
 	Clones         ClonesConfig             `toml:"clones"`          // [clones] section - unified flat structure
 	MockData       MockDataTomlConfig       `toml:"mock_data"`       // [mock_data] section
 	DI             DITomlConfig             `toml:"di"`              // [di] section
+	Security       SecurityTomlConfig       `toml:"security"`        // [security] section
+	Documentation  DocumentationTomlConfig  `toml:"documentation"`   // [documentation] section
+	Typing         TypingTomlConfig         `toml:"typing"`          // [typing] section
+	CustomRules    CustomRulesTomlConfig    `toml:"custom_rules"`    // [custom_rules] section
 }
 
 // ComplexityTomlConfig represents the [complexity] section
@@ -36,6 +41,103 @@ type ComplexityTomlConfig struct {
 	NestingDepthThreshold        *int  `toml:"nesting_depth_threshold"`        // pointer to detect unset
 	MaxComplexity                *int  `toml:"max_complexity"`                 // pointer to detect unset
 	MinComplexity                *int  `toml:"min_complexity"`                 // pointer to detect unset
+
+	// Boilerplate are patterns tagging the functions they match as boilerplate
+	Boilerplate []BoilerplatePatternToml `toml:"boilerplate"`
+
+	// Weights of expression-level branching in cyclomatic complexity
+	ComprehensionWeight   *float64 `toml:"comprehension_weight"`
+	GeneratorWeight       *float64 `toml:"generator_weight"`
+	BooleanOperatorWeight *float64 `toml:"boolean_operator_weight"`
+
+	IncludePatterns []string `toml:"include_patterns"` // overrides [analysis] for this analyzer in analyze
+	ExcludePatterns []string `toml:"exclude_patterns"` // overrides [analysis] for this analyzer in analyze
+}
+
+// BoilerplatePatternToml represents a [[complexity.boilerplate]] pattern
+type BoilerplatePatternToml struct {
+	Name            string               `toml:"name"`
+	Match           PatternRuleMatchToml `toml:"match"`
+	ExcludeFromRisk bool                 `toml:"exclude_from_risk"`
+}
+
+// PatternRuleMatchToml represents the match of a pattern, with the keys of
+// custom rule files
+type PatternRuleMatchToml struct {
+	Node          tomlNodeTypes           `toml:"node"`
+	Name          string                  `toml:"name,omitempty"`
+	Callee        string                  `toml:"callee,omitempty"`
+	Scope         domain.PatternRuleScope `toml:"scope,omitempty"`
+	Inside        tomlNodeTypes           `toml:"inside,omitempty"`
+	NotInside     tomlNodeTypes           `toml:"not_inside,omitempty"`
+	Bare          *bool                   `toml:"bare,omitempty"`
+	MinDecorators int                     `toml:"min_decorators,omitempty"`
+	MinArgs       int                     `toml:"min_args,omitempty"`
+	MinLines      int                     `toml:"min_lines,omitempty"`
+}
+
+// tomlNodeTypes accepts a single node type or a list of them
+type tomlNodeTypes []string
+
+// UnmarshalText decodes a single node type; lists decode as slices
+func (n *tomlNodeTypes) UnmarshalText(text []byte) error {
+	*n = tomlNodeTypes{string(text)}
+	return nil
+}
+
+// boilerplatePatternsFromToml converts [[complexity.boilerplate]] patterns
+func boilerplatePatternsFromToml(patterns []BoilerplatePatternToml) []domain.BoilerplatePattern {
+	if len(patterns) == 0 {
+		return nil
+	}
+	converted := make([]domain.BoilerplatePattern, len(patterns))
+	for i, p := range patterns {
+		converted[i] = domain.BoilerplatePattern{
+			Name: p.Name,
+			Match: domain.PatternRuleMatch{
+				Nodes:         p.Match.Node,
+				Name:          p.Match.Name,
+				Callee:        p.Match.Callee,
+				Scope:         p.Match.Scope,
+				Inside:        p.Match.Inside,
+				NotInside:     p.Match.NotInside,
+				Bare:          p.Match.Bare,
+				MinDecorators: p.Match.MinDecorators,
+				MinArgs:       p.Match.MinArgs,
+				MinLines:      p.Match.MinLines,
+			},
+			ExcludeFromRisk: p.ExcludeFromRisk,
+		}
+	}
+	return converted
+}
+
+// boilerplatePatternsToToml converts boilerplate patterns back to their
+// [[complexity.boilerplate]] form
+func boilerplatePatternsToToml(patterns []domain.BoilerplatePattern) []BoilerplatePatternToml {
+	if len(patterns) == 0 {
+		return nil
+	}
+	converted := make([]BoilerplatePatternToml, len(patterns))
+	for i, p := range patterns {
+		converted[i] = BoilerplatePatternToml{
+			Name: p.Name,
+			Match: PatternRuleMatchToml{
+				Node:          p.Match.Nodes,
+				Name:          p.Match.Name,
+				Callee:        p.Match.Callee,
+				Scope:         p.Match.Scope,
+				Inside:        p.Match.Inside,
+				NotInside:     p.Match.NotInside,
+				Bare:          p.Match.Bare,
+				MinDecorators: p.Match.MinDecorators,
+				MinArgs:       p.Match.MinArgs,
+				MinLines:      p.Match.MinLines,
+			},
+			ExcludeFromRisk: p.ExcludeFromRisk,
+		}
+	}
+	return converted
 }
 
 // DeadCodeTomlConfig represents the [dead_code] section
@@ -51,6 +153,15 @@ type DeadCodeTomlConfig struct {
 	DetectAfterRaise          *bool    `toml:"detect_after_raise"`
 	DetectUnreachableBranches *bool    `toml:"detect_unreachable_branches"`
 	IgnorePatterns            []string `toml:"ignore_patterns"`
+
+	// TerminatingCalls are calls that never return, besides the built-in ones
+	TerminatingCalls []string `toml:"terminating_calls"`
+
+	// Severities maps reasons to the severity their findings are reported with
+	Severities map[string]string `toml:"severities"`
+
+	IncludePatterns []string `toml:"include_patterns"` // overrides [analysis] for this analyzer in analyze
+	ExcludePatterns []string `toml:"exclude_patterns"` // overrides [analysis] for this analyzer in analyze
 }
 
 // OutputTomlConfig represents the [output] section
@@ -60,6 +171,15 @@ type OutputTomlConfig struct {
 	SortBy        string `toml:"sort_by"`
 	MinComplexity *int   `toml:"min_complexity"`
 	Directory     string `toml:"directory"`
+	LinkTemplate  string `toml:"link_template"`
+	Lang          string `toml:"lang"`
+
+	MetricsFile        string `toml:"metrics_file"`
+	MetricsPushgateway string `toml:"metrics_pushgateway"`
+	MetricsJob         string `toml:"metrics_job"`
+
+	HistoryRuns   *int  `toml:"history_runs"`
+	AbsolutePaths *bool `toml:"absolute_paths"`
 }
 
 // AnalysisTomlConfig represents the [analysis] section
@@ -68,6 +188,9 @@ type AnalysisTomlConfig struct {
 	ExcludePatterns []string `toml:"exclude_patterns"`
 	Recursive       *bool    `toml:"recursive"`
 	FollowSymlinks  *bool    `toml:"follow_symlinks"`
+	MaxDepth        *int     `toml:"max_depth"`
+	IncludeVendored *bool    `toml:"include_vendored"`
+	Serial          *bool    `toml:"serial"`
 
 	includePatternsSet bool
 }
@@ -86,39 +209,61 @@ type CboTomlConfig struct {
 	IncludeBuiltins       *bool `toml:"include_builtins"`
 	IncludeImports        *bool `toml:"include_imports"`
 	GroupNamespaceImports *bool `toml:"group_namespace_imports"`
+	IncludeTypeHints      *bool `toml:"include_type_hints"`
+
+	// Per-kind limits on coupled classes; 0 means no limit
+	MaxInheritance   *int `toml:"max_inheritance"`
+	MaxComposition   *int `toml:"max_composition"`
+	MaxInstantiation *int `toml:"max_instantiation"`
+	MaxMethodCalls   *int `toml:"max_method_calls"`
+	MaxTypeHints     *int `toml:"max_type_hints"`
+
+	IncludePatterns []string `toml:"include_patterns"` // overrides [analysis] for this analyzer in analyze
+	ExcludePatterns []string `toml:"exclude_patterns"` // overrides [analysis] for this analyzer in analyze
 }
 
 // LcomTomlConfig represents the [lcom] section
 type LcomTomlConfig struct {
 	LowThreshold    *int `toml:"low_threshold"`
 	MediumThreshold *int `toml:"medium_threshold"`
+
+	IncludePatterns []string `toml:"include_patterns"` // overrides [analysis] for this analyzer in analyze
+	ExcludePatterns []string `toml:"exclude_patterns"` // overrides [analysis] for this analyzer in analyze
 }
 
 // ArchitectureTomlConfig represents the [architecture] section
 type ArchitectureTomlConfig struct {
-	Enabled                         *bool                 `toml:"enabled"`
-	ValidateLayers                  *bool                 `toml:"validate_layers"`
-	ValidateCohesion                *bool                 `toml:"validate_cohesion"`
-	ValidateResponsibility          *bool                 `toml:"validate_responsibility"`
-	MinCohesion                     *float64              `toml:"min_cohesion"`
-	MaxCoupling                     *int                  `toml:"max_coupling"`
-	MaxResponsibilities             *int                  `toml:"max_responsibilities"`
-	LayerViolationSeverity          string                `toml:"layer_violation_severity"`
-	CohesionViolationSeverity       string                `toml:"cohesion_violation_severity"`
-	ResponsibilityViolationSeverity string                `toml:"responsibility_violation_severity"`
-	ShowAllViolations               *bool                 `toml:"show_all_violations"`
-	GroupByType                     *bool                 `toml:"group_by_type"`
-	IncludeSuggestions              *bool                 `toml:"include_suggestions"`
-	MaxViolationsToShow             *int                  `toml:"max_violations_to_show"`
-	CustomPatterns                  []string              `toml:"custom_patterns"`
-	AllowedPatterns                 []string              `toml:"allowed_patterns"`
-	ForbiddenPatterns               []string              `toml:"forbidden_patterns"`
-	StrictMode                      *bool                 `toml:"strict_mode"`
-	FailOnViolations                *bool                 `toml:"fail_on_violations"`
-	NeutralPrefixes                 []string              `toml:"neutral_prefixes"`
-	Style                           string                `toml:"style"`
-	Layers                          []LayerDefinitionToml `toml:"layers"`
-	Rules                           []LayerRuleToml       `toml:"rules"`
+	Enabled                         *bool                     `toml:"enabled"`
+	ValidateLayers                  *bool                     `toml:"validate_layers"`
+	ValidateCohesion                *bool                     `toml:"validate_cohesion"`
+	ValidateResponsibility          *bool                     `toml:"validate_responsibility"`
+	MinCohesion                     *float64                  `toml:"min_cohesion"`
+	MaxCoupling                     *int                      `toml:"max_coupling"`
+	MaxResponsibilities             *int                      `toml:"max_responsibilities"`
+	LayerViolationSeverity          string                    `toml:"layer_violation_severity"`
+	CohesionViolationSeverity       string                    `toml:"cohesion_violation_severity"`
+	ResponsibilityViolationSeverity string                    `toml:"responsibility_violation_severity"`
+	ValidateGodModules              *bool                     `toml:"validate_god_modules"`
+	GodModuleMaxLines               *int                      `toml:"god_module_max_lines"`
+	GodModuleMaxDefinitions         *int                      `toml:"god_module_max_definitions"`
+	GodModuleMaxFanIn               *int                      `toml:"god_module_max_fan_in"`
+	GodModuleMaxFanOut              *int                      `toml:"god_module_max_fan_out"`
+	ShowAllViolations               *bool                     `toml:"show_all_violations"`
+	GroupByType                     *bool                     `toml:"group_by_type"`
+	IncludeSuggestions              *bool                     `toml:"include_suggestions"`
+	MaxViolationsToShow             *int                      `toml:"max_violations_to_show"`
+	CustomPatterns                  []string                  `toml:"custom_patterns"`
+	AllowedPatterns                 []string                  `toml:"allowed_patterns"`
+	ForbiddenPatterns               []string                  `toml:"forbidden_patterns"`
+	StrictMode                      *bool                     `toml:"strict_mode"`
+	FailOnViolations                *bool                     `toml:"fail_on_violations"`
+	NeutralPrefixes                 []string                  `toml:"neutral_prefixes"`
+	IgnoreEdges                     []string                  `toml:"ignore_edges"`
+	DowngradeEdges                  []string                  `toml:"downgrade_edges"`
+	Style                           string                    `toml:"style"`
+	Layers                          []LayerDefinitionToml     `toml:"layers"`
+	Rules                           []LayerRuleToml           `toml:"rules"`
+	RuleSets                        []ArchitectureRuleSetToml `toml:"rule_sets"`
 }
 
 // LayerDefinitionToml represents a layer definition in TOML
@@ -136,6 +281,16 @@ type LayerRuleToml struct {
 	Deny        []string `toml:"deny"`
 	Warn        []string `toml:"warn"`
 	Description string   `toml:"description"`
+	Budget      int      `toml:"budget"`
+}
+
+// ArchitectureRuleSetToml represents a rule set scoped to sub-paths in TOML
+type ArchitectureRuleSetToml struct {
+	Name       string                `toml:"name"`
+	Paths      []string              `toml:"paths"`
+	StrictMode *bool                 `toml:"strict_mode"`
+	Layers     []LayerDefinitionToml `toml:"layers"`
+	Rules      []LayerRuleToml       `toml:"rules"`
 }
 
 // SystemAnalysisTomlConfig represents the [system_analysis] section
@@ -158,6 +313,9 @@ type CommunitiesTomlConfig struct {
 	IncludeLazyEdges    *bool    `toml:"include_lazy_edges"`
 	ReportBridgeModules *bool    `toml:"report_bridge_modules"`
 	Resolution          *float64 `toml:"resolution"`
+
+	IncludePatterns []string `toml:"include_patterns"` // overrides [analysis] for this analyzer in analyze
+	ExcludePatterns []string `toml:"exclude_patterns"` // overrides [analysis] for this analyzer in analyze
 }
 
 // DependenciesTomlConfig represents the [dependencies] section
@@ -181,6 +339,15 @@ type DependenciesTomlConfig struct {
 	CycleReporting    string   `toml:"cycle_reporting"`
 	MaxCyclesToShow   *int     `toml:"max_cycles_to_show"`
 	ShowCyclePaths    *bool    `toml:"show_cycle_paths"`
+
+	IncludeTypeChecking *bool    `toml:"include_type_checking"`
+	CycleIgnoreEdges    []string `toml:"cycle_ignore_edges"`
+
+	FacadeMinExports *int  `toml:"facade_min_exports"`
+	CollapseFacades  *bool `toml:"collapse_facades"`
+
+	IncludePatterns []string `toml:"include_patterns"` // overrides [analysis] for this analyzer in analyze
+	ExcludePatterns []string `toml:"exclude_patterns"` // overrides [analysis] for this analyzer in analyze
 }
 
 // MockDataTomlConfig represents the [mock_data] section
@@ -201,11 +368,40 @@ type DITomlConfig struct {
 	ConstructorParamThreshold *int   `toml:"constructor_param_threshold"`
 }
 
+// SecurityTomlConfig represents the [security] section
+type SecurityTomlConfig struct {
+	MinSeverity string `toml:"min_severity"`
+	IgnoreTests *bool  `toml:"ignore_tests"`
+}
+
+// DocumentationTomlConfig represents the [documentation] section
+type DocumentationTomlConfig struct {
+	Enabled    *bool  `toml:"enabled"`
+	Convention string `toml:"convention"`
+
+	IncludePatterns []string `toml:"include_patterns"` // overrides [analysis] for this analyzer in analyze
+	ExcludePatterns []string `toml:"exclude_patterns"` // overrides [analysis] for this analyzer in analyze
+}
+
+// TypingTomlConfig represents the [typing] section
+type TypingTomlConfig struct {
+	Enabled *bool `toml:"enabled"`
+
+	IncludePatterns []string `toml:"include_patterns"` // overrides [analysis] for this analyzer in analyze
+	ExcludePatterns []string `toml:"exclude_patterns"` // overrides [analysis] for this analyzer in analyze
+}
+
+// CustomRulesTomlConfig represents the [custom_rules] section
+type CustomRulesTomlConfig struct {
+	Files []string `toml:"files"` // YAML or JSON rule files, relative to the configuration file
+}
+
 // ClonesConfig represents the [clones] section (flat structure)
 type ClonesConfig struct {
 	// Analysis settings
 	MinLines          int     `toml:"min_lines"`
 	MinNodes          int     `toml:"min_nodes"`
+	MinTokens         int     `toml:"min_tokens"`
 	MaxEditDistance   float64 `toml:"max_edit_distance"`
 	IgnoreLiterals    *bool   `toml:"ignore_literals"`    // pointer to detect unset
 	IgnoreIdentifiers *bool   `toml:"ignore_identifiers"` // pointer to detect unset
@@ -222,6 +418,11 @@ type ClonesConfig struct {
 	// Advanced analysis
 	EnableDFA *bool `toml:"enable_dfa"` // Enable Data Flow Analysis for Type-4 detection
 
+	// String clones
+	StringClones              *bool   `toml:"string_clones"` // pointer to detect unset
+	MinStringLength           int     `toml:"min_string_length"`
+	StringSimilarityThreshold float64 `toml:"string_similarity_threshold"`
+
 	// Filtering
 	MinSimilarity     float64  `toml:"min_similarity"`
 	MaxSimilarity     float64  `toml:"max_similarity"`
@@ -260,6 +461,11 @@ type ClonesConfig struct {
 	ShowContent *bool  `toml:"show_content"` // pointer to detect unset
 	SortBy      string `toml:"sort_by"`
 	GroupClones *bool  `toml:"group_clones"` // pointer to detect unset
+
+	// Report shaping
+	MaxGroupMembers  int    `toml:"max_group_members"`
+	CollapseSameFile *bool  `toml:"collapse_same_file"` // pointer to detect unset
+	ReportGroupBy    string `toml:"report_group_by"`
 }
 
 // TomlConfigLoader handles TOML-only configuration loading
@@ -531,6 +737,18 @@ func (l *TomlConfigLoader) mergePyscnTomlConfigs(defaults *PyscnConfig, pyscnTom
 
 	// Merge from [di] section
 	mergeDISection(defaults, &pyscnToml.DI)
+
+	// Merge from [security] section
+	mergeSecuritySection(defaults, &pyscnToml.Security)
+
+	// Merge from [documentation] section
+	mergeDocumentationSection(defaults, &pyscnToml.Documentation)
+
+	// Merge from [typing] section
+	mergeTypingSection(defaults, &pyscnToml.Typing)
+
+	// Merge from [custom_rules] section
+	mergeCustomRulesSection(defaults, &pyscnToml.CustomRules)
 }
 
 func markTomlFieldPresence(data []byte, analysis *AnalysisTomlConfig, path ...string) {
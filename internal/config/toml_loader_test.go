@@ -172,6 +172,7 @@ context_lines = 5
 sort_by = "line"
 detect_after_return = false
 detect_after_break = false
+terminating_calls = ["myapp.log.fatal"]
 `
 	configPath := filepath.Join(tempDir, ".pyscn.toml")
 	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
@@ -211,6 +212,9 @@ detect_after_break = false
 	if domain.BoolValue(config.DeadCodeDetectAfterBreak, true) {
 		t.Errorf("Expected detect_after_break false, got %v", config.DeadCodeDetectAfterBreak)
 	}
+	if len(config.DeadCodeTerminatingCalls) != 1 || config.DeadCodeTerminatingCalls[0] != "myapp.log.fatal" {
+		t.Errorf("Expected terminating_calls [myapp.log.fatal], got %v", config.DeadCodeTerminatingCalls)
+	}
 }
 
 func TestLoadDIFromPyscnToml(t *testing.T) {
@@ -243,6 +247,80 @@ constructor_param_threshold = 9
 	}
 }
 
+func TestLoadSecurityFromPyscnToml(t *testing.T) {
+	tempDir := t.TempDir()
+
+	configContent := `[security]
+min_severity = "info"
+ignore_tests = false
+`
+	configPath := filepath.Join(tempDir, ".pyscn.toml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	loader := NewTomlConfigLoader()
+	config, err := loader.LoadConfig(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if config.SecurityMinSeverity != "info" {
+		t.Errorf("Expected security.min_severity info, got %s", config.SecurityMinSeverity)
+	}
+	if domain.BoolValue(config.SecurityIgnoreTests, true) {
+		t.Errorf("Expected security.ignore_tests false, got %v", config.SecurityIgnoreTests)
+	}
+}
+
+func TestLoadDocumentationFromPyscnToml(t *testing.T) {
+	tempDir := t.TempDir()
+
+	configContent := `[documentation]
+enabled = true
+convention = "all"
+`
+	configPath := filepath.Join(tempDir, ".pyscn.toml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	loader := NewTomlConfigLoader()
+	config, err := loader.LoadConfig(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if !domain.BoolValue(config.DocumentationEnabled, false) {
+		t.Errorf("Expected documentation.enabled true, got %v", config.DocumentationEnabled)
+	}
+	if config.DocumentationConvention != "all" {
+		t.Errorf("Expected documentation.convention all, got %s", config.DocumentationConvention)
+	}
+}
+
+func TestLoadTypingFromPyprojectToml(t *testing.T) {
+	tempDir := t.TempDir()
+
+	configContent := `[tool.pyscn.typing]
+enabled = true
+`
+	configPath := filepath.Join(tempDir, "pyproject.toml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	loader := NewTomlConfigLoader()
+	config, err := loader.LoadConfig(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if !domain.BoolValue(config.TypingEnabled, false) {
+		t.Errorf("Expected typing.enabled true, got %v", config.TypingEnabled)
+	}
+}
+
 func TestLoadConfig_DirectPyprojectPathIgnoresSiblingPyscn(t *testing.T) {
 	tempDir := t.TempDir()
 
@@ -380,3 +458,149 @@ style = "hexagonal"
 		t.Errorf("Expected architecture style 'hexagonal', got %q", cfg.ArchitectureStyle)
 	}
 }
+
+func TestLoadArchitectureRuleSetsFromPyscnToml(t *testing.T) {
+	tempDir := t.TempDir()
+
+	configContent := `[[architecture.rule_sets]]
+name = "services"
+paths = ["services/*"]
+strict_mode = true
+
+[[architecture.rule_sets.layers]]
+name = "api"
+packages = ["api"]
+
+[[architecture.rule_sets.layers]]
+name = "core"
+packages = ["core"]
+
+[[architecture.rule_sets.rules]]
+from = "core"
+deny = ["api"]
+`
+	configPath := filepath.Join(tempDir, ".pyscn.toml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := NewTomlConfigLoader().LoadConfig(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if len(cfg.ArchitectureRuleSets) != 1 {
+		t.Fatalf("Expected 1 rule set, got %+v", cfg.ArchitectureRuleSets)
+	}
+	ruleSet := cfg.ArchitectureRuleSets[0]
+	if ruleSet.Name != "services" || len(ruleSet.Paths) != 1 || ruleSet.Paths[0] != "services/*" || !ruleSet.StrictMode {
+		t.Errorf("Unexpected rule set %+v", ruleSet)
+	}
+	if len(ruleSet.Layers) != 2 || ruleSet.Layers[1].Name != "core" {
+		t.Errorf("Expected the layers of the rule set, got %+v", ruleSet.Layers)
+	}
+	if len(ruleSet.Rules) != 1 || ruleSet.Rules[0].From != "core" || ruleSet.Rules[0].Deny[0] != "api" {
+		t.Errorf("Expected the rules of the rule set, got %+v", ruleSet.Rules)
+	}
+	if len(cfg.ArchitectureLayers) != 0 {
+		t.Errorf("Rule set layers must not leak into the global layers, got %+v", cfg.ArchitectureLayers)
+	}
+}
+
+func TestLoadAnalyzerScopesFromPyscnToml(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, ".pyscn.toml")
+	content := `[analysis]
+exclude_patterns = ["**/build/**"]
+
+[complexity]
+exclude_patterns = ["**/tests/**", "**/migrations/**"]
+
+[typing]
+include_patterns = ["src/**/*.py"]
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write .pyscn.toml: %v", err)
+	}
+
+	cfg, err := NewTomlConfigLoader().LoadConfig(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if len(cfg.AnalyzerScopes) != 2 {
+		t.Fatalf("Expected 2 analyzer scopes, got %v", cfg.AnalyzerScopes)
+	}
+	complexity := cfg.AnalyzerScopes[domain.AnalysisScopeComplexity]
+	if len(complexity.ExcludePatterns) != 2 || complexity.IncludePatterns != nil {
+		t.Errorf("Unexpected complexity scope: %+v", complexity)
+	}
+	typing := cfg.AnalyzerScopes[domain.AnalysisScopeTyping]
+	if len(typing.IncludePatterns) != 1 || typing.ExcludePatterns != nil {
+		t.Errorf("Unexpected typing scope: %+v", typing)
+	}
+	if _, ok := cfg.AnalyzerScopes[domain.AnalysisScopeDeadCode]; ok {
+		t.Error("Expected no dead code scope without section patterns")
+	}
+}
+
+func TestLoadCustomRulesFromPyprojectToml(t *testing.T) {
+	tempDir := t.TempDir()
+
+	configContent := `[tool.pyscn.custom_rules]
+files = ["rules/team.yaml"]
+`
+	configPath := filepath.Join(tempDir, "pyproject.toml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	loader := NewTomlConfigLoader()
+	config, err := loader.LoadConfig(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if len(config.CustomRulesFiles) != 1 || config.CustomRulesFiles[0] != "rules/team.yaml" {
+		t.Errorf("Expected custom_rules.files [rules/team.yaml], got %v", config.CustomRulesFiles)
+	}
+}
+
+func TestLoadComplexityBoilerplateFromPyscnToml(t *testing.T) {
+	tempDir := t.TempDir()
+
+	configContent := `[[complexity.boilerplate]]
+name = "argparse"
+match = { node = "Call", callee = "*.add_argument" }
+exclude_from_risk = true
+
+[[complexity.boilerplate]]
+name = "serializer"
+match = { node = ["FunctionDef", "AsyncFunctionDef"], name = "to_*", not_inside = "FunctionDef" }
+`
+	configPath := filepath.Join(tempDir, ".pyscn.toml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	loader := NewTomlConfigLoader()
+	config, err := loader.LoadConfig(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	patterns := config.ComplexityBoilerplate
+	if len(patterns) != 2 {
+		t.Fatalf("Expected 2 boilerplate patterns, got %+v", patterns)
+	}
+	argparse := patterns[0]
+	if argparse.Name != "argparse" || !argparse.ExcludeFromRisk ||
+		len(argparse.Match.Nodes) != 1 || argparse.Match.Nodes[0] != "Call" || argparse.Match.Callee != "*.add_argument" {
+		t.Errorf("Unexpected argparse pattern: %+v", argparse)
+	}
+	serializer := patterns[1]
+	if serializer.Name != "serializer" || serializer.ExcludeFromRisk || len(serializer.Match.Nodes) != 2 ||
+		serializer.Match.Name != "to_*" || len(serializer.Match.NotInside) != 1 {
+		t.Errorf("Unexpected serializer pattern: %+v", serializer)
+	}
+}
@@ -0,0 +1,185 @@
+// Package daemon implements the local socket protocol between the pyscn CLI
+// and a long-running `pyscn daemon`. A connection carries a single JSON
+// request followed by a single JSON response. Requests are handled one at a
+// time because running a command changes process-wide state such as the
+// working directory.
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Request methods
+const (
+	MethodRun      = "run"      // Run a CLI invocation, see Request.Args
+	MethodStatus   = "status"   // Report Status
+	MethodShutdown = "shutdown" // Stop serving
+)
+
+// dialTimeout keeps the CLI fast when no daemon is listening
+const dialTimeout = 200 * time.Millisecond
+
+// ErrAlreadyRunning is returned by Listen when a daemon already serves the
+// socket
+var ErrAlreadyRunning = errors.New("a pyscn daemon is already running")
+
+// Request is a call from the CLI to the daemon
+type Request struct {
+	Method  string   `json:"method"`
+	Version string   `json:"version"` // pyscn version of the client
+	Cwd     string   `json:"cwd,omitempty"`
+	Args    []string `json:"args,omitempty"` // Command line without the program name
+}
+
+// Response is the daemon's answer. Error is set when the request could not
+// be served at all, in which case the client runs the command itself.
+type Response struct {
+	Error    string  `json:"error,omitempty"`
+	ExitCode int     `json:"exit_code"`
+	Stdout   string  `json:"stdout,omitempty"`
+	Stderr   string  `json:"stderr,omitempty"`
+	Status   *Status `json:"status,omitempty"`
+}
+
+// Status describes a running daemon
+type Status struct {
+	Version     string    `json:"version"`
+	PID         int       `json:"pid"`
+	Socket      string    `json:"socket"`
+	StartedAt   time.Time `json:"started_at"`
+	Requests    int       `json:"requests"`
+	CachedFiles int       `json:"cached_files"`
+	CacheHits   int       `json:"cache_hits"`
+	CacheMisses int       `json:"cache_misses"`
+}
+
+// Handler serves one request
+type Handler func(ctx context.Context, req Request) Response
+
+// DefaultSocketPath returns the per-user socket location. PYSCN_DAEMON_SOCKET
+// overrides it.
+func DefaultSocketPath() string {
+	if path := os.Getenv("PYSCN_DAEMON_SOCKET"); path != "" {
+		return path
+	}
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "pyscn", "daemon.sock")
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("pyscn-%d", os.Getuid()), "daemon.sock")
+}
+
+// CheckSocketDir verifies that dir, the directory of a socket, is private
+// to the current user: a real directory rather than a symlink and, on unix,
+// owned by the user with mode 0700. Otherwise another user could have
+// created it first and listen on the socket in place of the daemon.
+func CheckSocketDir(dir string) error {
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return fmt.Errorf("failed to inspect socket directory: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("socket directory %s is not a directory", dir)
+	}
+	return checkPrivate(dir, info)
+}
+
+// Listen opens the socket at path, replacing a stale socket file left by a
+// daemon that did not shut down cleanly. Only the current user can connect,
+// and the directory of the socket must pass CheckSocketDir.
+func Listen(path string) (net.Listener, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	if err := CheckSocketDir(dir); err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.DialTimeout("unix", path, dialTimeout); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("%w on %s", ErrAlreadyRunning, path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket %s: %w", path, err)
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+	return listener, nil
+}
+
+// Serve handles connections on listener until ctx is done. Requests are
+// passed to handler one at a time.
+func Serve(ctx context.Context, listener net.Listener, handler Handler) error {
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer conn.Close()
+
+			var req Request
+			if err := json.NewDecoder(conn).Decode(&req); err != nil {
+				_ = json.NewEncoder(conn).Encode(Response{Error: fmt.Sprintf("invalid request: %v", err)})
+				return
+			}
+			mu.Lock()
+			resp := handler(ctx, req)
+			mu.Unlock()
+			_ = json.NewEncoder(conn).Encode(resp)
+		}()
+	}
+}
+
+// Call sends req to the daemon listening on socketPath and waits for its
+// response. Connecting fails fast when no daemon is running, and is refused
+// when the directory of the socket does not pass CheckSocketDir.
+func Call(socketPath string, req Request) (*Response, error) {
+	if err := CheckSocketDir(filepath.Dir(socketPath)); err != nil {
+		return nil, err
+	}
+	conn, err := net.DialTimeout("unix", socketPath, dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	var resp Response
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return &resp, nil
+}
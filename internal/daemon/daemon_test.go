@@ -0,0 +1,111 @@
+package daemon
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// shortSocketPath returns a socket path below the unix socket length limit
+func shortSocketPath(t *testing.T) string {
+	dir, err := os.MkdirTemp("", "pyscnd")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return filepath.Join(dir, "d.sock")
+}
+
+func TestServeAndCall(t *testing.T) {
+	path := shortSocketPath(t)
+	listener, err := Listen(path)
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- Serve(ctx, listener, func(ctx context.Context, req Request) Response {
+			if req.Method != MethodRun {
+				return Response{Error: "unexpected method"}
+			}
+			return Response{ExitCode: len(req.Args), Stdout: req.Cwd, Stderr: req.Version}
+		})
+	}()
+
+	resp, err := Call(path, Request{Method: MethodRun, Version: "v1", Cwd: "/src", Args: []string{"analyze", "."}})
+	require.NoError(t, err)
+	assert.Equal(t, Response{ExitCode: 2, Stdout: "/src", Stderr: "v1"}, *resp)
+
+	resp, err = Call(path, Request{Method: MethodStatus})
+	require.NoError(t, err)
+	assert.Equal(t, "unexpected method", resp.Error)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestCallWithoutDaemon(t *testing.T) {
+	_, err := Call(shortSocketPath(t), Request{Method: MethodStatus})
+	assert.Error(t, err)
+}
+
+func TestListen_ReplacesStaleSocket(t *testing.T) {
+	path := shortSocketPath(t)
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+
+	listener, err := Listen(path)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	_, err = Listen(path)
+	assert.ErrorIs(t, err, ErrAlreadyRunning)
+}
+
+func TestCheckSocketDir(t *testing.T) {
+	private := filepath.Dir(shortSocketPath(t))
+	require.NoError(t, CheckSocketDir(private))
+
+	open := filepath.Join(private, "open")
+	require.NoError(t, os.Mkdir(open, 0o700))
+	require.NoError(t, os.Chmod(open, 0o755))
+	link := filepath.Join(private, "link")
+	require.NoError(t, os.Symlink(private, link))
+	file := filepath.Join(private, "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+
+	for _, dir := range []string{open, link, file, filepath.Join(private, "missing")} {
+		assert.Error(t, CheckSocketDir(dir), dir)
+	}
+}
+
+func TestListenAndCallRefuseSharedDirectory(t *testing.T) {
+	dir := filepath.Dir(shortSocketPath(t))
+	require.NoError(t, os.Chmod(dir, 0o755))
+	path := filepath.Join(dir, "d.sock")
+
+	_, err := Listen(path)
+	assert.ErrorContains(t, err, "want 0700")
+
+	// A daemon someone else started in the directory is not called
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	defer listener.Close()
+	_, err = Call(path, Request{Method: MethodStatus})
+	assert.ErrorContains(t, err, "want 0700")
+}
+
+func TestDefaultSocketPath(t *testing.T) {
+	t.Setenv("PYSCN_DAEMON_SOCKET", "/custom/pyscn.sock")
+	assert.Equal(t, "/custom/pyscn.sock", DefaultSocketPath())
+
+	t.Setenv("PYSCN_DAEMON_SOCKET", "")
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	assert.Equal(t, filepath.Join("/run/user/1000", "pyscn", "daemon.sock"), DefaultSocketPath())
+}
@@ -0,0 +1,22 @@
+//go:build unix
+
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// checkPrivate verifies that the socket directory dir is owned by the
+// current user and closed to everyone else
+func checkPrivate(dir string, info os.FileInfo) error {
+	if perm := info.Mode().Perm(); perm != 0o700 {
+		return fmt.Errorf("socket directory %s has mode %04o, want 0700", dir, perm)
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok || int(stat.Uid) != os.Getuid() {
+		return fmt.Errorf("socket directory %s is not owned by the current user", dir)
+	}
+	return nil
+}
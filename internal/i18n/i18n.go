@@ -0,0 +1,134 @@
+// Package i18n translates the text of human-readable reports.
+//
+// Messages are keyed by their English text, so English needs no catalog and
+// a message missing from a catalog falls back to English. Catalogs are JSON
+// objects from English to translated text in locales/<lang>.json.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+// DefaultLanguage is the language reports use when none is configured
+const DefaultLanguage = "en"
+
+var (
+	catalogsOnce sync.Once
+	catalogs     map[string]map[string]string
+	catalogsErr  error
+)
+
+// loadCatalogs reads the embedded catalogs once
+func loadCatalogs() (map[string]map[string]string, error) {
+	catalogsOnce.Do(func() {
+		catalogs = map[string]map[string]string{DefaultLanguage: {}}
+		entries, err := localeFS.ReadDir("locales")
+		if err != nil {
+			catalogsErr = err
+			return
+		}
+		for _, entry := range entries {
+			lang := strings.TrimSuffix(entry.Name(), ".json")
+			data, err := localeFS.ReadFile("locales/" + entry.Name())
+			if err != nil {
+				catalogsErr = err
+				return
+			}
+			messages := make(map[string]string)
+			if err := json.Unmarshal(data, &messages); err != nil {
+				catalogsErr = fmt.Errorf("invalid %s catalog: %w", lang, err)
+				return
+			}
+			catalogs[lang] = messages
+		}
+	})
+	return catalogs, catalogsErr
+}
+
+// Languages returns the codes of the supported languages in sorted order
+func Languages() []string {
+	all, _ := loadCatalogs()
+	languages := make([]string, 0, len(all))
+	for lang := range all {
+		languages = append(languages, lang)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// ParseLanguage returns the supported language code for lang. Case and a
+// region or encoding suffix are ignored, so "ja_JP.UTF-8" and "JA" both
+// give "ja". An empty lang gives DefaultLanguage.
+func ParseLanguage(lang string) (string, error) {
+	code := strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(code, "-_."); i >= 0 {
+		code = code[:i]
+	}
+	if code == "" {
+		return DefaultLanguage, nil
+	}
+	all, err := loadCatalogs()
+	if err != nil {
+		return "", err
+	}
+	if _, ok := all[code]; !ok {
+		return "", fmt.Errorf("unsupported language %q (supported: %s)", lang, strings.Join(Languages(), ", "))
+	}
+	return code, nil
+}
+
+// Translator translates messages into one language
+type Translator struct {
+	lang     string
+	messages map[string]string
+}
+
+// NewTranslator returns a translator for lang, which must be a code
+// returned by ParseLanguage. Unknown languages translate into English.
+func NewTranslator(lang string) *Translator {
+	all, _ := loadCatalogs()
+	if _, ok := all[lang]; !ok {
+		lang = DefaultLanguage
+	}
+	return &Translator{lang: lang, messages: all[lang]}
+}
+
+// Language returns the code of the language translated into
+func (t *Translator) Language() string {
+	if t == nil {
+		return DefaultLanguage
+	}
+	return t.lang
+}
+
+// T translates message and, when args are given, formats it with them like
+// fmt.Sprintf. A nil translator translates into English.
+func (t *Translator) T(message string, args ...any) string {
+	if t != nil {
+		if translated, ok := t.messages[message]; ok && translated != "" {
+			message = translated
+		}
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// Has reports whether lang has its own translation of message
+func Has(lang, message string) bool {
+	all, _ := loadCatalogs()
+	if lang == DefaultLanguage {
+		return true
+	}
+	translated, ok := all[lang][message]
+	return ok && translated != ""
+}
@@ -0,0 +1,108 @@
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestParseLanguage(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "", want: "en"},
+		{input: "en", want: "en"},
+		{input: "JA", want: "ja"},
+		{input: "ja_JP.UTF-8", want: "ja"},
+		{input: "en-US", want: "en"},
+		{input: "fr", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseLanguage(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseLanguage(%q) = %q, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseLanguage(%q) returned error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseLanguage(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranslator_T(t *testing.T) {
+	ja := NewTranslator("ja")
+	if got := ja.T("Summary"); got != "概要" {
+		t.Errorf("T(Summary) = %q, want %q", got, "概要")
+	}
+	if got := ja.T("Showing top %d of %d functions", 10, 42); got != "42 件中、上位 10 件の関数を表示" {
+		t.Errorf("T with args = %q", got)
+	}
+	if got := ja.T("not in any catalog"); got != "not in any catalog" {
+		t.Errorf("untranslated message = %q, want English fallback", got)
+	}
+
+	var nilTranslator *Translator
+	if got := nilTranslator.T("Generated: %s", "now"); got != "Generated: now" {
+		t.Errorf("nil translator T = %q", got)
+	}
+	if nilTranslator.Language() != DefaultLanguage {
+		t.Errorf("nil translator Language = %q", nilTranslator.Language())
+	}
+	if NewTranslator("xx").Language() != DefaultLanguage {
+		t.Error("unknown language should translate into English")
+	}
+}
+
+var verbPattern = regexp.MustCompile(`%(?:\[(\d+)\])?([-+# 0]*\d*(?:\.\d+)?[a-zA-Z%])`)
+
+// verbs counts the verbs of a format string by argument index, so a
+// translation may reorder its arguments with explicit indexes
+func verbs(format string) map[string]int {
+	counts := make(map[string]int)
+	next := 1
+	for _, match := range verbPattern.FindAllStringSubmatch(format, -1) {
+		if match[2] == "%" {
+			continue
+		}
+		index := next
+		if match[1] != "" {
+			index, _ = strconv.Atoi(match[1])
+		}
+		counts[fmt.Sprintf("%d:%%%s", index, match[2])]++
+		next = index + 1
+	}
+	return counts
+}
+
+func TestCatalogsKeepFormatVerbs(t *testing.T) {
+	all, err := loadCatalogs()
+	if err != nil {
+		t.Fatalf("loading catalogs: %v", err)
+	}
+	for lang, messages := range all {
+		for message, translated := range messages {
+			want, got := verbs(message), verbs(translated)
+			if len(want) != len(got) {
+				t.Errorf("%s: %q has verbs %v, translation %q has %v", lang, message, want, translated, got)
+				continue
+			}
+			for verb, count := range want {
+				if got[verb] != count {
+					t.Errorf("%s: %q has verbs %v, translation %q has %v", lang, message, want, translated, got)
+					break
+				}
+			}
+		}
+	}
+}
@@ -156,13 +156,14 @@ func (d *Detector) analyzeString(node *sitter.Node, source []byte) *domain.MockD
 	// Return the highest severity match
 	match := selectHighestSeverity(matches)
 
+	loc := parser.NodeLocation(node, source)
 	return &domain.MockDataFinding{
 		Location: domain.MockDataLocation{
 			FilePath:    "",
-			StartLine:   int(node.StartPoint().Row) + 1,
-			EndLine:     int(node.EndPoint().Row) + 1,
-			StartColumn: int(node.StartPoint().Column),
-			EndColumn:   int(node.EndPoint().Column),
+			StartLine:   loc.StartLine,
+			EndLine:     loc.EndLine,
+			StartColumn: loc.StartCol,
+			EndColumn:   loc.EndCol,
 		},
 		Value:       value,
 		Type:        match.Type,
@@ -184,13 +185,14 @@ func (d *Detector) analyzeIdentifier(node *sitter.Node, source []byte) *domain.M
 
 	match := matches[0]
 
+	loc := parser.NodeLocation(node, source)
 	return &domain.MockDataFinding{
 		Location: domain.MockDataLocation{
 			FilePath:    "",
-			StartLine:   int(node.StartPoint().Row) + 1,
-			EndLine:     int(node.EndPoint().Row) + 1,
-			StartColumn: int(node.StartPoint().Column),
-			EndColumn:   int(node.EndPoint().Column),
+			StartLine:   loc.StartLine,
+			EndLine:     loc.EndLine,
+			StartColumn: loc.StartCol,
+			EndColumn:   loc.EndCol,
 		},
 		Value:        name,
 		Type:         match.Type,
@@ -210,13 +212,14 @@ func (d *Detector) analyzeComment(node *sitter.Node, source []byte) *domain.Mock
 		return nil
 	}
 
+	loc := parser.NodeLocation(node, source)
 	return &domain.MockDataFinding{
 		Location: domain.MockDataLocation{
 			FilePath:    "",
-			StartLine:   int(node.StartPoint().Row) + 1,
-			EndLine:     int(node.EndPoint().Row) + 1,
-			StartColumn: int(node.StartPoint().Column),
-			EndColumn:   int(node.EndPoint().Column),
+			StartLine:   loc.StartLine,
+			EndLine:     loc.EndLine,
+			StartColumn: loc.StartCol,
+			EndColumn:   loc.EndCol,
 		},
 		Value:       comment,
 		Type:        match.Type,
@@ -259,13 +262,14 @@ func (d *Detector) analyzeAssignment(node *sitter.Node, source []byte) *domain.M
 		stringMatches := d.heuristics.CheckString(stringContent)
 		if len(stringMatches) > 0 {
 			match := selectHighestSeverity(stringMatches)
+			loc := parser.NodeLocation(node, source)
 			return &domain.MockDataFinding{
 				Location: domain.MockDataLocation{
 					FilePath:    "",
-					StartLine:   int(node.StartPoint().Row) + 1,
-					EndLine:     int(node.EndPoint().Row) + 1,
-					StartColumn: int(node.StartPoint().Column),
-					EndColumn:   int(node.EndPoint().Column),
+					StartLine:   loc.StartLine,
+					EndLine:     loc.EndLine,
+					StartColumn: loc.StartCol,
+					EndColumn:   loc.EndCol,
 				},
 				Value:        value,
 				Type:         match.Type,
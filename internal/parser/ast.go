@@ -101,13 +101,18 @@ const (
 	NodeTypeNode      NodeType = "type"
 )
 
-// Location represents the position of a node in the source code
+// Location represents the position of a node in the source code. Lines are
+// 1-based. Columns are 0-based and count code points, not bytes, so that a
+// column names the same character in any editor. StartByte and EndByte are
+// the exact byte range of the node in the source.
 type Location struct {
 	File      string
 	StartLine int
 	StartCol  int
 	EndLine   int
 	EndCol    int
+	StartByte int
+	EndByte   int
 }
 
 // Node represents an AST node
@@ -496,20 +501,28 @@ func (n *Node) Copy() *Node {
 }
 
 // RefreshParentLinks rebuilds Parent pointers from the canonical child graph.
+// It walks the tree with an explicit stack, so deeply nested trees do not
+// grow the goroutine stack.
 func (n *Node) RefreshParentLinks() {
-	refreshParentLinks(n, nil, make(map[*Node]struct{}))
-}
-
-func refreshParentLinks(node, parent *Node, seen map[*Node]struct{}) {
-	if node == nil {
-		return
-	}
-	if _, ok := seen[node]; ok {
-		return
-	}
-	seen[node] = struct{}{}
-	node.Parent = parent
-	for _, child := range node.GetChildren() {
-		refreshParentLinks(child, node, seen)
+	type link struct{ node, parent *Node }
+	seen := make(map[*Node]struct{})
+	stack := []link{{n, nil}}
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if top.node == nil {
+			continue
+		}
+		if _, ok := seen[top.node]; ok {
+			continue
+		}
+		seen[top.node] = struct{}{}
+		top.node.Parent = top.parent
+		// Push in reverse so that children are visited in order, as the
+		// first path to a shared node decides its parent
+		children := top.node.GetChildren()
+		for i := len(children) - 1; i >= 0; i-- {
+			stack = append(stack, link{children[i], top.node})
+		}
 	}
 }
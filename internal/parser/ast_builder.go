@@ -4,19 +4,55 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	sitter "github.com/smacker/go-tree-sitter"
 )
 
-// ASTBuilder converts tree-sitter parse trees to internal AST representation
+// ASTBuilder converts tree-sitter parse trees to internal AST representation.
+//
+// Building is driven by an explicit work stack rather than unbounded
+// recursion: a builder descends at most maxBuildDepth levels, and a subtree
+// below that limit is built as a work item of its own before the builder
+// that needed it is retried. Deeply nested generated code therefore costs
+// heap, not goroutine stack.
 type ASTBuilder struct {
 	source []byte
+
+	// depth is the number of buildNode calls on the Go stack, at most
+	// maxDepth
+	depth    int
+	maxDepth int
+	// built holds complete nodes of finished work items and of retried
+	// builds, keyed by the tree-sitter node they were built from
+	built map[*sitter.Node]*Node
+	// taken lists the nodes handed to the builders on the stack, so that
+	// the ones handed to a builder that has to be retried can be returned
+	// to built
+	taken []builtNode
+	// deferred lists the nodes below the depth limit in the current build
+	deferred []*sitter.Node
+
+	// slab is the remainder of the current block of preallocated nodes,
+	// and slabSize the size of that block
+	slab     []Node
+	slabSize int
 }
 
+// builtNode pairs a node with the tree-sitter node it was built from
+type builtNode struct {
+	tsNode *sitter.Node
+	node   *Node
+}
+
+// maxBuildDepth is the default bound on the nesting of buildNode calls
+const maxBuildDepth = 256
+
 // NewASTBuilder creates a new AST builder
 func NewASTBuilder(source []byte) *ASTBuilder {
 	return &ASTBuilder{
-		source: source,
+		source:   source,
+		maxDepth: maxBuildDepth,
 	}
 }
 
@@ -50,20 +86,80 @@ func (b *ASTBuilder) Build(tree *sitter.Tree) (*Node, error) {
 		return nil, fmt.Errorf("root node is nil")
 	}
 
-	ast := b.buildNode(rootNode)
+	ast := b.buildTree(rootNode)
+	b.releaseSlab()
 	if ast != nil {
 		ast.RefreshParentLinks()
 	}
 	return ast, nil
 }
 
-// buildNode recursively builds AST nodes from tree-sitter nodes
+// buildTree builds the AST of a tree-sitter node from a work stack. The top
+// item is built; if its build hit the depth limit, the subtrees it could not
+// reach are pushed and the item is retried once they are built.
+func (b *ASTBuilder) buildTree(root *sitter.Node) *Node {
+	b.built = make(map[*sitter.Node]*Node)
+	defer func() {
+		b.built, b.taken, b.deferred = nil, nil, nil
+	}()
+
+	work := []*sitter.Node{root}
+	for {
+		top := work[len(work)-1]
+		b.deferred = b.deferred[:0]
+		node := b.buildNode(top)
+		if len(b.deferred) > 0 {
+			work = append(work, b.deferred...)
+			continue
+		}
+
+		b.taken = b.taken[:0]
+		work = work[:len(work)-1]
+		if len(work) == 0 {
+			return node
+		}
+		b.built[top] = node
+	}
+}
+
+// buildNode builds the AST node of a tree-sitter node. A node built before
+// is handed out once. Below the depth limit the tree-sitter node is deferred
+// and nil is returned; the builders above it are then incomplete and their
+// result is discarded, but the complete subtrees they were handed are kept
+// for the retry.
 func (b *ASTBuilder) buildNode(tsNode *sitter.Node) *Node {
 	if tsNode == nil {
 		return nil
 	}
+	if node, ok := b.built[tsNode]; ok {
+		delete(b.built, tsNode)
+		b.taken = append(b.taken, builtNode{tsNode, node})
+		return node
+	}
+	if b.depth >= b.maxDepth {
+		b.deferred = append(b.deferred, tsNode)
+		return nil
+	}
 
-	nodeType := tsNode.Type()
+	deferred, taken := len(b.deferred), len(b.taken)
+	b.depth++
+	node := b.buildNodeOfType(tsNode)
+	b.depth--
+
+	if len(b.deferred) > deferred {
+		for _, child := range b.taken[taken:] {
+			b.built[child.tsNode] = child.node
+		}
+		b.taken = b.taken[:taken]
+		return node
+	}
+	b.taken = append(b.taken[:taken], builtNode{tsNode, node})
+	return node
+}
+
+// buildNodeOfType dispatches to the builder of the tree-sitter node type
+func (b *ASTBuilder) buildNodeOfType(tsNode *sitter.Node) *Node {
+	nodeType := b.nodeType(tsNode)
 
 	// Create appropriate AST node based on tree-sitter node type
 	switch nodeType {
@@ -190,7 +286,7 @@ func (b *ASTBuilder) buildNode(tsNode *sitter.Node) *Node {
 
 	default:
 		// For unhandled types, create a generic node with children
-		node := NewNode(NodeType(nodeType))
+		node := b.newNode(NodeType(nodeType))
 		node.Location = b.getLocation(tsNode)
 
 		childCount := int(tsNode.ChildCount())
@@ -208,7 +304,7 @@ func (b *ASTBuilder) buildNode(tsNode *sitter.Node) *Node {
 
 // buildModule builds a module node
 func (b *ASTBuilder) buildModule(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeModule)
+	node := b.newNode(NodeModule)
 	node.Location = b.getLocation(tsNode)
 
 	childCount := int(tsNode.ChildCount())
@@ -226,7 +322,7 @@ func (b *ASTBuilder) buildModule(tsNode *sitter.Node) *Node {
 
 // buildFunctionDef builds a function definition node
 func (b *ASTBuilder) buildFunctionDef(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeFunctionDef)
+	node := b.newNode(NodeFunctionDef)
 	node.Location = b.getLocation(tsNode)
 
 	// Check if it's async
@@ -274,7 +370,7 @@ func (b *ASTBuilder) buildFunctionDef(tsNode *sitter.Node) *Node {
 
 // buildClassDef builds a class definition node
 func (b *ASTBuilder) buildClassDef(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeClassDef)
+	node := b.newNode(NodeClassDef)
 	node.Location = b.getLocation(tsNode)
 
 	// Get class name
@@ -299,7 +395,7 @@ func (b *ASTBuilder) buildClassDef(tsNode *sitter.Node) *Node {
 
 // buildIfStatement builds an if statement node
 func (b *ASTBuilder) buildIfStatement(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeIf)
+	node := b.newNode(NodeIf)
 	node.Location = b.getLocation(tsNode)
 
 	// Get condition
@@ -358,7 +454,7 @@ func (b *ASTBuilder) buildIfStatement(tsNode *sitter.Node) *Node {
 
 // buildElifClause builds an elif clause node (similar to if statement)
 func (b *ASTBuilder) buildElifClause(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeElifClause)
+	node := b.newNode(NodeElifClause)
 	node.Location = b.getLocation(tsNode)
 
 	// Get condition
@@ -381,7 +477,7 @@ func (b *ASTBuilder) buildElifClause(tsNode *sitter.Node) *Node {
 
 // buildElseClause builds an else clause node
 func (b *ASTBuilder) buildElseClause(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeElseClause)
+	node := b.newNode(NodeElseClause)
 	node.Location = b.getLocation(tsNode)
 
 	// Get body
@@ -396,7 +492,7 @@ func (b *ASTBuilder) buildElseClause(tsNode *sitter.Node) *Node {
 
 // buildForStatement builds a for loop node
 func (b *ASTBuilder) buildForStatement(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeFor)
+	node := b.newNode(NodeFor)
 	node.Location = b.getLocation(tsNode)
 
 	// Check if it's async
@@ -436,7 +532,7 @@ func (b *ASTBuilder) buildForStatement(tsNode *sitter.Node) *Node {
 
 // buildWhileStatement builds a while loop node
 func (b *ASTBuilder) buildWhileStatement(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeWhile)
+	node := b.newNode(NodeWhile)
 	node.Location = b.getLocation(tsNode)
 
 	// Get condition
@@ -463,7 +559,7 @@ func (b *ASTBuilder) buildWhileStatement(tsNode *sitter.Node) *Node {
 
 // buildWithStatement builds a with statement node
 func (b *ASTBuilder) buildWithStatement(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeWith)
+	node := b.newNode(NodeWith)
 	node.Location = b.getLocation(tsNode)
 
 	// Check if it's async
@@ -475,7 +571,7 @@ func (b *ASTBuilder) buildWithStatement(tsNode *sitter.Node) *Node {
 	childCount := int(tsNode.ChildCount())
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
-		if child != nil && child.Type() == "with_clause" {
+		if child != nil && b.nodeType(child) == "with_clause" {
 			for _, withItem := range b.buildWithItems(child) {
 				node.AddChild(withItem)
 			}
@@ -494,7 +590,7 @@ func (b *ASTBuilder) buildWithStatement(tsNode *sitter.Node) *Node {
 
 // buildTryStatement builds a try statement node
 func (b *ASTBuilder) buildTryStatement(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeTry)
+	node := b.newNode(NodeTry)
 	node.Location = b.getLocation(tsNode)
 
 	// Get body
@@ -508,7 +604,7 @@ func (b *ASTBuilder) buildTryStatement(tsNode *sitter.Node) *Node {
 	childCount := int(tsNode.ChildCount())
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
-		if child != nil && child.Type() == "except_clause" {
+		if child != nil && b.nodeType(child) == "except_clause" {
 			if handler := b.buildExceptHandler(child); handler != nil {
 				node.Handlers = append(node.Handlers, handler)
 			}
@@ -518,7 +614,7 @@ func (b *ASTBuilder) buildTryStatement(tsNode *sitter.Node) *Node {
 	// Get else clause
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
-		if child != nil && child.Type() == "else_clause" {
+		if child != nil && b.nodeType(child) == "else_clause" {
 			if bodyNode := b.getChildByFieldName(child, "body"); bodyNode != nil {
 				if body := b.buildNode(bodyNode); body != nil {
 					node.Orelse = b.extractBlockBody(body, node)
@@ -532,12 +628,12 @@ func (b *ASTBuilder) buildTryStatement(tsNode *sitter.Node) *Node {
 	// This is similar to how except_clause is handled in buildExceptHandler
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
-		if child != nil && child.Type() == "finally_clause" {
+		if child != nil && b.nodeType(child) == "finally_clause" {
 			// Extract block directly from finally_clause children
 			finallyChildCount := int(child.ChildCount())
 			for j := 0; j < finallyChildCount; j++ {
 				finallyChild := child.Child(j)
-				if finallyChild != nil && finallyChild.Type() == "block" {
+				if finallyChild != nil && b.nodeType(finallyChild) == "block" {
 					if body := b.buildNode(finallyChild); body != nil {
 						node.Finalbody = b.extractBlockBody(body, node)
 					}
@@ -552,7 +648,7 @@ func (b *ASTBuilder) buildTryStatement(tsNode *sitter.Node) *Node {
 
 // buildMatchStatement builds a match statement node (Python 3.10+)
 func (b *ASTBuilder) buildMatchStatement(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeMatch)
+	node := b.newNode(NodeMatch)
 	node.Location = b.getLocation(tsNode)
 
 	// Get subject
@@ -565,7 +661,7 @@ func (b *ASTBuilder) buildMatchStatement(tsNode *sitter.Node) *Node {
 		childCount := int(body.ChildCount())
 		for i := 0; i < childCount; i++ {
 			child := body.Child(i)
-			if child != nil && child.Type() == "case_clause" {
+			if child != nil && b.nodeType(child) == "case_clause" {
 				if caseNode := b.buildMatchCase(child); caseNode != nil {
 					node.AddToBody(caseNode)
 				}
@@ -580,13 +676,13 @@ func (b *ASTBuilder) buildMatchStatement(tsNode *sitter.Node) *Node {
 
 // buildReturnStatement builds a return statement node
 func (b *ASTBuilder) buildReturnStatement(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeReturn)
+	node := b.newNode(NodeReturn)
 	node.Location = b.getLocation(tsNode)
 
 	childCount := int(tsNode.ChildCount())
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
-		if child != nil && child.Type() != "return" {
+		if child != nil && b.nodeType(child) != "return" {
 			node.Value = b.buildNode(child)
 			break
 		}
@@ -597,13 +693,13 @@ func (b *ASTBuilder) buildReturnStatement(tsNode *sitter.Node) *Node {
 
 // buildDeleteStatement builds a delete statement node
 func (b *ASTBuilder) buildDeleteStatement(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeDelete)
+	node := b.newNode(NodeDelete)
 	node.Location = b.getLocation(tsNode)
 
 	childCount := int(tsNode.ChildCount())
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
-		if child != nil && child.Type() != "del" {
+		if child != nil && b.nodeType(child) != "del" {
 			if target := b.buildNode(child); target != nil {
 				node.Targets = append(node.Targets, target)
 			}
@@ -615,13 +711,13 @@ func (b *ASTBuilder) buildDeleteStatement(tsNode *sitter.Node) *Node {
 
 // buildRaiseStatement builds a raise statement node
 func (b *ASTBuilder) buildRaiseStatement(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeRaise)
+	node := b.newNode(NodeRaise)
 	node.Location = b.getLocation(tsNode)
 
 	childCount := int(tsNode.ChildCount())
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
-		if child != nil && child.Type() != "raise" {
+		if child != nil && b.nodeType(child) != "raise" {
 			// First non-raise child is the exception
 			if node.Value == nil {
 				node.Value = b.buildNode(child)
@@ -637,14 +733,14 @@ func (b *ASTBuilder) buildRaiseStatement(tsNode *sitter.Node) *Node {
 
 // buildAssertStatement builds an assert statement node
 func (b *ASTBuilder) buildAssertStatement(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeAssert)
+	node := b.newNode(NodeAssert)
 	node.Location = b.getLocation(tsNode)
 
 	childCount := int(tsNode.ChildCount())
 	argCount := 0
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
-		if child != nil && child.Type() != "assert" && child.Type() != "," {
+		if child != nil && b.nodeType(child) != "assert" && b.nodeType(child) != "," {
 			if argCount == 0 {
 				node.Test = b.buildNode(child)
 			} else {
@@ -659,7 +755,7 @@ func (b *ASTBuilder) buildAssertStatement(tsNode *sitter.Node) *Node {
 
 // buildImportStatement builds an import statement node
 func (b *ASTBuilder) buildImportStatement(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeImport)
+	node := b.newNode(NodeImport)
 	node.Location = b.getLocation(tsNode)
 
 	// Tree-sitter uses "name" field for import names
@@ -669,10 +765,10 @@ func (b *ASTBuilder) buildImportStatement(tsNode *sitter.Node) *Node {
 		child := tsNode.Child(i)
 
 		if fieldName == "name" && child != nil {
-			if child.Type() == "dotted_name" {
+			if b.nodeType(child) == "dotted_name" {
 				// Simple import
 				node.Names = append(node.Names, b.getNodeText(child))
-			} else if child.Type() == "aliased_import" {
+			} else if b.nodeType(child) == "aliased_import" {
 				// Import with alias
 				if alias := b.buildAlias(child); alias != nil {
 					node.AddChild(alias)
@@ -690,7 +786,7 @@ func (b *ASTBuilder) buildImportStatement(tsNode *sitter.Node) *Node {
 
 // buildImportFromStatement builds an import from statement node
 func (b *ASTBuilder) buildImportFromStatement(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeImportFrom)
+	node := b.newNode(NodeImportFrom)
 	node.Location = b.getLocation(tsNode)
 
 	// Count leading dots for relative imports
@@ -704,14 +800,14 @@ func (b *ASTBuilder) buildImportFromStatement(tsNode *sitter.Node) *Node {
 	// Get module name
 	if moduleNode := b.getChildByFieldName(tsNode, "module_name"); moduleNode != nil {
 		// Handle relative imports
-		if moduleNode.Type() == "relative_import" {
+		if b.nodeType(moduleNode) == "relative_import" {
 			// Count dots in import_prefix
 			for i := 0; i < int(moduleNode.ChildCount()); i++ {
 				child := moduleNode.Child(i)
-				if child != nil && child.Type() == "import_prefix" {
+				if child != nil && b.nodeType(child) == "import_prefix" {
 					dots := b.getNodeText(child)
 					node.Level = len(dots)
-				} else if child != nil && child.Type() == "dotted_name" {
+				} else if child != nil && b.nodeType(child) == "dotted_name" {
 					node.Module = b.getNodeText(child)
 				}
 			}
@@ -728,9 +824,9 @@ func (b *ASTBuilder) buildImportFromStatement(tsNode *sitter.Node) *Node {
 
 		if fieldName == "name" && child != nil {
 			// Handle each imported name
-			if child.Type() == "dotted_name" || child.Type() == "identifier" {
+			if b.nodeType(child) == "dotted_name" || b.nodeType(child) == "identifier" {
 				node.Names = append(node.Names, b.getNodeText(child))
-			} else if child.Type() == "aliased_import" {
+			} else if b.nodeType(child) == "aliased_import" {
 				// Handle aliased imports - extract the original name
 				if nameChild := b.getChildByFieldName(child, "name"); nameChild != nil {
 					node.Names = append(node.Names, b.getNodeText(nameChild))
@@ -740,7 +836,7 @@ func (b *ASTBuilder) buildImportFromStatement(tsNode *sitter.Node) *Node {
 					node.AddChild(alias)
 				}
 			}
-		} else if child != nil && child.Type() == "wildcard_import" {
+		} else if child != nil && b.nodeType(child) == "wildcard_import" {
 			// Handle wildcard imports (from module import *)
 			node.Names = append(node.Names, "*")
 		}
@@ -751,13 +847,13 @@ func (b *ASTBuilder) buildImportFromStatement(tsNode *sitter.Node) *Node {
 
 // buildGlobalStatement builds a global statement node
 func (b *ASTBuilder) buildGlobalStatement(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeGlobal)
+	node := b.newNode(NodeGlobal)
 	node.Location = b.getLocation(tsNode)
 
 	childCount := int(tsNode.ChildCount())
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
-		if child != nil && child.Type() == "identifier" {
+		if child != nil && b.nodeType(child) == "identifier" {
 			node.Names = append(node.Names, b.getNodeText(child))
 		}
 	}
@@ -767,13 +863,13 @@ func (b *ASTBuilder) buildGlobalStatement(tsNode *sitter.Node) *Node {
 
 // buildNonlocalStatement builds a nonlocal statement node
 func (b *ASTBuilder) buildNonlocalStatement(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeNonlocal)
+	node := b.newNode(NodeNonlocal)
 	node.Location = b.getLocation(tsNode)
 
 	childCount := int(tsNode.ChildCount())
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
-		if child != nil && child.Type() == "identifier" {
+		if child != nil && b.nodeType(child) == "identifier" {
 			node.Names = append(node.Names, b.getNodeText(child))
 		}
 	}
@@ -791,14 +887,14 @@ func (b *ASTBuilder) buildExpressionStatement(tsNode *sitter.Node) *Node {
 		}
 	}
 
-	node := NewNode(NodeExpr)
+	node := b.newNode(NodeExpr)
 	node.Location = b.getLocation(tsNode)
 	return node
 }
 
 // buildAssignment builds an assignment node
 func (b *ASTBuilder) buildAssignment(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeAssign)
+	node := b.newNode(NodeAssign)
 	node.Location = b.getLocation(tsNode)
 
 	// Get left-hand side (targets)
@@ -825,7 +921,7 @@ func (b *ASTBuilder) buildAssignment(tsNode *sitter.Node) *Node {
 
 // buildAugmentedAssignment builds an augmented assignment node
 func (b *ASTBuilder) buildAugmentedAssignment(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeAugAssign)
+	node := b.newNode(NodeAugAssign)
 	node.Location = b.getLocation(tsNode)
 
 	// Get target
@@ -850,21 +946,21 @@ func (b *ASTBuilder) buildAugmentedAssignment(tsNode *sitter.Node) *Node {
 
 // buildPassStatement builds a pass statement node
 func (b *ASTBuilder) buildPassStatement(tsNode *sitter.Node) *Node {
-	node := NewNode(NodePass)
+	node := b.newNode(NodePass)
 	node.Location = b.getLocation(tsNode)
 	return node
 }
 
 // buildBreakStatement builds a break statement node
 func (b *ASTBuilder) buildBreakStatement(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeBreak)
+	node := b.newNode(NodeBreak)
 	node.Location = b.getLocation(tsNode)
 	return node
 }
 
 // buildContinueStatement builds a continue statement node
 func (b *ASTBuilder) buildContinueStatement(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeContinue)
+	node := b.newNode(NodeContinue)
 	node.Location = b.getLocation(tsNode)
 	return node
 }
@@ -880,7 +976,7 @@ func (b *ASTBuilder) buildDecoratedDefinition(tsNode *sitter.Node) *Node {
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
 		if child != nil {
-			switch child.Type() {
+			switch b.nodeType(child) {
 			case "decorator":
 				dec := b.buildDecorator(child)
 				if dec != nil {
@@ -903,7 +999,7 @@ func (b *ASTBuilder) buildDecoratedDefinition(tsNode *sitter.Node) *Node {
 
 // buildBinaryOp builds a binary operation node
 func (b *ASTBuilder) buildBinaryOp(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeBinOp)
+	node := b.newNode(NodeBinOp)
 	node.Location = b.getLocation(tsNode)
 
 	if left := b.getChildByFieldName(tsNode, "left"); left != nil {
@@ -923,7 +1019,7 @@ func (b *ASTBuilder) buildBinaryOp(tsNode *sitter.Node) *Node {
 
 // buildUnaryOp builds a unary operation node
 func (b *ASTBuilder) buildUnaryOp(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeUnaryOp)
+	node := b.newNode(NodeUnaryOp)
 	node.Location = b.getLocation(tsNode)
 
 	if operator := b.getChildByFieldName(tsNode, "operator"); operator != nil {
@@ -956,7 +1052,7 @@ func (b *ASTBuilder) buildUnaryOp(tsNode *sitter.Node) *Node {
 
 // buildNamedExpr builds a named expression node (walrus operator)
 func (b *ASTBuilder) buildNamedExpr(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeNamedExpr)
+	node := b.newNode(NodeNamedExpr)
 	node.Location = b.getLocation(tsNode)
 
 	// Get name (target)
@@ -974,7 +1070,7 @@ func (b *ASTBuilder) buildNamedExpr(tsNode *sitter.Node) *Node {
 
 // buildBoolOp builds a boolean operation node
 func (b *ASTBuilder) buildBoolOp(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeBoolOp)
+	node := b.newNode(NodeBoolOp)
 	node.Location = b.getLocation(tsNode)
 
 	if operator := b.getChildByFieldName(tsNode, "operator"); operator != nil {
@@ -994,7 +1090,7 @@ func (b *ASTBuilder) buildBoolOp(tsNode *sitter.Node) *Node {
 
 // buildCompare builds a comparison operation node
 func (b *ASTBuilder) buildCompare(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeCompare)
+	node := b.newNode(NodeCompare)
 	node.Location = b.getLocation(tsNode)
 
 	childCount := int(tsNode.ChildCount())
@@ -1016,7 +1112,7 @@ func (b *ASTBuilder) buildCompare(tsNode *sitter.Node) *Node {
 
 // buildIfExp builds a conditional expression node
 func (b *ASTBuilder) buildIfExp(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeIfExp)
+	node := b.newNode(NodeIfExp)
 	node.Location = b.getLocation(tsNode)
 
 	childCount := int(tsNode.ChildCount())
@@ -1025,9 +1121,9 @@ func (b *ASTBuilder) buildIfExp(tsNode *sitter.Node) *Node {
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
 		if child != nil {
-			if child.Type() == "if" {
+			if b.nodeType(child) == "if" {
 				stage = 1
-			} else if child.Type() == "else" {
+			} else if b.nodeType(child) == "else" {
 				stage = 2
 			} else if !b.isTrivia(child) {
 				built := b.buildNode(child)
@@ -1048,7 +1144,7 @@ func (b *ASTBuilder) buildIfExp(tsNode *sitter.Node) *Node {
 
 // buildLambda builds a lambda expression node
 func (b *ASTBuilder) buildLambda(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeLambda)
+	node := b.newNode(NodeLambda)
 	node.Location = b.getLocation(tsNode)
 
 	if params := b.getChildByFieldName(tsNode, "parameters"); params != nil {
@@ -1064,7 +1160,7 @@ func (b *ASTBuilder) buildLambda(tsNode *sitter.Node) *Node {
 
 // buildCall builds a function call node
 func (b *ASTBuilder) buildCall(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeCall)
+	node := b.newNode(NodeCall)
 	node.Location = b.getLocation(tsNode)
 
 	if function := b.getChildByFieldName(tsNode, "function"); function != nil {
@@ -1072,7 +1168,12 @@ func (b *ASTBuilder) buildCall(tsNode *sitter.Node) *Node {
 	}
 
 	if arguments := b.getChildByFieldName(tsNode, "arguments"); arguments != nil {
-		node.Args, node.Keywords = b.buildCallArguments(arguments)
+		// f(x for x in xs): the generator is the whole argument list
+		if b.nodeType(arguments) == "generator_expression" {
+			node.Args = []*Node{b.buildNode(arguments)}
+		} else {
+			node.Args, node.Keywords = b.buildCallArguments(arguments)
+		}
 	}
 
 	return node
@@ -1080,7 +1181,7 @@ func (b *ASTBuilder) buildCall(tsNode *sitter.Node) *Node {
 
 // buildAttribute builds an attribute access node
 func (b *ASTBuilder) buildAttribute(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeAttribute)
+	node := b.newNode(NodeAttribute)
 	node.Location = b.getLocation(tsNode)
 
 	if object := b.getChildByFieldName(tsNode, "object"); object != nil {
@@ -1096,7 +1197,7 @@ func (b *ASTBuilder) buildAttribute(tsNode *sitter.Node) *Node {
 
 // buildSubscript builds a subscript node
 func (b *ASTBuilder) buildSubscript(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeSubscript)
+	node := b.newNode(NodeSubscript)
 	node.Location = b.getLocation(tsNode)
 
 	if value := b.getChildByFieldName(tsNode, "object"); value != nil {
@@ -1116,7 +1217,7 @@ func (b *ASTBuilder) buildSubscript(tsNode *sitter.Node) *Node {
 }
 
 func (b *ASTBuilder) isUnaryOperatorToken(tsNode *sitter.Node) bool {
-	switch tsNode.Type() {
+	switch b.nodeType(tsNode) {
 	case "+", "-", "~", "not":
 		return true
 	default:
@@ -1126,7 +1227,7 @@ func (b *ASTBuilder) isUnaryOperatorToken(tsNode *sitter.Node) bool {
 
 // buildSlice builds a slice node
 func (b *ASTBuilder) buildSlice(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeSlice)
+	node := b.newNode(NodeSlice)
 	node.Location = b.getLocation(tsNode)
 
 	childCount := int(tsNode.ChildCount())
@@ -1136,7 +1237,7 @@ func (b *ASTBuilder) buildSlice(tsNode *sitter.Node) *Node {
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
 		if child != nil {
-			if child.Type() == ":" {
+			if b.nodeType(child) == ":" {
 				argIndex++
 			} else if !b.isTrivia(child) && argIndex < 3 {
 				sliceArgs[argIndex] = b.buildNode(child)
@@ -1162,13 +1263,13 @@ func (b *ASTBuilder) buildSlice(tsNode *sitter.Node) *Node {
 
 // buildList builds a list node
 func (b *ASTBuilder) buildList(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeList)
+	node := b.newNode(NodeList)
 	node.Location = b.getLocation(tsNode)
 
 	childCount := int(tsNode.ChildCount())
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
-		if child != nil && child.Type() != "[" && child.Type() != "]" && child.Type() != "," {
+		if child != nil && b.nodeType(child) != "[" && b.nodeType(child) != "]" && b.nodeType(child) != "," {
 			node.AddChild(b.buildNode(child))
 		}
 	}
@@ -1178,13 +1279,13 @@ func (b *ASTBuilder) buildList(tsNode *sitter.Node) *Node {
 
 // buildTuple builds a tuple node
 func (b *ASTBuilder) buildTuple(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeTuple)
+	node := b.newNode(NodeTuple)
 	node.Location = b.getLocation(tsNode)
 
 	childCount := int(tsNode.ChildCount())
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
-		if child != nil && child.Type() != "(" && child.Type() != ")" && child.Type() != "," {
+		if child != nil && b.nodeType(child) != "(" && b.nodeType(child) != ")" && b.nodeType(child) != "," {
 			node.AddChild(b.buildNode(child))
 		}
 	}
@@ -1194,13 +1295,13 @@ func (b *ASTBuilder) buildTuple(tsNode *sitter.Node) *Node {
 
 // buildDict builds a dictionary node
 func (b *ASTBuilder) buildDict(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeDict)
+	node := b.newNode(NodeDict)
 	node.Location = b.getLocation(tsNode)
 
 	childCount := int(tsNode.ChildCount())
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
-		if child != nil && child.Type() == "pair" {
+		if child != nil && b.nodeType(child) == "pair" {
 			if key := b.getChildByFieldName(child, "key"); key != nil {
 				node.AddChild(b.buildNode(key))
 			}
@@ -1215,13 +1316,13 @@ func (b *ASTBuilder) buildDict(tsNode *sitter.Node) *Node {
 
 // buildSet builds a set node
 func (b *ASTBuilder) buildSet(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeSet)
+	node := b.newNode(NodeSet)
 	node.Location = b.getLocation(tsNode)
 
 	childCount := int(tsNode.ChildCount())
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
-		if child != nil && child.Type() != "{" && child.Type() != "}" && child.Type() != "," {
+		if child != nil && b.nodeType(child) != "{" && b.nodeType(child) != "}" && b.nodeType(child) != "," {
 			node.AddChild(b.buildNode(child))
 		}
 	}
@@ -1233,7 +1334,7 @@ func (b *ASTBuilder) buildSet(tsNode *sitter.Node) *Node {
 
 // buildListComp builds a list comprehension node
 func (b *ASTBuilder) buildListComp(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeListComp)
+	node := b.newNode(NodeListComp)
 	node.Location = b.getLocation(tsNode)
 	b.buildComprehension(tsNode, node)
 	return node
@@ -1241,7 +1342,7 @@ func (b *ASTBuilder) buildListComp(tsNode *sitter.Node) *Node {
 
 // buildDictComp builds a dictionary comprehension node
 func (b *ASTBuilder) buildDictComp(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeDictComp)
+	node := b.newNode(NodeDictComp)
 	node.Location = b.getLocation(tsNode)
 	b.buildComprehension(tsNode, node)
 	return node
@@ -1249,7 +1350,7 @@ func (b *ASTBuilder) buildDictComp(tsNode *sitter.Node) *Node {
 
 // buildSetComp builds a set comprehension node
 func (b *ASTBuilder) buildSetComp(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeSetComp)
+	node := b.newNode(NodeSetComp)
 	node.Location = b.getLocation(tsNode)
 	b.buildComprehension(tsNode, node)
 	return node
@@ -1257,7 +1358,7 @@ func (b *ASTBuilder) buildSetComp(tsNode *sitter.Node) *Node {
 
 // buildGeneratorExp builds a generator expression node
 func (b *ASTBuilder) buildGeneratorExp(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeGeneratorExp)
+	node := b.newNode(NodeGeneratorExp)
 	node.Location = b.getLocation(tsNode)
 	b.buildComprehension(tsNode, node)
 	return node
@@ -1270,12 +1371,12 @@ func (b *ASTBuilder) buildComprehension(tsNode *sitter.Node, node *Node) {
 	if childCount > 0 {
 		firstChild := tsNode.Child(0)
 		// Skip opening bracket/brace
-		if firstChild != nil && firstChild.Type() != "[" && firstChild.Type() != "{" && firstChild.Type() != "(" {
+		if firstChild != nil && b.nodeType(firstChild) != "[" && b.nodeType(firstChild) != "{" && b.nodeType(firstChild) != "(" {
 			node.Value = b.buildNode(firstChild)
 		} else if childCount > 1 {
 			// Body is likely the second child after opening bracket
 			bodyChild := tsNode.Child(1)
-			if bodyChild != nil && bodyChild.Type() != "for_in_clause" {
+			if bodyChild != nil && b.nodeType(bodyChild) != "for_in_clause" {
 				node.Value = b.buildNode(bodyChild)
 			}
 		}
@@ -1289,9 +1390,9 @@ func (b *ASTBuilder) buildComprehension(tsNode *sitter.Node, node *Node) {
 			continue
 		}
 
-		if child.Type() == "for_in_clause" {
+		if b.nodeType(child) == "for_in_clause" {
 			// Create new comprehension node for each for clause
-			comp := NewNode(NodeComprehension)
+			comp := b.newNode(NodeComprehension)
 
 			// Extract target expression from the grammar field. This handles names,
 			// tuple unpacking, and other valid assignment targets.
@@ -1314,9 +1415,9 @@ func (b *ASTBuilder) buildComprehension(tsNode *sitter.Node, node *Node) {
 						continue
 					}
 					switch {
-					case subChild.Type() == "for":
+					case b.nodeType(subChild) == "for":
 						continue
-					case subChild.Type() == "in":
+					case b.nodeType(subChild) == "in":
 						foundIn = true
 					case !foundIn && len(comp.Targets) == 0:
 						comp.Targets = []*Node{b.buildNode(subChild)}
@@ -1328,12 +1429,12 @@ func (b *ASTBuilder) buildComprehension(tsNode *sitter.Node, node *Node) {
 
 			node.AddChild(comp)
 			currentComp = comp
-		} else if child.Type() == "if_clause" && currentComp != nil {
+		} else if b.nodeType(child) == "if_clause" && currentComp != nil {
 			// if_clause follows the for_in_clause it applies to
 			// Extract the condition expression
 			for j := 0; j < int(child.ChildCount()); j++ {
 				subChild := child.Child(j)
-				if subChild != nil && subChild.Type() != "if" {
+				if subChild != nil && b.nodeType(subChild) != "if" {
 					currentComp.Test = b.buildNode(subChild)
 					break
 				}
@@ -1346,13 +1447,13 @@ func (b *ASTBuilder) buildComprehension(tsNode *sitter.Node, node *Node) {
 
 // buildYield builds a yield expression node
 func (b *ASTBuilder) buildYield(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeYield)
+	node := b.newNode(NodeYield)
 	node.Location = b.getLocation(tsNode)
 
 	childCount := int(tsNode.ChildCount())
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
-		if child != nil && child.Type() != "yield" {
+		if child != nil && b.nodeType(child) != "yield" {
 			node.Value = b.buildNode(child)
 			break
 		}
@@ -1363,13 +1464,13 @@ func (b *ASTBuilder) buildYield(tsNode *sitter.Node) *Node {
 
 // buildYieldFrom builds a yield from expression node
 func (b *ASTBuilder) buildYieldFrom(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeYieldFrom)
+	node := b.newNode(NodeYieldFrom)
 	node.Location = b.getLocation(tsNode)
 
 	childCount := int(tsNode.ChildCount())
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
-		if child != nil && child.Type() != "yield" && child.Type() != "from" {
+		if child != nil && b.nodeType(child) != "yield" && b.nodeType(child) != "from" {
 			node.Value = b.buildNode(child)
 			break
 		}
@@ -1380,13 +1481,13 @@ func (b *ASTBuilder) buildYieldFrom(tsNode *sitter.Node) *Node {
 
 // buildAwait builds an await expression node
 func (b *ASTBuilder) buildAwait(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeAwait)
+	node := b.newNode(NodeAwait)
 	node.Location = b.getLocation(tsNode)
 
 	childCount := int(tsNode.ChildCount())
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
-		if child != nil && child.Type() != "await" {
+		if child != nil && b.nodeType(child) != "await" {
 			node.Value = b.buildNode(child)
 			break
 		}
@@ -1399,7 +1500,7 @@ func (b *ASTBuilder) buildAwait(tsNode *sitter.Node) *Node {
 
 // buildName builds an identifier/name node
 func (b *ASTBuilder) buildName(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeName)
+	node := b.newNode(NodeName)
 	node.Location = b.getLocation(tsNode)
 	node.Name = b.getNodeText(tsNode)
 	return node
@@ -1407,11 +1508,11 @@ func (b *ASTBuilder) buildName(tsNode *sitter.Node) *Node {
 
 // buildConstant builds a constant value node
 func (b *ASTBuilder) buildConstant(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeConstant)
+	node := b.newNode(NodeConstant)
 	node.Location = b.getLocation(tsNode)
 
 	text := b.getNodeText(tsNode)
-	nodeType := tsNode.Type()
+	nodeType := b.nodeType(tsNode)
 
 	switch nodeType {
 	case "integer":
@@ -1448,7 +1549,7 @@ func (b *ASTBuilder) buildConstant(tsNode *sitter.Node) *Node {
 
 // buildFormattedString builds a formatted string (f-string) node
 func (b *ASTBuilder) buildFormattedString(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeJoinedStr)
+	node := b.newNode(NodeJoinedStr)
 	node.Location = b.getLocation(tsNode)
 	b.addFormattedStringChildren(node, tsNode)
 	return node
@@ -1462,11 +1563,11 @@ func (b *ASTBuilder) addFormattedStringChildren(node *Node, tsNode *sitter.Node)
 			continue
 		}
 
-		switch child.Type() {
+		switch b.nodeType(child) {
 		case "interpolation":
 			node.AddChild(b.buildFormattedValue(child))
 		case "string_content":
-			strNode := NewNode(NodeConstant)
+			strNode := b.newNode(NodeConstant)
 			strNode.Location = b.getLocation(child)
 			strNode.Value = b.getNodeText(child)
 			node.AddChild(strNode)
@@ -1481,7 +1582,7 @@ func (b *ASTBuilder) addFormattedStringChildren(node *Node, tsNode *sitter.Node)
 }
 
 func (b *ASTBuilder) buildFormattedValue(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeFormattedValue)
+	node := b.newNode(NodeFormattedValue)
 	node.Location = b.getLocation(tsNode)
 
 	cursor := tsNode.StartByte()
@@ -1500,13 +1601,13 @@ func (b *ASTBuilder) buildFormattedValue(tsNode *sitter.Node) *Node {
 			continue
 		}
 
-		if child.Type() == "format_specifier" {
+		if b.nodeType(child) == "format_specifier" {
 			b.addFormattedLiteral(node, cursor, child.StartByte(), tsNode, true)
 			b.addFormatSpecifierParts(node, child)
 			cursor = child.EndByte()
 			continue
 		}
-		if child.Type() == "type_conversion" {
+		if b.nodeType(child) == "type_conversion" {
 			b.addFormattedLiteral(node, cursor, child.StartByte(), tsNode, true)
 			b.addFormattedLiteral(node, child.StartByte(), child.EndByte(), child, true)
 			cursor = child.EndByte()
@@ -1541,7 +1642,7 @@ func (b *ASTBuilder) addFormatSpecifierParts(node *Node, tsNode *sitter.Node) {
 			continue
 		}
 
-		switch child.Type() {
+		switch b.nodeType(child) {
 		case "format_expression":
 			b.addFormattedLiteral(node, cursor, child.StartByte(), tsNode, false)
 			b.addFormatExpression(node, child)
@@ -1566,7 +1667,7 @@ func (b *ASTBuilder) addFormatExpression(node *Node, tsNode *sitter.Node) {
 		if child == nil || b.isFormattedStringSyntax(child) {
 			continue
 		}
-		if child.Type() == "format_specifier" {
+		if b.nodeType(child) == "format_specifier" {
 			b.addFormatSpecifierParts(node, child)
 			continue
 		}
@@ -1588,7 +1689,7 @@ func (b *ASTBuilder) addFormattedLiteral(node *Node, start, end uint32, location
 		return
 	}
 
-	strNode := NewNode(NodeConstant)
+	strNode := b.newNode(NodeConstant)
 	strNode.Location = b.getLocation(locationNode)
 	strNode.Value = literal
 	node.AddChild(strNode)
@@ -1598,7 +1699,7 @@ func (b *ASTBuilder) hasStringInterpolation(tsNode *sitter.Node) bool {
 	if tsNode == nil {
 		return false
 	}
-	switch tsNode.Type() {
+	switch b.nodeType(tsNode) {
 	case "interpolation":
 		return true
 	case "string":
@@ -1661,7 +1762,7 @@ func (b *ASTBuilder) isFormattedStringSyntax(tsNode *sitter.Node) bool {
 		return true
 	}
 
-	switch tsNode.Type() {
+	switch b.nodeType(tsNode) {
 	case "string_start", "string_end", "string_content":
 		return true
 	default:
@@ -1671,7 +1772,7 @@ func (b *ASTBuilder) isFormattedStringSyntax(tsNode *sitter.Node) bool {
 
 // buildBlock builds a block of statements
 func (b *ASTBuilder) buildBlock(tsNode *sitter.Node) *Node {
-	node := NewNode("block")
+	node := b.newNode("block")
 	node.Location = b.getLocation(tsNode)
 
 	childCount := int(tsNode.ChildCount())
@@ -1697,14 +1798,14 @@ func (b *ASTBuilder) buildParameters(tsNode *sitter.Node) []*Node {
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
 		if child != nil {
-			switch child.Type() {
+			switch b.nodeType(child) {
 			case "identifier":
-				arg := NewNode(NodeArg)
+				arg := b.newNode(NodeArg)
 				arg.Location = b.getLocation(child)
 				arg.Name = b.getNodeText(child)
 				params = append(params, arg)
 			case "default_parameter":
-				arg := NewNode(NodeArg)
+				arg := b.newNode(NodeArg)
 				arg.Location = b.getLocation(child)
 				if nameNode := b.getChildByFieldName(child, "name"); nameNode != nil {
 					arg.Name = b.getNodeText(nameNode)
@@ -1717,7 +1818,7 @@ func (b *ASTBuilder) buildParameters(tsNode *sitter.Node) []*Node {
 				}
 				params = append(params, arg)
 			case "typed_parameter", "typed_default_parameter":
-				arg := NewNode(NodeArg)
+				arg := b.newNode(NodeArg)
 				arg.Location = b.getLocation(child)
 				if nameNode := b.getChildByFieldName(child, "name"); nameNode != nil {
 					arg.Name = b.getNodeText(nameNode)
@@ -1733,12 +1834,12 @@ func (b *ASTBuilder) buildParameters(tsNode *sitter.Node) []*Node {
 				}
 				params = append(params, arg)
 			case "list_splat_pattern":
-				arg := NewNode(NodeArg)
+				arg := b.newNode(NodeArg)
 				arg.Location = b.getLocation(child)
 				arg.Name = "*" + b.getNodeTextExcluding(child, "*")
 				params = append(params, arg)
 			case "dictionary_splat_pattern":
-				arg := NewNode(NodeArg)
+				arg := b.newNode(NodeArg)
 				arg.Location = b.getLocation(child)
 				arg.Name = "**" + b.getNodeTextExcluding(child, "**")
 				params = append(params, arg)
@@ -1757,7 +1858,7 @@ func (b *ASTBuilder) extractParameterName(tsNode *sitter.Node) string {
 			continue
 		}
 
-		switch child.Type() {
+		switch b.nodeType(child) {
 		case "identifier", "keyword_identifier":
 			return b.getNodeText(child)
 		}
@@ -1773,7 +1874,7 @@ func (b *ASTBuilder) buildArgumentList(tsNode *sitter.Node) []*Node {
 	childCount := int(tsNode.ChildCount())
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
-		if child != nil && child.Type() != "(" && child.Type() != ")" && child.Type() != "," {
+		if child != nil && b.nodeType(child) != "(" && b.nodeType(child) != ")" && b.nodeType(child) != "," {
 			args = append(args, b.buildNode(child))
 		}
 	}
@@ -1790,9 +1891,9 @@ func (b *ASTBuilder) buildCallArguments(tsNode *sitter.Node) ([]*Node, []*Node)
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
 		if child != nil {
-			switch child.Type() {
+			switch b.nodeType(child) {
 			case "keyword_argument":
-				kw := NewNode(NodeKeyword)
+				kw := b.newNode(NodeKeyword)
 				if nameNode := b.getChildByFieldName(child, "name"); nameNode != nil {
 					kw.Name = b.getNodeText(nameNode)
 				}
@@ -1817,7 +1918,7 @@ func (b *ASTBuilder) buildWithItems(tsNode *sitter.Node) []*Node {
 		return nil
 	}
 
-	if tsNode.Type() != "with_clause" && tsNode.Type() != "parenthesized_expression" {
+	if b.nodeType(tsNode) != "with_clause" && b.nodeType(tsNode) != "parenthesized_expression" {
 		if item := b.buildWithItem(tsNode); item != nil {
 			return []*Node{item}
 		}
@@ -1832,7 +1933,7 @@ func (b *ASTBuilder) buildWithItems(tsNode *sitter.Node) []*Node {
 			continue
 		}
 
-		switch child.Type() {
+		switch b.nodeType(child) {
 		case "as_pattern":
 			if item := b.buildWithItem(child); item != nil {
 				items = append(items, item)
@@ -1851,15 +1952,15 @@ func (b *ASTBuilder) buildWithItems(tsNode *sitter.Node) []*Node {
 
 // buildWithItem builds a with item node
 func (b *ASTBuilder) buildWithItem(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeWithItem)
+	node := b.newNode(NodeWithItem)
 	node.Location = b.getLocation(tsNode)
 
-	if tsNode.Type() == "as_pattern" {
+	if b.nodeType(tsNode) == "as_pattern" {
 		return b.populateWithItemFromAsPattern(node, tsNode)
 	}
 
 	if value := b.getChildByFieldName(tsNode, "value"); value != nil {
-		if value.Type() == "as_pattern" {
+		if b.nodeType(value) == "as_pattern" {
 			return b.populateWithItemFromAsPattern(node, value)
 		}
 		node.Value = b.buildNode(value)
@@ -1900,7 +2001,7 @@ func (b *ASTBuilder) populateWithItemFromAsPattern(node *Node, tsNode *sitter.No
 		// tree-sitter-python wraps the actual target in an `as_pattern_target` node;
 		// unwrap it so we build the inner identifier/tuple/list directly.
 		aliasTarget := alias
-		if alias.Type() == "as_pattern_target" {
+		if b.nodeType(alias) == "as_pattern_target" {
 			for i := 0; i < int(alias.ChildCount()); i++ {
 				child := alias.Child(i)
 				if child == nil || b.isTrivia(child) || !child.IsNamed() {
@@ -1927,14 +2028,14 @@ func (b *ASTBuilder) populateWithItemFromAsPattern(node *Node, tsNode *sitter.No
 
 // buildExceptHandler builds an except handler node
 func (b *ASTBuilder) buildExceptHandler(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeExceptHandler)
+	node := b.newNode(NodeExceptHandler)
 	node.Location = b.getLocation(tsNode)
 
 	childCount := int(tsNode.ChildCount())
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
 		if child != nil {
-			switch child.Type() {
+			switch b.nodeType(child) {
 			case "as_pattern":
 				// Exception type and optional name
 				if exType := b.getChildByFieldName(child, "type"); exType != nil {
@@ -1949,7 +2050,7 @@ func (b *ASTBuilder) buildExceptHandler(tsNode *sitter.Node) *Node {
 					node.Body = b.extractBlockBody(body, node)
 				}
 			default:
-				if child.Type() != "except" && child.Type() != ":" {
+				if b.nodeType(child) != "except" && b.nodeType(child) != ":" {
 					// Exception type without alias
 					node.Value = b.buildNode(child)
 				}
@@ -1962,7 +2063,7 @@ func (b *ASTBuilder) buildExceptHandler(tsNode *sitter.Node) *Node {
 
 // buildMatchCase builds a match case node
 func (b *ASTBuilder) buildMatchCase(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeMatchCase)
+	node := b.newNode(NodeMatchCase)
 	node.Location = b.getLocation(tsNode)
 
 	if pattern := b.getChildByFieldName(tsNode, "pattern"); pattern != nil {
@@ -1988,7 +2089,7 @@ func (b *ASTBuilder) buildCaseGuard(tsNode *sitter.Node) *Node {
 	childCount := int(tsNode.ChildCount())
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
-		if child == nil || child.Type() == "if" || b.isTrivia(child) {
+		if child == nil || b.nodeType(child) == "if" || b.isTrivia(child) {
 			continue
 		}
 		return b.buildNode(child)
@@ -1998,13 +2099,13 @@ func (b *ASTBuilder) buildCaseGuard(tsNode *sitter.Node) *Node {
 
 // buildDecorator builds a decorator node
 func (b *ASTBuilder) buildDecorator(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeDecorator)
+	node := b.newNode(NodeDecorator)
 	node.Location = b.getLocation(tsNode)
 
 	childCount := int(tsNode.ChildCount())
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
-		if child != nil && child.Type() != "@" {
+		if child != nil && b.nodeType(child) != "@" {
 			node.Value = b.buildNode(child)
 			break
 		}
@@ -2015,10 +2116,10 @@ func (b *ASTBuilder) buildDecorator(tsNode *sitter.Node) *Node {
 
 // buildAlias builds an import alias node
 func (b *ASTBuilder) buildAlias(tsNode *sitter.Node) *Node {
-	node := NewNode(NodeAlias)
+	node := b.newNode(NodeAlias)
 	node.Location = b.getLocation(tsNode)
 
-	if tsNode.Type() == "aliased_import" {
+	if b.nodeType(tsNode) == "aliased_import" {
 		if nameNode := b.getChildByFieldName(tsNode, "name"); nameNode != nil {
 			node.Name = b.getNodeText(nameNode)
 		}
@@ -2036,20 +2137,47 @@ func (b *ASTBuilder) buildAlias(tsNode *sitter.Node) *Node {
 
 // getLocation extracts location information from a tree-sitter node
 func (b *ASTBuilder) getLocation(tsNode *sitter.Node) Location {
-	startPoint := tsNode.StartPoint()
-	endPoint := tsNode.EndPoint()
+	return NodeLocation(tsNode, b.source)
+}
+
+// NodeLocation returns the location of a tree-sitter node in source.
+// Tree-sitter columns count bytes; they are converted to code points.
+func NodeLocation(tsNode *sitter.Node, source []byte) Location {
+	startPoint, endPoint := tsNode.StartPoint(), tsNode.EndPoint()
+	startByte, endByte := tsNode.StartByte(), tsNode.EndByte()
 
 	return Location{
 		StartLine: int(startPoint.Row) + 1,
-		StartCol:  int(startPoint.Column),
+		StartCol:  runeColumn(source, startByte, startPoint.Column),
 		EndLine:   int(endPoint.Row) + 1,
-		EndCol:    int(endPoint.Column),
+		EndCol:    runeColumn(source, endByte, endPoint.Column),
+		StartByte: int(startByte),
+		EndByte:   int(endByte),
+	}
+}
+
+// runeColumn converts the byte column of a byte offset into the number of
+// code points before it on its line. Invalid UTF-8 counts one per byte.
+func runeColumn(source []byte, offset, column uint32) int {
+	if column > offset || int(offset) > len(source) {
+		return int(column)
+	}
+	line := source[offset-column : offset]
+	for _, c := range line {
+		if c >= utf8.RuneSelf {
+			return utf8.RuneCount(line)
+		}
 	}
+	return int(column)
 }
 
 // getNodeText gets the text content of a node
 func (b *ASTBuilder) getNodeText(tsNode *sitter.Node) string {
-	return tsNode.Content(b.source)
+	text := b.source[tsNode.StartByte():tsNode.EndByte()]
+	if token, ok := internedTokens[string(text)]; ok {
+		return token
+	}
+	return string(text)
 }
 
 // getNodeTextExcluding gets node text excluding certain prefixes
@@ -2068,7 +2196,7 @@ func (b *ASTBuilder) hasChildOfType(tsNode *sitter.Node, childType string) bool
 	childCount := int(tsNode.ChildCount())
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
-		if child != nil && child.Type() == childType {
+		if child != nil && b.nodeType(child) == childType {
 			return true
 		}
 	}
@@ -2079,7 +2207,7 @@ func (b *ASTBuilder) getFirstChildByType(tsNode *sitter.Node, childType string)
 	childCount := int(tsNode.ChildCount())
 	for i := 0; i < childCount; i++ {
 		child := tsNode.Child(i)
-		if child != nil && child.Type() == childType {
+		if child != nil && b.nodeType(child) == childType {
 			return child
 		}
 	}
@@ -2088,7 +2216,7 @@ func (b *ASTBuilder) getFirstChildByType(tsNode *sitter.Node, childType string)
 
 // isTrivia checks if a node is trivia (comments, whitespace)
 func (b *ASTBuilder) isTrivia(tsNode *sitter.Node) bool {
-	nodeType := tsNode.Type()
+	nodeType := b.nodeType(tsNode)
 	return nodeType == "comment" || nodeType == "line_continuation"
 }
 
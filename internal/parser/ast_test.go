@@ -2,7 +2,14 @@ package parser
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/python"
 )
 
 func TestASTBuilder(t *testing.T) {
@@ -855,6 +862,24 @@ func TestComprehensionIteratorAndTargetFields(t *testing.T) {
 	}
 }
 
+func TestGeneratorAsSoleCallArgument(t *testing.T) {
+	result, err := New().Parse(context.Background(), []byte("total = sum(x * 2 for x in items if x)\n"))
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+
+	calls := result.AST.FindByType(NodeCall)
+	if len(calls) != 1 {
+		t.Fatalf("Expected 1 call, got %d", len(calls))
+	}
+	if len(calls[0].Args) != 1 || calls[0].Args[0].Type != NodeGeneratorExp {
+		t.Fatalf("Args = %#v, want a single generator expression", calls[0].Args)
+	}
+	if comps := result.AST.FindByType(NodeComprehension); len(comps) != 1 || comps[0].Iter == nil || comps[0].Iter.Name != "items" {
+		t.Fatalf("Expected one comprehension over items, got %#v", comps)
+	}
+}
+
 func collectNameLeaves(n *Node) []string {
 	var out []string
 	n.WalkDeep(func(child *Node) bool {
@@ -905,3 +930,139 @@ func countStringConstants(node *Node, value string) int {
 	})
 	return count
 }
+
+func buildWithMaxDepth(t *testing.T, source []byte, maxDepth int) *Node {
+	t.Helper()
+	p := sitter.NewParser()
+	p.SetLanguage(python.GetLanguage())
+	tree, err := p.ParseCtx(context.Background(), nil, source)
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	builder := NewASTBuilder(source)
+	builder.maxDepth = maxDepth
+	ast, err := builder.Build(tree)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	return ast
+}
+
+// dumpAST renders every field of the tree, checking parent links on the way
+func dumpAST(t *testing.T, sb *strings.Builder, node, parent *Node, indent string) {
+	if node == nil {
+		sb.WriteString(indent + "<nil>\n")
+		return
+	}
+	if node.Parent != parent {
+		t.Errorf("%s at line %d has a stale parent link", node.Type, node.Location.StartLine)
+	}
+	value := ""
+	if node.Value != nil {
+		if _, ok := node.Value.(*Node); !ok {
+			value = fmt.Sprintf("%v", node.Value)
+		}
+	}
+	fmt.Fprintf(sb, "%s%s name=%q op=%q module=%q names=%v level=%d value=%q loc=%v\n",
+		indent, node.Type, node.Name, node.Op, node.Module, node.Names, node.Level, value, node.Location)
+	single := func(label string, child *Node) {
+		if child != nil {
+			sb.WriteString(indent + " ." + label + "\n")
+			dumpAST(t, sb, child, node, indent+"  ")
+		}
+	}
+	list := func(label string, children []*Node) {
+		for _, child := range children {
+			sb.WriteString(indent + " ." + label + "\n")
+			dumpAST(t, sb, child, node, indent+"  ")
+		}
+	}
+	if value, ok := node.Value.(*Node); ok {
+		single("value", value)
+	}
+	single("target", node.Target)
+	single("test", node.Test)
+	single("iter", node.Iter)
+	single("left", node.Left)
+	single("right", node.Right)
+	list("children", node.Children)
+	list("targets", node.Targets)
+	list("body", node.Body)
+	list("orelse", node.Orelse)
+	list("finalbody", node.Finalbody)
+	list("handlers", node.Handlers)
+	list("args", node.Args)
+	list("keywords", node.Keywords)
+	list("decorator", node.Decorator)
+	list("bases", node.Bases)
+}
+
+func TestASTBuilderDepthLimitBuildsSameTree(t *testing.T) {
+	files, err := filepath.Glob("../../testdata/python/*/*.py")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no Python test data found: %v", err)
+	}
+
+	for _, file := range files {
+		source, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var want strings.Builder
+		dumpAST(t, &want, buildWithMaxDepth(t, source, maxBuildDepth), nil, "")
+		for _, maxDepth := range []int{1, 2, 5} {
+			var got strings.Builder
+			dumpAST(t, &got, buildWithMaxDepth(t, source, maxDepth), nil, "")
+			if got.String() != want.String() {
+				t.Errorf("%s: tree built with depth limit %d differs from the recursive one", file, maxDepth)
+			}
+		}
+	}
+}
+
+func TestASTBuilderDeeplyNestedExpression(t *testing.T) {
+	const depth = 20000
+	source := "x = " + strings.Repeat("1 + ", depth) + "1\n"
+
+	result, err := New().Parse(context.Background(), []byte(source))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	expr := result.AST.Body[0].Value.(*Node)
+	operations := 0
+	for expr != nil && expr.Type == NodeBinOp {
+		if expr.Right == nil || expr.Left.Parent != expr {
+			t.Fatalf("incomplete operation at nesting %d", operations)
+		}
+		operations++
+		expr = expr.Left
+	}
+	if operations != depth {
+		t.Errorf("expected %d nested operations, got %d", depth, operations)
+	}
+}
+
+func TestASTBuilderMultiByteLocations(t *testing.T) {
+	source := "名前 = \"héllo\"\nx = 名前\n"
+	result, err := New().Parse(context.Background(), []byte(source))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	value := result.AST.Body[0].Value.(*Node)
+	if value.Location.StartCol != 5 || value.Location.EndCol != 12 {
+		t.Errorf("expected the string at code point columns 5-12, got %d-%d", value.Location.StartCol, value.Location.EndCol)
+	}
+	if got := source[value.Location.StartByte:value.Location.EndByte]; got != "\"héllo\"" {
+		t.Errorf("expected the byte range to cover the string, got %q", got)
+	}
+
+	use := result.AST.Body[1].Value.(*Node)
+	if use.Location.StartCol != 4 || use.Location.EndCol != 6 {
+		t.Errorf("expected the name at code point columns 4-6, got %d-%d", use.Location.StartCol, use.Location.EndCol)
+	}
+	if got := source[use.Location.StartByte:use.Location.EndByte]; got != "名前" {
+		t.Errorf("expected the byte range to cover the name, got %q", got)
+	}
+}
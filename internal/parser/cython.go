@@ -0,0 +1,535 @@
+package parser
+
+import (
+	"strings"
+)
+
+// cythonFrontend parses Cython by rewriting its source into Python, since
+// no Cython grammar is bundled: C declarations are dropped or turned into
+// assignments, cdef and cpdef functions into def functions and cdef
+// classes into classes, and casts and address-of operators are removed.
+// Each statement stays on its lines, so complexity, clones and the other
+// line-based findings hold for the original file; columns may move.
+type cythonFrontend struct {
+	pythonFrontend
+}
+
+// CythonFrontend returns the frontend of Cython implementation files
+func CythonFrontend() LanguageFrontend {
+	return cythonFrontend{}
+}
+
+func (cythonFrontend) Name() string { return "cython" }
+
+func (cythonFrontend) Extensions() []string { return []string{".pyx"} }
+
+func (cythonFrontend) Prepare(source []byte) []byte {
+	return []byte(normalizeCython(string(source)))
+}
+
+// cythonModifiers are the qualifiers of cdef declarations that do not
+// change what they declare
+var cythonModifiers = map[string]bool{
+	"public":   true,
+	"readonly": true,
+	"api":      true,
+	"inline":   true,
+	"static":   true,
+	"packed":   true,
+	"noexcept": true,
+}
+
+// cythonTypeDeclarations are the cdef declarations of C types, whose body
+// holds C fields rather than Python statements
+var cythonTypeDeclarations = map[string]bool{
+	"extern":   true,
+	"struct":   true,
+	"union":    true,
+	"enum":     true,
+	"cppclass": true,
+	"fused":    true,
+}
+
+// cythonOperandKeywords are the keywords after which an expression starts,
+// where "<" opens a cast and "&" takes an address
+var cythonOperandKeywords = map[string]bool{
+	"return": true,
+	"yield":  true,
+	"in":     true,
+	"not":    true,
+	"and":    true,
+	"or":     true,
+	"if":     true,
+	"else":   true,
+	"is":     true,
+	"lambda": true,
+	"assert": true,
+	"print":  true,
+}
+
+// cythonNormalizer rewrites Cython statements, tracking the blocks that
+// span several of them
+type cythonNormalizer struct {
+	// skipIndent is the indentation of a C type declaration whose body is
+	// being dropped, or -1
+	skipIndent int
+
+	// blockIndent is the indentation of a "cdef:" block whose body lines
+	// are declarations, or -1
+	blockIndent int
+}
+
+// normalizeCython rewrites Cython source into Python, keeping every
+// statement on its lines
+func normalizeCython(source string) string {
+	n := &cythonNormalizer{skipIndent: -1, blockIndent: -1}
+	var out strings.Builder
+	out.Grow(len(source))
+	for _, stmt := range splitLogicalLines(source) {
+		out.WriteString(n.statement(stmt))
+	}
+	return out.String()
+}
+
+// splitLogicalLines splits source into statements, each with its line
+// break: the lines a bracket, a triple-quoted string or a backslash joins
+// stay together
+func splitLogicalLines(source string) []string {
+	var stmts []string
+	start, depth := 0, 0
+	var quote string
+	for i := 0; i < len(source); i++ {
+		c := source[i]
+		switch {
+		case quote != "":
+			if c == '\\' {
+				i++
+			} else if c == '\n' && len(quote) == 1 {
+				// An unterminated string ends with its line
+				quote = ""
+				if depth == 0 {
+					stmts = append(stmts, source[start:i+1])
+					start = i + 1
+				}
+			} else if strings.HasPrefix(source[i:], quote) {
+				i += len(quote) - 1
+				quote = ""
+			}
+		case c == '#':
+			for i+1 < len(source) && source[i+1] != '\n' {
+				i++
+			}
+		case c == '"' || c == '\'':
+			quote = string(c)
+			if strings.HasPrefix(source[i:], strings.Repeat(quote, 3)) {
+				quote = strings.Repeat(quote, 3)
+				i += 2
+			}
+		case c == '\\' && i+1 < len(source) && source[i+1] == '\n':
+			i++
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			if depth > 0 {
+				depth--
+			}
+		case c == '\n' && depth == 0:
+			stmts = append(stmts, source[start:i+1])
+			start = i + 1
+		}
+	}
+	if start < len(source) {
+		stmts = append(stmts, source[start:])
+	}
+	return stmts
+}
+
+// statement rewrites a statement, padding it with line breaks to the lines
+// it spanned
+func (n *cythonNormalizer) statement(stmt string) string {
+	lines := strings.Count(stmt, "\n")
+	body := strings.TrimRight(stmt, "\r\n")
+	text := strings.TrimLeft(body, " \t")
+	indent := body[:len(body)-len(text)]
+	if text == "" || text[0] == '#' {
+		return stmt
+	}
+
+	if n.skipIndent >= 0 {
+		if len(indent) > n.skipIndent {
+			return strings.Repeat("\n", lines)
+		}
+		n.skipIndent = -1
+	}
+	if n.blockIndent >= 0 {
+		if len(indent) > n.blockIndent {
+			text = "cdef " + text
+		} else {
+			n.blockIndent = -1
+		}
+	}
+
+	rewritten := removeCythonOperators(n.rewrite(len(indent), text))
+	rewritten = indent + rewritten
+	if missing := lines - strings.Count(rewritten, "\n"); missing > 0 {
+		rewritten += strings.Repeat("\n", missing)
+	}
+	return rewritten
+}
+
+// rewrite turns a Cython statement into Python
+func (n *cythonNormalizer) rewrite(indent int, text string) string {
+	word := leadingWord(text)
+	rest := text[len(word):]
+	switch word {
+	case "include":
+		return "pass"
+	case "cimport":
+		return "import" + rest
+	case "from":
+		if i := strings.Index(rest, " cimport "); i >= 0 {
+			return word + rest[:i] + " import " + rest[i+len(" cimport "):]
+		}
+	case "DEF":
+		return strings.TrimLeft(rest, " \t")
+	case "IF", "ELIF", "ELSE":
+		return strings.ToLower(word) + rest
+	case "ctypedef":
+		if endsWithColon(text) {
+			n.skipIndent = indent
+		}
+		return "pass"
+	case "cdef", "cpdef":
+		return n.declaration(indent, strings.TrimLeft(rest, " \t"))
+	case "def":
+		// Parameters of def functions may have C types too
+		rest = strings.TrimLeft(rest, " \t")
+		mask := codeMask(rest)
+		if paren := indexTopLevel(rest, mask, "("); paren >= 0 {
+			if def := cythonFunction(rest, mask, paren); def != "pass" {
+				return def
+			}
+		}
+	}
+	return text
+}
+
+// declaration turns what follows cdef or cpdef into Python
+func (n *cythonNormalizer) declaration(indent int, rest string) string {
+	if strings.HasPrefix(rest, ":") {
+		n.blockIndent = indent
+		return "if True" + rest
+	}
+	for cythonModifiers[leadingWord(rest)] {
+		rest = strings.TrimLeft(rest[len(leadingWord(rest)):], " \t")
+	}
+	word := leadingWord(rest)
+	switch {
+	case word == "class":
+		return rest
+	case cythonTypeDeclarations[word]:
+		if endsWithColon(rest) {
+			n.skipIndent = indent
+		}
+		return "pass"
+	}
+
+	mask := codeMask(rest)
+	paren := indexTopLevel(rest, mask, "(=")
+	if paren >= 0 && rest[paren] == '(' {
+		return cythonFunction(rest, mask, paren)
+	}
+	return cythonVariables(rest)
+}
+
+// cythonFunction turns the signature of a cdef or cpdef function, from its
+// return type on, into a def one, and a forward declaration into pass
+func cythonFunction(rest string, mask []bool, paren int) string {
+	name := trailingIdentifier(rest[:paren])
+	closing := matchingBracket(rest, mask, paren)
+	if name == "" || closing < 0 {
+		return "pass"
+	}
+	tail := rest[closing+1:]
+	colon := indexTopLevel(tail, mask[closing+1:], ":")
+	if colon < 0 {
+		return "pass"
+	}
+
+	var params []string
+	for _, param := range splitTopLevel(rest[paren+1:closing], ',') {
+		params = append(params, cythonParameter(param))
+	}
+	// Drop the exception values and nogil clauses before the colon
+	annotation := ""
+	if clauses := strings.TrimSpace(tail[:colon]); strings.HasPrefix(clauses, "->") {
+		annotation = " " + clauses
+	}
+	return "def " + name + "(" + strings.Join(params, ",") + ")" + annotation + tail[colon:]
+}
+
+// cythonParameter drops the C type of a parameter, keeping its name and
+// default value and the line breaks around them
+func cythonParameter(param string) string {
+	trimmed := strings.TrimSpace(param)
+	if trimmed == "" || trimmed == "/" || strings.HasPrefix(trimmed, "*") {
+		return param
+	}
+	decl, value := trimmed, ""
+	if eq := indexTopLevel(trimmed, codeMask(trimmed), "=:"); eq >= 0 {
+		if trimmed[eq] == ':' {
+			// Annotated in pure Python syntax
+			return param
+		}
+		decl, value = trimmed[:eq], trimmed[eq:]
+	}
+	decl = strings.TrimSuffix(strings.TrimSuffix(strings.TrimSpace(decl), " not None"), " or None")
+	name := trailingIdentifier(decl)
+	if name == "" {
+		return param
+	}
+	kept := name + value
+	breaks := strings.Count(param, "\n") - strings.Count(kept, "\n")
+	return strings.Repeat("\n", max(breaks, 0)) + kept
+}
+
+// cythonVariables turns a declaration of C variables into assignments of
+// those with an initial value, or pass when none has one
+func cythonVariables(rest string) string {
+	var assignments []string
+	for _, declarator := range splitTopLevel(rest, ',') {
+		decl, value := declarator, ""
+		if eq := indexTopLevel(declarator, codeMask(declarator), "="); eq >= 0 {
+			decl, value = declarator[:eq], declarator[eq+1:]
+		}
+		name := trailingIdentifier(decl)
+		if name == "" || value == "" {
+			continue
+		}
+		assignments = append(assignments, name+" = "+strings.TrimLeft(value, " \t"))
+	}
+	if len(assignments) == 0 {
+		return "pass"
+	}
+	return strings.Join(assignments, "; ")
+}
+
+// removeCythonOperators removes casts such as <double>x and <Foo?>y and
+// address-of operators from the code of a statement
+func removeCythonOperators(text string) string {
+	mask := codeMask(text)
+	var out strings.Builder
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if mask[i] && (c == '<' || c == '&') && startsOperand(text, mask, i) {
+			if c == '&' && i+1 < len(text) && isIdentifierByte(text[i+1]) {
+				continue
+			}
+			if c == '<' {
+				if end := castEnd(text, i); end > 0 {
+					i = end
+					continue
+				}
+			}
+		}
+		out.WriteByte(c)
+	}
+	return out.String()
+}
+
+// startsOperand reports whether position i is where an operand starts,
+// after an operator, an opening bracket or a keyword
+func startsOperand(text string, mask []bool, i int) bool {
+	j := i - 1
+	for j >= 0 && (text[j] == ' ' || text[j] == '\t' || text[j] == '\n') {
+		j--
+	}
+	if j < 0 {
+		return true
+	}
+	if !mask[j] {
+		return false
+	}
+	if strings.IndexByte("=(,[{:+-*/%!|^~", text[j]) >= 0 {
+		return true
+	}
+	end := j + 1
+	for j >= 0 && isIdentifierByte(text[j]) {
+		j--
+	}
+	return cythonOperandKeywords[text[j+1:end]]
+}
+
+// castEnd returns the index of the ">" closing a cast opened at i, or -1
+func castEnd(text string, i int) int {
+	if i+1 >= len(text) || !isIdentifierStart(text[i+1]) {
+		return -1
+	}
+	for j := i + 1; j < len(text); j++ {
+		c := text[j]
+		switch {
+		case c == '>':
+			return j
+		case isIdentifierByte(c) || strings.IndexByte(" .*[]:,?", c) >= 0:
+		default:
+			return -1
+		}
+	}
+	return -1
+}
+
+// codeMask marks the bytes of text that are code, outside strings and
+// comments
+func codeMask(text string) []bool {
+	mask := make([]bool, len(text))
+	var quote string
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != "":
+			if c == '\\' {
+				i++
+			} else if strings.HasPrefix(text[i:], quote) {
+				i += len(quote) - 1
+				quote = ""
+			} else if c == '\n' && len(quote) == 1 {
+				quote = ""
+			}
+		case c == '#':
+			for i+1 < len(text) && text[i+1] != '\n' {
+				i++
+			}
+		case c == '"' || c == '\'':
+			quote = string(c)
+			if strings.HasPrefix(text[i:], strings.Repeat(quote, 3)) {
+				quote = strings.Repeat(quote, 3)
+				i += 2
+			}
+		default:
+			mask[i] = true
+		}
+	}
+	return mask
+}
+
+// indexTopLevel returns the index of the first of chars in the code of
+// text outside brackets, or -1
+func indexTopLevel(text string, mask []bool, chars string) int {
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		if !mask[i] {
+			continue
+		}
+		switch c := text[i]; {
+		case c == '(' && depth == 0 && strings.IndexByte(chars, c) >= 0:
+			return i
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+		case depth == 0 && strings.IndexByte(chars, c) >= 0:
+			return i
+		}
+	}
+	return -1
+}
+
+// splitTopLevel splits text at the separators in its code outside brackets
+func splitTopLevel(text string, sep byte) []string {
+	mask := codeMask(text)
+	var parts []string
+	start, depth := 0, 0
+	for i := 0; i < len(text); i++ {
+		if !mask[i] {
+			continue
+		}
+		switch c := text[i]; {
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+		case c == sep && depth == 0:
+			parts = append(parts, text[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, text[start:])
+}
+
+// matchingBracket returns the index of the bracket closing the one at
+// open, or -1
+func matchingBracket(text string, mask []bool, open int) int {
+	depth := 0
+	for i := open; i < len(text); i++ {
+		if !mask[i] {
+			continue
+		}
+		switch text[i] {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// endsWithColon reports whether the code of a statement ends with a colon,
+// opening a block
+func endsWithColon(text string) bool {
+	mask := codeMask(text)
+	for i := len(text) - 1; i >= 0; i-- {
+		if !mask[i] || text[i] == ' ' || text[i] == '\t' || text[i] == '\r' || text[i] == '\n' {
+			continue
+		}
+		return text[i] == ':'
+	}
+	return false
+}
+
+// leadingWord returns the identifier text starts with
+func leadingWord(text string) string {
+	i := 0
+	for i < len(text) && isIdentifierByte(text[i]) {
+		i++
+	}
+	return text[:i]
+}
+
+// trailingIdentifier returns the identifier a C declarator ends with,
+// skipping array dimensions, or ""
+func trailingIdentifier(decl string) string {
+	decl = strings.TrimRight(decl, " \t\r\n")
+	for strings.HasSuffix(decl, "]") {
+		open := strings.LastIndex(decl, "[")
+		if open < 0 {
+			return ""
+		}
+		prefix := strings.TrimRight(decl[:open], " \t")
+		// Brackets after a type, as in double[:] x, belong to the type
+		if prefix == "" || !isIdentifierByte(prefix[len(prefix)-1]) {
+			return ""
+		}
+		decl = prefix
+	}
+	end := len(decl)
+	i := end
+	for i > 0 && isIdentifierByte(decl[i-1]) {
+		i--
+	}
+	if i == end || !isIdentifierStart(decl[i]) {
+		return ""
+	}
+	return decl[i:end]
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isIdentifierByte(c byte) bool {
+	return isIdentifierStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,178 @@
+package parser
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeCython(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{
+			name:   "cimports",
+			source: "cimport numpy as cnp\nfrom libc.math cimport sqrt, cos\n",
+			want:   "import numpy as cnp\nfrom libc.math import sqrt, cos\n",
+		},
+		{
+			name:   "compile-time definitions and conditionals",
+			source: "DEF SIZE = 16\nIF UNAME_SYSNAME == \"Linux\":\n    x = 1\nELSE:\n    x = 2\n",
+			want:   "SIZE = 16\nif UNAME_SYSNAME == \"Linux\":\n    x = 1\nelse:\n    x = 2\n",
+		},
+		{
+			name:   "variable declarations",
+			source: "cdef int i, j = 0, k\ncdef double[:, ::1] view = arr\ncdef object x\n",
+			want:   "j = 0\nview = arr\npass\n",
+		},
+		{
+			name:   "declaration block",
+			source: "def f():\n    cdef:\n        int total = 0\n        char *name\n    return total\n",
+			want:   "def f():\n    if True:\n        total = 0\n        pass\n    return total\n",
+		},
+		{
+			name:   "cdef function with exception value",
+			source: "cdef inline int clamp(int value, int low=0) except -1 nogil:\n    return value\n",
+			want:   "def clamp(value,low=0):\n    return value\n",
+		},
+		{
+			name:   "cpdef method with a multiline signature",
+			source: "    cpdef double scale(self,\n                       double factor,\n                       list items not None):\n        return factor\n",
+			want:   "    def scale(self,\nfactor,\nitems):\n        return factor\n",
+		},
+		{
+			name:   "typed def parameters",
+			source: "def area(Shape shape, double ratio=1.0) -> float:\n    return ratio\n",
+			want:   "def area(shape,ratio=1.0) -> float:\n    return ratio\n",
+		},
+		{
+			name:   "forward declaration",
+			source: "cdef int twice(int x)\n",
+			want:   "pass\n",
+		},
+		{
+			name:   "cdef class and attributes",
+			source: "cdef class Point:\n    cdef public double x, y\n    cdef readonly int dims\n",
+			want:   "class Point:\n    pass\n    pass\n",
+		},
+		{
+			name:   "extern block and ctypedef struct",
+			source: "cdef extern from \"math.h\" nogil:\n    double cos(double x)\n\nctypedef struct Pair:\n    int a\n    int b\nx = 1\n",
+			want:   "pass\n\n\npass\n\n\nx = 1\n",
+		},
+		{
+			name:   "casts and address-of",
+			source: "total += <double>values[i]\nptr = &buffer[0]\nnode = <Node?>obj\nflag = a < b and c > d\nmask = a & b\n",
+			want:   "total += values[i]\nptr = buffer[0]\nnode = obj\nflag = a < b and c > d\nmask = a & b\n",
+		},
+		{
+			name:   "strings and comments are kept",
+			source: "s = \"cdef int <x>\"  # cdef int y\ndoc = '''\ncdef int z\n'''\n",
+			want:   "s = \"cdef int <x>\"  # cdef int y\ndoc = '''\ncdef int z\n'''\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := normalizeCython(tt.source)
+			if got != tt.want {
+				t.Errorf("normalizeCython() =\n%s\nwant\n%s", got, tt.want)
+			}
+			if strings.Count(got, "\n") != strings.Count(tt.source, "\n") {
+				t.Errorf("normalizeCython() changed the line count")
+			}
+		})
+	}
+}
+
+func TestCythonFrontendParse(t *testing.T) {
+	source := `cimport cython
+from libc.math cimport sqrt
+
+cdef extern from "math.h":
+    double cos(double x)
+
+cdef class Vector:
+    cdef public double x, y
+
+    def __init__(self, double x, double y):
+        self.x = x
+        self.y = y
+
+    cpdef double norm(self) except -1:
+        return sqrt(self.x * self.x + self.y * self.y)
+
+
+cdef inline int clamp(int value,
+                      int low=0,
+                      int high=16) nogil:
+    cdef int result = value
+    if value < low:
+        result = low
+    elif value > high:
+        result = high
+    return result
+`
+	result, err := NewWithFrontend(CythonFrontend()).Parse(context.Background(), []byte(source))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := map[string][2]int{
+		"Vector":   {7, 15},
+		"__init__": {10, 12},
+		"norm":     {14, 15},
+		"clamp":    {18, 26},
+	}
+	got := make(map[string][2]int)
+	result.AST.Walk(func(n *Node) bool {
+		if n.Type == NodeFunctionDef || n.Type == NodeClassDef {
+			got[n.Name] = [2]int{n.Location.StartLine, n.Location.EndLine}
+		}
+		return true
+	})
+	for name, lines := range want {
+		if got[name] != lines {
+			t.Errorf("%s spans lines %v, want %v", name, got[name], lines)
+		}
+	}
+}
+
+func TestFrontendForFile(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"pkg/module.py", "python"},
+		{"pkg/types.pyi", "python"},
+		{"pkg/fast.pyx", "cython"},
+		{"pkg/FAST.PYX", "cython"},
+		{"pkg/fast.c", ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if frontend := FrontendForFile(tt.path); frontend != nil {
+			got = frontend.Name()
+		}
+		if got != tt.want {
+			t.Errorf("FrontendForFile(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestParserParseFor(t *testing.T) {
+	p := New()
+	ctx := context.Background()
+
+	if _, err := p.ParseFor(ctx, "fast.pyx", []byte("cdef int twice(int x):\n    return x * 2\n")); err != nil {
+		t.Fatalf("ParseFor(.pyx) error = %v", err)
+	}
+	if p.Frontend().Name() != "cython" {
+		t.Errorf("Frontend() = %q after parsing a .pyx file, want cython", p.Frontend().Name())
+	}
+	if _, err := p.ParseFor(ctx, "module.py", []byte("cdef int x\n")); err == nil {
+		t.Error("ParseFor(.py) accepted Cython syntax")
+	}
+}
@@ -10,6 +10,8 @@
 //   - Error-tolerant parsing with syntax error detection
 //   - Tree traversal and node searching utilities
 //   - Cross-platform compatibility
+//   - Language frontends selected by file extension, with Cython (.pyx)
+//     rewritten into Python before parsing
 //
 // Basic usage:
 //
@@ -19,4 +21,7 @@
 //	    // Handle parsing error
 //	}
 //	// Use result.RootNode to traverse the AST
+//
+//	// Pick the frontend by the extension of the file
+//	result, err = p.ParseFor(ctx, "fast.pyx", source)
 package parser
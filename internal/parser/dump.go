@@ -0,0 +1,191 @@
+package parser
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// DumpNode is a serializable view of a tree node. The internal AST cannot
+// be marshalled directly because of its parent links, and tree-sitter nodes
+// only expose their shape through method calls.
+type DumpNode struct {
+	Type string `json:"type"`
+	// Field is the field of the parent holding this node, if any
+	Field    string       `json:"field,omitempty"`
+	Name     string       `json:"name,omitempty"`
+	Op       string       `json:"op,omitempty"`
+	Module   string       `json:"module,omitempty"`
+	Names    []string     `json:"names,omitempty"`
+	Level    int          `json:"level,omitempty"`
+	Value    string       `json:"value,omitempty"`
+	Text     string       `json:"text,omitempty"`
+	Location DumpLocation `json:"location"`
+	Children []*DumpNode  `json:"children,omitempty"`
+}
+
+// DumpLocation is the position of a dumped node. Lines are 1-based and
+// columns are 0-based code point offsets.
+type DumpLocation struct {
+	StartLine int `json:"start_line"`
+	StartCol  int `json:"start_col"`
+	EndLine   int `json:"end_line"`
+	EndCol    int `json:"end_col"`
+	StartByte int `json:"start_byte"`
+	EndByte   int `json:"end_byte"`
+}
+
+func dumpLocation(loc Location) DumpLocation {
+	return DumpLocation{
+		StartLine: loc.StartLine,
+		StartCol:  loc.StartCol,
+		EndLine:   loc.EndLine,
+		EndCol:    loc.EndCol,
+		StartByte: loc.StartByte,
+		EndByte:   loc.EndByte,
+	}
+}
+
+// DumpAST converts an internal AST into a dump tree. Children are labeled
+// with the node field holding them and listed in canonical order.
+func DumpAST(node *Node) *DumpNode {
+	if node == nil {
+		return nil
+	}
+
+	dump := &DumpNode{
+		Type:     string(node.Type),
+		Name:     node.Name,
+		Op:       node.Op,
+		Module:   node.Module,
+		Names:    node.Names,
+		Level:    node.Level,
+		Location: dumpLocation(node.Location),
+	}
+	if node.Value != nil {
+		if _, ok := node.Value.(*Node); !ok {
+			dump.Value = fmt.Sprint(node.Value)
+		}
+	}
+
+	seen := make(map[*Node]struct{})
+	appendNode := func(field string, child *Node) {
+		if child == nil {
+			return
+		}
+		if _, ok := seen[child]; ok {
+			return
+		}
+		seen[child] = struct{}{}
+		childDump := DumpAST(child)
+		childDump.Field = field
+		dump.Children = append(dump.Children, childDump)
+	}
+	appendNodes := func(field string, children []*Node) {
+		for _, child := range children {
+			appendNode(field, child)
+		}
+	}
+
+	// Same order as OrderedChildren, so a dump reads like a walk
+	appendNodes("children", node.Children)
+	appendNodes("decorator", node.Decorator)
+	appendNodes("bases", node.Bases)
+	appendNodes("args", node.Args)
+	appendNodes("targets", node.Targets)
+	appendNode("test", node.Test)
+	appendNode("iter", node.Iter)
+	appendNode("left", node.Left)
+	appendNode("right", node.Right)
+	appendNode("target", node.Target)
+	if valueNode, ok := node.Value.(*Node); ok {
+		appendNode("value", valueNode)
+	}
+	appendNodes("keywords", node.Keywords)
+	appendNodes("body", node.Body)
+	appendNodes("handlers", node.Handlers)
+	appendNodes("orelse", node.Orelse)
+	appendNodes("finalbody", node.Finalbody)
+
+	return dump
+}
+
+// DumpTree converts a tree-sitter tree into a dump tree of its named nodes,
+// labeled with their grammar field names. Leaf nodes carry their source text.
+func DumpTree(node *sitter.Node, source []byte) *DumpNode {
+	if node == nil {
+		return nil
+	}
+
+	dump := &DumpNode{
+		Type:     node.Type(),
+		Location: dumpLocation(NodeLocation(node, source)),
+	}
+	if node.NamedChildCount() == 0 {
+		dump.Text = node.Content(source)
+		return dump
+	}
+
+	for i := 0; i < int(node.ChildCount()); i++ {
+		child := node.Child(i)
+		if child == nil || !child.IsNamed() {
+			continue
+		}
+		childDump := DumpTree(child, source)
+		childDump.Field = node.FieldNameForChild(i)
+		dump.Children = append(dump.Children, childDump)
+	}
+	return dump
+}
+
+// WriteSExpr writes a dump tree as an indented s-expression, one node per
+// line, such as `body: (call [3:4-3:11]`. Positions are
+// startLine:startCol-endLine:endCol.
+func WriteSExpr(w io.Writer, node *DumpNode) error {
+	bw := bufio.NewWriter(w)
+	if node != nil {
+		writeSExprNode(bw, node, 0)
+		bw.WriteString("\n")
+	}
+	return bw.Flush()
+}
+
+func writeSExprNode(w *bufio.Writer, node *DumpNode, depth int) {
+	w.WriteString(strings.Repeat("  ", depth))
+	if node.Field != "" {
+		w.WriteString(node.Field)
+		w.WriteString(": ")
+	}
+	w.WriteString("(")
+	w.WriteString(node.Type)
+
+	writeAttr := func(key, value string) {
+		if value != "" {
+			fmt.Fprintf(w, " %s=%s", key, strconv.Quote(value))
+		}
+	}
+	writeAttr("name", node.Name)
+	writeAttr("op", node.Op)
+	writeAttr("module", node.Module)
+	if len(node.Names) > 0 {
+		writeAttr("names", strings.Join(node.Names, ","))
+	}
+	if node.Level > 0 {
+		writeAttr("level", strconv.Itoa(node.Level))
+	}
+	writeAttr("value", node.Value)
+	writeAttr("text", node.Text)
+
+	loc := node.Location
+	fmt.Fprintf(w, " [%d:%d-%d:%d]", loc.StartLine, loc.StartCol, loc.EndLine, loc.EndCol)
+
+	for _, child := range node.Children {
+		w.WriteString("\n")
+		writeSExprNode(w, child, depth+1)
+	}
+	w.WriteString(")")
+}
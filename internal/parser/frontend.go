@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"path/filepath"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/python"
+)
+
+// LanguageFrontend adapts a language to the parser: the tree-sitter grammar
+// parsing its files and the building of the internal AST from its trees.
+// Frontends of Python dialects reuse the Python grammar and AST builder,
+// rewriting their source first.
+type LanguageFrontend interface {
+	// Name identifies the language, such as "python"
+	Name() string
+
+	// Extensions are the file extensions of the language, with their dot
+	Extensions() []string
+
+	// Language is the tree-sitter grammar parsing the prepared source
+	Language() *sitter.Language
+
+	// Prepare rewrites source into what the grammar parses. It must keep
+	// every statement on its lines, since findings report the lines of
+	// the original file.
+	Prepare(source []byte) []byte
+
+	// BuildAST builds the internal AST from the tree of the prepared source
+	BuildAST(tree *sitter.Tree, source []byte) (*Node, error)
+}
+
+// pythonFrontend parses Python source and stubs
+type pythonFrontend struct{}
+
+// PythonFrontend returns the frontend of Python, the default one
+func PythonFrontend() LanguageFrontend {
+	return pythonFrontend{}
+}
+
+func (pythonFrontend) Name() string { return "python" }
+
+func (pythonFrontend) Extensions() []string { return []string{".py", ".pyi"} }
+
+func (pythonFrontend) Language() *sitter.Language { return python.GetLanguage() }
+
+func (pythonFrontend) Prepare(source []byte) []byte { return source }
+
+func (pythonFrontend) BuildAST(tree *sitter.Tree, source []byte) (*Node, error) {
+	return NewASTBuilder(source).Build(tree)
+}
+
+// frontends are the registered frontends, in the order they are looked up
+var frontends = []LanguageFrontend{PythonFrontend(), CythonFrontend()}
+
+// RegisterFrontend adds a frontend, taking over the extensions it shares
+// with those registered before. It is meant to be called from init
+// functions, before any parsing.
+func RegisterFrontend(frontend LanguageFrontend) {
+	frontends = append([]LanguageFrontend{frontend}, frontends...)
+}
+
+// FrontendForFile returns the frontend of a file by its extension, or nil
+// when no frontend parses it
+func FrontendForFile(path string) LanguageFrontend {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, frontend := range frontends {
+		for _, candidate := range frontend.Extensions() {
+			if ext == candidate {
+				return frontend
+			}
+		}
+	}
+	return nil
+}
+
+// IsSourceFile reports whether a frontend parses the file
+func IsSourceFile(path string) bool {
+	return FrontendForFile(path) != nil
+}
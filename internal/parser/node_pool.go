@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"sync"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Nodes are allocated in blocks that a builder fills front to back. The
+// first block is sized for the source at one node per sourceBytesPerNode
+// bytes, which covers most code, so that a small file does not allocate a
+// large block; later blocks double up to maxNodeSlabSize nodes. A block is
+// never shared between builds: it stays alive as long as any of its nodes
+// does, so sharing one would let an AST that outlives its build keep the
+// nodes of later builds alive. Nodes handed to a builder that is retried
+// after hitting the depth limit are not reused either; they are freed with
+// the rest of their block.
+const (
+	sourceBytesPerNode = 4
+	minNodeSlabSize    = 16
+	maxNodeSlabSize    = 256
+)
+
+// newNode returns a node initialized like NewNode, taken from the current
+// block of preallocated nodes
+func (b *ASTBuilder) newNode(nodeType NodeType) *Node {
+	if len(b.slab) == 0 {
+		if b.slabSize == 0 {
+			b.slabSize = len(b.source) / sourceBytesPerNode
+		} else {
+			b.slabSize *= 2
+		}
+		b.slabSize = min(max(b.slabSize, minNodeSlabSize), maxNodeSlabSize)
+		b.slab = make([]Node, b.slabSize)
+	}
+	node := &b.slab[0]
+	b.slab = b.slab[1:]
+
+	node.Type = nodeType
+	node.Children = []*Node{}
+	node.Body = []*Node{}
+	node.Orelse = []*Node{}
+	node.Args = []*Node{}
+	node.Keywords = []*Node{}
+	node.Names = []string{}
+	return node
+}
+
+// releaseSlab drops the unused nodes of the current block, so that the next
+// build starts a block of its own
+func (b *ASTBuilder) releaseSlab() {
+	b.slab = nil
+	b.slabSize = 0
+}
+
+// nodeTypeNames interns tree-sitter node type names by grammar symbol;
+// sitter.Node.Type allocates a new string on every call
+var nodeTypeNames sync.Map // sitter.Symbol -> string
+
+// nodeType returns the interned type name of a tree-sitter node
+func (b *ASTBuilder) nodeType(tsNode *sitter.Node) string {
+	symbol := tsNode.Symbol()
+	if name, ok := nodeTypeNames.Load(symbol); ok {
+		return name.(string)
+	}
+	name := tsNode.Type()
+	nodeTypeNames.Store(symbol, name)
+	return name
+}
+
+// internedTokens are operators, keywords and names so common that their
+// source text is shared instead of copied for every occurrence
+var internedTokens = func() map[string]string {
+	tokens := []string{
+		"+", "-", "*", "/", "//", "%", "**", "@", "<<", ">>", "&", "|", "^", "~",
+		"+=", "-=", "*=", "/=", "//=", "%=", "**=", "@=", "<<=", ">>=", "&=", "|=", "^=",
+		"<", ">", "==", ">=", "<=", "!=", "<>", "in", "not in", "is", "is not",
+		"and", "or", "not", "=", ":=",
+		"self", "cls", "None", "True", "False", "__init__", "super", "print", "len",
+	}
+	interned := make(map[string]string, len(tokens))
+	for _, token := range tokens {
+		interned[token] = token
+	}
+	return interned
+}()
@@ -6,33 +6,50 @@ import (
 	"io"
 
 	sitter "github.com/smacker/go-tree-sitter"
-	"github.com/smacker/go-tree-sitter/python"
 )
 
 // Parser provides Python code parsing capabilities using tree-sitter
 type Parser struct {
-	parser *sitter.Parser
+	parser   *sitter.Parser
+	frontend LanguageFrontend
 }
 
 // New creates a new Parser instance with Python grammar
 func New() *Parser {
+	return NewWithFrontend(PythonFrontend())
+}
+
+// NewWithFrontend creates a new Parser instance parsing the language of a
+// frontend
+func NewWithFrontend(frontend LanguageFrontend) *Parser {
 	parser := sitter.NewParser()
-	parser.SetLanguage(python.GetLanguage())
+	parser.SetLanguage(frontend.Language())
 	return &Parser{
-		parser: parser,
+		parser:   parser,
+		frontend: frontend,
 	}
 }
 
+// Frontend returns the frontend the parser currently parses with
+func (p *Parser) Frontend() LanguageFrontend {
+	return p.frontend
+}
+
 // ParseResult represents the result of parsing Python code
 type ParseResult struct {
 	Tree       *sitter.Tree
 	RootNode   *sitter.Node
 	SourceCode []byte
 	AST        *Node // Internal AST representation
+
+	// Language is the grammar of the tree; SourceCode is the source as
+	// prepared for it by the frontend
+	Language *sitter.Language
 }
 
 // Parse parses Python source code and returns the AST
 func (p *Parser) Parse(ctx context.Context, source []byte) (*ParseResult, error) {
+	source = p.frontend.Prepare(source)
 	tree, err := p.parser.ParseCtx(ctx, nil, source)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse source: %w", err)
@@ -44,8 +61,7 @@ func (p *Parser) Parse(ctx context.Context, source []byte) (*ParseResult, error)
 	}
 
 	// Build internal AST representation
-	builder := NewASTBuilder(source)
-	ast, err := builder.Build(tree)
+	ast, err := p.frontend.BuildAST(tree, source)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build AST: %w", err)
 	}
@@ -55,9 +71,24 @@ func (p *Parser) Parse(ctx context.Context, source []byte) (*ParseResult, error)
 		RootNode:   rootNode,
 		SourceCode: source,
 		AST:        ast,
+		Language:   p.frontend.Language(),
 	}, nil
 }
 
+// ParseFor parses the source of a file with the frontend of its extension,
+// falling back to Python for extensions no frontend claims
+func (p *Parser) ParseFor(ctx context.Context, path string, source []byte) (*ParseResult, error) {
+	frontend := FrontendForFile(path)
+	if frontend == nil {
+		frontend = PythonFrontend()
+	}
+	if frontend.Name() != p.frontend.Name() {
+		p.parser.SetLanguage(frontend.Language())
+		p.frontend = frontend
+	}
+	return p.Parse(ctx, source)
+}
+
 // ParseFile parses a Python file from a reader
 func (p *Parser) ParseFile(ctx context.Context, reader io.Reader) (*ParseResult, error) {
 	source, err := io.ReadAll(reader)
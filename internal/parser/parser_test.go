@@ -2,6 +2,7 @@ package parser
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -324,6 +325,72 @@ func TestParseRejectsIncompleteCode(t *testing.T) {
 	}
 }
 
+func TestParseResultQuery(t *testing.T) {
+	source := []byte("x = eval(a)\ny = exec(b)\nz = eval(c)\n")
+	result, err := New().Parse(context.Background(), source)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	matches, err := result.Query(`(call function: (identifier) @fn arguments: (argument_list (identifier) @arg) (#eq? @fn "eval"))`)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %d", len(matches))
+	}
+	var got []string
+	for _, match := range matches {
+		for _, capture := range match.Captures {
+			got = append(got, fmt.Sprintf("%s=%s@%d:%d", capture.Name, capture.Text, capture.Location.StartLine, capture.Location.StartCol))
+		}
+	}
+	want := "fn=eval@1:4 arg=a@1:9 fn=eval@3:4 arg=c@3:9"
+	if strings.Join(got, " ") != want {
+		t.Errorf("captures = %q, want %q", strings.Join(got, " "), want)
+	}
+
+	if _, err := result.Query("(call"); err == nil {
+		t.Error("expected an invalid query to return an error")
+	}
+}
+
+func TestWriteSExpr(t *testing.T) {
+	source := []byte("def f(x):\n    return x + 1\n")
+	result, err := New().Parse(context.Background(), source)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	var ast strings.Builder
+	if err := WriteSExpr(&ast, DumpAST(result.AST)); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`(Module [1:0-3:0]`,
+		`  body: (FunctionDef name="f" [1:0-2:16]`,
+		`      value: (BinOp op="+" [2:11-2:16]`,
+		`        right: (Constant value="1" [2:15-2:16])`,
+	} {
+		if !strings.Contains(ast.String(), want+"\n") && !strings.Contains(ast.String(), want+")") {
+			t.Errorf("AST dump is missing %q:\n%s", want, ast.String())
+		}
+	}
+
+	var raw strings.Builder
+	if err := WriteSExpr(&raw, DumpTree(result.RootNode, result.SourceCode)); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`    name: (identifier text="f" [1:4-1:5])`,
+		`          right: (integer text="1" [2:15-2:16])`,
+	} {
+		if !strings.Contains(raw.String(), want) {
+			t.Errorf("tree-sitter dump is missing %q:\n%s", want, raw.String())
+		}
+	}
+}
+
 func BenchmarkParse(b *testing.B) {
 	parser := New()
 	ctx := context.Background()
@@ -372,3 +439,22 @@ def func2():
 		})
 	}
 }
+
+func BenchmarkParseLargeFile(b *testing.B) {
+	parser := New()
+	ctx := context.Background()
+	var source strings.Builder
+	for i := 0; i < 200; i++ {
+		fmt.Fprintf(&source, "def handler_%d(request, *args, **kwargs):\n", i)
+		source.WriteString("    if request.method == \"POST\":\n")
+		source.WriteString("        return {\"status\": 201, \"items\": [x * 2 for x in args]}\n")
+		source.WriteString("    return kwargs.get(\"default\", None)\n\n")
+	}
+	data := []byte(source.String())
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = parser.Parse(ctx, data)
+	}
+}
@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"fmt"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/python"
+)
+
+// QueryCapture is a node captured by a tree-sitter query
+type QueryCapture struct {
+	// Name is the capture name without the leading "@"
+	Name     string
+	Node     *sitter.Node
+	Text     string
+	Location Location
+}
+
+// QueryMatch is one match of a pattern of a tree-sitter query
+type QueryMatch struct {
+	// Pattern is the index of the matching pattern in the query
+	Pattern  int
+	Captures []QueryCapture
+}
+
+// Query runs a tree-sitter query, such as
+// `(call function: (identifier) @name (#eq? @name "eval"))`, against the
+// retained tree-sitter tree and returns its matches in document order.
+// Predicates (#eq?, #match? and their negations) are applied.
+func (r *ParseResult) Query(pattern string) ([]QueryMatch, error) {
+	if r == nil || r.RootNode == nil {
+		return nil, fmt.Errorf("no tree to query")
+	}
+
+	language := r.Language
+	if language == nil {
+		language = python.GetLanguage()
+	}
+	query, err := sitter.NewQuery([]byte(pattern), language)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+	defer query.Close()
+
+	cursor := sitter.NewQueryCursor()
+	defer cursor.Close()
+	cursor.Exec(query, r.RootNode)
+
+	var matches []QueryMatch
+	for {
+		match, ok := cursor.NextMatch()
+		if !ok {
+			break
+		}
+		match = cursor.FilterPredicates(match, r.SourceCode)
+		if len(match.Captures) == 0 {
+			continue
+		}
+
+		result := QueryMatch{Pattern: int(match.PatternIndex)}
+		for _, capture := range match.Captures {
+			result.Captures = append(result.Captures, QueryCapture{
+				Name:     query.CaptureNameForId(capture.Index),
+				Node:     capture.Node,
+				Text:     capture.Node.Content(r.SourceCode),
+				Location: NodeLocation(capture.Node, r.SourceCode),
+			})
+		}
+		matches = append(matches, result)
+	}
+	return matches, nil
+}
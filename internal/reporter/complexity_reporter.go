@@ -36,6 +36,9 @@ type SerializableComplexityResult struct {
 	LoopStatements    int    `json:"loop_statements" yaml:"loop_statements"`
 	ExceptionHandlers int    `json:"exception_handlers" yaml:"exception_handlers"`
 	SwitchCases       int    `json:"switch_cases" yaml:"switch_cases"`
+	BooleanOperators  int    `json:"boolean_operators" yaml:"boolean_operators"`
+	Comprehensions    int    `json:"comprehensions" yaml:"comprehensions"`
+	NestingDepth      int    `json:"nesting_depth" yaml:"nesting_depth"`
 }
 
 // ComplexityReport represents a complete complexity analysis report
@@ -161,6 +164,9 @@ func (r *ComplexityReporter) GenerateReport(results []ComplexityResult, filesAna
 			LoopStatements:    detailed["loop_statements"],
 			ExceptionHandlers: detailed["exception_handlers"],
 			SwitchCases:       detailed["switch_cases"],
+			BooleanOperators:  detailed["boolean_operators"],
+			Comprehensions:    detailed["comprehensions"],
+			NestingDepth:      detailed["nesting_depth"],
 		}
 	}
 
@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// defaultEditor is used when neither $VISUAL nor $EDITOR is set
+const defaultEditor = "vi"
+
+// EditorFromEnv returns the user's editor command line
+func EditorFromEnv() string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(name)); editor != "" {
+			return editor
+		}
+	}
+	return defaultEditor
+}
+
+// EditorCommand builds the argv that opens file at line in editor. The
+// editor string may carry its own arguments (e.g. "code --wait"). Editors
+// disagree on how a line number is passed, so the common ones are
+// recognized by name; anything else gets the vi-style +LINE argument that
+// most terminal editors accept.
+func EditorCommand(editor, file string, line int) []string {
+	argv := strings.Fields(editor)
+	if len(argv) == 0 {
+		argv = []string{defaultEditor}
+	}
+	if line < 1 {
+		return append(argv, file)
+	}
+	lineArg := strconv.Itoa(line)
+
+	name := strings.TrimSuffix(filepath.Base(argv[0]), ".exe")
+	switch name {
+	case "code", "code-insiders", "codium", "cursor", "windsurf":
+		return append(argv, "--goto", file+":"+lineArg)
+	case "subl", "sublime_text", "hx", "helix", "zed":
+		return append(argv, file+":"+lineArg)
+	case "idea", "pycharm", "pycharm.sh", "charm", "idea.sh":
+		return append(argv, "--line", lineArg, file)
+	default:
+		return append(argv, "+"+lineArg, file)
+	}
+}
@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"bufio"
+	"unicode/utf8"
+)
+
+// KeyType identifies a key press
+type KeyType int
+
+const (
+	KeyRune KeyType = iota // A printable character, see KeyMsg.Rune
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyPageUp
+	KeyPageDown
+	KeyHome
+	KeyEnd
+	KeyEnter
+	KeyEscape
+	KeyBackspace
+	KeyTab
+	KeyCtrlC
+	KeyUnknown
+)
+
+// KeyMsg is a decoded key press
+type KeyMsg struct {
+	Type KeyType
+	Rune rune
+}
+
+// readKey decodes one key press from a terminal in raw mode. Escape
+// sequences arrive in a single write, so a lone ESC byte with nothing
+// buffered behind it is the Escape key itself.
+func readKey(r *bufio.Reader) (KeyMsg, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return KeyMsg{}, err
+	}
+
+	switch b {
+	case 0x03:
+		return KeyMsg{Type: KeyCtrlC}, nil
+	case '\r', '\n':
+		return KeyMsg{Type: KeyEnter}, nil
+	case '\t':
+		return KeyMsg{Type: KeyTab}, nil
+	case 0x7f, 0x08:
+		return KeyMsg{Type: KeyBackspace}, nil
+	case 0x1b:
+		if r.Buffered() == 0 {
+			return KeyMsg{Type: KeyEscape}, nil
+		}
+		return readEscapeSequence(r)
+	}
+
+	if b < utf8.RuneSelf {
+		if b < 0x20 {
+			return KeyMsg{Type: KeyUnknown}, nil
+		}
+		return KeyMsg{Type: KeyRune, Rune: rune(b)}, nil
+	}
+
+	// Multi-byte UTF-8 character
+	if err := r.UnreadByte(); err != nil {
+		return KeyMsg{}, err
+	}
+	ch, _, err := r.ReadRune()
+	if err != nil {
+		return KeyMsg{}, err
+	}
+	return KeyMsg{Type: KeyRune, Rune: ch}, nil
+}
+
+// readEscapeSequence decodes the CSI and SS3 sequences sent for cursor and
+// paging keys after the leading ESC
+func readEscapeSequence(r *bufio.Reader) (KeyMsg, error) {
+	intro, err := r.ReadByte()
+	if err != nil {
+		return KeyMsg{}, err
+	}
+	if intro != '[' && intro != 'O' {
+		return KeyMsg{Type: KeyUnknown}, nil
+	}
+
+	var params []byte
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return KeyMsg{}, err
+		}
+		if b >= 0x40 && b <= 0x7e {
+			return decodeFinalByte(b, string(params)), nil
+		}
+		params = append(params, b)
+	}
+}
+
+func decodeFinalByte(final byte, params string) KeyMsg {
+	switch final {
+	case 'A':
+		return KeyMsg{Type: KeyUp}
+	case 'B':
+		return KeyMsg{Type: KeyDown}
+	case 'C':
+		return KeyMsg{Type: KeyRight}
+	case 'D':
+		return KeyMsg{Type: KeyLeft}
+	case 'H':
+		return KeyMsg{Type: KeyHome}
+	case 'F':
+		return KeyMsg{Type: KeyEnd}
+	case '~':
+		switch params {
+		case "1", "7":
+			return KeyMsg{Type: KeyHome}
+		case "4", "8":
+			return KeyMsg{Type: KeyEnd}
+		case "5":
+			return KeyMsg{Type: KeyPageUp}
+		case "6":
+			return KeyMsg{Type: KeyPageDown}
+		}
+	}
+	return KeyMsg{Type: KeyUnknown}
+}
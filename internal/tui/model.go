@@ -0,0 +1,386 @@
+// Package tui implements the interactive results browser started by
+// `pyscn analyze --interactive`. It follows the model/update/view pattern:
+// Model holds all state, Update applies one key press and View renders a
+// full frame. Only Run touches the terminal, so the model is testable
+// without one.
+package tui
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// GroupBy selects how the finding list is grouped
+type GroupBy int
+
+const (
+	GroupByCategory GroupBy = iota
+	GroupByFile
+)
+
+// Action is what Run has to do after an update
+type Action int
+
+const (
+	ActionNone Action = iota
+	ActionQuit
+	ActionOpenEditor
+)
+
+// projectGroup labels findings that are not tied to a file when grouping
+// by file
+const projectGroup = "(project)"
+
+// categoryOrder is the display order of the category groups
+var categoryOrder = []domain.FindingCategory{
+	domain.FindingCategoryComplexity,
+	domain.FindingCategoryDeadCode,
+	domain.FindingCategoryClone,
+	domain.FindingCategoryCBO,
+	domain.FindingCategoryLCOM,
+	domain.FindingCategoryCycle,
+	domain.FindingCategoryArchitecture,
+	domain.FindingCategoryMockData,
+}
+
+// row is a line of the finding list: either a group header or a finding
+type row struct {
+	header  string
+	finding int // Index into Model.findings, -1 for headers
+}
+
+// Model is the state of the results browser
+type Model struct {
+	findings []domain.Finding
+	rows     []row
+	shown    int // Findings that pass the filters
+
+	cursor int // Row index of the selected finding, -1 when nothing matches
+	offset int // First row drawn in the list pane
+	width  int
+	height int
+
+	groupBy       GroupBy
+	minSeverity   domain.FindingSeverity // Empty shows all severities
+	filter        string
+	editingFilter bool
+	status        string
+
+	readFile func(path string) ([]string, error)
+	sources  map[string][]string
+}
+
+// NewModel creates a browser over findings, which are expected to be
+// sorted most severe first
+func NewModel(findings []domain.Finding) *Model {
+	m := &Model{
+		findings: findings,
+		width:    80,
+		height:   24,
+		readFile: readSourceLines,
+		sources:  make(map[string][]string),
+	}
+	m.rebuild()
+	return m
+}
+
+// SetSize updates the terminal dimensions
+func (m *Model) SetSize(width, height int) {
+	if width > 0 {
+		m.width = width
+	}
+	if height > 0 {
+		m.height = height
+	}
+	m.scrollToCursor()
+}
+
+// SetStatus shows a one-off message in the footer until the next key press
+func (m *Model) SetStatus(status string) {
+	m.status = status
+}
+
+// Selected returns the selected finding, or nil when nothing matches
+func (m *Model) Selected() *domain.Finding {
+	if m.cursor < 0 || m.cursor >= len(m.rows) {
+		return nil
+	}
+	return &m.findings[m.rows[m.cursor].finding]
+}
+
+// Update applies a key press and reports what the caller has to do next
+func (m *Model) Update(key KeyMsg) Action {
+	m.status = ""
+	if key.Type == KeyCtrlC {
+		return ActionQuit
+	}
+	if m.editingFilter {
+		m.updateFilter(key)
+		return ActionNone
+	}
+
+	switch key.Type {
+	case KeyUp:
+		m.move(-1)
+	case KeyDown:
+		m.move(1)
+	case KeyPageUp:
+		m.move(-m.listHeight())
+	case KeyPageDown:
+		m.move(m.listHeight())
+	case KeyHome:
+		m.move(-len(m.rows))
+	case KeyEnd:
+		m.move(len(m.rows))
+	case KeyTab:
+		m.toggleGrouping()
+	case KeyEscape:
+		if m.filter != "" {
+			m.filter = ""
+			m.rebuild()
+		}
+	case KeyEnter:
+		return m.openSelected()
+	case KeyRune:
+		return m.updateRune(key.Rune)
+	}
+	return ActionNone
+}
+
+func (m *Model) updateRune(r rune) Action {
+	switch r {
+	case 'q':
+		return ActionQuit
+	case 'k':
+		m.move(-1)
+	case 'j':
+		m.move(1)
+	case 'g':
+		m.move(-len(m.rows))
+	case 'G':
+		m.move(len(m.rows))
+	case 'e', 'o':
+		return m.openSelected()
+	case 's':
+		m.cycleSeverity()
+	case '/':
+		m.editingFilter = true
+	}
+	return ActionNone
+}
+
+func (m *Model) updateFilter(key KeyMsg) {
+	switch key.Type {
+	case KeyEnter:
+		m.editingFilter = false
+	case KeyEscape:
+		m.editingFilter = false
+		m.filter = ""
+		m.rebuild()
+	case KeyBackspace:
+		if runes := []rune(m.filter); len(runes) > 0 {
+			m.filter = string(runes[:len(runes)-1])
+			m.rebuild()
+		}
+	case KeyRune:
+		m.filter += string(key.Rune)
+		m.rebuild()
+	}
+}
+
+func (m *Model) openSelected() Action {
+	finding := m.Selected()
+	if finding == nil {
+		return ActionNone
+	}
+	if finding.FilePath == "" {
+		m.status = "This finding is not tied to a file"
+		return ActionNone
+	}
+	return ActionOpenEditor
+}
+
+func (m *Model) toggleGrouping() {
+	if m.groupBy == GroupByCategory {
+		m.groupBy = GroupByFile
+	} else {
+		m.groupBy = GroupByCategory
+	}
+	m.rebuild()
+}
+
+// cycleSeverity steps the severity filter through all → medium+ → high
+func (m *Model) cycleSeverity() {
+	switch m.minSeverity {
+	case "":
+		m.minSeverity = domain.FindingSeverityMedium
+	case domain.FindingSeverityMedium:
+		m.minSeverity = domain.FindingSeverityHigh
+	default:
+		m.minSeverity = ""
+	}
+	m.rebuild()
+}
+
+// move shifts the selection by delta findings, skipping group headers
+func (m *Model) move(delta int) {
+	if m.cursor < 0 {
+		return
+	}
+	step := 1
+	if delta < 0 {
+		step, delta = -1, -delta
+	}
+	for ; delta > 0; delta-- {
+		next := m.cursor + step
+		for next >= 0 && next < len(m.rows) && m.rows[next].finding < 0 {
+			next += step
+		}
+		if next < 0 || next >= len(m.rows) {
+			break
+		}
+		m.cursor = next
+	}
+	m.scrollToCursor()
+}
+
+func (m *Model) matches(f *domain.Finding) bool {
+	if m.minSeverity != "" && f.Severity.Rank() < m.minSeverity.Rank() {
+		return false
+	}
+	if m.filter == "" {
+		return true
+	}
+	needle := strings.ToLower(m.filter)
+	for _, field := range []string{string(f.Category), string(f.Severity), f.FilePath, f.Name, f.Message} {
+		if strings.Contains(strings.ToLower(field), needle) {
+			return true
+		}
+	}
+	return false
+}
+
+// rebuild regroups the findings that pass the filters, keeping the
+// selection on the same finding when it is still shown
+func (m *Model) rebuild() {
+	selected := -1
+	if m.cursor >= 0 && m.cursor < len(m.rows) {
+		selected = m.rows[m.cursor].finding
+	}
+
+	groups := make(map[string][]int)
+	var keys []string
+	m.shown = 0
+	for i := range m.findings {
+		f := &m.findings[i]
+		if !m.matches(f) {
+			continue
+		}
+		m.shown++
+		key := m.groupKey(f)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], i)
+	}
+	m.sortGroups(keys)
+
+	m.rows = m.rows[:0]
+	m.cursor = -1
+	for _, key := range keys {
+		m.rows = append(m.rows, row{header: fmt.Sprintf("%s (%d)", key, len(groups[key])), finding: -1})
+		for _, index := range groups[key] {
+			if index == selected || m.cursor < 0 {
+				m.cursor = len(m.rows)
+			}
+			m.rows = append(m.rows, row{finding: index})
+		}
+	}
+	m.offset = 0
+	m.scrollToCursor()
+}
+
+func (m *Model) groupKey(f *domain.Finding) string {
+	if m.groupBy == GroupByFile {
+		if f.FilePath == "" {
+			return projectGroup
+		}
+		return f.FilePath
+	}
+	return string(f.Category)
+}
+
+func (m *Model) sortGroups(keys []string) {
+	if m.groupBy == GroupByFile {
+		sort.Slice(keys, func(i, j int) bool {
+			// Project-level findings go last
+			if (keys[i] == projectGroup) != (keys[j] == projectGroup) {
+				return keys[j] == projectGroup
+			}
+			return keys[i] < keys[j]
+		})
+		return
+	}
+
+	order := make(map[string]int, len(categoryOrder))
+	for i, category := range categoryOrder {
+		order[string(category)] = i
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		return order[keys[i]] < order[keys[j]]
+	})
+}
+
+// listHeight is the number of rows in the list pane; the detail pane gets
+// the rest of the screen below it
+func (m *Model) listHeight() int {
+	available := m.height - 3 // Title, separator and footer lines
+	height := available / 2
+	if height < 3 {
+		height = 3
+	}
+	return height
+}
+
+func (m *Model) scrollToCursor() {
+	height := m.listHeight()
+	if m.cursor < 0 {
+		m.offset = 0
+		return
+	}
+	// Keep the group header of the first visible finding on screen
+	if m.cursor < m.offset+1 {
+		m.offset = m.cursor - 1
+	}
+	if m.cursor >= m.offset+height {
+		m.offset = m.cursor - height + 1
+	}
+	if m.offset < 0 {
+		m.offset = 0
+	}
+}
+
+// source returns the lines of a file, cached for the lifetime of the model
+func (m *Model) source(path string) []string {
+	if lines, ok := m.sources[path]; ok {
+		return lines
+	}
+	lines, err := m.readFile(path)
+	if err != nil {
+		lines = nil
+	}
+	m.sources[path] = lines
+	return lines
+}
+
+func readSourceLines(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n"), nil
+}
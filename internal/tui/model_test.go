@@ -0,0 +1,195 @@
+package tui
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+func testFindings() []domain.Finding {
+	return []domain.Finding{
+		{Category: domain.FindingCategoryDeadCode, Severity: domain.FindingSeverityHigh, FilePath: "b.py", StartLine: 3, EndLine: 4, Name: "run", Message: "Unreachable code in run"},
+		{Category: domain.FindingCategoryComplexity, Severity: domain.FindingSeverityMedium, FilePath: "a.py", StartLine: 1, EndLine: 9, Name: "parse", Message: "parse has complexity 14"},
+		{Category: domain.FindingCategoryComplexity, Severity: domain.FindingSeverityLow, FilePath: "b.py", StartLine: 20, Name: "load", Message: "load has complexity 11"},
+		{Category: domain.FindingCategoryCycle, Severity: domain.FindingSeverityHigh, Name: "a → b", Message: "Import cycle between 2 modules"},
+	}
+}
+
+func keys(m *Model, input string) Action {
+	action := ActionNone
+	for _, r := range input {
+		action = m.Update(KeyMsg{Type: KeyRune, Rune: r})
+	}
+	return action
+}
+
+func TestModelGroupsByCategoryInFixedOrder(t *testing.T) {
+	m := NewModel(testFindings())
+
+	var headers []string
+	for _, r := range m.rows {
+		if r.finding < 0 {
+			headers = append(headers, r.header)
+		}
+	}
+	want := []string{"complexity (2)", "dead_code (1)", "cycle (1)"}
+	if strings.Join(headers, ",") != strings.Join(want, ",") {
+		t.Errorf("expected groups %v, got %v", want, headers)
+	}
+	if got := m.Selected(); got == nil || got.Name != "parse" {
+		t.Errorf("expected the first finding to be selected, got %+v", got)
+	}
+}
+
+func TestModelNavigationSkipsHeaders(t *testing.T) {
+	m := NewModel(testFindings())
+
+	keys(m, "jj")
+	if got := m.Selected().Name; got != "run" {
+		t.Errorf("expected run after moving past the dead_code header, got %s", got)
+	}
+	m.Update(KeyMsg{Type: KeyEnd})
+	if got := m.Selected().Name; got != "a → b" {
+		t.Errorf("expected the last finding, got %s", got)
+	}
+	keys(m, "j")
+	if got := m.Selected().Name; got != "a → b" {
+		t.Errorf("moving past the end should stay on the last finding, got %s", got)
+	}
+	keys(m, "g")
+	if got := m.Selected().Name; got != "parse" {
+		t.Errorf("expected the first finding, got %s", got)
+	}
+}
+
+func TestModelFilterAndSeverity(t *testing.T) {
+	m := NewModel(testFindings())
+
+	keys(m, "/load")
+	if !m.editingFilter || m.shown != 1 || m.Selected().Name != "load" {
+		t.Fatalf("expected live filtering to show only load, got %d shown", m.shown)
+	}
+	m.Update(KeyMsg{Type: KeyBackspace})
+	m.Update(KeyMsg{Type: KeyBackspace})
+	m.Update(KeyMsg{Type: KeyBackspace})
+	m.Update(KeyMsg{Type: KeyEnter})
+	if m.editingFilter || m.filter != "l" {
+		t.Fatalf("expected filter %q after editing, got %q", "l", m.filter)
+	}
+	m.Update(KeyMsg{Type: KeyEscape})
+	if m.filter != "" || m.shown != 4 {
+		t.Fatalf("escape should clear the filter, got %q with %d shown", m.filter, m.shown)
+	}
+
+	keys(m, "s")
+	if m.shown != 3 {
+		t.Errorf("expected 3 findings at medium severity or above, got %d", m.shown)
+	}
+	keys(m, "s")
+	if m.shown != 2 {
+		t.Errorf("expected 2 high severity findings, got %d", m.shown)
+	}
+	keys(m, "s")
+	if m.shown != 4 {
+		t.Errorf("expected all findings after cycling back, got %d", m.shown)
+	}
+}
+
+func TestModelGroupByFileKeepsSelection(t *testing.T) {
+	m := NewModel(testFindings())
+	keys(m, "jj")
+
+	m.Update(KeyMsg{Type: KeyTab})
+	if m.groupBy != GroupByFile {
+		t.Fatal("tab should switch to grouping by file")
+	}
+	if got := m.Selected().Name; got != "run" {
+		t.Errorf("expected the selection to stay on run, got %s", got)
+	}
+	if last := m.rows[len(m.rows)-2]; last.header != projectGroup+" (1)" {
+		t.Errorf("expected project-level findings last, got %q", last.header)
+	}
+}
+
+func TestModelOpenEditor(t *testing.T) {
+	m := NewModel(testFindings())
+	if action := keys(m, "e"); action != ActionOpenEditor {
+		t.Errorf("expected ActionOpenEditor, got %v", action)
+	}
+
+	m.Update(KeyMsg{Type: KeyEnd})
+	if action := m.Update(KeyMsg{Type: KeyEnter}); action != ActionNone {
+		t.Errorf("findings without a file cannot be opened, got %v", action)
+	}
+	if m.status == "" {
+		t.Error("expected a status message explaining why nothing was opened")
+	}
+	if action := keys(m, "q"); action != ActionQuit {
+		t.Errorf("expected ActionQuit, got %v", action)
+	}
+}
+
+func TestModelViewShowsSnippet(t *testing.T) {
+	m := NewModel(testFindings())
+	m.readFile = func(path string) ([]string, error) {
+		return []string{"def parse(data):", "    if data:", "        return 1"}, nil
+	}
+	m.SetSize(60, 20)
+
+	view := m.View()
+	lines := strings.Split(view, "\n")
+	if len(lines) != 20 {
+		t.Errorf("expected a full 20 line frame, got %d lines", len(lines))
+	}
+	for _, expected := range []string{"pyscn — 4 findings", "a.py:1-9", "1 ▌ def parse(data):", "/ filter"} {
+		if !strings.Contains(view, expected) {
+			t.Errorf("expected view to contain %q:\n%s", expected, view)
+		}
+	}
+}
+
+func TestReadKey(t *testing.T) {
+	input := "j\x1b[A\x1b[6~\x1bOH\r\x7f\x03é"
+	reader := bufio.NewReader(strings.NewReader(input))
+
+	want := []KeyMsg{
+		{Type: KeyRune, Rune: 'j'},
+		{Type: KeyUp},
+		{Type: KeyPageDown},
+		{Type: KeyHome},
+		{Type: KeyEnter},
+		{Type: KeyBackspace},
+		{Type: KeyCtrlC},
+		{Type: KeyRune, Rune: 'é'},
+	}
+	for i, expected := range want {
+		got, err := readKey(reader)
+		if err != nil {
+			t.Fatalf("key %d: %v", i, err)
+		}
+		if got != expected {
+			t.Errorf("key %d: expected %+v, got %+v", i, expected, got)
+		}
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	tests := []struct {
+		editor string
+		want   string
+	}{
+		{"vim", "vim +12 app.py"},
+		{"/usr/bin/nvim", "/usr/bin/nvim +12 app.py"},
+		{"code --wait", "code --wait --goto app.py:12"},
+		{"subl", "subl app.py:12"},
+		{"pycharm", "pycharm --line 12 app.py"},
+		{"", "vi +12 app.py"},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(EditorCommand(tt.editor, "app.py", 12), " "); got != tt.want {
+			t.Errorf("EditorCommand(%q) = %q, want %q", tt.editor, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"golang.org/x/term"
+)
+
+// Terminal control sequences
+const (
+	enterAltScreen = "\x1b[?1049h\x1b[?25l"
+	exitAltScreen  = "\x1b[?25h\x1b[?1049l"
+	cursorHome     = "\x1b[H"
+	clearLineEnd   = "\x1b[K"
+	clearScreenEnd = "\x1b[J"
+)
+
+// ErrNotTerminal is returned when the browser is started without an
+// interactive terminal
+var ErrNotTerminal = errors.New("interactive mode requires a terminal on stdin and stdout")
+
+// Run shows the browser on the terminal until the user quits. Findings
+// are opened with $VISUAL or $EDITOR.
+func Run(in, out *os.File, findings []domain.Finding) error {
+	inFd, outFd := int(in.Fd()), int(out.Fd())
+	if !term.IsTerminal(inFd) || !term.IsTerminal(outFd) {
+		return ErrNotTerminal
+	}
+
+	screen := &terminal{in: in, out: out, inFd: inFd, outFd: outFd}
+	if err := screen.enter(); err != nil {
+		return err
+	}
+	defer screen.leave()
+
+	model := NewModel(findings)
+	reader := bufio.NewReader(in)
+	for {
+		if width, height, err := term.GetSize(outFd); err == nil {
+			model.SetSize(width, height)
+		}
+		screen.draw(model.View())
+
+		key, err := readKey(reader)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		switch model.Update(key) {
+		case ActionQuit:
+			return nil
+		case ActionOpenEditor:
+			f := model.Selected()
+			if err := screen.suspend(func() error { return openInEditor(f.FilePath, f.StartLine) }); err != nil {
+				model.SetStatus(fmt.Sprintf("Could not open editor: %v", err))
+			}
+		}
+	}
+}
+
+// terminal switches the terminal between the browser's raw alternate screen
+// and its normal state
+type terminal struct {
+	in, out     *os.File
+	inFd, outFd int
+	state       *term.State
+}
+
+func (t *terminal) enter() error {
+	state, err := term.MakeRaw(t.inFd)
+	if err != nil {
+		return fmt.Errorf("failed to configure terminal: %w", err)
+	}
+	t.state = state
+	fmt.Fprint(t.out, enterAltScreen)
+	return nil
+}
+
+func (t *terminal) leave() {
+	fmt.Fprint(t.out, exitAltScreen)
+	if t.state != nil {
+		_ = term.Restore(t.inFd, t.state)
+		t.state = nil
+	}
+}
+
+// suspend hands the terminal to fn, e.g. an editor, and takes it back after
+func (t *terminal) suspend(fn func() error) error {
+	t.leave()
+	runErr := fn()
+	if err := t.enter(); err != nil {
+		return err
+	}
+	return runErr
+}
+
+// draw repaints the screen in place. Raw mode disables the "\n" to
+// "\r\n" translation, so lines are joined explicitly.
+func (t *terminal) draw(frame string) {
+	var b strings.Builder
+	b.WriteString(cursorHome)
+	for i, line := range strings.Split(frame, "\n") {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+		b.WriteString(line)
+		b.WriteString(clearLineEnd)
+	}
+	b.WriteString(clearScreenEnd)
+	fmt.Fprint(t.out, b.String())
+}
+
+func openInEditor(file string, line int) error {
+	argv := EditorCommand(EditorFromEnv(), file, line)
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
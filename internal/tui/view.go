@@ -0,0 +1,212 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// ANSI styles used by the view
+const (
+	styleReset   = "\x1b[0m"
+	styleBold    = "\x1b[1m"
+	styleDim     = "\x1b[2m"
+	styleReverse = "\x1b[7m"
+	styleRed     = "\x1b[31m"
+	styleYellow  = "\x1b[33m"
+	styleCyan    = "\x1b[36m"
+)
+
+const helpLine = "↑↓/jk move  enter/e open  / filter  s severity  tab group  q quit"
+
+// View renders the full screen as lines separated by "\n", each no wider
+// than the terminal
+func (m *Model) View() string {
+	lines := make([]string, 0, m.height)
+	lines = append(lines, styleBold+truncate(m.title(), m.width)+styleReset)
+	lines = append(lines, m.listLines()...)
+	lines = append(lines, styleDim+strings.Repeat("─", m.width)+styleReset)
+
+	detailHeight := m.height - len(lines) - 1
+	lines = append(lines, m.detailLines(detailHeight)...)
+	for len(lines) < m.height-1 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, m.footer())
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) title() string {
+	grouping := "category"
+	if m.groupBy == GroupByFile {
+		grouping = "file"
+	}
+	title := fmt.Sprintf("pyscn — %d findings", len(m.findings))
+	if m.shown != len(m.findings) {
+		title += fmt.Sprintf(" (%d shown)", m.shown)
+	}
+	title += " · by " + grouping
+	if m.minSeverity != "" {
+		title += " · severity ≥ " + string(m.minSeverity)
+	}
+	if m.filter != "" && !m.editingFilter {
+		title += fmt.Sprintf(" · filter %q", m.filter)
+	}
+	return title
+}
+
+func (m *Model) listLines() []string {
+	height := m.listHeight()
+	lines := make([]string, 0, height)
+	if len(m.rows) == 0 {
+		lines = append(lines, "  No findings match")
+	}
+	for i := m.offset; i < len(m.rows) && len(lines) < height; i++ {
+		r := m.rows[i]
+		if r.finding < 0 {
+			lines = append(lines, styleBold+truncate(r.header, m.width)+styleReset)
+			continue
+		}
+
+		f := &m.findings[r.finding]
+		text := fmt.Sprintf("  %s %s  %s", severityTag(f.Severity), m.rowLocation(f), f.Message)
+		text = truncate(text, m.width)
+		if i == m.cursor {
+			text = styleReverse + padRight(text, m.width) + styleReset
+		} else {
+			text = severityStyle(f.Severity) + text + styleReset
+		}
+		lines = append(lines, text)
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+// rowLocation omits the file name when the list is already grouped by file
+func (m *Model) rowLocation(f *domain.Finding) string {
+	if f.FilePath == "" {
+		return f.Name
+	}
+	if m.groupBy == GroupByFile {
+		return fmt.Sprintf("L%d", f.StartLine)
+	}
+	return fmt.Sprintf("%s:%d", f.FilePath, f.StartLine)
+}
+
+func (m *Model) detailLines(height int) []string {
+	f := m.Selected()
+	if f == nil || height <= 0 {
+		return nil
+	}
+
+	lines := []string{
+		severityStyle(f.Severity) + styleBold + truncate(fmt.Sprintf("%s · %s · %s", f.Severity, f.Category, f.Name), m.width) + styleReset,
+	}
+	if f.FilePath != "" {
+		location := fmt.Sprintf("%s:%d", f.FilePath, f.StartLine)
+		if f.EndLine > f.StartLine {
+			location += fmt.Sprintf("-%d", f.EndLine)
+		}
+		lines = append(lines, truncate(location, m.width))
+	}
+	lines = append(lines, truncate(f.Message, m.width))
+	if f.Detail != "" {
+		lines = append(lines, styleDim+truncate(f.Detail, m.width)+styleReset)
+	}
+
+	if f.FilePath != "" && f.StartLine > 0 && len(lines)+1 < height {
+		lines = append(lines, "")
+		lines = append(lines, m.snippetLines(f, height-len(lines))...)
+	}
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	return lines
+}
+
+// snippetLines shows the finding's code with two lines of leading context,
+// marking the lines that belong to the finding
+func (m *Model) snippetLines(f *domain.Finding, height int) []string {
+	source := m.source(f.FilePath)
+	if len(source) == 0 {
+		return []string{styleDim + "(source not available)" + styleReset}
+	}
+
+	endLine := f.EndLine
+	if endLine < f.StartLine {
+		endLine = f.StartLine
+	}
+	first := f.StartLine - 2
+	if first < 1 {
+		first = 1
+	}
+	gutter := len(fmt.Sprint(first + height))
+
+	var lines []string
+	for n := first; n <= len(source) && len(lines) < height; n++ {
+		marker := " "
+		style := styleDim
+		if n >= f.StartLine && n <= endLine {
+			marker, style = "▌", ""
+		}
+		code := strings.ReplaceAll(source[n-1], "\t", "    ")
+		text := truncate(fmt.Sprintf("%*d %s %s", gutter, n, marker, code), m.width)
+		lines = append(lines, style+text+styleReset)
+	}
+	return lines
+}
+
+func (m *Model) footer() string {
+	switch {
+	case m.editingFilter:
+		return truncate("/"+m.filter+"█", m.width)
+	case m.status != "":
+		return styleYellow + truncate(m.status, m.width) + styleReset
+	default:
+		return styleDim + truncate(helpLine, m.width) + styleReset
+	}
+}
+
+func severityTag(severity domain.FindingSeverity) string {
+	switch severity {
+	case domain.FindingSeverityHigh:
+		return "H"
+	case domain.FindingSeverityMedium:
+		return "M"
+	default:
+		return "L"
+	}
+}
+
+func severityStyle(severity domain.FindingSeverity) string {
+	switch severity {
+	case domain.FindingSeverityHigh:
+		return styleRed
+	case domain.FindingSeverityMedium:
+		return styleYellow
+	default:
+		return styleCyan
+	}
+}
+
+// truncate cuts s to width runes, marking the cut with an ellipsis
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	if width <= 1 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+func padRight(s string, width int) string {
+	if n := len([]rune(s)); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
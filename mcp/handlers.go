@@ -69,6 +69,8 @@ func (h *HandlerSet) HandleAnalyzeCode(ctx context.Context, request mcp.CallTool
 		MinSeverity:     domain.DeadCodeSeverityWarning,
 		CloneSimilarity: 0.8,
 		ConfigFile:      h.deps.ConfigPath(),
+		// Clients resolve reported paths without knowing the project root
+		AbsolutePaths: true,
 	}, analyses)
 	if cfg := h.deps.Config(); cfg != nil {
 		if cfg.Output.MinComplexity > 0 {
@@ -147,6 +149,20 @@ func (h *HandlerSet) HandleAnalyzeCode(ctx context.Context, request mcp.CallTool
 		}
 	}
 
+	// Likewise for docstring coverage, which is opt-in.
+	if m, ok := responseData.(map[string]interface{}); ok && result.Summary.DocumentationScore != nil {
+		if sum, ok := m["summary"].(map[string]interface{}); ok {
+			sum["documentation_score"] = *result.Summary.DocumentationScore
+			sum["docstring_coverage"] = *result.Summary.DocstringCoverage
+		}
+	}
+	if m, ok := responseData.(map[string]interface{}); ok && result.Summary.TypednessScore != nil {
+		if sum, ok := m["summary"].(map[string]interface{}); ok {
+			sum["typedness_score"] = *result.Summary.TypednessScore
+			sum["type_annotation_coverage"] = *result.Summary.TypeAnnotationCoverage
+		}
+	}
+
 	// Convert result to JSON
 	jsonData, err := json.Marshal(responseData)
 	if err != nil {
@@ -704,6 +720,8 @@ func (h *HandlerSet) HandleGetHealthScore(ctx context.Context, request mcp.CallT
 		MinComplexity:   1,
 		CloneSimilarity: 0.8,
 		ConfigFile:      h.deps.ConfigPath(),
+		// Only the summary is returned, so skip keeping per-finding detail
+		SummaryOnly: true,
 	}
 	if cfg := h.deps.Config(); cfg != nil {
 		if cfg.Output.MinComplexity > 0 {
@@ -762,6 +780,18 @@ func (h *HandlerSet) HandleGetHealthScore(ctx context.Context, request mcp.CallT
 		}
 	}
 
+	// Include the documentation score only when docstring coverage ran.
+	if result.Summary.DocumentationScore != nil {
+		if cs, ok := healthScoreResult["category_scores"].(map[string]int); ok {
+			cs["documentation_score"] = *result.Summary.DocumentationScore
+		}
+	}
+	if result.Summary.TypednessScore != nil {
+		if cs, ok := healthScoreResult["category_scores"].(map[string]int); ok {
+			cs["typedness_score"] = *result.Summary.TypednessScore
+		}
+	}
+
 	// Convert to JSON
 	jsonData, err := json.Marshal(healthScoreResult)
 	if err != nil {
@@ -1391,6 +1421,26 @@ func buildAnalyzeUseCase(fileReader domain.FileReader) (*app.AnalyzeUseCase, err
 		return nil, err
 	}
 
+	// Build docstring coverage use case
+	documentationUC, err := app.NewDocumentationUseCaseBuilder().
+		WithService(service.NewDocumentationService()).
+		WithFileReader(fileReader).
+		WithConfigLoader(service.NewDocumentationConfigurationLoader()).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	// Build type annotation coverage use case
+	typeCoverageUC, err := app.NewTypeCoverageUseCaseBuilder().
+		WithService(service.NewTypeCoverageService()).
+		WithFileReader(fileReader).
+		WithConfigLoader(service.NewTypeCoverageConfigurationLoader()).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
 	// Build analyze use case
 	return app.NewAnalyzeUseCaseBuilder().
 		WithComplexityUseCase(complexityUC).
@@ -1400,6 +1450,8 @@ func buildAnalyzeUseCase(fileReader domain.FileReader) (*app.AnalyzeUseCase, err
 		WithLCOMUseCase(lcomUC).
 		WithSystemUseCase(systemUC).
 		WithCommunityUseCase(communityUC).
+		WithDocumentationUseCase(documentationUC).
+		WithTypeCoverageUseCase(typeCoverageUC).
 		WithFileReader(fileReader).
 		WithProgressManager(service.NewProgressManager()).
 		WithParallelExecutor(service.NewParallelExecutor()).
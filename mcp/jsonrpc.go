@@ -0,0 +1,188 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	jsonRPCParseError     = -32700
+	jsonRPCInvalidRequest = -32600
+	jsonRPCMethodNotFound = -32601
+	jsonRPCInvalidParams  = -32602
+	jsonRPCInternalError  = -32603
+	// jsonRPCToolError reports a tool that ran and failed, e.g. on a missing path
+	jsonRPCToolError = -32000
+)
+
+// ListMethodsMethod lists the methods served over JSON-RPC with their
+// parameter schemas
+const ListMethodsMethod = "list_methods"
+
+// jsonRPCRequest is a JSON-RPC 2.0 request. A request without id is a
+// notification and gets no response.
+type jsonRPCRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// jsonRPCResponse is a JSON-RPC 2.0 response carrying either a result or an error
+type jsonRPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *jsonRPCError   `json:"error,omitempty"`
+}
+
+type jsonRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// MethodInfo describes one JSON-RPC method, as returned by list_methods
+type MethodInfo struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Params      mcp.ToolInputSchema `json:"params"`
+}
+
+// JSONRPCServer serves the MCP tools as plain JSON-RPC 2.0 methods: the
+// method is the tool name, the params are the tool arguments and the result
+// is the JSON the tool returns
+type JSONRPCServer struct {
+	tools map[string]server.ServerTool
+	order []string
+}
+
+// NewJSONRPCServer creates a JSON-RPC server exposing the given tools
+func NewJSONRPCServer(tools []server.ServerTool) *JSONRPCServer {
+	s := &JSONRPCServer{tools: make(map[string]server.ServerTool, len(tools))}
+	for _, tool := range tools {
+		s.tools[tool.Tool.Name] = tool
+		s.order = append(s.order, tool.Tool.Name)
+	}
+	return s
+}
+
+// Serve reads newline-delimited requests from in and writes one response
+// line per request to out, in order, until in is exhausted or ctx is done
+func (s *JSONRPCServer) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	reader := bufio.NewReader(in)
+	encoder := json.NewEncoder(out)
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			if response := s.handle(ctx, line); response != nil {
+				if err := encoder.Encode(response); err != nil {
+					return fmt.Errorf("failed to write response: %w", err)
+				}
+			}
+		}
+		if errors.Is(readErr, io.EOF) {
+			return nil
+		}
+		if readErr != nil {
+			return fmt.Errorf("failed to read request: %w", readErr)
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+// handle processes one request line and returns its response, or nil for
+// notifications
+func (s *JSONRPCServer) handle(ctx context.Context, line []byte) *jsonRPCResponse {
+	var request jsonRPCRequest
+	if err := json.Unmarshal(line, &request); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return errorResponse(nil, jsonRPCInvalidRequest, "request must be a JSON object")
+		}
+		return errorResponse(nil, jsonRPCParseError, fmt.Sprintf("parse error: %v", err))
+	}
+	if request.JSONRPC != "2.0" || request.Method == "" {
+		return errorResponse(request.ID, jsonRPCInvalidRequest, `request must have "jsonrpc": "2.0" and a method`)
+	}
+
+	result, rpcErr := s.call(ctx, request.Method, request.Params)
+	if len(request.ID) == 0 {
+		return nil
+	}
+	if rpcErr != nil {
+		return &jsonRPCResponse{JSONRPC: "2.0", ID: request.ID, Error: rpcErr}
+	}
+	return &jsonRPCResponse{JSONRPC: "2.0", ID: request.ID, Result: result}
+}
+
+// call dispatches a method to list_methods or to the tool of that name
+func (s *JSONRPCServer) call(ctx context.Context, method string, params json.RawMessage) (any, *jsonRPCError) {
+	if method == ListMethodsMethod {
+		methods := make([]MethodInfo, 0, len(s.order))
+		for _, name := range s.order {
+			tool := s.tools[name].Tool
+			methods = append(methods, MethodInfo{Name: name, Description: tool.Description, Params: tool.InputSchema})
+		}
+		return methods, nil
+	}
+
+	tool, ok := s.tools[method]
+	if !ok {
+		return nil, &jsonRPCError{Code: jsonRPCMethodNotFound, Message: fmt.Sprintf("unknown method %q", method)}
+	}
+	arguments := map[string]any{}
+	if len(params) > 0 && string(params) != "null" {
+		if err := json.Unmarshal(params, &arguments); err != nil {
+			return nil, &jsonRPCError{Code: jsonRPCInvalidParams, Message: "params must be an object of named arguments"}
+		}
+	}
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = method
+	request.Params.Arguments = arguments
+	result, err := tool.Handler(ctx, request)
+	if err != nil {
+		return nil, &jsonRPCError{Code: jsonRPCInternalError, Message: err.Error()}
+	}
+	text := toolResultText(result)
+	if result.IsError {
+		return nil, &jsonRPCError{Code: jsonRPCToolError, Message: text}
+	}
+	if json.Valid([]byte(text)) {
+		return json.RawMessage(text), nil
+	}
+	return text, nil
+}
+
+// toolResultText joins the text content of a tool result
+func toolResultText(result *mcp.CallToolResult) string {
+	if result == nil {
+		return ""
+	}
+	var parts []string
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			parts = append(parts, text.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+func errorResponse(id json.RawMessage, code int, message string) *jsonRPCResponse {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &jsonRPCResponse{JSONRPC: "2.0", ID: id, Error: &jsonRPCError{Code: code, Message: message}}
+}
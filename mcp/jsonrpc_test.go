@@ -0,0 +1,107 @@
+package mcp_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result"`
+	Error   *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func serveJSONRPC(t *testing.T, requests ...string) []rpcResponse {
+	t.Helper()
+	server := mcp.NewJSONRPCServer(mcp.Tools(mcp.NewHandlerSet(nil)))
+	var out bytes.Buffer
+	require.NoError(t, server.Serve(context.Background(), strings.NewReader(strings.Join(requests, "\n")), &out))
+
+	var responses []rpcResponse
+	decoder := json.NewDecoder(&out)
+	for decoder.More() {
+		var response rpcResponse
+		require.NoError(t, decoder.Decode(&response))
+		responses = append(responses, response)
+	}
+	return responses
+}
+
+func TestJSONRPCServer_ListMethods(t *testing.T) {
+	responses := serveJSONRPC(t, `{"jsonrpc":"2.0","id":1,"method":"list_methods"}`)
+	require.Len(t, responses, 1)
+	require.Nil(t, responses[0].Error)
+
+	var methods []mcp.MethodInfo
+	require.NoError(t, json.Unmarshal(responses[0].Result, &methods))
+	names := make([]string, len(methods))
+	for i, method := range methods {
+		names[i] = method.Name
+	}
+	assert.Equal(t, "analyze_code", names[0])
+	assert.Contains(t, names, "check_complexity")
+	assert.Contains(t, methods[0].Params.Required, "path")
+}
+
+func TestJSONRPCServer_CallsTools(t *testing.T) {
+	dir := t.TempDir()
+	source := "def f(x):\n    if x:\n        return 1\n    return 2\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "module.py"), []byte(source), 0o644))
+	params, err := json.Marshal(map[string]any{"path": dir, "output_mode": "full"})
+	require.NoError(t, err)
+
+	responses := serveJSONRPC(t,
+		`{"jsonrpc":"2.0","id":"a","method":"check_complexity","params":`+string(params)+`}`,
+		`{"jsonrpc":"2.0","method":"check_complexity","params":`+string(params)+`}`,
+		`{"jsonrpc":"2.0","id":2,"method":"check_complexity","params":{"path":"/does/not/exist"}}`,
+	)
+	require.Len(t, responses, 2, "notifications get no response")
+
+	assert.JSONEq(t, `"a"`, string(responses[0].ID))
+	require.Nil(t, responses[0].Error)
+	var result struct {
+		Functions []struct {
+			Name string `json:"name"`
+		} `json:"functions"`
+	}
+	require.NoError(t, json.Unmarshal(responses[0].Result, &result))
+	require.NotEmpty(t, result.Functions)
+	assert.Equal(t, "f", result.Functions[0].Name)
+
+	require.NotNil(t, responses[1].Error)
+	assert.Equal(t, -32000, responses[1].Error.Code)
+	assert.Contains(t, responses[1].Error.Message, "does not exist")
+}
+
+func TestJSONRPCServer_ProtocolErrors(t *testing.T) {
+	responses := serveJSONRPC(t,
+		`not json`,
+		`[{"jsonrpc":"2.0","id":1,"method":"list_methods"}]`,
+		`{"id":3,"method":"list_methods"}`,
+		`{"jsonrpc":"2.0","id":4,"method":"no_such_method"}`,
+		`{"jsonrpc":"2.0","id":5,"method":"check_complexity","params":["positional"]}`,
+	)
+	require.Len(t, responses, 5)
+
+	codes := make([]int, len(responses))
+	for i, response := range responses {
+		require.NotNil(t, response.Error, "response %d", i)
+		codes[i] = response.Error.Code
+	}
+	assert.Equal(t, []int{-32700, -32600, -32600, -32601, -32602}, codes)
+	assert.JSONEq(t, `null`, string(responses[0].ID))
+	assert.JSONEq(t, `4`, string(responses[3].ID))
+}
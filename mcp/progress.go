@@ -0,0 +1,40 @@
+package mcp
+
+import (
+	"context"
+	"log"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ProgressMiddleware sends notifications/progress to clients that pass a
+// progress token with a tool call. Progress is the overall percentage of
+// analyze runs (analyze_code, get_health_score), as reported by the use case.
+func ProgressMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+			return next(ctx, request)
+		}
+		srv := server.ServerFromContext(ctx)
+		if srv == nil {
+			return next(ctx, request)
+		}
+		token := request.Params.Meta.ProgressToken
+		reporter := domain.ProgressReporterFunc(func(stage string, done, total int) {
+			if stage != domain.ProgressStageAnalyze {
+				return
+			}
+			params := map[string]any{
+				"progressToken": token,
+				"progress":      done,
+				"total":         total,
+			}
+			if err := srv.SendNotificationToClient(ctx, "notifications/progress", params); err != nil {
+				log.Printf("failed to send progress notification: %v", err)
+			}
+		})
+		return next(domain.WithProgressReporter(ctx, reporter), request)
+	}
+}
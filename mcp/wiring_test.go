@@ -3,9 +3,11 @@ package mcp
 import (
 	"context"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/ludo-technologies/pyscn/app"
+	"github.com/ludo-technologies/pyscn/domain"
 	"github.com/ludo-technologies/pyscn/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -32,3 +34,32 @@ func TestBuildAnalyzeUseCase_WiresCommunityAnalysis(t *testing.T) {
 	require.NotNil(t, response.Communities)
 	assert.Greater(t, response.Communities.TotalCommunities, 0)
 }
+
+func TestBuildAnalyzeUseCase_ReportsProgress(t *testing.T) {
+	fixtureRoot, err := filepath.Abs(filepath.Join("..", "testdata", "python", "mvc_app"))
+	require.NoError(t, err)
+
+	uc, err := buildAnalyzeUseCase(service.NewFileReader())
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var percents []int
+	ctx := domain.WithProgressReporter(context.Background(), domain.ProgressReporterFunc(func(stage string, done, total int) {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, domain.ProgressStageAnalyze, stage)
+		assert.Equal(t, 100, total)
+		percents = append(percents, done)
+	}))
+
+	_, err = uc.Execute(ctx, app.AnalyzeUseCaseConfig{
+		SkipClones:      true,
+		SkipSystem:      true,
+		SkipCommunities: true,
+	}, []string{fixtureRoot})
+	require.NoError(t, err)
+
+	require.NotEmpty(t, percents)
+	assert.IsNonDecreasing(t, percents)
+	assert.Equal(t, 100, percents[len(percents)-1])
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/analyzer"
+)
+
+// reportSkippedFile warns that a file was left out because it could not be
+// read or parsed. Every analysis reports it the same way, so a file skipped
+// by several analyses is reported once.
+func reportSkippedFile(ctx context.Context, path string, err error) bool {
+	return domain.ReportWarning(ctx, domain.AnalysisWarning{
+		Analyzer: domain.ProgressStageParse,
+		File:     path,
+		Message:  "skipped: " + err.Error(),
+	})
+}
+
+// reportCloneTruncation warns about the limits clone detection hit
+func reportCloneTruncation(ctx context.Context, truncation analyzer.CloneTruncation) {
+	if truncation.PairLimit > 0 {
+		domain.ReportWarning(ctx, domain.AnalysisWarning{
+			Analyzer: domain.ProgressStageClones,
+			Message:  fmt.Sprintf("clone pairs reached the limit of %d; lower-similarity pairs may be missing", truncation.PairLimit),
+		})
+	}
+	if truncation.CandidateLimitedQueries > 0 {
+		domain.ReportWarning(ctx, domain.AnalysisWarning{
+			Analyzer: domain.ProgressStageClones,
+			Message: fmt.Sprintf("LSH candidates truncated at %d for %d fragment(s); some clones may be missing",
+				truncation.CandidateLimit, truncation.CandidateLimitedQueries),
+		})
+	}
+}
@@ -54,6 +54,8 @@ type analyzeEnabledOverrides struct {
 	DependenciesEnabled       *bool
 	ArchitectureEnabled       *bool
 	CommunitiesEnabled        *bool
+	DocumentationEnabled      *bool
+	TypingEnabled             *bool
 }
 
 func defaultAnalyzeExecutionConfig() domain.AnalyzeExecutionConfig {
@@ -99,7 +101,14 @@ func analyzeExecutionConfigFromConfig(cfg *config.Config, overrides analyzeEnabl
 	}
 
 	executionCfg.Recursive = cfg.Analysis.Recursive
+	executionCfg.Walk = domain.FileWalkOptions{
+		MaxDepth:       cfg.Analysis.MaxDepth,
+		FollowSymlinks: cfg.Analysis.FollowSymlinks,
+	}
+	executionCfg.IncludeVendored = cfg.Analysis.IncludeVendored
+	executionCfg.Serial = cfg.Analysis.Serial
 	executionCfg.ShowDetails = cfg.Output.ShowDetails
+	executionCfg.AbsolutePaths = cfg.Output.AbsolutePaths
 	executionCfg.ComplexityEnabled = cfg.Complexity.Enabled
 	executionCfg.ComplexityReportUnchanged = cfg.Complexity.ReportUnchanged
 	executionCfg.ComplexityMinComplexity = cfg.Output.MinComplexity
@@ -108,7 +117,12 @@ func analyzeExecutionConfigFromConfig(cfg *config.Config, overrides analyzeEnabl
 	executionCfg.ComplexityMaxComplexity = cfg.Complexity.MaxComplexity
 	executionCfg.CognitiveComplexityThreshold = cfg.Complexity.CognitiveComplexityThreshold
 	executionCfg.NestingDepthThreshold = cfg.Complexity.NestingDepthThreshold
+	executionCfg.ComplexityBoilerplate = cfg.Complexity.Boilerplate
+	executionCfg.ComplexityComprehensionWeight = cfg.Complexity.ComprehensionWeight
+	executionCfg.ComplexityGeneratorWeight = cfg.Complexity.GeneratorWeight
+	executionCfg.ComplexityBooleanOperatorWeight = cfg.Complexity.BooleanOperatorWeight
 	executionCfg.DeadCodeEnabled = cfg.DeadCode.Enabled
+	executionCfg.DeadCodeTerminatingCalls = cfg.DeadCode.TerminatingCalls
 
 	if cfg.Clones != nil {
 		if cfg.Clones.LSH.Enabled != "" {
@@ -117,10 +131,18 @@ func analyzeExecutionConfigFromConfig(cfg *config.Config, overrides analyzeEnabl
 		if cfg.Clones.LSH.AutoThreshold > 0 {
 			executionCfg.CloneLSHAutoThreshold = cfg.Clones.LSH.AutoThreshold
 		}
+		if len(cfg.Clones.AnalyzerScopes) > 0 {
+			executionCfg.AnalysisScopes = make(map[string]domain.AnalysisScope, len(cfg.Clones.AnalyzerScopes))
+			for section, scope := range cfg.Clones.AnalyzerScopes {
+				executionCfg.AnalysisScopes[section] = scope
+			}
+		}
 	}
 
 	applySystemEnabledOverrides(&executionCfg, overrides)
 	applyCommunitiesEnabledOverrides(&executionCfg, overrides, cfg)
+	executionCfg.DocumentationEnabled = domain.BoolValue(overrides.DocumentationEnabled, false)
+	executionCfg.TypingEnabled = domain.BoolValue(overrides.TypingEnabled, false)
 
 	return executionCfg
 }
@@ -192,6 +214,8 @@ func loadAnalyzeEnabledOverrides(configPath string) (analyzeEnabledOverrides, er
 			parsed.Tool.Pyscn.Dependencies,
 			parsed.Tool.Pyscn.Architecture,
 			parsed.Tool.Pyscn.Communities,
+			parsed.Tool.Pyscn.Documentation,
+			parsed.Tool.Pyscn.Typing,
 		), nil
 	}
 
@@ -199,10 +223,10 @@ func loadAnalyzeEnabledOverrides(configPath string) (analyzeEnabledOverrides, er
 	if err := toml.Unmarshal(data, &parsed); err != nil {
 		return analyzeEnabledOverrides{}, err
 	}
-	return enabledOverridesFromSections(parsed.SystemAnalysis, parsed.Dependencies, parsed.Architecture, parsed.Communities), nil
+	return enabledOverridesFromSections(parsed.SystemAnalysis, parsed.Dependencies, parsed.Architecture, parsed.Communities, parsed.Documentation, parsed.Typing), nil
 }
 
-func enabledOverridesFromSections(system config.SystemAnalysisTomlConfig, dependencies config.DependenciesTomlConfig, architecture config.ArchitectureTomlConfig, communities config.CommunitiesTomlConfig) analyzeEnabledOverrides {
+func enabledOverridesFromSections(system config.SystemAnalysisTomlConfig, dependencies config.DependenciesTomlConfig, architecture config.ArchitectureTomlConfig, communities config.CommunitiesTomlConfig, documentation config.DocumentationTomlConfig, typing config.TypingTomlConfig) analyzeEnabledOverrides {
 	return analyzeEnabledOverrides{
 		SystemEnabled:             system.Enabled,
 		SystemAnalyzeDependencies: system.EnableDependencies,
@@ -210,5 +234,7 @@ func enabledOverridesFromSections(system config.SystemAnalysisTomlConfig, depend
 		DependenciesEnabled:       dependencies.Enabled,
 		ArchitectureEnabled:       architecture.Enabled,
 		CommunitiesEnabled:        communities.Enabled,
+		DocumentationEnabled:      documentation.Enabled,
+		TypingEnabled:             typing.Enabled,
 	}
 }
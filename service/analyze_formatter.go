@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"html/template"
 	"io"
+	"math"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/i18n"
 )
 
 // AnalyzeFormatter handles formatting of unified analysis reports
@@ -15,6 +18,8 @@ type AnalyzeFormatter struct {
 	complexityFormatter *OutputFormatterImpl
 	deadCodeFormatter   *DeadCodeFormatterImpl
 	cloneFormatter      *CloneOutputFormatter
+	linker              *SourceLinker
+	translator          *i18n.Translator
 }
 
 // NewAnalyzeFormatter creates a new analyze formatter
@@ -26,6 +31,19 @@ func NewAnalyzeFormatter() *AnalyzeFormatter {
 	}
 }
 
+// SetSourceLinker makes file references in the HTML report link to the
+// source. A nil linker renders them as plain text.
+func (f *AnalyzeFormatter) SetSourceLinker(linker *SourceLinker) {
+	f.linker = linker
+}
+
+// SetLanguage sets the language of the text and HTML reports, a code
+// returned by i18n.ParseLanguage. Analyzer output such as finding
+// descriptions is not translated.
+func (f *AnalyzeFormatter) SetLanguage(lang string) {
+	f.translator = i18n.NewTranslator(lang)
+}
+
 // Write formats and writes the unified analysis response
 func (f *AnalyzeFormatter) Write(response *domain.AnalyzeResponse, format domain.OutputFormat, writer io.Writer) error {
 	switch format {
@@ -39,6 +57,8 @@ func (f *AnalyzeFormatter) Write(response *domain.AnalyzeResponse, format domain
 		return f.writeCSV(response, writer)
 	case domain.OutputFormatHTML:
 		return f.writeHTML(response, writer)
+	case domain.OutputFormatGitHubCheck:
+		return WriteGitHubCheck(writer, response)
 	default:
 		return domain.NewUnsupportedFormatError(string(format))
 	}
@@ -46,7 +66,7 @@ func (f *AnalyzeFormatter) Write(response *domain.AnalyzeResponse, format domain
 
 // writeText formats the response as plain text
 func (f *AnalyzeFormatter) writeText(response *domain.AnalyzeResponse, writer io.Writer) error {
-	utils := NewFormatUtils()
+	utils := NewTranslatedFormatUtils(f.translator)
 
 	// Header
 	fmt.Fprint(writer, utils.FormatMainHeader("Comprehensive Analysis Report"))
@@ -68,7 +88,7 @@ func (f *AnalyzeFormatter) writeText(response *domain.AnalyzeResponse, writer io
 	// Analysis modules results
 	if response.Summary.ComplexityEnabled {
 		fmt.Fprint(writer, utils.FormatSectionHeader("COMPLEXITY ANALYSIS"))
-		fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, "Total Functions", formatFunctionCoverage(response.Summary.TotalFunctions, response.Summary.FunctionsParsed)))
+		fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, "Total Functions", formatTranslatedFunctionCoverage(utils, response.Summary.TotalFunctions, response.Summary.FunctionsParsed)))
 		fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, "Average Complexity", fmt.Sprintf("%.1f", response.Summary.AverageComplexity)))
 		fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, "High Complexity Count", response.Summary.HighComplexityCount))
 		fmt.Fprint(writer, utils.FormatSectionSeparator())
@@ -86,6 +106,16 @@ func (f *AnalyzeFormatter) writeText(response *domain.AnalyzeResponse, writer io
 		fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, "Unique Fragments", response.Summary.TotalClones))
 		fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, "Clone Groups", response.Summary.CloneGroups))
 		fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, "Fragments Cloned", utils.FormatPercentage(response.Summary.CodeDuplication)))
+		if response.Clone != nil && len(response.Clone.Consolidation) > 0 {
+			fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, "Consolidation Targets", ""))
+			for _, target := range response.Clone.Consolidation {
+				fmt.Fprint(writer, utils.FormatLabelWithIndent(ItemPadding, utils.T(packageLabel(target.Package)),
+					utils.T("%d group(s), %d duplicate lines (%s)", len(target.GroupIDs), target.DuplicateLines, strings.Join(target.GroupIDs, ", "))))
+			}
+		}
+		if response.Clone != nil && response.Clone.Request != nil && response.Clone.Request.ShouldShowContent() {
+			writeCloneDiffsText(writer, utils, response.Clone)
+		}
 		fmt.Fprint(writer, utils.FormatSectionSeparator())
 	}
 
@@ -98,12 +128,215 @@ func (f *AnalyzeFormatter) writeText(response *domain.AnalyzeResponse, writer io
 	}
 
 	if response.Summary.CommunitiesEnabled && response.Communities != nil {
-		WriteCommunityTextSummary(writer, response.Communities)
+		WriteCommunityTextSummary(writer, response.Communities, utils)
+	}
+
+	if response.Summary.DocumentationEnabled && response.Documentation != nil {
+		docs := response.Documentation
+		fmt.Fprint(writer, utils.FormatSectionHeader("DOCUMENTATION"))
+		fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, "Convention", string(docs.Convention)))
+		fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, "Docstring Coverage",
+			fmt.Sprintf("%s (%d/%d)", utils.FormatPercentage(docs.Summary.Coverage), docs.Summary.DocumentedItems, docs.Summary.TotalItems)))
+		for _, kind := range []struct {
+			kind  domain.DocumentationItemKind
+			label string
+		}{
+			{domain.DocumentationItemModule, "Modules"},
+			{domain.DocumentationItemClass, "Classes"},
+			{domain.DocumentationItemFunction, "Functions"},
+			{domain.DocumentationItemMethod, "Methods"},
+		} {
+			if stats := docs.Summary.ByKind[kind.kind]; stats.Total > 0 {
+				fmt.Fprint(writer, utils.FormatLabelWithIndent(ItemPadding, kind.label, fmt.Sprintf("%d/%d", stats.Documented, stats.Total)))
+			}
+		}
+		for i, finding := range docs.Findings {
+			if i == 10 {
+				fmt.Fprint(writer, utils.FormatLabelWithIndent(ItemPadding, "...", utils.T("%d more undocumented item(s)", len(docs.Findings)-i)))
+				break
+			}
+			fmt.Fprint(writer, utils.FormatLabelWithIndent(ItemPadding, fmt.Sprintf("%s:%d", finding.Location.FilePath, finding.Location.StartLine), finding.Description))
+		}
+		fmt.Fprint(writer, utils.FormatSectionSeparator())
+	}
+
+	if response.Summary.TypingEnabled && response.TypeCoverage != nil {
+		types := response.TypeCoverage.Summary
+		fmt.Fprint(writer, utils.FormatSectionHeader("TYPE ANNOTATIONS"))
+		fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, "Annotation Coverage",
+			fmt.Sprintf("%s (%d/%d)", utils.FormatPercentage(types.Coverage), types.AnnotatedParameters+types.AnnotatedReturns, types.Parameters+types.Returns)))
+		fmt.Fprint(writer, utils.FormatLabelWithIndent(ItemPadding, "Parameters", fmt.Sprintf("%d/%d", types.AnnotatedParameters, types.Parameters)))
+		fmt.Fprint(writer, utils.FormatLabelWithIndent(ItemPadding, "Return Types", fmt.Sprintf("%d/%d", types.AnnotatedReturns, types.Returns)))
+		fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, "Fully Annotated Functions", fmt.Sprintf("%d/%d", types.FullyAnnotatedFunctions, types.Functions)))
+		fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, "Unannotated Public Functions", types.UnannotatedPublicFunctions))
+		for i, finding := range response.TypeCoverage.Findings {
+			if i == 10 {
+				fmt.Fprint(writer, utils.FormatLabelWithIndent(ItemPadding, "...", utils.T("%d more unannotated function(s)", len(response.TypeCoverage.Findings)-i)))
+				break
+			}
+			fmt.Fprint(writer, utils.FormatLabelWithIndent(ItemPadding, fmt.Sprintf("%s:%d", finding.Location.FilePath, finding.Location.StartLine), finding.Description))
+		}
+		fmt.Fprint(writer, utils.FormatSectionSeparator())
+	}
+
+	if response.Workspace != nil {
+		fmt.Fprint(writer, utils.FormatSectionHeader("WORKSPACE TARGETS"))
+		for _, target := range response.Workspace.Targets {
+			if target.Result == nil {
+				fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, target.Path, utils.T("failed: %s", target.Error)))
+				continue
+			}
+			fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, target.Path,
+				utils.T("%d/100 (%s), %d files", target.Result.Summary.HealthScore, target.Result.Summary.Grade, target.Result.Summary.TotalFiles)))
+		}
+		fmt.Fprint(writer, utils.FormatSectionSeparator())
+	}
+
+	if response.Ownership != nil {
+		fmt.Fprint(writer, utils.FormatSectionHeader("OWNERSHIP"))
+		for _, owner := range response.Ownership.Owners {
+			fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, owner.Owner,
+				utils.T("%d/100 (%s), %d files, %d high complexity, %d dead code",
+					owner.HealthScore, owner.Grade, owner.Files, owner.HighComplexityCount, owner.DeadCodeCount)))
+		}
+		fmt.Fprint(writer, utils.FormatSectionSeparator())
+	}
+
+	if response.Hotspots != nil {
+		fmt.Fprint(writer, utils.FormatSectionHeader("HOTSPOTS"))
+		fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, "Churn Window", utils.T("%d days (since %s)", response.Hotspots.WindowDays, response.Hotspots.Since.Format("2006-01-02"))))
+		for i, hotspot := range response.Hotspots.Files {
+			if i == 10 {
+				fmt.Fprint(writer, utils.FormatLabelWithIndent(ItemPadding, "...", utils.T("%d more file(s)", len(response.Hotspots.Files)-i)))
+				break
+			}
+			fmt.Fprint(writer, utils.FormatLabelWithIndent(ItemPadding, hotspot.FilePath,
+				utils.T("score %d (complexity %d × %d commits), most complex: %s (%d)",
+					hotspot.Score, hotspot.TotalComplexity, hotspot.Commits, hotspot.MostComplexFunction, hotspot.MaxComplexity)))
+		}
+		fmt.Fprint(writer, utils.FormatSectionSeparator())
+	}
+
+	if len(response.Vendored) > 0 {
+		fmt.Fprint(writer, utils.FormatSectionHeader("VENDORED CODE (NOT ANALYZED)"))
+		for _, code := range response.Vendored {
+			fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, code.Path,
+				utils.T("%d file(s), %s", code.Files, utils.T(vendoredReasonLabel(code.Reason)))))
+		}
+		fmt.Fprint(writer, utils.FormatSectionSeparator())
+	}
+
+	if len(response.Warnings) > 0 {
+		fmt.Fprint(writer, utils.FormatSectionHeader("WARNINGS"))
+		for _, warning := range response.Warnings {
+			message := warning.Message
+			if warning.File != "" {
+				message = warning.File + ": " + message
+			}
+			fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, warning.Analyzer, message))
+		}
+		fmt.Fprint(writer, utils.FormatSectionSeparator())
+	}
+
+	if response.Manifest != nil {
+		writeManifestText(writer, utils, response.Manifest)
 	}
 
 	return nil
 }
 
+// formatTranslatedFunctionCoverage is formatFunctionCoverage in the
+// language of utils
+func formatTranslatedFunctionCoverage(utils *FormatUtils, reported, parsed int) string {
+	if parsed > 0 && parsed != reported {
+		return utils.T("%d reported / %d parsed", reported, parsed)
+	}
+	return strconv.Itoa(reported)
+}
+
+// vendoredReasonLabel describes why code counts as vendored
+func vendoredReasonLabel(reason string) string {
+	if reason == domain.VendoredReasonMarker {
+		return "marked # vendored"
+	}
+	return "vendoring directory"
+}
+
+// writeCloneDiffsText writes what differs between clone members: for the
+// top groups each member against the first, otherwise the top pairs. The
+// limits match the HTML report.
+func writeCloneDiffsText(writer io.Writer, utils *FormatUtils, clone *domain.CloneResponse) {
+	const maxGroups, maxPairs = 10, 15
+	if len(clone.CloneGroups) > 0 {
+		fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, "Clone Diffs", ""))
+		for _, group := range clone.CloneGroups[:min(len(clone.CloneGroups), maxGroups)] {
+			if group == nil {
+				continue
+			}
+			fmt.Fprint(writer, utils.FormatLabelWithIndent(ItemPadding, utils.T("Group %s", group.ID),
+				utils.T("%s, %d clones, similarity: %.3f", group.Type.String(), len(group.Clones), group.Similarity)))
+			writeCloneGroupDiffs(writer, ItemPadding+2, group)
+		}
+		return
+	}
+	if len(clone.ClonePairs) > 0 {
+		fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, "Clone Diffs", ""))
+		for _, pair := range clone.ClonePairs[:min(len(clone.ClonePairs), maxPairs)] {
+			if pair == nil {
+				continue
+			}
+			fmt.Fprint(writer, utils.FormatLabelWithIndent(ItemPadding, utils.T("Pair %s", pair.ID),
+				utils.T("%s, similarity: %.3f", pair.Type.String(), pair.Similarity)))
+			writeCloneDiff(writer, ItemPadding+2, pair.Clone1, pair.Clone2)
+		}
+	}
+}
+
+// writeManifestText writes the analysis manifest section of the text report
+func writeManifestText(writer io.Writer, utils *FormatUtils, manifest *domain.AnalysisManifest) {
+	fmt.Fprint(writer, utils.FormatSectionHeader("MANIFEST"))
+	fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, "pyscn", fmt.Sprintf("%s (%s, %s)", manifest.PyscnVersion, manifest.GoVersion, manifest.Platform)))
+	fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, "Targets", strings.Join(manifest.Targets, ", ")))
+	config := utils.T("defaults")
+	if manifest.ConfigFile != "" {
+		config = manifest.ConfigFile + " " + manifest.ConfigHash
+	}
+	fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, "Configuration", config))
+	if manifest.Thresholds != nil {
+		fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, "Thresholds", formatRiskThresholds(manifest.Thresholds)))
+	}
+	if manifest.Git != nil {
+		commit := manifest.Git.Commit
+		if manifest.Git.Dirty {
+			commit += " (" + utils.T("dirty") + ")"
+		}
+		fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, "Git Commit", commit))
+	}
+	fmt.Fprint(writer, utils.FormatSectionSeparator())
+}
+
+// formatRiskThresholds summarizes the risk thresholds on one line, low and
+// medium bounds separated by a slash
+func formatRiskThresholds(thresholds *domain.RiskThresholds) string {
+	if thresholds == nil {
+		return ""
+	}
+	var parts []string
+	if c := thresholds.Complexity; c != nil {
+		parts = append(parts, fmt.Sprintf("complexity %d/%d (cognitive %d, nesting %d)", c.Low, c.Medium, c.Cognitive, c.NestingDepth))
+	}
+	if c := thresholds.CBO; c != nil {
+		parts = append(parts, fmt.Sprintf("CBO %d/%d", c.Low, c.Medium))
+	}
+	if c := thresholds.LCOM; c != nil {
+		parts = append(parts, fmt.Sprintf("LCOM %d/%d", c.Low, c.Medium))
+	}
+	if c := thresholds.Clones; c != nil {
+		parts = append(parts, fmt.Sprintf("clone similarity %.2f (types %.2f/%.2f/%.2f/%.2f)", c.Similarity, c.Type1, c.Type2, c.Type3, c.Type4))
+	}
+	return strings.Join(parts, ", ")
+}
+
 // writeJSON formats the response as JSON
 // writeCSV formats the response as CSV (summary only)
 func (f *AnalyzeFormatter) writeCSV(response *domain.AnalyzeResponse, writer io.Writer) error {
@@ -126,6 +359,14 @@ func (f *AnalyzeFormatter) writeCSV(response *domain.AnalyzeResponse, writer io.
 	fmt.Fprintf(writer, "High Coupling (CBO) Classes,%d\n", response.Summary.HighCouplingClasses)
 	fmt.Fprintf(writer, "Average CBO,%.2f\n", response.Summary.AverageCoupling)
 
+	if manifest := response.Manifest; manifest != nil {
+		fmt.Fprintf(writer, "Pyscn Version,%s\n", manifest.PyscnVersion)
+		fmt.Fprintf(writer, "Config Hash,%s\n", manifest.ConfigHash)
+		if manifest.Git != nil {
+			fmt.Fprintf(writer, "Git Commit,%s\n", manifest.Git.Commit)
+		}
+	}
+
 	if response.Summary.CommunitiesEnabled && response.Communities != nil {
 		communities := response.Communities
 		fmt.Fprintf(writer, "Communities Enabled,true\n")
@@ -136,6 +377,18 @@ func (f *AnalyzeFormatter) writeCSV(response *domain.AnalyzeResponse, writer io.
 		fmt.Fprintf(writer, "Community Risk Score,%d\n", response.Summary.CommunityRiskScore)
 	}
 
+	if response.Summary.DocumentationScore != nil {
+		fmt.Fprintf(writer, "Docstring Coverage,%.2f\n", *response.Summary.DocstringCoverage)
+		fmt.Fprintf(writer, "Undocumented Items,%d\n", *response.Summary.UndocumentedItems)
+		fmt.Fprintf(writer, "Documentation Score,%d\n", *response.Summary.DocumentationScore)
+	}
+
+	if response.Summary.TypednessScore != nil {
+		fmt.Fprintf(writer, "Type Annotation Coverage,%.2f\n", *response.Summary.TypeAnnotationCoverage)
+		fmt.Fprintf(writer, "Unannotated Public Functions,%d\n", *response.Summary.UnannotatedPublicFunctions)
+		fmt.Fprintf(writer, "Typedness Score,%d\n", *response.Summary.TypednessScore)
+	}
+
 	return nil
 }
 
@@ -151,7 +404,12 @@ func (f *AnalyzeFormatter) writeHTML(response *domain.AnalyzeResponse, writer io
 		"sub": func(a, b int) int {
 			return a - b
 		},
+		"mul": func(a, b int) int {
+			return a * b
+		},
 		"mul100": func(v float64) float64 { return v * 100.0 },
+		// formatPercent takes a float64 so optional *float64 fields are dereferenced
+		"formatPercent": func(v float64) string { return fmt.Sprintf("%.1f%%", v) },
 		"slice": func(s []string, start, end int) []string {
 			if start < 0 || start >= len(s) {
 				return []string{}
@@ -161,6 +419,7 @@ func (f *AnalyzeFormatter) writeHTML(response *domain.AnalyzeResponse, writer io
 			}
 			return s[start:end]
 		},
+		"cloneDiff": cloneDiffHTML,
 		"previewContent": func(content string) string {
 			const maxLines = 8
 			lines := strings.Split(content, "\n")
@@ -181,12 +440,32 @@ func (f *AnalyzeFormatter) writeHTML(response *domain.AnalyzeResponse, writer io
 				return "poor"
 			}
 		},
+		"blameLabel":     formatBlameLabel,
+		"sparkline":      complexitySparkline,
+		"vendoredReason": func(reason string) string { return f.translator.T(vendoredReasonLabel(reason)) },
+		"riskThresholds": formatRiskThresholds,
+		"treemap":        BuildTreemap,
+		"cloneHeatmap":   BuildCloneHeatmap,
+		"packageLabel":   func(pkg string) string { return f.translator.T(packageLabel(pkg)) },
+		"t":              f.translator.T,
+		"tcode":          f.translatedCodeHTML,
+		"lang":           f.translator.Language,
+		"scoreInput":     formatScoreInput,
+		"percent": func(ratio float64) float64 {
+			return ratio * 100
+		},
+		"fileLink": func(path string, line, endLine int) template.HTML {
+			return f.sourceLinkHTML(path, path, line, endLine)
+		},
+		"locationLink": func(path string, line int) template.HTML {
+			return f.sourceLinkHTML(fmt.Sprintf("%s:%d", path, line), path, line, line)
+		},
 		"communitySummaryHTML": func(result *domain.CommunityAnalysisResult) template.HTML {
 			if result == nil {
 				return ""
 			}
 			var builder strings.Builder
-			WriteCommunityHTMLSummary(&builder, result)
+			WriteCommunityHTMLSummary(&builder, result, f.translator)
 			return template.HTML(builder.String())
 		},
 	}
@@ -194,27 +473,112 @@ func (f *AnalyzeFormatter) writeHTML(response *domain.AnalyzeResponse, writer io
 	return tmpl.Execute(writer, response)
 }
 
+// translatedCodeHTML translates message and replaces each %s in it with
+// the next of code, set as inline code
+func (f *AnalyzeFormatter) translatedCodeHTML(message string, code ...string) template.HTML {
+	parts := strings.Split(f.translator.T(message), "%s")
+	var builder strings.Builder
+	for i, part := range parts {
+		if i > 0 && i-1 < len(code) {
+			builder.WriteString("<code>" + template.HTMLEscapeString(code[i-1]) + "</code>")
+		}
+		builder.WriteString(template.HTMLEscapeString(part))
+	}
+	return template.HTML(builder.String())
+}
+
+// sourceLinkHTML renders label as a link to the source when a linker is set
+func (f *AnalyzeFormatter) sourceLinkHTML(label, path string, line, endLine int) template.HTML {
+	escaped := template.HTMLEscapeString(label)
+	if f.linker == nil || path == "" {
+		return template.HTML(escaped)
+	}
+	href := template.HTMLEscapeString(f.linker.URL(path, line, endLine))
+	return template.HTML(`<a class="source-link" href="` + href + `">` + escaped + `</a>`)
+}
+
 // HTML template for unified report
 const analyzeHTMLTemplate = `<!DOCTYPE html>
-<html lang="en">
+<html lang="{{lang}}">
 <head>
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>pyscn Analysis Report</title>
+    <title>{{t "pyscn Analysis Report"}}</title>
     <style>
         :root {
             --color-success: #15803d;
             --color-warning: #a16207;
             --color-danger:  #b91c1c;
+            --color-info:    #1e40af;
             --color-text:    #0f172a;
             --color-muted:   #334155;
+            --color-body:      #333;
+            --color-secondary: #666;
+            --color-subtle:    #475569;
+            --color-bg:          #f1f5f9;
+            --color-surface:     #ffffff;
+            --color-surface-alt: #f8f9fa;
+            --color-border:      #ddd;
+            --color-track:       #e0e0e0;
+            --callout-neutral-bg:     #f8fafc;
+            --callout-neutral-border: #cbd5e1;
+            --callout-success-bg:     #f0fdf4;
+            --callout-success-border: #bbf7d0;
+            --callout-success-text:   #14532d;
+            --callout-warning-bg:     #fffbeb;
+            --callout-warning-border: #fde68a;
+            --callout-warning-text:   #92400e;
+            --callout-info-bg:        #eff6ff;
+            --callout-info-border:    #bfdbfe;
+            --callout-info-text:      #1e3a8a;
+            --callout-danger-bg:      #fef2f2;
+            --callout-danger-border:  #fecaca;
+            --callout-danger-text:    #991b1b;
+            color-scheme: light;
+        }
+        /* Dark theme, chosen with the toggle or by the system preference.
+           Screen only, so printing always uses the light theme. */
+        @media screen {
+            :root[data-theme="dark"] {
+                --color-success: #4ade80;
+                --color-warning: #facc15;
+                --color-danger:  #f87171;
+                --color-info:    #93c5fd;
+                --color-text:    #f1f5f9;
+                --color-muted:   #cbd5e1;
+                --color-body:      #e2e8f0;
+                --color-secondary: #94a3b8;
+                --color-subtle:    #94a3b8;
+                --color-bg:          #0f172a;
+                --color-surface:     #1e293b;
+                --color-surface-alt: #273449;
+                --color-border:      #334155;
+                --color-track:       #334155;
+                --callout-neutral-bg:     #172033;
+                --callout-neutral-border: #475569;
+                --callout-success-bg:     #052e16;
+                --callout-success-border: #166534;
+                --callout-success-text:   #bbf7d0;
+                --callout-warning-bg:     #2d2305;
+                --callout-warning-border: #a16207;
+                --callout-warning-text:   #fde68a;
+                --callout-info-bg:        #172554;
+                --callout-info-border:    #1e40af;
+                --callout-info-text:      #bfdbfe;
+                --callout-danger-bg:      #450a0a;
+                --callout-danger-border:  #991b1b;
+                --callout-danger-text:    #fecaca;
+                color-scheme: dark;
+            }
+            :root[data-theme="dark"] .score-good { background: #a3e635; }
+            :root[data-theme="dark"] .score-badge-compact { color: #0f172a; }
         }
         * { margin: 0; padding: 0; box-sizing: border-box; }
         body {
             font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif;
             line-height: 1.6;
-            color: #333;
-            background-color: #f1f5f9;
+            color: var(--color-body);
+            background-color: var(--color-bg);
             min-height: 100vh;
         }
         .container {
@@ -223,7 +587,8 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
             padding: 20px;
         }
         .header {
-            background: white;
+            position: relative;
+            background: var(--color-surface);
             border-radius: 10px;
             padding: 30px;
             margin-bottom: 20px;
@@ -233,6 +598,28 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
             color: var(--color-text);
             margin-bottom: 10px;
         }
+        .theme-toggle {
+            position: absolute;
+            top: 30px;
+            right: 30px;
+            padding: 6px 12px;
+            border: 1px solid var(--color-border);
+            border-radius: 6px;
+            background: var(--color-surface-alt);
+            color: var(--color-body);
+            font-size: 14px;
+            cursor: pointer;
+        }
+        .report-warnings {
+            margin-top: 10px;
+            padding: 10px 15px;
+            background: var(--callout-warning-bg);
+            border-left: 4px solid var(--callout-warning-border);
+            border-radius: 4px;
+            color: var(--callout-warning-text);
+        }
+        .report-warnings summary { cursor: pointer; font-weight: 600; }
+        .report-warnings ul { margin: 8px 0 0 20px; }
         .score-badge {
             display: inline-block;
             padding: 10px 20px;
@@ -248,26 +635,27 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
         .grade-f { background: #7f1d1d; color: white; }
 
         .tabs {
-            background: white;
+            background: var(--color-surface);
             border-radius: 10px;
             overflow: hidden;
             box-shadow: 0 1px 3px rgba(0,0,0,0.08);
         }
         .tab-buttons {
             display: flex;
-            background: #f5f5f5;
+            background: var(--color-surface-alt);
         }
         .tab-button {
             flex: 1;
             padding: 15px;
             border: none;
             background: transparent;
+            color: inherit;
             cursor: pointer;
             font-size: 16px;
             transition: all 0.3s;
         }
         .tab-button.active {
-            background: white;
+            background: var(--color-surface);
             color: var(--color-muted);
             font-weight: bold;
             border-bottom: 2px solid var(--color-muted);
@@ -287,7 +675,7 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
             margin: 20px 0;
         }
         .metric-card {
-            background: #f8f9fa;
+            background: var(--color-surface-alt);
             padding: 20px;
             border-radius: 8px;
             text-align: center;
@@ -298,7 +686,7 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
             color: var(--color-text);
         }
         .metric-label {
-            color: #666;
+            color: var(--color-secondary);
             margin-top: 5px;
         }
         
@@ -310,22 +698,53 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
         .table th, .table td {
             padding: 12px;
             text-align: left;
-            border-bottom: 1px solid #ddd;
+            border-bottom: 1px solid var(--color-border);
         }
         .table th {
-            background: #f8f9fa;
+            background: var(--color-surface-alt);
+            font-weight: 600;
+        }
+        .manifest {
+            margin-top: 20px;
+            padding: 12px 20px;
+            background: var(--color-surface);
+            border-radius: 10px;
+            color: var(--color-subtle);
+            font-size: 0.9em;
+        }
+        .manifest summary {
+            cursor: pointer;
+            font-weight: 600;
+        }
+        .manifest th {
+            width: 160px;
+        }
+        .score-explanations {
+            margin-top: 16px;
+            color: var(--color-subtle);
+            font-size: 0.9em;
+        }
+        .score-explanations summary {
+            cursor: pointer;
             font-weight: 600;
         }
+        a.source-link {
+            color: inherit;
+            text-decoration: underline dotted;
+        }
+        a.source-link:hover {
+            color: #667eea;
+        }
         .code-preview-card {
             margin: 12px 0 0;
             padding: 12px 14px;
             border-radius: 8px;
-            background: #ffffff;
-            border: 1px solid #e2e8f0;
+            background: var(--color-surface);
+            border: 1px solid var(--color-border);
         }
         .code-preview-title {
             margin-bottom: 8px;
-            color: #475569;
+            color: var(--color-subtle);
             font-size: 12px;
             font-weight: 700;
             text-transform: uppercase;
@@ -342,6 +761,13 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
             font-size: 13px;
             line-height: 1.5;
         }
+
+        .clone-diff .diff-line { display: block; }
+        .clone-diff .diff-removed { background: rgba(220, 53, 69, 0.2); }
+        .clone-diff .diff-added { background: rgba(40, 167, 69, 0.2); }
+        .clone-diff del, .clone-diff ins { text-decoration: none; border-radius: 2px; }
+        .clone-diff del { background: rgba(220, 53, 69, 0.55); }
+        .clone-diff ins { background: rgba(40, 167, 69, 0.55); }
         
         .risk-low { color: var(--color-success); }
         .risk-medium { color: var(--color-warning); }
@@ -349,7 +775,7 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
 
         .severity-critical { color: var(--color-danger); }
         .severity-warning { color: var(--color-warning); }
-        .severity-info { color: #1e40af; }
+        .severity-info { color: var(--color-info); }
 
         /* Score bars */
         .score-bars {
@@ -366,7 +792,7 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
         }
         .score-label {
             font-weight: 600;
-            color: #333;
+            color: var(--color-body);
         }
         .score-value {
             font-weight: 700;
@@ -375,7 +801,7 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
         .score-bar-container {
             width: 100%;
             height: 12px;
-            background: #e0e0e0;
+            background: var(--color-track);
             border-radius: 6px;
             overflow: hidden;
             box-shadow: inset 0 1px 3px rgba(0,0,0,0.1);
@@ -392,7 +818,7 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
         .score-detail {
             margin-top: 4px;
             font-size: 12px;
-            color: #666;
+            color: var(--color-secondary);
         }
 
         /* Tab header with score badge */
@@ -402,7 +828,7 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
             justify-content: space-between;
             margin-bottom: 20px;
             padding-bottom: 12px;
-            border-bottom: 2px solid #e0e0e0;
+            border-bottom: 2px solid var(--color-border);
         }
 
         .score-badge-compact {
@@ -434,216 +860,389 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
             font-size: 13px;
             margin-top: 4px;
             padding-left: 20px;
-            color: #475569;
+            color: var(--color-subtle);
+        }
+
+        /* Callouts and severity pills */
+        .callout {
+            padding: 15px;
+            margin: 20px 0;
+            border-left: 4px solid;
+            border-radius: 4px;
+        }
+        .callout p, .callout ul { margin: 10px 0 0 0; }
+        .callout ul { padding-left: 20px; }
+        .callout-success { background: var(--callout-success-bg); border-color: var(--callout-success-border); color: var(--callout-success-text); }
+        .callout-warning { background: var(--callout-warning-bg); border-color: var(--callout-warning-border); color: var(--callout-warning-text); }
+        .callout-info { background: var(--callout-info-bg); border-color: var(--callout-info-border); color: var(--callout-info-text); }
+        .callout-danger { background: var(--callout-danger-bg); border-color: var(--callout-danger-border); color: var(--callout-danger-text); }
+        .clone-group {
+            background: var(--callout-neutral-bg);
+            padding: 15px;
+            margin-bottom: 15px;
+            border-radius: 8px;
+            border-left: 4px solid var(--callout-neutral-border);
+        }
+        .clone-group h4 { margin-top: 0; color: var(--color-body); }
+        .severity-pill {
+            padding: 4px 12px;
+            border-radius: 12px;
+            font-size: 12px;
+            font-weight: bold;
+        }
+        .severity-pill.pill-critical { background: var(--callout-danger-bg); color: var(--callout-danger-text); }
+        .severity-pill.pill-high { background: var(--callout-warning-bg); color: var(--callout-warning-text); }
+        .severity-pill.pill-low { background: var(--callout-info-bg); color: var(--callout-info-text); }
+
+        /* Treemap: packages framed, files colored from green to red */
+        .treemap-controls { display: flex; gap: 8px; align-items: center; margin-bottom: 12px; }
+        .treemap-controls button {
+            padding: 4px 12px;
+            border: 1px solid var(--color-border);
+            border-radius: 12px;
+            background: var(--color-surface);
+            color: var(--color-body);
+            cursor: pointer;
+        }
+        .treemap-controls button.active { background: var(--color-info); border-color: var(--color-info); color: var(--color-surface); }
+        .treemap-legend { display: inline-block; width: 120px; height: 10px; border-radius: 5px; background: linear-gradient(to right, hsl(120, 65%, 50%), hsl(60, 65%, 50%), hsl(0, 65%, 50%)); }
+        .treemap { width: 100%; height: auto; display: block; }
+        .treemap .treemap-package { fill: var(--color-surface-alt); stroke: var(--color-border); }
+        .treemap .treemap-package-label { fill: var(--color-muted); font-size: 11px; font-weight: bold; }
+        .treemap .treemap-file { stroke: var(--color-surface); stroke-width: 1; }
+        .treemap .treemap-file-label { fill: #0f172a; font-size: 11px; pointer-events: none; }
+
+        /* Clone heatmap: one row and one numbered column per location */
+        .clone-heatmap { border-collapse: collapse; font-size: 12px; margin-bottom: 20px; }
+        .clone-heatmap th, .clone-heatmap td { border: 1px solid var(--color-border); padding: 0; }
+        .clone-heatmap th { padding: 4px 8px; color: var(--color-muted); font-weight: normal; text-align: left; white-space: nowrap; }
+        .clone-heatmap thead th { text-align: center; min-width: 26px; }
+        .clone-heatmap td { width: 26px; height: 26px; text-align: center; }
+        .clone-heatmap td.diagonal { background: var(--color-surface-alt); }
+
+        /* Print and PDF export: every analysis one after the other in the
+           light theme, without the tab bar and the toggle */
+        @media print {
+            @page { margin: 15mm; }
+            body { background: white; font-size: 12px; min-height: 0; }
+            .container { max-width: none; padding: 0; }
+            .header, .tabs, .manifest { box-shadow: none; border-radius: 0; padding-left: 0; padding-right: 0; }
+            .theme-toggle, .tab-buttons { display: none; }
+            .tab-content, .tab-content.active { display: block; padding: 0; margin-top: 24px; }
+            .tab-content + .tab-content { break-before: page; }
+            h2, h3, h4 { break-after: avoid; }
+            tr, .metric-card, .score-bar-item, .callout, .clone-group, .code-preview-card { break-inside: avoid; }
+            thead { display: table-header-group; }
+            .metric-grid { grid-template-columns: repeat(4, 1fr); gap: 10px; }
+            .code-preview { background: var(--color-surface-alt); color: var(--color-body); border: 1px solid var(--color-border); }
+            a.source-link { text-decoration: none; }
+            .score-badge, .score-badge-compact, .score-bar-container, .score-bar-fill, .severity-pill, .treemap, .treemap-legend, .clone-heatmap {
+                -webkit-print-color-adjust: exact;
+                print-color-adjust: exact;
+            }
         }
     </style>
+    <script>
+        // Apply the remembered theme, or the system one, before the page is
+        // drawn so that it does not flash
+        (function () {
+            let theme = null;
+            try { theme = localStorage.getItem('pyscn-report-theme'); } catch (e) {}
+            if (theme !== 'dark' && theme !== 'light') {
+                theme = window.matchMedia && window.matchMedia('(prefers-color-scheme: dark)').matches ? 'dark' : 'light';
+            }
+            document.documentElement.dataset.theme = theme;
+        })();
+    </script>
 </head>
 <body>
     <div class="container">
         <div class="header">
-            <h1>pyscn Analysis Report</h1>
-            <p>Generated: {{.GeneratedAt.Format "2006-01-02 15:04:05"}}</p>
+            <button type="button" class="theme-toggle" onclick="toggleTheme()" aria-label="{{t "Toggle dark mode"}}">◐ {{t "Theme"}}</button>
+            <h1>{{t "pyscn Analysis Report"}}</h1>
+            <p>{{t "Generated: %s" (.GeneratedAt.Format "2006-01-02 15:04:05")}}</p>
             <div class="score-badge grade-{{if eq .Summary.Grade "A"}}a{{else if eq .Summary.Grade "B"}}b{{else if eq .Summary.Grade "C"}}c{{else if eq .Summary.Grade "D"}}d{{else}}f{{end}}">
-                Health Score: {{.Summary.HealthScore}}/100 (Grade: {{.Summary.Grade}})
+                {{t "Health Score: %d/100 (Grade: %s)" .Summary.HealthScore .Summary.Grade}}
             </div>
+            {{if .Warnings}}
+            <details class="report-warnings">
+                <summary>{{t "%d warning(s): results may be incomplete" (len .Warnings)}}</summary>
+                <ul>
+                    {{range .Warnings}}
+                    <li><code>{{.Analyzer}}</code> {{if .File}}{{.File}}: {{end}}{{.Message}}</li>
+                    {{end}}
+                </ul>
+            </details>
+            {{end}}
         </div>
 
         <div class="tabs">
+            {{$treemap := treemap .}}
             <div class="tab-buttons">
-                <button class="tab-button active" onclick="showTab('summary', this)">Summary</button>
+                <button class="tab-button active" onclick="showTab('summary', this)">{{t "Summary"}}</button>
+                {{if .Workspace}}
+                <button class="tab-button" onclick="showTab('workspace', this)">{{t "Targets"}}</button>
+                {{end}}
                 {{if .Suggestions}}
-                <button class="tab-button" onclick="showTab('suggestions', this)">Suggestions</button>
+                <button class="tab-button" onclick="showTab('suggestions', this)">{{t "Suggestions"}}</button>
                 {{end}}
                 {{if .Summary.ComplexityEnabled}}
-                <button class="tab-button" onclick="showTab('complexity', this)">Complexity</button>
+                <button class="tab-button" onclick="showTab('complexity', this)">{{t "Complexity"}}</button>
                 {{end}}
                 {{if .Summary.DeadCodeEnabled}}
-                <button class="tab-button" onclick="showTab('deadcode', this)">Dead Code</button>
+                <button class="tab-button" onclick="showTab('deadcode', this)">{{t "Dead Code"}}</button>
                 {{end}}
                 {{if .Summary.CloneEnabled}}
-                <button class="tab-button" onclick="showTab('clone', this)">Clone</button>
+                <button class="tab-button" onclick="showTab('clone', this)">{{t "Clone"}}</button>
                 {{end}}
                 {{if .Summary.CBOEnabled}}
-                <button class="tab-button" onclick="showTab('cbo', this)">Coupling</button>
+                <button class="tab-button" onclick="showTab('cbo', this)">{{t "Coupling"}}</button>
                 {{end}}
                 {{if .Summary.LCOMEnabled}}
-                <button class="tab-button" onclick="showTab('lcom', this)">Cohesion</button>
+                <button class="tab-button" onclick="showTab('lcom', this)">{{t "Cohesion"}}</button>
                 {{end}}
                 {{if .System}}
                 {{if .System.DependencyAnalysis}}
-                <button class="tab-button" onclick="showTab('sys-deps', this)">Dependencies</button>
+                <button class="tab-button" onclick="showTab('sys-deps', this)">{{t "Dependencies"}}</button>
                 {{end}}
                 {{if .System.ArchitectureAnalysis}}
-                <button class="tab-button" onclick="showTab('sys-arch', this)">Architecture</button>
+                <button class="tab-button" onclick="showTab('sys-arch', this)">{{t "Architecture"}}</button>
                 {{end}}
                 {{end}}
                 {{if and .Summary.CommunitiesEnabled .Communities}}
-                <button class="tab-button" onclick="showTab('communities', this)">Communities</button>
+                <button class="tab-button" onclick="showTab('communities', this)">{{t "Communities"}}</button>
+                {{end}}
+                {{if .Ownership}}
+                <button class="tab-button" onclick="showTab('owners', this)">{{t "Owners"}}</button>
+                {{end}}
+                {{if .Hotspots}}
+                <button class="tab-button" onclick="showTab('hotspots', this)">{{t "Hotspots"}}</button>
+                {{end}}
+                {{if .Packages}}
+                <button class="tab-button" onclick="showTab('packages', this)">{{t "Packages"}}</button>
+                {{end}}
+                {{if $treemap}}
+                <button class="tab-button" onclick="showTab('treemap', this)">{{t "Treemap"}}</button>
                 {{end}}
             </div>
 
             <div id="summary" class="tab-content active">
-                <h2>Analysis Summary</h2>
+                <h2>{{t "Analysis Summary"}}</h2>
 
-                <h3 style="margin-top: 20px; margin-bottom: 16px; color: var(--color-text);">Quality Scores</h3>
+                <h3 style="margin-top: 20px; margin-bottom: 16px; color: var(--color-text);">{{t "Quality Scores"}}</h3>
                 <div class="score-bars">
                     {{if .Summary.ComplexityEnabled}}
                     <div class="score-bar-item">
                         <div class="score-bar-header">
-                            <span class="score-label">Complexity</span>
+                            <span class="score-label">{{t "Complexity"}}</span>
                             <span class="score-value">{{.Summary.ComplexityScore}}/100</span>
                         </div>
                         <div class="score-bar-container">
                             <div class="score-bar-fill score-{{scoreQuality .Summary.ComplexityScore}}" style="width: {{.Summary.ComplexityScore}}%"></div>
                         </div>
-                        <div class="score-detail">Avg: {{printf "%.1f" .Summary.AverageComplexity}}, High-risk: {{.Summary.HighComplexityCount}}</div>
+                        <div class="score-detail">{{t "Avg: %.1f, High-risk: %d" .Summary.AverageComplexity .Summary.HighComplexityCount}}</div>
                     </div>
                     {{end}}
 
                     {{if .Summary.DeadCodeEnabled}}
                     <div class="score-bar-item">
                         <div class="score-bar-header">
-                            <span class="score-label">Dead Code</span>
+                            <span class="score-label">{{t "Dead Code"}}</span>
                             <span class="score-value">{{.Summary.DeadCodeScore}}/100</span>
                         </div>
                         <div class="score-bar-container">
                             <div class="score-bar-fill score-{{scoreQuality .Summary.DeadCodeScore}}" style="width: {{.Summary.DeadCodeScore}}%"></div>
                         </div>
-                        <div class="score-detail">{{.Summary.DeadCodeCount}} issues, {{.Summary.CriticalDeadCode}} critical</div>
+                        <div class="score-detail">{{t "%d issues, %d critical" .Summary.DeadCodeCount .Summary.CriticalDeadCode}}</div>
                     </div>
                     {{end}}
 
                     {{if .Summary.CloneEnabled}}
                     <div class="score-bar-item">
                         <div class="score-bar-header">
-                            <span class="score-label">Duplication</span>
+                            <span class="score-label">{{t "Duplication"}}</span>
                             <span class="score-value">{{.Summary.DuplicationScore}}/100</span>
                         </div>
                         <div class="score-bar-container">
                             <div class="score-bar-fill score-{{scoreQuality .Summary.DuplicationScore}}" style="width: {{.Summary.DuplicationScore}}%"></div>
                         </div>
-                        <div class="score-detail">{{printf "%.1f%%" .Summary.CodeDuplication}} of fragments cloned, {{.Summary.CloneGroups}} groups</div>
+                        <div class="score-detail">{{t "%.1f%% of fragments cloned, %d groups" .Summary.CodeDuplication .Summary.CloneGroups}}</div>
                     </div>
                     {{end}}
 
                     {{if .Summary.CBOEnabled}}
                     <div class="score-bar-item">
                         <div class="score-bar-header">
-                            <span class="score-label">Coupling (CBO)</span>
+                            <span class="score-label">{{t "Coupling (CBO)"}}</span>
                             <span class="score-value">{{.Summary.CouplingScore}}/100</span>
                         </div>
                         <div class="score-bar-container">
                             <div class="score-bar-fill score-{{scoreQuality .Summary.CouplingScore}}" style="width: {{.Summary.CouplingScore}}%"></div>
                         </div>
-                        <div class="score-detail">Avg: {{printf "%.1f" .Summary.AverageCoupling}}, High-coupling: {{.Summary.HighCouplingClasses}}/{{.Summary.CBOClasses}}</div>
+                        <div class="score-detail">{{t "Avg: %.1f, High-coupling: %d/%d" .Summary.AverageCoupling .Summary.HighCouplingClasses .Summary.CBOClasses}}</div>
                     </div>
                     {{end}}
 
                     {{if .Summary.LCOMEnabled}}
                     <div class="score-bar-item">
                         <div class="score-bar-header">
-                            <span class="score-label">Cohesion (LCOM)</span>
+                            <span class="score-label">{{t "Cohesion (LCOM)"}}</span>
                             <span class="score-value">{{.Summary.CohesionScore}}/100</span>
                         </div>
                         <div class="score-bar-container">
                             <div class="score-bar-fill score-{{scoreQuality .Summary.CohesionScore}}" style="width: {{.Summary.CohesionScore}}%"></div>
                         </div>
-                        <div class="score-detail">Avg: {{printf "%.1f" .Summary.AverageLCOM}}, Low-cohesion: {{.Summary.HighLCOMClasses}}/{{.Summary.LCOMClasses}}</div>
+                        <div class="score-detail">{{t "Avg: %.1f, Low-cohesion: %d/%d" .Summary.AverageLCOM .Summary.HighLCOMClasses .Summary.LCOMClasses}}</div>
                     </div>
                     {{end}}
 
                     {{if .Summary.DepsEnabled}}
                     <div class="score-bar-item">
                         <div class="score-bar-header">
-                            <span class="score-label">Dependencies</span>
+                            <span class="score-label">{{t "Dependencies"}}</span>
                             <span class="score-value">{{.Summary.DependencyScore}}/100</span>
                         </div>
                         <div class="score-bar-container">
                             <div class="score-bar-fill score-{{scoreQuality .Summary.DependencyScore}}" style="width: {{.Summary.DependencyScore}}%"></div>
                         </div>
-                        <div class="score-detail">{{if eq .Summary.DepsModulesInCycles 0}}No cycles{{else}}{{.Summary.DepsModulesInCycles}} cycles{{end}}, Depth: {{.Summary.DepsMaxDepth}}</div>
+                        <div class="score-detail">{{if eq .Summary.DepsModulesInCycles 0}}{{t "No cycles, Depth: %d" .Summary.DepsMaxDepth}}{{else}}{{t "%d cycles, Depth: %d" .Summary.DepsModulesInCycles .Summary.DepsMaxDepth}}{{end}}</div>
                     </div>
                     {{end}}
 
                     {{if .Summary.ArchEnabled}}
                     <div class="score-bar-item">
                         <div class="score-bar-header">
-                            <span class="score-label">Architecture</span>
+                            <span class="score-label">{{t "Architecture"}}</span>
                             <span class="score-value">{{.Summary.ArchitectureScore}}/100</span>
                         </div>
                         <div class="score-bar-container">
                             <div class="score-bar-fill score-{{scoreQuality .Summary.ArchitectureScore}}" style="width: {{.Summary.ArchitectureScore}}%"></div>
                         </div>
-                        <div class="score-detail">{{printf "%.0f%%" (mul100 .Summary.ArchCompliance)}} compliant</div>
+                        <div class="score-detail">{{t "%.0f%% compliant" (mul100 .Summary.ArchCompliance)}}</div>
                     </div>
                     {{end}}
 
                     {{if and .Summary.CommunitiesEnabled .Communities}}
                     <div class="score-bar-item">
                         <div class="score-bar-header">
-                            <span class="score-label">Communities</span>
+                            <span class="score-label">{{t "Communities"}}</span>
                             <span class="score-value">{{.Summary.CommunityScore}}/100</span>
                         </div>
                         <div class="score-bar-container">
                             <div class="score-bar-fill score-{{scoreQuality .Summary.CommunityScore}}" style="width: {{.Summary.CommunityScore}}%"></div>
                         </div>
-                        <div class="score-detail">{{.Communities.TotalCommunities}} communities, Q={{printf "%.3f" .Communities.Modularity}}, {{len .Communities.BridgeModules}} bridge modules</div>
+                        <div class="score-detail">{{t "%d communities, Q=%.3f, %d bridge modules" .Communities.TotalCommunities .Communities.Modularity (len .Communities.BridgeModules)}}</div>
+                    </div>
+                    {{end}}
+
+                    {{if and .Summary.DocumentationScore .Documentation}}
+                    <div class="score-bar-item">
+                        <div class="score-bar-header">
+                            <span class="score-label">{{t "Documentation"}}</span>
+                            <span class="score-value">{{.Summary.DocumentationScore}}/100</span>
+                        </div>
+                        <div class="score-bar-container">
+                            <div class="score-bar-fill score-{{scoreQuality .Summary.DocumentationScore}}" style="width: {{.Summary.DocumentationScore}}%"></div>
+                        </div>
+                        <div class="score-detail">{{t "%d/%d %s items documented" .Documentation.Summary.DocumentedItems .Documentation.Summary.TotalItems .Documentation.Convention}}</div>
+                    </div>
+                    {{end}}
+
+                    {{if and .Summary.TypednessScore .TypeCoverage}}
+                    <div class="score-bar-item">
+                        <div class="score-bar-header">
+                            <span class="score-label">{{t "Typedness"}}</span>
+                            <span class="score-value">{{.Summary.TypednessScore}}/100</span>
+                        </div>
+                        <div class="score-bar-container">
+                            <div class="score-bar-fill score-{{scoreQuality .Summary.TypednessScore}}" style="width: {{.Summary.TypednessScore}}%"></div>
+                        </div>
+                        <div class="score-detail">{{t "%d/%d functions fully annotated, %d public functions unannotated" .TypeCoverage.Summary.FullyAnnotatedFunctions .TypeCoverage.Summary.Functions .TypeCoverage.Summary.UnannotatedPublicFunctions}}</div>
                     </div>
                     {{end}}
                 </div>
 
-                <h3 style="margin-top: 24px; margin-bottom: 16px; color: var(--color-text);">File Statistics</h3>
+                {{if .Summary.Explanations}}
+                <details class="score-explanations">
+                    <summary>{{t "How the health score of %d was computed" .Summary.HealthScore}}</summary>
+                    <p>{{t "The health score starts at 100 and loses the penalty of each category."}}</p>
+                    <table class="table">
+                        <thead>
+                            <tr>
+                                <th>{{t "Category"}}</th>
+                                <th>{{t "Inputs"}}</th>
+                                <th>{{t "Formula"}}</th>
+                                <th>{{t "Penalty"}}</th>
+                                <th>{{t "Score"}}</th>
+                            </tr>
+                        </thead>
+                        <tbody>
+                            {{range .Summary.Explanations}}
+                            <tr>
+                                <td>{{.Category}}</td>
+                                <td>{{range $i, $input := .Inputs}}{{if $i}}<br>{{end}}<code>{{$input.Name}}</code> = {{scoreInput $input.Value}}{{end}}</td>
+                                <td>{{.Formula}}</td>
+                                <td>{{t "−%d of %d" .Penalty .MaxPenalty}}</td>
+                                <td>{{.Score}}/100</td>
+                            </tr>
+                            {{end}}
+                        </tbody>
+                    </table>
+                </details>
+                {{end}}
+
+                <h3 style="margin-top: 24px; margin-bottom: 16px; color: var(--color-text);">{{t "File Statistics"}}</h3>
                 <div class="metric-grid">
                     <div class="metric-card">
                         <div class="metric-value">{{.Summary.TotalFiles}}</div>
-                        <div class="metric-label">Total Files</div>
+                        <div class="metric-label">{{t "Total Files"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{.Summary.AnalyzedFiles}}</div>
-                        <div class="metric-label">Analyzed Files</div>
+                        <div class="metric-label">{{t "Analyzed Files"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{printf "%.2f" .Summary.AverageComplexity}}</div>
-                        <div class="metric-label">Avg Complexity</div>
+                        <div class="metric-label">{{t "Avg Complexity"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{.Summary.DeadCodeCount}}</div>
-                        <div class="metric-label">Dead Code Issues</div>
+                        <div class="metric-label">{{t "Dead Code Issues"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{.Summary.TotalClones}}</div>
-                        <div class="metric-label">Unique Fragments</div>
+                        <div class="metric-label">{{t "Unique Fragments"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{printf "%.1f%%" .Summary.CodeDuplication}}</div>
-                        <div class="metric-label">Fragments Cloned</div>
+                        <div class="metric-label">{{t "Fragments Cloned"}}</div>
                     </div>
                     {{if .Summary.CBOEnabled}}
                     <div class="metric-card">
                         <div class="metric-value">{{.Summary.CBOClasses}}</div>
-                        <div class="metric-label">Total Classes</div>
+                        <div class="metric-label">{{t "Total Classes"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{.Summary.HighCouplingClasses}}</div>
-                        <div class="metric-label">High Coupling (CBO)</div>
+                        <div class="metric-label">{{t "High Coupling (CBO)"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{printf "%.2f" .Summary.AverageCoupling}}</div>
-                        <div class="metric-label">Avg CBO</div>
+                        <div class="metric-label">{{t "Avg CBO"}}</div>
                     </div>
                     {{end}}
                     {{if .Summary.LCOMEnabled}}
                     <div class="metric-card">
                         <div class="metric-value">{{.Summary.LCOMClasses}}</div>
-                        <div class="metric-label">Classes (LCOM)</div>
+                        <div class="metric-label">{{t "Classes (LCOM)"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{.Summary.HighLCOMClasses}}</div>
-                        <div class="metric-label">Low Cohesion</div>
+                        <div class="metric-label">{{t "Low Cohesion"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{printf "%.2f" .Summary.AverageLCOM}}</div>
-                        <div class="metric-label">Avg LCOM4</div>
+                        <div class="metric-label">{{t "Avg LCOM4"}}</div>
                     </div>
                     {{end}}
                 </div>
@@ -651,70 +1250,70 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
                 {{/* System-level quick glance */}}
                 {{if .System}}
                 {{if .System.DependencyAnalysis}}
-                <h3 style="margin-top: 16px; color: var(--color-text);">Dependencies</h3>
+                <h3 style="margin-top: 16px; color: var(--color-text);">{{t "Dependencies"}}</h3>
                 <div class="metric-grid">
                     <div class="metric-card">
                         <div class="metric-value">{{.System.DependencyAnalysis.TotalModules}}</div>
-                        <div class="metric-label">Total Modules</div>
+                        <div class="metric-label">{{t "Total Modules"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{.System.DependencyAnalysis.TotalDependencies}}</div>
-                        <div class="metric-label">Total Dependencies</div>
+                        <div class="metric-label">{{t "Total Dependencies"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{.System.DependencyAnalysis.MaxDepth}}</div>
-                        <div class="metric-label">Max Depth</div>
+                        <div class="metric-label">{{t "Max Depth"}}</div>
                     </div>
                     {{if .System.DependencyAnalysis.CircularDependencies}}
                     <div class="metric-card">
                         <div class="metric-value">{{if .System.DependencyAnalysis.CircularDependencies.HasCircularDependencies}}❌ {{.System.DependencyAnalysis.CircularDependencies.TotalCycles}}{{else}}✅ 0{{end}}</div>
-                        <div class="metric-label">Circular Dependencies</div>
+                        <div class="metric-label">{{t "Circular Dependencies"}}</div>
                     </div>
                     {{end}}
                 </div>
                 {{end}}
 
                 {{if .System.ArchitectureAnalysis}}
-                <h3 style="margin-top: 8px; color: var(--color-text);">Architecture</h3>
+                <h3 style="margin-top: 8px; color: var(--color-text);">{{t "Architecture"}}</h3>
                 <div class="metric-grid">
                     <div class="metric-card">
                         <div class="metric-value">{{.System.ArchitectureAnalysis.TotalViolations}}</div>
-                        <div class="metric-label">Violations</div>
+                        <div class="metric-label">{{t "Violations"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{printf "%.1f%%" (mul100 .System.ArchitectureAnalysis.ComplianceScore)}}</div>
-                        <div class="metric-label">Compliance</div>
+                        <div class="metric-label">{{t "Compliance"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{if .System.ArchitectureAnalysis.LayerAnalysis}}{{.System.ArchitectureAnalysis.LayerAnalysis.LayersAnalyzed}}{{else}}0{{end}}</div>
-                        <div class="metric-label">Layers Analyzed</div>
+                        <div class="metric-label">{{t "Layers Analyzed"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{.System.ArchitectureAnalysis.TotalRules}}</div>
-                        <div class="metric-label">Total Rules</div>
+                        <div class="metric-label">{{t "Total Rules"}}</div>
                     </div>
                 </div>
                 {{end}}
                 {{end}}
 
                 {{if and .Summary.CommunitiesEnabled .Communities}}
-                <h3 style="margin-top: 8px; color: var(--color-text);">Communities</h3>
+                <h3 style="margin-top: 8px; color: var(--color-text);">{{t "Communities"}}</h3>
                 <div class="metric-grid">
                     <div class="metric-card">
                         <div class="metric-value">{{.Communities.TotalCommunities}}</div>
-                        <div class="metric-label">Communities</div>
+                        <div class="metric-label">{{t "Communities"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{printf "%.3f" .Communities.Modularity}}</div>
-                        <div class="metric-label">Modularity (Q)</div>
+                        <div class="metric-label">{{t "Modularity (Q)"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{len .Communities.BridgeModules}}</div>
-                        <div class="metric-label">Bridge Modules</div>
+                        <div class="metric-label">{{t "Bridge Modules"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{.Communities.Algorithm}}</div>
-                        <div class="metric-label">Algorithm</div>
+                        <div class="metric-label">{{t "Algorithm"}}</div>
                     </div>
                 </div>
                 {{end}}
@@ -722,16 +1321,16 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
 
             {{if .Suggestions}}
             <div id="suggestions" class="tab-content">
-                <h2>Suggestions</h2>
-                <p style="color: #666; margin-bottom: 20px;">Actionable improvements sorted by priority (severity × effort)</p>
+                <h2>{{t "Suggestions"}}</h2>
+                <p style="color: var(--color-secondary); margin-bottom: 20px;">{{t "Actionable improvements sorted by priority (severity × effort)"}}</p>
                 <table class="table">
                     <thead>
                         <tr>
-                            <th>Severity</th>
-                            <th>Category</th>
-                            <th>Title</th>
-                            <th>Effort</th>
-                            <th>Location</th>
+                            <th>{{t "Severity"}}</th>
+                            <th>{{t "Category"}}</th>
+                            <th>{{t "Title"}}</th>
+                            <th>{{t "Effort"}}</th>
+                            <th>{{t "Location"}}</th>
                         </tr>
                     </thead>
                     <tbody>
@@ -740,16 +1339,16 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
                         <tr>
                             <td><span class="severity-{{$s.Severity}}">{{$s.Severity}}</span></td>
                             <td>{{$s.Category}}</td>
-                            <td>{{$s.Title}}{{if $s.Description}}<br><small style="color: #666;">{{$s.Description}}</small>{{end}}{{if $s.Steps}}<ol class="suggestion-steps">{{range $s.Steps}}<li>{{.}}</li>{{end}}</ol>{{end}}</td>
+                            <td>{{$s.Title}}{{if $s.Description}}<br><small style="color: var(--color-secondary);">{{$s.Description}}</small>{{end}}{{if $s.Steps}}<ol class="suggestion-steps">{{range $s.Steps}}<li>{{.}}</li>{{end}}</ol>{{end}}</td>
                             <td>{{$s.Effort}}</td>
-                            <td>{{if $s.FilePath}}{{$s.FilePath}}{{if $s.StartLine}}:{{$s.StartLine}}{{end}}{{end}}</td>
+                            <td>{{if $s.FilePath}}{{if $s.StartLine}}{{locationLink $s.FilePath $s.StartLine}}{{else}}{{fileLink $s.FilePath 0 0}}{{end}}{{end}}</td>
                         </tr>
                         {{end}}
                         {{end}}
                     </tbody>
                 </table>
                 {{if gt (len .Suggestions) 30}}
-                <p style="color: #666; margin-top: 10px;">Showing top 30 of {{len .Suggestions}} suggestions</p>
+                <p style="color: var(--color-secondary); margin-top: 10px;">{{t "Showing top %d of %d suggestions" 30 (len .Suggestions)}}</p>
                 {{end}}
             </div>
             {{end}}
@@ -757,7 +1356,7 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
             {{if .Summary.ComplexityEnabled}}
             <div id="complexity" class="tab-content">
                 <div class="tab-header-with-score">
-                    <h2 style="margin: 0;">Complexity Analysis</h2>
+                    <h2 style="margin: 0;">{{t "Complexity Analysis"}}</h2>
                     <div class="score-badge-compact score-{{scoreQuality .Summary.ComplexityScore}}">
                         {{.Summary.ComplexityScore}}/100
                     </div>
@@ -766,47 +1365,53 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
                 <div class="metric-grid">
                     <div class="metric-card">
                         <div class="metric-value">{{if and (gt .Complexity.Summary.FunctionsParsed 0) (ne .Complexity.Summary.FunctionsParsed .Complexity.Summary.TotalFunctions)}}{{.Complexity.Summary.TotalFunctions}} / {{.Complexity.Summary.FunctionsParsed}}{{else}}{{.Complexity.Summary.TotalFunctions}}{{end}}</div>
-                        <div class="metric-label">{{if and (gt .Complexity.Summary.FunctionsParsed 0) (ne .Complexity.Summary.FunctionsParsed .Complexity.Summary.TotalFunctions)}}Reported / Parsed{{else}}Total Functions{{end}}</div>
+                        <div class="metric-label">{{if and (gt .Complexity.Summary.FunctionsParsed 0) (ne .Complexity.Summary.FunctionsParsed .Complexity.Summary.TotalFunctions)}}{{t "Reported / Parsed"}}{{else}}{{t "Total Functions"}}{{end}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{printf "%.2f" .Complexity.Summary.AverageComplexity}}</div>
-                        <div class="metric-label">Average</div>
+                        <div class="metric-label">{{t "Average"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{.Complexity.Summary.MaxComplexity}}</div>
-                        <div class="metric-label">Maximum</div>
+                        <div class="metric-label">{{t "Maximum"}}</div>
                     </div>
                 </div>
                 
-                <h3>Top Complex Functions</h3>
+                <h3>{{t "Top Complex Functions"}}</h3>
                 <table class="table">
                     <thead>
                         <tr>
-                            <th>Function</th>
-                            <th>File</th>
-                            <th>Complexity</th>
-                            <th>Cognitive</th>
-                            <th>Nesting Depth</th>
-                            <th>Risk</th>
+                            <th>{{t "Function"}}</th>
+                            <th>{{t "File"}}</th>
+                            <th>{{t "Complexity"}}</th>
+                            <th>{{t "Cognitive"}}</th>
+                            <th>{{t "Nesting Depth"}}</th>
+                            <th>{{t "Breakdown"}}</th>
+                            <th>{{t "Risk"}}</th>
+                            {{if $.Summary.BlameEnabled}}<th>{{t "Last Changed"}}</th>{{end}}
+                            {{if $.Summary.HistoryEnabled}}<th>{{t "Trend"}}</th>{{end}}
                         </tr>
                     </thead>
                     <tbody>
                         {{range $i, $f := .Complexity.Functions}}
                         {{if lt $i 10}}
                         <tr>
-                            <td>{{$f.Name}}</td>
-                            <td>{{$f.FilePath}}</td>
-                            <td>{{$f.Metrics.Complexity}}</td>
+                            <td>{{$f.Name}}{{if $f.Boilerplate}} <span class="severity-pill pill-low" title="{{$f.Boilerplate}}">{{t "boilerplate"}}</span>{{end}}</td>
+                            <td>{{fileLink $f.FilePath $f.StartLine $f.EndLine}}</td>
+                            <td>{{$f.Metrics.Complexity}}{{if gt $f.ComplexityBudget 0}} / {{$f.ComplexityBudget}}{{if $f.OverBudget}} ({{t "over budget"}}){{end}}{{end}}</td>
                             <td>{{$f.Metrics.CognitiveComplexity}}</td>
                             <td>{{$f.Metrics.NestingDepth}}</td>
-                            <td class="risk-{{$f.RiskLevel}}">{{$f.RiskLevel}}</td>
+                            <td>{{$f.Metrics.Breakdown}}</td>
+                            <td class="risk-{{$f.RiskLevel}}">{{$f.RiskLevel}}{{if $f.ExcludedFromRisk}} ({{t "excluded"}}){{end}}</td>
+                            {{if $.Summary.BlameEnabled}}<td>{{blameLabel $f.Blame}}</td>{{end}}
+                            {{if $.Summary.HistoryEnabled}}<td>{{sparkline $f.History}}</td>{{end}}
                         </tr>
                         {{end}}
                         {{end}}
                     </tbody>
                 </table>
                 {{if gt (len .Complexity.Functions) 10}}
-                <p style="color: #666; margin-top: 10px;">Showing top 10 of {{len .Complexity.Functions}} functions</p>
+                <p style="color: var(--color-secondary); margin-top: 10px;">{{t "Showing top %d of %d functions" 10 (len .Complexity.Functions)}}</p>
                 {{end}}
                 {{end}}
             </div>
@@ -815,7 +1420,7 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
             {{if .Summary.DeadCodeEnabled}}
             <div id="deadcode" class="tab-content">
                 <div class="tab-header-with-score">
-                    <h2 style="margin: 0;">Dead Code Detection</h2>
+                    <h2 style="margin: 0;">{{t "Dead Code Detection"}}</h2>
                     <div class="score-badge-compact score-{{scoreQuality .Summary.DeadCodeScore}}">
                         {{.Summary.DeadCodeScore}}/100
                     </div>
@@ -824,28 +1429,29 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
                 <div class="metric-grid">
                     <div class="metric-card">
                         <div class="metric-value">{{.DeadCode.Summary.TotalFindings}}</div>
-                        <div class="metric-label">Total Issues</div>
+                        <div class="metric-label">{{t "Total Issues"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{.DeadCode.Summary.CriticalFindings}}</div>
-                        <div class="metric-label">Critical</div>
+                        <div class="metric-label">{{t "Critical"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{.DeadCode.Summary.WarningFindings}}</div>
-                        <div class="metric-label">Warnings</div>
+                        <div class="metric-label">{{t "Warnings"}}</div>
                     </div>
                 </div>
                 
                 {{if gt .DeadCode.Summary.TotalFindings 0}}
-                <h3>Top Dead Code Issues</h3>
+                <h3>{{t "Top Dead Code Issues"}}</h3>
                 <table class="table">
                     <thead>
                         <tr>
-                            <th>File</th>
-                            <th>Function</th>
-                            <th>Lines</th>
-                            <th>Severity</th>
-                            <th>Reason</th>
+                            <th>{{t "File"}}</th>
+                            <th>{{t "Function"}}</th>
+                            <th>{{t "Lines"}}</th>
+                            <th>{{t "Severity"}}</th>
+                            <th>{{t "Reason"}}</th>
+                            {{if $.Summary.BlameEnabled}}<th>{{t "Last Changed"}}</th>{{end}}
                         </tr>
                     </thead>
                     <tbody>
@@ -854,11 +1460,12 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
                         {{range $i, $finding := $func.Findings}}
                         {{if lt $i 10}}
                         <tr>
-                            <td>{{$finding.Location.FilePath}}</td>
+                            <td>{{fileLink $finding.Location.FilePath $finding.Location.StartLine $finding.Location.EndLine}}</td>
                             <td>{{$finding.FunctionName}}</td>
                             <td>{{$finding.Location.StartLine}}-{{$finding.Location.EndLine}}</td>
                             <td class="severity-{{$finding.Severity}}">{{$finding.Severity}}</td>
                             <td>{{$finding.Reason}}</td>
+                            {{if $.Summary.BlameEnabled}}<td>{{blameLabel $finding.Blame}}</td>{{end}}
                         </tr>
                         {{end}}
                         {{end}}
@@ -867,10 +1474,10 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
                     </tbody>
                 </table>
                 {{if gt .DeadCode.Summary.TotalFindings 10}}
-                <p style="color: #666; margin-top: 10px;">Showing top 10 of {{.DeadCode.Summary.TotalFindings}} dead code issues</p>
+                <p style="color: var(--color-secondary); margin-top: 10px;">{{t "Showing top %d of %d dead code issues" 10 .DeadCode.Summary.TotalFindings}}</p>
                 {{end}}
                 {{else}}
-                <p style="color: var(--color-success); font-weight: bold; margin-top: 20px;">✓ No dead code detected</p>
+                <p style="color: var(--color-success); font-weight: bold; margin-top: 20px;">✓ {{t "No dead code detected"}}</p>
                 {{end}}
                 {{end}}
             </div>
@@ -879,7 +1486,7 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
             {{if .Summary.CloneEnabled}}
             <div id="clone" class="tab-content">
                 <div class="tab-header-with-score">
-                    <h2 style="margin: 0;">Clone</h2>
+                    <h2 style="margin: 0;">{{t "Clone"}}</h2>
                     <div class="score-badge-compact score-{{scoreQuality .Summary.DuplicationScore}}">
                         {{.Summary.DuplicationScore}}/100
                     </div>
@@ -888,56 +1495,155 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
                 <div class="metric-grid">
                     <div class="metric-card">
                         <div class="metric-value">{{.Clone.Statistics.TotalClones}}</div>
-                        <div class="metric-label">Unique Fragments</div>
+                        <div class="metric-label">{{t "Unique Fragments"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{.Clone.Statistics.TotalCloneGroups}}</div>
-                        <div class="metric-label">Clone Groups</div>
+                        <div class="metric-label">{{t "Clone Groups"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{printf "%.2f" .Clone.Statistics.AverageSimilarity}}</div>
-                        <div class="metric-label">Avg Similarity</div>
+                        <div class="metric-label">{{t "Avg Similarity"}}</div>
                     </div>
                 </div>
                 
+                {{if .Clone.Consolidation}}
+                <h3>{{t "Consolidation Targets"}}</h3>
+                <p style="color: var(--color-secondary); margin-bottom: 15px;">{{t "Packages where helpers extracted from clone groups spanning several modules should live"}}</p>
+                <table class="table">
+                    <thead>
+                        <tr>
+                            <th>{{t "Package"}}</th>
+                            <th>{{t "Clone Groups"}}</th>
+                            <th>{{t "Fragments"}}</th>
+                            <th>{{t "Duplicate Lines"}}</th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {{range .Clone.Consolidation}}
+                        <tr>
+                            <td><code>{{packageLabel .Package}}</code></td>
+                            <td>{{join .GroupIDs ", "}}</td>
+                            <td>{{.Fragments}}</td>
+                            <td>{{.DuplicateLines}}</td>
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+                {{end}}
+
+                {{if .Clone.Locations}}
+                <h3>{{if eq .Clone.Request.ReportGroupBy "package"}}{{t "Clones by Package"}}{{else}}{{t "Clones by File"}}{{end}}</h3>
+                <p style="color: var(--color-secondary); margin-bottom: 15px;">{{if eq .Clone.Request.ReportGroupBy "package"}}{{t "Clone groups with members in each package, most duplicated lines first"}}{{else}}{{t "Clone groups with members in each file, most duplicated lines first"}}{{end}}</p>
+                <table class="table">
+                    <thead>
+                        <tr>
+                            <th>{{if eq .Clone.Request.ReportGroupBy "package"}}{{t "Package"}}{{else}}{{t "File"}}{{end}}</th>
+                            <th>{{t "Groups"}}</th>
+                            <th>{{t "Fragments"}}</th>
+                            <th>{{t "Duplicate Lines"}}</th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {{range $i, $loc := .Clone.Locations}}
+                        {{if lt $i 20}}
+                        <tr>
+                            <td>{{if eq $.Clone.Request.ReportGroupBy "package"}}<code>{{$loc.Location}}</code>{{else}}{{fileLink $loc.Location 0 0}}{{end}}</td>
+                            <td>{{range $j, $member := $loc.Groups}}{{if $j}}<br>{{end}}{{t "Group %v (Type %v): %d of %d, lines %s" $member.GroupID $member.Type $member.Fragments $member.GroupSize (join $member.Lines ", ")}}{{end}}</td>
+                            <td>{{$loc.Fragments}}</td>
+                            <td>{{$loc.DuplicateLines}}</td>
+                        </tr>
+                        {{end}}
+                        {{end}}
+                    </tbody>
+                </table>
+                {{if gt (len .Clone.Locations) 20}}
+                <p style="color: var(--color-secondary); margin-top: 10px;">{{t "Showing top %d of %d locations" 20 (len .Clone.Locations)}}</p>
+                {{end}}
+                {{end}}
+
+                {{with cloneHeatmap .Clone}}
+                <h3>{{if eq .GroupBy "package"}}{{t "Similarity Between Packages"}}{{else}}{{t "Similarity Between Files"}}{{end}}</h3>
+                <p style="color: var(--color-secondary); margin-bottom: 15px;">{{t "Share of the lines of two locations duplicated with each other; the diagonal is the share duplicated within a location. Pairs in dark cells are candidates for merging or for a shared base module."}}</p>
+                <div style="overflow-x: auto;">
+                <table class="clone-heatmap">
+                    <thead>
+                        <tr>
+                            <th></th>
+                            {{range .Rows}}<th title="{{.Location}}">{{.Index}}</th>{{end}}
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {{range $row := .Rows}}
+                        <tr>
+                            <th title="{{$row.Location}} ({{t "%d lines" $row.Lines}})">{{$row.Index}}. {{$row.Label}}</th>
+                            {{range $j, $cell := $row.Cells}}
+                            <td{{if eq (add $j 1) $row.Index}} class="diagonal"{{end}}{{if $cell.Color}} style="background: {{$cell.Color}};"{{end}} title="{{$row.Location}} ↔ {{$cell.Other}}: {{printf "%.1f" (percent $cell.Similarity)}}%"></td>
+                            {{end}}
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+                </div>
+                {{if .Omitted}}
+                <p style="color: var(--color-secondary); margin-top: -10px; margin-bottom: 20px;">{{t "Showing top %d of %d locations" (len .Rows) (add (len .Rows) .Omitted)}}</p>
+                {{end}}
+                {{end}}
+
                 {{if gt .Clone.Statistics.TotalCloneGroups 0}}
-                <h3>Clone Groups</h3>
-                <p style="color: #666; margin-bottom: 15px;">Code fragments grouped by similarity</p>
+                <h3>{{t "Clone Groups"}}</h3>
+                <p style="color: var(--color-secondary); margin-bottom: 15px;">{{t "Code fragments grouped by similarity"}}</p>
+                {{$limit := 10}}
+                {{if and .Clone.Request (gt .Clone.Request.MaxGroupMembers 0)}}{{$limit = .Clone.Request.MaxGroupMembers}}{{end}}
                 {{range $i, $group := .Clone.CloneGroups}}
                 {{if lt $i 10}}
-                <div style="background: #f8fafc; padding: 15px; margin-bottom: 15px; border-radius: 8px; border-left: 4px solid #cbd5e1;">
-                    <h4 style="margin-top: 0; color: #333;">Group {{$group.ID}} - {{len $group.Clones}} clones (Type {{$group.Type}}, similarity: {{printf "%.2f" $group.Similarity}})</h4>
+                <div class="clone-group">
+                    <h4>{{t "Group %v - %d clones (Type %v, similarity: %.2f)" $group.ID (or $group.TotalMembers (len $group.Clones)) $group.Type $group.Similarity}}</h4>
+                    {{with $group.ExtractionTarget}}<p style="color: var(--color-secondary); margin: 0 0 10px;">{{tcode "Extract to %s" (packageLabel .Package)}}{{if .Layer}} ({{t "layer %s" .Layer}}){{end}}: {{.Rationale}}</p>{{end}}
                     <table class="table" style="margin-bottom: 0;">
                         <thead>
                             <tr>
-                                <th>File</th>
-                                <th>Lines</th>
-                                <th>Size</th>
+                                <th>{{t "File"}}</th>
+                                <th>{{t "Lines"}}</th>
+                                <th>{{t "Size"}}</th>
                             </tr>
                         </thead>
                         <tbody>
                             {{range $j, $clone := $group.Clones}}
-                            {{if lt $j 10}}
+                            {{if lt $j $limit}}
                             <tr>
-                                <td>{{$clone.Location.FilePath}}</td>
+                                <td>{{fileLink $clone.Location.FilePath $clone.Location.StartLine $clone.Location.EndLine}}</td>
                                 <td>{{$clone.Location.StartLine}}-{{$clone.Location.EndLine}}</td>
-                                <td>{{$clone.LineCount}} lines</td>
+                                <td>{{t "%d lines" $clone.LineCount}}</td>
                             </tr>
                             {{if and $.Clone.Request $.Clone.Request.ShouldShowContent $clone.Content}}
                             <tr>
                                 <td colspan="3" style="padding-top: 0;">
                                     <div class="code-preview-card">
-                                        <div class="code-preview-title">Code Preview</div>
+                                        {{if and (gt $j 0) (index $group.Clones 0).Content}}
+                                        <div class="code-preview-title">{{t "Diff against clone 1"}}</div>
+                                        {{cloneDiff (index $group.Clones 0) $clone}}
+                                        {{else}}
+                                        <div class="code-preview-title">{{t "Code Preview"}}</div>
                                         <pre class="code-preview">{{previewContent $clone.Content}}</pre>
+                                        {{end}}
                                     </div>
                                 </td>
                             </tr>
                             {{end}}
                             {{end}}
                             {{end}}
-                            {{if gt (len $group.Clones) 10}}
+                            {{$shown := len $group.Clones}}
+                            {{if gt $shown $limit}}{{$shown = $limit}}{{end}}
+                            {{$more := add (sub (len $group.Clones) $shown) $group.OmittedMembers}}
+                            {{if gt $more 0}}
                             <tr>
-                                <td colspan="3" style="color: #666; font-style: italic;">... and {{sub (len $group.Clones) 10}} more clones</td>
+                                <td colspan="3" style="color: var(--color-secondary); font-style: italic;">{{t "... and %d more clones" $more}}</td>
+                            </tr>
+                            {{end}}
+                            {{if gt $group.CollapsedMembers 0}}
+                            <tr>
+                                <td colspan="3" style="color: var(--color-secondary); font-style: italic;">{{t "%d more in files listed above" $group.CollapsedMembers}}</td>
                             </tr>
                             {{end}}
                         </tbody>
@@ -946,49 +1652,39 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
                 {{end}}
                 {{end}}
                 {{if gt .Clone.Statistics.TotalCloneGroups 10}}
-                <p style="color: #666; margin-top: 10px;">Showing top 10 of {{.Clone.Statistics.TotalCloneGroups}} clone groups</p>
+                <p style="color: var(--color-secondary); margin-top: 10px;">{{t "Showing top %d of %d clone groups" 10 .Clone.Statistics.TotalCloneGroups}}</p>
                 {{end}}
                 {{else if gt .Clone.Statistics.TotalClonePairs 0}}
-                <h3>Clone Pairs</h3>
-                <p style="color: #666; margin-bottom: 15px;">No groups formed, showing individual pairs</p>
+                <h3>{{t "Clone Pairs"}}</h3>
+                <p style="color: var(--color-secondary); margin-bottom: 15px;">{{t "No groups formed, showing individual pairs"}}</p>
                 <table class="table">
                     <thead>
                         <tr>
-                            <th>File 1</th>
-                            <th>File 2</th>
-                            <th>Lines 1</th>
-                            <th>Lines 2</th>
-                            <th>Similarity</th>
-                            <th>Type</th>
+                            <th>{{t "File 1"}}</th>
+                            <th>{{t "File 2"}}</th>
+                            <th>{{t "Lines 1"}}</th>
+                            <th>{{t "Lines 2"}}</th>
+                            <th>{{t "Similarity"}}</th>
+                            <th>{{t "Type"}}</th>
                         </tr>
                     </thead>
                     <tbody>
                         {{range $i, $pair := .Clone.ClonePairs}}
                         {{if lt $i 15}}
                         <tr>
-                            <td>{{$pair.Clone1.Location.FilePath}}</td>
-                            <td>{{$pair.Clone2.Location.FilePath}}</td>
+                            <td>{{fileLink $pair.Clone1.Location.FilePath $pair.Clone1.Location.StartLine $pair.Clone1.Location.EndLine}}</td>
+                            <td>{{fileLink $pair.Clone2.Location.FilePath $pair.Clone2.Location.StartLine $pair.Clone2.Location.EndLine}}</td>
                             <td>{{$pair.Clone1.Location.StartLine}}-{{$pair.Clone1.Location.EndLine}}</td>
                             <td>{{$pair.Clone2.Location.StartLine}}-{{$pair.Clone2.Location.EndLine}}</td>
                             <td>{{printf "%.3f" $pair.Similarity}}</td>
                             <td>{{$pair.Type}}</td>
                         </tr>
-                        {{if and $.Clone.Request $.Clone.Request.ShouldShowContent $pair.Clone1.Content}}
-                        <tr>
-                            <td colspan="6" style="padding-top: 0;">
-                                <div class="code-preview-card">
-                                    <div class="code-preview-title">Clone 1 Preview</div>
-                                    <pre class="code-preview">{{previewContent $pair.Clone1.Content}}</pre>
-                                </div>
-                            </td>
-                        </tr>
-                        {{end}}
-                        {{if and $.Clone.Request $.Clone.Request.ShouldShowContent $pair.Clone2.Content}}
+                        {{if and $.Clone.Request $.Clone.Request.ShouldShowContent $pair.Clone1.Content $pair.Clone2.Content}}
                         <tr>
                             <td colspan="6" style="padding-top: 0;">
                                 <div class="code-preview-card">
-                                    <div class="code-preview-title">Clone 2 Preview</div>
-                                    <pre class="code-preview">{{previewContent $pair.Clone2.Content}}</pre>
+                                    <div class="code-preview-title">{{t "Diff from clone 1 to clone 2"}}</div>
+                                    {{cloneDiff $pair.Clone1 $pair.Clone2}}
                                 </div>
                             </td>
                         </tr>
@@ -998,10 +1694,43 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
                     </tbody>
                 </table>
                 {{if gt .Clone.Statistics.TotalClonePairs 15}}
-                <p style="color: #666; margin-top: 10px;">Showing top 15 of {{.Clone.Statistics.TotalClonePairs}} clone pairs</p>
+                <p style="color: var(--color-secondary); margin-top: 10px;">{{t "Showing top %d of %d clone pairs" 15 .Clone.Statistics.TotalClonePairs}}</p>
                 {{end}}
                 {{else}}
-                <p style="color: var(--color-success); font-weight: bold; margin-top: 20px;">✓ No clones detected</p>
+                <p style="color: var(--color-success); font-weight: bold; margin-top: 20px;">✓ {{t "No clones detected"}}</p>
+                {{end}}
+
+                {{if .Clone.StringClones}}
+                <h3>{{t "String Clones"}}</h3>
+                <p style="color: var(--color-secondary); margin-bottom: 15px;">{{t "Near-duplicate string literals, such as embedded SQL, HTML or shell commands"}}</p>
+                {{range $i, $group := .Clone.StringClones}}
+                {{if lt $i 10}}
+                <div class="clone-group">
+                    <h4>{{t "Group %d - %d literals (%s, similarity: %.2f)" $group.ID (len $group.Literals) $group.Kind $group.Similarity}}</h4>
+                    <table class="table" style="margin-bottom: 0;">
+                        <thead>
+                            <tr>
+                                <th>{{t "File"}}</th>
+                                <th>{{t "Lines"}}</th>
+                                <th>{{t "Preview"}}</th>
+                            </tr>
+                        </thead>
+                        <tbody>
+                            {{range $group.Literals}}
+                            <tr>
+                                <td>{{fileLink .Location.FilePath .Location.StartLine .Location.EndLine}}</td>
+                                <td>{{.Location.StartLine}}-{{.Location.EndLine}}</td>
+                                <td><code>{{.Preview}}</code></td>
+                            </tr>
+                            {{end}}
+                        </tbody>
+                    </table>
+                </div>
+                {{end}}
+                {{end}}
+                {{if gt (len .Clone.StringClones) 10}}
+                <p style="color: var(--color-secondary); margin-top: 10px;">{{t "Showing top %d of %d string clone groups" 10 (len .Clone.StringClones)}}</p>
+                {{end}}
                 {{end}}
                 {{end}}
             </div>
@@ -1010,41 +1739,42 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
             {{if .Summary.CBOEnabled}}
             <div id="cbo" class="tab-content">
                 <div class="tab-header-with-score">
-                    <h2 style="margin: 0;">Coupling</h2>
+                    <h2 style="margin: 0;">{{t "Coupling"}}</h2>
                     <div class="score-badge-compact score-{{scoreQuality .Summary.CouplingScore}}">
                         {{.Summary.CouplingScore}}/100
                     </div>
                 </div>
-                <p style="margin-bottom: 20px; color: #666;">Coupling Between Objects (CBO) metrics</p>
+                <p style="margin-bottom: 20px; color: var(--color-secondary);">{{t "Coupling Between Objects (CBO) metrics"}}</p>
                 {{if .CBO}}
                 <div class="metric-grid">
                     <div class="metric-card">
                         <div class="metric-value">{{.CBO.Summary.TotalClasses}}</div>
-                        <div class="metric-label">Total Classes</div>
+                        <div class="metric-label">{{t "Total Classes"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{.CBO.Summary.HighRiskClasses}}</div>
-                        <div class="metric-label">High Risk Classes</div>
+                        <div class="metric-label">{{t "High Risk Classes"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{printf "%.2f" .CBO.Summary.AverageCBO}}</div>
-                        <div class="metric-label">Average CBO</div>
+                        <div class="metric-label">{{t "Average CBO"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{.CBO.Summary.MaxCBO}}</div>
-                        <div class="metric-label">Max CBO</div>
+                        <div class="metric-label">{{t "Max CBO"}}</div>
                     </div>
                 </div>
                 
-                <h3>Most Dependent Classes</h3>
+                <h3>{{t "Most Dependent Classes"}}</h3>
                 <table class="table">
                     <thead>
                         <tr>
-                            <th>Class</th>
-                            <th>File</th>
-                            <th>CBO</th>
-                            <th>Risk Level</th>
-                            <th>Dependent Classes</th>
+                            <th>{{t "Class"}}</th>
+                            <th>{{t "File"}}</th>
+                            <th>{{t "CBO"}}</th>
+                            <th>{{t "Risk Level"}}</th>
+                            <th title="{{t "Inheritance / Composition / Instantiation / Method calls / Type hints"}}">{{t "Kinds (Inh / Comp / Inst / Calls / Hints)"}}</th>
+                            <th>{{t "Dependent Classes"}}</th>
                         </tr>
                     </thead>
                     <tbody>
@@ -1052,9 +1782,10 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
                         {{if lt $i 10}}
                         <tr>
                             <td>{{$c.Name}}</td>
-                            <td>{{$c.FilePath}}</td>
+                            <td>{{fileLink $c.FilePath $c.StartLine $c.EndLine}}</td>
                             <td>{{$c.Metrics.CouplingCount}}</td>
                             <td class="risk-{{$c.RiskLevel}}">{{$c.RiskLevel}}</td>
+                            <td>{{$c.Metrics.Kinds.Inheritance}} / {{$c.Metrics.Kinds.Composition}} / {{$c.Metrics.Kinds.Instantiation}} / {{$c.Metrics.Kinds.MethodCalls}} / {{$c.Metrics.Kinds.TypeHints}}{{if $c.KindViolations}}<br><small class="risk-high">{{t "over threshold: %s" (join $c.KindViolations ", ")}}</small>{{end}}</td>
                             <td>{{join $c.Metrics.DependentClasses ", "}}</td>
                         </tr>
                         {{end}}
@@ -1062,7 +1793,7 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
                     </tbody>
                 </table>
                 {{if gt (len .CBO.Classes) 10}}
-                <p style="color: #666; margin-top: 10px;">Showing top 10 of {{len .CBO.Classes}} classes</p>
+                <p style="color: var(--color-secondary); margin-top: 10px;">{{t "Showing top %d of %d classes" 10 (len .CBO.Classes)}}</p>
                 {{end}}
                 {{end}}
             </div>
@@ -1071,42 +1802,42 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
             {{if .Summary.LCOMEnabled}}
             <div id="lcom" class="tab-content">
                 <div class="tab-header-with-score">
-                    <h2 style="margin: 0;">Class Cohesion</h2>
+                    <h2 style="margin: 0;">{{t "Class Cohesion"}}</h2>
                     <div class="score-badge-compact score-{{scoreQuality .Summary.CohesionScore}}">
                         {{.Summary.CohesionScore}}/100
                     </div>
                 </div>
-                <p style="margin-bottom: 20px; color: #666;">Lack of Cohesion of Methods (LCOM4) metrics</p>
+                <p style="margin-bottom: 20px; color: var(--color-secondary);">{{t "Lack of Cohesion of Methods (LCOM4) metrics"}}</p>
                 {{if .LCOM}}
                 <div class="metric-grid">
                     <div class="metric-card">
                         <div class="metric-value">{{.LCOM.Summary.TotalClasses}}</div>
-                        <div class="metric-label">Total Classes</div>
+                        <div class="metric-label">{{t "Total Classes"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{.LCOM.Summary.HighRiskClasses}}</div>
-                        <div class="metric-label">Low Cohesion</div>
+                        <div class="metric-label">{{t "Low Cohesion"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{printf "%.2f" .LCOM.Summary.AverageLCOM}}</div>
-                        <div class="metric-label">Average LCOM4</div>
+                        <div class="metric-label">{{t "Average LCOM4"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{.LCOM.Summary.MaxLCOM}}</div>
-                        <div class="metric-label">Max LCOM4</div>
+                        <div class="metric-label">{{t "Max LCOM4"}}</div>
                     </div>
                 </div>
 
-                <h3>Least Cohesive Classes</h3>
+                <h3>{{t "Least Cohesive Classes"}}</h3>
                 <table class="table">
                     <thead>
                         <tr>
-                            <th>Class</th>
-                            <th>File</th>
-                            <th>LCOM4</th>
-                            <th>Risk</th>
-                            <th>Methods</th>
-                            <th>Instance Vars</th>
+                            <th>{{t "Class"}}</th>
+                            <th>{{t "File"}}</th>
+                            <th>{{t "LCOM4"}}</th>
+                            <th>{{t "Risk"}}</th>
+                            <th>{{t "Methods"}}</th>
+                            <th>{{t "Instance Vars"}}</th>
                         </tr>
                     </thead>
                     <tbody>
@@ -1114,7 +1845,7 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
                         {{if lt $i 10}}
                         <tr>
                             <td>{{$c.Name}}</td>
-                            <td>{{$c.FilePath}}:{{$c.StartLine}}</td>
+                            <td>{{locationLink $c.FilePath $c.StartLine}}</td>
                             <td>{{$c.Metrics.LCOM4}}</td>
                             <td class="risk-{{$c.RiskLevel}}">{{$c.RiskLevel}}</td>
                             <td>{{sub $c.Metrics.TotalMethods $c.Metrics.ExcludedMethods}}</td>
@@ -1125,7 +1856,7 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
                     </tbody>
                 </table>
                 {{if gt (len .LCOM.Classes) 10}}
-                <p style="color: #666; margin-top: 10px;">Showing top 10 of {{len .LCOM.Classes}} classes</p>
+                <p style="color: var(--color-secondary); margin-top: 10px;">{{t "Showing top %d of %d classes" 10 (len .LCOM.Classes)}}</p>
                 {{end}}
                 {{end}}
             </div>
@@ -1135,69 +1866,69 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
             {{if .System.DependencyAnalysis}}
             <div id="sys-deps" class="tab-content">
                 <div class="tab-header-with-score">
-                    <h2 style="margin: 0;">Module Dependencies</h2>
+                    <h2 style="margin: 0;">{{t "Module Dependencies"}}</h2>
                     <div class="score-badge-compact score-{{scoreQuality .Summary.DependencyScore}}">
                         {{.Summary.DependencyScore}}/100
                     </div>
                 </div>
-                <p style="margin-bottom: 20px; color: #666;">Project-wide module dependency graph metrics</p>
+                <p style="margin-bottom: 20px; color: var(--color-secondary);">{{t "Project-wide module dependency graph metrics"}}</p>
                 <div class="metric-grid">
                     <div class="metric-card">
                         <div class="metric-value">{{.System.DependencyAnalysis.TotalModules}}</div>
-                        <div class="metric-label">Total Modules</div>
+                        <div class="metric-label">{{t "Total Modules"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{.System.DependencyAnalysis.TotalDependencies}}</div>
-                        <div class="metric-label">Total Dependencies</div>
+                        <div class="metric-label">{{t "Total Dependencies"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{.System.DependencyAnalysis.MaxDepth}}</div>
-                        <div class="metric-label">Max Depth</div>
+                        <div class="metric-label">{{t "Max Depth"}}</div>
                     </div>
                     {{if .System.DependencyAnalysis.CircularDependencies}}
                     <div class="metric-card">
                         <div class="metric-value">{{if .System.DependencyAnalysis.CircularDependencies.HasCircularDependencies}}❌ {{.System.DependencyAnalysis.CircularDependencies.TotalCycles}}{{else}}✅ 0{{end}}</div>
-                        <div class="metric-label">Circular Dependencies</div>
+                        <div class="metric-label">{{t "Circular Dependencies"}}</div>
                     </div>
                     {{end}}
                 </div>
 
                 {{if .System.DependencyAnalysis.CouplingAnalysis}}
-                <h3 style="margin-top: 30px;">Main Sequence</h3>
+                <h3 style="margin-top: 30px;">{{t "Main Sequence"}}</h3>
                 <div class="metric-grid">
                     <div class="metric-card">
                         <div class="metric-value">{{printf "%.3f" .System.DependencyAnalysis.CouplingAnalysis.AverageInstability}}</div>
-                        <div class="metric-label">Average Instability</div>
+                        <div class="metric-label">{{t "Average Instability"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{printf "%.3f" .System.DependencyAnalysis.CouplingAnalysis.MainSequenceDeviation}}</div>
-                        <div class="metric-label">Main Sequence Deviation</div>
+                        <div class="metric-label">{{t "Main Sequence Deviation"}}</div>
                     </div>
                 </div>
                 {{if or .System.DependencyAnalysis.CouplingAnalysis.ZoneOfPain .System.DependencyAnalysis.CouplingAnalysis.ZoneOfUselessness .System.DependencyAnalysis.CouplingAnalysis.MainSequence}}
                 <table class="table">
                     <thead>
                         <tr>
-                            <th>Zone</th>
-                            <th>Modules</th>
+                            <th>{{t "Zone"}}</th>
+                            <th>{{t "Modules"}}</th>
                         </tr>
                     </thead>
                     <tbody>
                         {{if .System.DependencyAnalysis.CouplingAnalysis.ZoneOfPain}}
                         <tr>
-                            <td>Zone of Pain</td>
+                            <td>{{t "Zone of Pain"}}</td>
                             <td>{{join .System.DependencyAnalysis.CouplingAnalysis.ZoneOfPain ", "}}</td>
                         </tr>
                         {{end}}
                         {{if .System.DependencyAnalysis.CouplingAnalysis.ZoneOfUselessness}}
                         <tr>
-                            <td>Zone of Uselessness</td>
+                            <td>{{t "Zone of Uselessness"}}</td>
                             <td>{{join .System.DependencyAnalysis.CouplingAnalysis.ZoneOfUselessness ", "}}</td>
                         </tr>
                         {{end}}
                         {{if .System.DependencyAnalysis.CouplingAnalysis.MainSequence}}
                         <tr>
-                            <td>Main Sequence</td>
+                            <td>{{t "Main Sequence"}}</td>
                             <td>{{join .System.DependencyAnalysis.CouplingAnalysis.MainSequence ", "}}</td>
                         </tr>
                         {{end}}
@@ -1208,19 +1939,19 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
 
                 {{/* Circular Dependencies Details Section */}}
                 {{if .System.DependencyAnalysis.CircularDependencies}}
-                <h3 style="margin-top: 30px;">Circular Dependencies</h3>
+                <h3 style="margin-top: 30px;">{{t "Circular Dependencies"}}</h3>
                 {{if not .System.DependencyAnalysis.CircularDependencies.HasCircularDependencies}}
-                <div style="padding: 20px; background: #f0fdf4; border-left: 4px solid #bbf7d0; border-radius: 4px; margin: 20px 0;">
-                    <strong style="color: #14532d;">✅ No circular dependencies detected</strong>
-                    <p style="color: #14532d; margin: 10px 0 0 0;">All modules have acyclic dependency relationships.</p>
+                <div class="callout callout-success">
+                    <strong>✅ {{t "No circular dependencies detected"}}</strong>
+                    <p>{{t "All modules have acyclic dependency relationships."}}</p>
                 </div>
                 {{else}}
                 <table class="table">
                     <thead>
                         <tr>
-                            <th style="width: 10%;">Severity</th>
-                            <th style="width: 8%;">Size</th>
-                            <th>Dependency Paths</th>
+                            <th style="width: 10%;">{{t "Severity"}}</th>
+                            <th style="width: 8%;">{{t "Size"}}</th>
+                            <th>{{t "Dependency Paths"}}</th>
                         </tr>
                     </thead>
                     <tbody>
@@ -1228,10 +1959,10 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
                         {{if lt $i 20}}
                         <tr>
                             <td>
-                                {{if eq $cycle.Severity "critical"}}<span style="background: #f8d7da; color: #721c24; padding: 4px 12px; border-radius: 12px; font-size: 12px; font-weight: bold;">CRITICAL</span>
-                                {{else if eq $cycle.Severity "high"}}<span style="background: #fff3cd; color: #856404; padding: 4px 12px; border-radius: 12px; font-size: 12px; font-weight: bold;">HIGH</span>
-                                {{else if eq $cycle.Severity "medium"}}<span style="background: #fff3cd; color: #856404; padding: 4px 12px; border-radius: 12px; font-size: 12px; font-weight: bold;">MEDIUM</span>
-                                {{else}}<span style="background: #d1ecf1; color: #0c5460; padding: 4px 12px; border-radius: 12px; font-size: 12px; font-weight: bold;">LOW</span>{{end}}
+                                {{if eq $cycle.Severity "critical"}}<span class="severity-pill pill-critical">{{t "CRITICAL"}}</span>
+                                {{else if eq $cycle.Severity "high"}}<span class="severity-pill pill-high">{{t "HIGH"}}</span>
+                                {{else if eq $cycle.Severity "medium"}}<span class="severity-pill pill-high">{{t "MEDIUM"}}</span>
+                                {{else}}<span class="severity-pill pill-low">{{t "LOW"}}</span>{{end}}
                             </td>
                             <td>{{$cycle.Size}}</td>
                             <td>
@@ -1242,7 +1973,10 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
                                     {{end}}
                                 {{end}}
                                 {{if gt (len $cycle.Dependencies) 5}}
-                                    <br><em style="font-size: 11px; color: #666;">... and {{sub (len $cycle.Dependencies) 5}} more paths</em>
+                                    <br><em style="font-size: 11px; color: var(--color-secondary);">{{t "... and %d more paths" (sub (len $cycle.Dependencies) 5)}}</em>
+                                {{end}}
+                                {{with $cycle.HeaviestEdge}}
+                                    <br><span style="font-size: 11px; color: var(--color-secondary);">{{if eq .Weight 1}}{{t "Heaviest edge: %s → %s (1 name)" .From .To}}{{else}}{{t "Heaviest edge: %s → %s (%d names)" .From .To .Weight}}{{end}}</span>
                                 {{end}}
                             </td>
                         </tr>
@@ -1250,7 +1984,7 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
                         {{end}}
                         {{if gt (len .System.DependencyAnalysis.CircularDependencies.CircularDependencies) 20}}
                         <tr>
-                            <td colspan="3"><em>... and {{sub (len .System.DependencyAnalysis.CircularDependencies.CircularDependencies) 20}} more circular dependencies</em></td>
+                            <td colspan="3"><em>{{t "... and %d more circular dependencies" (sub (len .System.DependencyAnalysis.CircularDependencies.CircularDependencies) 20)}}</em></td>
                         </tr>
                         {{end}}
                     </tbody>
@@ -1258,17 +1992,17 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
 
                 {{/* Core Infrastructure Modules */}}
                 {{if gt (len .System.DependencyAnalysis.CircularDependencies.CoreInfrastructure) 0}}
-                <div style="padding: 15px; background: #fefce8; border-left: 4px solid #fde68a; border-radius: 4px; margin: 20px 0;">
-                    <strong style="color: #713f12;">⚠️ Core Infrastructure Modules (appear in multiple cycles):</strong>
-                    <p style="color: #713f12; margin: 10px 0 0 0;">{{join .System.DependencyAnalysis.CircularDependencies.CoreInfrastructure ", "}}</p>
+                <div class="callout callout-warning">
+                    <strong>⚠️ {{t "Core Infrastructure Modules (appear in multiple cycles):"}}</strong>
+                    <p>{{join .System.DependencyAnalysis.CircularDependencies.CoreInfrastructure ", "}}</p>
                 </div>
                 {{end}}
 
                 {{/* Cycle Breaking Suggestions */}}
                 {{if gt (len .System.DependencyAnalysis.CircularDependencies.CycleBreakingSuggestions) 0}}
-                <div style="padding: 15px; background: #eff6ff; border-left: 4px solid #bfdbfe; border-radius: 4px; margin: 20px 0;">
-                    <strong style="color: #1e3a8a;">💡 Suggestions for Breaking Cycles:</strong>
-                    <ul style="margin: 10px 0 0 20px; color: #1e3a8a;">
+                <div class="callout callout-info">
+                    <strong>💡 {{t "Suggestions for Breaking Cycles:"}}</strong>
+                    <ul>
                         {{range .System.DependencyAnalysis.CircularDependencies.CycleBreakingSuggestions}}
                         <li>{{.}}</li>
                         {{end}}
@@ -1279,13 +2013,13 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
                 {{end}}
 
                 {{if gt (len .System.DependencyAnalysis.LongestChains) 0}}
-                <h3>Longest Dependency Chains</h3>
+                <h3>{{t "Longest Dependency Chains"}}</h3>
                 <table class="table">
                     <thead>
                         <tr>
                             <th>#</th>
-                            <th>Depth</th>
-                            <th>Path</th>
+                            <th>{{t "Depth"}}</th>
+                            <th>{{t "Path"}}</th>
                         </tr>
                     </thead>
                     <tbody>
@@ -1301,13 +2035,76 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
                     </tbody>
                 </table>
                 {{end}}
+
+                {{if .System.DependencyAnalysis.UnanalyzableImports}}
+                <h3>{{t "Unanalyzable Dynamic Imports"}}</h3>
+                <p>{{t "%d dependencies were found through dynamic imports with a literal module name. The calls below compute the module name at runtime, so their targets are missing from the graph." .System.DependencyAnalysis.DynamicDependencies}}</p>
+                <table class="table">
+                    <thead>
+                        <tr>
+                            <th>{{t "Module"}}</th>
+                            <th>{{t "Call"}}</th>
+                            <th>{{t "Location"}}</th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {{range .System.DependencyAnalysis.UnanalyzableImports}}
+                        <tr>
+                            <td>{{.Module}}</td>
+                            <td><code>{{.Function}}()</code></td>
+                            <td>{{locationLink .FilePath .Line}}</td>
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+                {{end}}
+
+                {{with .System.DependencyAnalysis.ImportInventory}}
+                {{if gt (len .Packages) 0}}
+                <h3>{{t "External Imports"}}</h3>
+                <p>{{t "%d third-party, %d standard library, %d unknown" .ThirdPartyCount .StdlibCount .UnknownCount}}{{if .ManifestFiles}} &middot; {{t "declared in %s" (join .ManifestFiles ", ")}}{{end}}</p>
+                <table class="table">
+                    <thead>
+                        <tr>
+                            <th>{{t "Package"}}</th>
+                            <th>{{t "Category"}}</th>
+                            <th>{{t "Distribution"}}</th>
+                            <th>{{t "Imports"}}</th>
+                            <th>{{t "First Location"}}</th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {{range .Packages}}
+                        <tr>
+                            <td><code>{{.Name}}</code></td>
+                            <td>{{.Category}}</td>
+                            <td>{{if .Distribution}}{{.Distribution}}{{else}}-{{end}}</td>
+                            <td>{{.UsageCount}}</td>
+                            <td>{{with index .Locations 0}}{{locationLink .FilePath .Line}}{{end}}</td>
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+                {{if .Undeclared}}
+                <div class="callout callout-danger">
+                    <strong>{{t "Imported but not declared:"}}</strong> {{join .Undeclared ", "}}
+                </div>
+                {{end}}
+                {{if .UnusedDeclared}}
+                <div class="callout callout-warning">
+                    <strong>{{t "Declared but never imported:"}}</strong>
+                    {{range $i, $dep := .UnusedDeclared}}{{if $i}}, {{end}}{{$dep.Name}}{{end}}
+                </div>
+                {{end}}
+                {{end}}
+                {{end}}
             </div>
             {{end}}
 
             {{if .System.ArchitectureAnalysis}}
             <div id="sys-arch" class="tab-content">
                 <div class="tab-header-with-score">
-                    <h2 style="margin: 0;">Architecture Validation</h2>
+                    <h2 style="margin: 0;">{{t "Architecture Validation"}}</h2>
                     <div class="score-badge-compact score-{{scoreQuality .Summary.ArchitectureScore}}">
                         {{.Summary.ArchitectureScore}}/100
                     </div>
@@ -1315,31 +2112,31 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
                 <div class="metric-grid">
                     <div class="metric-card">
                         <div class="metric-value">{{if .System.ArchitectureAnalysis.LayerAnalysis}}{{.System.ArchitectureAnalysis.LayerAnalysis.LayersAnalyzed}}{{else}}0{{end}}</div>
-                        <div class="metric-label">Layers Analyzed</div>
+                        <div class="metric-label">{{t "Layers Analyzed"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{.System.ArchitectureAnalysis.TotalRules}}</div>
-                        <div class="metric-label">Total Rules</div>
+                        <div class="metric-label">{{t "Total Rules"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{.System.ArchitectureAnalysis.TotalViolations}}</div>
-                        <div class="metric-label">Violations</div>
+                        <div class="metric-label">{{t "Violations"}}</div>
                     </div>
                     <div class="metric-card">
                         <div class="metric-value">{{printf "%.1f%%" (mul100 .System.ArchitectureAnalysis.ComplianceScore)}}</div>
-                        <div class="metric-label">Compliance</div>
+                        <div class="metric-label">{{t "Compliance"}}</div>
                     </div>
                 </div>
 
                 {{if and .System.ArchitectureAnalysis.LayerAnalysis (gt (len .System.ArchitectureAnalysis.LayerAnalysis.LayerViolations) 0)}}
-                <h3>Top Rule Violations</h3>
+                <h3>{{t "Top Rule Violations"}}</h3>
                 <table class="table">
                     <thead>
                         <tr>
-                            <th>Severity</th>
-                            <th>Rule</th>
-                            <th>From</th>
-                            <th>To</th>
+                            <th>{{t "Severity"}}</th>
+                            <th>{{t "Rule"}}</th>
+                            <th>{{t "From"}}</th>
+                            <th>{{t "To"}}</th>
                         </tr>
                     </thead>
                     <tbody>
@@ -1356,7 +2153,82 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
                     </tbody>
                 </table>
                 {{else}}
-                <p style="color: var(--color-success); font-weight: bold; margin-top: 20px;">✓ No architecture violations</p>
+                <p style="color: var(--color-success); font-weight: bold; margin-top: 20px;">✓ {{t "No architecture violations"}}</p>
+                {{end}}
+
+                {{if .System.ArchitectureAnalysis.RuleSets}}
+                <h3>{{t "Rule Sets"}}</h3>
+                <table class="table">
+                    <thead>
+                        <tr>
+                            <th>{{t "Rule Set"}}</th>
+                            <th>{{t "Scopes"}}</th>
+                            <th>{{t "Modules"}}</th>
+                            <th>{{t "Violations"}}</th>
+                            <th>{{t "Compliance"}}</th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {{range .System.ArchitectureAnalysis.RuleSets}}
+                        <tr>
+                            <td>{{.Name}}</td>
+                            <td>{{join .Scopes ", "}}</td>
+                            <td>{{.ModulesAnalyzed}}</td>
+                            <td>{{.TotalViolations}}</td>
+                            <td>{{printf "%.1f%%" (mul100 .ComplianceScore)}}</td>
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+                <table class="table">
+                    <thead>
+                        <tr>
+                            <th>{{t "Rule Set"}}</th>
+                            <th>{{t "Severity"}}</th>
+                            <th>{{t "Rule"}}</th>
+                            <th>{{t "From"}}</th>
+                            <th>{{t "To"}}</th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {{range .System.ArchitectureAnalysis.Violations}}
+                        {{if .RuleSet}}
+                        <tr>
+                            <td>{{.RuleSet}}</td>
+                            <td>{{.Severity}}</td>
+                            <td>{{.Rule}}</td>
+                            <td>{{.Module}}</td>
+                            <td>{{.Target}}</td>
+                        </tr>
+                        {{end}}
+                        {{end}}
+                    </tbody>
+                </table>
+                {{end}}
+
+                {{with .System.ArchitectureAnalysis.LayerDetection}}
+                <h3>{{t "Detected Layers"}}</h3>
+                <p style="color: var(--color-secondary);">{{tcode "No layers are configured, so they were detected from module names. Run %s to write them to your config and edit them." "pyscn arch init"}}</p>
+                <table class="table">
+                    <thead>
+                        <tr>
+                            <th>{{t "Module"}}</th>
+                            <th>{{t "Layer"}}</th>
+                            <th>{{t "Confidence"}}</th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {{range $i, $a := .Assignments}}
+                        {{if lt $i 50}}
+                        <tr>
+                            <td>{{$a.Module}}</td>
+                            <td>{{$a.Layer}}</td>
+                            <td>{{printf "%.0f%%" (mul100 $a.Confidence)}}</td>
+                        </tr>
+                        {{end}}
+                        {{end}}
+                    </tbody>
+                </table>
                 {{end}}
             </div>
             {{end}}
@@ -1364,15 +2236,316 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
 
             {{if and .Summary.CommunitiesEnabled .Communities}}
             <div id="communities" class="tab-content">
-                <h2>Module Communities</h2>
-                <p style="margin-bottom: 20px; color: #666;">Detected module communities and bridge modules coupling them</p>
+                <h2>{{t "Module Communities"}}</h2>
+                <p style="margin-bottom: 20px; color: var(--color-secondary);">{{t "Detected module communities and bridge modules coupling them"}}</p>
                 {{communitySummaryHTML .Communities}}
             </div>
             {{end}}
+
+            {{if .Workspace}}
+            <div id="workspace" class="tab-content">
+                <h2>{{t "Workspace Targets"}}</h2>
+                <p style="margin-bottom: 20px; color: var(--color-secondary);">{{t "Each target was analyzed as an independent project; the summary combines all targets"}}</p>
+                <table class="table">
+                    <thead>
+                        <tr>
+                            <th>{{t "Target"}}</th>
+                            <th>{{t "Files"}}</th>
+                            <th>{{t "Health"}}</th>
+                            <th>{{t "Complexity"}}</th>
+                            <th>{{t "Dead Code"}}</th>
+                            <th>{{t "Duplication"}}</th>
+                            <th>{{t "Coupling"}}</th>
+                            <th>{{t "Dependencies"}}</th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {{range .Workspace.Targets}}
+                        {{if .Result}}
+                        <tr>
+                            <td>{{.Path}}</td>
+                            <td>{{.Result.Summary.TotalFiles}}</td>
+                            <td>{{.Result.Summary.HealthScore}} ({{.Result.Summary.Grade}})</td>
+                            <td>{{if .Result.Summary.ComplexityEnabled}}{{.Result.Summary.ComplexityScore}}{{else}}-{{end}}</td>
+                            <td>{{if .Result.Summary.DeadCodeEnabled}}{{.Result.Summary.DeadCodeScore}}{{else}}-{{end}}</td>
+                            <td>{{if .Result.Summary.CloneEnabled}}{{.Result.Summary.DuplicationScore}}{{else}}-{{end}}</td>
+                            <td>{{if .Result.Summary.CBOEnabled}}{{.Result.Summary.CouplingScore}}{{else}}-{{end}}</td>
+                            <td>{{if .Result.Summary.DepsEnabled}}{{.Result.Summary.DependencyScore}}{{else}}-{{end}}</td>
+                        </tr>
+                        {{else}}
+                        <tr>
+                            <td>{{.Path}}</td>
+                            <td colspan="7">{{t "Failed: %s" .Error}}</td>
+                        </tr>
+                        {{end}}
+                        {{end}}
+                    </tbody>
+                </table>
+            </div>
+            {{end}}
+
+            {{if .Ownership}}
+            <div id="owners" class="tab-content">
+                <h2>{{t "Findings by Owner"}}</h2>
+                <p style="margin-bottom: 20px; color: var(--color-secondary);">{{t "Grouped by %s. Scores cover complexity and dead code." .Ownership.CodeownersFile}}</p>
+                <table class="table">
+                    <thead>
+                        <tr>
+                            <th>{{t "Owner"}}</th>
+                            <th>{{t "Files"}}</th>
+                            <th>{{t "Functions"}}</th>
+                            <th>{{t "Avg Complexity"}}</th>
+                            <th>{{t "High Complexity"}}</th>
+                            <th>{{t "Dead Code"}}</th>
+                            <th>{{t "Clone Fragments"}}</th>
+                            <th>{{t "Score"}}</th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {{range .Ownership.Owners}}
+                        <tr>
+                            <td>{{.Owner}}</td>
+                            <td>{{.Files}}</td>
+                            <td>{{.Functions}}</td>
+                            <td>{{printf "%.2f" .AverageComplexity}}</td>
+                            <td>{{.HighComplexityCount}}</td>
+                            <td>{{.DeadCodeCount}}</td>
+                            <td>{{.CloneFragments}}</td>
+                            <td>{{.HealthScore}} ({{.Grade}})</td>
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+
+                {{range .Ownership.Owners}}
+                <h3>{{.Owner}}</h3>
+                <table class="table">
+                    <thead>
+                        <tr>
+                            <th>{{t "Package"}}</th>
+                            <th>{{t "Files"}}</th>
+                            <th>{{t "Functions"}}</th>
+                            <th>{{t "Avg Complexity"}}</th>
+                            <th>{{t "High Complexity"}}</th>
+                            <th>{{t "Dead Code"}}</th>
+                            <th>{{t "Score"}}</th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {{range .Packages}}
+                        <tr>
+                            <td>{{.Package}}</td>
+                            <td>{{.Files}}</td>
+                            <td>{{.Functions}}</td>
+                            <td>{{printf "%.2f" .AverageComplexity}}</td>
+                            <td>{{.HighComplexityCount}}</td>
+                            <td>{{.DeadCodeCount}}</td>
+                            <td>{{.HealthScore}} ({{.Grade}})</td>
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+                {{end}}
+            </div>
+            {{end}}
+
+            {{if .Hotspots}}
+            <div id="hotspots" class="tab-content">
+                <h2>{{t "Hotspots"}}</h2>
+                <p style="margin-bottom: 20px; color: var(--color-secondary);">{{t "Files ranked by total complexity × commits in the last %d days (since %s). Complex code that changes often is where refactoring pays off first." .Hotspots.WindowDays (.Hotspots.Since.Format "2006-01-02")}}</p>
+                {{if .Hotspots.Files}}
+                <table class="table">
+                    <thead>
+                        <tr>
+                            <th>{{t "File"}}</th>
+                            <th>{{t "Commits"}}</th>
+                            <th>{{t "Functions"}}</th>
+                            <th>{{t "Total Complexity"}}</th>
+                            <th>{{t "Most Complex Function"}}</th>
+                            <th>{{t "Score"}}</th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {{range $i, $h := .Hotspots.Files}}
+                        {{if lt $i 30}}
+                        <tr>
+                            <td>{{fileLink $h.FilePath 0 0}}</td>
+                            <td>{{$h.Commits}}</td>
+                            <td>{{$h.Functions}}</td>
+                            <td>{{$h.TotalComplexity}}</td>
+                            <td>{{$h.MostComplexFunction}} ({{$h.MaxComplexity}})</td>
+                            <td>{{$h.Score}} ({{printf "%.0f" (percent $h.RelativeScore)}}%)</td>
+                        </tr>
+                        {{end}}
+                        {{end}}
+                    </tbody>
+                </table>
+                {{if gt (len .Hotspots.Files) 30}}
+                <p style="color: var(--color-secondary); margin-top: 10px;">{{t "Showing top %d of %d files" 30 (len .Hotspots.Files)}}</p>
+                {{end}}
+                {{else}}
+                <p>{{t "No analyzed file changed in this window."}}</p>
+                {{end}}
+            </div>
+            {{end}}
+
+            {{if .Packages}}
+            <div id="packages" class="tab-content">
+                <h2>{{t "Packages"}}</h2>
+                <p style="margin-bottom: 20px; color: var(--color-secondary);">{{t "Results rolled up by directory. Each row covers its subdirectories; click a directory to collapse it. Scores cover complexity and dead code."}}</p>
+                <table class="table package-tree">
+                    <thead>
+                        <tr>
+                            <th>{{t "Directory"}}</th>
+                            <th>{{t "Files"}}</th>
+                            {{if $.Complexity}}<th>{{t "Avg Complexity"}}</th><th>{{t "Max Complexity"}}</th><th>{{t "High Complexity"}}</th>{{end}}
+                            {{if $.DeadCode}}<th>{{t "Dead Code"}}</th>{{end}}
+                            {{if $.Clone}}<th>{{t "Duplication"}}</th>{{end}}
+                            {{if $.CBO}}<th>{{t "Avg CBO"}}</th><th>{{t "CBO Low / Medium / High"}}</th>{{end}}
+                            {{if $.LCOM}}<th>{{t "Avg LCOM4"}}</th><th>{{t "High LCOM"}}</th>{{end}}
+                            {{if $.Documentation}}<th>{{t "Missing Docstrings"}}</th>{{end}}
+                            {{if $.TypeCoverage}}<th>{{t "Typed"}}</th>{{end}}
+                            <th>{{t "Score"}}</th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {{range .Packages}}
+                        <tr data-package="{{.Path}}" data-parent="{{.Parent}}">
+                            <td style="padding-left: {{add 12 (mul .Depth 20)}}px; cursor: pointer;" onclick="togglePackage(this.parentElement)" title="{{.Path}}">{{.Name}}</td>
+                            <td>{{.Files}}</td>
+                            {{if $.Complexity}}<td>{{printf "%.2f" .AverageComplexity}}</td><td>{{.MaxComplexity}}</td><td>{{.HighComplexityCount}}</td>{{end}}
+                            {{if $.DeadCode}}<td>{{.DeadCodeCount}}</td>{{end}}
+                            {{if $.Clone}}<td>{{printf "%.1f" .DuplicationPercentage}}%</td>{{end}}
+                            {{if $.CBO}}<td>{{printf "%.2f" .AverageCBO}}</td><td>{{.LowRiskClasses}} / {{.MediumRiskClasses}} / {{.HighRiskClasses}}</td>{{end}}
+                            {{if $.LCOM}}<td>{{printf "%.2f" .AverageLCOM}}</td><td>{{.HighLCOMCount}}</td>{{end}}
+                            {{if $.Documentation}}<td>{{with .MissingDocstrings}}{{.}}{{end}}</td>{{end}}
+                            {{if $.TypeCoverage}}<td>{{with .TypeAnnotationCoverage}}{{formatPercent .}}{{end}}</td>{{end}}
+                            <td>{{.HealthScore}} ({{.Grade}})</td>
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+            </div>
+            {{end}}
+
+            {{with $treemap}}
+            <div id="treemap" class="tab-content">
+                <h2>{{t "Treemap"}}</h2>
+                <p style="margin-bottom: 20px; color: var(--color-secondary);">{{t "Files grouped by directory and sized by source lines. Hover a file for its metrics."}}</p>
+                <div class="treemap-controls">
+                    {{if .Duplication}}
+                    <span>{{t "Color by"}}</span>
+                    <button type="button" class="active" data-metric="complexity" onclick="colorTreemap('complexity')">{{t "Complexity"}}</button>
+                    <button type="button" data-metric="duplication" onclick="colorTreemap('duplication')">{{t "Duplication"}}</button>
+                    {{end}}
+                    <span>0</span><span class="treemap-legend"></span>
+                    <span id="treemap-scale-complexity">{{t "complexity %d+" .ComplexityScale}}</span>
+                    {{if .Duplication}}<span id="treemap-scale-duplication" hidden>{{t "%d%%+ duplicated" .DuplicationScale}}</span>{{end}}
+                </div>
+                <svg class="treemap" viewBox="0 0 {{.Width}} {{.Height}}" role="img" aria-label="{{t "Treemap"}}">
+                    {{range .Packages}}
+                    <g>
+                        <title>{{.Path}}</title>
+                        <rect class="treemap-package" x="{{printf "%.1f" .X}}" y="{{printf "%.1f" .Y}}" width="{{printf "%.1f" .Width}}" height="{{printf "%.1f" .Height}}"></rect>
+                        {{if .ShowLabel}}<text class="treemap-package-label" x="{{printf "%.1f" .LabelX}}" y="{{printf "%.1f" .LabelY}}">{{.Label}}</text>{{end}}
+                    </g>
+                    {{end}}
+                    {{range .Files}}
+                    <g>
+                        <title>{{.Path}}
+{{t "Lines"}}: {{.Lines}}
+{{t "Functions"}}: {{.Functions}}
+{{t "Avg Complexity"}}: {{printf "%.2f" .AverageComplexity}}
+{{t "Max Complexity"}}: {{.MaxComplexity}}{{if $treemap.Duplication}}
+{{t "Duplication"}}: {{printf "%.1f" .DuplicationPercentage}}%{{end}}</title>
+                        <rect class="treemap-file" x="{{printf "%.1f" .X}}" y="{{printf "%.1f" .Y}}" width="{{printf "%.1f" .Width}}" height="{{printf "%.1f" .Height}}" fill="{{.ComplexityColor}}" data-complexity="{{.ComplexityColor}}" data-duplication="{{.DuplicationColor}}"></rect>
+                        {{if .ShowLabel}}<text class="treemap-file-label" x="{{printf "%.1f" .LabelX}}" y="{{printf "%.1f" .LabelY}}">{{.Label}}</text>{{end}}
+                    </g>
+                    {{end}}
+                </svg>
+            </div>
+            {{end}}
         </div>
+
+        {{with .Vendored}}
+        <details class="manifest">
+            <summary>{{t "Vendored code (%d files, not analyzed)" $.Summary.VendoredFiles}}</summary>
+            <p style="margin: 10px 0; color: var(--color-secondary);">{{tcode "Left out of the analyses and the scores. Run with %s to analyze it." "--include-vendored"}}</p>
+            <table class="table">
+                <thead>
+                    <tr><th>{{t "Path"}}</th><th>{{t "Files"}}</th><th>{{t "Detected by"}}</th></tr>
+                </thead>
+                <tbody>
+                    {{range .}}
+                    <tr><td>{{.Path}}</td><td>{{.Files}}</td><td>{{vendoredReason .Reason}}</td></tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </details>
+        {{end}}
+
+        {{with .Manifest}}
+        <details class="manifest">
+            <summary>{{t "Analysis manifest"}}</summary>
+            <table class="table">
+                <tbody>
+                    <tr><th>pyscn</th><td>{{.PyscnVersion}} ({{.GoVersion}}, {{.Platform}})</td></tr>
+                    <tr><th>{{t "Targets"}}</th><td>{{join .Targets ", "}}</td></tr>
+                    <tr><th>{{t "Files"}}</th><td>{{.FileCount}}</td></tr>
+                    <tr><th>{{t "Configuration"}}</th><td>{{if .ConfigFile}}{{.ConfigFile}}<br><code>{{.ConfigHash}}</code>{{else}}{{t "defaults"}}{{end}}</td></tr>
+                    {{with .Thresholds}}<tr><th>{{t "Thresholds"}}</th><td>{{riskThresholds .}}</td></tr>{{end}}
+                    {{with .Git}}<tr><th>{{t "Git commit"}}</th><td><code>{{.Commit}}</code>{{if .Branch}} ({{.Branch}}){{end}}{{if .Dirty}} {{t "with uncommitted changes"}}{{end}}</td></tr>{{end}}
+                    {{if .Flags}}<tr><th>{{t "Flags"}}</th><td>{{range $name, $value := .Flags}}<code>--{{$name}}={{$value}}</code> {{end}}</td></tr>{{end}}
+                    <tr><th>{{t "Analyzers"}}</th><td>{{range $name, $version := .Analyzers}}{{$name}} v{{$version}} {{end}}</td></tr>
+                </tbody>
+            </table>
+        </details>
+        {{end}}
     </div>
 
     <script>
+        // togglePackage collapses or expands the subdirectories of a package row
+        function togglePackage(row) {
+            const collapse = !row.classList.contains('collapsed');
+            row.classList.toggle('collapsed', collapse);
+            const hide = (parent) => {
+                document.querySelectorAll('tr[data-parent="' + CSS.escape(parent) + '"]').forEach(child => {
+                    child.style.display = collapse ? 'none' : '';
+                    child.classList.toggle('collapsed', collapse);
+                    hide(child.dataset.package);
+                });
+            };
+            hide(row.dataset.package);
+        }
+
+        function toggleTheme() {
+            const theme = document.documentElement.dataset.theme === 'dark' ? 'light' : 'dark';
+            document.documentElement.dataset.theme = theme;
+            try { localStorage.setItem('pyscn-report-theme', theme); } catch (e) {}
+        }
+
+        // Printing shows every tab, so open the collapsed sections too and
+        // close them again afterwards
+        let openedForPrint = [];
+        window.addEventListener('beforeprint', () => {
+            openedForPrint = Array.from(document.querySelectorAll('details:not([open])'));
+            openedForPrint.forEach(d => d.open = true);
+        });
+        window.addEventListener('afterprint', () => {
+            openedForPrint.forEach(d => d.open = false);
+            openedForPrint = [];
+        });
+
+        // colorTreemap colors the treemap files by complexity or duplication
+        function colorTreemap(metric) {
+            document.querySelectorAll('.treemap-file').forEach(rect => rect.setAttribute('fill', rect.dataset[metric]));
+            document.querySelectorAll('.treemap-controls button').forEach(btn => btn.classList.toggle('active', btn.dataset.metric === metric));
+            ['complexity', 'duplication'].forEach(m => {
+                const scale = document.getElementById('treemap-scale-' + m);
+                if (scale) { scale.hidden = m !== metric; }
+            });
+        }
+
         function showTab(tabName, el) {
             // Hide all tabs
             const tabs = document.querySelectorAll('.tab-content');
@@ -1391,3 +2564,9 @@ const analyzeHTMLTemplate = `<!DOCTYPE html>
     </script>
 </body>
 </html>`
+
+// formatScoreInput prints a score input rounded to three decimals without
+// trailing zeros
+func formatScoreInput(value float64) string {
+	return strconv.FormatFloat(math.Round(value*1000)/1000, 'f', -1, 64)
+}
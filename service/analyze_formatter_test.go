@@ -3,11 +3,14 @@ package service
 import (
 	"bytes"
 	"encoding/json"
+	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/i18n"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
@@ -298,7 +301,7 @@ func TestAnalyzeFormatter_WriteHTML_ShowsCloneGroupContentWhenEnabled(t *testing
 		Request: &domain.CloneRequest{ShowContent: domain.BoolPtr(true)},
 		CloneGroups: []*domain.CloneGroup{
 			{
-				ID:         1,
+				ID:         "1",
 				Type:       domain.Type2Clone,
 				Similarity: 0.93,
 				Size:       2,
@@ -325,7 +328,9 @@ func TestAnalyzeFormatter_WriteHTML_ShowsCloneGroupContentWhenEnabled(t *testing
 	output := buf.String()
 	assert.Contains(t, output, "Code Preview")
 	assert.Contains(t, output, "def alpha():")
-	assert.Contains(t, output, "def beta():")
+	assert.Contains(t, output, "Diff against clone 1")
+	assert.Contains(t, output, `-def <del>alpha</del>():`)
+	assert.Contains(t, output, `+def <ins>beta</ins>():`)
 }
 
 func TestAnalyzeFormatter_WriteHTML_ShowsClonePairContentWhenEnabled(t *testing.T) {
@@ -361,10 +366,10 @@ func TestAnalyzeFormatter_WriteHTML_ShowsClonePairContentWhenEnabled(t *testing.
 	require.NoError(t, err)
 
 	output := buf.String()
-	assert.Contains(t, output, "Clone 1 Preview")
-	assert.Contains(t, output, "Clone 2 Preview")
-	assert.Contains(t, output, "def alpha():")
-	assert.Contains(t, output, "def beta():")
+	assert.Contains(t, output, "Diff from clone 1 to clone 2")
+	assert.Contains(t, output, `-def <del>alpha</del>():`)
+	assert.Contains(t, output, `+def <ins>beta</ins>():`)
+	assert.Contains(t, output, `<span class="diff-line">     return 1</span>`)
 }
 
 func TestAnalyzeFormatter_WriteHTML_HidesCloneContentWhenDisabled(t *testing.T) {
@@ -379,7 +384,7 @@ func TestAnalyzeFormatter_WriteHTML_HidesCloneContentWhenDisabled(t *testing.T)
 		Request: &domain.CloneRequest{ShowContent: domain.BoolPtr(false)},
 		CloneGroups: []*domain.CloneGroup{
 			{
-				ID:         1,
+				ID:         "1",
 				Type:       domain.Type2Clone,
 				Similarity: 0.93,
 				Size:       2,
@@ -498,3 +503,367 @@ func TestAnalyzeFormatter_WriteHTML_ScoreQuality(t *testing.T) {
 		})
 	}
 }
+
+func TestAnalyzeFormatter_WriteHTML_ThemeAndPrintStyles(t *testing.T) {
+	formatter := NewAnalyzeFormatter()
+	var buf bytes.Buffer
+
+	err := formatter.Write(createTestAnalyzeResponse(), domain.OutputFormatHTML, &buf)
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, `onclick="toggleTheme()"`)
+	assert.Contains(t, output, "localStorage.setItem('pyscn-report-theme'")
+	assert.Contains(t, output, `:root[data-theme="dark"]`)
+	assert.Contains(t, output, "@media print")
+	// Inline colors would not follow the theme
+	assert.NotRegexp(t, `style="[^"]*(background|color): #`, output)
+}
+
+func TestAnalyzeFormatter_WriteJapanese(t *testing.T) {
+	formatter := NewAnalyzeFormatter()
+	formatter.SetLanguage("ja")
+
+	t.Run("html", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := formatter.Write(createTestAnalyzeResponse(), domain.OutputFormatHTML, &buf)
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, `<html lang="ja">`)
+		assert.Contains(t, output, "pyscn 解析レポート")
+		assert.Contains(t, output, "複雑度の解析")
+		assert.NotContains(t, output, "Analysis Summary")
+	})
+
+	t.Run("text", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := formatter.Write(createTestAnalyzeResponse(), domain.OutputFormatText, &buf)
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, "複雑度の解析")
+		assert.Contains(t, output, "総関数数")
+		assert.NotContains(t, output, "COMPLEXITY ANALYSIS")
+	})
+
+	t.Run("json is not translated", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := formatter.Write(createTestAnalyzeResponse(), domain.OutputFormatJSON, &buf)
+		require.NoError(t, err)
+		assert.NotContains(t, buf.String(), "複雑度")
+	})
+}
+
+func TestAnalyzeHTMLTemplate_MessagesHaveJapaneseTranslations(t *testing.T) {
+	calls := regexp.MustCompile(`\{\{-?\s*(?:t|tcode)\s+"((?:[^"\\]|\\.)*)"`)
+	matches := calls.FindAllStringSubmatch(analyzeHTMLTemplate, -1)
+	require.NotEmpty(t, matches)
+	for _, match := range matches {
+		message, err := strconv.Unquote(`"` + match[1] + `"`)
+		require.NoError(t, err)
+		assert.True(t, i18n.Has("ja", message), "missing ja translation for %q", message)
+	}
+}
+
+func TestAnalyzeFormatter_WriteHTML_ShowsOwnershipTab(t *testing.T) {
+	formatter := NewAnalyzeFormatter()
+	response := createTestAnalyzeResponse()
+	response.Ownership = &domain.OwnershipReport{
+		CodeownersFile: "/repo/.github/CODEOWNERS",
+		Owners: []domain.OwnerSummary{{
+			Owner:       "@org/payments",
+			Files:       3,
+			HealthScore: 72,
+			Grade:       "C",
+			Packages: []domain.OwnerPackageScore{{
+				Package:     "payments/api",
+				Files:       3,
+				HealthScore: 72,
+				Grade:       "C",
+			}},
+		}},
+	}
+	var buf bytes.Buffer
+
+	err := formatter.Write(response, domain.OutputFormatHTML, &buf)
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, "showTab('owners', this)")
+	assert.Contains(t, output, "@org/payments")
+	assert.Contains(t, output, "payments/api")
+}
+
+func TestAnalyzeFormatter_WriteHTML_ShowsPackageCoverage(t *testing.T) {
+	formatter := NewAnalyzeFormatter()
+	response := createTestAnalyzeResponse()
+	response.Documentation = &domain.DocumentationResponse{}
+	response.TypeCoverage = &domain.TypeCoverageResponse{}
+	missing, coverage := 3, 62.5
+	response.Packages = []domain.PackageSummary{{
+		Path:                   "payments",
+		Name:                   "payments",
+		Files:                  2,
+		MissingDocstrings:      &missing,
+		TypeAnnotationCoverage: &coverage,
+	}}
+	var buf bytes.Buffer
+
+	err := formatter.Write(response, domain.OutputFormatHTML, &buf)
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, "<td>3</td>")
+	assert.Contains(t, output, "<td>62.5%</td>")
+}
+
+func TestAnalyzeFormatter_WriteHTML_ShowsScoreExplanations(t *testing.T) {
+	formatter := NewAnalyzeFormatter()
+	response := createTestAnalyzeResponse()
+	response.Summary.Explanations = []domain.ScoreExplanation{{
+		Category:   "duplication",
+		Inputs:     []domain.ScoreInput{{Name: "code_duplication_percentage", Value: 12.3456}},
+		Formula:    "min(20, duplication / 30% × 20)",
+		MaxPenalty: 20,
+		Penalty:    8,
+		Score:      60,
+	}}
+	var buf bytes.Buffer
+
+	err := formatter.Write(response, domain.OutputFormatHTML, &buf)
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, `<details class="score-explanations">`)
+	assert.Contains(t, output, "<code>code_duplication_percentage</code> = 12.346")
+	assert.Contains(t, output, "−8 of 20")
+}
+
+func TestAnalyzeFormatter_WriteHTML_ShowsWorkspaceTargets(t *testing.T) {
+	formatter := NewAnalyzeFormatter()
+	response := createTestAnalyzeResponse()
+	response.Workspace = &domain.WorkspaceReport{
+		Targets: []domain.WorkspaceTarget{
+			{Path: "svc-a/", Result: createTestAnalyzeResponse()},
+			{Path: "svc-b/", Error: "no Python files found in the specified paths"},
+		},
+	}
+	var buf bytes.Buffer
+
+	err := formatter.Write(response, domain.OutputFormatHTML, &buf)
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, "showTab('workspace', this)")
+	assert.Contains(t, output, "svc-a/")
+	assert.Contains(t, output, "Failed: no Python files found")
+}
+
+func TestAnalyzeFormatter_WriteHTML_LinksFileReferences(t *testing.T) {
+	linker, err := NewSourceLinker("https://github.com/org/repo/blob/main/{relpath}#L{line}-L{endline}", "/repo")
+	require.NoError(t, err)
+
+	formatter := NewAnalyzeFormatter()
+	formatter.SetSourceLinker(linker)
+	response := createTestAnalyzeResponse()
+	response.Complexity.Functions[0].FilePath = "/repo/pkg/test.py"
+	response.Complexity.Functions[0].StartLine = 10
+	response.Complexity.Functions[0].EndLine = 24
+	var buf bytes.Buffer
+
+	require.NoError(t, formatter.Write(response, domain.OutputFormatHTML, &buf))
+	assert.Contains(t, buf.String(),
+		`<a class="source-link" href="https://github.com/org/repo/blob/main/pkg/test.py#L10-L24">/repo/pkg/test.py</a>`)
+}
+
+func TestAnalyzeFormatter_WriteHTML_PlainFileReferencesWithoutLinker(t *testing.T) {
+	formatter := NewAnalyzeFormatter()
+	var buf bytes.Buffer
+
+	require.NoError(t, formatter.Write(createTestAnalyzeResponse(), domain.OutputFormatHTML, &buf))
+	assert.Contains(t, buf.String(), "<td>test.py</td>")
+	assert.NotContains(t, buf.String(), `class="source-link"`)
+}
+
+func TestAnalyzeFormatter_WriteHTML_ShowsComplexityTrend(t *testing.T) {
+	formatter := NewAnalyzeFormatter()
+	response := createTestAnalyzeResponse()
+	var buf bytes.Buffer
+
+	require.NoError(t, formatter.Write(response, domain.OutputFormatHTML, &buf))
+	assert.NotContains(t, buf.String(), "<th>Trend</th>")
+
+	response.Summary.HistoryEnabled = true
+	response.Complexity.Functions[0].History = []int{9, 12, 15}
+	buf.Reset()
+	require.NoError(t, formatter.Write(response, domain.OutputFormatHTML, &buf))
+	assert.Contains(t, buf.String(), "<th>Trend</th>")
+	assert.Contains(t, buf.String(), "Complexity over the last 3 runs: 9 → 12 → 15")
+}
+
+func TestAnalyzeFormatter_WritesVendoredAppendix(t *testing.T) {
+	formatter := NewAnalyzeFormatter()
+	response := createTestAnalyzeResponse()
+	response.Vendored = []domain.VendoredCode{
+		{Path: "src/_compat", Reason: domain.VendoredReasonMarker, Files: 2},
+		{Path: "vendor", Reason: domain.VendoredReasonLayout, Files: 14},
+	}
+	response.Summary.VendoredFiles = 16
+
+	var text bytes.Buffer
+	require.NoError(t, formatter.Write(response, domain.OutputFormatText, &text))
+	assert.Contains(t, text.String(), "VENDORED CODE (NOT ANALYZED)")
+	assert.Contains(t, text.String(), "14 file(s), vendoring directory")
+	assert.Contains(t, text.String(), "2 file(s), marked # vendored")
+
+	var html bytes.Buffer
+	require.NoError(t, formatter.Write(response, domain.OutputFormatHTML, &html))
+	assert.Contains(t, html.String(), "Vendored code (16 files, not analyzed)")
+	assert.Contains(t, html.String(), "<td>src/_compat</td><td>2</td><td>marked # vendored</td>")
+}
+
+func TestAnalyzeFormatter_WritesManifest(t *testing.T) {
+	response := createTestAnalyzeResponse()
+	response.Manifest = &domain.AnalysisManifest{
+		PyscnVersion: "1.2.3",
+		GoVersion:    "go1.25.5",
+		Platform:     "linux/amd64",
+		ConfigFile:   "/repo/.pyscn.toml",
+		ConfigHash:   "sha256:abc",
+		Targets:      []string{"src/"},
+		FileCount:    3,
+		Git:          &domain.GitProvenance{Commit: "3f2a9c1", Dirty: true},
+		Analyzers:    map[string]string{"complexity": "1"},
+	}
+	formatter := NewAnalyzeFormatter()
+
+	var text bytes.Buffer
+	require.NoError(t, formatter.Write(response, domain.OutputFormatText, &text))
+	assert.Contains(t, text.String(), "MANIFEST")
+	assert.Contains(t, text.String(), "/repo/.pyscn.toml sha256:abc")
+	assert.Contains(t, text.String(), "3f2a9c1 (dirty)")
+
+	var csv bytes.Buffer
+	require.NoError(t, formatter.Write(response, domain.OutputFormatCSV, &csv))
+	assert.Contains(t, csv.String(), "Pyscn Version,1.2.3\n")
+	assert.Contains(t, csv.String(), "Git Commit,3f2a9c1\n")
+
+	var html bytes.Buffer
+	require.NoError(t, formatter.Write(response, domain.OutputFormatHTML, &html))
+	assert.Contains(t, html.String(), "Analysis manifest")
+	assert.Contains(t, html.String(), "<code>3f2a9c1</code> with uncommitted changes")
+	assert.Contains(t, html.String(), "complexity v1")
+}
+
+func TestAnalyzeFormatter_WritesConsolidationTargets(t *testing.T) {
+	response := createTestAnalyzeResponse()
+	response.Clone.CloneGroups = []*domain.CloneGroup{{
+		ID:               "cg-1",
+		ExtractionTarget: &domain.CloneExtractionTarget{Package: "app.api", Modules: []string{"app.api.a", "app.api.b"}, Rationale: "lowest common package of app.api.a, app.api.b"},
+	}}
+	response.Clone.Consolidation = []*domain.CloneConsolidation{{Package: "app.api", GroupIDs: []string{"cg-1"}, Fragments: 2, DuplicateLines: 12}}
+	formatter := NewAnalyzeFormatter()
+
+	var text bytes.Buffer
+	require.NoError(t, formatter.Write(response, domain.OutputFormatText, &text))
+	assert.Contains(t, text.String(), "Consolidation Targets")
+	assert.Contains(t, text.String(), "1 group(s), 12 duplicate lines (cg-1)")
+
+	var html bytes.Buffer
+	require.NoError(t, formatter.Write(response, domain.OutputFormatHTML, &html))
+	assert.Contains(t, html.String(), "Consolidation Targets")
+	assert.Contains(t, html.String(), "Extract to <code>app.api</code>")
+}
+
+func TestAnalyzeFormatter_WritesHotspots(t *testing.T) {
+	response := createTestAnalyzeResponse()
+	response.Hotspots = &domain.HotspotReport{
+		WindowDays: 90,
+		Since:      time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		Files: []domain.FileHotspot{{
+			FilePath: "app/parser.py", Commits: 12, Functions: 3, TotalComplexity: 40,
+			MaxComplexity: 25, MostComplexFunction: "parse", Score: 480, RelativeScore: 1,
+		}},
+	}
+	formatter := NewAnalyzeFormatter()
+
+	var text bytes.Buffer
+	require.NoError(t, formatter.Write(response, domain.OutputFormatText, &text))
+	assert.Contains(t, text.String(), "HOTSPOTS")
+	assert.Contains(t, text.String(), "90 days (since 2024-04-01)")
+	assert.Contains(t, text.String(), "score 480 (complexity 40 × 12 commits), most complex: parse (25)")
+
+	var html bytes.Buffer
+	require.NoError(t, formatter.Write(response, domain.OutputFormatHTML, &html))
+	assert.Contains(t, html.String(), "showTab('hotspots', this)")
+	assert.Contains(t, html.String(), "parse (25)")
+	assert.Contains(t, html.String(), "480 (100%)")
+}
+
+func TestAnalyzeFormatter_WriteText_ShowsCloneDiffsWhenContentEnabled(t *testing.T) {
+	formatter := NewAnalyzeFormatter()
+	response := createTestAnalyzeResponse()
+	response.Summary.CloneEnabled = true
+	response.Clone = &domain.CloneResponse{
+		Request: &domain.CloneRequest{ShowContent: domain.BoolPtr(true)},
+		CloneGroups: []*domain.CloneGroup{
+			{
+				ID:         "g1",
+				Type:       domain.Type2Clone,
+				Similarity: 0.93,
+				Clones: []*domain.Clone{
+					{Location: &domain.CloneLocation{FilePath: "alpha.py", StartLine: 1, EndLine: 2}, Content: "def alpha():\n    return 1"},
+					{Location: &domain.CloneLocation{FilePath: "beta.py", StartLine: 4, EndLine: 5}, Content: "def beta():\n    return 1"},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, formatter.Write(response, domain.OutputFormatText, &buf))
+
+	output := buf.String()
+	assert.Contains(t, output, "Clone Diffs")
+	assert.Contains(t, output, "--- alpha.py:1:0-2:0")
+	assert.Contains(t, output, "+++ beta.py:4:0-5:0")
+	assert.Contains(t, output, "-def alpha():")
+	assert.Contains(t, output, "+def beta():")
+}
+
+func TestAnalyzeFormatter_WritesTreemap(t *testing.T) {
+	response := createTestAnalyzeResponse()
+	response.Complexity.RawMetrics = []domain.RawMetrics{
+		{FilePath: "app/test.py", SLOC: 120, TotalLines: 150},
+		{FilePath: "app/util/helpers.py", SLOC: 80, TotalLines: 90},
+	}
+	formatter := NewAnalyzeFormatter()
+
+	var html bytes.Buffer
+	require.NoError(t, formatter.Write(response, domain.OutputFormatHTML, &html))
+	output := html.String()
+	assert.Contains(t, output, "showTab('treemap', this)")
+	assert.Contains(t, output, `<svg class="treemap" viewBox="0 0 1000 600"`)
+	assert.Contains(t, output, `<title>app/util</title>`)
+	assert.Contains(t, output, `data-duplication="hsl(120, 65%, 50%)"`)
+	assert.Contains(t, output, "onclick=\"colorTreemap('duplication')\"")
+
+	// Without raw metrics there is nothing to size the files by
+	html.Reset()
+	require.NoError(t, formatter.Write(createTestAnalyzeResponse(), domain.OutputFormatHTML, &html))
+	assert.NotContains(t, html.String(), "showTab('treemap', this)")
+}
+
+func TestAnalyzeFormatter_WritesCloneHeatmap(t *testing.T) {
+	response := createTestAnalyzeResponse()
+	pairs, groups, fileLines := similarityMatrixFixture()
+	response.Clone.SimilarityMatrix = buildCloneSimilarityMatrix(pairs, groups, fileLines, domain.CloneReportGroupByFile)
+
+	var html bytes.Buffer
+	require.NoError(t, NewAnalyzeFormatter().Write(response, domain.OutputFormatHTML, &html))
+	output := html.String()
+	assert.Contains(t, output, `<table class="clone-heatmap">`)
+	assert.Contains(t, output, `<th title="pkg/a.py (100 lines)">1. a.py</th>`)
+	assert.Contains(t, output, `<td class="diagonal" style="background: #dc2626a8;" title="pkg/a.py ↔ pkg/a.py: 30.0%"></td>`)
+	assert.Contains(t, output, `title="pkg/b.py ↔ other/c.py: 0.0%"></td>`)
+}
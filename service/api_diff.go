@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/analyzer"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// DiffAPIBetweenRevisions compares the public API of two git revisions.
+// Only the public modules that differ between them are parsed. Paths
+// restrict the comparison to files and directories, as git pathspecs, and
+// are relative to the current directory.
+func DiffAPIBetweenRevisions(ctx context.Context, oldRevision, newRevision string, paths []string) (*domain.APIDiff, error) {
+	args := []string{"-c", "core.quotePath=false", "diff", "--name-only", "--relative", "--no-renames", "-z", oldRevision, newRevision, "--"}
+	out, err := exec.CommandContext(ctx, "git", append(args, paths...)...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files changed between %s and %s: %w", oldRevision, newRevision, gitError(err))
+	}
+
+	diff := &domain.APIDiff{OldRevision: oldRevision, NewRevision: newRevision, Changes: []domain.APIChange{}}
+	for _, path := range strings.Split(string(out), "\x00") {
+		if path == "" || !analyzer.IsPublicAPIModule(path) {
+			continue
+		}
+		oldSurface, err := apiSurfaceAtRevision(ctx, oldRevision, path)
+		if err != nil {
+			return nil, err
+		}
+		newSurface, err := apiSurfaceAtRevision(ctx, newRevision, path)
+		if err != nil {
+			return nil, err
+		}
+		diff.Changes = append(diff.Changes, analyzer.DiffAPISurfaces(oldSurface, newSurface)...)
+	}
+	diff.Summarize()
+	return diff, nil
+}
+
+// apiSurfaceAtRevision returns the public API of a file at a revision, an
+// empty one when the file does not exist there
+func apiSurfaceAtRevision(ctx context.Context, revision, path string) (*analyzer.APISurface, error) {
+	source, err := exec.CommandContext(ctx, "git", "show", revision+":./"+path).Output()
+	if err != nil {
+		return analyzer.ExtractAPISurface(path, nil), nil
+	}
+	result, err := parser.New().Parse(ctx, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s at %s: %w", path, revision, err)
+	}
+	return analyzer.ExtractAPISurface(path, result), nil
+}
+
+// describeAPIChange describes a change in words, quoting code with quote
+func describeAPIChange(change domain.APIChange, quote func(string) string) string {
+	orNone := func(s string) string {
+		if s == "" {
+			return "none"
+		}
+		return quote(s)
+	}
+	param := quote(change.Parameter)
+	switch change.Kind {
+	case domain.APISymbolAdded:
+		return string(change.Symbol) + " added"
+	case domain.APISymbolRemoved:
+		return string(change.Symbol) + " removed"
+	case domain.APIAsyncChanged:
+		if change.New == "async" {
+			return "became async"
+		}
+		return "is no longer async"
+	case domain.APIBasesChanged:
+		return fmt.Sprintf("base classes changed from %s to %s", orNone(change.Old), orNone(change.New))
+	case domain.APIParameterAdded:
+		if change.Bump == domain.SemverMajor {
+			return fmt.Sprintf("required parameter %s added", param)
+		}
+		return fmt.Sprintf("optional parameter %s added", param)
+	case domain.APIParameterRemoved:
+		return fmt.Sprintf("parameter %s removed", param)
+	case domain.APIParameterMoved:
+		return fmt.Sprintf("parameter %s moved from position %s to %s", param, change.Old, change.New)
+	case domain.APIParameterKindChanged:
+		return fmt.Sprintf("parameter %s changed from %s to %s", param, change.Old, change.New)
+	case domain.APIDefaultAdded:
+		return fmt.Sprintf("parameter %s got default %s", param, quote(change.New))
+	case domain.APIDefaultRemoved:
+		return fmt.Sprintf("parameter %s lost its default %s", param, quote(change.Old))
+	case domain.APIDefaultChanged:
+		return fmt.Sprintf("default of %s changed from %s to %s", param, quote(change.Old), quote(change.New))
+	case domain.APIAnnotationChanged:
+		return fmt.Sprintf("annotation of %s changed from %s to %s", param, orNone(change.Old), orNone(change.New))
+	case domain.APIReturnAnnotationChanged:
+		return fmt.Sprintf("return annotation changed from %s to %s", orNone(change.Old), orNone(change.New))
+	}
+	return string(change.Kind)
+}
+
+// WriteAPIDiffText writes the API changes grouped by module, followed by
+// the version bump they call for
+func WriteAPIDiffText(w io.Writer, diff *domain.APIDiff) {
+	if len(diff.Changes) == 0 {
+		fmt.Fprintf(w, "No public API changes between %s and %s.\n", diff.OldRevision, diff.NewRevision)
+		return
+	}
+
+	plain := func(s string) string { return s }
+	module := ""
+	for _, change := range diff.Changes {
+		if change.Module != module || module == "" {
+			if module != "" {
+				fmt.Fprintln(w)
+			}
+			module = change.Module
+			fmt.Fprintln(w, module)
+		}
+		fmt.Fprintf(w, "  %-5s  %s: %s\n", change.Bump, change.Name, describeAPIChange(change, plain))
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%d change(s): %d major, %d minor, %d patch\n",
+		len(diff.Changes), diff.Summary.Major, diff.Summary.Minor, diff.Summary.Patch)
+	fmt.Fprintf(w, "Version bump: %s\n", diff.Summary.Bump)
+}
+
+// WriteAPIDiffMarkdown writes the API changes as a changelog section,
+// grouped by the version bump they call for
+func WriteAPIDiffMarkdown(w io.Writer, diff *domain.APIDiff) {
+	fmt.Fprintf(w, "## API changes from %s to %s\n", diff.OldRevision, diff.NewRevision)
+	if len(diff.Changes) == 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "No public API changes.")
+		return
+	}
+
+	code := func(s string) string { return "`" + s + "`" }
+	sections := []struct {
+		bump  domain.SemverBump
+		title string
+	}{
+		{domain.SemverMajor, "Breaking changes"},
+		{domain.SemverMinor, "Compatible changes"},
+		{domain.SemverPatch, "Annotation changes"},
+	}
+	for _, section := range sections {
+		written := false
+		for _, change := range diff.Changes {
+			if change.Bump != section.bump {
+				continue
+			}
+			if !written {
+				fmt.Fprintf(w, "\n### %s\n\n", section.title)
+				written = true
+			}
+			fmt.Fprintf(w, "- %s: %s\n", code(change.QualifiedName()), describeAPIChange(change, code))
+		}
+	}
+	fmt.Fprintf(w, "\nVersion bump: **%s**\n", diff.Summary.Bump)
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+func TestDiffAPIBetweenRevisions(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, content string) {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	git("init", "-q")
+	write("src/shop/cart.py", "def total(items):\n    return sum(items)\n")
+	write("src/shop/_internal.py", "def helper():\n    pass\n")
+	write("tests/test_cart.py", "def test_total():\n    pass\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "v1")
+	git("tag", "v1")
+	write("src/shop/cart.py", "def total(items, discount=0):\n    return sum(items) - discount\n")
+	write("src/shop/_internal.py", "def helper(x):\n    pass\n")
+	write("src/shop/orders.py", "class Order:\n    pass\n")
+	write("tests/test_cart.py", "def test_total(tmp_path):\n    pass\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "v2")
+	t.Chdir(dir)
+
+	diff, err := DiffAPIBetweenRevisions(context.Background(), "v1", "HEAD", nil)
+	if err != nil {
+		t.Fatalf("DiffAPIBetweenRevisions() error = %v", err)
+	}
+	var got []string
+	for _, change := range diff.Changes {
+		got = append(got, string(change.Kind)+" "+change.QualifiedName())
+	}
+	want := "parameter_added shop.cart.total,added shop.orders.Order"
+	if strings.Join(got, ",") != want {
+		t.Errorf("changes = %v, want %s", got, want)
+	}
+	if diff.Summary.Bump != domain.SemverMinor || diff.Summary.Minor != 2 {
+		t.Errorf("summary = %+v, want 2 minor changes", diff.Summary)
+	}
+
+	diff, err = DiffAPIBetweenRevisions(context.Background(), "v1", "HEAD", []string{"src/shop/orders.py"})
+	if err != nil || len(diff.Changes) != 1 {
+		t.Fatalf("expected the pathspec to select one change, got %+v: %v", diff, err)
+	}
+
+	if _, err := DiffAPIBetweenRevisions(context.Background(), "no-such-revision", "HEAD", nil); err == nil {
+		t.Error("expected an unknown revision to fail")
+	}
+}
+
+func TestWriteAPIDiff(t *testing.T) {
+	diff := &domain.APIDiff{
+		OldRevision: "v1",
+		NewRevision: "v2",
+		Changes: []domain.APIChange{
+			{Kind: domain.APIParameterRemoved, Symbol: domain.DiffSymbolMethod, Module: "shop.cart", Name: "Cart.total", Parameter: "rate", Bump: domain.SemverMajor},
+			{Kind: domain.APIDefaultChanged, Symbol: domain.DiffSymbolFunction, Module: "shop.cart", Name: "total", Parameter: "rate", Old: "1", New: "2", Bump: domain.SemverMinor},
+			{Kind: domain.APISymbolAdded, Symbol: domain.DiffSymbolClass, Module: "shop.orders", Name: "Order", Bump: domain.SemverMinor},
+		},
+	}
+	diff.Summarize()
+
+	var buf bytes.Buffer
+	WriteAPIDiffText(&buf, diff)
+	for _, want := range []string{
+		"shop.cart\n  major  Cart.total: parameter rate removed\n  minor  total: default of rate changed from 1 to 2\n\nshop.orders\n  minor  Order: class added\n",
+		"3 change(s): 1 major, 2 minor, 0 patch\nVersion bump: major\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("text output is missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	WriteAPIDiffMarkdown(&buf, diff)
+	for _, want := range []string{
+		"## API changes from v1 to v2\n",
+		"### Breaking changes\n\n- `shop.cart.Cart.total`: parameter `rate` removed\n",
+		"### Compatible changes\n\n- `shop.cart.total`: default of `rate` changed from `1` to `2`\n- `shop.orders.Order`: class added\n",
+		"Version bump: **major**\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("markdown output is missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	empty := &domain.APIDiff{OldRevision: "v1", NewRevision: "v2"}
+	empty.Summarize()
+	WriteAPIDiffText(&buf, empty)
+	if buf.String() != "No public API changes between v1 and v2.\n" {
+		t.Errorf("unexpected output without changes: %q", buf.String())
+	}
+}
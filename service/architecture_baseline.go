@@ -0,0 +1,225 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// LoadArchitectureBaseline reads an architecture baseline file. A missing
+// file yields nil and no error, so that callers can tell "not recorded yet"
+// from a broken file.
+func LoadArchitectureBaseline(path string) (*domain.ArchitectureBaseline, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, domain.NewFileNotFoundError(path, err)
+	}
+
+	var baseline domain.ArchitectureBaseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, domain.NewConfigError(fmt.Sprintf("failed to parse architecture baseline %s", path), err)
+	}
+	return &baseline, nil
+}
+
+// SaveArchitectureBaseline writes an architecture baseline file with its
+// entries sorted, so that diffs of the committed file stay small
+func SaveArchitectureBaseline(path string, baseline *domain.ArchitectureBaseline) error {
+	sortArchitectureBaselineEntries(baseline.Violations)
+
+	file, err := os.Create(path)
+	if err != nil {
+		return domain.NewOutputError(fmt.Sprintf("failed to create architecture baseline %s", path), err)
+	}
+	defer file.Close()
+
+	return WriteJSON(file, baseline)
+}
+
+// CollectArchitectureBaselineEntries returns the violations of an
+// architecture analysis that the gate applies to: all but the ones at info
+// severity, such as violations of downgraded edges. Each violation appears
+// once, sorted.
+func CollectArchitectureBaselineEntries(result *domain.ArchitectureAnalysisResult) []domain.ArchitectureBaselineEntry {
+	entries := make([]domain.ArchitectureBaselineEntry, 0)
+	if result == nil {
+		return entries
+	}
+
+	seen := make(map[string]bool)
+	for _, v := range result.Violations {
+		if v.Severity == domain.ViolationSeverityInfo {
+			continue
+		}
+		entry := domain.ArchitectureBaselineEntry{
+			RuleSet:     v.RuleSet,
+			Type:        v.Type,
+			Rule:        v.Rule,
+			Module:      v.Module,
+			Target:      v.Target,
+			Severity:    v.Severity,
+			Description: v.Description,
+			Layer:       v.Layer,
+		}
+		if seen[entry.Key()] {
+			continue
+		}
+		seen[entry.Key()] = true
+		entries = append(entries, entry)
+	}
+	sortArchitectureBaselineEntries(entries)
+	return entries
+}
+
+// CheckArchitectureBaseline compares the violations of a run with the
+// baseline. Violations found in the baseline are grandfathered. Layer
+// violations missing from it are charged, in order, to the budget of the
+// rule of their layer; the rest are new. Baseline entries that no longer
+// occur are fixed. A nil baseline grandfathers nothing.
+func CheckArchitectureBaseline(recorded *domain.ArchitectureBaseline, current []domain.ArchitectureBaselineEntry,
+	rules *domain.ArchitectureRules) *domain.ArchitectureGateReport {
+
+	report := &domain.ArchitectureGateReport{
+		New:          []domain.ArchitectureBaselineEntry{},
+		WithinBudget: []domain.ArchitectureBaselineEntry{},
+		Fixed:        []domain.ArchitectureBaselineEntry{},
+		Budgets:      architectureBudgets(rules),
+	}
+	budgets := make(map[string]*domain.ArchitectureBudgetUsage, len(report.Budgets))
+	for i := range report.Budgets {
+		budget := &report.Budgets[i]
+		budgets[budget.RuleSet+"\x00"+budget.Layer] = budget
+	}
+
+	grandfathered := make(map[string]bool)
+	if recorded != nil {
+		for _, entry := range recorded.Violations {
+			grandfathered[entry.Key()] = true
+		}
+	}
+
+	seen := make(map[string]bool, len(current))
+	for _, entry := range current {
+		seen[entry.Key()] = true
+		if grandfathered[entry.Key()] {
+			report.Grandfathered++
+			continue
+		}
+		if budget := budgets[entry.RuleSet+"\x00"+entry.Layer]; entry.Type == domain.ViolationTypeLayer && budget != nil && budget.Used < budget.Budget {
+			budget.Used++
+			report.WithinBudget = append(report.WithinBudget, entry)
+			continue
+		}
+		report.New = append(report.New, entry)
+	}
+
+	if recorded != nil {
+		for _, entry := range recorded.Violations {
+			if !seen[entry.Key()] {
+				report.Fixed = append(report.Fixed, entry)
+			}
+		}
+	}
+	return report
+}
+
+// ShrinkArchitectureBaseline returns the baseline without its fixed entries
+func ShrinkArchitectureBaseline(recorded *domain.ArchitectureBaseline, fixed []domain.ArchitectureBaselineEntry, version string) *domain.ArchitectureBaseline {
+	dropped := make(map[string]bool, len(fixed))
+	for _, entry := range fixed {
+		dropped[entry.Key()] = true
+	}
+	shrunk := &domain.ArchitectureBaseline{Version: version, Violations: []domain.ArchitectureBaselineEntry{}}
+	for _, entry := range recorded.Violations {
+		if !dropped[entry.Key()] {
+			shrunk.Violations = append(shrunk.Violations, entry)
+		}
+	}
+	return shrunk
+}
+
+// WriteArchitectureGateReportText lists the new violations of an
+// architecture check, then the ones within budget and the fixed ones
+func WriteArchitectureGateReportText(w io.Writer, report *domain.ArchitectureGateReport) {
+	writeEntries := func(title string, entries []domain.ArchitectureBaselineEntry) {
+		if len(entries) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "%s (%d)\n", title, len(entries))
+		fmt.Fprintln(w, strings.Repeat("-", 80))
+		for _, entry := range entries {
+			fmt.Fprintf(w, "  %s  %s\n", architectureBaselineLocation(entry), entry.Rule)
+			if entry.Description != "" {
+				fmt.Fprintf(w, "    %s\n", entry.Description)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+	writeEntries("NEW VIOLATIONS", report.New)
+	writeEntries("WITHIN BUDGET", report.WithinBudget)
+	writeEntries("FIXED", report.Fixed)
+
+	if len(report.Budgets) > 0 {
+		fmt.Fprintln(w, "BUDGETS")
+		fmt.Fprintln(w, strings.Repeat("-", 80))
+		for _, budget := range report.Budgets {
+			layer := budget.Layer
+			if budget.RuleSet != "" {
+				layer = fmt.Sprintf("[%s] %s", budget.RuleSet, layer)
+			}
+			fmt.Fprintf(w, "  %-30s %d of %d used\n", layer, budget.Used, budget.Budget)
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "%d grandfathered violation(s) still present.\n", report.Grandfathered)
+	if len(report.New) == 0 {
+		fmt.Fprintln(w, "No new architecture violations.")
+	}
+}
+
+// architectureBudgets lists the layer rules with a budget, global rules first
+func architectureBudgets(rules *domain.ArchitectureRules) []domain.ArchitectureBudgetUsage {
+	budgets := []domain.ArchitectureBudgetUsage{}
+	if rules == nil {
+		return budgets
+	}
+	add := func(ruleSet string, layerRules []domain.LayerRule) {
+		for _, rule := range layerRules {
+			if rule.Budget > 0 {
+				budgets = append(budgets, domain.ArchitectureBudgetUsage{RuleSet: ruleSet, Layer: rule.From, Budget: rule.Budget})
+			}
+		}
+	}
+	add("", rules.Rules)
+	for _, ruleSet := range rules.RuleSets {
+		add(ruleSet.Name, ruleSet.Rules)
+	}
+	return budgets
+}
+
+func architectureBaselineLocation(entry domain.ArchitectureBaselineEntry) string {
+	location := entry.Module
+	if entry.Target != "" {
+		location += " -> " + entry.Target
+	}
+	if entry.RuleSet != "" {
+		location = fmt.Sprintf("[%s] %s", entry.RuleSet, location)
+	}
+	return location
+}
+
+func sortArchitectureBaselineEntries(entries []domain.ArchitectureBaselineEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key() < entries[j].Key()
+	})
+}
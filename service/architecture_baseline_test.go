@@ -0,0 +1,106 @@
+package service
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+func layerViolation(ruleSet, layer, module, target string) domain.ArchitectureViolation {
+	return domain.ArchitectureViolation{
+		Type:     domain.ViolationTypeLayer,
+		Severity: domain.ViolationSeverityError,
+		Module:   module,
+		Target:   target,
+		Rule:     layer + " -> {" + layer + "}",
+		RuleSet:  ruleSet,
+		Layer:    layer,
+	}
+}
+
+func TestCollectArchitectureBaselineEntries(t *testing.T) {
+	downgraded := layerViolation("", "domain", "app.domain.a", "app.api.lazy")
+	downgraded.Severity = domain.ViolationSeverityInfo
+	result := &domain.ArchitectureAnalysisResult{Violations: []domain.ArchitectureViolation{
+		layerViolation("", "domain", "app.domain.b", "app.api.routes"),
+		layerViolation("", "domain", "app.domain.a", "app.api.routes"),
+		layerViolation("", "domain", "app.domain.a", "app.api.routes"),
+		downgraded,
+	}}
+
+	entries := CollectArchitectureBaselineEntries(result)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries without duplicates and info violations, got %+v", entries)
+	}
+	if entries[0].Module != "app.domain.a" || entries[1].Module != "app.domain.b" {
+		t.Errorf("expected entries sorted by module, got %+v", entries)
+	}
+}
+
+func TestCheckArchitectureBaseline(t *testing.T) {
+	grandfathered := layerViolation("", "domain", "app.domain.a", "app.api.routes")
+	fixed := layerViolation("", "domain", "app.domain.old", "app.api.routes")
+	recorded := &domain.ArchitectureBaseline{Violations: CollectArchitectureBaselineEntries(
+		&domain.ArchitectureAnalysisResult{Violations: []domain.ArchitectureViolation{grandfathered, fixed}})}
+
+	current := CollectArchitectureBaselineEntries(&domain.ArchitectureAnalysisResult{Violations: []domain.ArchitectureViolation{
+		grandfathered,
+		layerViolation("", "domain", "app.domain.b", "app.api.routes"),
+		layerViolation("", "domain", "app.domain.c", "app.api.routes"),
+		layerViolation("billing", "domain", "billing.domain.x", "billing.api.y"),
+	}})
+	rules := &domain.ArchitectureRules{
+		Rules: []domain.LayerRule{{From: "domain", Allow: []string{"domain"}, Budget: 1}},
+		RuleSets: []domain.ArchitectureRuleSet{{
+			Name:  "billing",
+			Rules: []domain.LayerRule{{From: "domain", Allow: []string{"domain"}}},
+		}},
+	}
+
+	report := CheckArchitectureBaseline(recorded, current, rules)
+	if report.Grandfathered != 1 {
+		t.Errorf("expected 1 grandfathered violation, got %d", report.Grandfathered)
+	}
+	if len(report.WithinBudget) != 1 || report.WithinBudget[0].Module != "app.domain.b" {
+		t.Errorf("expected the first new domain violation within budget, got %+v", report.WithinBudget)
+	}
+	if len(report.New) != 2 || report.New[0].Module != "app.domain.c" || report.New[1].RuleSet != "billing" {
+		t.Errorf("expected the rule set violation and the one beyond budget to be new, got %+v", report.New)
+	}
+	if len(report.Fixed) != 1 || report.Fixed[0].Module != "app.domain.old" {
+		t.Errorf("expected the missing violation to be fixed, got %+v", report.Fixed)
+	}
+	if len(report.Budgets) != 1 || report.Budgets[0].Used != 1 || report.Budgets[0].Budget != 1 {
+		t.Errorf("expected the domain budget to be used up, got %+v", report.Budgets)
+	}
+
+	shrunk := ShrinkArchitectureBaseline(recorded, report.Fixed, "1.0.0")
+	if len(shrunk.Violations) != 1 || shrunk.Violations[0].Module != "app.domain.a" || shrunk.Version != "1.0.0" {
+		t.Errorf("expected only the grandfathered violation to remain, got %+v", shrunk)
+	}
+}
+
+func TestArchitectureBaselineRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if baseline, err := LoadArchitectureBaseline(path); err != nil || baseline != nil {
+		t.Fatalf("expected a missing baseline to load as nil, got %+v, %v", baseline, err)
+	}
+
+	baseline := &domain.ArchitectureBaseline{Version: "1.0.0", Violations: CollectArchitectureBaselineEntries(
+		&domain.ArchitectureAnalysisResult{Violations: []domain.ArchitectureViolation{
+			layerViolation("", "domain", "app.domain.b", "app.api.routes"),
+			layerViolation("", "domain", "app.domain.a", "app.api.routes"),
+		}})}
+	if err := SaveArchitectureBaseline(path, baseline); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadArchitectureBaseline(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := CheckArchitectureBaseline(loaded, baseline.Violations, nil)
+	if report.Grandfathered != 2 || len(report.New) != 0 || len(report.Fixed) != 0 {
+		t.Errorf("expected the saved violations to be grandfathered, got %+v", report)
+	}
+}
@@ -14,7 +14,9 @@ func ArchitectureRulesFromPyscnConfig(cfg *config.PyscnConfig) *domain.Architect
 	if cfg.ArchitectureStrictMode == nil && cfg.ArchitectureStyle == "" &&
 		len(cfg.ArchitectureAllowedPatterns) == 0 && len(cfg.ArchitectureForbiddenPatterns) == 0 &&
 		len(cfg.ArchitectureLayers) == 0 && len(cfg.ArchitectureRules) == 0 &&
-		len(cfg.ArchitectureNeutralPrefixes) == 0 {
+		len(cfg.ArchitectureNeutralPrefixes) == 0 &&
+		len(cfg.ArchitectureIgnoreEdges) == 0 && len(cfg.ArchitectureDowngradeEdges) == 0 &&
+		len(cfg.ArchitectureRuleSets) == 0 {
 		return nil
 	}
 
@@ -40,6 +42,21 @@ func ArchitectureRulesFromPyscnConfig(cfg *config.PyscnConfig) *domain.Architect
 	if len(cfg.ArchitectureNeutralPrefixes) > 0 {
 		rules.NeutralPrefixes = cfg.ArchitectureNeutralPrefixes
 	}
+	if len(cfg.ArchitectureIgnoreEdges) > 0 {
+		rules.IgnoreEdgeKinds = cfg.ArchitectureIgnoreEdges
+	}
+	if len(cfg.ArchitectureDowngradeEdges) > 0 {
+		rules.DowngradeEdgeKinds = cfg.ArchitectureDowngradeEdges
+	}
+	for _, rs := range cfg.ArchitectureRuleSets {
+		rules.RuleSets = append(rules.RuleSets, domain.ArchitectureRuleSet{
+			Name:       rs.Name,
+			Paths:      rs.Paths,
+			Layers:     convertLayerDefinitions(rs.Layers),
+			Rules:      convertLayerRules(rs.Rules),
+			StrictMode: rs.StrictMode,
+		})
+	}
 	return rules
 }
 
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// lowLayerConfidence is the confidence below which a module is listed for
+// review in the written layers
+const lowLayerConfidence = 0.5
+
+// tomlTableHeader matches a [table] or [[array of tables]] header line
+var tomlTableHeader = regexp.MustCompile(`^\s*\[\[?\s*([^\[\]]+?)\s*\]\]?\s*(#.*)?$`)
+
+// FormatArchitectureLayersTOML renders detected layers and the rules of those
+// layers as [[architecture.layers]] and [[architecture.rules]] tables. Each
+// layer is preceded by a comment with its module count and mean confidence,
+// and the modules detected with low confidence. tablePrefix is prepended to
+// the table names, e.g. "tool.pyscn." for pyproject.toml.
+func FormatArchitectureLayersTOML(rules *domain.ArchitectureRules, tablePrefix string) string {
+	var b strings.Builder
+	b.WriteString("# Layers detected by `pyscn arch init`. Review the packages of each layer\n")
+	b.WriteString("# and the rules between them; pyscn validates imports against them.\n")
+
+	modules := make(map[string][]domain.LayerAssignment)
+	if rules.Detection != nil {
+		for _, assignment := range rules.Detection.Assignments {
+			modules[assignment.Layer] = append(modules[assignment.Layer], assignment)
+		}
+	}
+
+	detected := make(map[string]bool, len(rules.Layers))
+	for _, layer := range rules.Layers {
+		detected[layer.Name] = true
+		b.WriteString("\n")
+		if assigned := modules[layer.Name]; len(assigned) > 0 {
+			total := 0.0
+			for _, assignment := range assigned {
+				total += assignment.Confidence
+			}
+			fmt.Fprintf(&b, "# %s: %d module(s), mean confidence %.0f%%\n", layer.Name, len(assigned), total/float64(len(assigned))*100)
+			for _, assignment := range assigned {
+				if assignment.Confidence < lowLayerConfidence {
+					fmt.Fprintf(&b, "#   review %s (%.0f%%", assignment.Module, assignment.Confidence*100)
+					if len(assignment.Candidates) > 0 {
+						fmt.Fprintf(&b, ", also matches %s", strings.Join(assignment.Candidates, ", "))
+					}
+					b.WriteString(")\n")
+				}
+			}
+		}
+		fmt.Fprintf(&b, "[[%sarchitecture.layers]]\n", tablePrefix)
+		fmt.Fprintf(&b, "name = %s\n", strconv.Quote(layer.Name))
+		fmt.Fprintf(&b, "packages = %s\n", tomlStringArray(detectedPackages(layer, modules[layer.Name])))
+	}
+
+	for _, rule := range rules.Rules {
+		if !detected[rule.From] {
+			continue
+		}
+		fmt.Fprintf(&b, "\n[[%sarchitecture.rules]]\n", tablePrefix)
+		fmt.Fprintf(&b, "from = %s\n", strconv.Quote(rule.From))
+		if len(rule.Allow) > 0 {
+			fmt.Fprintf(&b, "allow = %s\n", tomlStringArray(rule.Allow))
+		}
+		if len(rule.Deny) > 0 {
+			fmt.Fprintf(&b, "deny = %s\n", tomlStringArray(rule.Deny))
+		}
+		if len(rule.Warn) > 0 {
+			fmt.Fprintf(&b, "warn = %s\n", tomlStringArray(rule.Warn))
+		}
+	}
+	return b.String()
+}
+
+// HasArchitectureLayerTables reports whether a TOML document defines
+// architecture layers or rules under tablePrefix
+func HasArchitectureLayerTables(content, tablePrefix string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		if isArchitectureLayerTable(line, tablePrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// StripArchitectureLayerTables removes the architecture layer and rule
+// tables under tablePrefix from a TOML document, keeping everything else
+func StripArchitectureLayerTables(content, tablePrefix string) string {
+	lines := strings.Split(content, "\n")
+	kept := make([]string, 0, len(lines))
+	skipping := false
+	for _, line := range lines {
+		if tomlTableHeader.MatchString(line) {
+			skipping = isArchitectureLayerTable(line, tablePrefix)
+		}
+		if !skipping {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+func isArchitectureLayerTable(line, tablePrefix string) bool {
+	match := tomlTableHeader.FindStringSubmatch(line)
+	if match == nil {
+		return false
+	}
+	name := strings.Join(strings.Fields(match[1]), "")
+	return name == tablePrefix+"architecture.layers" || name == tablePrefix+"architecture.rules"
+}
+
+// detectedPackages returns the packages that matched the layer in its
+// modules, falling back to the layer's own packages without a detection
+func detectedPackages(layer domain.Layer, assigned []domain.LayerAssignment) []string {
+	seen := make(map[string]bool)
+	var packages []string
+	for _, assignment := range assigned {
+		if assignment.Package != "" && !seen[assignment.Package] {
+			seen[assignment.Package] = true
+			packages = append(packages, assignment.Package)
+		}
+	}
+	if len(packages) == 0 {
+		return layer.Packages
+	}
+	sort.Strings(packages)
+	return packages
+}
+
+func tomlStringArray(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = strconv.Quote(value)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatArchitectureLayersTOML(t *testing.T) {
+	rules := &domain.ArchitectureRules{
+		Layers: []domain.Layer{
+			{Name: "presentation", Packages: []string{"app"}},
+			{Name: "domain", Packages: []string{"app"}},
+		},
+		Rules: []domain.LayerRule{
+			{From: "presentation", Allow: []string{"presentation", "domain"}},
+			{From: "domain", Allow: []string{"domain"}, Deny: []string{"presentation"}},
+			{From: "infrastructure", Allow: []string{"domain"}},
+		},
+		Detection: &domain.LayerDetection{Assignments: []domain.LayerAssignment{
+			{Module: "app.api.routes", Layer: "presentation", Package: "app.api", Confidence: 0.9},
+			{Module: "app.models.api_helpers", Layer: "domain", Package: "app.models", Confidence: 0.4, Candidates: []string{"presentation"}},
+			{Module: "app.models.user", Layer: "domain", Package: "app.models", Confidence: 0.8},
+		}},
+	}
+
+	out := FormatArchitectureLayersTOML(rules, "tool.pyscn.")
+
+	assert.Contains(t, out, "# presentation: 1 module(s), mean confidence 90%\n[[tool.pyscn.architecture.layers]]\nname = \"presentation\"\npackages = [\"app.api\"]\n")
+	assert.Contains(t, out, "# domain: 2 module(s), mean confidence 60%\n#   review app.models.api_helpers (40%, also matches presentation)\n")
+	assert.Contains(t, out, "packages = [\"app.models\"]\n")
+	assert.Contains(t, out, "[[tool.pyscn.architecture.rules]]\nfrom = \"domain\"\nallow = [\"domain\"]\ndeny = [\"presentation\"]\n")
+	assert.NotContains(t, out, "infrastructure", "rules of undetected layers are left out")
+}
+
+func TestStripArchitectureLayerTables(t *testing.T) {
+	content := strings.Join([]string{
+		"[architecture]",
+		"enabled = true",
+		"",
+		"[[architecture.layers]]",
+		"name = \"domain\"",
+		"packages = [\"models\"]",
+		"",
+		"[[ architecture.rules ]]  # comment",
+		"from = \"domain\"",
+		"",
+		"[complexity]",
+		"max_complexity = 12",
+	}, "\n")
+
+	assert.True(t, HasArchitectureLayerTables(content, ""))
+	assert.False(t, HasArchitectureLayerTables(content, "tool.pyscn."))
+
+	stripped := StripArchitectureLayerTables(content, "")
+	assert.Equal(t, "[architecture]\nenabled = true\n\n[complexity]\nmax_complexity = 12", stripped)
+	assert.False(t, HasArchitectureLayerTables(stripped, ""))
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/analyzer"
+	"github.com/ludo-technologies/pyscn/internal/config"
+)
+
+// Weights of the name evidence for a layer. A package segment at the start
+// of the module counts fully; a later segment, or a name that only contains
+// the package joined with an underscore, is weaker evidence.
+const (
+	layerNameSuffixWeight     = 0.9
+	layerNameUnderscoreWeight = 0.6
+)
+
+// layerCandidate is a layer a module name matched, with the strength of the
+// best matching pattern
+type layerCandidate struct {
+	layer string
+	pkg   string // module path through the matched segment
+	score float64
+}
+
+// autoDetectArchitecture automatically detects architecture patterns from the
+// dependency graph. Every module is scored against the layers its name
+// matches; the dependencies the built-in rules allow raise a candidate's
+// score and forbidden ones lower it. The winning assignments and their
+// confidence are attached as Detection.
+func (s *SystemAnalysisServiceImpl) autoDetectArchitecture(graph *analyzer.DependencyGraph) *domain.ArchitectureRules {
+	// Load layer patterns and rules from the embedded default config
+	defaultConfig, err := config.LoadDefaultConfigFromTOML()
+	if err != nil {
+		// This should never happen since the config is embedded at compile time
+		// But if it does, return nil to indicate no auto-detection is possible
+		return nil
+	}
+
+	defaultLayers := convertLayerDefinitions(defaultConfig.Architecture.Layers)
+	compiled := s.compileLayerPatterns(defaultLayers)
+	defaultRules := &domain.ArchitectureRules{Rules: convertLayerRules(defaultConfig.Architecture.Rules)}
+
+	// Name evidence first; the best name match of each neighbor is what the
+	// dependency evidence is checked against
+	modules := make([]string, 0, len(graph.Nodes))
+	candidates := make(map[string][]layerCandidate)
+	nameLayer := make(map[string]string)
+	for module := range graph.Nodes {
+		if s.isTestModule(module) {
+			continue
+		}
+		if found := s.layerCandidates(module, compiled); len(found) > 0 {
+			modules = append(modules, module)
+			candidates[module] = found
+			nameLayer[module] = found[0].layer
+		}
+	}
+
+	// If no standard patterns found, return nil
+	if len(modules) == 0 {
+		return nil
+	}
+	sort.Strings(modules)
+
+	detection := &domain.LayerDetection{Assignments: make([]domain.LayerAssignment, 0, len(modules))}
+	layerModules := make(map[string][]string)
+	for _, module := range modules {
+		assignment := s.assignLayer(graph.Nodes[module], candidates[module], nameLayer, defaultRules)
+		detection.Assignments = append(detection.Assignments, assignment)
+		layerModules[assignment.Layer] = append(layerModules[assignment.Layer], module)
+	}
+
+	// Build layers configuration in the order of the built-in layers
+	layers := make([]domain.Layer, 0, len(layerModules))
+	for _, layer := range defaultLayers {
+		// Extract unique package prefixes from modules
+		packagePrefixes := s.extractPackagePrefixes(layerModules[layer.Name])
+		if len(packagePrefixes) > 0 {
+			layers = append(layers, domain.Layer{
+				Name:        layer.Name,
+				Description: fmt.Sprintf("Auto-detected %s layer", layer.Name),
+				Packages:    packagePrefixes,
+			})
+		}
+	}
+
+	return &domain.ArchitectureRules{
+		Layers:     layers,
+		Rules:      defaultRules.Rules,
+		StrictMode: false,
+		Detection:  detection,
+	}
+}
+
+// layerCandidates returns the layers a module name matches, best first, each
+// scored by its best matching pattern
+func (s *SystemAnalysisServiceImpl) layerCandidates(module string, compiled map[string][]compiledPattern) []layerCandidate {
+	var candidates []layerCandidate
+	seen := make(map[string]bool)
+	for _, match := range s.rankLayerMatches(module, compiled) {
+		if seen[match.layer] {
+			continue
+		}
+		seen[match.layer] = true
+
+		score := 1.0
+		if !match.isPrefix {
+			score *= layerNameSuffixWeight
+		}
+		if match.boundary < 2 {
+			score *= layerNameUnderscoreWeight
+		}
+		pkg := module
+		if end := strings.Index(module[match.matchPos:], "."); end >= 0 {
+			pkg = module[:match.matchPos+end]
+		}
+		candidates = append(candidates, layerCandidate{layer: match.layer, pkg: pkg, score: score})
+	}
+	return candidates
+}
+
+// assignLayer picks the candidate with the best combined name and dependency
+// score. Confidence is the winner's share of all candidate scores, scaled by
+// the winner's own score, so an ambiguous name, a weak match or dependencies
+// against the rules all lower it.
+func (s *SystemAnalysisServiceImpl) assignLayer(node *analyzer.ModuleNode, candidates []layerCandidate,
+	nameLayer map[string]string, rules *domain.ArchitectureRules) domain.LayerAssignment {
+
+	scores := make([]float64, len(candidates))
+	total := 0.0
+	best := 0
+	for i, candidate := range candidates {
+		scores[i] = candidate.score * s.dependencyFit(node, candidate.layer, nameLayer, rules)
+		total += scores[i]
+		if scores[i] > scores[best] {
+			best = i
+		}
+	}
+
+	others := make([]string, 0, len(candidates)-1)
+	for i, candidate := range candidates {
+		if i != best {
+			others = append(others, candidate.layer)
+		}
+	}
+
+	confidence := scores[best] / total * math.Min(scores[best], 1)
+	return domain.LayerAssignment{
+		Module:     node.Name,
+		Layer:      candidates[best].layer,
+		Package:    candidates[best].pkg,
+		Confidence: math.Round(confidence*100) / 100,
+		Candidates: others,
+	}
+}
+
+// dependencyFit returns 1 when every dependency between the module and
+// modules with a detected layer is allowed by the rules if the module
+// belonged to layer, down to 0.5 when none is. Modules without such
+// dependencies fit any layer.
+func (s *SystemAnalysisServiceImpl) dependencyFit(node *analyzer.ModuleNode, layer string,
+	nameLayer map[string]string, rules *domain.ArchitectureRules) float64 {
+
+	allowed, total := 0, 0
+	for dependency := range node.Dependencies {
+		if target, ok := nameLayer[dependency]; ok && dependency != node.Name {
+			total++
+			if s.evaluateLayerEdge(rules, node.Name, dependency, layer, target) == nil {
+				allowed++
+			}
+		}
+	}
+	for dependent := range node.Dependents {
+		if source, ok := nameLayer[dependent]; ok && dependent != node.Name {
+			total++
+			if s.evaluateLayerEdge(rules, dependent, node.Name, source, layer) == nil {
+				allowed++
+			}
+		}
+	}
+	if total == 0 {
+		return 1
+	}
+	return 0.5 + 0.5*float64(allowed)/float64(total)
+}
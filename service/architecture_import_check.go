@@ -0,0 +1,94 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// CheckImport evaluates a dependency from one module to another against
+// resolved architecture rules without it having to exist, and explains the
+// outcome. Rule sets apply when both modules fall in the same scope; module
+// names are mapped to paths the way imports are, relative to the project
+// root.
+func (s *SystemAnalysisServiceImpl) CheckImport(rules *domain.ArchitectureRules, from, to string) *domain.ImportCheck {
+	check := &domain.ImportCheck{From: from, To: to, Verdicts: []domain.ImportVerdict{}}
+	if rules == nil {
+		return check
+	}
+
+	if len(rules.Layers) > 0 {
+		verdict := s.explainImport(rules, from, to, from, to)
+		check.Verdicts = append(check.Verdicts, verdict)
+	}
+
+	fromPath := strings.ReplaceAll(from, ".", "/") + ".py"
+	toPath := strings.ReplaceAll(to, ".", "/") + ".py"
+	for _, ruleSet := range rules.RuleSets {
+		fromScope, fromScoped, ok := ruleSetScope(fromPath, ruleSet.Paths)
+		if !ok {
+			continue
+		}
+		toScope, toScoped, ok := ruleSetScope(toPath, ruleSet.Paths)
+		if !ok || toScope != fromScope {
+			continue
+		}
+		verdict := s.explainImport(s.ruleSetRules(ruleSet), from, to, fromScoped, toScoped)
+		verdict.RuleSet = ruleSet.Name
+		verdict.Scope = fromScope
+		check.Verdicts = append(check.Verdicts, verdict)
+	}
+	return check
+}
+
+// explainImport checks one edge against one set of rules. fromName and
+// toName are the names matched against the layers, which differ from the
+// module names inside a rule set scope.
+func (s *SystemAnalysisServiceImpl) explainImport(rules *domain.ArchitectureRules, from, to, fromName, toName string) domain.ImportVerdict {
+	compiled := s.compileLayerPatterns(rules.Layers)
+	verdict := domain.ImportVerdict{FromLayer: "unknown", ToLayer: "unknown"}
+	if fromName != "" {
+		verdict.FromLayer, verdict.FromPattern = s.matchModuleLayer(fromName, rules, compiled)
+	}
+	if toName != "" {
+		verdict.ToLayer, verdict.ToPattern = s.matchModuleLayer(toName, rules, compiled)
+	}
+
+	if violation := s.evaluateLayerEdge(rules, from, to, verdict.FromLayer, verdict.ToLayer); violation != nil {
+		verdict.Decision = domain.ImportDecisionDenied
+		if violation.Severity != domain.ViolationSeverityError {
+			verdict.Decision = domain.ImportDecisionWarning
+		}
+		verdict.Rule = violation.Rule
+		verdict.Reason = violation.Description
+		return verdict
+	}
+
+	switch {
+	case verdict.FromLayer == "unknown" || verdict.ToLayer == "unknown":
+		verdict.Decision = domain.ImportDecisionUnchecked
+		verdict.Reason = "Dependency involves a module in no layer, and strict_mode is off"
+	default:
+		var layerRule *domain.LayerRule
+		for i := range rules.Rules {
+			if rules.Rules[i].From == verdict.FromLayer {
+				layerRule = &rules.Rules[i]
+				break
+			}
+		}
+		if layerRule == nil {
+			verdict.Decision = domain.ImportDecisionUnchecked
+			verdict.Reason = fmt.Sprintf("No rule defined for layer '%s', and strict_mode is off", verdict.FromLayer)
+			break
+		}
+		verdict.Decision = domain.ImportDecisionAllowed
+		if len(layerRule.Allow) > 0 {
+			verdict.Rule = fmt.Sprintf("%s -> {%s}", verdict.FromLayer, strings.Join(layerRule.Allow, ","))
+			verdict.Reason = fmt.Sprintf("Layer '%s' allows '%s'", verdict.FromLayer, verdict.ToLayer)
+		} else {
+			verdict.Reason = fmt.Sprintf("The rule for layer '%s' has no allow list and does not deny '%s'", verdict.FromLayer, verdict.ToLayer)
+		}
+	}
+	return verdict
+}
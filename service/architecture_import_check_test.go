@@ -0,0 +1,65 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckImport(t *testing.T) {
+	service := NewSystemAnalysisService()
+	rules := &domain.ArchitectureRules{
+		Layers: []domain.Layer{
+			{Name: "presentation", Packages: []string{"api"}},
+			{Name: "domain", Packages: []string{"models"}},
+			{Name: "infrastructure", Packages: []string{"db"}},
+		},
+		Rules: []domain.LayerRule{
+			{From: "presentation", Allow: []string{"presentation", "domain"}},
+			{From: "domain", Allow: []string{"domain"}, Deny: []string{"presentation"}, Warn: []string{"infrastructure"}},
+		},
+		RuleSets: []domain.ArchitectureRuleSet{{
+			Name:   "services",
+			Paths:  []string{"services/*"},
+			Layers: []domain.Layer{{Name: "core", Packages: []string{"core"}}, {Name: "web", Packages: []string{"web"}}},
+			Rules:  []domain.LayerRule{{From: "core", Deny: []string{"web"}}},
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		from, to string
+		decision domain.ImportDecision
+		rule     string
+	}{
+		{"allowed", "app.api.users", "app.models.user", domain.ImportDecisionAllowed, "presentation -> {presentation,domain}"},
+		{"denied", "app.models.user", "app.api.users", domain.ImportDecisionDenied, "domain !> presentation"},
+		{"discouraged", "app.models.user", "app.db.session", domain.ImportDecisionWarning, "domain ~> infrastructure"},
+		{"no rule", "app.db.session", "app.models.user", domain.ImportDecisionUnchecked, ""},
+		{"no layer", "app.misc", "app.models.user", domain.ImportDecisionUnchecked, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := service.CheckImport(rules, tt.from, tt.to)
+			require.Len(t, check.Verdicts, 1)
+			assert.Equal(t, tt.decision, check.Verdicts[0].Decision)
+			assert.Equal(t, tt.rule, check.Verdicts[0].Rule)
+			assert.NotEmpty(t, check.Verdicts[0].Reason)
+		})
+	}
+
+	// Within one service both the global rules and the rule set apply
+	check := service.CheckImport(rules, "services.billing.core.invoice", "services.billing.web.views")
+	require.Len(t, check.Verdicts, 2)
+	assert.Equal(t, domain.ImportDecisionUnchecked, check.Verdicts[0].Decision)
+	assert.Equal(t, "services", check.Verdicts[1].RuleSet)
+	assert.Equal(t, "services/billing", check.Verdicts[1].Scope)
+	assert.Equal(t, "core", check.Verdicts[1].FromLayer)
+	assert.Equal(t, domain.ImportDecisionDenied, check.Verdicts[1].Decision)
+
+	// Across services only the global rules apply
+	check = service.CheckImport(rules, "services.billing.core.invoice", "services.shipping.web.views")
+	assert.Len(t, check.Verdicts, 1)
+}
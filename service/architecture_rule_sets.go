@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/analyzer"
+)
+
+// evaluateRuleSets checks every rule set against the modules under each
+// directory matching its paths. Each matching directory is a scope of its
+// own: modules are assigned to layers by their path below the scope, and
+// only dependencies between modules of the same scope are checked.
+// Dependencies leaving a scope are left to the global rules.
+func (s *SystemAnalysisServiceImpl) evaluateRuleSets(ctx context.Context, graph *analyzer.DependencyGraph,
+	ruleSets []domain.ArchitectureRuleSet, policy layerEdgePolicy) ([]domain.RuleSetResult, []domain.ArchitectureViolation, int, error) {
+
+	if len(ruleSets) == 0 {
+		return nil, nil, 0, nil
+	}
+
+	modules := graph.GetModuleNames()
+	results := make([]domain.RuleSetResult, 0, len(ruleSets))
+	violations := make([]domain.ArchitectureViolation, 0)
+	checked := 0
+
+	for _, ruleSet := range ruleSets {
+		rules := s.ruleSetRules(ruleSet)
+		compiled := s.compileLayerPatterns(rules.Layers)
+
+		moduleScope := make(map[string]string)
+		moduleToLayer := make(map[string]string)
+		scopes := make(map[string]bool)
+		for _, module := range modules {
+			scope, scoped, ok := ruleSetScope(relativeModulePath(graph, graph.Nodes[module]), ruleSet.Paths)
+			if !ok {
+				continue
+			}
+			moduleScope[module] = scope
+			scopes[scope] = true
+			moduleToLayer[module] = "unknown"
+			if scoped != "" {
+				moduleToLayer[module] = s.layerForModule(scoped, rules, compiled)
+			}
+		}
+
+		edges := make([]*analyzer.DependencyEdge, 0)
+		for _, edge := range graph.Edges {
+			if scope, ok := moduleScope[edge.From]; ok && moduleScope[edge.To] == scope {
+				edges = append(edges, edge)
+			}
+		}
+
+		found, severityCounts, _, ruleChecks := s.evaluateLayerRules(ctx, edges, moduleToLayer, rules, policy)
+		if found == nil {
+			return nil, nil, 0, fmt.Errorf("architecture analysis cancelled: %w", ctx.Err())
+		}
+		for i := range found {
+			found[i].RuleSet = ruleSet.Name
+			found[i].Description = fmt.Sprintf("[%s] %s", ruleSet.Name, found[i].Description)
+		}
+
+		compliance, weighted := s.calculateComplianceWeighted(
+			severityCounts[domain.ViolationSeverityError], severityCounts[domain.ViolationSeverityWarning], ruleChecks)
+		scopeNames := make([]string, 0, len(scopes))
+		for scope := range scopes {
+			scopeNames = append(scopeNames, scope)
+		}
+		sort.Strings(scopeNames)
+
+		results = append(results, domain.RuleSetResult{
+			Name:               ruleSet.Name,
+			Scopes:             scopeNames,
+			ModulesAnalyzed:    len(moduleScope),
+			ComplianceScore:    compliance,
+			TotalViolations:    len(found),
+			WeightedViolations: weighted,
+			TotalRules:         ruleChecks,
+		})
+		violations = append(violations, found...)
+		checked += ruleChecks
+	}
+
+	return results, violations, checked, nil
+}
+
+// ruleSetRules returns the rules a rule set is checked against; without
+// rules of its own, the default rules of its layers apply
+func (s *SystemAnalysisServiceImpl) ruleSetRules(ruleSet domain.ArchitectureRuleSet) *domain.ArchitectureRules {
+	rules := &domain.ArchitectureRules{
+		Layers:     ruleSet.Layers,
+		Rules:      ruleSet.Rules,
+		StrictMode: ruleSet.StrictMode,
+	}
+	if len(rules.Rules) == 0 {
+		rules.Rules = s.loadDefaultRulesForLayers(rules.Layers)
+	}
+	return rules
+}
+
+// relativeModulePath returns the slash-separated path of a module file
+// relative to the project root
+func relativeModulePath(graph *analyzer.DependencyGraph, node *analyzer.ModuleNode) string {
+	rel := node.RelativePath
+	if rel == "" || strings.HasPrefix(rel, "..") {
+		if r, err := filepath.Rel(graph.ProjectRoot, node.FilePath); err == nil {
+			rel = r
+		}
+	}
+	return filepath.ToSlash(rel)
+}
+
+// ruleSetScope matches the directory of a module file against directory
+// globs, one segment per path segment. It returns the matching directory and
+// the dotted module path below it, which is empty for the package of the
+// directory itself.
+func ruleSetScope(relPath string, patterns []string) (string, string, bool) {
+	dir := path.Dir(relPath)
+	if dir == "." {
+		return "", "", false
+	}
+	segments := strings.Split(dir, "/")
+	for _, pattern := range patterns {
+		pattern = strings.Trim(path.Clean(filepath.ToSlash(pattern)), "/")
+		depth := strings.Count(pattern, "/") + 1
+		if pattern == "." || depth > len(segments) {
+			continue
+		}
+		scope := strings.Join(segments[:depth], "/")
+		if matched, err := path.Match(pattern, scope); err != nil || !matched {
+			continue
+		}
+
+		below := strings.TrimSuffix(strings.TrimPrefix(relPath, scope+"/"), ".py")
+		below = strings.TrimSuffix(strings.TrimSuffix(below, "__init__"), "/")
+		return scope, strings.ReplaceAll(below, "/", "."), true
+	}
+	return "", "", false
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/analyzer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleSetScope(t *testing.T) {
+	scope, module, ok := ruleSetScope("services/billing/api/routes.py", []string{"services/*"})
+	require.True(t, ok)
+	assert.Equal(t, "services/billing", scope)
+	assert.Equal(t, "api.routes", module)
+
+	scope, module, ok = ruleSetScope("services/billing/__init__.py", []string{"services/*/"})
+	require.True(t, ok)
+	assert.Equal(t, "services/billing", scope)
+	assert.Empty(t, module)
+
+	_, _, ok = ruleSetScope("libs/core/models.py", []string{"services/*"})
+	assert.False(t, ok)
+	_, _, ok = ruleSetScope("services/main.py", []string{"services/*"})
+	assert.False(t, ok, "files directly in services/ belong to no service")
+}
+
+func TestAnalyzeArchitectureGraph_RuleSetsPerDirectory(t *testing.T) {
+	service := NewSystemAnalysisService()
+	graph := analyzer.NewDependencyGraph("/project")
+	for _, module := range []string{
+		"services.billing.api.routes",
+		"services.billing.core.invoice",
+		"services.shipping.api.routes",
+		"services.shipping.core.parcel",
+	} {
+		graph.AddModule(module, "/project/"+strings.ReplaceAll(module, ".", "/")+".py")
+	}
+	// Allowed within billing
+	graph.AddDependency("services.billing.api.routes", "services.billing.core.invoice", analyzer.DependencyEdgeImport, nil)
+	// Denied within shipping
+	graph.AddDependency("services.shipping.core.parcel", "services.shipping.api.routes", analyzer.DependencyEdgeImport, nil)
+	// Across services: left to the global rules
+	graph.AddDependency("services.billing.core.invoice", "services.shipping.api.routes", analyzer.DependencyEdgeImport, nil)
+
+	req := domain.SystemAnalysisRequest{
+		ValidateResponsibility: domain.BoolPtr(false),
+		ValidateCohesion:       domain.BoolPtr(false),
+		ValidateGodModules:     domain.BoolPtr(false),
+		ArchitectureRules: &domain.ArchitectureRules{
+			Layers: []domain.Layer{{Name: "services", Packages: []string{"services"}}},
+			Rules:  []domain.LayerRule{{From: "services", Allow: []string{"services"}}},
+			RuleSets: []domain.ArchitectureRuleSet{{
+				Name:  "service-layers",
+				Paths: []string{"services/*"},
+				Layers: []domain.Layer{
+					{Name: "api", Packages: []string{"api"}},
+					{Name: "core", Packages: []string{"core"}},
+				},
+				Rules: []domain.LayerRule{
+					{From: "api", Allow: []string{"api", "core"}},
+					{From: "core", Allow: []string{"core"}},
+				},
+			}},
+		},
+	}
+
+	result, err := service.analyzeArchitectureGraph(context.Background(), graph, req)
+	require.NoError(t, err)
+	require.Len(t, result.RuleSets, 1)
+
+	ruleSet := result.RuleSets[0]
+	assert.Equal(t, "service-layers", ruleSet.Name)
+	assert.Equal(t, []string{"services/billing", "services/shipping"}, ruleSet.Scopes)
+	assert.Equal(t, 4, ruleSet.ModulesAnalyzed)
+	assert.Equal(t, 2, ruleSet.TotalRules)
+	assert.Equal(t, 1, ruleSet.TotalViolations)
+	assert.Less(t, ruleSet.ComplianceScore, 1.0)
+
+	var scoped []domain.ArchitectureViolation
+	for _, violation := range result.Violations {
+		if violation.RuleSet != "" {
+			scoped = append(scoped, violation)
+		}
+	}
+	require.Len(t, scoped, 1)
+	assert.Equal(t, "services.shipping.core.parcel", scoped[0].Module)
+	assert.Equal(t, "service-layers", scoped[0].RuleSet)
+	assert.Empty(t, result.LayerAnalysis.LayerViolations, "rule set violations stay out of the global layer summary")
+	// Three global edges and two edges within a service
+	assert.Equal(t, 5, result.TotalRules)
+}
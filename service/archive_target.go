@@ -0,0 +1,178 @@
+package service
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// archiveExtensions are the file name suffixes of the packaged releases
+// accepted as analysis targets: wheels, zip files and sdists
+var archiveExtensions = []string{".whl", ".zip", ".tar.gz", ".tgz"}
+
+// archiveProjectFiles are the non-source files extracted along with the
+// sources, so that the release's own configuration and project root apply
+var archiveProjectFiles = map[string]bool{
+	".pyscn.toml":      true,
+	"pyproject.toml":   true,
+	"setup.cfg":        true,
+	"requirements.txt": true,
+}
+
+// maxArchiveExtractBytes caps the size of the files extracted from one
+// archive, so that a compression bomb cannot fill the disk
+const maxArchiveExtractBytes = 1 << 30
+
+// IsArchiveTarget reports whether arg names a wheel, zip file or sdist to
+// extract and analyze
+func IsArchiveTarget(arg string) bool {
+	lower := strings.ToLower(arg)
+	for _, ext := range archiveExtensions {
+		if strings.HasSuffix(lower, ext) {
+			info, err := os.Stat(arg)
+			return err == nil && info.Mode().IsRegular()
+		}
+	}
+	return false
+}
+
+// ArchiveTargetName returns the archive file name without its extension,
+// e.g. "requests-2.31.0" for requests-2.31.0.tar.gz
+func ArchiveTargetName(arg string) string {
+	name := filepath.Base(arg)
+	lower := strings.ToLower(name)
+	for _, ext := range archiveExtensions {
+		if strings.HasSuffix(lower, ext) {
+			return name[:len(name)-len(ext)]
+		}
+	}
+	return name
+}
+
+// ExtractArchiveTarget extracts the Python sources and project files of a
+// wheel, zip file or sdist into dir. Entries that would land outside dir,
+// links and other files are skipped.
+func ExtractArchiveTarget(archive, dir string) error {
+	var err error
+	if lower := strings.ToLower(archive); strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz") {
+		err = extractTarGz(archive, dir)
+	} else {
+		err = extractZip(archive, dir)
+	}
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return fmt.Errorf("failed to extract %s: %w", archive, err)
+	}
+	return nil
+}
+
+func extractZip(archive, dir string) error {
+	reader, err := zip.OpenReader(archive)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	extractor := &archiveExtractor{dir: dir}
+	for _, file := range reader.File {
+		if !file.Mode().IsRegular() {
+			continue
+		}
+		target, ok := extractor.target(file.Name)
+		if !ok {
+			continue
+		}
+		content, err := file.Open()
+		if err != nil {
+			return err
+		}
+		err = extractor.write(target, content)
+		content.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractTarGz(archive, dir string) error {
+	file, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	extractor := &archiveExtractor{dir: dir}
+	reader := tar.NewReader(gz)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		target, ok := extractor.target(header.Name)
+		if !ok {
+			continue
+		}
+		if err := extractor.write(target, reader); err != nil {
+			return err
+		}
+	}
+}
+
+// archiveExtractor writes the wanted entries of an archive below dir
+type archiveExtractor struct {
+	dir     string
+	written int64
+}
+
+// target returns where an entry is extracted to, or false when it is not a
+// source or project file or would escape dir
+func (e *archiveExtractor) target(name string) (string, bool) {
+	name = path.Clean(strings.ReplaceAll(name, "\\", "/"))
+	if path.IsAbs(name) || name == "." || name == ".." || strings.HasPrefix(name, "../") || filepath.VolumeName(name) != "" {
+		return "", false
+	}
+	if !parser.IsSourceFile(name) && !archiveProjectFiles[path.Base(name)] {
+		return "", false
+	}
+	return filepath.Join(e.dir, filepath.FromSlash(name)), true
+}
+
+func (e *archiveExtractor) write(target string, content io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	n, err := io.Copy(out, io.LimitReader(content, maxArchiveExtractBytes-e.written+1))
+	closeErr := out.Close()
+	e.written += n
+	if err != nil {
+		return err
+	}
+	if e.written > maxArchiveExtractBytes {
+		return fmt.Errorf("archive expands to more than %d bytes", maxArchiveExtractBytes)
+	}
+	return closeErr
+}
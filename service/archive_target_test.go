@@ -0,0 +1,137 @@
+package service
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// archiveEntries are the entries of the test archives, including ones that
+// must not be extracted
+var archiveEntries = map[string]string{
+	"mypkg-1.0/pyproject.toml":        "[tool.pyscn.complexity]\nmax_complexity = 5\n",
+	"mypkg-1.0/mypkg/__init__.py":     "from .core import run\n",
+	"mypkg-1.0/mypkg/core.py":         "def run():\n    return 1\n",
+	"mypkg-1.0/mypkg/py.typed":        "",
+	"mypkg-1.0/mypkg/data/logo.png":   "png",
+	"mypkg-1.0/PKG-INFO":              "Metadata-Version: 2.1\n",
+	"../evil.py":                      "print('escaped')\n",
+	"mypkg-1.0/../../also_evil.py":    "print('escaped')\n",
+	"mypkg-1.0/mypkg/_speedups.pyx":   "def fast():\n    pass\n",
+	"/absolute/mypkg/outside.py":      "print('absolute')\n",
+	"mypkg-1.0/mypkg/helpers/util.py": "def helper():\n    pass\n",
+}
+
+var extractedArchiveFiles = []string{
+	"mypkg-1.0/mypkg/__init__.py",
+	"mypkg-1.0/mypkg/_speedups.pyx",
+	"mypkg-1.0/mypkg/core.py",
+	"mypkg-1.0/mypkg/helpers/util.py",
+	"mypkg-1.0/pyproject.toml",
+}
+
+func writeZipArchive(t *testing.T, path string) {
+	t.Helper()
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+	writer := zip.NewWriter(file)
+	for name, content := range archiveEntries {
+		entry, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = entry.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+}
+
+func writeTarGzArchive(t *testing.T, path string) {
+	t.Helper()
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+	gz := gzip.NewWriter(file)
+	writer := tar.NewWriter(gz)
+	for name, content := range archiveEntries {
+		require.NoError(t, writer.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := writer.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.WriteHeader(&tar.Header{Name: "mypkg-1.0/mypkg/link.py", Linkname: "/etc/passwd", Typeflag: tar.TypeSymlink}))
+	require.NoError(t, writer.Close())
+	require.NoError(t, gz.Close())
+}
+
+// listFiles returns the regular files below dir, relative to it
+func listFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	var files []string
+	require.NoError(t, filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		files = append(files, filepath.ToSlash(rel))
+		return err
+	}))
+	return files
+}
+
+func TestExtractArchiveTarget(t *testing.T) {
+	for _, name := range []string{"mypkg-1.0-py3-none-any.whl", "mypkg-1.0.zip", "mypkg-1.0.tar.gz", "mypkg-1.0.tgz"} {
+		t.Run(name, func(t *testing.T) {
+			tmp := t.TempDir()
+			archive := filepath.Join(tmp, name)
+			if filepath.Ext(name) == ".whl" || filepath.Ext(name) == ".zip" {
+				writeZipArchive(t, archive)
+			} else {
+				writeTarGzArchive(t, archive)
+			}
+
+			dir := filepath.Join(tmp, "work", "extracted")
+			require.NoError(t, ExtractArchiveTarget(archive, dir))
+			assert.Equal(t, extractedArchiveFiles, listFiles(t, dir))
+			assert.NoFileExists(t, filepath.Join(tmp, "work", "evil.py"))
+			assert.NoFileExists(t, filepath.Join(tmp, "also_evil.py"))
+		})
+	}
+}
+
+func TestExtractArchiveTargetInvalidArchive(t *testing.T) {
+	tmp := t.TempDir()
+	archive := filepath.Join(tmp, "broken.tar.gz")
+	require.NoError(t, os.WriteFile(archive, []byte("not gzip"), 0o644))
+
+	dir := filepath.Join(tmp, "extracted")
+	err := ExtractArchiveTarget(archive, dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to extract")
+	assert.NoDirExists(t, dir)
+}
+
+func TestIsArchiveTarget(t *testing.T) {
+	tmp := t.TempDir()
+	wheel := filepath.Join(tmp, "mypkg-1.0-py3-none-any.whl")
+	require.NoError(t, os.WriteFile(wheel, nil, 0o644))
+	sdistDir := filepath.Join(tmp, "unpacked.tar.gz")
+	require.NoError(t, os.Mkdir(sdistDir, 0o755))
+
+	assert.True(t, IsArchiveTarget(wheel))
+	assert.False(t, IsArchiveTarget(filepath.Join(tmp, "missing.zip")))
+	assert.False(t, IsArchiveTarget(sdistDir))
+	assert.False(t, IsArchiveTarget(tmp))
+	assert.False(t, IsArchiveTarget("https://github.com/org/repo"))
+}
+
+func TestArchiveTargetName(t *testing.T) {
+	assert.Equal(t, "requests-2.31.0", ArchiveTargetName("dist/requests-2.31.0.tar.gz"))
+	assert.Equal(t, "requests-2.31.0", ArchiveTargetName("requests-2.31.0.TGZ"))
+	assert.Equal(t, "requests-2.31.0-py3-none-any", ArchiveTargetName("requests-2.31.0-py3-none-any.whl"))
+	assert.Equal(t, "src", ArchiveTargetName("src"))
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/analyzer"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// DiffPythonSources compares two versions of a Python file by their
+// definitions. A nil source stands for a file missing from that version.
+func DiffPythonSources(ctx context.Context, oldPath string, oldSource []byte, newPath string, newSource []byte) (*domain.ASTDiff, error) {
+	parse := func(path string, source []byte) (*parser.ParseResult, error) {
+		if source == nil {
+			return nil, nil
+		}
+		result, err := parser.New().Parse(ctx, source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		return result, nil
+	}
+
+	oldResult, err := parse(oldPath, oldSource)
+	if err != nil {
+		return nil, err
+	}
+	newResult, err := parse(newPath, newSource)
+	if err != nil {
+		return nil, err
+	}
+	return analyzer.DiffAST(oldPath, oldResult, newPath, newResult), nil
+}
+
+// DiffPythonFiles compares two Python files by their definitions
+func DiffPythonFiles(ctx context.Context, oldPath, newPath string) (*domain.ASTDiff, error) {
+	oldSource, err := os.ReadFile(oldPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", oldPath, err)
+	}
+	newSource, err := os.ReadFile(newPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", newPath, err)
+	}
+	return DiffPythonSources(ctx, oldPath, oldSource, newPath, newSource)
+}
+
+// DiffPythonFilesSinceRevision compares the Python files that differ between
+// a git revision and the work tree with their version at the revision.
+// Paths restrict the comparison to files and directories, as git pathspecs;
+// untracked files are not included. Files are listed relative to the
+// current directory.
+func DiffPythonFilesSinceRevision(ctx context.Context, revision string, paths []string) ([]*domain.ASTDiff, error) {
+	args := []string{"-c", "core.quotePath=false", "diff", "--name-only", "--relative", "--no-renames", "-z", revision, "--"}
+	out, err := exec.CommandContext(ctx, "git", append(args, paths...)...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files changed since %s: %w", revision, gitError(err))
+	}
+
+	diffs := []*domain.ASTDiff{}
+	for _, path := range strings.Split(string(out), "\x00") {
+		if path == "" || !strings.HasSuffix(path, ".py") {
+			continue
+		}
+
+		oldSource, err := exec.CommandContext(ctx, "git", "show", revision+":./"+path).Output()
+		if err != nil {
+			// Added since the revision
+			oldSource = nil
+		}
+		newSource, err := os.ReadFile(filepath.FromSlash(path))
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				return nil, fmt.Errorf("failed to read %s: %w", path, err)
+			}
+			newSource = nil
+		}
+
+		diff, err := DiffPythonSources(ctx, path, oldSource, path, newSource)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs, nil
+}
+
+// gitError adds the message git printed to a failed git invocation
+func gitError(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(bytes.TrimSpace(exitErr.Stderr)) > 0 {
+		return fmt.Errorf("%s", bytes.TrimSpace(exitErr.Stderr))
+	}
+	return err
+}
+
+// WriteASTDiffText writes the changed definitions of each file, followed by
+// totals over all files
+func WriteASTDiffText(w io.Writer, diffs []*domain.ASTDiff) {
+	var total domain.ASTDiffSummary
+	changedFiles := 0
+	for _, diff := range diffs {
+		total.Added += diff.Summary.Added
+		total.Removed += diff.Summary.Removed
+		total.Modified += diff.Summary.Modified
+		total.Renamed += diff.Summary.Renamed
+		total.SignatureChanges += diff.Summary.SignatureChanges
+		if len(diff.Changes) == 0 {
+			continue
+		}
+		changedFiles++
+
+		path := diff.NewPath
+		if diff.OldPath != diff.NewPath {
+			path = diff.OldPath + " -> " + diff.NewPath
+		}
+		fmt.Fprintln(w, path)
+		for _, change := range diff.Changes {
+			name := change.Name
+			if change.OldName != "" {
+				name = change.OldName + " -> " + change.Name
+			}
+			line := fmt.Sprintf("  %-9s %-9s %s", change.Kind, change.Symbol, name)
+			if len(change.Aspects) > 0 {
+				aspects := make([]string, len(change.Aspects))
+				for i, aspect := range change.Aspects {
+					aspects[i] = string(aspect)
+				}
+				line += " (" + strings.Join(aspects, ", ") + ")"
+			}
+			fmt.Fprintln(w, line)
+			if change.HasAspect(domain.ChangeAspectSignature) {
+				fmt.Fprintf(w, "      - %s\n      + %s\n", change.OldSignature, change.NewSignature)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+
+	if changedFiles == 0 {
+		fmt.Fprintln(w, "No semantic changes.")
+		return
+	}
+	fmt.Fprintf(w, "%d file(s): %d added, %d removed, %d modified (%d signature change(s)), %d renamed\n",
+		changedFiles, total.Added, total.Removed, total.Modified, total.SignatureChanges, total.Renamed)
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+func TestDiffPythonFilesSinceRevision(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, content string) {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	git("init", "-q")
+	write("app/cart.py", "def total(items):\n    return sum(items)\n")
+	write("app/gone.py", "def old():\n    pass\n")
+	write("notes.txt", "v1\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "initial")
+	write("app/cart.py", "def total(items, discount=0):\n    return sum(items) - discount\n")
+	write("notes.txt", "v2\n")
+	git("rm", "-q", "app/gone.py")
+	write("app/new.py", "def added():\n    pass\n")
+	git("add", "app/new.py")
+	t.Chdir(dir)
+
+	diffs, err := DiffPythonFilesSinceRevision(context.Background(), "HEAD", nil)
+	if err != nil {
+		t.Fatalf("DiffPythonFilesSinceRevision() error = %v", err)
+	}
+	got := make(map[string]string)
+	for _, diff := range diffs {
+		for _, change := range diff.Changes {
+			got[diff.NewPath] += string(change.Kind) + " " + change.Name + ";"
+		}
+	}
+	want := map[string]string{
+		"app/cart.py": "modified total;",
+		"app/gone.py": "removed old;",
+		"app/new.py":  "added added;",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("changes = %v, want %v", got, want)
+	}
+	for path, changes := range want {
+		if got[path] != changes {
+			t.Errorf("%s: changes = %q, want %q", path, got[path], changes)
+		}
+	}
+
+	diffs, err = DiffPythonFilesSinceRevision(context.Background(), "HEAD", []string{"app/cart.py"})
+	if err != nil || len(diffs) != 1 {
+		t.Fatalf("expected the pathspec to select one file, got %d diffs: %v", len(diffs), err)
+	}
+
+	if _, err := DiffPythonFilesSinceRevision(context.Background(), "no-such-revision", nil); err == nil {
+		t.Error("expected an unknown revision to fail")
+	}
+}
+
+func TestWriteASTDiffText(t *testing.T) {
+	diff, err := DiffPythonSources(context.Background(),
+		"cart.py", []byte("def total(items):\n    return sum(items)\n"),
+		"cart.py", []byte("def total(items, discount=0):\n    return sum(items) - discount\n\ndef added():\n    pass\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	WriteASTDiffText(&buf, []*domain.ASTDiff{diff})
+	for _, want := range []string{
+		"cart.py\n",
+		"  modified  function  total (signature, body)\n",
+		"      - def total(items)\n      + def total(items, discount=0)\n",
+		"  added     function  added\n",
+		"1 file(s): 1 added, 0 removed, 1 modified (1 signature change(s)), 0 renamed\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output is missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	WriteASTDiffText(&buf, []*domain.ASTDiff{{OldPath: "a.py", NewPath: "a.py"}})
+	if buf.String() != "No semantic changes.\n" {
+		t.Errorf("unexpected output for an unchanged file: %q", buf.String())
+	}
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadBatchManifest reads a batch manifest. Relative target paths, config
+// files and the output directory are resolved against the directory of the
+// manifest; targets without a name are named after their path.
+func LoadBatchManifest(path string) (*domain.BatchManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read batch manifest: %w", err)
+	}
+	var manifest domain.BatchManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse batch manifest %s: %w", path, err)
+	}
+	if len(manifest.Targets) == 0 {
+		return nil, fmt.Errorf("batch manifest %s lists no targets", path)
+	}
+	if manifest.Jobs < 0 {
+		return nil, fmt.Errorf("batch manifest %s: jobs must not be negative, got %d", path, manifest.Jobs)
+	}
+
+	base := filepath.Dir(path)
+	resolve := func(p string) string {
+		if p == "" || filepath.IsAbs(p) {
+			return p
+		}
+		return filepath.Join(base, p)
+	}
+
+	names := make(map[string]bool, len(manifest.Targets))
+	for i := range manifest.Targets {
+		target := &manifest.Targets[i]
+		if target.Path == "" {
+			return nil, fmt.Errorf("batch manifest %s: target %d has no path", path, i+1)
+		}
+		remote, isRemote := ParseRemoteTarget(target.Path)
+		if target.Name == "" {
+			if isRemote {
+				target.Name = remote.Name()
+			} else {
+				target.Name = ArchiveTargetName(filepath.Clean(target.Path))
+			}
+		}
+		if names[target.Name] {
+			return nil, fmt.Errorf("batch manifest %s: duplicate target name %q", path, target.Name)
+		}
+		names[target.Name] = true
+
+		if !isRemote {
+			target.Path = resolve(target.Path)
+		}
+		target.Config = resolve(target.Config)
+	}
+	manifest.OutputDir = resolve(manifest.OutputDir)
+	return &manifest, nil
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// BatchReportFileName returns the report file name of a target, unique
+// among targets with different names
+func BatchReportFileName(index int, name, extension string) string {
+	safe := strings.Trim(unsafeFileNameChars.ReplaceAllString(name, "-"), "-.")
+	if safe == "" {
+		safe = "target"
+	}
+	return fmt.Sprintf("%02d-%s.%s", index+1, safe, extension)
+}
+
+// BatchResult is the outcome of analyzing one batch target
+type BatchResult struct {
+	Target   domain.BatchTarget
+	Response *domain.AnalyzeResponse
+	Report   string // Report file name; empty when no report was written
+	Err      error
+}
+
+// BuildBatchIndex ranks the analyzed targets by health score, best first.
+// Ties keep manifest order. Targets that failed are listed last, unranked.
+func BuildBatchIndex(results []BatchResult) []domain.BatchIndexEntry {
+	entries := make([]domain.BatchIndexEntry, 0, len(results))
+	for _, result := range results {
+		entry := domain.BatchIndexEntry{
+			Name:   result.Target.Name,
+			Path:   result.Target.Path,
+			Report: result.Report,
+		}
+		if result.Err != nil {
+			entry.Error = result.Err.Error()
+		}
+		if response := result.Response; response != nil && result.Err == nil {
+			s := response.Summary
+			entry.HealthScore = s.HealthScore
+			entry.Grade = s.Grade
+			entry.ComplexityScore = s.ComplexityScore
+			entry.DeadCodeScore = s.DeadCodeScore
+			entry.DuplicationScore = s.DuplicationScore
+			entry.CouplingScore = s.CouplingScore
+			entry.CohesionScore = s.CohesionScore
+			entry.DependencyScore = s.DependencyScore
+			entry.ArchitectureScore = s.ArchitectureScore
+			entry.TotalFiles = s.TotalFiles
+			entry.DurationMs = response.Duration
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		failedI, failedJ := entries[i].Error != "", entries[j].Error != ""
+		if failedI != failedJ {
+			return failedJ
+		}
+		return !failedI && entries[i].HealthScore > entries[j].HealthScore
+	})
+	rank := 0
+	for i := range entries {
+		if entries[i].Error == "" {
+			rank++
+			entries[i].Rank = rank
+		}
+	}
+	return entries
+}
+
+var batchIndexTemplate = template.Must(template.New("batch").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>pyscn batch report</title>
+    <style>
+        body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, sans-serif; margin: 0; padding: 20px; background: #f5f5f5; color: #333; }
+        .container { max-width: 1200px; margin: 0 auto; background: white; border-radius: 10px; padding: 30px; }
+        h1 { margin-top: 0; }
+        .meta { color: #64748b; margin-bottom: 20px; }
+        table { width: 100%; border-collapse: collapse; }
+        th, td { padding: 10px 12px; text-align: left; border-bottom: 1px solid #ddd; }
+        th { background: #f8f9fa; font-weight: 600; }
+        .grade { font-weight: bold; }
+        .grade-A, .grade-B { color: #15803d; }
+        .grade-C { color: #b45309; }
+        .grade-D, .grade-F { color: #b91c1c; }
+        .error { color: #b91c1c; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <h1>pyscn batch report</h1>
+        <div class="meta">{{len .Targets}} targets, generated {{.GeneratedAt.Format "2006-01-02 15:04:05"}}{{if .Version}} by pyscn {{.Version}}{{end}}</div>
+        <table>
+            <thead>
+                <tr>
+                    <th>Rank</th>
+                    <th>Target</th>
+                    <th>Health</th>
+                    <th>Complexity</th>
+                    <th>Dead code</th>
+                    <th>Duplication</th>
+                    <th>Coupling</th>
+                    <th>Cohesion</th>
+                    <th>Dependencies</th>
+                    <th>Architecture</th>
+                    <th>Files</th>
+                </tr>
+            </thead>
+            <tbody>
+                {{range .Targets}}
+                <tr>
+                    <td>{{if .Rank}}{{.Rank}}{{else}}-{{end}}</td>
+                    <td>{{if .Report}}<a href="{{.Report}}">{{.Name}}</a>{{else}}{{.Name}}{{end}}<br><small>{{.Path}}</small></td>
+                    {{if .Error}}
+                    <td colspan="9" class="error">{{.Error}}</td>
+                    {{else}}
+                    <td><span class="grade grade-{{.Grade}}">{{.HealthScore}} ({{.Grade}})</span></td>
+                    <td>{{.ComplexityScore}}</td>
+                    <td>{{.DeadCodeScore}}</td>
+                    <td>{{.DuplicationScore}}</td>
+                    <td>{{.CouplingScore}}</td>
+                    <td>{{.CohesionScore}}</td>
+                    <td>{{.DependencyScore}}</td>
+                    <td>{{.ArchitectureScore}}</td>
+                    <td>{{.TotalFiles}}</td>
+                    {{end}}
+                </tr>
+                {{end}}
+            </tbody>
+        </table>
+    </div>
+</body>
+</html>
+`))
+
+// WriteBatchIndexHTML writes the ranking of a batch run as a standalone HTML
+// page linking to the per-target reports
+func WriteBatchIndexHTML(w io.Writer, index *domain.BatchIndex) error {
+	return batchIndexTemplate.Execute(w, index)
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+func TestLoadBatchManifest(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, "repos.yaml")
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	manifest, err := LoadBatchManifest(write(`output_dir: out
+jobs: 2
+targets:
+  - path: services/api
+    config: services/api/.pyscn.toml
+  - name: worker
+    path: /abs/worker
+  - path: https://github.com/org/lib@v1
+`))
+	if err != nil {
+		t.Fatalf("LoadBatchManifest failed: %v", err)
+	}
+	if manifest.Jobs != 2 || manifest.OutputDir != filepath.Join(dir, "out") {
+		t.Errorf("Expected jobs 2 and output dir under the manifest, got %d %q", manifest.Jobs, manifest.OutputDir)
+	}
+	want := []domain.BatchTarget{
+		{Name: "api", Path: filepath.Join(dir, "services/api"), Config: filepath.Join(dir, "services/api/.pyscn.toml")},
+		{Name: "worker", Path: "/abs/worker"},
+		{Name: "lib", Path: "https://github.com/org/lib@v1"},
+	}
+	for i, target := range want {
+		if manifest.Targets[i] != target {
+			t.Errorf("Target %d: expected %+v, got %+v", i, target, manifest.Targets[i])
+		}
+	}
+
+	for content, message := range map[string]string{
+		"targets: []\n":                          "no targets",
+		"targets:\n  - name: a\n":                "has no path",
+		"targets:\n  - path: a\n  - path: x/a\n": "duplicate target name",
+		"jobs: -1\ntargets:\n  - path: a\n":      "must not be negative",
+	} {
+		if _, err := LoadBatchManifest(write(content)); err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("Expected %q for %q, got %v", message, content, err)
+		}
+	}
+}
+
+func TestBatchReportFileName(t *testing.T) {
+	if got := BatchReportFileName(0, "org/repo name", "html"); got != "01-org-repo-name.html" {
+		t.Errorf("Expected unsafe characters to be replaced, got %q", got)
+	}
+	if got := BatchReportFileName(11, "..", "json"); got != "12-target.json" {
+		t.Errorf("Expected a fallback name, got %q", got)
+	}
+}
+
+func TestBuildBatchIndex(t *testing.T) {
+	scored := func(name string, score int) BatchResult {
+		return BatchResult{
+			Target:   domain.BatchTarget{Name: name},
+			Response: &domain.AnalyzeResponse{Summary: domain.AnalyzeSummary{HealthScore: score, Grade: "B"}},
+			Report:   name + ".html",
+		}
+	}
+	entries := BuildBatchIndex([]BatchResult{
+		scored("low", 60),
+		{Target: domain.BatchTarget{Name: "broken"}, Err: errors.New("no Python files")},
+		scored("high", 90),
+		scored("tied", 60),
+	})
+
+	var order []string
+	for _, entry := range entries {
+		order = append(order, entry.Name)
+	}
+	if strings.Join(order, ",") != "high,low,tied,broken" {
+		t.Fatalf("Expected ranking by score with failures last, got %v", order)
+	}
+	if entries[0].Rank != 1 || entries[2].Rank != 3 || entries[3].Rank != 0 {
+		t.Errorf("Expected ranks 1..3 and none for the failure, got %+v", entries)
+	}
+	if entries[3].Error != "no Python files" || entries[3].Report != "" {
+		t.Errorf("Expected the failure to carry its error, got %+v", entries[3])
+	}
+
+	var buf bytes.Buffer
+	if err := WriteBatchIndexHTML(&buf, &domain.BatchIndex{Targets: entries}); err != nil {
+		t.Fatalf("WriteBatchIndexHTML failed: %v", err)
+	}
+	html := buf.String()
+	if !strings.Contains(html, `<a href="high.html">high</a>`) || !strings.Contains(html, "no Python files") {
+		t.Errorf("Expected links to reports and errors in the index, got:\n%s", html)
+	}
+}
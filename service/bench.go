@@ -0,0 +1,279 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// Finding kinds recorded for each benchmark run
+const (
+	BenchFindingFiles          = "files"
+	BenchFindingFunctions      = "functions"
+	BenchFindingHighComplexity = "high_complexity"
+	BenchFindingDeadCode       = "dead_code"
+	BenchFindingClonePairs     = "clone_pairs"
+	BenchFindingCloneGroups    = "clone_groups"
+	BenchFindingHighCoupling   = "high_coupling"
+	BenchFindingLowCohesion    = "low_cohesion"
+	BenchFindingCycleModules   = "cycle_modules"
+)
+
+// LoadBenchCorpus reads a benchmark corpus file and validates its entries
+func LoadBenchCorpus(path string) (*domain.BenchCorpus, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, domain.NewFileNotFoundError(path, err)
+	}
+
+	var corpus domain.BenchCorpus
+	if err := toml.Unmarshal(data, &corpus); err != nil {
+		return nil, domain.NewConfigError(fmt.Sprintf("failed to parse corpus file %s", path), err)
+	}
+
+	seen := make(map[string]bool)
+	for i, repo := range corpus.Repos {
+		switch {
+		case repo.Name == "":
+			return nil, domain.NewConfigError(fmt.Sprintf("corpus entry %d has no name", i+1), nil)
+		case seen[repo.Name]:
+			return nil, domain.NewConfigError(fmt.Sprintf("duplicate corpus entry %q", repo.Name), nil)
+		case repo.URL == "" && repo.Path == "":
+			return nil, domain.NewConfigError(fmt.Sprintf("corpus entry %q needs either url or path", repo.Name), nil)
+		case repo.URL != "" && repo.Path != "":
+			return nil, domain.NewConfigError(fmt.Sprintf("corpus entry %q sets both url and path", repo.Name), nil)
+		case repo.URL != "" && repo.Ref == "":
+			return nil, domain.NewConfigError(fmt.Sprintf("corpus entry %q must pin a ref", repo.Name), nil)
+		}
+		seen[repo.Name] = true
+	}
+
+	return &corpus, nil
+}
+
+// LoadBenchBaseline reads a stored baseline. A missing file yields an empty
+// baseline so that the first run can create it.
+func LoadBenchBaseline(path string) (*domain.BenchBaseline, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &domain.BenchBaseline{}, nil
+	}
+	if err != nil {
+		return nil, domain.NewFileNotFoundError(path, err)
+	}
+
+	var baseline domain.BenchBaseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, domain.NewConfigError(fmt.Sprintf("failed to parse baseline file %s", path), err)
+	}
+	return &baseline, nil
+}
+
+// SaveBenchBaseline writes a baseline with results sorted by repository
+func SaveBenchBaseline(path string, baseline *domain.BenchBaseline) error {
+	sort.Slice(baseline.Results, func(i, j int) bool {
+		return baseline.Results[i].Repo < baseline.Results[j].Repo
+	})
+
+	file, err := os.Create(path)
+	if err != nil {
+		return domain.NewOutputError(fmt.Sprintf("failed to create baseline file %s", path), err)
+	}
+	defer file.Close()
+
+	return WriteJSON(file, baseline)
+}
+
+// PrepareBenchRepo returns the directory to analyze for a corpus entry.
+// Remote entries are shallow-cloned at their pinned ref into cacheDir and
+// reused on later runs.
+func PrepareBenchRepo(ctx context.Context, repo domain.BenchRepo, corpusDir, cacheDir string) (string, error) {
+	var root string
+	if repo.IsRemote() {
+		root = filepath.Join(cacheDir, benchCacheName(repo))
+		if _, err := os.Stat(root); errors.Is(err, os.ErrNotExist) {
+			if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+				return "", fmt.Errorf("failed to create cache directory: %w", err)
+			}
+			cmd := exec.CommandContext(ctx, "git", "clone", "--quiet", "--depth", "1",
+				"--branch", repo.Ref, repo.URL, root)
+			if out, err := cmd.CombinedOutput(); err != nil {
+				_ = os.RemoveAll(root)
+				return "", fmt.Errorf("failed to clone %s at %s: %w: %s", repo.URL, repo.Ref, err, strings.TrimSpace(string(out)))
+			}
+		}
+	} else {
+		root = repo.Path
+		if !filepath.IsAbs(root) {
+			root = filepath.Join(corpusDir, root)
+		}
+	}
+
+	if repo.Subdir != "" {
+		root = filepath.Join(root, repo.Subdir)
+	}
+	if _, err := os.Stat(root); err != nil {
+		return "", domain.NewFileNotFoundError(root, err)
+	}
+	return root, nil
+}
+
+// benchCacheName returns the cache directory name for a pinned repository
+func benchCacheName(repo domain.BenchRepo) string {
+	name := repo.Name + "@" + repo.Ref
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// BenchFindingCounts extracts the finding counts tracked by the benchmark
+// from an analysis summary
+func BenchFindingCounts(summary *domain.AnalyzeSummary) map[string]int {
+	return map[string]int{
+		BenchFindingFiles:          summary.AnalyzedFiles,
+		BenchFindingFunctions:      summary.TotalFunctions,
+		BenchFindingHighComplexity: summary.HighComplexityCount,
+		BenchFindingDeadCode:       summary.DeadCodeCount,
+		BenchFindingClonePairs:     summary.ClonePairs,
+		BenchFindingCloneGroups:    summary.CloneGroups,
+		BenchFindingHighCoupling:   summary.HighCouplingClasses,
+		BenchFindingLowCohesion:    summary.HighLCOMClasses,
+		BenchFindingCycleModules:   summary.DepsModulesInCycles,
+	}
+}
+
+// CompareBench compares run results against a baseline. Time and memory are
+// allowed to drift within the tolerance; any change in a finding count is
+// reported, since analysis output is deterministic for a pinned ref.
+func CompareBench(baseline *domain.BenchBaseline, results []domain.BenchResult, tolerance domain.BenchTolerance) *domain.BenchReport {
+	report := &domain.BenchReport{
+		Results:     results,
+		Regressions: []domain.BenchRegression{},
+	}
+
+	for _, result := range results {
+		if result.Error != "" {
+			continue
+		}
+		base := baseline.Result(result.Repo)
+		if base == nil {
+			report.Missing = append(report.Missing, result.Repo)
+			continue
+		}
+
+		if exceedsTolerance(base.WallClockMs, result.WallClockMs, tolerance.WallClock) &&
+			result.WallClockMs-base.WallClockMs >= tolerance.MinWallClockMs {
+			report.Regressions = append(report.Regressions, domain.BenchRegression{
+				Repo: result.Repo, Metric: "wall_clock_ms",
+				Baseline: float64(base.WallClockMs), Current: float64(result.WallClockMs),
+			})
+		}
+		if base.PeakRSSKB > 0 && result.PeakRSSKB > 0 &&
+			exceedsTolerance(base.PeakRSSKB, result.PeakRSSKB, tolerance.PeakRSS) {
+			report.Regressions = append(report.Regressions, domain.BenchRegression{
+				Repo: result.Repo, Metric: "peak_rss_kb",
+				Baseline: float64(base.PeakRSSKB), Current: float64(result.PeakRSSKB),
+			})
+		}
+
+		kinds := make(map[string]bool)
+		for kind := range base.Findings {
+			kinds[kind] = true
+		}
+		for kind := range result.Findings {
+			kinds[kind] = true
+		}
+		for _, kind := range sortedBenchKinds(kinds) {
+			if base.Findings[kind] != result.Findings[kind] {
+				report.Regressions = append(report.Regressions, domain.BenchRegression{
+					Repo: result.Repo, Metric: "findings." + kind,
+					Baseline: float64(base.Findings[kind]), Current: float64(result.Findings[kind]),
+				})
+			}
+		}
+	}
+
+	return report
+}
+
+// exceedsTolerance reports whether current grew beyond base by more than the
+// allowed ratio
+func exceedsTolerance(base, current int64, ratio float64) bool {
+	if base <= 0 {
+		return false
+	}
+	return float64(current) > float64(base)*(1+ratio)
+}
+
+func sortedBenchKinds(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// WriteBenchReportText writes a benchmark report as a table followed by the
+// list of regressions
+func WriteBenchReportText(w io.Writer, report *domain.BenchReport, baseline *domain.BenchBaseline) {
+	fmt.Fprintln(w, "BENCHMARK RESULTS")
+	fmt.Fprintln(w, strings.Repeat("=", 80))
+	fmt.Fprintf(w, "%-24s %12s %12s %12s %12s\n", "Repository", "Time (ms)", "Δ time", "Peak RSS", "Δ RSS")
+	fmt.Fprintln(w, strings.Repeat("-", 80))
+	for _, result := range report.Results {
+		if result.Error != "" {
+			fmt.Fprintf(w, "%-24s error: %s\n", result.Repo, result.Error)
+			continue
+		}
+		timeDelta, rssDelta := "-", "-"
+		if base := baseline.Result(result.Repo); base != nil {
+			timeDelta = formatBenchDelta(base.WallClockMs, result.WallClockMs)
+			rssDelta = formatBenchDelta(base.PeakRSSKB, result.PeakRSSKB)
+		}
+		fmt.Fprintf(w, "%-24s %12d %12s %12s %12s\n", result.Repo, result.WallClockMs, timeDelta,
+			formatBenchRSS(result.PeakRSSKB), rssDelta)
+	}
+	fmt.Fprintln(w)
+
+	if len(report.Missing) > 0 {
+		fmt.Fprintf(w, "No baseline for: %s (run with --update-baseline to record)\n\n", strings.Join(report.Missing, ", "))
+	}
+
+	if len(report.Regressions) == 0 {
+		fmt.Fprintln(w, "No regressions against baseline.")
+		return
+	}
+	fmt.Fprintf(w, "REGRESSIONS (%d)\n", len(report.Regressions))
+	fmt.Fprintln(w, strings.Repeat("-", 80))
+	for _, r := range report.Regressions {
+		fmt.Fprintf(w, "  %-24s %-28s %.0f -> %.0f\n", r.Repo, r.Metric, r.Baseline, r.Current)
+	}
+}
+
+func formatBenchDelta(base, current int64) string {
+	if base <= 0 || current <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%+.1f%%", (float64(current)-float64(base))/float64(base)*100)
+}
+
+func formatBenchRSS(kb int64) string {
+	if kb <= 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.1f MB", float64(kb)/1024)
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+func TestLoadBenchCorpus(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+		repos   int
+	}{
+		{
+			name: "valid",
+			content: `
+[[repo]]
+name = "local"
+path = "fixtures"
+
+[[repo]]
+name = "requests"
+url = "https://github.com/psf/requests.git"
+ref = "v2.32.3"
+subdir = "src"
+`,
+			repos: 2,
+		},
+		{
+			name:    "missing ref",
+			content: "[[repo]]\nname = \"x\"\nurl = \"https://example.com/x.git\"\n",
+			wantErr: "must pin a ref",
+		},
+		{
+			name:    "missing source",
+			content: "[[repo]]\nname = \"x\"\n",
+			wantErr: "needs either url or path",
+		},
+		{
+			name:    "duplicate name",
+			content: "[[repo]]\nname = \"x\"\npath = \"a\"\n[[repo]]\nname = \"x\"\npath = \"b\"\n",
+			wantErr: "duplicate corpus entry",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "corpus.toml")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			corpus, err := LoadBenchCorpus(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(corpus.Repos) != tt.repos {
+				t.Errorf("expected %d repos, got %d", tt.repos, len(corpus.Repos))
+			}
+		})
+	}
+}
+
+func TestBenchBaselineRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+
+	baseline, err := LoadBenchBaseline(path)
+	if err != nil {
+		t.Fatalf("missing baseline should load as empty: %v", err)
+	}
+	if len(baseline.Results) != 0 {
+		t.Fatalf("expected empty baseline, got %d results", len(baseline.Results))
+	}
+
+	baseline.Results = []domain.BenchResult{
+		{Repo: "b", WallClockMs: 20, Findings: map[string]int{"dead_code": 1}},
+		{Repo: "a", WallClockMs: 10, PeakRSSKB: 2048, Findings: map[string]int{"dead_code": 2}},
+	}
+	if err := SaveBenchBaseline(path, baseline); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadBenchBaseline(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Results) != 2 || loaded.Results[0].Repo != "a" {
+		t.Fatalf("expected results sorted by repo, got %+v", loaded.Results)
+	}
+	if got := loaded.Result("a"); got == nil || got.PeakRSSKB != 2048 || got.Findings["dead_code"] != 2 {
+		t.Errorf("unexpected result for a: %+v", got)
+	}
+}
+
+func TestCompareBench(t *testing.T) {
+	baseline := &domain.BenchBaseline{Results: []domain.BenchResult{
+		{Repo: "stable", WallClockMs: 1000, PeakRSSKB: 100000, Findings: map[string]int{"dead_code": 3}},
+		{Repo: "slow", WallClockMs: 1000, PeakRSSKB: 100000, Findings: map[string]int{"dead_code": 3}},
+		{Repo: "tiny", WallClockMs: 100, Findings: map[string]int{}},
+	}}
+	results := []domain.BenchResult{
+		{Repo: "stable", WallClockMs: 1100, PeakRSSKB: 110000, Findings: map[string]int{"dead_code": 3}},
+		{Repo: "slow", WallClockMs: 1500, PeakRSSKB: 130000, Findings: map[string]int{"dead_code": 2, "clone_pairs": 1}},
+		{Repo: "tiny", WallClockMs: 250, Findings: map[string]int{}},
+		{Repo: "new", WallClockMs: 10, Findings: map[string]int{}},
+		{Repo: "broken", Error: "clone failed"},
+	}
+
+	report := CompareBench(baseline, results, domain.DefaultBenchTolerance())
+
+	var metrics []string
+	for _, r := range report.Regressions {
+		if r.Repo != "slow" {
+			t.Errorf("unexpected regression for %s: %+v", r.Repo, r)
+		}
+		metrics = append(metrics, r.Metric)
+	}
+	want := []string{"wall_clock_ms", "peak_rss_kb", "findings.clone_pairs", "findings.dead_code"}
+	if strings.Join(metrics, ",") != strings.Join(want, ",") {
+		t.Errorf("expected regressions %v, got %v", want, metrics)
+	}
+	if len(report.Missing) != 1 || report.Missing[0] != "new" {
+		t.Errorf("expected missing [new], got %v", report.Missing)
+	}
+
+	var buf bytes.Buffer
+	WriteBenchReportText(&buf, report, baseline)
+	output := buf.String()
+	for _, expected := range []string{"BENCHMARK RESULTS", "REGRESSIONS (4)", "+50.0%", "error: clone failed", "No baseline for: new"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected report to contain %q:\n%s", expected, output)
+		}
+	}
+}
+
+func TestPrepareBenchRepoLocal(t *testing.T) {
+	corpusDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(corpusDir, "project", "src"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := PrepareBenchRepo(context.Background(),
+		domain.BenchRepo{Name: "project", Path: "project", Subdir: "src"}, corpusDir, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dir != filepath.Join(corpusDir, "project", "src") {
+		t.Errorf("unexpected directory %s", dir)
+	}
+
+	if _, err := PrepareBenchRepo(context.Background(),
+		domain.BenchRepo{Name: "missing", Path: "missing"}, corpusDir, t.TempDir()); err == nil {
+		t.Error("expected error for missing local path")
+	}
+}
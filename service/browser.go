@@ -12,10 +12,24 @@ func IsSSH() bool {
 	return os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != ""
 }
 
+// machineMode disables every interactive behavior, see SetMachineMode
+var machineMode bool
+
+// SetMachineMode forces non-interactive behavior regardless of the
+// terminal: no browser opening, no progress bars, no TTY detection
+func SetMachineMode(enabled bool) {
+	machineMode = enabled
+}
+
+// IsMachineMode reports whether non-interactive behavior is forced
+func IsMachineMode() bool {
+	return machineMode
+}
+
 // IsInteractiveEnvironment returns true if the environment appears to be
 // an interactive TTY session (and not CI)
 func IsInteractiveEnvironment() bool {
-	if os.Getenv("CI") != "" {
+	if machineMode || os.Getenv("CI") != "" {
 		return false
 	}
 	if fi, err := os.Stderr.Stat(); err == nil {
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// CalibrateThresholds measures the distribution of complexity per function,
+// coupling per class and similarity per clone pair, and suggests thresholds
+// so that about (100 - percentile)% of them are reported. The low thresholds
+// are taken at percentile and the medium thresholds halfway between it and
+// the maximum. current holds the values in effect, keyed by "section.key".
+func CalibrateThresholds(response *domain.AnalyzeResponse, percentile float64, current map[string]float64) *domain.CalibrationReport {
+	report := &domain.CalibrationReport{
+		Percentile:    percentile,
+		Distributions: []domain.MetricDistribution{},
+		Suggestions:   []domain.ThresholdSuggestion{},
+	}
+	if response == nil {
+		return report
+	}
+	upper := percentile + (100-percentile)/2
+
+	suggest := func(section, key, metric string, at, value float64) {
+		report.Suggestions = append(report.Suggestions, domain.ThresholdSuggestion{
+			Section:    section,
+			Key:        key,
+			Metric:     metric,
+			Percentile: at,
+			Current:    current[section+"."+key],
+			Suggested:  value,
+		})
+	}
+
+	if response.Complexity != nil {
+		values := make([]float64, 0, len(response.Complexity.Functions))
+		for _, function := range response.Complexity.Functions {
+			if function.Name != domain.ModuleFunctionName {
+				values = append(values, float64(function.Metrics.Complexity))
+			}
+		}
+		if sorted := sortedSamples(values); len(sorted) > 0 {
+			report.Distributions = append(report.Distributions, metricDistribution(domain.CalibrationMetricComplexity, sorted))
+			low := math.Max(1, percentileValue(sorted, percentile))
+			suggest("complexity", "low_threshold", domain.CalibrationMetricComplexity, percentile, low)
+			suggest("complexity", "medium_threshold", domain.CalibrationMetricComplexity, upper,
+				math.Max(low+1, percentileValue(sorted, upper)))
+		}
+	}
+
+	if response.CBO != nil {
+		values := make([]float64, 0, len(response.CBO.Classes))
+		for _, class := range response.CBO.Classes {
+			values = append(values, float64(class.Metrics.CouplingCount))
+		}
+		if sorted := sortedSamples(values); len(sorted) > 0 {
+			report.Distributions = append(report.Distributions, metricDistribution(domain.CalibrationMetricCBO, sorted))
+			low := percentileValue(sorted, percentile)
+			suggest("cbo", "low_threshold", domain.CalibrationMetricCBO, percentile, low)
+			suggest("cbo", "medium_threshold", domain.CalibrationMetricCBO, upper,
+				math.Max(low+1, percentileValue(sorted, upper)))
+		}
+	}
+
+	if response.Clone != nil {
+		values := make([]float64, 0, len(response.Clone.ClonePairs))
+		for _, pair := range response.Clone.ClonePairs {
+			if pair != nil {
+				values = append(values, pair.Similarity)
+			}
+		}
+		if sorted := sortedSamples(values); len(sorted) > 0 {
+			report.Distributions = append(report.Distributions, metricDistribution(domain.CalibrationMetricCloneSimilarity, sorted))
+			// Round down so the pair at the percentile is still reported
+			similarity := math.Floor(percentileValue(sorted, percentile)*100) / 100
+			suggest("clones", "similarity_threshold", domain.CalibrationMetricCloneSimilarity, percentile, similarity)
+		}
+	}
+
+	return report
+}
+
+func sortedSamples(values []float64) []float64 {
+	sort.Float64s(values)
+	return values
+}
+
+// percentileValue returns the nearest-rank percentile of sorted values
+func percentileValue(sorted []float64, percentile float64) float64 {
+	rank := int(math.Ceil(percentile / 100 * float64(len(sorted))))
+	return sorted[min(max(rank-1, 0), len(sorted)-1)]
+}
+
+func metricDistribution(metric string, sorted []float64) domain.MetricDistribution {
+	return domain.MetricDistribution{
+		Metric:  metric,
+		Samples: len(sorted),
+		Min:     sorted[0],
+		Median:  percentileValue(sorted, 50),
+		P75:     percentileValue(sorted, 75),
+		P90:     percentileValue(sorted, 90),
+		P95:     percentileValue(sorted, 95),
+		P99:     percentileValue(sorted, 99),
+		Max:     sorted[len(sorted)-1],
+	}
+}
+
+// WriteCalibrationTOML writes the suggested thresholds as a snippet to paste
+// into .pyscn.toml, with the current values as comments
+func WriteCalibrationTOML(w io.Writer, report *domain.CalibrationReport) {
+	if len(report.Suggestions) == 0 {
+		fmt.Fprintln(w, "# pyscn calibrate found no functions, classes or clone pairs to calibrate against")
+		return
+	}
+	fmt.Fprintf(w, "# Suggested by pyscn calibrate at percentile %s\n", formatCalibrationValue(report.Percentile))
+	section := ""
+	for _, suggestion := range report.Suggestions {
+		if suggestion.Section != section {
+			if section != "" {
+				fmt.Fprintln(w)
+			}
+			section = suggestion.Section
+			fmt.Fprintf(w, "[%s]\n", section)
+		}
+		setting := fmt.Sprintf("%s = %s", suggestion.Key, formatCalibrationValue(suggestion.Suggested))
+		fmt.Fprintf(w, "%-34s # current: %s\n", setting, formatCalibrationValue(suggestion.Current))
+	}
+}
+
+// WriteCalibrationText writes the measured distributions followed by the
+// configuration snippet
+func WriteCalibrationText(w io.Writer, report *domain.CalibrationReport) {
+	if len(report.Distributions) > 0 {
+		fmt.Fprintln(w, "DISTRIBUTIONS")
+		fmt.Fprintln(w, strings.Repeat("-", 80))
+		fmt.Fprintf(w, "  %-18s %8s %8s %8s %8s %8s %8s %8s\n", "Metric", "Samples", "Median", "P75", "P90", "P95", "P99", "Max")
+		for _, d := range report.Distributions {
+			fmt.Fprintf(w, "  %-18s %8d %8s %8s %8s %8s %8s %8s\n", d.Metric, d.Samples,
+				formatCalibrationValue(d.Median), formatCalibrationValue(d.P75), formatCalibrationValue(d.P90),
+				formatCalibrationValue(d.P95), formatCalibrationValue(d.P99), formatCalibrationValue(d.Max))
+		}
+		fmt.Fprintln(w)
+	}
+	WriteCalibrationTOML(w, report)
+}
+
+// formatCalibrationValue prints whole values without decimals and others
+// with at most three
+func formatCalibrationValue(value float64) string {
+	return strconv.FormatFloat(math.Round(value*1000)/1000, 'f', -1, 64)
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+func calibrationTestResponse() *domain.AnalyzeResponse {
+	response := &domain.AnalyzeResponse{
+		Complexity: &domain.ComplexityResponse{},
+		CBO:        &domain.CBOResponse{},
+		Clone:      &domain.CloneResponse{},
+	}
+	// Complexity 1..20, plus module-level code that is not a function
+	for i := 1; i <= 20; i++ {
+		response.Complexity.Functions = append(response.Complexity.Functions, domain.FunctionComplexity{
+			Name: "f", Metrics: domain.ComplexityMetrics{Complexity: i},
+		})
+	}
+	response.Complexity.Functions = append(response.Complexity.Functions, domain.FunctionComplexity{
+		Name: domain.ModuleFunctionName, Metrics: domain.ComplexityMetrics{Complexity: 99},
+	})
+	// Every class has coupling 2
+	for range 5 {
+		response.CBO.Classes = append(response.CBO.Classes, domain.ClassCoupling{Metrics: domain.CBOMetrics{CouplingCount: 2}})
+	}
+	for _, similarity := range []float64{0.7, 0.8, 0.876, 0.9, 0.95} {
+		response.Clone.ClonePairs = append(response.Clone.ClonePairs, &domain.ClonePair{Similarity: similarity})
+	}
+	return response
+}
+
+func TestCalibrateThresholds(t *testing.T) {
+	current := map[string]float64{"complexity.low_threshold": 9, "clones.similarity_threshold": 0.65}
+	report := CalibrateThresholds(calibrationTestResponse(), 60, current)
+
+	if len(report.Distributions) != 3 {
+		t.Fatalf("expected 3 distributions, got %d", len(report.Distributions))
+	}
+	complexity := report.Distributions[0]
+	if complexity.Samples != 20 || complexity.Max != 20 || complexity.Median != 10 || complexity.P90 != 18 {
+		t.Errorf("complexity distribution = %+v", complexity)
+	}
+
+	suggested := make(map[string]domain.ThresholdSuggestion)
+	for _, suggestion := range report.Suggestions {
+		suggested[suggestion.Section+"."+suggestion.Key] = suggestion
+	}
+	want := map[string]float64{
+		"complexity.low_threshold":    12, // 60th percentile
+		"complexity.medium_threshold": 16, // 80th percentile
+		"cbo.low_threshold":           2,
+		"cbo.medium_threshold":        3, // kept above the low threshold
+		"clones.similarity_threshold": 0.87,
+	}
+	if len(suggested) != len(want) {
+		t.Fatalf("suggestions = %+v", report.Suggestions)
+	}
+	for key, value := range want {
+		if got := suggested[key].Suggested; got != value {
+			t.Errorf("%s = %v, want %v", key, got, value)
+		}
+	}
+	if got := suggested["complexity.low_threshold"].Current; got != 9 {
+		t.Errorf("current complexity.low_threshold = %v, want 9", got)
+	}
+	if got := suggested["complexity.medium_threshold"].Percentile; got != 80 {
+		t.Errorf("medium threshold percentile = %v, want 80", got)
+	}
+}
+
+func TestCalibrateThresholdsWithoutSamples(t *testing.T) {
+	report := CalibrateThresholds(&domain.AnalyzeResponse{Complexity: &domain.ComplexityResponse{}}, 90, nil)
+	if len(report.Distributions) != 0 || len(report.Suggestions) != 0 {
+		t.Fatalf("expected an empty report, got %+v", report)
+	}
+
+	var buf bytes.Buffer
+	WriteCalibrationText(&buf, report)
+	if !strings.Contains(buf.String(), "found no functions") {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestWriteCalibrationTOML(t *testing.T) {
+	report := CalibrateThresholds(calibrationTestResponse(), 90, map[string]float64{"cbo.low_threshold": 3})
+
+	var buf bytes.Buffer
+	WriteCalibrationTOML(&buf, report)
+	output := buf.String()
+	for _, want := range []string{
+		"# Suggested by pyscn calibrate at percentile 90\n",
+		"[complexity]\nlow_threshold = 18",
+		"\n\n[cbo]\nlow_threshold = 2                  # current: 3\n",
+		"[clones]\nsimilarity_threshold = 0.95",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in:\n%s", want, output)
+		}
+	}
+}
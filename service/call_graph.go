@@ -0,0 +1,362 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/analyzer"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// BuildCallGraph parses the files and returns their call graph. Entry points
+// are resolved with analyzer.CallGraph.EntryPoint; when there are any, every
+// node is marked reachable or not, and the reachable functions and lines
+// are summed up. With stringRefs, string literals naming a function or class
+// are edges that reachability follows. Files inside the current directory
+// are named relative to it.
+func BuildCallGraph(ctx context.Context, files, entryPoints []string, stringRefs bool) (*domain.CallGraphReport, error) {
+	cwd, _ := os.Getwd()
+	graph := analyzer.NewCallGraph()
+	p := parser.New()
+	for _, file := range files {
+		source, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		result, err := p.Parse(ctx, source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		cfgs, err := analyzer.NewCFGBuilder().BuildAll(result.AST)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build control flow graphs for %s: %w", file, err)
+		}
+		graph.AddFile(cwdRelativePath(cwd, file), result.AST, cfgs)
+	}
+	graph.Resolve()
+
+	report := &domain.CallGraphReport{Roots: []string{}}
+	for _, spec := range entryPoints {
+		id, err := graph.EntryPoint(spec)
+		if err != nil {
+			return nil, fmt.Errorf("entry point %q: %w", spec, err)
+		}
+		report.Roots = append(report.Roots, id)
+	}
+
+	report.Nodes = callGraphNodes(graph)
+	report.Edges = callGraphEdges(graph, report.Nodes, stringRefs)
+	if len(report.Roots) > 0 {
+		report.Reachability = markReachable(report, graph.Reachable(report.Roots, stringRefs))
+	}
+	return report, nil
+}
+
+// callGraphNodes returns the functions and classes of the graph ordered by
+// file and position, with the module-level code first in each file
+func callGraphNodes(graph *analyzer.CallGraph) []domain.CallGraphNode {
+	nodes := make([]domain.CallGraphNode, 0, len(graph.Functions)+len(graph.Classes))
+	for _, fn := range graph.Functions {
+		kind := domain.CallGraphNodeFunction
+		if fn.Name == domain.ModuleFunctionName {
+			kind = domain.CallGraphNodeModule
+		} else if idx := strings.LastIndex(fn.Name, "."); idx != -1 {
+			if _, ok := graph.Classes[analyzer.CallGraphID(fn.FilePath, fn.Name[:idx])]; ok {
+				kind = domain.CallGraphNodeMethod
+			}
+		}
+		nodes = append(nodes, domain.CallGraphNode{
+			ID:       fn.ID,
+			Kind:     kind,
+			Module:   analyzer.APIModuleName(fn.FilePath),
+			Name:     fn.Name,
+			Location: fn.Location,
+		})
+	}
+	for _, class := range graph.Classes {
+		nodes = append(nodes, domain.CallGraphNode{
+			ID:       class.ID,
+			Kind:     domain.CallGraphNodeClass,
+			Module:   analyzer.APIModuleName(class.FilePath),
+			Name:     class.Name,
+			Location: class.Location,
+		})
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		a, b := nodes[i].Location, nodes[j].Location
+		if a.FilePath != b.FilePath {
+			return a.FilePath < b.FilePath
+		}
+		if a.StartLine != b.StartLine {
+			return a.StartLine < b.StartLine
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+	countOwnLines(nodes)
+	return nodes
+}
+
+// countOwnLines sets the lines of each function and method: the lines of
+// its definition whose innermost enclosing function it is
+func countOwnLines(nodes []domain.CallGraphNode) {
+	owners := make(map[string]map[int]int)
+	for i, node := range nodes {
+		if node.Kind != domain.CallGraphNodeFunction && node.Kind != domain.CallGraphNodeMethod {
+			continue
+		}
+		file := owners[node.Location.FilePath]
+		if file == nil {
+			file = make(map[int]int)
+			owners[node.Location.FilePath] = file
+		}
+		// Nodes are ordered by start line, so nested functions come after
+		// the functions enclosing them and take their lines over
+		for line := node.Location.StartLine; line <= node.Location.EndLine; line++ {
+			file[line] = i
+		}
+	}
+	for _, file := range owners {
+		for _, i := range file {
+			nodes[i].Lines++
+		}
+	}
+}
+
+// callGraphEdges returns the calls, uses, string references when stringRefs
+// is set, methods and bases of the graph in node order, each sorted by
+// target
+func callGraphEdges(graph *analyzer.CallGraph, nodes []domain.CallGraphNode, stringRefs bool) []domain.CallGraphEdge {
+	edges := []domain.CallGraphEdge{}
+	add := func(from string, targets []string, kind domain.CallGraphEdgeKind) {
+		sort.Strings(targets)
+		for _, to := range targets {
+			edges = append(edges, domain.CallGraphEdge{From: from, To: to, Kind: kind})
+		}
+	}
+	keys := func(set map[string]bool) []string {
+		ids := make([]string, 0, len(set))
+		for id := range set {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	for _, node := range nodes {
+		if fn, ok := graph.Functions[node.ID]; ok {
+			add(node.ID, keys(fn.Calls), domain.CallGraphEdgeCall)
+			add(node.ID, keys(fn.Uses), domain.CallGraphEdgeUse)
+			if stringRefs {
+				add(node.ID, keys(fn.StringRefs), domain.CallGraphEdgeString)
+			}
+			continue
+		}
+		class := graph.Classes[node.ID]
+		add(node.ID, append([]string{}, class.Methods...), domain.CallGraphEdgeMethod)
+		add(node.ID, keys(class.Bases), domain.CallGraphEdgeBase)
+	}
+	return edges
+}
+
+// markReachable marks the nodes the entry points reach and sums up the
+// reachable functions and lines
+func markReachable(report *domain.CallGraphReport, reached map[string]bool) *domain.CallGraphReachability {
+	summary := &domain.CallGraphReachability{Unreachable: []string{}}
+	for i := range report.Nodes {
+		node := &report.Nodes[i]
+		reachable := reached[node.ID]
+		node.Reachable = &reachable
+		if node.Kind != domain.CallGraphNodeFunction && node.Kind != domain.CallGraphNodeMethod {
+			continue
+		}
+		summary.Functions++
+		summary.Lines += node.Lines
+		if reachable {
+			summary.ReachableFunctions++
+			summary.ReachableLines += node.Lines
+		} else {
+			summary.UnreachableFunctions++
+			summary.Unreachable = append(summary.Unreachable, node.ID)
+		}
+	}
+	if summary.Lines > 0 {
+		summary.ReachablePercent = float64(summary.ReachableLines) * 100 / float64(summary.Lines)
+	}
+	return summary
+}
+
+// ProjectEntryPoints returns the console and GUI scripts declared in the
+// pyproject.toml of root, as "module:function" entry points, sorted. Both
+// PEP 621 [project.scripts] and [tool.poetry.scripts] are read.
+func ProjectEntryPoints(root string) []string {
+	data, err := os.ReadFile(filepath.Join(root, "pyproject.toml"))
+	if err != nil {
+		return nil
+	}
+	var doc struct {
+		Project struct {
+			Scripts    map[string]interface{} `toml:"scripts"`
+			GUIScripts map[string]interface{} `toml:"gui-scripts"`
+		} `toml:"project"`
+		Tool struct {
+			Poetry struct {
+				Scripts map[string]interface{} `toml:"scripts"`
+			} `toml:"poetry"`
+		} `toml:"tool"`
+	}
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var entryPoints []string
+	for _, scripts := range []map[string]interface{}{doc.Project.Scripts, doc.Project.GUIScripts, doc.Tool.Poetry.Scripts} {
+		for _, value := range scripts {
+			// Poetry also accepts tables such as { callable = "app:main" }
+			if table, ok := value.(map[string]interface{}); ok {
+				value = table["callable"]
+			}
+			if spec, ok := value.(string); ok && strings.Contains(spec, ":") && !seen[spec] {
+				seen[spec] = true
+				entryPoints = append(entryPoints, spec)
+			}
+		}
+	}
+	sort.Strings(entryPoints)
+	return entryPoints
+}
+
+// callGraphLabel returns the label of a node: its module and name, or only
+// its module for module-level code
+func callGraphLabel(node domain.CallGraphNode) string {
+	if node.Kind == domain.CallGraphNodeModule {
+		return node.Module
+	}
+	if node.Module == "" {
+		return node.Name
+	}
+	return node.Module + "." + node.Name
+}
+
+// WriteCallGraphText writes the reachability of the call graph: the entry
+// points, the reachable functions and lines, and the unreachable functions
+func WriteCallGraphText(w io.Writer, report *domain.CallGraphReport) {
+	counts := make(map[domain.CallGraphNodeKind]int)
+	for _, node := range report.Nodes {
+		counts[node.Kind]++
+	}
+	fmt.Fprintf(w, "Call graph: %d functions, %d classes, %d modules, %d edges\n",
+		counts[domain.CallGraphNodeFunction]+counts[domain.CallGraphNodeMethod], counts[domain.CallGraphNodeClass],
+		counts[domain.CallGraphNodeModule], len(report.Edges))
+
+	summary := report.Reachability
+	if summary == nil {
+		fmt.Fprintln(w, "No entry points: pass --root, or declare [project.scripts] in pyproject.toml")
+		return
+	}
+
+	fmt.Fprintln(w, "\nEntry points:")
+	for _, root := range report.Roots {
+		fmt.Fprintf(w, "  %s\n", root)
+	}
+	fmt.Fprintf(w, "\nReachable: %d of %d functions, %d of %d lines (%.1f%%)\n",
+		summary.ReachableFunctions, summary.Functions, summary.ReachableLines, summary.Lines, summary.ReachablePercent)
+	if len(summary.Unreachable) == 0 {
+		return
+	}
+
+	unreachable := make(map[string]bool, len(summary.Unreachable))
+	for _, id := range summary.Unreachable {
+		unreachable[id] = true
+	}
+	fmt.Fprintf(w, "\nUnreachable functions (%d):\n", len(summary.Unreachable))
+	for _, node := range report.Nodes {
+		if !unreachable[node.ID] {
+			continue
+		}
+		location := fmt.Sprintf("%s:%d", node.Location.FilePath, node.Location.StartLine)
+		fmt.Fprintf(w, "  %-40s %s (%d lines)\n", location, callGraphLabel(node), node.Lines)
+	}
+}
+
+// WriteCallGraphDOT writes the call graph as a Graphviz digraph with one
+// cluster per file. Entry points are drawn bold and unreachable nodes
+// dashed and grey; uses are dashed edges, string references dashed and
+// grey, methods dotted and bases hollow.
+func WriteCallGraphDOT(out io.Writer, report *domain.CallGraphReport) error {
+	w := bufio.NewWriter(out)
+	fmt.Fprintln(w, "digraph CallGraph {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, "  node [shape=box, fontname=\"monospace\"];")
+
+	roots := make(map[string]bool, len(report.Roots))
+	for _, root := range report.Roots {
+		roots[root] = true
+	}
+	ids := make(map[string]string, len(report.Nodes))
+	cluster := -1
+	file := ""
+	for i, node := range report.Nodes {
+		if cluster == -1 || node.Location.FilePath != file {
+			if cluster >= 0 {
+				fmt.Fprintln(w, "  }")
+			}
+			cluster++
+			file = node.Location.FilePath
+			fmt.Fprintf(w, "\n  subgraph cluster_%d {\n", cluster)
+			fmt.Fprintf(w, "    label=%s;\n", strconv.Quote(file))
+		}
+		ids[node.ID] = fmt.Sprintf("n%d", i)
+
+		label := node.Name
+		var attrs []string
+		switch node.Kind {
+		case domain.CallGraphNodeModule:
+			label = node.Module
+			attrs = append(attrs, "shape=folder")
+		case domain.CallGraphNodeClass:
+			attrs = append(attrs, "shape=component")
+		}
+		if roots[node.ID] {
+			attrs = append(attrs, "penwidth=2")
+		}
+		if node.Reachable != nil && !*node.Reachable {
+			attrs = append(attrs, "style=dashed", "color=grey", "fontcolor=grey")
+		}
+		fmt.Fprintf(w, "    %s [label=%s", ids[node.ID], strconv.Quote(label))
+		for _, attr := range attrs {
+			fmt.Fprintf(w, ", %s", attr)
+		}
+		fmt.Fprintln(w, "];")
+	}
+	if cluster >= 0 {
+		fmt.Fprintln(w, "  }")
+	}
+
+	if len(report.Edges) > 0 {
+		fmt.Fprintln(w)
+	}
+	for _, edge := range report.Edges {
+		attrs := ""
+		switch edge.Kind {
+		case domain.CallGraphEdgeUse:
+			attrs = " [style=dashed]"
+		case domain.CallGraphEdgeString:
+			attrs = " [style=dashed, color=grey]"
+		case domain.CallGraphEdgeMethod:
+			attrs = " [style=dotted, arrowhead=none]"
+		case domain.CallGraphEdgeBase:
+			attrs = " [arrowhead=empty]"
+		}
+		fmt.Fprintf(w, "  %s -> %s%s;\n", ids[edge.From], ids[edge.To], attrs)
+	}
+	fmt.Fprintln(w, "}")
+	return w.Flush()
+}
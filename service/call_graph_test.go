@@ -0,0 +1,125 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeCallGraphFixture(t *testing.T) []string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Chdir(dir)
+	files := map[string]string{
+		"app/cli.py": `from .core import Engine
+
+def main():
+    return Engine().run()
+
+def unused():
+    def inner():
+        pass
+    return inner
+`,
+		"app/core.py": `class Engine:
+    def run(self):
+        return 1
+`,
+	}
+	var paths []string
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+		require.NoError(t, os.WriteFile(name, []byte(content), 0o644))
+		paths = append(paths, filepath.Join(dir, name))
+	}
+	return paths
+}
+
+func TestBuildCallGraph(t *testing.T) {
+	files := writeCallGraphFixture(t)
+
+	report, err := BuildCallGraph(context.Background(), files, []string{"app.cli:main"}, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"app/cli.py::main"}, report.Roots)
+	var ids []string
+	for _, node := range report.Nodes {
+		ids = append(ids, string(node.Kind)+" "+node.ID)
+	}
+	assert.Equal(t, []string{
+		"module app/cli.py::<module>",
+		"function app/cli.py::main",
+		"function app/cli.py::unused",
+		"function app/cli.py::unused.inner",
+		"module app/core.py::<module>",
+		"class app/core.py::Engine",
+		"method app/core.py::Engine.run",
+	}, ids)
+	assert.Contains(t, report.Edges, domain.CallGraphEdge{From: "app/cli.py::main", To: "app/core.py::Engine", Kind: domain.CallGraphEdgeUse})
+	assert.Contains(t, report.Edges, domain.CallGraphEdge{From: "app/core.py::Engine", To: "app/core.py::Engine.run", Kind: domain.CallGraphEdgeMethod})
+
+	summary := report.Reachability
+	require.NotNil(t, summary)
+	assert.Equal(t, []string{"app/cli.py::unused", "app/cli.py::unused.inner"}, summary.Unreachable)
+	assert.Equal(t, 2, summary.ReachableFunctions)
+	// unused owns its def and return lines; inner its own two lines
+	assert.Equal(t, 4, summary.ReachableLines)
+	assert.Equal(t, 8, summary.Lines)
+	assert.Equal(t, 50.0, summary.ReachablePercent)
+
+	var text bytes.Buffer
+	WriteCallGraphText(&text, report)
+	assert.Contains(t, text.String(), "Reachable: 2 of 4 functions, 4 of 8 lines (50.0%)")
+	assert.Contains(t, text.String(), "app.cli.unused.inner (2 lines)")
+
+	var dot bytes.Buffer
+	require.NoError(t, WriteCallGraphDOT(&dot, report))
+	assert.Contains(t, dot.String(), `n1 [label="main", penwidth=2];`)
+	assert.Contains(t, dot.String(), `n2 [label="unused", style=dashed, color=grey, fontcolor=grey];`)
+	assert.Contains(t, dot.String(), "n5 -> n6 [style=dotted, arrowhead=none];")
+}
+
+func TestBuildCallGraph_WithoutEntryPoints(t *testing.T) {
+	files := writeCallGraphFixture(t)
+
+	report, err := BuildCallGraph(context.Background(), files, nil, true)
+	require.NoError(t, err)
+	assert.Nil(t, report.Reachability)
+	for _, node := range report.Nodes {
+		assert.Nil(t, node.Reachable, node.ID)
+	}
+
+	var text bytes.Buffer
+	WriteCallGraphText(&text, report)
+	assert.True(t, strings.HasSuffix(text.String(), "No entry points: pass --root, or declare [project.scripts] in pyproject.toml\n"))
+
+	_, err = BuildCallGraph(context.Background(), files, []string{"app.cli:missing"}, true)
+	assert.ErrorContains(t, err, `entry point "app.cli:missing": no function or class missing in app/cli.py`)
+}
+
+func TestProjectEntryPoints(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pyproject.toml"), []byte(`
+[project.scripts]
+app = "app.cli:main"
+app-admin = "app.admin:run [admin]"
+
+[project.gui-scripts]
+app-gui = "app.gui:start"
+
+[tool.poetry.scripts]
+legacy = "app.cli:main"
+tool = { callable = "app.tool:run" }
+path = { reference = "bin/run.sh", type = "file" }
+`), 0o644))
+
+	assert.Equal(t, []string{"app.admin:run [admin]", "app.cli:main", "app.gui:start", "app.tool:run"}, ProjectEntryPoints(dir))
+	assert.Empty(t, ProjectEntryPoints(t.TempDir()))
+}
@@ -80,6 +80,11 @@ func (cl *CBOConfigurationLoaderImpl) MergeConfig(base *domain.CBORequest, overr
 	// Thresholds
 	merged.LowThreshold = config.Merge(merged.LowThreshold, override.LowThreshold)
 	merged.MediumThreshold = config.Merge(merged.MediumThreshold, override.MediumThreshold)
+	merged.KindThresholds.Inheritance = config.Merge(merged.KindThresholds.Inheritance, override.KindThresholds.Inheritance)
+	merged.KindThresholds.Composition = config.Merge(merged.KindThresholds.Composition, override.KindThresholds.Composition)
+	merged.KindThresholds.Instantiation = config.Merge(merged.KindThresholds.Instantiation, override.KindThresholds.Instantiation)
+	merged.KindThresholds.MethodCalls = config.Merge(merged.KindThresholds.MethodCalls, override.KindThresholds.MethodCalls)
+	merged.KindThresholds.TypeHints = config.Merge(merged.KindThresholds.TypeHints, override.KindThresholds.TypeHints)
 
 	// ConfigPath - always override if provided
 	merged.ConfigPath = config.Merge(merged.ConfigPath, override.ConfigPath)
@@ -91,6 +96,7 @@ func (cl *CBOConfigurationLoaderImpl) MergeConfig(base *domain.CBORequest, overr
 	merged.IncludeBuiltins = config.MergePtr(merged.IncludeBuiltins, override.IncludeBuiltins)
 	merged.IncludeImports = config.MergePtr(merged.IncludeImports, override.IncludeImports)
 	merged.GroupNamespaceImports = config.MergePtr(merged.GroupNamespaceImports, override.GroupNamespaceImports)
+	merged.IncludeTypeHints = config.MergePtr(merged.IncludeTypeHints, override.IncludeTypeHints)
 
 	// Analysis options
 	merged.Recursive = config.MergePtr(merged.Recursive, override.Recursive)
@@ -115,6 +121,7 @@ func (cl *CBOConfigurationLoaderImpl) configToRequest(pyscnCfg *config.PyscnConf
 			IncludeBuiltins:       domain.BoolPtr(false),
 			IncludeImports:        domain.BoolPtr(true),
 			GroupNamespaceImports: domain.BoolPtr(true),
+			IncludeTypeHints:      domain.BoolPtr(true),
 			SortBy:                domain.SortByComplexity,
 			OutputFormat:          domain.OutputFormatText,
 			Recursive:             domain.BoolPtr(true),
@@ -134,10 +141,18 @@ func (cl *CBOConfigurationLoaderImpl) configToRequest(pyscnCfg *config.PyscnConf
 		IncludeBuiltins:       pyscnCfg.CboIncludeBuiltins,
 		IncludeImports:        pyscnCfg.CboIncludeImports,
 		GroupNamespaceImports: pyscnCfg.CboGroupNamespaceImports,
-		SortBy:                domain.SortByComplexity, // Default, can be overridden
-		Recursive:             pyscnCfg.AnalysisRecursive,
-		IncludePatterns:       pyscnCfg.AnalysisIncludePatterns,
-		ExcludePatterns:       pyscnCfg.AnalysisExcludePatterns,
+		IncludeTypeHints:      pyscnCfg.CboIncludeTypeHints,
+		KindThresholds: domain.CBOCouplingKinds{
+			Inheritance:   pyscnCfg.CboMaxInheritance,
+			Composition:   pyscnCfg.CboMaxComposition,
+			Instantiation: pyscnCfg.CboMaxInstantiation,
+			MethodCalls:   pyscnCfg.CboMaxMethodCalls,
+			TypeHints:     pyscnCfg.CboMaxTypeHints,
+		},
+		SortBy:          domain.SortByComplexity, // Default, can be overridden
+		Recursive:       pyscnCfg.AnalysisRecursive,
+		IncludePatterns: pyscnCfg.AnalyzerIncludePatterns(domain.AnalysisScopeCBO),
+		ExcludePatterns: pyscnCfg.AnalyzerExcludePatterns(domain.AnalysisScopeCBO),
 	}
 }
 
@@ -2,7 +2,6 @@ package service
 
 import (
 	"encoding/csv"
-	"encoding/json"
 	"fmt"
 	"io"
 	"sort"
@@ -11,7 +10,6 @@ import (
 	"time"
 
 	"github.com/ludo-technologies/pyscn/domain"
-	"gopkg.in/yaml.v3"
 )
 
 // CBOFormatterImpl implements CBOOutputFormatter interface
@@ -67,6 +65,9 @@ func (f *CBOFormatterImpl) formatText(response *domain.CBOResponse) (string, err
 		"Max CBO":        response.Summary.MaxCBO,
 		"Min CBO":        response.Summary.MinCBO,
 	}
+	if response.Summary.KindViolationClasses > 0 {
+		stats["Over Kind Threshold"] = response.Summary.KindViolationClasses
+	}
 	builder.WriteString(utils.FormatSummaryStats(stats))
 
 	// Risk distribution
@@ -204,25 +205,45 @@ func (f *CBOFormatterImpl) writeClassDetails(builder *strings.Builder, class dom
 		if len(class.Metrics.DependentClasses) > 0 {
 			builder.WriteString(utils.FormatLabelWithIndent(ItemPadding+2, "Coupled to", strings.Join(class.Metrics.DependentClasses, ", ")))
 		}
+
+		builder.WriteString(utils.FormatLabelWithIndent(ItemPadding, "Coupling Kinds", ""))
+		for _, kind := range domain.CBOKinds {
+			if count := class.Metrics.Kinds.Get(kind); count > 0 {
+				value := strconv.Itoa(count)
+				if containsString(class.KindViolations, kind) {
+					value += " (over threshold)"
+				}
+				builder.WriteString(utils.FormatLabelWithIndent(ItemPadding+2, cboKindLabel(kind), value))
+			}
+		}
+	}
+}
+
+// cboKindLabel returns the display name of a coupling kind
+func cboKindLabel(kind string) string {
+	switch kind {
+	case domain.CBOKindInheritance:
+		return "Inheritance"
+	case domain.CBOKindComposition:
+		return "Composition"
+	case domain.CBOKindInstantiation:
+		return "Instantiation"
+	case domain.CBOKindMethodCalls:
+		return "Method Calls"
+	case domain.CBOKindTypeHints:
+		return "Type Hints"
 	}
+	return kind
 }
 
 // formatJSON formats the response as JSON
 func (f *CBOFormatterImpl) formatJSON(response *domain.CBOResponse) (string, error) {
-	jsonBytes, err := json.MarshalIndent(response, "", "  ")
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal JSON: %w", err)
-	}
-	return string(jsonBytes), nil
+	return EncodeJSON(response)
 }
 
 // formatYAML formats the response as YAML
 func (f *CBOFormatterImpl) formatYAML(response *domain.CBOResponse) (string, error) {
-	yamlBytes, err := yaml.Marshal(response)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal YAML: %w", err)
-	}
-	return string(yamlBytes), nil
+	return EncodeYAML(response)
 }
 
 // formatCSV formats the response as CSV
@@ -235,6 +256,8 @@ func (f *CBOFormatterImpl) formatCSV(response *domain.CBOResponse) (string, erro
 		"ClassName", "FilePath", "StartLine", "EndLine", "CBO", "RiskLevel", "IsAbstract",
 		"InheritanceDeps", "TypeHintDeps", "InstantiationDeps", "AttributeAccessDeps", "ImportDeps",
 		"BaseClasses", "DependentClasses",
+		"InheritanceCoupling", "CompositionCoupling", "InstantiationCoupling", "MethodCallCoupling", "TypeHintCoupling",
+		"KindViolations",
 	}
 	if err := writer.Write(header); err != nil {
 		return "", fmt.Errorf("failed to write CSV header: %w", err)
@@ -257,6 +280,12 @@ func (f *CBOFormatterImpl) formatCSV(response *domain.CBOResponse) (string, erro
 			strconv.Itoa(class.Metrics.ImportDependencies),
 			strings.Join(class.BaseClasses, ";"),
 			strings.Join(class.Metrics.DependentClasses, ";"),
+			strconv.Itoa(class.Metrics.Kinds.Inheritance),
+			strconv.Itoa(class.Metrics.Kinds.Composition),
+			strconv.Itoa(class.Metrics.Kinds.Instantiation),
+			strconv.Itoa(class.Metrics.Kinds.MethodCalls),
+			strconv.Itoa(class.Metrics.Kinds.TypeHints),
+			strings.Join(class.KindViolations, ";"),
 		}
 		if err := writer.Write(row); err != nil {
 			return "", fmt.Errorf("failed to write CSV row: %w", err)
@@ -383,6 +412,22 @@ func (f *CBOFormatterImpl) formatHTML(response *domain.CBOResponse) (string, err
 				}
 				content.WriteString(strings.Join(deps, ", "))
 				content.WriteString(`</small>`)
+
+				kinds := []string{}
+				for _, kind := range domain.CBOKinds {
+					if count := class.Metrics.Kinds.Get(kind); count > 0 {
+						label := fmt.Sprintf("%s: %d", cboKindLabel(kind), count)
+						if containsString(class.KindViolations, kind) {
+							label = `<strong style="color: #dc3545;">` + label + ` (over threshold)</strong>`
+						}
+						kinds = append(kinds, label)
+					}
+				}
+				if len(kinds) > 0 {
+					content.WriteString(`<br><small style="color: #666;">Coupling kinds: `)
+					content.WriteString(strings.Join(kinds, ", "))
+					content.WriteString(`</small>`)
+				}
 			}
 
 			content.WriteString(`                    </td>
@@ -98,7 +98,7 @@ func (s *CBOServiceImpl) AnalyzeSnapshot(ctx context.Context, snapshot *ProjectS
 	var errors []string
 	filesProcessed := 0
 
-	for _, file := range snapshot.Files {
+	for i, file := range snapshot.Files {
 		select {
 		case <-ctx.Done():
 			return nil, fmt.Errorf("CBO analysis cancelled: %w", ctx.Err())
@@ -106,6 +106,7 @@ func (s *CBOServiceImpl) AnalyzeSnapshot(ctx context.Context, snapshot *ProjectS
 		}
 
 		classes, fileWarnings, fileErrors := s.analyzeProjectFile(file, req)
+		domain.ReportProgress(ctx, domain.ProgressStageCBO, i+1, len(snapshot.Files))
 
 		if len(fileErrors) > 0 {
 			errors = append(errors, fileErrors...)
@@ -167,7 +168,7 @@ func (s *CBOServiceImpl) analyzeFile(ctx context.Context, filePath string, req d
 		return classes, warnings, errors
 	}
 
-	result, err := s.parser.Parse(ctx, content)
+	result, err := s.parser.ParseFor(ctx, filePath, content)
 	if err != nil {
 		errors = append(errors, fmt.Sprintf("[%s] Parse error: %v", filePath, err))
 		return classes, warnings, errors
@@ -188,7 +189,7 @@ func (s *CBOServiceImpl) analyzeFile(ctx context.Context, filePath string, req d
 		return classes, warnings, errors
 	}
 
-	classes = s.convertCBOResults(cboResults)
+	classes = s.convertCBOResults(cboResults, req)
 	return classes, warnings, errors
 }
 
@@ -222,11 +223,11 @@ func (s *CBOServiceImpl) analyzeProjectFile(file *ProjectFile, req domain.CBOReq
 		return classes, warnings, errors
 	}
 
-	classes = s.convertCBOResults(cboResults)
+	classes = s.convertCBOResults(cboResults, req)
 	return classes, warnings, errors
 }
 
-func (s *CBOServiceImpl) convertCBOResults(cboResults []*analyzer.CBOResult) []domain.ClassCoupling {
+func (s *CBOServiceImpl) convertCBOResults(cboResults []*analyzer.CBOResult, req domain.CBORequest) []domain.ClassCoupling {
 	classes := make([]domain.ClassCoupling, 0, len(cboResults))
 
 	for _, cboResult := range cboResults {
@@ -242,12 +243,20 @@ func (s *CBOServiceImpl) convertCBOResults(cboResults []*analyzer.CBOResult) []d
 				InstantiationDependencies:   cboResult.InstantiationDependencies,
 				AttributeAccessDependencies: cboResult.AttributeAccessDependencies,
 				ImportDependencies:          cboResult.ImportDependencies,
-				DependentClasses:            cboResult.DependentClasses,
+				Kinds: domain.CBOCouplingKinds{
+					Inheritance:   cboResult.InheritanceCoupling,
+					Composition:   cboResult.CompositionCoupling,
+					Instantiation: cboResult.InstantiationCoupling,
+					MethodCalls:   cboResult.MethodCallCoupling,
+					TypeHints:     cboResult.TypeHintCoupling,
+				},
+				DependentClasses: cboResult.DependentClasses,
 			},
 			RiskLevel:   domain.RiskLevel(cboResult.RiskLevel),
 			IsAbstract:  cboResult.IsAbstract,
 			BaseClasses: cboResult.BaseClasses,
 		}
+		class.KindViolations = s.kindViolations(class.Metrics.Kinds, req.KindThresholds)
 
 		classes = append(classes, class)
 	}
@@ -255,6 +264,17 @@ func (s *CBOServiceImpl) convertCBOResults(cboResults []*analyzer.CBOResult) []d
 	return classes
 }
 
+// kindViolations returns the coupling kinds whose count exceeds its threshold
+func (s *CBOServiceImpl) kindViolations(kinds, thresholds domain.CBOCouplingKinds) []string {
+	var violations []string
+	for _, kind := range domain.CBOKinds {
+		if limit := thresholds.Get(kind); limit > 0 && kinds.Get(kind) > limit {
+			violations = append(violations, kind)
+		}
+	}
+	return violations
+}
+
 // filterClasses filters classes based on request criteria
 func (s *CBOServiceImpl) filterClasses(classes []domain.ClassCoupling, req domain.CBORequest) []domain.ClassCoupling {
 	var filtered []domain.ClassCoupling
@@ -363,6 +383,10 @@ func (s *CBOServiceImpl) generateSummary(classes []domain.ClassCoupling, filesAn
 			summary.HighRiskClasses++
 		}
 
+		if len(class.KindViolations) > 0 {
+			summary.KindViolationClasses++
+		}
+
 		// Build CBO distribution
 		cboRange := s.getCBORange(cbo)
 		summary.CBODistribution[cboRange]++
@@ -412,6 +436,7 @@ func (s *CBOServiceImpl) buildCBOOptions(req domain.CBORequest) *analyzer.CBOOpt
 		IncludeBuiltins:       domain.BoolValue(req.IncludeBuiltins, false),
 		IncludeImports:        domain.BoolValue(req.IncludeImports, true),
 		GroupNamespaceImports: domain.BoolValue(req.GroupNamespaceImports, true),
+		IncludeTypeHints:      domain.BoolValue(req.IncludeTypeHints, true),
 		PublicClassesOnly:     false, // Could add this to domain.CBORequest later
 		ExcludePatterns:       req.ExcludePatterns,
 		LowThreshold:          req.LowThreshold,
@@ -427,9 +452,11 @@ func (s *CBOServiceImpl) buildConfigForResponse(req domain.CBORequest) interface
 		"showZeros":             domain.BoolValue(req.ShowZeros, false),
 		"lowThreshold":          req.LowThreshold,
 		"mediumThreshold":       req.MediumThreshold,
+		"kindThresholds":        req.KindThresholds,
 		"includeBuiltins":       domain.BoolValue(req.IncludeBuiltins, false),
 		"includeImports":        domain.BoolValue(req.IncludeImports, true),
 		"groupNamespaceImports": domain.BoolValue(req.GroupNamespaceImports, true),
+		"includeTypeHints":      domain.BoolValue(req.IncludeTypeHints, true),
 		"outputFormat":          req.OutputFormat,
 		"sortBy":                req.SortBy,
 	}
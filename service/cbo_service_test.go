@@ -622,3 +622,30 @@ func TestCBOService_ResponseMetadata(t *testing.T) {
 	// Verify config is present
 	assert.NotNil(t, response.Config)
 }
+
+func TestCBOService_KindThresholds(t *testing.T) {
+	source := `
+from models import Base, Mixin, Engine, Driver, Route
+
+class Car(Base, Mixin):
+    def __init__(self):
+        self.engine = Engine()
+
+    def drive(self, driver: Driver) -> Route:
+        return None
+`
+
+	filePath := filepath.Join(t.TempDir(), "car.py")
+	require.NoError(t, os.WriteFile(filePath, []byte(source), 0o600))
+
+	req := newDefaultCBORequest(filePath)
+	req.KindThresholds = domain.CBOCouplingKinds{Inheritance: 1, TypeHints: 2}
+	response, err := NewCBOService().Analyze(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, response.Classes, 1)
+
+	car := response.Classes[0]
+	assert.Equal(t, domain.CBOCouplingKinds{Inheritance: 2, Composition: 1, Instantiation: 1, TypeHints: 2}, car.Metrics.Kinds)
+	assert.Equal(t, []string{domain.CBOKindInheritance}, car.KindViolations)
+	assert.Equal(t, 1, response.Summary.KindViolationClasses)
+}
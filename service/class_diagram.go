@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/analyzer"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// classDiagramColors are the fills of classes by CBO risk level
+var classDiagramColors = map[domain.RiskLevel]string{
+	domain.RiskLevelLow:    "#bbf7d0",
+	domain.RiskLevelMedium: "#fde68a",
+	domain.RiskLevelHigh:   "#fecaca",
+}
+
+// ExtractClassDiagram parses the files and returns their classes and the
+// inheritance and composition between them. Files inside the current
+// directory are named relative to it, so module names start at the project.
+func ExtractClassDiagram(ctx context.Context, files []string) (*domain.ClassDiagram, error) {
+	cwd, _ := os.Getwd()
+	extractor := analyzer.NewClassDiagramExtractor()
+	p := parser.New()
+	for _, file := range files {
+		source, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		result, err := p.Parse(ctx, source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		extractor.AddModule(cwdRelativePath(cwd, file), result)
+	}
+	return extractor.Diagram(), nil
+}
+
+// cwdRelativePath returns a file path relative to cwd when the file is
+// inside it, with forward slashes
+func cwdRelativePath(cwd, file string) string {
+	name := file
+	if abs, err := filepath.Abs(file); err == nil && cwd != "" {
+		if rel, err := filepath.Rel(cwd, abs); err == nil && !strings.HasPrefix(rel, "..") {
+			name = rel
+		}
+	}
+	return filepath.ToSlash(name)
+}
+
+// AnnotateClassDiagramCBO sets the CBO and risk level of the classes that
+// CBO analysis measured, matched by file and line
+func AnnotateClassDiagramCBO(diagram *domain.ClassDiagram, response *domain.CBOResponse) {
+	if diagram == nil || response == nil {
+		return
+	}
+	type key struct {
+		path string
+		line int
+	}
+	absolute := func(path string) string {
+		if abs, err := filepath.Abs(path); err == nil {
+			return abs
+		}
+		return filepath.Clean(path)
+	}
+	measured := make(map[key]domain.ClassCoupling, len(response.Classes))
+	for _, class := range response.Classes {
+		measured[key{absolute(class.FilePath), class.StartLine}] = class
+	}
+	for i := range diagram.Classes {
+		class := &diagram.Classes[i]
+		coupling, ok := measured[key{absolute(class.FilePath), class.StartLine}]
+		if !ok {
+			continue
+		}
+		cbo := coupling.Metrics.CouplingCount
+		class.CBO = &cbo
+		class.RiskLevel = coupling.RiskLevel
+	}
+}
+
+// classDiagramID turns a class ID into an identifier both Mermaid and
+// PlantUML accept
+func classDiagramID(id string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, id)
+}
+
+// classDiagramLabels returns the label of each class: its name, or its ID
+// when another class of the diagram has the same name
+func classDiagramLabels(diagram *domain.ClassDiagram) map[string]string {
+	count := make(map[string]int)
+	for _, class := range diagram.Classes {
+		count[class.Name]++
+	}
+	labels := make(map[string]string, len(diagram.Classes))
+	for _, class := range diagram.Classes {
+		labels[class.ID] = class.Name
+		if count[class.Name] > 1 {
+			labels[class.ID] = class.ID
+		}
+	}
+	return labels
+}
+
+// classDiagramMembers returns the lines listed in a class box: attributes,
+// method count and CBO
+func classDiagramMembers(class domain.DiagramClass) []string {
+	var members []string
+	for _, attribute := range class.Attributes {
+		if attribute.Type == "" {
+			members = append(members, attribute.Name)
+			continue
+		}
+		members = append(members, attribute.Name+" : "+attribute.Type)
+	}
+	if class.Methods == 1 {
+		members = append(members, "1 method")
+	} else {
+		members = append(members, fmt.Sprintf("%d methods", class.Methods))
+	}
+	if class.CBO != nil {
+		members = append(members, fmt.Sprintf("CBO %d", *class.CBO))
+	}
+	return members
+}
+
+// WriteClassDiagramMermaid writes the diagram as a Mermaid classDiagram.
+// Classes annotated with CBO are colored by risk level.
+func WriteClassDiagramMermaid(w io.Writer, diagram *domain.ClassDiagram) {
+	labels := classDiagramLabels(diagram)
+	fmt.Fprintln(w, "classDiagram")
+
+	byRisk := make(map[domain.RiskLevel][]string)
+	for _, class := range diagram.Classes {
+		id := classDiagramID(class.ID)
+		fmt.Fprintf(w, "  class %s[\"%s\"] {\n", id, labels[class.ID])
+		if class.IsAbstract {
+			fmt.Fprintln(w, "    <<abstract>>")
+		}
+		for _, member := range classDiagramMembers(class) {
+			fmt.Fprintf(w, "    %s\n", member)
+		}
+		fmt.Fprintln(w, "  }")
+		if class.RiskLevel != "" {
+			byRisk[class.RiskLevel] = append(byRisk[class.RiskLevel], id)
+		}
+	}
+
+	for _, relation := range diagram.Relations {
+		from, to := classDiagramID(relation.From), classDiagramID(relation.To)
+		switch relation.Kind {
+		case domain.ClassRelationInheritance:
+			fmt.Fprintf(w, "  %s <|-- %s\n", to, from)
+		case domain.ClassRelationComposition:
+			fmt.Fprintf(w, "  %s *-- %s : %s\n", from, to, relation.Attribute)
+		}
+	}
+
+	for _, level := range []domain.RiskLevel{domain.RiskLevelLow, domain.RiskLevelMedium, domain.RiskLevelHigh} {
+		if ids := byRisk[level]; len(ids) > 0 {
+			fmt.Fprintf(w, "  classDef cbo_%s fill:%s\n", level, classDiagramColors[level])
+			fmt.Fprintf(w, "  cssClass \"%s\" cbo_%s\n", strings.Join(ids, ","), level)
+		}
+	}
+}
+
+// WriteClassDiagramPlantUML writes the diagram as a PlantUML class diagram
+// with one package per module. Classes annotated with CBO are colored by
+// risk level.
+func WriteClassDiagramPlantUML(w io.Writer, diagram *domain.ClassDiagram) {
+	labels := classDiagramLabels(diagram)
+	fmt.Fprintln(w, "@startuml")
+	fmt.Fprintln(w, "set separator none")
+	fmt.Fprintln(w, "skinparam classAttributeIconSize 0")
+
+	module := ""
+	for i, class := range diagram.Classes {
+		if i == 0 || class.Module != module {
+			if i > 0 {
+				fmt.Fprintln(w, "}")
+			}
+			module = class.Module
+			fmt.Fprintf(w, "package \"%s\" {\n", module)
+		}
+		keyword := "class"
+		if class.IsAbstract {
+			keyword = "abstract class"
+		}
+		color := ""
+		if fill, ok := classDiagramColors[class.RiskLevel]; ok {
+			color = " " + fill
+		}
+		fmt.Fprintf(w, "  %s \"%s\" as %s%s {\n", keyword, labels[class.ID], classDiagramID(class.ID), color)
+		members := classDiagramMembers(class)
+		for j, member := range members {
+			if j > 0 && j == len(class.Attributes) {
+				fmt.Fprintln(w, "    --")
+			}
+			fmt.Fprintf(w, "    %s\n", member)
+		}
+		fmt.Fprintln(w, "  }")
+	}
+	if len(diagram.Classes) > 0 {
+		fmt.Fprintln(w, "}")
+	}
+
+	for _, relation := range diagram.Relations {
+		from, to := classDiagramID(relation.From), classDiagramID(relation.To)
+		switch relation.Kind {
+		case domain.ClassRelationInheritance:
+			fmt.Fprintf(w, "%s <|-- %s\n", to, from)
+		case domain.ClassRelationComposition:
+			fmt.Fprintf(w, "%s *-- %s : %s\n", from, to, relation.Attribute)
+		}
+	}
+	fmt.Fprintln(w, "@enduml")
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func classDiagramFixture() *domain.ClassDiagram {
+	return &domain.ClassDiagram{
+		Classes: []domain.DiagramClass{
+			{ID: "shop.base.Entity", Name: "Entity", Module: "shop.base", FilePath: "shop/base.py", StartLine: 1, IsAbstract: true, Methods: 1},
+			{ID: "shop.cart.Cart", Name: "Cart", Module: "shop.cart", FilePath: "shop/cart.py", StartLine: 3, Methods: 2,
+				Attributes: []domain.DiagramAttribute{{Name: "items", Type: "list[Item]"}, {Name: "count"}}},
+			{ID: "shop.cart.Item", Name: "Item", Module: "shop.cart", FilePath: "shop/cart.py", StartLine: 9},
+			{ID: "legacy.cart.Cart", Name: "Cart", Module: "legacy.cart", FilePath: "legacy/cart.py", StartLine: 1},
+		},
+		Relations: []domain.ClassRelation{
+			{From: "shop.cart.Cart", To: "shop.base.Entity", Kind: domain.ClassRelationInheritance},
+			{From: "shop.cart.Cart", To: "shop.cart.Item", Kind: domain.ClassRelationComposition, Attribute: "items"},
+		},
+	}
+}
+
+func TestWriteClassDiagramMermaid(t *testing.T) {
+	diagram := classDiagramFixture()
+	AnnotateClassDiagramCBO(diagram, &domain.CBOResponse{Classes: []domain.ClassCoupling{
+		{Name: "Cart", FilePath: "./shop/cart.py", StartLine: 3, Metrics: domain.CBOMetrics{CouplingCount: 9}, RiskLevel: domain.RiskLevelHigh},
+		{Name: "Item", FilePath: "shop/cart.py", StartLine: 9, Metrics: domain.CBOMetrics{CouplingCount: 0}, RiskLevel: domain.RiskLevelLow},
+	}})
+
+	var buf bytes.Buffer
+	WriteClassDiagramMermaid(&buf, diagram)
+	assert.Equal(t, `classDiagram
+  class shop_base_Entity["Entity"] {
+    <<abstract>>
+    1 method
+  }
+  class shop_cart_Cart["shop.cart.Cart"] {
+    items : list[Item]
+    count
+    2 methods
+    CBO 9
+  }
+  class shop_cart_Item["Item"] {
+    0 methods
+    CBO 0
+  }
+  class legacy_cart_Cart["legacy.cart.Cart"] {
+    0 methods
+  }
+  shop_base_Entity <|-- shop_cart_Cart
+  shop_cart_Cart *-- shop_cart_Item : items
+  classDef cbo_low fill:#bbf7d0
+  cssClass "shop_cart_Item" cbo_low
+  classDef cbo_high fill:#fecaca
+  cssClass "shop_cart_Cart" cbo_high
+`, buf.String())
+}
+
+func TestWriteClassDiagramPlantUML(t *testing.T) {
+	diagram := classDiagramFixture()
+	diagram.Classes = diagram.Classes[:3]
+	cbo := 4
+	diagram.Classes[1].CBO, diagram.Classes[1].RiskLevel = &cbo, domain.RiskLevelMedium
+
+	var buf bytes.Buffer
+	WriteClassDiagramPlantUML(&buf, diagram)
+	assert.Equal(t, `@startuml
+set separator none
+skinparam classAttributeIconSize 0
+package "shop.base" {
+  abstract class "Entity" as shop_base_Entity {
+    1 method
+  }
+}
+package "shop.cart" {
+  class "Cart" as shop_cart_Cart #fde68a {
+    items : list[Item]
+    count
+    --
+    2 methods
+    CBO 4
+  }
+  class "Item" as shop_cart_Item {
+    0 methods
+  }
+}
+shop_base_Entity <|-- shop_cart_Cart
+shop_cart_Cart *-- shop_cart_Item : items
+@enduml
+`, buf.String())
+}
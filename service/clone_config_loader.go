@@ -95,12 +95,17 @@ func (c *CloneConfigurationLoader) MergeConfig(base *domain.CloneRequest, overri
 	merged.IgnoreLiterals = config.MergePtr(merged.IgnoreLiterals, override.IgnoreLiterals)
 	merged.IgnoreIdentifiers = config.MergePtr(merged.IgnoreIdentifiers, override.IgnoreIdentifiers)
 	merged.SkipDocstrings = config.MergePtr(merged.SkipDocstrings, override.SkipDocstrings)
+	merged.StringClones = config.MergePtr(merged.StringClones, override.StringClones)
 	merged.ShowDetails = config.MergePtr(merged.ShowDetails, override.ShowDetails)
 	merged.ShowContent = config.MergePtr(merged.ShowContent, override.ShowContent)
 	merged.GroupClones = config.MergePtr(merged.GroupClones, override.GroupClones)
+	merged.CollapseSameFile = config.MergePtr(merged.CollapseSameFile, override.CollapseSameFile)
 
 	merged.MinLines = config.Merge(merged.MinLines, override.MinLines)
 	merged.MinNodes = config.Merge(merged.MinNodes, override.MinNodes)
+	merged.MinTokens = config.Merge(merged.MinTokens, override.MinTokens)
+	merged.MinStringLength = config.Merge(merged.MinStringLength, override.MinStringLength)
+	merged.StringSimilarityThreshold = config.Merge(merged.StringSimilarityThreshold, override.StringSimilarityThreshold)
 	merged.SimilarityThreshold = config.Merge(merged.SimilarityThreshold, override.SimilarityThreshold)
 	merged.MaxEditDistance = config.Merge(merged.MaxEditDistance, override.MaxEditDistance)
 	merged.Type1Threshold = config.Merge(merged.Type1Threshold, override.Type1Threshold)
@@ -111,7 +116,9 @@ func (c *CloneConfigurationLoader) MergeConfig(base *domain.CloneRequest, overri
 	merged.MaxSimilarity = config.Merge(merged.MaxSimilarity, override.MaxSimilarity)
 	merged.GroupThreshold = config.Merge(merged.GroupThreshold, override.GroupThreshold)
 	merged.KCoreK = config.Merge(merged.KCoreK, override.KCoreK)
+	merged.MaxGroupMembers = config.Merge(merged.MaxGroupMembers, override.MaxGroupMembers)
 	merged.Timeout = config.Merge(merged.Timeout, override.Timeout)
+	merged.Jobs = config.Merge(merged.Jobs, override.Jobs)
 	merged.LSHAutoThreshold = config.Merge(merged.LSHAutoThreshold, override.LSHAutoThreshold)
 	merged.LSHSimilarityThreshold = config.Merge(merged.LSHSimilarityThreshold, override.LSHSimilarityThreshold)
 	merged.LSHBands = config.Merge(merged.LSHBands, override.LSHBands)
@@ -120,6 +127,7 @@ func (c *CloneConfigurationLoader) MergeConfig(base *domain.CloneRequest, overri
 
 	merged.SortBy = config.Merge(merged.SortBy, override.SortBy)
 	merged.GroupMode = config.Merge(merged.GroupMode, override.GroupMode)
+	merged.ReportGroupBy = config.Merge(merged.ReportGroupBy, override.ReportGroupBy)
 	merged.LSHEnabled = config.Merge(merged.LSHEnabled, override.LSHEnabled)
 	merged.ConfigPath = config.Merge(merged.ConfigPath, override.ConfigPath)
 
@@ -163,32 +171,39 @@ func (c *CloneConfigurationLoader) cloneConfigToCloneRequest(cloneCfg *config.Py
 	}
 
 	return &domain.CloneRequest{
-		Paths:               cloneCfg.Input.Paths,
-		MinLines:            cloneCfg.Analysis.MinLines,
-		MinNodes:            cloneCfg.Analysis.MinNodes,
-		SimilarityThreshold: cloneCfg.Thresholds.SimilarityThreshold,
-		MaxEditDistance:     cloneCfg.Analysis.MaxEditDistance,
-		IgnoreLiterals:      domain.BoolPtr(domain.BoolValue(cloneCfg.Analysis.IgnoreLiterals, false)),
-		IgnoreIdentifiers:   domain.BoolPtr(domain.BoolValue(cloneCfg.Analysis.IgnoreIdentifiers, false)),
-		SkipDocstrings:      domain.BoolPtr(domain.BoolValue(cloneCfg.Analysis.SkipDocstrings, true)),
-		Type1Threshold:      cloneCfg.Thresholds.Type1Threshold,
-		Type2Threshold:      cloneCfg.Thresholds.Type2Threshold,
-		Type3Threshold:      cloneCfg.Thresholds.Type3Threshold,
-		Type4Threshold:      cloneCfg.Thresholds.Type4Threshold,
-		ShowDetails:         domain.BoolPtr(domain.BoolValue(cloneCfg.Output.ShowDetails, false)),
-		ShowContent:         domain.BoolPtr(domain.BoolValue(cloneCfg.Output.ShowContent, false)),
-		SortBy:              sortBy,
-		GroupClones:         domain.BoolPtr(domain.BoolValue(cloneCfg.Output.GroupClones, true)),
-		GroupMode:           cloneCfg.Grouping.Mode,
-		GroupThreshold:      cloneCfg.Grouping.Threshold,
-		KCoreK:              cloneCfg.Grouping.KCoreK,
-		MinSimilarity:       cloneCfg.Filtering.MinSimilarity,
-		MaxSimilarity:       cloneCfg.Filtering.MaxSimilarity,
-		CloneTypes:          cloneTypes,
-		OutputFormat:        domain.OutputFormatText, // Default, overridden by CLI
-		Recursive:           domain.BoolPtr(domain.BoolValue(cloneCfg.Input.Recursive, true)),
-		IncludePatterns:     cloneCfg.Input.IncludePatterns,
-		ExcludePatterns:     cloneCfg.Input.ExcludePatterns,
+		Paths:                     cloneCfg.Input.Paths,
+		MinLines:                  cloneCfg.Analysis.MinLines,
+		MinNodes:                  cloneCfg.Analysis.MinNodes,
+		MinTokens:                 cloneCfg.Analysis.MinTokens,
+		SimilarityThreshold:       cloneCfg.Thresholds.SimilarityThreshold,
+		MaxEditDistance:           cloneCfg.Analysis.MaxEditDistance,
+		IgnoreLiterals:            domain.BoolPtr(domain.BoolValue(cloneCfg.Analysis.IgnoreLiterals, false)),
+		IgnoreIdentifiers:         domain.BoolPtr(domain.BoolValue(cloneCfg.Analysis.IgnoreIdentifiers, false)),
+		SkipDocstrings:            domain.BoolPtr(domain.BoolValue(cloneCfg.Analysis.SkipDocstrings, true)),
+		StringClones:              domain.BoolPtr(domain.BoolValue(cloneCfg.Analysis.StringClones, false)),
+		MinStringLength:           cloneCfg.Analysis.MinStringLength,
+		StringSimilarityThreshold: cloneCfg.Analysis.StringSimilarityThreshold,
+		Type1Threshold:            cloneCfg.Thresholds.Type1Threshold,
+		Type2Threshold:            cloneCfg.Thresholds.Type2Threshold,
+		Type3Threshold:            cloneCfg.Thresholds.Type3Threshold,
+		Type4Threshold:            cloneCfg.Thresholds.Type4Threshold,
+		ShowDetails:               domain.BoolPtr(domain.BoolValue(cloneCfg.Output.ShowDetails, false)),
+		ShowContent:               domain.BoolPtr(domain.BoolValue(cloneCfg.Output.ShowContent, false)),
+		SortBy:                    sortBy,
+		GroupClones:               domain.BoolPtr(domain.BoolValue(cloneCfg.Output.GroupClones, true)),
+		MaxGroupMembers:           cloneCfg.Output.MaxGroupMembers,
+		CollapseSameFile:          domain.BoolPtr(domain.BoolValue(cloneCfg.Output.CollapseSameFile, false)),
+		ReportGroupBy:             cloneCfg.Output.ReportGroupBy,
+		GroupMode:                 cloneCfg.Grouping.Mode,
+		GroupThreshold:            cloneCfg.Grouping.Threshold,
+		KCoreK:                    cloneCfg.Grouping.KCoreK,
+		MinSimilarity:             cloneCfg.Filtering.MinSimilarity,
+		MaxSimilarity:             cloneCfg.Filtering.MaxSimilarity,
+		CloneTypes:                cloneTypes,
+		OutputFormat:              domain.OutputFormatText, // Default, overridden by CLI
+		Recursive:                 domain.BoolPtr(domain.BoolValue(cloneCfg.Input.Recursive, true)),
+		IncludePatterns:           cloneCfg.Input.IncludePatterns,
+		ExcludePatterns:           cloneCfg.Input.ExcludePatterns,
 		// DFA (Data Flow Analysis) - default enabled for multi-dimensional classification
 		EnableDFA: domain.BoolValue(cloneCfg.Analysis.EnableDFA, true),
 		// LSH settings
@@ -238,11 +253,15 @@ func (c *CloneConfigurationLoader) updateConfigFromCloneRequest(cfg *config.Conf
 
 	cfg.Clones.Analysis.MinLines = req.MinLines
 	cfg.Clones.Analysis.MinNodes = req.MinNodes
+	cfg.Clones.Analysis.MinTokens = req.MinTokens
 	cfg.Clones.Analysis.MaxEditDistance = req.MaxEditDistance
 	cfg.Clones.Analysis.CostModelType = "python" // Default cost model
 	cfg.Clones.Analysis.IgnoreLiterals = domain.BoolPtr(domain.BoolValue(req.IgnoreLiterals, false))
 	cfg.Clones.Analysis.IgnoreIdentifiers = domain.BoolPtr(domain.BoolValue(req.IgnoreIdentifiers, false))
 	cfg.Clones.Analysis.SkipDocstrings = domain.BoolPtr(domain.BoolValue(req.SkipDocstrings, true))
+	cfg.Clones.Analysis.StringClones = domain.BoolPtr(req.ShouldDetectStringClones())
+	cfg.Clones.Analysis.MinStringLength = req.MinStringLength
+	cfg.Clones.Analysis.StringSimilarityThreshold = req.StringSimilarityThreshold
 
 	cfg.Clones.Thresholds.Type1Threshold = req.Type1Threshold
 	cfg.Clones.Thresholds.Type2Threshold = req.Type2Threshold
@@ -252,6 +271,9 @@ func (c *CloneConfigurationLoader) updateConfigFromCloneRequest(cfg *config.Conf
 
 	cfg.Clones.Output.ShowContent = domain.BoolPtr(req.ShouldShowContent())
 	cfg.Clones.Output.GroupClones = domain.BoolPtr(req.ShouldGroupClones())
+	cfg.Clones.Output.MaxGroupMembers = req.MaxGroupMembers
+	cfg.Clones.Output.CollapseSameFile = domain.BoolPtr(req.ShouldCollapseSameFile())
+	cfg.Clones.Output.ReportGroupBy = req.ReportGroupBy
 	cfg.Clones.Output.SortBy = sortBy
 
 	cfg.Clones.Filtering.MinSimilarity = req.MinSimilarity
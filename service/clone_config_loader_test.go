@@ -98,3 +98,22 @@ skip_docstrings = false
 
 	assert.False(t, domain.BoolValue(req.SkipDocstrings, true))
 }
+
+func TestCloneConfigurationLoader_LoadCloneConfig_LoadsReportShaping(t *testing.T) {
+	loader := NewCloneConfigurationLoader()
+	configPath := filepath.Join(t.TempDir(), ".pyscn.toml")
+	configContent := `[clones]
+max_group_members = 5
+collapse_same_file = true
+report_group_by = "package"
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+
+	req, err := loader.LoadCloneConfig(configPath)
+	require.NoError(t, err)
+	require.NotNil(t, req)
+
+	assert.Equal(t, 5, req.MaxGroupMembers)
+	assert.True(t, req.ShouldCollapseSameFile())
+	assert.Equal(t, domain.CloneReportGroupByPackage, req.ReportGroupBy)
+}
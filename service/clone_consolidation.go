@@ -0,0 +1,267 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// SuggestCloneExtractionTargets suggests, for each clone group spanning
+// several modules, the package where a helper replacing the clones should
+// live, and summarizes the groups by that package in
+// clones.Consolidation. It needs the dependency analysis and does nothing
+// without it.
+//
+// The helper is modeled as a new module imported by every module of the
+// group and importing the project modules all of them already depend on.
+// Starting from the lowest common package of the group, the first package
+// whose helper adds no layer violation is suggested. No target is suggested
+// when the helper would join an import cycle.
+func SuggestCloneExtractionTargets(clones *domain.CloneResponse, system *domain.SystemAnalysisResponse) {
+	if clones == nil || system == nil || system.DependencyAnalysis == nil {
+		return
+	}
+	metrics := system.DependencyAnalysis.ModuleMetrics
+
+	moduleByFile := make(map[string]string, len(metrics))
+	for name, module := range metrics {
+		if module != nil && module.FilePath != "" {
+			moduleByFile[cloneModulePathKey(module.FilePath)] = name
+		}
+	}
+
+	var layers *cloneLayerPolicy
+	if arch := system.ArchitectureAnalysis; arch != nil && arch.Rules != nil && len(arch.Rules.Layers) > 0 {
+		layers = newCloneLayerPolicy(arch.Rules)
+	}
+
+	for _, group := range clones.CloneGroups {
+		if group == nil {
+			continue
+		}
+		group.ExtractionTarget = nil
+
+		var modules []string
+		seen := make(map[string]bool)
+		for _, clone := range group.Clones {
+			if clone == nil || clone.Location == nil {
+				continue
+			}
+			module, ok := moduleByFile[cloneModulePathKey(clone.Location.FilePath)]
+			if ok && !seen[module] {
+				seen[module] = true
+				modules = append(modules, module)
+			}
+		}
+		if len(modules) < 2 {
+			continue
+		}
+		sort.Strings(modules)
+		group.ExtractionTarget = suggestExtractionTarget(modules, metrics, layers)
+	}
+
+	clones.Consolidation = consolidateCloneGroups(clones.CloneGroups)
+}
+
+// suggestExtractionTarget walks up from the lowest common package of
+// modules to the first package that keeps the layer rules
+func suggestExtractionTarget(modules []string, metrics map[string]*domain.ModuleDependencyMetrics, layers *cloneLayerPolicy) *domain.CloneExtractionTarget {
+	deps := sharedDependencies(modules, metrics)
+	for _, dep := range deps {
+		if metric := metrics[dep]; metric != nil {
+			for _, reached := range append([]string{dep}, metric.TransitiveDependencies...) {
+				if containsString(modules, reached) {
+					return nil // helper -> dep -> ... -> module -> helper
+				}
+			}
+		}
+	}
+
+	var rejected []string
+	for _, pkg := range packageCandidates(modules) {
+		layer, violation := "", ""
+		if layers != nil {
+			layer, violation = layers.check(pkg, modules, deps)
+		}
+		if violation != "" {
+			rejected = append(rejected, fmt.Sprintf("%s: %s", packageLabel(pkg), violation))
+			continue
+		}
+
+		rationale := fmt.Sprintf("lowest common package of %s", strings.Join(modules, ", "))
+		if len(rejected) > 0 {
+			rationale = fmt.Sprintf("lowest common package without layer violations (rejected %s)", strings.Join(rejected, "; "))
+		}
+		if layer == "unknown" {
+			layer = ""
+		}
+		return &domain.CloneExtractionTarget{
+			Package:   pkg,
+			Layer:     layer,
+			Modules:   modules,
+			Rationale: rationale,
+		}
+	}
+	return nil
+}
+
+// sharedDependencies returns the project modules every module depends on
+// directly, excluding the modules themselves
+func sharedDependencies(modules []string, metrics map[string]*domain.ModuleDependencyMetrics) []string {
+	counts := make(map[string]int)
+	for _, module := range modules {
+		metric := metrics[module]
+		if metric == nil {
+			return nil
+		}
+		seen := make(map[string]bool)
+		for _, dep := range metric.DirectDependencies {
+			if !seen[dep] {
+				seen[dep] = true
+				counts[dep]++
+			}
+		}
+	}
+
+	var shared []string
+	for dep, count := range counts {
+		if count == len(modules) && !containsString(modules, dep) {
+			shared = append(shared, dep)
+		}
+	}
+	sort.Strings(shared)
+	return shared
+}
+
+// packageCandidates returns the packages containing all modules, lowest
+// first, ending with the top level ("")
+func packageCandidates(modules []string) []string {
+	common := strings.Split(modules[0], ".")
+	common = common[:len(common)-1]
+	for _, module := range modules[1:] {
+		parts := strings.Split(module, ".")
+		n := 0
+		for n < len(common) && n < len(parts)-1 && common[n] == parts[n] {
+			n++
+		}
+		common = common[:n]
+	}
+
+	candidates := make([]string, 0, len(common)+1)
+	for n := len(common); n >= 0; n-- {
+		candidates = append(candidates, strings.Join(common[:n], "."))
+	}
+	return candidates
+}
+
+// consolidateCloneGroups groups clone groups by extraction target, largest
+// duplication first
+func consolidateCloneGroups(groups []*domain.CloneGroup) []*domain.CloneConsolidation {
+	byPackage := make(map[string]*domain.CloneConsolidation)
+	var result []*domain.CloneConsolidation
+	for _, group := range groups {
+		if group == nil || group.ExtractionTarget == nil {
+			continue
+		}
+		pkg := group.ExtractionTarget.Package
+		entry, ok := byPackage[pkg]
+		if !ok {
+			entry = &domain.CloneConsolidation{Package: pkg}
+			byPackage[pkg] = entry
+			result = append(result, entry)
+		}
+
+		entry.GroupIDs = append(entry.GroupIDs, group.ID)
+		total, largest := 0, 0
+		for _, clone := range group.Clones {
+			if clone == nil {
+				continue
+			}
+			entry.Fragments++
+			total += clone.LineCount
+			largest = max(largest, clone.LineCount)
+		}
+		entry.DuplicateLines += total - largest
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].DuplicateLines != result[j].DuplicateLines {
+			return result[i].DuplicateLines > result[j].DuplicateLines
+		}
+		return result[i].Package < result[j].Package
+	})
+	return result
+}
+
+// cloneLayerPolicy evaluates the layer rules for a hypothetical helper
+type cloneLayerPolicy struct {
+	service  *SystemAnalysisServiceImpl
+	rules    *domain.ArchitectureRules
+	compiled map[string][]compiledPattern
+}
+
+func newCloneLayerPolicy(rules *domain.ArchitectureRules) *cloneLayerPolicy {
+	service := NewSystemAnalysisService()
+	return &cloneLayerPolicy{
+		service:  service,
+		rules:    rules,
+		compiled: service.compileLayerPatterns(rules.Layers),
+	}
+}
+
+// check returns the layer of a helper placed in pkg and, when importing it
+// from modules or importing deps from it breaks a rule, why
+func (p *cloneLayerPolicy) check(pkg string, modules, deps []string) (string, string) {
+	helper := pkg
+	if helper == "" {
+		helper = "shared"
+	}
+	layer := p.service.layerForModule(helper, p.rules, p.compiled)
+
+	for _, module := range modules {
+		from := p.service.layerForModule(module, p.rules, p.compiled)
+		if violation := p.service.evaluateLayerEdge(p.rules, module, helper, from, layer); isLayerError(violation) {
+			return layer, fmt.Sprintf("%s may not import %s", from, layer)
+		}
+	}
+	for _, dep := range deps {
+		to := p.service.layerForModule(dep, p.rules, p.compiled)
+		if violation := p.service.evaluateLayerEdge(p.rules, helper, dep, layer, to); isLayerError(violation) {
+			return layer, fmt.Sprintf("%s may not import %s", layer, to)
+		}
+	}
+	return layer, ""
+}
+
+// isLayerError reports whether violation breaks a rule; warnings for
+// discouraged or unknown layers are tolerated
+func isLayerError(violation *domain.ArchitectureViolation) bool {
+	return violation != nil && violation.Severity == domain.ViolationSeverityError
+}
+
+// packageLabel names a package for display
+func packageLabel(pkg string) string {
+	if pkg == "" {
+		return "top level"
+	}
+	return pkg
+}
+
+func cloneModulePathKey(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
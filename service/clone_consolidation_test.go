@@ -0,0 +1,98 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func consolidationFixture(groups ...*domain.CloneGroup) (*domain.CloneResponse, *domain.SystemAnalysisResponse) {
+	module := func(name, path string, deps ...string) *domain.ModuleDependencyMetrics {
+		return &domain.ModuleDependencyMetrics{ModuleName: name, FilePath: path, DirectDependencies: deps, TransitiveDependencies: deps}
+	}
+	system := &domain.SystemAnalysisResponse{
+		DependencyAnalysis: &domain.DependencyAnalysisResult{
+			ModuleMetrics: map[string]*domain.ModuleDependencyMetrics{
+				"app.api.users":     module("app.api.users", "app/api/users.py", "app.domain.models"),
+				"app.api.orders":    module("app.api.orders", "app/api/orders.py", "app.domain.models"),
+				"app.domain.models": module("app.domain.models", "app/domain/models.py"),
+			},
+		},
+	}
+	return &domain.CloneResponse{CloneGroups: groups}, system
+}
+
+func cloneGroup(id string, lines int, paths ...string) *domain.CloneGroup {
+	group := &domain.CloneGroup{ID: id}
+	for _, path := range paths {
+		group.AddClone(&domain.Clone{Location: &domain.CloneLocation{FilePath: path}, LineCount: lines})
+	}
+	return group
+}
+
+func TestSuggestCloneExtractionTargets_LowestCommonPackage(t *testing.T) {
+	spanning := cloneGroup("cg-1", 10, "app/api/users.py", "app/api/orders.py")
+	local := cloneGroup("cg-2", 5, "app/api/users.py", "app/api/users.py")
+	clones, system := consolidationFixture(spanning, local)
+
+	SuggestCloneExtractionTargets(clones, system)
+
+	require.NotNil(t, spanning.ExtractionTarget)
+	assert.Equal(t, "app.api", spanning.ExtractionTarget.Package)
+	assert.Equal(t, []string{"app.api.orders", "app.api.users"}, spanning.ExtractionTarget.Modules)
+	assert.Nil(t, local.ExtractionTarget, "groups within one module need no shared module")
+
+	require.Len(t, clones.Consolidation, 1)
+	assert.Equal(t, domain.CloneConsolidation{Package: "app.api", GroupIDs: []string{"cg-1"}, Fragments: 2, DuplicateLines: 10}, *clones.Consolidation[0])
+}
+
+func TestSuggestCloneExtractionTargets_SkipsPackagesBreakingLayerRules(t *testing.T) {
+	group := cloneGroup("cg-1", 10, "app/api/users.py", "app/api/orders.py")
+	clones, system := consolidationFixture(group)
+	system.ArchitectureAnalysis = &domain.ArchitectureAnalysisResult{
+		Rules: &domain.ArchitectureRules{
+			Layers: []domain.Layer{
+				{Name: "presentation", Packages: []string{"api"}},
+				{Name: "domain", Packages: []string{"domain"}},
+			},
+			// Presentation modules may not import each other
+			Rules: []domain.LayerRule{{From: "presentation", Allow: []string{"domain"}}},
+		},
+	}
+
+	SuggestCloneExtractionTargets(clones, system)
+
+	require.NotNil(t, group.ExtractionTarget)
+	assert.Equal(t, "app", group.ExtractionTarget.Package)
+	assert.Contains(t, group.ExtractionTarget.Rationale, "app.api: presentation may not import presentation")
+}
+
+func TestSuggestCloneExtractionTargets_AvoidsCycles(t *testing.T) {
+	group := cloneGroup("cg-1", 10, "app/api/users.py", "app/api/orders.py")
+	clones, system := consolidationFixture(group)
+	// models imports users, so a helper importing models would close a cycle
+	models := system.DependencyAnalysis.ModuleMetrics["app.domain.models"]
+	models.DirectDependencies = []string{"app.api.users"}
+	models.TransitiveDependencies = []string{"app.api.users", "app.domain.models"}
+
+	SuggestCloneExtractionTargets(clones, system)
+
+	assert.Nil(t, group.ExtractionTarget)
+	assert.Empty(t, clones.Consolidation)
+}
+
+func TestSuggestCloneExtractionTargets_RequiresDependencyAnalysis(t *testing.T) {
+	group := cloneGroup("cg-1", 10, "app/api/users.py", "app/api/orders.py")
+	clones := &domain.CloneResponse{CloneGroups: []*domain.CloneGroup{group}}
+
+	SuggestCloneExtractionTargets(clones, nil)
+
+	assert.Nil(t, group.ExtractionTarget)
+}
+
+func TestPackageCandidates(t *testing.T) {
+	assert.Equal(t, []string{"app.api", "app", ""}, packageCandidates([]string{"app.api.a", "app.api.b.c"}))
+	assert.Equal(t, []string{""}, packageCandidates([]string{"a", "b"}))
+}
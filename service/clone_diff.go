@@ -0,0 +1,322 @@
+package service
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// maxCloneDiffLines bounds the lines shown of one clone diff
+const maxCloneDiffLines = 40
+
+// maxCloneDiffCells bounds the LCS table of a diff; larger inputs are shown
+// as all lines removed and added
+const maxCloneDiffCells = 1 << 20
+
+// cloneDiffOp is the role of a line in a clone diff
+type cloneDiffOp int
+
+const (
+	cloneDiffEqual cloneDiffOp = iota
+	cloneDiffRemoved
+	cloneDiffAdded
+)
+
+// cloneDiffSegment is a run of a changed line; Changed runs differ from the
+// paired line of the other clone, e.g. a renamed identifier
+type cloneDiffSegment struct {
+	Text    string
+	Changed bool
+}
+
+// cloneDiffLine is one line of a clone diff. Changed lines paired with a line
+// of the other clone carry Segments.
+type cloneDiffLine struct {
+	Op       cloneDiffOp
+	Text     string
+	Segments []cloneDiffSegment
+}
+
+// diffCloneContent diffs the source of two clones line by line. Both are
+// dedented first, so clones at different nesting levels line up. Runs of
+// removed and added lines are paired in order and diffed token by token.
+func diffCloneContent(from, to string) []cloneDiffLine {
+	lines := diffLines(dedentLines(from), dedentLines(to))
+
+	for start := 0; start < len(lines); {
+		if lines[start].Op == cloneDiffEqual {
+			start++
+			continue
+		}
+		end := start
+		for end < len(lines) && lines[end].Op == cloneDiffRemoved {
+			end++
+		}
+		removed := lines[start:end]
+		addedEnd := end
+		for addedEnd < len(lines) && lines[addedEnd].Op == cloneDiffAdded {
+			addedEnd++
+		}
+		added := lines[end:addedEnd]
+		for i := range min(len(removed), len(added)) {
+			removed[i].Segments, added[i].Segments = diffTokens(removed[i].Text, added[i].Text)
+		}
+		start = max(addedEnd, start+1)
+	}
+	return lines
+}
+
+// diffLines returns the line diff of a and b, removals before additions
+func diffLines(a, b []string) []cloneDiffLine {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	lines := make([]cloneDiffLine, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		lines = append(lines, cloneDiffLine{Op: cloneDiffEqual, Text: line})
+	}
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	for _, op := range lcsOps(midA, midB, func(i, j int) bool { return midA[i] == midB[j] }) {
+		switch op.op {
+		case cloneDiffEqual, cloneDiffRemoved:
+			lines = append(lines, cloneDiffLine{Op: op.op, Text: midA[op.index]})
+		case cloneDiffAdded:
+			lines = append(lines, cloneDiffLine{Op: op.op, Text: midB[op.index]})
+		}
+	}
+	for _, line := range a[len(a)-suffix:] {
+		lines = append(lines, cloneDiffLine{Op: cloneDiffEqual, Text: line})
+	}
+	return lines
+}
+
+// diffTokens splits two paired lines into segments, marking the tokens that
+// differ between them
+func diffTokens(from, to string) (fromSegments, toSegments []cloneDiffSegment) {
+	a, b := splitDiffTokens(from), splitDiffTokens(to)
+	appendSegment := func(segments []cloneDiffSegment, text string, changed bool) []cloneDiffSegment {
+		if n := len(segments); n > 0 && segments[n-1].Changed == changed {
+			segments[n-1].Text += text
+			return segments
+		}
+		return append(segments, cloneDiffSegment{Text: text, Changed: changed})
+	}
+	for _, op := range lcsOps(a, b, func(i, j int) bool { return a[i] == b[j] }) {
+		switch op.op {
+		case cloneDiffEqual:
+			fromSegments = appendSegment(fromSegments, a[op.index], false)
+			toSegments = appendSegment(toSegments, a[op.index], false)
+		case cloneDiffRemoved:
+			fromSegments = appendSegment(fromSegments, a[op.index], true)
+		case cloneDiffAdded:
+			toSegments = appendSegment(toSegments, b[op.index], true)
+		}
+	}
+	return fromSegments, toSegments
+}
+
+// lcsOp is one step of an edit script: index is into a for equal and
+// removed steps and into b for added steps
+type lcsOp struct {
+	op    cloneDiffOp
+	index int
+}
+
+// lcsOps returns the edit script turning a into b along their longest common
+// subsequence, removals before additions. Inputs too large for the table are
+// replaced wholesale.
+func lcsOps[T any](a, b []T, equal func(i, j int) bool) []lcsOp {
+	n, m := len(a), len(b)
+	ops := make([]lcsOp, 0, n+m)
+	if n*m > maxCloneDiffCells {
+		for i := range n {
+			ops = append(ops, lcsOp{op: cloneDiffRemoved, index: i})
+		}
+		for j := range m {
+			ops = append(ops, lcsOp{op: cloneDiffAdded, index: j})
+		}
+		return ops
+	}
+
+	// lengths[i][j] is the LCS length of a[i:] and b[j:]
+	lengths := make([][]int, n+1)
+	for i := range lengths {
+		lengths[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if equal(i, j) {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && equal(i, j):
+			ops = append(ops, lcsOp{op: cloneDiffEqual, index: i})
+			i++
+			j++
+		case j == m || (i < n && lengths[i+1][j] >= lengths[i][j+1]):
+			ops = append(ops, lcsOp{op: cloneDiffRemoved, index: i})
+			i++
+		default:
+			ops = append(ops, lcsOp{op: cloneDiffAdded, index: j})
+			j++
+		}
+	}
+	return ops
+}
+
+// splitDiffTokens splits a line into identifiers and numbers, runs of
+// whitespace and single other characters
+func splitDiffTokens(line string) []string {
+	var tokens []string
+	runes := []rune(line)
+	for start := 0; start < len(runes); {
+		end := start + 1
+		switch r := runes[start]; {
+		case isIdentifierRune(r):
+			for end < len(runes) && isIdentifierRune(runes[end]) {
+				end++
+			}
+		case unicode.IsSpace(r):
+			for end < len(runes) && unicode.IsSpace(runes[end]) {
+				end++
+			}
+		}
+		tokens = append(tokens, string(runes[start:end]))
+		start = end
+	}
+	return tokens
+}
+
+func isIdentifierRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// dedentLines splits content into lines and removes the indentation common
+// to its non-blank lines
+func dedentLines(content string) []string {
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		width := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent < 0 || width < indent {
+			indent = width
+		}
+	}
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if len(line) >= indent && indent > 0 {
+			line = line[indent:]
+		}
+		lines[i] = line
+	}
+	return lines
+}
+
+// cloneDiffPrefix returns the unified diff marker of a line
+func cloneDiffPrefix(op cloneDiffOp) string {
+	switch op {
+	case cloneDiffRemoved:
+		return "-"
+	case cloneDiffAdded:
+		return "+"
+	default:
+		return " "
+	}
+}
+
+// writeCloneDiff writes a unified diff turning from into to, each line
+// indented by indent. Nothing is written unless both clones carry content.
+func writeCloneDiff(writer io.Writer, indent int, from, to *domain.Clone) {
+	if from == nil || to == nil || from.Content == "" || to.Content == "" {
+		return
+	}
+	pad := strings.Repeat(" ", indent)
+	fmt.Fprintf(writer, "%s--- %s\n", pad, cloneDiffLabel(from))
+	fmt.Fprintf(writer, "%s+++ %s\n", pad, cloneDiffLabel(to))
+	lines := diffCloneContent(from.Content, to.Content)
+	for i, line := range lines {
+		if i == maxCloneDiffLines {
+			fmt.Fprintf(writer, "%s... %d more lines\n", pad, len(lines)-i)
+			break
+		}
+		fmt.Fprintf(writer, "%s%s%s\n", pad, cloneDiffPrefix(line.Op), line.Text)
+	}
+}
+
+// cloneDiffHTML renders the diff turning from into to for the HTML report,
+// highlighting the tokens that differ within changed lines
+func cloneDiffHTML(from, to *domain.Clone) template.HTML {
+	if from == nil || to == nil || from.Content == "" || to.Content == "" {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(`<pre class="code-preview clone-diff">`)
+	lines := diffCloneContent(from.Content, to.Content)
+	for i, line := range lines {
+		if i == maxCloneDiffLines {
+			fmt.Fprintf(&b, `<span class="diff-line">... %d more lines</span>`, len(lines)-i)
+			break
+		}
+		class, mark := "diff-line", ""
+		switch line.Op {
+		case cloneDiffRemoved:
+			class, mark = "diff-line diff-removed", "del"
+		case cloneDiffAdded:
+			class, mark = "diff-line diff-added", "ins"
+		}
+		fmt.Fprintf(&b, `<span class="%s">%s`, class, cloneDiffPrefix(line.Op))
+		if line.Segments == nil {
+			b.WriteString(template.HTMLEscapeString(line.Text))
+		}
+		for _, segment := range line.Segments {
+			text := template.HTMLEscapeString(segment.Text)
+			if segment.Changed && strings.TrimSpace(segment.Text) != "" {
+				fmt.Fprintf(&b, "<%s>%s</%s>", mark, text, mark)
+			} else {
+				b.WriteString(text)
+			}
+		}
+		b.WriteString("</span>")
+	}
+	b.WriteString("</pre>")
+	return template.HTML(b.String())
+}
+
+// cloneDiffLabel names a clone in a diff header
+func cloneDiffLabel(clone *domain.Clone) string {
+	if clone.Location == nil {
+		return fmt.Sprintf("clone %d", clone.ID)
+	}
+	return clone.Location.String()
+}
+
+// writeCloneGroupDiffs writes the diff of each member of a group against its
+// first member, the representative the others are compared with
+func writeCloneGroupDiffs(writer io.Writer, indent int, group *domain.CloneGroup) {
+	if group == nil || len(group.Clones) < 2 {
+		return
+	}
+	for _, clone := range group.Clones[1:] {
+		writeCloneDiff(writer, indent, group.Clones[0], clone)
+	}
+}
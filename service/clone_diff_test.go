@@ -0,0 +1,60 @@
+package service
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffCloneContent(t *testing.T) {
+	from := "    def total(items):\n        result = 0\n        for item in items:\n            result += item\n        return result\n"
+	to := "def sum_all(values):\n    acc = 0\n    for value in values:\n        acc += value\n    log(acc)\n    return acc\n"
+
+	lines := diffCloneContent(from, to)
+
+	var ops []cloneDiffOp
+	for _, line := range lines {
+		ops = append(ops, line.Op)
+	}
+	assert.Equal(t, []cloneDiffOp{
+		cloneDiffRemoved, cloneDiffRemoved, cloneDiffRemoved, cloneDiffRemoved, cloneDiffRemoved,
+		cloneDiffAdded, cloneDiffAdded, cloneDiffAdded, cloneDiffAdded, cloneDiffAdded, cloneDiffAdded,
+	}, ops)
+
+	// Paired lines mark only the renamed identifiers
+	assert.Equal(t, []cloneDiffSegment{
+		{Text: "def ", Changed: false},
+		{Text: "total", Changed: true},
+		{Text: "(", Changed: false},
+		{Text: "items", Changed: true},
+		{Text: "):", Changed: false},
+	}, lines[0].Segments)
+	assert.Equal(t, "def sum_all(values):", lines[5].Text)
+	assert.Nil(t, lines[10].Segments)
+}
+
+func TestDiffCloneContent_KeepsCommonLines(t *testing.T) {
+	lines := diffCloneContent("if x:\n    a = 1\n    return a", "if x:\n    b = 1\n    return a")
+
+	assert.Len(t, lines, 4)
+	assert.Equal(t, cloneDiffEqual, lines[0].Op)
+	assert.Equal(t, cloneDiffRemoved, lines[1].Op)
+	assert.Equal(t, cloneDiffAdded, lines[2].Op)
+	assert.Equal(t, cloneDiffEqual, lines[3].Op)
+}
+
+func TestWriteCloneDiff(t *testing.T) {
+	from := &domain.Clone{Location: &domain.CloneLocation{FilePath: "a.py", StartLine: 1, EndLine: 2}, Content: "def a():\n    return 1"}
+	to := &domain.Clone{Location: &domain.CloneLocation{FilePath: "b.py", StartLine: 3, EndLine: 4}, Content: "def b():\n    return 1"}
+
+	var buf bytes.Buffer
+	writeCloneDiff(&buf, 2, from, to)
+
+	assert.Equal(t, "  --- a.py:1:0-2:0\n  +++ b.py:3:0-4:0\n  -def a():\n  +def b():\n       return 1\n", buf.String())
+
+	buf.Reset()
+	writeCloneDiff(&buf, 2, from, &domain.Clone{Location: to.Location})
+	assert.Empty(t, buf.String())
+}
@@ -90,6 +90,7 @@ func (f *CloneOutputFormatter) formatAsText(response *domain.CloneResponse, writ
 	if len(response.ClonePairs) == 0 {
 		fmt.Fprint(writer, utils.FormatSectionHeader("RESULTS"))
 		fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, "Status", "No clones detected"))
+		writeStringClonesText(writer, utils, response.StringClones)
 		return nil
 	}
 
@@ -101,15 +102,26 @@ func (f *CloneOutputFormatter) formatAsText(response *domain.CloneResponse, writ
 			if group == nil {
 				continue
 			}
-			fmt.Fprint(writer, utils.FormatLabelWithIndent(0, "Group", fmt.Sprintf("%d (%s, %d clones, similarity: %.3f)",
-				group.ID, group.Type.String(), group.Size, group.Similarity)))
+			count := fmt.Sprintf("%d clones", group.Size)
+			if nested := group.Size - group.UniqueFragmentCount; group.UniqueFragmentCount > 0 && nested > 0 {
+				count = fmt.Sprintf("%d clones, %d nested dropped", group.UniqueFragmentCount, nested)
+			}
+			fmt.Fprint(writer, utils.FormatLabelWithIndent(0, "Group", fmt.Sprintf("%s (%s, %s, similarity: %.3f)",
+				group.ID, group.Type.String(), count, group.Similarity)))
 
 			for i, clone := range group.Clones {
 				if clone == nil || clone.Location == nil {
 					continue
 				}
+				representative := ""
+				if clone.ID == group.RepresentativeID {
+					representative = ", representative"
+				}
 				fmt.Fprint(writer, utils.FormatLabelWithIndent(ItemPadding, fmt.Sprintf("Clone %d", i+1),
-					fmt.Sprintf("%s (%d lines, %d nodes)", clone.Location.String(), clone.LineCount, clone.Size)))
+					fmt.Sprintf("%s (%d lines, %d nodes%s)", clone.Location.String(), clone.LineCount, clone.Size, representative)))
+			}
+			if response.Request.ShouldShowContent() {
+				writeCloneGroupDiffs(writer, ItemPadding+2, group)
 			}
 			fmt.Fprint(writer, "\n")
 		}
@@ -133,25 +145,38 @@ func (f *CloneOutputFormatter) formatAsText(response *domain.CloneResponse, writ
 					fmt.Sprintf("%s (%d lines, %d nodes)", pair.Clone2.Location.String(), pair.Clone2.LineCount, pair.Clone2.Size)))
 			}
 
-			if response.Request != nil && response.Request.ShouldShowContent() && pair.Clone1 != nil && pair.Clone1.Content != "" {
-				fmt.Fprint(writer, utils.FormatLabelWithIndent(SectionPadding, "Preview", ""))
-				lines := strings.Split(pair.Clone1.Content, "\n")
-				for j, line := range lines {
-					if j >= 5 { // Limit preview to 5 lines
-						fmt.Fprintf(writer, "%s...\n", strings.Repeat(" ", ItemPadding+2))
-						break
-					}
-					fmt.Fprintf(writer, "%s%s\n", strings.Repeat(" ", ItemPadding+2), line)
-				}
+			if response.Request != nil && response.Request.ShouldShowContent() {
+				writeCloneDiff(writer, ItemPadding+2, pair.Clone1, pair.Clone2)
 			}
 
 			fmt.Fprint(writer, "\n")
 		}
 	}
 
+	writeStringClonesText(writer, utils, response.StringClones)
 	return nil
 }
 
+// writeStringClonesText writes the string clone groups, if any
+func writeStringClonesText(writer io.Writer, utils *FormatUtils, groups []*domain.StringCloneGroup) {
+	if len(groups) == 0 {
+		return
+	}
+	fmt.Fprint(writer, utils.FormatSectionHeader("STRING CLONES"))
+	for _, group := range groups {
+		fmt.Fprint(writer, utils.FormatLabelWithIndent(0, "Group", fmt.Sprintf("%d (%s, %d literals, similarity: %.3f)",
+			group.ID, group.Kind, len(group.Literals), group.Similarity)))
+		for i, literal := range group.Literals {
+			fmt.Fprint(writer, utils.FormatLabelWithIndent(ItemPadding, fmt.Sprintf("Literal %d", i+1),
+				fmt.Sprintf("%s (%d chars)", literal.Location.String(), literal.Length)))
+		}
+		if len(group.Literals) > 0 {
+			fmt.Fprint(writer, utils.FormatLabelWithIndent(ItemPadding, "Preview", group.Literals[0].Preview))
+		}
+		fmt.Fprint(writer, "\n")
+	}
+}
+
 // formatAsJSON formats the response as JSON
 // formatAsCSV formats the response as CSV
 func (f *CloneOutputFormatter) formatAsCSV(response *domain.CloneResponse, writer io.Writer) error {
@@ -171,7 +196,7 @@ func (f *CloneOutputFormatter) formatAsCSV(response *domain.CloneResponse, write
 	// Write clone pairs
 	for _, pair := range response.ClonePairs {
 		record := []string{
-			fmt.Sprintf("%d", pair.ID),
+			pair.ID,
 			pair.Type.String(),
 			fmt.Sprintf("%.6f", pair.Similarity),
 			fmt.Sprintf("%.6f", pair.Confidence),
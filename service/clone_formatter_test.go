@@ -52,7 +52,7 @@ func createTestCloneResponse() *domain.CloneResponse {
 		},
 		CloneGroups: []*domain.CloneGroup{
 			{
-				ID:         1,
+				ID:         "1",
 				Type:       1,
 				Similarity: 0.92,
 				Clones: []*domain.Clone{
@@ -128,6 +128,29 @@ func TestCloneOutputFormatter_FormatCloneResponse_Text(t *testing.T) {
 				"No clones detected",
 			},
 		},
+		{
+			name: "string clones without code clones",
+			response: func() *domain.CloneResponse {
+				response := createMinimalCloneResponse()
+				response.StringClones = []*domain.StringCloneGroup{{
+					ID:         1,
+					Kind:       domain.StringCloneKindSQL,
+					Similarity: 0.9,
+					Literals: []*domain.StringClone{
+						{Location: &domain.CloneLocation{FilePath: "users.py", StartLine: 3, EndLine: 3}, Length: 90, Preview: "SELECT id FROM users"},
+						{Location: &domain.CloneLocation{FilePath: "reports.py", StartLine: 8, EndLine: 10}, Length: 92, Preview: "SELECT id FROM users WHERE"},
+					},
+				}}
+				return response
+			}(),
+			expectedParts: []string{
+				"No clones detected",
+				"STRING CLONES",
+				"1 (sql, 2 literals, similarity: 0.900)",
+				"reports.py:8",
+				"SELECT id FROM users",
+			},
+		},
 		{
 			name:     "failed response",
 			response: createFailedCloneResponse(),
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// ShapeCloneReport applies the report options of the clone request to its
+// response. With report_group_by set to file or package it lists the groups
+// by location. Unless full is set it then folds group members sharing a file
+// (collapse_same_file) and caps the members listed per group
+// (max_group_members). Clones and ClonePairs are left untouched.
+func ShapeCloneReport(clones *domain.CloneResponse, full bool) {
+	if clones == nil || clones.Request == nil {
+		return
+	}
+	req := clones.Request
+
+	switch req.ReportGroupBy {
+	case domain.CloneReportGroupByFile, domain.CloneReportGroupByPackage:
+		clones.Locations = groupClonesByLocation(clones.CloneGroups, req.ReportGroupBy)
+	default:
+		clones.Locations = nil
+	}
+
+	if full {
+		return
+	}
+	for _, group := range clones.CloneGroups {
+		if group == nil {
+			continue
+		}
+		total := len(group.Clones)
+		members := group.Clones
+		if req.ShouldCollapseSameFile() {
+			members = collapseSameFileMembers(members)
+			group.CollapsedMembers = total - len(members)
+		}
+		if req.MaxGroupMembers > 0 && len(members) > req.MaxGroupMembers {
+			group.OmittedMembers = len(members) - req.MaxGroupMembers
+			members = members[:req.MaxGroupMembers]
+		}
+		if len(members) != total {
+			group.TotalMembers = total
+			group.Clones = members
+		}
+	}
+}
+
+// collapseSameFileMembers keeps the first member of each file
+func collapseSameFileMembers(members []*domain.Clone) []*domain.Clone {
+	seen := make(map[string]bool)
+	kept := make([]*domain.Clone, 0, len(members))
+	for _, clone := range members {
+		if clone == nil || clone.Location == nil {
+			kept = append(kept, clone)
+			continue
+		}
+		if seen[clone.Location.FilePath] {
+			continue
+		}
+		seen[clone.Location.FilePath] = true
+		kept = append(kept, clone)
+	}
+	return kept
+}
+
+// groupClonesByLocation lists, for each file or package directory, the
+// groups with members there, most duplicated lines first
+func groupClonesByLocation(groups []*domain.CloneGroup, groupBy string) []*domain.CloneLocationGroups {
+	byLocation := make(map[string]*domain.CloneLocationGroups)
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+		// Members in group order, per location
+		members := make(map[string]*domain.CloneLocationMember)
+		var order []string
+		for _, clone := range group.Clones {
+			if clone == nil || clone.Location == nil {
+				continue
+			}
+			location := filepath.ToSlash(clone.Location.FilePath)
+			if groupBy == domain.CloneReportGroupByPackage {
+				location = filepath.ToSlash(filepath.Dir(clone.Location.FilePath))
+			}
+			member, ok := members[location]
+			if !ok {
+				member = &domain.CloneLocationMember{
+					GroupID:    group.ID,
+					Type:       group.Type,
+					Similarity: group.Similarity,
+					GroupSize:  len(group.Clones),
+				}
+				members[location] = member
+				order = append(order, location)
+			}
+			member.Fragments++
+			lines := fmt.Sprintf("%d-%d", clone.Location.StartLine, clone.Location.EndLine)
+			if groupBy == domain.CloneReportGroupByPackage {
+				lines = filepath.ToSlash(clone.Location.FilePath) + ":" + lines
+			}
+			member.Lines = append(member.Lines, lines)
+
+			entry, ok := byLocation[location]
+			if !ok {
+				entry = &domain.CloneLocationGroups{Location: location}
+				byLocation[location] = entry
+			}
+			entry.Fragments++
+			entry.DuplicateLines += clone.LineCount
+		}
+		for _, location := range order {
+			byLocation[location].Groups = append(byLocation[location].Groups, *members[location])
+		}
+	}
+
+	result := make([]*domain.CloneLocationGroups, 0, len(byLocation))
+	for _, entry := range byLocation {
+		result = append(result, entry)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].DuplicateLines != result[j].DuplicateLines {
+			return result[i].DuplicateLines > result[j].DuplicateLines
+		}
+		return result[i].Location < result[j].Location
+	})
+	return result
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cloneReportFixture(req *domain.CloneRequest) *domain.CloneResponse {
+	return &domain.CloneResponse{
+		Request: req,
+		CloneGroups: []*domain.CloneGroup{
+			cloneGroup("cg-1", 10, "pkg/a.py", "pkg/a.py", "pkg/a.py", "pkg/b.py", "other/c.py"),
+			cloneGroup("cg-2", 4, "pkg/b.py", "other/c.py"),
+		},
+	}
+}
+
+func TestShapeCloneReport_CapsMembers(t *testing.T) {
+	req := domain.DefaultCloneRequest()
+	req.MaxGroupMembers = 2
+	clones := cloneReportFixture(req)
+
+	ShapeCloneReport(clones, false)
+
+	big := clones.CloneGroups[0]
+	assert.Len(t, big.Clones, 2)
+	assert.Equal(t, 5, big.TotalMembers)
+	assert.Equal(t, 3, big.OmittedMembers)
+	assert.Zero(t, big.CollapsedMembers)
+
+	small := clones.CloneGroups[1]
+	assert.Len(t, small.Clones, 2)
+	assert.Zero(t, small.TotalMembers, "groups within the cap are left as they are")
+}
+
+func TestShapeCloneReport_CollapsesSameFileMembers(t *testing.T) {
+	req := domain.DefaultCloneRequest()
+	collapse := true
+	req.CollapseSameFile = &collapse
+	clones := cloneReportFixture(req)
+
+	ShapeCloneReport(clones, false)
+
+	big := clones.CloneGroups[0]
+	require.Len(t, big.Clones, 3)
+	assert.Equal(t, "pkg/a.py", big.Clones[0].Location.FilePath)
+	assert.Equal(t, "pkg/b.py", big.Clones[1].Location.FilePath)
+	assert.Equal(t, "other/c.py", big.Clones[2].Location.FilePath)
+	assert.Equal(t, 5, big.TotalMembers)
+	assert.Equal(t, 2, big.CollapsedMembers)
+}
+
+func TestShapeCloneReport_FullKeepsEveryMember(t *testing.T) {
+	req := domain.DefaultCloneRequest()
+	collapse := true
+	req.CollapseSameFile = &collapse
+	req.MaxGroupMembers = 1
+	clones := cloneReportFixture(req)
+
+	ShapeCloneReport(clones, true)
+
+	assert.Len(t, clones.CloneGroups[0].Clones, 5)
+	assert.Zero(t, clones.CloneGroups[0].TotalMembers)
+	assert.Zero(t, clones.CloneGroups[0].OmittedMembers)
+}
+
+func TestShapeCloneReport_GroupsByFile(t *testing.T) {
+	req := domain.DefaultCloneRequest()
+	req.ReportGroupBy = domain.CloneReportGroupByFile
+	clones := cloneReportFixture(req)
+
+	ShapeCloneReport(clones, false)
+
+	require.Len(t, clones.Locations, 3)
+	assert.Equal(t, "pkg/a.py", clones.Locations[0].Location)
+	assert.Equal(t, 30, clones.Locations[0].DuplicateLines)
+	require.Len(t, clones.Locations[0].Groups, 1)
+	assert.Equal(t, 3, clones.Locations[0].Groups[0].Fragments)
+	assert.Equal(t, 5, clones.Locations[0].Groups[0].GroupSize)
+
+	// Ties on duplicate lines are ordered by location
+	assert.Equal(t, "other/c.py", clones.Locations[1].Location)
+	assert.Equal(t, "pkg/b.py", clones.Locations[2].Location)
+	assert.Len(t, clones.Locations[2].Groups, 2)
+}
+
+func TestShapeCloneReport_GroupsByPackage(t *testing.T) {
+	req := domain.DefaultCloneRequest()
+	req.ReportGroupBy = domain.CloneReportGroupByPackage
+	req.MaxGroupMembers = 1
+	clones := cloneReportFixture(req)
+
+	ShapeCloneReport(clones, false)
+
+	require.Len(t, clones.Locations, 2)
+	assert.Equal(t, "pkg", clones.Locations[0].Location)
+	assert.Equal(t, 44, clones.Locations[0].DuplicateLines)
+	assert.Equal(t, 5, clones.Locations[0].Fragments)
+	require.Len(t, clones.Locations[0].Groups, 2)
+	assert.Equal(t, "pkg/b.py:0-0", clones.Locations[0].Groups[1].Lines[0])
+	assert.Equal(t, "other", clones.Locations[1].Location)
+	assert.Equal(t, 2, clones.Locations[1].Fragments, "locations count every member, not just the listed ones")
+}
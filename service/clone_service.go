@@ -3,8 +3,10 @@ package service
 import (
 	"context"
 	"fmt"
+	"math"
 	"os"
 	"time"
+	"unicode/utf8"
 
 	coreapted "github.com/ludo-technologies/polyscan/core/apted"
 	"github.com/ludo-technologies/pyscn/domain"
@@ -67,46 +69,64 @@ func (s *CloneService) DetectClonesInFiles(ctx context.Context, filePaths []stri
 	detectorConfig := s.createDetectorConfig(req)
 	detector := analyzer.NewCloneDetector(detectorConfig)
 
-	allFragments, filesAnalyzed, linesAnalyzed, nodesAnalyzed, err := s.extractFragmentsFromFiles(ctx, filePaths, detector)
+	var stringDetector *analyzer.StringCloneDetector
+	if req.ShouldDetectStringClones() {
+		stringDetector = analyzer.NewStringCloneDetector(req.MinStringLength, req.StringSimilarityThreshold)
+	}
+
+	allFragments, fileLines, linesAnalyzed, nodesAnalyzed, err := s.extractFragmentsFromFiles(ctx, filePaths, detector, stringDetector)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.buildCloneResponse(ctx, startTime, detectorConfig, detector, allFragments, filesAnalyzed, linesAnalyzed, nodesAnalyzed, req)
+	response, err := s.buildCloneResponse(ctx, startTime, detectorConfig, detector, allFragments, fileLines, linesAnalyzed, nodesAnalyzed, req)
+	if err != nil {
+		return nil, err
+	}
+	if stringDetector != nil {
+		response.StringClones = convertStringCloneGroups(stringDetector.DetectGroups(ctx))
+	}
+	return response, nil
 }
 
-func (s *CloneService) extractFragmentsFromFiles(ctx context.Context, filePaths []string, detector *analyzer.CloneDetector) ([]*analyzer.CodeFragment, int, int, int, error) {
+// extractFragmentsFromFiles parses the files and extracts their fragments,
+// and their string literals when stringDetector is not nil. It also returns
+// the source lines of each file analyzed.
+func (s *CloneService) extractFragmentsFromFiles(ctx context.Context, filePaths []string, detector *analyzer.CloneDetector, stringDetector *analyzer.StringCloneDetector) ([]*analyzer.CodeFragment, map[string]int, int, int, error) {
 	pyParser := parser.New()
 	var allFragments []*analyzer.CodeFragment
+	fileLines := make(map[string]int)
 	linesAnalyzed := 0
 	nodesAnalyzed := 0
-	filesAnalyzed := 0
 
 	for _, filePath := range filePaths {
 		select {
 		case <-ctx.Done():
-			return nil, 0, 0, 0, fmt.Errorf("clone analysis cancelled: %w", ctx.Err())
+			return nil, nil, 0, 0, fmt.Errorf("clone analysis cancelled: %w", ctx.Err())
 		default:
 		}
 
 		content, err := readFileContent(filePath)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: Failed to read file %s: %v\n", filePath, err)
+			if !reportSkippedFile(ctx, filePath, err) {
+				fmt.Fprintf(os.Stderr, "Warning: Failed to read file %s: %v\n", filePath, err)
+			}
 			continue
 		}
 
-		parseResult, err := pyParser.Parse(ctx, content)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: Failed to parse file %s: %v\n", filePath, err)
-			continue
+		parseResult, err := pyParser.ParseFor(ctx, filePath, content)
+		if err == nil && (parseResult == nil || parseResult.AST == nil) {
+			err = fmt.Errorf("invalid parse result")
 		}
-		if parseResult == nil || parseResult.AST == nil {
-			fmt.Fprintf(os.Stderr, "Warning: Invalid parse result for file %s\n", filePath)
+		if err != nil {
+			if !reportSkippedFile(ctx, filePath, err) {
+				fmt.Fprintf(os.Stderr, "Warning: Failed to parse file %s: %v\n", filePath, err)
+			}
 			continue
 		}
 
-		filesAnalyzed++
-		linesAnalyzed += countSourceLines(content)
+		fileLines[filePath] = countSourceLines(content)
+		linesAnalyzed += fileLines[filePath]
 
 		statsVisitor := parser.NewStatisticsVisitor()
 		parseResult.AST.Accept(statsVisitor)
@@ -114,10 +134,29 @@ func (s *CloneService) extractFragmentsFromFiles(ctx context.Context, filePaths
 
 		astNodes := []*parser.Node{parseResult.AST}
 		fragments := detector.ExtractFragmentsWithSource(astNodes, filePath, content)
-		allFragments = append(allFragments, fragments...)
+		allFragments = append(allFragments, withoutAllowedClones(fragments, parseCodeDirectives(parseResult.AST, content))...)
+		if stringDetector != nil {
+			stringDetector.AddFile(filePath, parseResult.AST)
+		}
 	}
 
-	return allFragments, filesAnalyzed, linesAnalyzed, nodesAnalyzed, nil
+	return allFragments, fileLines, linesAnalyzed, nodesAnalyzed, nil
+}
+
+// withoutAllowedClones drops the fragments of functions and classes tagged
+// "# pyscn: allow-clone", so they never pair with other code
+func withoutAllowedClones(fragments []*analyzer.CodeFragment, directives *codeDirectives) []*analyzer.CodeFragment {
+	if directives == nil {
+		return fragments
+	}
+	kept := fragments[:0]
+	for _, fragment := range fragments {
+		if fragment.Location != nil && directives.allowsClone(fragment.Location.StartLine) {
+			continue
+		}
+		kept = append(kept, fragment)
+	}
+	return kept
 }
 
 func (s *CloneService) buildCloneResponse(
@@ -126,11 +165,12 @@ func (s *CloneService) buildCloneResponse(
 	detectorConfig *analyzer.CloneDetectorConfig,
 	detector *analyzer.CloneDetector,
 	allFragments []*analyzer.CodeFragment,
-	filesAnalyzed int,
+	fileLines map[string]int,
 	linesAnalyzed int,
 	nodesAnalyzed int,
 	req *domain.CloneRequest,
 ) (*domain.CloneResponse, error) {
+	filesAnalyzed := len(fileLines)
 	if len(allFragments) == 0 {
 		return &domain.CloneResponse{
 			Clones:      []*domain.Clone{},
@@ -161,16 +201,19 @@ func (s *CloneService) buildCloneResponse(
 
 	// Detect clones (detector will automatically use LSH or standard algorithm based on UseLSH setting)
 	detectionResult := detector.DetectClonesWithLSH(ctx, allFragments)
+	reportCloneTruncation(ctx, detectionResult.Truncation)
 
 	// Convert to domain objects
 	domainClones, fragmentIDs := s.convertFragmentsToDomainClones(allFragments)
 	domainClonePairs := s.convertClonePairsToDomain(detectionResult.Pairs, req.ShouldShowContent(), fragmentIDs)
 	domainCloneGroups := s.convertCloneGroupsToDomain(detectionResult.Groups, req.ShouldShowContent(), fragmentIDs)
+	domain.AssignStableCloneIDs(domainClonePairs, domainCloneGroups)
 
 	// Filter results based on request criteria
 	domainClonePairs = s.filterClonePairs(domainClonePairs, req)
 	domainCloneGroups = s.filterCloneGroups(domainCloneGroups, req)
 	domainClones = filterClonesToReferencedFragments(domainClones, domainClonePairs, domainCloneGroups)
+	loadCloneContents(domainClones, domainClonePairs, domainCloneGroups, req.ShouldShowContent())
 
 	// Sort results
 	s.sortResults(domainClones, domainClonePairs, domainCloneGroups, req)
@@ -186,16 +229,45 @@ func (s *CloneService) buildCloneResponse(
 	//	duration, len(domainClonePairs), len(domainCloneGroups)))
 
 	return &domain.CloneResponse{
-		Clones:      domainClones,
-		ClonePairs:  domainClonePairs,
-		CloneGroups: domainCloneGroups,
-		Statistics:  statistics,
-		Request:     req,
-		Duration:    duration,
-		Success:     true,
+		Clones:           domainClones,
+		ClonePairs:       domainClonePairs,
+		CloneGroups:      domainCloneGroups,
+		Statistics:       statistics,
+		SimilarityMatrix: buildCloneSimilarityMatrix(domainClonePairs, domainCloneGroups, fileLines, req.ReportGroupBy),
+		Request:          req,
+		Duration:         duration,
+		Success:          true,
 	}, nil
 }
 
+// convertStringCloneGroups converts string clone groups to domain objects,
+// numbering them in order
+func convertStringCloneGroups(groups []*analyzer.StringCloneGroup) []*domain.StringCloneGroup {
+	var result []*domain.StringCloneGroup
+	for i, group := range groups {
+		domainGroup := &domain.StringCloneGroup{
+			ID:         i + 1,
+			Kind:       group.Kind,
+			Similarity: math.Round(group.Similarity*1000) / 1000,
+		}
+		for _, literal := range group.Literals {
+			domainGroup.Literals = append(domainGroup.Literals, &domain.StringClone{
+				Location: &domain.CloneLocation{
+					FilePath:  literal.FilePath,
+					StartLine: literal.StartLine,
+					EndLine:   literal.EndLine,
+					StartCol:  literal.StartCol,
+					EndCol:    literal.EndCol,
+				},
+				Length:  utf8.RuneCountInString(literal.Text),
+				Preview: literal.Preview(),
+			})
+		}
+		result = append(result, domainGroup)
+	}
+	return result
+}
+
 // ComputeSimilarity computes similarity between two code fragments
 func (s *CloneService) ComputeSimilarity(ctx context.Context, fragment1, fragment2 string) (float64, error) {
 	// Input validation
@@ -272,6 +344,7 @@ func (s *CloneService) createDetectorConfig(req *domain.CloneRequest) *analyzer.
 	return &analyzer.CloneDetectorConfig{
 		MinLines:            req.MinLines,
 		MinNodes:            req.MinNodes,
+		MinTokens:           req.MinTokens,
 		Type1Threshold:      req.Type1Threshold,
 		Type2Threshold:      req.Type2Threshold,
 		Type3Threshold:      req.Type3Threshold,
@@ -284,6 +357,7 @@ func (s *CloneService) createDetectorConfig(req *domain.CloneRequest) *analyzer.
 		CostModelType:       "python", // Default to Python cost model
 		MaxClonePairs:       10000,    // Default max pairs
 		BatchSizeThreshold:  50,       // Default batch size threshold
+		MaxGoroutines:       req.Jobs,
 
 		// Advanced analysis
 		EnableDFAAnalysis: req.EnableDFA,
@@ -379,7 +453,6 @@ func (s *CloneService) convertClonePairsToDomain(
 		}
 
 		domainPairs[i] = &domain.ClonePair{
-			ID:         i + 1,
 			Clone1:     clone1,
 			Clone2:     clone2,
 			Similarity: pair.Similarity,
@@ -430,17 +503,48 @@ func filterClonesToReferencedFragments(
 	return filtered
 }
 
-// convertCloneGroupsToDomain converts analyzer clone groups to domain clone groups.
+// loadCloneContents reads the source text of the reported clones back from
+// their files, since fragments drop it after extraction. The top-level
+// clones always carry it; the clones of pairs and groups only when content
+// is shown.
+func loadCloneContents(clones []*domain.Clone, pairs []*domain.ClonePair, groups []*domain.CloneGroup, includeContent bool) {
+	reader := analyzer.NewFragmentSourceReader()
+	load := func(clone *domain.Clone) {
+		if clone == nil || clone.Location == nil || clone.Content != "" {
+			return
+		}
+		clone.Content = reader.Content(clone.Location.FilePath, clone.Location.StartLine, clone.Location.EndLine, clone.Hash)
+	}
+
+	for _, clone := range clones {
+		load(clone)
+	}
+	if !includeContent {
+		return
+	}
+	for _, pair := range pairs {
+		load(pair.Clone1)
+		load(pair.Clone2)
+	}
+	for _, group := range groups {
+		for _, clone := range group.Clones {
+			load(clone)
+		}
+	}
+}
+
+// convertCloneGroupsToDomain converts analyzer clone groups to domain clone
+// groups. Members nested in another member of the same file are dropped, and
+// so are groups left with fewer than two members.
 func (s *CloneService) convertCloneGroupsToDomain(
 	cloneGroups []*analyzer.CloneGroup,
 	includeContent bool,
 	fragmentIDs map[*analyzer.CodeFragment]int,
 ) []*domain.CloneGroup {
-	domainGroups := make([]*domain.CloneGroup, len(cloneGroups))
+	domainGroups := make([]*domain.CloneGroup, 0, len(cloneGroups))
 
-	for i, group := range cloneGroups {
+	for _, group := range cloneGroups {
 		domainGroup := &domain.CloneGroup{
-			ID:         group.ID,
 			Type:       s.convertCloneType(group.CloneType),
 			Similarity: group.Similarity,
 			Size:       group.Size,
@@ -472,7 +576,11 @@ func (s *CloneService) convertCloneGroupsToDomain(
 			domainGroup.AddClone(clone)
 		}
 
-		domainGroups[i] = domainGroup
+		domainGroup.SelectRepresentative()
+		if domainGroup.UniqueFragmentCount < 2 {
+			continue
+		}
+		domainGroups = append(domainGroups, domainGroup)
 	}
 
 	return domainGroups
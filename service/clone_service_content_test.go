@@ -1,6 +1,8 @@
 package service
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/ludo-technologies/pyscn/domain"
@@ -127,6 +129,28 @@ func TestCloneService_convertCloneGroupsToDomain_FragmentIDAndType(t *testing.T)
 	}
 }
 
+func TestCloneService_convertCloneGroupsToDomain_DropsNestedMembers(t *testing.T) {
+	service := NewCloneService()
+	outer := &analyzer.CodeFragment{Location: &analyzer.CodeLocation{FilePath: "a.py", StartLine: 1, EndLine: 12}, Size: 40, LineCount: 12}
+	inner := &analyzer.CodeFragment{Location: &analyzer.CodeLocation{FilePath: "a.py", StartLine: 3, EndLine: 8}, Size: 20, LineCount: 6}
+	other := &analyzer.CodeFragment{Location: &analyzer.CodeLocation{FilePath: "b.py", StartLine: 4, EndLine: 9}, Size: 20, LineCount: 6}
+	fragmentIDs := map[*analyzer.CodeFragment]int{outer: 1, inner: 2, other: 3}
+
+	groups := service.convertCloneGroupsToDomain([]*analyzer.CloneGroup{
+		{ID: 1, CloneType: analyzer.Type3Clone, Similarity: 0.9, Size: 3, Fragments: []*analyzer.CodeFragment{inner, other, outer}},
+		// Left with a single member once the nested one is dropped
+		{ID: 2, CloneType: analyzer.Type2Clone, Similarity: 0.9, Size: 2, Fragments: []*analyzer.CodeFragment{inner, outer}},
+	}, false, fragmentIDs)
+
+	require.Len(t, groups, 1)
+	assert.Equal(t, 3, groups[0].Size)
+	assert.Equal(t, 2, groups[0].UniqueFragmentCount)
+	assert.Equal(t, 1, groups[0].RepresentativeID)
+	require.Len(t, groups[0].Clones, 2)
+	assert.Equal(t, 1, groups[0].Clones[0].ID)
+	assert.Equal(t, 3, groups[0].Clones[1].ID)
+}
+
 // Regression for #488: the fragment hash must be forwarded to both pair-level
 // and group-level clone output instead of being dropped.
 func TestCloneService_convertClonesToDomain_Hash(t *testing.T) {
@@ -179,3 +203,37 @@ func TestCloneService_FilterClonesToReferencedFragments(t *testing.T) {
 	assert.Equal(t, domain.Type2Clone, pairs[0].Clone1.Type)
 	assert.Equal(t, domain.Type2Clone, pairs[0].Clone2.Type)
 }
+
+func TestCloneService_DetectClonesInFiles_LoadsContent(t *testing.T) {
+	dir := t.TempDir()
+	body := "    total = 0\n    for item in items:\n        if item > 0:\n            total += item\n    return total\n"
+	pathA := filepath.Join(dir, "a.py")
+	pathB := filepath.Join(dir, "b.py")
+	require.NoError(t, os.WriteFile(pathA, []byte("def sum_a(items):\n"+body), 0o644))
+	require.NoError(t, os.WriteFile(pathB, []byte("def sum_b(items):\n"+body), 0o644))
+
+	for _, showContent := range []bool{false, true} {
+		req := domain.DefaultCloneRequest()
+		req.Paths = []string{pathA, pathB}
+		req.MinLines = 2
+		req.MinNodes = 3
+		req.ShowContent = domain.BoolPtr(showContent)
+
+		response, err := NewCloneService().DetectClonesInFiles(t.Context(), req.Paths, req)
+		require.NoError(t, err)
+		require.NotEmpty(t, response.ClonePairs)
+		require.NotEmpty(t, response.Clones)
+
+		for _, clone := range response.Clones {
+			assert.Contains(t, clone.Content, "total += item", "top-level clones carry their source")
+		}
+		pair := response.ClonePairs[0]
+		if showContent {
+			assert.Contains(t, pair.Clone1.Content, "total += item")
+			assert.Contains(t, pair.Clone2.Content, "total += item")
+		} else {
+			assert.Empty(t, pair.Clone1.Content)
+			assert.Empty(t, pair.Clone2.Content)
+		}
+	}
+}
@@ -252,6 +252,26 @@ func TestCloneService_DetectClonesInFiles(t *testing.T) {
 		assert.Equal(t, 1, response.Statistics.FilesAnalyzed)
 	})
 
+	t.Run("parsing failures are reported as warnings", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		brokenFile := filepath.Join(tmpDir, "broken.py")
+		require.NoError(t, os.WriteFile(brokenFile, []byte("def oops(:\n    pass"), 0o644))
+
+		var warnings []domain.AnalysisWarning
+		warnCtx := domain.WithWarningReporter(ctx, domain.WarningReporterFunc(func(w domain.AnalysisWarning) {
+			warnings = append(warnings, w)
+		}))
+
+		req := newDefaultCloneRequest("../testdata/python/simple/functions.py", brokenFile)
+		_, err := service.DetectClonesInFiles(warnCtx, req.Paths, req)
+
+		require.NoError(t, err)
+		require.Len(t, warnings, 1)
+		assert.Equal(t, "parse", warnings[0].Analyzer)
+		assert.Equal(t, brokenFile, warnings[0].File)
+		assert.Contains(t, warnings[0].Message, "skipped")
+	})
+
 	t.Run("statistics uses processed file count after detection", func(t *testing.T) {
 		tmp := t.TempDir()
 		f1 := filepath.Join(tmp, "a.py")
@@ -527,22 +547,22 @@ func TestCloneService_FilterClonePairs(t *testing.T) {
 
 	pairs := []*domain.ClonePair{
 		{
-			ID:         1,
+			ID:         "1",
 			Similarity: 0.8,
 			Type:       domain.Type1Clone,
 		},
 		{
-			ID:         2,
+			ID:         "2",
 			Similarity: 0.6,
 			Type:       domain.Type2Clone,
 		},
 		{
-			ID:         3,
+			ID:         "3",
 			Similarity: 0.9,
 			Type:       domain.Type3Clone,
 		},
 		{
-			ID:         4,
+			ID:         "4",
 			Similarity: 0.4,
 			Type:       domain.Type1Clone,
 		},
@@ -557,7 +577,7 @@ func TestCloneService_FilterClonePairs(t *testing.T) {
 		filtered := service.filterClonePairs(pairs, req)
 
 		require.Len(t, filtered, 1)
-		assert.Equal(t, 1, filtered[0].ID)
+		assert.Equal(t, "1", filtered[0].ID)
 		assert.Equal(t, 0.8, filtered[0].Similarity)
 	})
 
@@ -593,17 +613,17 @@ func TestCloneService_FilterCloneGroups(t *testing.T) {
 
 	groups := []*domain.CloneGroup{
 		{
-			ID:         1,
+			ID:         "1",
 			Similarity: 0.8,
 			Type:       domain.Type1Clone,
 		},
 		{
-			ID:         2,
+			ID:         "2",
 			Similarity: 0.6,
 			Type:       domain.Type2Clone,
 		},
 		{
-			ID:         3,
+			ID:         "3",
 			Similarity: 0.9,
 			Type:       domain.Type3Clone,
 		},
@@ -618,7 +638,7 @@ func TestCloneService_FilterCloneGroups(t *testing.T) {
 		filtered := service.filterCloneGroups(groups, req)
 
 		require.Len(t, filtered, 1)
-		assert.Equal(t, 1, filtered[0].ID)
+		assert.Equal(t, "1", filtered[0].ID)
 		assert.Equal(t, 0.8, filtered[0].Similarity)
 	})
 
@@ -644,14 +664,14 @@ func TestCloneService_BuildCloneStatistics(t *testing.T) {
 	cloneC := &domain.Clone{ID: 3, Location: &domain.CloneLocation{FilePath: "c.py", StartLine: 1, EndLine: 10}}
 
 	pairs := []*domain.ClonePair{
-		{ID: 1, Clone1: cloneA, Clone2: cloneB, Similarity: 0.8, Type: domain.Type1Clone},
-		{ID: 2, Clone1: cloneB, Clone2: cloneC, Similarity: 0.9, Type: domain.Type2Clone},
-		{ID: 3, Clone1: cloneA, Clone2: cloneC, Similarity: 0.7, Type: domain.Type1Clone},
+		{ID: "1", Clone1: cloneA, Clone2: cloneB, Similarity: 0.8, Type: domain.Type1Clone},
+		{ID: "2", Clone1: cloneB, Clone2: cloneC, Similarity: 0.9, Type: domain.Type2Clone},
+		{ID: "3", Clone1: cloneA, Clone2: cloneC, Similarity: 0.7, Type: domain.Type1Clone},
 	}
 
 	groups := []*domain.CloneGroup{
-		{ID: 1, Clones: []*domain.Clone{cloneA, cloneB}},
-		{ID: 2, Clones: []*domain.Clone{cloneB, cloneC}},
+		{ID: "1", Clones: []*domain.Clone{cloneA, cloneB}},
+		{ID: "2", Clones: []*domain.Clone{cloneB, cloneC}},
 	}
 
 	fragA := &analyzer.CodeFragment{Location: &analyzer.CodeLocation{FilePath: "a.py", StartLine: 1, EndLine: 10}}
@@ -734,3 +754,69 @@ func TestCloneService_ResponseStructure(t *testing.T) {
 	assert.LessOrEqual(t, stats.AverageSimilarity, 1.0)
 	assert.NotNil(t, stats.ClonesByType)
 }
+
+func TestCloneService_AllowCloneDirective(t *testing.T) {
+	service := NewCloneService()
+	body := `    total = 0
+    for item in items:
+        if item > 10:
+            total += item * 2
+        else:
+            total -= 1
+    result = total * 3
+    print(result)
+    return [total, result]
+`
+	path := filepath.Join(t.TempDir(), "dup.py")
+	write := func(directive string) {
+		content := "def one(items):\n" + body + "\n\n" + directive + "def two(items):\n" + body
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	req := newDefaultCloneRequest(path)
+
+	write("")
+	response, err := service.DetectClonesInFiles(context.Background(), req.Paths, req)
+	require.NoError(t, err)
+	require.NotEmpty(t, response.ClonePairs)
+
+	write("# pyscn: allow-clone\n")
+	response, err = service.DetectClonesInFiles(context.Background(), req.Paths, req)
+	require.NoError(t, err)
+	assert.Empty(t, response.ClonePairs)
+}
+
+func TestCloneService_StringClones(t *testing.T) {
+	service := NewCloneService()
+	dir := t.TempDir()
+	files := map[string]string{
+		"users.py":   "def active(db):\n    return db.execute(\"SELECT id, name, email FROM users WHERE active = 1 ORDER BY name LIMIT 50\")\n",
+		"reports.py": "def inactive(db):\n    return db.execute(\"SELECT id, name, email FROM users WHERE active = 0 ORDER BY name LIMIT 50\")\n",
+	}
+	var paths []string
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		paths = append(paths, path)
+	}
+	req := newDefaultCloneRequest(paths...)
+
+	response, err := service.DetectClonesInFiles(context.Background(), req.Paths, req)
+	require.NoError(t, err)
+	assert.Empty(t, response.StringClones, "string clones are opt-in")
+
+	req.StringClones = domain.BoolPtr(true)
+	req.MinStringLength = 40
+	req.StringSimilarityThreshold = 0.8
+	response, err = service.DetectClonesInFiles(context.Background(), req.Paths, req)
+	require.NoError(t, err)
+	require.Len(t, response.StringClones, 1)
+
+	group := response.StringClones[0]
+	assert.Equal(t, 1, group.ID)
+	assert.Equal(t, domain.StringCloneKindSQL, group.Kind)
+	require.Len(t, group.Literals, 2)
+	assert.Equal(t, filepath.Join(dir, "reports.py"), group.Literals[0].Location.FilePath)
+	assert.Equal(t, 2, group.Literals[0].Location.StartLine)
+	assert.Equal(t, 73, group.Literals[0].Length)
+	assert.Empty(t, response.ClonePairs)
+}
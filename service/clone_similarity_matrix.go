@@ -0,0 +1,216 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// cloneSimilarityMatrixLimit caps the locations of the similarity matrix,
+// whose size grows with their square
+const cloneSimilarityMatrixLimit = 100
+
+// cloneLineCoverage records, for each file, the lines in clones of code in
+// each location
+type cloneLineCoverage struct {
+	groupBy string
+	lines   map[string]map[string]map[int]bool // file -> other location -> lines
+}
+
+// location returns the file, or its package directory, a file belongs to
+func (c *cloneLineCoverage) location(file string) string {
+	if c.groupBy == domain.CloneReportGroupByPackage {
+		return filepath.ToSlash(filepath.Dir(file))
+	}
+	return filepath.ToSlash(file)
+}
+
+// cover marks the lines of a clone as duplicated with code in a location
+func (c *cloneLineCoverage) cover(clone *domain.Clone, other string) {
+	file := filepath.ToSlash(clone.Location.FilePath)
+	byLocation, ok := c.lines[file]
+	if !ok {
+		byLocation = make(map[string]map[int]bool)
+		c.lines[file] = byLocation
+	}
+	lines, ok := byLocation[other]
+	if !ok {
+		lines = make(map[int]bool)
+		byLocation[other] = lines
+	}
+	for line := clone.Location.StartLine; line <= clone.Location.EndLine; line++ {
+		lines[line] = true
+	}
+}
+
+// link marks clones similar to each other: each one's lines are duplicated
+// with code in the locations of the others
+func (c *cloneLineCoverage) link(clones []*domain.Clone) {
+	var members []*domain.Clone
+	count := make(map[string]int)
+	for _, clone := range clones {
+		if clone == nil || clone.Location == nil {
+			continue
+		}
+		members = append(members, clone)
+		count[c.location(clone.Location.FilePath)]++
+	}
+	for _, clone := range members {
+		own := c.location(clone.Location.FilePath)
+		for other, n := range count {
+			// Within a location only when another member lies there
+			if other != own || n > 1 {
+				c.cover(clone, other)
+			}
+		}
+	}
+}
+
+// buildCloneSimilarityMatrix computes the share of duplicated lines between
+// each pair of files, or packages, from the clone pairs and groups.
+// fileLines holds the source lines of every analyzed file, so that a
+// package counts the lines of its files without clones too.
+func buildCloneSimilarityMatrix(pairs []*domain.ClonePair, groups []*domain.CloneGroup, fileLines map[string]int, groupBy string) *domain.CloneSimilarityMatrix {
+	if groupBy != domain.CloneReportGroupByPackage {
+		groupBy = domain.CloneReportGroupByFile
+	}
+	coverage := &cloneLineCoverage{groupBy: groupBy, lines: make(map[string]map[string]map[int]bool)}
+	for _, pair := range pairs {
+		if pair != nil {
+			coverage.link([]*domain.Clone{pair.Clone1, pair.Clone2})
+		}
+	}
+	for _, group := range groups {
+		if group != nil {
+			coverage.link(group.Clones)
+		}
+	}
+	if len(coverage.lines) == 0 {
+		return nil
+	}
+
+	// shared[a][b] counts the lines of a duplicated with code in b, and
+	// duplicated[a] the lines of a duplicated anywhere
+	shared := make(map[string]map[string]int)
+	duplicated := make(map[string]int)
+	for file, byLocation := range coverage.lines {
+		location := coverage.location(file)
+		if shared[location] == nil {
+			shared[location] = make(map[string]int)
+		}
+		union := make(map[int]bool)
+		for other, lines := range byLocation {
+			shared[location][other] += len(lines)
+			for line := range lines {
+				union[line] = true
+			}
+		}
+		duplicated[location] += len(union)
+	}
+	totalLines := make(map[string]int)
+	for file, lines := range fileLines {
+		totalLines[coverage.location(file)] += lines
+	}
+
+	locations := make([]string, 0, len(duplicated))
+	for location := range duplicated {
+		locations = append(locations, location)
+	}
+	sort.Slice(locations, func(i, j int) bool {
+		if duplicated[locations[i]] != duplicated[locations[j]] {
+			return duplicated[locations[i]] > duplicated[locations[j]]
+		}
+		return locations[i] < locations[j]
+	})
+	matrix := &domain.CloneSimilarityMatrix{GroupBy: groupBy}
+	if len(locations) > cloneSimilarityMatrixLimit {
+		matrix.OmittedLocations = len(locations) - cloneSimilarityMatrixLimit
+		locations = locations[:cloneSimilarityMatrixLimit]
+	}
+
+	matrix.Locations = locations
+	matrix.Lines = make([]int, len(locations))
+	for i, location := range locations {
+		// Clones always lie within their file, but guard against missing counts
+		matrix.Lines[i] = max(totalLines[location], duplicated[location])
+	}
+	matrix.Similarity = make([][]float64, len(locations))
+	for i, a := range locations {
+		matrix.Similarity[i] = make([]float64, len(locations))
+		for j, b := range locations {
+			lines := matrix.Lines[i] + matrix.Lines[j]
+			if lines == 0 {
+				continue
+			}
+			share := float64(shared[a][b]+shared[b][a]) / float64(lines)
+			matrix.Similarity[i][j] = math.Round(share*1000) / 1000
+		}
+	}
+	return matrix
+}
+
+// cloneHeatmapLimit caps the locations shown in the HTML heatmap
+const cloneHeatmapLimit = 20
+
+// cloneHeatmapScale is the similarity shown in the darkest color
+const cloneHeatmapScale = 0.5
+
+// CloneHeatmap is the similarity matrix laid out for the HTML report
+type CloneHeatmap struct {
+	GroupBy string
+	Rows    []CloneHeatmapRow
+
+	// Omitted counts the locations of the matrix left out of the heatmap
+	Omitted int
+}
+
+// CloneHeatmapRow is a location and its cells, one per location
+type CloneHeatmapRow struct {
+	Index    int // 1-based, labeling the column of the location
+	Location string
+	Label    string
+	Lines    int
+	Cells    []CloneHeatmapCell
+}
+
+// CloneHeatmapCell is the similarity between two locations
+type CloneHeatmapCell struct {
+	Other      string
+	Similarity float64
+	Color      string // Red with an alpha by similarity; empty when the locations share no lines
+}
+
+// BuildCloneHeatmap lays out the first locations of the similarity matrix
+// of a clone response, or returns nil without one
+func BuildCloneHeatmap(clones *domain.CloneResponse) *CloneHeatmap {
+	if clones == nil || clones.SimilarityMatrix == nil || len(clones.SimilarityMatrix.Locations) == 0 {
+		return nil
+	}
+	matrix := clones.SimilarityMatrix
+	n := min(len(matrix.Locations), cloneHeatmapLimit)
+	heatmap := &CloneHeatmap{
+		GroupBy: matrix.GroupBy,
+		Omitted: len(matrix.Locations) - n + matrix.OmittedLocations,
+	}
+	for i := 0; i < n; i++ {
+		location := matrix.Locations[i]
+		label := filepath.Base(location)
+		if matrix.GroupBy == domain.CloneReportGroupByPackage {
+			label = location
+		}
+		row := CloneHeatmapRow{Index: i + 1, Location: location, Label: label, Lines: matrix.Lines[i]}
+		for j := 0; j < n; j++ {
+			cell := CloneHeatmapCell{Other: matrix.Locations[j], Similarity: matrix.Similarity[i][j]}
+			if cell.Similarity > 0 {
+				alpha := 0.15 + 0.85*math.Min(1, cell.Similarity/cloneHeatmapScale)
+				cell.Color = fmt.Sprintf("#dc2626%02x", int(math.Round(alpha*255)))
+			}
+			row.Cells = append(row.Cells, cell)
+		}
+		heatmap.Rows = append(heatmap.Rows, row)
+	}
+	return heatmap
+}
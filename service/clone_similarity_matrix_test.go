@@ -0,0 +1,63 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func spanClone(path string, start, end int) *domain.Clone {
+	return &domain.Clone{
+		Location:  &domain.CloneLocation{FilePath: path, StartLine: start, EndLine: end},
+		LineCount: end - start + 1,
+	}
+}
+
+// similarityMatrixFixture pairs a.py with b.py and groups two fragments of
+// a.py with one of c.py; d.py has no clones
+func similarityMatrixFixture() ([]*domain.ClonePair, []*domain.CloneGroup, map[string]int) {
+	pairs := []*domain.ClonePair{
+		{Clone1: spanClone("pkg/a.py", 1, 20), Clone2: spanClone("pkg/b.py", 1, 20)},
+	}
+	group := &domain.CloneGroup{ID: "cg-1"}
+	group.AddClone(spanClone("pkg/a.py", 11, 30))
+	group.AddClone(spanClone("pkg/a.py", 41, 50))
+	group.AddClone(spanClone("other/c.py", 1, 10))
+	fileLines := map[string]int{"pkg/a.py": 100, "pkg/b.py": 50, "other/c.py": 40, "pkg/d.py": 10}
+	return pairs, []*domain.CloneGroup{group}, fileLines
+}
+
+func TestBuildCloneSimilarityMatrix_ByFile(t *testing.T) {
+	pairs, groups, fileLines := similarityMatrixFixture()
+
+	matrix := buildCloneSimilarityMatrix(pairs, groups, fileLines, domain.CloneReportGroupByGroup)
+
+	require.NotNil(t, matrix)
+	assert.Equal(t, domain.CloneReportGroupByFile, matrix.GroupBy)
+	assert.Equal(t, []string{"pkg/a.py", "pkg/b.py", "other/c.py"}, matrix.Locations, "most duplicated lines first")
+	assert.Equal(t, []int{100, 50, 40}, matrix.Lines)
+	assert.Equal(t, [][]float64{
+		// a.py: 30 lines duplicated within it, 20 with b.py and 30 with c.py
+		{0.3, 0.267, 0.286},
+		{0.267, 0, 0},
+		{0.286, 0, 0},
+	}, matrix.Similarity)
+	assert.Zero(t, matrix.OmittedLocations)
+}
+
+func TestBuildCloneSimilarityMatrix_ByPackage(t *testing.T) {
+	pairs, groups, fileLines := similarityMatrixFixture()
+
+	matrix := buildCloneSimilarityMatrix(pairs, groups, fileLines, domain.CloneReportGroupByPackage)
+
+	require.NotNil(t, matrix)
+	assert.Equal(t, []string{"pkg", "other"}, matrix.Locations)
+	assert.Equal(t, []int{160, 40}, matrix.Lines, "packages count the lines of files without clones")
+	assert.Equal(t, [][]float64{{0.375, 0.2}, {0.2, 0}}, matrix.Similarity)
+}
+
+func TestBuildCloneSimilarityMatrix_WithoutClones(t *testing.T) {
+	assert.Nil(t, buildCloneSimilarityMatrix(nil, nil, map[string]int{"a.py": 10}, domain.CloneReportGroupByFile))
+}
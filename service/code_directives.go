@@ -0,0 +1,189 @@
+package service
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// Directives a function or class can carry in a "# pyscn: ..." comment or a
+// "pyscn: ..." line of its docstring
+const (
+	directiveMaxComplexity = "max-complexity"
+	directiveAllowClone    = "allow-clone"
+	directiveAllowDeadCode = "allow-dead-code"
+)
+
+// codeDirectivePattern matches the directive list following "pyscn:"
+var codeDirectivePattern = regexp.MustCompile(`(?i)\bpyscn:\s*([a-z][a-z0-9_\-=,\s]*)`)
+
+// directiveAssignPattern matches the "=" of a directive value and its spacing
+var directiveAssignPattern = regexp.MustCompile(`\s*=\s*`)
+
+// codeDirectiveScope is a function or class carrying directives. The scope
+// spans its decorators through the end of its body.
+type codeDirectiveScope struct {
+	startLine     int
+	endLine       int
+	maxComplexity int
+	allowClone    bool
+	allowDeadCode bool
+}
+
+// codeDirectives holds the directive scopes of one file, outermost first
+type codeDirectives struct {
+	scopes []codeDirectiveScope
+}
+
+// parseCodeDirectives collects the directives of every function and class in
+// the file. A directive is read from the comment lines directly above the
+// definition or its decorators, a trailing comment on the def line, or a
+// docstring line. It returns nil when the file has none.
+func parseCodeDirectives(ast *parser.Node, content []byte) *codeDirectives {
+	if ast == nil || !bytes.Contains(bytes.ToLower(content), []byte("pyscn:")) {
+		return nil
+	}
+	lines := strings.Split(string(content), "\n")
+
+	var directives codeDirectives
+	ast.Walk(func(node *parser.Node) bool {
+		switch node.Type {
+		case parser.NodeFunctionDef, parser.NodeAsyncFunctionDef, parser.NodeClassDef:
+		default:
+			return true
+		}
+		scope := codeDirectiveScope{startLine: node.Location.StartLine, endLine: node.Location.EndLine}
+		for _, decorator := range node.Decorator {
+			if decorator != nil && decorator.Location.StartLine > 0 && decorator.Location.StartLine < scope.startLine {
+				scope.startLine = decorator.Location.StartLine
+			}
+		}
+
+		var text []string
+		// Comment block directly above
+		for line := scope.startLine - 1; line >= 1 && line <= len(lines); line-- {
+			trimmed := strings.TrimSpace(lines[line-1])
+			if !strings.HasPrefix(trimmed, "#") {
+				break
+			}
+			text = append(text, trimmed)
+		}
+		// Trailing comment on the def line
+		if line := node.Location.StartLine; line >= 1 && line <= len(lines) {
+			if idx := strings.Index(lines[line-1], "#"); idx >= 0 {
+				text = append(text, lines[line-1][idx:])
+			}
+		}
+		// Docstring
+		if len(node.Body) > 0 && hasDocstringNode(node.Body[0]) {
+			loc := node.Body[0].Location
+			for line := loc.StartLine; line >= 1 && line <= loc.EndLine && line <= len(lines); line++ {
+				text = append(text, lines[line-1])
+			}
+		}
+
+		if scope.apply(text) {
+			directives.scopes = append(directives.scopes, scope)
+		}
+		return true
+	})
+
+	if len(directives.scopes) == 0 {
+		return nil
+	}
+	return &directives
+}
+
+// hasDocstringNode reports whether the first body statement is a string
+func hasDocstringNode(first *parser.Node) bool {
+	if first == nil {
+		return false
+	}
+	if first.Type == parser.NodeExpr && len(first.Children) == 1 {
+		first = first.Children[0]
+	}
+	if first.Type != parser.NodeConstant {
+		return false
+	}
+	_, ok := first.Value.(string)
+	return ok
+}
+
+// apply records the directives found in text and reports whether any was
+// recognized. Unknown directives, such as the line-level "ignore", are
+// skipped.
+func (s *codeDirectiveScope) apply(text []string) bool {
+	found := false
+	for _, chunk := range text {
+		match := codeDirectivePattern.FindStringSubmatch(chunk)
+		if match == nil {
+			continue
+		}
+		list := directiveAssignPattern.ReplaceAllString(match[1], "=")
+		for _, token := range strings.FieldsFunc(list, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+			name, value, _ := strings.Cut(strings.ToLower(token), "=")
+			switch name {
+			case directiveMaxComplexity:
+				if budget, err := strconv.Atoi(value); err == nil && budget > 0 {
+					s.maxComplexity = budget
+					found = true
+				}
+			case directiveAllowClone:
+				s.allowClone = true
+				found = true
+			case directiveAllowDeadCode:
+				s.allowDeadCode = true
+				found = true
+			}
+		}
+	}
+	return found
+}
+
+// complexityBudget returns the max-complexity budget of the innermost scope
+// around line that sets one, or 0
+func (d *codeDirectives) complexityBudget(line int) int {
+	if d == nil {
+		return 0
+	}
+	budget := 0
+	for _, scope := range d.scopes {
+		if scope.maxComplexity > 0 && scope.contains(line) {
+			budget = scope.maxComplexity
+		}
+	}
+	return budget
+}
+
+// allowsClone reports whether line lies in a scope tagged allow-clone
+func (d *codeDirectives) allowsClone(line int) bool {
+	if d == nil {
+		return false
+	}
+	for _, scope := range d.scopes {
+		if scope.allowClone && scope.contains(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsDeadCode reports whether line lies in a scope tagged allow-dead-code
+func (d *codeDirectives) allowsDeadCode(line int) bool {
+	if d == nil {
+		return false
+	}
+	for _, scope := range d.scopes {
+		if scope.allowDeadCode && scope.contains(line) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s codeDirectiveScope) contains(line int) bool {
+	return line >= s.startLine && line <= s.endLine
+}
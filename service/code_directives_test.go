@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseDirectivesSource(t *testing.T, source string) *codeDirectives {
+	t.Helper()
+	result, err := parser.New().Parse(context.Background(), []byte(source))
+	require.NoError(t, err)
+	return parseCodeDirectives(result.AST, []byte(source))
+}
+
+func TestParseCodeDirectives(t *testing.T) {
+	source := `# pyscn: max-complexity = 25
+@decorator
+def legacy(x):
+    return x
+
+
+def tagged(x):  # pyscn: allow-clone, allow-dead-code
+    return x
+
+
+class Service:
+    """Legacy service.
+
+    pyscn: max-complexity=12
+    """
+
+    # pyscn: max-complexity=30
+    def handle(self):
+        pass
+
+    def other(self):
+        pass
+
+
+def plain():  # pyscn: ignore
+    pass
+`
+	directives := parseDirectivesSource(t, source)
+	require.NotNil(t, directives)
+
+	// Comment above the decorators
+	assert.Equal(t, 25, directives.complexityBudget(2))
+	assert.Equal(t, 25, directives.complexityBudget(3))
+
+	// Trailing comment on the def line
+	assert.True(t, directives.allowsClone(7))
+	assert.True(t, directives.allowsDeadCode(8))
+	assert.False(t, directives.allowsClone(3))
+
+	// The innermost budget wins; methods inherit the class budget
+	assert.Equal(t, 30, directives.complexityBudget(19))
+	assert.Equal(t, 12, directives.complexityBudget(22))
+
+	// Line-level suppressions are not scope directives
+	assert.Equal(t, 0, directives.complexityBudget(26))
+}
+
+func TestParseCodeDirectives_None(t *testing.T) {
+	assert.Nil(t, parseDirectivesSource(t, "def f():\n    pass\n"))
+	assert.Nil(t, parseDirectivesSource(t, "def f():  # pyscn: max-complexity=abc\n    pass\n"))
+
+	var directives *codeDirectives
+	assert.Equal(t, 0, directives.complexityBudget(1))
+	assert.False(t, directives.allowsClone(1))
+	assert.False(t, directives.allowsDeadCode(1))
+}
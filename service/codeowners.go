@@ -0,0 +1,219 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// codeownersLocations lists where GitHub looks for a CODEOWNERS file,
+// relative to the repository root, in order of precedence.
+var codeownersLocations = []string{
+	filepath.Join(".github", "CODEOWNERS"),
+	"CODEOWNERS",
+	filepath.Join("docs", "CODEOWNERS"),
+}
+
+// codeownersRule is a single pattern line of a CODEOWNERS file
+type codeownersRule struct {
+	pattern string
+	re      *regexp.Regexp
+	owners  []string
+}
+
+// Codeowners implements domain.OwnershipResolver using GitHub CODEOWNERS
+// semantics: patterns follow gitignore rules and the last matching rule wins.
+type Codeowners struct {
+	// Path is the CODEOWNERS file the rules were read from
+	Path string
+	// Root is the directory patterns are resolved against
+	Root string
+
+	rules []codeownersRule
+}
+
+// LoadCodeowners reads the CODEOWNERS file at path. When path is empty the
+// file is discovered by walking up from the first target to the repository
+// root and checking the standard GitHub locations.
+func LoadCodeowners(path string, targets []string) (*Codeowners, error) {
+	if path == "" {
+		found, err := findCodeowners(targets)
+		if err != nil {
+			return nil, err
+		}
+		path = found
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve CODEOWNERS path %s: %w", path, err)
+	}
+
+	file, err := os.Open(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CODEOWNERS file: %w", err)
+	}
+	defer file.Close()
+
+	owners, err := ParseCodeowners(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	owners.Path = absPath
+	owners.Root = codeownersRoot(absPath)
+	return owners, nil
+}
+
+// ParseCodeowners parses CODEOWNERS content. Root and Path are left empty;
+// callers resolving real files must set Root.
+func ParseCodeowners(r io.Reader) (*Codeowners, error) {
+	owners := &Codeowners{}
+
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if idx := strings.Index(line, " #"); idx >= 0 {
+			line = strings.TrimSpace(line[:idx])
+		}
+
+		fields := strings.Fields(line)
+		re, err := compileCodeownersPattern(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid pattern %q: %w", lineNo, fields[0], err)
+		}
+		owners.rules = append(owners.rules, codeownersRule{
+			pattern: fields[0],
+			re:      re,
+			owners:  fields[1:],
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return owners, nil
+}
+
+// Owners returns the owners of filePath. Files outside Root, files no rule
+// matches and files matched by a rule without owners have no owner.
+func (c *Codeowners) Owners(filePath string) []string {
+	rel := filePath
+	if c.Root != "" {
+		absPath, err := filepath.Abs(filePath)
+		if err != nil {
+			return nil
+		}
+		rel, err = filepath.Rel(c.Root, absPath)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return nil
+		}
+	}
+	rel = filepath.ToSlash(rel)
+
+	for i := len(c.rules) - 1; i >= 0; i-- {
+		if c.rules[i].re.MatchString(rel) {
+			return c.rules[i].owners
+		}
+	}
+	return nil
+}
+
+// compileCodeownersPattern translates a gitignore-style pattern into a regular
+// expression matched against slash-separated paths relative to the root.
+func compileCodeownersPattern(pattern string) (*regexp.Regexp, error) {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	trimmed := strings.TrimSuffix(pattern, "/")
+
+	// A pattern with a slash anywhere but the end is anchored to the root;
+	// otherwise it matches at any depth
+	anchored := strings.Contains(trimmed, "/")
+	trimmed = strings.TrimPrefix(trimmed, "/")
+
+	var sb strings.Builder
+	if anchored {
+		sb.WriteString("^")
+	} else {
+		sb.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(trimmed); i++ {
+		ch := trimmed[i]
+		switch {
+		case ch == '*' && i+1 < len(trimmed) && trimmed[i+1] == '*':
+			i++
+			if i+1 < len(trimmed) && trimmed[i+1] == '/' {
+				// "**/" matches zero or more leading directories
+				i++
+				sb.WriteString("(?:.*/)?")
+			} else {
+				sb.WriteString(".*")
+			}
+		case ch == '*':
+			sb.WriteString("[^/]*")
+		case ch == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+
+	// A match on a directory covers everything beneath it
+	if dirOnly {
+		sb.WriteString("/.*$")
+	} else {
+		sb.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(sb.String())
+}
+
+// findCodeowners walks up from the first target looking for a CODEOWNERS file,
+// stopping at the repository root.
+func findCodeowners(targets []string) (string, error) {
+	start := "."
+	if len(targets) > 0 {
+		start = targets[0]
+	}
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return "", err
+	}
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+
+	for {
+		for _, loc := range codeownersLocations {
+			candidate := filepath.Join(dir, loc)
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				return candidate, nil
+			}
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return "", fmt.Errorf("no CODEOWNERS file found for %s", start)
+}
+
+// codeownersRoot returns the repository root implied by a CODEOWNERS location
+func codeownersRoot(path string) string {
+	dir := filepath.Dir(path)
+	switch filepath.Base(dir) {
+	case ".github", "docs":
+		return filepath.Dir(dir)
+	}
+	return dir
+}
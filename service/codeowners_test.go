@@ -0,0 +1,84 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleCodeowners = `# Default owners
+*                   @org/platform
+
+# Python packages
+/payments/          @org/payments
+*.sql               @org/data
+docs/               @org/docs # trailing comment
+/src/**/legacy/*.py @org/legacy
+/payments/vendor/
+`
+
+func TestParseCodeowners_LastMatchWins(t *testing.T) {
+	owners, err := ParseCodeowners(strings.NewReader(sampleCodeowners))
+	require.NoError(t, err)
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"setup.py", []string{"@org/platform"}},
+		{"payments/api/views.py", []string{"@org/payments"}},
+		{"payments/schema.sql", []string{"@org/data"}},
+		{"src/docs/conf.py", []string{"@org/docs"}},
+		{"src/a/b/legacy/old.py", []string{"@org/legacy"}},
+		{"src/legacy/old.py", []string{"@org/legacy"}},
+		{"src/legacy/nested/old.py", []string{"@org/platform"}},
+		{"payments/vendor/lib.py", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got := owners.Owners(tt.path)
+			if len(tt.want) == 0 {
+				assert.Empty(t, got)
+			} else {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseCodeowners_AnchoredPatterns(t *testing.T) {
+	owners, err := ParseCodeowners(strings.NewReader("/app.py @root-only\nlib/util.py @lib\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"@root-only"}, owners.Owners("app.py"))
+	assert.Empty(t, owners.Owners("pkg/app.py"))
+	assert.Equal(t, []string{"@lib"}, owners.Owners("lib/util.py"))
+	assert.Empty(t, owners.Owners("pkg/lib/util.py"))
+}
+
+func TestLoadCodeowners_DiscoversGitHubLocation(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, ".git"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".github"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "pkg", "core"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".github", "CODEOWNERS"), []byte("/pkg/ @org/core\n"), 0644))
+
+	owners, err := LoadCodeowners("", []string{filepath.Join(root, "pkg", "core")})
+	require.NoError(t, err)
+
+	assert.Equal(t, root, owners.Root)
+	assert.Equal(t, []string{"@org/core"}, owners.Owners(filepath.Join(root, "pkg", "core", "mod.py")))
+	assert.Empty(t, owners.Owners(filepath.Join(t.TempDir(), "pkg", "elsewhere.py")))
+}
+
+func TestLoadCodeowners_NotFound(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, ".git"), 0755))
+
+	_, err := LoadCodeowners("", []string{root})
+	assert.Error(t, err)
+}
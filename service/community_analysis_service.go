@@ -120,13 +120,13 @@ func (s *CommunityAnalysisServiceImpl) buildDependencyGraph(ctx context.Context,
 		return nil, fmt.Errorf("failed to create module analyzer: %w", err)
 	}
 
-	graph, err := ma.AnalyzeFiles(req.Paths)
+	graph, err := ma.AnalyzeFilesWithContext(ctx, req.Paths)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, fmt.Errorf("module graph cancelled: %w", ctxErr)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to build module graph: %w", err)
 	}
-	if err := ctx.Err(); err != nil {
-		return nil, fmt.Errorf("module graph cancelled: %w", err)
-	}
 	return graph, nil
 }
 
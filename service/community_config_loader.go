@@ -92,8 +92,8 @@ func (cl *CommunityConfigurationLoaderImpl) configToRequest(pyscnCfg *config.Pys
 	req.ReportBridgeModules = pyscnCfg.CommunitiesReportBridgeModules
 	req.Resolution = pyscnCfg.CommunitiesResolution
 	req.Recursive = pyscnCfg.AnalysisRecursive
-	req.IncludePatterns = pyscnCfg.AnalysisIncludePatterns
-	req.ExcludePatterns = pyscnCfg.AnalysisExcludePatterns
+	req.IncludePatterns = pyscnCfg.AnalyzerIncludePatterns(domain.AnalysisScopeCommunities)
+	req.ExcludePatterns = pyscnCfg.AnalyzerExcludePatterns(domain.AnalysisScopeCommunities)
 
 	if req.Algorithm == "" {
 		req.Algorithm = domain.DefaultCommunityAlgorithm
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/i18n"
 )
 
 const (
@@ -29,7 +30,9 @@ var communityDOTColors = []string{
 }
 
 // CommunityFormatter formats community analysis results for output.
-type CommunityFormatter struct{}
+type CommunityFormatter struct {
+	translator *i18n.Translator
+}
 
 // NewCommunityFormatter creates a community analysis formatter.
 func NewCommunityFormatter() *CommunityFormatter {
@@ -89,14 +92,14 @@ func (f *CommunityFormatter) formatText(response *domain.CommunityAnalysisResult
 	return builder.String()
 }
 
-// WriteCommunityTextSummary writes a concise community section for unified analyze text output.
-func WriteCommunityTextSummary(writer io.Writer, response *domain.CommunityAnalysisResult) {
+// WriteCommunityTextSummary writes a concise community section for unified
+// analyze text output, translated by utils.
+func WriteCommunityTextSummary(writer io.Writer, response *domain.CommunityAnalysisResult, utils *FormatUtils) {
 	if response == nil {
 		return
 	}
 
 	var builder strings.Builder
-	utils := NewFormatUtils()
 	builder.WriteString(utils.FormatSectionHeader("COMMUNITY DETECTION"))
 	f := &CommunityFormatter{}
 	f.writeTextSummary(&builder, response, utils)
@@ -199,7 +202,7 @@ func (f *CommunityFormatter) writeTextSummary(builder *strings.Builder, response
 			builder.WriteString(utils.FormatLabelWithIndent(
 				SectionPadding,
 				"...",
-				fmt.Sprintf("and %d more communities", len(communities)-limit),
+				utils.T("and %d more communities", len(communities)-limit),
 			))
 		}
 		builder.WriteString("\n")
@@ -337,43 +340,45 @@ func (f *CommunityFormatter) formatHTML(response *domain.CommunityAnalysisResult
 }
 
 // WriteCommunityHTMLSummary writes a concise community section for unified analyze HTML output.
-func WriteCommunityHTMLSummary(builder *strings.Builder, response *domain.CommunityAnalysisResult) {
+func WriteCommunityHTMLSummary(builder *strings.Builder, response *domain.CommunityAnalysisResult, translator *i18n.Translator) {
 	if response == nil {
 		return
 	}
-	formatter := &CommunityFormatter{}
+	formatter := &CommunityFormatter{translator: translator}
 	formatter.writeHTMLSummary(builder, response)
 }
 
 func (f *CommunityFormatter) writeHTMLSummary(builder *strings.Builder, response *domain.CommunityAnalysisResult) {
-	builder.WriteString(GenerateSectionHeader("Module Communities"))
+	builder.WriteString(GenerateSectionHeader(f.translator.T("Module Communities")))
 	builder.WriteString(`<div class="metric-grid">`)
-	builder.WriteString(GenerateMetricCard(strconv.Itoa(response.TotalCommunities), "Communities"))
-	builder.WriteString(GenerateMetricCard(fmt.Sprintf("%.3f", response.Modularity), "Modularity (Q)"))
-	builder.WriteString(GenerateMetricCard(response.Algorithm, "Algorithm"))
-	builder.WriteString(GenerateMetricCard(strconv.Itoa(len(response.BridgeModules)), "Bridge Modules"))
+	builder.WriteString(GenerateMetricCard(strconv.Itoa(response.TotalCommunities), f.translator.T("Communities")))
+	builder.WriteString(GenerateMetricCard(fmt.Sprintf("%.3f", response.Modularity), f.translator.T("Modularity (Q)")))
+	builder.WriteString(GenerateMetricCard(response.Algorithm, f.translator.T("Algorithm")))
+	builder.WriteString(GenerateMetricCard(strconv.Itoa(len(response.BridgeModules)), f.translator.T("Bridge Modules")))
 	if response.PackageAlignmentScore != nil {
-		builder.WriteString(GenerateMetricCard(fmt.Sprintf("%.3f", *response.PackageAlignmentScore), "Package Alignment"))
+		builder.WriteString(GenerateMetricCard(fmt.Sprintf("%.3f", *response.PackageAlignmentScore), f.translator.T("Package Alignment")))
 	}
 	if response.LayerAlignmentScore != nil {
-		builder.WriteString(GenerateMetricCard(fmt.Sprintf("%.3f", *response.LayerAlignmentScore), "Layer Alignment"))
+		builder.WriteString(GenerateMetricCard(fmt.Sprintf("%.3f", *response.LayerAlignmentScore), f.translator.T("Layer Alignment")))
 	}
 	builder.WriteString(`</div>`)
 
 	writeCommunityGraphHTML(builder, response)
 
 	if len(response.CrossLayerCommunities) > 0 || len(response.LayerBridgeModules) > 0 {
-		builder.WriteString(GenerateSectionHeader("Layer Mismatch"))
+		builder.WriteString(GenerateSectionHeader(f.translator.T("Layer Mismatch")))
 		builder.WriteString(`<ul>`)
 		if len(response.CrossLayerCommunities) > 0 {
 			builder.WriteString(fmt.Sprintf(
-				`<li><strong>Cross-layer communities:</strong> %s</li>`,
+				`<li><strong>%s</strong> %s</li>`,
+				EscapeHTML(f.translator.T("Cross-layer communities:")),
 				JoinEscapedHTML(response.CrossLayerCommunities, ", "),
 			))
 		}
 		if len(response.LayerBridgeModules) > 0 {
 			builder.WriteString(fmt.Sprintf(
-				`<li><strong>Layer bridge modules:</strong> %s</li>`,
+				`<li><strong>%s</strong> %s</li>`,
+				EscapeHTML(f.translator.T("Layer bridge modules:")),
 				JoinEscapedHTML(response.LayerBridgeModules, ", "),
 			))
 		}
@@ -381,17 +386,19 @@ func (f *CommunityFormatter) writeHTMLSummary(builder *strings.Builder, response
 	}
 
 	if len(response.SplitPackages) > 0 || len(response.MixedCommunities) > 0 {
-		builder.WriteString(GenerateSectionHeader("Package Mismatch"))
+		builder.WriteString(GenerateSectionHeader(f.translator.T("Package Mismatch")))
 		builder.WriteString(`<ul>`)
 		if len(response.SplitPackages) > 0 {
 			builder.WriteString(fmt.Sprintf(
-				`<li><strong>Split packages:</strong> %s</li>`,
+				`<li><strong>%s</strong> %s</li>`,
+				EscapeHTML(f.translator.T("Split packages:")),
 				JoinEscapedHTML(response.SplitPackages, ", "),
 			))
 		}
 		if len(response.MixedCommunities) > 0 {
 			builder.WriteString(fmt.Sprintf(
-				`<li><strong>Mixed communities:</strong> %s</li>`,
+				`<li><strong>%s</strong> %s</li>`,
+				EscapeHTML(f.translator.T("Mixed communities:")),
 				JoinEscapedHTML(response.MixedCommunities, ", "),
 			))
 		}
@@ -400,20 +407,20 @@ func (f *CommunityFormatter) writeHTMLSummary(builder *strings.Builder, response
 
 	communities := communitiesBySize(response.Communities)
 	if len(communities) > 0 {
-		builder.WriteString(GenerateSectionHeader("Largest Communities"))
+		builder.WriteString(GenerateSectionHeader(f.translator.T("Largest Communities")))
 		builder.WriteString(`
             <table class="table">
                 <thead>
                     <tr>
-                        <th>Community</th>
-                        <th>Modules</th>
-                        <th>Internal</th>
-                        <th>External</th>
-                        <th>Cross-In</th>
-                        <th>Cross-Out</th>
-                        <th>Dominant Package</th>
-                        <th>Packages</th>
-                        <th>Package Alignment</th>
+                        <th>` + EscapeHTML(f.translator.T("Community")) + `</th>
+                        <th>` + EscapeHTML(f.translator.T("Modules")) + `</th>
+                        <th>` + EscapeHTML(f.translator.T("Internal")) + `</th>
+                        <th>` + EscapeHTML(f.translator.T("External")) + `</th>
+                        <th>` + EscapeHTML(f.translator.T("Cross-In")) + `</th>
+                        <th>` + EscapeHTML(f.translator.T("Cross-Out")) + `</th>
+                        <th>` + EscapeHTML(f.translator.T("Dominant Package")) + `</th>
+                        <th>` + EscapeHTML(f.translator.T("Packages")) + `</th>
+                        <th>` + EscapeHTML(f.translator.T("Package Alignment")) + `</th>
                     </tr>
                 </thead>
                 <tbody>`)
@@ -446,8 +453,8 @@ func (f *CommunityFormatter) writeHTMLSummary(builder *strings.Builder, response
 		if len(communities) > limit {
 			builder.WriteString(fmt.Sprintf(`
                     <tr>
-                        <td colspan="9"><em>... and %d more communities</em></td>
-                    </tr>`, len(communities)-limit))
+                        <td colspan="9"><em>%s</em></td>
+                    </tr>`, EscapeHTML(f.translator.T("... and %d more communities", len(communities)-limit))))
 		}
 		builder.WriteString(`
                 </tbody>
@@ -456,15 +463,15 @@ func (f *CommunityFormatter) writeHTMLSummary(builder *strings.Builder, response
 
 	bridges := bridgesByCoupling(response.BridgeModules)
 	if len(bridges) > 0 {
-		builder.WriteString(GenerateSectionHeader("Bridge Modules"))
+		builder.WriteString(GenerateSectionHeader(f.translator.T("Bridge Modules")))
 		builder.WriteString(`
             <table class="table">
                 <thead>
                     <tr>
-                        <th>Module</th>
-                        <th>Community</th>
-                        <th>Cross Edges</th>
-                        <th>Target Communities</th>
+                        <th>` + EscapeHTML(f.translator.T("Module")) + `</th>
+                        <th>` + EscapeHTML(f.translator.T("Community")) + `</th>
+                        <th>` + EscapeHTML(f.translator.T("Cross Edges")) + `</th>
+                        <th>` + EscapeHTML(f.translator.T("Target Communities")) + `</th>
                     </tr>
                 </thead>
                 <tbody>`)
@@ -487,8 +494,8 @@ func (f *CommunityFormatter) writeHTMLSummary(builder *strings.Builder, response
 		if len(bridges) > limit {
 			builder.WriteString(fmt.Sprintf(`
                     <tr>
-                        <td colspan="4"><em>... and %d more bridge modules</em></td>
-                    </tr>`, len(bridges)-limit))
+                        <td colspan="4"><em>%s</em></td>
+                    </tr>`, EscapeHTML(f.translator.T("... and %d more bridge modules", len(bridges)-limit))))
 		}
 		builder.WriteString(`
                 </tbody>
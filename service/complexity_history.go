@@ -0,0 +1,144 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+const complexityHistoryVersion = 1
+
+// LoadComplexityHistory reads the history at path. A missing file is an
+// empty history; a file from another version is discarded.
+func LoadComplexityHistory(path string) (*domain.ComplexityHistory, error) {
+	history := &domain.ComplexityHistory{Version: complexityHistoryVersion}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read complexity history: %w", err)
+	}
+	var stored domain.ComplexityHistory
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("failed to parse complexity history %s: %w", path, err)
+	}
+	if stored.Version != complexityHistoryVersion {
+		return history, nil
+	}
+	return &stored, nil
+}
+
+// SaveComplexityHistory writes the history to path, replacing it atomically
+func SaveComplexityHistory(path string, history *domain.ComplexityHistory) error {
+	data, err := json.Marshal(history)
+	if err != nil {
+		return fmt.Errorf("failed to encode complexity history: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create complexity history directory: %w", err)
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), ".pyscn-history-*")
+	if err != nil {
+		return fmt.Errorf("failed to write complexity history: %w", err)
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write complexity history: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write complexity history: %w", err)
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("failed to write complexity history: %w", err)
+	}
+	return nil
+}
+
+// RecordComplexityHistory annotates each function with its complexity in the
+// recorded runs, then appends this run to the history, keeping the last
+// maxRuns runs. Functions are matched by their path relative to root and
+// their name.
+func RecordComplexityHistory(history *domain.ComplexityHistory, functions []domain.FunctionComplexity, root string, recordedAt time.Time, maxRuns int) {
+	run := domain.ComplexityHistoryRun{
+		RecordedAt: recordedAt,
+		Functions:  make(map[string]int, len(functions)),
+	}
+	for i := range functions {
+		fn := &functions[i]
+		relPath := fn.FilePath
+		if abs, err := filepath.Abs(fn.FilePath); err == nil {
+			if rel, err := filepath.Rel(root, abs); err == nil {
+				relPath = rel
+			}
+		}
+		key := domain.FunctionFingerprint(filepath.ToSlash(relPath), fn.Name)
+		run.Functions[key] = fn.Metrics.Complexity
+
+		var trend []int
+		for _, previous := range history.Runs {
+			if complexity, ok := previous.Functions[key]; ok {
+				trend = append(trend, complexity)
+			}
+		}
+		if len(trend) > 0 {
+			fn.History = append(trend, fn.Metrics.Complexity)
+		}
+	}
+
+	history.Runs = append(history.Runs, run)
+	if maxRuns > 0 && len(history.Runs) > maxRuns {
+		history.Runs = history.Runs[len(history.Runs)-maxRuns:]
+	}
+}
+
+// Size of the trend sparklines in HTML reports, in pixels
+const (
+	sparklineWidth  = 64
+	sparklineHeight = 16
+)
+
+// complexitySparkline renders a complexity trend as an inline SVG line,
+// red when the function got more complex over the runs and green when it
+// got simpler. The tooltip lists the values.
+func complexitySparkline(values []int) template.HTML {
+	if len(values) < 2 {
+		return "-"
+	}
+	lowest, highest := values[0], values[0]
+	for _, v := range values {
+		lowest = min(lowest, v)
+		highest = max(highest, v)
+	}
+	spread := max(highest-lowest, 1)
+
+	points := make([]string, len(values))
+	labels := make([]string, len(values))
+	for i, v := range values {
+		x := float64(i) * (sparklineWidth - 2) / float64(len(values)-1)
+		y := float64(highest-v) * (sparklineHeight - 2) / float64(spread)
+		points[i] = strconv.FormatFloat(x+1, 'f', 1, 64) + "," + strconv.FormatFloat(y+1, 'f', 1, 64)
+		labels[i] = strconv.Itoa(v)
+	}
+
+	color := "#888"
+	switch last := values[len(values)-1]; {
+	case last > values[0]:
+		color = "#dc3545"
+	case last < values[0]:
+		color = "#28a745"
+	}
+	return template.HTML(fmt.Sprintf(
+		`<svg class="sparkline" width="%d" height="%d" viewBox="0 0 %d %d" role="img"><title>Complexity over the last %d runs: %s</title><polyline fill="none" stroke="%s" stroke-width="1.5" points="%s"/></svg>`,
+		sparklineWidth, sparklineHeight, sparklineWidth, sparklineHeight,
+		len(values), strings.Join(labels, " → "), color, strings.Join(points, " ")))
+}
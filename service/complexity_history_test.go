@@ -0,0 +1,112 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func historyTestFunctions(root string, complexities ...int) []domain.FunctionComplexity {
+	names := []string{"parse", "render", "save"}
+	functions := make([]domain.FunctionComplexity, len(complexities))
+	for i, complexity := range complexities {
+		functions[i] = domain.FunctionComplexity{
+			Name:     names[i],
+			FilePath: filepath.Join(root, "pkg", "app.py"),
+			Metrics:  domain.ComplexityMetrics{Complexity: complexity},
+		}
+	}
+	return functions
+}
+
+func TestLoadComplexityHistory_MissingFileIsEmpty(t *testing.T) {
+	history, err := LoadComplexityHistory(filepath.Join(t.TempDir(), domain.ComplexityHistoryFileName))
+	require.NoError(t, err)
+	assert.Equal(t, complexityHistoryVersion, history.Version)
+	assert.Empty(t, history.Runs)
+}
+
+func TestLoadComplexityHistory_DiscardsOtherVersions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), domain.ComplexityHistoryFileName)
+	require.NoError(t, os.WriteFile(path, []byte(`{"version":99,"runs":[{"functions":{"a.py::f":3}}]}`), 0o644))
+
+	history, err := LoadComplexityHistory(path)
+	require.NoError(t, err)
+	assert.Empty(t, history.Runs)
+}
+
+func TestLoadComplexityHistory_InvalidJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), domain.ComplexityHistoryFileName)
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+
+	_, err := LoadComplexityHistory(path)
+	assert.Error(t, err)
+}
+
+func TestComplexityHistory_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reports", domain.ComplexityHistoryFileName)
+	history := &domain.ComplexityHistory{Version: complexityHistoryVersion}
+	RecordComplexityHistory(history, historyTestFunctions("/repo", 4, 7), "/repo", time.Unix(1700000000, 0).UTC(), 10)
+
+	require.NoError(t, SaveComplexityHistory(path, history))
+	loaded, err := LoadComplexityHistory(path)
+	require.NoError(t, err)
+	require.Len(t, loaded.Runs, 1)
+	assert.Equal(t, map[string]int{"pkg/app.py::parse": 4, "pkg/app.py::render": 7}, loaded.Runs[0].Functions)
+	assert.True(t, loaded.Runs[0].RecordedAt.Equal(time.Unix(1700000000, 0)))
+}
+
+func TestRecordComplexityHistory_AnnotatesTrend(t *testing.T) {
+	history := &domain.ComplexityHistory{Version: complexityHistoryVersion}
+	start := time.Unix(1700000000, 0)
+
+	first := historyTestFunctions("/repo", 4, 7)
+	RecordComplexityHistory(history, first, "/repo", start, 10)
+	assert.Nil(t, first[0].History, "no trend without a previous run")
+
+	second := historyTestFunctions("/repo", 6, 7, 2)
+	RecordComplexityHistory(history, second, "/repo", start.Add(time.Hour), 10)
+	assert.Equal(t, []int{4, 6}, second[0].History)
+	assert.Equal(t, []int{7, 7}, second[1].History)
+	assert.Nil(t, second[2].History, "new functions have no trend yet")
+
+	third := historyTestFunctions("/repo", 9, 5, 3)
+	RecordComplexityHistory(history, third, "/repo", start.Add(2*time.Hour), 10)
+	assert.Equal(t, []int{4, 6, 9}, third[0].History)
+	assert.Equal(t, []int{2, 3}, third[2].History)
+}
+
+func TestRecordComplexityHistory_KeepsLastRuns(t *testing.T) {
+	history := &domain.ComplexityHistory{Version: complexityHistoryVersion}
+	start := time.Unix(1700000000, 0)
+	for i := range 5 {
+		RecordComplexityHistory(history, historyTestFunctions("/repo", i+1), "/repo", start.Add(time.Duration(i)*time.Hour), 3)
+	}
+
+	require.Len(t, history.Runs, 3)
+	assert.Equal(t, 3, history.Runs[0].Functions["pkg/app.py::parse"])
+
+	functions := historyTestFunctions("/repo", 10)
+	RecordComplexityHistory(history, functions, "/repo", start.Add(5*time.Hour), 3)
+	assert.Equal(t, []int{3, 4, 5, 10}, functions[0].History)
+}
+
+func TestComplexitySparkline(t *testing.T) {
+	assert.Equal(t, "-", string(complexitySparkline(nil)))
+	assert.Equal(t, "-", string(complexitySparkline([]int{5})))
+
+	rising := string(complexitySparkline([]int{3, 5, 8}))
+	assert.True(t, strings.HasPrefix(rising, `<svg class="sparkline"`))
+	assert.Contains(t, rising, "<title>Complexity over the last 3 runs: 3 → 5 → 8</title>")
+	assert.Contains(t, rising, `stroke="#dc3545"`)
+	assert.Contains(t, rising, `points="1.0,15.0 32.0,9.4 63.0,1.0"`)
+
+	assert.Contains(t, string(complexitySparkline([]int{8, 5})), `stroke="#28a745"`)
+	assert.Contains(t, string(complexitySparkline([]int{4, 4})), `stroke="#888"`)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/ludo-technologies/pyscn/domain"
@@ -34,6 +35,11 @@ func (s *ComplexityServiceImpl) Analyze(ctx context.Context, req domain.Complexi
 	var warnings []string
 	var errors []string
 	filesProcessed := 0
+	callGraph := analyzer.NewCallGraph()
+	boilerplate, err := analyzer.NewBoilerplateMatcher(req.BoilerplatePatterns)
+	if err != nil {
+		return nil, domain.NewConfigError("invalid complexity boilerplate patterns", err)
+	}
 
 	for _, filePath := range req.Paths {
 		// Check context cancellation
@@ -46,7 +52,7 @@ func (s *ComplexityServiceImpl) Analyze(ctx context.Context, req domain.Complexi
 		// Progress reporting removed - file parsing is fast
 
 		// Analyze single file
-		functions, rawMetrics, fileWarnings, fileErrors := s.analyzeFile(ctx, filePath, req)
+		functions, rawMetrics, fileWarnings, fileErrors := s.analyzeFile(ctx, filePath, req, callGraph, boilerplate)
 
 		if rawMetrics != nil {
 			allRawMetrics = append(allRawMetrics, *s.convertRawMetrics(rawMetrics))
@@ -67,6 +73,8 @@ func (s *ComplexityServiceImpl) Analyze(ctx context.Context, req domain.Complexi
 		return nil, domain.NewAnalysisError("no functions found to analyze", nil)
 	}
 
+	warnings = append(warnings, s.annotateRecursion(allFunctions, callGraph)...)
+
 	// Filter and sort results
 	functionsParsed := len(allFunctions)
 	filteredFunctions := s.filterFunctions(allFunctions, req)
@@ -101,15 +109,21 @@ func (s *ComplexityServiceImpl) AnalyzeSnapshot(ctx context.Context, snapshot *P
 	var warnings []string
 	var errors []string
 	filesProcessed := 0
+	callGraph := analyzer.NewCallGraph()
+	boilerplate, err := analyzer.NewBoilerplateMatcher(req.BoilerplatePatterns)
+	if err != nil {
+		return nil, domain.NewConfigError("invalid complexity boilerplate patterns", err)
+	}
 
-	for _, file := range snapshot.Files {
+	for i, file := range snapshot.Files {
 		select {
 		case <-ctx.Done():
 			return nil, fmt.Errorf("complexity analysis cancelled: %w", ctx.Err())
 		default:
 		}
 
-		functions, rawMetrics, fileWarnings, fileErrors := s.analyzeProjectFile(file, req)
+		functions, rawMetrics, fileWarnings, fileErrors := s.analyzeProjectFile(file, req, callGraph, boilerplate)
+		domain.ReportProgress(ctx, domain.ProgressStageComplexity, i+1, len(snapshot.Files))
 
 		if rawMetrics != nil {
 			allRawMetrics = append(allRawMetrics, *s.convertRawMetrics(rawMetrics))
@@ -130,6 +144,8 @@ func (s *ComplexityServiceImpl) AnalyzeSnapshot(ctx context.Context, snapshot *P
 		return nil, domain.NewAnalysisError("no functions found to analyze", nil)
 	}
 
+	warnings = append(warnings, s.annotateRecursion(allFunctions, callGraph)...)
+
 	functionsParsed := len(allFunctions)
 	filteredFunctions := s.filterFunctions(allFunctions, req)
 	sortedFunctions := s.sortFunctions(filteredFunctions, req.SortBy)
@@ -159,7 +175,7 @@ func (s *ComplexityServiceImpl) AnalyzeFile(ctx context.Context, filePath string
 }
 
 // analyzeFile performs complexity analysis on a single file
-func (s *ComplexityServiceImpl) analyzeFile(ctx context.Context, filePath string, req domain.ComplexityRequest) ([]domain.FunctionComplexity, *analyzer.RawMetricsResult, []string, []string) {
+func (s *ComplexityServiceImpl) analyzeFile(ctx context.Context, filePath string, req domain.ComplexityRequest, callGraph *analyzer.CallGraph, boilerplate *analyzer.BoilerplateMatcher) ([]domain.FunctionComplexity, *analyzer.RawMetricsResult, []string, []string) {
 	var functions []domain.FunctionComplexity
 	var warnings []string
 	var errors []string
@@ -173,7 +189,7 @@ func (s *ComplexityServiceImpl) analyzeFile(ctx context.Context, filePath string
 
 	rawMetrics := analyzer.CalculateRawMetrics(content, filePath)
 
-	result, err := s.parser.Parse(ctx, content)
+	result, err := s.parser.ParseFor(ctx, filePath, content)
 	if err != nil {
 		// Enhanced error context with file path
 		errors = append(errors, fmt.Sprintf("[%s] Parse error: %v", filePath, err))
@@ -191,14 +207,16 @@ func (s *ComplexityServiceImpl) analyzeFile(ctx context.Context, filePath string
 		return functions, rawMetrics, warnings, errors
 	}
 
+	callGraph.AddFile(filePath, result.AST, cfgs)
+
 	// Calculate complexity for each function
 	complexityConfig := s.buildComplexityConfig(req)
-	functions, warnings = s.calculateFunctionComplexities(filePath, cfgs, complexityConfig, req)
+	functions, warnings = s.calculateFunctionComplexities(filePath, cfgs, parseCodeDirectives(result.AST, content), boilerplate.Functions(result.AST), complexityConfig, req)
 
 	return functions, rawMetrics, warnings, errors
 }
 
-func (s *ComplexityServiceImpl) analyzeProjectFile(file *ProjectFile, req domain.ComplexityRequest) ([]domain.FunctionComplexity, *analyzer.RawMetricsResult, []string, []string) {
+func (s *ComplexityServiceImpl) analyzeProjectFile(file *ProjectFile, req domain.ComplexityRequest, callGraph *analyzer.CallGraph, boilerplate *analyzer.BoilerplateMatcher) ([]domain.FunctionComplexity, *analyzer.RawMetricsResult, []string, []string) {
 	var functions []domain.FunctionComplexity
 	var warnings []string
 	var errors []string
@@ -228,12 +246,19 @@ func (s *ComplexityServiceImpl) analyzeProjectFile(file *ProjectFile, req domain
 		return functions, rawMetrics, warnings, errors
 	}
 
+	callGraph.AddFile(file.Path, file.AST, cfgs)
+
 	complexityConfig := s.buildComplexityConfig(req)
-	functions, warnings = s.calculateFunctionComplexities(file.Path, cfgs, complexityConfig, req)
+	functions, warnings = s.calculateFunctionComplexities(file.Path, cfgs, file.directives, boilerplate.Functions(file.AST), complexityConfig, req)
 	return functions, rawMetrics, warnings, errors
 }
 
-func (s *ComplexityServiceImpl) calculateFunctionComplexities(filePath string, cfgs map[string]*analyzer.CFG, complexityConfig *config.ComplexityConfig, req domain.ComplexityRequest) ([]domain.FunctionComplexity, []string) {
+// calculateFunctionComplexities measures every function. A function within
+// its "# pyscn: max-complexity=N" budget is rated low risk and raises no
+// threshold warnings; over budget it is rated as usual and marked. A
+// function matching a boilerplate pattern is tagged with it, and rated like
+// a function within budget when the pattern excludes it from risk rating.
+func (s *ComplexityServiceImpl) calculateFunctionComplexities(filePath string, cfgs map[string]*analyzer.CFG, directives *codeDirectives, boilerplate map[string]domain.BoilerplatePattern, complexityConfig *config.ComplexityConfig, req domain.ComplexityRequest) ([]domain.FunctionComplexity, []string) {
 	var functions []domain.FunctionComplexity
 	var warnings []string
 
@@ -247,8 +272,14 @@ func (s *ComplexityServiceImpl) calculateFunctionComplexities(filePath string, c
 			continue
 		}
 
-		riskLevel := s.calculateRiskLevel(result.Complexity, result.CognitiveComplexity, result.NestingDepth, req)
-		warnings = append(warnings, s.metricThresholdWarnings(filePath, functionName, result, req)...)
+		budget := directives.complexityBudget(result.StartLine)
+		pattern, isBoilerplate := boilerplate[functionName]
+		excluded := isBoilerplate && pattern.ExcludeFromRisk
+		riskLevel := domain.RiskLevelLow
+		if !excluded && (budget == 0 || result.Complexity > budget) {
+			riskLevel = s.calculateRiskLevel(result.Complexity, result.CognitiveComplexity, result.NestingDepth, req)
+			warnings = append(warnings, s.metricThresholdWarnings(filePath, functionName, result, req)...)
+		}
 
 		function := domain.FunctionComplexity{
 			Name:        functionName,
@@ -266,8 +297,19 @@ func (s *ComplexityServiceImpl) calculateFunctionComplexities(filePath string, c
 				LoopStatements:      result.LoopStatements,
 				ExceptionHandlers:   result.ExceptionHandlers,
 				SwitchCases:         result.SwitchCases,
+				BooleanOperators:    result.BooleanOperators,
+				Comprehensions:      result.Comprehensions,
+				IsAsync:             result.IsAsync,
+				AwaitPoints:         result.AwaitPoints,
+				AsyncFanOut:         result.AsyncFanOut,
+				AsyncComplexity:     result.AsyncComplexity,
+				AwaitDensity:        result.AwaitDensity,
 			},
-			RiskLevel: riskLevel,
+			RiskLevel:        riskLevel,
+			ComplexityBudget: budget,
+			OverBudget:       budget > 0 && result.Complexity > budget,
+			Boilerplate:      pattern.Name,
+			ExcludedFromRisk: excluded,
 		}
 
 		functions = append(functions, function)
@@ -276,6 +318,34 @@ func (s *ComplexityServiceImpl) calculateFunctionComplexities(filePath string, c
 	return functions, warnings
 }
 
+// annotateRecursion resolves the project call graph and records recursion
+// metrics on the analyzed functions. It returns an informational warning for
+// each recursive function without an obvious base case.
+func (s *ComplexityServiceImpl) annotateRecursion(functions []domain.FunctionComplexity, callGraph *analyzer.CallGraph) []string {
+	callGraph.Resolve()
+	recursion := callGraph.Recursion()
+
+	var warnings []string
+	for i := range functions {
+		function := &functions[i]
+		info := recursion[analyzer.CallGraphID(function.FilePath, function.Name)]
+		if info == nil {
+			continue
+		}
+		function.Metrics.IsRecursive = true
+		function.Metrics.RecursionKind = info.Kind
+		function.Metrics.RecursionDepth = info.CycleLength
+		function.Metrics.RecursiveCalls = info.RecursiveCalls
+		function.Metrics.UnboundedRecursion = info.Unbounded
+		if info.Unbounded {
+			warnings = append(warnings, fmt.Sprintf("[%s:%s] Possible unbounded recursion: every path calls back into %s",
+				function.FilePath, function.Name, strings.Join(info.Cycle, ", ")))
+		}
+	}
+	sort.Strings(warnings)
+	return warnings
+}
+
 // filterFunctions filters functions based on complexity thresholds
 func (s *ComplexityServiceImpl) filterFunctions(functions []domain.FunctionComplexity, req domain.ComplexityRequest) []domain.FunctionComplexity {
 	var filtered []domain.FunctionComplexity
@@ -361,6 +431,8 @@ func (s *ComplexityServiceImpl) generateSummary(functions []domain.FunctionCompl
 	var maxComplexity int
 	minComplexity := functions[0].Metrics.Complexity
 	var lowCount, mediumCount, highCount int
+	var budgetedCount, overBudgetCount int
+	var boilerplateCount, excludedCount int
 	complexityDist := make(map[string]int)
 
 	for _, function := range functions {
@@ -386,6 +458,19 @@ func (s *ComplexityServiceImpl) generateSummary(functions []domain.FunctionCompl
 			highCount++
 		}
 
+		if function.ComplexityBudget > 0 {
+			budgetedCount++
+			if function.OverBudget {
+				overBudgetCount++
+			}
+		}
+		if function.Boilerplate != "" {
+			boilerplateCount++
+			if function.ExcludedFromRisk {
+				excludedCount++
+			}
+		}
+
 		// Build complexity distribution
 		distKey := s.getComplexityDistributionKey(complexity)
 		complexityDist[distKey]++
@@ -407,6 +492,10 @@ func (s *ComplexityServiceImpl) generateSummary(functions []domain.FunctionCompl
 		LowRiskFunctions:           lowCount,
 		MediumRiskFunctions:        mediumCount,
 		HighRiskFunctions:          highCount,
+		BudgetedFunctions:          budgetedCount,
+		OverBudgetFunctions:        overBudgetCount,
+		BoilerplateFunctions:       boilerplateCount,
+		ExcludedFromRiskFunctions:  excludedCount,
 		ComplexityDistribution:     complexityDist,
 	}
 }
@@ -466,11 +555,14 @@ func (s *ComplexityServiceImpl) buildComplexityConfig(req domain.ComplexityReque
 		Enabled:                      domain.BoolValue(req.Enabled, true),
 		ReportUnchanged:              domain.BoolValue(req.ReportUnchanged, true),
 		MaxComplexity:                req.MaxComplexity,
+		ComprehensionWeight:          req.ComprehensionWeight,
+		GeneratorWeight:              req.GeneratorWeight,
+		BooleanOperatorWeight:        req.BooleanOperatorWeight,
 	}
 }
 
 func (s *ComplexityServiceImpl) buildConfigForResponse(req domain.ComplexityRequest) interface{} {
-	return map[string]interface{}{
+	cfg := map[string]interface{}{
 		"output_format":                  string(req.OutputFormat),
 		"min_complexity":                 req.MinComplexity,
 		"max_complexity":                 req.MaxComplexity,
@@ -486,6 +578,17 @@ func (s *ComplexityServiceImpl) buildConfigForResponse(req domain.ComplexityRequ
 		"include_patterns":               req.IncludePatterns,
 		"exclude_patterns":               req.ExcludePatterns,
 	}
+	// Weights appear only when set, so reports of the default count are unchanged
+	for key, weight := range map[string]*float64{
+		"comprehension_weight":    req.ComprehensionWeight,
+		"generator_weight":        req.GeneratorWeight,
+		"boolean_operator_weight": req.BooleanOperatorWeight,
+	} {
+		if weight != nil {
+			cfg[key] = *weight
+		}
+	}
+	return cfg
 }
 
 func (s *ComplexityServiceImpl) convertRawMetrics(result *analyzer.RawMetricsResult) *domain.RawMetrics {
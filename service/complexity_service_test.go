@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -338,6 +339,67 @@ def handle(value):
 	})
 }
 
+func TestComplexityService_Recursion(t *testing.T) {
+	service := NewComplexityService()
+	tempDir := t.TempDir()
+
+	files := map[string]string{
+		"even.py": "from odd import is_odd\n\n\ndef is_even(n):\n    if n == 0:\n        return True\n    return is_odd(n - 1)\n",
+		"odd.py":  "from even import is_even\n\n\ndef is_odd(n):\n    return is_even(n - 1)\n\n\ndef fact(n):\n    if n <= 1:\n        return 1\n    return n * fact(n - 1)\n",
+	}
+	var paths []string
+	for name, content := range files {
+		path := tempDir + "/" + name
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		paths = append(paths, path)
+	}
+
+	response, err := service.Analyze(context.Background(), newDefaultComplexityRequest(paths...))
+	require.NoError(t, err)
+
+	fact := findFunctionComplexity(response.Functions, "fact")
+	require.NotNil(t, fact)
+	assert.True(t, fact.Metrics.IsRecursive)
+	assert.Equal(t, "direct", fact.Metrics.RecursionKind)
+	assert.Equal(t, 1, fact.Metrics.RecursionDepth)
+	assert.False(t, fact.Metrics.UnboundedRecursion)
+
+	isOdd := findFunctionComplexity(response.Functions, "is_odd")
+	require.NotNil(t, isOdd)
+	assert.Equal(t, "mutual", isOdd.Metrics.RecursionKind)
+	assert.Equal(t, 2, isOdd.Metrics.RecursionDepth)
+	assert.True(t, isOdd.Metrics.UnboundedRecursion)
+
+	isEven := findFunctionComplexity(response.Functions, "is_even")
+	require.NotNil(t, isEven)
+	assert.False(t, isEven.Metrics.UnboundedRecursion)
+
+	var recursionWarnings []string
+	for _, warning := range response.Warnings {
+		if strings.Contains(warning, "unbounded recursion") {
+			recursionWarnings = append(recursionWarnings, warning)
+		}
+	}
+	require.Len(t, recursionWarnings, 1)
+	assert.Contains(t, recursionWarnings[0], "is_odd")
+}
+
+func TestComplexityService_Cython(t *testing.T) {
+	service := NewComplexityService()
+	path := t.TempDir() + "/fast.pyx"
+	source := "cimport cython\n\n\ncdef int clamp(int value, int low, int high) nogil:\n    cdef int result = value\n    if value < low:\n        result = low\n    elif value > high:\n        result = high\n    return result\n"
+	require.NoError(t, os.WriteFile(path, []byte(source), 0644))
+
+	response, err := service.Analyze(context.Background(), newDefaultComplexityRequest(path))
+	require.NoError(t, err)
+
+	clamp := findFunctionComplexity(response.Functions, "clamp")
+	require.NotNil(t, clamp)
+	assert.Equal(t, 3, clamp.Metrics.Complexity)
+	assert.Equal(t, 4, clamp.StartLine)
+	assert.Equal(t, 10, clamp.EndLine)
+}
+
 func TestComplexityService_AnalyzeFile(t *testing.T) {
 	service := NewComplexityService()
 	ctx := context.Background()
@@ -726,3 +788,105 @@ func TestComplexityService_ResponseMetadata(t *testing.T) {
 	// Verify config is present
 	assert.NotNil(t, response.Config)
 }
+
+func TestComplexityService_ComplexityBudgets(t *testing.T) {
+	service := NewComplexityService()
+	path := t.TempDir() + "/legacy.py"
+	content := `# pyscn: max-complexity=15
+def legacy(x):
+    if x == 1: return 1
+    if x == 2: return 2
+    if x == 3: return 3
+    if x == 4: return 4
+    if x == 5: return 5
+    if x == 6: return 6
+    if x == 7: return 7
+    if x == 8: return 8
+    if x == 9: return 9
+    if x == 10: return 10
+    if x == 11: return 11
+    return 0
+
+
+def over(x):  # pyscn: max-complexity=2
+    if x == 1: return 1
+    if x == 2: return 2
+    return 0
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	response, err := service.Analyze(context.Background(), newDefaultComplexityRequest(path))
+	require.NoError(t, err)
+
+	legacy := findFunctionComplexity(response.Functions, "legacy")
+	require.NotNil(t, legacy)
+	assert.Equal(t, 12, legacy.Metrics.Complexity)
+	assert.Equal(t, 15, legacy.ComplexityBudget)
+	assert.False(t, legacy.OverBudget)
+	assert.Equal(t, domain.RiskLevelLow, legacy.RiskLevel)
+
+	over := findFunctionComplexity(response.Functions, "over")
+	require.NotNil(t, over)
+	assert.Equal(t, 2, over.ComplexityBudget)
+	assert.True(t, over.OverBudget)
+
+	assert.Equal(t, 2, response.Summary.BudgetedFunctions)
+	assert.Equal(t, 1, response.Summary.OverBudgetFunctions)
+}
+
+func TestComplexityService_BoilerplatePatterns(t *testing.T) {
+	service := NewComplexityService()
+	path := t.TempDir() + "/cli.py"
+	content := `import argparse
+
+def build_parser(mode):
+    parser = argparse.ArgumentParser()
+    if mode == 1: parser.add_argument("--a")
+    if mode == 2: parser.add_argument("--b")
+    if mode == 3: parser.add_argument("--c")
+    if mode == 4: parser.add_argument("--d")
+    if mode == 5: parser.add_argument("--e")
+    if mode == 6: parser.add_argument("--f")
+    if mode == 7: parser.add_argument("--g")
+    if mode == 8: parser.add_argument("--h")
+    if mode == 9: parser.add_argument("--i")
+    if mode == 10: parser.add_argument("--j")
+    if mode == 11: parser.add_argument("--k")
+    return parser
+
+
+def to_dict(x):
+    return {"x": x}
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	req := newDefaultComplexityRequest(path)
+	req.BoilerplatePatterns = []domain.BoilerplatePattern{
+		{Name: "argparse", Match: domain.PatternRuleMatch{Nodes: []string{"Call"}, Callee: "*.add_argument"}, ExcludeFromRisk: true},
+		{Name: "serializer", Match: domain.PatternRuleMatch{Nodes: []string{"FunctionDef"}, Name: "to_*"}},
+	}
+	response, err := service.Analyze(context.Background(), req)
+	require.NoError(t, err)
+
+	parser := findFunctionComplexity(response.Functions, "build_parser")
+	require.NotNil(t, parser)
+	assert.Equal(t, 12, parser.Metrics.Complexity)
+	assert.Equal(t, "argparse", parser.Boilerplate)
+	assert.True(t, parser.ExcludedFromRisk)
+	assert.Equal(t, domain.RiskLevelLow, parser.RiskLevel)
+	for _, warning := range response.Warnings {
+		assert.NotContains(t, warning, "build_parser")
+	}
+
+	serializer := findFunctionComplexity(response.Functions, "to_dict")
+	require.NotNil(t, serializer)
+	assert.Equal(t, "serializer", serializer.Boilerplate)
+	assert.False(t, serializer.ExcludedFromRisk)
+
+	assert.Equal(t, 2, response.Summary.BoilerplateFunctions)
+	assert.Equal(t, 1, response.Summary.ExcludedFromRiskFunctions)
+
+	req.BoilerplatePatterns = []domain.BoilerplatePattern{{Name: "broken"}}
+	_, err = service.Analyze(context.Background(), req)
+	assert.ErrorContains(t, err, `boilerplate pattern "broken"`)
+}
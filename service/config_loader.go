@@ -79,6 +79,10 @@ func (c *ConfigurationLoaderImpl) MergeConfig(base *domain.ComplexityRequest, ov
 
 	merged.Enabled = config.MergePtr(merged.Enabled, override.Enabled)
 	merged.ReportUnchanged = config.MergePtr(merged.ReportUnchanged, override.ReportUnchanged)
+	merged.BoilerplatePatterns = config.MergeSlice(merged.BoilerplatePatterns, override.BoilerplatePatterns)
+	merged.ComprehensionWeight = config.MergePtr(merged.ComprehensionWeight, override.ComprehensionWeight)
+	merged.GeneratorWeight = config.MergePtr(merged.GeneratorWeight, override.GeneratorWeight)
+	merged.BooleanOperatorWeight = config.MergePtr(merged.BooleanOperatorWeight, override.BooleanOperatorWeight)
 
 	// Config path is always from override if provided
 	merged.ConfigPath = config.Merge(merged.ConfigPath, override.ConfigPath)
@@ -133,6 +137,10 @@ func (c *ConfigurationLoaderImpl) convertToComplexityRequest(cfg *config.Config)
 		NestingDepthThreshold:        cfg.Complexity.NestingDepthThreshold,
 		Enabled:                      domain.BoolPtr(cfg.Complexity.Enabled),
 		ReportUnchanged:              domain.BoolPtr(cfg.Complexity.ReportUnchanged),
+		BoilerplatePatterns:          cfg.Complexity.Boilerplate,
+		ComprehensionWeight:          cfg.Complexity.ComprehensionWeight,
+		GeneratorWeight:              cfg.Complexity.GeneratorWeight,
+		BooleanOperatorWeight:        cfg.Complexity.BooleanOperatorWeight,
 		Recursive:                    domain.BoolPtr(cfg.Analysis.Recursive),
 		IncludePatterns:              cfg.Analysis.IncludePatterns,
 		ExcludePatterns:              cfg.Analysis.ExcludePatterns,
@@ -169,6 +177,12 @@ func (c *ConfigurationLoaderImpl) ValidateConfig(req *domain.ComplexityRequest)
 		return domain.NewConfigError("minimum complexity cannot be greater than maximum complexity", nil)
 	}
 
+	for _, weight := range []*float64{req.ComprehensionWeight, req.GeneratorWeight, req.BooleanOperatorWeight} {
+		if weight != nil && *weight != 0 && *weight != 0.5 && *weight != 1 {
+			return domain.NewConfigError("comprehension, generator and boolean operator weights must be 0, 0.5 or 1", nil)
+		}
+	}
+
 	return nil
 }
 
@@ -210,6 +224,10 @@ func (c *ConfigurationLoaderImpl) pyscnConfigToUnifiedConfig(pyscnCfg *config.Py
 	cfg.Complexity.CognitiveComplexityThreshold = pyscnCfg.CognitiveComplexityThreshold
 	cfg.Complexity.NestingDepthThreshold = pyscnCfg.NestingDepthThreshold
 	cfg.Complexity.MaxComplexity = pyscnCfg.ComplexityMaxComplexity
+	cfg.Complexity.Boilerplate = pyscnCfg.ComplexityBoilerplate
+	cfg.Complexity.ComprehensionWeight = pyscnCfg.ComplexityComprehensionWeight
+	cfg.Complexity.GeneratorWeight = pyscnCfg.ComplexityGeneratorWeight
+	cfg.Complexity.BooleanOperatorWeight = pyscnCfg.ComplexityBooleanOperatorWeight
 	cfg.Output.MinComplexity = pyscnCfg.EffectiveOutputMinComplexity()
 
 	// Map dead code settings from [dead_code] section
@@ -224,6 +242,7 @@ func (c *ConfigurationLoaderImpl) pyscnConfigToUnifiedConfig(pyscnCfg *config.Py
 	cfg.DeadCode.DetectAfterRaise = domain.BoolValue(pyscnCfg.DeadCodeDetectAfterRaise, true)
 	cfg.DeadCode.DetectUnreachableBranches = domain.BoolValue(pyscnCfg.DeadCodeDetectUnreachableBranches, true)
 	cfg.DeadCode.IgnorePatterns = pyscnCfg.DeadCodeIgnorePatterns
+	cfg.DeadCode.TerminatingCalls = pyscnCfg.DeadCodeTerminatingCalls
 
 	// Map general output settings from [output] section (override clone-specific if set)
 	if pyscnCfg.OutputFormat != "" {
@@ -240,11 +259,11 @@ func (c *ConfigurationLoaderImpl) pyscnConfigToUnifiedConfig(pyscnCfg *config.Py
 		cfg.Output.ShowDetails = *pyscnCfg.OutputShowDetails
 	}
 	// Map general analysis settings from [analysis] section (override clone-specific if set)
-	if len(pyscnCfg.AnalysisIncludePatterns) > 0 {
-		cfg.Analysis.IncludePatterns = pyscnCfg.AnalysisIncludePatterns
+	if patterns := pyscnCfg.AnalyzerIncludePatterns(domain.AnalysisScopeComplexity); len(patterns) > 0 {
+		cfg.Analysis.IncludePatterns = patterns
 	}
-	if len(pyscnCfg.AnalysisExcludePatterns) > 0 {
-		cfg.Analysis.ExcludePatterns = pyscnCfg.AnalysisExcludePatterns
+	if patterns := pyscnCfg.AnalyzerExcludePatterns(domain.AnalysisScopeComplexity); len(patterns) > 0 {
+		cfg.Analysis.ExcludePatterns = patterns
 	}
 	// Override if explicitly set
 	if pyscnCfg.AnalysisRecursive != nil {
@@ -254,6 +273,7 @@ func (c *ConfigurationLoaderImpl) pyscnConfigToUnifiedConfig(pyscnCfg *config.Py
 	if pyscnCfg.AnalysisFollowSymlinks != nil {
 		cfg.Analysis.FollowSymlinks = *pyscnCfg.AnalysisFollowSymlinks
 	}
+	cfg.Analysis.MaxDepth = pyscnCfg.AnalysisMaxDepth
 
 	// Map architecture settings from [architecture] section
 	cfg.Architecture.Enabled = domain.BoolValue(pyscnCfg.ArchitectureEnabled, false)
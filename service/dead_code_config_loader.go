@@ -2,6 +2,7 @@ package service
 
 import (
 	"fmt"
+	"maps"
 
 	"github.com/ludo-technologies/pyscn/domain"
 	"github.com/ludo-technologies/pyscn/internal/config"
@@ -92,6 +93,17 @@ func (cl *DeadCodeConfigurationLoaderImpl) MergeConfig(base *domain.DeadCodeRequ
 	merged.IncludePatterns = config.MergeSlice(merged.IncludePatterns, override.IncludePatterns)
 	merged.ExcludePatterns = config.MergeSlice(merged.ExcludePatterns, override.ExcludePatterns)
 	merged.IgnorePatterns = config.MergeSlice(merged.IgnorePatterns, override.IgnorePatterns)
+	merged.Reasons = config.MergeSlice(merged.Reasons, override.Reasons)
+	merged.TerminatingCalls = config.MergeSlice(merged.TerminatingCalls, override.TerminatingCalls)
+
+	// Severity mappings merge by reason, so an override only replaces the
+	// reasons it names
+	if len(override.ReasonSeverities) > 0 {
+		severities := make(map[string]domain.DeadCodeSeverity, len(merged.ReasonSeverities)+len(override.ReasonSeverities))
+		maps.Copy(severities, merged.ReasonSeverities)
+		maps.Copy(severities, override.ReasonSeverities)
+		merged.ReasonSeverities = severities
+	}
 
 	return &merged
 }
@@ -156,9 +168,24 @@ func (cl *DeadCodeConfigurationLoaderImpl) configToRequest(cfg *config.Config) *
 		DetectAfterContinue:       domain.BoolPtr(cfg.DeadCode.DetectAfterContinue),
 		DetectAfterRaise:          domain.BoolPtr(cfg.DeadCode.DetectAfterRaise),
 		DetectUnreachableBranches: domain.BoolPtr(cfg.DeadCode.DetectUnreachableBranches),
+		ReasonSeverities:          reasonSeveritiesFromConfig(cfg.DeadCode.Severities),
+		TerminatingCalls:          cfg.DeadCode.TerminatingCalls,
 	}
 }
 
+// reasonSeveritiesFromConfig converts the [dead_code] severities table, or
+// returns nil when it is empty
+func reasonSeveritiesFromConfig(severities map[string]string) map[string]domain.DeadCodeSeverity {
+	if len(severities) == 0 {
+		return nil
+	}
+	converted := make(map[string]domain.DeadCodeSeverity, len(severities))
+	for reason, severity := range severities {
+		converted[reason] = domain.DeadCodeSeverity(severity)
+	}
+	return converted
+}
+
 // FindDefaultConfigFile looks for TOML config files from the current directory upward.
 func (cl *DeadCodeConfigurationLoaderImpl) FindDefaultConfigFile() string {
 	tomlLoader := config.NewTomlConfigLoader()
@@ -234,6 +261,13 @@ func (cl *DeadCodeConfigurationLoaderImpl) requestToConfig(req *domain.DeadCodeR
 	cfg.DeadCode.DetectAfterRaise = domain.BoolValue(req.DetectAfterRaise, true)
 	cfg.DeadCode.DetectUnreachableBranches = domain.BoolValue(req.DetectUnreachableBranches, true)
 	cfg.DeadCode.IgnorePatterns = req.IgnorePatterns
+	cfg.DeadCode.TerminatingCalls = req.TerminatingCalls
+	if len(req.ReasonSeverities) > 0 {
+		cfg.DeadCode.Severities = make(map[string]string, len(req.ReasonSeverities))
+		for reason, severity := range req.ReasonSeverities {
+			cfg.DeadCode.Severities[reason] = string(severity)
+		}
+	}
 
 	// Set analysis config
 	cfg.Analysis.Recursive = domain.BoolValue(req.Recursive, true)
@@ -273,20 +307,23 @@ func (cl *DeadCodeConfigurationLoaderImpl) pyscnConfigToUnifiedConfig(pyscnCfg *
 	cfg.DeadCode.DetectAfterRaise = domain.BoolValue(pyscnCfg.DeadCodeDetectAfterRaise, true)
 	cfg.DeadCode.DetectUnreachableBranches = domain.BoolValue(pyscnCfg.DeadCodeDetectUnreachableBranches, true)
 	cfg.DeadCode.IgnorePatterns = pyscnCfg.DeadCodeIgnorePatterns
+	cfg.DeadCode.Severities = pyscnCfg.DeadCodeSeverities
+	cfg.DeadCode.TerminatingCalls = pyscnCfg.DeadCodeTerminatingCalls
 
 	// Step 3: Apply general [analysis] section overrides (highest priority for analysis settings)
 	// Only override if explicitly set (non-empty/non-zero values)
-	if len(pyscnCfg.AnalysisIncludePatterns) > 0 {
-		cfg.Analysis.IncludePatterns = pyscnCfg.AnalysisIncludePatterns
+	if patterns := pyscnCfg.AnalyzerIncludePatterns(domain.AnalysisScopeDeadCode); len(patterns) > 0 {
+		cfg.Analysis.IncludePatterns = patterns
 	}
-	if len(pyscnCfg.AnalysisExcludePatterns) > 0 {
-		cfg.Analysis.ExcludePatterns = pyscnCfg.AnalysisExcludePatterns
+	if patterns := pyscnCfg.AnalyzerExcludePatterns(domain.AnalysisScopeDeadCode); len(patterns) > 0 {
+		cfg.Analysis.ExcludePatterns = patterns
 	}
 	// Only override if explicitly set (non-nil)
 	if pyscnCfg.AnalysisRecursive != nil {
 		cfg.Analysis.Recursive = *pyscnCfg.AnalysisRecursive
 	}
 	cfg.Analysis.FollowSymlinks = domain.BoolValue(pyscnCfg.AnalysisFollowSymlinks, false)
+	cfg.Analysis.MaxDepth = pyscnCfg.AnalysisMaxDepth
 
 	// Step 4: Apply general [output] section overrides (highest priority for output settings)
 	// Only override if explicitly set (non-empty values)
@@ -1,10 +1,13 @@
 package service
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/ludo-technologies/pyscn/domain"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDeadCodeConfigurationLoader_MergeConfig_NilGuards(t *testing.T) {
@@ -101,3 +104,39 @@ func TestDeadCodeConfigurationLoader_MergeConfig_NoOpenIsCallerOnly(t *testing.T
 	merged = loader.MergeConfig(&domain.DeadCodeRequest{}, &domain.DeadCodeRequest{NoOpen: true})
 	assert.True(t, merged.NoOpen, "explicit caller NoOpen must be preserved")
 }
+
+func TestDeadCodeConfigurationLoader_MergeConfig_ReasonSeveritiesMergeByReason(t *testing.T) {
+	loader := NewDeadCodeConfigurationLoader()
+
+	base := &domain.DeadCodeRequest{ReasonSeverities: map[string]domain.DeadCodeSeverity{
+		"unreachable_after_return": domain.DeadCodeSeverityInfo,
+		"unreachable_branch":       domain.DeadCodeSeverityInfo,
+	}}
+	override := &domain.DeadCodeRequest{ReasonSeverities: map[string]domain.DeadCodeSeverity{
+		"unreachable_branch": domain.DeadCodeSeverityCritical,
+	}}
+
+	merged := loader.MergeConfig(base, override)
+	assert.Equal(t, map[string]domain.DeadCodeSeverity{
+		"unreachable_after_return": domain.DeadCodeSeverityInfo,
+		"unreachable_branch":       domain.DeadCodeSeverityCritical,
+	}, merged.ReasonSeverities)
+	assert.Len(t, base.ReasonSeverities, 2)
+	assert.Equal(t, domain.DeadCodeSeverityInfo, base.ReasonSeverities["unreachable_branch"], "base must not change")
+}
+
+func TestDeadCodeConfigurationLoader_LoadConfig_Severities(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".pyscn.toml")
+	content := `[dead_code.severities]
+unreachable_after_return = "info"
+unreachable_after_raise = "warning"
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	req, err := NewDeadCodeConfigurationLoader().LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]domain.DeadCodeSeverity{
+		"unreachable_after_return": domain.DeadCodeSeverityInfo,
+		"unreachable_after_raise":  domain.DeadCodeSeverityWarning,
+	}, req.ReasonSeverities)
+}
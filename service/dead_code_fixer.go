@@ -0,0 +1,436 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/internal/analyzer"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// Kinds of removals made by the dead code fixer
+const (
+	DeadCodeRemovalUnreachable  = "unreachable"
+	DeadCodeRemovalUnusedImport = "unused_import"
+)
+
+// diffContextLines is the number of unchanged lines around each hunk
+const diffContextLines = 3
+
+// suppressionPattern matches comments that exclude a line from automatic
+// fixes
+var suppressionPattern = regexp.MustCompile(`(?i)#\s*(noqa|pyscn:\s*ignore)\b`)
+
+// DeadCodeRemoval is one edit made by the dead code fixer
+type DeadCodeRemoval struct {
+	Kind        string `json:"kind"`
+	StartLine   int    `json:"start_line"`
+	EndLine     int    `json:"end_line"`
+	Description string `json:"description"`
+}
+
+// DeadCodeFix holds the safe removals for one file
+type DeadCodeFix struct {
+	FilePath string
+	Removals []DeadCodeRemoval
+
+	lines []string // Original lines including their line endings
+	edits []lineEdit
+}
+
+// lineEdit replaces the original lines start..end (1-based, inclusive)
+type lineEdit struct {
+	start, end  int
+	replacement []string
+}
+
+// DeadCodeFixer computes conservative fixes for dead code findings: it
+// removes statements that follow a return, raise, break or continue in the
+// same block, and module-level imports whose names are never referenced.
+// Lines carrying a suppression comment (# noqa, # pyscn: ignore) are never
+// touched, and a fix is discarded unless the result still parses.
+type DeadCodeFixer struct {
+	parser *parser.Parser
+}
+
+// NewDeadCodeFixer creates a new dead code fixer
+func NewDeadCodeFixer() *DeadCodeFixer {
+	return &DeadCodeFixer{parser: parser.New()}
+}
+
+// FixFile reads path and computes its fix
+func (f *DeadCodeFixer) FixFile(ctx context.Context, path string) (*DeadCodeFix, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return f.Fix(ctx, path, content)
+}
+
+// Fix computes the fix for content read from path. The returned fix has no
+// removals when nothing can be removed safely.
+func (f *DeadCodeFixer) Fix(ctx context.Context, path string, content []byte) (*DeadCodeFix, error) {
+	result, err := f.parser.Parse(ctx, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if f.parser.HasSyntaxErrors(result.RootNode) {
+		return nil, fmt.Errorf("%s has syntax errors", path)
+	}
+
+	fix := &DeadCodeFix{FilePath: path, lines: splitLinesKeepEnds(string(content))}
+	fix.addUnreachableRemovals(result.AST, unreachableLines(result.AST, path))
+	if filepath.Base(path) != "__init__.py" { // Imports in packages are usually re-exports
+		fix.addUnusedImportRemovals(result.AST)
+	}
+	if len(fix.edits) == 0 {
+		return fix, nil
+	}
+
+	sort.Slice(fix.edits, func(i, j int) bool { return fix.edits[i].start < fix.edits[j].start })
+	sort.Slice(fix.Removals, func(i, j int) bool { return fix.Removals[i].StartLine < fix.Removals[j].StartLine })
+
+	fixed, err := f.parser.Parse(ctx, fix.Fixed())
+	if err != nil || f.parser.HasSyntaxErrors(fixed.RootNode) {
+		return nil, fmt.Errorf("fixing %s would produce invalid code; left unchanged", path)
+	}
+	return fix, nil
+}
+
+// unreachableLines returns the lines the dead code detector reports as
+// unreachable after a return, raise, break or continue
+func unreachableLines(ast *parser.Node, path string) map[int]bool {
+	lines := make(map[int]bool)
+	cfgs, err := analyzer.NewCFGBuilder().BuildAll(ast)
+	if err != nil {
+		return lines
+	}
+	for _, result := range analyzer.DetectInFile(cfgs, path) {
+		for _, finding := range result.Findings {
+			switch finding.Reason {
+			case analyzer.ReasonUnreachableAfterReturn, analyzer.ReasonUnreachableAfterRaise,
+				analyzer.ReasonUnreachableAfterBreak, analyzer.ReasonUnreachableAfterContinue:
+				for line := finding.StartLine; line <= finding.EndLine; line++ {
+					lines[line] = true
+				}
+			}
+		}
+	}
+	return lines
+}
+
+// addUnreachableRemovals removes the statements that follow a terminating
+// statement in the same block, where the detector agrees they are dead
+func (fix *DeadCodeFix) addUnreachableRemovals(node *parser.Node, deadLines map[int]bool) {
+	if node == nil {
+		return
+	}
+
+	removed := make(map[*parser.Node]bool)
+	for _, block := range [][]*parser.Node{node.Body, node.Orelse, node.Finalbody} {
+		terminator := ""
+		for i, stmt := range block {
+			if terminator == "" {
+				switch stmt.Type {
+				case parser.NodeReturn, parser.NodeRaise, parser.NodeBreak, parser.NodeContinue:
+					terminator = strings.ToLower(string(stmt.Type))
+				}
+				continue
+			}
+
+			start, end := statementLines(stmt)
+			if start <= block[i-1].Location.EndLine || !anyLine(deadLines, start, end) || fix.suppressed(start, end) {
+				continue
+			}
+			removed[stmt] = true
+			fix.edits = append(fix.edits, lineEdit{start: start, end: end})
+			fix.Removals = append(fix.Removals, DeadCodeRemoval{
+				Kind:        DeadCodeRemovalUnreachable,
+				StartLine:   start,
+				EndLine:     end,
+				Description: fmt.Sprintf("unreachable %s after %s", describeStatement(stmt), terminator),
+			})
+		}
+	}
+
+	for _, child := range parser.OrderedChildren(node, nil) {
+		if !removed[child] {
+			fix.addUnreachableRemovals(child, deadLines)
+		}
+	}
+}
+
+// addUnusedImportRemovals removes module-level imports, one line each, whose
+// bound names appear nowhere else in the file. Names are matched as words
+// in the raw source, so uses in strings, annotations and __all__ keep an
+// import.
+func (fix *DeadCodeFix) addUnusedImportRemovals(module *parser.Node) {
+	for _, stmt := range module.Body {
+		if stmt.Type != parser.NodeImport && stmt.Type != parser.NodeImportFrom {
+			continue
+		}
+		line := stmt.Location.StartLine
+		if stmt.Location.EndLine != line || line < 1 || line > len(fix.lines) || fix.edited(line) {
+			continue
+		}
+		text := fix.lines[line-1]
+		if strings.ContainsAny(text, "#;\\") { // Comments, several statements or a continuation
+			continue
+		}
+		imp := parseImportLine(strings.TrimRight(text, "\r\n"))
+		if imp == nil {
+			continue
+		}
+
+		rest := strings.Join(fix.lines[:line-1], "") + strings.Join(fix.lines[line:], "")
+		var kept, unused []string
+		for _, item := range imp.items {
+			if nameReferenced(rest, item.bound) {
+				kept = append(kept, item.text)
+			} else {
+				unused = append(unused, item.bound)
+			}
+		}
+		if len(unused) == 0 {
+			continue
+		}
+
+		edit := lineEdit{start: line, end: line}
+		if len(kept) > 0 {
+			edit.replacement = []string{imp.prefix + strings.Join(kept, ", ") + lineEnding(text)}
+		}
+		fix.edits = append(fix.edits, edit)
+
+		description := fmt.Sprintf("unused import '%s'", unused[0])
+		if len(unused) > 1 {
+			description = fmt.Sprintf("unused imports '%s'", strings.Join(unused, "', '"))
+		}
+		fix.Removals = append(fix.Removals, DeadCodeRemoval{
+			Kind:        DeadCodeRemovalUnusedImport,
+			StartLine:   line,
+			EndLine:     line,
+			Description: description,
+		})
+	}
+}
+
+// importLine is a single-line import statement split into its parts
+type importLine struct {
+	prefix string // "import " or "from x import ", with indentation
+	items  []importItem
+}
+
+type importItem struct {
+	text  string // As written, e.g. "a.b as c"
+	bound string // Name bound in the module, e.g. "c"
+}
+
+var (
+	importPattern     = regexp.MustCompile(`^(\s*import\s+)(.+?)\s*$`)
+	importFromPattern = regexp.MustCompile(`^(\s*from\s+(\S+)\s+import\s+)\(?\s*(.+?)\s*,?\s*\)?\s*$`)
+	importAsPattern   = regexp.MustCompile(`^([\w.]+)(?:\s+as\s+(\w+))?$`)
+)
+
+// parseImportLine parses a plain import or from-import. It returns nil for
+// star imports, __future__ imports and anything it does not understand.
+func parseImportLine(text string) *importLine {
+	var prefix, names string
+	fromImport := false
+	if m := importFromPattern.FindStringSubmatch(text); m != nil {
+		if m[2] == "__future__" {
+			return nil
+		}
+		prefix, names, fromImport = m[1], m[3], true
+	} else if m := importPattern.FindStringSubmatch(text); m != nil {
+		prefix, names = m[1], m[2]
+	} else {
+		return nil
+	}
+
+	imp := &importLine{prefix: prefix}
+	for _, part := range strings.Split(names, ",") {
+		part = strings.TrimSpace(part)
+		m := importAsPattern.FindStringSubmatch(part)
+		if m == nil {
+			return nil
+		}
+		bound := m[2]
+		if bound == "" {
+			bound = m[1]
+			if fromImport && strings.Contains(bound, ".") {
+				return nil
+			}
+			// "import a.b" binds "a"
+			bound, _, _ = strings.Cut(bound, ".")
+		}
+		imp.items = append(imp.items, importItem{text: part, bound: bound})
+	}
+	return imp
+}
+
+// nameReferenced reports whether name occurs as a whole word in source
+func nameReferenced(source, name string) bool {
+	return regexp.MustCompile(`(?m)(^|[^\w.])` + regexp.QuoteMeta(name) + `\b`).MatchString(source)
+}
+
+// statementLines returns the lines of stmt including its decorators
+func statementLines(stmt *parser.Node) (int, int) {
+	start := stmt.Location.StartLine
+	for _, decorator := range stmt.Decorator {
+		if decorator != nil && decorator.Location.StartLine > 0 && decorator.Location.StartLine < start {
+			start = decorator.Location.StartLine
+		}
+	}
+	return start, stmt.Location.EndLine
+}
+
+// describeStatement names a statement kind for removal descriptions
+func describeStatement(stmt *parser.Node) string {
+	switch stmt.Type {
+	case parser.NodeFunctionDef, parser.NodeAsyncFunctionDef:
+		return "function definition"
+	case parser.NodeClassDef:
+		return "class definition"
+	default:
+		return "statement"
+	}
+}
+
+// anyLine reports whether any line in start..end is set in lines
+func anyLine(lines map[int]bool, start, end int) bool {
+	for line := start; line <= end; line++ {
+		if lines[line] {
+			return true
+		}
+	}
+	return false
+}
+
+// edited reports whether line is already part of an edit
+func (fix *DeadCodeFix) edited(line int) bool {
+	for _, edit := range fix.edits {
+		if line >= edit.start && line <= edit.end {
+			return true
+		}
+	}
+	return false
+}
+
+// suppressed reports whether a line in start..end carries a suppression
+// comment
+func (fix *DeadCodeFix) suppressed(start, end int) bool {
+	for line := start; line <= end && line <= len(fix.lines); line++ {
+		if suppressionPattern.MatchString(fix.lines[line-1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Fixed returns the file content with all removals applied
+func (fix *DeadCodeFix) Fixed() []byte {
+	var b strings.Builder
+	next := 1
+	for _, edit := range fix.edits {
+		for ; next < edit.start; next++ {
+			b.WriteString(fix.lines[next-1])
+		}
+		for _, line := range edit.replacement {
+			b.WriteString(line)
+		}
+		next = edit.end + 1
+	}
+	for ; next <= len(fix.lines); next++ {
+		b.WriteString(fix.lines[next-1])
+	}
+	return []byte(b.String())
+}
+
+// Diff returns the fix as a unified diff against a/name and b/name, or an
+// empty string when there is nothing to remove
+func (fix *DeadCodeFix) Diff(name string) string {
+	if len(fix.edits) == 0 {
+		return ""
+	}
+	name = filepath.ToSlash(name)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- a/%s\n+++ b/%s\n", name, name)
+
+	delta := 0 // Lines added minus lines removed by earlier hunks
+	for i := 0; i < len(fix.edits); {
+		// Group edits whose context would overlap into one hunk
+		j := i + 1
+		for j < len(fix.edits) && fix.edits[j].start-fix.edits[j-1].end <= 2*diffContextLines+1 {
+			j++
+		}
+		hunk := fix.edits[i:j]
+
+		oldStart := max(1, hunk[0].start-diffContextLines)
+		oldEnd := min(len(fix.lines), hunk[len(hunk)-1].end+diffContextLines)
+		oldCount := oldEnd - oldStart + 1
+		newCount := oldCount
+		for _, edit := range hunk {
+			newCount += len(edit.replacement) - (edit.end - edit.start + 1)
+		}
+		newStart := oldStart + delta
+		if newCount == 0 {
+			newStart--
+		}
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+
+		line := oldStart
+		for _, edit := range hunk {
+			for ; line < edit.start; line++ {
+				writeDiffLine(&b, ' ', fix.lines[line-1])
+			}
+			for ; line <= edit.end; line++ {
+				writeDiffLine(&b, '-', fix.lines[line-1])
+			}
+			for _, replacement := range edit.replacement {
+				writeDiffLine(&b, '+', replacement)
+			}
+		}
+		for ; line <= oldEnd; line++ {
+			writeDiffLine(&b, ' ', fix.lines[line-1])
+		}
+
+		delta += newCount - oldCount
+		i = j
+	}
+	return b.String()
+}
+
+func writeDiffLine(b *strings.Builder, prefix byte, line string) {
+	b.WriteByte(prefix)
+	b.WriteString(line)
+	if !strings.HasSuffix(line, "\n") {
+		b.WriteString("\n\\ No newline at end of file\n")
+	}
+}
+
+// splitLinesKeepEnds splits s into lines that keep their line endings
+func splitLinesKeepEnds(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// lineEnding returns the line ending of line
+func lineEnding(line string) string {
+	switch {
+	case strings.HasSuffix(line, "\r\n"):
+		return "\r\n"
+	case strings.HasSuffix(line, "\n"):
+		return "\n"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fixSource(t *testing.T, path, source string) *DeadCodeFix {
+	t.Helper()
+	fix, err := NewDeadCodeFixer().Fix(context.Background(), path, []byte(source))
+	require.NoError(t, err)
+	return fix
+}
+
+func TestDeadCodeFixer_RemovesStatementsAfterTerminators(t *testing.T) {
+	source := `def f(items):
+    for item in items:
+        if item:
+            continue
+            print(item)
+    raise ValueError("no")
+    cleanup(
+        items,
+    )
+
+
+def g():
+    return 1
+    x = 2  # pyscn: ignore
+`
+	fix := fixSource(t, "module.py", source)
+
+	require.Len(t, fix.Removals, 2)
+	assert.Equal(t, DeadCodeRemoval{Kind: DeadCodeRemovalUnreachable, StartLine: 5, EndLine: 5, Description: "unreachable statement after continue"}, fix.Removals[0])
+	assert.Equal(t, 7, fix.Removals[1].StartLine)
+	assert.Equal(t, 9, fix.Removals[1].EndLine)
+
+	assert.Equal(t, `def f(items):
+    for item in items:
+        if item:
+            continue
+    raise ValueError("no")
+
+
+def g():
+    return 1
+    x = 2  # pyscn: ignore
+`, string(fix.Fixed()))
+}
+
+func TestDeadCodeFixer_RemovesUnusedImports(t *testing.T) {
+	source := `from __future__ import annotations
+import os
+import collections as col, re
+from typing import Any, Dict as D
+import json  # noqa: F401
+import logging
+
+__all__ = ["Any"]
+log = logging.getLogger()
+pattern = re.compile(".")
+`
+	fix := fixSource(t, "module.py", source)
+
+	descriptions := make([]string, 0, len(fix.Removals))
+	for _, removal := range fix.Removals {
+		descriptions = append(descriptions, removal.Description)
+	}
+	assert.Equal(t, []string{"unused import 'os'", "unused import 'col'", "unused import 'D'"}, descriptions)
+
+	assert.Equal(t, `from __future__ import annotations
+import re
+from typing import Any
+import json  # noqa: F401
+import logging
+
+__all__ = ["Any"]
+log = logging.getLogger()
+pattern = re.compile(".")
+`, string(fix.Fixed()))
+}
+
+func TestDeadCodeFixer_SkipsPackageInit(t *testing.T) {
+	fix := fixSource(t, "pkg/__init__.py", "from .core import run\n")
+	assert.Empty(t, fix.Removals)
+	assert.Empty(t, fix.Diff("pkg/__init__.py"))
+}
+
+func TestDeadCodeFixer_RejectsSyntaxErrors(t *testing.T) {
+	_, err := NewDeadCodeFixer().Fix(context.Background(), "broken.py", []byte("def f(:\n"))
+	assert.Error(t, err)
+}
+
+func TestDeadCodeFix_Diff(t *testing.T) {
+	source := "import os\n\n\ndef f():\n    return 1\n    print(2)"
+	fix := fixSource(t, "pkg/mod.py", source)
+
+	assert.Equal(t, `--- a/pkg/mod.py
++++ b/pkg/mod.py
+@@ -1,6 +1,4 @@
+-import os
+ 
+ 
+ def f():
+     return 1
+-    print(2)
+\ No newline at end of file
+`, fix.Diff("pkg/mod.py"))
+}
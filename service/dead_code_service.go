@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/ludo-technologies/pyscn/domain"
@@ -25,56 +27,14 @@ func NewDeadCodeService() *DeadCodeServiceImpl {
 	}
 }
 
-// Analyze performs dead code analysis on multiple files
+// Analyze performs dead code analysis on multiple files. All files are
+// parsed before any is analyzed, so calls to the project's NoReturn
+// functions end blocks in every file.
 func (s *DeadCodeServiceImpl) Analyze(ctx context.Context, req domain.DeadCodeRequest) (*domain.DeadCodeResponse, error) {
-	var allFiles []domain.FileDeadCode
-	var warnings []string
-	var errors []string
-	filesProcessed := 0
-
-	for _, filePath := range req.Paths {
-		// Check context cancellation
-		select {
-		case <-ctx.Done():
-			return nil, fmt.Errorf("dead code analysis cancelled: %w", ctx.Err())
-		default:
-		}
-
-		// Progress reporting removed - file parsing is fast
-
-		// Analyze single file
-		fileResult, fileWarnings, fileErrors := s.analyzeFile(ctx, filePath, req)
-
-		if len(fileErrors) > 0 {
-			errors = append(errors, fileErrors...)
-			continue // Skip this file but continue with others
-		}
-
-		// Only include files that have dead code findings
-		if fileResult != nil && (len(fileResult.Functions) > 0 || fileResult.TotalFindings > 0) {
-			allFiles = append(allFiles, *fileResult)
-		}
-
-		warnings = append(warnings, fileWarnings...)
-		filesProcessed++
-	}
-
-	// Filter and sort results
-	filteredFiles := s.filterFiles(allFiles, req)
-	sortedFiles := s.sortFiles(filteredFiles, req.SortBy)
-
-	// Generate summary
-	summary := s.generateSummary(sortedFiles, filesProcessed, req)
-
-	return &domain.DeadCodeResponse{
-		Files:       sortedFiles,
-		Summary:     summary,
-		Warnings:    warnings,
-		Errors:      errors,
-		GeneratedAt: time.Now().Format(time.RFC3339),
-		Version:     version.Version,
-		Config:      s.buildConfigForResponse(req),
-	}, nil
+	snapshot := BuildProjectSnapshotWithOptions(ctx, req.Paths, ProjectSnapshotOptions{
+		TerminatingCalls: req.TerminatingCalls,
+	})
+	return s.AnalyzeSnapshot(ctx, snapshot, req)
 }
 
 // AnalyzeSnapshot performs dead code analysis using already parsed project files.
@@ -88,7 +48,7 @@ func (s *DeadCodeServiceImpl) AnalyzeSnapshot(ctx context.Context, snapshot *Pro
 	var errors []string
 	filesProcessed := 0
 
-	for _, file := range snapshot.Files {
+	for i, file := range snapshot.Files {
 		select {
 		case <-ctx.Done():
 			return nil, fmt.Errorf("dead code analysis cancelled: %w", ctx.Err())
@@ -96,6 +56,7 @@ func (s *DeadCodeServiceImpl) AnalyzeSnapshot(ctx context.Context, snapshot *Pro
 		}
 
 		fileResult, fileWarnings, fileErrors := s.analyzeProjectFile(file, req)
+		domain.ReportProgress(ctx, domain.ProgressStageDeadCode, i+1, len(snapshot.Files))
 
 		if len(fileErrors) > 0 {
 			errors = append(errors, fileErrors...)
@@ -110,7 +71,14 @@ func (s *DeadCodeServiceImpl) AnalyzeSnapshot(ctx context.Context, snapshot *Pro
 		filesProcessed++
 	}
 
+	if shouldIncludeDeadCodeFinding(analyzer.ReasonUnusedAttribute, req) {
+		allFiles = s.addUnusedAttributes(allFiles, snapshot.Files, req)
+	}
+
 	filteredFiles := s.filterFiles(allFiles, req)
+	if domain.BoolValue(req.ShowContext, false) {
+		warnings = append(warnings, s.addSourceContext(filteredFiles, req.ContextLines)...)
+	}
 	sortedFiles := s.sortFiles(filteredFiles, req.SortBy)
 	summary := s.generateSummary(sortedFiles, filesProcessed, req)
 
@@ -122,6 +90,7 @@ func (s *DeadCodeServiceImpl) AnalyzeSnapshot(ctx context.Context, snapshot *Pro
 		GeneratedAt: time.Now().Format(time.RFC3339),
 		Version:     version.Version,
 		Config:      s.buildConfigForResponse(req),
+		Reasons:     s.reasonTaxonomy(req),
 	}, nil
 }
 
@@ -164,7 +133,7 @@ func (s *DeadCodeServiceImpl) analyzeFile(ctx context.Context, filePath string,
 		return nil, warnings, errors
 	}
 
-	result, err := s.parser.Parse(ctx, content)
+	result, err := s.parser.ParseFor(ctx, filePath, content)
 	if err != nil {
 		errors = append(errors, fmt.Sprintf("[%s] Parse error: %v", filePath, err))
 		return nil, warnings, errors
@@ -190,8 +159,16 @@ func (s *DeadCodeServiceImpl) analyzeFile(ctx context.Context, filePath string,
 		}, warnings, errors
 	}
 
-	fileResult, fileWarnings := s.analyzeCFGs(filePath, cfgs, req)
+	directives := parseCodeDirectives(result.AST, content)
+	fileResult, fileWarnings := s.analyzeCFGs(filePath, cfgs, directives, req)
 	warnings = append(warnings, fileWarnings...)
+	if shouldIncludeDeadCodeFinding(analyzer.ReasonUnusedAttribute, req) {
+		projectFile := &ProjectFile{Path: filePath, AST: result.AST, directives: directives}
+		fileResult = &s.addUnusedAttributes([]domain.FileDeadCode{*fileResult}, []*ProjectFile{projectFile}, req)[0]
+	}
+	if domain.BoolValue(req.ShowContext, false) {
+		attachSourceContext(fileResult, content, req.ContextLines)
+	}
 
 	return fileResult, warnings, errors
 }
@@ -231,12 +208,14 @@ func (s *DeadCodeServiceImpl) analyzeProjectFile(file *ProjectFile, req domain.D
 		}, warnings, errors
 	}
 
-	fileResult, fileWarnings := s.analyzeCFGs(file.Path, cfgs, req)
+	fileResult, fileWarnings := s.analyzeCFGs(file.Path, cfgs, file.directives, req)
 	warnings = append(warnings, fileWarnings...)
 	return fileResult, warnings, errors
 }
 
-func (s *DeadCodeServiceImpl) analyzeCFGs(filePath string, cfgs map[string]*analyzer.CFG, req domain.DeadCodeRequest) (*domain.FileDeadCode, []string) {
+// analyzeCFGs detects dead code in every function, skipping findings inside
+// functions and classes tagged "# pyscn: allow-dead-code"
+func (s *DeadCodeServiceImpl) analyzeCFGs(filePath string, cfgs map[string]*analyzer.CFG, directives *codeDirectives, req domain.DeadCodeRequest) (*domain.FileDeadCode, []string) {
 	var warnings []string
 	var functions []domain.FunctionDeadCode
 	totalFindings := 0
@@ -260,7 +239,7 @@ func (s *DeadCodeServiceImpl) analyzeCFGs(filePath string, cfgs map[string]*anal
 
 		// Apply severity filtering
 		filteredFindings := s.filterFindingsBySeverity(functionResult.Findings, req.MinSeverity)
-		functionResult.Findings = filteredFindings
+		functionResult.Findings = withoutAllowedDeadCode(filteredFindings, directives)
 
 		// Only include functions that have findings after filtering
 		if len(functionResult.Findings) > 0 {
@@ -295,6 +274,133 @@ func (s *DeadCodeServiceImpl) analyzeCFGs(filePath string, cfgs map[string]*anal
 	return fileResult, warnings
 }
 
+// addUnusedAttributes indexes the attribute reads and writes of all the
+// project files and adds a finding for each attribute nothing reads to the
+// function assigning it
+func (s *DeadCodeServiceImpl) addUnusedAttributes(files []domain.FileDeadCode, projectFiles []*ProjectFile, req domain.DeadCodeRequest) []domain.FileDeadCode {
+	index := analyzer.NewAttributeUsageIndex()
+	filesByPath := make(map[string]*ProjectFile)
+	for _, file := range projectFiles {
+		if file == nil || file.ReadErr != nil || file.ParseErr != nil {
+			continue
+		}
+		index.AddFile(file.Path, file.AST)
+		filesByPath[file.Path] = file
+	}
+
+	for _, analyzerFinding := range index.UnusedAttributes() {
+		file := filesByPath[analyzerFinding.FilePath]
+		finding := domain.DeadCodeFinding{
+			Location: domain.DeadCodeLocation{
+				FilePath:  analyzerFinding.FilePath,
+				StartLine: analyzerFinding.StartLine,
+				EndLine:   analyzerFinding.EndLine,
+			},
+			FunctionName: analyzerFinding.FunctionName,
+			Code:         analyzerFinding.Code,
+			Reason:       string(analyzerFinding.Reason),
+			Severity:     s.findingSeverity(analyzerFinding, req),
+			Description:  analyzerFinding.Description,
+		}
+		kept := withoutAllowedDeadCode(s.filterFindingsBySeverity([]domain.DeadCodeFinding{finding}, req.MinSeverity), file.directives)
+		if len(kept) == 0 {
+			continue
+		}
+
+		fileIndex := slices.IndexFunc(files, func(f domain.FileDeadCode) bool { return f.FilePath == finding.Location.FilePath })
+		if fileIndex < 0 {
+			totalFunctions := 0
+			if cfgs, err := file.CFGs(); err == nil && len(cfgs) > 0 {
+				totalFunctions = len(cfgs) - 1 // Exclude __main__
+			}
+			files = append(files, domain.FileDeadCode{FilePath: finding.Location.FilePath, TotalFunctions: totalFunctions})
+			fileIndex = len(files) - 1
+		}
+		fileResult := &files[fileIndex]
+
+		functionIndex := slices.IndexFunc(fileResult.Functions, func(f domain.FunctionDeadCode) bool { return f.Name == finding.FunctionName })
+		if functionIndex < 0 {
+			fileResult.Functions = append(fileResult.Functions, domain.FunctionDeadCode{
+				Name:           finding.FunctionName,
+				FilePath:       finding.Location.FilePath,
+				ReachableRatio: 1.0,
+			})
+			functionIndex = len(fileResult.Functions) - 1
+			fileResult.AffectedFunctions++
+		}
+		functionResult := &fileResult.Functions[functionIndex]
+		functionResult.Findings = append(functionResult.Findings, finding)
+		functionResult.CalculateSeverityCounts()
+		fileResult.TotalFindings++
+	}
+	return files
+}
+
+// addSourceContext reads each file with findings once and attaches the
+// source around its findings; it returns a warning for each file that can
+// no longer be read
+func (s *DeadCodeServiceImpl) addSourceContext(files []domain.FileDeadCode, contextLines int) []string {
+	var warnings []string
+	for i := range files {
+		if files[i].TotalFindings == 0 {
+			continue
+		}
+		content, err := s.readFile(files[i].FilePath)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("[%s] No dead code context: %v", files[i].FilePath, err))
+			continue
+		}
+		attachSourceContext(&files[i], content, contextLines)
+	}
+	return warnings
+}
+
+// attachSourceContext sets the context of every finding of file from its
+// source
+func attachSourceContext(file *domain.FileDeadCode, content []byte, contextLines int) {
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	sourceLines := func(from, to int) []string {
+		from, to = max(from, 1), min(to, len(lines))
+		result := []string{}
+		for line := from; line <= to; line++ {
+			result = append(result, strings.TrimSuffix(lines[line-1], "\r"))
+		}
+		return result
+	}
+
+	for i := range file.Functions {
+		for j := range file.Functions[i].Findings {
+			finding := &file.Functions[i].Findings[j]
+			start := finding.Location.StartLine
+			if start < 1 || start > len(lines) {
+				continue
+			}
+			end := max(finding.Location.EndLine, start)
+			finding.Context = &domain.DeadCodeContext{
+				StartLine: max(start-contextLines, 1),
+				Before:    sourceLines(start-contextLines, start-1),
+				Lines:     sourceLines(start, end),
+				After:     sourceLines(end+1, end+contextLines),
+			}
+		}
+	}
+}
+
+// withoutAllowedDeadCode drops findings inside scopes tagged allow-dead-code
+func withoutAllowedDeadCode(findings []domain.DeadCodeFinding, directives *codeDirectives) []domain.DeadCodeFinding {
+	if directives == nil {
+		return findings
+	}
+	kept := findings[:0]
+	for _, finding := range findings {
+		if directives.allowsDeadCode(finding.Location.StartLine) {
+			continue
+		}
+		kept = append(kept, finding)
+	}
+	return kept
+}
+
 // convertToFunctionDeadCode converts analyzer results to domain model
 func (s *DeadCodeServiceImpl) convertToFunctionDeadCode(result *analyzer.DeadCodeResult, req domain.DeadCodeRequest) domain.FunctionDeadCode {
 	var findings []domain.DeadCodeFinding
@@ -312,9 +418,8 @@ func (s *DeadCodeServiceImpl) convertToFunctionDeadCode(result *analyzer.DeadCod
 			FunctionName: analyzerFinding.FunctionName,
 			Code:         analyzerFinding.Code,
 			Reason:       string(analyzerFinding.Reason),
-			Severity:     s.convertSeverity(analyzerFinding.Severity),
+			Severity:     s.findingSeverity(analyzerFinding, req),
 			Description:  analyzerFinding.Description,
-			Context:      analyzerFinding.Context,
 			BlockID:      analyzerFinding.BlockID,
 		}
 		findings = append(findings, finding)
@@ -336,6 +441,9 @@ func (s *DeadCodeServiceImpl) convertToFunctionDeadCode(result *analyzer.DeadCod
 }
 
 func shouldIncludeDeadCodeFinding(reason analyzer.DeadCodeReason, req domain.DeadCodeRequest) bool {
+	if len(req.Reasons) > 0 && !slices.Contains(req.Reasons, string(reason)) {
+		return false
+	}
 	switch reason {
 	case analyzer.ReasonUnreachableAfterReturn:
 		return domain.BoolValue(req.DetectAfterReturn, true)
@@ -352,6 +460,37 @@ func shouldIncludeDeadCodeFinding(reason analyzer.DeadCodeReason, req domain.Dea
 	}
 }
 
+// findingSeverity returns the severity a finding is reported with: the one
+// configured for its reason, or the analyzer's
+func (s *DeadCodeServiceImpl) findingSeverity(finding *analyzer.DeadCodeFinding, req domain.DeadCodeRequest) domain.DeadCodeSeverity {
+	if severity, ok := req.ReasonSeverities[string(finding.Reason)]; ok {
+		return severity
+	}
+	return s.convertSeverity(finding.Severity)
+}
+
+// reasonTaxonomy lists every dead code reason with its category and the
+// severity findings of it are reported with
+func (s *DeadCodeServiceImpl) reasonTaxonomy(req domain.DeadCodeRequest) []domain.DeadCodeReasonInfo {
+	reasons := domain.DeadCodeReasons()
+	taxonomy := make([]domain.DeadCodeReasonInfo, 0, len(reasons))
+	for _, reason := range reasons {
+		defaultSeverity := s.convertSeverity(analyzer.DefaultSeverity(analyzer.DeadCodeReason(reason)))
+		severity := defaultSeverity
+		if configured, ok := req.ReasonSeverities[reason]; ok {
+			severity = configured
+		}
+		taxonomy = append(taxonomy, domain.DeadCodeReasonInfo{
+			Reason:          reason,
+			Category:        domain.DeadCodeReasonCategory(reason),
+			Description:     analyzer.DescribeReason(analyzer.DeadCodeReason(reason)),
+			Severity:        severity,
+			DefaultSeverity: defaultSeverity,
+		})
+	}
+	return taxonomy
+}
+
 // convertSeverity converts analyzer severity to domain severity
 func (s *DeadCodeServiceImpl) convertSeverity(analyzerSeverity analyzer.SeverityLevel) domain.DeadCodeSeverity {
 	switch analyzerSeverity {
@@ -498,5 +637,6 @@ func (s *DeadCodeServiceImpl) buildConfigForResponse(req domain.DeadCodeRequest)
 		"include_patterns":            req.IncludePatterns,
 		"exclude_patterns":            req.ExcludePatterns,
 		"ignore_patterns":             req.IgnorePatterns,
+		"severities":                  req.ReasonSeverities,
 	}
 }
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -585,6 +587,65 @@ func TestDeadCodeService_DetectionFlagsFilterFindings(t *testing.T) {
 	}
 }
 
+func TestDeadCodeService_ReasonsFilterFindings(t *testing.T) {
+	service := NewDeadCodeService()
+	req := newDefaultDeadCodeRequest("../testdata/python/simple/dead_code_simple.py")
+
+	all, err := service.Analyze(context.Background(), req)
+	require.NoError(t, err)
+	require.Positive(t, all.Summary.FindingsByReason[string(analyzer.ReasonUnreachableAfterReturn)])
+
+	req.Reasons = []string{string(analyzer.ReasonUnreachableAfterReturn)}
+	response, err := service.Analyze(context.Background(), req)
+	require.NoError(t, err)
+
+	assert.Equal(t, all.Summary.FindingsByReason[string(analyzer.ReasonUnreachableAfterReturn)], response.Summary.TotalFindings)
+	for reason := range response.Summary.FindingsByReason {
+		assert.Equal(t, string(analyzer.ReasonUnreachableAfterReturn), reason)
+	}
+}
+
+func TestDeadCodeService_ReasonSeverities(t *testing.T) {
+	service := NewDeadCodeService()
+	req := newDefaultDeadCodeRequest("../testdata/python/simple/dead_code_simple.py")
+	afterReturn := string(analyzer.ReasonUnreachableAfterReturn)
+	req.ReasonSeverities = map[string]domain.DeadCodeSeverity{afterReturn: domain.DeadCodeSeverityInfo}
+
+	response, err := service.Analyze(context.Background(), req)
+	require.NoError(t, err)
+	require.Positive(t, response.Summary.FindingsByReason[afterReturn])
+	for _, file := range response.Files {
+		for _, function := range file.Functions {
+			for _, finding := range function.Findings {
+				if finding.Reason == afterReturn {
+					assert.Equal(t, domain.DeadCodeSeverityInfo, finding.Severity)
+				} else {
+					assert.NotEqual(t, domain.DeadCodeSeverityInfo, finding.Severity, finding.Reason)
+				}
+			}
+		}
+	}
+
+	require.Len(t, response.Reasons, len(domain.DeadCodeReasons()))
+	for _, reason := range response.Reasons {
+		assert.NotEmpty(t, reason.Category)
+		assert.NotEmpty(t, reason.Description)
+		switch reason.Reason {
+		case afterReturn:
+			assert.Equal(t, domain.DeadCodeSeverityInfo, reason.Severity)
+			assert.Equal(t, domain.DeadCodeSeverityCritical, reason.DefaultSeverity)
+		default:
+			assert.Equal(t, reason.DefaultSeverity, reason.Severity)
+		}
+	}
+
+	// Findings mapped below the minimum severity are dropped
+	req.MinSeverity = domain.DeadCodeSeverityWarning
+	response, err = service.Analyze(context.Background(), req)
+	require.NoError(t, err)
+	assert.Zero(t, response.Summary.FindingsByReason[afterReturn])
+}
+
 func TestDeadCodeService_ResponseMetadata(t *testing.T) {
 	service := NewDeadCodeService()
 	ctx := context.Background()
@@ -611,3 +672,151 @@ func TestDeadCodeService_ResponseMetadata(t *testing.T) {
 	// Verify config is present
 	assert.NotNil(t, response.Config)
 }
+
+func TestDeadCodeService_AllowDeadCodeDirective(t *testing.T) {
+	service := NewDeadCodeService()
+	path := t.TempDir() + "/fallback.py"
+	content := `def kept():
+    """Keeps a fallback.
+
+    pyscn: allow-dead-code
+    """
+    return 1
+    print("fallback")
+
+
+def reported():
+    return 1
+    print("dead")
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	response, err := service.Analyze(context.Background(), newDefaultDeadCodeRequest(path))
+	require.NoError(t, err)
+
+	require.Len(t, response.Files, 1)
+	require.Len(t, response.Files[0].Functions, 1)
+	assert.Equal(t, "reported", response.Files[0].Functions[0].Name)
+}
+
+func TestDeadCodeService_SourceContext(t *testing.T) {
+	service := NewDeadCodeService()
+	path := t.TempDir() + "/context.py"
+	content := "def first():\r\n    x = 1\r\n    return x\r\n    print(\"dead\")\r\n\r\n\r\ndef second():\r\n    pass\r\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	req := newDefaultDeadCodeRequest(path)
+	response, err := service.Analyze(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, response.Files, 1)
+	assert.Nil(t, response.Files[0].Functions[0].Findings[0].Context, "context is off by default")
+
+	req.ShowContext = domain.BoolPtr(true)
+	req.ContextLines = 2
+	response, err = service.Analyze(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, response.Files, 1)
+	finding := response.Files[0].Functions[0].Findings[0]
+	require.NotNil(t, finding.Context)
+	assert.Equal(t, &domain.DeadCodeContext{
+		StartLine: 2,
+		Before:    []string{"    x = 1", "    return x"},
+		Lines:     []string{`    print("dead")`},
+		After:     []string{"", ""},
+	}, finding.Context)
+
+	req.ContextLines = 5
+	fileResult, err := service.AnalyzeFile(context.Background(), path, req)
+	require.NoError(t, err)
+	fileContext := fileResult.Functions[0].Findings[0].Context
+	require.NotNil(t, fileContext)
+	assert.Equal(t, 1, fileContext.StartLine, "context is clipped at the start of the file")
+	assert.Len(t, fileContext.Before, 3)
+	assert.Equal(t, []string{"", "", "def second():", "    pass"}, fileContext.After, "context is clipped at the end of the file")
+}
+
+// The examples of the dead code rules are what pyscn explain prints, so
+// each violation must be reported under its rule and each fix must not.
+func TestDeadCodeService_RuleExamples(t *testing.T) {
+	service := NewDeadCodeService()
+	ctx := context.Background()
+	req := newDefaultDeadCodeRequest()
+	req.Paths = nil
+
+	reasonsIn := func(t *testing.T, source string) map[string]bool {
+		path := filepath.Join(t.TempDir(), "example.py")
+		require.NoError(t, os.WriteFile(path, []byte(source+"\n"), 0o644))
+		file, err := service.AnalyzeFile(ctx, path, req)
+		require.NoError(t, err)
+		reasons := make(map[string]bool)
+		for _, function := range file.Functions {
+			for _, finding := range function.Findings {
+				reasons[finding.Reason] = true
+			}
+		}
+		return reasons
+	}
+
+	for _, rule := range domain.AnalysisRules() {
+		if rule.Analysis != domain.AnalysisDeadCode || len(rule.DeadCodeReasons) != 1 {
+			continue
+		}
+		reason := rule.DeadCodeReasons[0]
+		t.Run(reason, func(t *testing.T) {
+			require.NotEmpty(t, rule.Violation)
+			require.NotEmpty(t, rule.Compliant)
+			assert.True(t, reasonsIn(t, rule.Violation)[reason], "violation example is not reported as %s", reason)
+			assert.Empty(t, reasonsIn(t, rule.Compliant), "compliant example has findings")
+		})
+	}
+}
+
+func TestDeadCodeService_UnusedAttributes(t *testing.T) {
+	service := NewDeadCodeService()
+	dir := t.TempDir()
+	cart := `class Cart:
+    def __init__(self):
+        self._items = []
+        self._cache = {}
+        self._total = 0
+
+
+class Legacy:
+    """Kept for pickles of older releases.
+
+    pyscn: allow-dead-code
+    """
+
+    def __init__(self):
+        self._version = 1
+`
+	report := `def report(cart):
+    return cart._total
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cart.py"), []byte(cart), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "report.py"), []byte(report), 0o644))
+
+	req := newDefaultDeadCodeRequest(filepath.Join(dir, "cart.py"), filepath.Join(dir, "report.py"))
+	response, err := service.Analyze(context.Background(), req)
+	require.NoError(t, err)
+
+	// report.py reads _total, and Legacy allows dead code
+	require.Len(t, response.Files, 1)
+	require.Len(t, response.Files[0].Functions, 1)
+	function := response.Files[0].Functions[0]
+	assert.Equal(t, "Cart.__init__", function.Name)
+	var codes []string
+	for _, finding := range function.Findings {
+		assert.Equal(t, string(analyzer.ReasonUnusedAttribute), finding.Reason)
+		assert.Equal(t, domain.DeadCodeSeverityWarning, finding.Severity)
+		codes = append(codes, finding.Code)
+	}
+	assert.Equal(t, []string{"self._items = ...", "self._cache = ..."}, codes)
+	assert.Equal(t, 2, response.Summary.FindingsByReason[string(analyzer.ReasonUnusedAttribute)])
+
+	// Selecting other reasons leaves attributes out
+	req.Reasons = []string{string(analyzer.ReasonUnreachableAfterReturn)}
+	response, err = service.Analyze(context.Background(), req)
+	require.NoError(t, err)
+	assert.Zero(t, response.Summary.TotalFindings)
+}
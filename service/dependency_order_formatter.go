@@ -0,0 +1,52 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// WriteDependencyOrderText writes the topological module listing grouped by
+// level. Modules on the same level do not depend on each other, so each level
+// can be processed once all previous levels are done.
+func WriteDependencyOrderText(writer io.Writer, order *domain.DependencyOrder) {
+	if order == nil {
+		return
+	}
+
+	var builder strings.Builder
+	utils := NewFormatUtils()
+	builder.WriteString(utils.FormatSectionHeader("DEPENDENCY ORDER"))
+
+	cycles := 0
+	modules := 0
+	for _, component := range order.Components {
+		modules += len(component.Modules)
+		if component.Cyclic {
+			cycles++
+		}
+	}
+	builder.WriteString(utils.FormatSummaryStats(map[string]interface{}{
+		"Modules": modules,
+		"Levels":  order.Levels,
+		"Cycles":  cycles,
+	}))
+
+	level := -1
+	for _, component := range order.Components {
+		if component.Level != level {
+			level = component.Level
+			builder.WriteString(fmt.Sprintf("\nLevel %d:\n", level))
+		}
+		if component.Cyclic {
+			builder.WriteString(fmt.Sprintf("%s[cycle] %s\n", strings.Repeat(" ", SectionPadding), strings.Join(component.Modules, ", ")))
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("%s%s\n", strings.Repeat(" ", SectionPadding), component.Modules[0]))
+	}
+	builder.WriteString(utils.FormatSectionSeparator())
+
+	_, _ = io.WriteString(writer, builder.String())
+}
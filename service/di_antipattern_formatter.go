@@ -1,13 +1,11 @@
 package service
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
 
 	"github.com/ludo-technologies/pyscn/domain"
-	"gopkg.in/yaml.v3"
 )
 
 // DIAntipatternFormatter implements the DIAntipatternOutputFormatter interface
@@ -45,16 +43,12 @@ func (f *DIAntipatternFormatter) Write(response *domain.DIAntipatternResponse, f
 
 // writeJSON writes output in JSON format
 func (f *DIAntipatternFormatter) writeJSON(response *domain.DIAntipatternResponse, writer io.Writer) error {
-	encoder := json.NewEncoder(writer)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(response)
+	return WriteJSON(writer, response)
 }
 
 // writeYAML writes output in YAML format
 func (f *DIAntipatternFormatter) writeYAML(response *domain.DIAntipatternResponse, writer io.Writer) error {
-	encoder := yaml.NewEncoder(writer)
-	encoder.SetIndent(2)
-	return encoder.Encode(response)
+	return WriteYAML(writer, response)
 }
 
 // writeText writes output in human-readable text format
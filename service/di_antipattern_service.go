@@ -97,7 +97,7 @@ func (s *DIAntipatternServiceImpl) analyzeFile(ctx context.Context, filePath str
 	}
 
 	// Parse the file
-	result, err := s.parser.Parse(ctx, content)
+	result, err := s.parser.ParseFor(ctx, filePath, content)
 	if err != nil {
 		errors = append(errors, fmt.Sprintf("[%s] Parse error: %v", filePath, err))
 		return findings, warnings, errors
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/config"
+)
+
+// DocumentationConfigurationLoaderImpl implements the DocumentationConfigurationLoader interface
+type DocumentationConfigurationLoaderImpl struct{}
+
+// NewDocumentationConfigurationLoader creates a new documentation configuration loader service
+func NewDocumentationConfigurationLoader() *DocumentationConfigurationLoaderImpl {
+	return &DocumentationConfigurationLoaderImpl{}
+}
+
+// LoadConfig loads documentation configuration from the specified path using TOML-only strategy
+func (cl *DocumentationConfigurationLoaderImpl) LoadConfig(path string) (*domain.DocumentationRequest, error) {
+	tomlLoader := config.NewTomlConfigLoader()
+	pyscnCfg, err := tomlLoader.LoadConfig(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config from %s: %w", path, err)
+	}
+
+	return cl.configToRequest(pyscnCfg), nil
+}
+
+// LoadDefaultConfig loads the default documentation configuration, first checking for .pyscn.toml
+func (cl *DocumentationConfigurationLoaderImpl) LoadDefaultConfig() *domain.DocumentationRequest {
+	tomlLoader := config.NewTomlConfigLoader()
+	if configFile := tomlLoader.FindConfigFileFromPath(""); configFile != "" {
+		if configReq, err := cl.LoadConfig(configFile); err == nil {
+			return configReq
+		}
+		// If loading failed, fall back to hardcoded defaults
+	}
+
+	return domain.DefaultDocumentationRequest()
+}
+
+// MergeConfig merges request values with configuration file values
+func (cl *DocumentationConfigurationLoaderImpl) MergeConfig(base *domain.DocumentationRequest, override *domain.DocumentationRequest) *domain.DocumentationRequest {
+	if base == nil {
+		return override
+	}
+	if override == nil {
+		return base
+	}
+
+	merged := *base
+
+	// Always override paths as they come from command arguments
+	merged.Paths = config.MergeSlice(merged.Paths, override.Paths)
+	merged.ConfigPath = config.Merge(merged.ConfigPath, override.ConfigPath)
+	merged.Convention = config.Merge(merged.Convention, override.Convention)
+
+	merged.Recursive = config.MergePtr(merged.Recursive, override.Recursive)
+	merged.IncludePatterns = config.MergeSlice(merged.IncludePatterns, override.IncludePatterns)
+	merged.ExcludePatterns = config.MergeSlice(merged.ExcludePatterns, override.ExcludePatterns)
+
+	return &merged
+}
+
+// configToRequest converts a PyscnConfig to domain.DocumentationRequest
+func (cl *DocumentationConfigurationLoaderImpl) configToRequest(pyscnCfg *config.PyscnConfig) *domain.DocumentationRequest {
+	if pyscnCfg == nil {
+		return domain.DefaultDocumentationRequest()
+	}
+
+	convention := domain.DocumentationConvention(pyscnCfg.DocumentationConvention)
+	if convention == "" {
+		convention = domain.DocumentationConvention(domain.DefaultDocumentationConvention)
+	}
+
+	return &domain.DocumentationRequest{
+		Convention:      convention,
+		Recursive:       domain.BoolPtr(domain.BoolValue(pyscnCfg.AnalysisRecursive, true)),
+		IncludePatterns: pyscnCfg.AnalyzerIncludePatterns(domain.AnalysisScopeDocumentation),
+		ExcludePatterns: pyscnCfg.AnalyzerExcludePatterns(domain.AnalysisScopeDocumentation),
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/analyzer"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// DocumentationServiceImpl implements the DocumentationService interface
+type DocumentationServiceImpl struct {
+	parser *parser.Parser
+}
+
+// NewDocumentationService creates a new docstring coverage service
+func NewDocumentationService() *DocumentationServiceImpl {
+	return &DocumentationServiceImpl{
+		parser: parser.New(),
+	}
+}
+
+// Analyze measures docstring coverage across the requested files
+func (s *DocumentationServiceImpl) Analyze(ctx context.Context, req domain.DocumentationRequest) (*domain.DocumentationResponse, error) {
+	convention := req.Convention
+	if convention == "" {
+		convention = domain.DocumentationConvention(domain.DefaultDocumentationConvention)
+	}
+	docAnalyzer := analyzer.NewDocstringAnalyzer(convention)
+
+	var allFindings []domain.DocumentationFinding
+	var errors []string
+	byKind := make(map[domain.DocumentationItemKind]domain.DocumentationKindStats)
+	filesProcessed := 0
+
+	for _, filePath := range req.Paths {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("documentation analysis cancelled: %w", ctx.Err())
+		default:
+		}
+
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("[%s] Failed to read file: %v", filePath, err))
+			reportSkippedFile(ctx, filePath, err)
+			continue
+		}
+		result, err := s.parser.ParseFor(ctx, filePath, content)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("[%s] Parse error: %v", filePath, err))
+			reportSkippedFile(ctx, filePath, err)
+			continue
+		}
+
+		fileResult := docAnalyzer.Analyze(result.AST, filePath)
+		allFindings = append(allFindings, fileResult.Findings...)
+		for kind, stats := range fileResult.ByKind {
+			total := byKind[kind]
+			total.Total += stats.Total
+			total.Documented += stats.Documented
+			byKind[kind] = total
+		}
+		filesProcessed++
+	}
+
+	return &domain.DocumentationResponse{
+		Convention: convention,
+		Findings:   analyzer.SortDocumentationFindings(allFindings),
+		Summary:    analyzer.GenerateDocumentationSummary(byKind, filesProcessed),
+		Errors:     errors,
+	}, nil
+}
@@ -2,22 +2,31 @@ package service
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/bmatcuk/doublestar/v4"
 	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/parser"
 )
 
 // FileReaderImpl implements the FileReader interface
-type FileReaderImpl struct{}
+type FileReaderImpl struct {
+	walk domain.FileWalkOptions
+}
 
 // NewFileReader creates a new file reader service
 func NewFileReader() *FileReaderImpl {
 	return &FileReaderImpl{}
 }
 
+// WithWalkOptions returns a file reader that walks directories with options
+func (f *FileReaderImpl) WithWalkOptions(options domain.FileWalkOptions) domain.FileReader {
+	return &FileReaderImpl{walk: options}
+}
+
 // CollectPythonFiles recursively finds all Python files in the given paths
 func (f *FileReaderImpl) CollectPythonFiles(paths []string, recursive bool, includePatterns, excludePatterns []string) ([]string, error) {
 
@@ -57,10 +66,10 @@ func (f *FileReaderImpl) ReadFile(path string) ([]byte, error) {
 	return content, nil
 }
 
-// IsValidPythonFile checks if a file is a valid Python file
+// IsValidPythonFile checks if a file is a valid Python file, or one of a
+// dialect a parser frontend handles, such as Cython
 func (f *FileReaderImpl) IsValidPythonFile(path string) bool {
-	ext := strings.ToLower(filepath.Ext(path))
-	return ext == ".py" || ext == ".pyi"
+	return parser.IsSourceFile(path)
 }
 
 // FileExists checks if a file exists
@@ -75,56 +84,93 @@ func (f *FileReaderImpl) FileExists(path string) (bool, error) {
 	return !info.IsDir(), nil
 }
 
-// collectFromDirectory collects Python files from a directory
+// collectFromDirectory collects Python files from a directory. Unreadable
+// directories are skipped.
 func (f *FileReaderImpl) collectFromDirectory(dirPath string, recursive bool, includePatterns, excludePatterns []string) ([]string, error) {
-	var files []string
+	walker := &directoryWalker{
+		reader:          f,
+		recursive:       recursive,
+		includePatterns: includePatterns,
+		excludePatterns: excludePatterns,
+		visited:         make(map[string]bool),
+	}
+	walker.walk(dirPath, 0)
+	return walker.files, nil
+}
 
-	walkFunc := func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			// Log warning but continue processing other files
-			return nil
-		}
+// directoryWalker collects the Python files below one target directory
+type directoryWalker struct {
+	reader          *FileReaderImpl
+	recursive       bool
+	includePatterns []string
+	excludePatterns []string
 
-		// Skip directories if not recursive
-		if info.IsDir() && !recursive && path != dirPath {
-			return filepath.SkipDir
-		}
+	// visited holds the resolved paths of the directories walked so far
+	// when symlinks are followed, so cycles and aliases are walked once
+	visited map[string]bool
+	files   []string
+}
 
-		// Skip hidden directories and files (but not the root directory being walked)
-		if strings.HasPrefix(info.Name(), ".") && path != dirPath {
-			if info.IsDir() {
-				return filepath.SkipDir
-			}
-			return nil
+// walk collects the files of dir, depth levels below the target, in
+// lexical order and descends into its subdirectories
+func (w *directoryWalker) walk(dir string, depth int) {
+	if w.reader.walk.FollowSymlinks {
+		resolved, err := filepath.EvalSymlinks(dir)
+		if err != nil || w.visited[resolved] {
+			return
 		}
+		w.visited[resolved] = true
+	}
 
-		// Skip common directories that shouldn't contain Python source files
-		if info.IsDir() && f.shouldSkipDirectory(info.Name()) {
-			return filepath.SkipDir
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		path := filepath.Join(dir, name)
+
+		// Skip hidden directories and files
+		if strings.HasPrefix(name, ".") {
+			continue
 		}
 
-		// Check if it's a Python file
-		if !info.IsDir() && f.IsValidPythonFile(path) {
-			if f.shouldIncludeFile(path, includePatterns, excludePatterns) {
-				files = append(files, path)
+		if w.isDir(path, entry) {
+			// Skip common directories that shouldn't contain Python source files
+			if !w.recursive || w.reader.shouldSkipDirectory(name) {
+				continue
 			}
+			if maxDepth := w.reader.walk.MaxDepth; maxDepth > 0 && depth >= maxDepth {
+				continue
+			}
+			w.walk(path, depth+1)
+			continue
 		}
 
-		return nil
+		if w.reader.IsValidPythonFile(path) && w.reader.shouldIncludeFile(path, w.includePatterns, w.excludePatterns) {
+			w.files = append(w.files, path)
+		}
 	}
+}
 
-	if err := filepath.Walk(dirPath, walkFunc); err != nil {
-		return nil, fmt.Errorf("failed to walk directory %s: %w", dirPath, err)
+// isDir reports whether an entry is a directory to descend into: a real
+// one, or a symlink to one when symlinks are followed
+func (w *directoryWalker) isDir(path string, entry fs.DirEntry) bool {
+	if entry.IsDir() {
+		return true
 	}
-
-	return files, nil
+	if entry.Type()&fs.ModeSymlink == 0 || !w.reader.walk.FollowSymlinks {
+		return false
+	}
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
 }
 
 // shouldIncludeFile checks if a file should be included based on patterns
 func (f *FileReaderImpl) shouldIncludeFile(path string, includePatterns, excludePatterns []string) bool {
 	// Check exclude patterns first
 	for _, pattern := range excludePatterns {
-		if patternMatches(pattern, path, true) {
+		if MatchPathPattern(pattern, path, true) {
 			return false
 		}
 	}
@@ -136,7 +182,7 @@ func (f *FileReaderImpl) shouldIncludeFile(path string, includePatterns, exclude
 
 	// Check include patterns
 	for _, pattern := range includePatterns {
-		if patternMatches(pattern, path, false) {
+		if MatchPathPattern(pattern, path, false) {
 			return true
 		}
 	}
@@ -144,15 +190,20 @@ func (f *FileReaderImpl) shouldIncludeFile(path string, includePatterns, exclude
 	return false
 }
 
-// patternMatches checks whether a glob pattern matches a file path.
+// MatchPathPattern checks whether a glob pattern matches a file path.
 // Paths are normalized to forward slashes so directory globs behave
-// consistently across platforms. When matchBasename is true and the
-// pattern has no path separators (bare-filename patterns like "test_*.py"),
-// matching also tries the file basename so exclude patterns work at any depth.
-func patternMatches(pattern, path string, matchBasename bool) bool {
+// consistently across platforms, and matched case-insensitively where file
+// names are (Windows). When matchBasename is true and the pattern has no
+// path separators (bare-filename patterns like "test_*.py"), matching also
+// tries the file basename so exclude patterns work at any depth.
+func MatchPathPattern(pattern, path string, matchBasename bool) bool {
 	// ToSlash only replaces the platform separator; also fold backslashes so
 	// directory globs work for Windows-style paths on every platform.
-	normalized := strings.ReplaceAll(filepath.ToSlash(path), "\\", "/")
+	normalized := strings.ReplaceAll(filepath.ToSlash(trimLongPathPrefix(path)), "\\", "/")
+	if caseInsensitivePaths {
+		pattern = strings.ToLower(pattern)
+		normalized = strings.ToLower(normalized)
+	}
 	if matched, _ := doublestar.Match(pattern, normalized); matched {
 		return true
 	}
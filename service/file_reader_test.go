@@ -154,6 +154,22 @@ func TestFileReader_CollectPythonFiles(t *testing.T) {
 			expectedFiles:   []string{"module.py", "types.pyi"},
 			expectError:     false,
 		},
+		{
+			name: "include cython files by pattern",
+			setupFiles: func(t *testing.T) (string, []string) {
+				tmpDir := createTempDir(t)
+				createTestFile(t, tmpDir, "pkg/module.py", "class Module: pass")
+				createTestFile(t, tmpDir, "pkg/fast.pyx", "cdef int twice(int x):\n    return x * 2\n")
+				createTestFile(t, tmpDir, "pkg/fast.c", "int twice(int x);")
+				return tmpDir, []string{tmpDir}
+			},
+			recursive:       true,
+			includePatterns: []string{"**/*.py", "**/*.pyx"},
+			excludePatterns: []string{},
+			expectedCount:   2,
+			expectedFiles:   []string{"module.py", "fast.pyx"},
+			expectError:     false,
+		},
 		{
 			name: "exclude patterns filtering",
 			setupFiles: func(t *testing.T) (string, []string) {
@@ -719,3 +735,55 @@ func TestFileReader_PermissionHandling(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, exists)
 }
+
+// TestFileReader_MaxDepth tests limiting how deep directories are walked
+func TestFileReader_MaxDepth(t *testing.T) {
+	tmpDir := createTestDirectoryStructure(t)
+
+	tests := []struct {
+		maxDepth int
+		expected []string
+	}{
+		{0, []string{"main.py", "utils.py", "config.py", "types.pyi", "__init__.py", "module.py", "file.py"}},
+		{1, []string{"main.py", "utils.py", "config.py", "types.pyi", "__init__.py", "module.py"}},
+		{3, []string{"main.py", "utils.py", "config.py", "types.pyi", "__init__.py", "module.py", "file.py"}},
+	}
+
+	for _, tt := range tests {
+		reader := NewFileReader().WithWalkOptions(domain.FileWalkOptions{MaxDepth: tt.maxDepth})
+		files, err := reader.CollectPythonFiles([]string{tmpDir}, true, nil, nil)
+		assert.NoError(t, err)
+
+		var names []string
+		for _, file := range files {
+			names = append(names, filepath.Base(file))
+		}
+		assert.ElementsMatch(t, tt.expected, names, "max depth %d", tt.maxDepth)
+	}
+}
+
+// TestFileReader_FollowSymlinks tests walking symlinked directories,
+// including symlink cycles
+func TestFileReader_FollowSymlinks(t *testing.T) {
+	tmpDir := createTempDir(t)
+	createTestFile(t, tmpDir, "project/app.py", "")
+	createTestFile(t, tmpDir, "shared/lib.py", "")
+	project := filepath.Join(tmpDir, "project")
+	if err := os.Symlink(filepath.Join(tmpDir, "shared"), filepath.Join(project, "shared")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	assert.NoError(t, os.Symlink(project, filepath.Join(project, "loop")))
+	assert.NoError(t, os.Symlink(tmpDir, filepath.Join(tmpDir, "shared", "up")))
+
+	files, err := NewFileReader().CollectPythonFiles([]string{project}, true, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(project, "app.py")}, files)
+
+	reader := NewFileReader().WithWalkOptions(domain.FileWalkOptions{FollowSymlinks: true})
+	files, err = reader.CollectPythonFiles([]string{project}, true, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(project, "app.py"),
+		filepath.Join(project, "shared", "lib.py"),
+	}, files)
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// CollectFindings flattens an analyze response into a single list of
+// actionable findings, most severe first. Low-risk complexity, CBO and LCOM
+// results are not findings and are left out.
+func CollectFindings(response *domain.AnalyzeResponse) []domain.Finding {
+	if response == nil {
+		return nil
+	}
+
+	var findings []domain.Finding
+	findings = append(findings, complexityFindings(response.Complexity)...)
+	findings = append(findings, deadCodeFindings(response.DeadCode)...)
+	findings = append(findings, cloneFindings(response.Clone)...)
+	findings = append(findings, cboFindings(response.CBO)...)
+	findings = append(findings, lcomFindings(response.LCOM)...)
+	findings = append(findings, systemFindings(response.System)...)
+	findings = append(findings, mockDataFindings(response.MockData)...)
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Severity.Rank() != b.Severity.Rank() {
+			return a.Severity.Rank() > b.Severity.Rank()
+		}
+		if a.FilePath != b.FilePath {
+			return a.FilePath < b.FilePath
+		}
+		return a.StartLine < b.StartLine
+	})
+	return findings
+}
+
+func complexityFindings(response *domain.ComplexityResponse) []domain.Finding {
+	if response == nil {
+		return nil
+	}
+	var findings []domain.Finding
+	for _, fn := range response.Functions {
+		if fn.RiskLevel == domain.RiskLevelLow {
+			continue
+		}
+		findings = append(findings, domain.Finding{
+			Category:  domain.FindingCategoryComplexity,
+			Severity:  domain.NormalizeFindingSeverity(string(fn.RiskLevel)),
+			FilePath:  fn.FilePath,
+			StartLine: fn.StartLine,
+			EndLine:   fn.EndLine,
+			Name:      fn.Name,
+			Message: fmt.Sprintf("%s has complexity %d, cognitive complexity %d",
+				fn.Name, fn.Metrics.Complexity, fn.Metrics.CognitiveComplexity),
+			Detail: fmt.Sprintf("Nesting depth %d, %s risk", fn.Metrics.NestingDepth, fn.RiskLevel),
+		})
+	}
+	return findings
+}
+
+func deadCodeFindings(response *domain.DeadCodeResponse) []domain.Finding {
+	if response == nil {
+		return nil
+	}
+	var findings []domain.Finding
+	for _, file := range response.Files {
+		for _, fn := range file.Functions {
+			for _, finding := range fn.Findings {
+				findings = append(findings, domain.Finding{
+					Category:  domain.FindingCategoryDeadCode,
+					Severity:  domain.NormalizeFindingSeverity(string(finding.Severity)),
+					FilePath:  finding.Location.FilePath,
+					StartLine: finding.Location.StartLine,
+					EndLine:   finding.Location.EndLine,
+					Name:      finding.FunctionName,
+					Message:   fmt.Sprintf("Unreachable code in %s (%s)", finding.FunctionName, finding.Reason),
+					Detail:    finding.Description,
+				})
+			}
+		}
+	}
+	return findings
+}
+
+func cloneFindings(response *domain.CloneResponse) []domain.Finding {
+	if response == nil {
+		return nil
+	}
+	var findings []domain.Finding
+	for _, pair := range response.ClonePairs {
+		if pair == nil || pair.Clone1 == nil || pair.Clone2 == nil ||
+			pair.Clone1.Location == nil || pair.Clone2.Location == nil {
+			continue
+		}
+		severity := domain.FindingSeverityMedium
+		if pair.Type == domain.Type1Clone || pair.Type == domain.Type2Clone {
+			severity = domain.FindingSeverityHigh
+		}
+		loc := pair.Clone1.Location
+		other := pair.Clone2.Location
+		findings = append(findings, domain.Finding{
+			Category:  domain.FindingCategoryClone,
+			Severity:  severity,
+			FilePath:  loc.FilePath,
+			StartLine: loc.StartLine,
+			EndLine:   loc.EndLine,
+			Name:      pair.ID,
+			Message:   fmt.Sprintf("%s clone of %s:%d (%.0f%% similar)", pair.Type, other.FilePath, other.StartLine, pair.Similarity*100),
+			Detail:    fmt.Sprintf("Other member: %s:%d-%d", other.FilePath, other.StartLine, other.EndLine),
+		})
+	}
+	return findings
+}
+
+func cboFindings(response *domain.CBOResponse) []domain.Finding {
+	if response == nil {
+		return nil
+	}
+	var findings []domain.Finding
+	for _, class := range response.Classes {
+		if class.RiskLevel == domain.RiskLevelLow {
+			continue
+		}
+		findings = append(findings, domain.Finding{
+			Category:  domain.FindingCategoryCBO,
+			Severity:  domain.NormalizeFindingSeverity(string(class.RiskLevel)),
+			FilePath:  class.FilePath,
+			StartLine: class.StartLine,
+			EndLine:   class.EndLine,
+			Name:      class.Name,
+			Message:   fmt.Sprintf("%s is coupled to %d classes", class.Name, class.Metrics.CouplingCount),
+			Detail:    strings.Join(class.Metrics.DependentClasses, ", "),
+		})
+	}
+	return findings
+}
+
+func lcomFindings(response *domain.LCOMResponse) []domain.Finding {
+	if response == nil {
+		return nil
+	}
+	var findings []domain.Finding
+	for _, class := range response.Classes {
+		if class.RiskLevel == domain.RiskLevelLow {
+			continue
+		}
+		findings = append(findings, domain.Finding{
+			Category:  domain.FindingCategoryLCOM,
+			Severity:  domain.NormalizeFindingSeverity(string(class.RiskLevel)),
+			FilePath:  class.FilePath,
+			StartLine: class.StartLine,
+			EndLine:   class.EndLine,
+			Name:      class.Name,
+			Message:   fmt.Sprintf("%s has %d unrelated method groups (LCOM4)", class.Name, class.Metrics.LCOM4),
+		})
+	}
+	return findings
+}
+
+func systemFindings(response *domain.SystemAnalysisResponse) []domain.Finding {
+	if response == nil {
+		return nil
+	}
+	var findings []domain.Finding
+
+	if deps := response.DependencyAnalysis; deps != nil && deps.CircularDependencies != nil {
+		for _, cycle := range deps.CircularDependencies.CircularDependencies {
+			finding := domain.Finding{
+				Category: domain.FindingCategoryCycle,
+				Severity: domain.NormalizeFindingSeverity(string(cycle.Severity)),
+				Name:     strings.Join(cycle.Modules, " → "),
+				Message:  fmt.Sprintf("Import cycle between %d modules", cycle.Size),
+				Detail:   cycle.Description,
+			}
+			// Anchor the cycle at its first module so it can be opened
+			if len(cycle.Modules) > 0 {
+				if metrics := deps.ModuleMetrics[cycle.Modules[0]]; metrics != nil {
+					finding.FilePath = metrics.FilePath
+					finding.StartLine = 1
+				}
+			}
+			findings = append(findings, finding)
+		}
+	}
+
+	if arch := response.ArchitectureAnalysis; arch != nil {
+		for _, violation := range arch.Violations {
+			finding := domain.Finding{
+				Category: domain.FindingCategoryArchitecture,
+				Severity: domain.NormalizeFindingSeverity(string(violation.Severity)),
+				Name:     violation.Module,
+				Message:  violation.Description,
+				Detail:   violation.Suggestion,
+			}
+			if violation.Location != nil {
+				finding.FilePath = violation.Location.FilePath
+				finding.StartLine = violation.Location.StartLine
+				finding.EndLine = violation.Location.EndLine
+			}
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+func mockDataFindings(response *domain.MockDataResponse) []domain.Finding {
+	if response == nil {
+		return nil
+	}
+	var findings []domain.Finding
+	for _, file := range response.Files {
+		for _, finding := range file.Findings {
+			findings = append(findings, domain.Finding{
+				Category:  domain.FindingCategoryMockData,
+				Severity:  domain.NormalizeFindingSeverity(string(finding.Severity)),
+				FilePath:  finding.Location.FilePath,
+				StartLine: finding.Location.StartLine,
+				EndLine:   finding.Location.EndLine,
+				Name:      finding.Value,
+				Message:   finding.Description,
+				Detail:    finding.Rationale,
+			})
+		}
+	}
+	return findings
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+func TestCollectFindings(t *testing.T) {
+	response := &domain.AnalyzeResponse{
+		Complexity: &domain.ComplexityResponse{Functions: []domain.FunctionComplexity{
+			{Name: "simple", FilePath: "a.py", StartLine: 1, RiskLevel: domain.RiskLevelLow},
+			{Name: "tangled", FilePath: "a.py", StartLine: 10, RiskLevel: domain.RiskLevelHigh,
+				Metrics: domain.ComplexityMetrics{Complexity: 25}},
+		}},
+		DeadCode: &domain.DeadCodeResponse{Files: []domain.FileDeadCode{{
+			FilePath: "b.py",
+			Functions: []domain.FunctionDeadCode{{Findings: []domain.DeadCodeFinding{{
+				Location:     domain.DeadCodeLocation{FilePath: "b.py", StartLine: 7, EndLine: 8},
+				FunctionName: "run",
+				Reason:       "unreachable_after_return",
+				Severity:     domain.DeadCodeSeverityWarning,
+			}}}},
+		}}},
+		Clone: &domain.CloneResponse{ClonePairs: []*domain.ClonePair{{
+			ID:         "cp-1",
+			Clone1:     &domain.Clone{Location: &domain.CloneLocation{FilePath: "c.py", StartLine: 3, EndLine: 12}},
+			Clone2:     &domain.Clone{Location: &domain.CloneLocation{FilePath: "d.py", StartLine: 5, EndLine: 14}},
+			Similarity: 0.97,
+			Type:       domain.Type1Clone,
+		}}},
+		System: &domain.SystemAnalysisResponse{DependencyAnalysis: &domain.DependencyAnalysisResult{
+			ModuleMetrics: map[string]*domain.ModuleDependencyMetrics{"pkg.a": {FilePath: "pkg/a.py"}},
+			CircularDependencies: &domain.CircularDependencyAnalysis{CircularDependencies: []domain.CircularDependency{{
+				Modules:  []string{"pkg.a", "pkg.b"},
+				Severity: domain.CycleSeverityLow,
+				Size:     2,
+			}}},
+		}},
+	}
+
+	findings := CollectFindings(response)
+	if len(findings) != 4 {
+		t.Fatalf("expected 4 findings (low-risk complexity excluded), got %d: %+v", len(findings), findings)
+	}
+
+	// Most severe first, then by location
+	want := []struct {
+		category domain.FindingCategory
+		severity domain.FindingSeverity
+		file     string
+	}{
+		{domain.FindingCategoryComplexity, domain.FindingSeverityHigh, "a.py"},
+		{domain.FindingCategoryClone, domain.FindingSeverityHigh, "c.py"},
+		{domain.FindingCategoryDeadCode, domain.FindingSeverityMedium, "b.py"},
+		{domain.FindingCategoryCycle, domain.FindingSeverityLow, "pkg/a.py"},
+	}
+	for i, w := range want {
+		f := findings[i]
+		if f.Category != w.category || f.Severity != w.severity || f.FilePath != w.file {
+			t.Errorf("finding %d: expected %s/%s in %s, got %s/%s in %s",
+				i, w.category, w.severity, w.file, f.Category, f.Severity, f.FilePath)
+		}
+	}
+	if findings[1].Name != "cp-1" {
+		t.Errorf("expected clone findings to be named by pair ID, got %q", findings[1].Name)
+	}
+}
+
+func TestCollectFindingsNilResponse(t *testing.T) {
+	if findings := CollectFindings(nil); findings != nil {
+		t.Errorf("expected no findings, got %+v", findings)
+	}
+}
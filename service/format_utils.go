@@ -1,51 +1,77 @@
 package service
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
 
 	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/i18n"
 	"gopkg.in/yaml.v3"
 )
 
-// EncodeJSON returns an indented JSON string for the given value.
+// EncodeJSON returns an indented JSON string for the given value. NaN and
+// infinite floats are encoded as null and invalid UTF-8 is replaced.
 func EncodeJSON(v interface{}) (string, error) {
-	data, err := json.MarshalIndent(v, "", "  ")
+	data, err := encodeStrict(v, func(v interface{}) ([]byte, error) {
+		return json.MarshalIndent(v, "", "  ")
+	})
 	if err != nil {
 		return "", domain.NewOutputError("failed to marshal JSON", err)
 	}
 	return string(data), nil
 }
 
-// WriteJSON writes indented JSON for the given value to the writer.
+// WriteJSON writes indented JSON for the given value to the writer, with the
+// same replacements as EncodeJSON.
 func WriteJSON(w io.Writer, v interface{}) error {
-	enc := json.NewEncoder(w)
-	enc.SetIndent("", "  ")
-	if err := enc.Encode(v); err != nil {
+	data, err := encodeStrict(v, func(v interface{}) ([]byte, error) {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("", "  ")
+		err := enc.Encode(v)
+		return buf.Bytes(), err
+	})
+	if err != nil {
 		return domain.NewOutputError("failed to encode JSON", err)
 	}
+	if _, err := w.Write(data); err != nil {
+		return domain.NewOutputError("failed to write JSON", err)
+	}
 	return nil
 }
 
-// EncodeYAML returns a YAML string for the given value.
+// EncodeYAML returns a YAML string for the given value. NaN and infinite
+// floats are encoded as null and invalid UTF-8 is replaced.
 func EncodeYAML(v interface{}) (string, error) {
-	data, err := yaml.Marshal(v)
+	data, err := encodeStrict(v, yaml.Marshal)
 	if err != nil {
 		return "", domain.NewOutputError("failed to marshal YAML", err)
 	}
 	return string(data), nil
 }
 
-// WriteYAML writes YAML for the given value to the writer.
+// WriteYAML writes YAML for the given value to the writer, with the same
+// replacements as EncodeYAML.
 func WriteYAML(w io.Writer, v interface{}) error {
-	enc := yaml.NewEncoder(w)
-	defer enc.Close()
-	enc.SetIndent(2)
-	if err := enc.Encode(v); err != nil {
+	data, err := encodeStrict(v, func(v interface{}) ([]byte, error) {
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return nil, err
+		}
+		err := enc.Close()
+		return buf.Bytes(), err
+	})
+	if err != nil {
 		return domain.NewOutputError("failed to encode YAML", err)
 	}
+	if _, err := w.Write(data); err != nil {
+		return domain.NewOutputError("failed to write YAML", err)
+	}
 	return nil
 }
 
@@ -77,26 +103,62 @@ const (
 )
 
 // FormatUtils provides shared formatting utilities
-type FormatUtils struct{}
+type FormatUtils struct {
+	translator *i18n.Translator
+}
 
 // NewFormatUtils creates a new format utilities instance
 func NewFormatUtils() *FormatUtils {
 	return &FormatUtils{}
 }
 
+// NewTranslatedFormatUtils creates format utilities that translate headers
+// and labels. Values are printed as given.
+func NewTranslatedFormatUtils(translator *i18n.Translator) *FormatUtils {
+	return &FormatUtils{translator: translator}
+}
+
+// T translates a message with the utilities' translator, formatting it with
+// args like fmt.Sprintf when given
+func (f *FormatUtils) T(message string, args ...any) string {
+	return f.translator.T(message, args...)
+}
+
+// displayWidth returns how many terminal columns s takes, counting East
+// Asian wide characters twice
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		switch {
+		case r >= 0x1100 && r <= 0x115f,
+			r >= 0x2e80 && r <= 0xa4cf,
+			r >= 0xac00 && r <= 0xd7a3,
+			r >= 0xf900 && r <= 0xfaff,
+			r >= 0xfe30 && r <= 0xfe4f,
+			r >= 0xff00 && r <= 0xff60,
+			r >= 0xffe0 && r <= 0xffe6:
+			width += 2
+		default:
+			width++
+		}
+	}
+	return width
+}
+
 // FormatMainHeader creates a standardized main header
 func (f *FormatUtils) FormatMainHeader(title string) string {
 	var builder strings.Builder
-	builder.WriteString(title + "\n")
+	builder.WriteString(f.T(title) + "\n")
 	builder.WriteString(strings.Repeat("=", HeaderWidth) + "\n\n")
 	return builder.String()
 }
 
 // FormatSectionHeader creates a standardized section header
 func (f *FormatUtils) FormatSectionHeader(title string) string {
+	title = f.T(title)
 	var builder strings.Builder
 	builder.WriteString(strings.ToUpper(title) + "\n")
-	builder.WriteString(strings.Repeat("-", len(title)) + "\n")
+	builder.WriteString(strings.Repeat("-", displayWidth(title)) + "\n")
 	return builder.String()
 }
 
@@ -107,7 +169,8 @@ func (f *FormatUtils) FormatSectionSeparator() string {
 
 // FormatLabel creates a consistently formatted label with right alignment
 func (f *FormatUtils) FormatLabel(label string, value interface{}) string {
-	padding := LabelWidth - len(label)
+	label = f.T(label)
+	padding := LabelWidth - displayWidth(label)
 	if padding < 0 {
 		padding = 0
 	}
@@ -116,7 +179,7 @@ func (f *FormatUtils) FormatLabel(label string, value interface{}) string {
 
 // FormatLabelWithIndent creates a formatted label with specific indentation
 func (f *FormatUtils) FormatLabelWithIndent(indent int, label string, value interface{}) string {
-	return fmt.Sprintf("%s%s: %v\n", strings.Repeat(" ", indent), label, value)
+	return fmt.Sprintf("%s%s: %v\n", strings.Repeat(" ", indent), f.T(label), value)
 }
 
 // FormatPercentage formats a percentage value consistently
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// isUncommitted reports whether commit is the all-zero id git blame reports
+// for lines that only exist in the working tree.
+func isUncommitted(commit string) bool {
+	return strings.Trim(commit, "0") == ""
+}
+
+// isCommitHash reports whether s is a full SHA-1 or SHA-256 commit id.
+func isCommitHash(s string) bool {
+	if len(s) != 40 && len(s) != 64 {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// blameLine holds the porcelain metadata git reports for a single line.
+type blameLine struct {
+	commit     string
+	author     string
+	email      string
+	authorTime time.Time
+}
+
+// GitBlameProvider implements domain.BlameProvider by shelling out to git.
+// Each file is blamed once and cached, so enriching many findings in the same
+// file costs a single git invocation. Different files are blamed
+// concurrently; mu only guards the cache.
+type GitBlameProvider struct {
+	mu    sync.Mutex
+	cache map[string][]blameLine
+	now   func() time.Time
+}
+
+// NewGitBlameProvider creates a new git-backed blame provider.
+func NewGitBlameProvider() *GitBlameProvider {
+	return &GitBlameProvider{
+		cache: make(map[string][]blameLine),
+		now:   time.Now,
+	}
+}
+
+// Blame returns blame metadata for the inclusive line range of filePath.
+// Files outside a git work tree or not tracked by git yield (nil, nil).
+func (p *GitBlameProvider) Blame(filePath string, startLine, endLine int) (*domain.BlameInfo, error) {
+	lines, err := p.fileBlame(filePath)
+	if err != nil || lines == nil {
+		return nil, err
+	}
+	return summarizeBlame(lines, startLine, endLine, p.now()), nil
+}
+
+// fileBlame returns the cached per-line blame for filePath, running git on
+// first access.
+func (p *GitBlameProvider) fileBlame(filePath string) ([]blameLine, error) {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		absPath = filePath
+	}
+
+	p.mu.Lock()
+	lines, ok := p.cache[absPath]
+	p.mu.Unlock()
+	if ok {
+		return lines, nil
+	}
+
+	cmd := exec.Command("git", "-C", filepath.Dir(absPath), "blame", "--line-porcelain", "--", filepath.Base(absPath))
+	out, err := cmd.Output()
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); !ok {
+			return nil, fmt.Errorf("failed to run git blame: %w", err)
+		}
+		// Not a repository or untracked file: nothing to report
+		lines = nil
+	} else if lines, err = parseBlamePorcelain(bytes.NewReader(out)); err != nil {
+		return nil, fmt.Errorf("failed to parse git blame output for %s: %w", filePath, err)
+	}
+
+	// Two callers blaming the same file at once both run git; either
+	// result is the same
+	p.mu.Lock()
+	p.cache[absPath] = lines
+	p.mu.Unlock()
+	return lines, nil
+}
+
+// parseBlamePorcelain parses `git blame --line-porcelain` output into one
+// entry per source line, in file order.
+func parseBlamePorcelain(r io.Reader) ([]blameLine, error) {
+	var lines []blameLine
+	var current blameLine
+	inHeader := false
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		text := scanner.Text()
+
+		if strings.HasPrefix(text, "\t") {
+			// Content line terminates the header block for this source line
+			lines = append(lines, current)
+			inHeader = false
+			continue
+		}
+
+		if !inHeader {
+			fields := strings.Fields(text)
+			if len(fields) < 3 || !isCommitHash(fields[0]) {
+				return nil, fmt.Errorf("unexpected blame header: %q", text)
+			}
+			current = blameLine{commit: fields[0]}
+			inHeader = true
+			continue
+		}
+
+		key, value, _ := strings.Cut(text, " ")
+		switch key {
+		case "author":
+			current.author = value
+		case "author-mail":
+			current.email = strings.Trim(value, "<>")
+		case "author-time":
+			if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+				current.authorTime = time.Unix(secs, 0).UTC()
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// summarizeBlame reduces the per-line blame of a range (1-based, inclusive)
+// to the most recent change, whose age AgeDays is, and the time of the
+// oldest line.
+func summarizeBlame(lines []blameLine, startLine, endLine int, now time.Time) *domain.BlameInfo {
+	if startLine < 1 {
+		startLine = 1
+	}
+	if endLine < startLine {
+		endLine = startLine
+	}
+	if endLine > len(lines) {
+		endLine = len(lines)
+	}
+	if startLine > endLine {
+		return nil
+	}
+
+	info := &domain.BlameInfo{}
+	var latest *blameLine
+	for i := startLine - 1; i < endLine; i++ {
+		line := &lines[i]
+		if isUncommitted(line.commit) {
+			info.Uncommitted = true
+			continue
+		}
+		if info.OldestModified.IsZero() || line.authorTime.Before(info.OldestModified) {
+			info.OldestModified = line.authorTime
+		}
+		if latest == nil || line.authorTime.After(latest.authorTime) {
+			latest = line
+		}
+	}
+
+	if latest == nil {
+		// Every line is uncommitted: the finding is brand new
+		info.LastModified = now.UTC()
+		return info
+	}
+
+	info.LastAuthor = latest.author
+	info.LastAuthorEmail = latest.email
+	info.LastCommit = latest.commit
+	info.LastModified = latest.authorTime
+	if age := now.Sub(latest.authorTime); age > 0 {
+		info.AgeDays = int(age.Hours() / 24)
+	}
+	return info
+}
+
+// formatBlameLabel renders blame metadata as a short "author, N days ago"
+// label for report tables.
+func formatBlameLabel(info *domain.BlameInfo) string {
+	if info == nil {
+		return "-"
+	}
+	if info.LastAuthor == "" {
+		return "uncommitted"
+	}
+	label := fmt.Sprintf("%s, %s (%dd ago)", info.LastAuthor, info.LastModified.Format("2006-01-02"), info.AgeDays)
+	if info.Uncommitted {
+		label += ", has uncommitted changes"
+	}
+	return label
+}
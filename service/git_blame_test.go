@@ -0,0 +1,166 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+const sampleBlamePorcelain = `1111111111111111111111111111111111111111 1 1 2
+author Alice
+author-mail <alice@example.com>
+author-time 1600000000
+author-tz +0000
+summary initial
+filename app.py
+	def handler():
+1111111111111111111111111111111111111111 2 2
+author Alice
+author-mail <alice@example.com>
+author-time 1600000000
+author-tz +0000
+summary initial
+filename app.py
+	    return 1
+2222222222222222222222222222222222222222 3 3 1
+author Bob
+author-mail <bob@example.com>
+author-time 1700000000
+author-tz +0000
+summary refactor
+previous 1111111111111111111111111111111111111111 app.py
+filename app.py
+	    print("dead")
+0000000000000000000000000000000000000000 4 4 1
+author Not Committed Yet
+author-mail <not.committed.yet>
+author-time 1800000000
+author-tz +0000
+summary Version of app.py from app.py
+filename app.py
+	    x = 2
+`
+
+func TestParseBlamePorcelain(t *testing.T) {
+	lines, err := parseBlamePorcelain(strings.NewReader(sampleBlamePorcelain))
+	if err != nil {
+		t.Fatalf("parseBlamePorcelain() error = %v", err)
+	}
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines, got %d", len(lines))
+	}
+	if lines[2].author != "Bob" || lines[2].email != "bob@example.com" {
+		t.Errorf("unexpected third line blame: %+v", lines[2])
+	}
+	if !lines[0].authorTime.Equal(time.Unix(1600000000, 0)) {
+		t.Errorf("unexpected author time: %v", lines[0].authorTime)
+	}
+}
+
+func TestParseBlamePorcelain_SHA256(t *testing.T) {
+	committed := strings.Repeat("ab", 32)
+	porcelain := committed + ` 1 1 1
+author Alice
+author-time 1600000000
+filename app.py
+	def handler():
+` + strings.Repeat("0", 64) + ` 2 2 1
+author Not Committed Yet
+author-time 1800000000
+filename app.py
+	    return 1
+`
+	lines, err := parseBlamePorcelain(strings.NewReader(porcelain))
+	if err != nil {
+		t.Fatalf("parseBlamePorcelain() error = %v", err)
+	}
+	if len(lines) != 2 || lines[0].commit != committed {
+		t.Fatalf("unexpected blame: %+v", lines)
+	}
+
+	info := summarizeBlame(lines, 1, 2, time.Unix(1600000000, 0).Add(48*time.Hour))
+	if !info.Uncommitted || info.LastCommit != committed || info.AgeDays != 2 {
+		t.Errorf("unexpected summary: %+v", info)
+	}
+}
+
+func TestParseBlamePorcelain_RejectsGarbage(t *testing.T) {
+	for _, input := range []string{"not porcelain\n", strings.Repeat("1", 50) + " 1 1 1\n", strings.Repeat("g", 40) + " 1 1 1\n"} {
+		if _, err := parseBlamePorcelain(strings.NewReader(input)); err == nil {
+			t.Errorf("expected error for malformed input %q", input)
+		}
+	}
+}
+
+func TestSummarizeBlame(t *testing.T) {
+	lines, err := parseBlamePorcelain(strings.NewReader(sampleBlamePorcelain))
+	if err != nil {
+		t.Fatalf("parseBlamePorcelain() error = %v", err)
+	}
+	now := time.Unix(1700000000, 0).Add(10 * 24 * time.Hour)
+
+	info := summarizeBlame(lines, 1, 3, now)
+	if info == nil {
+		t.Fatal("expected blame info")
+	}
+	if info.LastAuthor != "Bob" {
+		t.Errorf("LastAuthor = %q, want Bob", info.LastAuthor)
+	}
+	if info.AgeDays != 10 {
+		t.Errorf("AgeDays = %d, want 10", info.AgeDays)
+	}
+	if !info.OldestModified.Equal(time.Unix(1600000000, 0)) {
+		t.Errorf("OldestModified = %v", info.OldestModified)
+	}
+	if info.Uncommitted {
+		t.Error("range 1-3 should not be marked uncommitted")
+	}
+
+	info = summarizeBlame(lines, 3, 4, now)
+	if !info.Uncommitted {
+		t.Error("range 3-4 should be marked uncommitted")
+	}
+
+	info = summarizeBlame(lines, 4, 4, now)
+	if info.LastAuthor != "" || !info.Uncommitted {
+		t.Errorf("fully uncommitted range should have no author, got %+v", info)
+	}
+
+	if summarizeBlame(lines, 10, 12, now) != nil {
+		t.Error("out-of-range request should return nil")
+	}
+}
+
+func TestGitBlameProvider_UntrackedFileReturnsNil(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "untracked.py")
+	if err := os.WriteFile(path, []byte("x = 1\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	info, err := NewGitBlameProvider().Blame(path, 1, 1)
+	if err != nil {
+		t.Fatalf("Blame() error = %v", err)
+	}
+	if info != nil {
+		t.Errorf("expected nil blame for file outside git, got %+v", info)
+	}
+}
+
+func TestFormatBlameLabel(t *testing.T) {
+	if got := formatBlameLabel(nil); got != "-" {
+		t.Errorf("formatBlameLabel(nil) = %q", got)
+	}
+	info := &domain.BlameInfo{
+		LastAuthor:   "Alice",
+		LastModified: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		AgeDays:      3,
+	}
+	if got := formatBlameLabel(info); got != "Alice, 2024-01-02 (3d ago)" {
+		t.Errorf("formatBlameLabel() = %q", got)
+	}
+}
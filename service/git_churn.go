@@ -0,0 +1,121 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// GitChurnProvider implements domain.ChurnProvider by reading git log.
+// Files are grouped by repository, so each repository costs a single git
+// invocation however many files are analyzed.
+type GitChurnProvider struct{}
+
+// NewGitChurnProvider creates a new git-backed churn provider.
+func NewGitChurnProvider() *GitChurnProvider {
+	return &GitChurnProvider{}
+}
+
+// CommitCounts returns the number of commits since the given time touching
+// each file. Files outside a git work tree are omitted; if none of the files
+// is in one the result is (nil, nil).
+func (p *GitChurnProvider) CommitCounts(filePaths []string, since time.Time) (map[string]int, error) {
+	// Repository root -> path relative to the root -> files passed in
+	repos := make(map[string]map[string][]string)
+	roots := make(map[string]string)
+	for _, filePath := range filePaths {
+		absPath, err := filepath.Abs(filePath)
+		if err != nil {
+			continue
+		}
+		if resolved, err := filepath.EvalSymlinks(absPath); err == nil {
+			absPath = resolved
+		}
+
+		dir := filepath.Dir(absPath)
+		root, ok := roots[dir]
+		if !ok {
+			root = gitTopLevel(dir)
+			roots[dir] = root
+		}
+		if root == "" {
+			continue
+		}
+		rel, err := filepath.Rel(root, absPath)
+		if err != nil {
+			continue
+		}
+		if repos[root] == nil {
+			repos[root] = make(map[string][]string)
+		}
+		rel = filepath.ToSlash(rel)
+		repos[root][rel] = append(repos[root][rel], filePath)
+	}
+	if len(repos) == 0 {
+		return nil, nil
+	}
+
+	counts := make(map[string]int)
+	for root, files := range repos {
+		cmd := exec.Command("git", "-C", root, "-c", "core.quotePath=false", "log",
+			"--since="+since.Format(time.RFC3339), "--no-renames", "--name-only", "--format=")
+		out, err := cmd.Output()
+		if err != nil {
+			if _, ok := err.(*exec.ExitError); ok {
+				// Repository without commits yet: no churn to report
+				continue
+			}
+			return nil, fmt.Errorf("failed to run git log: %w", err)
+		}
+
+		repoCounts, err := parseChangedFiles(bytes.NewReader(out))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse git log output for %s: %w", root, err)
+		}
+		for rel, paths := range files {
+			if n := repoCounts[rel]; n > 0 {
+				for _, path := range paths {
+					counts[path] = n
+				}
+			}
+		}
+	}
+	return counts, nil
+}
+
+// parseChangedFiles counts the lines of `git log --name-only --format=`
+// output per path. Git lists a path at most once per commit, so the count is
+// the number of commits that touched it.
+func parseChangedFiles(r io.Reader) (map[string]int, error) {
+	counts := make(map[string]int)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		if path := strings.TrimSpace(scanner.Text()); path != "" {
+			counts[path]++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// gitTopLevel returns the root of the work tree containing dir, or "" when
+// dir is not inside one or git is unavailable
+func gitTopLevel(dir string) string {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return ""
+	}
+	root := strings.TrimSpace(string(out))
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+	return filepath.FromSlash(root)
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseChangedFiles(t *testing.T) {
+	out := "app/api.py\napp/models.py\n\napp/api.py\n\nREADME.md\n"
+	counts, err := parseChangedFiles(strings.NewReader(out))
+	if err != nil {
+		t.Fatalf("parseChangedFiles() error = %v", err)
+	}
+	if counts["app/api.py"] != 2 || counts["app/models.py"] != 1 || counts["README.md"] != 1 {
+		t.Errorf("unexpected counts: %v", counts)
+	}
+}
+
+func TestGitChurnProvider_CountsCommitsPerFile(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	git("init", "-q")
+	write("hot.py", "x = 1\n")
+	write("cold.py", "y = 1\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "initial")
+	write("hot.py", "x = 2\n")
+	git("commit", "-q", "-am", "change hot")
+	write("untracked.py", "z = 1\n")
+
+	hot := filepath.Join(dir, "hot.py")
+	cold := filepath.Join(dir, "cold.py")
+	untracked := filepath.Join(dir, "untracked.py")
+	counts, err := NewGitChurnProvider().CommitCounts([]string{hot, cold, untracked}, time.Now().AddDate(0, 0, -1))
+	if err != nil {
+		t.Fatalf("CommitCounts() error = %v", err)
+	}
+	if counts[hot] != 2 || counts[cold] != 1 {
+		t.Errorf("unexpected counts: %v", counts)
+	}
+	if _, ok := counts[untracked]; ok {
+		t.Errorf("untracked file should have no churn, got %v", counts)
+	}
+
+	counts, err = NewGitChurnProvider().CommitCounts([]string{hot}, time.Now().AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("CommitCounts() error = %v", err)
+	}
+	if len(counts) != 0 {
+		t.Errorf("commits before the window should not count, got %v", counts)
+	}
+}
+
+func TestGitChurnProvider_OutsideRepositoryReturnsNil(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "module.py")
+	if err := os.WriteFile(path, []byte("x = 1\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	counts, err := NewGitChurnProvider().CommitCounts([]string{path}, time.Now().AddDate(0, 0, -30))
+	if err != nil {
+		t.Fatalf("CommitCounts() error = %v", err)
+	}
+	if counts != nil {
+		t.Errorf("expected nil counts outside git, got %v", counts)
+	}
+}
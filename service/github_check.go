@@ -0,0 +1,163 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// maxGitHubCheckProjectFindings caps the project-level findings, which have
+// no line to annotate, listed in the summary
+const maxGitHubCheckProjectFindings = 10
+
+// BuildGitHubCheck turns an analyze response into a GitHub check run: the
+// health score as title, a Markdown summary, and the most severe findings as
+// annotations, capped at what one Checks API request accepts. Annotation
+// paths are relative to the git work tree containing the project root, as
+// GitHub expects.
+func BuildGitHubCheck(response *domain.AnalyzeResponse) *domain.GitHubCheckRun {
+	findings := CollectFindings(response)
+	pathOf := githubCheckPathResolver(response.Manifest)
+
+	annotations := []domain.GitHubCheckAnnotation{}
+	var projectFindings []domain.Finding
+	annotatable := 0
+	for _, finding := range findings {
+		path, ok := pathOf(finding.FilePath)
+		if !ok {
+			projectFindings = append(projectFindings, finding)
+			continue
+		}
+		annotatable++
+		if len(annotations) < domain.MaxGitHubCheckAnnotations {
+			annotations = append(annotations, githubCheckAnnotation(finding, path))
+		}
+	}
+
+	conclusion := domain.GitHubCheckConclusionSuccess
+	switch {
+	case response.Cancelled:
+		conclusion = domain.GitHubCheckConclusionNeutral
+	case !response.Summary.IsHealthy():
+		conclusion = domain.GitHubCheckConclusionFailure
+	}
+
+	return &domain.GitHubCheckRun{
+		Name:       "pyscn",
+		Status:     "completed",
+		Conclusion: conclusion,
+		Output: domain.GitHubCheckOutput{
+			Title:       fmt.Sprintf("Health score %d (%s), %d findings", response.Summary.HealthScore, response.Summary.Grade, len(findings)),
+			Summary:     githubCheckSummary(response, findings, projectFindings, len(annotations), annotatable),
+			Annotations: annotations,
+		},
+	}
+}
+
+// WriteGitHubCheck writes the GitHub check run of an analyze response as JSON
+func WriteGitHubCheck(writer io.Writer, response *domain.AnalyzeResponse) error {
+	return WriteJSON(writer, BuildGitHubCheck(response))
+}
+
+// githubCheckPathResolver returns a function mapping a report path to a
+// path relative to the git work tree, or false for findings without a file
+// and files outside the tree
+func githubCheckPathResolver(manifest *domain.AnalysisManifest) func(string) (string, bool) {
+	projectRoot, treeRoot := "", ""
+	if manifest != nil && manifest.Root != "" {
+		projectRoot = filepath.FromSlash(manifest.Root)
+		if resolved, err := filepath.EvalSymlinks(projectRoot); err == nil {
+			projectRoot = resolved
+		}
+		treeRoot = gitTopLevel(projectRoot)
+	}
+
+	return func(path string) (string, bool) {
+		if path == "" {
+			return "", false
+		}
+		path = filepath.FromSlash(path)
+		if !filepath.IsAbs(path) {
+			if treeRoot == "" {
+				return filepath.ToSlash(path), true
+			}
+			path = filepath.Join(projectRoot, path)
+		}
+		if treeRoot == "" {
+			return "", false
+		}
+		rel, err := filepath.Rel(treeRoot, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return "", false
+		}
+		return filepath.ToSlash(rel), true
+	}
+}
+
+func githubCheckAnnotation(finding domain.Finding, path string) domain.GitHubCheckAnnotation {
+	level := domain.GitHubAnnotationNotice
+	switch finding.Severity {
+	case domain.FindingSeverityHigh:
+		level = domain.GitHubAnnotationFailure
+	case domain.FindingSeverityMedium:
+		level = domain.GitHubAnnotationWarning
+	}
+	start := max(finding.StartLine, 1)
+	return domain.GitHubCheckAnnotation{
+		Path:            path,
+		StartLine:       start,
+		EndLine:         max(finding.EndLine, start),
+		AnnotationLevel: level,
+		Title:           fmt.Sprintf("pyscn %s: %s", finding.Category, finding.Name),
+		Message:         finding.Message,
+		RawDetails:      finding.Detail,
+	}
+}
+
+// githubCheckSummary renders the Markdown summary of the check run
+func githubCheckSummary(response *domain.AnalyzeResponse, findings, projectFindings []domain.Finding, annotated, annotatable int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**Health score: %d/100 (grade %s)**\n\n", response.Summary.HealthScore, response.Summary.Grade)
+	if response.Cancelled {
+		b.WriteString("The analysis was cancelled: results are partial.\n\n")
+	}
+	if len(findings) == 0 {
+		b.WriteString("No findings.\n")
+		return b.String()
+	}
+
+	counts := make(map[domain.FindingCategory][3]int)
+	var categories []domain.FindingCategory
+	for _, finding := range findings {
+		count, ok := counts[finding.Category]
+		if !ok {
+			categories = append(categories, finding.Category)
+		}
+		count[3-finding.Severity.Rank()]++
+		counts[finding.Category] = count
+	}
+	b.WriteString("| Analysis | High | Medium | Low |\n| --- | ---: | ---: | ---: |\n")
+	for _, category := range categories {
+		count := counts[category]
+		fmt.Fprintf(&b, "| %s | %d | %d | %d |\n", category, count[0], count[1], count[2])
+	}
+
+	if annotated < annotatable {
+		fmt.Fprintf(&b, "\nThe %d most severe of %d findings are annotated. Run `pyscn analyze` for the full report.\n", annotated, annotatable)
+	}
+
+	if len(projectFindings) > 0 {
+		b.WriteString("\n### Project-level findings\n\n")
+		for i, finding := range projectFindings {
+			if i == maxGitHubCheckProjectFindings {
+				fmt.Fprintf(&b, "- and %d more\n", len(projectFindings)-i)
+				break
+			}
+			fmt.Fprintf(&b, "- %s\n", finding.Message)
+		}
+	}
+	return b.String()
+}
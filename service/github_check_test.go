@@ -0,0 +1,122 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// complexFunctions returns n high-risk and one medium-risk function in a.py
+func complexFunctions(n int) *domain.ComplexityResponse {
+	functions := []domain.FunctionComplexity{
+		{Name: "busy", FilePath: "a.py", StartLine: 2, EndLine: 9, RiskLevel: domain.RiskLevelMedium},
+	}
+	for i := range n {
+		functions = append(functions, domain.FunctionComplexity{
+			Name: fmt.Sprintf("tangled%d", i), FilePath: "a.py", StartLine: 100 + i, EndLine: 100 + i, RiskLevel: domain.RiskLevelHigh,
+		})
+	}
+	return &domain.ComplexityResponse{Functions: functions}
+}
+
+func TestBuildGitHubCheckCapsAnnotations(t *testing.T) {
+	response := &domain.AnalyzeResponse{
+		Summary:    domain.AnalyzeSummary{HealthScore: 55, Grade: "D"},
+		Complexity: complexFunctions(60),
+	}
+
+	check := BuildGitHubCheck(response)
+	if check.Conclusion != domain.GitHubCheckConclusionFailure {
+		t.Errorf("expected an unhealthy score to fail the check, got %q", check.Conclusion)
+	}
+	if check.Output.Title != "Health score 55 (D), 61 findings" {
+		t.Errorf("unexpected title %q", check.Output.Title)
+	}
+	annotations := check.Output.Annotations
+	if len(annotations) != domain.MaxGitHubCheckAnnotations {
+		t.Fatalf("expected %d annotations, got %d", domain.MaxGitHubCheckAnnotations, len(annotations))
+	}
+	// Most severe first: the medium-risk function did not make the cut
+	for _, annotation := range annotations {
+		if annotation.AnnotationLevel != domain.GitHubAnnotationFailure {
+			t.Fatalf("expected only failure annotations, got %+v", annotation)
+		}
+	}
+	if annotations[0].Path != "a.py" || annotations[0].StartLine != 100 || annotations[0].EndLine != 100 {
+		t.Errorf("unexpected first annotation %+v", annotations[0])
+	}
+	if !strings.Contains(check.Output.Summary, "| complexity | 60 | 1 | 0 |") {
+		t.Errorf("summary lacks the finding counts:\n%s", check.Output.Summary)
+	}
+	if !strings.Contains(check.Output.Summary, "The 50 most severe of 61 findings are annotated") {
+		t.Errorf("summary does not mention the cap:\n%s", check.Output.Summary)
+	}
+}
+
+func TestBuildGitHubCheckWithoutFindings(t *testing.T) {
+	response := &domain.AnalyzeResponse{Summary: domain.AnalyzeSummary{HealthScore: 95, Grade: "A"}}
+
+	var buf bytes.Buffer
+	if err := WriteGitHubCheck(&buf, response); err != nil {
+		t.Fatalf("WriteGitHubCheck() error = %v", err)
+	}
+	var check domain.GitHubCheckRun
+	if err := json.Unmarshal(buf.Bytes(), &check); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if check.Conclusion != domain.GitHubCheckConclusionSuccess || check.Status != "completed" {
+		t.Errorf("unexpected check %+v", check)
+	}
+	// The Checks API rejects a null annotation list
+	if !strings.Contains(buf.String(), `"annotations": []`) {
+		t.Errorf("expected an empty annotation list:\n%s", buf.String())
+	}
+}
+
+func TestBuildGitHubCheckPathsRelativeToWorkTree(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "-C", dir, "init", "-q").CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	project := filepath.Join(dir, "services", "api")
+	if err := os.MkdirAll(project, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	response := &domain.AnalyzeResponse{
+		Summary:    domain.AnalyzeSummary{HealthScore: 80, Grade: "B"},
+		Manifest:   &domain.AnalysisManifest{Root: filepath.ToSlash(project)},
+		Complexity: complexFunctions(1),
+		DeadCode: &domain.DeadCodeResponse{Files: []domain.FileDeadCode{{
+			Functions: []domain.FunctionDeadCode{{Findings: []domain.DeadCodeFinding{{
+				Location:     domain.DeadCodeLocation{FilePath: filepath.Join(t.TempDir(), "outside.py"), StartLine: 7, EndLine: 8},
+				FunctionName: "run",
+				Reason:       "unreachable_after_return",
+				Severity:     domain.DeadCodeSeverityCritical,
+			}}}},
+		}}},
+	}
+
+	check := BuildGitHubCheck(response)
+	if len(check.Output.Annotations) != 2 {
+		t.Fatalf("expected 2 annotations, got %+v", check.Output.Annotations)
+	}
+	for _, annotation := range check.Output.Annotations {
+		if annotation.Path != "services/api/a.py" {
+			t.Errorf("expected a path relative to the work tree, got %q", annotation.Path)
+		}
+	}
+	if !strings.Contains(check.Output.Summary, "### Project-level findings\n\n- Unreachable code in run") {
+		t.Errorf("expected the file outside the work tree in the summary:\n%s", check.Output.Summary)
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/analyzer"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// maxSuggestedClusters caps the clusters spelled out in a suggestion; the
+// full list stays in GodModule.Clusters
+const maxSuggestedClusters = 5
+
+type godModuleOptions struct {
+	maxLines       int
+	maxDefinitions int
+	maxFanIn       int
+	maxFanOut      int
+	severity       domain.ViolationSeverity
+}
+
+func godModuleOptionsFromRequest(req domain.SystemAnalysisRequest) godModuleOptions {
+	options := godModuleOptions{
+		maxLines:       domain.DefaultGodModuleMaxLines,
+		maxDefinitions: domain.DefaultGodModuleMaxDefinitions,
+		maxFanIn:       domain.DefaultGodModuleMaxFanIn,
+		maxFanOut:      domain.DefaultGodModuleMaxFanOut,
+		severity:       domain.ViolationSeverityWarning,
+	}
+	if req.GodModuleMaxLines > 0 {
+		options.maxLines = req.GodModuleMaxLines
+	}
+	if req.GodModuleMaxDefinitions > 0 {
+		options.maxDefinitions = req.GodModuleMaxDefinitions
+	}
+	if req.GodModuleMaxFanIn > 0 {
+		options.maxFanIn = req.GodModuleMaxFanIn
+	}
+	if req.GodModuleMaxFanOut > 0 {
+		options.maxFanOut = req.GodModuleMaxFanOut
+	}
+	return options
+}
+
+// analyzeGodModulesForRequest flags modules exceeding the line, definition,
+// fan-in and fan-out thresholds all at once. Each module in the graph counts
+// as one rule invocation.
+func (s *SystemAnalysisServiceImpl) analyzeGodModulesForRequest(
+	graph *analyzer.DependencyGraph,
+	req domain.SystemAnalysisRequest,
+) (*domain.GodModuleAnalysis, []domain.ArchitectureViolation, int) {
+	if !domain.BoolValue(req.ValidateGodModules, true) {
+		return nil, nil, 0
+	}
+
+	options := godModuleOptionsFromRequest(req)
+	modules := graph.GetModuleNames()
+	analysis := &domain.GodModuleAnalysis{GodModules: []domain.GodModule{}}
+	violations := make([]domain.ArchitectureViolation, 0)
+
+	for _, module := range modules {
+		node := graph.Nodes[module]
+		if !isGodModule(node, options) {
+			continue
+		}
+
+		clusters := godModuleClusters(node.FilePath)
+		suggestion := godModuleSuggestion(module, clusters)
+		analysis.GodModules = append(analysis.GodModules, domain.GodModule{
+			Module:     module,
+			FilePath:   node.FilePath,
+			Lines:      node.LineCount,
+			Classes:    node.ClassCount,
+			Functions:  node.FunctionCount,
+			FanIn:      node.InDegree,
+			FanOut:     node.OutDegree,
+			Severity:   options.severity,
+			Clusters:   clusters,
+			Suggestion: suggestion,
+		})
+		violations = append(violations, domain.ArchitectureViolation{
+			Type:     domain.ViolationTypeGodModule,
+			Severity: options.severity,
+			Module:   module,
+			Rule:     "god-module",
+			Description: fmt.Sprintf("Module '%s' has %d lines, %d classes and functions, fan-in %d and fan-out %d",
+				module, node.LineCount, node.ClassCount+node.FunctionCount, node.InDegree, node.OutDegree),
+			Suggestion: suggestion,
+		})
+	}
+
+	return analysis, violations, len(modules)
+}
+
+func isGodModule(node *analyzer.ModuleNode, options godModuleOptions) bool {
+	return node.LineCount > options.maxLines &&
+		node.ClassCount+node.FunctionCount > options.maxDefinitions &&
+		node.InDegree > options.maxFanIn &&
+		node.OutDegree > options.maxFanOut
+}
+
+// godModuleClusters parses a flagged module again to group its definitions;
+// a file that no longer parses yields no clusters
+func godModuleClusters(filePath string) [][]string {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil
+	}
+	result, err := parser.New().Parse(context.Background(), content)
+	if err != nil {
+		return nil
+	}
+	return analyzer.ModuleDefinitionClusters(result.AST)
+}
+
+func godModuleSuggestion(module string, clusters [][]string) string {
+	if len(clusters) < 2 {
+		return fmt.Sprintf("The definitions of '%s' all reference each other; extract a stable core and move the code around it into separate modules", module)
+	}
+
+	parts := make([]string, 0, maxSuggestedClusters+1)
+	for i, cluster := range clusters {
+		if i == maxSuggestedClusters {
+			parts = append(parts, fmt.Sprintf("and %d more", len(clusters)-i))
+			break
+		}
+		parts = append(parts, "["+strings.Join(cluster, ", ")+"]")
+	}
+	return fmt.Sprintf("Split '%s' into modules along its %d independent groups of definitions: %s",
+		module, len(clusters), strings.Join(parts, "; "))
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/analyzer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const godModuleSource = `class Order:
+    pass
+
+def place_order():
+    return Order()
+
+class Invoice:
+    pass
+
+def bill(order):
+    return Invoice()
+
+def healthcheck():
+    return True
+`
+
+func godModuleGraph(t *testing.T) *analyzer.DependencyGraph {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "hub.py")
+	require.NoError(t, os.WriteFile(path, []byte(godModuleSource), 0o644))
+
+	graph := analyzer.NewDependencyGraph(dir)
+	graph.AddModule("app.hub", path)
+	graph.AddModule("app.small", filepath.Join(dir, "small.py"))
+	for _, module := range []string{"app.a", "app.b", "app.c"} {
+		graph.AddModule(module, filepath.Join(dir, module+".py"))
+		graph.AddDependency("app.hub", module, analyzer.DependencyEdgeImport, nil)
+		graph.AddDependency(module, "app.hub", analyzer.DependencyEdgeImport, nil)
+		graph.AddDependency("app.small", module, analyzer.DependencyEdgeImport, nil)
+		graph.AddDependency(module, "app.small", analyzer.DependencyEdgeImport, nil)
+	}
+
+	hub := graph.Nodes["app.hub"]
+	hub.LineCount, hub.ClassCount, hub.FunctionCount = 15, 2, 3
+	small := graph.Nodes["app.small"]
+	small.LineCount, small.ClassCount, small.FunctionCount = 15, 0, 1
+	return graph
+}
+
+func godModuleRequest() domain.SystemAnalysisRequest {
+	return domain.SystemAnalysisRequest{
+		GodModuleMaxLines:       10,
+		GodModuleMaxDefinitions: 4,
+		GodModuleMaxFanIn:       2,
+		GodModuleMaxFanOut:      2,
+	}
+}
+
+func TestAnalyzeGodModulesRequiresEveryThreshold(t *testing.T) {
+	service := NewSystemAnalysisService()
+
+	analysis, violations, checks := service.analyzeGodModulesForRequest(godModuleGraph(t), godModuleRequest())
+
+	require.NotNil(t, analysis)
+	require.Len(t, analysis.GodModules, 1)
+	god := analysis.GodModules[0]
+	assert.Equal(t, "app.hub", god.Module)
+	assert.Equal(t, 3, god.FanIn)
+	assert.Equal(t, 3, god.FanOut)
+	assert.Equal(t, [][]string{{"Order", "place_order"}, {"Invoice", "bill"}, {"healthcheck"}}, god.Clusters)
+	assert.Contains(t, god.Suggestion, "3 independent groups")
+	assert.Contains(t, god.Suggestion, "[Order, place_order]; [Invoice, bill]; [healthcheck]")
+
+	require.Len(t, violations, 1)
+	assert.Equal(t, domain.ViolationTypeGodModule, violations[0].Type)
+	assert.Equal(t, "god-module", violations[0].Rule)
+	assert.Equal(t, domain.ViolationSeverityWarning, violations[0].Severity)
+	assert.Equal(t, 5, checks)
+}
+
+func TestAnalyzeGodModulesCanBeDisabled(t *testing.T) {
+	service := NewSystemAnalysisService()
+	req := godModuleRequest()
+	req.ValidateGodModules = domain.BoolPtr(false)
+
+	analysis, violations, checks := service.analyzeGodModulesForRequest(godModuleGraph(t), req)
+
+	assert.Nil(t, analysis)
+	assert.Empty(t, violations)
+	assert.Zero(t, checks)
+}
+
+func TestGodModuleSuggestionWithoutIndependentGroups(t *testing.T) {
+	suggestion := godModuleSuggestion("app.hub", [][]string{{"Order", "place_order"}})
+
+	assert.Contains(t, suggestion, "all reference each other")
+}
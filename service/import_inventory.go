@@ -0,0 +1,417 @@
+package service
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/analyzer"
+)
+
+// distributionImportAliases maps normalized distribution names whose import
+// name differs from the distribution name.
+var distributionImportAliases = map[string][]string{
+	"beautifulsoup4":           {"bs4"},
+	"pyyaml":                   {"yaml"},
+	"pillow":                   {"PIL"},
+	"scikit-learn":             {"sklearn"},
+	"scikit-image":             {"skimage"},
+	"python-dateutil":          {"dateutil"},
+	"opencv-python":            {"cv2"},
+	"opencv-python-headless":   {"cv2"},
+	"opencv-contrib-python":    {"cv2"},
+	"protobuf":                 {"google"},
+	"googleapis-common-protos": {"google"},
+	"google-cloud-storage":     {"google"},
+	"pyjwt":                    {"jwt"},
+	"python-dotenv":            {"dotenv"},
+	"python-multipart":         {"multipart"},
+	"mysqlclient":              {"MySQLdb"},
+	"psycopg2-binary":          {"psycopg2"},
+	"psycopg-binary":           {"psycopg"},
+	"attrs":                    {"attr", "attrs"},
+	"pyzmq":                    {"zmq"},
+	"pyserial":                 {"serial"},
+	"pycryptodome":             {"Crypto"},
+	"pyopenssl":                {"OpenSSL"},
+	"setuptools":               {"setuptools", "pkg_resources"},
+	"typing-extensions":        {"typing_extensions"},
+	"msgpack-python":           {"msgpack"},
+	"ruamel-yaml":              {"ruamel"},
+	"discord-py":               {"discord"},
+	"faiss-cpu":                {"faiss"},
+	"tensorflow-gpu":           {"tensorflow"},
+}
+
+// requirementNamePattern extracts the distribution name from a PEP 508 requirement
+var requirementNamePattern = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._-]*)`)
+
+// distributionSeparatorPattern matches runs of PEP 503 name separators
+var distributionSeparatorPattern = regexp.MustCompile(`[-_.]+`)
+
+// virtualenvDirs are project-relative locations checked for installed packages
+var virtualenvDirs = []string{".venv", "venv", "env"}
+
+// buildImportInventory classifies the external imports recorded in graph and
+// cross-checks them against the dependency manifests found in the project root.
+func buildImportInventory(graph *analyzer.DependencyGraph) *domain.ImportInventory {
+	inventory := &domain.ImportInventory{
+		Packages:       []domain.ExternalPackage{},
+		ManifestFiles:  []string{},
+		Declared:       []domain.DeclaredDependency{},
+		Undeclared:     []string{},
+		UnusedDeclared: []domain.DeclaredDependency{},
+	}
+	if graph == nil {
+		return inventory
+	}
+
+	if graph.ProjectRoot != "" {
+		inventory.ManifestFiles, inventory.Declared = loadDeclaredDependencies(graph.ProjectRoot)
+	}
+	declaredByImport := make(map[string]*domain.DeclaredDependency)
+	for i := range inventory.Declared {
+		for _, name := range inventory.Declared[i].ImportNames {
+			if _, exists := declaredByImport[name]; !exists {
+				declaredByImport[name] = &inventory.Declared[i]
+			}
+		}
+	}
+	sitePackages := findSitePackages(graph.ProjectRoot)
+
+	packages := make(map[string]*domain.ExternalPackage)
+	moduleSets := make(map[string]map[string]bool)
+	for _, ext := range graph.ExternalImports {
+		pkg, ok := packages[ext.TopLevel]
+		if !ok {
+			pkg = &domain.ExternalPackage{Name: ext.TopLevel}
+			switch {
+			case analyzer.IsStandardLibraryModule(ext.TopLevel):
+				pkg.Category = domain.ExternalImportStdlib
+			case declaredByImport[ext.TopLevel] != nil:
+				pkg.Category = domain.ExternalImportThirdParty
+				pkg.Distribution = declaredByImport[ext.TopLevel].Name
+			case isInstalledPackage(sitePackages, ext.TopLevel):
+				pkg.Category = domain.ExternalImportThirdParty
+			default:
+				pkg.Category = domain.ExternalImportUnknown
+			}
+			packages[ext.TopLevel] = pkg
+			moduleSets[ext.TopLevel] = make(map[string]bool)
+		}
+		pkg.UsageCount++
+		pkg.Locations = append(pkg.Locations, domain.ImportLocation{
+			FilePath:         ext.FilePath,
+			Line:             ext.Line,
+			Module:           ext.FromModule,
+			TypeCheckingOnly: ext.IsTypeChecking,
+		})
+		if !moduleSets[ext.TopLevel][ext.Module] {
+			moduleSets[ext.TopLevel][ext.Module] = true
+			pkg.Modules = append(pkg.Modules, ext.Module)
+		}
+	}
+
+	for _, pkg := range packages {
+		sort.Strings(pkg.Modules)
+		sort.SliceStable(pkg.Locations, func(i, j int) bool {
+			if pkg.Locations[i].FilePath != pkg.Locations[j].FilePath {
+				return pkg.Locations[i].FilePath < pkg.Locations[j].FilePath
+			}
+			return pkg.Locations[i].Line < pkg.Locations[j].Line
+		})
+		inventory.Packages = append(inventory.Packages, *pkg)
+
+		switch pkg.Category {
+		case domain.ExternalImportStdlib:
+			inventory.StdlibCount++
+		case domain.ExternalImportThirdParty:
+			inventory.ThirdPartyCount++
+		default:
+			inventory.UnknownCount++
+		}
+		if len(inventory.ManifestFiles) > 0 && pkg.Category != domain.ExternalImportStdlib && pkg.Distribution == "" {
+			inventory.Undeclared = append(inventory.Undeclared, pkg.Name)
+		}
+	}
+	sort.Slice(inventory.Packages, func(i, j int) bool {
+		ci, cj := externalCategoryRank(inventory.Packages[i].Category), externalCategoryRank(inventory.Packages[j].Category)
+		if ci != cj {
+			return ci < cj
+		}
+		return inventory.Packages[i].Name < inventory.Packages[j].Name
+	})
+	sort.Strings(inventory.Undeclared)
+
+	// Optional and development dependencies are often tools that are never
+	// imported, so only required dependencies are reported as unused
+	for _, dep := range inventory.Declared {
+		if dep.Optional {
+			continue
+		}
+		used := false
+		for _, name := range dep.ImportNames {
+			if _, ok := packages[name]; ok {
+				used = true
+				break
+			}
+		}
+		if !used {
+			inventory.UnusedDeclared = append(inventory.UnusedDeclared, dep)
+		}
+	}
+
+	return inventory
+}
+
+func externalCategoryRank(category domain.ExternalImportCategory) int {
+	switch category {
+	case domain.ExternalImportThirdParty:
+		return 0
+	case domain.ExternalImportUnknown:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// loadDeclaredDependencies reads pyproject.toml and requirements files in root.
+// Unreadable or malformed manifests are skipped.
+func loadDeclaredDependencies(root string) ([]string, []domain.DeclaredDependency) {
+	files := []string{}
+	declared := []domain.DeclaredDependency{}
+	seen := make(map[string]int)
+
+	add := func(deps []domain.DeclaredDependency) {
+		for _, dep := range deps {
+			key := normalizeDistributionName(dep.Name)
+			if idx, ok := seen[key]; ok {
+				// A dependency required anywhere is required
+				declared[idx].Optional = declared[idx].Optional && dep.Optional
+				continue
+			}
+			seen[key] = len(declared)
+			declared = append(declared, dep)
+		}
+	}
+
+	pyproject := filepath.Join(root, "pyproject.toml")
+	if deps, ok := parsePyprojectDependencies(pyproject); ok {
+		files = append(files, pyproject)
+		add(deps)
+	}
+
+	var requirements []string
+	for _, pattern := range []string{"requirements*.txt", filepath.Join("requirements", "*.txt")} {
+		matches, _ := filepath.Glob(filepath.Join(root, pattern))
+		requirements = append(requirements, matches...)
+	}
+	sort.Strings(requirements)
+	for _, path := range requirements {
+		if deps, ok := parseRequirementsFile(path); ok {
+			files = append(files, path)
+			add(deps)
+		}
+	}
+
+	return files, declared
+}
+
+// parsePyprojectDependencies extracts PEP 621, PEP 735 and Poetry dependencies.
+// A pyproject.toml without any dependency table is not treated as a manifest.
+func parsePyprojectDependencies(path string) ([]domain.DeclaredDependency, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var doc map[string]interface{}
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return nil, false
+	}
+
+	var deps []domain.DeclaredDependency
+	hasDependencyTable := false
+	addRequirement := func(spec interface{}, optional bool) {
+		s, ok := spec.(string)
+		if !ok {
+			return
+		}
+		if name := requirementName(s); name != "" {
+			deps = append(deps, newDeclaredDependency(name, path, optional))
+		}
+	}
+	addRequirements := func(list interface{}, optional bool) {
+		items, ok := list.([]interface{})
+		hasDependencyTable = hasDependencyTable || ok
+		for _, item := range items {
+			addRequirement(item, optional)
+		}
+	}
+	addPoetryTable := func(table interface{}, optional bool) {
+		entries, ok := table.(map[string]interface{})
+		hasDependencyTable = hasDependencyTable || ok
+		for name := range entries {
+			if strings.EqualFold(name, "python") {
+				continue
+			}
+			deps = append(deps, newDeclaredDependency(name, path, optional))
+		}
+	}
+
+	if project, ok := doc["project"].(map[string]interface{}); ok {
+		addRequirements(project["dependencies"], false)
+		if extras, ok := project["optional-dependencies"].(map[string]interface{}); ok {
+			for _, list := range extras {
+				addRequirements(list, true)
+			}
+		}
+	}
+	if groups, ok := doc["dependency-groups"].(map[string]interface{}); ok {
+		for _, list := range groups {
+			addRequirements(list, true)
+		}
+	}
+	if tool, ok := doc["tool"].(map[string]interface{}); ok {
+		if poetry, ok := tool["poetry"].(map[string]interface{}); ok {
+			addPoetryTable(poetry["dependencies"], false)
+			addPoetryTable(poetry["dev-dependencies"], true)
+			if groups, ok := poetry["group"].(map[string]interface{}); ok {
+				for _, group := range groups {
+					if g, ok := group.(map[string]interface{}); ok {
+						addPoetryTable(g["dependencies"], true)
+					}
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(deps, func(i, j int) bool { return deps[i].Name < deps[j].Name })
+	return deps, hasDependencyTable
+}
+
+// parseRequirementsFile extracts requirement names from a pip requirements file.
+// Files whose name suggests development use are treated as optional.
+func parseRequirementsFile(path string) ([]domain.DeclaredDependency, bool) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, false
+	}
+	defer file.Close()
+
+	base := strings.ToLower(filepath.Base(path))
+	optional := false
+	for _, marker := range []string{"dev", "test", "doc", "lint"} {
+		if strings.Contains(base, marker) {
+			optional = true
+			break
+		}
+	}
+
+	var deps []domain.DeclaredDependency
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = strings.TrimSpace(line[:idx])
+		}
+		// Skip blanks, pip options (-r, -e, --index-url) and bare URLs
+		if line == "" || strings.HasPrefix(line, "-") || (strings.Contains(line, "://") && !strings.Contains(line, "@")) {
+			continue
+		}
+		if name := requirementName(line); name != "" {
+			deps = append(deps, newDeclaredDependency(name, path, optional))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, false
+	}
+	return deps, true
+}
+
+// requirementName returns the distribution name of a PEP 508 requirement
+func requirementName(spec string) string {
+	match := requirementNamePattern.FindStringSubmatch(spec)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+func newDeclaredDependency(name, source string, optional bool) domain.DeclaredDependency {
+	return domain.DeclaredDependency{
+		Name:        name,
+		ImportNames: distributionImportNames(name),
+		Source:      source,
+		Optional:    optional,
+	}
+}
+
+// normalizeDistributionName applies PEP 503 name normalization
+func normalizeDistributionName(name string) string {
+	return distributionSeparatorPattern.ReplaceAllString(strings.ToLower(name), "-")
+}
+
+// distributionImportNames guesses the top-level import names a distribution provides
+func distributionImportNames(name string) []string {
+	normalized := normalizeDistributionName(name)
+	if aliases, ok := distributionImportAliases[normalized]; ok {
+		return aliases
+	}
+	importName := strings.ReplaceAll(normalized, "-", "_")
+	// Namespace-style distributions such as "zope.interface" import as "zope"
+	if strings.Contains(name, ".") {
+		importName = strings.ToLower(strings.SplitN(name, ".", 2)[0])
+	}
+	return []string{importName}
+}
+
+// findSitePackages returns the site-packages directories of virtual
+// environments in root and of the active VIRTUAL_ENV.
+func findSitePackages(root string) []string {
+	var envs []string
+	if venv := os.Getenv("VIRTUAL_ENV"); venv != "" {
+		envs = append(envs, venv)
+	}
+	if root != "" {
+		for _, dir := range virtualenvDirs {
+			envs = append(envs, filepath.Join(root, dir))
+		}
+	}
+
+	var dirs []string
+	for _, env := range envs {
+		matches, _ := filepath.Glob(filepath.Join(env, "lib", "python*", "site-packages"))
+		dirs = append(dirs, matches...)
+		windows := filepath.Join(env, "Lib", "site-packages")
+		if isDir(windows) {
+			dirs = append(dirs, windows)
+		}
+	}
+	return dirs
+}
+
+// isInstalledPackage reports whether name is installed in any site-packages dir
+func isInstalledPackage(sitePackages []string, name string) bool {
+	for _, dir := range sitePackages {
+		if isDir(filepath.Join(dir, name)) {
+			return true
+		}
+		for _, pattern := range []string{name + ".py", name + ".*.so", name + ".*.pyd"} {
+			if matches, _ := filepath.Glob(filepath.Join(dir, pattern)); len(matches) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
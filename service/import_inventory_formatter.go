@@ -0,0 +1,93 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// WriteImportInventoryText writes the external import inventory including the
+// file locations of every import.
+func WriteImportInventoryText(writer io.Writer, inventory *domain.ImportInventory) {
+	if inventory == nil {
+		return
+	}
+
+	var builder strings.Builder
+	utils := NewFormatUtils()
+	writeImportInventorySection(&builder, inventory, utils, true)
+	builder.WriteString(utils.FormatSectionSeparator())
+
+	_, _ = io.WriteString(writer, builder.String())
+}
+
+// writeImportInventorySection lists imported packages by category followed by
+// the manifest cross-check. Locations are only listed when withLocations is set.
+func writeImportInventorySection(builder *strings.Builder, inventory *domain.ImportInventory, utils *FormatUtils, withLocations bool) {
+	builder.WriteString(utils.FormatSectionHeader("EXTERNAL IMPORTS"))
+	builder.WriteString(utils.FormatSummaryStats(map[string]interface{}{
+		"Third-Party": inventory.ThirdPartyCount,
+		"Stdlib":      inventory.StdlibCount,
+		"Unknown":     inventory.UnknownCount,
+	}))
+
+	indent := strings.Repeat(" ", SectionPadding)
+	category := domain.ExternalImportCategory("")
+	for _, pkg := range inventory.Packages {
+		if pkg.Category != category {
+			category = pkg.Category
+			builder.WriteString(fmt.Sprintf("\n%s:\n", externalCategoryLabel(category)))
+		}
+		name := pkg.Name
+		if pkg.Distribution != "" && !strings.EqualFold(pkg.Distribution, pkg.Name) {
+			name = fmt.Sprintf("%s (%s)", pkg.Name, pkg.Distribution)
+		}
+		builder.WriteString(fmt.Sprintf("%s%-30s %d import(s)\n", indent, name, pkg.UsageCount))
+		if withLocations {
+			for _, loc := range pkg.Locations {
+				suffix := ""
+				if loc.TypeCheckingOnly {
+					suffix = " [TYPE_CHECKING]"
+				}
+				builder.WriteString(fmt.Sprintf("%s%s%s:%d%s\n", indent, indent, loc.FilePath, loc.Line, suffix))
+			}
+		}
+	}
+
+	if len(inventory.ManifestFiles) == 0 {
+		builder.WriteString(fmt.Sprintf("\n%sNo pyproject.toml or requirements file found; declared dependencies were not checked\n", indent))
+		builder.WriteString("\n")
+		return
+	}
+
+	builder.WriteString("\n")
+	builder.WriteString(utils.FormatLabelWithIndent(SectionPadding, "Manifests", strings.Join(inventory.ManifestFiles, ", ")))
+	if len(inventory.Undeclared) > 0 {
+		builder.WriteString(utils.FormatLabelWithIndent(SectionPadding, "Undeclared", strings.Join(inventory.Undeclared, ", ")))
+	} else {
+		builder.WriteString(utils.FormatLabelWithIndent(SectionPadding, "Undeclared", "none"))
+	}
+	if len(inventory.UnusedDeclared) > 0 {
+		names := make([]string, 0, len(inventory.UnusedDeclared))
+		for _, dep := range inventory.UnusedDeclared {
+			names = append(names, dep.Name)
+		}
+		builder.WriteString(utils.FormatLabelWithIndent(SectionPadding, "Unused Declared", strings.Join(names, ", ")))
+	} else {
+		builder.WriteString(utils.FormatLabelWithIndent(SectionPadding, "Unused Declared", "none"))
+	}
+	builder.WriteString("\n")
+}
+
+func externalCategoryLabel(category domain.ExternalImportCategory) string {
+	switch category {
+	case domain.ExternalImportStdlib:
+		return "Standard Library"
+	case domain.ExternalImportThirdParty:
+		return "Third-Party"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/analyzer"
+)
+
+func TestParsePyprojectDependencies(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "pyproject.toml")
+	content := `[project]
+name = "demo"
+dependencies = ["requests>=2.0", "PyYAML[libyaml] ; python_version >= '3.8'"]
+
+[project.optional-dependencies]
+docs = ["sphinx"]
+
+[tool.poetry.dependencies]
+python = "^3.10"
+beautifulsoup4 = "*"
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	deps, ok := parsePyprojectDependencies(path)
+	require.True(t, ok)
+
+	byName := make(map[string]domain.DeclaredDependency)
+	for _, dep := range deps {
+		byName[dep.Name] = dep
+	}
+	require.Len(t, byName, 4)
+	assert.Equal(t, []string{"requests"}, byName["requests"].ImportNames)
+	assert.Equal(t, []string{"yaml"}, byName["PyYAML"].ImportNames)
+	assert.Equal(t, []string{"bs4"}, byName["beautifulsoup4"].ImportNames)
+	assert.True(t, byName["sphinx"].Optional)
+	assert.False(t, byName["requests"].Optional)
+}
+
+func TestParsePyprojectDependencies_NoDependencyTable(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "pyproject.toml")
+	require.NoError(t, os.WriteFile(path, []byte("[tool.pyscn]\nfoo = 1\n"), 0644))
+
+	_, ok := parsePyprojectDependencies(path)
+	assert.False(t, ok, "pyproject.toml without dependencies should not count as a manifest")
+}
+
+func TestParseRequirementsFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "requirements-dev.txt")
+	content := `# tooling
+-r requirements.txt
+--index-url https://example.com/simple
+pytest==8.0  # tests
+python_dateutil>=2.8
+mypkg @ https://example.com/mypkg.tar.gz
+https://example.com/other.tar.gz
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	deps, ok := parseRequirementsFile(path)
+	require.True(t, ok)
+	require.Len(t, deps, 3)
+	assert.Equal(t, "pytest", deps[0].Name)
+	assert.Equal(t, []string{"dateutil"}, deps[1].ImportNames)
+	assert.Equal(t, "mypkg", deps[2].Name)
+	for _, dep := range deps {
+		assert.True(t, dep.Optional, "dev requirements should be optional")
+	}
+}
+
+func TestBuildImportInventory(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "requirements.txt"), []byte("requests\nPyYAML\nunused-lib\n"), 0644))
+
+	graph := analyzer.NewDependencyGraph(dir)
+	graph.ExternalImports = []*analyzer.ExternalImport{
+		{Module: "requests", TopLevel: "requests", FromModule: "app.a", FilePath: "app/a.py", Line: 3},
+		{Module: "requests.adapters", TopLevel: "requests", FromModule: "app.b", FilePath: "app/b.py", Line: 1},
+		{Module: "yaml", TopLevel: "yaml", FromModule: "app.a", FilePath: "app/a.py", Line: 4},
+		{Module: "os.path", TopLevel: "os", FromModule: "app.a", FilePath: "app/a.py", Line: 1},
+		{Module: "numpy", TopLevel: "numpy", FromModule: "app.b", FilePath: "app/b.py", Line: 2, IsTypeChecking: true},
+	}
+
+	inventory := buildImportInventory(graph)
+	require.NotNil(t, inventory)
+
+	assert.Equal(t, 2, inventory.ThirdPartyCount)
+	assert.Equal(t, 1, inventory.StdlibCount)
+	assert.Equal(t, 1, inventory.UnknownCount)
+	assert.Equal(t, []string{"numpy"}, inventory.Undeclared)
+	require.Len(t, inventory.UnusedDeclared, 1)
+	assert.Equal(t, "unused-lib", inventory.UnusedDeclared[0].Name)
+
+	names := make([]string, 0, len(inventory.Packages))
+	for _, pkg := range inventory.Packages {
+		names = append(names, pkg.Name)
+	}
+	assert.Equal(t, []string{"requests", "yaml", "numpy", "os"}, names)
+
+	requests := inventory.Packages[0]
+	assert.Equal(t, domain.ExternalImportThirdParty, requests.Category)
+	assert.Equal(t, 2, requests.UsageCount)
+	assert.Equal(t, []string{"requests", "requests.adapters"}, requests.Modules)
+	assert.Equal(t, "PyYAML", inventory.Packages[1].Distribution)
+	assert.True(t, inventory.Packages[2].Locations[0].TypeCheckingOnly)
+
+	var out strings.Builder
+	WriteImportInventoryText(&out, inventory)
+	assert.Contains(t, out.String(), "EXTERNAL IMPORTS")
+	assert.Contains(t, out.String(), "yaml (PyYAML)")
+	assert.Contains(t, out.String(), "app/b.py:2 [TYPE_CHECKING]")
+	assert.Contains(t, out.String(), "Unused Declared: unused-lib")
+}
+
+func TestBuildImportInventory_NoManifest(t *testing.T) {
+	graph := analyzer.NewDependencyGraph(t.TempDir())
+	graph.ExternalImports = []*analyzer.ExternalImport{
+		{Module: "requests", TopLevel: "requests", FilePath: "a.py", Line: 1},
+	}
+
+	inventory := buildImportInventory(graph)
+	assert.Empty(t, inventory.ManifestFiles)
+	assert.Empty(t, inventory.Undeclared, "undeclared imports are only reported when a manifest exists")
+	assert.Equal(t, 1, inventory.UnknownCount)
+}
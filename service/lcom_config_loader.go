@@ -96,7 +96,7 @@ func (cl *LCOMConfigurationLoaderImpl) configToRequest(pyscnCfg *config.PyscnCon
 		MaxLCOM:         0,
 		SortBy:          domain.SortByCohesion,
 		Recursive:       pyscnCfg.AnalysisRecursive,
-		IncludePatterns: pyscnCfg.AnalysisIncludePatterns,
-		ExcludePatterns: pyscnCfg.AnalysisExcludePatterns,
+		IncludePatterns: pyscnCfg.AnalyzerIncludePatterns(domain.AnalysisScopeLCOM),
+		ExcludePatterns: pyscnCfg.AnalyzerExcludePatterns(domain.AnalysisScopeLCOM),
 	}
 }
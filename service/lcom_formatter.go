@@ -2,7 +2,6 @@ package service
 
 import (
 	"encoding/csv"
-	"encoding/json"
 	"fmt"
 	"io"
 	"sort"
@@ -10,7 +9,6 @@ import (
 	"strings"
 
 	"github.com/ludo-technologies/pyscn/domain"
-	"gopkg.in/yaml.v3"
 )
 
 // LCOMFormatterImpl implements LCOMOutputFormatter interface
@@ -166,20 +164,12 @@ func domainRiskToStandard(risk domain.RiskLevel) RiskLevel {
 
 // formatJSON formats the response as JSON
 func (f *LCOMFormatterImpl) formatJSON(response *domain.LCOMResponse) (string, error) {
-	data, err := json.MarshalIndent(response, "", "  ")
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal LCOM response to JSON: %w", err)
-	}
-	return string(data), nil
+	return EncodeJSON(response)
 }
 
 // formatYAML formats the response as YAML
 func (f *LCOMFormatterImpl) formatYAML(response *domain.LCOMResponse) (string, error) {
-	data, err := yaml.Marshal(response)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal LCOM response to YAML: %w", err)
-	}
-	return string(data), nil
+	return EncodeYAML(response)
 }
 
 // formatCSV formats the response as CSV
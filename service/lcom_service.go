@@ -90,7 +90,7 @@ func (s *LCOMServiceImpl) AnalyzeSnapshot(ctx context.Context, snapshot *Project
 	var errors []string
 	filesProcessed := 0
 
-	for _, file := range snapshot.Files {
+	for i, file := range snapshot.Files {
 		select {
 		case <-ctx.Done():
 			return nil, fmt.Errorf("LCOM analysis cancelled: %w", ctx.Err())
@@ -98,6 +98,7 @@ func (s *LCOMServiceImpl) AnalyzeSnapshot(ctx context.Context, snapshot *Project
 		}
 
 		classes, fileWarnings, fileErrors := s.analyzeProjectFile(file, req)
+		domain.ReportProgress(ctx, domain.ProgressStageLCOM, i+1, len(snapshot.Files))
 
 		if len(fileErrors) > 0 {
 			errors = append(errors, fileErrors...)
@@ -156,7 +157,7 @@ func (s *LCOMServiceImpl) analyzeFile(ctx context.Context, filePath string, req
 		return classes, warnings, errors
 	}
 
-	result, err := s.parser.Parse(ctx, content)
+	result, err := s.parser.ParseFor(ctx, filePath, content)
 	if err != nil {
 		errors = append(errors, fmt.Sprintf("[%s] Parse error: %v", filePath, err))
 		return classes, warnings, errors
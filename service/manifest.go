@@ -0,0 +1,122 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// BuildAnalysisManifest describes the inputs and environment of an
+// analysis. The pyscn version and flags are known only to the CLI and are
+// filled in by the caller.
+func BuildAnalysisManifest(paths []string, configPath string, fileCount int, summary domain.AnalyzeSummary) *domain.AnalysisManifest {
+	manifest := &domain.AnalysisManifest{
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+		Targets:   append([]string(nil), paths...),
+		FileCount: fileCount,
+		Analyzers: make(map[string]string),
+	}
+
+	if configPath != "" {
+		manifest.ConfigFile = configPath
+		if data, err := os.ReadFile(configPath); err == nil {
+			sum := sha256.Sum256(data)
+			manifest.ConfigHash = "sha256:" + hex.EncodeToString(sum[:])
+		}
+	}
+
+	if len(paths) > 0 {
+		manifest.Git = gitProvenance(paths[0])
+	}
+
+	for _, name := range summary.EnabledAnalyzers() {
+		manifest.Analyzers[name] = domain.AnalyzerVersions[name]
+	}
+	return manifest
+}
+
+// gitProvenance returns the commit of the work tree containing path, or nil
+// when path is not inside one or git is unavailable
+func gitProvenance(path string) *domain.GitProvenance {
+	dir := path
+	if info, err := os.Stat(path); err == nil && !info.IsDir() {
+		dir = filepath.Dir(path)
+	}
+
+	git := func(args ...string) (string, bool) {
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).Output()
+		return strings.TrimSpace(string(out)), err == nil
+	}
+
+	commit, ok := git("rev-parse", "HEAD")
+	if !ok || commit == "" {
+		return nil
+	}
+	provenance := &domain.GitProvenance{Commit: commit}
+	if branch, ok := git("rev-parse", "--abbrev-ref", "HEAD"); ok && branch != "HEAD" {
+		provenance.Branch = branch
+	}
+	if status, ok := git("status", "--porcelain", "--untracked-files=no"); ok {
+		provenance.Dirty = status != ""
+	}
+	return provenance
+}
+
+// ReportThresholds returns the risk thresholds the analyzers of response
+// ran with, read from the effective configuration each one reports
+func ReportThresholds(response *domain.AnalyzeResponse) *domain.RiskThresholds {
+	thresholds := &domain.RiskThresholds{}
+	if response.Complexity != nil {
+		if config, ok := response.Complexity.Config.(map[string]interface{}); ok {
+			thresholds.Complexity = &domain.ComplexityThresholds{
+				Low:          configInt(config, "low_threshold"),
+				Medium:       configInt(config, "medium_threshold"),
+				Cognitive:    configInt(config, "cognitive_complexity_threshold"),
+				NestingDepth: configInt(config, "nesting_depth_threshold"),
+			}
+		}
+	}
+	if response.CBO != nil {
+		if config, ok := response.CBO.Config.(map[string]interface{}); ok {
+			thresholds.CBO = &domain.LevelThresholds{
+				Low:    configInt(config, "lowThreshold"),
+				Medium: configInt(config, "mediumThreshold"),
+			}
+		}
+	}
+	if response.LCOM != nil {
+		if config, ok := response.LCOM.Config.(map[string]interface{}); ok {
+			thresholds.LCOM = &domain.LevelThresholds{
+				Low:    configInt(config, "lowThreshold"),
+				Medium: configInt(config, "mediumThreshold"),
+			}
+		}
+	}
+	if response.Clone != nil && response.Clone.Request != nil {
+		req := response.Clone.Request
+		thresholds.Clones = &domain.CloneThresholds{
+			Similarity: req.SimilarityThreshold,
+			Type1:      req.Type1Threshold,
+			Type2:      req.Type2Threshold,
+			Type3:      req.Type3Threshold,
+			Type4:      req.Type4Threshold,
+		}
+	}
+	if *thresholds == (domain.RiskThresholds{}) {
+		return nil
+	}
+	return thresholds
+}
+
+// configInt returns an integer entry of a response configuration map
+func configInt(config map[string]interface{}, key string) int {
+	value, _ := config[key].(int)
+	return value
+}
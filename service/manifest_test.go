@@ -0,0 +1,91 @@
+package service
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildAnalysisManifest(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, ".pyscn.toml")
+	require.NoError(t, os.WriteFile(configPath, []byte("test"), 0o644))
+
+	summary := domain.AnalyzeSummary{ComplexityEnabled: true, CloneEnabled: true}
+	manifest := BuildAnalysisManifest([]string{dir}, configPath, 12, summary)
+
+	assert.Equal(t, runtime.Version(), manifest.GoVersion)
+	assert.Equal(t, runtime.GOOS+"/"+runtime.GOARCH, manifest.Platform)
+	assert.Equal(t, []string{dir}, manifest.Targets)
+	assert.Equal(t, 12, manifest.FileCount)
+	assert.Equal(t, configPath, manifest.ConfigFile)
+	// sha256("test")
+	assert.Equal(t, "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", manifest.ConfigHash)
+	assert.Equal(t, map[string]string{"complexity": "1", "clones": "1"}, manifest.Analyzers)
+	assert.Nil(t, manifest.Git, "temp dir is not a git work tree")
+}
+
+func TestBuildAnalysisManifest_WithoutConfig(t *testing.T) {
+	manifest := BuildAnalysisManifest([]string{t.TempDir()}, "", 0, domain.AnalyzeSummary{})
+	assert.Empty(t, manifest.ConfigFile)
+	assert.Empty(t, manifest.ConfigHash)
+	assert.Empty(t, manifest.Analyzers)
+}
+
+func TestReportThresholds(t *testing.T) {
+	// The configurations come from the services, so a renamed key fails here
+	response := &domain.AnalyzeResponse{
+		Complexity: &domain.ComplexityResponse{Config: (&ComplexityServiceImpl{}).buildConfigForResponse(domain.ComplexityRequest{
+			LowThreshold: 8, MediumThreshold: 16, CognitiveComplexityThreshold: 12, NestingDepthThreshold: 3,
+		})},
+		CBO:  &domain.CBOResponse{Config: (&CBOServiceImpl{}).buildConfigForResponse(domain.CBORequest{LowThreshold: 4, MediumThreshold: 9})},
+		LCOM: &domain.LCOMResponse{Config: (&LCOMServiceImpl{}).buildConfigForResponse(domain.LCOMRequest{LowThreshold: 2, MediumThreshold: 5})},
+		Clone: &domain.CloneResponse{Request: &domain.CloneRequest{
+			SimilarityThreshold: 0.7, Type1Threshold: 0.95, Type2Threshold: 0.85, Type3Threshold: 0.8, Type4Threshold: 0.75,
+		}},
+	}
+
+	assert.Equal(t, &domain.RiskThresholds{
+		Complexity: &domain.ComplexityThresholds{Low: 8, Medium: 16, Cognitive: 12, NestingDepth: 3},
+		CBO:        &domain.LevelThresholds{Low: 4, Medium: 9},
+		LCOM:       &domain.LevelThresholds{Low: 2, Medium: 5},
+		Clones:     &domain.CloneThresholds{Similarity: 0.7, Type1: 0.95, Type2: 0.85, Type3: 0.8, Type4: 0.75},
+	}, ReportThresholds(response))
+
+	assert.Nil(t, ReportThresholds(&domain.AnalyzeResponse{}), "no analyzer ran")
+}
+
+func TestGitProvenance(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	git := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return string(out)
+	}
+	git("init", "--quiet", "--initial-branch=main")
+	file := filepath.Join(dir, "app.py")
+	require.NoError(t, os.WriteFile(file, []byte("x = 1\n"), 0o644))
+	git("add", "app.py")
+	git("commit", "--quiet", "-m", "initial")
+	head := git("rev-parse", "HEAD")
+
+	provenance := gitProvenance(file)
+	require.NotNil(t, provenance)
+	assert.Equal(t, head[:40], provenance.Commit)
+	assert.Equal(t, "main", provenance.Branch)
+	assert.False(t, provenance.Dirty)
+
+	require.NoError(t, os.WriteFile(file, []byte("x = 2\n"), 0o644))
+	assert.True(t, gitProvenance(dir).Dirty)
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// DefaultMetricsJob is the job label of metrics pushed to a Pushgateway
+// when [output] metrics_job is not set
+const DefaultMetricsJob = "pyscn"
+
+// metricsPushTimeout bounds a push so an unreachable Pushgateway cannot
+// stall the run
+const metricsPushTimeout = 30 * time.Second
+
+// metricSample is one line of the exposition: a value with optional labels
+type metricSample struct {
+	labels [][2]string
+	value  float64
+}
+
+// metricFamily is a gauge with its help text and samples
+type metricFamily struct {
+	name    string
+	help    string
+	samples []metricSample
+}
+
+// analysisMetrics collects the key metrics of an analyze run. Scores and
+// finding counts are only included for the analyses that ran, so dashboards
+// show gaps rather than zeros for skipped ones.
+func analysisMetrics(response *domain.AnalyzeResponse) []metricFamily {
+	s := response.Summary
+	var architectureViolations int
+	if response.System != nil && response.System.ArchitectureAnalysis != nil {
+		architectureViolations = response.System.ArchitectureAnalysis.TotalViolations
+	}
+	type categoryMetric struct {
+		name     string
+		enabled  bool
+		score    int
+		findings int
+	}
+	categories := []categoryMetric{
+		{"complexity", s.ComplexityEnabled, s.ComplexityScore, s.HighComplexityCount},
+		{"dead_code", s.DeadCodeEnabled, s.DeadCodeScore, s.DeadCodeCount},
+		{"duplication", s.CloneEnabled, s.DuplicationScore, s.CloneGroups},
+		{"coupling", s.CBOEnabled, s.CouplingScore, s.HighCouplingClasses},
+		{"cohesion", s.LCOMEnabled, s.CohesionScore, s.HighLCOMClasses},
+		{"dependencies", s.DepsEnabled, s.DependencyScore, s.DepsModulesInCycles},
+		{"architecture", s.ArchEnabled, s.ArchitectureScore, architectureViolations},
+	}
+	if s.DocumentationScore != nil && s.UndocumentedItems != nil {
+		categories = append(categories, categoryMetric{"documentation", true, *s.DocumentationScore, *s.UndocumentedItems})
+	}
+	if s.TypednessScore != nil && s.UnannotatedPublicFunctions != nil {
+		categories = append(categories, categoryMetric{"typedness", true, *s.TypednessScore, *s.UnannotatedPublicFunctions})
+	}
+
+	scores := metricFamily{name: "pyscn_category_score", help: "Score of each analyzed category (0-100)."}
+	findings := metricFamily{name: "pyscn_findings", help: "Findings per category: high-risk functions and classes, dead code, clone groups, modules in cycles, violations, undocumented and unannotated items."}
+	for _, category := range categories {
+		if !category.enabled {
+			continue
+		}
+		labels := [][2]string{{"category", category.name}}
+		scores.samples = append(scores.samples, metricSample{labels: labels, value: float64(category.score)})
+		findings.samples = append(findings.samples, metricSample{labels: labels, value: float64(category.findings)})
+	}
+
+	families := []metricFamily{
+		{name: "pyscn_health_score", help: "Overall health score (0-100).",
+			samples: []metricSample{{value: float64(s.HealthScore)}}},
+		scores,
+		findings,
+		{name: "pyscn_files", help: "Python files found, by outcome.",
+			samples: []metricSample{
+				{labels: [][2]string{{"state", "analyzed"}}, value: float64(s.AnalyzedFiles)},
+				{labels: [][2]string{{"state", "skipped"}}, value: float64(s.SkippedFiles)},
+			}},
+		{name: "pyscn_analysis_duration_seconds", help: "Wall-clock time of the analysis.",
+			samples: []metricSample{{value: float64(response.Duration) / 1000}}},
+	}
+	if !response.GeneratedAt.IsZero() {
+		families = append(families, metricFamily{
+			name: "pyscn_analysis_timestamp_seconds", help: "Time the analysis finished, in seconds since the epoch.",
+			samples: []metricSample{{value: float64(response.GeneratedAt.Unix())}},
+		})
+	}
+	return families
+}
+
+// writeMetrics writes the metrics in the text exposition format. OpenMetrics
+// adds a terminating # EOF line, which the Pushgateway does not accept.
+func writeMetrics(w io.Writer, families []metricFamily, openMetrics bool) error {
+	var buf bytes.Buffer
+	for _, family := range families {
+		if len(family.samples) == 0 {
+			continue
+		}
+		fmt.Fprintf(&buf, "# HELP %s %s\n", family.name, family.help)
+		fmt.Fprintf(&buf, "# TYPE %s gauge\n", family.name)
+		for _, sample := range family.samples {
+			buf.WriteString(family.name)
+			if len(sample.labels) > 0 {
+				pairs := make([]string, len(sample.labels))
+				for i, label := range sample.labels {
+					pairs[i] = label[0] + "=" + strconv.Quote(label[1])
+				}
+				buf.WriteString("{" + strings.Join(pairs, ",") + "}")
+			}
+			buf.WriteString(" " + strconv.FormatFloat(sample.value, 'g', -1, 64) + "\n")
+		}
+	}
+	if openMetrics {
+		buf.WriteString("# EOF\n")
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// WriteOpenMetrics writes the key metrics of an analyze run (health score,
+// scores and findings per category, file counts, duration) in OpenMetrics
+// text format
+func WriteOpenMetrics(w io.Writer, response *domain.AnalyzeResponse) error {
+	return writeMetrics(w, analysisMetrics(response), true)
+}
+
+// WriteOpenMetricsFile writes the metrics to path, replacing it atomically
+// so a scraper never reads a partial file
+func WriteOpenMetricsFile(path string, response *domain.AnalyzeResponse) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create metrics directory: %w", err)
+		}
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), ".pyscn-metrics-*")
+	if err != nil {
+		return fmt.Errorf("failed to create metrics file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	if err := WriteOpenMetrics(file, response); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	if err := os.Chmod(file.Name(), 0o644); err != nil {
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	return nil
+}
+
+// PushMetrics replaces the metrics of job on the Prometheus Pushgateway at
+// gatewayURL with those of the analyze run
+func PushMetrics(ctx context.Context, gatewayURL, job string, response *domain.AnalyzeResponse) error {
+	if job == "" {
+		job = DefaultMetricsJob
+	}
+	base, err := url.Parse(gatewayURL)
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return fmt.Errorf("invalid metrics_pushgateway URL %q: expected http(s)://host[:port]", gatewayURL)
+	}
+	endpoint := strings.TrimSuffix(base.String(), "/") + "/metrics/job/" + url.PathEscape(job)
+
+	var body bytes.Buffer
+	if err := writeMetrics(&body, analysisMetrics(response), false); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, metricsPushTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, &body)
+	if err != nil {
+		return fmt.Errorf("failed to push metrics: %w", err)
+	}
+	request.Header.Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	resp, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to push metrics: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("failed to push metrics: pushgateway returned %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+func metricsTestResponse() *domain.AnalyzeResponse {
+	return &domain.AnalyzeResponse{
+		GeneratedAt: time.Unix(1700000000, 0),
+		Duration:    1500,
+		Summary: domain.AnalyzeSummary{
+			AnalyzedFiles:       12,
+			SkippedFiles:        1,
+			HealthScore:         82,
+			ComplexityEnabled:   true,
+			ComplexityScore:     75,
+			HighComplexityCount: 3,
+			DeadCodeEnabled:     true,
+			DeadCodeScore:       100,
+		},
+	}
+}
+
+func TestWriteOpenMetrics(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteOpenMetrics(&buf, metricsTestResponse()); err != nil {
+		t.Fatalf("WriteOpenMetrics failed: %v", err)
+	}
+	output := buf.String()
+
+	for _, line := range []string{
+		"# TYPE pyscn_health_score gauge\npyscn_health_score 82\n",
+		`pyscn_category_score{category="complexity"} 75`,
+		`pyscn_findings{category="complexity"} 3`,
+		`pyscn_findings{category="dead_code"} 0`,
+		`pyscn_files{state="analyzed"} 12`,
+		"pyscn_analysis_duration_seconds 1.5\n",
+		"pyscn_analysis_timestamp_seconds 1.7e+09\n",
+	} {
+		if !strings.Contains(output, line) {
+			t.Errorf("Expected %q in:\n%s", line, output)
+		}
+	}
+	if strings.Contains(output, `category="duplication"`) {
+		t.Errorf("Expected no metrics for analyses that did not run:\n%s", output)
+	}
+	if !strings.HasSuffix(output, "# EOF\n") {
+		t.Errorf("Expected the OpenMetrics terminator:\n%s", output)
+	}
+}
+
+func TestWriteOpenMetricsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics", "pyscn.prom")
+	if err := WriteOpenMetricsFile(path, metricsTestResponse()); err != nil {
+		t.Fatalf("WriteOpenMetricsFile failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(data), "pyscn_health_score 82") {
+		t.Fatalf("Expected the metrics file, got %v: %s", err, data)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("Expected no temporary files left behind, got %v", entries)
+	}
+}
+
+func TestPushMetrics(t *testing.T) {
+	var method, path, contentType, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path, contentType = r.Method, r.URL.EscapedPath(), r.Header.Get("Content-Type")
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+	}))
+	defer server.Close()
+
+	if err := PushMetrics(context.Background(), server.URL+"/", "my project", metricsTestResponse()); err != nil {
+		t.Fatalf("PushMetrics failed: %v", err)
+	}
+	if method != http.MethodPut || path != "/metrics/job/my%20project" {
+		t.Errorf("Expected PUT /metrics/job/my%%20project, got %s %s", method, path)
+	}
+	if !strings.HasPrefix(contentType, "text/plain; version=0.0.4") {
+		t.Errorf("Expected the Prometheus text format, got %q", contentType)
+	}
+	if !strings.Contains(body, "pyscn_health_score 82") || strings.Contains(body, "# EOF") {
+		t.Errorf("Expected metrics without the OpenMetrics terminator, got:\n%s", body)
+	}
+
+	if err := PushMetrics(context.Background(), "localhost:9091", "", metricsTestResponse()); err == nil {
+		t.Error("Expected a URL without scheme to be rejected")
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad metric", http.StatusBadRequest)
+	}))
+	defer failing.Close()
+	if err := PushMetrics(context.Background(), failing.URL, "", metricsTestResponse()); err == nil || !strings.Contains(err.Error(), "bad metric") {
+		t.Errorf("Expected the pushgateway error to be reported, got %v", err)
+	}
+}
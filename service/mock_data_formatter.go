@@ -133,10 +133,7 @@ func (f *MockDataFormatterImpl) formatFindingText(finding domain.MockDataFinding
 	coloredSeverity := utils.FormatRiskWithColor(standardRisk)
 
 	// Truncate long values
-	value := finding.Value
-	if len(value) > 50 {
-		value = value[:47] + "..."
-	}
+	value := truncateMockValue(finding.Value)
 
 	return fmt.Sprintf("    [%s] Line %d: %s - %s (%s)",
 		coloredSeverity,
@@ -305,10 +302,7 @@ func (f *MockDataFormatterImpl) formatHTML(response *domain.MockDataResponse) (s
 					severityClass = "severity-warning"
 				}
 
-				value := finding.Value
-				if len(value) > 50 {
-					value = value[:47] + "..."
-				}
+				value := truncateMockValue(finding.Value)
 
 				output.WriteString(fmt.Sprintf(`
                 <tr>
@@ -346,3 +340,13 @@ func (f *MockDataFormatterImpl) formatHTML(response *domain.MockDataResponse) (s
 
 	return output.String(), nil
 }
+
+// truncateMockValue shortens a value to 50 characters. It cuts between code
+// points, so that multi-byte characters are never split.
+func truncateMockValue(value string) string {
+	runes := []rune(value)
+	if len(runes) <= 50 {
+		return value
+	}
+	return string(runes[:47]) + "..."
+}
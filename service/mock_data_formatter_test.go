@@ -1,7 +1,9 @@
 package service
 
 import (
+	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/ludo-technologies/pyscn/domain"
 	"github.com/stretchr/testify/assert"
@@ -38,3 +40,14 @@ func TestMockDataFormatter_FormatHTML_EscapesFindingStrings(t *testing.T) {
 	assert.Contains(t, output, `&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;`)
 	assert.Contains(t, output, `bad &lt;value&gt;`)
 }
+
+func TestTruncateMockValueKeepsUTF8Valid(t *testing.T) {
+	value := strings.Repeat("テスト", 20)
+
+	truncated := truncateMockValue(value)
+	assert.True(t, utf8.ValidString(truncated))
+	assert.Equal(t, 50, utf8.RuneCountInString(truncated))
+	assert.True(t, strings.HasSuffix(truncated, "..."))
+
+	assert.Equal(t, "short", truncateMockValue("short"))
+}
@@ -133,6 +133,12 @@ func (f *OutputFormatterImpl) formatText(response *domain.ComplexityResponse) (s
 				coloredRisk))
 		}
 		builder.WriteString(utils.FormatSectionSeparator())
+
+		f.writeComplexityBreakdownSection(&builder, response.Functions, utils)
+		f.writeAsyncFunctionsSection(&builder, response.Functions, utils)
+		f.writeRecursiveFunctionsSection(&builder, response.Functions, utils)
+		f.writeComplexityBudgetsSection(&builder, response.Functions, utils)
+		f.writeBoilerplateSection(&builder, response.Functions, utils)
 	}
 
 	// Warnings
@@ -158,6 +164,137 @@ func (f *OutputFormatterImpl) formatText(response *domain.ComplexityResponse) (s
 	return builder.String(), nil
 }
 
+// writeComplexityBreakdownSection lists what makes each function complex: its
+// deepest nesting and how many of each branching construct it contains
+func (f *OutputFormatterImpl) writeComplexityBreakdownSection(builder *strings.Builder, functions []domain.FunctionComplexity, utils *FormatUtils) {
+	builder.WriteString(utils.FormatSectionHeader("COMPLEXITY BREAKDOWN"))
+	builder.WriteString(utils.FormatTableHeader("Function", "Nesting", "If/elif", "Loops", "Except", "Cases", "Bool ops", "Comps"))
+	for _, function := range functions {
+		builder.WriteString(fmt.Sprintf("%-30s %10d %10d %10d %10d %10d %10d %10d\n",
+			function.Name,
+			function.Metrics.NestingDepth,
+			function.Metrics.IfStatements,
+			function.Metrics.LoopStatements,
+			function.Metrics.ExceptionHandlers,
+			function.Metrics.SwitchCases,
+			function.Metrics.BooleanOperators,
+			function.Metrics.Comprehensions))
+	}
+	builder.WriteString(utils.FormatSectionSeparator())
+}
+
+// writeAsyncFunctionsSection lists suspension points and fan-out for coroutines
+func (f *OutputFormatterImpl) writeAsyncFunctionsSection(builder *strings.Builder, functions []domain.FunctionComplexity, utils *FormatUtils) {
+	var coroutines []domain.FunctionComplexity
+	for _, function := range functions {
+		if function.Metrics.IsAsync {
+			coroutines = append(coroutines, function)
+		}
+	}
+	if len(coroutines) == 0 {
+		return
+	}
+
+	builder.WriteString(utils.FormatSectionHeader("ASYNC COROUTINES"))
+	builder.WriteString(utils.FormatTableHeader("Coroutine", "Async", "Awaits", "Fan-out", "Density"))
+	for _, function := range coroutines {
+		builder.WriteString(fmt.Sprintf("%-30s %10d %10d %10d %10.2f\n",
+			function.Name,
+			function.Metrics.AsyncComplexity,
+			function.Metrics.AwaitPoints,
+			function.Metrics.AsyncFanOut,
+			function.Metrics.AwaitDensity))
+	}
+	builder.WriteString(utils.FormatSectionSeparator())
+}
+
+// writeRecursiveFunctionsSection lists functions on a call cycle
+func (f *OutputFormatterImpl) writeRecursiveFunctionsSection(builder *strings.Builder, functions []domain.FunctionComplexity, utils *FormatUtils) {
+	var recursive []domain.FunctionComplexity
+	for _, function := range functions {
+		if function.Metrics.IsRecursive {
+			recursive = append(recursive, function)
+		}
+	}
+	if len(recursive) == 0 {
+		return
+	}
+
+	builder.WriteString(utils.FormatSectionHeader("RECURSIVE FUNCTIONS"))
+	builder.WriteString(utils.FormatTableHeader("Function", "Kind", "Depth", "Calls", "Base case"))
+	for _, function := range recursive {
+		baseCase := "yes"
+		if function.Metrics.UnboundedRecursion {
+			baseCase = "none"
+		}
+		builder.WriteString(fmt.Sprintf("%-30s %10s %10d %10d %10s\n",
+			function.Name,
+			function.Metrics.RecursionKind,
+			function.Metrics.RecursionDepth,
+			function.Metrics.RecursiveCalls,
+			baseCase))
+	}
+	builder.WriteString(utils.FormatSectionSeparator())
+}
+
+// writeComplexityBudgetsSection lists the functions carrying a
+// "# pyscn: max-complexity=N" budget and how much of it they use
+func (f *OutputFormatterImpl) writeComplexityBudgetsSection(builder *strings.Builder, functions []domain.FunctionComplexity, utils *FormatUtils) {
+	var budgeted []domain.FunctionComplexity
+	for _, function := range functions {
+		if function.ComplexityBudget > 0 {
+			budgeted = append(budgeted, function)
+		}
+	}
+	if len(budgeted) == 0 {
+		return
+	}
+
+	builder.WriteString(utils.FormatSectionHeader("COMPLEXITY BUDGETS"))
+	builder.WriteString(utils.FormatTableHeader("Function", "Complexity", "Budget", "Status"))
+	for _, function := range budgeted {
+		status := "within"
+		if function.OverBudget {
+			status = "over"
+		}
+		builder.WriteString(fmt.Sprintf("%-30s %10d %10d %10s\n",
+			function.Name,
+			function.Metrics.Complexity,
+			function.ComplexityBudget,
+			status))
+	}
+	builder.WriteString(utils.FormatSectionSeparator())
+}
+
+// writeBoilerplateSection lists the functions matching a boilerplate
+// pattern and whether it excludes them from risk rating
+func (f *OutputFormatterImpl) writeBoilerplateSection(builder *strings.Builder, functions []domain.FunctionComplexity, utils *FormatUtils) {
+	var boilerplate []domain.FunctionComplexity
+	for _, function := range functions {
+		if function.Boilerplate != "" {
+			boilerplate = append(boilerplate, function)
+		}
+	}
+	if len(boilerplate) == 0 {
+		return
+	}
+
+	builder.WriteString(utils.FormatSectionHeader("BOILERPLATE"))
+	builder.WriteString(utils.FormatTableHeader("Function", "Complexity", "Pattern", "Risk"))
+	for _, function := range boilerplate {
+		risk := string(function.RiskLevel)
+		if function.ExcludedFromRisk {
+			risk = "excluded"
+		}
+		builder.WriteString(fmt.Sprintf("%-30s %10d %-20s %10s\n",
+			function.Name,
+			function.Metrics.Complexity,
+			function.Boilerplate,
+			risk))
+	}
+	builder.WriteString(utils.FormatSectionSeparator())
+}
+
 // formatJSON formats the response as JSON
 func (f *OutputFormatterImpl) formatJSON(response *domain.ComplexityResponse) (string, error) {
 	// Create a JSON-friendly structure
@@ -178,7 +315,7 @@ func (f *OutputFormatterImpl) formatCSV(response *domain.ComplexityResponse) (st
 	writer := csv.NewWriter(&builder)
 
 	// Write header
-	header := []string{"Function", "Complexity", "Cognitive Complexity", "Risk", "Nodes", "Edges", "Nesting Depth", "If Statements", "Loop Statements", "Exception Handlers"}
+	header := []string{"Function", "Complexity", "Cognitive Complexity", "Risk", "Nodes", "Edges", "Nesting Depth", "If Statements", "Loop Statements", "Exception Handlers", "Match Cases", "Boolean Operators", "Comprehensions"}
 	if err := writer.Write(header); err != nil {
 		return "", domain.NewOutputError("failed to write CSV header", err)
 	}
@@ -196,6 +333,9 @@ func (f *OutputFormatterImpl) formatCSV(response *domain.ComplexityResponse) (st
 			fmt.Sprintf("%d", function.Metrics.IfStatements),
 			fmt.Sprintf("%d", function.Metrics.LoopStatements),
 			fmt.Sprintf("%d", function.Metrics.ExceptionHandlers),
+			fmt.Sprintf("%d", function.Metrics.SwitchCases),
+			fmt.Sprintf("%d", function.Metrics.BooleanOperators),
+			fmt.Sprintf("%d", function.Metrics.Comprehensions),
 		}
 		if err := writer.Write(row); err != nil {
 			return "", domain.NewOutputError("failed to write CSV row", err)
@@ -228,6 +368,32 @@ func (f *OutputFormatterImpl) createJSONResponse(response *domain.ComplexityResp
 			"loop_statements":      function.Metrics.LoopStatements,
 			"exception_handlers":   function.Metrics.ExceptionHandlers,
 			"switch_cases":         function.Metrics.SwitchCases,
+			"boolean_operators":    function.Metrics.BooleanOperators,
+			"comprehensions":       function.Metrics.Comprehensions,
+		}
+		if function.ComplexityBudget > 0 {
+			functions[i]["complexity_budget"] = function.ComplexityBudget
+			functions[i]["over_budget"] = function.OverBudget
+		}
+		if function.Boilerplate != "" {
+			functions[i]["boilerplate"] = function.Boilerplate
+			functions[i]["excluded_from_risk"] = function.ExcludedFromRisk
+		}
+		if function.Metrics.IsAsync {
+			functions[i]["async"] = map[string]interface{}{
+				"await_points":     function.Metrics.AwaitPoints,
+				"fan_out":          function.Metrics.AsyncFanOut,
+				"async_complexity": function.Metrics.AsyncComplexity,
+				"await_density":    function.Metrics.AwaitDensity,
+			}
+		}
+		if function.Metrics.IsRecursive {
+			functions[i]["recursion"] = map[string]interface{}{
+				"kind":            function.Metrics.RecursionKind,
+				"depth":           function.Metrics.RecursionDepth,
+				"recursive_calls": function.Metrics.RecursiveCalls,
+				"unbounded":       function.Metrics.UnboundedRecursion,
+			}
 		}
 	}
 
@@ -248,6 +414,15 @@ func (f *OutputFormatterImpl) createJSONResponse(response *domain.ComplexityResp
 		"complexity_distribution": response.Summary.ComplexityDistribution,
 	}
 
+	if response.Summary.BudgetedFunctions > 0 {
+		summary["budgeted_functions"] = response.Summary.BudgetedFunctions
+		summary["over_budget_functions"] = response.Summary.OverBudgetFunctions
+	}
+	if response.Summary.BoilerplateFunctions > 0 {
+		summary["boilerplate_functions"] = response.Summary.BoilerplateFunctions
+		summary["excluded_from_risk_functions"] = response.Summary.ExcludedFromRiskFunctions
+	}
+
 	if response.Summary.TotalFunctions > 0 {
 		summary["average_complexity"] = response.Summary.AverageComplexity
 		summary["max_complexity"] = response.Summary.MaxComplexity
@@ -343,6 +518,12 @@ func (f *OutputFormatterImpl) formatSummaryText(response *domain.ComplexityRespo
 	builder.WriteString(fmt.Sprintf("  High: %d\n", response.Summary.HighRiskFunctions))
 	builder.WriteString(fmt.Sprintf("  Medium: %d\n", response.Summary.MediumRiskFunctions))
 	builder.WriteString(fmt.Sprintf("  Low: %d\n", response.Summary.LowRiskFunctions))
+	if response.Summary.BudgetedFunctions > 0 {
+		builder.WriteString(fmt.Sprintf("\nComplexity Budgets: %d (%d over budget)\n", response.Summary.BudgetedFunctions, response.Summary.OverBudgetFunctions))
+	}
+	if response.Summary.BoilerplateFunctions > 0 {
+		builder.WriteString(fmt.Sprintf("Boilerplate: %d (%d excluded from risk)\n", response.Summary.BoilerplateFunctions, response.Summary.ExcludedFromRiskFunctions))
+	}
 
 	if len(response.Summary.ComplexityDistribution) > 0 {
 		builder.WriteString("\nComplexity Distribution:\n")
@@ -180,7 +180,7 @@ func TestOutputFormatter_Format(t *testing.T) {
 				assert.Len(t, records, 3, "Should have header plus 2 function rows")
 
 				// Check header
-				expectedHeaders := []string{"Function", "Complexity", "Cognitive Complexity", "Risk", "Nodes", "Edges", "Nesting Depth", "If Statements", "Loop Statements", "Exception Handlers"}
+				expectedHeaders := []string{"Function", "Complexity", "Cognitive Complexity", "Risk", "Nodes", "Edges", "Nesting Depth", "If Statements", "Loop Statements", "Exception Handlers", "Match Cases", "Boolean Operators", "Comprehensions"}
 				assert.Equal(t, expectedHeaders, records[0])
 
 				// Check first data row
@@ -579,6 +579,79 @@ func TestOutputFormatter_formatJSON(t *testing.T) {
 	assert.Len(t, rawMetrics, 1)
 }
 
+func TestOutputFormatter_AsyncMetrics(t *testing.T) {
+	formatter := NewOutputFormatter()
+	response := createTestComplexityResponse()
+	response.Functions[1].Name = "fetch_all"
+	response.Functions[1].Metrics.IsAsync = true
+	response.Functions[1].Metrics.AwaitPoints = 3
+	response.Functions[1].Metrics.AsyncFanOut = 4
+	response.Functions[1].Metrics.AsyncComplexity = 7
+	response.Functions[1].Metrics.AwaitDensity = 0.25
+
+	output, err := formatter.formatJSON(response)
+	require.NoError(t, err)
+	var parsed map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(output), &parsed))
+	functions := parsed["results"].([]interface{})
+	assert.NotContains(t, functions[0].(map[string]interface{}), "async")
+	async := functions[1].(map[string]interface{})["async"].(map[string]interface{})
+	assert.Equal(t, float64(7), async["async_complexity"])
+	assert.Equal(t, float64(4), async["fan_out"])
+
+	text, err := formatter.formatText(response)
+	require.NoError(t, err)
+	assert.Contains(t, text, "ASYNC COROUTINES")
+	assert.Contains(t, text, "fetch_all")
+}
+
+func TestOutputFormatter_RecursionMetrics(t *testing.T) {
+	formatter := NewOutputFormatter()
+	response := createTestComplexityResponse()
+	response.Functions[0].Name = "walk_tree"
+	response.Functions[0].Metrics.IsRecursive = true
+	response.Functions[0].Metrics.RecursionKind = "mutual"
+	response.Functions[0].Metrics.RecursionDepth = 2
+	response.Functions[0].Metrics.RecursiveCalls = 3
+	response.Functions[0].Metrics.UnboundedRecursion = true
+
+	output, err := formatter.formatJSON(response)
+	require.NoError(t, err)
+	var parsed map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(output), &parsed))
+	functions := parsed["results"].([]interface{})
+	assert.NotContains(t, functions[1].(map[string]interface{}), "recursion")
+	recursion := functions[0].(map[string]interface{})["recursion"].(map[string]interface{})
+	assert.Equal(t, "mutual", recursion["kind"])
+	assert.Equal(t, float64(2), recursion["depth"])
+	assert.Equal(t, true, recursion["unbounded"])
+
+	text, err := formatter.formatText(response)
+	require.NoError(t, err)
+	assert.Contains(t, text, "RECURSIVE FUNCTIONS")
+	assert.Contains(t, text, "walk_tree")
+}
+
+func TestOutputFormatter_ComplexityBreakdown(t *testing.T) {
+	formatter := NewOutputFormatter()
+	response := createTestComplexityResponse()
+	response.Functions[0].Metrics.BooleanOperators = 4
+	response.Functions[0].Metrics.Comprehensions = 2
+
+	output, err := formatter.formatJSON(response)
+	require.NoError(t, err)
+	var parsed map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(output), &parsed))
+	function := parsed["results"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, float64(4), function["boolean_operators"])
+	assert.Equal(t, float64(2), function["comprehensions"])
+
+	text, err := formatter.formatText(response)
+	require.NoError(t, err)
+	assert.Contains(t, text, "COMPLEXITY BREAKDOWN")
+	assert.Contains(t, text, "Bool ops")
+}
+
 // TestOutputFormatter_formatYAML tests YAML formatting details
 func TestOutputFormatter_formatYAML(t *testing.T) {
 	formatter := NewOutputFormatter()
@@ -619,12 +692,12 @@ func TestOutputFormatter_formatCSV(t *testing.T) {
 	assert.Len(t, records, 3) // Header + 2 functions
 
 	// Check header
-	expectedHeaders := []string{"Function", "Complexity", "Cognitive Complexity", "Risk", "Nodes", "Edges", "Nesting Depth", "If Statements", "Loop Statements", "Exception Handlers"}
+	expectedHeaders := []string{"Function", "Complexity", "Cognitive Complexity", "Risk", "Nodes", "Edges", "Nesting Depth", "If Statements", "Loop Statements", "Exception Handlers", "Match Cases", "Boolean Operators", "Comprehensions"}
 	assert.Equal(t, expectedHeaders, records[0])
 
 	// Check data rows (risk levels are lowercase in actual implementation)
-	assert.Equal(t, []string{"simple_function", "2", "0", "low", "5", "4", "0", "1", "0", "0"}, records[1])
-	assert.Equal(t, []string{"complex_function", "8", "0", "high", "20", "18", "0", "3", "2", "1"}, records[2])
+	assert.Equal(t, []string{"simple_function", "2", "0", "low", "5", "4", "0", "1", "0", "0", "0", "0", "0"}, records[1])
+	assert.Equal(t, []string{"complex_function", "8", "0", "high", "20", "18", "0", "3", "2", "1", "0", "0", "0"}, records[2])
 }
 
 // TestOutputFormatter_NewOutputFormatter tests service creation
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// nonFiniteSentinel stands in for NaN and ±Inf while a value is encoded.
+// encoding/json refuses non-finite floats and YAML writes them as .nan or
+// .inf, which many parsers reject; the sentinel is encoded as a plain number
+// and rewritten to null afterwards. It is a subnormal no analysis produces.
+const nonFiniteSentinel = 7.77e-301
+
+var nonFiniteSentinelLiteral = []byte(strconv.FormatFloat(nonFiniteSentinel, 'g', -1, 64))
+
+// outputSanitizer replaces the values of a response that strict JSON and
+// YAML parsers reject, and remembers how to put them back
+type outputSanitizer struct {
+	warnings []string
+	restores []func()
+	visited  map[uintptr]bool
+}
+
+// encodeStrict sanitizes v, encodes it and restores v. Non-finite floats
+// become null and invalid UTF-8 is replaced with U+FFFD; each replacement
+// is reported as a warning in the Warnings field of the response, or on
+// stderr when it has none.
+func encodeStrict(v interface{}, encode func(interface{}) ([]byte, error)) ([]byte, error) {
+	s := &outputSanitizer{visited: make(map[uintptr]bool)}
+	target := s.sanitize(v)
+	defer s.restore()
+
+	data, err := encode(target)
+	if err != nil {
+		return nil, err
+	}
+	return replaceNonFiniteSentinel(data), nil
+}
+
+// sanitize walks v and returns the value to encode: v itself, or an
+// addressable copy when v is not a pointer
+func (s *outputSanitizer) sanitize(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return v
+	}
+	target := v
+	if rv.Kind() != reflect.Ptr {
+		copied := reflect.New(rv.Type())
+		copied.Elem().Set(rv)
+		rv = copied
+		target = copied.Interface()
+	}
+	s.walk(rv, "")
+	s.reportWarnings(rv)
+	return target
+}
+
+func (s *outputSanitizer) walk(rv reflect.Value, path string) {
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() || s.visited[rv.Pointer()] {
+			return
+		}
+		s.visited[rv.Pointer()] = true
+		s.walk(rv.Elem(), path)
+	case reflect.Interface:
+		if rv.IsNil() {
+			return
+		}
+		elem := rv.Elem()
+		if elem.Kind() == reflect.Ptr {
+			s.walk(elem, path)
+			return
+		}
+		// Interface contents are not addressable; sanitize a copy
+		copied := reflect.New(elem.Type()).Elem()
+		copied.Set(elem)
+		if s.walkCopy(copied, path) && rv.CanSet() {
+			s.set(rv, copied)
+		}
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			if field := rv.Type().Field(i); field.IsExported() {
+				s.walk(rv.Field(i), joinOutputPath(path, field.Name))
+			}
+		}
+	case reflect.Slice:
+		if rv.IsNil() || rv.Len() == 0 || s.visited[rv.Pointer()] {
+			return
+		}
+		s.visited[rv.Pointer()] = true
+		fallthrough
+	case reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			s.walk(rv.Index(i), fmt.Sprintf("%s[%d]", path, i))
+		}
+	case reflect.Map:
+		if rv.IsNil() || s.visited[rv.Pointer()] {
+			return
+		}
+		s.visited[rv.Pointer()] = true
+		iter := rv.MapRange()
+		for iter.Next() {
+			key, value := iter.Key(), iter.Value()
+			copied := reflect.New(value.Type()).Elem()
+			copied.Set(value)
+			if s.walkCopy(copied, fmt.Sprintf("%s[%v]", path, key)) {
+				s.setMapIndex(rv, key, copied)
+			}
+		}
+	case reflect.Float64:
+		if f := rv.Float(); (math.IsNaN(f) || math.IsInf(f, 0)) && rv.CanSet() {
+			s.warn(path, fmt.Sprintf("%v replaced with null", f))
+			s.set(rv, reflect.ValueOf(nonFiniteSentinel).Convert(rv.Type()))
+		}
+	case reflect.String:
+		if str := rv.String(); !utf8.ValidString(str) && rv.CanSet() {
+			s.warn(path, "invalid UTF-8 replaced with U+FFFD")
+			s.set(rv, reflect.ValueOf(strings.ToValidUTF8(str, "\uFFFD")).Convert(rv.Type()))
+		}
+	}
+}
+
+// walkCopy walks an addressable copy of a value and reports whether it changed
+func (s *outputSanitizer) walkCopy(copied reflect.Value, path string) bool {
+	before := len(s.warnings)
+	s.walk(copied, path)
+	return len(s.warnings) > before
+}
+
+func (s *outputSanitizer) set(rv, value reflect.Value) {
+	original := reflect.New(rv.Type()).Elem()
+	original.Set(rv)
+	rv.Set(value)
+	s.restores = append(s.restores, func() { rv.Set(original) })
+}
+
+func (s *outputSanitizer) setMapIndex(m, key, value reflect.Value) {
+	original := m.MapIndex(key)
+	m.SetMapIndex(key, value)
+	s.restores = append(s.restores, func() { m.SetMapIndex(key, original) })
+}
+
+func (s *outputSanitizer) warn(path, message string) {
+	if path == "" {
+		path = "value"
+	}
+	s.warnings = append(s.warnings, fmt.Sprintf("%s: %s", path, message))
+}
+
+// reportWarnings appends the warnings to the Warnings field of the
+// response, or writes them to stderr when it has none
+func (s *outputSanitizer) reportWarnings(rv reflect.Value) {
+	if len(s.warnings) == 0 {
+		return
+	}
+	if elem := rv.Elem(); elem.Kind() == reflect.Struct {
+		field := elem.FieldByName("Warnings")
+		if field.IsValid() && field.CanSet() {
+			switch existing := field.Interface().(type) {
+			case []string:
+				s.set(field, reflect.ValueOf(append(slices.Clone(existing), s.warnings...)))
+				return
+			case []domain.AnalysisWarning:
+				warnings := slices.Clone(existing)
+				for _, message := range s.warnings {
+					warnings = append(warnings, domain.AnalysisWarning{Analyzer: "output", Message: message})
+				}
+				s.set(field, reflect.ValueOf(warnings))
+				return
+			}
+		}
+	}
+	for _, warning := range s.warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+}
+
+// restore puts back the replaced values, last replacement first
+func (s *outputSanitizer) restore() {
+	for i := len(s.restores) - 1; i >= 0; i-- {
+		s.restores[i]()
+	}
+}
+
+func joinOutputPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// replaceNonFiniteSentinel rewrites every sentinel number of encoded output
+// to null. Occurrences inside quoted strings or longer numbers are kept.
+func replaceNonFiniteSentinel(data []byte) []byte {
+	if !bytes.Contains(data, nonFiniteSentinelLiteral) {
+		return data
+	}
+	var out bytes.Buffer
+	out.Grow(len(data))
+	for {
+		i := bytes.Index(data, nonFiniteSentinelLiteral)
+		if i < 0 {
+			out.Write(data)
+			return out.Bytes()
+		}
+		end := i + len(nonFiniteSentinelLiteral)
+		standalone := (i == 0 || isOutputDelimiter(data[i-1])) && (end == len(data) || isOutputDelimiter(data[end]))
+		out.Write(data[:i])
+		if standalone {
+			out.WriteString("null")
+		} else {
+			out.Write(nonFiniteSentinelLiteral)
+		}
+		data = data[end:]
+	}
+}
+
+func isOutputDelimiter(b byte) bool {
+	switch b {
+	case ' ', '\t', '\n', '\r', ':', ',', '[', ']', '{', '}':
+		return true
+	}
+	return false
+}
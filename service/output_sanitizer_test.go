@@ -0,0 +1,108 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type strictOutputItem struct {
+	Name  string  `json:"name" yaml:"name"`
+	Score float64 `json:"score" yaml:"score"`
+}
+
+type strictOutputResponse struct {
+	Average  float64            `json:"average" yaml:"average"`
+	Items    []strictOutputItem `json:"items" yaml:"items"`
+	Ratios   map[string]float64 `json:"ratios" yaml:"ratios"`
+	Details  interface{}        `json:"details" yaml:"details"`
+	Note     string             `json:"note" yaml:"note"`
+	Warnings []string           `json:"warnings" yaml:"warnings"`
+}
+
+func newStrictOutputResponse() *strictOutputResponse {
+	return &strictOutputResponse{
+		Average:  math.NaN(),
+		Items:    []strictOutputItem{{Name: "a\xffb", Score: math.Inf(1)}, {Name: "c", Score: 0.5}},
+		Ratios:   map[string]float64{"x": math.Inf(-1), "y": 1},
+		Details:  math.NaN(),
+		Note:     "7.77e-301",
+		Warnings: []string{"existing"},
+	}
+}
+
+func TestWriteJSONReplacesNonFiniteFloatsAndInvalidUTF8(t *testing.T) {
+	response := newStrictOutputResponse()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSON(&buf, response))
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Nil(t, decoded["average"])
+	assert.Nil(t, decoded["details"])
+	assert.Equal(t, "7.77e-301", decoded["note"])
+	items := decoded["items"].([]interface{})
+	assert.Equal(t, "a\uFFFDb", items[0].(map[string]interface{})["name"])
+	assert.Nil(t, items[0].(map[string]interface{})["score"])
+	assert.Equal(t, 0.5, items[1].(map[string]interface{})["score"])
+	assert.Nil(t, decoded["ratios"].(map[string]interface{})["x"])
+	assert.Equal(t, 1.0, decoded["ratios"].(map[string]interface{})["y"])
+
+	warnings := decoded["warnings"].([]interface{})
+	require.Len(t, warnings, 6)
+	assert.Equal(t, "existing", warnings[0])
+	assert.Equal(t, "Average: NaN replaced with null", warnings[1])
+
+	// The response itself is left untouched
+	assert.True(t, math.IsNaN(response.Average))
+	assert.Equal(t, "a\xffb", response.Items[0].Name)
+	assert.True(t, math.IsInf(response.Items[0].Score, 1))
+	assert.True(t, math.IsInf(response.Ratios["x"], -1))
+	assert.True(t, math.IsNaN(response.Details.(float64)))
+	assert.Equal(t, []string{"existing"}, response.Warnings)
+}
+
+func TestEncodeYAMLReplacesNonFiniteFloats(t *testing.T) {
+	out, err := EncodeYAML(newStrictOutputResponse())
+	require.NoError(t, err)
+	assert.NotContains(t, strings.ToLower(out), ".nan")
+	assert.NotContains(t, strings.ToLower(out), ".inf")
+	assert.NotContains(t, out, "!!binary")
+
+	var decoded strictOutputResponse
+	require.NoError(t, yaml.Unmarshal([]byte(out), &decoded))
+	assert.Equal(t, 0.0, decoded.Average)
+	assert.Equal(t, "a\uFFFDb", decoded.Items[0].Name)
+	assert.Equal(t, "7.77e-301", decoded.Note)
+	assert.Len(t, decoded.Warnings, 6)
+}
+
+func TestWriteJSONReportsAnalyzeWarnings(t *testing.T) {
+	response := &domain.AnalyzeResponse{
+		Summary: domain.AnalyzeSummary{AverageComplexity: math.NaN()},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSON(&buf, response))
+
+	var decoded domain.AnalyzeResponse
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Len(t, decoded.Warnings, 1)
+	assert.Equal(t, "output", decoded.Warnings[0].Analyzer)
+	assert.Contains(t, decoded.Warnings[0].Message, "Summary.AverageComplexity")
+	assert.Empty(t, response.Warnings)
+}
+
+func TestEncodeJSONWithoutNonFiniteValues(t *testing.T) {
+	out, err := EncodeJSON(strictOutputItem{Name: "a", Score: 1.5})
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"name\": \"a\",\n  \"score\": 1.5\n}", out)
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// patternRuleFile is the layout of a custom rule file. JSON is a subset of
+// YAML, so one decoder reads both.
+type patternRuleFile struct {
+	Rules []patternRuleEntry `yaml:"rules"`
+}
+
+type patternRuleEntry struct {
+	ID       string           `yaml:"id"`
+	Message  string           `yaml:"message"`
+	Severity string           `yaml:"severity"`
+	Match    patternRuleMatch `yaml:"match"`
+}
+
+type patternRuleMatch struct {
+	Node          patternRuleNodes        `yaml:"node"`
+	Name          string                  `yaml:"name"`
+	Callee        string                  `yaml:"callee"`
+	Scope         domain.PatternRuleScope `yaml:"scope"`
+	Inside        patternRuleNodes        `yaml:"inside"`
+	NotInside     patternRuleNodes        `yaml:"not_inside"`
+	Bare          *bool                   `yaml:"bare"`
+	MinDecorators int                     `yaml:"min_decorators"`
+	MinArgs       int                     `yaml:"min_args"`
+	MinLines      int                     `yaml:"min_lines"`
+}
+
+// patternRuleNodes accepts a single node type or a list of them
+type patternRuleNodes []string
+
+// UnmarshalYAML decodes a scalar or a sequence of node types
+func (n *patternRuleNodes) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*n = patternRuleNodes{value.Value}
+		return nil
+	}
+	var nodes []string
+	if err := value.Decode(&nodes); err != nil {
+		return err
+	}
+	*n = nodes
+	return nil
+}
+
+// PatternRulesConfigurationLoaderImpl implements the PatternRulesConfigurationLoader interface
+type PatternRulesConfigurationLoaderImpl struct{}
+
+// NewPatternRulesConfigurationLoader creates a new custom rules configuration loader service
+func NewPatternRulesConfigurationLoader() *PatternRulesConfigurationLoaderImpl {
+	return &PatternRulesConfigurationLoaderImpl{}
+}
+
+// LoadConfig loads the configuration at the specified path, or discovered
+// from it when it is a directory, and the rule files it lists. Rule file
+// paths are relative to the configuration file.
+func (cl *PatternRulesConfigurationLoaderImpl) LoadConfig(path string) (*domain.PatternRulesRequest, error) {
+	tomlLoader := config.NewTomlConfigLoader()
+	configFile, err := tomlLoader.ResolveConfigPath(path, "")
+	if err != nil {
+		return nil, err
+	}
+	if configFile == "" {
+		return &domain.PatternRulesRequest{}, nil
+	}
+
+	pyscnCfg, err := tomlLoader.LoadConfig(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config from %s: %w", configFile, err)
+	}
+
+	req := &domain.PatternRulesRequest{
+		OutputFormat:    domain.OutputFormat(pyscnCfg.Output.Format),
+		Recursive:       domain.BoolPtr(domain.BoolValue(pyscnCfg.AnalysisRecursive, true)),
+		IncludePatterns: pyscnCfg.AnalysisIncludePatterns,
+		ExcludePatterns: pyscnCfg.AnalysisExcludePatterns,
+	}
+
+	seen := make(map[string]string)
+	for _, file := range pyscnCfg.CustomRulesFiles {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(filepath.Dir(configFile), file)
+		}
+		rules, err := LoadPatternRuleFile(file)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range rules {
+			if previous, ok := seen[rule.ID]; ok {
+				return nil, fmt.Errorf("custom rule %q in %s is already defined in %s", rule.ID, file, previous)
+			}
+			seen[rule.ID] = file
+		}
+		req.Rules = append(req.Rules, rules...)
+		req.RuleFiles = append(req.RuleFiles, file)
+	}
+	return req, nil
+}
+
+// LoadDefaultConfig loads the configuration discovered from the current
+// directory. Unlike other analyses it does not fall back to defaults on a
+// broken configuration: a rule file that does not load is an error.
+func (cl *PatternRulesConfigurationLoaderImpl) LoadDefaultConfig() (*domain.PatternRulesRequest, error) {
+	configFile := config.NewTomlConfigLoader().FindConfigFileFromPath("")
+	if configFile == "" {
+		return &domain.PatternRulesRequest{}, nil
+	}
+	return cl.LoadConfig(configFile)
+}
+
+// MergeConfig merges CLI flags with configuration file
+func (cl *PatternRulesConfigurationLoaderImpl) MergeConfig(base *domain.PatternRulesRequest, override *domain.PatternRulesRequest) *domain.PatternRulesRequest {
+	if base == nil {
+		return override
+	}
+	if override == nil {
+		return base
+	}
+
+	// Start with base config
+	merged := *base
+
+	// Always override paths as they come from command arguments
+	merged.Paths = config.MergeSlice(merged.Paths, override.Paths)
+
+	// Output configuration
+	merged.OutputFormat = config.Merge(merged.OutputFormat, override.OutputFormat)
+	if override.OutputWriter != nil {
+		merged.OutputWriter = override.OutputWriter
+	}
+	merged.OutputPath = config.Merge(merged.OutputPath, override.OutputPath)
+	merged.NoOpen = override.NoOpen
+
+	// ConfigPath
+	merged.ConfigPath = config.Merge(merged.ConfigPath, override.ConfigPath)
+
+	// Analysis options
+	merged.Recursive = config.MergePtr(merged.Recursive, override.Recursive)
+	merged.IncludePatterns = config.MergeSlice(merged.IncludePatterns, override.IncludePatterns)
+	merged.ExcludePatterns = config.MergeSlice(merged.ExcludePatterns, override.ExcludePatterns)
+
+	// Rules
+	merged.Rules = config.MergeSlice(merged.Rules, override.Rules)
+	merged.RuleFiles = config.MergeSlice(merged.RuleFiles, override.RuleFiles)
+
+	return &merged
+}
+
+// LoadPatternRuleFile reads the rules of a YAML or JSON rule file. Rules
+// without a severity report at warning.
+func LoadPatternRuleFile(path string) ([]domain.PatternRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read custom rule file %s: %w", path, err)
+	}
+
+	var file patternRuleFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse custom rule file %s: %w", path, err)
+	}
+
+	rules := make([]domain.PatternRule, 0, len(file.Rules))
+	for i, entry := range file.Rules {
+		if entry.ID == "" {
+			return nil, fmt.Errorf("custom rule file %s: rules[%d] has no id", path, i)
+		}
+		if entry.Message == "" {
+			return nil, fmt.Errorf("custom rule file %s: rule %q has no message", path, entry.ID)
+		}
+		severity := domain.PatternRuleSeverity(entry.Severity)
+		if severity == "" {
+			severity = domain.PatternRuleSeverityWarning
+		}
+		rules = append(rules, domain.PatternRule{
+			ID:       entry.ID,
+			Message:  entry.Message,
+			Severity: severity,
+			Match: domain.PatternRuleMatch{
+				Nodes:         entry.Match.Node,
+				Name:          entry.Match.Name,
+				Callee:        entry.Match.Callee,
+				Scope:         entry.Match.Scope,
+				Inside:        entry.Match.Inside,
+				NotInside:     entry.Match.NotInside,
+				Bare:          entry.Match.Bare,
+				MinDecorators: entry.Match.MinDecorators,
+				MinArgs:       entry.Match.MinArgs,
+				MinLines:      entry.Match.MinLines,
+			},
+		})
+	}
+	return rules, nil
+}
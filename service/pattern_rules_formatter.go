@@ -0,0 +1,114 @@
+package service
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// PatternRulesFormatter implements the PatternRulesOutputFormatter interface
+type PatternRulesFormatter struct{}
+
+// NewPatternRulesFormatter creates a new custom rules formatter
+func NewPatternRulesFormatter() *PatternRulesFormatter {
+	return &PatternRulesFormatter{}
+}
+
+// Write writes the formatted output to the writer
+func (f *PatternRulesFormatter) Write(response *domain.PatternRulesResponse, format domain.OutputFormat, writer io.Writer) error {
+	switch format {
+	case domain.OutputFormatYAML:
+		return WriteYAML(writer, response)
+	case domain.OutputFormatText:
+		return f.writeText(response, writer)
+	case domain.OutputFormatCSV:
+		return f.writeCSV(response, writer)
+	default:
+		return WriteJSON(writer, response)
+	}
+}
+
+// writeText writes output in human-readable text format
+func (f *PatternRulesFormatter) writeText(response *domain.PatternRulesResponse, writer io.Writer) error {
+	fmt.Fprintf(writer, "Custom Rules Results\n")
+	fmt.Fprintf(writer, "====================\n\n")
+
+	fmt.Fprintf(writer, "Summary:\n")
+	fmt.Fprintf(writer, "  Rules Run: %d\n", response.Summary.RulesRun)
+	fmt.Fprintf(writer, "  Total Findings: %d\n", response.Summary.TotalFindings)
+	fmt.Fprintf(writer, "  Files Analyzed: %d\n", response.Summary.FilesAnalyzed)
+	fmt.Fprintf(writer, "  Affected Files: %d\n", response.Summary.AffectedFiles)
+	fmt.Fprintf(writer, "\n")
+
+	if len(response.Summary.ByRule) > 0 {
+		rules := make([]string, 0, len(response.Summary.ByRule))
+		for rule := range response.Summary.ByRule {
+			rules = append(rules, rule)
+		}
+		sort.Strings(rules)
+
+		fmt.Fprintf(writer, "By Rule:\n")
+		for _, rule := range rules {
+			fmt.Fprintf(writer, "  %s: %d\n", rule, response.Summary.ByRule[rule])
+		}
+		fmt.Fprintf(writer, "\n")
+	}
+
+	if len(response.Findings) > 0 {
+		fmt.Fprintf(writer, "Findings:\n")
+		fmt.Fprintf(writer, "---------\n")
+		for i, finding := range response.Findings {
+			fmt.Fprintf(writer, "\n%d. [%s] %s\n", i+1, strings.ToUpper(string(finding.Severity)), finding.Rule)
+			fmt.Fprintf(writer, "   Location: %s:%d:%d\n", finding.Location.FilePath, finding.Location.StartLine, finding.Location.StartCol)
+			if finding.FunctionName != "" {
+				fmt.Fprintf(writer, "   Function: %s\n", finding.FunctionName)
+			}
+			fmt.Fprintf(writer, "   Node: %s\n", finding.Node)
+			fmt.Fprintf(writer, "   Message: %s\n", finding.Message)
+		}
+	}
+
+	if len(response.Errors) > 0 {
+		fmt.Fprintf(writer, "\nErrors:\n")
+		for _, err := range response.Errors {
+			fmt.Fprintf(writer, "  - %s\n", err)
+		}
+	}
+
+	fmt.Fprintf(writer, "\nGenerated at: %s\n", response.GeneratedAt)
+	fmt.Fprintf(writer, "Version: %s\n", response.Version)
+
+	return nil
+}
+
+// writeCSV writes output in CSV format
+func (f *PatternRulesFormatter) writeCSV(response *domain.PatternRulesResponse, writer io.Writer) error {
+	w := csv.NewWriter(writer)
+	if err := w.Write([]string{"rule", "severity", "node", "function_name", "file_path", "start_line", "start_col", "message"}); err != nil {
+		return err
+	}
+
+	for _, finding := range response.Findings {
+		record := []string{
+			finding.Rule,
+			string(finding.Severity),
+			finding.Node,
+			finding.FunctionName,
+			finding.Location.FilePath,
+			strconv.Itoa(finding.Location.StartLine),
+			strconv.Itoa(finding.Location.StartCol),
+			finding.Message,
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/analyzer"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+	"github.com/ludo-technologies/pyscn/internal/version"
+)
+
+// patternRuleSuppressionPattern matches comments that silence custom rule
+// findings on their line
+var patternRuleSuppressionPattern = regexp.MustCompile(`(?i)#\s*(noqa|pyscn:\s*ignore)\b`)
+
+// PatternRulesServiceImpl implements the PatternRulesService interface
+type PatternRulesServiceImpl struct {
+	parser *parser.Parser
+}
+
+// NewPatternRulesService creates a new custom rules service
+func NewPatternRulesService() *PatternRulesServiceImpl {
+	return &PatternRulesServiceImpl{
+		parser: parser.New(),
+	}
+}
+
+// Analyze runs the custom rules of the request over its files
+func (s *PatternRulesServiceImpl) Analyze(ctx context.Context, req domain.PatternRulesRequest) (*domain.PatternRulesResponse, error) {
+	matcher, err := analyzer.NewPatternRuleMatcher(req.Rules)
+	if err != nil {
+		return nil, domain.NewConfigError("invalid custom rules", err)
+	}
+
+	var findings []domain.PatternRuleFinding
+	var errors []string
+	filesProcessed := 0
+
+	for _, filePath := range req.Paths {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("custom rules analysis cancelled: %w", ctx.Err())
+		default:
+		}
+
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("[%s] Failed to read file: %v", filePath, err))
+			continue
+		}
+		result, err := s.parser.ParseFor(ctx, filePath, content)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("[%s] Parse error: %v", filePath, err))
+			continue
+		}
+
+		findings = append(findings, filterSuppressedPatternRuleFindings(matcher.Analyze(result.AST, filePath), content)...)
+		filesProcessed++
+	}
+
+	if findings == nil {
+		findings = []domain.PatternRuleFinding{}
+	}
+	return &domain.PatternRulesResponse{
+		Findings:    findings,
+		Summary:     summarizePatternRuleFindings(findings, len(req.Rules), filesProcessed),
+		Errors:      errors,
+		GeneratedAt: time.Now().Format(time.RFC3339),
+		Version:     version.Version,
+	}, nil
+}
+
+// filterSuppressedPatternRuleFindings drops findings whose node starts on a
+// line carrying a suppression comment
+func filterSuppressedPatternRuleFindings(findings []domain.PatternRuleFinding, content []byte) []domain.PatternRuleFinding {
+	if len(findings) == 0 {
+		return findings
+	}
+	lines := bytes.Split(content, []byte("\n"))
+
+	kept := findings[:0]
+	for _, finding := range findings {
+		line := finding.Location.StartLine
+		if line >= 1 && line <= len(lines) && patternRuleSuppressionPattern.Match(lines[line-1]) {
+			continue
+		}
+		kept = append(kept, finding)
+	}
+	return kept
+}
+
+func summarizePatternRuleFindings(findings []domain.PatternRuleFinding, rulesRun, filesAnalyzed int) domain.PatternRulesSummary {
+	summary := domain.PatternRulesSummary{
+		TotalFindings: len(findings),
+		ByRule:        make(map[string]int),
+		BySeverity:    make(map[domain.PatternRuleSeverity]int),
+		RulesRun:      rulesRun,
+		FilesAnalyzed: filesAnalyzed,
+	}
+	affected := make(map[string]bool)
+	for _, finding := range findings {
+		summary.ByRule[finding.Rule]++
+		summary.BySeverity[finding.Severity]++
+		affected[finding.Location.FilePath] = true
+	}
+	summary.AffectedFiles = len(affected)
+	return summary
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+func writePatternRulesFixture(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+}
+
+func TestPatternRulesConfigurationLoader_LoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	writePatternRulesFixture(t, dir, map[string]string{
+		".pyscn.toml": "[custom_rules]\nfiles = [\"rules/team.yaml\", \"rules/extra.json\"]\n",
+		"rules/team.yaml": `rules:
+  - id: team.no-module-print
+    message: Use logging instead of print at import time
+    match:
+      node: Call
+      callee: print
+      scope: module
+`,
+		"rules/extra.json": `{"rules": [{"id": "team.bare-except", "message": "Catch a specific exception", "severity": "error",
+  "match": {"node": ["ExceptHandler"], "bare": true}}]}`,
+	})
+
+	req, err := NewPatternRulesConfigurationLoader().LoadConfig(filepath.Join(dir, ".pyscn.toml"))
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if len(req.Rules) != 2 || len(req.RuleFiles) != 2 {
+		t.Fatalf("expected 2 rules from 2 files, got %+v", req)
+	}
+	if req.Rules[0].Severity != domain.PatternRuleSeverityWarning {
+		t.Errorf("expected the default severity to be warning, got %q", req.Rules[0].Severity)
+	}
+	if got := req.Rules[1].Match.Nodes; len(got) != 1 || got[0] != "ExceptHandler" || req.Rules[1].Match.Bare == nil {
+		t.Errorf("expected the JSON rule to match bare except handlers, got %+v", req.Rules[1].Match)
+	}
+}
+
+func TestPatternRulesConfigurationLoader_RejectsDuplicateIDs(t *testing.T) {
+	dir := t.TempDir()
+	rule := "rules:\n  - id: dup\n    message: m\n    match:\n      node: Call\n"
+	writePatternRulesFixture(t, dir, map[string]string{
+		".pyscn.toml": "[custom_rules]\nfiles = [\"a.yaml\", \"b.yaml\"]\n",
+		"a.yaml":      rule,
+		"b.yaml":      rule,
+	})
+
+	_, err := NewPatternRulesConfigurationLoader().LoadConfig(dir)
+	if err == nil || !strings.Contains(err.Error(), `custom rule "dup"`) {
+		t.Fatalf("expected a duplicate rule ID error, got %v", err)
+	}
+}
+
+func TestPatternRulesService_Suppression(t *testing.T) {
+	dir := t.TempDir()
+	appPath := filepath.Join(dir, "app.py")
+	writePatternRulesFixture(t, dir, map[string]string{
+		"app.py": "print(\"a\")\nprint(\"b\")  # noqa\n",
+	})
+
+	req := domain.PatternRulesRequest{
+		Paths: []string{appPath},
+		Rules: []domain.PatternRule{{
+			ID: "team.no-print", Message: "no print", Severity: domain.PatternRuleSeverityWarning,
+			Match: domain.PatternRuleMatch{Nodes: []string{"Call"}, Callee: "print"},
+		}},
+	}
+	response, err := NewPatternRulesService().Analyze(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if len(response.Findings) != 1 || response.Findings[0].Location.StartLine != 1 {
+		t.Fatalf("expected only the unsuppressed finding on line 1, got %+v", response.Findings)
+	}
+	if response.Summary.ByRule["team.no-print"] != 1 || response.Summary.AffectedFiles != 1 {
+		t.Errorf("unexpected summary %+v", response.Summary)
+	}
+
+	req.Rules[0].Match.Nodes = []string{"Print"}
+	if _, err := NewPatternRulesService().Analyze(context.Background(), req); err == nil {
+		t.Error("expected an invalid rule to fail the analysis")
+	}
+}
@@ -82,7 +82,7 @@ func (pm *ProgressManagerImpl) SetWriter(writer io.Writer) {
 	pm.writer = writer
 
 	// Update interactivity check based on new writer
-	if file, ok := writer.(*os.File); ok {
+	if file, ok := writer.(*os.File); ok && !IsMachineMode() {
 		pm.interactive = term.IsTerminal(int(file.Fd()))
 	} else {
 		pm.interactive = false
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// ProgressStream writes the progress of an analyze run as newline-delimited
+// JSON events, for GUIs and CI wrappers that render their own progress.
+// Attach it to the context of the run with domain.WithProgressReporter.
+type ProgressStream struct {
+	mu      sync.Mutex
+	writer  io.Writer
+	failed  bool // A write failed, e.g. the reader of a pipe went away
+	start   time.Time
+	started map[string]time.Time // phase -> start time
+	percent map[string]int       // phase -> last percentage written
+	now     func() time.Time
+}
+
+// NewProgressStream creates a progress stream writing to writer; the run is
+// timed from now on
+func NewProgressStream(writer io.Writer) *ProgressStream {
+	return &ProgressStream{
+		writer:  writer,
+		start:   time.Now(),
+		started: make(map[string]time.Time),
+		percent: make(map[string]int),
+		now:     time.Now,
+	}
+}
+
+// StageStarted writes a phase_start event
+func (s *ProgressStream) StageStarted(stage string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.started[stage] = s.now()
+	s.write(domain.ProgressEvent{Event: domain.ProgressEventPhaseStart, Phase: stage})
+}
+
+// StageFinished writes a phase_finish event
+func (s *ProgressStream) StageFinished(stage string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.write(domain.ProgressEvent{Event: domain.ProgressEventPhaseFinish, Phase: stage})
+}
+
+// ReportProgress writes a progress event each time a phase gains a whole
+// percent, so that large runs do not flood the stream
+func (s *ProgressStream) ReportProgress(stage string, done, total int) {
+	if total <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	percent := min(done*100/total, 100)
+	if last, ok := s.percent[stage]; ok && percent <= last {
+		return
+	}
+	s.percent[stage] = percent
+
+	event := domain.ProgressEvent{Event: domain.ProgressEventProgress, Phase: stage, Done: done, Total: total}
+	start, ok := s.started[stage]
+	if !ok {
+		start = s.start
+	}
+	if done > 0 {
+		elapsed := s.now().Sub(start)
+		eta := time.Duration(float64(elapsed) * float64(total-done) / float64(done)).Milliseconds()
+		event.ETAMs = &eta
+	}
+	s.write(event)
+}
+
+func (s *ProgressStream) write(event domain.ProgressEvent) {
+	if s.failed {
+		return
+	}
+	event.ElapsedMs = s.now().Sub(s.start).Milliseconds()
+	line, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	if _, err := s.writer.Write(append(line, '\n')); err != nil {
+		s.failed = true
+	}
+}
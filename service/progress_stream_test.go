@@ -0,0 +1,101 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestProgressStream returns a stream reading the time from clock
+func newTestProgressStream(buf *bytes.Buffer, clock *time.Time) *ProgressStream {
+	stream := NewProgressStream(buf)
+	stream.start = *clock
+	stream.now = func() time.Time { return *clock }
+	return stream
+}
+
+func decodeProgressEvents(t *testing.T, buf *bytes.Buffer) []domain.ProgressEvent {
+	t.Helper()
+	var events []domain.ProgressEvent
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var event domain.ProgressEvent
+		require.NoError(t, json.Unmarshal([]byte(line), &event), line)
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestProgressStreamWritesPhaseEvents(t *testing.T) {
+	var buf bytes.Buffer
+	clock := time.Unix(0, 0)
+	stream := newTestProgressStream(&buf, &clock)
+
+	clock = clock.Add(2 * time.Second)
+	stream.StageStarted("complexity")
+	clock = clock.Add(time.Second)
+	stream.ReportProgress("complexity", 1, 4)
+	clock = clock.Add(3 * time.Second)
+	stream.ReportProgress("complexity", 4, 4)
+	stream.StageFinished("complexity")
+
+	events := decodeProgressEvents(t, &buf)
+	require.Len(t, events, 4)
+	assert.Equal(t, domain.ProgressEventPhaseStart, events[0].Event)
+	assert.Equal(t, "complexity", events[0].Phase)
+	assert.Nil(t, events[0].ETAMs)
+
+	assert.Equal(t, domain.ProgressEventProgress, events[1].Event)
+	assert.Equal(t, 1, events[1].Done)
+	assert.Equal(t, 4, events[1].Total)
+	require.NotNil(t, events[1].ETAMs)
+	// One second for the first file of the phase leaves three seconds
+	assert.Equal(t, int64(3000), *events[1].ETAMs)
+
+	require.NotNil(t, events[2].ETAMs)
+	assert.Equal(t, int64(0), *events[2].ETAMs)
+	assert.Equal(t, domain.ProgressEventPhaseFinish, events[3].Event)
+	assert.Equal(t, int64(2000), events[0].ElapsedMs)
+	assert.Equal(t, int64(6000), events[3].ElapsedMs)
+}
+
+func TestProgressStreamThrottlesToWholePercents(t *testing.T) {
+	var buf bytes.Buffer
+	clock := time.Unix(0, 0)
+	stream := newTestProgressStream(&buf, &clock)
+
+	stream.StageStarted("parse")
+	for done := 1; done <= 1000; done++ {
+		stream.ReportProgress("parse", done, 1000)
+	}
+	stream.ReportProgress("parse", 0, 0)
+
+	events := decodeProgressEvents(t, &buf)
+	// phase_start plus one event for each whole percent from 0% to 100%
+	assert.Len(t, events, 1+101)
+	assert.Equal(t, 1000, events[len(events)-1].Done)
+}
+
+type failingWriter struct{ writes int }
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return 0, errors.New("broken pipe")
+}
+
+func TestProgressStreamStopsAfterWriteError(t *testing.T) {
+	writer := &failingWriter{}
+	stream := NewProgressStream(writer)
+
+	stream.StageStarted("parse")
+	stream.ReportProgress("parse", 1, 2)
+	stream.StageFinished("parse")
+
+	assert.Equal(t, 1, writer.writes)
+}
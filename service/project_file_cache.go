@@ -0,0 +1,100 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// maxProjectFileCacheEntries bounds the cache of a long-running process
+// that analyzes many projects; the cache starts over when it is exceeded
+const maxProjectFileCacheEntries = 50000
+
+// projectFileCache is consulted by BuildProjectSnapshotWithOptions when set
+var projectFileCache atomic.Pointer[ProjectFileCache]
+
+// SetProjectFileCache makes project snapshots reuse parsed files from
+// cache. A nil cache turns reuse off, which is the default: a one-shot CLI
+// run never sees the same file twice.
+func SetProjectFileCache(cache *ProjectFileCache) {
+	projectFileCache.Store(cache)
+}
+
+// ProjectFileCache keeps parsed project files between analyses of the same
+// tree. An entry is reused while the file's size and modification time are
+// unchanged, together with the CFGs built from it.
+type ProjectFileCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedProjectFile
+	hits    int
+	misses  int
+}
+
+type cachedProjectFile struct {
+	size    int64
+	modTime time.Time
+	file    *ProjectFile
+}
+
+// ProjectFileCacheStats describes the cache contents and its hit rate
+type ProjectFileCacheStats struct {
+	Entries int `json:"entries"`
+	Hits    int `json:"hits"`
+	Misses  int `json:"misses"`
+}
+
+// NewProjectFileCache creates an empty cache
+func NewProjectFileCache() *ProjectFileCache {
+	return &ProjectFileCache{entries: make(map[string]cachedProjectFile)}
+}
+
+// Stats returns the current cache statistics
+func (c *ProjectFileCache) Stats() ProjectFileCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return ProjectFileCacheStats{Entries: len(c.entries), Hits: c.hits, Misses: c.misses}
+}
+
+// lookup returns the cached file for path when it is still current and was
+// built with at least the requested options
+func (c *ProjectFileCache) lookup(path string, info os.FileInfo, options ProjectSnapshotOptions) *ProjectFile {
+	key := projectFileCacheKey(path)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if ok && entry.size == info.Size() && entry.modTime.Equal(info.ModTime()) &&
+		entry.file.Path == path && (!options.IncludeRawMetrics || entry.file.RawMetrics != nil) {
+		c.hits++
+		return entry.file
+	}
+	c.misses++
+	return nil
+}
+
+// store caches file as the content of path described by info. Files that
+// could not be read are not cached, so the next analysis retries them.
+func (c *ProjectFileCache) store(path string, info os.FileInfo, file *ProjectFile) {
+	if file.ReadErr != nil {
+		return
+	}
+	key := projectFileCacheKey(path)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= maxProjectFileCacheEntries {
+		c.entries = make(map[string]cachedProjectFile)
+	}
+	c.entries[key] = cachedProjectFile{size: info.Size(), modTime: info.ModTime(), file: file}
+}
+
+func projectFileCacheKey(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectFileCache_ReusesUnchangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "module.py")
+	require.NoError(t, os.WriteFile(path, []byte("def f():\n    return 1\n"), 0o644))
+
+	cache := NewProjectFileCache()
+	SetProjectFileCache(cache)
+	defer SetProjectFileCache(nil)
+
+	first := BuildProjectSnapshotWithOptions(context.Background(), []string{path}, ProjectSnapshotOptions{})
+	second := BuildProjectSnapshotWithOptions(context.Background(), []string{path}, ProjectSnapshotOptions{})
+	require.Len(t, second.Files, 1)
+	assert.Same(t, first.Files[0], second.Files[0])
+	assert.Equal(t, ProjectFileCacheStats{Entries: 1, Hits: 1, Misses: 1}, cache.Stats())
+
+	// Raw metrics were not computed for the cached entry
+	withMetrics := BuildProjectSnapshotWithOptions(context.Background(), []string{path}, ProjectSnapshotOptions{IncludeRawMetrics: true})
+	assert.NotSame(t, first.Files[0], withMetrics.Files[0])
+	assert.NotNil(t, withMetrics.Files[0].RawMetrics)
+
+	// A modified file is parsed again
+	require.NoError(t, os.WriteFile(path, []byte("def g():\n    return 2\n"), 0o644))
+	later := time.Now().Add(time.Second)
+	require.NoError(t, os.Chtimes(path, later, later))
+	changed := BuildProjectSnapshotWithOptions(context.Background(), []string{path}, ProjectSnapshotOptions{})
+	assert.NotSame(t, withMetrics.Files[0], changed.Files[0])
+	assert.Equal(t, 3, cache.Stats().Misses)
+}
+
+func TestProjectFileCache_SkipsUnreadableFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "module.py")
+	require.NoError(t, os.WriteFile(path, []byte("x = 1\n"), 0o644))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+
+	cache := NewProjectFileCache()
+	cache.store(path, info, &ProjectFile{Path: path, ReadErr: os.ErrPermission})
+	assert.Equal(t, 0, cache.Stats().Entries)
+}
@@ -9,9 +9,26 @@ import (
 // FindProjectRoot locates the project root from the given paths by finding their
 // common parent and walking upward for standard Python project markers.
 func FindProjectRoot(paths []string) string {
+	root, _, _ := findProjectRoot(paths)
+	return root
+}
+
+// ReportRoot is the directory report paths are relative to: the project root
+// when a marker was found, otherwise the common parent of the analyzed paths
+func ReportRoot(paths []string) string {
+	root, marked, commonParent := findProjectRoot(paths)
+	if !marked {
+		return commonParent
+	}
+	return root
+}
+
+// findProjectRoot returns the project root, whether a project marker was
+// found there, and the common parent of paths the search started from
+func findProjectRoot(paths []string) (root string, marked bool, commonParent string) {
 	if len(paths) == 0 {
 		cwd, _ := os.Getwd()
-		return cwd
+		return cwd, false, cwd
 	}
 
 	absPaths := make([]string, 0, len(paths))
@@ -31,10 +48,10 @@ func FindProjectRoot(paths []string) string {
 
 	if len(absPaths) == 0 {
 		cwd, _ := os.Getwd()
-		return cwd
+		return cwd, false, cwd
 	}
 
-	commonParent := absPaths[0]
+	commonParent = absPaths[0]
 	for _, path := range absPaths[1:] {
 		for !strings.HasPrefix(path, commonParent) {
 			commonParent = filepath.Dir(commonParent)
@@ -44,16 +61,17 @@ func FindProjectRoot(paths []string) string {
 		}
 	}
 
+	root = commonParent
 	for {
 		markers := []string{"setup.py", "pyproject.toml", "setup.cfg", ".git", "requirements.txt"}
 		for _, marker := range markers {
-			if _, err := os.Stat(filepath.Join(commonParent, marker)); err == nil {
-				return commonParent
+			if _, err := os.Stat(filepath.Join(root, marker)); err == nil {
+				return root, true, commonParent
 			}
 		}
 
-		parent := filepath.Dir(commonParent)
-		if parent == commonParent || parent == "/" || parent == "." {
+		parent := filepath.Dir(root)
+		if parent == root || parent == "/" || parent == "." {
 			break
 		}
 
@@ -61,8 +79,8 @@ func FindProjectRoot(paths []string) string {
 			break
 		}
 
-		commonParent = parent
+		root = parent
 	}
 
-	return commonParent
+	return root, false, commonParent
 }
@@ -40,3 +40,21 @@ func TestFindProjectRoot_FromSiblingPackages(t *testing.T) {
 	})
 	assert.Equal(t, root, got)
 }
+
+func TestReportRoot(t *testing.T) {
+	t.Run("project root when marked", func(t *testing.T) {
+		root := t.TempDir()
+		pkg := filepath.Join(root, "pkg")
+		require.NoError(t, os.MkdirAll(pkg, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, "setup.cfg"), []byte(""), 0o644))
+
+		assert.Equal(t, root, ReportRoot([]string{pkg}))
+	})
+
+	t.Run("analyzed directory without marker", func(t *testing.T) {
+		pkg := filepath.Join(t.TempDir(), "pkg")
+		require.NoError(t, os.MkdirAll(pkg, 0o755))
+
+		assert.Equal(t, pkg, ReportRoot([]string{pkg}))
+	})
+}
@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 
+	"github.com/ludo-technologies/pyscn/domain"
 	"github.com/ludo-technologies/pyscn/internal/analyzer"
 	"github.com/ludo-technologies/pyscn/internal/parser"
 )
@@ -15,11 +18,19 @@ import (
 // ProjectSnapshot stores the parsed source needed by multiple analyzers.
 type ProjectSnapshot struct {
 	Files []*ProjectFile
+
+	// BytesParsed is the size of the files read and parsed to build the
+	// snapshot; files served from the parse cache are not counted
+	BytesParsed int64
 }
 
 // ProjectSnapshotOptions controls which optional per-file analysis caches are built.
 type ProjectSnapshotOptions struct {
 	IncludeRawMetrics bool
+
+	// TerminatingCalls are calls that never return, in addition to
+	// analyzer.DefaultTerminatingCalls and the project's NoReturn functions
+	TerminatingCalls []string
 }
 
 // ProjectFile stores one Python file after read and parse.
@@ -30,9 +41,17 @@ type ProjectFile struct {
 	ReadErr    error
 	ParseErr   error
 
-	cfgOnce sync.Once
-	cfgs    map[string]*analyzer.CFG
-	cfgErr  error
+	// directives are the "# pyscn:" directives of the file's functions and classes
+	directives *codeDirectives
+
+	// terminatingCalls are the calls the CFGs end blocks at; cached CFGs
+	// built with other calls are rebuilt
+	terminatingCalls *analyzer.TerminatingCalls
+
+	cfgMu    sync.Mutex
+	cfgBuilt bool
+	cfgs     map[string]*analyzer.CFG
+	cfgErr   error
 }
 
 // BuildProjectSnapshot reads and parses each file once for the full analyze command.
@@ -58,6 +77,8 @@ func BuildProjectSnapshotWithOptions(ctx context.Context, paths []string, option
 
 	jobs := make(chan int)
 	var wg sync.WaitGroup
+	var parsed atomic.Int64
+	var parsedBytes atomic.Int64
 
 	for range workerCount {
 		wg.Add(1)
@@ -67,7 +88,10 @@ func BuildProjectSnapshotWithOptions(ctx context.Context, paths []string, option
 			pyParser := parser.New()
 			for idx := range jobs {
 				path := paths[idx]
-				snapshot.Files[idx] = buildProjectFile(ctx, pyParser, path, options)
+				file, size := buildProjectFile(ctx, pyParser, path, options)
+				snapshot.Files[idx] = file
+				parsedBytes.Add(int64(size))
+				domain.ReportProgress(ctx, domain.ProgressStageParse, int(parsed.Add(1)), len(paths))
 			}
 		}()
 	}
@@ -89,6 +113,7 @@ func BuildProjectSnapshotWithOptions(ctx context.Context, paths []string, option
 
 	close(jobs)
 	wg.Wait()
+	snapshot.BytesParsed = parsedBytes.Load()
 
 	for idx, path := range paths {
 		if snapshot.Files[idx] == nil {
@@ -96,9 +121,54 @@ func BuildProjectSnapshotWithOptions(ctx context.Context, paths []string, option
 		}
 	}
 
+	if ctx.Err() == nil {
+		for _, file := range snapshot.Files {
+			err := file.ReadErr
+			if err == nil {
+				err = file.ParseErr
+			}
+			if err != nil {
+				reportSkippedFile(ctx, file.Path, err)
+			}
+		}
+	}
+
+	snapshot.useTerminatingCalls(options.TerminatingCalls)
 	return snapshot
 }
 
+// useTerminatingCalls sets the calls the CFGs of the snapshot end blocks
+// at: the defaults, the configured ones and the functions of the project
+// annotated NoReturn or Never
+func (s *ProjectSnapshot) useTerminatingCalls(configured []string) {
+	var modules []*parser.Node
+	for _, file := range s.Files {
+		if file.Parsed() {
+			modules = append(modules, file.AST)
+		}
+	}
+	calls := analyzer.NewTerminatingCalls(
+		append(slices.Clone(analyzer.DefaultTerminatingCalls), configured...),
+		analyzer.NoReturnFunctions(modules),
+	)
+	for _, file := range s.Files {
+		file.setTerminatingCalls(calls)
+	}
+}
+
+// setTerminatingCalls sets the calls the file's CFGs end blocks at,
+// dropping CFGs built with different ones
+func (f *ProjectFile) setTerminatingCalls(calls *analyzer.TerminatingCalls) {
+	f.cfgMu.Lock()
+	defer f.cfgMu.Unlock()
+	if f.terminatingCalls.Key() == calls.Key() {
+		return
+	}
+	f.terminatingCalls = calls
+	f.cfgBuilt = false
+	f.cfgs, f.cfgErr = nil, nil
+}
+
 // Paths returns the file paths represented by the snapshot.
 func (s *ProjectSnapshot) Paths() []string {
 	if s == nil {
@@ -114,6 +184,27 @@ func (s *ProjectSnapshot) Paths() []string {
 	return paths
 }
 
+// Subset returns a snapshot holding only the given paths, in snapshot order.
+// Files are shared with the original snapshot, not re-parsed.
+func (s *ProjectSnapshot) Subset(paths []string) *ProjectSnapshot {
+	if s == nil {
+		return nil
+	}
+
+	keep := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		keep[path] = true
+	}
+
+	subset := &ProjectSnapshot{Files: make([]*ProjectFile, 0, len(paths))}
+	for _, file := range s.Files {
+		if file != nil && keep[file.Path] {
+			subset.Files = append(subset.Files, file)
+		}
+	}
+	return subset
+}
+
 // Parsed reports whether the file has a valid parsed AST.
 func (f *ProjectFile) Parsed() bool {
 	return f != nil && f.ReadErr == nil && f.ParseErr == nil && f.AST != nil
@@ -134,50 +225,73 @@ func (f *ProjectFile) CFGs() (map[string]*analyzer.CFG, error) {
 		return nil, fmt.Errorf("invalid parse result")
 	}
 
-	f.cfgOnce.Do(func() {
+	f.cfgMu.Lock()
+	defer f.cfgMu.Unlock()
+	if !f.cfgBuilt {
 		builder := analyzer.NewCFGBuilder()
+		if f.terminatingCalls != nil {
+			builder.SetTerminatingCalls(f.terminatingCalls)
+		}
 		f.cfgs, f.cfgErr = builder.BuildAll(f.AST)
-	})
+		f.cfgBuilt = true
+	}
 
 	return f.cfgs, f.cfgErr
 }
 
-func buildProjectFile(ctx context.Context, pyParser *parser.Parser, path string, options ProjectSnapshotOptions) *ProjectFile {
+// buildProjectFile reads and parses one file, or takes it from the parse
+// cache. It also returns the number of bytes it parsed, 0 for cached files.
+func buildProjectFile(ctx context.Context, pyParser *parser.Parser, path string, options ProjectSnapshotOptions) (*ProjectFile, int) {
 	file := &ProjectFile{Path: path}
 
 	select {
 	case <-ctx.Done():
 		file.ReadErr = fmt.Errorf("analysis cancelled: %w", ctx.Err())
-		return file
+		return file, 0
 	default:
 	}
 
+	if cache := projectFileCache.Load(); cache != nil {
+		info, err := os.Stat(path)
+		if err == nil {
+			if cached := cache.lookup(path, info, options); cached != nil {
+				return cached, 0
+			}
+			defer func() {
+				if ctx.Err() == nil { // A cancelled parse says nothing about the file
+					cache.store(path, info, file)
+				}
+			}()
+		}
+	}
+
 	content, err := os.ReadFile(path)
 	if err != nil {
 		file.ReadErr = err
-		return file
+		return file, 0
 	}
 
 	if options.IncludeRawMetrics {
 		file.RawMetrics = analyzer.CalculateRawMetrics(content, path)
 	}
 
-	result, err := pyParser.Parse(ctx, content)
+	result, err := pyParser.ParseFor(ctx, path, content)
 	if err != nil {
 		file.ParseErr = err
-		return file
+		return file, len(content)
 	}
 	if result == nil || result.AST == nil {
 		file.ParseErr = fmt.Errorf("invalid parse result")
-		return file
+		return file, len(content)
 	}
 
 	file.AST = result.AST
+	file.directives = parseCodeDirectives(file.AST, content)
 	if file.RawMetrics != nil {
 		analyzer.PopulateLogicalLines(file.RawMetrics, file.AST)
 	}
 
-	return file
+	return file, len(content)
 }
 
 func cancelledProjectFile(path string, err error) *ProjectFile {
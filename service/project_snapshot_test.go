@@ -63,6 +63,20 @@ func TestProjectSnapshotOptionsSkipRawMetrics(t *testing.T) {
 	}
 }
 
+func TestProjectSnapshotCountsBytesParsed(t *testing.T) {
+	sourcePath := writeSnapshotFixture(t)
+	info, err := os.Stat(sourcePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing.py")
+	snapshot := BuildProjectSnapshotWithOptions(context.Background(), []string{sourcePath, missing}, ProjectSnapshotOptions{})
+	if snapshot.BytesParsed != info.Size() {
+		t.Errorf("expected %d bytes parsed, got %d", info.Size(), snapshot.BytesParsed)
+	}
+}
+
 func TestComplexitySnapshotRequiresRawMetrics(t *testing.T) {
 	ctx := context.Background()
 	sourcePath := writeSnapshotFixture(t)
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// LoadRatchet reads a ratchet file. A missing file yields nil and no error,
+// so that callers can tell "not recorded yet" from a broken file.
+func LoadRatchet(path string) (*domain.Ratchet, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, domain.NewFileNotFoundError(path, err)
+	}
+
+	var ratchet domain.Ratchet
+	if err := json.Unmarshal(data, &ratchet); err != nil {
+		return nil, domain.NewConfigError(fmt.Sprintf("failed to parse ratchet file %s", path), err)
+	}
+	return &ratchet, nil
+}
+
+// SaveRatchet writes a ratchet file with entries sorted by path, function
+// and metric, so that diffs of the committed file stay small
+func SaveRatchet(path string, ratchet *domain.Ratchet) error {
+	sortRatchetEntries(ratchet.Entries)
+
+	file, err := os.Create(path)
+	if err != nil {
+		return domain.NewOutputError(fmt.Sprintf("failed to create ratchet file %s", path), err)
+	}
+	defer file.Close()
+
+	return WriteJSON(file, ratchet)
+}
+
+// RatchetThresholds returns the values allowed for files and functions
+// without an entry: the configured max_complexity (or the check default) and
+// cognitive complexity threshold, and no dead code or clones
+func RatchetThresholds(response *domain.AnalyzeResponse) map[string]int {
+	thresholds := map[string]int{
+		domain.RatchetMetricComplexity:          domain.DefaultRatchetMaxComplexity,
+		domain.RatchetMetricCognitiveComplexity: domain.DefaultCognitiveComplexityThreshold,
+		domain.RatchetMetricDeadCode:            0,
+		domain.RatchetMetricClones:              0,
+	}
+	if response != nil && response.Complexity != nil && response.Complexity.Request != nil {
+		if req := response.Complexity.Request; req.MaxComplexity > 0 {
+			thresholds[domain.RatchetMetricComplexity] = req.MaxComplexity
+		}
+		if req := response.Complexity.Request; req.CognitiveComplexityThreshold > 0 {
+			thresholds[domain.RatchetMetricCognitiveComplexity] = req.CognitiveComplexityThreshold
+		}
+	}
+	return thresholds
+}
+
+// CollectRatchetEntries returns the worst value of each metric per function
+// and file that exceeds its threshold, with paths relative to root. A name
+// defined twice in a file is recorded with its worse value; module-level
+// code is not recorded.
+func CollectRatchetEntries(response *domain.AnalyzeResponse, root string, thresholds map[string]int) []domain.RatchetEntry {
+	worst := make(map[string]domain.RatchetEntry)
+	record := func(entry domain.RatchetEntry) {
+		if entry.Value <= thresholds[entry.Metric] {
+			return
+		}
+		if existing, ok := worst[entry.Key()]; ok && existing.Value >= entry.Value {
+			return
+		}
+		worst[entry.Key()] = entry
+	}
+
+	if response.Complexity != nil {
+		for _, fn := range response.Complexity.Functions {
+			if fn.Name == domain.ModuleFunctionName {
+				continue
+			}
+			path := RatchetPath(fn.FilePath, root)
+			record(domain.RatchetEntry{Path: path, Function: fn.Name, Line: fn.StartLine,
+				Metric: domain.RatchetMetricComplexity, Value: fn.Metrics.Complexity})
+			record(domain.RatchetEntry{Path: path, Function: fn.Name, Line: fn.StartLine,
+				Metric: domain.RatchetMetricCognitiveComplexity, Value: fn.Metrics.CognitiveComplexity})
+		}
+	}
+
+	counts := make(map[string]map[string]int)
+	count := func(filePath, metric string) {
+		path := RatchetPath(filePath, root)
+		if counts[path] == nil {
+			counts[path] = make(map[string]int)
+		}
+		counts[path][metric]++
+	}
+	if response.DeadCode != nil {
+		for _, file := range response.DeadCode.Files {
+			for _, fn := range file.Functions {
+				for _, finding := range fn.Findings {
+					count(finding.Location.FilePath, domain.RatchetMetricDeadCode)
+				}
+			}
+		}
+	}
+	if response.Clone != nil {
+		for _, clone := range response.Clone.Clones {
+			if clone != nil && clone.Location != nil {
+				count(clone.Location.FilePath, domain.RatchetMetricClones)
+			}
+		}
+	}
+	for path, metrics := range counts {
+		for metric, value := range metrics {
+			record(domain.RatchetEntry{Path: path, Metric: metric, Value: value})
+		}
+	}
+
+	entries := make([]domain.RatchetEntry, 0, len(worst))
+	for _, entry := range worst {
+		entries = append(entries, entry)
+	}
+	sortRatchetEntries(entries)
+	return entries
+}
+
+// RatchetAnalyzedFiles returns the files of the run, relative to root. A
+// recorded entry of one of them that the run did not reproduce has improved.
+func RatchetAnalyzedFiles(response *domain.AnalyzeResponse, root string) map[string]bool {
+	files := make(map[string]bool)
+	if response.Complexity != nil {
+		for _, metrics := range response.Complexity.RawMetrics {
+			files[RatchetPath(metrics.FilePath, root)] = true
+		}
+		for _, fn := range response.Complexity.Functions {
+			files[RatchetPath(fn.FilePath, root)] = true
+		}
+	}
+	return files
+}
+
+// CompareRatchet compares the entries of a run with the recorded ratchet.
+// An entry above its recorded value, or above the threshold when it was not
+// recorded, is a regression. A recorded entry of an analyzed file that went
+// down or disappeared is an improvement.
+func CompareRatchet(recorded *domain.Ratchet, current []domain.RatchetEntry, analyzed map[string]bool) *domain.RatchetReport {
+	report := &domain.RatchetReport{
+		Regressions:  []domain.RatchetChange{},
+		Improvements: []domain.RatchetChange{},
+	}
+
+	previous := make(map[string]domain.RatchetEntry, len(recorded.Entries))
+	for _, entry := range recorded.Entries {
+		previous[entry.Key()] = entry
+	}
+	seen := make(map[string]domain.RatchetEntry, len(current))
+	for _, entry := range current {
+		seen[entry.Key()] = entry
+		change := domain.RatchetChange{
+			Path: entry.Path, Function: entry.Function, Line: entry.Line, Metric: entry.Metric,
+			Allowed: recorded.Threshold(entry.Metric), Current: entry.Value,
+		}
+		if before, ok := previous[entry.Key()]; ok {
+			change.Allowed = before.Value
+			change.Recorded = true
+		}
+		switch {
+		case change.Current > change.Allowed:
+			report.Regressions = append(report.Regressions, change)
+		case change.Recorded && change.Current < change.Allowed:
+			report.Improvements = append(report.Improvements, change)
+		}
+	}
+
+	for _, entry := range recorded.Entries {
+		if _, ok := seen[entry.Key()]; ok || !analyzed[entry.Path] {
+			continue
+		}
+		report.Improvements = append(report.Improvements, domain.RatchetChange{
+			Path: entry.Path, Function: entry.Function, Metric: entry.Metric,
+			Allowed: entry.Value, Recorded: true,
+		})
+	}
+	return report
+}
+
+// MergeRatchet returns a ratchet holding the current entries of the analyzed
+// files. Entries of files outside the run are kept while the file exists
+// below root, so checking part of a project keeps the rest recorded.
+func MergeRatchet(recorded *domain.Ratchet, current []domain.RatchetEntry, analyzed map[string]bool, root, version string, thresholds map[string]int) *domain.Ratchet {
+	merged := &domain.Ratchet{Version: version, Thresholds: thresholds, Entries: append([]domain.RatchetEntry{}, current...)}
+	if recorded == nil {
+		return merged
+	}
+	for _, entry := range recorded.Entries {
+		if analyzed[entry.Path] {
+			continue
+		}
+		path := entry.Path
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(root, filepath.FromSlash(path))
+		}
+		if _, err := os.Stat(path); err == nil {
+			merged.Entries = append(merged.Entries, entry)
+		}
+	}
+	return merged
+}
+
+// RatchetPath returns filePath relative to root with forward slashes, or the
+// cleaned absolute path when it lies outside root
+func RatchetPath(filePath, root string) string {
+	abs, err := filepath.Abs(filePath)
+	if err != nil {
+		return filepath.ToSlash(filepath.Clean(filePath))
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(abs)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// WriteRatchetReportText lists the regressions of a ratchet check, then the
+// improvements
+func WriteRatchetReportText(w io.Writer, report *domain.RatchetReport) {
+	if len(report.Regressions) > 0 {
+		fmt.Fprintf(w, "REGRESSIONS (%d)\n", len(report.Regressions))
+		fmt.Fprintln(w, strings.Repeat("-", 80))
+		for _, change := range report.Regressions {
+			limit := "recorded"
+			if !change.Recorded {
+				limit = "threshold"
+			}
+			fmt.Fprintf(w, "  %s  %s %d > %d (%s)\n", ratchetLocation(change), change.Metric, change.Current, change.Allowed, limit)
+		}
+		fmt.Fprintln(w)
+	}
+	if len(report.Improvements) > 0 {
+		fmt.Fprintf(w, "IMPROVEMENTS (%d)\n", len(report.Improvements))
+		fmt.Fprintln(w, strings.Repeat("-", 80))
+		for _, change := range report.Improvements {
+			fmt.Fprintf(w, "  %s  %s %d -> %d\n", ratchetLocation(change), change.Metric, change.Allowed, change.Current)
+		}
+		fmt.Fprintln(w)
+	}
+	if len(report.Regressions) == 0 {
+		fmt.Fprintln(w, "No regressions against the ratchet.")
+	}
+}
+
+func ratchetLocation(change domain.RatchetChange) string {
+	location := change.Path
+	if change.Line > 0 {
+		location += fmt.Sprintf(":%d", change.Line)
+	}
+	if change.Function != "" {
+		location += " " + change.Function
+	}
+	return location
+}
+
+func sortRatchetEntries(entries []domain.RatchetEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Function != b.Function {
+			return a.Function < b.Function
+		}
+		return a.Metric < b.Metric
+	})
+}
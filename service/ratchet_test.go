@@ -0,0 +1,135 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+func ratchetTestResponse(root string) *domain.AnalyzeResponse {
+	file := func(name string) string { return filepath.Join(root, name) }
+	complexity := func(name, path string, value int) domain.FunctionComplexity {
+		return domain.FunctionComplexity{Name: name, FilePath: path, StartLine: 3,
+			Metrics: domain.ComplexityMetrics{Complexity: value, CognitiveComplexity: value}}
+	}
+	return &domain.AnalyzeResponse{
+		Complexity: &domain.ComplexityResponse{
+			Functions: []domain.FunctionComplexity{
+				complexity("legacy", file("a.py"), 14),
+				complexity("legacy", file("a.py"), 12),
+				complexity("small", file("a.py"), 4),
+				complexity(domain.ModuleFunctionName, file("a.py"), 40),
+			},
+			RawMetrics: []domain.RawMetrics{{FilePath: file("a.py")}, {FilePath: file("b.py")}},
+			Request:    &domain.ComplexityRequest{MaxComplexity: 10, CognitiveComplexityThreshold: 30},
+		},
+		DeadCode: &domain.DeadCodeResponse{Files: []domain.FileDeadCode{{
+			Functions: []domain.FunctionDeadCode{{Findings: []domain.DeadCodeFinding{
+				{Location: domain.DeadCodeLocation{FilePath: file("b.py")}},
+				{Location: domain.DeadCodeLocation{FilePath: file("b.py")}},
+			}}},
+		}}},
+	}
+}
+
+func TestCollectRatchetEntries(t *testing.T) {
+	root := t.TempDir()
+	response := ratchetTestResponse(root)
+
+	thresholds := RatchetThresholds(response)
+	if thresholds[domain.RatchetMetricComplexity] != 10 || thresholds[domain.RatchetMetricCognitiveComplexity] != 30 {
+		t.Fatalf("unexpected thresholds %v", thresholds)
+	}
+
+	entries := CollectRatchetEntries(response, root, thresholds)
+	var got []string
+	for _, entry := range entries {
+		got = append(got, fmt.Sprintf("%s %s %s=%d", entry.Path, entry.Function, entry.Metric, entry.Value))
+	}
+	want := []string{"a.py legacy complexity=14", "b.py  dead_code=2"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expected entries %v, got %v", want, got)
+	}
+}
+
+func TestCompareRatchet(t *testing.T) {
+	recorded := &domain.Ratchet{
+		Thresholds: map[string]int{domain.RatchetMetricComplexity: 10, domain.RatchetMetricDeadCode: 0},
+		Entries: []domain.RatchetEntry{
+			{Path: "a.py", Function: "legacy", Metric: domain.RatchetMetricComplexity, Value: 15},
+			{Path: "a.py", Function: "fixed", Metric: domain.RatchetMetricComplexity, Value: 20},
+			{Path: "b.py", Function: "grown", Metric: domain.RatchetMetricComplexity, Value: 11},
+			{Path: "other.py", Function: "untouched", Metric: domain.RatchetMetricComplexity, Value: 30},
+		},
+	}
+	current := []domain.RatchetEntry{
+		{Path: "a.py", Function: "legacy", Metric: domain.RatchetMetricComplexity, Value: 13},
+		{Path: "b.py", Function: "grown", Metric: domain.RatchetMetricComplexity, Value: 12},
+		{Path: "b.py", Metric: domain.RatchetMetricDeadCode, Value: 1},
+	}
+	analyzed := map[string]bool{"a.py": true, "b.py": true}
+
+	report := CompareRatchet(recorded, current, analyzed)
+
+	if len(report.Regressions) != 2 {
+		t.Fatalf("expected 2 regressions, got %+v", report.Regressions)
+	}
+	if r := report.Regressions[0]; r.Function != "grown" || !r.Recorded || r.Allowed != 11 || r.Current != 12 {
+		t.Errorf("unexpected recorded regression %+v", r)
+	}
+	if r := report.Regressions[1]; r.Metric != domain.RatchetMetricDeadCode || r.Recorded || r.Allowed != 0 {
+		t.Errorf("unexpected threshold regression %+v", r)
+	}
+	if len(report.Improvements) != 2 || report.Improvements[0].Current != 13 ||
+		report.Improvements[1].Function != "fixed" || report.Improvements[1].Current != 0 {
+		t.Errorf("unexpected improvements %+v", report.Improvements)
+	}
+
+	var buf bytes.Buffer
+	WriteRatchetReportText(&buf, report)
+	for _, expected := range []string{"REGRESSIONS (2)", "b.py grown  complexity 12 > 11 (recorded)", "dead_code 1 > 0 (threshold)", "IMPROVEMENTS (2)"} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("expected report to contain %q:\n%s", expected, buf.String())
+		}
+	}
+}
+
+func TestMergeRatchetRoundTrip(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "pyscn-ratchet.json")
+	if err := os.WriteFile(filepath.Join(root, "kept.py"), []byte("pass\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	missing, err := LoadRatchet(path)
+	if err != nil || missing != nil {
+		t.Fatalf("missing ratchet should load as nil, got %+v, %v", missing, err)
+	}
+
+	recorded := &domain.Ratchet{Entries: []domain.RatchetEntry{
+		{Path: "kept.py", Metric: domain.RatchetMetricClones, Value: 2},
+		{Path: "deleted.py", Metric: domain.RatchetMetricClones, Value: 2},
+		{Path: "a.py", Function: "old", Metric: domain.RatchetMetricComplexity, Value: 20},
+	}}
+	current := []domain.RatchetEntry{{Path: "a.py", Function: "legacy", Metric: domain.RatchetMetricComplexity, Value: 14}}
+	merged := MergeRatchet(recorded, current, map[string]bool{"a.py": true}, root, "1.0.0", map[string]int{"complexity": 10})
+	if err := SaveRatchet(path, merged); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadRatchet(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Version != "1.0.0" || loaded.Threshold(domain.RatchetMetricComplexity) != 10 {
+		t.Errorf("unexpected ratchet header %+v", loaded)
+	}
+	if len(loaded.Entries) != 2 || loaded.Entries[0].Function != "legacy" || loaded.Entries[1].Path != "kept.py" {
+		t.Errorf("expected the current entry and the entry of the existing unanalyzed file, got %+v", loaded.Entries)
+	}
+}
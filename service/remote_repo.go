@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// remoteURLSchemes are the URL prefixes accepted as remote analysis targets
+var remoteURLSchemes = []string{"https://", "http://", "ssh://", "git://", "file://"}
+
+// scpLikeURL matches scp-style git URLs such as git@github.com:org/repo
+var scpLikeURL = regexp.MustCompile(`^[A-Za-z0-9._-]+@[A-Za-z0-9.-]+:[^/\\]`)
+
+// RemoteTarget is a git repository to clone and analyze
+type RemoteTarget struct {
+	URL string
+	Ref string // Branch, tag or commit; empty for the default branch
+}
+
+// String returns the target in the url[@ref] form it was given in
+func (t RemoteTarget) String() string {
+	if t.Ref == "" {
+		return t.URL
+	}
+	return t.URL + "@" + t.Ref
+}
+
+// Name returns the repository name, e.g. "repo" for
+// https://github.com/org/repo.git
+func (t RemoteTarget) Name() string {
+	trimmed := strings.TrimSuffix(strings.TrimRight(t.URL, "/"), ".git")
+	if i := strings.LastIndexAny(trimmed, "/:"); i >= 0 {
+		trimmed = trimmed[i+1:]
+	}
+	if trimmed == "" {
+		return "repo"
+	}
+	return trimmed
+}
+
+// ParseRemoteTarget recognizes git URLs with an optional @ref suffix, e.g.
+// https://github.com/org/repo@v1.2.0. It returns false for anything else,
+// which callers treat as a local path, and for refs starting with "-",
+// which git would read as options.
+func ParseRemoteTarget(arg string) (RemoteTarget, bool) {
+	var pathStart int
+	switch {
+	case hasRemoteScheme(arg):
+		rest := arg[strings.Index(arg, "://")+3:]
+		slash := strings.Index(rest, "/")
+		if slash < 0 {
+			return RemoteTarget{}, false
+		}
+		pathStart = len(arg) - len(rest) + slash
+	case scpLikeURL.MatchString(arg):
+		pathStart = strings.Index(arg, ":")
+	default:
+		return RemoteTarget{}, false
+	}
+
+	// Only an "@" in the path part separates the ref; one before it
+	// belongs to the user name
+	target := RemoteTarget{URL: arg}
+	if at := strings.LastIndex(arg[pathStart:], "@"); at >= 0 {
+		target.URL = arg[:pathStart+at]
+		target.Ref = arg[pathStart+at+1:]
+	}
+	if strings.HasSuffix(arg, "@") || strings.HasPrefix(target.Ref, "-") || strings.Trim(target.URL[pathStart:], "/:") == "" {
+		return RemoteTarget{}, false
+	}
+	return target, true
+}
+
+func hasRemoteScheme(arg string) bool {
+	lower := strings.ToLower(arg)
+	for _, scheme := range remoteURLSchemes {
+		if strings.HasPrefix(lower, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// CloneRemoteTarget shallow-clones target into dir, which must not exist
+// or be empty. Authentication is left to git, so credential helpers and SSH
+// agents configured for the user apply.
+func CloneRemoteTarget(ctx context.Context, target RemoteTarget, dir string) error {
+	if strings.HasPrefix(target.Ref, "-") {
+		return fmt.Errorf("failed to clone %s: invalid ref %q", target, target.Ref)
+	}
+
+	// "--" keeps a URL or ref starting with "-" from being read as an option
+	var steps [][]string
+	if target.Ref == "" {
+		steps = [][]string{{"clone", "--quiet", "--depth", "1", "--", target.URL, dir}}
+	} else {
+		// Fetching the ref directly works for branches, tags and commits
+		steps = [][]string{
+			{"init", "--quiet", "--", dir},
+			{"-C", dir, "fetch", "--quiet", "--depth", "1", "--", target.URL, target.Ref},
+			{"-C", dir, "checkout", "--quiet", "FETCH_HEAD"},
+		}
+	}
+
+	for _, args := range steps {
+		cmd := exec.CommandContext(ctx, "git", args...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			_ = os.RemoveAll(dir)
+			return fmt.Errorf("failed to clone %s: %w: %s", target, err, strings.TrimSpace(string(out)))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRemoteTarget(t *testing.T) {
+	tests := []struct {
+		arg      string
+		ok       bool
+		expected RemoteTarget
+		name     string
+	}{
+		{arg: "https://github.com/org/repo", ok: true, expected: RemoteTarget{URL: "https://github.com/org/repo"}, name: "repo"},
+		{arg: "https://github.com/org/repo.git@v1.2.0", ok: true, expected: RemoteTarget{URL: "https://github.com/org/repo.git", Ref: "v1.2.0"}, name: "repo"},
+		{arg: "https://user@gitlab.com/org/sub/proj@main", ok: true, expected: RemoteTarget{URL: "https://user@gitlab.com/org/sub/proj", Ref: "main"}, name: "proj"},
+		{arg: "git@github.com:org/repo.git", ok: true, expected: RemoteTarget{URL: "git@github.com:org/repo.git"}, name: "repo"},
+		{arg: "git@github.com:org/repo@3f2a9c1", ok: true, expected: RemoteTarget{URL: "git@github.com:org/repo", Ref: "3f2a9c1"}, name: "repo"},
+		{arg: "ssh://git@host:2222/team/app.git@release/2.x", ok: true, expected: RemoteTarget{URL: "ssh://git@host:2222/team/app.git", Ref: "release/2.x"}, name: "app"},
+		{arg: "file:///srv/git/lib", ok: true, expected: RemoteTarget{URL: "file:///srv/git/lib"}, name: "lib"},
+		{arg: "https://github.com/", ok: false},
+		{arg: "https://github.com/org/repo@", ok: false},
+		{arg: "https://github.com/org/repo@--upload-pack=touch /tmp/x", ok: false},
+		{arg: "git@github.com:org/repo@-c", ok: false},
+		{arg: "src/", ok: false},
+		{arg: "./pkg@2", ok: false},
+		{arg: `C:\projects\app`, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			target, ok := ParseRemoteTarget(tt.arg)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.expected, target)
+				assert.Equal(t, tt.name, target.Name())
+				assert.Equal(t, tt.arg, target.String())
+			}
+		})
+	}
+}
+
+func TestCloneRemoteTarget(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	origin := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", origin, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git("init", "--quiet")
+	require.NoError(t, os.WriteFile(filepath.Join(origin, "app.py"), []byte("x = 1\n"), 0o644))
+	git("add", "app.py")
+	git("commit", "--quiet", "-m", "first")
+	git("tag", "v1")
+	require.NoError(t, os.WriteFile(filepath.Join(origin, "app.py"), []byte("x = 2\n"), 0o644))
+	git("commit", "--quiet", "-am", "second")
+
+	url := "file://" + filepath.ToSlash(origin)
+	tests := []struct {
+		ref      string
+		expected string
+	}{
+		{ref: "", expected: "x = 2\n"},
+		{ref: "v1", expected: "x = 1\n"},
+	}
+	for _, tt := range tests {
+		t.Run("ref="+tt.ref, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "clone")
+			require.NoError(t, CloneRemoteTarget(context.Background(), RemoteTarget{URL: url, Ref: tt.ref}, dir))
+
+			content, err := os.ReadFile(filepath.Join(dir, "app.py"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(content))
+		})
+	}
+
+	t.Run("option-like ref", func(t *testing.T) {
+		marker := filepath.Join(t.TempDir(), "marker")
+		dir := filepath.Join(t.TempDir(), "clone")
+		err := CloneRemoteTarget(context.Background(), RemoteTarget{URL: url, Ref: "--upload-pack=touch " + marker}, dir)
+		assert.Error(t, err)
+		assert.NoFileExists(t, marker)
+		assert.NoDirExists(t, dir)
+	})
+
+	t.Run("option-like url", func(t *testing.T) {
+		marker := filepath.Join(t.TempDir(), "marker")
+		dir := filepath.Join(t.TempDir(), "clone")
+		err := CloneRemoteTarget(context.Background(), RemoteTarget{URL: "--upload-pack=touch " + marker}, dir)
+		assert.Error(t, err)
+		assert.NoFileExists(t, marker)
+		assert.NoDirExists(t, dir)
+	})
+
+	t.Run("missing ref", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "clone")
+		err := CloneRemoteTarget(context.Background(), RemoteTarget{URL: url, Ref: "no-such-ref"}, dir)
+		assert.Error(t, err)
+		assert.NoDirExists(t, dir)
+	})
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// caseInsensitivePaths is true where file names are case-insensitive, so
+// include/exclude patterns match regardless of case
+var caseInsensitivePaths = runtime.GOOS == "windows"
+
+// trimLongPathPrefix removes the Windows extended-length prefix: \\?\C:\src
+// becomes C:\src and \\?\UNC\server\share becomes \\server\share
+func trimLongPathPrefix(path string) string {
+	if rest, ok := strings.CutPrefix(path, `\\?\UNC\`); ok {
+		return `\\` + rest
+	}
+	return strings.TrimPrefix(path, `\\?\`)
+}
+
+// ReportPathNormalizer rewrites the file paths of a report to a single form
+// so reports from different machines and platforms can be compared: forward
+// slashes, relative to the project root by default or absolute. Paths
+// outside the project root stay absolute.
+type ReportPathNormalizer struct {
+	root     string
+	absolute bool
+	paths    map[string]string // original path -> normalized path
+}
+
+// NewReportPathNormalizer creates a normalizer for paths under root
+func NewReportPathNormalizer(root string, absolute bool) *ReportPathNormalizer {
+	root = trimLongPathPrefix(root)
+	if abs, err := filepath.Abs(root); err == nil {
+		root = abs
+	}
+	return &ReportPathNormalizer{root: root, absolute: absolute, paths: make(map[string]string)}
+}
+
+// Root returns the project root with forward slashes
+func (n *ReportPathNormalizer) Root() string {
+	return filepath.ToSlash(n.root)
+}
+
+// Path returns the normalized form of a path reported by the analyzers,
+// which is absolute or relative to the working directory
+func (n *ReportPathNormalizer) Path(path string) string {
+	if path == "" {
+		return ""
+	}
+	if normalized, ok := n.paths[path]; ok {
+		return normalized
+	}
+
+	normalized := trimLongPathPrefix(path)
+	if abs, err := filepath.Abs(normalized); err == nil {
+		normalized = abs
+	}
+	if !n.absolute {
+		if rel, err := filepath.Rel(n.root, normalized); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			normalized = rel
+		}
+	}
+	normalized = filepath.ToSlash(normalized)
+	n.paths[path] = normalized
+	return normalized
+}
+
+// NormalizeResponse rewrites every file path of an analyze report in place
+// and records the project root in its manifest
+func (n *ReportPathNormalizer) NormalizeResponse(response *domain.AnalyzeResponse) {
+	if complexity := response.Complexity; complexity != nil {
+		for i := range complexity.Functions {
+			n.normalize(&complexity.Functions[i].FilePath)
+		}
+		for i := range complexity.RawMetrics {
+			n.normalize(&complexity.RawMetrics[i].FilePath)
+		}
+	}
+
+	if deadCode := response.DeadCode; deadCode != nil {
+		for i := range deadCode.Files {
+			file := &deadCode.Files[i]
+			n.normalize(&file.FilePath)
+			for j := range file.Functions {
+				function := &file.Functions[j]
+				n.normalize(&function.FilePath)
+				for k := range function.Findings {
+					n.normalize(&function.Findings[k].Location.FilePath)
+				}
+			}
+		}
+	}
+
+	if clone := response.Clone; clone != nil {
+		n.normalizeClones(clone)
+	}
+
+	if cbo := response.CBO; cbo != nil {
+		for i := range cbo.Classes {
+			n.normalize(&cbo.Classes[i].FilePath)
+		}
+	}
+	if lcom := response.LCOM; lcom != nil {
+		for i := range lcom.Classes {
+			n.normalize(&lcom.Classes[i].FilePath)
+		}
+	}
+
+	if system := response.System; system != nil {
+		n.normalizeSystem(system)
+	}
+
+	if mockData := response.MockData; mockData != nil {
+		for i := range mockData.Files {
+			file := &mockData.Files[i]
+			n.normalize(&file.FilePath)
+			for j := range file.Findings {
+				n.normalize(&file.Findings[j].Location.FilePath)
+			}
+		}
+	}
+
+	if docs := response.Documentation; docs != nil {
+		for i := range docs.Findings {
+			n.normalize(&docs.Findings[i].Location.FilePath)
+		}
+	}
+
+	if types := response.TypeCoverage; types != nil {
+		for i := range types.Files {
+			file := &types.Files[i]
+			n.normalize(&file.FilePath)
+			for j := range file.Functions {
+				n.normalize(&file.Functions[j].Location.FilePath)
+			}
+		}
+		for i := range types.Findings {
+			n.normalize(&types.Findings[i].Location.FilePath)
+		}
+	}
+
+	if ownership := response.Ownership; ownership != nil {
+		n.normalize(&ownership.CodeownersFile)
+		for i := range ownership.Owners {
+			n.normalizeOwnedFindings(&ownership.Owners[i])
+		}
+	}
+
+	if hotspots := response.Hotspots; hotspots != nil {
+		for i := range hotspots.Files {
+			n.normalize(&hotspots.Files[i].FilePath)
+		}
+	}
+
+	for i := range response.Packages {
+		pkg := &response.Packages[i]
+		n.normalize(&pkg.Path)
+		n.normalize(&pkg.Parent)
+		if pkg.Depth == 0 {
+			pkg.Name = pkg.Path
+		}
+	}
+
+	for i := range response.Vendored {
+		n.normalize(&response.Vendored[i].Path)
+	}
+
+	for i := range response.Suggestions {
+		n.normalize(&response.Suggestions[i].FilePath)
+	}
+
+	for i := range response.Warnings {
+		n.normalize(&response.Warnings[i].File)
+	}
+
+	if manifest := response.Manifest; manifest != nil {
+		n.normalize(&manifest.ConfigFile)
+		manifest.Root = n.Root()
+	}
+}
+
+func (n *ReportPathNormalizer) normalize(path *string) {
+	*path = n.Path(*path)
+}
+
+// normalizeClones rewrites clone locations. Pairs and groups share their
+// clones with the clone list, so each location is rewritten once.
+func (n *ReportPathNormalizer) normalizeClones(clone *domain.CloneResponse) {
+	seen := make(map[*domain.CloneLocation]bool)
+	location := func(c *domain.Clone) {
+		if c == nil || c.Location == nil || seen[c.Location] {
+			return
+		}
+		seen[c.Location] = true
+		n.normalize(&c.Location.FilePath)
+	}
+
+	for _, c := range clone.Clones {
+		location(c)
+	}
+	for _, pair := range clone.ClonePairs {
+		location(pair.Clone1)
+		location(pair.Clone2)
+	}
+	for _, group := range clone.CloneGroups {
+		for _, c := range group.Clones {
+			location(c)
+		}
+	}
+	for _, group := range clone.Locations {
+		n.normalize(&group.Location)
+		for i := range group.Groups {
+			lines := group.Groups[i].Lines
+			for j, line := range lines {
+				// By-package locations prefix each range with "file:"
+				if sep := strings.LastIndex(line, ":"); sep > 0 {
+					lines[j] = n.Path(line[:sep]) + line[sep:]
+				}
+			}
+		}
+	}
+}
+
+func (n *ReportPathNormalizer) normalizeSystem(system *domain.SystemAnalysisResponse) {
+	if deps := system.DependencyAnalysis; deps != nil {
+		for _, metrics := range deps.ModuleMetrics {
+			if metrics != nil {
+				n.normalize(&metrics.FilePath)
+			}
+		}
+		for i := range deps.UnanalyzableImports {
+			n.normalize(&deps.UnanalyzableImports[i].FilePath)
+		}
+		if inventory := deps.ImportInventory; inventory != nil {
+			for i := range inventory.ManifestFiles {
+				n.normalize(&inventory.ManifestFiles[i])
+			}
+			for i := range inventory.Packages {
+				locations := inventory.Packages[i].Locations
+				for j := range locations {
+					n.normalize(&locations[j].FilePath)
+				}
+			}
+		}
+	}
+
+	if arch := system.ArchitectureAnalysis; arch != nil {
+		for i := range arch.Violations {
+			if location := arch.Violations[i].Location; location != nil {
+				n.normalize(&location.FilePath)
+			}
+		}
+		if gods := arch.GodModuleAnalysis; gods != nil {
+			for i := range gods.GodModules {
+				n.normalize(&gods.GodModules[i].FilePath)
+			}
+		}
+	}
+}
+
+func (n *ReportPathNormalizer) normalizeOwnedFindings(owner *domain.OwnerSummary) {
+	for _, findings := range [][]domain.OwnedFinding{owner.HighComplexityFunctions, owner.DeadCodeFindings} {
+		for i := range findings {
+			n.normalize(&findings[i].FilePath)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportPathNormalizer_Path(t *testing.T) {
+	root := t.TempDir()
+	inside := filepath.Join(root, "pkg", "a.py")
+	outside := filepath.Join(filepath.Dir(root), "other", "b.py")
+
+	relative := NewReportPathNormalizer(root, false)
+	assert.Equal(t, "pkg/a.py", relative.Path(inside))
+	assert.Equal(t, filepath.ToSlash(outside), relative.Path(outside))
+	assert.Equal(t, "", relative.Path(""))
+
+	absolute := NewReportPathNormalizer(root, true)
+	assert.Equal(t, filepath.ToSlash(inside), absolute.Path(inside))
+	assert.Equal(t, filepath.ToSlash(root), absolute.Root())
+}
+
+func TestTrimLongPathPrefix(t *testing.T) {
+	assert.Equal(t, `C:\src\a.py`, trimLongPathPrefix(`\\?\C:\src\a.py`))
+	assert.Equal(t, `\\server\share\a.py`, trimLongPathPrefix(`\\?\UNC\server\share\a.py`))
+	assert.Equal(t, `/src/a.py`, trimLongPathPrefix(`/src/a.py`))
+}
+
+func TestReportPathNormalizer_NormalizeResponse(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, "pkg", "a.py")
+
+	// Clone groups share the locations of their clones; each is rewritten once
+	location := &domain.CloneLocation{FilePath: file, StartLine: 1, EndLine: 3}
+	clone := &domain.Clone{ID: 1, Location: location}
+	response := &domain.AnalyzeResponse{
+		Complexity: &domain.ComplexityResponse{
+			Functions: []domain.FunctionComplexity{{Name: "f", FilePath: file}},
+		},
+		Clone: &domain.CloneResponse{
+			Clones:      []*domain.Clone{clone},
+			ClonePairs:  []*domain.ClonePair{{Clone1: clone, Clone2: clone}},
+			CloneGroups: []*domain.CloneGroup{{Clones: []*domain.Clone{clone}}},
+		},
+		Vendored: []domain.VendoredCode{{Path: filepath.Join(root, "vendor"), Reason: domain.VendoredReasonLayout, Files: 1}},
+		Manifest: &domain.AnalysisManifest{},
+	}
+
+	NewReportPathNormalizer(root, false).NormalizeResponse(response)
+
+	assert.Equal(t, "pkg/a.py", response.Complexity.Functions[0].FilePath)
+	assert.Equal(t, "pkg/a.py", location.FilePath)
+	assert.Equal(t, "vendor", response.Vendored[0].Path)
+	assert.Equal(t, filepath.ToSlash(root), response.Manifest.Root)
+}
+
+func TestMatchPathPattern_CaseInsensitivePaths(t *testing.T) {
+	original := caseInsensitivePaths
+	t.Cleanup(func() { caseInsensitivePaths = original })
+
+	caseInsensitivePaths = false
+	assert.False(t, MatchPathPattern("tests/*.py", "Tests/test_a.py", false))
+
+	caseInsensitivePaths = true
+	assert.True(t, MatchPathPattern("tests/*.py", "Tests/test_a.py", false))
+	assert.True(t, MatchPathPattern("*.PY", "pkg/a.py", true))
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// workerMemoryBudget is the heap a single analysis worker is assumed to
+// need when sizing worker pools under a memory limit
+const workerMemoryBudget = 256 << 20
+
+// memoryLimitHeadroom is the share of the memory limit handed to the Go
+// runtime; the rest is left for stacks, binaries and the OS page cache
+const memoryLimitHeadroom = 0.9
+
+// ResourceLimits are soft limits for running inside constrained
+// environments such as containers. Zero values mean no limit.
+type ResourceLimits struct {
+	MaxCPU         int   // Maximum number of CPUs used for analysis
+	MaxMemoryBytes int64 // Target upper bound for memory use
+}
+
+// Workers returns the worker pool size that fits the limits: one worker
+// per allowed CPU, reduced so each keeps workerMemoryBudget of memory
+func (l ResourceLimits) Workers() int {
+	workers := runtime.NumCPU()
+	if l.MaxCPU > 0 && l.MaxCPU < workers {
+		workers = l.MaxCPU
+	}
+	if l.MaxMemoryBytes > 0 {
+		if byMemory := int(l.MaxMemoryBytes / workerMemoryBudget); byMemory < workers {
+			workers = byMemory
+		}
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	return workers
+}
+
+// ApplyResourceLimits sizes the runtime for the limits. Worker pools are
+// sized from GOMAXPROCS, so capping it bounds both CPU use and the number
+// of files parsed at once; the memory limit makes the garbage collector
+// work harder before the process grows past it.
+func ApplyResourceLimits(l ResourceLimits) {
+	if l.MaxCPU > 0 || l.MaxMemoryBytes > 0 {
+		runtime.GOMAXPROCS(l.Workers())
+	}
+	if l.MaxMemoryBytes > 0 {
+		debug.SetMemoryLimit(int64(float64(l.MaxMemoryBytes) * memoryLimitHeadroom))
+	}
+}
+
+// byteSizeUnits maps size suffixes to multipliers. Both decimal-looking
+// (K, M, G) and binary (Ki, Mi, Gi) suffixes are binary, as in container
+// runtimes.
+var byteSizeUnits = map[string]int64{
+	"":  1,
+	"k": 1 << 10,
+	"m": 1 << 20,
+	"g": 1 << 30,
+	"t": 1 << 40,
+}
+
+// ParseByteSize parses a memory size such as "512M", "2GiB" or "1.5g".
+// A bare number is a count of bytes.
+func ParseByteSize(s string) (int64, error) {
+	value := strings.ToLower(strings.TrimSpace(s))
+	value = strings.TrimSuffix(value, "b")
+	value = strings.TrimSuffix(value, "i")
+
+	unit := ""
+	if n := len(value); n > 0 && strings.ContainsRune("kmgt", rune(value[n-1])) {
+		unit, value = value[n-1:], value[:n-1]
+	}
+
+	number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || number <= 0 || math.IsInf(number, 0) {
+		return 0, fmt.Errorf("invalid size %q (expected e.g. 512M, 2GiB)", s)
+	}
+	bytes := number * float64(byteSizeUnits[unit])
+	if bytes > math.MaxInt64 {
+		return 0, fmt.Errorf("size %q is too large", s)
+	}
+	return int64(bytes), nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"1048576", 1 << 20},
+		{"512M", 512 << 20},
+		{"512MiB", 512 << 20},
+		{"512mb", 512 << 20},
+		{"2G", 2 << 30},
+		{"2Gi", 2 << 30},
+		{"1.5g", 3 << 29},
+		{"64k", 64 << 10},
+		{" 1T ", 1 << 40},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			size, err := ParseByteSize(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, size)
+		})
+	}
+
+	for _, input := range []string{"", "G", "-1G", "0", "lots", "2X", "1e400"} {
+		t.Run("invalid "+input, func(t *testing.T) {
+			_, err := ParseByteSize(input)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestResourceLimits_Workers(t *testing.T) {
+	cpus := runtime.NumCPU()
+
+	assert.Equal(t, cpus, ResourceLimits{}.Workers())
+	assert.Equal(t, 1, ResourceLimits{MaxCPU: 1}.Workers())
+	assert.Equal(t, cpus, ResourceLimits{MaxCPU: cpus + 8}.Workers())
+
+	// Memory caps workers at one per 256 MiB, but never below one
+	assert.Equal(t, 1, ResourceLimits{MaxMemoryBytes: 64 << 20}.Workers())
+	assert.Equal(t, min(cpus, 2), ResourceLimits{MaxMemoryBytes: 512 << 20}.Workers())
+	assert.Equal(t, 1, ResourceLimits{MaxCPU: 1, MaxMemoryBytes: 8 << 30}.Workers())
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// WriteScoreSimulationText writes the projected health score of each fix
+// and of all of them together
+func WriteScoreSimulationText(w io.Writer, simulation *domain.ScoreSimulation) {
+	fmt.Fprintf(w, "Health score: %d (%s)\n\n", simulation.HealthScore, simulation.Grade)
+	if len(simulation.Fixes) == 0 {
+		fmt.Fprintln(w, "No findings deduct points; there is nothing to fix.")
+		return
+	}
+
+	fmt.Fprintf(w, "%-16s %8s %10s %6s\n", "FIX", "PENALTY", "PROJECTED", "GAIN")
+	fmt.Fprintln(w, strings.Repeat("-", 43))
+	for _, fix := range simulation.Fixes {
+		fmt.Fprintf(w, "%-16s %8d %10d %6s\n", fix.Analysis, fix.Penalty, fix.ProjectedScore, fmt.Sprintf("%+d", fix.Gain))
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Fixing all of them: %d (%s) -> %d (%s), %+d\n",
+		simulation.HealthScore, simulation.Grade, simulation.ProjectedScore, simulation.ProjectedGrade, simulation.Gain)
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/config"
+)
+
+// SecurityConfigurationLoaderImpl implements the SecurityConfigurationLoader interface
+type SecurityConfigurationLoaderImpl struct{}
+
+// NewSecurityConfigurationLoader creates a new security configuration loader service
+func NewSecurityConfigurationLoader() *SecurityConfigurationLoaderImpl {
+	return &SecurityConfigurationLoaderImpl{}
+}
+
+// LoadConfig loads security configuration from the specified path using TOML-only strategy
+func (cl *SecurityConfigurationLoaderImpl) LoadConfig(path string) (*domain.SecurityRequest, error) {
+	// Use TOML-only loader
+	tomlLoader := config.NewTomlConfigLoader()
+	pyscnCfg, err := tomlLoader.LoadConfig(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config from %s: %w", path, err)
+	}
+
+	// Convert pyscn config to security request
+	return cl.configToRequest(pyscnCfg), nil
+}
+
+// LoadDefaultConfig loads the default security configuration, first checking for .pyscn.toml
+func (cl *SecurityConfigurationLoaderImpl) LoadDefaultConfig() *domain.SecurityRequest {
+	// First, try to find and load a config file in the current directory
+	configFile := cl.FindDefaultConfigFile()
+	if configFile != "" {
+		if configReq, err := cl.LoadConfig(configFile); err == nil {
+			return configReq
+		}
+		// If loading failed, fall back to hardcoded defaults
+	}
+
+	// Fall back to hardcoded default configuration
+	return domain.DefaultSecurityRequest()
+}
+
+// MergeConfig merges CLI flags with configuration file
+func (cl *SecurityConfigurationLoaderImpl) MergeConfig(base *domain.SecurityRequest, override *domain.SecurityRequest) *domain.SecurityRequest {
+	if base == nil {
+		return override
+	}
+	if override == nil {
+		return base
+	}
+
+	// Start with base config
+	merged := *base
+
+	// Always override paths as they come from command arguments
+	merged.Paths = config.MergeSlice(merged.Paths, override.Paths)
+
+	// Output configuration
+	merged.OutputFormat = config.Merge(merged.OutputFormat, override.OutputFormat)
+	if override.OutputWriter != nil {
+		merged.OutputWriter = override.OutputWriter
+	}
+	merged.OutputPath = config.Merge(merged.OutputPath, override.OutputPath)
+
+	// NoOpen flag
+	merged.NoOpen = override.NoOpen
+
+	// Filtering
+	merged.MinSeverity = config.Merge(merged.MinSeverity, override.MinSeverity)
+	merged.SortBy = config.Merge(merged.SortBy, override.SortBy)
+
+	// ConfigPath
+	merged.ConfigPath = config.Merge(merged.ConfigPath, override.ConfigPath)
+
+	// Analysis options
+	merged.Recursive = config.MergePtr(merged.Recursive, override.Recursive)
+	merged.IgnoreTests = config.MergePtr(merged.IgnoreTests, override.IgnoreTests)
+
+	// Array values
+	merged.IncludePatterns = config.MergeSlice(merged.IncludePatterns, override.IncludePatterns)
+	merged.ExcludePatterns = config.MergeSlice(merged.ExcludePatterns, override.ExcludePatterns)
+
+	return &merged
+}
+
+// configToRequest converts a PyscnConfig to domain.SecurityRequest
+func (cl *SecurityConfigurationLoaderImpl) configToRequest(pyscnCfg *config.PyscnConfig) *domain.SecurityRequest {
+	if pyscnCfg == nil {
+		return domain.DefaultSecurityRequest()
+	}
+
+	// Convert config values, falling back to defaults
+	minSeverity := domain.SecuritySeverity(pyscnCfg.SecurityMinSeverity)
+	if minSeverity == "" {
+		minSeverity = domain.SecuritySeverity(domain.DefaultSecurityMinSeverity)
+	}
+
+	return &domain.SecurityRequest{
+		OutputFormat:    domain.OutputFormat(pyscnCfg.Output.Format),
+		MinSeverity:     minSeverity,
+		IgnoreTests:     domain.BoolPtr(domain.BoolValue(pyscnCfg.SecurityIgnoreTests, domain.DefaultSecurityIgnoreTests)),
+		SortBy:          domain.SortBySeverity,
+		Recursive:       domain.BoolPtr(domain.BoolValue(pyscnCfg.AnalysisRecursive, true)),
+		IncludePatterns: pyscnCfg.AnalysisIncludePatterns,
+		ExcludePatterns: pyscnCfg.AnalysisExcludePatterns,
+	}
+}
+
+// FindDefaultConfigFile looks for TOML config files from the current directory upward.
+func (cl *SecurityConfigurationLoaderImpl) FindDefaultConfigFile() string {
+	tomlLoader := config.NewTomlConfigLoader()
+	return tomlLoader.FindConfigFileFromPath("")
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// SecurityFormatter implements the SecurityOutputFormatter interface
+type SecurityFormatter struct{}
+
+// NewSecurityFormatter creates a new security formatter
+func NewSecurityFormatter() *SecurityFormatter {
+	return &SecurityFormatter{}
+}
+
+// Format formats the analysis response according to the specified format
+func (f *SecurityFormatter) Format(response *domain.SecurityResponse, format domain.OutputFormat) (string, error) {
+	var sb strings.Builder
+	if err := f.Write(response, format, &sb); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// Write writes the formatted output to the writer
+func (f *SecurityFormatter) Write(response *domain.SecurityResponse, format domain.OutputFormat, writer io.Writer) error {
+	switch format {
+	case domain.OutputFormatJSON:
+		return f.writeJSON(response, writer)
+	case domain.OutputFormatYAML:
+		return f.writeYAML(response, writer)
+	case domain.OutputFormatText:
+		return f.writeText(response, writer)
+	case domain.OutputFormatCSV:
+		return f.writeCSV(response, writer)
+	default:
+		return f.writeJSON(response, writer)
+	}
+}
+
+// writeJSON writes output in JSON format
+func (f *SecurityFormatter) writeJSON(response *domain.SecurityResponse, writer io.Writer) error {
+	return WriteJSON(writer, response)
+}
+
+// writeYAML writes output in YAML format
+func (f *SecurityFormatter) writeYAML(response *domain.SecurityResponse, writer io.Writer) error {
+	return WriteYAML(writer, response)
+}
+
+// writeText writes output in human-readable text format
+func (f *SecurityFormatter) writeText(response *domain.SecurityResponse, writer io.Writer) error {
+	// Header
+	fmt.Fprintf(writer, "Security Analysis Results\n")
+	fmt.Fprintf(writer, "=========================\n\n")
+
+	// Summary
+	fmt.Fprintf(writer, "Summary:\n")
+	fmt.Fprintf(writer, "  Total Findings: %d\n", response.Summary.TotalFindings)
+	fmt.Fprintf(writer, "  Files Analyzed: %d\n", response.Summary.FilesAnalyzed)
+	fmt.Fprintf(writer, "  Affected Files: %d\n", response.Summary.AffectedFiles)
+	fmt.Fprintf(writer, "\n")
+
+	// By rule
+	if len(response.Summary.ByRule) > 0 {
+		rules := make([]string, 0, len(response.Summary.ByRule))
+		for rule := range response.Summary.ByRule {
+			rules = append(rules, string(rule))
+		}
+		sort.Strings(rules)
+
+		fmt.Fprintf(writer, "By Rule:\n")
+		for _, rule := range rules {
+			fmt.Fprintf(writer, "  %s: %d\n", rule, response.Summary.ByRule[domain.SecurityRule(rule)])
+		}
+		fmt.Fprintf(writer, "\n")
+	}
+
+	// By severity, most severe first
+	if len(response.Summary.BySeverity) > 0 {
+		fmt.Fprintf(writer, "By Severity:\n")
+		for _, s := range []domain.SecuritySeverity{domain.SecuritySeverityError, domain.SecuritySeverityWarning, domain.SecuritySeverityInfo} {
+			if count := response.Summary.BySeverity[s]; count > 0 {
+				fmt.Fprintf(writer, "  %s: %d\n", s, count)
+			}
+		}
+		fmt.Fprintf(writer, "\n")
+	}
+
+	// Findings
+	if len(response.Findings) > 0 {
+		fmt.Fprintf(writer, "Findings:\n")
+		fmt.Fprintf(writer, "---------\n")
+
+		for i, finding := range response.Findings {
+			fmt.Fprintf(writer, "\n%d. [%s] %s\n", i+1, strings.ToUpper(string(finding.Severity)), finding.Rule)
+			fmt.Fprintf(writer, "   Location: %s:%d:%d\n", finding.Location.FilePath, finding.Location.StartLine, finding.Location.StartCol)
+			fmt.Fprintf(writer, "   Function: %s\n", finding.FunctionName)
+			fmt.Fprintf(writer, "   Sink: %s\n", finding.Sink)
+			if finding.Source != "" {
+				fmt.Fprintf(writer, "   Source: %s\n", finding.Source)
+			}
+			fmt.Fprintf(writer, "   Description: %s\n", finding.Description)
+			fmt.Fprintf(writer, "   Suggestion: %s\n", finding.Suggestion)
+		}
+	}
+
+	// Warnings
+	if len(response.Warnings) > 0 {
+		fmt.Fprintf(writer, "\nWarnings:\n")
+		for _, warning := range response.Warnings {
+			fmt.Fprintf(writer, "  - %s\n", warning)
+		}
+	}
+
+	// Errors
+	if len(response.Errors) > 0 {
+		fmt.Fprintf(writer, "\nErrors:\n")
+		for _, err := range response.Errors {
+			fmt.Fprintf(writer, "  - %s\n", err)
+		}
+	}
+
+	fmt.Fprintf(writer, "\nGenerated at: %s\n", response.GeneratedAt)
+	fmt.Fprintf(writer, "Version: %s\n", response.Version)
+
+	return nil
+}
+
+// writeCSV writes output in CSV format
+func (f *SecurityFormatter) writeCSV(response *domain.SecurityResponse, writer io.Writer) error {
+	w := csv.NewWriter(writer)
+	if err := w.Write([]string{"rule", "severity", "function_name", "file_path", "start_line", "start_col", "sink", "source", "description", "suggestion"}); err != nil {
+		return err
+	}
+
+	for _, finding := range response.Findings {
+		record := []string{
+			string(finding.Rule),
+			string(finding.Severity),
+			finding.FunctionName,
+			finding.Location.FilePath,
+			strconv.Itoa(finding.Location.StartLine),
+			strconv.Itoa(finding.Location.StartCol),
+			finding.Sink,
+			finding.Source,
+			finding.Description,
+			finding.Suggestion,
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
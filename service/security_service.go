@@ -0,0 +1,159 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/analyzer"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+	"github.com/ludo-technologies/pyscn/internal/version"
+)
+
+// securitySuppressionPattern matches comments that silence a security finding
+// on their line. nosec is accepted so existing bandit annotations carry over.
+var securitySuppressionPattern = regexp.MustCompile(`(?i)#\s*(nosec|noqa|pyscn:\s*ignore)\b`)
+
+// SecurityServiceImpl implements the SecurityService interface
+type SecurityServiceImpl struct {
+	parser *parser.Parser
+}
+
+// NewSecurityService creates a new security pattern service
+func NewSecurityService() *SecurityServiceImpl {
+	return &SecurityServiceImpl{
+		parser: parser.New(),
+	}
+}
+
+// Analyze performs security pattern analysis on multiple files
+func (s *SecurityServiceImpl) Analyze(ctx context.Context, req domain.SecurityRequest) (*domain.SecurityResponse, error) {
+	var allFindings []domain.SecurityFinding
+	var warnings []string
+	var errors []string
+	filesProcessed := 0
+
+	for _, filePath := range req.Paths {
+		// Check context cancellation
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("security analysis cancelled: %w", ctx.Err())
+		default:
+		}
+
+		if domain.BoolValue(req.IgnoreTests, domain.DefaultSecurityIgnoreTests) && s.isTestFile(filePath) {
+			continue
+		}
+
+		fileFindings, fileErrors := s.analyzeFile(ctx, filePath, req)
+		if len(fileErrors) > 0 {
+			errors = append(errors, fileErrors...)
+			continue
+		}
+
+		allFindings = append(allFindings, fileFindings...)
+		filesProcessed++
+	}
+
+	sortedFindings := analyzer.SortSecurityFindings(allFindings, req.SortBy)
+	summary := analyzer.GenerateSecuritySummary(sortedFindings, filesProcessed)
+
+	return &domain.SecurityResponse{
+		Findings:    sortedFindings,
+		Summary:     summary,
+		Warnings:    warnings,
+		Errors:      errors,
+		GeneratedAt: time.Now().Format(time.RFC3339),
+		Version:     version.Version,
+		Config:      s.buildConfigForResponse(req),
+	}, nil
+}
+
+// AnalyzeFile analyzes a single Python file
+func (s *SecurityServiceImpl) AnalyzeFile(ctx context.Context, filePath string, req domain.SecurityRequest) (*domain.SecurityResponse, error) {
+	singleFileReq := req
+	singleFileReq.Paths = []string{filePath}
+	return s.Analyze(ctx, singleFileReq)
+}
+
+// analyzeFile performs security pattern analysis on a single file
+func (s *SecurityServiceImpl) analyzeFile(ctx context.Context, filePath string, req domain.SecurityRequest) ([]domain.SecurityFinding, []string) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, []string{fmt.Sprintf("[%s] Failed to read file: %v", filePath, err)}
+	}
+
+	result, err := s.parser.ParseFor(ctx, filePath, content)
+	if err != nil {
+		return nil, []string{fmt.Sprintf("[%s] Parse error: %v", filePath, err)}
+	}
+
+	minSeverity := req.MinSeverity
+	if minSeverity == "" {
+		minSeverity = domain.SecuritySeverity(domain.DefaultSecurityMinSeverity)
+	}
+	detector := analyzer.NewSecurityDetector(&analyzer.SecurityOptions{MinSeverity: minSeverity})
+
+	findings, err := detector.Analyze(result.AST, filePath)
+	if err != nil {
+		return nil, []string{fmt.Sprintf("[%s] Security analysis failed: %v", filePath, err)}
+	}
+
+	return filterSuppressedSecurityFindings(findings, content), nil
+}
+
+// filterSuppressedSecurityFindings drops findings whose sink starts on a line
+// carrying a suppression comment
+func filterSuppressedSecurityFindings(findings []domain.SecurityFinding, content []byte) []domain.SecurityFinding {
+	if len(findings) == 0 {
+		return findings
+	}
+	lines := bytes.Split(content, []byte("\n"))
+
+	kept := findings[:0]
+	for _, finding := range findings {
+		line := finding.Location.StartLine
+		if line >= 1 && line <= len(lines) && securitySuppressionPattern.Match(lines[line-1]) {
+			continue
+		}
+		kept = append(kept, finding)
+	}
+	return kept
+}
+
+// buildConfigForResponse creates config info for response
+func (s *SecurityServiceImpl) buildConfigForResponse(req domain.SecurityRequest) interface{} {
+	return map[string]interface{}{
+		"min_severity": req.MinSeverity,
+		"ignore_tests": domain.BoolValue(req.IgnoreTests, domain.DefaultSecurityIgnoreTests),
+		"sort_by":      req.SortBy,
+	}
+}
+
+// isTestFile checks if the file is a test file that should be skipped
+func (s *SecurityServiceImpl) isTestFile(filePath string) bool {
+	baseName := filepath.Base(filePath)
+
+	// Check file name patterns: test_*.py, *_test.py, conftest.py
+	if strings.HasPrefix(baseName, "test_") && strings.HasSuffix(baseName, ".py") {
+		return true
+	}
+	if strings.HasSuffix(baseName, "_test.py") || baseName == "conftest.py" {
+		return true
+	}
+
+	// Check directory patterns: tests/, test/, testing/, __tests__/
+	for _, part := range strings.Split(filepath.Dir(filePath), string(filepath.Separator)) {
+		switch part {
+		case "tests", "test", "testing", "__tests__":
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+func TestSecurityService_SuppressionAndTestFiles(t *testing.T) {
+	dir := t.TempDir()
+	source := `import pickle
+
+def load(data):
+    first = pickle.loads(data)
+    second = pickle.loads(data)  # nosec
+    third = pickle.loads(data)  # pyscn: ignore
+    return first, second, third
+`
+	appPath := filepath.Join(dir, "app.py")
+	testPath := filepath.Join(dir, "test_app.py")
+	for _, path := range []string{appPath, testPath} {
+		if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+
+	req := *domain.DefaultSecurityRequest()
+	req.Paths = []string{appPath, testPath}
+
+	response, err := NewSecurityService().Analyze(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if response.Summary.FilesAnalyzed != 1 {
+		t.Errorf("test file should be skipped, analyzed %d files", response.Summary.FilesAnalyzed)
+	}
+	if len(response.Findings) != 1 || response.Findings[0].Location.StartLine != 4 {
+		t.Fatalf("expected only the unsuppressed finding on line 4, got %+v", response.Findings)
+	}
+
+	req.IgnoreTests = domain.BoolPtr(false)
+	response, err = NewSecurityService().Analyze(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if response.Summary.FilesAnalyzed != 2 || response.Summary.AffectedFiles != 2 {
+		t.Errorf("expected both files analyzed and affected, got %+v", response.Summary)
+	}
+}
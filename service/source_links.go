@@ -0,0 +1,108 @@
+package service
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Link template presets for common editors
+var linkTemplatePresets = map[string]string{
+	"vscode":  "vscode://file{path}:{line}",
+	"cursor":  "cursor://file{path}:{line}",
+	"pycharm": "pycharm://open?file={path}&line={line}",
+	"idea":    "idea://open?file={path}&line={line}",
+}
+
+// LinkTemplatePresets returns the names of the built-in link templates
+func LinkTemplatePresets() []string {
+	return []string{"vscode", "cursor", "pycharm", "idea"}
+}
+
+// SourceLinker builds URLs that point at a file and line, e.g. to open
+// them in an editor or on a repository web host. Templates use these
+// placeholders:
+//
+//	{path}     absolute path with forward slashes and a leading "/"
+//	{relpath}  path relative to the project root
+//	{line}     first line of the reference
+//	{endline}  last line of the reference (same as {line} when unknown)
+//	{commit}   commit checked out at the project root ("HEAD" outside git)
+type SourceLinker struct {
+	template string
+	root     string
+	commit   string
+}
+
+// NewSourceLinker creates a linker from a preset name or URL template.
+// {relpath} is relative to root; relative file paths are taken to be
+// relative to root too, as analyze reports them.
+func NewSourceLinker(template, root string) (*SourceLinker, error) {
+	template = strings.TrimSpace(template)
+	if preset, ok := linkTemplatePresets[strings.ToLower(template)]; ok {
+		template = preset
+	}
+	if !strings.Contains(template, "{path}") && !strings.Contains(template, "{relpath}") {
+		return nil, fmt.Errorf("invalid link template %q: expected one of %s or a URL containing {path} or {relpath}",
+			template, strings.Join(LinkTemplatePresets(), ", "))
+	}
+
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		absRoot = root
+	}
+	linker := &SourceLinker{template: template, root: absRoot}
+	if strings.Contains(template, "{commit}") {
+		// Pinning links to the analyzed commit keeps shared reports valid
+		// after the branch moves on; web hosts resolve "HEAD" otherwise
+		linker.commit = "HEAD"
+		if provenance := gitProvenance(absRoot); provenance != nil {
+			linker.commit = provenance.Commit
+		}
+	}
+	return linker, nil
+}
+
+// URL returns the link for a file range. endLine may be 0 when unknown.
+func (l *SourceLinker) URL(path string, line, endLine int) string {
+	if line < 1 {
+		line = 1
+	}
+	if endLine < line {
+		endLine = line
+	}
+
+	absPath := filepath.FromSlash(path)
+	if !filepath.IsAbs(absPath) {
+		absPath = filepath.Join(l.root, absPath)
+	}
+	relPath, err := filepath.Rel(l.root, absPath)
+	if err != nil || strings.HasPrefix(relPath, "..") {
+		relPath = path
+	}
+
+	slashPath := filepath.ToSlash(absPath)
+	if !strings.HasPrefix(slashPath, "/") {
+		slashPath = "/" + slashPath // Windows drive paths, e.g. /C:/src/app.py
+	}
+
+	return strings.NewReplacer(
+		"{path}", escapeURLPath(slashPath),
+		"{relpath}", escapeURLPath(strings.TrimPrefix(filepath.ToSlash(relPath), "/")),
+		"{line}", strconv.Itoa(line),
+		"{endline}", strconv.Itoa(endLine),
+		"{commit}", l.commit,
+	).Replace(l.template)
+}
+
+// escapeURLPath escapes each path segment, keeping the separators. "&"
+// is escaped too because some templates put the path in a query string.
+func escapeURLPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = strings.ReplaceAll(url.PathEscape(segment), "&", "%26")
+	}
+	return strings.Join(segments, "/")
+}
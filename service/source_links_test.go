@@ -0,0 +1,95 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceLinker_URL(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		path     string
+		line     int
+		endLine  int
+		expected string
+	}{
+		{
+			name:     "vscode preset",
+			template: "vscode",
+			path:     "/repo/app/models.py",
+			line:     12,
+			expected: "vscode://file/repo/app/models.py:12",
+		},
+		{
+			name:     "pycharm preset",
+			template: "PyCharm",
+			path:     "/repo/app/models.py",
+			line:     3,
+			expected: "pycharm://open?file=/repo/app/models.py&line=3",
+		},
+		{
+			name:     "relative path in web template",
+			template: "https://gitlab.com/org/repo/-/blob/main/{relpath}#L{line}-{endline}",
+			path:     "/repo/app/models.py",
+			line:     5,
+			endLine:  9,
+			expected: "https://gitlab.com/org/repo/-/blob/main/app/models.py#L5-9",
+		},
+		{
+			name:     "end line defaults to start line",
+			template: "https://example.com/{relpath}#L{line}-L{endline}",
+			path:     "/repo/a.py",
+			line:     7,
+			expected: "https://example.com/a.py#L7-L7",
+		},
+		{
+			name:     "special characters are escaped",
+			template: "vscode",
+			path:     "/repo/my app/a&b#1.py",
+			line:     1,
+			expected: "vscode://file/repo/my%20app/a%26b%231.py:1",
+		},
+		{
+			name:     "file outside root keeps its path",
+			template: "https://example.com/{relpath}",
+			path:     "/other/a.py",
+			line:     1,
+			expected: "https://example.com/other/a.py",
+		},
+		{
+			name:     "report path relative to root",
+			template: "vscode",
+			path:     "app/models.py",
+			line:     4,
+			expected: "vscode://file/repo/app/models.py:4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linker, err := NewSourceLinker(tt.template, "/repo")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, linker.URL(tt.path, tt.line, tt.endLine))
+		})
+	}
+}
+
+func TestSourceLinker_URLWithCommit(t *testing.T) {
+	linker, err := NewSourceLinker("https://github.com/org/repo/blob/{commit}/{relpath}#L{line}", t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, "HEAD", linker.commit, "outside a git work tree")
+
+	linker.commit = "3f2a9c1"
+	assert.Equal(t, "https://github.com/org/repo/blob/3f2a9c1/app/models.py#L8", linker.URL("app/models.py", 8, 0))
+}
+
+func TestNewSourceLinker_RejectsTemplateWithoutPath(t *testing.T) {
+	_, err := NewSourceLinker("https://example.com/#L{line}", "/repo")
+	assert.Error(t, err)
+
+	_, err = NewSourceLinker("sublime", "/repo")
+	assert.Error(t, err)
+}
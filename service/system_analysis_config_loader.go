@@ -51,6 +51,18 @@ func (cl *SystemAnalysisConfigurationLoaderImpl) pyscnConfigToSystemAnalysisRequ
 	if cfg.DependenciesDetectCycles != nil {
 		request.DetectCycles = cfg.DependenciesDetectCycles
 	}
+	if cfg.DependenciesIncludeTypeChecking != nil {
+		request.IncludeTypeChecking = cfg.DependenciesIncludeTypeChecking
+	}
+	if cfg.DependenciesFacadeMinExports > 0 {
+		request.FacadeMinExports = cfg.DependenciesFacadeMinExports
+	}
+	if cfg.DependenciesCollapseFacades != nil {
+		request.CollapseFacades = cfg.DependenciesCollapseFacades
+	}
+	if cfg.DependenciesCycleIgnoreEdges != nil {
+		request.CycleIgnoreEdgeKinds = cfg.DependenciesCycleIgnoreEdges
+	}
 	if cfg.ArchitectureValidateCohesion != nil {
 		request.ValidateCohesion = cfg.ArchitectureValidateCohesion
 	}
@@ -69,17 +81,38 @@ func (cl *SystemAnalysisConfigurationLoaderImpl) pyscnConfigToSystemAnalysisRequ
 	if cfg.ArchitectureResponsibilityViolationSeverity != "" {
 		request.ResponsibilityViolationSeverity = parseViolationSeverity(cfg.ArchitectureResponsibilityViolationSeverity)
 	}
+	if cfg.ArchitectureValidateGodModules != nil {
+		request.ValidateGodModules = cfg.ArchitectureValidateGodModules
+	}
+	if cfg.ArchitectureGodModuleMaxLines > 0 {
+		request.GodModuleMaxLines = cfg.ArchitectureGodModuleMaxLines
+	}
+	if cfg.ArchitectureGodModuleMaxDefinitions > 0 {
+		request.GodModuleMaxDefinitions = cfg.ArchitectureGodModuleMaxDefinitions
+	}
+	if cfg.ArchitectureGodModuleMaxFanIn > 0 {
+		request.GodModuleMaxFanIn = cfg.ArchitectureGodModuleMaxFanIn
+	}
+	if cfg.ArchitectureGodModuleMaxFanOut > 0 {
+		request.GodModuleMaxFanOut = cfg.ArchitectureGodModuleMaxFanOut
+	}
 
 	// Architecture settings
 	if rules := ArchitectureRulesFromPyscnConfig(cfg); rules != nil {
 		request.ArchitectureRules = rules
 	}
 
-	// Analysis settings (include/exclude patterns)
-	if cfg.HasExplicitAnalysisIncludePatterns() {
+	// Analysis settings (include/exclude patterns); [dependencies] patterns
+	// take precedence over [analysis]
+	scope, scoped := cfg.AnalyzerScopes[domain.AnalysisScopeDependencies]
+	if scoped && scope.IncludePatterns != nil {
+		request.IncludePatterns = scope.IncludePatterns
+	} else if cfg.HasExplicitAnalysisIncludePatterns() {
 		request.IncludePatterns = cfg.AnalysisIncludePatterns
 	}
-	if len(cfg.AnalysisExcludePatterns) > 0 {
+	if scoped && scope.ExcludePatterns != nil {
+		request.ExcludePatterns = scope.ExcludePatterns
+	} else if len(cfg.AnalysisExcludePatterns) > 0 {
 		request.ExcludePatterns = cfg.AnalysisExcludePatterns
 	}
 	if cfg.AnalysisRecursive != nil {
@@ -106,6 +139,13 @@ func (cl *SystemAnalysisConfigurationLoaderImpl) LoadDefaultConfig() *domain.Sys
 		MaxResponsibilities:             domain.DefaultArchitectureMaxResponsibilities,
 		CohesionViolationSeverity:       domain.ViolationSeverityWarning,
 		ResponsibilityViolationSeverity: domain.ViolationSeverityWarning,
+		ValidateGodModules:              domain.BoolPtr(true),
+		GodModuleMaxLines:               domain.DefaultGodModuleMaxLines,
+		GodModuleMaxDefinitions:         domain.DefaultGodModuleMaxDefinitions,
+		GodModuleMaxFanIn:               domain.DefaultGodModuleMaxFanIn,
+		GodModuleMaxFanOut:              domain.DefaultGodModuleMaxFanOut,
+		FacadeMinExports:                domain.DefaultFacadeMinExports,
+		CollapseFacades:                 domain.BoolPtr(false),
 		Recursive:                       domain.BoolPtr(true),
 		IncludePatterns:                 domain.DefaultPythonModuleIncludePatterns(),
 		ExcludePatterns:                 domain.DefaultAnalysisExcludePatterns(),
@@ -135,6 +175,7 @@ func (cl *SystemAnalysisConfigurationLoaderImpl) MergeConfig(base *domain.System
 
 	// Boolean flags - CLI always takes precedence for explicit settings
 	merged.NoOpen = override.NoOpen
+	merged.DetectArchitecture = override.DetectArchitecture
 
 	// Analysis type overrides - only override if explicitly set (non-nil)
 	merged.AnalyzeDependencies = config.MergePtr(merged.AnalyzeDependencies, override.AnalyzeDependencies)
@@ -145,6 +186,10 @@ func (cl *SystemAnalysisConfigurationLoaderImpl) MergeConfig(base *domain.System
 	merged.IncludeThirdParty = config.MergePtr(merged.IncludeThirdParty, override.IncludeThirdParty)
 	merged.FollowRelative = config.MergePtr(merged.FollowRelative, override.FollowRelative)
 	merged.DetectCycles = config.MergePtr(merged.DetectCycles, override.DetectCycles)
+	merged.IncludeTypeChecking = config.MergePtr(merged.IncludeTypeChecking, override.IncludeTypeChecking)
+	if override.CycleIgnoreEdgeKinds != nil {
+		merged.CycleIgnoreEdgeKinds = override.CycleIgnoreEdgeKinds
+	}
 	merged.ValidateArchitecture = config.MergePtr(merged.ValidateArchitecture, override.ValidateArchitecture)
 	merged.ValidateCohesion = config.MergePtr(merged.ValidateCohesion, override.ValidateCohesion)
 	merged.ValidateResponsibility = config.MergePtr(merged.ValidateResponsibility, override.ValidateResponsibility)
@@ -152,6 +197,13 @@ func (cl *SystemAnalysisConfigurationLoaderImpl) MergeConfig(base *domain.System
 	merged.MaxResponsibilities = config.Merge(merged.MaxResponsibilities, override.MaxResponsibilities)
 	merged.CohesionViolationSeverity = config.Merge(merged.CohesionViolationSeverity, override.CohesionViolationSeverity)
 	merged.ResponsibilityViolationSeverity = config.Merge(merged.ResponsibilityViolationSeverity, override.ResponsibilityViolationSeverity)
+	merged.ValidateGodModules = config.MergePtr(merged.ValidateGodModules, override.ValidateGodModules)
+	merged.GodModuleMaxLines = config.Merge(merged.GodModuleMaxLines, override.GodModuleMaxLines)
+	merged.GodModuleMaxDefinitions = config.Merge(merged.GodModuleMaxDefinitions, override.GodModuleMaxDefinitions)
+	merged.GodModuleMaxFanIn = config.Merge(merged.GodModuleMaxFanIn, override.GodModuleMaxFanIn)
+	merged.GodModuleMaxFanOut = config.Merge(merged.GodModuleMaxFanOut, override.GodModuleMaxFanOut)
+	merged.FacadeMinExports = config.Merge(merged.FacadeMinExports, override.FacadeMinExports)
+	merged.CollapseFacades = config.MergePtr(merged.CollapseFacades, override.CollapseFacades)
 
 	// File selection
 	merged.IncludePatterns = config.MergeSlice(merged.IncludePatterns, override.IncludePatterns)
@@ -187,6 +239,15 @@ func (cl *SystemAnalysisConfigurationLoaderImpl) MergeConfig(base *domain.System
 			if len(override.ArchitectureRules.NeutralPrefixes) > 0 {
 				merged.ArchitectureRules.NeutralPrefixes = override.ArchitectureRules.NeutralPrefixes
 			}
+			if len(override.ArchitectureRules.IgnoreEdgeKinds) > 0 {
+				merged.ArchitectureRules.IgnoreEdgeKinds = override.ArchitectureRules.IgnoreEdgeKinds
+			}
+			if len(override.ArchitectureRules.DowngradeEdgeKinds) > 0 {
+				merged.ArchitectureRules.DowngradeEdgeKinds = override.ArchitectureRules.DowngradeEdgeKinds
+			}
+			if len(override.ArchitectureRules.RuleSets) > 0 {
+				merged.ArchitectureRules.RuleSets = override.ArchitectureRules.RuleSets
+			}
 		}
 	}
 
@@ -239,10 +300,11 @@ func convertLayerRules(rules []config.LayerRule) []domain.LayerRule {
 	out := make([]domain.LayerRule, len(rules))
 	for i, r := range rules {
 		out[i] = domain.LayerRule{
-			From:  r.From,
-			Allow: r.Allow,
-			Deny:  r.Deny,
-			Warn:  r.Warn,
+			From:   r.From,
+			Allow:  r.Allow,
+			Deny:   r.Deny,
+			Warn:   r.Warn,
+			Budget: r.Budget,
 		}
 	}
 	return out
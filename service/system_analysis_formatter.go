@@ -2,7 +2,6 @@ package service
 
 import (
 	"encoding/csv"
-	"encoding/json"
 	"fmt"
 	"io"
 	"sort"
@@ -10,7 +9,6 @@ import (
 	"strings"
 
 	"github.com/ludo-technologies/pyscn/domain"
-	"gopkg.in/yaml.v3"
 )
 
 // SystemAnalysisFormatterImpl implements the SystemAnalysisOutputFormatter interface
@@ -122,6 +120,10 @@ func (f *SystemAnalysisFormatterImpl) writeDependenciesSection(builder *strings.
 				}
 				builder.WriteString(utils.FormatLabelWithIndent(SectionPadding*2, fmt.Sprintf("Cycle %d", i+1),
 					fmt.Sprintf("%s (%d modules)", cycle.Description, len(cycle.Modules))))
+				if cycle.HeaviestEdge != nil {
+					builder.WriteString(utils.FormatLabelWithIndent(SectionPadding*3, "Heaviest edge",
+						formatHeaviestEdge(cycle.HeaviestEdge)))
+				}
 			}
 
 			// Cycle breaking suggestions
@@ -172,6 +174,40 @@ func (f *SystemAnalysisFormatterImpl) writeDependenciesSection(builder *strings.
 		builder.WriteString("\n")
 	}
 
+	// Dynamic imports
+	if deps.DynamicDependencies > 0 || len(deps.UnanalyzableImports) > 0 {
+		builder.WriteString(utils.FormatSectionHeader("DYNAMIC IMPORTS"))
+		builder.WriteString(utils.FormatLabelWithIndent(SectionPadding, "Dynamic Edges", strconv.Itoa(deps.DynamicDependencies)))
+		builder.WriteString(utils.FormatLabelWithIndent(SectionPadding, "Unanalyzable", strconv.Itoa(len(deps.UnanalyzableImports))))
+		for i, imp := range deps.UnanalyzableImports {
+			if i >= 10 {
+				builder.WriteString(utils.FormatLabelWithIndent(SectionPadding*2, "...", fmt.Sprintf("and %d more", len(deps.UnanalyzableImports)-i)))
+				break
+			}
+			builder.WriteString(utils.FormatLabelWithIndent(SectionPadding*2, fmt.Sprintf("%s:%d", imp.FilePath, imp.Line),
+				fmt.Sprintf("%s() with a non-literal module name", imp.Function)))
+		}
+		builder.WriteString("\n")
+	}
+
+	// Re-export facades
+	if len(deps.Facades) > 0 {
+		builder.WriteString(utils.FormatSectionHeader("RE-EXPORT FACADES"))
+		for _, facade := range deps.Facades {
+			detail := fmt.Sprintf("%d names from %d modules", facade.Exports, facade.FanOut)
+			if facade.Collapsed {
+				detail += " (collapsed)"
+			}
+			builder.WriteString(utils.FormatLabelWithIndent(SectionPadding, facade.Module, detail))
+		}
+		builder.WriteString("\n")
+	}
+
+	// External imports
+	if deps.ImportInventory != nil && len(deps.ImportInventory.Packages) > 0 {
+		writeImportInventorySection(builder, deps.ImportInventory, utils, false)
+	}
+
 	builder.WriteString(utils.FormatSectionSeparator())
 }
 
@@ -198,6 +234,45 @@ func (f *SystemAnalysisFormatterImpl) writeArchitectureSection(builder *strings.
 		}
 	}
 
+	if len(arch.RuleSets) > 0 {
+		builder.WriteString(utils.FormatSectionHeader("RULE SETS"))
+		for _, ruleSet := range arch.RuleSets {
+			builder.WriteString(utils.FormatLabelWithIndent(SectionPadding, ruleSet.Name,
+				fmt.Sprintf("%.1f%% compliant, %d violations in %d modules (%s)", ruleSet.ComplianceScore*100,
+					ruleSet.TotalViolations, ruleSet.ModulesAnalyzed, strings.Join(ruleSet.Scopes, ", "))))
+			shown := 0
+			for _, violation := range arch.Violations {
+				if violation.RuleSet != ruleSet.Name {
+					continue
+				}
+				if shown >= 10 {
+					builder.WriteString(utils.FormatLabelWithIndent(ItemPadding, "...",
+						fmt.Sprintf("and %d more violations", ruleSet.TotalViolations-shown)))
+					break
+				}
+				builder.WriteString(utils.FormatLabelWithIndent(ItemPadding, "Rule",
+					fmt.Sprintf("%s: %s -> %s (%s)", violation.Rule, violation.Module, violation.Target, violation.Severity)))
+				shown++
+			}
+		}
+		builder.WriteString("\n")
+	}
+
+	if arch.LayerDetection != nil && len(arch.LayerDetection.Assignments) > 0 {
+		builder.WriteString(utils.FormatSectionHeader("DETECTED LAYERS"))
+		builder.WriteString("  No layers configured; detected from module names. Run 'pyscn arch init' to write them to the config.\n")
+		for i, assignment := range arch.LayerDetection.Assignments {
+			if i >= 20 {
+				builder.WriteString(utils.FormatLabelWithIndent(SectionPadding, "...",
+					fmt.Sprintf("and %d more modules", len(arch.LayerDetection.Assignments)-i)))
+				break
+			}
+			builder.WriteString(utils.FormatLabelWithIndent(SectionPadding, assignment.Module,
+				fmt.Sprintf("%s (%.0f%%)", assignment.Layer, assignment.Confidence*100)))
+		}
+		builder.WriteString("\n")
+	}
+
 	if arch.ResponsibilityAnalysis != nil && len(arch.ResponsibilityAnalysis.SRPViolations) > 0 {
 		builder.WriteString(utils.FormatSectionHeader("RESPONSIBILITY VIOLATIONS"))
 		for i, violation := range arch.ResponsibilityAnalysis.SRPViolations {
@@ -212,6 +287,17 @@ func (f *SystemAnalysisFormatterImpl) writeArchitectureSection(builder *strings.
 		builder.WriteString("\n")
 	}
 
+	if arch.GodModuleAnalysis != nil && len(arch.GodModuleAnalysis.GodModules) > 0 {
+		builder.WriteString(utils.FormatSectionHeader("GOD MODULES"))
+		for _, god := range arch.GodModuleAnalysis.GodModules {
+			builder.WriteString(utils.FormatLabelWithIndent(SectionPadding, god.Module,
+				fmt.Sprintf("%s %d lines, %d classes, %d functions, fan-in %d, fan-out %d",
+					god.Severity, god.Lines, god.Classes, god.Functions, god.FanIn, god.FanOut)))
+			builder.WriteString(utils.FormatLabelWithIndent(ItemPadding, "Suggestion", god.Suggestion))
+		}
+		builder.WriteString("\n")
+	}
+
 	if arch.CohesionAnalysis != nil && len(arch.CohesionAnalysis.LowCohesionPackages) > 0 {
 		builder.WriteString(utils.FormatSectionHeader("LOW PACKAGE COHESION"))
 		for _, pkg := range arch.CohesionAnalysis.LowCohesionPackages {
@@ -234,20 +320,12 @@ func (f *SystemAnalysisFormatterImpl) writeArchitectureSection(builder *strings.
 
 // formatJSON formats the response as JSON
 func (f *SystemAnalysisFormatterImpl) formatJSON(response *domain.SystemAnalysisResponse) (string, error) {
-	data, err := json.MarshalIndent(response, "", "  ")
-	if err != nil {
-		return "", err
-	}
-	return string(data), nil
+	return EncodeJSON(response)
 }
 
 // formatYAML formats the response as YAML
 func (f *SystemAnalysisFormatterImpl) formatYAML(response *domain.SystemAnalysisResponse) (string, error) {
-	data, err := yaml.Marshal(response)
-	if err != nil {
-		return "", err
-	}
-	return string(data), nil
+	return EncodeYAML(response)
 }
 
 // formatCSV formats the response as CSV
@@ -614,6 +692,9 @@ func (f *SystemAnalysisFormatterImpl) writeHTMLDependenciesContent(builder *stri
 				if pathCount > 5 {
 					pathsHTML.WriteString(`<br><em style="font-size: 11px; color: #666;">... and ` + strconv.Itoa(pathCount-5) + ` more paths</em>`)
 				}
+				if cycle.HeaviestEdge != nil {
+					pathsHTML.WriteString(`<br><span style="font-size: 11px; color: #666;">Heaviest edge: ` + EscapeHTML(formatHeaviestEdge(cycle.HeaviestEdge)) + `</span>`)
+				}
 
 				builder.WriteString(`
                     <tr>
@@ -858,6 +939,98 @@ func (f *SystemAnalysisFormatterImpl) writeHTMLArchitectureContent(builder *stri
             </table>`)
 	}
 
+	if len(arch.RuleSets) > 0 {
+		builder.WriteString(GenerateSectionHeader("Rule Sets"))
+		builder.WriteString(`
+            <table class="table">
+                <thead>
+                    <tr>
+                        <th>Rule Set</th>
+                        <th>Scopes</th>
+                        <th>Modules</th>
+                        <th>Violations</th>
+                        <th>Compliance</th>
+                    </tr>
+                </thead>
+                <tbody>`)
+		for _, ruleSet := range arch.RuleSets {
+			builder.WriteString(`
+                    <tr>
+                        <td>` + EscapeHTML(ruleSet.Name) + `</td>
+                        <td>` + JoinEscapedHTML(ruleSet.Scopes, ", ") + `</td>
+                        <td>` + strconv.Itoa(ruleSet.ModulesAnalyzed) + `</td>
+                        <td>` + strconv.Itoa(ruleSet.TotalViolations) + `</td>
+                        <td>` + fmt.Sprintf("%.1f%%", ruleSet.ComplianceScore*100) + `</td>
+                    </tr>`)
+		}
+		builder.WriteString(`
+                </tbody>
+            </table>`)
+	}
+
+	if arch.LayerDetection != nil && len(arch.LayerDetection.Assignments) > 0 {
+		builder.WriteString(GenerateSectionHeader("Detected Layers"))
+		builder.WriteString(`
+            <p>No layers are configured, so they were detected from module names. Run <code>pyscn arch init</code> to write them to your config and edit them.</p>
+            <table class="table">
+                <thead>
+                    <tr>
+                        <th>Module</th>
+                        <th>Layer</th>
+                        <th>Confidence</th>
+                        <th>Other Candidates</th>
+                    </tr>
+                </thead>
+                <tbody>`)
+		for _, assignment := range arch.LayerDetection.Assignments {
+			builder.WriteString(`
+                    <tr>
+                        <td>` + EscapeHTML(assignment.Module) + `</td>
+                        <td>` + EscapeHTML(assignment.Layer) + `</td>
+                        <td>` + fmt.Sprintf("%.0f%%", assignment.Confidence*100) + `</td>
+                        <td>` + JoinEscapedHTML(assignment.Candidates, ", ") + `</td>
+                    </tr>`)
+		}
+		builder.WriteString(`
+                </tbody>
+            </table>`)
+	}
+
+	if arch.GodModuleAnalysis != nil && len(arch.GodModuleAnalysis.GodModules) > 0 {
+		builder.WriteString(GenerateSectionHeader("God Modules"))
+		builder.WriteString(`
+            <table class="table">
+                <thead>
+                    <tr>
+                        <th>Severity</th>
+                        <th>Module</th>
+                        <th>Lines</th>
+                        <th>Classes</th>
+                        <th>Functions</th>
+                        <th>Fan-in</th>
+                        <th>Fan-out</th>
+                        <th>Suggestion</th>
+                    </tr>
+                </thead>
+                <tbody>`)
+		for _, god := range arch.GodModuleAnalysis.GodModules {
+			builder.WriteString(`
+                    <tr>
+                        <td>` + architectureSeverityBadge(god.Severity) + `</td>
+                        <td>` + EscapeHTML(god.Module) + `</td>
+                        <td>` + strconv.Itoa(god.Lines) + `</td>
+                        <td>` + strconv.Itoa(god.Classes) + `</td>
+                        <td>` + strconv.Itoa(god.Functions) + `</td>
+                        <td>` + strconv.Itoa(god.FanIn) + `</td>
+                        <td>` + strconv.Itoa(god.FanOut) + `</td>
+                        <td>` + EscapeHTML(god.Suggestion) + `</td>
+                    </tr>`)
+		}
+		builder.WriteString(`
+                </tbody>
+            </table>`)
+	}
+
 	if arch.CohesionAnalysis != nil && len(arch.CohesionAnalysis.LowCohesionPackages) > 0 {
 		builder.WriteString(GenerateSectionHeader("Package Cohesion"))
 		builder.WriteString(`<div class="metric-grid">`)
@@ -923,3 +1096,13 @@ func min(a, b int) int {
 	}
 	return b
 }
+
+// formatHeaviestEdge describes the heaviest edge of a cycle with the number
+// of names imported over it
+func formatHeaviestEdge(edge *domain.DependencyPath) string {
+	names := "names"
+	if edge.Weight == 1 {
+		names = "name"
+	}
+	return fmt.Sprintf("%s -> %s (%d %s)", edge.From, edge.To, edge.Weight, names)
+}
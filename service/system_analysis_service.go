@@ -53,7 +53,7 @@ func (s *SystemAnalysisServiceImpl) Analyze(ctx context.Context, req domain.Syst
 	// Analyze dependencies if requested
 	var dependencyResult *domain.DependencyAnalysisResult
 	if analyzeDependencies && graph != nil {
-		result, err := s.buildDependencyAnalysisResult(ctx, graph)
+		result, err := s.buildDependencyAnalysisResult(ctx, graph, req)
 		if err != nil {
 			errors = append(errors, fmt.Sprintf("Dependency analysis failed: %v", err))
 		} else {
@@ -349,14 +349,22 @@ func (s *SystemAnalysisServiceImpl) AnalyzeDependencies(ctx context.Context, req
 	if err != nil {
 		return nil, err
 	}
-	return s.buildDependencyAnalysisResult(ctx, graph)
+	return s.buildDependencyAnalysisResult(ctx, graph, req)
 }
 
-func (s *SystemAnalysisServiceImpl) buildDependencyAnalysisResult(ctx context.Context, graph *analyzer.DependencyGraph) (*domain.DependencyAnalysisResult, error) {
+func (s *SystemAnalysisServiceImpl) buildDependencyAnalysisResult(ctx context.Context, graph *analyzer.DependencyGraph, req domain.SystemAnalysisRequest) (*domain.DependencyAnalysisResult, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, fmt.Errorf("dependency analysis cancelled: %w", err)
 	}
 
+	cycleIgnored := analyzer.DefaultCycleIgnoredEdgeKinds()
+	if req.CycleIgnoreEdgeKinds != nil {
+		var err error
+		if cycleIgnored, err = analyzer.ParseEdgeKindSet(req.CycleIgnoreEdgeKinds); err != nil {
+			return nil, fmt.Errorf("invalid cycle_ignore_edges: %w", err)
+		}
+	}
+
 	// Check if any modules were processed
 	if graph.TotalModules == 0 {
 		return &domain.DependencyAnalysisResult{
@@ -369,7 +377,7 @@ func (s *SystemAnalysisServiceImpl) buildDependencyAnalysisResult(ctx context.Co
 	}
 
 	// Detect circular dependencies
-	circularDetector := analyzer.NewCircularDependencyDetector(graph)
+	circularDetector := analyzer.NewCircularDependencyDetector(graph).WithIgnoredEdgeKinds(cycleIgnored)
 	circularResult := circularDetector.DetectCircularDependencies()
 	if err := ctx.Err(); err != nil {
 		return nil, fmt.Errorf("dependency analysis cancelled: %w", err)
@@ -377,7 +385,10 @@ func (s *SystemAnalysisServiceImpl) buildDependencyAnalysisResult(ctx context.Co
 
 	// Calculate coupling metrics
 	metricsCalculator := analyzer.NewCouplingMetricsCalculator(graph, analyzer.DefaultCouplingMetricsOptions())
-	if err := metricsCalculator.CalculateMetrics(); err != nil {
+	if err := metricsCalculator.CalculateMetricsWithContext(ctx); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("dependency analysis cancelled: %w", ctxErr)
+		}
 		return nil, err
 	}
 	couplingResults := s.extractCouplingResult(graph)
@@ -415,6 +426,11 @@ func (s *SystemAnalysisServiceImpl) buildDependencyAnalysisResult(ctx context.Co
 		CouplingAnalysis:     s.convertCouplingResults(couplingResults),
 		LongestChains:        longestChains,
 		MaxDepth:             s.calculateMaxDepth(graph),
+		DependencyOrder:      s.convertDependencyOrder(analyzer.ComputeDependencyOrder(graph)),
+		ImportInventory:      buildImportInventory(graph),
+		DynamicDependencies:  countDynamicDependencies(graph),
+		UnanalyzableImports:  convertUnresolvedDynamicImports(graph.UnresolvedDynamicImports),
+		Facades:              convertFacades(graph.SortedFacades()),
 	}
 
 	return result, nil
@@ -439,15 +455,38 @@ func (s *SystemAnalysisServiceImpl) analyzeArchitectureGraph(ctx context.Context
 	}
 
 	responsibilityAnalysis, cohesionAnalysis, responsibilityViolations, responsibilityChecks := s.analyzeResponsibilityForRequest(graph, req)
+	godModuleAnalysis, godModuleViolations, godModuleChecks := s.analyzeGodModulesForRequest(graph, req)
+	responsibilityViolations = append(responsibilityViolations, godModuleViolations...)
+	responsibilityChecks += godModuleChecks
 	if err := ctx.Err(); err != nil {
 		return nil, fmt.Errorf("architecture analysis cancelled: %w", err)
 	}
 
+	// Rule sets are checked on their own, whatever the global layers are
+	var ruleSets []domain.ArchitectureRuleSet
+	if req.ArchitectureRules != nil {
+		ruleSets = req.ArchitectureRules.RuleSets
+	}
+	ruleSetPolicy, err := newLayerEdgePolicy(req.ArchitectureRules)
+	if err != nil {
+		return nil, err
+	}
+	ruleSetResults, ruleSetViolations, ruleSetChecks, err := s.evaluateRuleSets(ctx, graph, ruleSets, ruleSetPolicy)
+	if err != nil {
+		return nil, err
+	}
+	responsibilityViolations = append(responsibilityViolations, ruleSetViolations...)
+	responsibilityChecks += ruleSetChecks
+
 	// Clone ArchitectureRules before modifying to avoid mutating the caller's object
 	// (the pointer is shared even though SystemAnalysisRequest is passed by value).
-	rules := s.resolveArchitectureRules(graph, req.ArchitectureRules)
+	configured := req.ArchitectureRules
+	if req.DetectArchitecture {
+		configured = withoutConfiguredLayers(configured)
+	}
+	rules := s.resolveArchitectureRules(graph, configured)
 	if rules == nil || len(rules.Layers) == 0 {
-		if responsibilityAnalysis == nil && cohesionAnalysis == nil {
+		if responsibilityAnalysis == nil && cohesionAnalysis == nil && godModuleAnalysis == nil && ruleSetResults == nil {
 			return s.emptyArchitectureResult(), nil
 		}
 		severityCounts := responsibilitySeverityCounts(responsibilityViolations)
@@ -457,7 +496,7 @@ func (s *SystemAnalysisServiceImpl) analyzeArchitectureGraph(ctx context.Context
 		compliance, weighted := s.calculateComplianceWeighted(errorCount, warningCount, checked)
 		recommendations := s.generateArchitectureRecommendations(responsibilityViolations, map[string]float64{}, nil, compliance)
 		refactoringTargets := s.identifyArchitectureRefactoringTargets(responsibilityViolations, map[string]string{})
-		return s.buildArchitectureResultWithRecommendations(
+		result := s.buildArchitectureResultWithRecommendations(
 			responsibilityViolations,
 			severityCounts,
 			map[string]map[string]int{},
@@ -472,15 +511,26 @@ func (s *SystemAnalysisServiceImpl) analyzeArchitectureGraph(ctx context.Context
 			refactoringTargets,
 			cohesionAnalysis,
 			responsibilityAnalysis,
-		), nil
+		)
+		result.GodModuleAnalysis = godModuleAnalysis
+		result.RuleSets = ruleSetResults
+		if len(ruleSets) > 0 {
+			result.Rules = rules
+		}
+		return result, nil
 	}
 	req.ArchitectureRules = rules
 
+	edgePolicy, err := newLayerEdgePolicy(rules)
+	if err != nil {
+		return nil, err
+	}
+
 	// Map modules to layers
 	moduleToLayer := s.buildModuleLayerMap(graph, req.ArchitectureRules)
 
 	// Evaluate layer rules and collect violations
-	violations, severityCounts, layerCoupling, checked := s.evaluateLayerRules(ctx, graph, moduleToLayer, req.ArchitectureRules)
+	violations, severityCounts, layerCoupling, checked := s.evaluateLayerRules(ctx, graph.Edges, moduleToLayer, req.ArchitectureRules, edgePolicy)
 	if violations == nil {
 		// Check if context was cancelled
 		select {
@@ -510,9 +560,14 @@ func (s *SystemAnalysisServiceImpl) analyzeArchitectureGraph(ctx context.Context
 	refactoringTargets := s.identifyArchitectureRefactoringTargets(violations, moduleToLayer)
 
 	// Build result
-	return s.buildArchitectureResultWithRecommendations(violations, severityCounts, layerCoupling, layerCohesion,
+	result := s.buildArchitectureResultWithRecommendations(violations, severityCounts, layerCoupling, layerCohesion,
 		problematic, layersAnalyzed, compliance, weighted, checked, moduleToLayer, recommendations, refactoringTargets,
-		cohesionAnalysis, responsibilityAnalysis), nil
+		cohesionAnalysis, responsibilityAnalysis)
+	result.GodModuleAnalysis = godModuleAnalysis
+	result.LayerDetection = rules.Detection
+	result.RuleSets = ruleSetResults
+	result.Rules = rules
+	return result, nil
 }
 
 // emptyArchitectureResult returns an empty result when no rules are defined
@@ -551,24 +606,50 @@ func (s *SystemAnalysisServiceImpl) buildDependencyGraph(ctx context.Context, re
 		FollowRelative:    req.FollowRelative,
 		IncludePatterns:   req.IncludePatterns,
 		ExcludePatterns:   req.ExcludePatterns,
+
+		IncludeTypeChecking: req.IncludeTypeChecking,
+		FacadeMinExports:    req.FacadeMinExports,
+		CollapseFacades:     req.CollapseFacades,
 	}
 	ma, err := analyzer.NewModuleAnalyzer(options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create module analyzer: %w", err)
 	}
-	graph, err := ma.AnalyzeFiles(req.Paths)
+	graph, err := ma.AnalyzeFilesWithContext(ctx, req.Paths)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, fmt.Errorf("module graph cancelled: %w", ctxErr)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to build module graph: %w", err)
 	}
-	if err := ctx.Err(); err != nil {
-		return nil, fmt.Errorf("module graph cancelled: %w", err)
-	}
 	return graph, nil
 }
 
-// evaluateLayerRules evaluates all edges against layer rules
-func (s *SystemAnalysisServiceImpl) evaluateLayerRules(ctx context.Context, graph *analyzer.DependencyGraph,
-	moduleToLayer map[string]string, rules *domain.ArchitectureRules) ([]domain.ArchitectureViolation,
+// layerEdgePolicy selects the dependency edges layer rules skip and the ones
+// whose violations are reported at info severity
+type layerEdgePolicy struct {
+	ignored    analyzer.EdgeKindSet
+	downgraded analyzer.EdgeKindSet
+}
+
+func newLayerEdgePolicy(rules *domain.ArchitectureRules) (layerEdgePolicy, error) {
+	var policy layerEdgePolicy
+	if rules == nil {
+		return policy, nil
+	}
+	var err error
+	if policy.ignored, err = analyzer.ParseEdgeKindSet(rules.IgnoreEdgeKinds); err != nil {
+		return policy, fmt.Errorf("invalid architecture ignore_edges: %w", err)
+	}
+	if policy.downgraded, err = analyzer.ParseEdgeKindSet(rules.DowngradeEdgeKinds); err != nil {
+		return policy, fmt.Errorf("invalid architecture downgrade_edges: %w", err)
+	}
+	return policy, nil
+}
+
+// evaluateLayerRules evaluates edges against layer rules
+func (s *SystemAnalysisServiceImpl) evaluateLayerRules(ctx context.Context, edges []*analyzer.DependencyEdge,
+	moduleToLayer map[string]string, rules *domain.ArchitectureRules, policy layerEdgePolicy) ([]domain.ArchitectureViolation,
 	map[domain.ViolationSeverity]int, map[string]map[string]int, int) {
 
 	layerCoupling := make(map[string]map[string]int)
@@ -576,12 +657,15 @@ func (s *SystemAnalysisServiceImpl) evaluateLayerRules(ctx context.Context, grap
 	severityCounts := make(map[domain.ViolationSeverity]int)
 	checked := 0
 
-	for _, edge := range graph.Edges {
+	for _, edge := range edges {
 		select {
 		case <-ctx.Done():
 			return nil, nil, nil, 0
 		default:
 		}
+		if policy.ignored.MatchesEdge(edge) {
+			continue
+		}
 		fromLayer := moduleToLayer[edge.From]
 		toLayer := moduleToLayer[edge.To]
 
@@ -591,6 +675,12 @@ func (s *SystemAnalysisServiceImpl) evaluateLayerRules(ctx context.Context, grap
 		layerCoupling[fromLayer][toLayer]++
 
 		if v := s.evaluateLayerEdge(rules, edge.From, edge.To, fromLayer, toLayer); v != nil {
+			v.EdgeKind = string(edge.Kind())
+			v.Layer = fromLayer
+			if policy.downgraded.MatchesEdge(edge) {
+				v.Severity = domain.ViolationSeverityInfo
+				v.Description += fmt.Sprintf(" (%s import)", edge.Kind())
+			}
 			violations = append(violations, *v)
 			severityCounts[v.Severity]++
 		}
@@ -786,26 +876,48 @@ func (s *SystemAnalysisServiceImpl) buildModuleLayerMap(graph *analyzer.Dependen
 	out := make(map[string]string)
 	compiled := s.compileLayerPatterns(rules.Layers)
 	for module := range graph.Nodes {
-		if s.isTestModule(module) {
-			out[module] = "unknown"
-			continue
-		}
-		// Strip the first matching neutral prefix before layer matching
-		stripped := module
-		for _, prefix := range rules.NeutralPrefixes {
-			if strings.HasPrefix(stripped, prefix+".") {
-				stripped = stripped[len(prefix)+1:]
-				break
-			}
-		}
-		out[module] = s.findLayerForModule(stripped, compiled)
-		if out[module] == "" {
-			out[module] = "unknown"
-		}
+		out[module] = s.layerForModule(module, rules, compiled)
 	}
 	return out
 }
 
+// layerForModule returns the layer of a module, or "unknown" for test
+// modules and modules that match no layer
+func (s *SystemAnalysisServiceImpl) layerForModule(module string, rules *domain.ArchitectureRules, compiled map[string][]compiledPattern) string {
+	layer, _ := s.matchModuleLayer(module, rules, compiled)
+	return layer
+}
+
+// matchModuleLayer returns the layer of a module and the package pattern
+// that placed it there; the pattern is empty for "unknown"
+func (s *SystemAnalysisServiceImpl) matchModuleLayer(module string, rules *domain.ArchitectureRules, compiled map[string][]compiledPattern) (string, string) {
+	if s.isTestModule(module) {
+		return "unknown", ""
+	}
+	// Strip the first matching neutral prefix before layer matching
+	stripped := module
+	for _, prefix := range rules.NeutralPrefixes {
+		if strings.HasPrefix(stripped, prefix+".") {
+			stripped = stripped[len(prefix)+1:]
+			break
+		}
+	}
+	if matches := s.rankLayerMatches(stripped, compiled); len(matches) > 0 {
+		return matches[0].layer, matches[0].pattern
+	}
+	return "unknown", ""
+}
+
+// layerMatch is one layer package pattern matching a module
+type layerMatch struct {
+	layer       string
+	pattern     string
+	specificity int
+	isPrefix    bool // true = prefix match (higher priority)
+	boundary    int
+	matchPos    int // byte offset where the pattern matched in the module
+}
+
 // findLayerForModule returns the most specific matching layer for a module.
 // Tie-breaking priority:
 //  1. Higher specificity (more dots in pattern) wins
@@ -815,20 +927,21 @@ func (s *SystemAnalysisServiceImpl) buildModuleLayerMap(graph *analyzer.Dependen
 //  5. Longer pattern string wins
 //  6. Alphabetical layer name for determinism
 func (s *SystemAnalysisServiceImpl) findLayerForModule(module string, compiled map[string][]compiledPattern) string {
-	type match struct {
-		layer       string
-		pattern     string
-		specificity int
-		isPrefix    bool // true = prefix match (higher priority)
-		boundary    int
-		matchPos    int // byte offset where the pattern matched in the module
+	matches := s.rankLayerMatches(module, compiled)
+	if len(matches) == 0 {
+		return ""
 	}
+	return matches[0].layer
+}
 
-	var matches []match
+// rankLayerMatches returns every pattern matching the module, best first in
+// the order findLayerForModule documents
+func (s *SystemAnalysisServiceImpl) rankLayerMatches(module string, compiled map[string][]compiledPattern) []layerMatch {
+	var matches []layerMatch
 	for layer, patterns := range compiled {
 		for _, cp := range patterns {
 			if m := cp.matchModule(module); m.matched {
-				matches = append(matches, match{
+				matches = append(matches, layerMatch{
 					layer:       layer,
 					pattern:     cp.original,
 					specificity: cp.specificity,
@@ -840,10 +953,6 @@ func (s *SystemAnalysisServiceImpl) findLayerForModule(module string, compiled m
 		}
 	}
 
-	if len(matches) == 0 {
-		return ""
-	}
-
 	// Sort matches by priority: specificity > prefix > boundary strength > match position > pattern length > layer name
 	// Specificity (dot count) is the primary signal so that "api.v1" always beats
 	// "foo" even when "foo" matches at prefix position. Among equal-specificity
@@ -875,7 +984,7 @@ func (s *SystemAnalysisServiceImpl) findLayerForModule(module string, compiled m
 		return a.layer < b.layer
 	})
 
-	return matches[0].layer
+	return matches
 }
 
 // compileModulePatterns converts simple glob-like patterns to a compiledPattern
@@ -947,77 +1056,6 @@ func (s *SystemAnalysisServiceImpl) compileModulePattern(glob string) *regexp.Re
 	return re
 }
 
-// autoDetectArchitecture automatically detects architecture patterns from the dependency graph
-func (s *SystemAnalysisServiceImpl) autoDetectArchitecture(graph *analyzer.DependencyGraph) *domain.ArchitectureRules {
-	// Load layer patterns and rules from the embedded default config
-	defaultConfig, err := config.LoadDefaultConfigFromTOML()
-	if err != nil {
-		// This should never happen since the config is embedded at compile time
-		// But if it does, return nil to indicate no auto-detection is possible
-		return nil
-	}
-
-	// Convert config layer definitions to domain layers and compile patterns
-	domainLayers := make([]domain.Layer, 0, len(defaultConfig.Architecture.Layers))
-	for _, l := range defaultConfig.Architecture.Layers {
-		domainLayers = append(domainLayers, domain.Layer{
-			Name:     l.Name,
-			Packages: l.Packages,
-		})
-	}
-	compiled := s.compileLayerPatterns(domainLayers)
-
-	// Detect which modules belong to which layer
-	moduleToLayer := make(map[string]string)
-	layerModules := make(map[string][]string)
-
-	for module := range graph.Nodes {
-		if s.isTestModule(module) {
-			continue
-		}
-		layer := s.findLayerForModule(module, compiled)
-		if layer != "" {
-			moduleToLayer[module] = layer
-			layerModules[layer] = append(layerModules[layer], module)
-		}
-	}
-
-	// If no standard patterns found, return nil
-	if len(layerModules) == 0 {
-		return nil
-	}
-
-	// Build layers configuration
-	layers := make([]domain.Layer, 0)
-	for layerName, modules := range layerModules {
-		// Extract unique package prefixes from modules
-		packagePrefixes := s.extractPackagePrefixes(modules)
-		if len(packagePrefixes) > 0 {
-			layers = append(layers, domain.Layer{
-				Name:        layerName,
-				Description: fmt.Sprintf("Auto-detected %s layer", layerName),
-				Packages:    packagePrefixes,
-			})
-		}
-	}
-
-	// Convert config.LayerRule to domain.LayerRule
-	rules := make([]domain.LayerRule, 0, len(defaultConfig.Architecture.Rules))
-	for _, rule := range defaultConfig.Architecture.Rules {
-		rules = append(rules, domain.LayerRule{
-			From:  rule.From,
-			Allow: rule.Allow,
-			Deny:  rule.Deny,
-		})
-	}
-
-	return &domain.ArchitectureRules{
-		Layers:     layers,
-		Rules:      rules,
-		StrictMode: false,
-	}
-}
-
 // resolveArchitectureRules returns a self-contained ArchitectureRules ready for
 // evaluation. It clones the caller's rules (if any) to avoid mutation, then fills
 // in missing layers or rules from auto-detection / embedded defaults.
@@ -1036,6 +1074,10 @@ func (s *SystemAnalysisServiceImpl) resolveArchitectureRules(graph *analyzer.Dep
 		StrictMode:        orig.StrictMode,
 		AllowedPatterns:   orig.AllowedPatterns,
 		ForbiddenPatterns: orig.ForbiddenPatterns,
+
+		IgnoreEdgeKinds:    orig.IgnoreEdgeKinds,
+		DowngradeEdgeKinds: orig.DowngradeEdgeKinds,
+		RuleSets:           orig.RuleSets,
 	}
 
 	// Settings such as strict_mode can be configured without defining layers or
@@ -1044,6 +1086,7 @@ func (s *SystemAnalysisServiceImpl) resolveArchitectureRules(graph *analyzer.Dep
 		if autoDetected := s.autoDetectArchitecture(graph); autoDetected != nil {
 			resolved.Layers = autoDetected.Layers
 			resolved.Rules = autoDetected.Rules
+			resolved.Detection = autoDetected.Detection
 		}
 		return resolved
 	}
@@ -1086,12 +1129,26 @@ func (s *SystemAnalysisServiceImpl) resolveArchitectureRules(graph *analyzer.Dep
 		if autoDetected != nil {
 			resolved.Layers = autoDetected.Layers
 			resolved.Rules = s.mergeLayerRules(autoDetected.Rules, resolved.Rules)
+			resolved.Detection = autoDetected.Detection
 		}
 	}
 
 	return resolved
 }
 
+// withoutConfiguredLayers returns a copy of rules without layers, rules and
+// style, so that they are auto-detected while the other settings still apply
+func withoutConfiguredLayers(rules *domain.ArchitectureRules) *domain.ArchitectureRules {
+	if rules == nil {
+		return nil
+	}
+	stripped := *rules
+	stripped.Style = ""
+	stripped.Layers = nil
+	stripped.Rules = nil
+	return &stripped
+}
+
 // mergeLayerRules merges base rules with override rules. For any From value
 // that appears in overrides, the override replaces the base rule entirely.
 func (s *SystemAnalysisServiceImpl) mergeLayerRules(base, overrides []domain.LayerRule) []domain.LayerRule {
@@ -1329,7 +1386,8 @@ func (s *SystemAnalysisServiceImpl) evaluateLayerEdge(rules *domain.Architecture
 func (s *SystemAnalysisServiceImpl) toLayerViolations(vs []domain.ArchitectureViolation, moduleToLayer map[string]string) []domain.LayerViolation {
 	out := make([]domain.LayerViolation, 0, len(vs))
 	for _, v := range vs {
-		if v.Type != domain.ViolationTypeLayer {
+		// Rule set violations are between the layers of the rule set
+		if v.Type != domain.ViolationTypeLayer || v.RuleSet != "" {
 			continue
 		}
 		out = append(out, domain.LayerViolation{
@@ -1341,6 +1399,7 @@ func (s *SystemAnalysisServiceImpl) toLayerViolations(vs []domain.ArchitectureVi
 			Severity:    v.Severity,
 			Description: v.Description,
 			Suggestion:  v.Suggestion,
+			EdgeKind:    v.EdgeKind,
 		})
 	}
 	return out
@@ -1504,12 +1563,19 @@ func (s *SystemAnalysisServiceImpl) convertCircularResults(result *analyzer.Circ
 	coreModules := make(map[string]int) // Track modules appearing in multiple cycles
 
 	for _, cycle := range result.CircularDependencies {
+		var heaviestEdge *domain.DependencyPath
+		if cycle.HeaviestEdge != nil {
+			edge := convertDependencyChain(*cycle.HeaviestEdge)
+			heaviestEdge = &edge
+		}
 		circularDeps = append(circularDeps, domain.CircularDependency{
 			Modules:      cycle.Modules,
 			Description:  cycle.Description,
 			Severity:     domain.CycleSeverity(cycle.Severity),
 			Size:         cycle.Size,
 			Dependencies: s.convertDependencyChains(cycle.Dependencies),
+			ModuleSizes:  cycle.ModuleSizes,
+			HeaviestEdge: heaviestEdge,
 		})
 
 		// Count occurrences for core infrastructure identification
@@ -1540,6 +1606,75 @@ func (s *SystemAnalysisServiceImpl) convertCircularResults(result *analyzer.Circ
 	}
 }
 
+// countDynamicDependencies counts the edges that exist only through dynamic imports
+func countDynamicDependencies(graph *analyzer.DependencyGraph) int {
+	count := 0
+	for _, edge := range graph.Edges {
+		if edge.IsDynamic {
+			count++
+		}
+	}
+	return count
+}
+
+// convertUnresolvedDynamicImports converts analyzer dynamic imports to informational findings
+func convertUnresolvedDynamicImports(imports []*analyzer.DynamicImport) []domain.UnanalyzableImport {
+	if len(imports) == 0 {
+		return nil
+	}
+	findings := make([]domain.UnanalyzableImport, 0, len(imports))
+	for _, dyn := range imports {
+		findings = append(findings, domain.UnanalyzableImport{
+			Module:   dyn.FromModule,
+			FilePath: dyn.FilePath,
+			Line:     dyn.Line,
+			Function: dyn.Function,
+		})
+	}
+	return findings
+}
+
+// convertFacades converts analyzer facades to domain facades
+func convertFacades(facades []*analyzer.FacadeModule) []domain.FacadeModule {
+	if len(facades) == 0 {
+		return nil
+	}
+	converted := make([]domain.FacadeModule, 0, len(facades))
+	for _, facade := range facades {
+		sources := facade.Sources()
+		converted = append(converted, domain.FacadeModule{
+			Module:    facade.Module,
+			Exports:   len(facade.Exports),
+			FanOut:    len(sources),
+			Sources:   sources,
+			Collapsed: facade.Collapsed,
+		})
+	}
+	return converted
+}
+
+// convertDependencyOrder converts analyzer.DependencyOrderResult to domain.DependencyOrder
+func (s *SystemAnalysisServiceImpl) convertDependencyOrder(result *analyzer.DependencyOrderResult) *domain.DependencyOrder {
+	if result == nil {
+		return nil
+	}
+
+	components := make([]domain.OrderedComponent, 0, len(result.Components))
+	for _, component := range result.Components {
+		components = append(components, domain.OrderedComponent{
+			Modules: component.Modules,
+			Level:   component.Level,
+			Cyclic:  component.Cyclic,
+		})
+	}
+
+	return &domain.DependencyOrder{
+		Components: components,
+		Levels:     result.Levels,
+		IsAcyclic:  result.IsAcyclic,
+	}
+}
+
 // Architecture analysis helper methods (simplified)
 
 // Removed legacy helpers for ad-hoc layer counting.
@@ -1551,17 +1686,23 @@ func (s *SystemAnalysisServiceImpl) convertDependencyChains(chains []analyzer.De
 	var deps []domain.DependencyPath
 
 	for _, chain := range chains {
-		deps = append(deps, domain.DependencyPath{
-			From:   chain.From,
-			To:     chain.To,
-			Path:   chain.Path,
-			Length: chain.Length,
-		})
+		deps = append(deps, convertDependencyChain(chain))
 	}
 
 	return deps
 }
 
+func convertDependencyChain(chain analyzer.DependencyChain) domain.DependencyPath {
+	return domain.DependencyPath{
+		From:    chain.From,
+		To:      chain.To,
+		Path:    chain.Path,
+		Length:  chain.Length,
+		Weight:  chain.Weight,
+		Symbols: chain.Symbols,
+	}
+}
+
 // extractCouplingResult extracts coupling analysis from the dependency graph
 func (s *SystemAnalysisServiceImpl) extractCouplingResult(graph *analyzer.DependencyGraph) *analyzer.SystemMetrics {
 	// If SystemMetrics is already calculated in the graph, use it
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -179,6 +180,57 @@ func TestAutoDetectArchitecture_NoSpuriousViolationsForUnknownLayers(t *testing.
 		"StrictMode must be false when auto-detected without user config (#659)")
 }
 
+func TestAutoDetectArchitecture_ScoresAssignments(t *testing.T) {
+	service := NewSystemAnalysisService()
+	graph := analyzer.NewDependencyGraph("/project")
+
+	graph.AddModule("app.api.routes", "/project/app/api/routes.py")
+	graph.AddModule("app.models.user", "/project/app/models/user.py")
+	graph.AddModule("app.models.api_helpers", "/project/app/models/api_helpers.py")
+	graph.AddModule("app.services.orders", "/project/app/services/orders.py")
+	graph.AddDependency("app.api.routes", "app.models.api_helpers", analyzer.DependencyEdgeImport, nil)
+	graph.AddDependency("app.services.orders", "app.models.api_helpers", analyzer.DependencyEdgeImport, nil)
+
+	rules := service.autoDetectArchitecture(graph)
+	require.NotNil(t, rules)
+	require.NotNil(t, rules.Detection)
+
+	assignments := make(map[string]domain.LayerAssignment)
+	for _, assignment := range rules.Detection.Assignments {
+		assignments[assignment.Module] = assignment
+	}
+
+	user := assignments["app.models.user"]
+	assert.Equal(t, "domain", user.Layer)
+	assert.Equal(t, "app.models", user.Package)
+	assert.Empty(t, user.Candidates)
+
+	// Matching two layers by name makes the assignment less certain
+	helper := assignments["app.models.api_helpers"]
+	assert.Equal(t, "domain", helper.Layer)
+	assert.Equal(t, []string{"presentation"}, helper.Candidates)
+	assert.Less(t, helper.Confidence, user.Confidence)
+}
+
+func TestAnalyzeArchitectureGraph_DetectArchitectureIgnoresConfiguredLayers(t *testing.T) {
+	service := NewSystemAnalysisService()
+	graph := analyzer.NewDependencyGraph("/project")
+	graph.AddModule("app.api.routes", "/project/app/api/routes.py")
+	graph.AddModule("app.models.user", "/project/app/models/user.py")
+
+	req := domain.SystemAnalysisRequest{
+		DetectArchitecture: true,
+		ArchitectureRules: &domain.ArchitectureRules{
+			Layers: []domain.Layer{{Name: "web", Packages: []string{"app"}}},
+		},
+	}
+
+	result, err := service.analyzeArchitectureGraph(context.Background(), graph, req)
+	require.NoError(t, err)
+	require.NotNil(t, result.LayerDetection)
+	assert.Len(t, result.LayerDetection.Assignments, 2)
+}
+
 func TestEvaluateLayerEdge_AutoDetectDoesNotFlagUnknownLayers(t *testing.T) {
 	service := NewSystemAnalysisService()
 
@@ -615,6 +667,26 @@ func TestPyscnConfigToSystemAnalysisRequest_PropagatesResponsibilitySettings(t *
 	assert.Equal(t, domain.ViolationSeverityCritical, request.ResponsibilityViolationSeverity)
 }
 
+func TestPyscnConfigToSystemAnalysisRequest_PropagatesGodModuleSettings(t *testing.T) {
+	loader := NewSystemAnalysisConfigurationLoader()
+	cfg := &config.PyscnConfig{
+		ArchitectureValidateGodModules:      domain.BoolPtr(false),
+		ArchitectureGodModuleMaxLines:       800,
+		ArchitectureGodModuleMaxDefinitions: 40,
+		ArchitectureGodModuleMaxFanIn:       0,
+		ArchitectureGodModuleMaxFanOut:      12,
+	}
+
+	request := loader.pyscnConfigToSystemAnalysisRequest(cfg)
+
+	require.NotNil(t, request.ValidateGodModules)
+	assert.False(t, *request.ValidateGodModules)
+	assert.Equal(t, 800, request.GodModuleMaxLines)
+	assert.Equal(t, 40, request.GodModuleMaxDefinitions)
+	assert.Equal(t, domain.DefaultGodModuleMaxFanIn, request.GodModuleMaxFanIn)
+	assert.Equal(t, 12, request.GodModuleMaxFanOut)
+}
+
 func TestPyscnConfigToSystemAnalysisRequest_PropagatesLayersWithStrictMode(t *testing.T) {
 	loader := NewSystemAnalysisConfigurationLoader()
 	strictMode := true
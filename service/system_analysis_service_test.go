@@ -254,6 +254,146 @@ func TestAnalyzeArchitectureDoesNotReportStdlibShadowImport(t *testing.T) {
 	assert.Empty(t, response.Violations)
 }
 
+func TestAnalyzeArchitectureEdgeKindPolicy(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"pyproject.toml":         "[project]\nname = \"edge-kinds\"\n",
+		"app/__init__.py":        "",
+		"app/domain/__init__.py": "",
+		"app/domain/model.py":    "def load():\n    from app.infra import db\n    return db\n",
+		"app/infra/__init__.py":  "",
+		"app/infra/db.py":        "VALUE = 1\n",
+		"app/domain/typed.py":    "from typing import TYPE_CHECKING\nif TYPE_CHECKING:\n    from app.infra import db\n",
+		"app/domain/optional.py": "try:\n    from app.infra import db\nexcept ImportError:\n    db = None\n",
+	}
+	var paths []string
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		if strings.HasSuffix(name, ".py") {
+			paths = append(paths, path)
+		}
+	}
+
+	request := func(rules *domain.ArchitectureRules) domain.SystemAnalysisRequest {
+		rules.Layers = []domain.Layer{
+			{Name: "domain", Packages: []string{"app.domain"}},
+			{Name: "infrastructure", Packages: []string{"app.infra"}},
+		}
+		rules.Rules = []domain.LayerRule{{From: "domain", Deny: []string{"infrastructure"}}}
+		return domain.SystemAnalysisRequest{
+			Paths:               paths,
+			IncludeThirdParty:   domain.BoolPtr(false),
+			IncludeTypeChecking: domain.BoolPtr(true),
+			ArchitectureRules:   rules,
+		}
+	}
+	kinds := func(result *domain.ArchitectureAnalysisResult) map[string]domain.ViolationSeverity {
+		out := make(map[string]domain.ViolationSeverity)
+		for _, v := range result.Violations {
+			if v.Type == domain.ViolationTypeLayer {
+				out[v.EdgeKind] = v.Severity
+			}
+		}
+		return out
+	}
+
+	service := NewSystemAnalysisService()
+	result, err := service.AnalyzeArchitecture(context.Background(), request(&domain.ArchitectureRules{}))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]domain.ViolationSeverity{
+		"lazy":          domain.ViolationSeverityError,
+		"type_checking": domain.ViolationSeverityError,
+		"conditional":   domain.ViolationSeverityError,
+	}, kinds(result))
+
+	result, err = service.AnalyzeArchitecture(context.Background(), request(&domain.ArchitectureRules{
+		IgnoreEdgeKinds:    []string{"type_checking"},
+		DowngradeEdgeKinds: []string{"lazy"},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]domain.ViolationSeverity{
+		"lazy":        domain.ViolationSeverityInfo,
+		"conditional": domain.ViolationSeverityError,
+	}, kinds(result))
+
+	_, err = service.AnalyzeArchitecture(context.Background(), request(&domain.ArchitectureRules{
+		IgnoreEdgeKinds: []string{"sometimes"},
+	}))
+	assert.Error(t, err)
+}
+
+func TestAnalyzeDependenciesReportsDynamicImports(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"plugins/__init__.py": "",
+		"plugins/csv.py":      "VALUE = 1\n",
+		"plugins/loader.py":   "import importlib\n\nCSV = importlib.import_module(\"plugins.csv\")\n\ndef load(name):\n    return importlib.import_module(name)\n",
+	}
+	var paths []string
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		paths = append(paths, path)
+	}
+
+	response, err := NewSystemAnalysisService().AnalyzeDependencies(context.Background(), domain.SystemAnalysisRequest{
+		Paths:             paths,
+		IncludeThirdParty: domain.BoolPtr(false),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, response.DynamicDependencies)
+	require.Len(t, response.UnanalyzableImports, 1)
+	assert.Equal(t, "importlib.import_module", response.UnanalyzableImports[0].Function)
+	assert.Equal(t, 6, response.UnanalyzableImports[0].Line)
+	loader := moduleWithSuffix(t, response.ModuleMetrics, "plugins.loader")
+	assert.True(t, response.DependencyMatrix[loader][moduleWithSuffix(t, response.ModuleMetrics, "plugins.csv")])
+}
+
+func TestAnalyzeDependenciesReportsFacades(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"pkg/__init__.py":     "",
+		"pkg/api/__init__.py": "from pkg.models import User, Group\nfrom pkg.services import create_user\n",
+		"pkg/models.py":       "class User:\n    pass\n\nclass Group:\n    pass\n",
+		"pkg/services.py":     "from pkg.api import User\n\ndef create_user():\n    return User()\n",
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pyproject.toml"), []byte("[project]\nname = \"pkg\"\n"), 0o644))
+	var paths []string
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		paths = append(paths, path)
+	}
+
+	request := domain.SystemAnalysisRequest{
+		Paths:             paths,
+		IncludeThirdParty: domain.BoolPtr(false),
+		FacadeMinExports:  3,
+	}
+	response, err := NewSystemAnalysisService().AnalyzeDependencies(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, response.Facades, 1)
+	facade := response.Facades[0]
+	assert.Equal(t, "pkg.api", facade.Module)
+	assert.Equal(t, 3, facade.Exports)
+	assert.Equal(t, 2, facade.FanOut)
+	assert.False(t, facade.Collapsed)
+
+	request.CollapseFacades = domain.BoolPtr(true)
+	response, err = NewSystemAnalysisService().AnalyzeDependencies(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, response.Facades, 1)
+	assert.True(t, response.Facades[0].Collapsed)
+	services := moduleWithSuffix(t, response.ModuleMetrics, "pkg.services")
+	assert.True(t, response.DependencyMatrix[services][moduleWithSuffix(t, response.ModuleMetrics, "pkg.models")])
+	assert.False(t, response.DependencyMatrix[services][facade.Module])
+}
+
 func moduleWithSuffix(t *testing.T, modules map[string]*domain.ModuleDependencyMetrics, suffix string) string {
 	t.Helper()
 	for module := range modules {
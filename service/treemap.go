@@ -0,0 +1,379 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// Treemap dimensions in SVG units; the SVG scales to the report width
+const (
+	treemapWidth  = 1000.0
+	treemapHeight = 600.0
+
+	// treemapHeader is the band above a package's files that holds its name
+	treemapHeader = 16.0
+	treemapPad    = 2.0
+
+	// treemapCharWidth approximates the width of a label character, to leave
+	// out labels that would not fit their tile
+	treemapCharWidth = 6.5
+
+	// treemapLabelBaseline is the baseline of a label below the top of its
+	// rectangle
+	treemapLabelBaseline = 12.0
+)
+
+// treemapDuplicationScale is the default Treemap.DuplicationScale
+const treemapDuplicationScale = 40
+
+// TreemapPackage is a directory of the treemap, drawn around its files
+type TreemapPackage struct {
+	Path   string
+	Label  string
+	X, Y   float64
+	Width  float64
+	Height float64
+
+	// ShowLabel is false when the name does not fit the header band
+	ShowLabel      bool
+	LabelX, LabelY float64
+}
+
+// TreemapFile is a file of the treemap, sized by its source lines
+type TreemapFile struct {
+	Path   string
+	Label  string
+	X, Y   float64
+	Width  float64
+	Height float64
+
+	ShowLabel      bool
+	LabelX, LabelY float64
+
+	Lines                 int
+	Functions             int
+	AverageComplexity     float64
+	MaxComplexity         int
+	DuplicationPercentage float64
+
+	// ComplexityColor and DuplicationColor run from green to red
+	ComplexityColor  string
+	DuplicationColor string
+}
+
+// Treemap lays out the analyzed files by package
+type Treemap struct {
+	Width    float64
+	Height   float64
+	Packages []TreemapPackage
+	Files    []TreemapFile
+
+	// Duplication is set when clone detection ran, so files can be colored
+	// by duplication
+	Duplication bool
+
+	// ComplexityScale is the maximum complexity at which a file is colored
+	// fully red, one above the medium risk threshold
+	ComplexityScale int
+
+	// DuplicationScale is the duplication percentage at which a file is
+	// colored fully red
+	DuplicationScale int
+}
+
+// treemapNode is a directory or file while the treemap is laid out
+type treemapNode struct {
+	name     string
+	path     string
+	size     float64
+	file     *TreemapFile
+	children []*treemapNode
+}
+
+// BuildTreemap lays out the files with raw metrics as a squarified treemap,
+// sized by source lines and colored by the highest function complexity and
+// by the share of lines inside clones. It returns nil when complexity
+// analysis did not run or no file has source lines.
+func BuildTreemap(response *domain.AnalyzeResponse) *Treemap {
+	if response == nil || response.Complexity == nil || len(response.Complexity.RawMetrics) == 0 {
+		return nil
+	}
+
+	treemap := &Treemap{
+		Width:            treemapWidth,
+		Height:           treemapHeight,
+		Duplication:      response.Clone != nil,
+		ComplexityScale:  domain.DefaultComplexityMediumThreshold + 1,
+		DuplicationScale: treemapDuplicationScale,
+	}
+	if manifest := response.Manifest; manifest != nil && manifest.Thresholds != nil && manifest.Thresholds.Complexity != nil {
+		treemap.ComplexityScale = manifest.Thresholds.Complexity.Medium + 1
+	}
+
+	files := make(map[string]*TreemapFile)
+	totalLines := make(map[string]int)
+	var paths []string
+	for _, metrics := range response.Complexity.RawMetrics {
+		if metrics.SLOC <= 0 {
+			continue
+		}
+		if _, ok := files[metrics.FilePath]; !ok {
+			paths = append(paths, metrics.FilePath)
+		}
+		files[metrics.FilePath] = &TreemapFile{Path: metrics.FilePath, Lines: metrics.SLOC}
+		totalLines[metrics.FilePath] = metrics.TotalLines
+	}
+	if len(files) == 0 {
+		return nil
+	}
+
+	complexitySums := make(map[string]int)
+	for _, fn := range response.Complexity.Functions {
+		file, ok := files[fn.FilePath]
+		if !ok {
+			continue
+		}
+		file.Functions++
+		complexitySums[fn.FilePath] += fn.Metrics.Complexity
+		if fn.Metrics.Complexity > file.MaxComplexity {
+			file.MaxComplexity = fn.Metrics.Complexity
+		}
+	}
+
+	duplicated := treemapDuplicatedLines(response.Clone)
+	for filePath, file := range files {
+		if file.Functions > 0 {
+			file.AverageComplexity = float64(complexitySums[filePath]) / float64(file.Functions)
+		}
+		// Duplication is measured against all lines, as in the package rollup
+		if total := totalLines[filePath]; total > 0 {
+			file.DuplicationPercentage = math.Min(float64(duplicated[filePath])/float64(total)*100, 100)
+		}
+		file.ComplexityColor = treemapColor(float64(file.MaxComplexity) / float64(treemap.ComplexityScale))
+		file.DuplicationColor = treemapColor(file.DuplicationPercentage / float64(treemap.DuplicationScale))
+	}
+
+	root := buildTreemapTree(paths, files)
+	treemap.layout(root, 0, 0, treemapWidth, treemapHeight, true)
+	return treemap
+}
+
+// treemapDuplicatedLines counts the distinct lines of each file inside clone
+// fragments
+func treemapDuplicatedLines(clone *domain.CloneResponse) map[string]int {
+	counts := make(map[string]int)
+	if clone == nil {
+		return counts
+	}
+	lines := make(map[string]map[int]struct{})
+	for _, c := range clone.Clones {
+		if c == nil || c.Location == nil {
+			continue
+		}
+		seen, ok := lines[c.Location.FilePath]
+		if !ok {
+			seen = make(map[int]struct{})
+			lines[c.Location.FilePath] = seen
+		}
+		for line := c.Location.StartLine; line <= c.Location.EndLine; line++ {
+			seen[line] = struct{}{}
+		}
+	}
+	for filePath, seen := range lines {
+		counts[filePath] = len(seen)
+	}
+	return counts
+}
+
+// buildTreemapTree nests the files under the directories below their
+// common root, and sorts every level by size
+func buildTreemapTree(paths []string, files map[string]*TreemapFile) *treemapNode {
+	dirs := make([][]string, len(paths))
+	var root []string
+	for i, p := range paths {
+		dir := strings.Split(filepath.ToSlash(filepath.Dir(filepath.Clean(p))), "/")
+		if len(dir) == 1 && dir[0] == "." {
+			dir = nil
+		}
+		dirs[i] = dir
+		if i == 0 {
+			root = dir
+			continue
+		}
+		n := 0
+		for n < len(root) && n < len(dir) && root[n] == dir[n] {
+			n++
+		}
+		root = root[:n]
+	}
+
+	rootPath := strings.Join(root, "/")
+	if rootPath == "" {
+		rootPath = "."
+	}
+	tree := &treemapNode{name: rootPath, path: rootPath}
+	for i, p := range paths {
+		node := tree
+		for depth := len(root); depth < len(dirs[i]); depth++ {
+			node = node.child(dirs[i][depth], strings.Join(dirs[i][:depth+1], "/"))
+		}
+		file := files[p]
+		file.Label = path.Base(filepath.ToSlash(p))
+		node.children = append(node.children, &treemapNode{name: file.Label, path: p, file: file})
+	}
+	tree.sum()
+	return tree
+}
+
+// child returns the subdirectory named name, adding it when missing
+func (n *treemapNode) child(name, dirPath string) *treemapNode {
+	for _, c := range n.children {
+		if c.file == nil && c.name == name {
+			return c
+		}
+	}
+	c := &treemapNode{name: name, path: dirPath}
+	n.children = append(n.children, c)
+	return c
+}
+
+// sum totals the lines below the node and sorts its children largest first
+func (n *treemapNode) sum() float64 {
+	if n.file != nil {
+		n.size = float64(n.file.Lines)
+		return n.size
+	}
+	n.size = 0
+	for _, c := range n.children {
+		n.size += c.sum()
+	}
+	sort.SliceStable(n.children, func(i, j int) bool {
+		if n.children[i].size != n.children[j].size {
+			return n.children[i].size > n.children[j].size
+		}
+		return n.children[i].name < n.children[j].name
+	})
+	return n.size
+}
+
+// layout places the node in the rectangle: files fill it, directories get a
+// header band with their name and split the rest among their children. The
+// root itself is not drawn.
+func (t *Treemap) layout(n *treemapNode, x, y, w, h float64, root bool) {
+	if n.file != nil {
+		n.file.X, n.file.Y, n.file.Width, n.file.Height = x, y, w, h
+		n.file.ShowLabel = w >= float64(len(n.file.Label))*treemapCharWidth+6 && h >= 14
+		n.file.LabelX, n.file.LabelY = x+4, y+treemapLabelBaseline
+		t.Files = append(t.Files, *n.file)
+		return
+	}
+
+	if !root {
+		t.Packages = append(t.Packages, TreemapPackage{
+			Path: n.path, Label: n.name, X: x, Y: y, Width: w, Height: h,
+			ShowLabel: w >= float64(len(n.name))*treemapCharWidth+6 && h >= treemapHeader*2,
+			LabelX:    x + 4, LabelY: y + treemapLabelBaseline,
+		})
+		if h >= treemapHeader*2 {
+			y += treemapHeader
+			h -= treemapHeader
+		}
+		x, y, w, h = x+treemapPad, y+treemapPad, w-2*treemapPad, h-2*treemapPad
+		if w <= 0 || h <= 0 {
+			return
+		}
+	}
+
+	sizes := make([]float64, len(n.children))
+	for i, c := range n.children {
+		sizes[i] = c.size
+	}
+	for i, rect := range squarify(sizes, x, y, w, h) {
+		t.layout(n.children[i], rect[0], rect[1], rect[2], rect[3], false)
+	}
+}
+
+// squarify splits the rectangle into one rectangle per size, as x, y,
+// width and height, keeping them close to squares (Bruls, Huizing and van
+// Wijk). Sizes must be sorted largest first.
+func squarify(sizes []float64, x, y, w, h float64) [][4]float64 {
+	rects := make([][4]float64, len(sizes))
+	total := 0.0
+	for _, size := range sizes {
+		total += size
+	}
+	if total <= 0 || w <= 0 || h <= 0 {
+		return rects
+	}
+
+	areas := make([]float64, len(sizes))
+	for i, size := range sizes {
+		areas[i] = size / total * w * h
+	}
+
+	for start := 0; start < len(areas); {
+		side := math.Min(w, h)
+		end := start + 1
+		for end < len(areas) && worstRatio(areas[start:end+1], side) <= worstRatio(areas[start:end], side) {
+			end++
+		}
+
+		rowArea := 0.0
+		for _, area := range areas[start:end] {
+			rowArea += area
+		}
+		if w >= h {
+			// A column along the left edge
+			width := rowArea / h
+			offset := y
+			for i := start; i < end; i++ {
+				height := areas[i] / width
+				rects[i] = [4]float64{x, offset, width, height}
+				offset += height
+			}
+			x += width
+			w -= width
+		} else {
+			// A row along the top edge
+			height := rowArea / w
+			offset := x
+			for i := start; i < end; i++ {
+				width := areas[i] / height
+				rects[i] = [4]float64{offset, y, width, height}
+				offset += width
+			}
+			y += height
+			h -= height
+		}
+		start = end
+	}
+	return rects
+}
+
+// worstRatio returns the highest aspect ratio of a row of areas laid along
+// a side
+func worstRatio(areas []float64, side float64) float64 {
+	sum, lo, hi := 0.0, math.Inf(1), 0.0
+	for _, area := range areas {
+		sum += area
+		lo = math.Min(lo, area)
+		hi = math.Max(hi, area)
+	}
+	if sum == 0 || lo == 0 {
+		return math.Inf(1)
+	}
+	sq := side * side
+	return math.Max(sq*hi/(sum*sum), (sum*sum)/(sq*lo))
+}
+
+// treemapColor maps a ratio from 0 to 1 onto a hue from green to red
+func treemapColor(ratio float64) string {
+	ratio = math.Max(0, math.Min(1, ratio))
+	return fmt.Sprintf("hsl(%d, 65%%, 50%%)", int(math.Round(120*(1-ratio))))
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func treemapFixture() *domain.AnalyzeResponse {
+	return &domain.AnalyzeResponse{
+		Complexity: &domain.ComplexityResponse{
+			Functions: []domain.FunctionComplexity{
+				{Name: "parse", FilePath: "app/core/parser.py", Metrics: domain.ComplexityMetrics{Complexity: 30}},
+				{Name: "tokenize", FilePath: "app/core/parser.py", Metrics: domain.ComplexityMetrics{Complexity: 4}},
+				{Name: "main", FilePath: "app/cli.py", Metrics: domain.ComplexityMetrics{Complexity: 2}},
+			},
+			RawMetrics: []domain.RawMetrics{
+				{FilePath: "app/core/parser.py", SLOC: 300, TotalLines: 400},
+				{FilePath: "app/core/lexer.py", SLOC: 100, TotalLines: 100},
+				{FilePath: "app/cli.py", SLOC: 200, TotalLines: 200},
+				{FilePath: "app/__init__.py", SLOC: 0, TotalLines: 1},
+			},
+		},
+		Clone: &domain.CloneResponse{
+			Clones: []*domain.Clone{
+				{Location: &domain.CloneLocation{FilePath: "app/cli.py", StartLine: 1, EndLine: 50}},
+				{Location: &domain.CloneLocation{FilePath: "app/cli.py", StartLine: 41, EndLine: 60}},
+			},
+		},
+	}
+}
+
+func TestSquarify_FillsRectangleProportionally(t *testing.T) {
+	sizes := []float64{6, 6, 4, 3, 2, 2, 1}
+	rects := squarify(sizes, 0, 0, 600, 400)
+	require.Len(t, rects, len(sizes))
+
+	area := 0.0
+	for i, rect := range rects {
+		assert.InDelta(t, sizes[i]/24*600*400, rect[2]*rect[3], 1e-6)
+		assert.GreaterOrEqual(t, rect[0], -1e-9)
+		assert.GreaterOrEqual(t, rect[1], -1e-9)
+		assert.LessOrEqual(t, rect[0]+rect[2], 600+1e-9)
+		assert.LessOrEqual(t, rect[1]+rect[3], 400+1e-9)
+		area += rect[2] * rect[3]
+	}
+	assert.InDelta(t, 600*400, area, 1e-6)
+}
+
+func TestSquarify_EmptySizes(t *testing.T) {
+	rects := squarify([]float64{0, 0}, 0, 0, 100, 100)
+	assert.Equal(t, [][4]float64{{}, {}}, rects)
+}
+
+func TestBuildTreemap(t *testing.T) {
+	treemap := BuildTreemap(treemapFixture())
+	require.NotNil(t, treemap)
+	assert.True(t, treemap.Duplication)
+	assert.Equal(t, domain.DefaultComplexityMediumThreshold+1, treemap.ComplexityScale)
+
+	// Files without source lines are left out; the common root "app" is
+	// not drawn, its subdirectory is
+	require.Len(t, treemap.Packages, 1)
+	assert.Equal(t, "app/core", treemap.Packages[0].Path)
+	assert.Equal(t, "core", treemap.Packages[0].Label)
+
+	files := make(map[string]TreemapFile)
+	for _, file := range treemap.Files {
+		files[file.Path] = file
+	}
+	require.Len(t, files, 3)
+
+	parser := files["app/core/parser.py"]
+	assert.Equal(t, "parser.py", parser.Label)
+	assert.Equal(t, 2, parser.Functions)
+	assert.Equal(t, 30, parser.MaxComplexity)
+	assert.InDelta(t, 17, parser.AverageComplexity, 1e-9)
+	assert.Equal(t, "hsl(0, 65%, 50%)", parser.ComplexityColor)
+	assert.Equal(t, "hsl(120, 65%, 50%)", parser.DuplicationColor)
+
+	// Overlapping clones count each line once: 60 of 200 lines
+	cli := files["app/cli.py"]
+	assert.InDelta(t, 30, cli.DuplicationPercentage, 1e-9)
+	assert.Equal(t, "hsl(30, 65%, 50%)", cli.DuplicationColor)
+	assert.Equal(t, "hsl(108, 65%, 50%)", cli.ComplexityColor)
+
+	// Files sit inside their package and are sized by source lines
+	pkg := treemap.Packages[0]
+	for _, path := range []string{"app/core/parser.py", "app/core/lexer.py"} {
+		file := files[path]
+		assert.GreaterOrEqual(t, file.X, pkg.X)
+		assert.GreaterOrEqual(t, file.Y, pkg.Y+treemapHeader)
+		assert.LessOrEqual(t, file.X+file.Width, pkg.X+pkg.Width+1e-9)
+		assert.LessOrEqual(t, file.Y+file.Height, pkg.Y+pkg.Height+1e-9)
+	}
+	ratio := (parser.Width * parser.Height) / (files["app/core/lexer.py"].Width * files["app/core/lexer.py"].Height)
+	assert.InDelta(t, 3, ratio, 1e-6)
+	assert.InDelta(t, treemapWidth*treemapHeight/3, cli.Width*cli.Height, 1e-6)
+}
+
+func TestBuildTreemap_UsesManifestThreshold(t *testing.T) {
+	response := treemapFixture()
+	response.Manifest = &domain.AnalysisManifest{Thresholds: &domain.RiskThresholds{
+		Complexity: &domain.ComplexityThresholds{Low: 5, Medium: 59},
+	}}
+	treemap := BuildTreemap(response)
+	require.NotNil(t, treemap)
+	assert.Equal(t, 60, treemap.ComplexityScale)
+	for _, file := range treemap.Files {
+		if file.Path == "app/core/parser.py" {
+			assert.Equal(t, "hsl(60, 65%, 50%)", file.ComplexityColor)
+		}
+	}
+}
+
+func TestBuildTreemap_WithoutRawMetrics(t *testing.T) {
+	assert.Nil(t, BuildTreemap(nil))
+	assert.Nil(t, BuildTreemap(&domain.AnalyzeResponse{}))
+
+	response := treemapFixture()
+	response.Complexity.RawMetrics = []domain.RawMetrics{{FilePath: "empty.py"}}
+	assert.Nil(t, BuildTreemap(response))
+}
+
+func TestTreemapColor(t *testing.T) {
+	assert.Equal(t, "hsl(120, 65%, 50%)", treemapColor(-1))
+	assert.Equal(t, "hsl(60, 65%, 50%)", treemapColor(0.5))
+	assert.Equal(t, "hsl(0, 65%, 50%)", treemapColor(math.Inf(1)))
+}
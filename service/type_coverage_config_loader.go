@@ -0,0 +1,75 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/config"
+)
+
+// TypeCoverageConfigurationLoaderImpl implements the TypeCoverageConfigurationLoader interface
+type TypeCoverageConfigurationLoaderImpl struct{}
+
+// NewTypeCoverageConfigurationLoader creates a new type coverage configuration loader service
+func NewTypeCoverageConfigurationLoader() *TypeCoverageConfigurationLoaderImpl {
+	return &TypeCoverageConfigurationLoaderImpl{}
+}
+
+// LoadConfig loads type coverage configuration from the specified path using TOML-only strategy
+func (cl *TypeCoverageConfigurationLoaderImpl) LoadConfig(path string) (*domain.TypeCoverageRequest, error) {
+	tomlLoader := config.NewTomlConfigLoader()
+	pyscnCfg, err := tomlLoader.LoadConfig(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config from %s: %w", path, err)
+	}
+
+	return cl.configToRequest(pyscnCfg), nil
+}
+
+// LoadDefaultConfig loads the default type coverage configuration, first checking for .pyscn.toml
+func (cl *TypeCoverageConfigurationLoaderImpl) LoadDefaultConfig() *domain.TypeCoverageRequest {
+	tomlLoader := config.NewTomlConfigLoader()
+	if configFile := tomlLoader.FindConfigFileFromPath(""); configFile != "" {
+		if configReq, err := cl.LoadConfig(configFile); err == nil {
+			return configReq
+		}
+		// If loading failed, fall back to hardcoded defaults
+	}
+
+	return domain.DefaultTypeCoverageRequest()
+}
+
+// MergeConfig merges request values with configuration file values
+func (cl *TypeCoverageConfigurationLoaderImpl) MergeConfig(base *domain.TypeCoverageRequest, override *domain.TypeCoverageRequest) *domain.TypeCoverageRequest {
+	if base == nil {
+		return override
+	}
+	if override == nil {
+		return base
+	}
+
+	merged := *base
+
+	// Always override paths as they come from command arguments
+	merged.Paths = config.MergeSlice(merged.Paths, override.Paths)
+	merged.ConfigPath = config.Merge(merged.ConfigPath, override.ConfigPath)
+
+	merged.Recursive = config.MergePtr(merged.Recursive, override.Recursive)
+	merged.IncludePatterns = config.MergeSlice(merged.IncludePatterns, override.IncludePatterns)
+	merged.ExcludePatterns = config.MergeSlice(merged.ExcludePatterns, override.ExcludePatterns)
+
+	return &merged
+}
+
+// configToRequest converts a PyscnConfig to domain.TypeCoverageRequest
+func (cl *TypeCoverageConfigurationLoaderImpl) configToRequest(pyscnCfg *config.PyscnConfig) *domain.TypeCoverageRequest {
+	if pyscnCfg == nil {
+		return domain.DefaultTypeCoverageRequest()
+	}
+
+	return &domain.TypeCoverageRequest{
+		Recursive:       domain.BoolPtr(domain.BoolValue(pyscnCfg.AnalysisRecursive, true)),
+		IncludePatterns: pyscnCfg.AnalyzerIncludePatterns(domain.AnalysisScopeTyping),
+		ExcludePatterns: pyscnCfg.AnalyzerExcludePatterns(domain.AnalysisScopeTyping),
+	}
+}
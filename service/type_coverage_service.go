@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/ludo-technologies/pyscn/internal/analyzer"
+	"github.com/ludo-technologies/pyscn/internal/parser"
+)
+
+// TypeCoverageServiceImpl implements the TypeCoverageService interface
+type TypeCoverageServiceImpl struct {
+	parser *parser.Parser
+}
+
+// NewTypeCoverageService creates a new type annotation coverage service
+func NewTypeCoverageService() *TypeCoverageServiceImpl {
+	return &TypeCoverageServiceImpl{
+		parser: parser.New(),
+	}
+}
+
+// Analyze measures type annotation coverage across the requested files
+func (s *TypeCoverageServiceImpl) Analyze(ctx context.Context, req domain.TypeCoverageRequest) (*domain.TypeCoverageResponse, error) {
+	typeAnalyzer := analyzer.NewTypeCoverageAnalyzer()
+
+	var files []domain.TypeCoverageFile
+	var allFindings []domain.TypeCoverageFinding
+	var errors []string
+
+	for _, filePath := range req.Paths {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("type coverage analysis cancelled: %w", ctx.Err())
+		default:
+		}
+
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("[%s] Failed to read file: %v", filePath, err))
+			reportSkippedFile(ctx, filePath, err)
+			continue
+		}
+		result, err := s.parser.ParseFor(ctx, filePath, content)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("[%s] Parse error: %v", filePath, err))
+			reportSkippedFile(ctx, filePath, err)
+			continue
+		}
+
+		file, findings := typeAnalyzer.Analyze(result.AST, filePath)
+		files = append(files, file)
+		allFindings = append(allFindings, findings...)
+	}
+
+	return &domain.TypeCoverageResponse{
+		Files:    analyzer.SortTypeCoverageFiles(files),
+		Findings: analyzer.SortTypeCoverageFindings(allFindings),
+		Summary:  analyzer.GenerateTypeCoverageSummary(files, len(allFindings)),
+		Errors:   errors,
+	}, nil
+}
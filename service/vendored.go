@@ -0,0 +1,169 @@
+package service
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ludo-technologies/pyscn/domain"
+)
+
+// vendoredMarkerScanLines bounds how far into a file the leading comments
+// are searched for a "# vendored" marker
+const vendoredMarkerScanLines = 50
+
+// VendoredDetector finds vendored code below the analyzed paths. Only the part
+// of a file's path below the analyzed directory is checked, so analyzing a
+// vendor directory itself still analyzes its code.
+type VendoredDetector struct {
+	roots   []string        // analyzed directories, absolute, longest first
+	markers map[string]bool // absolute file path -> carries a "# vendored" marker
+}
+
+// NewVendoredDetector creates a detector for the analyzed paths
+func NewVendoredDetector(paths []string) *VendoredDetector {
+	d := &VendoredDetector{markers: make(map[string]bool)}
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			continue
+		}
+		if info, err := os.Stat(abs); err == nil && !info.IsDir() {
+			abs = filepath.Dir(abs)
+		}
+		d.roots = append(d.roots, abs)
+	}
+	sort.Slice(d.roots, func(i, j int) bool { return len(d.roots[i]) > len(d.roots[j]) })
+	return d
+}
+
+// Vendored returns the vendored directory or module containing file, in the
+// form file was given in, and why it is vendored. The outermost vendored
+// directory wins. ok is false for project code.
+func (d *VendoredDetector) Vendored(file string) (path, reason string, ok bool) {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return "", "", false
+	}
+	root := filepath.Dir(abs)
+	for _, candidate := range d.roots {
+		if abs == candidate || strings.HasPrefix(abs, candidate+string(filepath.Separator)) {
+			root = candidate
+			break
+		}
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return "", "", false
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+
+	dir := root
+	for i, part := range parts[:len(parts)-1] {
+		dir = filepath.Join(dir, part)
+		reason := ""
+		switch {
+		case slices.Contains(domain.VendoredDirectoryNames, part):
+			reason = domain.VendoredReasonLayout
+		case d.hasMarker(filepath.Join(dir, "__init__.py")):
+			reason = domain.VendoredReasonMarker
+		default:
+			continue
+		}
+		path := file
+		for range len(parts) - 1 - i {
+			path = filepath.Dir(path)
+		}
+		return path, reason, true
+	}
+
+	if d.hasMarker(abs) {
+		if filepath.Base(abs) == "__init__.py" && len(parts) > 1 {
+			return filepath.Dir(file), domain.VendoredReasonMarker, true
+		}
+		return file, domain.VendoredReasonMarker, true
+	}
+	return "", "", false
+}
+
+// Split separates the vendored files from the others. Vendored files are
+// grouped by vendored directory or module, sorted by path.
+func (d *VendoredDetector) Split(files []string) (kept []string, vendored []domain.VendoredCode) {
+	groups := make(map[string]*domain.VendoredCode)
+	kept = make([]string, 0, len(files))
+	for _, file := range files {
+		path, reason, ok := d.Vendored(file)
+		if !ok {
+			kept = append(kept, file)
+			continue
+		}
+		group, exists := groups[path]
+		if !exists {
+			group = &domain.VendoredCode{Path: path, Reason: reason}
+			groups[path] = group
+		}
+		group.Files++
+	}
+
+	for _, group := range groups {
+		vendored = append(vendored, *group)
+	}
+	sort.Slice(vendored, func(i, j int) bool { return vendored[i].Path < vendored[j].Path })
+	return kept, vendored
+}
+
+// hasMarker reports whether the leading comments of the file include a
+// "# vendored" line. Missing and unreadable files have none.
+func (d *VendoredDetector) hasMarker(path string) bool {
+	if marked, ok := d.markers[path]; ok {
+		return marked
+	}
+	marked := readVendoredMarker(path)
+	d.markers[path] = marked
+	return marked
+}
+
+func readVendoredMarker(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for range vendoredMarkerScanLines {
+		if !scanner.Scan() {
+			return false
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		comment, isComment := strings.CutPrefix(line, "#")
+		if !isComment {
+			return false
+		}
+		if isVendoredMarker(comment) {
+			return true
+		}
+	}
+	return false
+}
+
+// isVendoredMarker matches "vendored", alone or followed by a note such as
+// "vendored from six 1.16.0"
+func isVendoredMarker(comment string) bool {
+	rest, found := strings.CutPrefix(strings.ToLower(strings.TrimSpace(comment)), "vendored")
+	if !found {
+		return false
+	}
+	if rest == "" {
+		return true
+	}
+	next := rune(rest[0])
+	return !unicode.IsLetter(next) && !unicode.IsDigit(next) && next != '_'
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ludo-technologies/pyscn/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeVendoredTestFiles(t *testing.T, root string, files map[string]string) []string {
+	t.Helper()
+	var paths []string
+	for rel, content := range files {
+		path := filepath.Join(root, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+func TestVendoredDetector_Split(t *testing.T) {
+	root := t.TempDir()
+	files := writeVendoredTestFiles(t, root, map[string]string{
+		"app/main.py":                  "import six\n",
+		"app/vendor/six.py":            "",
+		"app/vendor/urllib3/util.py":   "",
+		"_vendor/attr.py":              "",
+		"third_party/proto/gen_pb2.py": "",
+		"app/compat.py":                "#!/usr/bin/env python\n# Vendored: copied from backports\nx = 1\n",
+		"app/_shims/__init__.py":       "# -*- coding: utf-8 -*-\n\n# vendored\n",
+		"app/_shims/nested/mod.py":     "",
+		"app/late_marker.py":           "x = 1\n# vendored\n",
+		"app/vendoring.py":             "# vendoring helpers\n",
+		"app/vendor_tools.py":          "",
+	})
+
+	kept, vendored := NewVendoredDetector([]string{root}).Split(files)
+
+	assert.ElementsMatch(t, []string{
+		filepath.Join(root, "app/main.py"),
+		filepath.Join(root, "app/late_marker.py"),
+		filepath.Join(root, "app/vendoring.py"),
+		filepath.Join(root, "app/vendor_tools.py"),
+	}, kept)
+	assert.Equal(t, []domain.VendoredCode{
+		{Path: filepath.Join(root, "_vendor"), Reason: domain.VendoredReasonLayout, Files: 1},
+		{Path: filepath.Join(root, "app/_shims"), Reason: domain.VendoredReasonMarker, Files: 2},
+		{Path: filepath.Join(root, "app/compat.py"), Reason: domain.VendoredReasonMarker, Files: 1},
+		{Path: filepath.Join(root, "app/vendor"), Reason: domain.VendoredReasonLayout, Files: 2},
+		{Path: filepath.Join(root, "third_party"), Reason: domain.VendoredReasonLayout, Files: 1},
+	}, vendored)
+}
+
+func TestVendoredDetector_OnlyChecksBelowAnalyzedPath(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "vendor", "project")
+	files := writeVendoredTestFiles(t, root, map[string]string{
+		"main.py":         "",
+		"lib/vendor/x.py": "",
+	})
+
+	kept, vendored := NewVendoredDetector([]string{root}).Split(files)
+	assert.Equal(t, []string{filepath.Join(root, "main.py")}, kept)
+	require.Len(t, vendored, 1)
+	assert.Equal(t, filepath.Join(root, "lib/vendor"), vendored[0].Path)
+
+	// Analyzing a vendor directory itself analyzes its code
+	vendorDir := filepath.Join(root, "lib", "vendor")
+	kept, vendored = NewVendoredDetector([]string{vendorDir}).Split([]string{filepath.Join(vendorDir, "x.py")})
+	assert.Len(t, kept, 1)
+	assert.Empty(t, vendored)
+}
+
+func TestVendoredDetector_KeepsPathForm(t *testing.T) {
+	root := t.TempDir()
+	writeVendoredTestFiles(t, root, map[string]string{"pkg/third_party/lib.py": ""})
+	t.Chdir(root)
+
+	path, reason, ok := NewVendoredDetector([]string{"pkg"}).Vendored(filepath.Join("pkg", "third_party", "lib.py"))
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join("pkg", "third_party"), path)
+	assert.Equal(t, domain.VendoredReasonLayout, reason)
+}
+
+func TestIsVendoredMarker(t *testing.T) {
+	for comment, want := range map[string]bool{
+		" vendored":                 true,
+		"vendored":                  true,
+		" VENDORED from six 1.16.0": true,
+		" vendored: attrs 23.1":     true,
+		" vendored-in":              true,
+		" vendoring helpers":        false,
+		" vendored_deps":            false,
+		" not vendored":             false,
+		"":                          false,
+	} {
+		assert.Equal(t, want, isVendoredMarker(comment), comment)
+	}
+}